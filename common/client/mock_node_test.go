@@ -292,6 +292,51 @@ func (_c *mockNode_RPC_Call[CHAIN_ID, RPC]) RunAndReturn(run func() RPC) *mockNo
 	return _c
 }
 
+// Score provides a mock function with given fields:
+func (_m *mockNode[CHAIN_ID, RPC]) Score() NodeScore {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Score")
+	}
+
+	var r0 NodeScore
+	if rf, ok := ret.Get(0).(func() NodeScore); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(NodeScore)
+	}
+
+	return r0
+}
+
+// mockNode_Score_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Score'
+type mockNode_Score_Call[CHAIN_ID types.ID, RPC any] struct {
+	*mock.Call
+}
+
+// Score is a helper method to define mock.On call
+func (_e *mockNode_Expecter[CHAIN_ID, RPC]) Score() *mockNode_Score_Call[CHAIN_ID, RPC] {
+	return &mockNode_Score_Call[CHAIN_ID, RPC]{Call: _e.mock.On("Score")}
+}
+
+func (_c *mockNode_Score_Call[CHAIN_ID, RPC]) Run(run func()) *mockNode_Score_Call[CHAIN_ID, RPC] {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *mockNode_Score_Call[CHAIN_ID, RPC]) Return(_a0 NodeScore) *mockNode_Score_Call[CHAIN_ID, RPC] {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *mockNode_Score_Call[CHAIN_ID, RPC]) RunAndReturn(run func() NodeScore) *mockNode_Score_Call[CHAIN_ID, RPC] {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SetPoolChainInfoProvider provides a mock function with given fields: _a0
 func (_m *mockNode[CHAIN_ID, RPC]) SetPoolChainInfoProvider(_a0 PoolChainInfoProvider) {
 	_m.Called(_a0)
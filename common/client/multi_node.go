@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"math/big"
+	"slices"
 	"sync"
 	"time"
 
@@ -34,12 +35,15 @@ type MultiNode[
 	services.Service
 	eng *services.Engine
 
+	// nodesMu guards the nodes and their selector, which are replaced by ReplaceNodes
+	nodesMu               sync.RWMutex
 	primaryNodes          []Node[CHAIN_ID, RPC]
 	sendOnlyNodes         []SendOnlyNode[CHAIN_ID, RPC]
+	nodeSelector          NodeSelector[CHAIN_ID, RPC]
+	newNodeSelector       NodeSelectorFactory[CHAIN_ID, RPC]
 	chainID               CHAIN_ID
 	lggr                  logger.SugaredLogger
 	selectionMode         string
-	nodeSelector          NodeSelector[CHAIN_ID, RPC]
 	leaseDuration         time.Duration
 	leaseTicker           *time.Ticker
 	chainFamily           string
@@ -63,7 +67,29 @@ func NewMultiNode[
 	chainFamily string, // name of the chain family - used in the metrics
 	deathDeclarationDelay time.Duration,
 ) *MultiNode[CHAIN_ID, RPC] {
-	nodeSelector := newNodeSelector(selectionMode, primaryNodes)
+	newSelector := func(nodes []Node[CHAIN_ID, RPC]) NodeSelector[CHAIN_ID, RPC] {
+		return newNodeSelector(selectionMode, nodes)
+	}
+	return NewMultiNodeWithSelector(lggr, newSelector, leaseDuration, primaryNodes, sendOnlyNodes, chainID, chainFamily, deathDeclarationDelay)
+}
+
+// NewMultiNodeWithSelector returns a MultiNode which selects its active node with the selectors returned by
+// newSelector instead of one of the NodeSelectionModes, the name of the selector is used as the selection mode.
+func NewMultiNodeWithSelector[
+	CHAIN_ID types.ID,
+	RPC any,
+](
+	lggr logger.Logger,
+	newSelector NodeSelectorFactory[CHAIN_ID, RPC],
+	leaseDuration time.Duration, // defines interval on which new "best" RPC should be selected
+	primaryNodes []Node[CHAIN_ID, RPC],
+	sendOnlyNodes []SendOnlyNode[CHAIN_ID, RPC],
+	chainID CHAIN_ID, // configured chain ID (used to verify that passed primaryNodes belong to the same chain)
+	chainFamily string, // name of the chain family - used in the metrics
+	deathDeclarationDelay time.Duration,
+) *MultiNode[CHAIN_ID, RPC] {
+	nodeSelector := newSelector(primaryNodes)
+	selectionMode := nodeSelector.Name()
 	// Prometheus' default interval is 15s, set this to under 7.5s to avoid
 	// aliasing (see: https://en.wikipedia.org/wiki/Nyquist_frequency)
 	const reportInterval = 6500 * time.Millisecond
@@ -73,6 +99,7 @@ func NewMultiNode[
 		chainID:               chainID,
 		selectionMode:         selectionMode,
 		nodeSelector:          nodeSelector,
+		newNodeSelector:       newSelector,
 		leaseDuration:         leaseDuration,
 		chainFamily:           chainFamily,
 		reportInterval:        reportInterval,
@@ -94,10 +121,24 @@ func (c *MultiNode[CHAIN_ID, RPC]) ChainID() CHAIN_ID {
 	return c.chainID
 }
 
+// nodes returns the current primary and send-only nodes of the pool
+func (c *MultiNode[CHAIN_ID, RPC]) nodes() ([]Node[CHAIN_ID, RPC], []SendOnlyNode[CHAIN_ID, RPC]) {
+	c.nodesMu.RLock()
+	defer c.nodesMu.RUnlock()
+	return c.primaryNodes, c.sendOnlyNodes
+}
+
+func (c *MultiNode[CHAIN_ID, RPC]) selector() NodeSelector[CHAIN_ID, RPC] {
+	c.nodesMu.RLock()
+	defer c.nodesMu.RUnlock()
+	return c.nodeSelector
+}
+
 func (c *MultiNode[CHAIN_ID, RPC]) DoAll(ctx context.Context, do func(ctx context.Context, rpc RPC, isSendOnly bool)) error {
 	return c.eng.IfNotStopped(func() error {
+		primaryNodes, sendOnlyNodes := c.nodes()
 		callsCompleted := 0
-		for _, n := range c.primaryNodes {
+		for _, n := range primaryNodes {
 			select {
 			case <-ctx.Done():
 				return ctx.Err()
@@ -110,7 +151,7 @@ func (c *MultiNode[CHAIN_ID, RPC]) DoAll(ctx context.Context, do func(ctx contex
 			}
 		}
 
-		for _, n := range c.sendOnlyNodes {
+		for _, n := range sendOnlyNodes {
 			select {
 			case <-ctx.Done():
 				return ctx.Err()
@@ -129,26 +170,56 @@ func (c *MultiNode[CHAIN_ID, RPC]) DoAll(ctx context.Context, do func(ctx contex
 }
 
 func (c *MultiNode[CHAIN_ID, RPC]) NodeStates() map[string]string {
+	primaryNodes, sendOnlyNodes := c.nodes()
 	states := map[string]string{}
-	for _, n := range c.primaryNodes {
+	for _, n := range primaryNodes {
 		states[n.Name()] = n.State().String()
 	}
-	for _, n := range c.sendOnlyNodes {
+	for _, n := range sendOnlyNodes {
 		states[n.Name()] = n.State().String()
 	}
 	return states
 }
 
+// NodeScores returns the score of every primary node in the pool, by node name
+func (c *MultiNode[CHAIN_ID, RPC]) NodeScores() map[string]NodeScore {
+	primaryNodes, _ := c.nodes()
+	scores := map[string]NodeScore{}
+	for _, n := range primaryNodes {
+		scores[n.Name()] = n.Score()
+	}
+	return scores
+}
+
 // Start starts every node in the pool
 //
 // Nodes handle their own redialing and runloops, so this function does not
 // return any error if the nodes aren't available
 func (c *MultiNode[CHAIN_ID, RPC]) start(ctx context.Context) error {
-	if len(c.primaryNodes) == 0 {
+	primaryNodes, sendOnlyNodes := c.nodes()
+	if len(primaryNodes) == 0 {
 		return fmt.Errorf("no available nodes for chain %s", c.chainID.String())
 	}
+	if err := c.startNodes(ctx, primaryNodes, sendOnlyNodes); err != nil {
+		return err
+	}
+	c.eng.Go(c.runLoop)
+
+	if c.leaseDuration.Seconds() > 0 && c.selectionMode != NodeSelectionModeRoundRobin {
+		c.lggr.Infof("The MultiNode will switch to best node every %s", c.leaseDuration.String())
+		c.eng.Go(c.checkLeaseLoop)
+	} else {
+		c.lggr.Info("Best node switching is disabled")
+	}
+
+	return nil
+}
+
+// startNodes starts the nodes, after checking they are configured with the chain ID of the pool. If any node fails
+// to start, the nodes started before it are closed.
+func (c *MultiNode[CHAIN_ID, RPC]) startNodes(ctx context.Context, primaryNodes []Node[CHAIN_ID, RPC], sendOnlyNodes []SendOnlyNode[CHAIN_ID, RPC]) error {
 	var ms services.MultiStart
-	for _, n := range c.primaryNodes {
+	for _, n := range primaryNodes {
 		if n.ConfiguredChainID().String() != c.chainID.String() {
 			return ms.CloseBecause(fmt.Errorf("node %s has configured chain ID %s which does not match multinode configured chain ID of %s", n.String(), n.ConfiguredChainID().String(), c.chainID.String()))
 		}
@@ -158,7 +229,7 @@ func (c *MultiNode[CHAIN_ID, RPC]) start(ctx context.Context) error {
 			return err
 		}
 	}
-	for _, s := range c.sendOnlyNodes {
+	for _, s := range sendOnlyNodes {
 		if s.ConfiguredChainID().String() != c.chainID.String() {
 			return ms.CloseBecause(fmt.Errorf("sendonly node %s has configured chain ID %s which does not match multinode configured chain ID of %s", s.String(), s.ConfiguredChainID().String(), c.chainID.String()))
 		}
@@ -166,21 +237,61 @@ func (c *MultiNode[CHAIN_ID, RPC]) start(ctx context.Context) error {
 			return err
 		}
 	}
-	c.eng.Go(c.runLoop)
-
-	if c.leaseDuration.Seconds() > 0 && c.selectionMode != NodeSelectionModeRoundRobin {
-		c.lggr.Infof("The MultiNode will switch to best node every %s", c.leaseDuration.String())
-		c.eng.Go(c.checkLeaseLoop)
-	} else {
-		c.lggr.Info("Best node switching is disabled")
-	}
-
 	return nil
 }
 
 // Close tears down the MultiNode and closes all nodes
 func (c *MultiNode[CHAIN_ID, RPC]) close() error {
-	return services.CloseAll(services.MultiCloser(c.primaryNodes), services.MultiCloser(c.sendOnlyNodes))
+	primaryNodes, sendOnlyNodes := c.nodes()
+	return services.CloseAll(services.MultiCloser(primaryNodes), services.MultiCloser(sendOnlyNodes))
+}
+
+// ReplaceNodes replaces the nodes of a running pool, e.g. when its RPC endpoints are reloaded. Nodes of the pool
+// which are passed again keep running, the new nodes are started and the nodes which are no longer passed are
+// closed once they have been replaced. The nodes are only replaced if all the new nodes start.
+func (c *MultiNode[CHAIN_ID, RPC]) ReplaceNodes(ctx context.Context, primaryNodes []Node[CHAIN_ID, RPC], sendOnlyNodes []SendOnlyNode[CHAIN_ID, RPC]) error {
+	if len(primaryNodes) == 0 {
+		return fmt.Errorf("no available nodes for chain %s", c.chainID.String())
+	}
+	return c.eng.IfStarted(func() error {
+		oldPrimaryNodes, oldSendOnlyNodes := c.nodes()
+		addedPrimaries, removedPrimaries := diffNodes(oldPrimaryNodes, primaryNodes)
+		addedSendOnlys, removedSendOnlys := diffNodes(oldSendOnlyNodes, sendOnlyNodes)
+		if err := c.startNodes(ctx, addedPrimaries, addedSendOnlys); err != nil {
+			return err
+		}
+
+		c.nodesMu.Lock()
+		c.primaryNodes = primaryNodes
+		c.sendOnlyNodes = sendOnlyNodes
+		c.nodeSelector = c.newNodeSelector(primaryNodes)
+		c.nodesMu.Unlock()
+
+		c.activeMu.Lock()
+		if c.activeNode != nil && slices.Contains(removedPrimaries, c.activeNode) {
+			// the next call selects a new active node
+			c.activeNode = nil
+		}
+		c.activeMu.Unlock()
+
+		c.lggr.Infow("Replaced nodes", "added", len(addedPrimaries)+len(addedSendOnlys), "removed", len(removedPrimaries)+len(removedSendOnlys))
+		return services.CloseAll(services.MultiCloser(removedPrimaries), services.MultiCloser(removedSendOnlys))
+	})
+}
+
+// diffNodes returns the nodes of next which are not in prev, and the nodes of prev which are not in next.
+func diffNodes[N comparable](prev, next []N) (added, removed []N) {
+	for _, n := range next {
+		if !slices.Contains(prev, n) {
+			added = append(added, n)
+		}
+	}
+	for _, n := range prev {
+		if !slices.Contains(next, n) {
+			removed = append(removed, n)
+		}
+	}
+	return
 }
 
 // SelectRPC returns an RPC of an active node. If there are no active nodes it returns an error.
@@ -215,9 +326,10 @@ func (c *MultiNode[CHAIN_ID, RPC]) selectNode() (node Node[CHAIN_ID, RPC], err e
 		prevNodeName = c.activeNode.String()
 		c.activeNode.UnsubscribeAllExceptAliveLoop()
 	}
-	c.activeNode = c.nodeSelector.Select()
+	nodeSelector := c.selector()
+	c.activeNode = nodeSelector.Select()
 	if c.activeNode == nil {
-		c.lggr.Criticalw("No live RPC nodes available", "NodeSelectionMode", c.nodeSelector.Name())
+		c.lggr.Criticalw("No live RPC nodes available", "NodeSelectionMode", nodeSelector.Name())
 		c.eng.EmitHealthErr(fmt.Errorf("no live nodes available for chain %s", c.chainID.String()))
 		return nil, ErroringNodeError
 	}
@@ -234,7 +346,8 @@ func (c *MultiNode[CHAIN_ID, RPC]) LatestChainInfo() (int, ChainInfo) {
 	ch := ChainInfo{
 		TotalDifficulty: big.NewInt(0),
 	}
-	for _, n := range c.primaryNodes {
+	primaryNodes, _ := c.nodes()
+	for _, n := range primaryNodes {
 		if s, nodeChainInfo := n.StateAndLatest(); s == nodeStateAlive {
 			nLiveNodes++
 			ch.BlockNumber = max(ch.BlockNumber, nodeChainInfo.BlockNumber)
@@ -250,7 +363,8 @@ func (c *MultiNode[CHAIN_ID, RPC]) HighestUserObservations() ChainInfo {
 	ch := ChainInfo{
 		TotalDifficulty: big.NewInt(0),
 	}
-	for _, n := range c.primaryNodes {
+	primaryNodes, _ := c.nodes()
+	for _, n := range primaryNodes {
 		nodeChainInfo := n.HighestUserObservations()
 		ch.BlockNumber = max(ch.BlockNumber, nodeChainInfo.BlockNumber)
 		ch.FinalizedBlockNumber = max(ch.FinalizedBlockNumber, nodeChainInfo.FinalizedBlockNumber)
@@ -260,8 +374,9 @@ func (c *MultiNode[CHAIN_ID, RPC]) HighestUserObservations() ChainInfo {
 }
 
 func (c *MultiNode[CHAIN_ID, RPC]) checkLease() {
-	bestNode := c.nodeSelector.Select()
-	for _, n := range c.primaryNodes {
+	bestNode := c.selector().Select()
+	primaryNodes, _ := c.nodes()
+	for _, n := range primaryNodes {
 		// Terminate client subscriptions. Services are responsible for reconnecting, which will be routed to the new
 		// best node. Only terminate connections with more than 1 subscription to account for the aliveLoop subscription
 		if n.State() == nodeStateAlive && n != bestNode {
@@ -295,16 +410,7 @@ func (c *MultiNode[CHAIN_ID, RPC]) checkLeaseLoop(ctx context.Context) {
 }
 
 func (c *MultiNode[CHAIN_ID, RPC]) runLoop(ctx context.Context) {
-	nodeStates := make([]nodeWithState, len(c.primaryNodes))
-	for i, n := range c.primaryNodes {
-		nodeStates[i] = nodeWithState{
-			Node:      n.String(),
-			State:     n.State().String(),
-			DeadSince: nil,
-		}
-	}
-
-	c.report(nodeStates)
+	nodeStates := c.report(nil)
 
 	monitor := services.NewTicker(c.reportInterval)
 	defer monitor.Stop()
@@ -312,7 +418,7 @@ func (c *MultiNode[CHAIN_ID, RPC]) runLoop(ctx context.Context) {
 	for {
 		select {
 		case <-monitor.C:
-			c.report(nodeStates)
+			nodeStates = c.report(nodeStates)
 		case <-ctx.Done():
 			return
 		}
@@ -325,11 +431,20 @@ type nodeWithState struct {
 	DeadSince *time.Time
 }
 
-func (c *MultiNode[CHAIN_ID, RPC]) report(nodesStateInfo []nodeWithState) {
+// report reports the states of the primary nodes, given their states of the previous report, and returns
+// their current states.
+func (c *MultiNode[CHAIN_ID, RPC]) report(prevNodesStateInfo []nodeWithState) []nodeWithState {
 	start := time.Now()
 	var dead int
 	counts := make(map[nodeState]int)
-	for i, n := range c.primaryNodes {
+	primaryNodes, _ := c.nodes()
+	nodesStateInfo := make([]nodeWithState, len(primaryNodes))
+	for i, n := range primaryNodes {
+		nodesStateInfo[i].Node = n.String()
+		// nodes may have been replaced since the previous report
+		if j := slices.IndexFunc(prevNodesStateInfo, func(s nodeWithState) bool { return s.Node == nodesStateInfo[i].Node }); j >= 0 {
+			nodesStateInfo[i].DeadSince = prevNodesStateInfo[j].DeadSince
+		}
 		state := n.State()
 		counts[state]++
 		nodesStateInfo[i].State = state.String()
@@ -351,7 +466,7 @@ func (c *MultiNode[CHAIN_ID, RPC]) report(nodesStateInfo []nodeWithState) {
 		PromMultiNodeRPCNodeStates.WithLabelValues(c.chainFamily, c.chainID.String(), state.String()).Set(float64(count))
 	}
 
-	total := len(c.primaryNodes)
+	total := len(primaryNodes)
 	live := total - dead
 	c.lggr.Tracew(fmt.Sprintf("MultiNode state: %d/%d nodes are alive", live, total), "nodeStates", nodesStateInfo)
 	if total == dead {
@@ -361,4 +476,5 @@ func (c *MultiNode[CHAIN_ID, RPC]) report(nodesStateInfo []nodeWithState) {
 	} else if dead > 0 {
 		c.lggr.Errorw(fmt.Sprintf("At least one primary node is dead: %d/%d nodes are alive", live, total), "nodeStates", nodesStateInfo)
 	}
+	return nodesStateInfo
 }
@@ -193,6 +193,85 @@ func TestMultiNode_Dial(t *testing.T) {
 	})
 }
 
+func TestMultiNode_ReplaceNodes(t *testing.T) {
+	t.Parallel()
+	t.Run("Fails if not started", func(t *testing.T) {
+		t.Parallel()
+		chainID := types.RandomID()
+		mn := newTestMultiNode(t, multiNodeOpts{
+			selectionMode: NodeSelectionModeRoundRobin,
+			chainID:       chainID,
+		})
+		err := mn.ReplaceNodes(tests.Context(t), []Node[types.ID, multiNodeRPCClient]{newMockNode[types.ID, multiNodeRPCClient](t)}, nil)
+		require.Error(t, err)
+	})
+	t.Run("Fails without nodes", func(t *testing.T) {
+		t.Parallel()
+		chainID := types.RandomID()
+		node := newHealthyNode(t, chainID)
+		mn := newTestMultiNode(t, multiNodeOpts{
+			selectionMode: NodeSelectionModeRoundRobin,
+			chainID:       chainID,
+			nodes:         []Node[types.ID, multiNodeRPCClient]{node},
+		})
+		servicetest.Run(t, mn)
+		err := mn.ReplaceNodes(tests.Context(t), nil, nil)
+		assert.ErrorContains(t, err, fmt.Sprintf("no available nodes for chain %s", chainID))
+	})
+	t.Run("Fails if a new node fails to start", func(t *testing.T) {
+		t.Parallel()
+		chainID := types.RandomID()
+		node := newHealthyNode(t, chainID)
+		mn := newTestMultiNode(t, multiNodeOpts{
+			selectionMode: NodeSelectionModeRoundRobin,
+			chainID:       chainID,
+			nodes:         []Node[types.ID, multiNodeRPCClient]{node},
+		})
+		servicetest.Run(t, mn)
+		failing := newMockNode[types.ID, multiNodeRPCClient](t)
+		failing.On("ConfiguredChainID").Return(chainID).Once()
+		failing.On("SetPoolChainInfoProvider", mock.Anything).Once()
+		expectedError := errors.New("failed to start node")
+		failing.On("Start", mock.Anything).Return(expectedError).Once()
+		err := mn.ReplaceNodes(tests.Context(t), []Node[types.ID, multiNodeRPCClient]{failing}, nil)
+		require.ErrorIs(t, err, expectedError)
+		primaryNodes, _ := mn.nodes()
+		assert.Equal(t, []Node[types.ID, multiNodeRPCClient]{node}, primaryNodes)
+	})
+	t.Run("Starts new nodes and closes removed ones", func(t *testing.T) {
+		t.Parallel()
+		chainID := types.RandomID()
+		node1 := newHealthyNode(t, chainID)
+		mn := newTestMultiNode(t, multiNodeOpts{
+			selectionMode: NodeSelectionModeRoundRobin,
+			chainID:       chainID,
+			nodes:         []Node[types.ID, multiNodeRPCClient]{node1},
+		})
+		servicetest.Run(t, mn)
+		selected, err := mn.selectNode()
+		require.NoError(t, err)
+		require.Equal(t, node1, selected)
+
+		// node1 is kept running, node2 is started
+		node2 := newHealthyNode(t, chainID)
+		sendOnly := newMockSendOnlyNode[types.ID, multiNodeRPCClient](t)
+		sendOnly.On("ConfiguredChainID").Return(chainID).Once()
+		sendOnly.On("Start", mock.Anything).Return(nil).Once()
+		sendOnly.On("Close").Return(nil).Once()
+		err = mn.ReplaceNodes(tests.Context(t), []Node[types.ID, multiNodeRPCClient]{node1, node2}, []SendOnlyNode[types.ID, multiNodeRPCClient]{sendOnly})
+		require.NoError(t, err)
+
+		// node1 is closed and no longer selected
+		err = mn.ReplaceNodes(tests.Context(t), []Node[types.ID, multiNodeRPCClient]{node2}, nil)
+		require.NoError(t, err)
+		node1.AssertCalled(t, "Close")
+		sendOnly.AssertCalled(t, "Close")
+		selected, err = mn.selectNode()
+		require.NoError(t, err)
+		assert.Equal(t, node2, selected)
+	})
+}
+
 func TestMultiNode_Report(t *testing.T) {
 	t.Parallel()
 	t.Run("Dial starts periodical reporting", func(t *testing.T) {
@@ -398,6 +477,34 @@ func TestMultiNode_selectNode(t *testing.T) {
 	})
 }
 
+func TestMultiNode_CustomSelector(t *testing.T) {
+	t.Parallel()
+	chainID := types.RandomID()
+	node1 := newHealthyNode(t, chainID)
+	node2 := newHealthyNode(t, chainID)
+	var selectorNodes [][]Node[types.ID, multiNodeRPCClient]
+	newSelector := func(nodes []Node[types.ID, multiNodeRPCClient]) NodeSelector[types.ID, multiNodeRPCClient] {
+		selectorNodes = append(selectorNodes, nodes)
+		nodeSelector := newMockNodeSelector[types.ID, multiNodeRPCClient](t)
+		nodeSelector.On("Select").Return(nodes[len(nodes)-1]).Maybe()
+		nodeSelector.On("Name").Return("Custom").Maybe()
+		return nodeSelector
+	}
+	mn := NewMultiNodeWithSelector[types.ID, multiNodeRPCClient](logger.Test(t), newSelector, 0,
+		[]Node[types.ID, multiNodeRPCClient]{node1}, nil, chainID, "", 0)
+	assert.Equal(t, "Custom", mn.selectionMode)
+	servicetest.Run(t, mn)
+	selected, err := mn.selectNode()
+	require.NoError(t, err)
+	assert.Equal(t, node1, selected)
+
+	// the nodes of the replaced pool get a new selector
+	require.NoError(t, mn.ReplaceNodes(tests.Context(t), []Node[types.ID, multiNodeRPCClient]{node1, node2}, nil))
+	require.Len(t, selectorNodes, 2)
+	assert.Equal(t, []Node[types.ID, multiNodeRPCClient]{node1, node2}, selectorNodes[1])
+	assert.Equal(t, node2, mn.selector().Select())
+}
+
 func TestMultiNode_ChainInfo(t *testing.T) {
 	t.Parallel()
 	type nodeParams struct {
@@ -79,6 +79,8 @@ type Node[
 	ConfiguredChainID() CHAIN_ID
 	// Order - returns priority order configured for the RPC
 	Order() int32
+	// Score - returns latency, lag and error rate metrics of the RPC
+	Score() NodeScore
 	// Start - starts health checks
 	Start(context.Context) error
 	Close() error
@@ -114,6 +116,11 @@ type node[
 	wg sync.WaitGroup
 
 	healthCheckSubs []types.Subscription
+
+	scoreMu     sync.RWMutex // protects poll statistics
+	pollLatency time.Duration
+	polls       uint64
+	pollsFailed uint64
 }
 
 func NewNode[
@@ -143,8 +143,10 @@ func (n *node[CHAIN_ID, HEAD, RPC]) aliveLoop() {
 			promPoolRPCNodePolls.WithLabelValues(n.chainID.String(), n.name).Inc()
 			lggr.Tracew("Pinging RPC", "nodeState", n.State(), "pollFailures", pollFailures)
 			pollCtx, cancel := context.WithTimeout(ctx, pollInterval)
+			pollStart := time.Now()
 			err = n.RPC().Ping(pollCtx)
 			cancel()
+			n.recordPoll(time.Since(pollStart), err)
 			if err != nil {
 				// prevent overflow
 				if pollFailures < math.MaxUint32 {
@@ -370,7 +372,8 @@ func (n *node[CHAIN_ID, HEAD, RPC]) isOutOfSyncWithPool() (outOfSync bool, liveN
 	localChainInfo, _ := n.rpc.GetInterceptedChainInfo()
 	mode := n.nodePoolCfg.SelectionMode()
 	switch mode {
-	case NodeSelectionModeHighestHead, NodeSelectionModeRoundRobin, NodeSelectionModePriorityLevel:
+	case NodeSelectionModeHighestHead, NodeSelectionModeRoundRobin, NodeSelectionModePriorityLevel,
+		NodeSelectionModeLowestLatency, NodeSelectionModeStickyRoundRobin:
 		outOfSync = localChainInfo.BlockNumber < ci.BlockNumber-int64(threshold)
 	case NodeSelectionModeTotalDifficulty:
 		bigThreshold := big.NewInt(int64(threshold))
@@ -0,0 +1,56 @@
+package client
+
+import (
+	"time"
+)
+
+// latencyEWMAWeight is the weight of the most recent poll in the exponentially weighted moving average of RPC latency
+const latencyEWMAWeight = 0.2
+
+// NodeScore describes the health and performance of an RPC as observed by the NodePool. It is used by latency-aware
+// selection policies and exposed via the node API.
+type NodeScore struct {
+	// Latency is an exponentially weighted moving average of the RPC's response time to health check polls.
+	// Zero if no poll has succeeded yet.
+	Latency time.Duration
+	// HeadLag is the number of blocks the RPC's latest head is behind the highest head of the pool
+	HeadLag int64
+	// FinalizedLag is the number of blocks the RPC's latest finalized block is behind the highest finalized block of the pool
+	FinalizedLag int64
+	// ErrorRate is the share of failed health check polls, in range [0, 1]
+	ErrorRate float64
+}
+
+// recordPoll updates latency and error rate statistics with the outcome of a health check poll
+func (n *node[CHAIN_ID, HEAD, RPC]) recordPoll(latency time.Duration, err error) {
+	n.scoreMu.Lock()
+	defer n.scoreMu.Unlock()
+	n.polls++
+	if err != nil {
+		n.pollsFailed++
+		return
+	}
+	if n.pollLatency == 0 {
+		n.pollLatency = latency
+		return
+	}
+	n.pollLatency = time.Duration(latencyEWMAWeight*float64(latency) + (1-latencyEWMAWeight)*float64(n.pollLatency))
+}
+
+func (n *node[CHAIN_ID, HEAD, RPC]) Score() NodeScore {
+	n.scoreMu.RLock()
+	score := NodeScore{Latency: n.pollLatency}
+	if n.polls > 0 {
+		score.ErrorRate = float64(n.pollsFailed) / float64(n.polls)
+	}
+	n.scoreMu.RUnlock()
+
+	if n.poolInfoProvider == nil {
+		return score
+	}
+	_, poolChainInfo := n.poolInfoProvider.LatestChainInfo()
+	localChainInfo, _ := n.rpc.GetInterceptedChainInfo()
+	score.HeadLag = max(poolChainInfo.BlockNumber-localChainInfo.BlockNumber, 0)
+	score.FinalizedLag = max(poolChainInfo.FinalizedBlockNumber-localChainInfo.FinalizedBlockNumber, 0)
+	return score
+}
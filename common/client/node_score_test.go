@@ -0,0 +1,41 @@
+package client
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/smartcontractkit/chainlink/v2/common/types"
+)
+
+func TestNode_Score(t *testing.T) {
+	t.Parallel()
+
+	t.Run("without poll results and pool info", func(t *testing.T) {
+		node := newTestNode(t, testNodeOpts{rpc: newMockRPCClient[types.ID, Head](t)})
+		assert.Equal(t, NodeScore{}, node.Score())
+	})
+
+	t.Run("latency, error rate and lags", func(t *testing.T) {
+		rpc := newMockRPCClient[types.ID, Head](t)
+		node := newTestNode(t, testNodeOpts{rpc: rpc})
+		poolInfo := newMockPoolChainInfoProvider(t)
+		poolInfo.On("LatestChainInfo").Return(2, ChainInfo{BlockNumber: 20, FinalizedBlockNumber: 10})
+		node.SetPoolChainInfoProvider(poolInfo)
+		rpc.On("GetInterceptedChainInfo").Return(ChainInfo{BlockNumber: 17, FinalizedBlockNumber: 10}, ChainInfo{})
+
+		node.recordPoll(100*time.Millisecond, nil)
+		node.recordPoll(200*time.Millisecond, nil)
+		node.recordPoll(time.Second, errors.New("failed to ping"))
+		node.recordPoll(100*time.Millisecond, nil)
+
+		score := node.Score()
+		// 100ms, then 0.2*200ms + 0.8*100ms = 120ms, then 0.2*100ms + 0.8*120ms = 116ms
+		assert.Equal(t, 116*time.Millisecond, score.Latency)
+		assert.InDelta(t, 0.25, score.ErrorRate, 1e-9)
+		assert.Equal(t, int64(3), score.HeadLag)
+		assert.Equal(t, int64(0), score.FinalizedLag)
+	})
+}
@@ -7,10 +7,12 @@ import (
 )
 
 const (
-	NodeSelectionModeHighestHead     = "HighestHead"
-	NodeSelectionModeRoundRobin      = "RoundRobin"
-	NodeSelectionModeTotalDifficulty = "TotalDifficulty"
-	NodeSelectionModePriorityLevel   = "PriorityLevel"
+	NodeSelectionModeHighestHead      = "HighestHead"
+	NodeSelectionModeRoundRobin       = "RoundRobin"
+	NodeSelectionModeTotalDifficulty  = "TotalDifficulty"
+	NodeSelectionModePriorityLevel    = "PriorityLevel"
+	NodeSelectionModeLowestLatency    = "LowestLatency"
+	NodeSelectionModeStickyRoundRobin = "StickyRoundRobin"
 )
 
 type NodeSelector[
@@ -24,6 +26,13 @@ type NodeSelector[
 	Name() string
 }
 
+// NodeSelectorFactory returns the NodeSelector of the primary nodes of a MultiNode. It is called again with the new
+// nodes when the nodes of the MultiNode are replaced.
+type NodeSelectorFactory[
+	CHAIN_ID types.ID,
+	RPC any,
+] func(nodes []Node[CHAIN_ID, RPC]) NodeSelector[CHAIN_ID, RPC]
+
 func newNodeSelector[
 	CHAIN_ID types.ID,
 	RPC any,
@@ -37,6 +46,10 @@ func newNodeSelector[
 		return NewTotalDifficultyNodeSelector[CHAIN_ID, RPC](nodes)
 	case NodeSelectionModePriorityLevel:
 		return NewPriorityLevelNodeSelector[CHAIN_ID, RPC](nodes)
+	case NodeSelectionModeLowestLatency:
+		return NewLowestLatencyNodeSelector[CHAIN_ID, RPC](nodes)
+	case NodeSelectionModeStickyRoundRobin:
+		return NewStickyRoundRobinSelector[CHAIN_ID, RPC](nodes)
 	default:
 		panic(fmt.Sprintf("unsupported NodeSelectionMode: %s", selectionMode))
 	}
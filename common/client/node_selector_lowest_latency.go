@@ -0,0 +1,53 @@
+package client
+
+import (
+	"sort"
+
+	"github.com/smartcontractkit/chainlink/v2/common/types"
+)
+
+type lowestLatencyNodeSelector[
+	CHAIN_ID types.ID,
+	RPC any,
+] []Node[CHAIN_ID, RPC]
+
+func NewLowestLatencyNodeSelector[
+	CHAIN_ID types.ID,
+	RPC any,
+](nodes []Node[CHAIN_ID, RPC]) NodeSelector[CHAIN_ID, RPC] {
+	return lowestLatencyNodeSelector[CHAIN_ID, RPC](nodes)
+}
+
+// Select returns the alive node with the lowest measured latency. Nodes without latency measurements are only
+// selected if no other node is alive. Ties are broken by node order.
+func (s lowestLatencyNodeSelector[CHAIN_ID, RPC]) Select() Node[CHAIN_ID, RPC] {
+	type nodeWithLatency struct {
+		node  Node[CHAIN_ID, RPC]
+		score NodeScore
+	}
+	var nodes []nodeWithLatency
+	for _, n := range s {
+		if n.State() == nodeStateAlive {
+			nodes = append(nodes, nodeWithLatency{n, n.Score()})
+		}
+	}
+	if len(nodes) == 0 {
+		return nil
+	}
+
+	sort.SliceStable(nodes, func(i, j int) bool {
+		li, lj := nodes[i].score.Latency, nodes[j].score.Latency
+		if (li == 0) != (lj == 0) {
+			return lj == 0
+		}
+		if li != lj {
+			return li < lj
+		}
+		return nodes[i].node.Order() < nodes[j].node.Order()
+	})
+	return nodes[0].node
+}
+
+func (s lowestLatencyNodeSelector[CHAIN_ID, RPC]) Name() string {
+	return NodeSelectionModeLowestLatency
+}
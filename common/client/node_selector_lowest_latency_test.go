@@ -0,0 +1,75 @@
+package client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/smartcontractkit/chainlink/v2/common/types"
+)
+
+func TestLowestLatencyNodeSelectorName(t *testing.T) {
+	selector := newNodeSelector[types.ID, RPCClient[types.ID, Head]](NodeSelectionModeLowestLatency, nil)
+	assert.Equal(t, selector.Name(), NodeSelectionModeLowestLatency)
+}
+
+func TestLowestLatencyNodeSelector(t *testing.T) {
+	t.Parallel()
+
+	type nodeClient RPCClient[types.ID, Head]
+	type nodeOpts struct {
+		state   nodeState
+		latency time.Duration
+		order   int32
+	}
+
+	newNodes := func(t *testing.T, opts []nodeOpts) []Node[types.ID, nodeClient] {
+		var nodes []Node[types.ID, nodeClient]
+		for _, o := range opts {
+			node := newMockNode[types.ID, nodeClient](t)
+			node.On("State").Return(o.state)
+			node.On("Score").Return(NodeScore{Latency: o.latency}).Maybe()
+			node.On("Order").Return(o.order).Maybe()
+			nodes = append(nodes, node)
+		}
+		return nodes
+	}
+
+	t.Run("selects alive node with lowest latency", func(t *testing.T) {
+		nodes := newNodes(t, []nodeOpts{
+			{state: nodeStateOutOfSync, latency: time.Millisecond},
+			{state: nodeStateAlive, latency: 30 * time.Millisecond},
+			{state: nodeStateAlive, latency: 20 * time.Millisecond},
+		})
+		selector := newNodeSelector(NodeSelectionModeLowestLatency, nodes)
+		assert.Same(t, nodes[2], selector.Select())
+	})
+
+	t.Run("prefers nodes with latency measurements", func(t *testing.T) {
+		nodes := newNodes(t, []nodeOpts{
+			{state: nodeStateAlive},
+			{state: nodeStateAlive, latency: 30 * time.Millisecond},
+		})
+		selector := newNodeSelector(NodeSelectionModeLowestLatency, nodes)
+		assert.Same(t, nodes[1], selector.Select())
+	})
+
+	t.Run("breaks ties by order", func(t *testing.T) {
+		nodes := newNodes(t, []nodeOpts{
+			{state: nodeStateAlive, latency: 10 * time.Millisecond, order: 2},
+			{state: nodeStateAlive, latency: 10 * time.Millisecond, order: 1},
+		})
+		selector := newNodeSelector(NodeSelectionModeLowestLatency, nodes)
+		assert.Same(t, nodes[1], selector.Select())
+	})
+
+	t.Run("returns nil if no node is alive", func(t *testing.T) {
+		nodes := newNodes(t, []nodeOpts{
+			{state: nodeStateOutOfSync},
+			{state: nodeStateUnreachable},
+		})
+		selector := newNodeSelector(NodeSelectionModeLowestLatency, nodes)
+		assert.Nil(t, selector.Select())
+	})
+}
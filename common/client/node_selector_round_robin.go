@@ -1,6 +1,7 @@
 package client
 
 import (
+	"sync"
 	"sync/atomic"
 
 	"github.com/smartcontractkit/chainlink/v2/common/types"
@@ -46,3 +47,48 @@ func (s *roundRobinSelector[CHAIN_ID, RPC]) Select() Node[CHAIN_ID, RPC] {
 func (s *roundRobinSelector[CHAIN_ID, RPC]) Name() string {
 	return NodeSelectionModeRoundRobin
 }
+
+type stickyRoundRobinSelector[
+	CHAIN_ID types.ID,
+	RPC any,
+] struct {
+	nodes []Node[CHAIN_ID, RPC]
+
+	mu sync.Mutex
+	// current is the index of the node kept while it is alive, -1 until a node is selected
+	current int
+}
+
+// NewStickyRoundRobinSelector returns a selector which keeps the selected node for as long as it is alive. Once it
+// is not, the next alive node in round-robin order is selected, and kept in turn, even if the previous node recovers.
+func NewStickyRoundRobinSelector[
+	CHAIN_ID types.ID,
+	RPC any,
+](nodes []Node[CHAIN_ID, RPC]) NodeSelector[CHAIN_ID, RPC] {
+	return &stickyRoundRobinSelector[CHAIN_ID, RPC]{
+		nodes:   nodes,
+		current: -1,
+	}
+}
+
+func (s *stickyRoundRobinSelector[CHAIN_ID, RPC]) Select() Node[CHAIN_ID, RPC] {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	nNodes := len(s.nodes)
+	if s.current >= 0 && s.nodes[s.current].State() == nodeStateAlive {
+		return s.nodes[s.current]
+	}
+	for i := 1; i <= nNodes; i++ {
+		idx := (s.current + i) % nNodes
+		if s.nodes[idx].State() == nodeStateAlive {
+			s.current = idx
+			return s.nodes[idx]
+		}
+	}
+	return nil
+}
+
+func (s *stickyRoundRobinSelector[CHAIN_ID, RPC]) Name() string {
+	return NodeSelectionModeStickyRoundRobin
+}
@@ -59,3 +59,40 @@ func TestRoundRobinNodeSelector_None(t *testing.T) {
 	selector := newNodeSelector(NodeSelectionModeRoundRobin, nodes)
 	assert.Nil(t, selector.Select())
 }
+
+func TestStickyRoundRobinNodeSelectorName(t *testing.T) {
+	selector := newNodeSelector[types.ID, RPCClient[types.ID, Head]](NodeSelectionModeStickyRoundRobin, nil)
+	assert.Equal(t, selector.Name(), NodeSelectionModeStickyRoundRobin)
+}
+
+func TestStickyRoundRobinNodeSelector(t *testing.T) {
+	t.Parallel()
+
+	type nodeClient RPCClient[types.ID, Head]
+	var nodes []Node[types.ID, nodeClient]
+
+	states := []nodeState{nodeStateOutOfSync, nodeStateAlive, nodeStateAlive}
+	for i := range states {
+		node := newMockNode[types.ID, nodeClient](t)
+		node.On("State").Return(func() nodeState { return states[i] }).Maybe()
+		nodes = append(nodes, node)
+	}
+
+	selector := newNodeSelector(NodeSelectionModeStickyRoundRobin, nodes)
+	// the first alive node is kept while it is alive
+	assert.Same(t, nodes[1], selector.Select())
+	assert.Same(t, nodes[1], selector.Select())
+
+	// the next alive node is selected once it is not, and kept when the previous one recovers
+	states[1] = nodeStateUnreachable
+	assert.Same(t, nodes[2], selector.Select())
+	states[1] = nodeStateAlive
+	assert.Same(t, nodes[2], selector.Select())
+
+	// the selection wraps around
+	states[2] = nodeStateOutOfSync
+	assert.Same(t, nodes[1], selector.Select())
+
+	states[1] = nodeStateUnreachable
+	assert.Nil(t, selector.Select())
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"sync"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -28,6 +29,11 @@ var (
 		Name: "head_tracker_very_old_head",
 		Help: "Counter is incremented every time we get a head that is much lower than the highest seen head ('much lower' is defined as a block that is EVM.FinalityDepth or greater below the highest seen head)",
 	}, []string{"evmChainID"})
+
+	promFinalityViolation = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "head_tracker_finality_violation",
+		Help: "Counter is incremented every time a block previously observed as finalized is no longer part of the canonical chain",
+	}, []string{"evmChainID"})
 )
 
 // HeadsBufferSize - The buffer is used when heads sampling is disabled, to ensure the callback is run for every head
@@ -66,6 +72,11 @@ type headTracker[
 	broadcastMB  *mailbox.Mailbox[HTH]
 	headListener HeadListener[HTH, BLOCK_HASH]
 	getNilHead   func() HTH
+
+	// finalizedMu guards the latest finalized block observed by backfill, which is used to detect finality violations
+	finalizedMu          sync.Mutex
+	observedFinalized    BLOCK_HASH
+	observedFinalizedNum int64
 }
 
 // NewHeadTracker instantiates a new HeadTracker using HeadSaver to persist new block numbers.
@@ -202,7 +213,71 @@ func (ht *headTracker[HTH, S, ID, BLOCK_HASH]) Backfill(ctx context.Context, hea
 			latestFinalized.BlockNumber(), headWithChain.BlockNumber(), ht.htConfig.MaxAllowedFinalityDepth())
 	}
 
-	return ht.backfill(ctx, headWithChain, latestFinalized)
+	if err = ht.backfill(ctx, headWithChain, latestFinalized); err != nil {
+		return err
+	}
+
+	return ht.checkFinality(ctx, headWithChain, latestFinalized)
+}
+
+// checkFinality verifies that the block previously observed as finalized is still part of the canonical chain ending
+// at headWithChain. If it is not, a previously-finalized block was reorged: the violation is logged, counted and
+// reported as a health error. Otherwise, latestFinalized becomes the new reference for the next check.
+//
+// The violation isn't reported to the MultiNode: the heads and the finalized block are served by whichever RPC it
+// selected at the time, and it doesn't tell which one, so the violation can't be attributed to an RPC. RPCs lagging
+// on their finalized block are already taken out of the pool by the MultiNode itself, see FinalizedBlockOutOfSync.
+func (ht *headTracker[HTH, S, ID, BLOCK_HASH]) checkFinality(ctx context.Context, headWithChain, latestFinalized HTH) error {
+	ht.finalizedMu.Lock()
+	defer ht.finalizedMu.Unlock()
+
+	var zero BLOCK_HASH
+	if ht.observedFinalized != zero && latestFinalized.BlockNumber() >= ht.observedFinalizedNum {
+		canonicalHash, err := ht.canonicalHashAt(ctx, headWithChain, ht.observedFinalizedNum)
+		if err != nil {
+			// keep the reference, so that it's checked by the next backfill
+			return fmt.Errorf("failed to check finality of block %d: %w", ht.observedFinalizedNum, err)
+		}
+		if canonicalHash != ht.observedFinalized {
+			err := FinalityViolationError[BLOCK_HASH]{
+				BlockNumber: ht.observedFinalizedNum,
+				Finalized:   ht.observedFinalized,
+				Canonical:   canonicalHash,
+			}
+			promFinalityViolation.WithLabelValues(ht.chainID.String()).Inc()
+			ht.log.Criticalw("Finality violation detected: a block previously observed as finalized was reorged. Either the chain broke its finality guarantees or one of the RPC nodes reported an invalid finalized block. This node may not function correctly without manual intervention.",
+				"block_number", err.BlockNumber, "finalized_hash", err.Finalized, "canonical_hash", err.Canonical, "err", err)
+			ht.eng.EmitHealthErr(err)
+			// keep the new canonical chain as the reference, so that the violation is reported only once
+			ht.observedFinalized, ht.observedFinalizedNum = latestFinalized.BlockHash(), latestFinalized.BlockNumber()
+			return err
+		}
+	}
+
+	if latestFinalized.BlockNumber() >= ht.observedFinalizedNum {
+		ht.observedFinalized, ht.observedFinalizedNum = latestFinalized.BlockHash(), latestFinalized.BlockNumber()
+	}
+	return nil
+}
+
+// canonicalHashAt returns the hash of the block at the height in the canonical chain ending at headWithChain. The
+// block is fetched when the height is no longer part of the chain kept in memory, e.g. when finality advanced by
+// more than HistoryDepth blocks since the previous check.
+func (ht *headTracker[HTH, S, ID, BLOCK_HASH]) canonicalHashAt(ctx context.Context, headWithChain HTH, height int64) (BLOCK_HASH, error) {
+	var zero BLOCK_HASH
+	if canonical := ht.headSaver.Chain(headWithChain.BlockHash()); canonical.IsValid() {
+		if hash := canonical.HashAtHeight(height); hash != zero {
+			return hash, nil
+		}
+	}
+	head, err := ht.client.HeadByNumber(ctx, big.NewInt(height))
+	if err != nil {
+		return zero, fmt.Errorf("failed to fetch block %d: %w", height, err)
+	}
+	if !head.IsValid() {
+		return zero, fmt.Errorf("block %d not found", height)
+	}
+	return head.BlockHash(), nil
 }
 
 func (ht *headTracker[HTH, S, ID, BLOCK_HASH]) LatestChain() HTH {
@@ -462,6 +537,16 @@ func (e FinalizedMissingError[BLOCK_HASH]) Error() string {
 	return fmt.Sprintf("finalized block %s missing from canonical chain %s", e.Finalized, e.Canonical)
 }
 
+// FinalityViolationError is returned when a block previously observed as finalized is no longer part of the canonical chain.
+type FinalityViolationError[BLOCK_HASH types.Hashable] struct {
+	BlockNumber          int64
+	Finalized, Canonical BLOCK_HASH
+}
+
+func (e FinalityViolationError[BLOCK_HASH]) Error() string {
+	return fmt.Sprintf("finality violated: finalized block %s at height %d was replaced by %s in canonical chain", e.Finalized, e.BlockNumber, e.Canonical)
+}
+
 func (ht *headTracker[HTH, S, ID, BLOCK_HASH]) fetchAndSaveHead(ctx context.Context, n int64, hash BLOCK_HASH) (HTH, error) {
 	ht.log.Debugw("Fetching head", "blockHeight", n, "blockHash", hash)
 	head, err := ht.client.HeadByHash(ctx, hash)
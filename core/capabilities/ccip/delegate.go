@@ -64,6 +64,8 @@ type Delegate struct {
 	monitoringEndpointGen telemetry.MonitoringEndpointGenerator
 	capabilityConfig      config.Capabilities
 	evmConfigs            toml.EVMConfigs
+	newRMNPeerClient      oraclecreator.NewRMNPeerClientFn
+	pluginTelemetry       oraclecreator.PluginTelemetrySink
 
 	isNewlyCreatedJob bool
 }
@@ -79,6 +81,8 @@ func NewDelegate(
 	monitoringEndpointGen telemetry.MonitoringEndpointGenerator,
 	capabilityConfig config.Capabilities,
 	evmConfigs toml.EVMConfigs,
+	newRMNPeerClient oraclecreator.NewRMNPeerClientFn,
+	pluginTelemetry oraclecreator.PluginTelemetrySink,
 ) *Delegate {
 	return &Delegate{
 		lggr:                  lggr,
@@ -91,6 +95,8 @@ func NewDelegate(
 		monitoringEndpointGen: monitoringEndpointGen,
 		capabilityConfig:      capabilityConfig,
 		evmConfigs:            evmConfigs,
+		newRMNPeerClient:      newRMNPeerClient,
+		pluginTelemetry:       pluginTelemetry,
 	}
 }
 
@@ -205,6 +211,8 @@ func (d *Delegate) ServicesForSpec(ctx context.Context, spec job.Job) (services
 			bootstrapperLocators,
 			hcr,
 			cciptypes.ChainSelector(homeChainChainSelector),
+			d.newRMNPeerClient,
+			d.pluginTelemetry,
 		)
 	} else {
 		oracleCreator = oraclecreator.NewBootstrapOracleCreator(
@@ -22,6 +22,7 @@ import (
 	cctypes "github.com/smartcontractkit/chainlink/v2/core/capabilities/ccip/types"
 	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/assets"
 	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/config/toml"
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/logpoller"
 
 	"github.com/smartcontractkit/libocr/commontypes"
 	libocr3 "github.com/smartcontractkit/libocr/offchainreporting2plus"
@@ -55,6 +56,10 @@ const (
 	defaultExecGasLimit   = 6_500_000
 )
 
+// NewRMNPeerClientFn creates the client used by the commit plugin to talk to the RMN nodes.
+// It allows tests to replace the RMN nodes reachable over p2p with in-process ones.
+type NewRMNPeerClientFn func(lggr logger.Logger) rmn.PeerClient
+
 // pluginOracleCreator creates oracles that reference plugins running
 // in the same process as the chainlink node, i.e not LOOPPs.
 type pluginOracleCreator struct {
@@ -72,6 +77,8 @@ type pluginOracleCreator struct {
 	homeChainReader       ccipreaderpkg.HomeChain
 	homeChainSelector     cciptypes.ChainSelector
 	relayers              map[types.RelayID]loop.Relayer
+	newRMNPeerClient      NewRMNPeerClientFn
+	pluginTelemetry       PluginTelemetrySink
 }
 
 func NewPluginOracleCreator(
@@ -89,6 +96,8 @@ func NewPluginOracleCreator(
 	bootstrapperLocators []commontypes.BootstrapperLocator,
 	homeChainReader ccipreaderpkg.HomeChain,
 	homeChainSelector cciptypes.ChainSelector,
+	newRMNPeerClient NewRMNPeerClientFn,
+	pluginTelemetry PluginTelemetrySink,
 ) cctypes.OracleCreator {
 	return &pluginOracleCreator{
 		ocrKeyBundles:         ocrKeyBundles,
@@ -105,6 +114,8 @@ func NewPluginOracleCreator(
 		bootstrapperLocators:  bootstrapperLocators,
 		homeChainReader:       homeChainReader,
 		homeChainSelector:     homeChainSelector,
+		newRMNPeerClient:      newRMNPeerClient,
+		pluginTelemetry:       pluginTelemetry,
 	}
 }
 
@@ -115,6 +126,9 @@ func (i *pluginOracleCreator) Type() cctypes.OracleType {
 
 // Create implements types.OracleCreator.
 func (i *pluginOracleCreator) Create(ctx context.Context, donID uint32, config cctypes.OCR3ConfigWithMeta) (cctypes.CCIPOracle, error) {
+	// Oracles are created by the launcher when the DON is configured, outside of the job spawner, the filters of
+	// their contract readers are attributed to the CCIP job explicitly.
+	ctx = logpoller.WithJobID(ctx, i.jobID)
 	pluginType := cctypes.PluginType(config.Config.PluginType)
 	chainSelector := uint64(config.Config.ChainSelector)
 	destChainFamily, err := chainsel.GetSelectorFamily(chainSelector)
@@ -229,25 +243,37 @@ func (i *pluginOracleCreator) createFactoryAndTransmitter(
 ) (ocr3types.ReportingPluginFactory[[]byte], ocr3types.ContractTransmitter[[]byte], error) {
 	var factory ocr3types.ReportingPluginFactory[[]byte]
 	var transmitter ocr3types.ContractTransmitter[[]byte]
+	var telemetry *pluginTelemetry
+	pluginLggr := i.lggr
+	if i.pluginTelemetry != nil {
+		telemetry = newPluginTelemetry(i.pluginTelemetry, donID, cctypes.PluginType(config.Config.PluginType), config.Config.ChainSelector)
+		pluginLggr = newTelemetryLogger(i.lggr, telemetry)
+	}
 	if config.Config.PluginType == uint8(cctypes.PluginTypeCCIPCommit) {
 		if !i.peerWrapper.IsStarted() {
 			return nil, nil, fmt.Errorf("peer wrapper is not started")
 		}
 
-		i.lggr.Infow("creating rmn peer client",
-			"bootstrapperLocators", i.bootstrapperLocators, "deltaRound", publicConfig.DeltaRound)
-
-		rmnPeerClient := rmn.NewPeerClient(
-			i.lggr.Named("RMNPeerClient"),
-			i.peerWrapper.PeerGroupFactory,
-			i.bootstrapperLocators,
-			publicConfig.DeltaRound,
-		)
+		var rmnPeerClient rmn.PeerClient
+		if i.newRMNPeerClient != nil {
+			i.lggr.Infow("creating custom rmn peer client")
+			rmnPeerClient = i.newRMNPeerClient(i.lggr.Named("RMNPeerClient"))
+		} else {
+			i.lggr.Infow("creating rmn peer client",
+				"bootstrapperLocators", i.bootstrapperLocators, "deltaRound", publicConfig.DeltaRound)
+
+			rmnPeerClient = rmn.NewPeerClient(
+				i.lggr.Named("RMNPeerClient"),
+				i.peerWrapper.PeerGroupFactory,
+				i.bootstrapperLocators,
+				publicConfig.DeltaRound,
+			)
+		}
 
 		rmnCrypto := ccipevm.NewEVMRMNCrypto(i.lggr.Named("EVMRMNCrypto"))
 
 		factory = commitocr3.NewPluginFactory(
-			i.lggr.
+			pluginLggr.
 				Named("CCIPCommitPlugin").
 				Named(destRelayID.String()).
 				Named(fmt.Sprintf("%d", config.Config.ChainSelector)).
@@ -269,7 +295,7 @@ func (i *pluginOracleCreator) createFactoryAndTransmitter(
 		)
 	} else if config.Config.PluginType == uint8(cctypes.PluginTypeCCIPExec) {
 		factory = execocr3.NewPluginFactory(
-			i.lggr.
+			pluginLggr.
 				Named("CCIPExecPlugin").
 				Named(destRelayID.String()).
 				Named(hexutil.Encode(config.Config.OfframpAddress)),
@@ -290,6 +316,9 @@ func (i *pluginOracleCreator) createFactoryAndTransmitter(
 	} else {
 		return nil, nil, fmt.Errorf("unsupported plugin type %d", config.Config.PluginType)
 	}
+	if telemetry != nil {
+		factory = newTelemetryPluginFactory(factory, telemetry)
+	}
 	return factory, transmitter, nil
 }
 
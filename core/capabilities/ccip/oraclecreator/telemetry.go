@@ -0,0 +1,282 @@
+package oraclecreator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
+	ocrtypes "github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+
+	commitocr3 "github.com/smartcontractkit/chainlink-ccip/commit"
+	"github.com/smartcontractkit/chainlink-ccip/execute/exectypes"
+	cciptypes "github.com/smartcontractkit/chainlink-ccip/pkg/types/ccipocr3"
+
+	cctypes "github.com/smartcontractkit/chainlink/v2/core/capabilities/ccip/types"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+// PluginTelemetrySink receives the round data of the CCIP plugins of a node. It allows tests to assert
+// what the plugins did, e.g. that exec skipped a message because it was too costly, instead of inferring it
+// from a timeout. PublishPluginEvent is called from the OCR rounds and must not block.
+type PluginTelemetrySink interface {
+	PublishPluginEvent(event PluginEvent)
+}
+
+// PluginEventKind is the kind of a PluginEvent.
+type PluginEventKind string
+
+const (
+	// PluginEventObservation is published when the plugin made an observation.
+	PluginEventObservation PluginEventKind = "observation"
+	// PluginEventOutcome is published when the plugin computed the outcome of a round,
+	// with the messages it batched: the ones committed by commit, the ones executed by exec.
+	PluginEventOutcome PluginEventKind = "outcome"
+	// PluginEventReports is published when the plugin built the reports of an outcome.
+	PluginEventReports PluginEventKind = "reports"
+	// PluginEventReportSkipped is published when a report is not accepted or transmitted,
+	// or when exec dropped a report exceeding its limits.
+	PluginEventReportSkipped PluginEventKind = "report_skipped"
+	// PluginEventMessageSkipped is published when exec left a message out of its report.
+	PluginEventMessageSkipped PluginEventKind = "message_skipped"
+)
+
+// PluginEvent is the data of a CCIP plugin round published to a PluginTelemetrySink.
+type PluginEvent struct {
+	Kind       PluginEventKind
+	Time       time.Time
+	DONID      uint32
+	OracleID   commontypes.OracleID
+	PluginType cctypes.PluginType
+	// DestChainSelector is the chain the plugin reports to.
+	DestChainSelector cciptypes.ChainSelector
+	// SeqNr is the sequence number of the round.
+	SeqNr uint64
+	// Messages are the sequence numbers of the messages of the event by source chain,
+	// e.g. the messages batched in an outcome or the message skipped.
+	Messages map[cciptypes.ChainSelector][]cciptypes.SeqNum
+	// Reports is the number of reports built.
+	Reports int
+	// Reason is why a message or report was skipped, e.g. the message state of a message skipped by exec.
+	Reason string
+	// Err is the error the plugin failed the step with, if any.
+	Err error
+}
+
+// pluginTelemetry publishes the events of the plugin of an oracle.
+type pluginTelemetry struct {
+	sink              PluginTelemetrySink
+	donID             uint32
+	pluginType        cctypes.PluginType
+	destChainSelector cciptypes.ChainSelector
+	oracleID          atomic.Uint32
+	// seqNr is the sequence number of the round being run, to attribute the events logged by the plugin.
+	seqNr atomic.Uint64
+}
+
+func newPluginTelemetry(sink PluginTelemetrySink, donID uint32, pluginType cctypes.PluginType, destChainSelector cciptypes.ChainSelector) *pluginTelemetry {
+	return &pluginTelemetry{
+		sink:              sink,
+		donID:             donID,
+		pluginType:        pluginType,
+		destChainSelector: destChainSelector,
+	}
+}
+
+func (p *pluginTelemetry) publish(event PluginEvent) {
+	event.Time = time.Now()
+	event.DONID = p.donID
+	event.OracleID = commontypes.OracleID(p.oracleID.Load())
+	event.PluginType = p.pluginType
+	event.DestChainSelector = p.destChainSelector
+	if event.SeqNr == 0 {
+		event.SeqNr = p.seqNr.Load()
+	}
+	p.sink.PublishPluginEvent(event)
+}
+
+// batchedMessages returns the messages batched in the outcome of the plugin, nil if it can't be decoded.
+func (p *pluginTelemetry) batchedMessages(outcome ocr3types.Outcome) map[cciptypes.ChainSelector][]cciptypes.SeqNum {
+	if len(outcome) == 0 {
+		return nil
+	}
+	messages := make(map[cciptypes.ChainSelector][]cciptypes.SeqNum)
+	switch p.pluginType {
+	case cctypes.PluginTypeCCIPCommit:
+		var o commitocr3.Outcome
+		if err := json.Unmarshal(outcome, &o); err != nil {
+			return nil
+		}
+		for _, root := range o.MerkleRootOutcome.RootsToReport {
+			for seqNr := root.SeqNumsRange.Start(); seqNr <= root.SeqNumsRange.End(); seqNr++ {
+				messages[root.ChainSel] = append(messages[root.ChainSel], seqNr)
+			}
+		}
+	case cctypes.PluginTypeCCIPExec:
+		o, err := exectypes.DecodeOutcome(outcome)
+		if err != nil {
+			return nil
+		}
+		for _, report := range o.Report.ChainReports {
+			for _, msg := range report.Messages {
+				messages[report.SourceChainSelector] = append(messages[report.SourceChainSelector], msg.Header.SequenceNumber)
+			}
+		}
+	}
+	return messages
+}
+
+// telemetryPluginFactory publishes the events of the reporting plugins it creates.
+type telemetryPluginFactory struct {
+	ocr3types.ReportingPluginFactory[[]byte]
+	telemetry *pluginTelemetry
+}
+
+func newTelemetryPluginFactory(factory ocr3types.ReportingPluginFactory[[]byte], telemetry *pluginTelemetry) ocr3types.ReportingPluginFactory[[]byte] {
+	return &telemetryPluginFactory{ReportingPluginFactory: factory, telemetry: telemetry}
+}
+
+func (f *telemetryPluginFactory) NewReportingPlugin(ctx context.Context, config ocr3types.ReportingPluginConfig) (ocr3types.ReportingPlugin[[]byte], ocr3types.ReportingPluginInfo, error) {
+	plugin, info, err := f.ReportingPluginFactory.NewReportingPlugin(ctx, config)
+	if err != nil {
+		return nil, info, err
+	}
+	f.telemetry.oracleID.Store(uint32(config.OracleID))
+	return &telemetryPlugin{ReportingPlugin: plugin, telemetry: f.telemetry}, info, nil
+}
+
+// telemetryPlugin publishes the steps of the rounds of a reporting plugin.
+type telemetryPlugin struct {
+	ocr3types.ReportingPlugin[[]byte]
+	telemetry *pluginTelemetry
+}
+
+func (p *telemetryPlugin) Observation(ctx context.Context, outctx ocr3types.OutcomeContext, query ocrtypes.Query) (ocrtypes.Observation, error) {
+	p.telemetry.seqNr.Store(outctx.SeqNr)
+	observation, err := p.ReportingPlugin.Observation(ctx, outctx, query)
+	p.telemetry.publish(PluginEvent{Kind: PluginEventObservation, SeqNr: outctx.SeqNr, Err: err})
+	return observation, err
+}
+
+func (p *telemetryPlugin) Outcome(ctx context.Context, outctx ocr3types.OutcomeContext, query ocrtypes.Query, aos []ocrtypes.AttributedObservation) (ocr3types.Outcome, error) {
+	p.telemetry.seqNr.Store(outctx.SeqNr)
+	outcome, err := p.ReportingPlugin.Outcome(ctx, outctx, query, aos)
+	p.telemetry.publish(PluginEvent{
+		Kind:     PluginEventOutcome,
+		SeqNr:    outctx.SeqNr,
+		Messages: p.telemetry.batchedMessages(outcome),
+		Err:      err,
+	})
+	return outcome, err
+}
+
+func (p *telemetryPlugin) Reports(ctx context.Context, seqNr uint64, outcome ocr3types.Outcome) ([]ocr3types.ReportPlus[[]byte], error) {
+	reports, err := p.ReportingPlugin.Reports(ctx, seqNr, outcome)
+	p.telemetry.publish(PluginEvent{
+		Kind:     PluginEventReports,
+		SeqNr:    seqNr,
+		Messages: p.telemetry.batchedMessages(outcome),
+		Reports:  len(reports),
+		Err:      err,
+	})
+	return reports, err
+}
+
+func (p *telemetryPlugin) ShouldAcceptAttestedReport(ctx context.Context, seqNr uint64, report ocr3types.ReportWithInfo[[]byte]) (bool, error) {
+	accept, err := p.ReportingPlugin.ShouldAcceptAttestedReport(ctx, seqNr, report)
+	if !accept || err != nil {
+		p.telemetry.publish(PluginEvent{Kind: PluginEventReportSkipped, SeqNr: seqNr, Reason: "not accepted", Err: err})
+	}
+	return accept, err
+}
+
+func (p *telemetryPlugin) ShouldTransmitAcceptedReport(ctx context.Context, seqNr uint64, report ocr3types.ReportWithInfo[[]byte]) (bool, error) {
+	transmit, err := p.ReportingPlugin.ShouldTransmitAcceptedReport(ctx, seqNr, report)
+	if !transmit || err != nil {
+		p.telemetry.publish(PluginEvent{Kind: PluginEventReportSkipped, SeqNr: seqNr, Reason: "not transmitted", Err: err})
+	}
+	return transmit, err
+}
+
+// telemetryLogger publishes the messages and reports the plugin logs it skipped, as the reasons
+// are not returned by the plugin.
+type telemetryLogger struct {
+	logger.Logger
+	telemetry *pluginTelemetry
+}
+
+func newTelemetryLogger(lggr logger.Logger, telemetry *pluginTelemetry) logger.Logger {
+	return &telemetryLogger{Logger: lggr.Helper(1), telemetry: telemetry}
+}
+
+// With and Named keep the loggers derived by the plugin publishing.
+
+func (l *telemetryLogger) With(args ...interface{}) logger.Logger {
+	return &telemetryLogger{Logger: l.Logger.With(args...), telemetry: l.telemetry}
+}
+
+func (l *telemetryLogger) Named(name string) logger.Logger {
+	return &telemetryLogger{Logger: l.Logger.Named(name), telemetry: l.telemetry}
+}
+
+func (l *telemetryLogger) Helper(skip int) logger.Logger {
+	return &telemetryLogger{Logger: l.Logger.Helper(skip), telemetry: l.telemetry}
+}
+
+func (l *telemetryLogger) Debugw(msg string, keysAndValues ...interface{}) {
+	l.Logger.Debugw(msg, keysAndValues...)
+	l.inspect(msg, keysAndValues)
+}
+
+func (l *telemetryLogger) Infow(msg string, keysAndValues ...interface{}) {
+	l.Logger.Infow(msg, keysAndValues...)
+	l.inspect(msg, keysAndValues)
+}
+
+func (l *telemetryLogger) Warnw(msg string, keysAndValues ...interface{}) {
+	l.Logger.Warnw(msg, keysAndValues...)
+	l.inspect(msg, keysAndValues)
+}
+
+func (l *telemetryLogger) Errorw(msg string, keysAndValues ...interface{}) {
+	l.Logger.Errorw(msg, keysAndValues...)
+	l.inspect(msg, keysAndValues)
+}
+
+// inspect publishes the log entry if it reports a message skipped, i.e. it has a message state,
+// or a report dropped for exceeding the limits of the plugin. The plugins don't expose these skips otherwise,
+// the entries are the ones of the exec report builder of chainlink-ccip and
+// Test_telemetryLogger_execReportBuilder fails when they change.
+func (l *telemetryLogger) inspect(msg string, keysAndValues []interface{}) {
+	if strings.HasPrefix(msg, "invalid report") {
+		l.telemetry.publish(PluginEvent{Kind: PluginEventReportSkipped, Reason: msg})
+		return
+	}
+	var (
+		state       string
+		sourceChain cciptypes.ChainSelector
+		seqNr       cciptypes.SeqNum
+	)
+	for i := 0; i+1 < len(keysAndValues); i += 2 {
+		switch keysAndValues[i] {
+		case "messageState":
+			state = fmt.Sprint(keysAndValues[i+1])
+		case "sourceChain":
+			sourceChain, _ = keysAndValues[i+1].(cciptypes.ChainSelector)
+		case "seqNum":
+			seqNr, _ = keysAndValues[i+1].(cciptypes.SeqNum)
+		}
+	}
+	if state == "" {
+		return
+	}
+	l.telemetry.publish(PluginEvent{
+		Kind:     PluginEventMessageSkipped,
+		Messages: map[cciptypes.ChainSelector][]cciptypes.SeqNum{sourceChain: {seqNr}},
+		Reason:   state,
+	})
+}
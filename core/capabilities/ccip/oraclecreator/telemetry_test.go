@@ -0,0 +1,158 @@
+package oraclecreator
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
+	ocrtypes "github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+
+	"github.com/smartcontractkit/chainlink-ccip/execute/exectypes"
+	"github.com/smartcontractkit/chainlink-ccip/execute/report"
+	cciptypes "github.com/smartcontractkit/chainlink-ccip/pkg/types/ccipocr3"
+	commonlogger "github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+
+	"github.com/smartcontractkit/chainlink/v2/core/capabilities/ccip/ccipevm"
+	cctypes "github.com/smartcontractkit/chainlink/v2/core/capabilities/ccip/types"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+type recordingSink struct {
+	mu     sync.Mutex
+	events []PluginEvent
+}
+
+func (s *recordingSink) PublishPluginEvent(event PluginEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = append(s.events, event)
+}
+
+type stubPlugin struct {
+	ocr3types.ReportingPlugin[[]byte]
+	outcome ocr3types.Outcome
+	accept  bool
+}
+
+func (p stubPlugin) Outcome(context.Context, ocr3types.OutcomeContext, ocrtypes.Query, []ocrtypes.AttributedObservation) (ocr3types.Outcome, error) {
+	return p.outcome, nil
+}
+
+func (p stubPlugin) ShouldAcceptAttestedReport(context.Context, uint64, ocr3types.ReportWithInfo[[]byte]) (bool, error) {
+	return p.accept, nil
+}
+
+func Test_telemetryLogger_publishesSkippedMessages(t *testing.T) {
+	sink := &recordingSink{}
+	telemetry := newPluginTelemetry(sink, 1, cctypes.PluginTypeCCIPExec, 3)
+	telemetry.seqNr.Store(7)
+
+	// the plugins derive their loggers with the chainlink-common helpers
+	lggr := commonlogger.With(commonlogger.Named(newTelemetryLogger(logger.TestLogger(t), telemetry), "ExecutePlugin"), "donID", 1)
+	lggr.Infow("message too costly to execute",
+		"sourceChain", cciptypes.ChainSelector(5),
+		"seqNum", cciptypes.SeqNum(9),
+		"messageState", "TooCostly")
+	lggr.Infow("invalid report, report estimated gas usage exceeds limit", "gas", 2, "maxGas", 1)
+	lggr.Infow("read token data", "sourceChain", cciptypes.ChainSelector(5))
+
+	require.Len(t, sink.events, 2)
+	assert.Equal(t, PluginEventMessageSkipped, sink.events[0].Kind)
+	assert.Equal(t, "TooCostly", sink.events[0].Reason)
+	assert.Equal(t, uint64(7), sink.events[0].SeqNr)
+	assert.Equal(t, cciptypes.ChainSelector(3), sink.events[0].DestChainSelector)
+	assert.Equal(t, map[cciptypes.ChainSelector][]cciptypes.SeqNum{5: {9}}, sink.events[0].Messages)
+	assert.Equal(t, PluginEventReportSkipped, sink.events[1].Kind)
+	assert.Equal(t, "invalid report, report estimated gas usage exceeds limit", sink.events[1].Reason)
+}
+
+// Test_telemetryLogger_execReportBuilder pins the log entries inspected to the ones of the exec report builder
+// of the chainlink-ccip version in use, it fails when their messages or keys change upstream.
+func Test_telemetryLogger_execReportBuilder(t *testing.T) {
+	ctx := tests.Context(t)
+	sink := &recordingSink{}
+	telemetry := newPluginTelemetry(sink, 1, cctypes.PluginTypeCCIPExec, 3)
+	lggr := newTelemetryLogger(logger.TestLogger(t), telemetry)
+	hasher := ccipevm.NewMessageHasherV1(logger.TestLogger(t))
+
+	// EVMExtraArgsV1 with a gas limit of 100k
+	extraArgs := append(hexutil.MustDecode("0x97a657c9"), common.LeftPadBytes(big.NewInt(100_000).Bytes(), 32)...)
+	commitData := exectypes.CommitData{
+		SourceChain:         5,
+		SequenceNumberRange: cciptypes.NewSeqNumRange(1, 2),
+		MessageTokenData:    []exectypes.MessageTokenData{{}, {}},
+	}
+	for seqNr := cciptypes.SeqNum(1); seqNr <= 2; seqNr++ {
+		commitData.Messages = append(commitData.Messages, cciptypes.Message{
+			Header: cciptypes.RampMessageHeader{
+				MessageID:           cciptypes.Bytes32{byte(seqNr)},
+				SourceChainSelector: 5,
+				DestChainSelector:   3,
+				SequenceNumber:      seqNr,
+			},
+			Sender:    common.HexToAddress("0x01").Bytes(),
+			Receiver:  common.HexToAddress("0x02").Bytes(),
+			ExtraArgs: extraArgs,
+		})
+	}
+	commitData.CostlyMessages = []cciptypes.Bytes32{commitData.Messages[1].Header.MessageID}
+	tree, err := report.ConstructMerkleTree(ctx, hasher, commitData, lggr)
+	require.NoError(t, err)
+	commitData.MerkleRoot = tree.Root()
+
+	// the second message is too costly, the report of the first one exceeds the max gas
+	builder := report.NewBuilder(lggr, hasher, ccipevm.NewExecutePluginCodecV1(), ccipevm.NewGasEstimateProvider(),
+		nil, 3, 1_000_000, 1)
+	_, err = builder.Add(ctx, commitData)
+	require.NoError(t, err)
+
+	require.NotEmpty(t, sink.events)
+	assert.Equal(t, PluginEventMessageSkipped, sink.events[0].Kind)
+	assert.Equal(t, fmt.Sprint(report.TooCostly), sink.events[0].Reason)
+	assert.Equal(t, map[cciptypes.ChainSelector][]cciptypes.SeqNum{5: {2}}, sink.events[0].Messages)
+	for _, event := range sink.events[1:] {
+		assert.Equal(t, PluginEventReportSkipped, event.Kind)
+		assert.Equal(t, "invalid report, report estimated gas usage exceeds limit", event.Reason)
+	}
+	assert.Len(t, sink.events, 3)
+}
+
+func Test_telemetryPlugin_publishesRounds(t *testing.T) {
+	outcome, err := exectypes.Outcome{
+		State: exectypes.Filter,
+		Report: cciptypes.ExecutePluginReport{ChainReports: []cciptypes.ExecutePluginReportSingleChain{{
+			SourceChainSelector: 5,
+			Messages: []cciptypes.Message{
+				{Header: cciptypes.RampMessageHeader{SequenceNumber: 1}},
+				{Header: cciptypes.RampMessageHeader{SequenceNumber: 2}},
+			},
+		}}},
+	}.Encode()
+	require.NoError(t, err)
+
+	sink := &recordingSink{}
+	telemetry := newPluginTelemetry(sink, 1, cctypes.PluginTypeCCIPExec, 3)
+	plugin := &telemetryPlugin{ReportingPlugin: stubPlugin{outcome: outcome}, telemetry: telemetry}
+
+	_, err = plugin.Outcome(tests.Context(t), ocr3types.OutcomeContext{SeqNr: 4}, nil, nil)
+	require.NoError(t, err)
+	accepted, err := plugin.ShouldAcceptAttestedReport(tests.Context(t), 4, ocr3types.ReportWithInfo[[]byte]{})
+	require.NoError(t, err)
+	require.False(t, accepted)
+
+	require.Len(t, sink.events, 2)
+	assert.Equal(t, PluginEventOutcome, sink.events[0].Kind)
+	assert.Equal(t, uint64(4), sink.events[0].SeqNr)
+	assert.Equal(t, map[cciptypes.ChainSelector][]cciptypes.SeqNum{5: {1, 2}}, sink.events[0].Messages)
+	assert.Equal(t, PluginEventReportSkipped, sink.events[1].Kind)
+	assert.Equal(t, "not accepted", sink.events[1].Reason)
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/smartcontractkit/chainlink/v2/core/capabilities/remote/types"
 	"github.com/smartcontractkit/chainlink/v2/core/config"
 	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	gw_config "github.com/smartcontractkit/chainlink/v2/core/services/gateway/config"
 	"github.com/smartcontractkit/chainlink/v2/core/services/gateway/handlers/common"
 	p2ptypes "github.com/smartcontractkit/chainlink/v2/core/services/p2p/types"
 )
@@ -49,7 +50,7 @@ type key struct {
 var _ services.Service = &dispatcher{}
 
 func NewDispatcher(cfg config.Dispatcher, peerWrapper p2ptypes.PeerWrapper, signer p2ptypes.Signer, registry core.CapabilitiesRegistry, lggr logger.Logger) (*dispatcher, error) {
-	rl, err := common.NewRateLimiter(common.RateLimiterConfig{
+	rl, err := common.NewRateLimiter(gw_config.RateLimiterConfig{
 		GlobalRPS:      cfg.RateLimit().GlobalRPS(),
 		GlobalBurst:    cfg.RateLimit().GlobalBurst(),
 		PerSenderRPS:   cfg.RateLimit().PerSenderRPS(),
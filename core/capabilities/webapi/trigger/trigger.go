@@ -17,6 +17,7 @@ import (
 	"github.com/smartcontractkit/chainlink/v2/core/capabilities/webapi/webapicap"
 	"github.com/smartcontractkit/chainlink/v2/core/logger"
 	"github.com/smartcontractkit/chainlink/v2/core/services/gateway/api"
+	"github.com/smartcontractkit/chainlink/v2/core/services/gateway/config"
 	"github.com/smartcontractkit/chainlink/v2/core/services/gateway/connector"
 	ghcapabilities "github.com/smartcontractkit/chainlink/v2/core/services/gateway/handlers/capabilities"
 	"github.com/smartcontractkit/chainlink/v2/core/services/gateway/handlers/common"
@@ -194,7 +195,7 @@ func (h *triggerConnectorHandler) RegisterTrigger(ctx context.Context, req capab
 	}
 
 	rateLimiterConfig := reqConfig.RateLimiter
-	commonRateLimiter := common.RateLimiterConfig{
+	commonRateLimiter := config.RateLimiterConfig{
 		GlobalRPS:      rateLimiterConfig.GlobalRPS,
 		GlobalBurst:    int(rateLimiterConfig.GlobalBurst),
 		PerSenderRPS:   rateLimiterConfig.PerSenderRPS,
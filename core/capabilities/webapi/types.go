@@ -1,6 +1,6 @@
 package webapi
 
-import "github.com/smartcontractkit/chainlink/v2/core/services/gateway/handlers/common"
+import "github.com/smartcontractkit/chainlink/v2/core/services/gateway/config"
 
 const (
 	SingleNode string = "SingleNode"
@@ -13,5 +13,5 @@ const (
 // Note that workflow executions have their own internal timeouts and retries set by the user
 // that are separate from this configuration
 type ServiceConfig struct {
-	RateLimiter common.RateLimiterConfig `toml:"rateLimiter" json:"rateLimiter" yaml:"rateLimiter" mapstructure:"rateLimiter"`
+	RateLimiter config.RateLimiterConfig `toml:"rateLimiter" json:"rateLimiter" yaml:"rateLimiter" mapstructure:"rateLimiter"`
 }
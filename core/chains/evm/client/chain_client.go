@@ -17,6 +17,7 @@ import (
 	commonclient "github.com/smartcontractkit/chainlink/v2/common/client"
 	evmconfig "github.com/smartcontractkit/chainlink/v2/core/chains/evm/config"
 	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/config/chaintype"
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/config/toml"
 	evmtypes "github.com/smartcontractkit/chainlink/v2/core/chains/evm/types"
 )
 
@@ -34,6 +35,9 @@ type Client interface {
 	// NodeStates returns a map of node Name->node state
 	// It might be nil or empty, e.g. for mock clients etc
 	NodeStates() map[string]string
+	// NodeScores returns a map of node Name->node score (latency, head lag, finalized lag, error rate)
+	// It might be nil or empty, e.g. for mock clients etc
+	NodeScores() map[string]commonclient.NodeScore
 
 	TokenBalance(ctx context.Context, address common.Address, contractAddress common.Address) (*big.Int, error)
 	BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error)
@@ -105,8 +109,24 @@ type chainClient struct {
 	logger       logger.SugaredLogger
 	chainType    chaintype.ChainType
 	clientErrors evmconfig.ClientErrors
+
+	// reloadMu guards the configured nodes, which are replaced by ReloadNodes.
+	// newNode is only set for clients created by NewEvmClient.
+	reloadMu   sync.Mutex
+	newNode    func(id int, node *toml.Node) configuredNode
+	nodes      map[string]configuredNode
+	nextNodeID int
+}
+
+// NodeReloader is implemented by clients whose RPC endpoints can be reloaded at runtime.
+type NodeReloader interface {
+	// ReloadNodes replaces the RPC endpoints of the client with the nodes. The nodes with the same name and
+	// endpoints as before keep their connections.
+	ReloadNodes(ctx context.Context, nodes []*toml.Node) error
 }
 
+var _ NodeReloader = (*chainClient)(nil)
+
 func NewChainClient(
 	lggr logger.Logger,
 	selectionMode string,
@@ -148,6 +168,38 @@ func NewChainClient(
 	}
 }
 
+func (c *chainClient) ReloadNodes(ctx context.Context, nodes []*toml.Node) error {
+	c.reloadMu.Lock()
+	defer c.reloadMu.Unlock()
+	if c.newNode == nil {
+		return errors.New("client does not support reloading nodes")
+	}
+
+	var primaries []commonclient.Node[*big.Int, *RPCClient]
+	var sendonlys []commonclient.SendOnlyNode[*big.Int, *RPCClient]
+	configured := make(map[string]configuredNode, len(nodes))
+	nextNodeID := c.nextNodeID
+	for _, node := range nodes {
+		n, ok := c.nodes[*node.Name]
+		if !ok || !n.config.Equal(node) {
+			n = c.newNode(nextNodeID, node)
+			nextNodeID++
+		}
+		if n.sendOnly != nil {
+			sendonlys = append(sendonlys, n.sendOnly)
+		} else {
+			primaries = append(primaries, n.primary)
+		}
+		configured[*node.Name] = n
+	}
+	if err := c.multiNode.ReplaceNodes(ctx, primaries, sendonlys); err != nil {
+		return err
+	}
+	c.nodes = configured
+	c.nextNodeID = nextNodeID
+	return nil
+}
+
 func (c *chainClient) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
 	r, err := c.multiNode.SelectRPC()
 	if err != nil {
@@ -354,6 +406,10 @@ func (c *chainClient) NodeStates() map[string]string {
 	return c.multiNode.NodeStates()
 }
 
+func (c *chainClient) NodeScores() map[string]commonclient.NodeScore {
+	return c.multiNode.NodeScores()
+}
+
 func (c *chainClient) PendingCodeAt(ctx context.Context, account common.Address) (b []byte, err error) {
 	r, err := c.multiNode.SelectRPC()
 	if err != nil {
@@ -16,27 +16,50 @@ import (
 func NewEvmClient(cfg evmconfig.NodePool, chainCfg commonclient.ChainConfig, clientErrors evmconfig.ClientErrors, lggr logger.Logger, chainID *big.Int, nodes []*toml.Node, chainType chaintype.ChainType) (Client, error) {
 	var primaries []commonclient.Node[*big.Int, *RPCClient]
 	var sendonlys []commonclient.SendOnlyNode[*big.Int, *RPCClient]
-	largePayloadRPCTimeout, defaultRPCTimeout := getRPCTimeouts(chainType)
-
+	newNode := newNodeFunc(cfg, chainCfg, lggr, chainID, chainType)
+	configured := make(map[string]configuredNode, len(nodes))
 	for i, node := range nodes {
+		n := newNode(i, node)
+		if n.sendOnly != nil {
+			sendonlys = append(sendonlys, n.sendOnly)
+		} else {
+			primaries = append(primaries, n.primary)
+		}
+		configured[*node.Name] = n
+	}
+
+	c := NewChainClient(lggr, cfg.SelectionMode(), cfg.LeaseDuration(),
+		primaries, sendonlys, chainID, clientErrors, cfg.DeathDeclarationDelay(), chainType).(*chainClient)
+	c.newNode = newNode
+	c.nodes = configured
+	c.nextNodeID = len(nodes)
+	return c, nil
+}
+
+// configuredNode is a node of the client along with its config, either primary or send-only.
+type configuredNode struct {
+	config   *toml.Node
+	primary  commonclient.Node[*big.Int, *RPCClient]
+	sendOnly commonclient.SendOnlyNode[*big.Int, *RPCClient]
+}
+
+func newNodeFunc(cfg evmconfig.NodePool, chainCfg commonclient.ChainConfig, lggr logger.Logger, chainID *big.Int, chainType chaintype.ChainType) func(id int, node *toml.Node) configuredNode {
+	largePayloadRPCTimeout, defaultRPCTimeout := getRPCTimeouts(chainType)
+	return func(id int, node *toml.Node) configuredNode {
 		if node.SendOnly != nil && *node.SendOnly {
-			rpc := NewRPCClient(cfg, lggr, nil, node.HTTPURL.URL(), *node.Name, i, chainID,
+			rpc := NewRPCClient(cfg, lggr, nil, node.HTTPURL.URL(), *node.Name, id, chainID,
 				commonclient.Secondary, largePayloadRPCTimeout, defaultRPCTimeout, chainType)
 			sendonly := commonclient.NewSendOnlyNode(lggr, (url.URL)(*node.HTTPURL),
 				*node.Name, chainID, rpc)
-			sendonlys = append(sendonlys, sendonly)
-		} else {
-			rpc := NewRPCClient(cfg, lggr, node.WSURL.URL(), node.HTTPURL.URL(), *node.Name, i,
-				chainID, commonclient.Primary, largePayloadRPCTimeout, defaultRPCTimeout, chainType)
-			primaryNode := commonclient.NewNode(cfg, chainCfg,
-				lggr, node.WSURL.URL(), node.HTTPURL.URL(), *node.Name, i, chainID, *node.Order,
-				rpc, "EVM")
-			primaries = append(primaries, primaryNode)
+			return configuredNode{config: node, sendOnly: sendonly}
 		}
+		rpc := NewRPCClient(cfg, lggr, node.WSURL.URL(), node.HTTPURL.URL(), *node.Name, id,
+			chainID, commonclient.Primary, largePayloadRPCTimeout, defaultRPCTimeout, chainType)
+		primaryNode := commonclient.NewNode(cfg, chainCfg,
+			lggr, node.WSURL.URL(), node.HTTPURL.URL(), *node.Name, id, chainID, *node.Order,
+			rpc, "EVM")
+		return configuredNode{config: node, primary: primaryNode}
 	}
-
-	return NewChainClient(lggr, cfg.SelectionMode(), cfg.LeaseDuration(),
-		primaries, sendonlys, chainID, clientErrors, cfg.DeathDeclarationDelay(), chainType), nil
 }
 
 func getRPCTimeouts(chainType chaintype.ChainType) (largePayload, defaultTimeout time.Duration) {
@@ -1300,6 +1300,53 @@ func (_c *Client_LatestFinalizedBlock_Call) RunAndReturn(run func(context.Contex
 	return _c
 }
 
+// NodeScores provides a mock function with given fields:
+func (_m *Client) NodeScores() map[string]commonclient.NodeScore {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for NodeScores")
+	}
+
+	var r0 map[string]commonclient.NodeScore
+	if rf, ok := ret.Get(0).(func() map[string]commonclient.NodeScore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]commonclient.NodeScore)
+		}
+	}
+
+	return r0
+}
+
+// Client_NodeScores_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NodeScores'
+type Client_NodeScores_Call struct {
+	*mock.Call
+}
+
+// NodeScores is a helper method to define mock.On call
+func (_e *Client_Expecter) NodeScores() *Client_NodeScores_Call {
+	return &Client_NodeScores_Call{Call: _e.mock.On("NodeScores")}
+}
+
+func (_c *Client_NodeScores_Call) Run(run func()) *Client_NodeScores_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Client_NodeScores_Call) Return(_a0 map[string]commonclient.NodeScore) *Client_NodeScores_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Client_NodeScores_Call) RunAndReturn(run func() map[string]commonclient.NodeScore) *Client_NodeScores_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NodeStates provides a mock function with given fields:
 func (_m *Client) NodeStates() map[string]string {
 	ret := _m.Called()
@@ -223,6 +223,9 @@ func (nc *NullClient) SuggestGasTipCap(ctx context.Context) (tipCap *big.Int, er
 // NodeStates implements evmclient.Client
 func (nc *NullClient) NodeStates() map[string]string { return nil }
 
+// NodeScores implements evmclient.Client
+func (nc *NullClient) NodeScores() map[string]commonclient.NodeScore { return nil }
+
 func (nc *NullClient) IsL2() bool {
 	nc.lggr.Debug("IsL2")
 	return false
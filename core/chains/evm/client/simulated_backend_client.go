@@ -565,6 +565,9 @@ func (c *SimulatedBackendClient) Backend() evmtypes.Backend {
 // NodeStates implements evmclient.Client
 func (c *SimulatedBackendClient) NodeStates() map[string]string { return nil }
 
+// NodeScores implements evmclient.Client
+func (c *SimulatedBackendClient) NodeScores() map[string]commonclient.NodeScore { return nil }
+
 // Commit imports all the pending transactions as a single block and starts a
 // fresh new state.
 func (c *SimulatedBackendClient) Commit() common.Hash {
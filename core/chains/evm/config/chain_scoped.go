@@ -2,6 +2,7 @@ package config
 
 import (
 	"math/big"
+	"sync"
 	"time"
 
 	"github.com/smartcontractkit/chainlink-common/pkg/assets"
@@ -13,7 +14,7 @@ import (
 
 func NewTOMLChainScopedConfig(tomlConfig *toml.EVMConfig, lggr logger.Logger) *ChainScoped {
 	return &ChainScoped{
-		evmConfig: &EVMConfig{C: tomlConfig},
+		evmConfig: &EVMConfig{C: tomlConfig, r: &reloaded{}},
 		lggr:      lggr}
 }
 
@@ -22,6 +23,7 @@ type ChainScoped struct {
 	lggr logger.Logger
 
 	evmConfig *EVMConfig
+	reloadMu  sync.Mutex
 }
 
 func (c *ChainScoped) EVM() EVM {
@@ -29,6 +31,9 @@ func (c *ChainScoped) EVM() EVM {
 }
 
 func (c *ChainScoped) Nodes() toml.EVMNodes {
+	if nodes := c.evmConfig.r.loadNodes(); nodes != nil {
+		return nodes
+	}
 	return c.evmConfig.C.Nodes
 }
 
@@ -38,6 +43,8 @@ func (c *ChainScoped) BlockEmissionIdleWarningThreshold() time.Duration {
 
 type EVMConfig struct {
 	C *toml.EVMConfig
+	// r holds the settings reloaded at runtime, see ChainScoped.Reload
+	r *reloaded
 }
 
 func (e *EVMConfig) IsEnabled() bool {
@@ -45,7 +52,7 @@ func (e *EVMConfig) IsEnabled() bool {
 }
 
 func (e *EVMConfig) TOMLString() (string, error) {
-	return e.C.TOMLString()
+	return e.effective().TOMLString()
 }
 
 func (e *EVMConfig) BalanceMonitor() BalanceMonitor {
@@ -73,7 +80,7 @@ func (e *EVMConfig) Workflow() Workflow {
 }
 
 func (e *EVMConfig) GasEstimator() GasEstimator {
-	return &gasEstimatorConfig{c: e.C.GasEstimator, blockDelay: e.C.RPCBlockQueryDelay, transactionsMaxInFlight: e.C.Transactions.MaxInFlight, k: e.C.KeySpecific}
+	return &gasEstimatorConfig{c: e.C.GasEstimator, blockDelay: e.C.RPCBlockQueryDelay, transactionsMaxInFlight: e.C.Transactions.MaxInFlight, k: e.C.KeySpecific, r: e.r}
 }
 
 func (e *EVMConfig) AutoCreateKey() bool {
@@ -140,7 +147,7 @@ func (e *EVMConfig) MinIncomingConfirmations() uint32 {
 }
 
 func (e *EVMConfig) NodePool() NodePool {
-	return &NodePoolConfig{C: e.C.NodePool}
+	return &NodePoolConfig{C: e.C.NodePool, r: e.r}
 }
 
 func (e *EVMConfig) ClientErrors() ClientErrors {
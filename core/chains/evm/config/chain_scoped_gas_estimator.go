@@ -15,6 +15,7 @@ type gasEstimatorConfig struct {
 	k                       toml.KeySpecificConfig
 	blockDelay              *uint16
 	transactionsMaxInFlight *uint32
+	r                       *reloaded
 }
 
 func (g *gasEstimatorConfig) PriceMaxKey(addr gethcommon.Address) *assets.Wei {
@@ -27,12 +28,12 @@ func (g *gasEstimatorConfig) PriceMaxKey(addr gethcommon.Address) *assets.Wei {
 		}
 	}
 
-	chainSpecific := g.c.PriceMax
+	chainSpecific := g.PriceMax()
 	if keySpecific != nil && keySpecific.Cmp(chainSpecific) < 0 {
 		return keySpecific
 	}
 
-	return g.c.PriceMax
+	return chainSpecific
 }
 
 func (g *gasEstimatorConfig) BlockHistory() BlockHistory {
@@ -71,6 +72,9 @@ func (g *gasEstimatorConfig) BumpMin() *assets.Wei {
 }
 
 func (g *gasEstimatorConfig) FeeCapDefault() *assets.Wei {
+	if v := g.r.loadFeeCapDefault(); v != nil {
+		return v
+	}
 	return g.c.FeeCapDefault
 }
 
@@ -100,6 +104,9 @@ func (g *gasEstimatorConfig) PriceMin() *assets.Wei {
 }
 
 func (g *gasEstimatorConfig) PriceMax() *assets.Wei {
+	if v := g.r.loadPriceMax(); v != nil {
+		return v
+	}
 	return g.c.PriceMax
 }
 
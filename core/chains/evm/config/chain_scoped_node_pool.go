@@ -8,6 +8,7 @@ import (
 
 type NodePoolConfig struct {
 	C toml.NodePool
+	r *reloaded
 }
 
 func (n *NodePoolConfig) PollFailureThreshold() uint32 {
@@ -45,6 +46,9 @@ func (n *NodePoolConfig) NewHeadsPollInterval() time.Duration {
 func (n *NodePoolConfig) Errors() ClientErrors { return &clientErrorsConfig{c: n.C.Errors} }
 
 func (n *NodePoolConfig) EnforceRepeatableRead() bool {
+	if v := n.r.loadEnforceRepeatableRead(); v != nil {
+		return *v
+	}
 	return *n.C.EnforceRepeatableRead
 }
 
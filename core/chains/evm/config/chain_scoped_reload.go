@@ -0,0 +1,176 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	commonconfig "github.com/smartcontractkit/chainlink-common/pkg/config"
+
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/assets"
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/config/toml"
+)
+
+// reloaded holds the settings of a chain which were reloaded at runtime, overriding its TOML config.
+// A nil *reloaded holds no settings.
+type reloaded struct {
+	enforceRepeatableRead atomic.Pointer[bool]
+	priceMax              atomic.Pointer[assets.Wei]
+	feeCapDefault         atomic.Pointer[assets.Wei]
+	nodes                 atomic.Pointer[toml.EVMNodes]
+}
+
+func (r *reloaded) loadEnforceRepeatableRead() *bool {
+	if r == nil {
+		return nil
+	}
+	return r.enforceRepeatableRead.Load()
+}
+
+func (r *reloaded) loadPriceMax() *assets.Wei {
+	if r == nil {
+		return nil
+	}
+	return r.priceMax.Load()
+}
+
+func (r *reloaded) loadFeeCapDefault() *assets.Wei {
+	if r == nil {
+		return nil
+	}
+	return r.feeCapDefault.Load()
+}
+
+func (r *reloaded) loadNodes() toml.EVMNodes {
+	if r == nil {
+		return nil
+	}
+	if nodes := r.nodes.Load(); nodes != nil {
+		return *nodes
+	}
+	return nil
+}
+
+// effective returns a copy of the TOML config with the reloaded settings applied.
+func (e *EVMConfig) effective() *toml.EVMConfig {
+	c := *e.C
+	if v := e.r.loadEnforceRepeatableRead(); v != nil {
+		c.NodePool.EnforceRepeatableRead = v
+	}
+	if v := e.r.loadPriceMax(); v != nil {
+		c.GasEstimator.PriceMax = v
+	}
+	if v := e.r.loadFeeCapDefault(); v != nil {
+		c.GasEstimator.FeeCapDefault = v
+	}
+	if nodes := e.r.loadNodes(); nodes != nil {
+		c.Nodes = nodes
+	}
+	return &c
+}
+
+// ReloadChange is a setting changed by ChainScoped.Reload. The values of nodes only include the hosts of their
+// URLs, which may embed credentials otherwise.
+type ReloadChange struct {
+	Setting string `json:"setting"`
+	From    string `json:"from"`
+	To      string `json:"to"`
+}
+
+// Reload validates the settings along with the rest of the config of the chain and applies them, returning the
+// settings which changed. If the nodes changed, reloadNodes is called with them first, and nothing is applied if
+// it fails.
+func (c *ChainScoped) Reload(r *toml.Reloadable, reloadNodes func(toml.EVMNodes) error) ([]ReloadChange, error) {
+	if err := r.ValidateConfig(); err != nil {
+		return nil, err
+	}
+
+	c.reloadMu.Lock()
+	defer c.reloadMu.Unlock()
+	e := c.evmConfig
+	prev := e.effective()
+	next := e.effective()
+	if v := r.NodePool.EnforceRepeatableRead; v != nil {
+		next.NodePool.EnforceRepeatableRead = v
+	}
+	if v := r.GasEstimator.PriceMax; v != nil {
+		next.GasEstimator.PriceMax = v
+	}
+	if v := r.GasEstimator.FeeCapDefault; v != nil {
+		next.GasEstimator.FeeCapDefault = v
+	}
+	if len(r.Nodes) > 0 {
+		next.Nodes = r.Nodes
+	}
+	if err := commonconfig.Validate(next); err != nil {
+		return nil, err
+	}
+
+	var changes []ReloadChange
+	if *prev.NodePool.EnforceRepeatableRead != *next.NodePool.EnforceRepeatableRead {
+		changes = append(changes, ReloadChange{Setting: "NodePool.EnforceRepeatableRead",
+			From: strconv.FormatBool(*prev.NodePool.EnforceRepeatableRead), To: strconv.FormatBool(*next.NodePool.EnforceRepeatableRead)})
+	}
+	if prev.GasEstimator.PriceMax.Cmp(next.GasEstimator.PriceMax) != 0 {
+		changes = append(changes, ReloadChange{Setting: "GasEstimator.PriceMax",
+			From: prev.GasEstimator.PriceMax.String(), To: next.GasEstimator.PriceMax.String()})
+	}
+	if prev.GasEstimator.FeeCapDefault.Cmp(next.GasEstimator.FeeCapDefault) != 0 {
+		changes = append(changes, ReloadChange{Setting: "GasEstimator.FeeCapDefault",
+			From: prev.GasEstimator.FeeCapDefault.String(), To: next.GasEstimator.FeeCapDefault.String()})
+	}
+	nodeChanges := diffNodes(prev.Nodes, next.Nodes)
+	if len(nodeChanges) > 0 {
+		if err := reloadNodes(next.Nodes); err != nil {
+			return nil, fmt.Errorf("failed to reload nodes: %w", err)
+		}
+		changes = append(changes, nodeChanges...)
+	}
+
+	e.r.enforceRepeatableRead.Store(next.NodePool.EnforceRepeatableRead)
+	e.r.priceMax.Store(next.GasEstimator.PriceMax)
+	e.r.feeCapDefault.Store(next.GasEstimator.FeeCapDefault)
+	e.r.nodes.Store(&next.Nodes)
+	return changes, nil
+}
+
+func diffNodes(prev, next toml.EVMNodes) (changes []ReloadChange) {
+	byName := make(map[string]*toml.Node, len(prev))
+	for _, n := range prev {
+		byName[*n.Name] = n
+	}
+	for _, n := range next {
+		p, ok := byName[*n.Name]
+		delete(byName, *n.Name)
+		if ok && p.Equal(n) {
+			continue
+		}
+		change := ReloadChange{Setting: "Nodes." + *n.Name, To: nodeHosts(n)}
+		if ok {
+			change.From = nodeHosts(p)
+		}
+		changes = append(changes, change)
+	}
+	for _, n := range prev {
+		if _, ok := byName[*n.Name]; ok {
+			changes = append(changes, ReloadChange{Setting: "Nodes." + *n.Name, From: nodeHosts(n)})
+		}
+	}
+	return
+}
+
+// nodeHosts describes the node by the hosts of its URLs.
+func nodeHosts(n *toml.Node) string {
+	var parts []string
+	if n.WSURL != nil && !n.WSURL.IsZero() {
+		parts = append(parts, n.WSURL.Scheme+"://"+n.WSURL.Host)
+	}
+	if n.HTTPURL != nil {
+		parts = append(parts, n.HTTPURL.Scheme+"://"+n.HTTPURL.Host)
+	}
+	if n.SendOnly != nil && *n.SendOnly {
+		parts = append(parts, "sendOnly")
+	}
+	return strings.Join(parts, " ")
+}
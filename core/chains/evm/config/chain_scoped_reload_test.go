@@ -0,0 +1,146 @@
+package config_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	commonconfig "github.com/smartcontractkit/chainlink-common/pkg/config"
+
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/assets"
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/config"
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/config/toml"
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/testutils"
+)
+
+func newReloadTestConfig(t *testing.T) *config.ChainScoped {
+	name := "primary"
+	order := int32(100)
+	return testutils.NewTestChainScopedConfig(t, func(c *toml.EVMConfig) {
+		c.Nodes = toml.EVMNodes{{
+			Name:    &name,
+			WSURL:   commonconfig.MustParseURL("wss://primary.test/ws"),
+			HTTPURL: commonconfig.MustParseURL("https://primary.test/key"),
+			Order:   &order,
+		}}
+	}).(*config.ChainScoped)
+}
+
+func TestChainScoped_Reload(t *testing.T) {
+	noNodes := func(toml.EVMNodes) error {
+		t.Fatal("unexpected nodes reload")
+		return nil
+	}
+
+	t.Run("settings", func(t *testing.T) {
+		cfg := newReloadTestConfig(t)
+		// components keep the config they were created with
+		ge := cfg.EVM().GasEstimator()
+		np := cfg.EVM().NodePool()
+		require.True(t, np.EnforceRepeatableRead())
+
+		r, err := toml.ParseReloadable(`
+[NodePool]
+EnforceRepeatableRead = false
+
+[GasEstimator]
+PriceMax = '500 gwei'
+FeeCapDefault = '200 gwei'
+`)
+		require.NoError(t, err)
+		changes, err := cfg.Reload(r, noNodes)
+		require.NoError(t, err)
+		require.Len(t, changes, 3)
+		assert.Equal(t, config.ReloadChange{Setting: "NodePool.EnforceRepeatableRead", From: "true", To: "false"}, changes[0])
+		assert.Equal(t, "GasEstimator.PriceMax", changes[1].Setting)
+		assert.Equal(t, config.ReloadChange{Setting: "GasEstimator.FeeCapDefault", From: "100 gwei", To: "200 gwei"}, changes[2])
+
+		assert.False(t, np.EnforceRepeatableRead())
+		assert.False(t, cfg.EVM().NodePool().EnforceRepeatableRead())
+		assert.Equal(t, assets.GWei(500), ge.PriceMax())
+		assert.Equal(t, assets.GWei(200), ge.FeeCapDefault())
+		assert.Equal(t, assets.GWei(500), cfg.EVM().GasEstimator().PriceMax())
+
+		s, err := cfg.EVM().TOMLString()
+		require.NoError(t, err)
+		assert.Contains(t, s, "FeeCapDefault = '200 gwei'")
+
+		changes, err = cfg.Reload(r, noNodes)
+		require.NoError(t, err)
+		assert.Empty(t, changes)
+	})
+
+	t.Run("invalid settings", func(t *testing.T) {
+		cfg := newReloadTestConfig(t)
+		_, err := toml.ParseReloadable(`
+[GasEstimator]
+PriceDefault = '1 gwei'
+`)
+		require.Error(t, err)
+
+		r, err := toml.ParseReloadable(`
+[GasEstimator]
+PriceMax = '50 gwei'
+`)
+		require.NoError(t, err)
+		_, err = cfg.Reload(r, noNodes)
+		require.ErrorContains(t, err, "FeeCapDefault")
+		assert.Equal(t, assets.GWei(100), cfg.EVM().GasEstimator().FeeCapDefault())
+	})
+
+	t.Run("nodes", func(t *testing.T) {
+		cfg := newReloadTestConfig(t)
+		r, err := toml.ParseReloadable(`
+[[Nodes]]
+Name = 'primary'
+WSURL = 'wss://primary.test/ws'
+HTTPURL = 'https://primary.test/key'
+
+[[Nodes]]
+Name = 'backup'
+HTTPURL = 'https://backup.test/secret'
+SendOnly = true
+`)
+		require.NoError(t, err)
+
+		_, err = cfg.Reload(r, func(toml.EVMNodes) error { return errors.New("failed to dial") })
+		require.ErrorContains(t, err, "failed to dial")
+		require.Len(t, cfg.Nodes(), 1)
+
+		var reloaded toml.EVMNodes
+		changes, err := cfg.Reload(r, func(nodes toml.EVMNodes) error {
+			reloaded = nodes
+			return nil
+		})
+		require.NoError(t, err)
+		require.Len(t, reloaded, 2)
+		assert.Equal(t, reloaded, cfg.Nodes())
+		assert.Equal(t, []config.ReloadChange{{Setting: "Nodes.backup", To: "https://backup.test sendOnly"}}, changes)
+
+		r, err = toml.ParseReloadable(`
+[[Nodes]]
+Name = 'backup'
+WSURL = 'wss://backup.test/ws'
+HTTPURL = 'https://backup.test/secret'
+`)
+		require.NoError(t, err)
+		changes, err = cfg.Reload(r, func(toml.EVMNodes) error { return nil })
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []config.ReloadChange{
+			{Setting: "Nodes.backup", From: "https://backup.test sendOnly", To: "wss://backup.test https://backup.test"},
+			{Setting: "Nodes.primary", From: "wss://primary.test https://primary.test"},
+		}, changes)
+
+		r, err = toml.ParseReloadable(`
+[[Nodes]]
+Name = 'backup'
+HTTPURL = 'https://backup.test/secret'
+SendOnly = true
+`)
+		require.NoError(t, err)
+		_, err = cfg.Reload(r, noNodes)
+		require.ErrorContains(t, err, "must have at least one primary node")
+	})
+}
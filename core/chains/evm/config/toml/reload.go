@@ -0,0 +1,90 @@
+package toml
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"go.uber.org/multierr"
+
+	commonconfig "github.com/smartcontractkit/chainlink-common/pkg/config"
+
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/assets"
+)
+
+// Reloadable is the subset of the config of an EVM chain which can be reloaded at runtime, without restarting
+// the node. Unset settings are left as they are, while Nodes, if set, replaces all the nodes of the chain.
+type Reloadable struct {
+	NodePool     ReloadableNodePool     `toml:",omitempty"`
+	GasEstimator ReloadableGasEstimator `toml:",omitempty"`
+	Nodes        EVMNodes               `toml:",omitempty"`
+}
+
+type ReloadableNodePool struct {
+	EnforceRepeatableRead *bool
+}
+
+type ReloadableGasEstimator struct {
+	PriceMax      *assets.Wei
+	FeeCapDefault *assets.Wei
+}
+
+// ParseReloadable parses the TOML of the settings to reload, which must not contain settings which can not be
+// reloaded.
+func ParseReloadable(s string) (*Reloadable, error) {
+	var r Reloadable
+	if err := commonconfig.DecodeTOML(strings.NewReader(s), &r); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// IsEmpty returns true if no setting is set.
+func (r *Reloadable) IsEmpty() bool {
+	return r.NodePool.EnforceRepeatableRead == nil && r.GasEstimator.PriceMax == nil &&
+		r.GasEstimator.FeeCapDefault == nil && len(r.Nodes) == 0
+}
+
+// ValidateConfig validates the nodes, which must have unique names and URLs. The other settings are validated
+// with the rest of the config of the chain when they are reloaded.
+func (r *Reloadable) ValidateConfig() (err error) {
+	names := commonconfig.UniqueStrings{}
+	wsURLs := commonconfig.UniqueStrings{}
+	httpURLs := commonconfig.UniqueStrings{}
+	for i, n := range r.Nodes {
+		if nerr := n.ValidateConfig(); nerr != nil {
+			err = multierr.Append(err, commonconfig.NamedMultiErrorList(nerr, fmt.Sprintf("Nodes.%d", i)))
+			continue
+		}
+		if names.IsDupe(n.Name) {
+			err = multierr.Append(err, commonconfig.NewErrDuplicate(fmt.Sprintf("Nodes.%d.Name", i), *n.Name))
+		}
+		if u := (*url.URL)(n.WSURL); wsURLs.IsDupeFmt(u) {
+			err = multierr.Append(err, commonconfig.NewErrDuplicate(fmt.Sprintf("Nodes.%d.WSURL", i), u.String()))
+		}
+		if u := (*url.URL)(n.HTTPURL); httpURLs.IsDupeFmt(u) {
+			err = multierr.Append(err, commonconfig.NewErrDuplicate(fmt.Sprintf("Nodes.%d.HTTPURL", i), u.String()))
+		}
+	}
+	return
+}
+
+// Equal returns true if the nodes have the same settings.
+func (n *Node) Equal(o *Node) bool {
+	return equal(n.Name, o.Name) && urlString(n.WSURL) == urlString(o.WSURL) &&
+		urlString(n.HTTPURL) == urlString(o.HTTPURL) && equal(n.SendOnly, o.SendOnly) && equal(n.Order, o.Order)
+}
+
+func equal[T comparable](a, b *T) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func urlString(u *commonconfig.URL) string {
+	if u == nil {
+		return ""
+	}
+	return u.String()
+}
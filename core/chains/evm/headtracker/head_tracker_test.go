@@ -867,6 +867,7 @@ func testHeadTrackerBackfill(t *testing.T, newORM func(t *testing.T) headtracker
 		FinalizedBlockOffset    uint32
 		FinalityDepth           uint32
 		MaxAllowedFinalityDepth uint32
+		HistoryDepth            uint32
 	}
 	newHeadTrackerUniverse := func(t *testing.T, opts opts) *headTrackerUniverse {
 		evmcfg := testutils.NewTestChainScopedConfig(t, func(c *toml.EVMConfig) {
@@ -877,6 +878,9 @@ func testHeadTrackerBackfill(t *testing.T, newORM func(t *testing.T) headtracker
 			if opts.MaxAllowedFinalityDepth > 0 {
 				c.HeadTracker.MaxAllowedFinalityDepth = ptr(opts.MaxAllowedFinalityDepth)
 			}
+			if opts.HistoryDepth > 0 {
+				c.HeadTracker.HistoryDepth = ptr(opts.HistoryDepth)
+			}
 		})
 
 		ethClient := testutils.NewEthClientMock(t)
@@ -943,6 +947,72 @@ func testHeadTrackerBackfill(t *testing.T, newORM func(t *testing.T) headtracker
 		assertFinalized(false, "expected heads to remain unfinalized", h15, &head10)
 	})
 
+	t.Run("Returns error if previously finalized block was reorged", func(t *testing.T) {
+		h13Reorged := testutils.Head(13)
+		h13Reorged.ParentHash = h12.Hash
+		h14Reorged := testutils.Head(14)
+		h14Reorged.ParentHash = h13Reorged.Hash
+		h15Reorged := testutils.Head(15)
+		h15Reorged.ParentHash = h14Reorged.Hash
+		htu := newHeadTrackerUniverse(t, opts{Heads: append(heads, h13Reorged, h14Reorged, h15Reorged), FinalityTagEnabled: true})
+
+		htu.ethClient.On("LatestFinalizedBlock", mock.Anything).Return(h13, nil).Once()
+		require.NoError(t, htu.headTracker.Backfill(ctx, h15))
+
+		htu.ethClient.On("LatestFinalizedBlock", mock.Anything).Return(h14Reorged, nil).Once()
+		err := htu.headTracker.Backfill(ctx, h15Reorged)
+		var violation commonht.FinalityViolationError[common.Hash]
+		require.ErrorAs(t, err, &violation)
+		assert.Equal(t, int64(13), violation.BlockNumber)
+		assert.Equal(t, h13.Hash, violation.Finalized)
+		assert.Equal(t, h13Reorged.Hash, violation.Canonical)
+
+		// the violation is reported only once
+		htu.ethClient.On("LatestFinalizedBlock", mock.Anything).Return(h14Reorged, nil).Once()
+		require.NoError(t, htu.headTracker.Backfill(ctx, h15Reorged))
+	})
+
+	t.Run("Fetches previously finalized block pruned from history to check finality", func(t *testing.T) {
+		newUniverse := func(t *testing.T) *headTrackerUniverse {
+			htu := newHeadTrackerUniverse(t, opts{Heads: heads, FinalityTagEnabled: true, HistoryDepth: 1})
+			htu.ethClient.On("LatestFinalizedBlock", mock.Anything).Return(h11, nil).Once()
+			require.NoError(t, htu.headTracker.Backfill(ctx, h15))
+			// blocks older than 13 are pruned once 14 is finalized, including the previously finalized 11
+			htu.ethClient.On("LatestFinalizedBlock", mock.Anything).Return(h14, nil).Once()
+			return htu
+		}
+
+		t.Run("no violation", func(t *testing.T) {
+			htu := newUniverse(t)
+			htu.ethClient.On("HeadByNumber", mock.Anything, big.NewInt(11)).Return(h11, nil).Once()
+			require.NoError(t, htu.headTracker.Backfill(ctx, h15))
+			_, err := htu.headSaver.Chain(h15.Hash).HeadAtHeight(11)
+			require.Error(t, err, "expected block 11 to be pruned")
+		})
+
+		t.Run("violation", func(t *testing.T) {
+			htu := newUniverse(t)
+			h11Reorged := testutils.Head(11)
+			htu.ethClient.On("HeadByNumber", mock.Anything, big.NewInt(11)).Return(h11Reorged, nil).Once()
+			err := htu.headTracker.Backfill(ctx, h15)
+			var violation commonht.FinalityViolationError[common.Hash]
+			require.ErrorAs(t, err, &violation)
+			assert.Equal(t, int64(11), violation.BlockNumber)
+			assert.Equal(t, h11.Hash, violation.Finalized)
+			assert.Equal(t, h11Reorged.Hash, violation.Canonical)
+		})
+
+		t.Run("fetch fails", func(t *testing.T) {
+			htu := newUniverse(t)
+			htu.ethClient.On("HeadByNumber", mock.Anything, big.NewInt(11)).Return(nil, errors.New("rpc down")).Once()
+			require.ErrorContains(t, htu.headTracker.Backfill(ctx, h15), "failed to check finality of block 11")
+			// the block is checked again by the next backfill
+			htu.ethClient.On("LatestFinalizedBlock", mock.Anything).Return(h14, nil).Once()
+			htu.ethClient.On("HeadByNumber", mock.Anything, big.NewInt(11)).Return(h11, nil).Once()
+			require.NoError(t, htu.headTracker.Backfill(ctx, h15))
+		})
+	})
+
 	t.Run("fetches a missing head", func(t *testing.T) {
 		htu := newHeadTrackerUniverse(t, opts{Heads: heads, FinalityTagEnabled: true})
 		htu.ethClient.On("LatestFinalizedBlock", mock.Anything).Return(h9, nil).Once()
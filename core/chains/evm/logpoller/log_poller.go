@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"math/big"
 	"math/rand"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
@@ -40,6 +41,7 @@ type LogPoller interface {
 	Healthy() error
 	Replay(ctx context.Context, fromBlock int64) error
 	ReplayAsync(fromBlock int64)
+	ReplayRange(ctx context.Context, fromBlock, toBlock int64, addresses []common.Address, eventSigs []common.Hash) error
 	RegisterFilter(ctx context.Context, filter Filter) error
 	UnregisterFilter(ctx context.Context, name string) error
 	HasFilter(name string) bool
@@ -121,6 +123,7 @@ type logPoller struct {
 
 	filterMu        sync.RWMutex
 	filters         map[string]Filter
+	filterJobIDs    map[string][]int32 // jobs which registered the filters, not persisted, see WithJobID
 	filterDirty     bool
 	cachedAddresses []common.Address
 	cachedEventSigs []common.Hash
@@ -179,6 +182,7 @@ func NewLogPoller(orm ORM, ec Client, lggr logger.Logger, headTracker HeadTracke
 		logPrunePageSize:         opts.LogPrunePageSize,
 		clientErrors:             opts.ClientErrors,
 		filters:                  make(map[string]Filter),
+		filterJobIDs:             make(map[string][]int32),
 		filterDirty:              true, // Always build Filter on first call to cache an empty filter if nothing registered yet.
 	}
 }
@@ -193,6 +197,22 @@ type Filter struct {
 	Retention    time.Duration      // maximum amount of time to retain logs
 	MaxLogsKept  uint64             // maximum number of logs to retain ( 0 = unlimited )
 	LogsPerBlock uint64             // rate limit ( maximum # of logs per block, 0 = unlimited )
+	JobIDs       []int32            // jobs which registered the filter, set by GetFilters only, see WithJobID
+}
+
+type jobIDCtxKey struct{}
+
+// WithJobID returns a context attributing the filters registered with it to the job, e.g. the context the
+// services of the job are created and started with. The attribution is kept in memory: filters loaded from the
+// database are attributed again once re-registered by their job.
+func WithJobID(ctx context.Context, jobID int32) context.Context {
+	return context.WithValue(ctx, jobIDCtxKey{}, jobID)
+}
+
+// JobIDFromContext returns the job the filters registered with the context are attributed to, see WithJobID.
+func JobIDFromContext(ctx context.Context) (int32, bool) {
+	jobID, ok := ctx.Value(jobIDCtxKey{}).(int32)
+	return jobID, ok
 }
 
 // FilterName is a suggested convenience function for clients to construct unique filter names
@@ -286,6 +306,7 @@ func (lp *logPoller) RegisterFilter(ctx context.Context, filter Filter) error {
 		if existingFilter.Contains(&filter) {
 			// Nothing new in this Filter
 			lp.lggr.Warnw("Filter already present, no-op", "name", filter.Name, "filter", filter)
+			lp.addFilterJobID(ctx, filter.Name)
 			return nil
 		}
 		lp.lggr.Warnw("Updating existing filter", "name", filter.Name, "filter", filter)
@@ -294,7 +315,9 @@ func (lp *logPoller) RegisterFilter(ctx context.Context, filter Filter) error {
 	if err := lp.orm.InsertFilter(ctx, filter); err != nil {
 		return pkgerrors.Wrap(err, "error inserting filter")
 	}
+	filter.JobIDs = nil
 	lp.filters[filter.Name] = filter
+	lp.addFilterJobID(ctx, filter.Name)
 	lp.filterDirty = true
 	if filter.MaxLogsKept > 0 {
 		lp.countBasedLogPruningActive.Store(true)
@@ -302,6 +325,13 @@ func (lp *logPoller) RegisterFilter(ctx context.Context, filter Filter) error {
 	return nil
 }
 
+// addFilterJobID attributes the filter to the job of the context, if any. filterMu must be held.
+func (lp *logPoller) addFilterJobID(ctx context.Context, name string) {
+	if jobID, ok := JobIDFromContext(ctx); ok && !slices.Contains(lp.filterJobIDs[name], jobID) {
+		lp.filterJobIDs[name] = append(lp.filterJobIDs[name], jobID)
+	}
+}
+
 // UnregisterFilter will remove the filter with the given name.
 // If the name does not exist, it will log an error but not return an error.
 // Warnings/debug information is keyed by filter name.
@@ -319,6 +349,7 @@ func (lp *logPoller) UnregisterFilter(ctx context.Context, name string) error {
 		return pkgerrors.Wrap(err, "error deleting filter")
 	}
 	delete(lp.filters, name)
+	delete(lp.filterJobIDs, name)
 	lp.filterDirty = true
 	return nil
 }
@@ -349,6 +380,7 @@ func (lp *logPoller) GetFilters() map[string]Filter {
 			Retention:    v.Retention,
 			MaxLogsKept:  v.MaxLogsKept,
 			LogsPerBlock: v.LogsPerBlock,
+			JobIDs:       slices.Clone(lp.filterJobIDs[k]),
 		}
 		copy(deepCopyFilter.Addresses, v.Addresses)
 		copy(deepCopyFilter.EventSigs, v.EventSigs)
@@ -467,6 +499,44 @@ func (lp *logPoller) Replay(ctx context.Context, fromBlock int64) (err error) {
 	}
 }
 
+// ReplayRange backfills logs emitted by the given addresses within [fromBlock, toBlock], optionally narrowed down to
+// the given event signatures. Unlike Replay, only the requested addresses and topics are queried, so targeted
+// backfills (e.g. for a single job) don't force re-scanning logs of unrelated filters.
+// Only finalized blocks are replayed; the range is capped at the latest finalized block saved by LogPoller, since
+// anything past it is picked up by the main polling loop.
+func (lp *logPoller) ReplayRange(ctx context.Context, fromBlock, toBlock int64, addresses []common.Address, eventSigs []common.Hash) (err error) {
+	defer func() {
+		if errors.Is(err, context.Canceled) {
+			err = ErrReplayRequestAborted
+		}
+	}()
+
+	if len(addresses) == 0 {
+		return pkgerrors.New("at least one address is required for a targeted replay")
+	}
+	if fromBlock < 1 || fromBlock > toBlock {
+		return pkgerrors.Errorf("Invalid replay block range [%v, %v]", fromBlock, toBlock)
+	}
+
+	savedFinalizedBlockNumber, err := lp.savedFinalizedBlockNumber(ctx)
+	if err != nil {
+		return err
+	}
+	if fromBlock > savedFinalizedBlockNumber {
+		return pkgerrors.Errorf("Invalid replay block number %v, must not be past the latest finalized block %v", fromBlock, savedFinalizedBlockNumber)
+	}
+	toBlock = mathutil.Min(toBlock, savedFinalizedBlockNumber)
+
+	lp.lggr.Debugw("Replaying block range", "from", fromBlock, "to", toBlock, "addresses", addresses, "eventSigs", eventSigs)
+	topics := [][]common.Hash{eventSigs}
+	if len(eventSigs) == 0 {
+		topics = nil
+	}
+	return lp.backfillQuery(ctx, fromBlock, toBlock, func(from, to *big.Int) ethereum.FilterQuery {
+		return ethereum.FilterQuery{FromBlock: from, ToBlock: to, Topics: topics, Addresses: addresses}
+	})
+}
+
 // savedFinalizedBlockNumber returns the FinalizedBlockNumber saved with the last processed block in the db
 // (latestFinalizedBlock at the time the last processed block was saved)
 // If this is the first poll and no blocks are in the db, it returns 0
@@ -866,11 +936,18 @@ func (lp *logPoller) blocksFromLogs(ctx context.Context, logs []types.Log, endBl
 // Retries until ctx cancelled. Will return an error if cancelled
 // or if there is an error backfilling.
 func (lp *logPoller) backfill(ctx context.Context, start, end int64) error {
+	return lp.backfillQuery(ctx, start, end, func(from, to *big.Int) ethereum.FilterQuery {
+		return lp.Filter(from, to, nil)
+	})
+}
+
+// backfillQuery backfills logs in [start, end] in batches, using filterQuery to build the query for each batch.
+func (lp *logPoller) backfillQuery(ctx context.Context, start, end int64, filterQuery func(from, to *big.Int) ethereum.FilterQuery) error {
 	batchSize := lp.backfillBatchSize
 	for from := start; from <= end; from += batchSize {
 		to := mathutil.Min(from+batchSize-1, end)
 
-		gethLogs, err := lp.ec.FilterLogs(ctx, lp.Filter(big.NewInt(from), big.NewInt(to), nil))
+		gethLogs, err := lp.ec.FilterLogs(ctx, filterQuery(big.NewInt(from), big.NewInt(to)))
 		if err != nil {
 			if !client.IsTooManyResults(err, lp.clientErrors) {
 				lp.lggr.Errorw("Unable to query for logs", "err", err, "from", from, "to", to)
@@ -11,6 +11,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -149,6 +150,51 @@ func TestLogPoller_RegisterFilter(t *testing.T) {
 	assert.Len(t, lp.Filter(nil, nil, nil).Topics[0], 0)
 }
 
+func TestLogPoller_RegisterFilter_JobIDs(t *testing.T) {
+	t.Parallel()
+	a1 := common.HexToAddress("0x2ab9a2dc53736b361b72d900cdf9f78f9406fbbb")
+
+	lggr := logger.Test(t)
+	chainID := testutils.NewRandomEVMChainID()
+	db := pgtest.NewSqlxDB(t)
+	ctx := testutils.Context(t)
+
+	orm := NewORM(chainID, db, lggr)
+	lp := NewLogPoller(orm, nil, lggr, nil, Opts{
+		PollPeriod:               time.Hour,
+		BackfillBatchSize:        1,
+		RpcBatchSize:             2,
+		KeepFinalizedBlocksDepth: 1000,
+	})
+
+	filter := Filter{Name: "Emitter Log 1", EventSigs: []common.Hash{EmitterABI.Events["Log1"].ID}, Addresses: []common.Address{a1}}
+
+	// A filter registered without a job isn't attributed to any.
+	require.NoError(t, lp.RegisterFilter(ctx, filter))
+	assert.Empty(t, lp.GetFilters()[filter.Name].JobIDs)
+
+	// Registering the same filter for jobs attributes it to each of them once.
+	require.NoError(t, lp.RegisterFilter(WithJobID(ctx, 1), filter))
+	require.NoError(t, lp.RegisterFilter(WithJobID(ctx, 2), filter))
+	require.NoError(t, lp.RegisterFilter(WithJobID(ctx, 1), filter))
+	require.NoError(t, lp.RegisterFilter(ctx, filter))
+	assert.Equal(t, []int32{1, 2}, lp.GetFilters()[filter.Name].JobIDs)
+
+	// JobIDs of the registered filter are ignored, only the context attributes it.
+	updated := filter
+	updated.Retention = time.Hour
+	updated.JobIDs = []int32{3}
+	require.NoError(t, lp.RegisterFilter(ctx, updated))
+	assert.Equal(t, []int32{1, 2}, lp.GetFilters()[filter.Name].JobIDs)
+	assert.Nil(t, lp.filters[filter.Name].JobIDs)
+	validateFiltersTable(t, lp, orm)
+
+	// Unregistering the filter drops its jobs.
+	require.NoError(t, lp.UnregisterFilter(ctx, filter.Name))
+	require.NoError(t, lp.RegisterFilter(ctx, filter))
+	assert.Empty(t, lp.GetFilters()[filter.Name].JobIDs)
+}
+
 func TestLogPoller_ConvertLogs(t *testing.T) {
 	t.Parallel()
 	lggr := logger.Test(t)
@@ -543,6 +589,62 @@ func TestLogPoller_Replay(t *testing.T) {
 	})
 }
 
+func TestLogPoller_ReplayRange(t *testing.T) {
+	t.Parallel()
+	addr := common.HexToAddress("0x2ab9a2dc53736b361b72d900cdf9f78f9406fbbc")
+	events := []common.Hash{EmitterABI.Events["Log1"].ID}
+
+	lggr := logger.Test(t)
+	chainID := testutils.FixtureChainID
+	db := pgtest.NewSqlxDB(t)
+	orm := NewORM(chainID, db, lggr)
+
+	head := &evmtypes.Head{Number: 10}
+	ec := evmclimocks.NewClient(t)
+	ec.On("HeadByNumber", mock.Anything, mock.Anything).Return(head, nil)
+	ec.On("ConfiguredChainID").Return(chainID, nil)
+	ec.On("FilterLogs", mock.Anything, mock.MatchedBy(func(q ethereum.FilterQuery) bool {
+		return q.BlockHash != nil
+	})).Return(nil, nil).Once()
+
+	lpOpts := Opts{
+		PollPeriod:               time.Second,
+		FinalityDepth:            3,
+		BackfillBatchSize:        3,
+		RpcBatchSize:             3,
+		KeepFinalizedBlocksDepth: 20,
+	}
+	headTracker := htMocks.NewHeadTracker[*evmtypes.Head, common.Hash](t)
+	headTracker.On("LatestAndFinalizedBlock", mock.Anything).Return(head, &evmtypes.Head{Number: head.Number - lpOpts.FinalityDepth}, nil)
+	lp := NewLogPoller(orm, ec, lggr, headTracker, lpOpts)
+
+	ctx := testutils.Context(t)
+	lp.PollAndSaveLogs(ctx, 10)
+	latest, err := lp.LatestBlock(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(7), latest.FinalizedBlockNumber)
+
+	t.Run("invalid ranges", func(t *testing.T) {
+		require.ErrorContains(t, lp.ReplayRange(ctx, 0, 5, []common.Address{addr}, events), "Invalid replay block range")
+		require.ErrorContains(t, lp.ReplayRange(ctx, -1, 5, []common.Address{addr}, events), "Invalid replay block range")
+		require.ErrorContains(t, lp.ReplayRange(ctx, 5, 4, []common.Address{addr}, events), "Invalid replay block range")
+		require.ErrorContains(t, lp.ReplayRange(ctx, 2, 5, nil, events), "at least one address is required")
+		require.ErrorContains(t, lp.ReplayRange(ctx, 8, 9, []common.Address{addr}, events), "must not be past the latest finalized block")
+	})
+
+	t.Run("queries the addresses and events up to the finalized block", func(t *testing.T) {
+		for _, r := range [][2]int64{{2, 4}, {5, 7}} {
+			ec.On("FilterLogs", mock.Anything, ethereum.FilterQuery{
+				FromBlock: big.NewInt(r[0]),
+				ToBlock:   big.NewInt(r[1]),
+				Addresses: []common.Address{addr},
+				Topics:    [][]common.Hash{events},
+			}).Return(nil, nil).Once()
+		}
+		require.NoError(t, lp.ReplayRange(ctx, 2, 20, []common.Address{addr}, events))
+	})
+}
+
 func (lp *logPoller) reset() {
 	lp.StateMachine = services.StateMachine{}
 	lp.stopCh = make(chan struct{})
@@ -1763,6 +1763,56 @@ func (_c *LogPoller_ReplayAsync_Call) RunAndReturn(run func(int64)) *LogPoller_R
 	return _c
 }
 
+// ReplayRange provides a mock function with given fields: ctx, fromBlock, toBlock, addresses, eventSigs
+func (_m *LogPoller) ReplayRange(ctx context.Context, fromBlock int64, toBlock int64, addresses []common.Address, eventSigs []common.Hash) error {
+	ret := _m.Called(ctx, fromBlock, toBlock, addresses, eventSigs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplayRange")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, []common.Address, []common.Hash) error); ok {
+		r0 = rf(ctx, fromBlock, toBlock, addresses, eventSigs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// LogPoller_ReplayRange_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReplayRange'
+type LogPoller_ReplayRange_Call struct {
+	*mock.Call
+}
+
+// ReplayRange is a helper method to define mock.On call
+//   - ctx context.Context
+//   - fromBlock int64
+//   - toBlock int64
+//   - addresses []common.Address
+//   - eventSigs []common.Hash
+func (_e *LogPoller_Expecter) ReplayRange(ctx interface{}, fromBlock interface{}, toBlock interface{}, addresses interface{}, eventSigs interface{}) *LogPoller_ReplayRange_Call {
+	return &LogPoller_ReplayRange_Call{Call: _e.mock.On("ReplayRange", ctx, fromBlock, toBlock, addresses, eventSigs)}
+}
+
+func (_c *LogPoller_ReplayRange_Call) Run(run func(ctx context.Context, fromBlock int64, toBlock int64, addresses []common.Address, eventSigs []common.Hash)) *LogPoller_ReplayRange_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64), args[3].([]common.Address), args[4].([]common.Hash))
+	})
+	return _c
+}
+
+func (_c *LogPoller_ReplayRange_Call) Return(_a0 error) *LogPoller_ReplayRange_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *LogPoller_ReplayRange_Call) RunAndReturn(run func(context.Context, int64, int64, []common.Address, []common.Hash) error) *LogPoller_ReplayRange_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Start provides a mock function with given fields: _a0
 func (_m *LogPoller) Start(_a0 context.Context) error {
 	ret := _m.Called(_a0)
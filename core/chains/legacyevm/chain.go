@@ -58,6 +58,14 @@ var (
 	emptyString string
 )
 
+// ConfigReloader is implemented by chains whose config can be partially reloaded at runtime, see toml.Reloadable.
+type ConfigReloader interface {
+	// ReloadConfig reloads the settings, returning the ones which changed.
+	ReloadConfig(ctx context.Context, r *toml.Reloadable) ([]evmconfig.ReloadChange, error)
+}
+
+var _ ConfigReloader = &chain{}
+
 // LegacyChains implements [LegacyChainContainer]
 type LegacyChains struct {
 	*chains.ChainsKV[Chain]
@@ -460,6 +468,23 @@ func (c *chain) ListNodeStatuses(ctx context.Context, pageSize int32, pageToken
 	return common.ListNodeStatuses(int(pageSize), pageToken, c.listNodeStatuses)
 }
 
+func (c *chain) ReloadConfig(ctx context.Context, r *toml.Reloadable) ([]evmconfig.ReloadChange, error) {
+	changes, err := c.cfg.Reload(r, func(nodes toml.EVMNodes) error {
+		reloader, ok := c.client.(evmclient.NodeReloader)
+		if !ok {
+			return fmt.Errorf("client %T does not support reloading nodes", c.client)
+		}
+		return reloader.ReloadNodes(ctx, nodes)
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, change := range changes {
+		c.logger.Infow("Reloaded chain config", "setting", change.Setting, "from", change.From, "to", change.To)
+	}
+	return changes, nil
+}
+
 func (c *chain) ID() *big.Int                             { return c.id }
 func (c *chain) Client() evmclient.Client                 { return c.client }
 func (c *chain) Config() evmconfig.ChainScopedConfig      { return c.cfg }
@@ -2,14 +2,20 @@ package cmd
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"net/url"
+	"os"
 	"strconv"
+	"strings"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/manyminds/api2go/jsonapi"
 	"github.com/pkg/errors"
 	"github.com/urfave/cli"
 	"go.uber.org/multierr"
 
+	"github.com/smartcontractkit/chainlink/v2/core/utils"
 	"github.com/smartcontractkit/chainlink/v2/core/web"
 )
 
@@ -36,6 +42,37 @@ func initBlocksSubCmds(s *Shell) []cli.Command {
 				},
 			},
 		},
+		{
+			Name:   "replay-range",
+			Usage:  "Replays LogPoller logs in the given block range, only for the given addresses and event signatures",
+			Action: s.ReplayRange,
+			Flags: []cli.Flag{
+				cli.Int64Flag{
+					Name:     "from-block",
+					Usage:    "Block number to replay from",
+					Required: true,
+				},
+				cli.Int64Flag{
+					Name:     "to-block",
+					Usage:    "Block number to replay to (inclusive)",
+					Required: true,
+				},
+				cli.StringSliceFlag{
+					Name:     "address",
+					Usage:    "Contract address to replay logs for, can be passed multiple times",
+					Required: true,
+				},
+				cli.StringSliceFlag{
+					Name:  "event-sig",
+					Usage: "Event signature (topic 0) to replay logs for, can be passed multiple times. Defaults to all events",
+				},
+				cli.Int64Flag{
+					Name:     "evm-chain-id",
+					Usage:    "Chain ID of the EVM-based blockchain",
+					Required: false,
+				},
+			},
+		},
 		{
 			Name:   "find-lca",
 			Usage:  "Find latest common block stored in DB and on chain",
@@ -48,6 +85,52 @@ func initBlocksSubCmds(s *Shell) []cli.Command {
 				},
 			},
 		},
+		{
+			Name:   "list-filters",
+			Usage:  "List LogPoller filters registered for a chain, along with the jobs watching their addresses",
+			Action: s.ListLogPollerFilters,
+			Flags: []cli.Flag{
+				cli.Int64Flag{
+					Name:     "evm-chain-id",
+					Usage:    "Chain ID of the EVM-based blockchain",
+					Required: true,
+				},
+			},
+		},
+		{
+			Name:   "export-filters",
+			Usage:  "Export LogPoller filters registered for a chain to a JSON file",
+			Action: s.ExportLogPollerFilters,
+			Flags: []cli.Flag{
+				cli.Int64Flag{
+					Name:     "evm-chain-id",
+					Usage:    "Chain ID of the EVM-based blockchain",
+					Required: true,
+				},
+				cli.StringFlag{
+					Name:     "output, o",
+					Usage:    "Path where the JSON output will be saved",
+					Required: true,
+				},
+			},
+		},
+		{
+			Name:   "import-filters",
+			Usage:  "Register LogPoller filters for a chain from a JSON file produced by export-filters",
+			Action: s.ImportLogPollerFilters,
+			Flags: []cli.Flag{
+				cli.Int64Flag{
+					Name:     "evm-chain-id",
+					Usage:    "Chain ID of the EVM-based blockchain",
+					Required: true,
+				},
+				cli.StringFlag{
+					Name:     "file, f",
+					Usage:    "Path to the JSON file with filters to import",
+					Required: true,
+				},
+			},
+		},
 	}
 }
 
@@ -90,6 +173,60 @@ func (s *Shell) ReplayFromBlock(c *cli.Context) (err error) {
 	return nil
 }
 
+// ReplayRange replays LogPoller logs in the given block range, restricted to the given addresses and event signatures
+func (s *Shell) ReplayRange(c *cli.Context) (err error) {
+	fromBlock := c.Int64("from-block")
+	toBlock := c.Int64("to-block")
+	if fromBlock <= 0 || toBlock < fromBlock {
+		return s.errorOut(errors.New("Must pass a positive '--from-block' not greater than '--to-block'"))
+	}
+
+	request := web.ReplayRangeRequest{
+		FromBlock: fromBlock,
+		ToBlock:   toBlock,
+	}
+	for _, addr := range c.StringSlice("address") {
+		if !common.IsHexAddress(addr) {
+			return s.errorOut(errors.Errorf("Invalid address %q", addr))
+		}
+		request.Addresses = append(request.Addresses, common.HexToAddress(addr))
+	}
+	if len(request.Addresses) == 0 {
+		return s.errorOut(errors.New("Must pass at least one '--address'"))
+	}
+	for _, sig := range c.StringSlice("event-sig") {
+		request.EventSigs = append(request.EventSigs, common.HexToHash(sig))
+	}
+
+	v := url.Values{}
+	if c.IsSet("evm-chain-id") {
+		v.Add("evmChainID", fmt.Sprintf("%d", c.Int64("evm-chain-id")))
+	}
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		return s.errorOut(err)
+	}
+
+	resp, err := s.HTTP.Post(s.ctx(), "/v2/replay_range?"+v.Encode(), bytes.NewReader(body))
+	if err != nil {
+		return s.errorOut(err)
+	}
+
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	_, err = s.parseResponse(resp)
+	if err != nil {
+		return s.errorOut(err)
+	}
+	fmt.Println("Replay completed")
+	return nil
+}
+
 // LCAPresenter implements TableRenderer for an LCAResponse.
 type LCAPresenter struct {
 	web.LCAResponse
@@ -133,3 +270,147 @@ func (s *Shell) FindLCA(c *cli.Context) (err error) {
 
 	return s.renderAPIResponse(resp, &LCAPresenter{}, "Last Common Ancestor")
 }
+
+// LogPollerFilterPresenter implements TableRenderer for a LogPollerFilterResource.
+type LogPollerFilterPresenter struct {
+	web.LogPollerFilterResource
+}
+
+// ToRow presents the LogPollerFilterResource as a slice of strings.
+func (p *LogPollerFilterPresenter) ToRow() []string {
+	addresses := make([]string, 0, len(p.Addresses))
+	for _, addr := range p.Addresses {
+		addresses = append(addresses, addr.String())
+	}
+	eventSigs := make([]string, 0, len(p.EventSigs))
+	for _, sig := range p.EventSigs {
+		eventSigs = append(eventSigs, sig.String())
+	}
+	jobIDs := make([]string, 0, len(p.JobIDs))
+	for _, id := range p.JobIDs {
+		jobIDs = append(jobIDs, strconv.FormatInt(int64(id), 10))
+	}
+	return []string{
+		p.Name,
+		strings.Join(addresses, "\n"),
+		strings.Join(eventSigs, "\n"),
+		p.Retention,
+		strconv.FormatUint(p.MaxLogsKept, 10),
+		strings.Join(jobIDs, ", "),
+	}
+}
+
+var logPollerFilterHeaders = []string{"Name", "Addresses", "Event Sigs", "Retention", "Max Logs Kept", "Job IDs"}
+
+// LogPollerFilterPresenters implements TableRenderer for a slice of LogPollerFilterPresenter.
+type LogPollerFilterPresenters []LogPollerFilterPresenter
+
+// RenderTable implements TableRenderer
+func (ps LogPollerFilterPresenters) RenderTable(rt RendererTable) error {
+	var rows [][]string
+	for _, p := range ps {
+		rows = append(rows, p.ToRow())
+	}
+	renderList(logPollerFilterHeaders, rows, rt.Writer)
+
+	return nil
+}
+
+// ListLogPollerFilters lists the LogPoller filters registered for a chain.
+func (s *Shell) ListLogPollerFilters(c *cli.Context) (err error) {
+	v := url.Values{}
+	v.Add("evmChainID", fmt.Sprintf("%d", c.Int64("evm-chain-id")))
+
+	resp, err := s.HTTP.Get(s.ctx(), "/v2/log_poller/filters?"+v.Encode())
+	if err != nil {
+		return s.errorOut(err)
+	}
+
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	return s.renderAPIResponse(resp, &LogPollerFilterPresenters{}, "LogPoller Filters")
+}
+
+// ExportLogPollerFilters writes the LogPoller filters registered for a chain to a JSON file, which can later be
+// re-imported with ImportLogPollerFilters.
+func (s *Shell) ExportLogPollerFilters(c *cli.Context) (err error) {
+	filepath := c.String("output")
+	if len(filepath) == 0 {
+		return s.errorOut(errors.New("Must specify --output/-o flag"))
+	}
+
+	v := url.Values{}
+	v.Add("evmChainID", fmt.Sprintf("%d", c.Int64("evm-chain-id")))
+
+	resp, err := s.HTTP.Get(s.ctx(), "/v2/log_poller/filters?"+v.Encode())
+	if err != nil {
+		return s.errorOut(errors.Wrap(err, "Could not make HTTP request"))
+	}
+
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	var filters []web.LogPollerFilterResource
+	var links jsonapi.Links
+	if err = s.deserializeAPIResponse(resp, &filters, &links); err != nil {
+		return s.errorOut(err)
+	}
+	for i := range filters {
+		// job IDs and chain ID are specific to the exporting node
+		filters[i].JobIDs = nil
+		filters[i].EVMChainID = nil
+	}
+
+	filtersJSON, err := json.MarshalIndent(filters, "", "  ")
+	if err != nil {
+		return s.errorOut(errors.Wrap(err, "Could not marshal filters"))
+	}
+
+	err = utils.WriteFileWithMaxPerms(filepath, filtersJSON, 0o600)
+	if err != nil {
+		return s.errorOut(errors.Wrapf(err, "Could not write %v", filepath))
+	}
+
+	_, err = os.Stderr.WriteString(fmt.Sprintf("Exported %d LogPoller filters to %s\n", len(filters), filepath))
+	if err != nil {
+		return s.errorOut(err)
+	}
+
+	return nil
+}
+
+// ImportLogPollerFilters registers the LogPoller filters from a JSON file produced by ExportLogPollerFilters.
+func (s *Shell) ImportLogPollerFilters(c *cli.Context) (err error) {
+	filepath := c.String("file")
+	if len(filepath) == 0 {
+		return s.errorOut(errors.New("Must specify --file/-f flag"))
+	}
+
+	filtersJSON, err := os.ReadFile(filepath)
+	if err != nil {
+		return s.errorOut(err)
+	}
+
+	v := url.Values{}
+	v.Add("evmChainID", fmt.Sprintf("%d", c.Int64("evm-chain-id")))
+
+	resp, err := s.HTTP.Post(s.ctx(), "/v2/log_poller/filters?"+v.Encode(), bytes.NewReader(filtersJSON))
+	if err != nil {
+		return s.errorOut(err)
+	}
+
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	return s.renderAPIResponse(resp, &LogPollerFilterPresenters{}, "Imported LogPoller Filters")
+}
@@ -42,6 +42,42 @@ func Test_ReplayFromBlock(t *testing.T) {
 	require.NoError(t, client.ReplayFromBlock(c))
 }
 
+func Test_ReplayRange(t *testing.T) {
+	t.Parallel()
+
+	app := startNewApplicationV2(t, func(c *chainlink.Config, s *chainlink.Secrets) {
+		c.EVM[0].ChainID = (*ubig.Big)(big.NewInt(5))
+		c.EVM[0].Enabled = ptr(true)
+	})
+
+	client, _ := app.NewShellAndRenderer()
+
+	set := flag.NewFlagSet("test", 0)
+	flagSetApplyFromAction(client.ReplayRange, set, "")
+
+	// Incorrect block range
+	require.NoError(t, set.Set("from-block", "10"))
+	require.NoError(t, set.Set("to-block", "5"))
+	c := cli.NewContext(nil, set, nil)
+	require.ErrorContains(t, client.ReplayRange(c), "Must pass a positive '--from-block'")
+
+	// Missing address
+	require.NoError(t, set.Set("to-block", "20"))
+	c = cli.NewContext(nil, set, nil)
+	require.ErrorContains(t, client.ReplayRange(c), "Must pass at least one '--address'")
+
+	// Incorrect chain ID
+	require.NoError(t, set.Set("address", "0x0000000000000000000000000000000000000001"))
+	require.NoError(t, set.Set("evm-chain-id", "1"))
+	c = cli.NewContext(nil, set, nil)
+	require.ErrorContains(t, client.ReplayRange(c), "does not match any local chains")
+
+	// Correct chain ID
+	require.NoError(t, set.Set("evm-chain-id", "5"))
+	c = cli.NewContext(nil, set, nil)
+	require.ErrorContains(t, client.ReplayRange(c), "ReplayLogPollerRange is only available if LogPoller is enabled")
+}
+
 func Test_FindLCA(t *testing.T) {
 	t.Parallel()
 
@@ -66,3 +102,27 @@ func Test_FindLCA(t *testing.T) {
 	c = cli.NewContext(nil, set, nil)
 	require.ErrorContains(t, client.FindLCA(c), "FindLCA is only available if LogPoller is enabled")
 }
+
+func Test_ListLogPollerFilters(t *testing.T) {
+	t.Parallel()
+
+	app := startNewApplicationV2(t, func(c *chainlink.Config, s *chainlink.Secrets) {
+		c.EVM[0].ChainID = (*ubig.Big)(big.NewInt(5))
+		c.EVM[0].Enabled = ptr(true)
+	})
+
+	client, _ := app.NewShellAndRenderer()
+
+	set := flag.NewFlagSet("test", 0)
+	flagSetApplyFromAction(client.ListLogPollerFilters, set, "")
+
+	// Incorrect chain ID
+	require.NoError(t, set.Set("evm-chain-id", "1"))
+	c := cli.NewContext(nil, set, nil)
+	require.ErrorContains(t, client.ListLogPollerFilters(c), "does not match any local chains")
+
+	// Correct chain ID
+	require.NoError(t, set.Set("evm-chain-id", "5"))
+	c = cli.NewContext(nil, set, nil)
+	require.ErrorContains(t, client.ListLogPollerFilters(c), "GetLogPollerFilters is only available if LogPoller is enabled")
+}
@@ -0,0 +1,134 @@
+// package main is a script for regenerating the geth golang contract wrappers
+// of the artifacts pinned in a pinned artifacts file, after checking them against
+// their published digests, and recording the digests for the deployment package.
+//
+//	Usage:
+//
+// With the directory of the product wrappers, e.g. core/gethwrappers/ccip, as
+// your working directory, run
+//
+//	go run ../generation/generate_pinned/wrap_pinned.go generation/pinned-artifact-versions.txt
+//
+// This will output the wrappers to generated/<pkgname>/<pkgname>.go, and the
+// digests to generated/pinned_artifacts/pinned_artifacts.go.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"text/template"
+
+	gethParams "github.com/ethereum/go-ethereum/params"
+
+	gethwrappers2 "github.com/smartcontractkit/chainlink/v2/core/gethwrappers"
+)
+
+const pinnedArtifactsPkg = "pinned_artifacts"
+
+var pinnedArtifactsTemplate = template.Must(template.New(pinnedArtifactsPkg).Parse(`// Code generated by generation/generate_pinned - DO NOT EDIT.
+
+// Package pinned_artifacts records the compiler artifacts the wrappers were generated from.
+package pinned_artifacts
+
+// PinnedArtifact is the compiler artifact of a contract version.
+type PinnedArtifact struct {
+	// Package is the wrapper package generated from the artifact.
+	Package string
+	// TypeAndVersion is the type and version the contract is deployed as.
+	TypeAndVersion string
+	// BytecodeHash is the published hex encoded sha256 of the creation code of the artifact.
+	BytecodeHash string
+}
+
+// PinnedArtifacts are the pinned artifacts by type and version.
+var PinnedArtifacts = map[string]PinnedArtifact{
+{{- range .}}
+	{{printf "%q" .TypeAndVersion}}: {Package: {{printf "%q" .Package}}, TypeAndVersion: {{printf "%q" .TypeAndVersion}}, BytecodeHash: {{printf "%q" .BytecodeHash}}},
+{{- end}}
+}
+`))
+
+type pinnedArtifact struct {
+	Package, TypeAndVersion, BytecodeHash string
+}
+
+func main() {
+	pinsPath := os.Args[1]
+	pins, err := gethwrappers2.ReadPinnedArtifacts(pinsPath)
+	if err != nil {
+		gethwrappers2.Exit("could not read pinned artifacts", err)
+	}
+
+	cwd, err := os.Getwd() // product wrappers directory
+	if err != nil {
+		gethwrappers2.Exit("could not get working directory", err)
+	}
+	versions, err := gethwrappers2.ReadVersionsDB()
+	if err != nil {
+		gethwrappers2.Exit("could not read current versions database", err)
+	}
+	versions.GethVersion = gethParams.Version
+
+	var artifacts []pinnedArtifact
+	seen := make(map[string]string)
+	for _, pin := range pins {
+		if pkg, ok := seen[pin.TypeAndVersion]; ok {
+			gethwrappers2.Exit(fmt.Sprintf("%s is pinned by both %s and %s", pin.TypeAndVersion, pkg, pin.Package), nil)
+		}
+		seen[pin.TypeAndVersion] = pin.Package
+		fmt.Println("Generating", pin.Package, "contract wrapper from", pin.Artifact, pin.SolcVersion)
+
+		abiPath, binPath := pin.AbiPath(), pin.BinaryPath()
+		// the compiled artifact must be the published one, so that the wrapper deploys the released contract
+		hash, err := gethwrappers2.BytecodeHash(binPath)
+		if err != nil {
+			gethwrappers2.Exit("could not hash bytecode", err)
+		}
+		if hash != pin.Digest {
+			gethwrappers2.Exit(fmt.Sprintf("%s has hash %s but its pinned digest is %s, check the solc version "+
+				"and sources of the artifact", binPath, hash, pin.Digest), nil)
+		}
+		outDir := filepath.Join(cwd, "generated", pin.Package)
+		if mkdErr := os.MkdirAll(outDir, 0700); mkdErr != nil {
+			gethwrappers2.Exit("failed to create wrapper dir", mkdErr)
+		}
+		gethwrappers2.Abigen(gethwrappers2.AbigenArgs{
+			Bin: binPath, ABI: abiPath, Out: filepath.Join(outDir, pin.Package+".go"), Type: pin.Type, Pkg: pin.Package,
+		})
+
+		artifacts = append(artifacts, pinnedArtifact{
+			Package:        pin.Package,
+			TypeAndVersion: pin.TypeAndVersion,
+			BytecodeHash:   hash,
+		})
+		versions.ContractVersions[pin.Package] = gethwrappers2.ContractVersion{
+			Hash:       gethwrappers2.VersionHash(abiPath, binPath),
+			AbiPath:    abiPath,
+			BinaryPath: binPath,
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := pinnedArtifactsTemplate.Execute(&buf, artifacts); err != nil {
+		gethwrappers2.Exit("could not render pinned artifacts", err)
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		gethwrappers2.Exit("could not format pinned artifacts", err)
+	}
+	outDir := filepath.Join(cwd, "generated", pinnedArtifactsPkg)
+	if err := os.MkdirAll(outDir, 0700); err != nil {
+		gethwrappers2.Exit("failed to create pinned artifacts dir", err)
+	}
+	if err := os.WriteFile(filepath.Join(outDir, pinnedArtifactsPkg+".go"), src, 0600); err != nil {
+		gethwrappers2.Exit("could not write pinned artifacts", err)
+	}
+
+	// Build succeeded, so update the versions db with the new contract data
+	if err := gethwrappers2.WriteVersionsDB(versions); err != nil {
+		gethwrappers2.Exit("could not save versions db", err)
+	}
+}
@@ -0,0 +1,103 @@
+package gethwrappers
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	pkgerrors "github.com/pkg/errors"
+)
+
+// PinnedArtifact is a compiler artifact whose wrapper is regenerated by
+// generation/generate_pinned, see ReadPinnedArtifacts.
+type PinnedArtifact struct {
+	// Package is the golang package name of the wrapper
+	Package string
+	// SolcVersion is the directory of the artifacts of the compiler version, e.g. v0.8.24
+	SolcVersion string
+	// Artifact is the name of the compiled contract
+	Artifact string
+	// Type is the name of the wrapper type
+	Type string
+	// Digest is the published hex encoded sha256 of the creation code of the artifact,
+	// which the artifact is checked against before generating its wrapper.
+	Digest string
+	// TypeAndVersion is the type and version the contract is deployed as, e.g. "OnRamp 1.6.0-dev"
+	TypeAndVersion string
+}
+
+// AbiPath is the path to the compiled abi file of the artifact, relative to
+// the directory of the product wrappers, e.g. core/gethwrappers/ccip.
+func (a PinnedArtifact) AbiPath() string {
+	return filepath.Join("../../../contracts/solc", a.SolcVersion, a.Artifact, a.Artifact+".abi")
+}
+
+// BinaryPath is the path to the compiled bin file of the artifact.
+func (a PinnedArtifact) BinaryPath() string {
+	return filepath.Join("../../../contracts/solc", a.SolcVersion, a.Artifact, a.Artifact+".bin")
+}
+
+// ReadPinnedArtifacts reads the pinned artifacts file at path. Each line is
+//
+//	<pkgname>: <solc-version> <artifact> <type> <digest> <type-and-version>
+//
+// blank lines and lines starting with # are ignored.
+func ReadPinnedArtifacts(path string) ([]PinnedArtifact, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, pkgerrors.Wrapf(err, "could not open pinned artifacts")
+	}
+	defer f.Close()
+	var artifacts []PinnedArtifact
+	seen := make(map[string]bool)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		line := strings.Fields(text)
+		if len(line) < 7 || !strings.HasSuffix(line[0], ":") {
+			return nil, pkgerrors.Errorf(`"%s" should be `+
+				`"<pkgname>: <solc-version> <artifact> <type> <digest> <type-and-version>"`, text)
+		}
+		if digest, err := hex.DecodeString(line[4]); err != nil || len(digest) != sha256.Size {
+			return nil, pkgerrors.Errorf(`digest "%s" of "%s" should be a hex encoded sha256`, line[4], text)
+		}
+		pkgName := stripTrailingColon(line[0], "")
+		if seen[pkgName] {
+			return nil, pkgerrors.Errorf(`package "%s" already pinned`, pkgName)
+		}
+		seen[pkgName] = true
+		artifacts = append(artifacts, PinnedArtifact{
+			Package:        pkgName,
+			SolcVersion:    line[1],
+			Artifact:       line[2],
+			Type:           line[3],
+			Digest:         line[4],
+			TypeAndVersion: strings.Join(line[5:], " "),
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, pkgerrors.Wrapf(err, "could not read pinned artifacts")
+	}
+	return artifacts, nil
+}
+
+// BytecodeHash is the hex encoded sha256 of the creation code in the bin file,
+// which is the same as the one of the Bin of the wrapper generated from it.
+func BytecodeHash(binPath string) (string, error) {
+	bin, err := os.ReadFile(binPath)
+	if err != nil {
+		return "", pkgerrors.Wrapf(err, "could not read %s", binPath)
+	}
+	code, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(string(bin)), "0x"))
+	if err != nil {
+		return "", pkgerrors.Wrapf(err, "invalid bytecode in %s", binPath)
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(code)), nil
+}
@@ -0,0 +1,58 @@
+package gethwrappers
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/pinned_artifacts"
+)
+
+func TestReadPinnedArtifacts(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pins.txt")
+	digest := strings.Repeat("ab", 32)
+	require.NoError(t, os.WriteFile(path, []byte(`# comment
+
+onramp: v0.8.24 OnRamp OnRamp `+digest+` OnRamp 1.6.0-dev
+rmn_proxy_contract: v0.8.24 ARMProxy RMNProxyContract `+digest+` ARMProxy 1.0.0
+`), 0600))
+	pins, err := ReadPinnedArtifacts(path)
+	require.NoError(t, err)
+	require.Equal(t, []PinnedArtifact{
+		{Package: "onramp", SolcVersion: "v0.8.24", Artifact: "OnRamp", Type: "OnRamp", Digest: digest, TypeAndVersion: "OnRamp 1.6.0-dev"},
+		{Package: "rmn_proxy_contract", SolcVersion: "v0.8.24", Artifact: "ARMProxy", Type: "RMNProxyContract", Digest: digest, TypeAndVersion: "ARMProxy 1.0.0"},
+	}, pins)
+	assert.Equal(t, "../../../contracts/solc/v0.8.24/ARMProxy/ARMProxy.abi", pins[1].AbiPath())
+	assert.Equal(t, "../../../contracts/solc/v0.8.24/ARMProxy/ARMProxy.bin", pins[1].BinaryPath())
+
+	for _, invalid := range []string{
+		"onramp: v0.8.24 OnRamp OnRamp " + digest,
+		"onramp: v0.8.24 OnRamp OnRamp OnRamp 1.6.0-dev",
+		"onramp: v0.8.24 OnRamp OnRamp abcd OnRamp 1.6.0-dev",
+		"onramp v0.8.24 OnRamp OnRamp " + digest + " OnRamp 1.6.0-dev",
+		"onramp: v0.8.24 OnRamp OnRamp " + digest + " OnRamp 1.6.0-dev\nonramp: v0.8.24 OnRamp OnRamp " + digest + " OnRamp 1.6.0",
+	} {
+		require.NoError(t, os.WriteFile(path, []byte(invalid), 0600))
+		_, err := ReadPinnedArtifacts(path)
+		assert.Error(t, err, invalid)
+	}
+}
+
+// TestPinnedArtifactsUpToDate checks that the digests recorded for the deployment package were
+// generated from the current pins.
+func TestPinnedArtifactsUpToDate(t *testing.T) {
+	pins, err := ReadPinnedArtifacts("ccip/generation/pinned-artifact-versions.txt")
+	require.NoError(t, err)
+	require.Len(t, pinned_artifacts.PinnedArtifacts, len(pins), "please re-run `go generate` in core/gethwrappers/ccip")
+	for _, pin := range pins {
+		artifact, ok := pinned_artifacts.PinnedArtifacts[pin.TypeAndVersion]
+		if assert.True(t, ok, "%s is not recorded, please re-run `go generate` in core/gethwrappers/ccip", pin.TypeAndVersion) {
+			assert.Equal(t, pin.Package, artifact.Package)
+			assert.Equal(t, pin.Digest, artifact.BytecodeHash)
+		}
+	}
+}
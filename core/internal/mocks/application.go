@@ -11,10 +11,14 @@ import (
 
 	chainlink "github.com/smartcontractkit/chainlink/v2/core/services/chainlink"
 
+	common "github.com/ethereum/go-ethereum/common"
+
 	context "context"
 
 	feeds "github.com/smartcontractkit/chainlink/v2/core/services/feeds"
 
+	functions "github.com/smartcontractkit/chainlink/v2/core/services/ocr2/plugins/functions"
+
 	job "github.com/smartcontractkit/chainlink/v2/core/services/job"
 
 	jsonserializable "github.com/smartcontractkit/chainlink-common/pkg/utils/jsonserializable"
@@ -685,6 +689,53 @@ func (_c *Application_GetFeedsService_Call) RunAndReturn(run func() feeds.Servic
 	return _c
 }
 
+// GetFunctionsAdminRegistry provides a mock function with given fields:
+func (_m *Application) GetFunctionsAdminRegistry() *functions.AdminRegistry {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFunctionsAdminRegistry")
+	}
+
+	var r0 *functions.AdminRegistry
+	if rf, ok := ret.Get(0).(func() *functions.AdminRegistry); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*functions.AdminRegistry)
+		}
+	}
+
+	return r0
+}
+
+// Application_GetFunctionsAdminRegistry_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFunctionsAdminRegistry'
+type Application_GetFunctionsAdminRegistry_Call struct {
+	*mock.Call
+}
+
+// GetFunctionsAdminRegistry is a helper method to define mock.On call
+func (_e *Application_Expecter) GetFunctionsAdminRegistry() *Application_GetFunctionsAdminRegistry_Call {
+	return &Application_GetFunctionsAdminRegistry_Call{Call: _e.mock.On("GetFunctionsAdminRegistry")}
+}
+
+func (_c *Application_GetFunctionsAdminRegistry_Call) Run(run func()) *Application_GetFunctionsAdminRegistry_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Application_GetFunctionsAdminRegistry_Call) Return(_a0 *functions.AdminRegistry) *Application_GetFunctionsAdminRegistry_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Application_GetFunctionsAdminRegistry_Call) RunAndReturn(run func() *functions.AdminRegistry) *Application_GetFunctionsAdminRegistry_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetHealthChecker provides a mock function with given fields:
 func (_m *Application) GetHealthChecker() services.Checker {
 	ret := _m.Called()
@@ -779,6 +830,64 @@ func (_c *Application_GetKeyStore_Call) RunAndReturn(run func() keystore.Master)
 	return _c
 }
 
+// GetLogPollerFilters provides a mock function with given fields: chainID
+func (_m *Application) GetLogPollerFilters(chainID *big.Int) ([]logpoller.Filter, error) {
+	ret := _m.Called(chainID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLogPollerFilters")
+	}
+
+	var r0 []logpoller.Filter
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*big.Int) ([]logpoller.Filter, error)); ok {
+		return rf(chainID)
+	}
+	if rf, ok := ret.Get(0).(func(*big.Int) []logpoller.Filter); ok {
+		r0 = rf(chainID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]logpoller.Filter)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*big.Int) error); ok {
+		r1 = rf(chainID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Application_GetLogPollerFilters_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLogPollerFilters'
+type Application_GetLogPollerFilters_Call struct {
+	*mock.Call
+}
+
+// GetLogPollerFilters is a helper method to define mock.On call
+//   - chainID *big.Int
+func (_e *Application_Expecter) GetLogPollerFilters(chainID interface{}) *Application_GetLogPollerFilters_Call {
+	return &Application_GetLogPollerFilters_Call{Call: _e.mock.On("GetLogPollerFilters", chainID)}
+}
+
+func (_c *Application_GetLogPollerFilters_Call) Run(run func(chainID *big.Int)) *Application_GetLogPollerFilters_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*big.Int))
+	})
+	return _c
+}
+
+func (_c *Application_GetLogPollerFilters_Call) Return(_a0 []logpoller.Filter, _a1 error) *Application_GetLogPollerFilters_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Application_GetLogPollerFilters_Call) RunAndReturn(run func(*big.Int) ([]logpoller.Filter, error)) *Application_GetLogPollerFilters_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetLogger provides a mock function with given fields:
 func (_m *Application) GetLogger() logger.SugaredLogger {
 	ret := _m.Called()
@@ -1200,6 +1309,54 @@ func (_c *Application_PipelineORM_Call) RunAndReturn(run func() pipeline.ORM) *A
 	return _c
 }
 
+// RegisterLogPollerFilters provides a mock function with given fields: ctx, chainID, filters
+func (_m *Application) RegisterLogPollerFilters(ctx context.Context, chainID *big.Int, filters []logpoller.Filter) error {
+	ret := _m.Called(ctx, chainID, filters)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RegisterLogPollerFilters")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *big.Int, []logpoller.Filter) error); ok {
+		r0 = rf(ctx, chainID, filters)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Application_RegisterLogPollerFilters_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RegisterLogPollerFilters'
+type Application_RegisterLogPollerFilters_Call struct {
+	*mock.Call
+}
+
+// RegisterLogPollerFilters is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chainID *big.Int
+//   - filters []logpoller.Filter
+func (_e *Application_Expecter) RegisterLogPollerFilters(ctx interface{}, chainID interface{}, filters interface{}) *Application_RegisterLogPollerFilters_Call {
+	return &Application_RegisterLogPollerFilters_Call{Call: _e.mock.On("RegisterLogPollerFilters", ctx, chainID, filters)}
+}
+
+func (_c *Application_RegisterLogPollerFilters_Call) Run(run func(ctx context.Context, chainID *big.Int, filters []logpoller.Filter)) *Application_RegisterLogPollerFilters_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*big.Int), args[2].([]logpoller.Filter))
+	})
+	return _c
+}
+
+func (_c *Application_RegisterLogPollerFilters_Call) Return(_a0 error) *Application_RegisterLogPollerFilters_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Application_RegisterLogPollerFilters_Call) RunAndReturn(run func(context.Context, *big.Int, []logpoller.Filter) error) *Application_RegisterLogPollerFilters_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ReplayFromBlock provides a mock function with given fields: chainID, number, forceBroadcast
 func (_m *Application) ReplayFromBlock(chainID *big.Int, number uint64, forceBroadcast bool) error {
 	ret := _m.Called(chainID, number, forceBroadcast)
@@ -1248,6 +1405,57 @@ func (_c *Application_ReplayFromBlock_Call) RunAndReturn(run func(*big.Int, uint
 	return _c
 }
 
+// ReplayLogPollerRange provides a mock function with given fields: ctx, chainID, fromBlock, toBlock, addresses, eventSigs
+func (_m *Application) ReplayLogPollerRange(ctx context.Context, chainID *big.Int, fromBlock int64, toBlock int64, addresses []common.Address, eventSigs []common.Hash) error {
+	ret := _m.Called(ctx, chainID, fromBlock, toBlock, addresses, eventSigs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplayLogPollerRange")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *big.Int, int64, int64, []common.Address, []common.Hash) error); ok {
+		r0 = rf(ctx, chainID, fromBlock, toBlock, addresses, eventSigs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Application_ReplayLogPollerRange_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReplayLogPollerRange'
+type Application_ReplayLogPollerRange_Call struct {
+	*mock.Call
+}
+
+// ReplayLogPollerRange is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chainID *big.Int
+//   - fromBlock int64
+//   - toBlock int64
+//   - addresses []common.Address
+//   - eventSigs []common.Hash
+func (_e *Application_Expecter) ReplayLogPollerRange(ctx interface{}, chainID interface{}, fromBlock interface{}, toBlock interface{}, addresses interface{}, eventSigs interface{}) *Application_ReplayLogPollerRange_Call {
+	return &Application_ReplayLogPollerRange_Call{Call: _e.mock.On("ReplayLogPollerRange", ctx, chainID, fromBlock, toBlock, addresses, eventSigs)}
+}
+
+func (_c *Application_ReplayLogPollerRange_Call) Run(run func(ctx context.Context, chainID *big.Int, fromBlock int64, toBlock int64, addresses []common.Address, eventSigs []common.Hash)) *Application_ReplayLogPollerRange_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*big.Int), args[2].(int64), args[3].(int64), args[4].([]common.Address), args[5].([]common.Hash))
+	})
+	return _c
+}
+
+func (_c *Application_ReplayLogPollerRange_Call) Return(_a0 error) *Application_ReplayLogPollerRange_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Application_ReplayLogPollerRange_Call) RunAndReturn(run func(context.Context, *big.Int, int64, int64, []common.Address, []common.Hash) error) *Application_ReplayLogPollerRange_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ResumeJobV2 provides a mock function with given fields: ctx, taskID, result
 func (_m *Application) ResumeJobV2(ctx context.Context, taskID uuid.UUID, result pipeline.Result) error {
 	ret := _m.Called(ctx, taskID, result)
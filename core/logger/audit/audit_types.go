@@ -65,6 +65,8 @@ const (
 	ChainRpcNodeAdded   EventID = "CHAIN_RPC_NODE_ADDED"
 	ChainRpcNodeDeleted EventID = "CHAIN_RPC_NODE_DELETED"
 
+	ChainConfigReloaded EventID = "CHAIN_CONFIG_RELOADED"
+
 	BridgeCreated EventID = "BRIDGE_CREATED"
 	BridgeUpdated EventID = "BRIDGE_UPDATED"
 	BridgeDeleted EventID = "BRIDGE_DELETED"
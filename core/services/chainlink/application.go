@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"math/big"
 	"net/http"
+	"sort"
 	"sync"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -30,6 +31,7 @@ import (
 	"github.com/smartcontractkit/chainlink/v2/core/build"
 	"github.com/smartcontractkit/chainlink/v2/core/capabilities"
 	"github.com/smartcontractkit/chainlink/v2/core/capabilities/ccip"
+	"github.com/smartcontractkit/chainlink/v2/core/capabilities/ccip/oraclecreator"
 	gatewayconnector "github.com/smartcontractkit/chainlink/v2/core/capabilities/gateway_connector"
 	"github.com/smartcontractkit/chainlink/v2/core/capabilities/remote"
 	remotetypes "github.com/smartcontractkit/chainlink/v2/core/capabilities/remote/types"
@@ -55,6 +57,7 @@ import (
 	"github.com/smartcontractkit/chainlink/v2/core/services/llo"
 	"github.com/smartcontractkit/chainlink/v2/core/services/ocr"
 	"github.com/smartcontractkit/chainlink/v2/core/services/ocr2"
+	"github.com/smartcontractkit/chainlink/v2/core/services/ocr2/plugins/functions"
 	"github.com/smartcontractkit/chainlink/v2/core/services/ocrbootstrap"
 	"github.com/smartcontractkit/chainlink/v2/core/services/ocrcommon"
 	p2ptypes "github.com/smartcontractkit/chainlink/v2/core/services/p2p/types"
@@ -97,6 +100,8 @@ type Application interface {
 	GetRelayers() RelayerChainInteroperators
 	GetLoopRegistry() *plugins.LoopRegistry
 	GetLoopRegistrarConfig() plugins.RegistrarConfig
+	// GetFunctionsAdminRegistry returns the services of the running Functions jobs, for the admin endpoints.
+	GetFunctionsAdminRegistry() *functions.AdminRegistry
 
 	// V2 Jobs (TOML specified)
 	JobSpawner() job.Spawner
@@ -120,6 +125,9 @@ type Application interface {
 	// ReplayFromBlock replays logs from on or after the given block number. If forceBroadcast is
 	// set to true, consumers will reprocess data even if it has already been processed.
 	ReplayFromBlock(chainID *big.Int, number uint64, forceBroadcast bool) error
+	// ReplayLogPollerRange synchronously replays LogPoller logs within the given block range, restricted to the
+	// given addresses and (optionally) event signatures.
+	ReplayLogPollerRange(ctx context.Context, chainID *big.Int, fromBlock, toBlock int64, addresses []common.Address, eventSigs []common.Hash) error
 
 	// ID is unique to this particular application instance
 	ID() uuid.UUID
@@ -130,6 +138,10 @@ type Application interface {
 	FindLCA(ctx context.Context, chainID *big.Int) (*logpoller.LogPollerBlock, error)
 	// DeleteLogPollerDataAfter - delete LogPoller state starting from the specified block
 	DeleteLogPollerDataAfter(ctx context.Context, chainID *big.Int, start int64) error
+	// GetLogPollerFilters - returns the filters currently registered with LogPoller for the given chain, sorted by name
+	GetLogPollerFilters(chainID *big.Int) ([]logpoller.Filter, error)
+	// RegisterLogPollerFilters - registers the given filters with LogPoller for the given chain (e.g. re-import of exported filters)
+	RegisterLogPollerFilters(ctx context.Context, chainID *big.Int, filters []logpoller.Filter) error
 }
 
 // ChainlinkApplication contains fields for the JobSubscriber, Scheduler,
@@ -162,6 +174,7 @@ type ChainlinkApplication struct {
 	profiler                 *pyroscope.Profiler
 	loopRegistry             *plugins.LoopRegistry
 	loopRegistrarConfig      plugins.RegistrarConfig
+	functionsAdminRegistry   *functions.AdminRegistry
 
 	started     bool
 	startStopMu sync.Mutex
@@ -189,6 +202,10 @@ type ApplicationOpts struct {
 	CapabilitiesDispatcher     remotetypes.Dispatcher
 	CapabilitiesPeerWrapper    p2ptypes.PeerWrapper
 	NewOracleFactoryFn         standardcapabilities.NewOracleFactoryFn
+	// NewRMNPeerClientFn overrides how CCIP commit plugins connect to the RMN nodes, used in tests.
+	NewRMNPeerClientFn oraclecreator.NewRMNPeerClientFn
+	// PluginTelemetrySink receives the round data of the CCIP plugins, used in tests.
+	PluginTelemetrySink oraclecreator.PluginTelemetrySink
 }
 
 // NewApplication initializes a new store if one is not already
@@ -543,6 +560,7 @@ func NewApplication(opts ApplicationOpts) (Application, error) {
 		globalLogger.Debug("Off-chain reporting disabled")
 	}
 
+	functionsAdminRegistry := functions.NewAdminRegistry()
 	if cfg.OCR2().Enabled() {
 		globalLogger.Debug("Off-chain reporting v2 enabled")
 
@@ -566,6 +584,7 @@ func NewApplication(opts ApplicationOpts) (Application, error) {
 				MailMon:               mailMon,
 				CapabilitiesRegistry:  opts.CapabilitiesRegistry,
 				RetirementReportCache: opts.RetirementReportCache,
+				FunctionsAdmin:        functionsAdminRegistry,
 			},
 			ocr2DelegateConfig,
 		)
@@ -589,6 +608,8 @@ func NewApplication(opts ApplicationOpts) (Application, error) {
 			telemetryManager,
 			cfg.Capabilities(),
 			cfg.EVMConfigs(),
+			opts.NewRMNPeerClientFn,
+			opts.PluginTelemetrySink,
 		)
 	} else {
 		globalLogger.Debug("Off-chain reporting v2 disabled")
@@ -668,6 +689,7 @@ func NewApplication(opts ApplicationOpts) (Application, error) {
 		profiler:                 profiler,
 		loopRegistry:             loopRegistry,
 		loopRegistrarConfig:      loopRegistrarConfig,
+		functionsAdminRegistry:   functionsAdminRegistry,
 
 		ds: opts.DS,
 
@@ -749,6 +771,10 @@ func (app *ChainlinkApplication) GetLoopRegistrarConfig() plugins.RegistrarConfi
 	return app.loopRegistrarConfig
 }
 
+func (app *ChainlinkApplication) GetFunctionsAdminRegistry() *functions.AdminRegistry {
+	return app.functionsAdminRegistry
+}
+
 // Stop allows the application to exit by halting schedules, closing
 // logs, and closing the DB connection.
 func (app *ChainlinkApplication) Stop() error {
@@ -977,6 +1003,18 @@ func (app *ChainlinkApplication) ReplayFromBlock(chainID *big.Int, number uint64
 	return nil
 }
 
+// ReplayLogPollerRange implements the Application interface.
+func (app *ChainlinkApplication) ReplayLogPollerRange(ctx context.Context, chainID *big.Int, fromBlock, toBlock int64, addresses []common.Address, eventSigs []common.Hash) error {
+	chain, err := app.GetRelayers().LegacyEVMChains().Get(chainID.String())
+	if err != nil {
+		return err
+	}
+	if !app.Config.Feature().LogPoller() {
+		return fmt.Errorf("ReplayLogPollerRange is only available if LogPoller is enabled")
+	}
+	return chain.LogPoller().ReplayRange(ctx, fromBlock, toBlock, addresses, eventSigs)
+}
+
 func (app *ChainlinkApplication) GetRelayers() RelayerChainInteroperators {
 	return app.relayers
 }
@@ -1043,3 +1081,45 @@ func (app *ChainlinkApplication) DeleteLogPollerDataAfter(ctx context.Context, c
 
 	return nil
 }
+
+// GetLogPollerFilters - returns the filters currently registered with LogPoller for the given chain, sorted by name
+func (app *ChainlinkApplication) GetLogPollerFilters(chainID *big.Int) ([]logpoller.Filter, error) {
+	chain, err := app.GetRelayers().LegacyEVMChains().Get(chainID.String())
+	if err != nil {
+		return nil, err
+	}
+	if !app.Config.Feature().LogPoller() {
+		return nil, fmt.Errorf("GetLogPollerFilters is only available if LogPoller is enabled")
+	}
+
+	filters := chain.LogPoller().GetFilters()
+	result := make([]logpoller.Filter, 0, len(filters))
+	for _, filter := range filters {
+		result = append(result, filter)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result, nil
+}
+
+// RegisterLogPollerFilters - registers the given filters with LogPoller for the given chain (e.g. re-import of exported filters)
+func (app *ChainlinkApplication) RegisterLogPollerFilters(ctx context.Context, chainID *big.Int, filters []logpoller.Filter) error {
+	chain, err := app.GetRelayers().LegacyEVMChains().Get(chainID.String())
+	if err != nil {
+		return err
+	}
+	if !app.Config.Feature().LogPoller() {
+		return fmt.Errorf("RegisterLogPollerFilters is only available if LogPoller is enabled")
+	}
+
+	for _, filter := range filters {
+		if filter.Name == "" {
+			return fmt.Errorf("filter name cannot be empty")
+		}
+		if err := chain.LogPoller().RegisterFilter(ctx, filter); err != nil {
+			return fmt.Errorf("failed to register filter %q: %w", filter.Name, err)
+		}
+	}
+	return nil
+}
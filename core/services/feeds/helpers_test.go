@@ -1,7 +1,15 @@
 package feeds
 
+import "context"
+
 // SetConnectionsManager allows us to manually set the connections manager.
 // Only used for testing.
 func (s *service) SetConnectionsManager(cm ConnectionsManager) {
 	s.connMgr = cm
 }
+
+// SyncChangedNodeInfo exposes syncChangedNodeInfo.
+// Only used for testing.
+func (s *service) SyncChangedNodeInfo(ctx context.Context) {
+	s.syncChangedNodeInfo(ctx)
+}
@@ -2,9 +2,12 @@ package feeds
 
 import (
 	"context"
+	"crypto/sha256"
 	"database/sql"
 	"encoding/hex"
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/google/uuid"
@@ -13,6 +16,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
+	"google.golang.org/protobuf/proto"
 	"gopkg.in/guregu/null.v4"
 
 	"github.com/smartcontractkit/chainlink-common/pkg/services"
@@ -38,6 +42,9 @@ import (
 	"github.com/smartcontractkit/chainlink/v2/core/utils/crypto"
 )
 
+// nodeInfoSyncInterval is how often the node info is checked for changes which need to be pushed to the feeds managers
+const nodeInfoSyncInterval = time.Minute
+
 var (
 	ErrOCR2Disabled = errors.New("ocr2 is disabled")
 	ErrOCRDisabled  = errors.New("ocr is disabled")
@@ -138,6 +145,13 @@ type service struct {
 	lggr                logger.Logger
 	version             string
 	loopRegistrarConfig plugins.RegistrarConfig
+
+	// syncedNodeInfo holds a digest of the node info last pushed to each feeds manager, by feeds manager ID
+	syncedNodeInfoMu sync.Mutex
+	syncedNodeInfo   map[int64][sha256.Size]byte
+
+	stopCh services.StopChan
+	wg     sync.WaitGroup
 }
 
 // NewService constructs a new feeds service
@@ -179,6 +193,8 @@ func NewService(
 		lggr:                lggr,
 		version:             version,
 		loopRegistrarConfig: rc,
+		syncedNodeInfo:      make(map[int64][sha256.Size]byte),
+		stopCh:              make(services.StopChan),
 	}
 
 	return svc
@@ -258,9 +274,32 @@ func (s *service) SyncNodeInfo(ctx context.Context, id int64) error {
 		return errors.Wrap(err, "could not fetch client")
 	}
 
+	req, err := s.newUpdateNodeRequest(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if _, err = fmsClient.UpdateNode(ctx, req); err != nil {
+		return err
+	}
+
+	digest, err := nodeInfoDigest(req)
+	if err != nil {
+		s.lggr.Errorf("SyncNodeInfo: %v", err)
+		return nil
+	}
+	s.syncedNodeInfoMu.Lock()
+	s.syncedNodeInfo[id] = digest
+	s.syncedNodeInfoMu.Unlock()
+
+	return nil
+}
+
+// newUpdateNodeRequest builds the node info, including all chain configs, which is pushed to the feeds manager.
+func (s *service) newUpdateNodeRequest(ctx context.Context, id int64) (*pb.UpdateNodeRequest, error) {
 	cfgs, err := s.orm.ListChainConfigsByManagerIDs(ctx, []int64{id})
 	if err != nil {
-		return errors.Wrap(err, "could not fetch chain configs")
+		return nil, errors.Wrap(err, "could not fetch chain configs")
 	}
 
 	cfgMsgs := make([]*pb.ChainConfig, 0, len(cfgs))
@@ -275,14 +314,82 @@ func (s *service) SyncNodeInfo(ctx context.Context, id int64) error {
 		cfgMsgs = append(cfgMsgs, cfgMsg)
 	}
 
-	if _, err = fmsClient.UpdateNode(ctx, &pb.UpdateNodeRequest{
+	return &pb.UpdateNodeRequest{
 		Version:      s.version,
 		ChainConfigs: cfgMsgs,
-	}); err != nil {
-		return err
+	}, nil
+}
+
+// nodeInfoDigest returns a digest of the node info, used to detect changes since the last sync.
+func nodeInfoDigest(req *pb.UpdateNodeRequest) ([sha256.Size]byte, error) {
+	b, err := proto.MarshalOptions{Deterministic: true}.Marshal(req)
+	if err != nil {
+		return [sha256.Size]byte{}, errors.Wrap(err, "could not marshal node info")
 	}
+	return sha256.Sum256(b), nil
+}
 
-	return nil
+// syncChangedNodeInfo pushes the node info to every connected feeds manager whose last synced node info is
+// outdated, e.g. after a chain config, a forwarder address or a key bundle changed.
+func (s *service) syncChangedNodeInfo(ctx context.Context) {
+	mgrs, err := s.ListManagers(ctx)
+	if err != nil {
+		s.lggr.Errorf("Unable to list feeds managers to sync node info: %v", err)
+		return
+	}
+
+	for _, mgr := range mgrs {
+		if mgr.DisabledAt != nil || !s.connMgr.IsConnected(mgr.ID) {
+			continue
+		}
+
+		req, err := s.newUpdateNodeRequest(ctx, mgr.ID)
+		if err != nil {
+			s.lggr.Errorf("Unable to build node info for feeds manager %d: %v", mgr.ID, err)
+			continue
+		}
+		digest, err := nodeInfoDigest(req)
+		if err != nil {
+			s.lggr.Errorf("Unable to build node info for feeds manager %d: %v", mgr.ID, err)
+			continue
+		}
+
+		s.syncedNodeInfoMu.Lock()
+		synced, ok := s.syncedNodeInfo[mgr.ID]
+		s.syncedNodeInfoMu.Unlock()
+		if ok && synced == digest {
+			continue
+		}
+
+		s.lggr.Infow("Node info changed, syncing with feeds manager", "feedsManagerID", mgr.ID)
+		if err = s.SyncNodeInfo(ctx, mgr.ID); err != nil {
+			s.lggr.Infof("FMS: Unable to sync node info: %v", err)
+		}
+	}
+}
+
+// syncNodeInfoLoop periodically pushes the node info to the feeds managers whose last synced node info is outdated.
+// Chain config changes are pushed when they are made, the loop retries the pushes which failed, e.g. while the feeds
+// manager was disconnected. The P2P and OCR key bundles referenced by the chain configs are re-read from the
+// keystores at every tick. The CSA key is the identity of the node for the feeds manager and is not synced: the node
+// must be registered again when it changes.
+func (s *service) syncNodeInfoLoop() {
+	defer s.wg.Done()
+
+	ctx, cancel := s.stopCh.NewCtx()
+	defer cancel()
+
+	ticker := time.NewTicker(nodeInfoSyncInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.syncChangedNodeInfo(ctx)
+		}
+	}
 }
 
 // UpdateManager updates the feed manager details, takes down the
@@ -1072,6 +1179,10 @@ func (s *service) Start(ctx context.Context) error {
 		if err != nil {
 			return err
 		}
+
+		s.wg.Add(1)
+		go s.syncNodeInfoLoop()
+
 		if len(mgrs) < 1 {
 			s.lggr.Info("no feeds managers registered")
 
@@ -1102,6 +1213,9 @@ func (s *service) Start(ctx context.Context) error {
 // Close shuts down the service
 func (s *service) Close() error {
 	return s.StopOnce("FeedsService", func() error {
+		close(s.stopCh)
+		s.wg.Wait()
+
 		// This blocks until it finishes
 		s.connMgr.Close()
 
@@ -1721,6 +1721,47 @@ func Test_Service_SyncNodeInfo(t *testing.T) {
 	}
 }
 
+func Test_Service_SyncChangedNodeInfo(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mgr  = feeds.FeedsManager{ID: 1}
+		ccfg = feeds.ChainConfig{
+			ID:             100,
+			FeedsManagerID: mgr.ID,
+			ChainID:        "42",
+			ChainType:      feeds.ChainTypeEVM,
+			AccountAddress: "0x0000",
+			AdminAddress:   "0x0001",
+		}
+		ctx = testutils.Context(t)
+	)
+
+	svc := setupTestService(t)
+	syncer, ok := svc.Service.(interface{ SyncChangedNodeInfo(context.Context) })
+	require.True(t, ok)
+
+	svc.orm.On("ListManagers", mock.Anything).Return([]feeds.FeedsManager{mgr}, nil)
+	svc.connMgr.On("IsConnected", mgr.ID).Return(true)
+	svc.connMgr.On("GetClient", mgr.ID).Return(svc.fmsClient, nil)
+	svc.orm.On("ListChainConfigsByManagerIDs", mock.Anything, []int64{mgr.ID}).Return([]feeds.ChainConfig{ccfg}, nil).Times(3)
+	svc.fmsClient.On("UpdateNode", mock.Anything, mock.Anything).Return(&proto.UpdateNodeResponse{}, nil).Once()
+
+	// Never synced, so the node info is pushed
+	syncer.SyncChangedNodeInfo(ctx)
+	// Nothing changed since the last sync
+	syncer.SyncChangedNodeInfo(ctx)
+
+	// The forwarder address changed, so the node info is pushed again
+	ccfg.OCR2Config = feeds.OCR2ConfigModel{Enabled: true, ForwarderAddress: null.StringFrom("0x0002")}
+	svc.orm.On("ListChainConfigsByManagerIDs", mock.Anything, []int64{mgr.ID}).Return([]feeds.ChainConfig{ccfg}, nil).Times(2)
+	svc.fmsClient.On("UpdateNode", mock.Anything, mock.MatchedBy(func(req *proto.UpdateNodeRequest) bool {
+		return len(req.ChainConfigs) == 1 && req.ChainConfigs[0].Ocr2Config.GetForwarderAddress() == "0x0002"
+	})).Return(&proto.UpdateNodeResponse{}, nil).Once()
+
+	syncer.SyncChangedNodeInfo(ctx)
+}
+
 func Test_Service_IsJobManaged(t *testing.T) {
 	t.Parallel()
 
@@ -396,38 +396,80 @@ func (l *functionsListener) handleRequest(ctx context.Context, requestID Request
 	requestIDStr := formatRequestId(requestID)
 	l.logger.Infow("processing request", "requestID", requestIDStr)
 
-	eaClient, err := l.bridgeAccessor.NewExternalAdapterClient(ctx)
+	c, err := l.compute(ctx, requestID, subscriptionId, subscriptionOwner, flags, requestData, true)
 	if err != nil {
-		l.logger.Errorw("failed to create ExternalAdapterClient", "requestID", requestIDStr, "err", err)
 		l.setError(ctx, requestID, INTERNAL_ERROR, []byte(err.Error()))
 		return err
 	}
 
-	nodeProvidedSecrets, userErr, internalErr := l.getSecrets(ctx, eaClient, requestID, subscriptionOwner, requestData)
-	if internalErr != nil {
-		l.logger.Errorw("internal error during getSecrets", "requestID", requestIDStr, "err", internalErr)
-		l.setError(ctx, requestID, INTERNAL_ERROR, []byte(internalErr.Error()))
-		return internalErr
+	if len(c.domains) > 0 {
+		l.reportSourceCodeDomains(requestID, c.domains)
+	}
+
+	if c.errType != NONE {
+		l.logger.Debugw("saving computation error", "requestID", requestIDStr)
+		l.setError(ctx, requestID, c.errType, c.err)
+		if c.computed {
+			promComputationErrorSize.WithLabelValues(l.contractAddressHex).Set(float64(len(c.err)))
+		}
+	} else {
+		promRequestComputationSuccess.WithLabelValues(l.contractAddressHex).Inc()
+		promComputationResultSize.WithLabelValues(l.contractAddressHex).Set(float64(len(c.result)))
+		l.logger.Debugw("saving computation result", "requestID", requestIDStr)
+		if err2 := l.pluginORM.SetResult(ctx, requestID, c.result, time.Now()); err2 != nil {
+			l.logger.Errorw("call to SetResult failed", "requestID", requestIDStr, "err", err2)
+			return err2
+		}
+	}
+	return nil
+}
+
+// computation is the outcome of a request, either its result or a user error.
+type computation struct {
+	result  []byte
+	errType ErrType
+	err     []byte
+	domains []string
+	// computed is false for requests failing before their computation, e.g. on their secrets.
+	computed bool
+}
+
+// compute fetches and decrypts the secrets of the request, unless withSecrets is false, and runs its computation,
+// without recording the outcome. Return error only for internal errors.
+func (l *functionsListener) compute(ctx context.Context, requestID RequestID, subscriptionId uint64, subscriptionOwner common.Address, flags RequestFlags, requestData *RequestData, withSecrets bool) (*computation, error) {
+	requestIDStr := formatRequestId(requestID)
+
+	eaClient, err := l.bridgeAccessor.NewExternalAdapterClient(ctx)
+	if err != nil {
+		l.logger.Errorw("failed to create ExternalAdapterClient", "requestID", requestIDStr, "err", err)
+		return nil, err
 	}
-	if userErr != nil {
-		l.logger.Debugw("user error during getSecrets", "requestID", requestIDStr, "err", userErr)
-		l.setError(ctx, requestID, USER_ERROR, []byte(userErr.Error()))
-		return nil // user error
+
+	var nodeProvidedSecrets string
+	if withSecrets {
+		var userErr, internalErr error
+		nodeProvidedSecrets, userErr, internalErr = l.getSecrets(ctx, eaClient, requestID, subscriptionOwner, requestData)
+		if internalErr != nil {
+			l.logger.Errorw("internal error during getSecrets", "requestID", requestIDStr, "err", internalErr)
+			return nil, internalErr
+		}
+		if userErr != nil {
+			l.logger.Debugw("user error during getSecrets", "requestID", requestIDStr, "err", userErr)
+			return &computation{errType: USER_ERROR, err: []byte(userErr.Error())}, nil
+		}
 	}
 
 	maxSecretsSize := l.getMaxSecretsSize(flags)
 	if uint32(len(nodeProvidedSecrets)) > maxSecretsSize {
 		l.logger.Errorw("secrets size too big", "requestID", requestIDStr, "secretsSize", len(nodeProvidedSecrets), "maxSecretsSize", maxSecretsSize)
-		l.setError(ctx, requestID, USER_ERROR, []byte("secrets size too big"))
-		return nil // user error
+		return &computation{errType: USER_ERROR, err: []byte("secrets size too big")}, nil
 	}
 
 	computationResult, computationError, domains, err := eaClient.RunComputation(ctx, requestIDStr, l.job.Name.ValueOrZero(), subscriptionOwner.Hex(), subscriptionId, flags, nodeProvidedSecrets, requestData)
 
 	if err != nil {
 		l.logger.Errorw("internal adapter error", "requestID", requestIDStr, "err", err)
-		l.setError(ctx, requestID, INTERNAL_ERROR, []byte(err.Error()))
-		return err
+		return nil, err
 	}
 
 	if len(computationError) == 0 && len(computationResult) == 0 {
@@ -436,27 +478,13 @@ func (l *functionsListener) handleRequest(ctx context.Context, requestID Request
 		computationError = []byte{}
 	}
 
-	if len(domains) > 0 {
-		l.reportSourceCodeDomains(requestID, domains)
-	}
-
 	if len(computationError) != 0 {
 		if len(computationResult) != 0 {
 			l.logger.Warnw("both result and error are non-empty - using error", "requestID", requestIDStr)
 		}
-		l.logger.Debugw("saving computation error", "requestID", requestIDStr)
-		l.setError(ctx, requestID, USER_ERROR, computationError)
-		promComputationErrorSize.WithLabelValues(l.contractAddressHex).Set(float64(len(computationError)))
-	} else {
-		promRequestComputationSuccess.WithLabelValues(l.contractAddressHex).Inc()
-		promComputationResultSize.WithLabelValues(l.contractAddressHex).Set(float64(len(computationResult)))
-		l.logger.Debugw("saving computation result", "requestID", requestIDStr)
-		if err2 := l.pluginORM.SetResult(ctx, requestID, computationResult, time.Now()); err2 != nil {
-			l.logger.Errorw("call to SetResult failed", "requestID", requestIDStr, "err", err2)
-			return err2
-		}
+		return &computation{errType: USER_ERROR, err: computationError, domains: domains, computed: true}, nil
 	}
-	return nil
+	return &computation{result: computationResult, domains: domains, computed: true}, nil
 }
 
 func (l *functionsListener) handleOracleResponseV1(response *evmrelayTypes.OracleResponse) {
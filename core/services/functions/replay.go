@@ -0,0 +1,107 @@
+package functions
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/functions/generated/functions_coordinator"
+	evmrelayTypes "github.com/smartcontractkit/chainlink/v2/core/services/relay/evm/types"
+)
+
+// ReplayResult is the response the node would have computed for a request, see RequestReplayer.
+type ReplayResult struct {
+	RequestID         RequestID      `json:"requestId"`
+	TxHash            common.Hash    `json:"txHash"`
+	SubscriptionId    uint64         `json:"subscriptionId"`
+	SubscriptionOwner common.Address `json:"subscriptionOwner"`
+	// RequestData is the request as reconstructed from the onchain event, nil if its CBOR data can't be parsed.
+	RequestData *RequestData `json:"requestData,omitempty"`
+	Result      []byte       `json:"result,omitempty"`
+	// ErrorType is NONE if the request computed a result, Error is the error of the request otherwise.
+	ErrorType ErrType  `json:"errorType"`
+	Error     []byte   `json:"error,omitempty"`
+	Domains   []string `json:"domains,omitempty"`
+	// SecretsOmitted is true if the request has secrets, which are not passed to the replayed computation.
+	SecretsOmitted bool `json:"secretsOmitted,omitempty"`
+}
+
+// RequestReplayer re-executes Functions requests on the node as they would be when received, to debug
+// discrepancies in their fulfillment. Replays are not stored, transmitted nor reported: only the computation
+// runs again, through the external adapter of the node. The secrets of the request are not decrypted, as it
+// would take a round of threshold decryption by the DON: the computation runs without them.
+type RequestReplayer interface {
+	// ReplayTx replays the requests emitted by coordinators in the transaction.
+	ReplayTx(ctx context.Context, txHash common.Hash) ([]ReplayResult, error)
+	// ReplayRequest replays the request, as emitted onchain.
+	ReplayRequest(ctx context.Context, request *evmrelayTypes.OracleRequest) (*ReplayResult, error)
+}
+
+var _ RequestReplayer = &functionsListener{}
+
+func (l *functionsListener) ReplayTx(ctx context.Context, txHash common.Hash) ([]ReplayResult, error) {
+	receipt, err := l.client.TransactionReceipt(ctx, txHash)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to fetch receipt of tx %s", txHash)
+	}
+	topic := functions_coordinator.FunctionsCoordinatorOracleRequest{}.Topic()
+	var results []ReplayResult
+	for _, log := range receipt.Logs {
+		if len(log.Topics) == 0 || log.Topics[0] != topic {
+			continue
+		}
+		parsingContract, err := functions_coordinator.NewFunctionsCoordinator(log.Address, l.client)
+		if err != nil {
+			return nil, err
+		}
+		oracleRequest, err := parsingContract.ParseOracleRequest(*log)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse request log %d of tx %s", log.Index, txHash)
+		}
+		result, err := l.ReplayRequest(ctx, &evmrelayTypes.OracleRequest{
+			RequestId:           oracleRequest.RequestId,
+			RequestingContract:  oracleRequest.RequestingContract,
+			RequestInitiator:    oracleRequest.RequestInitiator,
+			SubscriptionId:      oracleRequest.SubscriptionId,
+			SubscriptionOwner:   oracleRequest.SubscriptionOwner,
+			Data:                oracleRequest.Data,
+			DataVersion:         oracleRequest.DataVersion,
+			Flags:               oracleRequest.Flags,
+			CallbackGasLimit:    oracleRequest.CallbackGasLimit,
+			TxHash:              txHash,
+			CoordinatorContract: log.Address,
+		})
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, *result)
+	}
+	if len(results) == 0 {
+		return nil, errors.Errorf("no requests in tx %s", txHash)
+	}
+	return results, nil
+}
+
+func (l *functionsListener) ReplayRequest(ctx context.Context, request *evmrelayTypes.OracleRequest) (*ReplayResult, error) {
+	l.logger.Infow("replaying request", "requestID", formatRequestId(request.RequestId), "txHash", request.TxHash)
+	result := &ReplayResult{
+		RequestID:         request.RequestId,
+		TxHash:            request.TxHash,
+		SubscriptionId:    request.SubscriptionId,
+		SubscriptionOwner: request.SubscriptionOwner,
+	}
+	requestData, err := l.parseCBOR(request.RequestId, request.Data, l.getMaxCBORsize(request.Flags))
+	if err != nil {
+		result.ErrorType, result.Error = USER_ERROR, []byte(err.Error())
+		return result, nil
+	}
+	result.RequestData = requestData
+	result.SecretsOmitted = len(requestData.Secrets) > 0
+	c, err := l.compute(ctx, request.RequestId, request.SubscriptionId, request.SubscriptionOwner, request.Flags, requestData, false)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to replay request %s", formatRequestId(request.RequestId))
+	}
+	result.Result, result.ErrorType, result.Error, result.Domains = c.result, c.errType, c.err, c.domains
+	return result, nil
+}
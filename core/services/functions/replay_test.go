@@ -0,0 +1,101 @@
+package functions_test
+
+import (
+	"testing"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	functions_service "github.com/smartcontractkit/chainlink/v2/core/services/functions"
+	"github.com/smartcontractkit/chainlink/v2/core/services/relay/evm/types"
+)
+
+func TestFunctionsListener_ReplayRequest(t *testing.T) {
+	testutils.SkipShortDB(t)
+	t.Parallel()
+
+	reqData := &struct {
+		Source string   `cbor:"source"`
+		Args   []string `cbor:"args"`
+	}{
+		Source: "return 1",
+		Args:   []string{"a"},
+	}
+	cborBytes, err := cbor.Marshal(reqData)
+	require.NoError(t, err)
+	// Remove first byte (map header) to make it "diet" CBOR
+	cborBytes = cborBytes[1:]
+	request := &types.OracleRequest{
+		RequestId:         RequestID,
+		SubscriptionId:    SubscriptionID,
+		SubscriptionOwner: SubscriptionOwner,
+		Flags:             packFlags(1, 0),
+		Data:              cborBytes,
+	}
+
+	t.Run("result", func(t *testing.T) {
+		uni := NewFunctionsListenerUniverse(t, 0, 1_000_000)
+		uni.bridgeAccessor.On("NewExternalAdapterClient", mock.Anything).Return(uni.eaClient, nil)
+		uni.eaClient.On("RunComputation", mock.Anything, RequestIDStr, mock.Anything, SubscriptionOwner.Hex(), SubscriptionID, mock.Anything, mock.Anything, mock.Anything).Return(ResultBytes, nil, Domains, nil)
+
+		// nothing is stored nor reported, as the ORM and the telemetry mocks have no expectations
+		result, err := uni.service.(functions_service.RequestReplayer).ReplayRequest(testutils.Context(t), request)
+		require.NoError(t, err)
+		require.Equal(t, "return 1", result.RequestData.Source)
+		require.Equal(t, []string{"a"}, result.RequestData.Args)
+		require.Equal(t, functions_service.NONE, result.ErrorType)
+		require.Equal(t, ResultBytes, result.Result)
+		require.Equal(t, Domains, result.Domains)
+	})
+
+	t.Run("computation error", func(t *testing.T) {
+		uni := NewFunctionsListenerUniverse(t, 0, 1_000_000)
+		uni.bridgeAccessor.On("NewExternalAdapterClient", mock.Anything).Return(uni.eaClient, nil)
+		uni.eaClient.On("RunComputation", mock.Anything, RequestIDStr, mock.Anything, SubscriptionOwner.Hex(), SubscriptionID, mock.Anything, mock.Anything, mock.Anything).Return(nil, ErrorBytes, nil, nil)
+
+		result, err := uni.service.(functions_service.RequestReplayer).ReplayRequest(testutils.Context(t), request)
+		require.NoError(t, err)
+		require.Equal(t, functions_service.USER_ERROR, result.ErrorType)
+		require.Equal(t, ErrorBytes, result.Error)
+		require.Empty(t, result.Result)
+	})
+
+	t.Run("secrets are not decrypted", func(t *testing.T) {
+		withSecrets, err := cbor.Marshal(&struct {
+			Source          string `cbor:"source"`
+			SecretsLocation int    `cbor:"secretsLocation"`
+			Secrets         []byte `cbor:"secrets"`
+		}{
+			Source:          "return 1",
+			SecretsLocation: 1,
+			Secrets:         []byte("secrets URL"),
+		})
+		require.NoError(t, err)
+		requestWithSecrets := *request
+		requestWithSecrets.Data = withSecrets[1:]
+		uni := NewFunctionsListenerUniverse(t, 0, 1_000_000)
+		uni.bridgeAccessor.On("NewExternalAdapterClient", mock.Anything).Return(uni.eaClient, nil)
+		// neither FetchEncryptedSecrets of the EA nor Decrypt of the DON are called
+		uni.eaClient.On("RunComputation", mock.Anything, RequestIDStr, mock.Anything, SubscriptionOwner.Hex(), SubscriptionID, mock.Anything, "", mock.Anything).Return(ResultBytes, nil, nil, nil)
+
+		result, err := uni.service.(functions_service.RequestReplayer).ReplayRequest(testutils.Context(t), &requestWithSecrets)
+		require.NoError(t, err)
+		require.True(t, result.SecretsOmitted)
+		require.Equal(t, ResultBytes, result.Result)
+	})
+
+	t.Run("CBOR too big", func(t *testing.T) {
+		uni := NewFunctionsListenerUniverse(t, 0, 1_000_000)
+		tooBig := *request
+		tooBig.Flags = packFlags(0, 0) // tier no 0 of request size, allows only for max 10 bytes
+		tooBig.Data = make([]byte, 20)
+
+		result, err := uni.service.(functions_service.RequestReplayer).ReplayRequest(testutils.Context(t), &tooBig)
+		require.NoError(t, err)
+		require.Nil(t, result.RequestData)
+		require.Equal(t, functions_service.USER_ERROR, result.ErrorType)
+		require.Equal(t, []byte("request too big (max 10 bytes)"), result.Error)
+	})
+}
@@ -28,6 +28,16 @@ type DONConfig struct {
 	HandlerConfig json.RawMessage
 	Members       []NodeConfig
 	F             int
+	// Not specifying UserRateLimiter config disables rate limiting of user messages to the DON
+	// by the Gateway. Handlers may additionally apply their own limits.
+	UserRateLimiter *RateLimiterConfig
+}
+
+type RateLimiterConfig struct {
+	GlobalRPS      float64 `json:"globalRPS"`
+	GlobalBurst    int     `json:"globalBurst"`
+	PerSenderRPS   float64 `json:"perSenderRPS"`
+	PerSenderBurst int     `json:"perSenderBurst"`
 }
 
 type NodeConfig struct {
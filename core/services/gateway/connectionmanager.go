@@ -108,7 +108,7 @@ func NewConnectionManager(gwConfig *config.GatewayConfig, clock clockwork.Clock,
 			if ok {
 				return nil, fmt.Errorf("duplicate node address %s in DON %s", nodeAddress, donConfig.DonId)
 			}
-			connWrapper := network.NewWSConnectionWrapper(lggr)
+			connWrapper := network.NewBatchingWSConnectionWrapper(lggr, gwConfig.NodeServerConfig.Batching)
 			if connWrapper == nil {
 				return nil, fmt.Errorf("error creating WSConnectionWrapper for node %s", nodeAddress)
 			}
@@ -4,6 +4,9 @@ import (
 	"github.com/smartcontractkit/chainlink/v2/core/services/gateway/network"
 )
 
+// ConnectorConfig configures the connections of a node to the Gateways of a DON. Compression and batching,
+// see network.WebSocketClientConfig, are negotiated with each Gateway: connections with legacy Gateways
+// stay uncompressed and unbatched.
 type ConnectorConfig struct {
 	NodeAddress               string
 	DonId                     string
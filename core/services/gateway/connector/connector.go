@@ -121,7 +121,7 @@ func NewGatewayConnector(config *ConnectorConfig, signer Signer, clock clockwork
 		}
 		l := lggr.With("URL", parsedURL)
 		gateway := &gatewayState{
-			conn:     network.NewWSConnectionWrapper(l),
+			conn:     network.NewBatchingWSConnectionWrapper(l, config.WsClientConfig.Batching),
 			config:   gw,
 			url:      parsedURL,
 			wsClient: network.NewWebSocketClient(config.WsClientConfig, connector, lggr),
@@ -69,6 +69,31 @@ URL = "ws://localhost:8081/node"
 	newTestConnector(t, tomlConfig)
 }
 
+func TestGatewayConnector_NewGatewayConnector_CompressionAndBatching(t *testing.T) {
+	t.Parallel()
+
+	tomlConfig := parseTOMLConfig(t, `
+NodeAddress = "0x68902d681c28119f9b2531473a417088bf008e59"
+DonId = "example_don"
+
+[WsClientConfig]
+EnableCompression = true
+
+[WsClientConfig.Batching]
+Enabled = true
+MaxBatchSize = 20
+FlushIntervalMillis = 5
+
+[[Gateways]]
+Id = "example_gateway"
+URL = "ws://localhost:8081/node"
+`)
+	require.True(t, tomlConfig.WsClientConfig.EnableCompression)
+	require.Equal(t, network.BatchingConfig{Enabled: true, MaxBatchSize: 20, FlushIntervalMillis: 5}, tomlConfig.WsClientConfig.Batching)
+
+	newTestConnector(t, tomlConfig)
+}
+
 func TestGatewayConnector_NewGatewayConnector_InvalidConfig(t *testing.T) {
 	t.Parallel()
 
@@ -3,6 +3,7 @@ package gateway
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -19,6 +20,7 @@ import (
 	"github.com/smartcontractkit/chainlink/v2/core/services/gateway/api"
 	"github.com/smartcontractkit/chainlink/v2/core/services/gateway/config"
 	"github.com/smartcontractkit/chainlink/v2/core/services/gateway/handlers"
+	hc "github.com/smartcontractkit/chainlink/v2/core/services/gateway/handlers/common"
 	gw_net "github.com/smartcontractkit/chainlink/v2/core/services/gateway/network"
 	"github.com/smartcontractkit/chainlink/v2/core/services/job"
 )
@@ -81,6 +83,13 @@ func NewGatewayFromConfig(config *config.GatewayConfig, handlerFactory HandlerFa
 		if err != nil {
 			return nil, err
 		}
+		if donConfig.UserRateLimiter != nil {
+			limiter, err := hc.NewRateLimiter(*donConfig.UserRateLimiter)
+			if err != nil {
+				return nil, fmt.Errorf("invalid user rate limiter of DON %s: %w", donConfig.DonId, err)
+			}
+			handler = hc.NewRateLimitedHandler(handler, limiter, donConfig.DonId)
+		}
 		handlerMap[donConfig.DonId] = handler
 		donConnMgr.SetHandler(handler)
 	}
@@ -147,6 +156,9 @@ func (g *gateway) ProcessRequest(ctx context.Context, rawRequest []byte) (rawRes
 	// send to the handler
 	responseCh := make(chan handlers.UserCallbackPayload, 1)
 	err = handler.HandleUserMessage(ctx, msg, responseCh)
+	if errors.Is(err, handlers.ErrRateLimited) {
+		return newError(g.codec, msg.Body.MessageId, api.RateLimitedError, err.Error())
+	}
 	if err != nil {
 		return newError(g.codec, msg.Body.MessageId, api.HandlerError, err.Error())
 	}
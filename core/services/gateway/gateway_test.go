@@ -125,6 +125,26 @@ Address = "0xnot_an_address"
 	require.Error(t, err)
 }
 
+func TestGateway_NewGatewayFromConfig_InvalidUserRateLimiter(t *testing.T) {
+	t.Parallel()
+
+	tomlConfig := buildConfig(`
+[[dons]]
+DonId = "my_don"
+HandlerName = "dummy"
+
+[dons.UserRateLimiter]
+GlobalRPS = 10.0
+GlobalBurst = 10
+PerSenderRPS = 0.0
+PerSenderBurst = 1
+`)
+
+	lggr := logger.TestLogger(t)
+	_, err := gateway.NewGatewayFromConfig(parseTOMLConfig(t, tomlConfig), gateway.NewHandlerFactory(nil, nil, nil, lggr), lggr)
+	require.Error(t, err)
+}
+
 func TestGateway_CleanStartAndClose(t *testing.T) {
 	t.Parallel()
 
@@ -245,3 +265,15 @@ func TestGateway_ProcessRequest_HandlerError(t *testing.T) {
 	requireJsonRPCError(t, response, "abcd", -32600, "failure")
 	require.Equal(t, 400, statusCode)
 }
+
+func TestGateway_ProcessRequest_HandlerRateLimited(t *testing.T) {
+	t.Parallel()
+
+	gw, handler := newGatewayWithMockHandler(t)
+	handler.On("HandleUserMessage", mock.Anything, mock.Anything, mock.Anything).Return(fmt.Errorf("%w: too many requests", handlers.ErrRateLimited))
+
+	req := newSignedRequest(t, "abcd", "request", "testDON", []byte{})
+	response, statusCode := gw.ProcessRequest(testutils.Context(t), req)
+	requireJsonRPCError(t, response, "abcd", -32005, "rate-limited: too many requests")
+	require.Equal(t, 429, statusCode)
+}
@@ -38,7 +38,7 @@ type handler struct {
 }
 
 type HandlerConfig struct {
-	NodeRateLimiter         common.RateLimiterConfig `json:"nodeRateLimiter"`
+	NodeRateLimiter         config.RateLimiterConfig `json:"nodeRateLimiter"`
 	MaxAllowedMessageAgeSec uint                     `json:"maxAllowedMessageAgeSec"`
 }
 
@@ -0,0 +1,44 @@
+package common
+
+import (
+	"context"
+	"errors"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/gateway/api"
+	"github.com/smartcontractkit/chainlink/v2/core/services/gateway/handlers"
+)
+
+var promUserRateLimited = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "gateway_user_rate_limited",
+	Help: "Metric to track user messages rejected by the rate limits of a DON",
+}, []string{"don_id", "limit"})
+
+type rateLimitedHandler struct {
+	handlers.Handler
+	limiter *RateLimiter
+	donId   string
+}
+
+var _ handlers.Handler = (*rateLimitedHandler)(nil)
+
+// NewRateLimitedHandler wraps the handler of a DON, rejecting the user messages exceeding the limits of the
+// limiter with ErrSenderRateLimited or ErrGlobalRateLimited before they reach the handler.
+// Messages from nodes are not limited.
+func NewRateLimitedHandler(handler handlers.Handler, limiter *RateLimiter, donId string) handlers.Handler {
+	return &rateLimitedHandler{Handler: handler, limiter: limiter, donId: donId}
+}
+
+func (h *rateLimitedHandler) HandleUserMessage(ctx context.Context, msg *api.Message, callbackCh chan<- handlers.UserCallbackPayload) error {
+	if err := h.limiter.Check(msg.Body.Sender); err != nil {
+		limit := "global"
+		if errors.Is(err, ErrSenderRateLimited) {
+			limit = "sender"
+		}
+		promUserRateLimited.WithLabelValues(h.donId, limit).Inc()
+		return err
+	}
+	return h.Handler.HandleUserMessage(ctx, msg, callbackCh)
+}
@@ -0,0 +1,46 @@
+package common_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/services/gateway/api"
+	"github.com/smartcontractkit/chainlink/v2/core/services/gateway/config"
+	"github.com/smartcontractkit/chainlink/v2/core/services/gateway/handlers"
+	"github.com/smartcontractkit/chainlink/v2/core/services/gateway/handlers/common"
+	handler_mocks "github.com/smartcontractkit/chainlink/v2/core/services/gateway/handlers/mocks"
+)
+
+func TestRateLimitedHandler(t *testing.T) {
+	t.Parallel()
+
+	rl, err := common.NewRateLimiter(config.RateLimiterConfig{
+		GlobalRPS:      1.0,
+		GlobalBurst:    2,
+		PerSenderRPS:   1.0,
+		PerSenderBurst: 1,
+	})
+	require.NoError(t, err)
+	handler := handler_mocks.NewHandler(t)
+	handler.On("HandleUserMessage", mock.Anything, mock.Anything, mock.Anything).Return(nil).Twice()
+	handler.On("HandleNodeMessage", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+	limited := common.NewRateLimitedHandler(handler, rl, "test_don")
+
+	ctx := testutils.Context(t)
+	callbackCh := make(chan handlers.UserCallbackPayload, 1)
+	user1 := &api.Message{Body: api.MessageBody{Sender: "0x1"}}
+	user2 := &api.Message{Body: api.MessageBody{Sender: "0x2"}}
+	user3 := &api.Message{Body: api.MessageBody{Sender: "0x3"}}
+	require.NoError(t, limited.HandleUserMessage(ctx, user1, callbackCh))
+	err = limited.HandleUserMessage(ctx, user1, callbackCh)
+	require.ErrorIs(t, err, common.ErrSenderRateLimited)
+	require.ErrorIs(t, err, handlers.ErrRateLimited)
+	require.NoError(t, limited.HandleUserMessage(ctx, user2, callbackCh))
+	require.ErrorIs(t, limited.HandleUserMessage(ctx, user3, callbackCh), common.ErrGlobalRateLimited)
+
+	// node messages are not limited
+	require.NoError(t, limited.HandleNodeMessage(ctx, user1, "0x4"))
+}
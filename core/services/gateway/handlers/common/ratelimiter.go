@@ -2,41 +2,49 @@ package common
 
 import (
 	"errors"
+	"fmt"
 	"sync"
 
 	"golang.org/x/time/rate"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/gateway/config"
+	"github.com/smartcontractkit/chainlink/v2/core/services/gateway/handlers"
+)
+
+var (
+	ErrSenderRateLimited = fmt.Errorf("%w: per-sender limit exceeded", handlers.ErrRateLimited)
+	ErrGlobalRateLimited = fmt.Errorf("%w: global limit exceeded", handlers.ErrRateLimited)
 )
 
 // Wrapper around Go's rate.Limiter that supports both global and a per-sender rate limiting.
 type RateLimiter struct {
 	global    *rate.Limiter
 	perSender map[string]*rate.Limiter
-	config    RateLimiterConfig
+	config    config.RateLimiterConfig
 	mu        sync.Mutex
 }
 
-type RateLimiterConfig struct {
-	GlobalRPS      float64 `json:"globalRPS"`
-	GlobalBurst    int     `json:"globalBurst"`
-	PerSenderRPS   float64 `json:"perSenderRPS"`
-	PerSenderBurst int     `json:"perSenderBurst"`
-}
-
-func NewRateLimiter(config RateLimiterConfig) (*RateLimiter, error) {
-	if config.GlobalRPS <= 0.0 || config.PerSenderRPS <= 0.0 {
+func NewRateLimiter(cfg config.RateLimiterConfig) (*RateLimiter, error) {
+	if cfg.GlobalRPS <= 0.0 || cfg.PerSenderRPS <= 0.0 {
 		return nil, errors.New("RPS values must be positive")
 	}
-	if config.GlobalBurst <= 0 || config.PerSenderBurst <= 0 {
+	if cfg.GlobalBurst <= 0 || cfg.PerSenderBurst <= 0 {
 		return nil, errors.New("burst values must be positive")
 	}
 	return &RateLimiter{
-		global:    rate.NewLimiter(rate.Limit(config.GlobalRPS), config.GlobalBurst),
+		global:    rate.NewLimiter(rate.Limit(cfg.GlobalRPS), cfg.GlobalBurst),
 		perSender: make(map[string]*rate.Limiter),
-		config:    config,
+		config:    cfg,
 	}, nil
 }
 
 func (rl *RateLimiter) Allow(sender string) bool {
+	return rl.Check(sender) == nil
+}
+
+// Check takes a token from the buckets of the sender and the global one, returning ErrSenderRateLimited or
+// ErrGlobalRateLimited if either is empty.
+func (rl *RateLimiter) Check(sender string) error {
 	rl.mu.Lock()
 	senderLimiter, ok := rl.perSender[sender]
 	if !ok {
@@ -45,5 +53,11 @@ func (rl *RateLimiter) Allow(sender string) bool {
 	}
 	rl.mu.Unlock()
 
-	return senderLimiter.Allow() && rl.global.Allow()
+	if !senderLimiter.Allow() {
+		return ErrSenderRateLimited
+	}
+	if !rl.global.Allow() {
+		return ErrGlobalRateLimited
+	}
+	return nil
 }
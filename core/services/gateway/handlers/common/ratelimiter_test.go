@@ -5,19 +5,20 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/smartcontractkit/chainlink/v2/core/services/gateway/config"
 	"github.com/smartcontractkit/chainlink/v2/core/services/gateway/handlers/common"
 )
 
 func TestRateLimiter_Simple(t *testing.T) {
 	t.Parallel()
 
-	config := common.RateLimiterConfig{
+	cfg := config.RateLimiterConfig{
 		GlobalRPS:      3.0,
 		GlobalBurst:    3,
 		PerSenderRPS:   1.0,
 		PerSenderBurst: 2,
 	}
-	rl, err := common.NewRateLimiter(config)
+	rl, err := common.NewRateLimiter(cfg)
 	require.NoError(t, err)
 	require.True(t, rl.Allow("user1"))
 	require.True(t, rl.Allow("user2"))
@@ -29,7 +29,7 @@ import (
 
 var (
 	ErrNotAllowlisted    = errors.New("sender not allowlisted")
-	ErrRateLimited       = errors.New("rate-limited")
+	ErrRateLimited       = handlers.ErrRateLimited
 	ErrUnsupportedMethod = errors.New("unsupported method")
 
 	promHandlerError = promauto.NewCounterVec(prometheus.CounterOpts{
@@ -66,11 +66,11 @@ type FunctionsHandlerConfig struct {
 	OnchainSubscriptions       *fsub.OnchainSubscriptionsConfig `json:"onchainSubscriptions"`
 	MinimumSubscriptionBalance *assets.Link                     `json:"minimumSubscriptionBalance"`
 	// Not specifying RateLimiter config disables rate limiting
-	UserRateLimiter            *hc.RateLimiterConfig `json:"userRateLimiter"`
-	NodeRateLimiter            *hc.RateLimiterConfig `json:"nodeRateLimiter"`
-	MaxPendingRequests         uint32                `json:"maxPendingRequests"`
-	RequestTimeoutMillis       int64                 `json:"requestTimeoutMillis"`
-	AllowedHeartbeatInitiators []string              `json:"allowedHeartbeatInitiators"`
+	UserRateLimiter            *config.RateLimiterConfig `json:"userRateLimiter"`
+	NodeRateLimiter            *config.RateLimiterConfig `json:"nodeRateLimiter"`
+	MaxPendingRequests         uint32                    `json:"maxPendingRequests"`
+	RequestTimeoutMillis       int64                     `json:"requestTimeoutMillis"`
+	AllowedHeartbeatInitiators []string                  `json:"allowedHeartbeatInitiators"`
 }
 
 type functionsHandler struct {
@@ -2,11 +2,16 @@ package handlers
 
 import (
 	"context"
+	"errors"
 
 	"github.com/smartcontractkit/chainlink/v2/core/services/gateway/api"
 	"github.com/smartcontractkit/chainlink/v2/core/services/job"
 )
 
+// ErrRateLimited is returned by HandleUserMessage, possibly wrapped, for user messages exceeding a rate limit.
+// The Gateway responds to them with a RateLimitedError.
+var ErrRateLimited = errors.New("rate-limited")
+
 // UserCallbackPayload is a response to user request sent to HandleUserMessage().
 // Each message needs to receive at most one response on the provided channel.
 type UserCallbackPayload struct {
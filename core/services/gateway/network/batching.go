@@ -0,0 +1,78 @@
+package network
+
+import (
+	"encoding/binary"
+	"errors"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// WsBatchingSubprotocol is the websocket subprotocol of connections whose binary messages, after the handshake,
+// are batch frames (see EncodeBatch). Only peers with batching enabled offer and accept it, so connections
+// with legacy peers keep exchanging one message per websocket message.
+const WsBatchingSubprotocol = "chainlink-gateway-batch.v1"
+
+const defaultBatchFlushIntervalMillis = 10
+
+var ErrInvalidBatch = errors.New("invalid batch frame")
+
+type BatchingConfig struct {
+	// Enabled offers batched framing of messages to the peer.
+	Enabled bool
+	// MaxBatchSize is the max number of messages of a batch, 0 for no limit.
+	MaxBatchSize uint32
+	// FlushIntervalMillis is how long messages are held back to be batched with the following ones,
+	// defaults to 10ms. Writes return once their batch is written.
+	FlushIntervalMillis uint32
+}
+
+func (c BatchingConfig) subprotocols() []string {
+	if !c.Enabled {
+		return nil
+	}
+	return []string{WsBatchingSubprotocol}
+}
+
+func (c BatchingConfig) flushInterval() time.Duration {
+	if c.FlushIntervalMillis == 0 {
+		return defaultBatchFlushIntervalMillis * time.Millisecond
+	}
+	return time.Duration(c.FlushIntervalMillis) * time.Millisecond
+}
+
+func isBatching(conn *websocket.Conn) bool {
+	return conn.Subprotocol() == WsBatchingSubprotocol
+}
+
+// EncodeBatch frames the messages as a sequence of messages, each prefixed by its 4-byte big-endian length.
+func EncodeBatch(msgs [][]byte) []byte {
+	size := 0
+	for _, msg := range msgs {
+		size += 4 + len(msg)
+	}
+	frame := make([]byte, 0, size)
+	for _, msg := range msgs {
+		frame = binary.BigEndian.AppendUint32(frame, uint32(len(msg)))
+		frame = append(frame, msg...)
+	}
+	return frame
+}
+
+// DecodeBatch splits a frame encoded by EncodeBatch into its messages.
+func DecodeBatch(frame []byte) ([][]byte, error) {
+	var msgs [][]byte
+	for len(frame) > 0 {
+		if len(frame) < 4 {
+			return nil, ErrInvalidBatch
+		}
+		msgLen := binary.BigEndian.Uint32(frame)
+		frame = frame[4:]
+		if uint64(len(frame)) < uint64(msgLen) {
+			return nil, ErrInvalidBatch
+		}
+		msgs = append(msgs, frame[:msgLen])
+		frame = frame[msgLen:]
+	}
+	return msgs, nil
+}
@@ -0,0 +1,118 @@
+package network_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/services/servicetest"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/gateway/network"
+)
+
+func TestBatch_EncodeDecode(t *testing.T) {
+	t.Parallel()
+
+	msgs := [][]byte{[]byte("first"), {}, []byte("third")}
+	decoded, err := network.DecodeBatch(network.EncodeBatch(msgs))
+	require.NoError(t, err)
+	require.Equal(t, msgs, decoded)
+
+	decoded, err = network.DecodeBatch(nil)
+	require.NoError(t, err)
+	require.Empty(t, decoded)
+
+	frame := network.EncodeBatch(msgs)
+	_, err = network.DecodeBatch(frame[:len(frame)-1])
+	require.ErrorIs(t, err, network.ErrInvalidBatch)
+	_, err = network.DecodeBatch([]byte{0, 0})
+	require.ErrorIs(t, err, network.ErrInvalidBatch)
+}
+
+// startRawServer returns the URL of a server handing the upgraded connections to the returned channel.
+func startRawServer(t *testing.T, upgrader *websocket.Upgrader) (string, <-chan *websocket.Conn) {
+	conns := make(chan *websocket.Conn, 1)
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		conns <- c
+	}))
+	t.Cleanup(s.Close)
+	return "ws" + strings.TrimPrefix(s.URL, "http"), conns
+}
+
+func TestWSConnectionWrapper_Batching(t *testing.T) {
+	t.Parallel()
+	lggr := logger.TestLogger(t)
+	serverURL, conns := startRawServer(t, &websocket.Upgrader{
+		EnableCompression: true,
+		Subprotocols:      []string{network.WsBatchingSubprotocol},
+	})
+
+	batching := network.BatchingConfig{Enabled: true, MaxBatchSize: 3, FlushIntervalMillis: 60_000}
+	clientConnWrapper := network.NewBatchingWSConnectionWrapper(lggr, batching)
+	servicetest.Run(t, clientConnWrapper)
+	dialer := &websocket.Dialer{EnableCompression: true, Subprotocols: []string{network.WsBatchingSubprotocol}}
+	conn, _, err := dialer.Dial(serverURL, nil)
+	require.NoError(t, err)
+	require.Equal(t, network.WsBatchingSubprotocol, conn.Subprotocol())
+	clientConnWrapper.Reset(conn)
+	serverConn := <-conns
+	defer serverConn.Close()
+
+	// writes are held back until the batch is full
+	msgs := []string{"first", "second", "third"}
+	errCh := make(chan error, len(msgs))
+	for _, msg := range msgs {
+		go func() {
+			errCh <- clientConnWrapper.Write(testutils.Context(t), websocket.BinaryMessage, []byte(msg))
+		}()
+	}
+	msgType, frame, err := serverConn.ReadMessage()
+	require.NoError(t, err)
+	require.Equal(t, websocket.BinaryMessage, msgType)
+	batch, err := network.DecodeBatch(frame)
+	require.NoError(t, err)
+	received := make([]string, len(batch))
+	for i, msg := range batch {
+		received[i] = string(msg)
+	}
+	require.ElementsMatch(t, msgs, received)
+	for range msgs {
+		require.NoError(t, <-errCh)
+	}
+
+	// batches are split on read
+	require.NoError(t, serverConn.WriteMessage(websocket.BinaryMessage, network.EncodeBatch([][]byte{[]byte("a"), []byte("b")})))
+	require.Equal(t, "a", string((<-clientConnWrapper.ReadChannel()).Data))
+	require.Equal(t, "b", string((<-clientConnWrapper.ReadChannel()).Data))
+}
+
+func TestWSConnectionWrapper_Batching_LegacyServer(t *testing.T) {
+	t.Parallel()
+	lggr := logger.TestLogger(t)
+	serverURL, conns := startRawServer(t, &websocket.Upgrader{})
+
+	clientConnWrapper := network.NewBatchingWSConnectionWrapper(lggr, network.BatchingConfig{Enabled: true, MaxBatchSize: 3})
+	servicetest.Run(t, clientConnWrapper)
+	dialer := &websocket.Dialer{EnableCompression: true, Subprotocols: []string{network.WsBatchingSubprotocol}}
+	conn, _, err := dialer.Dial(serverURL, nil)
+	require.NoError(t, err)
+	require.Empty(t, conn.Subprotocol())
+	clientConnWrapper.Reset(conn)
+	serverConn := <-conns
+	defer serverConn.Close()
+
+	// messages are written as they are
+	require.NoError(t, clientConnWrapper.Write(testutils.Context(t), websocket.BinaryMessage, []byte("hello")))
+	_, data, err := serverConn.ReadMessage()
+	require.NoError(t, err)
+	require.Equal(t, "hello", string(data))
+}
@@ -18,6 +18,11 @@ type WebSocketClient interface {
 
 type WebSocketClientConfig struct {
 	HandshakeTimeoutMillis uint32
+	// EnableCompression offers permessage-deflate compression to the server, servers not supporting it
+	// keep the connection uncompressed.
+	EnableCompression bool
+	// Batching offers batched framing of messages to the server, see WsBatchingSubprotocol.
+	Batching BatchingConfig
 }
 
 type webSocketClient struct {
@@ -28,7 +33,9 @@ type webSocketClient struct {
 
 func NewWebSocketClient(config WebSocketClientConfig, initiator ConnectionInitiator, lggr logger.Logger) WebSocketClient {
 	dialer := &websocket.Dialer{
-		HandshakeTimeout: time.Duration(config.HandshakeTimeoutMillis) * time.Millisecond,
+		HandshakeTimeout:  time.Duration(config.HandshakeTimeoutMillis) * time.Millisecond,
+		EnableCompression: config.EnableCompression,
+		Subprotocols:      config.Batching.subprotocols(),
 	}
 	client := &webSocketClient{
 		initiator: initiator,
@@ -69,6 +76,9 @@ func (c *webSocketClient) Connect(ctx context.Context, url *url.URL) (*websocket
 		return nil, err
 	}
 
+	c.lggr.Debugw("WebSocketClient: negotiated connection", "url", url.String(),
+		"compression", resp.Header.Get("Sec-Websocket-Extensions") != "", "batching", isBatching(conn))
+
 	response, err := c.initiator.ChallengeResponse(url, challenge)
 	if err != nil {
 		c.lggr.Error("WebSocketClient: couldn't generate challenge response; error: ", err)
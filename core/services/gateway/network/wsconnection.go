@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"sync/atomic"
+	"time"
 
 	"github.com/smartcontractkit/chainlink-common/pkg/services"
 
@@ -25,6 +26,8 @@ import (
 // This fits the Gateway very well as servers accept connections only from a fixed set of nodes
 // and conversely, nodes only connect to a fixed set of servers (Gateways).
 //
+// Binary messages of connections negotiating WsBatchingSubprotocol are batched on write and split on read.
+//
 // The concept of "pumps" is borrowed from https://github.com/smartcontractkit/wsrpc
 // All methods are thread-safe.
 type WSConnectionWrapper interface {
@@ -44,7 +47,8 @@ type wsConnectionWrapper struct {
 	services.StateMachine
 	lggr logger.Logger
 
-	conn atomic.Pointer[websocket.Conn]
+	conn     atomic.Pointer[websocket.Conn]
+	batching BatchingConfig
 
 	writeCh    chan writeItem
 	readCh     chan ReadItem
@@ -76,8 +80,15 @@ var (
 )
 
 func NewWSConnectionWrapper(lggr logger.Logger) WSConnectionWrapper {
+	return NewBatchingWSConnectionWrapper(lggr, BatchingConfig{})
+}
+
+// NewBatchingWSConnectionWrapper returns a WSConnectionWrapper batching the binary messages of connections
+// negotiating WsBatchingSubprotocol according to the config.
+func NewBatchingWSConnectionWrapper(lggr logger.Logger, batching BatchingConfig) WSConnectionWrapper {
 	cw := &wsConnectionWrapper{
 		lggr:       lggr.Named("WSConnectionWrapper"),
+		batching:   batching,
 		writeCh:    make(chan writeItem),
 		readCh:     make(chan ReadItem),
 		shutdownCh: make(chan struct{}),
@@ -147,24 +158,72 @@ func (c *wsConnectionWrapper) Close() error {
 }
 
 func (c *wsConnectionWrapper) writePump() {
+	var pending []writeItem
+	var flushCh <-chan time.Time
 	for {
 		select {
 		case wsMsg := <-c.writeCh:
 			// synchronization is a tradeoff for the ability to use a single write channel
 			conn := c.conn.Load()
-			if conn == nil {
-				wsMsg.ErrCh <- ErrNoActiveConnection
-				close(wsMsg.ErrCh)
+			if conn == nil || !isBatching(conn) || wsMsg.MsgType != websocket.BinaryMessage {
+				// keep the order of messages
+				c.write(pending)
+				pending, flushCh = nil, nil
+				c.write([]writeItem{wsMsg})
 				break
 			}
-			wsMsg.ErrCh <- conn.WriteMessage(wsMsg.MsgType, wsMsg.Data)
-			close(wsMsg.ErrCh)
+			pending = append(pending, wsMsg)
+			if c.batching.MaxBatchSize > 0 && len(pending) >= int(c.batching.MaxBatchSize) {
+				c.write(pending)
+				pending, flushCh = nil, nil
+			} else if flushCh == nil {
+				flushCh = time.After(c.batching.flushInterval())
+			}
+		case <-flushCh:
+			c.write(pending)
+			pending, flushCh = nil, nil
 		case <-c.shutdownCh:
+			for _, wsMsg := range pending {
+				wsMsg.ErrCh <- ErrWrapperShutdown
+				close(wsMsg.ErrCh)
+			}
 			return
 		}
 	}
 }
 
+// write writes the messages to the current connection, as a single batch frame if it is batching.
+func (c *wsConnectionWrapper) write(msgs []writeItem) {
+	if len(msgs) == 0 {
+		return
+	}
+	conn := c.conn.Load()
+	errs := make([]error, len(msgs))
+	switch {
+	case conn == nil:
+		for i := range errs {
+			errs[i] = ErrNoActiveConnection
+		}
+	case isBatching(conn) && msgs[0].MsgType == websocket.BinaryMessage:
+		data := make([][]byte, len(msgs))
+		for i, wsMsg := range msgs {
+			data[i] = wsMsg.Data
+		}
+		err := conn.WriteMessage(websocket.BinaryMessage, EncodeBatch(data))
+		for i := range errs {
+			errs[i] = err
+		}
+	default:
+		for i, wsMsg := range msgs {
+			errs[i] = conn.WriteMessage(wsMsg.MsgType, wsMsg.Data)
+		}
+	}
+	for i, wsMsg := range msgs {
+		wsMsg.ErrCh <- errs[i]
+		close(wsMsg.ErrCh)
+	}
+}
+
 func (c *wsConnectionWrapper) readPump(conn *websocket.Conn, closeCh chan<- error) {
 	for {
 		msgType, data, err := conn.ReadMessage()
@@ -173,12 +232,26 @@ func (c *wsConnectionWrapper) readPump(conn *websocket.Conn, closeCh chan<- erro
 			close(closeCh)
 			return
 		}
-		select {
-		case c.readCh <- ReadItem{msgType, data}:
-		case <-c.shutdownCh:
-			closeCh <- conn.Close()
-			close(closeCh)
-			return
+		items := []ReadItem{{msgType, data}}
+		if msgType == websocket.BinaryMessage && isBatching(conn) {
+			msgs, err := DecodeBatch(data)
+			if err != nil {
+				c.lggr.Errorw("dropping invalid batch frame", "err", err)
+				continue
+			}
+			items = items[:0]
+			for _, msg := range msgs {
+				items = append(items, ReadItem{msgType, msg})
+			}
+		}
+		for _, item := range items {
+			select {
+			case c.readCh <- item:
+			case <-c.shutdownCh:
+				closeCh <- conn.Close()
+				close(closeCh)
+				return
+			}
 		}
 	}
 }
@@ -25,6 +25,10 @@ type WebSocketServer interface {
 type WebSocketServerConfig struct {
 	HTTPServerConfig
 	HandshakeTimeoutMillis uint32
+	// EnableCompression accepts permessage-deflate compression offered by clients.
+	EnableCompression bool
+	// Batching accepts batched framing of messages offered by clients, see WsBatchingSubprotocol.
+	Batching BatchingConfig
 }
 
 type webSocketServer struct {
@@ -42,7 +46,9 @@ type webSocketServer struct {
 func NewWebSocketServer(config *WebSocketServerConfig, acceptor ConnectionAcceptor, lggr logger.Logger) WebSocketServer {
 	baseCtx, cancelBaseCtx := context.WithCancel(context.Background())
 	upgrader := &websocket.Upgrader{
-		HandshakeTimeout: time.Duration(config.HandshakeTimeoutMillis) * time.Millisecond,
+		HandshakeTimeout:  time.Duration(config.HandshakeTimeoutMillis) * time.Millisecond,
+		EnableCompression: config.EnableCompression,
+		Subprotocols:      config.Batching.subprotocols(),
 	}
 	server := &webSocketServer{
 		config:            config,
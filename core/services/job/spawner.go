@@ -13,6 +13,7 @@ import (
 	"github.com/smartcontractkit/chainlink-common/pkg/sqlutil"
 	"github.com/smartcontractkit/chainlink-common/pkg/utils"
 
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/logpoller"
 	"github.com/smartcontractkit/chainlink/v2/core/logger"
 )
 
@@ -207,6 +208,8 @@ func (js *spawner) StartService(ctx context.Context, jb Job) error {
 		jb.PipelineSpec.GasLimit = &jb.GasLimit.Uint32
 	}
 
+	// the LogPoller filters registered by the services of the job are attributed to it
+	ctx = logpoller.WithJobID(ctx, jb.ID)
 	srvs, err := delegate.ServicesForSpec(ctx, jb)
 	if err != nil {
 		lggr.Errorw("Error creating services for job", "err", err)
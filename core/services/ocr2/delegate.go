@@ -123,6 +123,7 @@ type Delegate struct {
 	isNewlyCreatedJob     bool // Set to true if this is a new job freshly added, false if job was present already on node boot.
 	mailMon               *mailbox.Monitor
 	retirementReportCache llo.RetirementReportCache
+	functionsAdmin        *functions.AdminRegistry
 
 	legacyChains         legacyevm.LegacyChainContainer // legacy: use relayers instead
 	capabilitiesRegistry core.CapabilitiesRegistry
@@ -232,6 +233,8 @@ type DelegateOpts struct {
 	MailMon               *mailbox.Monitor
 	CapabilitiesRegistry  core.CapabilitiesRegistry
 	RetirementReportCache llo.RetirementReportCache
+	// FunctionsAdmin optionally registers the services of the Functions jobs for the admin endpoints.
+	FunctionsAdmin *functions.AdminRegistry
 }
 
 func NewDelegate(
@@ -257,6 +260,7 @@ func NewDelegate(
 		mailMon:               opts.MailMon,
 		capabilitiesRegistry:  opts.CapabilitiesRegistry,
 		retirementReportCache: opts.RetirementReportCache,
+		functionsAdmin:        opts.FunctionsAdmin,
 	}
 }
 
@@ -1581,6 +1585,7 @@ func (d *Delegate) newServicesOCR2Functions(
 		EthKeystore:       d.ethKs,
 		ThresholdKeyShare: thresholdKeyShare,
 		LogPollerWrapper:  functionsProvider.LogPollerWrapper(),
+		AdminRegistry:     d.functionsAdmin,
 	}
 
 	functionsServices, err := functions.NewFunctionsServices(ctx, &functionsOracleArgs, &thresholdOracleArgs, &s4OracleArgs, &functionsServicesConfig)
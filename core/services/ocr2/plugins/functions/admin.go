@@ -0,0 +1,72 @@
+package functions
+
+import (
+	"context"
+	"net/http"
+	"sync"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/functions"
+	"github.com/smartcontractkit/chainlink/v2/core/services/job"
+	s4_plugin "github.com/smartcontractkit/chainlink/v2/core/services/ocr2/plugins/s4"
+)
+
+// JobAdmin are the services of a running Functions job which the admins of the node can inspect and act on.
+// Services which are disabled by the config of the job are nil.
+type JobAdmin struct {
+	S4Reconciler *s4_plugin.Reconciler
+	Replayer     functions.RequestReplayer
+	// DecryptionQueue serves the state of the threshold decryption queue and evicts its ciphertexts.
+	DecryptionQueue http.Handler
+}
+
+// AdminRegistry holds the JobAdmin of the Functions jobs running on the node, by job ID.
+type AdminRegistry struct {
+	mu   sync.RWMutex
+	jobs map[int32]JobAdmin
+}
+
+func NewAdminRegistry() *AdminRegistry {
+	return &AdminRegistry{jobs: make(map[int32]JobAdmin)}
+}
+
+// Register registers the services of the job, until the returned function is called.
+func (r *AdminRegistry) Register(jobID int32, admin JobAdmin) (unregister func()) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.jobs[jobID] = admin
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		delete(r.jobs, jobID)
+	}
+}
+
+// Get returns the services of the job, false if it isn't a running Functions job.
+func (r *AdminRegistry) Get(jobID int32) (JobAdmin, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	admin, ok := r.jobs[jobID]
+	return admin, ok
+}
+
+// adminRegistration registers the services of a job for as long as the job runs.
+type adminRegistration struct {
+	registry   *AdminRegistry
+	jobID      int32
+	admin      JobAdmin
+	unregister func()
+}
+
+var _ job.ServiceCtx = &adminRegistration{}
+
+func (a *adminRegistration) Start(context.Context) error {
+	a.unregister = a.registry.Register(a.jobID, a.admin)
+	return nil
+}
+
+func (a *adminRegistration) Close() error {
+	if a.unregister != nil {
+		a.unregister()
+	}
+	return nil
+}
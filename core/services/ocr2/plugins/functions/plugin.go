@@ -49,6 +49,8 @@ type FunctionsServicesConfig struct {
 	EthKeystore       keystore.Eth
 	ThresholdKeyShare []byte
 	LogPollerWrapper  evmrelayTypes.LogPollerWrapper
+	// AdminRegistry optionally registers the services of the job for the admin endpoints of the node.
+	AdminRegistry *AdminRegistry
 }
 
 const (
@@ -74,11 +76,13 @@ func NewFunctionsServices(ctx context.Context, functionsOracleArgs, thresholdOra
 	}
 
 	allServices := []job.ServiceCtx{}
+	var admin JobAdmin
 
 	var decryptor threshold.Decryptor
 	// thresholdOracleArgs nil check will be removed once the Threshold plugin is fully integrated w/ Functions
 	if len(conf.ThresholdKeyShare) > 0 && thresholdOracleArgs != nil && pluginConfig.DecryptionQueueConfig != nil {
-		decryptionQueue := threshold.NewDecryptionQueue(
+		decryptionQueue := threshold.NewNamedDecryptionQueue(
+			conf.ContractID,
 			int(pluginConfig.DecryptionQueueConfig.MaxQueueLength),
 			int(pluginConfig.DecryptionQueueConfig.MaxCiphertextBytes),
 			int(pluginConfig.DecryptionQueueConfig.MaxCiphertextIdLength),
@@ -86,6 +90,7 @@ func NewFunctionsServices(ctx context.Context, functionsOracleArgs, thresholdOra
 			conf.Logger.Named("DecryptionQueue"),
 		)
 		decryptor = decryptionQueue
+		admin.DecryptionQueue = decryptionQueue
 		thresholdServicesConfig := threshold.ThresholdServicesConfig{
 			DecryptionQueue:    decryptionQueue,
 			KeyshareWithPubKey: conf.ThresholdKeyShare,
@@ -139,6 +144,7 @@ func NewFunctionsServices(ctx context.Context, functionsOracleArgs, thresholdOra
 		conf.LogPollerWrapper,
 	)
 	allServices = append(allServices, functionsListener)
+	admin.Replayer = functionsListener
 
 	functionsOracleArgs.ReportingPluginFactory = FunctionsReportingPluginFactory{
 		Logger:              functionsOracleArgs.Logger,
@@ -186,10 +192,12 @@ func NewFunctionsServices(ctx context.Context, functionsOracleArgs, thresholdOra
 	}
 
 	if s4OracleArgs != nil && pluginConfig.S4Constraints != nil {
+		admin.S4Reconciler = s4_plugin.NewReconciler()
 		s4OracleArgs.ReportingPluginFactory = s4_plugin.S4ReportingPluginFactory{
 			Logger:        s4OracleArgs.Logger,
 			ORM:           s4ORM,
 			ConfigDecoder: config.S4ConfigDecoder,
+			Reconciler:    admin.S4Reconciler,
 		}
 		s4ReportingPluginOracle, err := libocr2.NewOracle(*s4OracleArgs)
 		if err != nil {
@@ -200,6 +208,10 @@ func NewFunctionsServices(ctx context.Context, functionsOracleArgs, thresholdOra
 		listenerLogger.Warn("s4OracleArgs is nil or S4Constraints are not configured. S4 plugin is disabled.")
 	}
 
+	if conf.AdminRegistry != nil {
+		allServices = append(allServices, &adminRegistration{registry: conf.AdminRegistry, jobID: conf.Job.ID, admin: admin})
+	}
+
 	return allServices, nil
 }
 
@@ -17,6 +17,8 @@ type S4ReportingPluginFactory struct {
 	Logger        commontypes.Logger
 	ORM           s4_orm.ORM
 	ConfigDecoder PluginConfigDecoder
+	// Reconciler is shared by the plugins created by the factory, optional.
+	Reconciler *Reconciler
 }
 
 var _ types.ReportingPluginFactory = (*S4ReportingPluginFactory)(nil)
@@ -35,7 +37,11 @@ func (f S4ReportingPluginFactory) NewReportingPlugin(ctx context.Context, rpConf
 		UniqueReports: false,
 		Limits:        *limits,
 	}
-	plugin, err := NewReportingPlugin(f.Logger, config, f.ORM)
+	reconciler := f.Reconciler
+	if reconciler == nil {
+		reconciler = NewReconciler()
+	}
+	plugin, err := NewReportingPluginWithReconciler(f.Logger, config, f.ORM, reconciler)
 	if err != nil {
 		f.Logger.Error("unable to create S4 reporting plugin", commontypes.LogFields{})
 		return nil, types.ReportingPluginInfo{}, err
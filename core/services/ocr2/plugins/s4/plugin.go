@@ -32,6 +32,7 @@ type plugin struct {
 	config       *PluginConfig
 	orm          s4.ORM
 	addressRange *s4.AddressRange
+	reconciler   *Reconciler
 }
 
 type key struct {
@@ -42,6 +43,15 @@ type key struct {
 var _ types.ReportingPlugin = (*plugin)(nil)
 
 func NewReportingPlugin(logger commontypes.Logger, config *PluginConfig, orm s4.ORM) (types.ReportingPlugin, error) {
+	return NewReportingPluginWithReconciler(logger, config, orm, NewReconciler())
+}
+
+// NewReportingPluginWithReconciler returns a reporting plugin recording the divergence of the node snapshot
+// to the reconciler and running its repairs.
+func NewReportingPluginWithReconciler(logger commontypes.Logger, config *PluginConfig, orm s4.ORM, reconciler *Reconciler) (types.ReportingPlugin, error) {
+	if reconciler == nil {
+		return nil, errors.New("reconciler cannot be nil")
+	}
 	if config.MaxObservationEntries == 0 {
 		return nil, errors.New("max number of observation entries cannot be zero")
 	}
@@ -62,30 +72,41 @@ func NewReportingPlugin(logger commontypes.Logger, config *PluginConfig, orm s4.
 		config:       config,
 		orm:          orm,
 		addressRange: addressRange,
+		reconciler:   reconciler,
 	}, nil
 }
 
 func (c *plugin) Query(ctx context.Context, ts types.ReportTimestamp) (types.Query, error) {
 	promReportingPluginQuery.WithLabelValues(c.config.ProductName).Inc()
 
-	snapshot, err := c.orm.GetSnapshot(ctx, c.addressRange)
+	// A repair queries its address range instead of the next shard, leaving out the rows not re-synced yet
+	// so that the other nodes observe them as missing.
+	addressRange := c.addressRange
+	repair := c.reconciler.nextRepair()
+	if repair != nil {
+		addressRange = repair.addressRange
+	}
+
+	snapshot, err := c.orm.GetSnapshot(ctx, addressRange)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to GetVersions in Query()")
 	}
 
 	var storageTotalByteSize uint64
-	rows := make([]*SnapshotRow, len(snapshot))
-	for i, v := range snapshot {
-		rows[i] = &SnapshotRow{
+	rows := make([]*SnapshotRow, 0, len(snapshot))
+	for _, v := range snapshot {
+		storageTotalByteSize += v.PayloadSize
+		if repair != nil && !c.reconciler.isRepaired(repair, v.Address, v.SlotId) {
+			continue
+		}
+		rows = append(rows, &SnapshotRow{
 			Address: v.Address.Bytes(),
 			Slotid:  uint32(v.SlotId),
 			Version: v.Version,
-		}
-
-		storageTotalByteSize += v.PayloadSize
+		})
 	}
 
-	queryBytes, err := MarshalQuery(rows, c.addressRange)
+	queryBytes, err := MarshalQuery(rows, addressRange)
 	if err != nil {
 		return nil, err
 	}
@@ -95,12 +116,15 @@ func (c *plugin) Query(ctx context.Context, ts types.ReportTimestamp) (types.Que
 
 	promStorageTotalByteSize.WithLabelValues().Set(float64(storageTotalByteSize))
 
-	c.addressRange.Advance()
+	if repair == nil {
+		c.addressRange.Advance()
+	}
 
 	c.logger.Debug("S4StorageReporting Query", commontypes.LogFields{
 		"epoch":         ts.Epoch,
 		"round":         ts.Round,
 		"nSnapshotRows": len(rows),
+		"repair":        repair != nil,
 	})
 
 	return queryBytes, err
@@ -147,6 +171,7 @@ func (c *plugin) Observation(ctx context.Context, ts types.ReportTimestamp, quer
 			}
 
 			snapshotVersionsMap := snapshotToVersionMap(snapshot)
+			c.recordDivergence(addressRange, queryRows, snapshotVersionsMap)
 			toBeAdded := make([]rkey, 0)
 			// Add rows from query snapshot that have a higher version locally.
 			for _, qr := range queryRows {
@@ -288,6 +313,9 @@ func (c *plugin) ShouldAcceptFinalizedReport(ctx context.Context, ts types.Repor
 			continue
 		}
 		promStoragePluginUpdatesCount.WithLabelValues().Inc()
+		if c.reconciler.markSynced(ormRow.Address, ormRow.SlotId) {
+			promReportingPluginsRepairedRows.WithLabelValues(c.config.ProductName).Inc()
+		}
 	}
 
 	c.logger.Debug("S4StorageReporting ShouldAcceptFinalizedReport", commontypes.LogFields{
@@ -308,6 +336,24 @@ func (c *plugin) Close() error {
 	return nil
 }
 
+// recordDivergence compares the query snapshot of the DON with the confirmed rows of the node.
+func (c *plugin) recordDivergence(addressRange *s4.AddressRange, queryRows []*SnapshotRow, snapshotVersionsMap map[key]uint64) {
+	var missing, outdated uint
+	for _, qr := range queryRows {
+		version, ok := snapshotVersionsMap[key{address: UnmarshalAddress(qr.Address).String(), slotID: uint(qr.Slotid)}]
+		switch {
+		case !ok:
+			missing++
+		case version < qr.Version:
+			outdated++
+		}
+	}
+	totalMissing, totalOutdated, lag := c.reconciler.recordDivergence(addressRange, missing, outdated, time.Now().UTC())
+	promReportingPluginsSnapshotMissingRows.WithLabelValues(c.config.ProductName).Set(float64(totalMissing))
+	promReportingPluginsSnapshotOutdatedRows.WithLabelValues(c.config.ProductName).Set(float64(totalOutdated))
+	promReportingPluginsReplicationLag.WithLabelValues(c.config.ProductName).Set(lag.Seconds())
+}
+
 func convertRow(from *s4.Row) *Row {
 	return &Row{
 		Address:    from.Address.Bytes(),
@@ -55,4 +55,24 @@ var (
 		Name: "s4_reporting_plugin_expired_rows",
 		Help: "Metric to track number of expired rows",
 	}, []string{"product"})
+
+	promReportingPluginsSnapshotMissingRows = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "s4_reporting_plugin_snapshot_missing_rows",
+		Help: "Metric to track number of rows of the DON snapshot missing from the node snapshot",
+	}, []string{"product"})
+
+	promReportingPluginsSnapshotOutdatedRows = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "s4_reporting_plugin_snapshot_outdated_rows",
+		Help: "Metric to track number of rows of the node snapshot with a lower version than the DON snapshot",
+	}, []string{"product"})
+
+	promReportingPluginsReplicationLag = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "s4_reporting_plugin_replication_lag_seconds",
+		Help: "Metric to track for how long the node snapshot has been diverging from the DON snapshot",
+	}, []string{"product"})
+
+	promReportingPluginsRepairedRows = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "s4_reporting_plugin_repaired_rows",
+		Help: "Metric to track number of rows re-synced by repairs",
+	}, []string{"product"})
 )
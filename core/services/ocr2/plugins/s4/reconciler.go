@@ -0,0 +1,232 @@
+package s4
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+
+	ubig "github.com/smartcontractkit/chainlink/v2/core/chains/evm/utils/big"
+	"github.com/smartcontractkit/chainlink/v2/core/services/s4"
+)
+
+// A repair is complete once no row of its range was re-synced in this many of its rounds.
+const repairMaxIdleRounds = 3
+
+// SnapshotDivergence is how the snapshot of the node diverged from the one of the DON over an address range,
+// when last compared. The snapshot of the DON is the one the leader of the round queries with.
+type SnapshotDivergence struct {
+	MinAddress common.Address `json:"minAddress"`
+	MaxAddress common.Address `json:"maxAddress"`
+	// MissingRows are the rows of the DON snapshot the node doesn't have.
+	MissingRows uint `json:"missingRows"`
+	// OutdatedRows are the rows the node has a lower version of than the DON snapshot.
+	OutdatedRows uint      `json:"outdatedRows"`
+	ComparedAt   time.Time `json:"comparedAt"`
+	// DivergentSince is when the address range stopped being in sync, zero while it is.
+	DivergentSince time.Time `json:"divergentSince"`
+}
+
+// Repair is a pending re-sync of an address range, see Reconciler.Repair.
+type Repair struct {
+	MinAddress common.Address `json:"minAddress"`
+	MaxAddress common.Address `json:"maxAddress"`
+	// RepairedRows are the rows of the address range re-synced so far.
+	RepairedRows int       `json:"repairedRows"`
+	RequestedAt  time.Time `json:"requestedAt"`
+}
+
+type repair struct {
+	addressRange   *s4.AddressRange
+	repaired       map[key]struct{}
+	repairedBefore int
+	idleRounds     int
+	requestedAt    time.Time
+}
+
+// Reconciler is the reconciliation API of the S4 reporting plugin of a node. It reports the divergence of the
+// snapshot of the node from the one of the DON, and forces full re-syncs of address ranges.
+// All methods are thread-safe.
+type Reconciler struct {
+	mu         sync.Mutex
+	divergence map[string]*SnapshotDivergence
+	repairs    []*repair
+}
+
+func NewReconciler() *Reconciler {
+	return &Reconciler{divergence: make(map[string]*SnapshotDivergence)}
+}
+
+// Divergence returns the divergence of every address range compared so far, in ascending order.
+func (r *Reconciler) Divergence() []SnapshotDivergence {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	divergence := make([]SnapshotDivergence, 0, len(r.divergence))
+	for _, d := range r.divergence {
+		divergence = append(divergence, *d)
+	}
+	sort.Slice(divergence, func(i, j int) bool {
+		return divergence[i].MinAddress.Cmp(divergence[j].MinAddress) < 0
+	})
+	return divergence
+}
+
+// Repair forces a full re-sync of the address range, nil for the full address space: the node re-fetches
+// the confirmed rows of the range from the other nodes, overwriting its own copies of the same version.
+// Repairs progress in the rounds led by the node, one at a time.
+func (r *Reconciler) Repair(addressRange *s4.AddressRange) error {
+	if addressRange == nil {
+		addressRange = s4.NewFullAddressRange()
+	}
+	if addressRange.MinAddress == nil || addressRange.MaxAddress == nil || addressRange.MinAddress.Cmp(addressRange.MaxAddress) > 0 {
+		return errors.New("invalid address range")
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, rp := range r.repairs {
+		if rp.addressRange.MinAddress.Cmp(addressRange.MinAddress) == 0 && rp.addressRange.MaxAddress.Cmp(addressRange.MaxAddress) == 0 {
+			return nil
+		}
+	}
+	r.repairs = append(r.repairs, &repair{
+		addressRange:   &s4.AddressRange{MinAddress: addressRange.MinAddress, MaxAddress: addressRange.MaxAddress},
+		repaired:       make(map[key]struct{}),
+		repairedBefore: -1,
+		requestedAt:    time.Now().UTC(),
+	})
+	return nil
+}
+
+// PendingRepairs returns the repairs in the order they progress.
+func (r *Reconciler) PendingRepairs() []Repair {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	repairs := make([]Repair, len(r.repairs))
+	for i, rp := range r.repairs {
+		repairs[i] = Repair{
+			MinAddress:   common.BigToAddress(rp.addressRange.MinAddress.ToInt()),
+			MaxAddress:   common.BigToAddress(rp.addressRange.MaxAddress.ToInt()),
+			RepairedRows: len(rp.repaired),
+			RequestedAt:  rp.requestedAt,
+		}
+	}
+	return repairs
+}
+
+type reconciliationResponse struct {
+	Divergence     []SnapshotDivergence `json:"divergence"`
+	PendingRepairs []Repair             `json:"pendingRepairs"`
+}
+
+// RepairRequest is the body of a repair request to the reconciliation endpoint.
+type RepairRequest struct {
+	MinAddress common.Address `json:"minAddress"`
+	MaxAddress common.Address `json:"maxAddress"`
+}
+
+// ServeHTTP serves the Reconciler as an endpoint: GET returns the divergence and the pending repairs,
+// POST with a RepairRequest body schedules a repair.
+func (r *Reconciler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	switch req.Method {
+	case http.MethodGet:
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(reconciliationResponse{
+			Divergence:     r.Divergence(),
+			PendingRepairs: r.PendingRepairs(),
+		})
+	case http.MethodPost:
+		var repairReq RepairRequest
+		if err := json.NewDecoder(req.Body).Decode(&repairReq); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		addressRange := &s4.AddressRange{
+			MinAddress: ubig.New(repairReq.MinAddress.Big()),
+			MaxAddress: ubig.New(repairReq.MaxAddress.Big()),
+		}
+		if err := r.Repair(addressRange); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// recordDivergence records the divergence of the address range, returning the divergence summed over all the
+// address ranges and the replication lag, which is how long the longest divergent address range has been.
+func (r *Reconciler) recordDivergence(addressRange *s4.AddressRange, missing, outdated uint, now time.Time) (totalMissing, totalOutdated uint, lag time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rangeKey := addressRange.MinAddress.String() + "-" + addressRange.MaxAddress.String()
+	d, ok := r.divergence[rangeKey]
+	if !ok {
+		d = &SnapshotDivergence{
+			MinAddress: common.BigToAddress(addressRange.MinAddress.ToInt()),
+			MaxAddress: common.BigToAddress(addressRange.MaxAddress.ToInt()),
+		}
+		r.divergence[rangeKey] = d
+	}
+	d.MissingRows, d.OutdatedRows, d.ComparedAt = missing, outdated, now
+	switch {
+	case missing+outdated == 0:
+		d.DivergentSince = time.Time{}
+	case d.DivergentSince.IsZero():
+		d.DivergentSince = now
+	}
+	for _, d := range r.divergence {
+		totalMissing += d.MissingRows
+		totalOutdated += d.OutdatedRows
+		if !d.DivergentSince.IsZero() && now.Sub(d.DivergentSince) > lag {
+			lag = now.Sub(d.DivergentSince)
+		}
+	}
+	return
+}
+
+// nextRepair returns the pending repair to query for instead of the next shard, nil if there is none.
+// Repairs idle for repairMaxIdleRounds are complete.
+func (r *Reconciler) nextRepair() *repair {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for len(r.repairs) > 0 {
+		rp := r.repairs[0]
+		if len(rp.repaired) == rp.repairedBefore {
+			rp.idleRounds++
+		} else {
+			rp.idleRounds = 0
+		}
+		rp.repairedBefore = len(rp.repaired)
+		if rp.idleRounds < repairMaxIdleRounds {
+			return rp
+		}
+		r.repairs = r.repairs[1:]
+	}
+	return nil
+}
+
+// isRepaired reports whether the row was re-synced by the repair, to advertise it in the queries of the repair.
+func (r *Reconciler) isRepaired(rp *repair, address *ubig.Big, slotID uint) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, ok := rp.repaired[key{address: address.String(), slotID: slotID}]
+	return ok
+}
+
+// markSynced marks the row as re-synced by the repairs of its address.
+func (r *Reconciler) markSynced(address *ubig.Big, slotID uint) (repaired bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, rp := range r.repairs {
+		if rp.addressRange.Contains(address) {
+			rp.repaired[key{address: address.String(), slotID: slotID}] = struct{}{}
+			repaired = true
+		}
+	}
+	return
+}
@@ -0,0 +1,154 @@
+package s4_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/protobuf/proto"
+
+	commonlogger "github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/ocr2/plugins/s4"
+	s4_svc "github.com/smartcontractkit/chainlink/v2/core/services/s4"
+	s4_mocks "github.com/smartcontractkit/chainlink/v2/core/services/s4/mocks"
+)
+
+func TestReconciler_Divergence(t *testing.T) {
+	t.Parallel()
+
+	logger := commonlogger.NewOCRWrapper(logger.TestLogger(t), true, func(msg string) {})
+	config := createPluginConfig(10)
+	orm := s4_mocks.NewORM(t)
+	reconciler := s4.NewReconciler()
+	plugin, err := s4.NewReportingPluginWithReconciler(logger, config, orm, reconciler)
+	assert.NoError(t, err)
+
+	ormRows := generateConfirmedTestOrmRows(t, 3, time.Minute)
+	snapshot := rowsToShapshotRows(ormRows[:2])
+	for _, row := range snapshot {
+		row.Confirmed = true
+	}
+	// Query snapshot has:
+	//   - First entry with same version
+	//   - Second entry with higher version
+	//   - Third entry missing locally
+	query := &s4.Query{}
+	for i, row := range ormRows {
+		version := row.Version
+		if i == 1 {
+			version++
+		}
+		query.Rows = append(query.Rows, &s4.SnapshotRow{Address: row.Address.Bytes(), Slotid: uint32(row.SlotId), Version: version})
+	}
+	queryBytes, err := proto.Marshal(query)
+	assert.NoError(t, err)
+
+	orm.On("DeleteExpired", mock.Anything, uint(10), mock.Anything, mock.Anything).Return(int64(0), nil).Once()
+	orm.On("GetUnconfirmedRows", mock.Anything, config.MaxObservationEntries).Return([]*s4_svc.Row{}, nil).Once()
+	orm.On("GetSnapshot", mock.Anything, mock.Anything).Return(snapshot, nil).Once()
+
+	_, err = plugin.Observation(testutils.Context(t), types.ReportTimestamp{}, queryBytes)
+	assert.NoError(t, err)
+
+	divergence := reconciler.Divergence()
+	assert.Len(t, divergence, 1)
+	assert.Equal(t, common.BigToAddress(s4_svc.MinAddress.ToInt()), divergence[0].MinAddress)
+	assert.Equal(t, common.BigToAddress(s4_svc.MaxAddress.ToInt()), divergence[0].MaxAddress)
+	assert.Equal(t, uint(1), divergence[0].MissingRows)
+	assert.Equal(t, uint(1), divergence[0].OutdatedRows)
+	assert.False(t, divergence[0].DivergentSince.IsZero())
+}
+
+func TestReconciler_Repair(t *testing.T) {
+	t.Parallel()
+
+	logger := commonlogger.NewOCRWrapper(logger.TestLogger(t), true, func(msg string) {})
+	config := createPluginConfig(10)
+	orm := s4_mocks.NewORM(t)
+	reconciler := s4.NewReconciler()
+	plugin, err := s4.NewReportingPluginWithReconciler(logger, config, orm, reconciler)
+	assert.NoError(t, err)
+
+	assert.NoError(t, reconciler.Repair(nil))
+	assert.Len(t, reconciler.PendingRepairs(), 1)
+
+	ormRows := generateConfirmedTestOrmRows(t, 2, time.Minute)
+	snapshot := rowsToShapshotRows(ormRows)
+	orm.On("GetSnapshot", mock.Anything, mock.Anything).Return(snapshot, nil)
+
+	queryRows := func() []*s4.SnapshotRow {
+		queryBytes, err := plugin.Query(testutils.Context(t), types.ReportTimestamp{})
+		assert.NoError(t, err)
+		query := &s4.Query{}
+		assert.NoError(t, proto.Unmarshal(queryBytes, query))
+		return query.Rows
+	}
+
+	// rows not re-synced yet are left out of the query
+	assert.Empty(t, queryRows())
+
+	report, err := s4.MarshalRows([]*s4.Row{{
+		Address:    ormRows[0].Address.Bytes(),
+		Slotid:     uint32(ormRows[0].SlotId),
+		Version:    ormRows[0].Version,
+		Expiration: ormRows[0].Expiration,
+		Payload:    ormRows[0].Payload,
+		Signature:  ormRows[0].Signature,
+	}})
+	assert.NoError(t, err)
+	orm.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
+	_, err = plugin.ShouldAcceptFinalizedReport(testutils.Context(t), types.ReportTimestamp{}, report)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, reconciler.PendingRepairs()[0].RepairedRows)
+
+	rows := queryRows()
+	assert.Len(t, rows, 1)
+	assert.Equal(t, ormRows[0].Address.Bytes(), rows[0].Address)
+
+	// the repair completes once idle
+	for i := 0; i < 2; i++ {
+		assert.Len(t, queryRows(), 1)
+	}
+	assert.Len(t, queryRows(), 2)
+	assert.Empty(t, reconciler.PendingRepairs())
+}
+
+func TestReconciler_ServeHTTP(t *testing.T) {
+	t.Parallel()
+
+	reconciler := s4.NewReconciler()
+	server := httptest.NewServer(reconciler)
+	defer server.Close()
+
+	post := func(req s4.RepairRequest) int {
+		body, err := json.Marshal(req)
+		assert.NoError(t, err)
+		resp, err := http.Post(server.URL, "application/json", bytes.NewReader(body))
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		return resp.StatusCode
+	}
+	assert.Equal(t, http.StatusBadRequest, post(s4.RepairRequest{MinAddress: common.HexToAddress("0x2"), MaxAddress: common.HexToAddress("0x1")}))
+	assert.Equal(t, http.StatusAccepted, post(s4.RepairRequest{MinAddress: common.HexToAddress("0x1"), MaxAddress: common.HexToAddress("0x2")}))
+
+	resp, err := http.Get(server.URL)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+	var body struct {
+		PendingRepairs []s4.Repair `json:"pendingRepairs"`
+	}
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Len(t, body.PendingRepairs, 1)
+	assert.Equal(t, common.HexToAddress("0x1"), body.PendingRepairs[0].MinAddress)
+	assert.Equal(t, common.HexToAddress("0x2"), body.PendingRepairs[0].MaxAddress)
+}
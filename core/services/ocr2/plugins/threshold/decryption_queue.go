@@ -20,11 +20,13 @@ type Decryptor interface {
 type pendingRequest struct {
 	chPlaintext chan<- []byte
 	ciphertext  []byte
+	enqueuedAt  time.Time
 }
 
 type completedRequest struct {
-	plaintext []byte
-	timer     *time.Timer
+	plaintext   []byte
+	timer       *time.Timer
+	completedAt time.Time
 }
 
 type decryptionQueue struct {
@@ -37,6 +39,10 @@ type decryptionQueue struct {
 	completedRequests             map[string]completedRequest
 	mu                            sync.RWMutex
 	lggr                          logger.Logger
+	name                          string
+	cacheHits                     uint64
+	cacheMisses                   uint64
+	decrypted                     uint64
 }
 
 var (
@@ -46,6 +52,11 @@ var (
 )
 
 func NewDecryptionQueue(maxQueueLength int, maxCiphertextBytes int, maxCiphertextIdLen int, completedRequestsCacheTimeout time.Duration, lggr logger.Logger) *decryptionQueue {
+	return NewNamedDecryptionQueue("", maxQueueLength, maxCiphertextBytes, maxCiphertextIdLen, completedRequestsCacheTimeout, lggr)
+}
+
+// NewNamedDecryptionQueue creates a decryption queue whose metrics are labeled with its name, e.g. the contract of its job.
+func NewNamedDecryptionQueue(name string, maxQueueLength int, maxCiphertextBytes int, maxCiphertextIdLen int, completedRequestsCacheTimeout time.Duration, lggr logger.Logger) *decryptionQueue {
 	dq := decryptionQueue{
 		maxQueueLength,
 		maxCiphertextBytes,
@@ -56,6 +67,10 @@ func NewDecryptionQueue(maxQueueLength int, maxCiphertextBytes int, maxCiphertex
 		make(map[string]completedRequest),
 		sync.RWMutex{},
 		lggr.Named("DecryptionQueue"),
+		name,
+		0,
+		0,
+		0,
 	}
 	return &dq
 }
@@ -92,6 +107,7 @@ func (dq *decryptionQueue) Decrypt(ctx context.Context, ciphertextId decryptionP
 		dq.mu.Lock()
 		defer dq.mu.Unlock()
 		delete(dq.pendingRequests, string(ciphertextId))
+		promQueueDepth.WithLabelValues(dq.name).Set(float64(len(dq.pendingRequests)))
 		return nil, errors.New("context provided by caller was cancelled")
 	}
 }
@@ -108,8 +124,12 @@ func (dq *decryptionQueue) getResult(ciphertextId decryptionPlugin.CiphertextId,
 		chPlaintext <- req.plaintext
 		req.timer.Stop()
 		delete(dq.completedRequests, string(ciphertextId))
+		dq.cacheHits++
+		promCompletedCacheHits.WithLabelValues(dq.name).Inc()
 		return chPlaintext, nil
 	}
+	dq.cacheMisses++
+	promCompletedCacheMisses.WithLabelValues(dq.name).Inc()
 
 	_, isDuplicateId := dq.pendingRequests[string(ciphertextId)]
 	if isDuplicateId {
@@ -124,7 +144,9 @@ func (dq *decryptionQueue) getResult(ciphertextId decryptionPlugin.CiphertextId,
 	dq.pendingRequests[string(ciphertextId)] = pendingRequest{
 		chPlaintext,
 		ciphertext,
+		time.Now(),
 	}
+	promQueueDepth.WithLabelValues(dq.name).Set(float64(len(dq.pendingRequests)))
 	dq.lggr.Debugf("ciphertextId %s added to pendingRequestQueue", ciphertextId)
 
 	return chPlaintext, nil
@@ -170,6 +192,14 @@ func (dq *decryptionQueue) GetRequests(requestCountLimit int, totalBytesLimit in
 
 	dq.pendingRequestQueue = removeMultipleIndices(dq.pendingRequestQueue, indicesToRemove)
 
+	var oldest time.Duration
+	for _, req := range dq.pendingRequests {
+		if age := time.Since(req.enqueuedAt); age > oldest {
+			oldest = age
+		}
+	}
+	promOldestRequestAge.WithLabelValues(dq.name).Set(oldest.Seconds())
+
 	if len(dq.pendingRequestQueue) > 0 {
 		dq.lggr.Debugf("returning first %d of %d total requests awaiting decryption", len(requests), len(dq.pendingRequestQueue))
 	} else {
@@ -212,6 +242,10 @@ func (dq *decryptionQueue) SetResult(ciphertextId decryptionPlugin.CiphertextId,
 		return
 	}
 
+	if err == nil {
+		dq.decrypted++
+	}
+
 	req, ok := dq.pendingRequests[string(ciphertextId)]
 	if ok {
 		if err != nil {
@@ -219,9 +253,11 @@ func (dq *decryptionQueue) SetResult(ciphertextId decryptionPlugin.CiphertextId,
 		} else {
 			dq.lggr.Debugf("responding with result for pending decryption request ciphertextId %s", ciphertextId)
 			req.chPlaintext <- plaintext
+			promDecryptionDuration.WithLabelValues(dq.name).Observe(time.Since(req.enqueuedAt).Seconds())
 		}
 		close(req.chPlaintext)
 		delete(dq.pendingRequests, string(ciphertextId))
+		promQueueDepth.WithLabelValues(dq.name).Set(float64(len(dq.pendingRequests)))
 	} else {
 		if err != nil {
 			// This is currently possible only for ErrAggregation, encountered during Report() phase.
@@ -241,6 +277,7 @@ func (dq *decryptionQueue) SetResult(ciphertextId decryptionPlugin.CiphertextId,
 		dq.completedRequests[string(ciphertextId)] = completedRequest{
 			plaintext,
 			timer,
+			time.Now(),
 		}
 	}
 }
@@ -2,11 +2,15 @@ package threshold
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"testing"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/onsi/gomega"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -432,6 +436,82 @@ func Test_decryptionQueue_Close(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func Test_decryptionQueue_State(t *testing.T) {
+	lggr := logger.TestLogger(t)
+	dq := NewDecryptionQueue(4, 1000, 64, testutils.WaitTimeout(t), lggr)
+
+	dq.SetResult([]byte("15"), []byte("decrypted"), nil)
+	dq.SetResult([]byte("16"), []byte("decrypted"), nil)
+	pt, err := dq.Decrypt(testutils.Context(t), []byte("15"), []byte("encrypted"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("decrypted"), pt)
+
+	ctx, cancel := context.WithCancel(testutils.Context(t))
+	defer cancel()
+	go func() {
+		_, _ = dq.Decrypt(ctx, []byte("17"), []byte("encrypted"))
+	}()
+	waitForPendingRequestToBeAdded(t, dq, []byte("17"))
+
+	state := dq.State()
+	require.Len(t, state.Pending, 1)
+	assert.Equal(t, []byte("17"), []byte(state.Pending[0].CiphertextId))
+	assert.Equal(t, len("encrypted"), state.Pending[0].CiphertextBytes)
+	require.Len(t, state.Completed, 1)
+	assert.Equal(t, []byte("16"), []byte(state.Completed[0].CiphertextId))
+	assert.Equal(t, 4, state.MaxQueueLength)
+	assert.Equal(t, uint64(1), state.CompletedCacheHits)
+	assert.Equal(t, uint64(1), state.CompletedCacheMisses)
+	assert.Equal(t, uint64(2), state.Decrypted)
+}
+
+func Test_decryptionQueue_Evict(t *testing.T) {
+	lggr := logger.TestLogger(t)
+	dq := NewDecryptionQueue(4, 1000, 64, testutils.WaitTimeout(t), lggr)
+
+	chErr := make(chan error, 1)
+	go func() {
+		_, err := dq.Decrypt(testutils.Context(t), []byte("18"), []byte("encrypted"))
+		chErr <- err
+	}()
+	waitForPendingRequestToBeAdded(t, dq, []byte("18"))
+	require.NoError(t, dq.Evict([]byte("18")))
+	assert.ErrorContains(t, <-chErr, "was closed without a response")
+	assert.Empty(t, dq.GetRequests(2, 1000))
+
+	dq.SetResult([]byte("19"), []byte("decrypted"), nil)
+	require.NoError(t, dq.Evict([]byte("19")))
+	assert.Empty(t, dq.State().Completed)
+
+	assert.ErrorIs(t, dq.Evict([]byte("19")), decryptionPlugin.ErrNotFound)
+}
+
+func Test_decryptionQueue_ServeHTTP(t *testing.T) {
+	lggr := logger.TestLogger(t)
+	dq := NewDecryptionQueue(4, 1000, 64, testutils.WaitTimeout(t), lggr)
+	dq.SetResult([]byte("20"), []byte("decrypted"), nil)
+
+	w := httptest.NewRecorder()
+	dq.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	var state QueueState
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &state))
+	require.Len(t, state.Completed, 1)
+	assert.Equal(t, []byte("20"), []byte(state.Completed[0].CiphertextId))
+
+	w = httptest.NewRecorder()
+	dq.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/?ciphertextId="+hexutil.Encode([]byte("20")), nil))
+	assert.Equal(t, http.StatusNoContent, w.Code)
+
+	w = httptest.NewRecorder()
+	dq.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/?ciphertextId="+hexutil.Encode([]byte("20")), nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = httptest.NewRecorder()
+	dq.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/?ciphertextId=20", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
 func waitForPendingRequestToBeAdded(t *testing.T, dq *decryptionQueue, ciphertextId decryptionPlugin.CiphertextId) {
 	gomega.NewGomegaWithT(t).Eventually(func() bool {
 		dq.mu.RLock()
@@ -0,0 +1,116 @@
+package threshold
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	decryptionPlugin "github.com/smartcontractkit/tdh2/go/ocr2/decryptionplugin"
+)
+
+// PendingCiphertext is a ciphertext awaiting decryption by the DON.
+type PendingCiphertext struct {
+	CiphertextId    hexutil.Bytes `json:"ciphertextId"`
+	CiphertextBytes int           `json:"ciphertextBytes"`
+	EnqueuedAt      time.Time     `json:"enqueuedAt"`
+	AgeSeconds      float64       `json:"ageSeconds"`
+}
+
+// CompletedCiphertext is a ciphertext decrypted by the DON before it was requested, cached until it is.
+type CompletedCiphertext struct {
+	CiphertextId hexutil.Bytes `json:"ciphertextId"`
+	CompletedAt  time.Time     `json:"completedAt"`
+}
+
+// QueueState is a snapshot of a decryption queue, see decryptionQueue.State.
+type QueueState struct {
+	// Pending are the ciphertexts awaiting decryption, oldest first.
+	Pending        []PendingCiphertext   `json:"pending"`
+	Completed      []CompletedCiphertext `json:"completed"`
+	MaxQueueLength int                   `json:"maxQueueLength"`
+	// CompletedCacheHits and CompletedCacheMisses count the Decrypt() calls served by the completed cache or not.
+	CompletedCacheHits   uint64 `json:"completedCacheHits"`
+	CompletedCacheMisses uint64 `json:"completedCacheMisses"`
+	// Decrypted counts the ciphertexts decrypted by the DON.
+	Decrypted uint64 `json:"decrypted"`
+}
+
+func (dq *decryptionQueue) State() QueueState {
+	dq.mu.RLock()
+	defer dq.mu.RUnlock()
+
+	now := time.Now()
+	state := QueueState{
+		Pending:              make([]PendingCiphertext, 0, len(dq.pendingRequests)),
+		Completed:            make([]CompletedCiphertext, 0, len(dq.completedRequests)),
+		MaxQueueLength:       dq.maxQueueLength,
+		CompletedCacheHits:   dq.cacheHits,
+		CompletedCacheMisses: dq.cacheMisses,
+		Decrypted:            dq.decrypted,
+	}
+	for id, req := range dq.pendingRequests {
+		state.Pending = append(state.Pending, PendingCiphertext{
+			CiphertextId:    []byte(id),
+			CiphertextBytes: len(req.ciphertext),
+			EnqueuedAt:      req.enqueuedAt,
+			AgeSeconds:      now.Sub(req.enqueuedAt).Seconds(),
+		})
+	}
+	sort.Slice(state.Pending, func(i, j int) bool { return state.Pending[i].EnqueuedAt.Before(state.Pending[j].EnqueuedAt) })
+	for id, req := range dq.completedRequests {
+		state.Completed = append(state.Completed, CompletedCiphertext{
+			CiphertextId: []byte(id),
+			CompletedAt:  req.completedAt,
+		})
+	}
+	sort.Slice(state.Completed, func(i, j int) bool { return state.Completed[i].CompletedAt.Before(state.Completed[j].CompletedAt) })
+	return state
+}
+
+// Evict drops the ciphertext from the queue, failing its pending Decrypt() call, or from the completed cache.
+// It returns decryptionPlugin.ErrNotFound if the ciphertext is in neither.
+func (dq *decryptionQueue) Evict(ciphertextId decryptionPlugin.CiphertextId) error {
+	dq.mu.Lock()
+	defer dq.mu.Unlock()
+
+	if req, ok := dq.pendingRequests[string(ciphertextId)]; ok {
+		close(req.chPlaintext)
+		delete(dq.pendingRequests, string(ciphertextId))
+		promQueueDepth.WithLabelValues(dq.name).Set(float64(len(dq.pendingRequests)))
+		dq.lggr.Infof("evicted pending decryption request for ciphertextId %s", ciphertextId)
+		return nil
+	}
+	if req, ok := dq.completedRequests[string(ciphertextId)]; ok {
+		req.timer.Stop()
+		delete(dq.completedRequests, string(ciphertextId))
+		dq.lggr.Infof("evicted completed decryption result for ciphertextId %s from cache", ciphertextId)
+		return nil
+	}
+	return decryptionPlugin.ErrNotFound
+}
+
+// ServeHTTP serves the queue as an endpoint: GET returns its QueueState, DELETE evicts the ciphertext of the
+// hex-encoded ciphertextId query parameter.
+func (dq *decryptionQueue) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	switch req.Method {
+	case http.MethodGet:
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(dq.State())
+	case http.MethodDelete:
+		ciphertextId, err := hexutil.Decode(req.URL.Query().Get("ciphertextId"))
+		if err != nil {
+			http.Error(w, "invalid ciphertextId: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err := dq.Evict(ciphertextId); err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
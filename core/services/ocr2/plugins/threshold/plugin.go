@@ -44,6 +44,9 @@ func NewThresholdService(sharedOracleArgs *libocr2.OCR2OracleArgs, conf *Thresho
 		OracleToKeyShare: oracleToKeyShare,
 		Logger:           sharedOracleArgs.Logger,
 	}
+	if dq, ok := conf.DecryptionQueue.(*decryptionQueue); ok {
+		sharedOracleArgs.ReportingPluginFactory = &roundMetricsFactory{sharedOracleArgs.ReportingPluginFactory, dq}
+	}
 
 	thresholdReportingPluginOracle, err := libocr2.NewOracle(*sharedOracleArgs)
 	if err != nil {
@@ -0,0 +1,40 @@
+package threshold
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	promQueueDepth = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "threshold_decryption_queue_depth",
+		Help: "Metric to track number of ciphertexts awaiting decryption",
+	}, []string{"queue"})
+
+	promOldestRequestAge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "threshold_decryption_queue_oldest_request_age_seconds",
+		Help: "Metric to track for how long the oldest ciphertext has been awaiting decryption",
+	}, []string{"queue"})
+
+	promDecryptionDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "threshold_decryption_duration_seconds",
+		Help:    "Metric to track how long ciphertexts awaited decryption",
+		Buckets: []float64{0.5, 1, 2, 5, 10, 30, 60, 120},
+	}, []string{"queue"})
+
+	promCompletedCacheHits = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "threshold_decryption_completed_cache_hits",
+		Help: "Metric to track number of Decrypt() calls served by results decrypted by the DON beforehand",
+	}, []string{"queue"})
+
+	promCompletedCacheMisses = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "threshold_decryption_completed_cache_misses",
+		Help: "Metric to track number of Decrypt() calls queued for decryption",
+	}, []string{"queue"})
+
+	promRoundDecrypted = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "threshold_decryption_round_decrypted",
+		Help:    "Metric to track number of ciphertexts decrypted per round",
+		Buckets: []float64{0, 1, 2, 5, 10, 20, 50, 100},
+	}, []string{"queue"})
+)
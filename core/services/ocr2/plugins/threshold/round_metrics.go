@@ -0,0 +1,42 @@
+package threshold
+
+import (
+	"context"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+)
+
+// roundMetricsFactory wraps the decryption reporting plugin to count the ciphertexts decrypted per round,
+// which are the results set on the queue while accepting the report of the round.
+type roundMetricsFactory struct {
+	wrapped types.ReportingPluginFactory
+	dq      *decryptionQueue
+}
+
+var _ types.ReportingPluginFactory = &roundMetricsFactory{}
+
+func (f *roundMetricsFactory) NewReportingPlugin(ctx context.Context, config types.ReportingPluginConfig) (types.ReportingPlugin, types.ReportingPluginInfo, error) {
+	plugin, info, err := f.wrapped.NewReportingPlugin(ctx, config)
+	if err != nil {
+		return nil, types.ReportingPluginInfo{}, err
+	}
+	return &roundMetricsPlugin{plugin, f.dq}, info, nil
+}
+
+type roundMetricsPlugin struct {
+	types.ReportingPlugin
+	dq *decryptionQueue
+}
+
+func (p *roundMetricsPlugin) ShouldAcceptFinalizedReport(ctx context.Context, timestamp types.ReportTimestamp, report types.Report) (bool, error) {
+	before := p.dq.decryptedCount()
+	accept, err := p.ReportingPlugin.ShouldAcceptFinalizedReport(ctx, timestamp, report)
+	promRoundDecrypted.WithLabelValues(p.dq.name).Observe(float64(p.dq.decryptedCount() - before))
+	return accept, err
+}
+
+func (dq *decryptionQueue) decryptedCount() uint64 {
+	dq.mu.RLock()
+	defer dq.mu.RUnlock()
+	return dq.decrypted
+}
@@ -0,0 +1,141 @@
+package validate
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	pkgerrors "github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/types"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/job"
+	"github.com/smartcontractkit/chainlink/v2/core/services/ocr2/plugins/ccip/config"
+	functionsconfig "github.com/smartcontractkit/chainlink/v2/core/services/ocr2/plugins/functions/config"
+	lloconfig "github.com/smartcontractkit/chainlink/v2/core/services/ocr2/plugins/llo/config"
+	mercuryconfig "github.com/smartcontractkit/chainlink/v2/core/services/ocr2/plugins/mercury/config"
+)
+
+// PluginConfigResolver resolves the plugin config of an OCR2 oracle spec to the config its plugin runs with,
+// see RegisterPluginConfigResolver.
+type PluginConfigResolver func(spec *job.OCR2OracleSpec) (job.JSONConfig, error)
+
+// PluginConfigValidator validates the plugin config of an OCR2 oracle spec, see RegisterPluginConfigValidator.
+type PluginConfigValidator func(ctx context.Context, spec *job.OCR2OracleSpec) error
+
+var (
+	pluginConfigValidatorsMu sync.RWMutex
+	pluginConfigValidators   = map[types.OCR2PluginType]PluginConfigValidator{}
+
+	pluginConfigResolversMu sync.RWMutex
+	pluginConfigResolvers   = map[types.OCR2PluginType]PluginConfigResolver{}
+)
+
+func init() {
+	RegisterPluginConfigValidator(types.OCR2Keeper, func(_ context.Context, spec *job.OCR2OracleSpec) error {
+		return validateOCR2KeeperSpec(spec.PluginConfig)
+	})
+	RegisterPluginConfigValidator(types.Functions, func(_ context.Context, spec *job.OCR2OracleSpec) error {
+		return validateOCR2FunctionsSpec(spec.PluginConfig)
+	})
+	RegisterPluginConfigValidator(types.Mercury, func(_ context.Context, spec *job.OCR2OracleSpec) error {
+		if spec.FeedID == nil {
+			return pkgerrors.New("feedID must be set")
+		}
+		return validateOCR2MercurySpec(spec, *spec.FeedID)
+	})
+	RegisterPluginConfigValidator(types.CCIPExecution, func(_ context.Context, spec *job.OCR2OracleSpec) error {
+		return validateOCR2CCIPExecutionSpec(spec.PluginConfig)
+	})
+	RegisterPluginConfigValidator(types.CCIPCommit, func(_ context.Context, spec *job.OCR2OracleSpec) error {
+		return validateOCR2CCIPCommitSpec(spec.PluginConfig)
+	})
+	RegisterPluginConfigValidator(types.LLO, func(_ context.Context, spec *job.OCR2OracleSpec) error {
+		return validateOCR2LLOSpec(spec.PluginConfig)
+	})
+
+	RegisterPluginConfigResolver(types.Functions, resolveAs[functionsconfig.PluginConfig])
+	RegisterPluginConfigResolver(types.Mercury, resolveAs[mercuryconfig.PluginConfig])
+	RegisterPluginConfigResolver(types.CCIPExecution, resolveAs[config.ExecPluginJobSpecConfig])
+	RegisterPluginConfigResolver(types.CCIPCommit, resolveAs[config.CommitPluginJobSpecConfig])
+	RegisterPluginConfigResolver(types.LLO, resolveAs[lloconfig.PluginConfig])
+}
+
+// RegisterPluginConfigValidator registers the validator of the plugin config of the specs of the plugin type,
+// replacing any registered before, a nil validator unregisters it. Specs are validated with it whenever they are
+// validated, e.g. when their job is created or proposed, so that a malformed plugin config is rejected before the
+// job starts.
+func RegisterPluginConfigValidator(pluginType types.OCR2PluginType, validator PluginConfigValidator) {
+	pluginConfigValidatorsMu.Lock()
+	defer pluginConfigValidatorsMu.Unlock()
+	if validator == nil {
+		delete(pluginConfigValidators, pluginType)
+		return
+	}
+	pluginConfigValidators[pluginType] = validator
+}
+
+// ValidatePluginConfig validates the plugin config of the spec with the validator registered for its plugin type,
+// if any.
+func ValidatePluginConfig(ctx context.Context, spec *job.OCR2OracleSpec) error {
+	pluginConfigValidatorsMu.RLock()
+	validator, ok := pluginConfigValidators[spec.PluginType]
+	pluginConfigValidatorsMu.RUnlock()
+	if !ok {
+		return nil
+	}
+	return pkgerrors.Wrapf(validator(ctx, spec), "invalid pluginConfig for pluginType %s", spec.PluginType)
+}
+
+// RegisterPluginConfigResolver registers the resolver of the plugin config of the specs of the plugin type,
+// replacing any registered before, a nil resolver unregisters it.
+func RegisterPluginConfigResolver(pluginType types.OCR2PluginType, resolver PluginConfigResolver) {
+	pluginConfigResolversMu.Lock()
+	defer pluginConfigResolversMu.Unlock()
+	if resolver == nil {
+		delete(pluginConfigResolvers, pluginType)
+		return
+	}
+	pluginConfigResolvers[pluginType] = resolver
+}
+
+// ResolvePluginConfig returns the plugin config of the spec as resolved by the resolver registered for its plugin
+// type, i.e. with the fields its plugin ignores dropped and the ones it defaults set. Without a resolver the plugin
+// config is returned as is.
+func ResolvePluginConfig(spec *job.OCR2OracleSpec) (job.JSONConfig, error) {
+	pluginConfigResolversMu.RLock()
+	resolver, ok := pluginConfigResolvers[spec.PluginType]
+	pluginConfigResolversMu.RUnlock()
+	if !ok {
+		return spec.PluginConfig, nil
+	}
+	resolved, err := resolver(spec)
+	return resolved, pkgerrors.Wrapf(err, "failed to resolve pluginConfig for pluginType %s", spec.PluginType)
+}
+
+// resolveAs resolves the plugin config by round-tripping it through the config type C its plugin unmarshals it to.
+func resolveAs[C any](spec *job.OCR2OracleSpec) (job.JSONConfig, error) {
+	var cfg C
+	if len(spec.PluginConfig) > 0 {
+		if err := json.Unmarshal(spec.PluginConfig.Bytes(), &cfg); err != nil {
+			return nil, pkgerrors.Wrap(err, "error while unmarshalling plugin config")
+		}
+	}
+	b, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "error while marshalling plugin config")
+	}
+	var resolved job.JSONConfig
+	if err := json.Unmarshal(b, &resolved); err != nil {
+		return nil, pkgerrors.Wrap(err, "error while unmarshalling resolved plugin config")
+	}
+	return resolved, nil
+}
+
+func validateOCR2FunctionsSpec(jsonConfig job.JSONConfig) error {
+	var pluginConfig functionsconfig.PluginConfig
+	if err := json.Unmarshal(jsonConfig.Bytes(), &pluginConfig); err != nil {
+		return pkgerrors.Wrap(err, "error while unmarshalling plugin config")
+	}
+	return pkgerrors.Wrap(functionsconfig.ValidatePluginConfig(pluginConfig), "Functions PluginConfig is invalid")
+}
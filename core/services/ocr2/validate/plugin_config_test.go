@@ -0,0 +1,52 @@
+package validate_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/types"
+
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/services/job"
+	"github.com/smartcontractkit/chainlink/v2/core/services/ocr2/validate"
+)
+
+func TestRegisterPluginConfigValidator(t *testing.T) {
+	ctx := testutils.Context(t)
+	spec := &job.OCR2OracleSpec{PluginType: types.Median, PluginConfig: job.JSONConfig{"foo": "bar"}}
+	require.NoError(t, validate.ValidatePluginConfig(ctx, spec))
+
+	validate.RegisterPluginConfigValidator(types.Median, func(_ context.Context, spec *job.OCR2OracleSpec) error {
+		if _, ok := spec.PluginConfig["foo"]; ok {
+			return errors.New("unexpected foo")
+		}
+		return nil
+	})
+	t.Cleanup(func() { validate.RegisterPluginConfigValidator(types.Median, nil) })
+	require.EqualError(t, validate.ValidatePluginConfig(ctx, spec), "invalid pluginConfig for pluginType median: unexpected foo")
+	require.NoError(t, validate.ValidatePluginConfig(ctx, &job.OCR2OracleSpec{PluginType: types.Median}))
+
+	validate.RegisterPluginConfigValidator(types.Median, nil)
+	require.NoError(t, validate.ValidatePluginConfig(ctx, spec))
+}
+
+func TestResolvePluginConfig(t *testing.T) {
+	spec := &job.OCR2OracleSpec{PluginType: types.Median, PluginConfig: job.JSONConfig{"foo": "bar"}}
+	resolved, err := validate.ResolvePluginConfig(spec)
+	require.NoError(t, err)
+	require.Equal(t, spec.PluginConfig, resolved)
+
+	spec = &job.OCR2OracleSpec{PluginType: types.LLO, PluginConfig: job.JSONConfig{"channelDefinitions": "{}", "unknown": true}}
+	resolved, err = validate.ResolvePluginConfig(spec)
+	require.NoError(t, err)
+	require.Equal(t, "{}", resolved["channelDefinitions"])
+	require.Equal(t, false, resolved["benchmarkMode"])
+	require.NotContains(t, resolved, "unknown")
+
+	spec = &job.OCR2OracleSpec{PluginType: types.LLO, PluginConfig: job.JSONConfig{"benchmarkMode": "yes"}}
+	_, err = validate.ResolvePluginConfig(spec)
+	require.ErrorContains(t, err, "failed to resolve pluginConfig for pluginType llo")
+}
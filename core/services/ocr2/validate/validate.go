@@ -111,19 +111,8 @@ func validateSpec(ctx context.Context, tree *toml.Tree, spec job.Job, rc plugins
 		if spec.Pipeline.Source == "" {
 			return errors.New("no pipeline specified")
 		}
-	case types.OCR2Keeper:
-		return validateOCR2KeeperSpec(spec.OCR2OracleSpec.PluginConfig)
-	case types.Functions:
-		// TODO validator for DR-OCR spec: https://smartcontract-it.atlassian.net/browse/FUN-112
-		return nil
-	case types.Mercury:
-		return validateOCR2MercurySpec(spec.OCR2OracleSpec, *spec.OCR2OracleSpec.FeedID)
-	case types.CCIPExecution:
-		return validateOCR2CCIPExecutionSpec(spec.OCR2OracleSpec.PluginConfig)
-	case types.CCIPCommit:
-		return validateOCR2CCIPCommitSpec(spec.OCR2OracleSpec.PluginConfig)
-	case types.LLO:
-		return validateOCR2LLOSpec(spec.OCR2OracleSpec.PluginConfig)
+	case types.OCR2Keeper, types.Functions, types.Mercury, types.CCIPExecution, types.CCIPCommit, types.LLO:
+		// validated by the PluginConfigValidator registered for the plugin type
 	case types.GenericPlugin:
 		return validateGenericPluginSpec(ctx, spec.OCR2OracleSpec, rc)
 	case "":
@@ -132,7 +121,7 @@ func validateSpec(ctx context.Context, tree *toml.Tree, spec job.Job, rc plugins
 		return pkgerrors.Errorf("invalid pluginType %s", spec.OCR2OracleSpec.PluginType)
 	}
 
-	return nil
+	return ValidatePluginConfig(ctx, spec.OCR2OracleSpec)
 }
 
 type PipelineSpec struct {
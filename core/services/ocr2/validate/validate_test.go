@@ -591,6 +591,42 @@ UpdateInterval="1m"
 				require.NoError(t, pc.ValidatePluginConfig())
 			},
 		},
+		{
+			name: "invalid functions plugin config",
+			toml: `
+type               = "offchainreporting2"
+pluginType         = "functions"
+schemaVersion      = 1
+relay              = "evm"
+contractID         = "0x613a38AC1659769640aaE063C651F48E0250454C"
+[relayConfig]
+chainID = 1337
+[pluginConfig]
+requestTimeoutSec = 300
+[pluginConfig.decryptionQueueConfig]
+maxQueueLength = 0
+`,
+			assertion: func(t *testing.T, os job.Job, err error) {
+				require.ErrorContains(t, err, "invalid pluginConfig for pluginType functions: Functions PluginConfig is invalid: missing or invalid decryptionQueueConfig maxQueueLength")
+			},
+		},
+		{
+			name: "malformed functions plugin config",
+			toml: `
+type               = "offchainreporting2"
+pluginType         = "functions"
+schemaVersion      = 1
+relay              = "evm"
+contractID         = "0x613a38AC1659769640aaE063C651F48E0250454C"
+[relayConfig]
+chainID = 1337
+[pluginConfig]
+requestTimeoutSec = "5m"
+`,
+			assertion: func(t *testing.T, os job.Job, err error) {
+				require.ErrorContains(t, err, "requestTimeoutSec")
+			},
+		},
 	}
 
 	for _, tc := range tt {
@@ -42,6 +42,8 @@ type chainReader struct {
 	parsed   *codec.ParsedTypes
 	bindings *read.BindingsRegistry
 	codec    commontypes.RemoteCodec
+	// jobID is the job which created the reader, if any, its polling filters are attributed to it.
+	jobID *int32
 	commonservices.StateMachine
 }
 
@@ -50,7 +52,7 @@ var _ commontypes.ContractTypeProvider = &chainReader{}
 
 // NewChainReaderService is a constructor for ChainReader, returns nil if there is any error
 // Note that the ChainReaderService returned does not support anonymous events.
-func NewChainReaderService(_ context.Context, lggr logger.Logger, lp logpoller.LogPoller, ht logpoller.HeadTracker, client evmclient.Client, config types.ChainReaderConfig) (ChainReaderService, error) {
+func NewChainReaderService(ctx context.Context, lggr logger.Logger, lp logpoller.LogPoller, ht logpoller.HeadTracker, client evmclient.Client, config types.ChainReaderConfig) (ChainReaderService, error) {
 	cr := &chainReader{
 		lggr:     logger.Named(lggr, "ChainReader"),
 		ht:       ht,
@@ -59,6 +61,9 @@ func NewChainReaderService(_ context.Context, lggr logger.Logger, lp logpoller.L
 		bindings: read.NewBindingsRegistry(),
 		parsed:   &codec.ParsedTypes{EncoderDefs: map[string]types.CodecEntry{}, DecoderDefs: map[string]types.CodecEntry{}},
 	}
+	if jobID, ok := logpoller.JobIDFromContext(ctx); ok {
+		cr.jobID = &jobID
+	}
 
 	var err error
 	if err = cr.init(config.Contracts); err != nil {
@@ -162,7 +167,7 @@ func (cr *chainReader) Name() string { return cr.lggr.Name() }
 // Start registers polling filters if contracts are already bound.
 func (cr *chainReader) Start(ctx context.Context) error {
 	return cr.StartOnce("ChainReader", func() error {
-		return cr.bindings.RegisterAll(ctx, cr.lp)
+		return cr.bindings.RegisterAll(cr.withJob(ctx), cr.lp)
 	})
 }
 
@@ -182,11 +187,20 @@ func (cr *chainReader) HealthReport() map[string]error {
 }
 
 func (cr *chainReader) Bind(ctx context.Context, bindings []commontypes.BoundContract) error {
-	return cr.bindings.Bind(ctx, cr.lp, bindings)
+	return cr.bindings.Bind(cr.withJob(ctx), cr.lp, bindings)
 }
 
 func (cr *chainReader) Unbind(ctx context.Context, bindings []commontypes.BoundContract) error {
-	return cr.bindings.Unbind(ctx, cr.lp, bindings)
+	return cr.bindings.Unbind(cr.withJob(ctx), cr.lp, bindings)
+}
+
+// withJob attributes the polling filters registered with the context to the job which created the reader. The
+// contracts are usually bound by the plugins of the job later on, with their own context.
+func (cr *chainReader) withJob(ctx context.Context) context.Context {
+	if cr.jobID == nil {
+		return ctx
+	}
+	return logpoller.WithJobID(ctx, *cr.jobID)
 }
 
 func (cr *chainReader) GetLatestValue(ctx context.Context, readName string, confidenceLevel primitives.ConfidenceLevel, params any, returnVal any) error {
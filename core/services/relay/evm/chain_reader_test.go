@@ -0,0 +1,55 @@
+package evm_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-ccip/pkg/consts"
+	clcommontypes "github.com/smartcontractkit/chainlink-common/pkg/types"
+
+	evmconfig "github.com/smartcontractkit/chainlink/v2/core/capabilities/ccip/configs/evm"
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/logpoller"
+	lpmocks "github.com/smartcontractkit/chainlink/v2/core/chains/evm/logpoller/mocks"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/relay/evm"
+	"github.com/smartcontractkit/chainlink/v2/core/services/relay/evm/types"
+)
+
+func TestChainReader_FiltersAttributedToJob(t *testing.T) {
+	const ccipJobID = int32(42)
+
+	// The CCIP oracle creator creates the source chain reader with the context of the CCIP job, the plugin binds
+	// the contracts later on with its own context.
+	cfgBytes, err := json.Marshal(evmconfig.SourceReaderConfig)
+	require.NoError(t, err)
+	var cfg types.ChainReaderConfig
+	require.NoError(t, json.Unmarshal(cfgBytes, &cfg))
+
+	var registered []int32
+	lp := lpmocks.NewLogPoller(t)
+	lp.EXPECT().HasFilter(mock.Anything).Return(false)
+	lp.EXPECT().RegisterFilter(mock.Anything, mock.Anything).Run(func(ctx context.Context, filter logpoller.Filter) {
+		jobID, ok := logpoller.JobIDFromContext(ctx)
+		require.True(t, ok, "filter %s registered without a job", filter.Name)
+		registered = append(registered, jobID)
+	}).Return(nil)
+
+	cr, err := evm.NewChainReaderService(logpoller.WithJobID(testutils.Context(t), ccipJobID), logger.TestLogger(t), lp, nil, nil, cfg)
+	require.NoError(t, err)
+
+	ctx := testutils.Context(t)
+	require.NoError(t, cr.Start(ctx))
+	t.Cleanup(func() { assert.NoError(t, cr.Close()) })
+	require.NoError(t, cr.Bind(ctx, []clcommontypes.BoundContract{{
+		Name:    consts.ContractNameOnRamp,
+		Address: testutils.NewAddress().Hex(),
+	}}))
+
+	assert.Equal(t, []int32{ccipJobID}, registered)
+}
@@ -0,0 +1,71 @@
+package web
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/config/toml"
+	"github.com/smartcontractkit/chainlink/v2/core/chains/legacyevm"
+	"github.com/smartcontractkit/chainlink/v2/core/logger/audit"
+	"github.com/smartcontractkit/chainlink/v2/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/v2/core/web/presenters"
+)
+
+// EVMChainConfigController reloads the config of EVM chains at runtime.
+type EVMChainConfigController struct {
+	App chainlink.Application
+}
+
+// ReloadEVMChainConfigRequest is the TOML of the settings of an EVM chain to reload, see toml.Reloadable.
+type ReloadEVMChainConfigRequest struct {
+	TOML string `json:"toml"`
+}
+
+// Reload reloads the subset of the config of an EVM chain which can be changed
+// without restarting the node, i.e. the RPC nodes, NodePool.EnforceRepeatableRead
+// and the gas price caps, and responds with the settings which changed.
+// Example:
+// "PATCH <application>/chains/evm/:ID/config"
+func (cc *EVMChainConfigController) Reload(c *gin.Context) {
+	request := ReloadEVMChainConfigRequest{}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+	r, err := toml.ParseReloadable(request.TOML)
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+	if r.IsEmpty() {
+		jsonAPIError(c, http.StatusUnprocessableEntity, errors.New("no settings to reload"))
+		return
+	}
+
+	chainID := c.Param("ID")
+	chain, err := cc.App.GetRelayers().LegacyEVMChains().Get(chainID)
+	if err != nil {
+		jsonAPIError(c, http.StatusNotFound, err)
+		return
+	}
+	reloader, ok := chain.(legacyevm.ConfigReloader)
+	if !ok {
+		jsonAPIError(c, http.StatusBadRequest, errors.New("chain does not support reloading its config"))
+		return
+	}
+	changes, err := reloader.ReloadConfig(c.Request.Context(), r)
+	if err != nil {
+		jsonAPIError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if len(changes) > 0 {
+		cc.App.GetAuditLogger().Audit(audit.ChainConfigReloaded, map[string]interface{}{
+			"chainID": chainID,
+			"changes": changes,
+		})
+	}
+	jsonAPIResponse(c, presenters.NewEVMChainConfigReloadResource(chainID, changes), "evm_chain_config_reload")
+}
@@ -0,0 +1,80 @@
+package web
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/utils/big"
+	"github.com/smartcontractkit/chainlink/v2/core/services/chainlink"
+)
+
+// EVMNodeScoresController exposes the NodePool scores of EVM RPC nodes.
+type EVMNodeScoresController struct {
+	App chainlink.Application
+}
+
+// Index lists the scores (latency, head lag, finalized lag and error rate) of the primary RPC nodes of a chain,
+// as observed by the NodePool.
+// Example:
+//
+//	"<application>/v2/nodes/evm/scores?evmChainID=1"
+func (nsc *EVMNodeScoresController) Index(c *gin.Context) {
+	chain, err := getChain(nsc.App.GetRelayers().LegacyEVMChains(), c.Query("evmChainID"))
+	if err != nil {
+		if errors.Is(err, ErrInvalidChainID) || errors.Is(err, ErrMultipleChains) || errors.Is(err, ErrMissingChainID) {
+			jsonAPIError(c, http.StatusUnprocessableEntity, err)
+			return
+		}
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	states := chain.Client().NodeStates()
+	scores := chain.Client().NodeScores()
+	resources := make([]EVMNodeScoreResource, 0, len(scores))
+	for name, score := range scores {
+		resources = append(resources, EVMNodeScoreResource{
+			Name:         name,
+			EVMChainID:   big.New(chain.ID()),
+			State:        states[name],
+			Latency:      score.Latency.String(),
+			HeadLag:      score.HeadLag,
+			FinalizedLag: score.FinalizedLag,
+			ErrorRate:    score.ErrorRate,
+		})
+	}
+	sort.Slice(resources, func(i, j int) bool {
+		return resources[i].Name < resources[j].Name
+	})
+	jsonAPIResponse(c, resources, "evm_node_scores")
+}
+
+type EVMNodeScoreResource struct {
+	Name         string   `json:"name"`
+	EVMChainID   *big.Big `json:"evmChainID"`
+	State        string   `json:"state"`
+	Latency      string   `json:"latency"`
+	HeadLag      int64    `json:"headLag"`
+	FinalizedLag int64    `json:"finalizedLag"`
+	ErrorRate    float64  `json:"errorRate"`
+}
+
+// GetID returns the jsonapi ID.
+func (r EVMNodeScoreResource) GetID() string {
+	return r.Name
+}
+
+// GetName returns the collection name for jsonapi.
+func (EVMNodeScoreResource) GetName() string {
+	return "evm_node_scores"
+}
+
+// SetID is used to conform to the UnmarshallIdentifier interface for
+// deserializing from jsonapi documents.
+func (r *EVMNodeScoreResource) SetID(id string) error {
+	r.Name = id
+	return nil
+}
@@ -0,0 +1,97 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/v2/core/services/job"
+	"github.com/smartcontractkit/chainlink/v2/core/services/ocr2/plugins/functions"
+)
+
+// FunctionsAdminController serves the admin endpoints of the running Functions jobs.
+type FunctionsAdminController struct {
+	App chainlink.Application
+}
+
+// S4Reconciliation serves the S4 reconciler of the job: GET returns the divergence of the snapshot of the node
+// and the pending repairs, POST schedules the repair of an address range.
+// Example:
+// "GET <application>/jobs/:ID/functions/s4/reconciliation"
+func (fac *FunctionsAdminController) S4Reconciliation(c *gin.Context) {
+	admin, ok := fac.findJobAdmin(c)
+	if !ok {
+		return
+	}
+	if admin.S4Reconciler == nil {
+		jsonAPIError(c, http.StatusNotFound, errors.New("S4 is not enabled for the job"))
+		return
+	}
+	admin.S4Reconciler.ServeHTTP(c.Writer, c.Request)
+}
+
+// DecryptionQueue serves the threshold decryption queue of the job: GET returns its state, DELETE evicts the
+// ciphertext of the hex-encoded ciphertextId query parameter.
+// Example:
+// "DELETE <application>/jobs/:ID/functions/decryption_queue?ciphertextId=0x01"
+func (fac *FunctionsAdminController) DecryptionQueue(c *gin.Context) {
+	admin, ok := fac.findJobAdmin(c)
+	if !ok {
+		return
+	}
+	if admin.DecryptionQueue == nil {
+		jsonAPIError(c, http.StatusNotFound, errors.New("threshold decryption is not enabled for the job"))
+		return
+	}
+	admin.DecryptionQueue.ServeHTTP(c.Writer, c.Request)
+}
+
+// ReplayRequestsRequest is the body of a request to replay the Functions requests of a transaction.
+type ReplayRequestsRequest struct {
+	TxHash common.Hash `json:"txHash"`
+}
+
+// ReplayRequests re-executes the Functions requests emitted in a transaction as the job would, without their
+// secrets, and returns the results it would have computed. Nothing is stored nor transmitted.
+// Example:
+// "POST <application>/jobs/:ID/functions/replay"
+func (fac *FunctionsAdminController) ReplayRequests(c *gin.Context) {
+	admin, ok := fac.findJobAdmin(c)
+	if !ok {
+		return
+	}
+	if admin.Replayer == nil {
+		jsonAPIError(c, http.StatusNotFound, errors.New("the job has no request listener"))
+		return
+	}
+	var request ReplayRequestsRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+	results, err := admin.Replayer.ReplayTx(c.Request.Context(), request.TxHash)
+	if err != nil {
+		jsonAPIError(c, http.StatusBadRequest, err)
+		return
+	}
+	c.JSON(http.StatusOK, results)
+}
+
+// findJobAdmin finds the services of the running Functions job of the :ID param, responding with an error
+// if there is none.
+func (fac *FunctionsAdminController) findJobAdmin(c *gin.Context) (functions.JobAdmin, bool) {
+	var jb job.Job
+	if err := jb.SetID(c.Param("ID")); err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return functions.JobAdmin{}, false
+	}
+	admin, ok := fac.App.GetFunctionsAdminRegistry().Get(jb.ID)
+	if !ok {
+		jsonAPIError(c, http.StatusNotFound, errors.New("no running Functions job with this ID"))
+		return functions.JobAdmin{}, false
+	}
+	return admin, true
+}
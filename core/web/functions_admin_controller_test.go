@@ -0,0 +1,145 @@
+package web_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	functions_service "github.com/smartcontractkit/chainlink/v2/core/services/functions"
+	"github.com/smartcontractkit/chainlink/v2/core/services/ocr2/plugins/functions"
+	s4_plugin "github.com/smartcontractkit/chainlink/v2/core/services/ocr2/plugins/s4"
+	"github.com/smartcontractkit/chainlink/v2/core/services/ocr2/plugins/threshold"
+	"github.com/smartcontractkit/chainlink/v2/core/sessions"
+)
+
+func TestFunctionsAdminController_S4Reconciliation(t *testing.T) {
+	t.Parallel()
+
+	app := cltest.NewApplicationEVMDisabled(t)
+	require.NoError(t, app.Start(testutils.Context(t)))
+	reconciler := s4_plugin.NewReconciler()
+	unregister := app.GetFunctionsAdminRegistry().Register(7, functions.JobAdmin{S4Reconciler: reconciler})
+	app.GetFunctionsAdminRegistry().Register(8, functions.JobAdmin{})
+	client := app.NewHTTPClient(nil)
+
+	resp, cleanup := client.Post("/v2/jobs/7/functions/s4/reconciliation", strings.NewReader(
+		`{"minAddress": "0x0000000000000000000000000000000000000001", "maxAddress": "0x00000000000000000000000000000000000000ff"}`))
+	t.Cleanup(cleanup)
+	cltest.AssertServerResponse(t, resp, http.StatusAccepted)
+	require.Len(t, reconciler.PendingRepairs(), 1)
+
+	resp, cleanup = client.Get("/v2/jobs/7/functions/s4/reconciliation")
+	t.Cleanup(cleanup)
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	var state struct {
+		PendingRepairs []s4_plugin.Repair `json:"pendingRepairs"`
+	}
+	require.NoError(t, json.Unmarshal(cltest.ParseResponseBody(t, resp), &state))
+	require.Len(t, state.PendingRepairs, 1)
+
+	resp, cleanup = client.Post("/v2/jobs/7/functions/s4/reconciliation", strings.NewReader(
+		`{"minAddress": "0x00000000000000000000000000000000000000ff", "maxAddress": "0x0000000000000000000000000000000000000001"}`))
+	t.Cleanup(cleanup)
+	cltest.AssertServerResponse(t, resp, http.StatusBadRequest)
+
+	// jobs without S4, invalid and stopped jobs
+	resp, cleanup = client.Get("/v2/jobs/8/functions/s4/reconciliation")
+	t.Cleanup(cleanup)
+	cltest.AssertServerResponse(t, resp, http.StatusNotFound)
+	resp, cleanup = client.Get("/v2/jobs/seven/functions/s4/reconciliation")
+	t.Cleanup(cleanup)
+	cltest.AssertServerResponse(t, resp, http.StatusUnprocessableEntity)
+	unregister()
+	resp, cleanup = client.Get("/v2/jobs/7/functions/s4/reconciliation")
+	t.Cleanup(cleanup)
+	cltest.AssertServerResponse(t, resp, http.StatusNotFound)
+
+	// only admins can inspect and repair
+	editor := app.NewHTTPClient(&cltest.User{Role: sessions.UserRoleEdit})
+	resp, cleanup = editor.Get("/v2/jobs/8/functions/s4/reconciliation")
+	t.Cleanup(cleanup)
+	cltest.AssertServerResponse(t, resp, http.StatusForbidden)
+}
+
+type fakeReplayer struct {
+	functions_service.RequestReplayer
+}
+
+func (fakeReplayer) ReplayTx(_ context.Context, txHash common.Hash) ([]functions_service.ReplayResult, error) {
+	if txHash == (common.Hash{}) {
+		return nil, errors.New("no requests in tx")
+	}
+	return []functions_service.ReplayResult{{TxHash: txHash, Result: []byte{1}, SecretsOmitted: true}}, nil
+}
+
+func TestFunctionsAdminController_ReplayRequests(t *testing.T) {
+	t.Parallel()
+
+	app := cltest.NewApplicationEVMDisabled(t)
+	require.NoError(t, app.Start(testutils.Context(t)))
+	app.GetFunctionsAdminRegistry().Register(7, functions.JobAdmin{Replayer: fakeReplayer{}})
+	client := app.NewHTTPClient(nil)
+
+	txHash := common.HexToHash("0x01")
+	resp, cleanup := client.Post("/v2/jobs/7/functions/replay", strings.NewReader(`{"txHash": "`+txHash.Hex()+`"}`))
+	t.Cleanup(cleanup)
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	var results []functions_service.ReplayResult
+	require.NoError(t, json.Unmarshal(cltest.ParseResponseBody(t, resp), &results))
+	require.Equal(t, []functions_service.ReplayResult{{TxHash: txHash, Result: []byte{1}, SecretsOmitted: true}}, results)
+
+	resp, cleanup = client.Post("/v2/jobs/7/functions/replay", strings.NewReader(`{}`))
+	t.Cleanup(cleanup)
+	cltest.AssertServerResponse(t, resp, http.StatusBadRequest)
+
+	editor := app.NewHTTPClient(&cltest.User{Role: sessions.UserRoleEdit})
+	resp, cleanup = editor.Post("/v2/jobs/7/functions/replay", strings.NewReader(`{"txHash": "`+txHash.Hex()+`"}`))
+	t.Cleanup(cleanup)
+	cltest.AssertServerResponse(t, resp, http.StatusForbidden)
+}
+
+func TestFunctionsAdminController_DecryptionQueue(t *testing.T) {
+	t.Parallel()
+
+	app := cltest.NewApplicationEVMDisabled(t)
+	require.NoError(t, app.Start(testutils.Context(t)))
+	queue := threshold.NewDecryptionQueue(10, 1000, 64, time.Minute, logger.TestLogger(t))
+	app.GetFunctionsAdminRegistry().Register(7, functions.JobAdmin{DecryptionQueue: queue})
+	client := app.NewHTTPClient(nil)
+
+	// a ciphertext decrypted by the DON before it is requested is cached until it is
+	queue.SetResult([]byte{1}, []byte("plaintext"), nil)
+	resp, cleanup := client.Get("/v2/jobs/7/functions/decryption_queue")
+	t.Cleanup(cleanup)
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	var state threshold.QueueState
+	require.NoError(t, json.Unmarshal(cltest.ParseResponseBody(t, resp), &state))
+	require.Equal(t, 10, state.MaxQueueLength)
+	require.Len(t, state.Completed, 1)
+
+	resp, cleanup = client.Delete("/v2/jobs/7/functions/decryption_queue?ciphertextId=0x01")
+	t.Cleanup(cleanup)
+	cltest.AssertServerResponse(t, resp, http.StatusNoContent)
+	require.Empty(t, queue.State().Completed)
+	resp, cleanup = client.Delete("/v2/jobs/7/functions/decryption_queue?ciphertextId=0x01")
+	t.Cleanup(cleanup)
+	cltest.AssertServerResponse(t, resp, http.StatusNotFound)
+	resp, cleanup = client.Delete("/v2/jobs/7/functions/decryption_queue?ciphertextId=zz")
+	t.Cleanup(cleanup)
+	cltest.AssertServerResponse(t, resp, http.StatusBadRequest)
+
+	editor := app.NewHTTPClient(&cltest.User{Role: sessions.UserRoleEdit})
+	resp, cleanup = editor.Get("/v2/jobs/7/functions/decryption_queue")
+	t.Cleanup(cleanup)
+	cltest.AssertServerResponse(t, resp, http.StatusForbidden)
+}
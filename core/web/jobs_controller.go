@@ -67,6 +67,47 @@ func (jc *JobsController) Index(c *gin.Context, size, page, offset int) {
 // Example:
 // "GET <application>/jobs/:ID"
 func (jc *JobsController) Show(c *gin.Context) {
+	jobSpec, ok := jc.findJob(c)
+	if !ok {
+		return
+	}
+
+	jsonAPIResponse(c, presenters.NewJobResource(jobSpec), "jobs")
+}
+
+// ShowPluginConfig returns the effective plugin config of an OCR2 job, resolved
+// and validated as its plugin would, or the plugin config of a CCIP job, with secrets redacted.
+// :ID could be both job ID and external job ID
+// Example:
+// "GET <application>/jobs/:ID/plugin_config"
+func (jc *JobsController) ShowPluginConfig(c *gin.Context) {
+	jobSpec, ok := jc.findJob(c)
+	if !ok {
+		return
+	}
+	if jobSpec.Type == job.CCIP && jobSpec.CCIPSpec != nil {
+		// the plugin config of CCIP jobs is read by the commit and exec plugins of every DON of the job, it's returned as is
+		jsonAPIResponse(c, presenters.NewPluginConfigResource(jobSpec, jobSpec.CCIPSpec.PluginConfig, nil), "pluginConfigs")
+		return
+	}
+	if jobSpec.Type != job.OffchainReporting2 || jobSpec.OCR2OracleSpec == nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, errors.Errorf("job type %s has no plugin config", jobSpec.Type))
+		return
+	}
+
+	spec := jobSpec.OCR2OracleSpec
+	validationErr := validate.ValidatePluginConfig(c.Request.Context(), spec)
+	pluginConfig, err := validate.ResolvePluginConfig(spec)
+	if err != nil && validationErr == nil {
+		validationErr = err
+	}
+
+	jsonAPIResponse(c, presenters.NewPluginConfigResource(jobSpec, pluginConfig, validationErr), "pluginConfigs")
+}
+
+// findJob finds the job of the :ID param, which could be both job ID and
+// external job ID, responding with an error if it is not found.
+func (jc *JobsController) findJob(c *gin.Context) (job.Job, bool) {
 	ctx := c.Request.Context()
 	var err error
 	jobSpec := job.Job{}
@@ -78,7 +119,7 @@ func (jc *JobsController) Show(c *gin.Context) {
 		jobSpec, err = jc.App.JobORM().FindJob(ctx, jobSpec.ID)
 	} else {
 		jsonAPIError(c, http.StatusUnprocessableEntity, pErr)
-		return
+		return job.Job{}, false
 	}
 	if err != nil {
 		if errors.Is(errors.Cause(err), sql.ErrNoRows) {
@@ -86,10 +127,9 @@ func (jc *JobsController) Show(c *gin.Context) {
 		} else {
 			jsonAPIError(c, http.StatusInternalServerError, err)
 		}
-		return
+		return job.Job{}, false
 	}
-
-	jsonAPIResponse(c, presenters.NewJobResource(jobSpec), "jobs")
+	return jobSpec, true
 }
 
 // CreateJobRequest represents a request to create and start a job (V2).
@@ -628,6 +628,26 @@ func TestJobsController_Show_NonExistentID(t *testing.T) {
 	cltest.AssertServerResponse(t, response, http.StatusNotFound)
 }
 
+func TestJobsController_ShowPluginConfig_NotOCR2(t *testing.T) {
+	_, client, _, jobID, _, _ := setupJobSpecsControllerTestsWithJobs(t)
+
+	response, cleanup := client.Get(fmt.Sprintf("/v2/jobs/%v/plugin_config", jobID))
+	t.Cleanup(cleanup)
+	cltest.AssertServerResponse(t, response, http.StatusUnprocessableEntity)
+}
+
+func TestJobsController_ShowPluginConfig_NonExistentID(t *testing.T) {
+	_, client, _, _, _, _ := setupJobSpecsControllerTestsWithJobs(t)
+
+	response, cleanup := client.Get("/v2/jobs/999999999/plugin_config")
+	t.Cleanup(cleanup)
+	cltest.AssertServerResponse(t, response, http.StatusNotFound)
+
+	response, cleanup = client.Get("/v2/jobs/uuidLikeString/plugin_config")
+	t.Cleanup(cleanup)
+	cltest.AssertServerResponse(t, response, http.StatusUnprocessableEntity)
+}
+
 func TestJobsController_Update_HappyPath(t *testing.T) {
 	ctx := testutils.Context(t)
 	cfg := configtest.NewGeneralConfig(t, func(c *chainlink.Config, s *chainlink.Secrets) {
@@ -0,0 +1,168 @@
+package web
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gin-gonic/gin"
+
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/logpoller"
+	evmtypes "github.com/smartcontractkit/chainlink/v2/core/chains/evm/types"
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/utils/big"
+	"github.com/smartcontractkit/chainlink/v2/core/services/chainlink"
+)
+
+type LogPollerFiltersController struct {
+	App chainlink.Application
+}
+
+// Index lists the filters registered with LogPoller for the given chain. Every filter is annotated with the IDs
+// of the jobs which registered it since the node started.
+// Example:
+//
+//	"<application>/v2/log_poller/filters?evmChainID=1"
+func (lfc *LogPollerFiltersController) Index(c *gin.Context) {
+	chain, err := getChain(lfc.App.GetRelayers().LegacyEVMChains(), c.Query("evmChainID"))
+	if err != nil {
+		if errors.Is(err, ErrInvalidChainID) || errors.Is(err, ErrMultipleChains) || errors.Is(err, ErrMissingChainID) {
+			jsonAPIError(c, http.StatusUnprocessableEntity, err)
+			return
+		}
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	chainID := chain.ID()
+
+	filters, err := lfc.App.GetLogPollerFilters(chainID)
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	resources := make([]LogPollerFilterResource, 0, len(filters))
+	for _, filter := range filters {
+		resources = append(resources, NewLogPollerFilterResource(filter, big.New(chainID)))
+	}
+	jsonAPIResponse(c, resources, "log_poller_filters")
+}
+
+// Import registers the given filters with LogPoller for the given chain. The request body is a JSON array of
+// filters, in the same format as returned by Index, which allows filters exported from one node to be re-imported.
+// Example:
+//
+//	"<application>/v2/log_poller/filters?evmChainID=1"
+func (lfc *LogPollerFiltersController) Import(c *gin.Context) {
+	chain, err := getChain(lfc.App.GetRelayers().LegacyEVMChains(), c.Query("evmChainID"))
+	if err != nil {
+		if errors.Is(err, ErrInvalidChainID) || errors.Is(err, ErrMultipleChains) || errors.Is(err, ErrMissingChainID) {
+			jsonAPIError(c, http.StatusUnprocessableEntity, err)
+			return
+		}
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	chainID := chain.ID()
+
+	var request []LogPollerFilterResource
+	if err = c.ShouldBindJSON(&request); err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	filters := make([]logpoller.Filter, 0, len(request))
+	for _, resource := range request {
+		filter, err2 := resource.ToFilter()
+		if err2 != nil {
+			jsonAPIError(c, http.StatusUnprocessableEntity, err2)
+			return
+		}
+		filters = append(filters, filter)
+	}
+
+	if err = lfc.App.RegisterLogPollerFilters(c.Request.Context(), chainID, filters); err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	resources := make([]LogPollerFilterResource, 0, len(filters))
+	for _, filter := range filters {
+		resources = append(resources, NewLogPollerFilterResource(filter, big.New(chainID)))
+	}
+	jsonAPIResponse(c, resources, "log_poller_filters")
+}
+
+// LogPollerFilterResource is the exported representation of a LogPoller filter.
+type LogPollerFilterResource struct {
+	Name         string           `json:"name"`
+	Addresses    []common.Address `json:"addresses"`
+	EventSigs    []common.Hash    `json:"eventSigs"`
+	Topic2       []common.Hash    `json:"topic2,omitempty"`
+	Topic3       []common.Hash    `json:"topic3,omitempty"`
+	Topic4       []common.Hash    `json:"topic4,omitempty"`
+	Retention    string           `json:"retention"`
+	MaxLogsKept  uint64           `json:"maxLogsKept"`
+	LogsPerBlock uint64           `json:"logsPerBlock"`
+	JobIDs       []int32          `json:"jobIDs,omitempty"`
+	EVMChainID   *big.Big         `json:"evmChainID,omitempty"`
+}
+
+// NewLogPollerFilterResource constructs a LogPollerFilterResource from a LogPoller filter.
+func NewLogPollerFilterResource(filter logpoller.Filter, chainID *big.Big) LogPollerFilterResource {
+	return LogPollerFilterResource{
+		Name:         filter.Name,
+		Addresses:    filter.Addresses,
+		EventSigs:    filter.EventSigs,
+		Topic2:       filter.Topic2,
+		Topic3:       filter.Topic3,
+		Topic4:       filter.Topic4,
+		Retention:    filter.Retention.String(),
+		MaxLogsKept:  filter.MaxLogsKept,
+		LogsPerBlock: filter.LogsPerBlock,
+		JobIDs:       filter.JobIDs,
+		EVMChainID:   chainID,
+	}
+}
+
+// ToFilter converts the resource back into a LogPoller filter. The filter isn't attributed to the jobs of the
+// resource, these are attributed when the filter is registered, see logpoller.WithJobID.
+func (r LogPollerFilterResource) ToFilter() (logpoller.Filter, error) {
+	var retention time.Duration
+	if r.Retention != "" {
+		var err error
+		retention, err = time.ParseDuration(r.Retention)
+		if err != nil {
+			return logpoller.Filter{}, fmt.Errorf("invalid retention %q for filter %q: %w", r.Retention, r.Name, err)
+		}
+	}
+	return logpoller.Filter{
+		Name:         r.Name,
+		Addresses:    evmtypes.AddressArray(r.Addresses),
+		EventSigs:    evmtypes.HashArray(r.EventSigs),
+		Topic2:       evmtypes.HashArray(r.Topic2),
+		Topic3:       evmtypes.HashArray(r.Topic3),
+		Topic4:       evmtypes.HashArray(r.Topic4),
+		Retention:    retention,
+		MaxLogsKept:  r.MaxLogsKept,
+		LogsPerBlock: r.LogsPerBlock,
+	}, nil
+}
+
+// GetID returns the jsonapi ID.
+func (r LogPollerFilterResource) GetID() string {
+	return r.Name
+}
+
+// GetName returns the collection name for jsonapi.
+func (LogPollerFilterResource) GetName() string {
+	return "log_poller_filters"
+}
+
+// SetID is used to conform to the UnmarshallIdentifier interface for
+// deserializing from jsonapi documents.
+func (r *LogPollerFilterResource) SetID(id string) error {
+	r.Name = id
+	return nil
+}
@@ -0,0 +1,80 @@
+package web_test
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/logpoller"
+	evmtypes "github.com/smartcontractkit/chainlink/v2/core/chains/evm/types"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils/configtest"
+	"github.com/smartcontractkit/chainlink/v2/core/web"
+)
+
+func TestLogPollerFiltersController_Index(t *testing.T) {
+	cfg := configtest.NewTestGeneralConfig(t)
+	ec := setupEthClientForControllerTests(t)
+	app := cltest.NewApplicationWithConfigAndKey(t, cfg, cltest.DefaultP2PKey, ec)
+	require.NoError(t, app.Start(testutils.Context(t)))
+	client := app.NewHTTPClient(nil)
+	resp, cleanup := client.Get("/v2/log_poller/filters?evmChainID=1")
+	t.Cleanup(cleanup)
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	b, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(b), "chain id does not match any local chains")
+}
+
+func TestLogPollerFiltersController_Import(t *testing.T) {
+	cfg := configtest.NewTestGeneralConfig(t)
+	ec := setupEthClientForControllerTests(t)
+	app := cltest.NewApplicationWithConfigAndKey(t, cfg, cltest.DefaultP2PKey, ec)
+	require.NoError(t, app.Start(testutils.Context(t)))
+	client := app.NewHTTPClient(nil)
+
+	resp, cleanup := client.Post("/v2/log_poller/filters?evmChainID=1", bytes.NewBufferString(`[]`))
+	t.Cleanup(cleanup)
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	b, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(b), "chain id does not match any local chains")
+}
+
+func TestLogPollerFilterResource_ToFilter(t *testing.T) {
+	filter := logpoller.Filter{
+		Name:         "test filter",
+		Addresses:    evmtypes.AddressArray{common.HexToAddress("0x1234")},
+		EventSigs:    evmtypes.HashArray{common.HexToHash("0x5678")},
+		Topic2:       evmtypes.HashArray{common.HexToHash("0x9abc")},
+		Retention:    90 * time.Minute,
+		MaxLogsKept:  100,
+		LogsPerBlock: 5,
+	}
+
+	resource := web.NewLogPollerFilterResource(filter, nil)
+	assert.Equal(t, "1h30m0s", resource.Retention)
+
+	got, err := resource.ToFilter()
+	require.NoError(t, err)
+	assert.Equal(t, filter, got)
+
+	// The jobs which registered the filter are listed, but aren't imported with it.
+	filter.JobIDs = []int32{1, 2}
+	resource = web.NewLogPollerFilterResource(filter, nil)
+	assert.Equal(t, []int32{1, 2}, resource.JobIDs)
+	got, err = resource.ToFilter()
+	require.NoError(t, err)
+	assert.Nil(t, got.JobIDs)
+
+	resource.Retention = "forever"
+	_, err = resource.ToFilter()
+	require.ErrorContains(t, err, `invalid retention "forever" for filter "test filter"`)
+}
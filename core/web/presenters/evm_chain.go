@@ -1,6 +1,10 @@
 package presenters
 
-import "github.com/smartcontractkit/chainlink-common/pkg/types"
+import (
+	"github.com/smartcontractkit/chainlink-common/pkg/types"
+
+	evmconfig "github.com/smartcontractkit/chainlink/v2/core/chains/evm/config"
+)
 
 // EVMChainResource is an EVM chain JSONAPI resource.
 type EVMChainResource struct {
@@ -21,6 +25,29 @@ func NewEVMChainResource(chain types.ChainStatus) EVMChainResource {
 	}}
 }
 
+// EVMChainConfigReloadResource is the JSONAPI resource of the settings changed by reloading the config of an
+// EVM chain.
+type EVMChainConfigReloadResource struct {
+	JAID
+	Changes []evmconfig.ReloadChange `json:"changes"`
+}
+
+// GetName implements the api2go EntityNamer interface
+func (r EVMChainConfigReloadResource) GetName() string {
+	return "evm_chain_config_reload"
+}
+
+// NewEVMChainConfigReloadResource returns a new EVMChainConfigReloadResource for the changes of the chain.
+func NewEVMChainConfigReloadResource(chainID string, changes []evmconfig.ReloadChange) EVMChainConfigReloadResource {
+	if changes == nil {
+		changes = []evmconfig.ReloadChange{}
+	}
+	return EVMChainConfigReloadResource{
+		JAID:    NewJAID(chainID),
+		Changes: changes,
+	}
+}
+
 // EVMNodeResource is an EVM node JSONAPI resource.
 type EVMNodeResource struct {
 	NodeResource
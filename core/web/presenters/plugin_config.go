@@ -0,0 +1,141 @@
+package presenters
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/job"
+)
+
+const redacted = "xxxxx"
+
+// secretWords are the (lowercased) words which make a config key secret wherever they appear in it, e.g.
+// dbPassword or clientSecret, its value is redacted then.
+var secretWords = []string{
+	"secret",
+	"password",
+	"passphrase",
+	"token",
+	"apikey",
+	"api_key",
+	"privatekey",
+	"private_key",
+	"credential",
+}
+
+// nonSecretKeys are the (lowercased) names of the known plugin config keys which contain a secret word but whose
+// values aren't secret.
+var nonSecretKeys = map[string]bool{
+	"tokenpricesusdpipeline":        true,
+	"tokenpricebatchwritefrequency": true,
+	"tokenpricechainselector":       true,
+	"tokeninfo":                     true,
+	"tokendataobservers":            true,
+	"maxsecretssizeslist":           true,
+}
+
+// PluginConfigResource represents the effective plugin config of an OCR2 or a CCIP job.
+type PluginConfigResource struct {
+	JAID
+	Type            JobSpecType            `json:"type"`
+	PluginType      string                 `json:"pluginType"`
+	Relay           string                 `json:"relay"`
+	RelayConfig     map[string]interface{} `json:"relayConfig"`
+	PluginConfig    map[string]interface{} `json:"pluginConfig"`
+	Valid           bool                   `json:"valid"`
+	ValidationError string                 `json:"validationError,omitempty"`
+}
+
+// GetName implements the api2go EntityNamer interface
+func (r PluginConfigResource) GetName() string {
+	return "pluginConfigs"
+}
+
+// NewPluginConfigResource initializes a new PluginConfigResource of the job, with the resolved plugin config and
+// the error validating it, if any. Values of secret keys and passwords of URLs are redacted. The relay config of
+// CCIP jobs are the relay configs of their chain families, and their plugin type the type of the job.
+func NewPluginConfigResource(j job.Job, pluginConfig job.JSONConfig, validationErr error) *PluginConfigResource {
+	resource := &PluginConfigResource{
+		JAID:         NewJAIDInt32(j.ID),
+		Type:         JobSpecType(j.Type),
+		PluginConfig: redactConfig(pluginConfig),
+		Valid:        validationErr == nil,
+	}
+	switch {
+	case j.OCR2OracleSpec != nil:
+		resource.PluginType = string(j.OCR2OracleSpec.PluginType)
+		resource.Relay = j.OCR2OracleSpec.Relay
+		resource.RelayConfig = redactConfig(j.OCR2OracleSpec.RelayConfig)
+	case j.CCIPSpec != nil:
+		resource.PluginType = string(j.Type)
+		resource.RelayConfig = redactConfig(j.CCIPSpec.RelayConfigs)
+	}
+	if validationErr != nil {
+		resource.ValidationError = validationErr.Error()
+	}
+	return resource
+}
+
+// redactConfig returns a copy of the config with the values of secret-like keys and the passwords of URLs redacted.
+func redactConfig(cfg map[string]interface{}) map[string]interface{} {
+	if cfg == nil {
+		return nil
+	}
+	out := make(map[string]interface{}, len(cfg))
+	for k, v := range cfg {
+		if isSecretKey(k) {
+			out[k] = redacted
+			continue
+		}
+		out[k] = redactValue(v)
+	}
+	return out
+}
+
+func redactValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		return redactConfig(v)
+	case job.JSONConfig:
+		return redactConfig(v)
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, e := range v {
+			out[i] = redactValue(e)
+		}
+		return out
+	case string:
+		return redactURL(v)
+	default:
+		return v
+	}
+}
+
+func isSecretKey(k string) bool {
+	lk := strings.ToLower(k)
+	if nonSecretKeys[lk] {
+		return false
+	}
+	for _, w := range secretWords {
+		if strings.Contains(lk, w) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactURL redacts the password of s if it is a URL with one, and returns s as is otherwise.
+func redactURL(s string) string {
+	if !strings.Contains(s, "://") {
+		return s
+	}
+	u, err := url.Parse(s)
+	if err != nil || u.User == nil {
+		return s
+	}
+	if _, ok := u.User.Password(); !ok {
+		return s
+	}
+	u.User = url.UserPassword(u.User.Username(), redacted)
+	return u.String()
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"strconv"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/gin-gonic/gin"
 	"github.com/pkg/errors"
 
@@ -69,6 +70,57 @@ func (bdc *ReplayController) ReplayFromBlock(c *gin.Context) {
 	jsonAPIResponse(c, &response, "response")
 }
 
+// ReplayRangeRequest is the request body for a targeted replay of LogPoller logs.
+type ReplayRangeRequest struct {
+	FromBlock int64            `json:"fromBlock"`
+	ToBlock   int64            `json:"toBlock"`
+	Addresses []common.Address `json:"addresses"`
+	EventSigs []common.Hash    `json:"eventSigs"`
+}
+
+// ReplayRange causes LogPoller to backfill logs within a block range, restricted to the given addresses and
+// event signatures, without re-scanning logs of unrelated filters. The replay completes before the response is sent.
+// Example:
+//
+//	"<application>/v2/replay_range?evmChainID=1"
+func (bdc *ReplayController) ReplayRange(c *gin.Context) {
+	var request ReplayRangeRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+	if request.FromBlock < 1 || request.ToBlock < request.FromBlock {
+		jsonAPIError(c, http.StatusUnprocessableEntity, errors.Errorf("invalid block range [%v, %v]", request.FromBlock, request.ToBlock))
+		return
+	}
+	if len(request.Addresses) == 0 {
+		jsonAPIError(c, http.StatusUnprocessableEntity, errors.New("at least one address is required"))
+		return
+	}
+
+	chain, err := getChain(bdc.App.GetRelayers().LegacyEVMChains(), c.Query("evmChainID"))
+	if err != nil {
+		if errors.Is(err, ErrInvalidChainID) || errors.Is(err, ErrMultipleChains) || errors.Is(err, ErrMissingChainID) {
+			jsonAPIError(c, http.StatusUnprocessableEntity, err)
+			return
+		}
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	chainID := chain.ID()
+
+	if err := bdc.App.ReplayLogPollerRange(c.Request.Context(), chainID, request.FromBlock, request.ToBlock, request.Addresses, request.EventSigs); err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	response := ReplayResponse{
+		Message:    "Replay completed",
+		EVMChainID: big.New(chainID),
+	}
+	jsonAPIResponse(c, &response, "response")
+}
+
 type ReplayResponse struct {
 	Message    string   `json:"message"`
 	EVMChainID *big.Big `json:"evmChainID"`
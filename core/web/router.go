@@ -291,8 +291,12 @@ func v2Routes(app chainlink.Application, r *gin.RouterGroup) {
 
 		rc := ReplayController{app}
 		authv2.POST("/replay_from_block/:number", auth.RequiresRunRole(rc.ReplayFromBlock))
+		authv2.POST("/replay_range", auth.RequiresRunRole(rc.ReplayRange))
 		lcaC := LCAController{app}
 		authv2.GET("/find_lca", auth.RequiresRunRole(lcaC.FindLCA))
+		lpfc := LogPollerFiltersController{app}
+		authv2.GET("/log_poller/filters", auth.RequiresRunRole(lpfc.Index))
+		authv2.POST("/log_poller/filters", auth.RequiresEditRole(lpfc.Import))
 
 		csakc := CSAKeysController{app}
 		authv2.GET("/keys/csa", csakc.Index)
@@ -369,10 +373,18 @@ func v2Routes(app chainlink.Application, r *gin.RouterGroup) {
 		jc := JobsController{app}
 		authv2.GET("/jobs", paginatedRequest(jc.Index))
 		authv2.GET("/jobs/:ID", jc.Show)
+		authv2.GET("/jobs/:ID/plugin_config", jc.ShowPluginConfig)
 		authv2.POST("/jobs", auth.RequiresEditRole(jc.Create))
 		authv2.PUT("/jobs/:ID", auth.RequiresEditRole(jc.Update))
 		authv2.DELETE("/jobs/:ID", auth.RequiresEditRole(jc.Delete))
 
+		fac := FunctionsAdminController{app}
+		authv2.GET("/jobs/:ID/functions/s4/reconciliation", auth.RequiresAdminRole(fac.S4Reconciliation))
+		authv2.POST("/jobs/:ID/functions/s4/reconciliation", auth.RequiresAdminRole(fac.S4Reconciliation))
+		authv2.POST("/jobs/:ID/functions/replay", auth.RequiresAdminRole(fac.ReplayRequests))
+		authv2.GET("/jobs/:ID/functions/decryption_queue", auth.RequiresAdminRole(fac.DecryptionQueue))
+		authv2.DELETE("/jobs/:ID/functions/decryption_queue", auth.RequiresAdminRole(fac.DecryptionQueue))
+
 		// PipelineRunsController
 		authv2.GET("/pipeline/runs", paginatedRequest(prc.Index))
 		authv2.GET("/jobs/:ID/runs", paginatedRequest(prc.Index))
@@ -402,6 +414,8 @@ func v2Routes(app chainlink.Application, r *gin.RouterGroup) {
 			chains.GET(chain.path, paginatedRequest(chain.cc.Index))
 			chains.GET(chain.path+"/:ID", chain.cc.Show)
 		}
+		eccc := EVMChainConfigController{app}
+		chains.PATCH("evm/:ID/config", auth.RequiresAdminRole(eccc.Reload))
 
 		nodes := authv2.Group("nodes")
 		for _, chain := range []struct {
@@ -421,6 +435,9 @@ func v2Routes(app chainlink.Application, r *gin.RouterGroup) {
 			chains.GET(chain.path+"/:ID/nodes", paginatedRequest(chain.nc.Index))
 		}
 
+		nsc := EVMNodeScoresController{app}
+		authv2.GET("/nodes/evm/scores", nsc.Index)
+
 		efc := EVMForwardersController{app}
 		authv2.GET("/nodes/evm/forwarders", paginatedRequest(efc.Index))
 		authv2.POST("/nodes/evm/forwarders/track", auth.RequiresEditRole(efc.Track))
@@ -0,0 +1,258 @@
+package deployment
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/tyler-smith/go-bip39"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/shared/generated/erc20"
+)
+
+// hardenedKeyStart is the index of the first hardened child key of BIP-32.
+const hardenedKeyStart = 1 << 31
+
+// AccountFleetConfig are the accounts of an AccountFleet and what they are funded with.
+type AccountFleetConfig struct {
+	// Mnemonic is the BIP-39 mnemonic the accounts are derived from, the same accounts are used on all the chains.
+	Mnemonic string
+	// Accounts is the number of accounts of each chain.
+	Accounts int
+	// Native is the native balance the accounts are topped up to from the deployer.
+	Native *big.Int
+	// Tokens are the balances of the ERC20 tokens of the chains the accounts are topped up to from the deployer,
+	// by chain selector and token.
+	Tokens map[uint64]map[common.Address]*big.Int
+}
+
+func (c AccountFleetConfig) Validate() error {
+	if c.Mnemonic == "" {
+		return errors.New("mnemonic must be set")
+	}
+	if c.Accounts <= 0 {
+		return errors.New("number of accounts must be positive")
+	}
+	if c.Native != nil && c.Native.Sign() < 0 {
+		return errors.New("native balance must not be negative")
+	}
+	for sel, tokens := range c.Tokens {
+		for token, amount := range tokens {
+			if amount == nil || amount.Sign() < 0 {
+				return fmt.Errorf("balance of token %s on chain %d must not be negative", token, sel)
+			}
+		}
+	}
+	return nil
+}
+
+// AccountFleet are funded accounts of the chains, so that load tests can send transactions concurrently rather
+// than queue them on the nonces of the deployer keys. Its funds are returned with SweepAccount.
+type AccountFleet struct {
+	// Accounts are the transact opts of the accounts of each chain, by chain selector.
+	Accounts map[uint64][]*bind.TransactOpts
+}
+
+// DeriveAccountKeys derives n account keys from the BIP-39 mnemonic along the BIP-44 path m/44'/60'/0'/0/i,
+// like wallets do. The checksum of the mnemonic is verified.
+func DeriveAccountKeys(mnemonic string, n int) ([]*ecdsa.PrivateKey, error) {
+	// BIP-39 seed, without passphrase
+	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, "")
+	if err != nil {
+		return nil, fmt.Errorf("invalid mnemonic: %w", err)
+	}
+	// no BIP-32 library is in the module graph, the keys are derived by hand along the path of go-ethereum
+	key, chainCode := bip32Master(seed)
+	for _, index := range accounts.DefaultRootDerivationPath {
+		var err error
+		key, chainCode, err = bip32Child(key, chainCode, index)
+		if err != nil {
+			return nil, err
+		}
+	}
+	keys := make([]*ecdsa.PrivateKey, 0, n)
+	for i := 0; i < n; i++ {
+		child, _, err := bip32Child(key, chainCode, uint32(i))
+		if err != nil {
+			return nil, err
+		}
+		privateKey, err := crypto.ToECDSA(child)
+		if err != nil {
+			return nil, fmt.Errorf("invalid key of account %d: %w", i, err)
+		}
+		keys = append(keys, privateKey)
+	}
+	return keys, nil
+}
+
+func bip32Master(seed []byte) (key, chainCode []byte) {
+	mac := hmac.New(sha512.New, []byte("Bitcoin seed"))
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+	return sum[:32], sum[32:]
+}
+
+// bip32Child derives the private child key of the index, hardened from hardenedKeyStart.
+func bip32Child(key, chainCode []byte, index uint32) ([]byte, []byte, error) {
+	var data []byte
+	if index >= hardenedKeyStart {
+		data = append([]byte{0}, key...)
+	} else {
+		privateKey, err := crypto.ToECDSA(key)
+		if err != nil {
+			return nil, nil, err
+		}
+		data = crypto.CompressPubkey(&privateKey.PublicKey)
+	}
+	data = binary.BigEndian.AppendUint32(data, index)
+	mac := hmac.New(sha512.New, chainCode)
+	mac.Write(data)
+	sum := mac.Sum(nil)
+	n := crypto.S256().Params().N
+	tweak := new(big.Int).SetBytes(sum[:32])
+	if tweak.Cmp(n) >= 0 {
+		return nil, nil, fmt.Errorf("invalid child key %d", index)
+	}
+	child := tweak.Add(tweak, new(big.Int).SetBytes(key))
+	child.Mod(child, n)
+	if child.Sign() == 0 {
+		return nil, nil, fmt.Errorf("invalid child key %d", index)
+	}
+	return common.LeftPadBytes(child.Bytes(), 32), sum[32:], nil
+}
+
+// NewAccountFleet derives the accounts of the config and tops up their native and token balances from the deployers
+// of the chains, which is idempotent. The transfers of a chain are sent at once and confirmed together.
+func NewAccountFleet(ctx context.Context, lggr logger.Logger, chains map[uint64]Chain, cfg AccountFleetConfig) (AccountFleet, error) {
+	if err := cfg.Validate(); err != nil {
+		return AccountFleet{}, fmt.Errorf("invalid account fleet config: %w", err)
+	}
+	for sel := range cfg.Tokens {
+		if _, ok := chains[sel]; !ok {
+			return AccountFleet{}, fmt.Errorf("chain %d of the tokens not found", sel)
+		}
+	}
+	keys, err := DeriveAccountKeys(cfg.Mnemonic, cfg.Accounts)
+	if err != nil {
+		return AccountFleet{}, fmt.Errorf("failed to derive accounts: %w", err)
+	}
+	fleet := AccountFleet{Accounts: make(map[uint64][]*bind.TransactOpts)}
+	for sel, chain := range chains {
+		chainID, err := evmChainID(ctx, chain)
+		if err != nil {
+			return AccountFleet{}, err
+		}
+		for _, key := range keys {
+			opts, err := bind.NewKeyedTransactorWithChainID(key, chainID)
+			if err != nil {
+				return AccountFleet{}, err
+			}
+			fleet.Accounts[sel] = append(fleet.Accounts[sel], opts)
+		}
+	}
+	var grp errgroup.Group
+	for sel, chain := range chains {
+		grp.Go(func() error {
+			return fundAccounts(ctx, lggr, chain, fleet.Accounts[sel], cfg.Native, cfg.Tokens[sel])
+		})
+	}
+	if err := grp.Wait(); err != nil {
+		return AccountFleet{}, err
+	}
+	return fleet, nil
+}
+
+// evmChainID returns the chain ID of the client of the chain, which differs from the one of its selector for
+// simulated chains.
+func evmChainID(ctx context.Context, chain Chain) (*big.Int, error) {
+	// the wrapping clients, e.g. InstrumentedClient, don't forward ChainID
+	if client, ok := UnwrapClient(chain.Client).(interface {
+		ChainID(ctx context.Context) (*big.Int, error)
+	}); ok {
+		chainID, err := client.ChainID(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get chain ID of chain %d: %w", chain.Selector, err)
+		}
+		return chainID, nil
+	}
+	chainID, err := EVMChainID(chain.Selector)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetUint64(chainID), nil
+}
+
+func fundAccounts(ctx context.Context, lggr logger.Logger, chain Chain, accounts []*bind.TransactOpts, native *big.Int, tokens map[common.Address]*big.Int) error {
+	deployer := chain.DeployerKey
+	nonce, err := chain.Client.PendingNonceAt(ctx, deployer.From)
+	if err != nil {
+		return fmt.Errorf("failed to get nonce of deployer on chain %d: %w", chain.Selector, err)
+	}
+	gasPrice, err := chain.Client.SuggestGasPrice(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to suggest gas price on chain %d: %w", chain.Selector, err)
+	}
+	var txs []*types.Transaction
+	for _, account := range accounts {
+		if native == nil {
+			break
+		}
+		balance, err := chain.Client.BalanceAt(ctx, account.From, nil)
+		if err != nil {
+			return fmt.Errorf("failed to get balance of %s on chain %d: %w", account.From, chain.Selector, err)
+		}
+		if balance.Cmp(native) >= 0 {
+			continue
+		}
+		tx, err := SendNativeTransfer(ctx, chain, deployer, nonce, gasPrice, account.From, new(big.Int).Sub(native, balance))
+		if err != nil {
+			return fmt.Errorf("failed to fund %s on chain %d: %w", account.From, chain.Selector, err)
+		}
+		txs = append(txs, tx)
+		nonce++
+	}
+	for token, amount := range tokens {
+		erc20Token, err := erc20.NewERC20(token, chain.Client)
+		if err != nil {
+			return err
+		}
+		for _, account := range accounts {
+			balance, err := erc20Token.BalanceOf(&bind.CallOpts{Context: ctx}, account.From)
+			if err != nil {
+				return fmt.Errorf("failed to get balance of token %s of %s on chain %d: %w", token, account.From, chain.Selector, err)
+			}
+			if balance.Cmp(amount) >= 0 {
+				continue
+			}
+			opts := *deployer
+			opts.Context = ctx
+			opts.Nonce = new(big.Int).SetUint64(nonce)
+			tx, err := erc20Token.Transfer(&opts, account.From, new(big.Int).Sub(amount, balance))
+			if err != nil {
+				return fmt.Errorf("failed to transfer token %s to %s on chain %d: %w", token, account.From, chain.Selector, MaybeDataErr(err))
+			}
+			txs = append(txs, tx)
+			nonce++
+		}
+	}
+	for _, tx := range txs {
+		if _, err := chain.Confirm(tx); err != nil {
+			return fmt.Errorf("failed to confirm funding tx %s on chain %d: %w", tx.Hash(), chain.Selector, err)
+		}
+	}
+	lggr.Infow("Funded accounts", "chain", chain.Selector, "accounts", len(accounts), "transfers", len(txs))
+	return nil
+}
@@ -0,0 +1,91 @@
+package deployment
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient/simulated"
+	chainsel "github.com/smartcontractkit/chain-selectors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/shared/generated/burn_mint_erc677"
+)
+
+func TestDeriveAccountKeys(t *testing.T) {
+	// the default accounts of hardhat and anvil
+	keys, err := DeriveAccountKeys("test test test test test test test test test test test junk", 2)
+	require.NoError(t, err)
+	require.Len(t, keys, 2)
+	require.Equal(t, common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"), crypto.PubkeyToAddress(keys[0].PublicKey))
+	require.Equal(t, common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8"), crypto.PubkeyToAddress(keys[1].PublicKey))
+
+	// a mistyped word isn't in the wordlist of the mnemonic
+	_, err = DeriveAccountKeys("test test test test test test test test test test test junkk", 2)
+	require.ErrorContains(t, err, "invalid mnemonic")
+}
+
+func TestNewAccountFleet(t *testing.T) {
+	ctx := context.Background()
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	deployer, err := bind.NewKeyedTransactorWithChainID(key, big.NewInt(1337))
+	require.NoError(t, err)
+	backend := simulated.NewBackend(types.GenesisAlloc{deployer.From: {Balance: E18Mult(1000)}})
+	chain := Chain{
+		Selector:    chainsel.TEST_90000001.Selector,
+		Client:      backend.Client(),
+		DeployerKey: deployer,
+		Confirm: func(tx *types.Transaction) (uint64, error) {
+			backend.Commit()
+			receipt, err := backend.Client().TransactionReceipt(ctx, tx.Hash())
+			if err != nil {
+				return 0, err
+			}
+			return receipt.BlockNumber.Uint64(), nil
+		},
+	}
+	tokenAddress, tx, token, err := burn_mint_erc677.DeployBurnMintERC677(deployer, backend.Client(), "Test", "TEST", 18, E18Mult(1000))
+	_, err = ConfirmIfNoError(chain, tx, err)
+	require.NoError(t, err)
+	tx, err = token.GrantMintRole(deployer, deployer.From)
+	_, err = ConfirmIfNoError(chain, tx, err)
+	require.NoError(t, err)
+	tx, err = token.Mint(deployer, deployer.From, E18Mult(100))
+	_, err = ConfirmIfNoError(chain, tx, err)
+	require.NoError(t, err)
+
+	cfg := AccountFleetConfig{
+		Mnemonic: "test test test test test test test test test test test junk",
+		Accounts: 3,
+		Native:   E18Mult(2),
+		Tokens:   map[uint64]map[common.Address]*big.Int{chain.Selector: {tokenAddress: E18Mult(5)}},
+	}
+	chains := map[uint64]Chain{chain.Selector: chain}
+	for range 2 {
+		// topping the accounts up again is a no-op
+		fleet, err := NewAccountFleet(ctx, logger.Test(t), chains, cfg)
+		require.NoError(t, err)
+		require.Len(t, fleet.Accounts[chain.Selector], 3)
+		for _, account := range fleet.Accounts[chain.Selector] {
+			balance, err := backend.Client().BalanceAt(ctx, account.From, nil)
+			require.NoError(t, err)
+			require.Equal(t, E18Mult(2), balance)
+			tokenBalance, err := token.BalanceOf(nil, account.From)
+			require.NoError(t, err)
+			require.Equal(t, E18Mult(5), tokenBalance)
+		}
+	}
+	tokenBalance, err := token.BalanceOf(nil, deployer.From)
+	require.NoError(t, err)
+	require.Equal(t, E18Mult(85), tokenBalance)
+
+	_, err = NewAccountFleet(ctx, logger.Test(t), chains, AccountFleetConfig{Mnemonic: cfg.Mnemonic})
+	require.Error(t, err)
+}
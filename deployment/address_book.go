@@ -1,6 +1,7 @@
 package deployment
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 	"sync"
@@ -9,6 +10,7 @@ import (
 
 	"github.com/Masterminds/semver/v3"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/gagliardetto/solana-go"
 	"github.com/pkg/errors"
 	chainsel "github.com/smartcontractkit/chain-selectors"
 )
@@ -43,6 +45,20 @@ func (tv TypeAndVersion) Equal(other TypeAndVersion) bool {
 	return tv.String() == other.String()
 }
 
+// MarshalText encodes the type and version as "<type> <version>", e.g. in JSON address books.
+func (tv TypeAndVersion) MarshalText() ([]byte, error) {
+	return []byte(tv.String()), nil
+}
+
+func (tv *TypeAndVersion) UnmarshalText(text []byte) error {
+	parsed, err := TypeAndVersionFromString(string(text))
+	if err != nil {
+		return err
+	}
+	*tv = parsed
+	return nil
+}
+
 func MustTypeAndVersionFromString(s string) TypeAndVersion {
 	tv, err := TypeAndVersionFromString(s)
 	if err != nil {
@@ -95,7 +111,7 @@ type AddressBookMap struct {
 
 // Save will save an address for a given chain selector. It will error if there is a conflicting existing address.
 func (m *AddressBookMap) save(chainSelector uint64, address string, typeAndVersion TypeAndVersion) error {
-	family, err := chainsel.GetSelectorFamily(chainSelector)
+	family, err := ChainFamily(chainSelector)
 	if err != nil {
 		return errors.Wrapf(ErrInvalidChainSelector, "chain selector %d", chainSelector)
 	}
@@ -110,8 +126,21 @@ func (m *AddressBookMap) save(chainSelector uint64, address string, typeAndVersi
 			return errors.Wrapf(ErrInvalidAddress, "address %s is not a valid Ethereum address, only Ethereum addresses supported for EVM chains", address)
 		}
 	}
+	if family == chainsel.FamilyAptos {
+		addr, err := ParseAptosAddress(address)
+		if err != nil || addr == (AptosAddress{}) {
+			return errors.Wrapf(ErrInvalidAddress, "address %s is not a valid Aptos address", address)
+		}
+		// aptos addresses are standardized to their long form, like EVM addresses to EIP55
+		address = addr.String()
+	}
+	if family == chainsel.FamilySolana {
+		if _, err := solana.PublicKeyFromBase58(address); err != nil {
+			return errors.Wrapf(ErrInvalidAddress, "address %s is not a valid Solana public key", address)
+		}
+	}
 
-	// TODO NONEVM-960: Add validation for non-EVM chain addresses
+	// TODO NONEVM-960: Add validation for other non-EVM chain addresses
 
 	if typeAndVersion.Type == "" {
 		return fmt.Errorf("type cannot be empty")
@@ -147,7 +176,7 @@ func (m *AddressBookMap) Addresses() (map[uint64]map[string]TypeAndVersion, erro
 }
 
 func (m *AddressBookMap) AddressesForChain(chainSelector uint64) (map[string]TypeAndVersion, error) {
-	_, err := chainsel.GetChainIDFromSelector(chainSelector)
+	_, err := ChainID(chainSelector)
 	if err != nil {
 		return nil, errors.Wrapf(ErrInvalidChainSelector, "chain selector %d", chainSelector)
 	}
@@ -240,6 +269,34 @@ func NewMemoryAddressBookFromMap(addressesByChain map[uint64]map[string]TypeAndV
 	}
 }
 
+// AddressBookFromJSON decodes an address book encoded by AddressBookToJSON, validating its addresses
+// like Save does.
+func AddressBookFromJSON(data []byte) (*AddressBookMap, error) {
+	var addressesByChain map[uint64]map[string]TypeAndVersion
+	if err := json.Unmarshal(data, &addressesByChain); err != nil {
+		return nil, fmt.Errorf("failed to decode address book: %w", err)
+	}
+	ab := NewMemoryAddressBook()
+	for chainSelector, addresses := range addressesByChain {
+		for address, tv := range addresses {
+			if err := ab.Save(chainSelector, address, tv); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return ab, nil
+}
+
+// AddressBookToJSON encodes the addresses of the address book as a JSON object keyed by chain selector,
+// then by address, e.g. {"5009297550715157269": {"0x...": "OnRamp 1.6.0-dev"}}.
+func AddressBookToJSON(ab AddressBook) ([]byte, error) {
+	addresses, err := ab.Addresses()
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(addresses, "", "  ")
+}
+
 // SearchAddressBook search an address book for a given chain and contract type and return the first matching address.
 func SearchAddressBook(ab AddressBook, chain uint64, typ ContractType) (string, error) {
 	addrs, err := ab.AddressesForChain(chain)
@@ -0,0 +1,270 @@
+package deployment
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// MergePolicy decides which type and version an address keeps when two address books
+// disagree on it.
+type MergePolicy string
+
+const (
+	// MergePolicyFailOnConflict fails the merge on any conflict without changing the address book.
+	MergePolicyFailOnConflict MergePolicy = "fail-on-conflict"
+	// MergePolicyPreferNewerVersion keeps the higher version of the same contract type.
+	// Conflicts between different contract types or equal versions still fail the merge.
+	MergePolicyPreferNewerVersion MergePolicy = "prefer-newer-version"
+	// MergePolicyPreferExisting keeps the type and version already in the address book.
+	MergePolicyPreferExisting MergePolicy = "prefer-existing"
+)
+
+func (p MergePolicy) Validate() error {
+	switch p {
+	case MergePolicyFailOnConflict, MergePolicyPreferNewerVersion, MergePolicyPreferExisting:
+		return nil
+	default:
+		return fmt.Errorf("unknown merge policy %q", p)
+	}
+}
+
+// AddressConflict is an address whose type and version differs between two address books.
+type AddressConflict struct {
+	ChainSelector uint64
+	Address       string
+	Existing      TypeAndVersion
+	Incoming      TypeAndVersion
+	// Resolved is the type and version the address kept, nil if the conflict could not be resolved.
+	Resolved *TypeAndVersion
+}
+
+func (c AddressConflict) String() string {
+	resolution := "unresolved"
+	if c.Resolved != nil {
+		resolution = "kept " + c.Resolved.String()
+	}
+	return fmt.Sprintf("chain %d address %s: existing %s, incoming %s, %s",
+		c.ChainSelector, c.Address, c.Existing, c.Incoming, resolution)
+}
+
+// MergeReport summarizes a merge of address books.
+type MergeReport struct {
+	Added     int
+	Removed   int
+	Unchanged int
+	Conflicts []AddressConflict
+}
+
+// Unresolved returns the conflicts the policy could not resolve.
+func (r MergeReport) Unresolved() []AddressConflict {
+	var unresolved []AddressConflict
+	for _, c := range r.Conflicts {
+		if c.Resolved == nil {
+			unresolved = append(unresolved, c)
+		}
+	}
+	return unresolved
+}
+
+func (r MergeReport) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d added, %d removed, %d unchanged, %d conflicts", r.Added, r.Removed, r.Unchanged, len(r.Conflicts))
+	for _, c := range r.Conflicts {
+		fmt.Fprintf(&b, "\n\t%s", c)
+	}
+	return b.String()
+}
+
+func (r *MergeReport) sortConflicts() {
+	sort.Slice(r.Conflicts, func(i, j int) bool {
+		if r.Conflicts[i].ChainSelector != r.Conflicts[j].ChainSelector {
+			return r.Conflicts[i].ChainSelector < r.Conflicts[j].ChainSelector
+		}
+		return r.Conflicts[i].Address < r.Conflicts[j].Address
+	})
+}
+
+func (r MergeReport) err() error {
+	if unresolved := r.Unresolved(); len(unresolved) > 0 {
+		msgs := make([]string, 0, len(unresolved))
+		for _, c := range unresolved {
+			msgs = append(msgs, c.String())
+		}
+		return fmt.Errorf("%d unresolved address book conflicts:\n\t%s", len(unresolved), strings.Join(msgs, "\n\t"))
+	}
+	return nil
+}
+
+// resolveConflict returns the type and version an address keeps under the policy, nil if unresolved.
+func resolveConflict(existing, incoming TypeAndVersion, policy MergePolicy) *TypeAndVersion {
+	switch policy {
+	case MergePolicyPreferExisting:
+		return &existing
+	case MergePolicyPreferNewerVersion:
+		if existing.Type != incoming.Type {
+			return nil
+		}
+		switch existing.Version.Compare(&incoming.Version) {
+		case 1:
+			return &existing
+		case -1:
+			return &incoming
+		}
+	}
+	return nil
+}
+
+// MergeWithPolicy merges the addresses of another address book into this one. Unlike Merge, addresses
+// already present with the same type and version are skipped, and conflicting ones are resolved with the policy.
+// The address book is left unchanged if any conflict is unresolved, the report lists all of them.
+func (m *AddressBookMap) MergeWithPolicy(ab AddressBook, policy MergePolicy) (MergeReport, error) {
+	if err := policy.Validate(); err != nil {
+		return MergeReport{}, err
+	}
+	addresses, err := ab.Addresses()
+	if err != nil {
+		return MergeReport{}, err
+	}
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	// validate against a copy so the address book is unchanged on errors
+	merged := &AddressBookMap{addressesByChain: m.cloneAddresses(m.addressesByChain)}
+	var report MergeReport
+	for chainSelector, chainAddresses := range addresses {
+		for address, incoming := range chainAddresses {
+			existing, exists := merged.addressesByChain[chainSelector][address]
+			if !exists {
+				if err := merged.save(chainSelector, address, incoming); err != nil {
+					return report, err
+				}
+				report.Added++
+				continue
+			}
+			if existing.Equal(incoming) {
+				report.Unchanged++
+				continue
+			}
+			conflict := AddressConflict{
+				ChainSelector: chainSelector,
+				Address:       address,
+				Existing:      existing,
+				Incoming:      incoming,
+				Resolved:      resolveConflict(existing, incoming, policy),
+			}
+			report.Conflicts = append(report.Conflicts, conflict)
+			if conflict.Resolved != nil {
+				merged.addressesByChain[chainSelector][address] = *conflict.Resolved
+			}
+		}
+	}
+	report.sortConflicts()
+	if err := report.err(); err != nil {
+		return report, err
+	}
+	m.addressesByChain = merged.addressesByChain
+	return report, nil
+}
+
+// ThreeWayMerge merges the address books of two concurrent changeset runs against the same environment,
+// ours and theirs, which both started from base. Addresses added, removed or changed by only one side are
+// taken from it. Addresses changed differently by both sides are conflicts resolved with the policy, where
+// ours is the existing side. An address removed by one side and changed by the other is never resolved.
+func ThreeWayMerge(base, ours, theirs AddressBook, policy MergePolicy) (*AddressBookMap, MergeReport, error) {
+	if err := policy.Validate(); err != nil {
+		return nil, MergeReport{}, err
+	}
+	books := make([]map[uint64]map[string]TypeAndVersion, 0, 3)
+	for _, ab := range []AddressBook{base, ours, theirs} {
+		addresses, err := ab.Addresses()
+		if err != nil {
+			return nil, MergeReport{}, err
+		}
+		books = append(books, addresses)
+	}
+	baseAddrs, ourAddrs, theirAddrs := books[0], books[1], books[2]
+
+	type key struct {
+		chainSelector uint64
+		address       string
+	}
+	keys := make(map[key]struct{})
+	for _, book := range books {
+		for chainSelector, chainAddresses := range book {
+			for address := range chainAddresses {
+				keys[key{chainSelector, address}] = struct{}{}
+			}
+		}
+	}
+
+	result := NewMemoryAddressBook()
+	var report MergeReport
+	for k := range keys {
+		b, inBase := baseAddrs[k.chainSelector][k.address]
+		o, inOurs := ourAddrs[k.chainSelector][k.address]
+		t, inTheirs := theirAddrs[k.chainSelector][k.address]
+		ourChange := inOurs != inBase || (inOurs && !o.Equal(b))
+		theirChange := inTheirs != inBase || (inTheirs && !t.Equal(b))
+
+		var keep *TypeAndVersion
+		switch {
+		case !ourChange && !theirChange:
+			if inBase {
+				keep = &b
+				report.Unchanged++
+			}
+		case ourChange && !theirChange:
+			keep = takeChange(inOurs, o, inBase, &report)
+		case !ourChange && theirChange:
+			keep = takeChange(inTheirs, t, inBase, &report)
+		case inOurs && inTheirs && o.Equal(t):
+			// both sides made the same change
+			keep = takeChange(true, o, inBase, &report)
+		case inOurs && inTheirs:
+			conflict := AddressConflict{
+				ChainSelector: k.chainSelector,
+				Address:       k.address,
+				Existing:      o,
+				Incoming:      t,
+				Resolved:      resolveConflict(o, t, policy),
+			}
+			report.Conflicts = append(report.Conflicts, conflict)
+			keep = conflict.Resolved
+		case !inOurs && !inTheirs:
+			// both sides removed it
+			report.Removed++
+		default:
+			conflict := AddressConflict{
+				ChainSelector: k.chainSelector,
+				Address:       k.address,
+				Existing:      o,
+				Incoming:      t,
+			}
+			report.Conflicts = append(report.Conflicts, conflict)
+		}
+		if keep != nil {
+			if err := result.save(k.chainSelector, k.address, *keep); err != nil {
+				return nil, report, err
+			}
+		}
+	}
+	report.sortConflicts()
+	if err := report.err(); err != nil {
+		return nil, report, err
+	}
+	return result, report, nil
+}
+
+// takeChange returns the type and version of an address changed by a single side of a three way merge.
+func takeChange(present bool, tv TypeAndVersion, inBase bool, report *MergeReport) *TypeAndVersion {
+	if !present {
+		report.Removed++
+		return nil
+	}
+	if !inBase {
+		report.Added++
+	}
+	return &tv
+}
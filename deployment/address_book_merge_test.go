@@ -0,0 +1,118 @@
+package deployment
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	chainsel "github.com/smartcontractkit/chain-selectors"
+	"github.com/stretchr/testify/require"
+	"gotest.tools/v3/assert"
+)
+
+func TestAddressBook_MergeWithPolicy(t *testing.T) {
+	chain := chainsel.TEST_90000001.Selector
+	onRamp100 := NewTypeAndVersion("OnRamp", Version1_0_0)
+	onRamp110 := NewTypeAndVersion("OnRamp", Version1_1_0)
+	offRamp100 := NewTypeAndVersion("OffRamp", Version1_0_0)
+	addr1 := common.HexToAddress("0x1").String()
+	addr2 := common.HexToAddress("0x2").String()
+	addr3 := common.HexToAddress("0x3").String()
+	existing := func() *AddressBookMap {
+		return NewMemoryAddressBookFromMap(map[uint64]map[string]TypeAndVersion{
+			chain: {addr1: onRamp100, addr2: onRamp100},
+		})
+	}
+	incoming := NewMemoryAddressBookFromMap(map[uint64]map[string]TypeAndVersion{
+		chain: {addr1: onRamp100, addr2: onRamp110, addr3: offRamp100},
+	})
+
+	ab := existing()
+	report, err := ab.MergeWithPolicy(incoming, MergePolicyFailOnConflict)
+	require.ErrorContains(t, err, "1 unresolved address book conflicts")
+	require.Len(t, report.Unresolved(), 1)
+	addresses, err := ab.Addresses()
+	require.NoError(t, err)
+	assert.DeepEqual(t, addresses, map[uint64]map[string]TypeAndVersion{
+		chain: {addr1: onRamp100, addr2: onRamp100},
+	})
+
+	ab = existing()
+	report, err = ab.MergeWithPolicy(incoming, MergePolicyPreferNewerVersion)
+	require.NoError(t, err)
+	require.Equal(t, 1, report.Added)
+	require.Equal(t, 1, report.Unchanged)
+	require.Len(t, report.Conflicts, 1)
+	addresses, err = ab.Addresses()
+	require.NoError(t, err)
+	assert.DeepEqual(t, addresses, map[uint64]map[string]TypeAndVersion{
+		chain: {addr1: onRamp100, addr2: onRamp110, addr3: offRamp100},
+	})
+
+	ab = existing()
+	_, err = ab.MergeWithPolicy(incoming, MergePolicyPreferExisting)
+	require.NoError(t, err)
+	addresses, err = ab.Addresses()
+	require.NoError(t, err)
+	assert.DeepEqual(t, addresses, map[uint64]map[string]TypeAndVersion{
+		chain: {addr1: onRamp100, addr2: onRamp100, addr3: offRamp100},
+	})
+
+	// different types can't be ordered by version
+	_, err = existing().MergeWithPolicy(NewMemoryAddressBookFromMap(map[uint64]map[string]TypeAndVersion{
+		chain: {addr1: offRamp100},
+	}), MergePolicyPreferNewerVersion)
+	require.Error(t, err)
+
+	_, err = existing().MergeWithPolicy(incoming, "unknown")
+	require.Error(t, err)
+}
+
+func TestThreeWayMerge(t *testing.T) {
+	chain := chainsel.TEST_90000001.Selector
+	onRamp100 := NewTypeAndVersion("OnRamp", Version1_0_0)
+	onRamp110 := NewTypeAndVersion("OnRamp", Version1_1_0)
+	offRamp100 := NewTypeAndVersion("OffRamp", Version1_0_0)
+	addr := func(i int64) string { return common.BigToAddress(big.NewInt(i)).String() }
+	base := NewMemoryAddressBookFromMap(map[uint64]map[string]TypeAndVersion{
+		chain: {addr(1): onRamp100, addr(2): onRamp100, addr(3): onRamp100},
+	})
+	ours := NewMemoryAddressBookFromMap(map[uint64]map[string]TypeAndVersion{
+		// removes 2, upgrades 3, adds 4
+		chain: {addr(1): onRamp100, addr(3): onRamp110, addr(4): offRamp100},
+	})
+	theirs := NewMemoryAddressBookFromMap(map[uint64]map[string]TypeAndVersion{
+		// keeps 2 and 3, adds 5
+		chain: {addr(1): onRamp100, addr(2): onRamp100, addr(3): onRamp100, addr(5): offRamp100},
+	})
+	merged, report, err := ThreeWayMerge(base, ours, theirs, MergePolicyFailOnConflict)
+	require.NoError(t, err)
+	require.Equal(t, 2, report.Added)
+	require.Equal(t, 1, report.Removed)
+	require.Empty(t, report.Conflicts)
+	addresses, err := merged.Addresses()
+	require.NoError(t, err)
+	assert.DeepEqual(t, addresses, map[uint64]map[string]TypeAndVersion{
+		chain: {addr(1): onRamp100, addr(3): onRamp110, addr(4): offRamp100, addr(5): offRamp100},
+	})
+
+	// both sides add the same address with different versions
+	conflicting := NewMemoryAddressBookFromMap(map[uint64]map[string]TypeAndVersion{
+		chain: {addr(1): onRamp100, addr(2): onRamp100, addr(3): onRamp100, addr(4): NewTypeAndVersion("OffRamp", Version1_1_0)},
+	})
+	_, report, err = ThreeWayMerge(base, ours, conflicting, MergePolicyFailOnConflict)
+	require.Error(t, err)
+	require.Len(t, report.Unresolved(), 1)
+	merged, _, err = ThreeWayMerge(base, ours, conflicting, MergePolicyPreferNewerVersion)
+	require.NoError(t, err)
+	addresses, err = merged.Addresses()
+	require.NoError(t, err)
+	require.Equal(t, NewTypeAndVersion("OffRamp", Version1_1_0), addresses[chain][addr(4)])
+
+	// removed by one side, changed by the other
+	changed := NewMemoryAddressBookFromMap(map[uint64]map[string]TypeAndVersion{
+		chain: {addr(1): onRamp100, addr(2): onRamp110, addr(3): onRamp100},
+	})
+	_, _, err = ThreeWayMerge(base, ours, changed, MergePolicyPreferExisting)
+	require.ErrorContains(t, err, addr(2))
+}
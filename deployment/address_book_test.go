@@ -72,6 +72,29 @@ func TestAddressBook_Save(t *testing.T) {
 	})
 }
 
+func TestAddressBook_JSON(t *testing.T) {
+	ab := NewMemoryAddressBook()
+	addr1 := common.HexToAddress("0x1").String()
+	require.NoError(t, ab.Save(chainsel.TEST_90000001.Selector, addr1, NewTypeAndVersion("OnRamp", Version1_6_0_dev)))
+	require.NoError(t, ab.Save(chainsel.TEST_90000002.Selector, addr1, NewTypeAndVersion("OffRamp", Version1_6_0_dev)))
+
+	data, err := AddressBookToJSON(ab)
+	require.NoError(t, err)
+	require.Contains(t, string(data), `"OnRamp 1.6.0-dev"`)
+	decoded, err := AddressBookFromJSON(data)
+	require.NoError(t, err)
+	expected, err := ab.Addresses()
+	require.NoError(t, err)
+	addresses, err := decoded.Addresses()
+	require.NoError(t, err)
+	assert.DeepEqual(t, expected, addresses)
+
+	_, err = AddressBookFromJSON([]byte(`{"0": {"0x0000000000000000000000000000000000000001": "OnRamp 1.6.0"}}`))
+	require.ErrorIs(t, err, ErrInvalidChainSelector)
+	_, err = AddressBookFromJSON([]byte(`{"909606746561742123": {"0x1": "OnRamp"}}`))
+	require.ErrorContains(t, err, "invalid type and version")
+}
+
 func TestAddressBook_Merge(t *testing.T) {
 	onRamp100 := NewTypeAndVersion("OnRamp", Version1_0_0)
 	onRamp110 := NewTypeAndVersion("OnRamp", Version1_1_0)
@@ -243,3 +266,18 @@ func TestAddressBook_ConcurrencyAndDeadlock(t *testing.T) {
 
 	wg.Wait()
 }
+
+func TestAddressBook_SaveAptos(t *testing.T) {
+	ab := NewMemoryAddressBook()
+	sel := chainsel.APTOS_TESTNET.Selector
+	tv := NewTypeAndVersion("Package", Version1_0_0)
+
+	require.NoError(t, ab.Save(sel, "0x1", tv))
+	addresses, err := ab.AddressesForChain(sel)
+	require.NoError(t, err)
+	require.Contains(t, addresses, "0x0000000000000000000000000000000000000000000000000000000000000001")
+	// the long form of a saved address is a duplicate
+	require.Error(t, ab.Save(sel, "0x0000000000000000000000000000000000000000000000000000000000000001", tv))
+	require.ErrorIs(t, ab.Save(sel, "0x0", tv), ErrInvalidAddress)
+	require.ErrorIs(t, ab.Save(sel, "not an address", tv), ErrInvalidAddress)
+}
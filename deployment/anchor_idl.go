@@ -0,0 +1,710 @@
+package deployment
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+// AnchorIDL is the interface description of an Anchor program, the JSON generated along with the program by
+// `anchor build` in target/idl. Both the format of Anchor 0.30, which has the discriminators, and the earlier
+// one are supported. Instructions are encoded and accounts decoded from it, so that the layouts always match
+// the program the IDL was generated with.
+//
+// Names are matched in snake_case whatever the format, e.g. "set_chain_remote_config" matches the
+// setChainRemoteConfig instruction of an earlier IDL, and decoded struct fields are keyed in snake_case.
+type AnchorIDL struct {
+	Name         string                 `json:"name"`
+	Metadata     struct{ Name string }  `json:"metadata"`
+	Instructions []AnchorIDLInstruction `json:"instructions"`
+	Accounts     []AnchorIDLTypeDef     `json:"accounts"`
+	Types        []AnchorIDLTypeDef     `json:"types"`
+}
+
+type AnchorIDLInstruction struct {
+	Name          string              `json:"name"`
+	Discriminator AnchorDiscriminator `json:"discriminator"`
+	Accounts      []AnchorIDLAccount  `json:"accounts"`
+	Args          []AnchorIDLField    `json:"args"`
+}
+
+// AnchorIDLAccount is an account of an instruction. Nested account groups aren't supported.
+type AnchorIDLAccount struct {
+	Name     string `json:"name"`
+	Writable bool   `json:"writable"`
+	IsMut    bool   `json:"isMut"`
+	Signer   bool   `json:"signer"`
+	IsSigner bool   `json:"isSigner"`
+	Optional bool   `json:"optional"`
+	// Address is the fixed address of the account, e.g. of the system program.
+	Address string `json:"address"`
+	// Accounts are set for nested account groups.
+	Accounts []json.RawMessage `json:"accounts"`
+}
+
+type AnchorIDLField struct {
+	Name string        `json:"name"`
+	Type AnchorIDLType `json:"type"`
+}
+
+// AnchorIDLTypeDef is a defined type, or an account whose type is the defined type of the same name in
+// the IDLs of Anchor 0.30.
+type AnchorIDLTypeDef struct {
+	Name          string              `json:"name"`
+	Discriminator AnchorDiscriminator `json:"discriminator"`
+	Type          *struct {
+		Kind string `json:"kind"`
+		// Fields are the named fields of a struct, tuple structs aren't supported.
+		Fields   json.RawMessage `json:"fields"`
+		Variants []struct {
+			Name   string          `json:"name"`
+			Fields json.RawMessage `json:"fields"`
+		} `json:"variants"`
+	} `json:"type"`
+}
+
+// AnchorIDLType is a primitive type like "u64" or "pubkey", or else one of vec, option, array and defined.
+// Other types, e.g. generics, are kept as their JSON in Primitive and can't be encoded.
+type AnchorIDLType struct {
+	Primitive string
+	Vec       *AnchorIDLType
+	Option    *AnchorIDLType
+	Array     *AnchorIDLType
+	ArrayLen  int
+	Defined   string
+}
+
+func (t *AnchorIDLType) UnmarshalJSON(b []byte) error {
+	if err := json.Unmarshal(b, &t.Primitive); err == nil {
+		return nil
+	}
+	var v struct {
+		Vec     *AnchorIDLType    `json:"vec"`
+		Option  *AnchorIDLType    `json:"option"`
+		Array   []json.RawMessage `json:"array"`
+		Defined json.RawMessage   `json:"defined"`
+	}
+	if err := json.Unmarshal(b, &v); err != nil {
+		t.Primitive = string(b)
+		return nil
+	}
+	t.Vec, t.Option = v.Vec, v.Option
+	if len(v.Array) == 2 {
+		t.Array = new(AnchorIDLType)
+		if err := json.Unmarshal(v.Array[0], t.Array); err != nil {
+			return err
+		}
+		if err := json.Unmarshal(v.Array[1], &t.ArrayLen); err != nil {
+			// the length is a generic
+			*t = AnchorIDLType{Primitive: string(b)}
+			return nil
+		}
+	}
+	if len(v.Defined) > 0 {
+		// a name, or {"name": ...} in Anchor 0.30
+		if err := json.Unmarshal(v.Defined, &t.Defined); err != nil {
+			var named struct{ Name string }
+			if err := json.Unmarshal(v.Defined, &named); err != nil {
+				return err
+			}
+			t.Defined = named.Name
+		}
+	}
+	if t.Vec == nil && t.Option == nil && t.Array == nil && t.Defined == "" {
+		t.Primitive = string(b)
+	}
+	return nil
+}
+
+func (t AnchorIDLType) String() string {
+	switch {
+	case t.Vec != nil:
+		return fmt.Sprintf("vec<%s>", t.Vec)
+	case t.Option != nil:
+		return fmt.Sprintf("option<%s>", t.Option)
+	case t.Array != nil:
+		return fmt.Sprintf("[%s; %d]", t.Array, t.ArrayLen)
+	case t.Defined != "":
+		return t.Defined
+	default:
+		return t.Primitive
+	}
+}
+
+// AnchorDiscriminator is the discriminator of an instruction or an account, a JSON array of bytes.
+type AnchorDiscriminator []byte
+
+func (d *AnchorDiscriminator) UnmarshalJSON(b []byte) error {
+	var v []uint8
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
+
+// ParseAnchorIDL parses the JSON IDL of a program.
+func ParseAnchorIDL(b []byte) (*AnchorIDL, error) {
+	var idl AnchorIDL
+	if err := json.Unmarshal(b, &idl); err != nil {
+		return nil, fmt.Errorf("invalid IDL: %w", err)
+	}
+	if idl.Name == "" {
+		idl.Name = idl.Metadata.Name
+	}
+	return &idl, nil
+}
+
+// Instruction returns the instruction of the program with the accounts and args, by name. The instruction
+// has the accounts of the IDL in order, optional accounts which aren't set are replaced by the program id as
+// Anchor expects, and remaining accounts can be appended to its AccountValues.
+//
+// The Go values of the args are integers for integer types (*big.Int for 128 bits), solana.PublicKey, []byte
+// for bytes, slices and arrays for vec and array, nil for empty options, map[string]any for structs, and the
+// variant name for enums, or map[string]any{variant: fields} for variants with fields.
+func (idl *AnchorIDL) Instruction(
+	programID solana.PublicKey,
+	name string,
+	accounts map[string]solana.PublicKey,
+	args map[string]any,
+) (*solana.GenericInstruction, error) {
+	ix, err := idl.instruction(name)
+	if err != nil {
+		return nil, err
+	}
+	metas := solana.AccountMetaSlice{}
+	seen := make(map[string]bool)
+	for _, account := range ix.Accounts {
+		if len(account.Accounts) > 0 {
+			return nil, fmt.Errorf("nested accounts %s of instruction %s aren't supported", account.Name, ix.Name)
+		}
+		accountName := anchorSnakeCase(account.Name)
+		key, ok := lookupSnakeCase(accounts, accountName)
+		switch {
+		case ok:
+			seen[accountName] = true
+		case account.Address != "":
+			if key, err = solana.PublicKeyFromBase58(account.Address); err != nil {
+				return nil, fmt.Errorf("invalid address of account %s: %w", account.Name, err)
+			}
+		case account.Optional:
+			key = programID
+		default:
+			return nil, fmt.Errorf("account %s of instruction %s not set", accountName, ix.Name)
+		}
+		meta := solana.Meta(key)
+		if account.Writable || account.IsMut {
+			meta = meta.WRITE()
+		}
+		if account.Signer || account.IsSigner {
+			meta = meta.SIGNER()
+		}
+		metas = append(metas, meta)
+	}
+	for accountName := range accounts {
+		if !seen[anchorSnakeCase(accountName)] {
+			return nil, fmt.Errorf("instruction %s has no account %s", ix.Name, accountName)
+		}
+	}
+
+	data := append([]byte{}, idl.instructionDiscriminator(ix)...)
+	data, err = idl.encodeFields(data, ix.Args, args)
+	if err != nil {
+		return nil, fmt.Errorf("invalid args of instruction %s: %w", ix.Name, err)
+	}
+	return solana.NewInstruction(programID, metas, data), nil
+}
+
+// DecodeInstruction decodes the data of an instruction of the program, returning its name in snake_case
+// and its args, decoded like DecodeAccount. It fails if the data isn't exactly an instruction of the IDL.
+func (idl *AnchorIDL) DecodeInstruction(data []byte) (string, map[string]any, error) {
+	for _, ix := range idl.Instructions {
+		discriminator := idl.instructionDiscriminator(ix)
+		if len(data) < len(discriminator) || string(data[:len(discriminator)]) != string(discriminator) {
+			continue
+		}
+		args, rest, err := idl.decodeFields(data[len(discriminator):], ix.Args)
+		if err != nil {
+			return "", nil, fmt.Errorf("invalid args of instruction %s: %w", ix.Name, err)
+		}
+		if len(rest) > 0 {
+			return "", nil, fmt.Errorf("%d trailing bytes after the args of instruction %s", len(rest), ix.Name)
+		}
+		return anchorSnakeCase(ix.Name), args, nil
+	}
+	return "", nil, fmt.Errorf("unknown instruction of program %s", idl.Name)
+}
+
+// DecodeAccount decodes the data of an account of the program of the type, checking its discriminator.
+// Values are decoded as the Go types of Instruction, with integers of their exact size, []any for vecs
+// and arrays other than bytes, and struct fields keyed in snake_case. Trailing bytes, e.g. the unused
+// space allocated for accounts which grow, are ignored.
+func (idl *AnchorIDL) DecodeAccount(name string, data []byte) (map[string]any, error) {
+	var def *AnchorIDLTypeDef
+	for i := range idl.Accounts {
+		if anchorSnakeCase(idl.Accounts[i].Name) == anchorSnakeCase(name) {
+			def = &idl.Accounts[i]
+		}
+	}
+	if def == nil {
+		return nil, fmt.Errorf("unknown account %s of program %s", name, idl.Name)
+	}
+	discriminator := []byte(def.Discriminator)
+	if len(discriminator) == 0 {
+		h := sha256.Sum256([]byte("account:" + def.Name))
+		discriminator = h[:8]
+	}
+	if len(data) < len(discriminator) || string(data[:len(discriminator)]) != string(discriminator) {
+		return nil, fmt.Errorf("account is not a %s", def.Name)
+	}
+	typeDef := def
+	if def.Type == nil {
+		if typeDef, _ = idl.typeDef(def.Name); typeDef == nil || typeDef.Type == nil {
+			return nil, fmt.Errorf("type of account %s not found", def.Name)
+		}
+	}
+	if typeDef.Type.Kind != "struct" {
+		return nil, fmt.Errorf("account %s is not a struct", def.Name)
+	}
+	structFields, err := namedFields(def.Name, typeDef.Type.Fields)
+	if err != nil {
+		return nil, err
+	}
+	fields, _, err := idl.decodeFields(data[len(discriminator):], structFields)
+	if err != nil {
+		return nil, fmt.Errorf("invalid account %s: %w", def.Name, err)
+	}
+	return fields, nil
+}
+
+func (idl *AnchorIDL) instruction(name string) (AnchorIDLInstruction, error) {
+	for _, ix := range idl.Instructions {
+		if anchorSnakeCase(ix.Name) == anchorSnakeCase(name) {
+			return ix, nil
+		}
+	}
+	return AnchorIDLInstruction{}, fmt.Errorf("unknown instruction %s of program %s", name, idl.Name)
+}
+
+func (idl *AnchorIDL) instructionDiscriminator(ix AnchorIDLInstruction) []byte {
+	if len(ix.Discriminator) > 0 {
+		return ix.Discriminator
+	}
+	h := sha256.Sum256([]byte("global:" + anchorSnakeCase(ix.Name)))
+	return h[:8]
+}
+
+func (idl *AnchorIDL) typeDef(name string) (*AnchorIDLTypeDef, error) {
+	for i := range idl.Types {
+		if idl.Types[i].Name == name {
+			return &idl.Types[i], nil
+		}
+	}
+	return nil, fmt.Errorf("unknown type %s", name)
+}
+
+func (idl *AnchorIDL) encodeFields(data []byte, fields []AnchorIDLField, values map[string]any) ([]byte, error) {
+	seen := make(map[string]bool)
+	for _, field := range fields {
+		fieldName := anchorSnakeCase(field.Name)
+		v, ok := lookupSnakeCase(values, fieldName)
+		if !ok {
+			return nil, fmt.Errorf("%s not set", fieldName)
+		}
+		seen[fieldName] = true
+		var err error
+		if data, err = idl.encode(data, field.Type, v); err != nil {
+			return nil, fmt.Errorf("%s: %w", fieldName, err)
+		}
+	}
+	for name := range values {
+		if !seen[anchorSnakeCase(name)] {
+			return nil, fmt.Errorf("unknown field %s", name)
+		}
+	}
+	return data, nil
+}
+
+// encode appends the Borsh encoding of the value of the type.
+func (idl *AnchorIDL) encode(data []byte, t AnchorIDLType, v any) ([]byte, error) {
+	rv := reflect.ValueOf(v)
+	switch {
+	case t.Option != nil:
+		if v == nil || (rv.Kind() == reflect.Pointer && rv.IsNil()) {
+			return append(data, 0), nil
+		}
+		if rv.Kind() == reflect.Pointer {
+			v = rv.Elem().Interface()
+		}
+		return idl.encode(append(data, 1), *t.Option, v)
+	case t.Vec != nil, t.Array != nil:
+		if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+			return nil, fmt.Errorf("expected a slice for %s, got %T", t, v)
+		}
+		elem := t.Vec
+		if t.Vec != nil {
+			data = binary.LittleEndian.AppendUint32(data, uint32(rv.Len()))
+		} else {
+			elem = t.Array
+			if rv.Len() != t.ArrayLen {
+				return nil, fmt.Errorf("expected %d elements for %s, got %d", t.ArrayLen, t, rv.Len())
+			}
+		}
+		for i := 0; i < rv.Len(); i++ {
+			var err error
+			if data, err = idl.encode(data, *elem, rv.Index(i).Interface()); err != nil {
+				return nil, fmt.Errorf("element %d: %w", i, err)
+			}
+		}
+		return data, nil
+	case t.Defined != "":
+		return idl.encodeDefined(data, t.Defined, v)
+	}
+
+	switch t.Primitive {
+	case "bool":
+		b, ok := v.(bool)
+		if !ok {
+			return nil, fmt.Errorf("expected a bool, got %T", v)
+		}
+		return append(data, BorshBool(b)...), nil
+	case "u8", "u16", "u32", "u64", "i8", "i16", "i32", "i64":
+		size := anchorIntSizes[t.Primitive]
+		signed := t.Primitive[0] == 'i'
+		var n uint64
+		switch {
+		case rv.CanUint():
+			n = rv.Uint()
+			if (!signed && size < 8 && n >= 1<<(8*size)) || (signed && n > math.MaxInt64>>(64-8*size)) {
+				return nil, fmt.Errorf("%d overflows %s", n, t.Primitive)
+			}
+		case rv.CanInt():
+			i := rv.Int()
+			if (!signed && i < 0) || (!signed && size < 8 && i >= 1<<(8*size)) ||
+				(signed && size < 8 && (i < -1<<(8*size-1) || i >= 1<<(8*size-1))) {
+				return nil, fmt.Errorf("%d overflows %s", i, t.Primitive)
+			}
+			n = uint64(i)
+		default:
+			return nil, fmt.Errorf("expected an integer for %s, got %T", t.Primitive, v)
+		}
+		return binary.LittleEndian.AppendUint64(data, n)[:len(data)+size], nil
+	case "u128", "i128":
+		var n *big.Int
+		switch x := v.(type) {
+		case *big.Int:
+			n = x
+		case uint64:
+			n = new(big.Int).SetUint64(x)
+		case int:
+			n = big.NewInt(int64(x))
+		default:
+			return nil, fmt.Errorf("expected a *big.Int for %s, got %T", t.Primitive, v)
+		}
+		if t.Primitive == "u128" && (n.Sign() < 0 || n.BitLen() > 128) || t.Primitive == "i128" && n.BitLen() > 127 {
+			return nil, fmt.Errorf("%s overflows %s", n, t.Primitive)
+		}
+		// two's complement, little endian
+		u := new(big.Int).Set(n)
+		if n.Sign() < 0 {
+			u.Add(u, new(big.Int).Lsh(big.NewInt(1), 128))
+		}
+		b := u.FillBytes(make([]byte, 16))
+		for i := 15; i >= 0; i-- {
+			data = append(data, b[i])
+		}
+		return data, nil
+	case "string":
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected a string, got %T", v)
+		}
+		return append(data, BorshBytes([]byte(s))...), nil
+	case "bytes":
+		b, ok := v.([]byte)
+		if !ok {
+			return nil, fmt.Errorf("expected bytes, got %T", v)
+		}
+		return append(data, BorshBytes(b)...), nil
+	case "pubkey", "publicKey":
+		key, ok := v.(solana.PublicKey)
+		if !ok {
+			return nil, fmt.Errorf("expected a solana.PublicKey, got %T", v)
+		}
+		return append(data, key.Bytes()...), nil
+	default:
+		return nil, fmt.Errorf("unsupported type %s", t)
+	}
+}
+
+func (idl *AnchorIDL) encodeDefined(data []byte, name string, v any) ([]byte, error) {
+	def, err := idl.typeDef(name)
+	if err != nil || def.Type == nil {
+		return nil, fmt.Errorf("unknown type %s", name)
+	}
+	switch def.Type.Kind {
+	case "struct":
+		fields, ok := v.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("expected a map[string]any for struct %s, got %T", name, v)
+		}
+		structFields, err := namedFields(name, def.Type.Fields)
+		if err != nil {
+			return nil, err
+		}
+		return idl.encodeFields(data, structFields, fields)
+	case "enum":
+		variant, fields := "", map[string]any(nil)
+		switch x := v.(type) {
+		case string:
+			variant = x
+		case map[string]any:
+			if len(x) != 1 {
+				return nil, fmt.Errorf("expected a single variant of enum %s, got %d", name, len(x))
+			}
+			for k, f := range x {
+				variant = k
+				if fields, ok = f.(map[string]any); !ok {
+					return nil, fmt.Errorf("expected a map[string]any for the fields of %s::%s, got %T", name, k, f)
+				}
+			}
+		default:
+			return nil, fmt.Errorf("expected a variant of enum %s, got %T", name, v)
+		}
+		for i, vt := range def.Type.Variants {
+			if anchorSnakeCase(vt.Name) != anchorSnakeCase(variant) {
+				continue
+			}
+			variantFields, err := namedFields(name+"::"+vt.Name, vt.Fields)
+			if err != nil {
+				return nil, err
+			}
+			if len(variantFields) == 0 && fields != nil {
+				return nil, fmt.Errorf("variant %s::%s has no fields", name, vt.Name)
+			}
+			return idl.encodeFields(append(data, uint8(i)), variantFields, fields)
+		}
+		return nil, fmt.Errorf("unknown variant %s of enum %s", variant, name)
+	default:
+		return nil, fmt.Errorf("unsupported kind %s of type %s", def.Type.Kind, name)
+	}
+}
+
+func (idl *AnchorIDL) decodeFields(data []byte, fields []AnchorIDLField) (map[string]any, []byte, error) {
+	values := make(map[string]any, len(fields))
+	for _, field := range fields {
+		v, rest, err := idl.decode(data, field.Type)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", anchorSnakeCase(field.Name), err)
+		}
+		values[anchorSnakeCase(field.Name)], data = v, rest
+	}
+	return values, data, nil
+}
+
+// decode decodes a value of the type from the Borsh encoded data, returning the remaining data.
+func (idl *AnchorIDL) decode(data []byte, t AnchorIDLType) (any, []byte, error) {
+	next := func(n int) ([]byte, error) {
+		if len(data) < n {
+			return nil, fmt.Errorf("expected %d bytes for %s, got %d", n, t, len(data))
+		}
+		b := data[:n]
+		data = data[n:]
+		return b, nil
+	}
+	switch {
+	case t.Option != nil:
+		b, err := next(1)
+		if err != nil || b[0] == 0 {
+			return nil, data, err
+		}
+		return idl.decode(data, *t.Option)
+	case t.Vec != nil, t.Array != nil:
+		elem, n := t.Array, t.ArrayLen
+		if t.Vec != nil {
+			b, err := next(4)
+			if err != nil {
+				return nil, nil, err
+			}
+			elem, n = t.Vec, int(binary.LittleEndian.Uint32(b))
+		}
+		if elem.Primitive == "u8" {
+			b, err := next(n)
+			return append([]byte{}, b...), data, err
+		}
+		values := make([]any, 0, min(n, len(data)))
+		for i := 0; i < n; i++ {
+			v, rest, err := idl.decode(data, *elem)
+			if err != nil {
+				return nil, nil, fmt.Errorf("element %d: %w", i, err)
+			}
+			values, data = append(values, v), rest
+		}
+		return values, data, nil
+	case t.Defined != "":
+		return idl.decodeDefined(data, t.Defined)
+	}
+
+	switch t.Primitive {
+	case "bool":
+		b, err := next(1)
+		if err != nil {
+			return nil, nil, err
+		}
+		if b[0] > 1 {
+			return nil, nil, fmt.Errorf("invalid bool %d", b[0])
+		}
+		return b[0] == 1, data, nil
+	case "u8", "u16", "u32", "u64", "i8", "i16", "i32", "i64":
+		b, err := next(anchorIntSizes[t.Primitive])
+		if err != nil {
+			return nil, nil, err
+		}
+		var u [8]byte
+		copy(u[:], b)
+		n := binary.LittleEndian.Uint64(u[:])
+		switch t.Primitive {
+		case "u8":
+			return uint8(n), data, nil
+		case "u16":
+			return uint16(n), data, nil
+		case "u32":
+			return uint32(n), data, nil
+		case "u64":
+			return n, data, nil
+		case "i8":
+			return int8(n), data, nil
+		case "i16":
+			return int16(n), data, nil
+		case "i32":
+			return int32(n), data, nil
+		default:
+			return int64(n), data, nil
+		}
+	case "u128", "i128":
+		b, err := next(16)
+		if err != nil {
+			return nil, nil, err
+		}
+		be := make([]byte, 16)
+		for i := range b {
+			be[15-i] = b[i]
+		}
+		n := new(big.Int).SetBytes(be)
+		if t.Primitive == "i128" && n.Bit(127) == 1 {
+			n.Sub(n, new(big.Int).Lsh(big.NewInt(1), 128))
+		}
+		return n, data, nil
+	case "string", "bytes":
+		b, err := next(4)
+		if err != nil {
+			return nil, nil, err
+		}
+		if b, err = next(int(binary.LittleEndian.Uint32(b))); err != nil {
+			return nil, nil, err
+		}
+		if t.Primitive == "string" {
+			return string(b), data, nil
+		}
+		return append([]byte{}, b...), data, nil
+	case "pubkey", "publicKey":
+		b, err := next(solana.PublicKeyLength)
+		if err != nil {
+			return nil, nil, err
+		}
+		return solana.PublicKeyFromBytes(b), data, nil
+	default:
+		return nil, nil, fmt.Errorf("unsupported type %s", t)
+	}
+}
+
+func (idl *AnchorIDL) decodeDefined(data []byte, name string) (any, []byte, error) {
+	def, err := idl.typeDef(name)
+	if err != nil || def.Type == nil {
+		return nil, nil, fmt.Errorf("unknown type %s", name)
+	}
+	switch def.Type.Kind {
+	case "struct":
+		fields, err := namedFields(name, def.Type.Fields)
+		if err != nil {
+			return nil, nil, err
+		}
+		return idl.decodeFields(data, fields)
+	case "enum":
+		if len(data) == 0 {
+			return nil, nil, fmt.Errorf("expected a variant of enum %s", name)
+		}
+		i := int(data[0])
+		if i >= len(def.Type.Variants) {
+			return nil, nil, fmt.Errorf("invalid variant %d of enum %s", i, name)
+		}
+		vt := def.Type.Variants[i]
+		fields, err := namedFields(name+"::"+vt.Name, vt.Fields)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(fields) == 0 {
+			return anchorSnakeCase(vt.Name), data[1:], nil
+		}
+		values, rest, err := idl.decodeFields(data[1:], fields)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s::%s: %w", name, vt.Name, err)
+		}
+		return map[string]any{anchorSnakeCase(vt.Name): values}, rest, nil
+	default:
+		return nil, nil, fmt.Errorf("unsupported kind %s of type %s", def.Type.Kind, name)
+	}
+}
+
+var anchorIntSizes = map[string]int{"u8": 1, "i8": 1, "u16": 2, "i16": 2, "u32": 4, "i32": 4, "u64": 8, "i64": 8}
+
+// namedFields parses the fields of a struct or an enum variant, which must be named.
+func namedFields(typeName string, raw json.RawMessage) ([]AnchorIDLField, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	var fields []AnchorIDLField
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil, fmt.Errorf("unsupported fields of %s: %w", typeName, err)
+	}
+	for _, field := range fields {
+		if field.Name == "" {
+			return nil, fmt.Errorf("unnamed fields of %s aren't supported", typeName)
+		}
+	}
+	return fields, nil
+}
+
+// anchorSnakeCase converts the camelCase names of the IDLs before Anchor 0.30 to the snake_case of the
+// Rust names, which the discriminators are derived from.
+func anchorSnakeCase(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		if unicode.IsUpper(r) {
+			if i > 0 && name[i-1] != '_' {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func lookupSnakeCase[V any](m map[string]V, name string) (V, bool) {
+	for k, v := range m {
+		if anchorSnakeCase(k) == name {
+			return v, true
+		}
+	}
+	var zero V
+	return zero, false
+}
@@ -0,0 +1,169 @@
+package deployment
+
+import (
+	"crypto/sha256"
+	"math/big"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/stretchr/testify/require"
+)
+
+// an IDL in the format before Anchor 0.30, with camelCase names and no discriminators
+const testLegacyIDL = `{
+  "version": "0.1.0",
+  "name": "pool",
+  "instructions": [{
+    "name": "setChainRemoteConfig",
+    "accounts": [
+      {"name": "config", "isMut": false, "isSigner": false},
+      {"name": "chainConfig", "isMut": true, "isSigner": false},
+      {"name": "authority", "isMut": true, "isSigner": true}
+    ],
+    "args": [
+      {"name": "remoteChainSelector", "type": "u64"},
+      {"name": "cfg", "type": {"defined": "RemoteConfig"}},
+      {"name": "rateLimit", "type": {"option": "u128"}},
+      {"name": "mode", "type": {"defined": "Mode"}}
+    ]
+  }],
+  "accounts": [{
+    "name": "ChainConfig",
+    "type": {"kind": "struct", "fields": [
+      {"name": "remote", "type": {"defined": "RemoteConfig"}},
+      {"name": "admins", "type": {"vec": "publicKey"}},
+      {"name": "delta", "type": "i16"}
+    ]}
+  }],
+  "types": [
+    {"name": "RemoteConfig", "type": {"kind": "struct", "fields": [
+      {"name": "poolAddress", "type": "bytes"},
+      {"name": "decimals", "type": "u8"},
+      {"name": "tag", "type": {"array": ["u8", 4]}}
+    ]}},
+    {"name": "Mode", "type": {"kind": "enum", "variants": [
+      {"name": "Off"},
+      {"name": "Limited", "fields": [{"name": "cap", "type": "u32"}]}
+    ]}},
+    {"name": "Pair", "type": {"kind": "struct", "fields": ["u8", "u8"]}}
+  ]
+}`
+
+func TestAnchorIDL(t *testing.T) {
+	idl, err := ParseAnchorIDL([]byte(testLegacyIDL))
+	require.NoError(t, err)
+	require.Equal(t, "pool", idl.Name)
+	program, config, chainConfig, authority := solana.PublicKey{1}, solana.PublicKey{2}, solana.PublicKey{3}, solana.PublicKey{4}
+	accounts := map[string]solana.PublicKey{"config": config, "chain_config": chainConfig, "authority": authority}
+	cfg := map[string]any{"pool_address": []byte{0xaa, 0xbb}, "decimals": 18, "tag": [4]byte{1, 2, 3, 4}}
+	args := map[string]any{"remote_chain_selector": uint64(7), "cfg": cfg, "rate_limit": nil, "mode": "off"}
+
+	ix, err := idl.Instruction(program, "set_chain_remote_config", accounts, args)
+	require.NoError(t, err)
+	require.Equal(t, program, ix.ProgramID())
+	require.Equal(t, solana.AccountMetaSlice{
+		solana.Meta(config), solana.Meta(chainConfig).WRITE(), solana.Meta(authority).WRITE().SIGNER(),
+	}, ix.Accounts())
+	discriminator := sha256.Sum256([]byte("global:set_chain_remote_config"))
+	want := append(discriminator[:8:8], 7, 0, 0, 0, 0, 0, 0, 0)
+	want = append(want, 2, 0, 0, 0, 0xaa, 0xbb, 18, 1, 2, 3, 4)
+	want = append(want, 0, 0)
+	require.Equal(t, want, ix.DataBytes)
+
+	name, decoded, err := idl.DecodeInstruction(ix.DataBytes)
+	require.NoError(t, err)
+	require.Equal(t, "set_chain_remote_config", name)
+	require.Equal(t, map[string]any{
+		"remote_chain_selector": uint64(7),
+		"cfg":                   map[string]any{"pool_address": []byte{0xaa, 0xbb}, "decimals": uint8(18), "tag": []byte{1, 2, 3, 4}},
+		"rate_limit":            nil,
+		"mode":                  "off",
+	}, decoded)
+	_, _, err = idl.DecodeInstruction(append(ix.DataBytes, 0))
+	require.ErrorContains(t, err, "trailing bytes")
+
+	args["rate_limit"] = new(big.Int).Lsh(big.NewInt(1), 100)
+	args["mode"] = map[string]any{"Limited": map[string]any{"cap": uint32(5)}}
+	ix, err = idl.Instruction(program, "setChainRemoteConfig", accounts, args)
+	require.NoError(t, err)
+	_, decoded, err = idl.DecodeInstruction(ix.DataBytes)
+	require.NoError(t, err)
+	require.Equal(t, 0, args["rate_limit"].(*big.Int).Cmp(decoded["rate_limit"].(*big.Int)))
+	require.Equal(t, map[string]any{"limited": map[string]any{"cap": uint32(5)}}, decoded["mode"])
+
+	// the args and accounts must be exactly the ones of the IDL
+	cfg["decimals"] = 256
+	_, err = idl.Instruction(program, "set_chain_remote_config", accounts, args)
+	require.ErrorContains(t, err, "overflows u8")
+	cfg["decimals"] = 18
+	cfg["extra"] = true
+	_, err = idl.Instruction(program, "set_chain_remote_config", accounts, args)
+	require.ErrorContains(t, err, "unknown field extra")
+	delete(cfg, "extra")
+	delete(args, "mode")
+	_, err = idl.Instruction(program, "set_chain_remote_config", accounts, args)
+	require.ErrorContains(t, err, "mode not set")
+	args["mode"] = "off"
+	delete(accounts, "authority")
+	_, err = idl.Instruction(program, "set_chain_remote_config", accounts, args)
+	require.ErrorContains(t, err, "account authority")
+	_, err = idl.Instruction(program, "initialize", accounts, args)
+	require.ErrorContains(t, err, "unknown instruction")
+
+	admin := solana.PublicKey{9}
+	data := sha256.Sum256([]byte("account:ChainConfig"))
+	account := append(data[:8:8], 1, 0, 0, 0, 0xcc, 6, 0, 0, 0, 0)
+	account = append(append(account, 1, 0, 0, 0), admin.Bytes()...)
+	account = append(account, 0xfe, 0xff, 0, 0)
+	fields, err := idl.DecodeAccount("ChainConfig", account)
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{
+		"remote": map[string]any{"pool_address": []byte{0xcc}, "decimals": uint8(6), "tag": []byte{0, 0, 0, 0}},
+		"admins": []any{admin},
+		"delta":  int16(-2),
+	}, fields)
+	_, err = idl.DecodeAccount("ChainConfig", account[1:])
+	require.ErrorContains(t, err, "is not a ChainConfig")
+	_, err = idl.DecodeAccount("ChainConfig", account[:20])
+	require.Error(t, err)
+}
+
+func TestAnchorIDL030(t *testing.T) {
+	// explicit discriminators, fixed addresses and defined types by name
+	idl, err := ParseAnchorIDL([]byte(`{
+  "address": "11111111111111111111111111111111",
+  "metadata": {"name": "router", "version": "0.1.0", "spec": "0.1.0"},
+  "instructions": [{
+    "name": "initialize",
+    "discriminator": [1, 2, 3, 4, 5, 6, 7, 8],
+    "accounts": [
+      {"name": "config", "writable": true},
+      {"name": "authority", "writable": true, "signer": true},
+      {"name": "fee_aggregator", "optional": true},
+      {"name": "system_program", "address": "11111111111111111111111111111111"}
+    ],
+    "args": [{"name": "fee_quoter", "type": "pubkey"}, {"name": "weights", "type": {"vec": {"defined": {"name": "Weight"}}}}]
+  }],
+  "accounts": [{"name": "Config", "discriminator": [9, 9, 9, 9, 9, 9, 9, 9]}],
+  "types": [
+    {"name": "Weight", "type": {"kind": "struct", "fields": [{"name": "value", "type": "u16"}]}},
+    {"name": "Config", "type": {"kind": "struct", "fields": [{"name": "fee_quoter", "type": "pubkey"}]}},
+    {"name": "Generic", "generics": [{"kind": "type", "name": "T"}], "type": {"kind": "struct", "fields": [{"name": "v", "type": {"generic": "T"}}]}}
+  ]
+}`))
+	require.NoError(t, err)
+	require.Equal(t, "router", idl.Name)
+	program, config, authority, feeQuoter := solana.PublicKey{1}, solana.PublicKey{2}, solana.PublicKey{3}, solana.PublicKey{4}
+	ix, err := idl.Instruction(program, "initialize", map[string]solana.PublicKey{"config": config, "authority": authority},
+		map[string]any{"fee_quoter": feeQuoter, "weights": []map[string]any{{"value": 1}, {"value": uint16(2)}}})
+	require.NoError(t, err)
+	require.Equal(t, solana.AccountMetaSlice{
+		solana.Meta(config).WRITE(), solana.Meta(authority).WRITE().SIGNER(), solana.Meta(program), solana.Meta(solana.SystemProgramID),
+	}, ix.Accounts())
+	want := append([]byte{1, 2, 3, 4, 5, 6, 7, 8}, feeQuoter.Bytes()...)
+	require.Equal(t, append(want, 2, 0, 0, 0, 1, 0, 2, 0), ix.DataBytes)
+
+	fields, err := idl.DecodeAccount("Config", append([]byte{9, 9, 9, 9, 9, 9, 9, 9}, feeQuoter.Bytes()...))
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{"fee_quoter": feeQuoter}, fields)
+}
@@ -0,0 +1,222 @@
+package deployment
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/sha3"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+)
+
+// aptosResourceAccountScheme is the domain separator of the addresses of resource accounts.
+const aptosResourceAccountScheme = 0xFF
+
+// AptosAddress is the address of an Aptos account.
+type AptosAddress [32]byte
+
+// ParseAptosAddress parses the hex encoded address, in its long or short form, e.g. 0x1.
+func ParseAptosAddress(s string) (AptosAddress, error) {
+	var addr AptosAddress
+	h := strings.TrimPrefix(s, "0x")
+	if len(h) == 0 || len(h) > 64 {
+		return addr, fmt.Errorf("invalid aptos address %q", s)
+	}
+	if len(h)%2 == 1 {
+		h = "0" + h
+	}
+	b, err := hex.DecodeString(h)
+	if err != nil {
+		return addr, fmt.Errorf("invalid aptos address %q: %w", s, err)
+	}
+	copy(addr[32-len(b):], b)
+	return addr, nil
+}
+
+// String returns the long form of the address, which is how addresses are stored in the address book.
+func (a AptosAddress) String() string {
+	return "0x" + hex.EncodeToString(a[:])
+}
+
+func (a AptosAddress) MarshalText() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+func (a *AptosAddress) UnmarshalText(b []byte) error {
+	addr, err := ParseAptosAddress(string(b))
+	if err != nil {
+		return err
+	}
+	*a = addr
+	return nil
+}
+
+// AptosResourceAccountAddress returns the address of the resource account named by the seed created by the creator,
+// which is where the packages published by PublishAptosPackage live.
+func AptosResourceAccountAddress(creator AptosAddress, seed []byte) AptosAddress {
+	h := sha3.New256()
+	h.Write(creator[:])
+	h.Write(seed)
+	h.Write([]byte{aptosResourceAccountScheme})
+	var addr AptosAddress
+	copy(addr[:], h.Sum(nil))
+	return addr
+}
+
+// AptosEntryFunction is a call to an entry or view function of a published module.
+type AptosEntryFunction struct {
+	Module     AptosAddress
+	ModuleName string
+	Function   string
+	TypeArgs   []string
+	// Args are the BCS encoded arguments, see the BCS helpers below.
+	Args [][]byte
+}
+
+func (f AptosEntryFunction) String() string {
+	return fmt.Sprintf("%s::%s::%s", f.Module, f.ModuleName, f.Function)
+}
+
+// AptosTransaction is the outcome of a committed Aptos transaction.
+type AptosTransaction struct {
+	Hash     string
+	Version  uint64
+	Success  bool
+	VMStatus string
+}
+
+// AptosClient is an Aptos chain client sending transactions from the deployer account of the chain.
+type AptosClient interface {
+	// PublishPackage publishes the modules of a package to the resource account named by the seed and returns
+	// the hash of the transaction.
+	PublishPackage(ctx context.Context, seed []byte, metadata []byte, modules [][]byte) (string, error)
+	// SubmitEntryFunction submits a call to an entry function and returns the hash of the transaction.
+	SubmitEntryFunction(ctx context.Context, fn AptosEntryFunction) (string, error)
+	// WaitForTransaction waits for the transaction to be committed.
+	WaitForTransaction(ctx context.Context, txHash string) (AptosTransaction, error)
+	// View calls a view function and returns its JSON encoded return values.
+	View(ctx context.Context, fn AptosEntryFunction) ([]json.RawMessage, error)
+	// SubmitMultisigTransaction submits the execution of the approved transaction of the multisig account
+	// calling the entry function and returns the hash of the transaction.
+	SubmitMultisigTransaction(ctx context.Context, multisig AptosAddress, fn AptosEntryFunction) (string, error)
+}
+
+// AptosChain is the Aptos counterpart of Chain.
+type AptosChain struct {
+	Selector uint64
+	Client   AptosClient
+	// DeployerAddress is the account sending the transactions of the client.
+	DeployerAddress AptosAddress
+}
+
+// AptosPackage is a compiled Move package.
+type AptosPackage struct {
+	// Metadata is the BCS encoded package metadata.
+	Metadata []byte
+	// Modules are the bytecode of the modules of the package, in dependency order.
+	Modules [][]byte
+	// Seed names the resource account the package is published to, see AptosResourceAccountAddress.
+	Seed []byte
+	Tv   TypeAndVersion
+}
+
+// PublishAptosPackage is the DeployContract counterpart for Aptos chains. It publishes the package to
+// its resource account and records the address of the account in the address book once the publication is committed.
+func PublishAptosPackage(lggr logger.Logger, chain AptosChain, addressBook AddressBook, pkg AptosPackage) (AptosAddress, error) {
+	if len(pkg.Modules) == 0 {
+		return AptosAddress{}, fmt.Errorf("package %s has no modules", pkg.Tv)
+	}
+	if len(pkg.Seed) == 0 {
+		return AptosAddress{}, fmt.Errorf("package %s has no seed", pkg.Tv)
+	}
+	ctx := context.Background()
+	txHash, err := chain.Client.PublishPackage(ctx, pkg.Seed, pkg.Metadata, pkg.Modules)
+	if err != nil {
+		lggr.Errorw("Failed to publish package", "err", err, "package", pkg.Tv)
+		return AptosAddress{}, err
+	}
+	if _, err := waitAptosTransaction(ctx, chain, txHash); err != nil {
+		lggr.Errorw("Failed to confirm package publication", "err", err, "tx", txHash)
+		return AptosAddress{}, err
+	}
+	addr := AptosResourceAccountAddress(chain.DeployerAddress, pkg.Seed)
+	if err := addressBook.Save(chain.Selector, addr.String(), pkg.Tv); err != nil {
+		lggr.Errorw("Failed to save package address", "err", err)
+		return AptosAddress{}, err
+	}
+	return addr, nil
+}
+
+// ExecuteAptosEntryFunction calls the entry function from the deployer account and waits for the call to be committed.
+func ExecuteAptosEntryFunction(chain AptosChain, fn AptosEntryFunction) (AptosTransaction, error) {
+	ctx := context.Background()
+	txHash, err := chain.Client.SubmitEntryFunction(ctx, fn)
+	if err != nil {
+		return AptosTransaction{}, fmt.Errorf("failed to submit %s on chain %d: %w", fn, chain.Selector, err)
+	}
+	tx, err := waitAptosTransaction(ctx, chain, txHash)
+	if err != nil {
+		return tx, fmt.Errorf("%s on chain %d: %w", fn, chain.Selector, err)
+	}
+	return tx, nil
+}
+
+func waitAptosTransaction(ctx context.Context, chain AptosChain, txHash string) (AptosTransaction, error) {
+	tx, err := chain.Client.WaitForTransaction(ctx, txHash)
+	if err != nil {
+		return tx, fmt.Errorf("tx %s not committed: %w", txHash, err)
+	}
+	if !tx.Success {
+		return tx, fmt.Errorf("tx %s failed: %s", txHash, tx.VMStatus)
+	}
+	return tx, nil
+}
+
+// BCS encoding of the arguments of entry functions, see https://github.com/diem/bcs.
+
+func BCSU8(v uint8) []byte {
+	return []byte{v}
+}
+
+func BCSU64(v uint64) []byte {
+	return binary.LittleEndian.AppendUint64(nil, v)
+}
+
+func BCSBool(v bool) []byte {
+	if v {
+		return []byte{1}
+	}
+	return []byte{0}
+}
+
+// BCSBytes encodes a vector<u8>.
+func BCSBytes(b []byte) []byte {
+	return append(bcsLength(len(b)), b...)
+}
+
+// BCSBytesVector encodes a vector<vector<u8>>.
+func BCSBytesVector(bs [][]byte) []byte {
+	out := bcsLength(len(bs))
+	for _, b := range bs {
+		out = append(out, BCSBytes(b)...)
+	}
+	return out
+}
+
+// BCSAddressVector encodes a vector<address>.
+func BCSAddressVector(addrs []AptosAddress) []byte {
+	out := bcsLength(len(addrs))
+	for _, addr := range addrs {
+		out = append(out, addr[:]...)
+	}
+	return out
+}
+
+// bcsLength encodes the length of a sequence as ULEB128.
+func bcsLength(n int) []byte {
+	return binary.AppendUvarint(nil, uint64(n))
+}
@@ -0,0 +1,273 @@
+package deployment
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/sha3"
+)
+
+const (
+	// DefaultAptosMaxGasAmount is the max gas of the transactions of RESTAptosClient, unless configured otherwise.
+	DefaultAptosMaxGasAmount = 200_000
+	// DefaultAptosGasUnitPrice is the gas unit price of the transactions of RESTAptosClient, in octas.
+	DefaultAptosGasUnitPrice = 100
+
+	aptosTxExpiration   = time.Minute
+	aptosPollInterval   = 500 * time.Millisecond
+	aptosSignedTxType   = "application/x.aptos.signed_transaction+bcs"
+	aptosViewFuncType   = "application/x.aptos.view_function+bcs"
+	aptosRawTxSalt      = "APTOS::RawTransaction"
+	aptosEd25519Scheme  = 0
+	aptosEntryFuncVar   = 2
+	aptosMultisigVar    = 3
+	aptosPendingTxType  = "pending_transaction"
+	aptosResourceModule = "resource_account"
+)
+
+// AptosAccountAddress returns the address of the account authenticated by the ed25519 key,
+// which is its authentication key.
+func AptosAccountAddress(pub ed25519.PublicKey) AptosAddress {
+	h := sha3.New256()
+	h.Write(pub)
+	h.Write([]byte{aptosEd25519Scheme})
+	var addr AptosAddress
+	copy(addr[:], h.Sum(nil))
+	return addr
+}
+
+// RESTAptosClient is an AptosClient sending the transactions of an ed25519 account through the REST API of
+// an Aptos node, e.g. of a local node started with `aptos node run-local-testnet`. Transactions are sent one
+// at a time, with the next sequence number of the account.
+type RESTAptosClient struct {
+	// URL is the URL of the REST API, e.g. http://127.0.0.1:8080/v1.
+	URL          string
+	MaxGasAmount uint64
+	GasUnitPrice uint64
+
+	key     ed25519.PrivateKey
+	address AptosAddress
+	http    *http.Client
+
+	mu      sync.Mutex
+	chainID *uint8
+}
+
+var _ AptosClient = (*RESTAptosClient)(nil)
+
+func NewRESTAptosClient(url string, key ed25519.PrivateKey) *RESTAptosClient {
+	return &RESTAptosClient{
+		URL:          strings.TrimSuffix(url, "/"),
+		MaxGasAmount: DefaultAptosMaxGasAmount,
+		GasUnitPrice: DefaultAptosGasUnitPrice,
+		key:          key,
+		address:      AptosAccountAddress(key.Public().(ed25519.PublicKey)),
+		http:         &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Address returns the address of the account sending the transactions.
+func (c *RESTAptosClient) Address() AptosAddress {
+	return c.address
+}
+
+// PublishPackage publishes the package to the resource account named by the seed, with
+// 0x1::resource_account::create_resource_account_and_publish_package.
+func (c *RESTAptosClient) PublishPackage(ctx context.Context, seed []byte, metadata []byte, modules [][]byte) (string, error) {
+	return c.SubmitEntryFunction(ctx, AptosEntryFunction{
+		Module:     AptosFrameworkAddress,
+		ModuleName: aptosResourceModule,
+		Function:   "create_resource_account_and_publish_package",
+		Args:       [][]byte{BCSBytes(seed), BCSBytes(metadata), BCSBytesVector(modules)},
+	})
+}
+
+func (c *RESTAptosClient) SubmitEntryFunction(ctx context.Context, fn AptosEntryFunction) (string, error) {
+	payload, err := bcsEntryFunction(fn)
+	if err != nil {
+		return "", err
+	}
+	return c.submit(ctx, append([]byte{aptosEntryFuncVar}, payload...))
+}
+
+func (c *RESTAptosClient) SubmitMultisigTransaction(ctx context.Context, multisig AptosAddress, fn AptosEntryFunction) (string, error) {
+	payload, err := bcsMultisigPayload(fn)
+	if err != nil {
+		return "", err
+	}
+	// Multisig { multisig_address, transaction_payload: Some(payload) }
+	p := append([]byte{aptosMultisigVar}, multisig[:]...)
+	p = append(p, 1)
+	return c.submit(ctx, append(p, payload...))
+}
+
+func (c *RESTAptosClient) WaitForTransaction(ctx context.Context, txHash string) (AptosTransaction, error) {
+	ticker := time.NewTicker(aptosPollInterval)
+	defer ticker.Stop()
+	for {
+		var tx struct {
+			Type     string `json:"type"`
+			Hash     string `json:"hash"`
+			Version  string `json:"version"`
+			Success  bool   `json:"success"`
+			VMStatus string `json:"vm_status"`
+		}
+		err := c.do(ctx, http.MethodGet, "/transactions/by_hash/"+txHash, "", nil, &tx)
+		var apiErr *AptosAPIError
+		switch {
+		case err == nil && tx.Type != aptosPendingTxType:
+			version, err := strconv.ParseUint(tx.Version, 10, 64)
+			if err != nil {
+				return AptosTransaction{}, fmt.Errorf("invalid version of tx %s: %w", txHash, err)
+			}
+			return AptosTransaction{Hash: tx.Hash, Version: version, Success: tx.Success, VMStatus: tx.VMStatus}, nil
+		case err == nil, errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound:
+			// pending, or not yet known to the node right after submission
+		default:
+			return AptosTransaction{}, err
+		}
+		select {
+		case <-ctx.Done():
+			return AptosTransaction{}, fmt.Errorf("tx %s not committed: %w", txHash, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *RESTAptosClient) View(ctx context.Context, fn AptosEntryFunction) ([]json.RawMessage, error) {
+	payload, err := bcsEntryFunction(fn)
+	if err != nil {
+		return nil, err
+	}
+	var values []json.RawMessage
+	if err := c.do(ctx, http.MethodPost, "/view", aptosViewFuncType, payload, &values); err != nil {
+		return nil, fmt.Errorf("failed to call view function %s: %w", fn, err)
+	}
+	return values, nil
+}
+
+// submit signs the transaction with the payload and submits it, returning its hash.
+func (c *RESTAptosClient) submit(ctx context.Context, payload []byte) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	chainID, err := c.getChainID(ctx)
+	if err != nil {
+		return "", err
+	}
+	var account struct {
+		SequenceNumber string `json:"sequence_number"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/accounts/"+c.address.String(), "", nil, &account); err != nil {
+		return "", fmt.Errorf("failed to get account %s: %w", c.address, err)
+	}
+	seq, err := strconv.ParseUint(account.SequenceNumber, 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("invalid sequence number of account %s: %w", c.address, err)
+	}
+
+	raw := append([]byte{}, c.address[:]...)
+	raw = append(raw, BCSU64(seq)...)
+	raw = append(raw, payload...)
+	raw = append(raw, BCSU64(c.MaxGasAmount)...)
+	raw = append(raw, BCSU64(c.GasUnitPrice)...)
+	raw = append(raw, BCSU64(uint64(time.Now().Add(aptosTxExpiration).Unix()))...)
+	raw = append(raw, BCSU8(chainID)...)
+
+	salt := sha3.Sum256([]byte(aptosRawTxSalt))
+	sig := ed25519.Sign(c.key, append(salt[:], raw...))
+	signed := append(raw, aptosEd25519Scheme)
+	signed = append(signed, BCSBytes(c.key.Public().(ed25519.PublicKey))...)
+	signed = append(signed, BCSBytes(sig)...)
+
+	var pending struct {
+		Hash string `json:"hash"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/transactions", aptosSignedTxType, signed, &pending); err != nil {
+		return "", fmt.Errorf("failed to submit transaction: %w", err)
+	}
+	return pending.Hash, nil
+}
+
+func (c *RESTAptosClient) getChainID(ctx context.Context) (uint8, error) {
+	if c.chainID != nil {
+		return *c.chainID, nil
+	}
+	var ledger struct {
+		ChainID uint8 `json:"chain_id"`
+	}
+	if err := c.do(ctx, http.MethodGet, "", "", nil, &ledger); err != nil {
+		return 0, fmt.Errorf("failed to get ledger info: %w", err)
+	}
+	c.chainID = &ledger.ChainID
+	return ledger.ChainID, nil
+}
+
+// AptosAPIError is an error response of the REST API of an Aptos node.
+type AptosAPIError struct {
+	StatusCode  int
+	Message     string `json:"message"`
+	ErrorCode   string `json:"error_code"`
+	VMErrorCode *int   `json:"vm_error_code"`
+}
+
+func (e *AptosAPIError) Error() string {
+	if e.VMErrorCode != nil {
+		return fmt.Sprintf("%d %s: %s (vm error %d)", e.StatusCode, e.ErrorCode, e.Message, *e.VMErrorCode)
+	}
+	return fmt.Sprintf("%d %s: %s", e.StatusCode, e.ErrorCode, e.Message)
+}
+
+func (c *RESTAptosClient) do(ctx context.Context, method, path, contentType string, body []byte, out any) error {
+	var r io.Reader
+	if body != nil {
+		r = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.URL+path, r)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode >= 300 {
+		apiErr := &AptosAPIError{StatusCode: resp.StatusCode}
+		if err := json.Unmarshal(b, apiErr); err != nil {
+			apiErr.Message = string(b)
+		}
+		return apiErr
+	}
+	return json.Unmarshal(b, out)
+}
+
+// bcsEntryFunction encodes the call as an EntryFunction, which is also the layout of ViewFunction.
+// Type arguments aren't supported.
+func bcsEntryFunction(fn AptosEntryFunction) ([]byte, error) {
+	if len(fn.TypeArgs) != 0 {
+		return nil, fmt.Errorf("type arguments of %s aren't supported", fn)
+	}
+	payload := append([]byte{}, fn.Module[:]...)
+	payload = append(payload, BCSBytes([]byte(fn.ModuleName))...)
+	payload = append(payload, BCSBytes([]byte(fn.Function))...)
+	payload = append(payload, bcsLength(0)...)
+	return append(payload, BCSBytesVector(fn.Args)...), nil
+}
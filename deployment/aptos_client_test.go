@@ -0,0 +1,109 @@
+package deployment
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/sha3"
+)
+
+func TestRESTAptosClient(t *testing.T) {
+	pub, key, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	addr := AptosAccountAddress(pub)
+	var submitted [][]byte
+	polls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v1":
+			_, _ = w.Write([]byte(`{"chain_id":4}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/accounts/"+addr.String():
+			_, _ = w.Write([]byte(`{"sequence_number":"7"}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/transactions":
+			require.Equal(t, aptosSignedTxType, r.Header.Get("Content-Type"))
+			b, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			submitted = append(submitted, b)
+			w.WriteHeader(http.StatusAccepted)
+			_, _ = w.Write([]byte(`{"hash":"0xabc"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/transactions/by_hash/0xabc":
+			polls++
+			switch polls {
+			case 1:
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"message":"not found","error_code":"transaction_not_found"}`))
+			case 2:
+				_, _ = w.Write([]byte(`{"type":"pending_transaction","hash":"0xabc"}`))
+			default:
+				_, _ = w.Write([]byte(`{"type":"user_transaction","hash":"0xabc","version":"42","success":false,"vm_status":"Move abort"}`))
+			}
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/view":
+			require.Equal(t, aptosViewFuncType, r.Header.Get("Content-Type"))
+			_, _ = w.Write([]byte(`["8"]`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"message":"unexpected request","error_code":"invalid_input"}`))
+		}
+	}))
+	defer srv.Close()
+	ctx := context.Background()
+	client := NewRESTAptosClient(srv.URL+"/v1/", key)
+	require.Equal(t, addr, client.Address())
+
+	fn := AptosEntryFunction{Module: AptosFrameworkAddress, ModuleName: "aptos_account", Function: "transfer",
+		Args: [][]byte{addr[:], BCSU64(1)}}
+	txHash, err := client.SubmitEntryFunction(ctx, fn)
+	require.NoError(t, err)
+	require.Equal(t, "0xabc", txHash)
+	require.Len(t, submitted, 1)
+
+	// RawTransaction, then the ed25519 authenticator
+	signed := submitted[0]
+	payload, err := bcsEntryFunction(fn)
+	require.NoError(t, err)
+	rawLen := 32 + 8 + 1 + len(payload) + 8 + 8 + 8 + 1
+	require.Len(t, signed, rawLen+1+1+32+1+64)
+	raw := signed[:rawLen]
+	require.Equal(t, addr[:], raw[:32])
+	require.Equal(t, BCSU64(7), raw[32:40])
+	require.Equal(t, byte(aptosEntryFuncVar), raw[40])
+	require.Equal(t, payload, raw[41:41+len(payload)])
+	require.Equal(t, BCSU64(DefaultAptosMaxGasAmount), raw[41+len(payload):49+len(payload)])
+	require.Equal(t, byte(4), raw[rawLen-1])
+	auth := signed[rawLen:]
+	require.Equal(t, []byte{aptosEd25519Scheme, 32}, auth[:2])
+	require.Equal(t, []byte(pub), auth[2:34])
+	require.Equal(t, byte(64), auth[34])
+	salt := sha3.Sum256([]byte(aptosRawTxSalt))
+	require.True(t, ed25519.Verify(pub, append(salt[:], raw...), auth[35:]))
+
+	tx, err := client.WaitForTransaction(ctx, txHash)
+	require.NoError(t, err)
+	require.Equal(t, AptosTransaction{Hash: "0xabc", Version: 42, Success: false, VMStatus: "Move abort"}, tx)
+	require.Equal(t, 3, polls)
+
+	multisig := AptosAddress{31: 0xaa}
+	_, err = client.SubmitMultisigTransaction(ctx, multisig, fn)
+	require.NoError(t, err)
+	require.Len(t, submitted, 2)
+	require.Equal(t, append(append([]byte{aptosMultisigVar}, multisig[:]...), 1, 0), submitted[1][40:40+35])
+
+	values, err := client.View(ctx, AptosEntryFunction{Module: AptosFrameworkAddress, ModuleName: "account", Function: "get_sequence_number",
+		Args: [][]byte{addr[:]}})
+	require.NoError(t, err)
+	require.Equal(t, []json.RawMessage{json.RawMessage(`"8"`)}, values)
+
+	_, err = client.SubmitEntryFunction(ctx, AptosEntryFunction{Module: AptosFrameworkAddress, ModuleName: "coin", Function: "transfer",
+		TypeArgs: []string{"0x1::aptos_coin::AptosCoin"}})
+	require.ErrorContains(t, err, "type arguments")
+	_, err = client.WaitForTransaction(ctx, "0xdef")
+	var apiErr *AptosAPIError
+	require.ErrorAs(t, err, &apiErr)
+	require.Equal(t, "invalid_input", apiErr.ErrorCode)
+}
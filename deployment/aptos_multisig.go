@@ -0,0 +1,107 @@
+package deployment
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// AptosFrameworkAddress is the address of the Aptos framework, e.g. of the multisig_account module.
+var AptosFrameworkAddress = AptosAddress{31: 1}
+
+// AptosMultisig executes the batches of multisig proposals on an Aptos chain with a multisig account of the
+// framework, which owns the packages. Each call of a batch becomes a multisig transaction, which is created,
+// and thus approved, by the deployer account, an owner of the multisig account, approved by the signers, then
+// executed once approved by the number of signatures required by the multisig account.
+type AptosMultisig struct {
+	Chain AptosChain
+	// Multisig is the multisig account.
+	Multisig AptosAddress
+	// Signers are other owners of the multisig account approving the batches of ExecuteBatch, i.e. the chain
+	// with the clients of their accounts.
+	Signers []AptosChain
+}
+
+var _ MultisigExecutor = AptosMultisig{}
+
+func (m AptosMultisig) ExecuteBatch(ctx context.Context, batch MultisigBatch) (string, error) {
+	index, err := m.ProposeBatch(ctx, batch)
+	if err != nil {
+		return "", err
+	}
+	for _, signer := range m.Signers {
+		if err := (AptosMultisig{Chain: signer, Multisig: m.Multisig}).ApproveBatch(ctx, batch, index); err != nil {
+			return "", err
+		}
+	}
+	return m.ExecuteApprovedBatch(ctx, batch, index)
+}
+
+// ProposeBatch creates the multisig transactions of the calls, whose sequence numbers follow the returned one.
+func (m AptosMultisig) ProposeBatch(ctx context.Context, batch MultisigBatch) (uint64, error) {
+	values, err := m.Chain.Client.View(ctx, m.framework("next_sequence_number", m.Multisig[:]))
+	if err != nil {
+		return 0, fmt.Errorf("failed to get next sequence number of multisig %s: %w", m.Multisig, err)
+	}
+	var seq string
+	if len(values) != 1 || json.Unmarshal(values[0], &seq) != nil {
+		return 0, fmt.Errorf("invalid next sequence number of multisig %s: %s", m.Multisig, values)
+	}
+	index, err := strconv.ParseUint(seq, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid next sequence number of multisig %s: %w", m.Multisig, err)
+	}
+	for _, call := range batch.AptosCalls {
+		payload, err := bcsMultisigPayload(call)
+		if err != nil {
+			return 0, err
+		}
+		if _, err := ExecuteAptosEntryFunction(m.Chain, m.framework("create_transaction", m.Multisig[:], BCSBytes(payload))); err != nil {
+			return 0, fmt.Errorf("failed to create multisig transaction for %s: %w", call, err)
+		}
+	}
+	return index, nil
+}
+
+func (m AptosMultisig) ApproveBatch(_ context.Context, batch MultisigBatch, index uint64) error {
+	for i := range batch.AptosCalls {
+		seq := index + uint64(i)
+		if _, err := ExecuteAptosEntryFunction(m.Chain, m.framework("approve_transaction", m.Multisig[:], BCSU64(seq))); err != nil {
+			return fmt.Errorf("failed to approve multisig transaction %d by %s: %w", seq, m.Chain.DeployerAddress, err)
+		}
+	}
+	return nil
+}
+
+// ExecuteApprovedBatch executes the multisig transactions of the calls in order, the framework rejects
+// them unless they are approved.
+func (m AptosMultisig) ExecuteApprovedBatch(ctx context.Context, batch MultisigBatch, index uint64) (string, error) {
+	var lastTx string
+	for i, call := range batch.AptosCalls {
+		txHash, err := m.Chain.Client.SubmitMultisigTransaction(ctx, m.Multisig, call)
+		if err != nil {
+			return lastTx, fmt.Errorf("failed to submit multisig transaction %d for %s on chain %d: %w", index+uint64(i), call, m.Chain.Selector, err)
+		}
+		if _, err := waitAptosTransaction(ctx, m.Chain, txHash); err != nil {
+			return lastTx, fmt.Errorf("multisig transaction %d for %s on chain %d: %w", index+uint64(i), call, m.Chain.Selector, err)
+		}
+		lastTx = txHash
+	}
+	return lastTx, nil
+}
+
+// framework returns the call to the function of the multisig_account module of the framework.
+func (m AptosMultisig) framework(function string, args ...[]byte) AptosEntryFunction {
+	return AptosEntryFunction{Module: AptosFrameworkAddress, ModuleName: "multisig_account", Function: function, Args: args}
+}
+
+// bcsMultisigPayload encodes the call as the MultisigTransactionPayload of a multisig transaction, whose only
+// variant is EntryFunction. Type arguments aren't supported.
+func bcsMultisigPayload(fn AptosEntryFunction) ([]byte, error) {
+	payload, err := bcsEntryFunction(fn)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{0}, payload...), nil
+}
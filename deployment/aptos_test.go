@@ -0,0 +1,91 @@
+package deployment
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	chainsel "github.com/smartcontractkit/chain-selectors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+type fakeAptosClient struct {
+	published [][]byte
+	txs       map[string]AptosTransaction
+}
+
+func (c *fakeAptosClient) PublishPackage(_ context.Context, seed []byte, _ []byte, _ [][]byte) (string, error) {
+	c.published = append(c.published, seed)
+	return "0xpublish", nil
+}
+
+func (c *fakeAptosClient) SubmitEntryFunction(_ context.Context, _ AptosEntryFunction) (string, error) {
+	return "0xcall", nil
+}
+
+func (c *fakeAptosClient) WaitForTransaction(_ context.Context, txHash string) (AptosTransaction, error) {
+	return c.txs[txHash], nil
+}
+
+func (c *fakeAptosClient) View(_ context.Context, _ AptosEntryFunction) ([]json.RawMessage, error) {
+	return nil, nil
+}
+
+func (c *fakeAptosClient) SubmitMultisigTransaction(_ context.Context, _ AptosAddress, _ AptosEntryFunction) (string, error) {
+	return "0xmultisig", nil
+}
+
+func TestParseAptosAddress(t *testing.T) {
+	short, err := ParseAptosAddress("0x1")
+	require.NoError(t, err)
+	require.Equal(t, "0x0000000000000000000000000000000000000000000000000000000000000001", short.String())
+	long, err := ParseAptosAddress(short.String())
+	require.NoError(t, err)
+	require.Equal(t, short, long)
+
+	_, err = ParseAptosAddress("0x")
+	require.Error(t, err)
+	_, err = ParseAptosAddress("0xzz")
+	require.Error(t, err)
+	_, err = ParseAptosAddress("0x" + short.String()[2:] + "00")
+	require.Error(t, err)
+}
+
+func TestBCS(t *testing.T) {
+	require.Equal(t, []byte{1, 0, 0, 0, 0, 0, 0, 0}, BCSU64(1))
+	require.Equal(t, []byte{2, 0xaa, 0xbb}, BCSBytes([]byte{0xaa, 0xbb}))
+	require.Equal(t, []byte{2, 1, 0xaa, 0}, BCSBytesVector([][]byte{{0xaa}, {}}))
+	long := BCSBytes(make([]byte, 128))
+	require.Equal(t, []byte{0x80, 0x01}, long[:2])
+	require.Len(t, BCSAddressVector([]AptosAddress{{}, {}}), 1+2*32)
+}
+
+func TestPublishAptosPackage(t *testing.T) {
+	lggr := logger.TestLogger(t)
+	sel := chainsel.APTOS_TESTNET.Selector
+	deployer, err := ParseAptosAddress("0xabc")
+	require.NoError(t, err)
+	client := &fakeAptosClient{txs: map[string]AptosTransaction{
+		"0xpublish": {Hash: "0xpublish", Success: true},
+		"0xcall":    {Hash: "0xcall", VMStatus: "ABORTED"},
+	}}
+	chain := AptosChain{Selector: sel, Client: client, DeployerAddress: deployer}
+	ab := NewMemoryAddressBook()
+	tv := NewTypeAndVersion("Package", Version1_0_0)
+
+	addr, err := PublishAptosPackage(lggr, chain, ab, AptosPackage{Modules: [][]byte{{1}}, Seed: []byte("seed"), Tv: tv})
+	require.NoError(t, err)
+	require.Equal(t, AptosResourceAccountAddress(deployer, []byte("seed")), addr)
+	require.NotEqual(t, AptosResourceAccountAddress(deployer, []byte("other")), addr)
+	addresses, err := ab.AddressesForChain(sel)
+	require.NoError(t, err)
+	require.Equal(t, map[string]TypeAndVersion{addr.String(): tv}, addresses)
+
+	_, err = PublishAptosPackage(lggr, chain, ab, AptosPackage{Seed: []byte("seed"), Tv: tv})
+	require.ErrorContains(t, err, "no modules")
+
+	_, err = ExecuteAptosEntryFunction(chain, AptosEntryFunction{Module: addr, ModuleName: "m", Function: "f"})
+	require.ErrorContains(t, err, "ABORTED")
+}
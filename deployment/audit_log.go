@@ -0,0 +1,507 @@
+package deployment
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"google.golang.org/grpc"
+
+	jobv1 "github.com/smartcontractkit/chainlink-protos/job-distributor/v1/job"
+)
+
+// AuditEventType is the kind of mutating operation of an audit event.
+type AuditEventType string
+
+const (
+	AuditTxExecuted       AuditEventType = "tx_executed"
+	AuditContractDeployed AuditEventType = "contract_deployed"
+	AuditProposalCreated  AuditEventType = "proposal_created"
+	AuditProposalExecuted AuditEventType = "proposal_executed"
+	AuditJobProposed      AuditEventType = "job_proposed"
+)
+
+// AuditEvent is a mutating operation of a changeset on a chain, see AuditLog.
+type AuditEvent struct {
+	Type AuditEventType `json:"type"`
+	Time time.Time      `json:"time"`
+	// Actor is the account which signed the transaction, or which ran the changeset creating or executing the proposal.
+	Actor         common.Address `json:"actor"`
+	Changeset     string         `json:"changeset"`
+	ChainSelector uint64         `json:"chainSelector"`
+	// TxHash is the transaction of transaction and contract events, and the last transaction of executed proposals.
+	TxHash common.Hash `json:"txHash"`
+	// TxID is the last transaction of the multisig proposals executed on non-EVM chains, as identified by their
+	// clients, see MultisigExecutor.
+	TxID string `json:"txId,omitempty"`
+	// Address is the created contract of contract events and of transactions creating contracts.
+	Address string `json:"address,omitempty"`
+	// TypeAndVersion is the type and version the contract of contract events is recorded as in the address book.
+	TypeAndVersion string `json:"typeAndVersion,omitempty"`
+	// Description is the description of the proposal of proposal events.
+	Description string `json:"description,omitempty"`
+	// NodeID and ProposalID are the node and the job distributor proposal of job events.
+	NodeID     string `json:"nodeId,omitempty"`
+	ProposalID string `json:"proposalId,omitempty"`
+	// PrevHash and Hash chain the events of the log, they are set when the event is appended: Hash is the
+	// keccak256 of PrevHash and of the event, PrevHash the Hash of the previous event of the log.
+	PrevHash common.Hash `json:"prevHash"`
+	Hash     common.Hash `json:"hash"`
+}
+
+// digest returns the hash of the event chained to the previous one.
+func (e AuditEvent) digest(prev common.Hash) (common.Hash, error) {
+	e.PrevHash, e.Hash = common.Hash{}, common.Hash{}
+	encoded, err := json.Marshal(e)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to encode audit event: %w", err)
+	}
+	return crypto.Keccak256Hash(prev.Bytes(), encoded), nil
+}
+
+// chainAuditEvents returns the events chained to the last hash of a log.
+func chainAuditEvents(last common.Hash, events []AuditEvent) ([]AuditEvent, error) {
+	chained := make([]AuditEvent, len(events))
+	for i, event := range events {
+		hash, err := event.digest(last)
+		if err != nil {
+			return nil, err
+		}
+		event.PrevHash, event.Hash = last, hash
+		chained[i], last = event, hash
+	}
+	return chained, nil
+}
+
+// VerifyAuditEvents checks the hash chain of all the events of a log, in order: an event which was modified,
+// removed or inserted after it was appended breaks the chain.
+func VerifyAuditEvents(events []AuditEvent) error {
+	var last common.Hash
+	for i, event := range events {
+		if event.PrevHash != last {
+			return fmt.Errorf("audit event %d doesn't follow the previous event", i)
+		}
+		hash, err := event.digest(last)
+		if err != nil {
+			return err
+		}
+		if event.Hash != hash {
+			return fmt.Errorf("audit event %d was modified", i)
+		}
+		last = hash
+	}
+	return nil
+}
+
+// AuditQuery selects audit events, the zero values of its fields match all the events.
+type AuditQuery struct {
+	Type          AuditEventType
+	Changeset     string
+	ChainSelector uint64
+	Actor         common.Address
+	Address       string
+	// Since and Until bound the time of the events, inclusive.
+	Since time.Time
+	Until time.Time
+}
+
+func (q AuditQuery) Matches(event AuditEvent) bool {
+	return (q.Type == "" || event.Type == q.Type) &&
+		(q.Changeset == "" || event.Changeset == q.Changeset) &&
+		(q.ChainSelector == 0 || event.ChainSelector == q.ChainSelector) &&
+		(q.Actor == common.Address{} || event.Actor == q.Actor) &&
+		(q.Address == "" || event.Address == q.Address) &&
+		(q.Since.IsZero() || !event.Time.Before(q.Since)) &&
+		(q.Until.IsZero() || !event.Time.After(q.Until))
+}
+
+// AuditLog is an append-only log of the mutating operations of the changesets applied to an environment, kept
+// alongside its address book for compliance reviews. The events are hash-chained as they are appended, see
+// VerifyAuditEvents.
+type AuditLog interface {
+	Append(events ...AuditEvent) error
+	// Query returns the events matching the query, in the order they were appended, with their hashes.
+	Query(q AuditQuery) ([]AuditEvent, error)
+}
+
+var (
+	_ AuditLog = &MemoryAuditLog{}
+	_ AuditLog = &FileAuditLog{}
+)
+
+// MemoryAuditLog is an AuditLog in memory, for tests.
+type MemoryAuditLog struct {
+	events []AuditEvent
+	mtx    sync.RWMutex
+}
+
+func NewMemoryAuditLog() *MemoryAuditLog {
+	return &MemoryAuditLog{}
+}
+
+func (l *MemoryAuditLog) Append(events ...AuditEvent) error {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	var last common.Hash
+	if len(l.events) > 0 {
+		last = l.events[len(l.events)-1].Hash
+	}
+	chained, err := chainAuditEvents(last, events)
+	if err != nil {
+		return err
+	}
+	l.events = append(l.events, chained...)
+	return nil
+}
+
+func (l *MemoryAuditLog) Query(q AuditQuery) ([]AuditEvent, error) {
+	l.mtx.RLock()
+	defer l.mtx.RUnlock()
+	return filterAuditEvents(l.events, q), nil
+}
+
+// FileAuditLog is an AuditLog appending the events as JSON lines to a file, e.g. next to the address book file of
+// the environment. The file is only ever opened for appending, so events can't be rewritten through it, and its
+// hash chain is verified whenever it's read: a file edited by other means fails to append to and to query.
+type FileAuditLog struct {
+	Path string
+	mtx  sync.Mutex
+}
+
+func NewFileAuditLog(path string) *FileAuditLog {
+	return &FileAuditLog{Path: path}
+}
+
+func (l *FileAuditLog) Append(events ...AuditEvent) (err error) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	existing, err := l.read()
+	if err != nil {
+		return err
+	}
+	var last common.Hash
+	if len(existing) > 0 {
+		last = existing[len(existing)-1].Hash
+	}
+	chained, err := chainAuditEvents(last, events)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(l.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log %s: %w", l.Path, err)
+	}
+	defer func() {
+		err = errors.Join(err, f.Close())
+	}()
+	enc := json.NewEncoder(f)
+	for _, event := range chained {
+		if err := enc.Encode(event); err != nil {
+			return fmt.Errorf("failed to append to audit log %s: %w", l.Path, err)
+		}
+	}
+	return f.Sync()
+}
+
+func (l *FileAuditLog) Query(q AuditQuery) ([]AuditEvent, error) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	events, err := l.read()
+	if err != nil {
+		return nil, err
+	}
+	return filterAuditEvents(events, q), nil
+}
+
+// read returns all the events of the file, verified.
+func (l *FileAuditLog) read() ([]AuditEvent, error) {
+	f, err := os.Open(l.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log %s: %w", l.Path, err)
+	}
+	defer f.Close()
+	var events []AuditEvent
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 1<<20)
+	for line := 1; scanner.Scan(); line++ {
+		var event AuditEvent
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			return nil, fmt.Errorf("invalid event at line %d of audit log %s: %w", line, l.Path, err)
+		}
+		events = append(events, event)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read audit log %s: %w", l.Path, err)
+	}
+	if err := VerifyAuditEvents(events); err != nil {
+		return nil, fmt.Errorf("audit log %s was tampered with: %w", l.Path, err)
+	}
+	return events, nil
+}
+
+func filterAuditEvents(events []AuditEvent, q AuditQuery) []AuditEvent {
+	var matching []AuditEvent
+	for _, event := range events {
+		if q.Matches(event) {
+			matching = append(matching, event)
+		}
+	}
+	return matching
+}
+
+// AuditChains returns a copy of the chains recording the transactions they confirm as executed by the changeset
+// to the log. A transaction which can't be recorded fails, as an operation missing from the log can't be audited.
+func AuditChains(log AuditLog, changeset string, chains map[uint64]Chain) map[uint64]Chain {
+	if log == nil {
+		return chains
+	}
+	audited := make(map[uint64]Chain, len(chains))
+	for sel, chain := range chains {
+		confirm := chain.Confirm
+		deployer := chain.DeployerKey
+		chain.Confirm = func(tx *types.Transaction) (uint64, error) {
+			block, err := confirm(tx)
+			if err != nil {
+				return block, err
+			}
+			event := AuditEvent{
+				Type:          AuditTxExecuted,
+				Time:          time.Now(),
+				Actor:         txSender(tx, deployer),
+				Changeset:     changeset,
+				ChainSelector: sel,
+				TxHash:        tx.Hash(),
+			}
+			if tx.To() == nil {
+				event.Address = crypto.CreateAddress(event.Actor, tx.Nonce()).Hex()
+			}
+			if err := log.Append(event); err != nil {
+				return block, fmt.Errorf("failed to audit tx %s on chain %d: %w", tx.Hash(), sel, err)
+			}
+			return block, nil
+		}
+		audited[sel] = chain
+	}
+	return audited
+}
+
+// AuditOffchain returns the offchain client recording the jobs it proposes as proposed by the changeset to the
+// log. A job proposal which can't be recorded fails, like the transactions of AuditChains.
+func AuditOffchain(log AuditLog, changeset string, client OffchainClient) OffchainClient {
+	if log == nil || client == nil {
+		return client
+	}
+	return &auditedOffchainClient{OffchainClient: client, log: log, changeset: changeset}
+}
+
+type auditedOffchainClient struct {
+	OffchainClient
+	log       AuditLog
+	changeset string
+}
+
+func (c *auditedOffchainClient) ProposeJob(ctx context.Context, in *jobv1.ProposeJobRequest, opts ...grpc.CallOption) (*jobv1.ProposeJobResponse, error) {
+	res, err := c.OffchainClient.ProposeJob(ctx, in, opts...)
+	if err != nil {
+		return res, err
+	}
+	if err := c.log.Append(c.jobProposed(in.NodeId, res)); err != nil {
+		return res, fmt.Errorf("failed to audit job proposed to node %s: %w", in.NodeId, err)
+	}
+	return res, nil
+}
+
+func (c *auditedOffchainClient) BatchProposeJob(ctx context.Context, in *jobv1.BatchProposeJobRequest, opts ...grpc.CallOption) (*jobv1.BatchProposeJobResponse, error) {
+	res, err := c.OffchainClient.BatchProposeJob(ctx, in, opts...)
+	if err != nil || res == nil {
+		return res, err
+	}
+	nodeIDs := make([]string, 0, len(res.SuccessResponses))
+	for nodeID := range res.SuccessResponses {
+		nodeIDs = append(nodeIDs, nodeID)
+	}
+	sort.Strings(nodeIDs)
+	events := make([]AuditEvent, 0, len(nodeIDs))
+	for _, nodeID := range nodeIDs {
+		events = append(events, c.jobProposed(nodeID, res.SuccessResponses[nodeID]))
+	}
+	if len(events) == 0 {
+		return res, nil
+	}
+	if err := c.log.Append(events...); err != nil {
+		return res, fmt.Errorf("failed to audit jobs proposed to nodes %v: %w", nodeIDs, err)
+	}
+	return res, nil
+}
+
+func (c *auditedOffchainClient) jobProposed(nodeID string, res *jobv1.ProposeJobResponse) AuditEvent {
+	event := AuditEvent{
+		Type:      AuditJobProposed,
+		Time:      time.Now(),
+		Changeset: c.changeset,
+		NodeID:    nodeID,
+	}
+	if res != nil && res.Proposal != nil {
+		event.ProposalID = res.Proposal.Id
+	}
+	return event
+}
+
+// AuditProposalExecuted records the execution of the proposal on the chain by the deployer to the log, the last
+// transaction of the execution is recorded along with it.
+func AuditProposalExecuted(log AuditLog, changeset string, e Environment, sel uint64, description string, txHash common.Hash) error {
+	if log == nil {
+		return nil
+	}
+	return log.Append(AuditEvent{
+		Type:          AuditProposalExecuted,
+		Time:          time.Now(),
+		Actor:         deployerOf(e, sel),
+		Changeset:     changeset,
+		ChainSelector: sel,
+		TxHash:        txHash,
+		Description:   description,
+	})
+}
+
+// AuditMultisigProposalExecuted is AuditProposalExecuted for the multisig proposals of non-EVM chains, whose
+// last transaction is recorded by the id returned by ExecuteMultisigProposal.
+func AuditMultisigProposalExecuted(log AuditLog, changeset string, e Environment, sel uint64, description string, txID string) error {
+	if log == nil {
+		return nil
+	}
+	return log.Append(AuditEvent{
+		Type:          AuditProposalExecuted,
+		Time:          time.Now(),
+		Actor:         deployerOf(e, sel),
+		Changeset:     changeset,
+		ChainSelector: sel,
+		TxID:          txID,
+		Description:   description,
+	})
+}
+
+// txSender returns the signer of the transaction, the deployer if it can't be recovered.
+func txSender(tx *types.Transaction, deployer *bind.TransactOpts) common.Address {
+	chainID := tx.ChainId()
+	if chainID.Sign() == 0 {
+		chainID = nil
+	}
+	if from, err := types.Sender(types.LatestSignerForChainID(chainID), tx); err == nil {
+		return from
+	}
+	if deployer == nil {
+		return common.Address{}
+	}
+	return deployer.From
+}
+
+// AuditChangesetOutput records the contracts of the address book and the proposals of the output of the changeset
+// to the log. The contracts are recorded with the transactions which created them, if audited by AuditChains.
+func AuditChangesetOutput(log AuditLog, changeset string, e Environment, out ChangesetOutput) error {
+	if log == nil {
+		return nil
+	}
+	now := time.Now()
+	var events []AuditEvent
+	if out.AddressBook != nil {
+		addresses, err := out.AddressBook.Addresses()
+		if err != nil {
+			return err
+		}
+		executed, err := log.Query(AuditQuery{Type: AuditTxExecuted, Changeset: changeset})
+		if err != nil {
+			return err
+		}
+		for sel, chainAddresses := range addresses {
+			for address, tv := range chainAddresses {
+				event := AuditEvent{
+					Type:           AuditContractDeployed,
+					Time:           now,
+					Actor:          deployerOf(e, sel),
+					Changeset:      changeset,
+					ChainSelector:  sel,
+					Address:        address,
+					TypeAndVersion: tv.String(),
+				}
+				for _, tx := range executed {
+					if tx.ChainSelector == sel && tx.Address == address {
+						event.TxHash, event.Actor = tx.TxHash, tx.Actor
+					}
+				}
+				events = append(events, event)
+			}
+		}
+		sort.Slice(events, func(i, j int) bool {
+			if events[i].ChainSelector != events[j].ChainSelector {
+				return events[i].ChainSelector < events[j].ChainSelector
+			}
+			return events[i].Address < events[j].Address
+		})
+	}
+	for _, prop := range out.Proposals {
+		chains := make(map[uint64]struct{})
+		for _, op := range prop.Transactions {
+			chains[uint64(op.ChainIdentifier)] = struct{}{}
+		}
+		for _, sel := range sortedSelectors(chains) {
+			events = append(events, AuditEvent{
+				Type:          AuditProposalCreated,
+				Time:          now,
+				Actor:         deployerOf(e, sel),
+				Changeset:     changeset,
+				ChainSelector: sel,
+				Description:   prop.Description,
+			})
+		}
+	}
+	for _, prop := range out.MultisigProposals {
+		chains := make(map[uint64]struct{})
+		for _, batch := range prop.Batches {
+			chains[batch.ChainSelector] = struct{}{}
+		}
+		for _, sel := range sortedSelectors(chains) {
+			events = append(events, AuditEvent{
+				Type:          AuditProposalCreated,
+				Time:          now,
+				Changeset:     changeset,
+				ChainSelector: sel,
+				Description:   prop.Description,
+			})
+		}
+	}
+	if len(events) == 0 {
+		return nil
+	}
+	return log.Append(events...)
+}
+
+// deployerOf returns the deployer of the EVM chain, the zero address for other chains.
+func deployerOf(e Environment, sel uint64) common.Address {
+	if chain, ok := e.Chains[sel]; ok && chain.DeployerKey != nil {
+		return chain.DeployerKey.From
+	}
+	return common.Address{}
+}
+
+func sortedSelectors(chains map[uint64]struct{}) []uint64 {
+	sels := make([]uint64, 0, len(chains))
+	for sel := range chains {
+		sels = append(sels, sel)
+	}
+	sort.Slice(sels, func(i, j int) bool { return sels[i] < sels[j] })
+	return sels
+}
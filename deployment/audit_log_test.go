@@ -0,0 +1,227 @@
+package deployment
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/proposal/mcms"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/proposal/timelock"
+	chainsel "github.com/smartcontractkit/chain-selectors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	jobv1 "github.com/smartcontractkit/chainlink-protos/job-distributor/v1/job"
+)
+
+func TestAuditLog(t *testing.T) {
+	for name, log := range map[string]AuditLog{
+		"memory": NewMemoryAuditLog(),
+		"file":   NewFileAuditLog(filepath.Join(t.TempDir(), "audit.jsonl")),
+	} {
+		t.Run(name, func(t *testing.T) {
+			events, err := log.Query(AuditQuery{})
+			require.NoError(t, err)
+			require.Empty(t, events)
+
+			start := time.Now().UTC().Truncate(time.Second)
+			first := AuditEvent{Type: AuditTxExecuted, Time: start, Changeset: "deploy", ChainSelector: 1, TxHash: common.HexToHash("0x1")}
+			second := AuditEvent{Type: AuditContractDeployed, Time: start.Add(time.Minute), Changeset: "deploy", ChainSelector: 1, Address: "0x2"}
+			third := AuditEvent{Type: AuditProposalCreated, Time: start.Add(2 * time.Minute), Changeset: "configure", ChainSelector: 2, Description: "configure"}
+			require.NoError(t, log.Append(first, second))
+			require.NoError(t, log.Append(third))
+
+			all, err := log.Query(AuditQuery{})
+			require.NoError(t, err)
+			require.Equal(t, []AuditEvent{first, second, third}, withoutHashes(all))
+			require.NoError(t, VerifyAuditEvents(all))
+			require.Equal(t, all[0].Hash, all[1].PrevHash)
+			require.Equal(t, all[1].Hash, all[2].PrevHash)
+			events, err = log.Query(AuditQuery{Changeset: "deploy"})
+			require.NoError(t, err)
+			require.Equal(t, all[:2], events)
+			events, err = log.Query(AuditQuery{Since: start.Add(time.Minute), Until: start.Add(time.Minute)})
+			require.NoError(t, err)
+			require.Equal(t, all[1:2], events)
+			events, err = log.Query(AuditQuery{Type: AuditProposalCreated, ChainSelector: 1})
+			require.NoError(t, err)
+			require.Empty(t, events)
+		})
+	}
+}
+
+func TestAuditLogTamperEvidence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	log := NewFileAuditLog(path)
+	for i := 1; i <= 3; i++ {
+		require.NoError(t, log.Append(AuditEvent{Type: AuditTxExecuted, Changeset: "deploy", ChainSelector: 1, TxHash: common.BigToHash(big.NewInt(int64(i)))}))
+	}
+	events, err := log.Query(AuditQuery{})
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+
+	modified := slices.Clone(events)
+	modified[1].Changeset = "other"
+	require.ErrorContains(t, VerifyAuditEvents(modified), "audit event 1 was modified")
+	require.ErrorContains(t, VerifyAuditEvents([]AuditEvent{events[0], events[2]}), "audit event 1 doesn't follow")
+	require.ErrorContains(t, VerifyAuditEvents(events[1:]), "audit event 0 doesn't follow")
+
+	// rewriting an event of the file breaks the chain, the log can't be read nor appended to anymore
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, bytes.Replace(data, []byte(`"changeset":"deploy"`), []byte(`"changeset":"other"`), 1), 0o600))
+	_, err = log.Query(AuditQuery{})
+	require.ErrorContains(t, err, "was tampered with")
+	require.ErrorContains(t, log.Append(AuditEvent{Type: AuditTxExecuted}), "was tampered with")
+}
+
+type fakeJobClient struct {
+	OffchainClient
+}
+
+func (fakeJobClient) ProposeJob(_ context.Context, in *jobv1.ProposeJobRequest, _ ...grpc.CallOption) (*jobv1.ProposeJobResponse, error) {
+	if in.Spec == "" {
+		return nil, errors.New("empty spec")
+	}
+	return &jobv1.ProposeJobResponse{Proposal: &jobv1.Proposal{Id: "proposal-" + in.NodeId}}, nil
+}
+
+func (fakeJobClient) BatchProposeJob(_ context.Context, in *jobv1.BatchProposeJobRequest, _ ...grpc.CallOption) (*jobv1.BatchProposeJobResponse, error) {
+	res := &jobv1.BatchProposeJobResponse{SuccessResponses: map[string]*jobv1.ProposeJobResponse{}}
+	for _, nodeID := range in.NodeIds {
+		res.SuccessResponses[nodeID] = &jobv1.ProposeJobResponse{Proposal: &jobv1.Proposal{Id: "proposal-" + nodeID}}
+	}
+	return res, nil
+}
+
+func TestAuditOffchainAndProposalExecution(t *testing.T) {
+	log := NewMemoryAuditLog()
+	client := AuditOffchain(log, "jobs", fakeJobClient{})
+	ctx := context.Background()
+	_, err := client.ProposeJob(ctx, &jobv1.ProposeJobRequest{NodeId: "node-1", Spec: "spec"})
+	require.NoError(t, err)
+	_, err = client.ProposeJob(ctx, &jobv1.ProposeJobRequest{NodeId: "node-1"})
+	require.Error(t, err)
+	_, err = client.BatchProposeJob(ctx, &jobv1.BatchProposeJobRequest{NodeIds: []string{"node-3", "node-2"}, Spec: "spec"})
+	require.NoError(t, err)
+
+	jobs, err := log.Query(AuditQuery{Type: AuditJobProposed, Changeset: "jobs"})
+	require.NoError(t, err)
+	require.Len(t, jobs, 3)
+	for i, nodeID := range []string{"node-1", "node-2", "node-3"} {
+		require.Equal(t, nodeID, jobs[i].NodeID)
+		require.Equal(t, "proposal-"+nodeID, jobs[i].ProposalID)
+	}
+
+	sel := chainsel.TEST_90000001.Selector
+	deployer := common.HexToAddress("0x1")
+	e := Environment{Chains: map[uint64]Chain{sel: {Selector: sel, DeployerKey: &bind.TransactOpts{From: deployer}}}}
+	require.NoError(t, AuditProposalExecuted(log, "", e, sel, "set router", common.HexToHash("0x2")))
+	executed, err := log.Query(AuditQuery{Type: AuditProposalExecuted})
+	require.NoError(t, err)
+	require.Len(t, executed, 1)
+	require.Equal(t, deployer, executed[0].Actor)
+	require.Equal(t, "set router", executed[0].Description)
+	require.Equal(t, common.HexToHash("0x2"), executed[0].TxHash)
+
+	solSel := chainsel.SOLANA_DEVNET.Selector
+	require.NoError(t, AuditMultisigProposalExecuted(log, "transfer", e, solSel, "accept ownership", "5VERv8NMvzbJMEkV8xnrLkEaWRtSz9CosKDYjCJjBRnbJLgp8uirBgmQpjKhoR4tjF3ZpRzrFmBV6UjKdiSZkQUW"))
+	executed, err = log.Query(AuditQuery{Type: AuditProposalExecuted, Changeset: "transfer"})
+	require.NoError(t, err)
+	require.Len(t, executed, 1)
+	require.Equal(t, solSel, executed[0].ChainSelector)
+	require.Equal(t, "5VERv8NMvzbJMEkV8xnrLkEaWRtSz9CosKDYjCJjBRnbJLgp8uirBgmQpjKhoR4tjF3ZpRzrFmBV6UjKdiSZkQUW", executed[0].TxID)
+	require.Equal(t, common.Hash{}, executed[0].TxHash)
+
+	// nothing is recorded without a log
+	require.Equal(t, OffchainClient(fakeJobClient{}), AuditOffchain(nil, "jobs", fakeJobClient{}))
+	require.NoError(t, AuditProposalExecuted(nil, "", e, sel, "set router", common.Hash{}))
+	require.NoError(t, AuditMultisigProposalExecuted(nil, "", e, sel, "set router", ""))
+}
+
+// withoutHashes returns the events as they were appended, without the hashes set by the log.
+func withoutHashes(events []AuditEvent) []AuditEvent {
+	stripped := make([]AuditEvent, len(events))
+	for i, event := range events {
+		event.PrevHash, event.Hash = common.Hash{}, common.Hash{}
+		stripped[i] = event
+	}
+	return stripped
+}
+
+func TestAuditChangeset(t *testing.T) {
+	sel := chainsel.TEST_90000001.Selector
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	deployer, err := bind.NewKeyedTransactorWithChainID(key, big.NewInt(1337))
+	require.NoError(t, err)
+	failing := errors.New("reverted")
+	log := NewMemoryAuditLog()
+	chains := AuditChains(log, "deploy", map[uint64]Chain{
+		sel: {Selector: sel, DeployerKey: deployer, Confirm: func(tx *types.Transaction) (uint64, error) {
+			if tx.Nonce() > 1 {
+				return 0, failing
+			}
+			return 10, nil
+		}},
+	})
+
+	creation, err := deployer.Signer(deployer.From, types.NewTx(&types.LegacyTx{Nonce: 0, GasPrice: big.NewInt(1), Data: []byte{0x1}}))
+	require.NoError(t, err)
+	contract := crypto.CreateAddress(deployer.From, 0)
+	call, err := deployer.Signer(deployer.From, types.NewTx(&types.LegacyTx{Nonce: 1, GasPrice: big.NewInt(1), To: &contract}))
+	require.NoError(t, err)
+	reverted, err := deployer.Signer(deployer.From, types.NewTx(&types.LegacyTx{Nonce: 2, GasPrice: big.NewInt(1), To: &contract}))
+	require.NoError(t, err)
+	for _, tx := range []*types.Transaction{creation, call} {
+		_, err = chains[sel].Confirm(tx)
+		require.NoError(t, err)
+	}
+	_, err = chains[sel].Confirm(reverted)
+	require.ErrorIs(t, err, failing)
+
+	executed, err := log.Query(AuditQuery{Type: AuditTxExecuted, Actor: deployer.From})
+	require.NoError(t, err)
+	require.Len(t, executed, 2)
+	require.Equal(t, creation.Hash(), executed[0].TxHash)
+	require.Equal(t, contract.Hex(), executed[0].Address)
+	require.Equal(t, call.Hash(), executed[1].TxHash)
+	require.Empty(t, executed[1].Address)
+
+	ab := NewMemoryAddressBook()
+	tv := NewTypeAndVersion("Router", Version1_2_0)
+	require.NoError(t, ab.Save(sel, contract.Hex(), tv))
+	out := ChangesetOutput{
+		AddressBook: ab,
+		Proposals: []timelock.MCMSWithTimelockProposal{{
+			MCMSProposal: mcms.MCMSProposal{Description: "set router"},
+			Transactions: []timelock.BatchChainOperation{{ChainIdentifier: mcms.ChainIdentifier(sel)}},
+		}},
+	}
+	require.NoError(t, AuditChangesetOutput(log, "deploy", Environment{Chains: chains}, out))
+
+	deployed, err := log.Query(AuditQuery{Type: AuditContractDeployed})
+	require.NoError(t, err)
+	require.Len(t, deployed, 1)
+	require.Equal(t, creation.Hash(), deployed[0].TxHash)
+	require.Equal(t, deployer.From, deployed[0].Actor)
+	require.Equal(t, tv.String(), deployed[0].TypeAndVersion)
+	proposals, err := log.Query(AuditQuery{Type: AuditProposalCreated, ChainSelector: sel})
+	require.NoError(t, err)
+	require.Len(t, proposals, 1)
+	require.Equal(t, "set router", proposals[0].Description)
+	require.Equal(t, deployer.From, proposals[0].Actor)
+
+	// nothing is recorded without a log
+	require.NoError(t, AuditChangesetOutput(nil, "deploy", Environment{}, out))
+}
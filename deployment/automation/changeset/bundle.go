@@ -0,0 +1,35 @@
+package changeset
+
+import (
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/deployment/automation"
+	commonchangeset "github.com/smartcontractkit/chainlink/deployment/common/changeset"
+	commontypes "github.com/smartcontractkit/chainlink/deployment/common/types"
+)
+
+// Bundle deploys a registry to every configured chain, and configures it with all the nodes of the environment.
+func Bundle(c automation.DeployRegistryConfig, ocrParams commontypes.OCRParameters, ocrSecrets deployment.OCRSecrets) commonchangeset.ProductBundle {
+	return commonchangeset.ProductBundle{
+		Name: "automation",
+		Stages: []commonchangeset.BundleStage{
+			func(e deployment.Environment) ([]commonchangeset.ChangesetApplication, error) {
+				changesets := []commonchangeset.ChangesetApplication{{
+					Changeset: commonchangeset.WrapChangeSet(DeployRegistryChangeSet),
+					Config:    c,
+				}}
+				for sel := range c.Chains {
+					changesets = append(changesets, commonchangeset.ChangesetApplication{
+						Changeset: commonchangeset.WrapChangeSet(ConfigureRegistryChangeSet),
+						Config: automation.ConfigureRegistryConfig{
+							ChainSel:      sel,
+							NodeIDs:       e.NodeIDs,
+							OCRParameters: ocrParams,
+							OCRSecrets:    ocrSecrets,
+						},
+					})
+				}
+				return changesets, nil
+			},
+		},
+	}
+}
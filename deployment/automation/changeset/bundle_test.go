@@ -0,0 +1,64 @@
+package changeset
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/chainlink-testing-framework/lib/utils/testcontext"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/deployment/automation"
+	ccipchangeset "github.com/smartcontractkit/chainlink/deployment/ccip/changeset"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset/testhelpers"
+	commonchangeset "github.com/smartcontractkit/chainlink/deployment/common/changeset"
+	commontypes "github.com/smartcontractkit/chainlink/deployment/common/types"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+// TestBundleWithCCIP runs Automation on the nodes and chains of a CCIP environment.
+func TestBundleWithCCIP(t *testing.T) {
+	lggr := logger.TestLogger(t)
+	tenv := testhelpers.NewMemoryEnvironmentWithJobsAndContracts(t, lggr, 2, 4, nil)
+	e := tenv.Env
+	sel := tenv.FeedChainSel
+
+	bundle := Bundle(automation.DeployRegistryConfig{
+		Chains: map[uint64]automation.ChainRegistryConfig{
+			sel: deployMockDependencies(t, e.Chains[sel]),
+		},
+	}, commontypes.OCRParameters{
+		DeltaProgress:                           10 * time.Second,
+		DeltaResend:                             10 * time.Second,
+		DeltaInitial:                            400 * time.Millisecond,
+		DeltaRound:                              2500 * time.Millisecond,
+		DeltaGrace:                              40 * time.Millisecond,
+		DeltaCertifiedCommitRequest:             300 * time.Millisecond,
+		DeltaStage:                              30 * time.Second,
+		Rmax:                                    24,
+		MaxDurationQuery:                        20 * time.Millisecond,
+		MaxDurationObservation:                  20 * time.Millisecond,
+		MaxDurationShouldAcceptAttestedReport:   1200 * time.Millisecond,
+		MaxDurationShouldTransmitAcceptedReport: 20 * time.Millisecond,
+	}, deployment.XXXGenerateTestOCRSecrets())
+	e, err := commonchangeset.ApplyBundles(testcontext.Get(t), lggr, e, bundle)
+	require.NoError(t, err)
+
+	_, err = RegisterTestUpkeepsChangeSet(e, automation.RegisterTestUpkeepsConfig{
+		ChainSel: sel,
+		Count:    1,
+		Funds:    big.NewInt(1e18),
+	})
+	require.NoError(t, err)
+
+	// both products are deployed side by side
+	ccipState, err := ccipchangeset.LoadOnchainState(e)
+	require.NoError(t, err)
+	require.NotNil(t, ccipState.Chains[sel].OnRamp)
+	addrs, err := e.ExistingAddresses.AddressesForChain(sel)
+	require.NoError(t, err)
+	state, err := automation.LoadChainState(e.Chains[sel], addrs)
+	require.NoError(t, err)
+	require.NotNil(t, state.Registry)
+}
@@ -0,0 +1,45 @@
+package changeset
+
+import (
+	"github.com/smartcontractkit/chainlink/deployment"
+	automationdeployment "github.com/smartcontractkit/chainlink/deployment/automation"
+)
+
+func DeployRegistryChangeSet(env deployment.Environment, c automationdeployment.DeployRegistryConfig) (deployment.ChangesetOutput, error) {
+	ab := deployment.NewMemoryAddressBook()
+	err := automationdeployment.DeployRegistry(env, ab, c)
+	if err != nil {
+		env.Logger.Errorw("Failed to deploy automation registry", "err", err, "addresses", ab)
+		return deployment.ChangesetOutput{AddressBook: ab}, deployment.MaybeDataErr(err)
+	}
+	return deployment.ChangesetOutput{
+		AddressBook: ab,
+	}, nil
+}
+
+// ConfigureRegistryChangeSet sets the OCR config of the registry and returns the automation job specs of the DON.
+// The caller needs to propose these job specs to the offchain system.
+func ConfigureRegistryChangeSet(env deployment.Environment, c automationdeployment.ConfigureRegistryConfig) (deployment.ChangesetOutput, error) {
+	specs, err := automationdeployment.ConfigureRegistry(env, c)
+	if err != nil {
+		env.Logger.Errorw("Failed to configure automation registry", "err", err)
+		return deployment.ChangesetOutput{}, deployment.MaybeDataErr(err)
+	}
+	return deployment.ChangesetOutput{
+		JobSpecs: specs,
+	}, nil
+}
+
+// RegisterTestUpkeepsChangeSet deploys, registers and funds UpkeepCounter upkeeps.
+func RegisterTestUpkeepsChangeSet(env deployment.Environment, c automationdeployment.RegisterTestUpkeepsConfig) (deployment.ChangesetOutput, error) {
+	ab := deployment.NewMemoryAddressBook()
+	ids, err := automationdeployment.RegisterTestUpkeeps(env, ab, c)
+	if err != nil {
+		env.Logger.Errorw("Failed to register test upkeeps", "err", err, "addresses", ab)
+		return deployment.ChangesetOutput{AddressBook: ab}, deployment.MaybeDataErr(err)
+	}
+	env.Logger.Infow("Registered test upkeeps", "upkeepIDs", ids)
+	return deployment.ChangesetOutput{
+		AddressBook: ab,
+	}, nil
+}
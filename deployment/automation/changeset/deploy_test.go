@@ -0,0 +1,74 @@
+package changeset
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/deployment/automation"
+	commontypes "github.com/smartcontractkit/chainlink/deployment/common/types"
+)
+
+func TestAutomationChangeSets(t *testing.T) {
+	e := newMemoryEnv(t)
+	sel := e.AllChainSelectors()[0]
+	c := automation.DeployRegistryConfig{
+		Chains: map[uint64]automation.ChainRegistryConfig{
+			sel: deployMockDependencies(t, e.Chains[sel]),
+		},
+	}
+	out, err := DeployRegistryChangeSet(e, c)
+	require.NoError(t, err)
+	require.NoError(t, e.ExistingAddresses.Merge(out.AddressBook))
+
+	// deploying again is a no-op
+	out, err = DeployRegistryChangeSet(e, c)
+	require.NoError(t, err)
+	ab, err := out.AddressBook.Addresses()
+	require.NoError(t, err)
+	require.Empty(t, ab)
+
+	out, err = ConfigureRegistryChangeSet(e, automation.ConfigureRegistryConfig{
+		ChainSel: sel,
+		NodeIDs:  e.NodeIDs,
+		OCRParameters: commontypes.OCRParameters{
+			DeltaProgress:                           10 * time.Second,
+			DeltaResend:                             10 * time.Second,
+			DeltaInitial:                            400 * time.Millisecond,
+			DeltaRound:                              2500 * time.Millisecond,
+			DeltaGrace:                              40 * time.Millisecond,
+			DeltaCertifiedCommitRequest:             300 * time.Millisecond,
+			DeltaStage:                              30 * time.Second,
+			Rmax:                                    24,
+			MaxDurationQuery:                        20 * time.Millisecond,
+			MaxDurationObservation:                  20 * time.Millisecond,
+			MaxDurationShouldAcceptAttestedReport:   1200 * time.Millisecond,
+			MaxDurationShouldTransmitAcceptedReport: 20 * time.Millisecond,
+		},
+		OCRSecrets: deployment.XXXGenerateTestOCRSecrets(),
+	})
+	require.NoError(t, err)
+	require.Len(t, out.JobSpecs, len(e.NodeIDs))
+
+	out, err = RegisterTestUpkeepsChangeSet(e, automation.RegisterTestUpkeepsConfig{
+		ChainSel: sel,
+		Count:    2,
+		Funds:    big.NewInt(1e18),
+	})
+	require.NoError(t, err)
+	require.NoError(t, e.ExistingAddresses.Merge(out.AddressBook))
+
+	addrs, err := e.ExistingAddresses.AddressesForChain(sel)
+	require.NoError(t, err)
+	state, err := automation.LoadChainState(e.Chains[sel], addrs)
+	require.NoError(t, err)
+	require.NotNil(t, state.Registry)
+	require.NotNil(t, state.Registrar)
+	require.Len(t, state.Upkeeps, 2)
+	ids, err := state.Registry.GetActiveUpkeepIDs(nil, big.NewInt(0), big.NewInt(0))
+	require.NoError(t, err)
+	require.Len(t, ids, 2)
+}
@@ -0,0 +1,219 @@
+package automation
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/confighelper"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3confighelper"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/deployment/common/types"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/generated/i_keeper_registry_master_wrapper_2_1"
+	"github.com/smartcontractkit/chainlink/v2/core/services/relay/evm"
+)
+
+// DefaultReportingPluginConfig is the ocr2keepers offchain config used when none is configured.
+var DefaultReportingPluginConfig = []byte(`{"performLockoutWindow":1200000,"minConfirmations":1}`)
+
+type ConfigureRegistryConfig struct {
+	ChainSel uint64
+	// NodeIDs are the nodes of the DON, including its bootstrap nodes.
+	NodeIDs       []string
+	OCRParameters types.OCRParameters
+	// OCRSecrets are the secrets the offchain config is encrypted with, the same secrets must be used by all the
+	// signers of a config.
+	OCRSecrets deployment.OCRSecrets `json:"-" toml:"-"`
+	// OnchainConfig defaults to DefaultOnchainConfig.
+	// The registrar of the chain is always added to its registrars.
+	OnchainConfig *i_keeper_registry_master_wrapper_2_1.IAutomationV21PlusCommonOnchainConfigLegacy
+	// ReportingPluginConfig is the JSON encoded ocr2keepers offchain config, defaults to DefaultReportingPluginConfig.
+	ReportingPluginConfig []byte
+}
+
+func (c ConfigureRegistryConfig) Validate(e deployment.Environment) error {
+	if _, ok := e.Chains[c.ChainSel]; !ok {
+		return fmt.Errorf("chain %d not found in environment", c.ChainSel)
+	}
+	if len(c.NodeIDs) == 0 {
+		return fmt.Errorf("no nodes")
+	}
+	if c.OCRSecrets.IsEmpty() {
+		return fmt.Errorf("OCR secrets are required")
+	}
+	return c.OCRParameters.Validate()
+}
+
+// DefaultOnchainConfig returns the registry onchain config used when none is configured.
+func DefaultOnchainConfig(upkeepPrivilegeManager common.Address) i_keeper_registry_master_wrapper_2_1.IAutomationV21PlusCommonOnchainConfigLegacy {
+	return i_keeper_registry_master_wrapper_2_1.IAutomationV21PlusCommonOnchainConfigLegacy{
+		PaymentPremiumPPB:      0,
+		FlatFeeMicroLink:       0,
+		CheckGasLimit:          6_500_000,
+		StalenessSeconds:       big.NewInt(90_000),
+		GasCeilingMultiplier:   2,
+		MinUpkeepSpend:         big.NewInt(0),
+		MaxPerformGas:          5_000_000,
+		MaxCheckDataSize:       5_000,
+		MaxPerformDataSize:     5_000,
+		MaxRevertDataSize:      5_000,
+		FallbackGasPrice:       big.NewInt(60_000_000_000),
+		FallbackLinkPrice:      big.NewInt(2_000_000_000_000_000_000),
+		UpkeepPrivilegeManager: upkeepPrivilegeManager,
+	}
+}
+
+// ConfigureRegistry sets the OCR config of the registry of the chain from the keys of the DON nodes,
+// and returns the bootstrap and automation job specs of the DON keyed by node id.
+func ConfigureRegistry(e deployment.Environment, c ConfigureRegistryConfig) (map[string][]string, error) {
+	if err := c.Validate(e); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+	chain := e.Chains[c.ChainSel]
+	state, err := existingChainState(e, chain)
+	if err != nil {
+		return nil, err
+	}
+	if state.Registry == nil || state.Registrar == nil {
+		return nil, fmt.Errorf("no automation registry on chain %d", c.ChainSel)
+	}
+	nodes, err := deployment.NodeInfo(c.NodeIDs, e.Offchain)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get node info: %w", err)
+	}
+	oracles := nodes.NonBootstraps()
+	if len(oracles) < 4 {
+		return nil, fmt.Errorf("need at least 4 non-bootstrap nodes, got %d", len(oracles))
+	}
+
+	var identities []confighelper.OracleIdentityExtra
+	var schedule []int
+	for _, node := range oracles {
+		ocrCfg, ok := node.OCRConfigForChainSelector(c.ChainSel)
+		if !ok {
+			return nil, fmt.Errorf("no OCR config for chain %d on node %s", c.ChainSel, node.NodeID)
+		}
+		identities = append(identities, confighelper.OracleIdentityExtra{
+			OracleIdentity: confighelper.OracleIdentity{
+				OnchainPublicKey:  ocrCfg.OnchainPublicKey,
+				OffchainPublicKey: ocrCfg.OffchainPublicKey,
+				PeerID:            ocrCfg.PeerID.Raw(),
+				TransmitAccount:   ocrCfg.TransmitAccount,
+			},
+			ConfigEncryptionPublicKey: ocrCfg.ConfigEncryptionPublicKey,
+		})
+		schedule = append(schedule, 1)
+	}
+
+	onchainCfg := DefaultOnchainConfig(chain.DeployerKey.From)
+	if c.OnchainConfig != nil {
+		onchainCfg = *c.OnchainConfig
+	}
+	onchainCfg.Registrars = append([]common.Address{state.Registrar.Address()}, onchainCfg.Registrars...)
+	pluginCfg := c.ReportingPluginConfig
+	if pluginCfg == nil {
+		pluginCfg = DefaultReportingPluginConfig
+	}
+
+	p := c.OCRParameters
+	signers, transmitters, f, _, offchainConfigVersion, offchainConfig, err := ocr3confighelper.ContractSetConfigArgsDeterministic(
+		c.OCRSecrets.EphemeralSk,
+		c.OCRSecrets.SharedSecret,
+		p.DeltaProgress,
+		p.DeltaResend,
+		p.DeltaInitial,
+		p.DeltaRound,
+		p.DeltaGrace,
+		p.DeltaCertifiedCommitRequest,
+		p.DeltaStage,
+		p.Rmax,
+		schedule,
+		identities,
+		pluginCfg,
+		nil, // maxDurationInitialization
+		p.MaxDurationQuery,
+		p.MaxDurationObservation,
+		p.MaxDurationShouldAcceptAttestedReport,
+		p.MaxDurationShouldTransmitAcceptedReport,
+		(len(identities)-1)/3,
+		nil, // the onchain config is passed separately
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build OCR config: %w", err)
+	}
+	signerAddresses, err := evm.OnchainPublicKeyToAddress(signers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert signers: %w", err)
+	}
+	transmitterAddresses, err := evm.AccountToAddress(transmitters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert transmitters: %w", err)
+	}
+	tx, err := state.Registry.SetConfigTypeSafe(chain.DeployerKey, signerAddresses, transmitterAddresses, f,
+		onchainCfg, offchainConfigVersion, offchainConfig)
+	if _, err := deployment.ConfirmIfNoError(chain, tx, err); err != nil {
+		return nil, fmt.Errorf("failed to set registry config: %w", deployment.MaybeDataErr(err))
+	}
+	e.Logger.Infow("Set automation registry config", "chainSelector", c.ChainSel, "registry", state.Registry.Address())
+
+	return automationJobSpecs(c.ChainSel, nodes, state.Registry.Address())
+}
+
+func automationJobSpecs(chainSel uint64, nodes deployment.Nodes, registryAddr common.Address) (map[string][]string, error) {
+	chainID, err := deployment.EVMChainID(chainSel)
+	if err != nil {
+		return nil, err
+	}
+	bootstrappers := nodes.BootstrapLocators()
+	specs := make(map[string][]string)
+	for _, node := range nodes {
+		if node.IsBootstrap {
+			specs[node.NodeID] = append(specs[node.NodeID], fmt.Sprintf(bootstrapSpecTemplate, registryAddr, chainID))
+			continue
+		}
+		ocrCfg, ok := node.OCRConfigForChainSelector(chainSel)
+		if !ok {
+			return nil, fmt.Errorf("no OCR config for chain %d on node %s", chainSel, node.NodeID)
+		}
+		var quoted []string
+		for _, b := range bootstrappers {
+			quoted = append(quoted, fmt.Sprintf("%q", b))
+		}
+		specs[node.NodeID] = append(specs[node.NodeID], fmt.Sprintf(automationSpecTemplate,
+			registryAddr, ocrCfg.KeyBundleID, ocrCfg.TransmitAccount, strings.Join(quoted, ", "), chainID, RegistryVersion2_1))
+	}
+	return specs, nil
+}
+
+const bootstrapSpecTemplate = `type = "bootstrap"
+relay = "evm"
+schemaVersion = 1
+name = "automation-boot"
+contractID = "%s"
+contractConfigTrackerPollInterval = "15s"
+
+[relayConfig]
+chainID = %d
+`
+
+const automationSpecTemplate = `type = "offchainreporting2"
+pluginType = "ocr2automation"
+relay = "evm"
+name = "automation"
+schemaVersion = 1
+contractID = "%s"
+contractConfigTrackerPollInterval = "15s"
+ocrKeyBundleID = "%s"
+transmitterID = "%s"
+p2pv2Bootstrappers = [%s]
+
+[relayConfig]
+chainID = %d
+
+[pluginConfig]
+maxServiceWorkers = 100
+cacheEvictionInterval = "1s"
+contractVersion = "%s"
+`
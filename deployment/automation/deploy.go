@@ -0,0 +1,146 @@
+package automation
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/generated/automation_forwarder_logic"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/generated/automation_registrar_wrapper2_1"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/generated/keeper_registry_logic_a_wrapper_2_1"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/generated/keeper_registry_logic_b_wrapper_2_1"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/generated/keeper_registry_wrapper_2_1"
+)
+
+// RegistryVersion is the version of the Automation registry contracts, as used in automation job specs.
+type RegistryVersion string
+
+const (
+	RegistryVersion2_1 RegistryVersion = "v2.1"
+)
+
+var (
+	Version2_1_0 = *semver.MustParse("2.1.0")
+)
+
+var (
+	AutomationRegistry  deployment.ContractType = "AutomationRegistry"
+	AutomationRegistrar deployment.ContractType = "AutomationRegistrar"
+	UpkeepCounter       deployment.ContractType = "UpkeepCounter"
+)
+
+// ChainRegistryConfig holds the chain specific dependencies of the registry.
+type ChainRegistryConfig struct {
+	LinkToken      common.Address
+	LinkNativeFeed common.Address
+	FastGasFeed    common.Address
+}
+
+type DeployRegistryConfig struct {
+	// Version defaults to v2.1, which is currently the only supported version.
+	Version RegistryVersion
+	Chains  map[uint64]ChainRegistryConfig
+	// MinRegistrationFunds is the minimum amount of LINK juels to register an upkeep through the registrar.
+	MinRegistrationFunds *big.Int
+}
+
+func (c DeployRegistryConfig) Validate(e deployment.Environment) error {
+	if c.Version != "" && c.Version != RegistryVersion2_1 {
+		return fmt.Errorf("unsupported registry version %s", c.Version)
+	}
+	if len(c.Chains) == 0 {
+		return fmt.Errorf("no chains to deploy")
+	}
+	for sel, chainCfg := range c.Chains {
+		if _, ok := e.Chains[sel]; !ok {
+			return fmt.Errorf("chain %d not found in environment", sel)
+		}
+		if chainCfg.LinkToken == (common.Address{}) || chainCfg.LinkNativeFeed == (common.Address{}) || chainCfg.FastGasFeed == (common.Address{}) {
+			return fmt.Errorf("link token and feeds are required on chain %d", sel)
+		}
+	}
+	if c.MinRegistrationFunds != nil && c.MinRegistrationFunds.Sign() < 0 {
+		return fmt.Errorf("negative minimum registration funds")
+	}
+	return nil
+}
+
+// DeployRegistry deploys the Automation registry, its logic contracts and a registrar to every configured chain.
+// Chains that already have a registry in the environment address book are skipped.
+func DeployRegistry(e deployment.Environment, ab deployment.AddressBook, c DeployRegistryConfig) error {
+	if err := c.Validate(e); err != nil {
+		return fmt.Errorf("invalid config: %w", err)
+	}
+	for sel, chainCfg := range c.Chains {
+		chain := e.Chains[sel]
+		existing, err := existingChainState(e, chain)
+		if err != nil {
+			return err
+		}
+		if existing.Registry != nil {
+			e.Logger.Infow("Automation registry already deployed", "chainSelector", sel)
+			continue
+		}
+		if err := deployRegistry21ToChain(e, chain, ab, chainCfg, c.MinRegistrationFunds); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deployRegistry21ToChain deploys a v2.1 registry and its registrar.
+// The registrar can only register upkeeps once it is part of the registry onchain config, see ConfigureRegistry.
+//
+// Note that this function modifies the given address book variable.
+func deployRegistry21ToChain(e deployment.Environment, chain deployment.Chain, ab deployment.AddressBook, cfg ChainRegistryConfig, minFunds *big.Int) error {
+	// the logic contracts are only reachable through the registry, so they are not saved to the address book
+	forwarderAddr, tx, _, err := automation_forwarder_logic.DeployAutomationForwarderLogic(chain.DeployerKey, chain.Client)
+	if _, err := deployment.ConfirmIfNoError(chain, tx, err); err != nil {
+		return fmt.Errorf("failed to deploy AutomationForwarderLogic: %w", err)
+	}
+	logicBAddr, tx, _, err := keeper_registry_logic_b_wrapper_2_1.DeployKeeperRegistryLogicB(chain.DeployerKey, chain.Client,
+		0, // default payment model
+		cfg.LinkToken, cfg.LinkNativeFeed, cfg.FastGasFeed, forwarderAddr)
+	if _, err := deployment.ConfirmIfNoError(chain, tx, err); err != nil {
+		return fmt.Errorf("failed to deploy KeeperRegistryLogicB: %w", err)
+	}
+	logicAAddr, tx, _, err := keeper_registry_logic_a_wrapper_2_1.DeployKeeperRegistryLogicA(chain.DeployerKey, chain.Client, logicBAddr)
+	if _, err := deployment.ConfirmIfNoError(chain, tx, err); err != nil {
+		return fmt.Errorf("failed to deploy KeeperRegistryLogicA: %w", err)
+	}
+	registry, err := deployment.DeployContract(e.Logger, chain, ab,
+		func(chain deployment.Chain) deployment.ContractDeploy[*keeper_registry_wrapper_2_1.KeeperRegistry] {
+			addr, tx, r, err2 := keeper_registry_wrapper_2_1.DeployKeeperRegistry(chain.DeployerKey, chain.Client, logicAAddr)
+			return deployment.ContractDeploy[*keeper_registry_wrapper_2_1.KeeperRegistry]{
+				Address: addr, Contract: r, Tx: tx, Err: err2,
+				Tv: deployment.NewTypeAndVersion(AutomationRegistry, Version2_1_0),
+			}
+		})
+	if err != nil {
+		return fmt.Errorf("failed to deploy KeeperRegistry: %w", err)
+	}
+	if minFunds == nil {
+		minFunds = big.NewInt(0)
+	}
+	_, err = deployment.DeployContract(e.Logger, chain, ab,
+		func(chain deployment.Chain) deployment.ContractDeploy[*automation_registrar_wrapper2_1.AutomationRegistrar] {
+			addr, tx, r, err2 := automation_registrar_wrapper2_1.DeployAutomationRegistrar(chain.DeployerKey, chain.Client,
+				cfg.LinkToken, registry.Address, minFunds,
+				[]automation_registrar_wrapper2_1.AutomationRegistrar21InitialTriggerConfig{
+					// conditional and log triggers, auto approved
+					{TriggerType: 0, AutoApproveType: 2, AutoApproveMaxAllowed: 1000},
+					{TriggerType: 1, AutoApproveType: 2, AutoApproveMaxAllowed: 1000},
+				})
+			return deployment.ContractDeploy[*automation_registrar_wrapper2_1.AutomationRegistrar]{
+				Address: addr, Contract: r, Tx: tx, Err: err2,
+				Tv: deployment.NewTypeAndVersion(AutomationRegistrar, Version2_1_0),
+			}
+		})
+	if err != nil {
+		return fmt.Errorf("failed to deploy AutomationRegistrar: %w", err)
+	}
+	return nil
+}
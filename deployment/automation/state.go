@@ -0,0 +1,65 @@
+package automation
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/generated/automation_registrar_wrapper2_1"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/generated/i_keeper_registry_master_wrapper_2_1"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/generated/upkeep_counter_wrapper"
+)
+
+// AutomationChainState holds a Go binding for all the currently deployed Automation contracts
+// on a chain. If a binding is nil, it means here is no such contract on the chain.
+type AutomationChainState struct {
+	// Registry is bound through the master interface, which covers the registry and its logic contracts.
+	Registry  *i_keeper_registry_master_wrapper_2_1.IKeeperRegistryMaster
+	Registrar *automation_registrar_wrapper2_1.AutomationRegistrar
+	Upkeeps   []*upkeep_counter_wrapper.UpkeepCounter
+}
+
+// LoadChainState Loads all state for a chain into state
+func LoadChainState(chain deployment.Chain, addresses map[string]deployment.TypeAndVersion) (AutomationChainState, error) {
+	var state AutomationChainState
+	for address, tv := range addresses {
+		switch tv.String() {
+		case deployment.NewTypeAndVersion(AutomationRegistry, Version2_1_0).String():
+			r, err := i_keeper_registry_master_wrapper_2_1.NewIKeeperRegistryMaster(common.HexToAddress(address), chain.Client)
+			if err != nil {
+				return state, err
+			}
+			state.Registry = r
+		case deployment.NewTypeAndVersion(AutomationRegistrar, Version2_1_0).String():
+			r, err := automation_registrar_wrapper2_1.NewAutomationRegistrar(common.HexToAddress(address), chain.Client)
+			if err != nil {
+				return state, err
+			}
+			state.Registrar = r
+		case deployment.NewTypeAndVersion(UpkeepCounter, deployment.Version1_0_0).String():
+			u, err := upkeep_counter_wrapper.NewUpkeepCounter(common.HexToAddress(address), chain.Client)
+			if err != nil {
+				return state, err
+			}
+			state.Upkeeps = append(state.Upkeeps, u)
+		default:
+			// other products can share the address book
+			continue
+		}
+	}
+	return state, nil
+}
+
+func existingChainState(e deployment.Environment, chain deployment.Chain) (AutomationChainState, error) {
+	addresses, err := e.ExistingAddresses.AddressesForChain(chain.Selector)
+	if errors.Is(err, deployment.ErrChainNotFound) {
+		// nothing deployed yet
+		return AutomationChainState{}, nil
+	}
+	if err != nil {
+		return AutomationChainState{}, fmt.Errorf("failed to get addresses for chain %d: %w", chain.Selector, err)
+	}
+	return LoadChainState(chain, addresses)
+}
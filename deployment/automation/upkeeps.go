@@ -0,0 +1,123 @@
+package automation
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/generated/link_token_interface"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/generated/upkeep_counter_wrapper"
+)
+
+type RegisterTestUpkeepsConfig struct {
+	ChainSel uint64
+	// Count is the number of conditional upkeeps to deploy and register.
+	Count int
+	// Funds is the amount of LINK juels added to each upkeep, taken from the deployer key.
+	Funds *big.Int
+	// GasLimit defaults to 500000.
+	GasLimit uint32
+	// TestRange and Interval configure the UpkeepCounter, see its contract.
+	// They default to 1000 blocks and 0 seconds.
+	TestRange *big.Int
+	Interval  *big.Int
+}
+
+func (c RegisterTestUpkeepsConfig) Validate(e deployment.Environment) error {
+	if _, ok := e.Chains[c.ChainSel]; !ok {
+		return fmt.Errorf("chain %d not found in environment", c.ChainSel)
+	}
+	if c.Count <= 0 {
+		return fmt.Errorf("upkeep count must be positive")
+	}
+	if c.Funds == nil || c.Funds.Sign() <= 0 {
+		return fmt.Errorf("upkeep funds must be positive")
+	}
+	return nil
+}
+
+// RegisterTestUpkeeps deploys UpkeepCounter contracts, registers them as conditional upkeeps
+// on the registry of the chain and funds them. It returns the ids of the registered upkeeps.
+func RegisterTestUpkeeps(e deployment.Environment, ab deployment.AddressBook, c RegisterTestUpkeepsConfig) ([]*big.Int, error) {
+	if err := c.Validate(e); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+	chain := e.Chains[c.ChainSel]
+	state, err := existingChainState(e, chain)
+	if err != nil {
+		return nil, err
+	}
+	if state.Registry == nil {
+		return nil, fmt.Errorf("no automation registry on chain %d", c.ChainSel)
+	}
+	linkAddr, err := state.Registry.GetLinkAddress(nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get registry LINK token: %w", err)
+	}
+	link, err := link_token_interface.NewLinkToken(linkAddr, chain.Client)
+	if err != nil {
+		return nil, err
+	}
+	gasLimit := c.GasLimit
+	if gasLimit == 0 {
+		gasLimit = 500_000
+	}
+	testRange, interval := c.TestRange, c.Interval
+	if testRange == nil {
+		testRange = big.NewInt(1000)
+	}
+	if interval == nil {
+		interval = big.NewInt(0)
+	}
+
+	total := new(big.Int).Mul(c.Funds, big.NewInt(int64(c.Count)))
+	tx, err := link.Approve(chain.DeployerKey, state.Registry.Address(), total)
+	if _, err := deployment.ConfirmIfNoError(chain, tx, err); err != nil {
+		return nil, fmt.Errorf("failed to approve LINK for the registry: %w", err)
+	}
+
+	var ids []*big.Int
+	for i := 0; i < c.Count; i++ {
+		upkeep, err := deployment.DeployContract(e.Logger, chain, ab,
+			func(chain deployment.Chain) deployment.ContractDeploy[*upkeep_counter_wrapper.UpkeepCounter] {
+				addr, tx, u, err2 := upkeep_counter_wrapper.DeployUpkeepCounter(chain.DeployerKey, chain.Client, testRange, interval)
+				return deployment.ContractDeploy[*upkeep_counter_wrapper.UpkeepCounter]{
+					Address: addr, Contract: u, Tx: tx, Err: err2,
+					Tv: deployment.NewTypeAndVersion(UpkeepCounter, deployment.Version1_0_0),
+				}
+			})
+		if err != nil {
+			return ids, fmt.Errorf("failed to deploy UpkeepCounter: %w", err)
+		}
+		// the registry owner can register upkeeps directly, without going through the registrar
+		tx, err := state.Registry.RegisterUpkeep0(chain.DeployerKey, upkeep.Address, gasLimit, chain.DeployerKey.From, []byte{}, []byte{})
+		block, err := deployment.ConfirmIfNoError(chain, tx, err)
+		if err != nil {
+			return ids, fmt.Errorf("failed to register upkeep %s: %w", upkeep.Address, deployment.MaybeDataErr(err))
+		}
+		it, err := state.Registry.FilterUpkeepRegistered(&bind.FilterOpts{Start: block, End: &block, Context: context.Background()}, nil)
+		if err != nil {
+			return ids, fmt.Errorf("failed to filter UpkeepRegistered events: %w", err)
+		}
+		var id *big.Int
+		for it.Next() {
+			if it.Event.Raw.TxHash == tx.Hash() {
+				id = it.Event.Id
+			}
+		}
+		it.Close()
+		if id == nil {
+			return ids, fmt.Errorf("no UpkeepRegistered event for upkeep %s", upkeep.Address)
+		}
+		tx, err = state.Registry.AddFunds(chain.DeployerKey, id, c.Funds)
+		if _, err := deployment.ConfirmIfNoError(chain, tx, err); err != nil {
+			return ids, fmt.Errorf("failed to fund upkeep %s: %w", id, deployment.MaybeDataErr(err))
+		}
+		e.Logger.Infow("Registered test upkeep", "chainSelector", c.ChainSel, "upkeepID", id, "target", upkeep.Address)
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
@@ -0,0 +1,56 @@
+package deployment
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// ErrBytecodeMismatch is returned by VerifyBytecode for contracts which were not compiled from the expected artifact.
+var ErrBytecodeMismatch = errors.New("bytecode mismatch")
+
+// BytecodeMetadata returns the CBOR encoded metadata solc appends to the code of contracts, which hashes their
+// sources and compiler settings. Unlike the code itself it doesn't depend on constructor arguments or immutables,
+// so it is the same for all the deployments of an artifact. The creation code of an artifact ends with the
+// metadata of its runtime code.
+func BytecodeMetadata(code []byte) ([]byte, error) {
+	if len(code) < 2 {
+		return nil, errors.New("code too short for metadata")
+	}
+	n := int(binary.BigEndian.Uint16(code[len(code)-2:]))
+	if n == 0 || n+2 > len(code) {
+		return nil, errors.New("code has no metadata")
+	}
+	return code[len(code)-2-n : len(code)-2], nil
+}
+
+// VerifyBytecode checks that the code deployed at the address was compiled from the artifact, the hex encoded
+// creation code of a wrapper, e.g. router.RouterMetaData.Bin, by comparing their metadata. This detects contracts
+// upgraded or replaced by hand, which don't match the releases they are recorded as in the address book.
+func VerifyBytecode(ctx context.Context, chain Chain, address common.Address, bin string) error {
+	expected, err := hexutil.Decode(bin)
+	if err != nil {
+		return fmt.Errorf("invalid artifact: %w", err)
+	}
+	expectedMetadata, err := BytecodeMetadata(expected)
+	if err != nil {
+		return fmt.Errorf("invalid artifact: %w", err)
+	}
+	code, err := chain.Client.CodeAt(ctx, address, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get code of %s on chain %d: %w", address, chain.Selector, err)
+	}
+	if len(code) == 0 {
+		return fmt.Errorf("%w: no code at %s on chain %d", ErrBytecodeMismatch, address, chain.Selector)
+	}
+	metadata, err := BytecodeMetadata(code)
+	if err != nil || !bytes.Equal(metadata, expectedMetadata) {
+		return fmt.Errorf("%w: code at %s on chain %d was not compiled from the artifact", ErrBytecodeMismatch, address, chain.Selector)
+	}
+	return nil
+}
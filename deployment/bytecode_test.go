@@ -0,0 +1,21 @@
+package deployment
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBytecodeMetadata(t *testing.T) {
+	code := []byte{0x60, 0x80, 0xa2, 0x64, 0x69, 0x70, 0x00, 0x04}
+	metadata, err := BytecodeMetadata(code)
+	require.NoError(t, err)
+	require.Equal(t, []byte{0xa2, 0x64, 0x69, 0x70}, metadata)
+
+	_, err = BytecodeMetadata([]byte{0x00})
+	require.Error(t, err)
+	_, err = BytecodeMetadata([]byte{0x60, 0x00, 0x00})
+	require.Error(t, err)
+	_, err = BytecodeMetadata([]byte{0x60, 0x00, 0x10})
+	require.Error(t, err)
+}
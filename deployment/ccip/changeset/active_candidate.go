@@ -52,6 +52,37 @@ func SetCandidatePluginChangeset(
 	tokenConfig TokenConfig,
 	pluginType cctypes.PluginType,
 ) (deployment.ChangesetOutput, error) {
+	setCandidateMCMSOps, err := setCandidatePluginOps(state, e, nodes, ocrSecrets, homeChainSel, feedChainSel, newChainSel, tokenConfig, pluginType)
+	if err != nil {
+		return deployment.ChangesetOutput{}, err
+	}
+
+	prop, err := BuildProposalFromBatches(state, []timelock.BatchChainOperation{{
+		ChainIdentifier: mcms.ChainIdentifier(homeChainSel),
+		Batch:           setCandidateMCMSOps,
+	}}, "SetCandidate for execution", 0)
+	if err != nil {
+		return deployment.ChangesetOutput{}, err
+	}
+	return deployment.ChangesetOutput{
+		Proposals: []timelock.MCMSWithTimelockProposal{
+			*prop,
+		},
+	}, nil
+
+}
+
+// setCandidatePluginOps builds the operations calling setCandidate on the CCIPHome with the OCR3 config
+// of the given plugin for a chain, derived from the current keys of the nodes.
+func setCandidatePluginOps(
+	state CCIPOnChainState,
+	e deployment.Environment,
+	nodes deployment.Nodes,
+	ocrSecrets deployment.OCRSecrets,
+	homeChainSel, feedChainSel, newChainSel uint64,
+	tokenConfig TokenConfig,
+	pluginType cctypes.PluginType,
+) ([]mcms.Operation, error) {
 	ccipOCRParams := DefaultOCRParams(
 		feedChainSel,
 		tokenConfig.GetTokenInfo(e.Logger, state.Chains[newChainSel].LinkToken, state.Chains[newChainSel].Weth9),
@@ -68,12 +99,12 @@ func SetCandidatePluginChangeset(
 		ccipOCRParams.ExecuteOffChainConfig,
 	)
 	if err != nil {
-		return deployment.ChangesetOutput{}, err
+		return nil, err
 	}
 
 	execConfig, ok := newDONArgs[pluginType]
 	if !ok {
-		return deployment.ChangesetOutput{}, fmt.Errorf("missing exec plugin in ocr3Configs")
+		return nil, fmt.Errorf("missing %s plugin in ocr3Configs", pluginType.String())
 	}
 
 	setCandidateMCMSOps, err := SetCandidateOnExistingDon(
@@ -84,20 +115,7 @@ func SetCandidatePluginChangeset(
 		nodes.NonBootstraps(),
 	)
 	if err != nil {
-		return deployment.ChangesetOutput{}, err
+		return nil, err
 	}
-
-	prop, err := BuildProposalFromBatches(state, []timelock.BatchChainOperation{{
-		ChainIdentifier: mcms.ChainIdentifier(homeChainSel),
-		Batch:           setCandidateMCMSOps,
-	}}, "SetCandidate for execution", 0)
-	if err != nil {
-		return deployment.ChangesetOutput{}, err
-	}
-	return deployment.ChangesetOutput{
-		Proposals: []timelock.MCMSWithTimelockProposal{
-			*prop,
-		},
-	}, nil
-
+	return setCandidateMCMSOps, nil
 }
@@ -17,6 +17,8 @@ import (
 
 // NewChainInboundChangeset generates a proposal
 // to connect the new chain to the existing chains.
+// The config of the new chain is added to the CCIPHome with the default price reporting params,
+// see NewChainInboundChangesetWithPriceReporting.
 func NewChainInboundChangeset(
 	e deployment.Environment,
 	state CCIPOnChainState,
@@ -24,6 +26,22 @@ func NewChainInboundChangeset(
 	newChainSel uint64,
 	sources []uint64,
 ) (deployment.ChangesetOutput, error) {
+	return NewChainInboundChangesetWithPriceReporting(e, state, homeChainSel, newChainSel, sources, DefaultPriceReportingParams())
+}
+
+// NewChainInboundChangesetWithPriceReporting is NewChainInboundChangeset adding the config of the new chain
+// with the gas price deviations of priceReporting, usually the PriceReporting of its CCIPOCRParams.
+func NewChainInboundChangesetWithPriceReporting(
+	e deployment.Environment,
+	state CCIPOnChainState,
+	homeChainSel uint64,
+	newChainSel uint64,
+	sources []uint64,
+	priceReporting PriceReportingParams,
+) (deployment.ChangesetOutput, error) {
+	if err := priceReporting.Validate(); err != nil {
+		return deployment.ChangesetOutput{}, fmt.Errorf("invalid price reporting params: %w", err)
+	}
 	// Generate proposal which enables new destination (from test router) on all source chains.
 	var batches []timelock.BatchChainOperation
 	for _, source := range sources {
@@ -65,7 +83,8 @@ func NewChainInboundChangeset(
 		})
 	}
 
-	addChainOp, err := ApplyChainConfigUpdatesOp(e, state, homeChainSel, []uint64{newChainSel})
+	addChainOp, err := ApplyChainConfigUpdatesOpWithPriceReporting(e, state, homeChainSel,
+		map[uint64]PriceReportingParams{newChainSel: priceReporting})
 	if err != nil {
 		return deployment.ChangesetOutput{}, err
 	}
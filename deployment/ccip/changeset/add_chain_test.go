@@ -1,7 +1,8 @@
-package changeset
+package changeset_test
 
 import (
 	"math/big"
+	"slices"
 	"testing"
 	"time"
 
@@ -12,11 +13,13 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/ccip_home"
 	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/rmn_home"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/smartcontractkit/chainlink-ccip/chainconfig"
 	cciptypes "github.com/smartcontractkit/chainlink-ccip/pkg/types/ccipocr3"
 	commonutils "github.com/smartcontractkit/chainlink-common/pkg/utils"
 
@@ -24,6 +27,8 @@ import (
 
 	"github.com/smartcontractkit/chainlink/deployment"
 
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset/testhelpers"
 	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/offramp"
 	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/router"
 	"github.com/smartcontractkit/chainlink/v2/core/logger"
@@ -31,15 +36,15 @@ import (
 
 func TestAddChainInbound(t *testing.T) {
 	// 4 chains where the 4th is added after initial deployment.
-	e := NewMemoryEnvironmentWithJobs(t, logger.TestLogger(t), 4, 4)
-	state, err := LoadOnchainState(e.Env)
+	e := testhelpers.NewMemoryEnvironmentWithJobs(t, logger.TestLogger(t), 4, 4)
+	state, err := changeset.LoadOnchainState(e.Env)
 	require.NoError(t, err)
 	// Take first non-home chain as the new chain.
 	newChain := e.Env.AllChainSelectorsExcluding([]uint64{e.HomeChainSel})[0]
 	// We deploy to the rest.
 	initialDeploy := e.Env.AllChainSelectorsExcluding([]uint64{newChain})
 	newAddresses := deployment.NewMemoryAddressBook()
-	err = deployPrerequisiteChainContracts(e.Env, newAddresses, initialDeploy, nil)
+	err = changeset.ExportedDeployPrerequisiteChainContracts(e.Env, newAddresses, initialDeploy, nil)
 	require.NoError(t, err)
 	require.NoError(t, e.Env.ExistingAddresses.Merge(newAddresses))
 
@@ -58,12 +63,12 @@ func TestAddChainInbound(t *testing.T) {
 	require.NoError(t, err)
 	require.NoError(t, e.Env.ExistingAddresses.Merge(out.AddressBook))
 	newAddresses = deployment.NewMemoryAddressBook()
-	tokenConfig := NewTestTokenConfig(state.Chains[e.FeedChainSel].USDFeeds)
-	ocrParams := make(map[uint64]CCIPOCRParams)
+	tokenConfig := changeset.NewTestTokenConfig(state.Chains[e.FeedChainSel].USDFeeds)
+	ocrParams := make(map[uint64]changeset.CCIPOCRParams)
 	for _, chain := range initialDeploy {
-		ocrParams[chain] = DefaultOCRParams(e.FeedChainSel, nil, nil)
+		ocrParams[chain] = changeset.DefaultOCRParams(e.FeedChainSel, nil, nil)
 	}
-	err = deployCCIPContracts(e.Env, newAddresses, NewChainsConfig{
+	err = changeset.ExportedDeployCCIPContracts(e.Env, newAddresses, changeset.NewChainsConfig{
 		HomeChainSel:   e.HomeChainSel,
 		FeedChainSel:   e.FeedChainSel,
 		ChainsToDeploy: initialDeploy,
@@ -73,19 +78,19 @@ func TestAddChainInbound(t *testing.T) {
 	})
 	require.NoError(t, err)
 
-	state, err = LoadOnchainState(e.Env)
+	state, err = changeset.LoadOnchainState(e.Env)
 	require.NoError(t, err)
 
 	// Connect all the existing lanes.
 	for _, source := range initialDeploy {
 		for _, dest := range initialDeploy {
 			if source != dest {
-				require.NoError(t, AddLaneWithDefaultPricesAndFeeQuoterConfig(e.Env, state, source, dest, false))
+				require.NoError(t, changeset.AddLaneWithDefaultPricesAndFeeQuoterConfig(e.Env, state, source, dest, false))
 			}
 		}
 	}
 
-	rmnHomeAddress, err := deployment.SearchAddressBook(e.Env.ExistingAddresses, e.HomeChainSel, RMNHome)
+	rmnHomeAddress, err := deployment.SearchAddressBook(e.Env.ExistingAddresses, e.HomeChainSel, changeset.RMNHome)
 	require.NoError(t, err)
 	require.True(t, common.IsHexAddress(rmnHomeAddress))
 	rmnHome, err := rmn_home.NewRMNHome(common.HexToAddress(rmnHomeAddress), e.Env.Chains[e.HomeChainSel].Client)
@@ -100,15 +105,15 @@ func TestAddChainInbound(t *testing.T) {
 
 	newAddresses = deployment.NewMemoryAddressBook()
 
-	err = deployPrerequisiteChainContracts(e.Env, newAddresses, []uint64{newChain}, nil)
+	err = changeset.ExportedDeployPrerequisiteChainContracts(e.Env, newAddresses, []uint64{newChain}, nil)
 	require.NoError(t, err)
 	require.NoError(t, e.Env.ExistingAddresses.Merge(newAddresses))
 	newAddresses = deployment.NewMemoryAddressBook()
-	err = deployChainContracts(e.Env,
-		e.Env.Chains[newChain], newAddresses, rmnHome)
+	err = changeset.ExportedDeployChainContracts(e.Env,
+		e.Env.Chains[newChain], newAddresses, rmnHome, changeset.ChainFeatures{})
 	require.NoError(t, err)
 	require.NoError(t, e.Env.ExistingAddresses.Merge(newAddresses))
-	state, err = LoadOnchainState(e.Env)
+	state, err = changeset.LoadOnchainState(e.Env)
 	require.NoError(t, err)
 
 	// Transfer onramp/fq ownership to timelock.
@@ -141,7 +146,7 @@ func TestAddChainInbound(t *testing.T) {
 	_, err = deployment.ConfirmIfNoError(e.Env.Chains[e.HomeChainSel], tx, err)
 	require.NoError(t, err)
 
-	acceptOwnershipProposal, err := GenerateAcceptOwnershipProposal(state, e.HomeChainSel, initialDeploy)
+	acceptOwnershipProposal, err := changeset.GenerateAcceptOwnershipProposal(state, e.HomeChainSel, initialDeploy)
 	require.NoError(t, err)
 	acceptOwnershipExec := commonchangeset.SignProposal(t, e.Env, acceptOwnershipProposal)
 	// Apply the accept ownership proposal to all the chains.
@@ -164,36 +169,58 @@ func TestAddChainInbound(t *testing.T) {
 	require.NoError(t, err)
 
 	// Generate and sign inbound proposal to new 4th chain.
-	chainInboundChangeset, err := NewChainInboundChangeset(e.Env, state, e.HomeChainSel, newChain, initialDeploy)
+	priceReporting := changeset.DefaultPriceReportingParams()
+	priceReporting.GasPriceDeviationPPB = big.NewInt(5e7)
+	priceReporting.DAGasPriceDeviationPPB = nil
+	chainInboundChangeset, err := changeset.NewChainInboundChangesetWithPriceReporting(e.Env, state, e.HomeChainSel, newChain, initialDeploy, priceReporting)
 	require.NoError(t, err)
-	ProcessChangeset(t, e.Env, chainInboundChangeset)
+	testhelpers.ProcessChangeset(t, e.Env, "NewChainInboundChangeset", chainInboundChangeset)
+	chainConfigs, err := state.Chains[e.HomeChainSel].CCIPHome.GetAllChainConfigs(nil, big.NewInt(0), big.NewInt(100))
+	require.NoError(t, err)
+	idx := slices.IndexFunc(chainConfigs, func(c ccip_home.CCIPHomeChainConfigArgs) bool { return c.ChainSelector == newChain })
+	require.NotEqual(t, -1, idx)
+	chainConfig, err := chainconfig.DecodeChainConfig(chainConfigs[idx].ChainConfig.Config)
+	require.NoError(t, err)
+	require.Equal(t, int64(5e7), chainConfig.GasPriceDeviationPPB.Int64())
+	require.Equal(t, int64(0), chainConfig.DAGasPriceDeviationPPB.Int64())
 
 	// TODO This currently is not working - Able to send the request here but request gets stuck in execution
 	// Send a new message and expect that this is delivered once the chain is completely set up as inbound
 	//TestSendRequest(t, e.Env, state, initialDeploy[0], newChain, true)
 
 	t.Logf("Executing add don and set candidate proposal for commit plugin on chain %d", newChain)
-	addDonChangeset, err := AddDonAndSetCandidateChangeset(state, e.Env, nodes, deployment.XXXGenerateTestOCRSecrets(), e.HomeChainSel, e.FeedChainSel, newChain, tokenConfig, types.PluginTypeCCIPCommit)
+	addDonChangeset, err := changeset.AddDonAndSetCandidateChangeset(state, e.Env, nodes, deployment.XXXGenerateTestOCRSecrets(), e.HomeChainSel, e.FeedChainSel, newChain, tokenConfig, types.PluginTypeCCIPCommit)
 	require.NoError(t, err)
-	ProcessChangeset(t, e.Env, addDonChangeset)
+	testhelpers.ProcessChangeset(t, e.Env, "AddDonAndSetCandidateChangeset", addDonChangeset)
 
 	t.Logf("Executing promote candidate proposal for exec plugin on chain %d", newChain)
-	setCandidateForExecChangeset, err := SetCandidatePluginChangeset(state, e.Env, nodes, deployment.XXXGenerateTestOCRSecrets(), e.HomeChainSel, e.FeedChainSel, newChain, tokenConfig, types.PluginTypeCCIPExec)
+	setCandidateForExecChangeset, err := changeset.SetCandidatePluginChangeset(state, e.Env, nodes, deployment.XXXGenerateTestOCRSecrets(), e.HomeChainSel, e.FeedChainSel, newChain, tokenConfig, types.PluginTypeCCIPExec)
 	require.NoError(t, err)
-	ProcessChangeset(t, e.Env, setCandidateForExecChangeset)
+	testhelpers.ProcessChangeset(t, e.Env, "SetCandidatePluginChangeset", setCandidateForExecChangeset)
 
 	t.Logf("Executing promote candidate proposal for both commit and exec plugins on chain %d", newChain)
-	donPromoteChangeset, err := PromoteAllCandidatesChangeset(state, e.HomeChainSel, newChain, nodes)
+	donPromoteChangeset, err := changeset.PromoteAllCandidatesChangeset(state, e.HomeChainSel, newChain, nodes)
 	require.NoError(t, err)
-	ProcessChangeset(t, e.Env, donPromoteChangeset)
+	testhelpers.ProcessChangeset(t, e.Env, "PromoteAllCandidatesChangeset", donPromoteChangeset)
 
 	// verify if the configs are updated
-	require.NoError(t, ValidateCCIPHomeConfigSetUp(
+	require.NoError(t, changeset.ValidateCCIPHomeConfigSetUp(
 		state.Chains[e.HomeChainSel].CapabilityRegistry,
 		state.Chains[e.HomeChainSel].CCIPHome,
 		newChain,
 	))
-	replayBlocks, err := LatestBlocksByChain(testcontext.Get(t), e.Env.Chains)
+	homeView, err := changeset.ViewCCIPHome(e.Env, e.HomeChainSel)
+	require.NoError(t, err)
+	don, ok := homeView.DONForChain(newChain)
+	require.True(t, ok)
+	require.Len(t, don.Members, len(nodes.NonBootstraps()))
+	require.NotNil(t, don.Commit.Active)
+	require.Nil(t, don.Commit.Candidate)
+	require.NotNil(t, don.Exec.Active.ExecuteOffchainConfig)
+	require.Equal(t, state.Chains[newChain].OffRamp.Address().Hex(), don.Commit.Active.OffRampAddress)
+	_, ok = homeView.ChainConfig(newChain)
+	require.True(t, ok)
+	replayBlocks, err := testhelpers.LatestBlocksByChain(testcontext.Get(t), e.Env.Chains)
 	require.NoError(t, err)
 
 	// Now configure the new chain using deployer key (not transferred to timelock yet).
@@ -221,7 +248,7 @@ func TestAddChainInbound(t *testing.T) {
 	require.NoError(t, err)
 
 	// Assert the inbound lanes to the new chain are wired correctly.
-	state, err = LoadOnchainState(e.Env)
+	state, err = changeset.LoadOnchainState(e.Env)
 	require.NoError(t, err)
 	for _, chain := range initialDeploy {
 		cfg, err2 := state.Chains[chain].OnRamp.GetDestChainConfig(nil, newChain)
@@ -236,14 +263,14 @@ func TestAddChainInbound(t *testing.T) {
 	}
 	// Ensure job related logs are up to date.
 	time.Sleep(30 * time.Second)
-	ReplayLogs(t, e.Env.Offchain, replayBlocks)
+	testhelpers.ReplayLogs(t, e.Env.Offchain, replayBlocks)
 
 	// TODO: Send via all inbound lanes and use parallel helper
 	// Now that the proposal has been executed we expect to be able to send traffic to this new 4th chain.
 	latesthdr, err := e.Env.Chains[newChain].Client.HeaderByNumber(testcontext.Get(t), nil)
 	require.NoError(t, err)
 	startBlock := latesthdr.Number.Uint64()
-	msgSentEvent := TestSendRequest(t, e.Env, state, initialDeploy[0], newChain, true, router.ClientEVM2AnyMessage{
+	msgSentEvent := testhelpers.TestSendRequest(t, e.Env, state, initialDeploy[0], newChain, true, router.ClientEVM2AnyMessage{
 		Receiver:     common.LeftPadBytes(state.Chains[newChain].Receiver.Address().Bytes(), 32),
 		Data:         []byte("hello world"),
 		TokenAmounts: nil,
@@ -251,13 +278,13 @@ func TestAddChainInbound(t *testing.T) {
 		ExtraArgs:    nil,
 	})
 	require.NoError(t,
-		commonutils.JustError(ConfirmCommitWithExpectedSeqNumRange(t, e.Env.Chains[initialDeploy[0]], e.Env.Chains[newChain], state.Chains[newChain].OffRamp, &startBlock, cciptypes.SeqNumRange{
+		commonutils.JustError(testhelpers.ConfirmCommitWithExpectedSeqNumRange(t, e.Env.Chains[initialDeploy[0]], e.Env.Chains[newChain], state.Chains[newChain].OffRamp, &startBlock, cciptypes.SeqNumRange{
 			cciptypes.SeqNum(1),
 			cciptypes.SeqNum(msgSentEvent.SequenceNumber),
 		})))
 	require.NoError(t,
 		commonutils.JustError(
-			ConfirmExecWithSeqNrs(
+			testhelpers.ConfirmExecWithSeqNrs(
 				t,
 				e.Env.Chains[initialDeploy[0]],
 				e.Env.Chains[newChain],
@@ -272,5 +299,5 @@ func TestAddChainInbound(t *testing.T) {
 	feeQuoter := state.Chains[newChain].FeeQuoter
 	timestampedPrice, err := feeQuoter.GetTokenPrice(nil, linkAddress)
 	require.NoError(t, err)
-	require.Equal(t, MockLinkPrice, timestampedPrice.Value)
+	require.Equal(t, testhelpers.MockLinkPrice, timestampedPrice.Value)
 }
@@ -123,8 +123,6 @@ func AddLane(e deployment.Environment, state CCIPOnChainState, config LaneConfig
 	var toRouter *router.Router
 	from := config.SourceSelector
 	to := config.DestSelector
-	feeQuoterDestChainConfig := config.FeeQuoterDestChain
-	initialPrices := config.InitialPricesBySource
 	if isTestRouter {
 		fromRouter = state.Chains[from].TestRouter
 		toRouter = state.Chains[to].TestRouter
@@ -132,6 +130,9 @@ func AddLane(e deployment.Environment, state CCIPOnChainState, config LaneConfig
 		fromRouter = state.Chains[from].Router
 		toRouter = state.Chains[to].Router
 	}
+	if err := configureLaneRamps(e, state, config, fromRouter.Address(), toRouter.Address()); err != nil {
+		return err
+	}
 	tx, err := fromRouter.ApplyRampUpdates(e.Chains[from].DeployerKey, []router.RouterOnRamp{
 		{
 			DestChainSelector: to,
@@ -141,11 +142,28 @@ func AddLane(e deployment.Environment, state CCIPOnChainState, config LaneConfig
 	if _, err := deployment.ConfirmIfNoError(e.Chains[from], tx, err); err != nil {
 		return err
 	}
-	tx, err = state.Chains[from].OnRamp.ApplyDestChainConfigUpdates(e.Chains[from].DeployerKey,
+	tx, err = toRouter.ApplyRampUpdates(e.Chains[to].DeployerKey, []router.RouterOnRamp{}, []router.RouterOffRamp{}, []router.RouterOffRamp{
+		{
+			SourceChainSelector: from,
+			OffRamp:             state.Chains[to].OffRamp.Address(),
+		},
+	})
+	_, err = deployment.ConfirmIfNoError(e.Chains[to], tx, err)
+	return err
+}
+
+// configureLaneRamps configures the onramp, fee quoter and offramp of a lane for the given routers,
+// without routing any traffic to them.
+func configureLaneRamps(e deployment.Environment, state CCIPOnChainState, config LaneConfig, fromRouter, toRouter common.Address) error {
+	from := config.SourceSelector
+	to := config.DestSelector
+	feeQuoterDestChainConfig := config.FeeQuoterDestChain
+	initialPrices := config.InitialPricesBySource
+	tx, err := state.Chains[from].OnRamp.ApplyDestChainConfigUpdates(e.Chains[from].DeployerKey,
 		[]onramp.OnRampDestChainConfigArgs{
 			{
 				DestChainSelector: to,
-				Router:            fromRouter.Address(),
+				Router:            fromRouter,
 			},
 		})
 	if _, err := deployment.ConfirmIfNoError(e.Chains[from], tx, err); err != nil {
@@ -189,7 +207,7 @@ func AddLane(e deployment.Environment, state CCIPOnChainState, config LaneConfig
 	tx, err = state.Chains[to].OffRamp.ApplySourceChainConfigUpdates(e.Chains[to].DeployerKey,
 		[]offramp.OffRampSourceChainConfigArgs{
 			{
-				Router:              toRouter.Address(),
+				Router:              toRouter,
 				SourceChainSelector: from,
 				IsEnabled:           true,
 				OnRamp:              common.LeftPadBytes(state.Chains[from].OnRamp.Address().Bytes(), 32),
@@ -198,14 +216,26 @@ func AddLane(e deployment.Environment, state CCIPOnChainState, config LaneConfig
 	if _, err := deployment.ConfirmIfNoError(e.Chains[to], tx, err); err != nil {
 		return err
 	}
-	tx, err = toRouter.ApplyRampUpdates(e.Chains[to].DeployerKey, []router.RouterOnRamp{}, []router.RouterOffRamp{}, []router.RouterOffRamp{
-		{
-			SourceChainSelector: from,
-			OffRamp:             state.Chains[to].OffRamp.Address(),
-		},
-	})
-	_, err = deployment.ConfirmIfNoError(e.Chains[to], tx, err)
-	return err
+	return nil
+}
+
+// gasPriceBits is the number of bits of each component of a packed gas price, see Internal.GAS_PRICE_BITS.
+const gasPriceBits = 112
+
+// ToPackedFee packs the execution and data availability gas prices into a uint224 gas price,
+// the data availability price in the upper 112 bits.
+func ToPackedFee(execFee, daFee *big.Int) *big.Int {
+	daShifted := new(big.Int).Lsh(daFee, gasPriceBits)
+	return new(big.Int).Or(daShifted, execFee)
+}
+
+// UnpackFee is the inverse of ToPackedFee, it splits a packed gas price into its
+// execution (lower 112 bits) and data availability (upper 112 bits) components.
+func UnpackFee(packed *big.Int) (execFee, daFee *big.Int) {
+	mask := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), gasPriceBits), big.NewInt(1))
+	execFee = new(big.Int).And(packed, mask)
+	daFee = new(big.Int).Rsh(packed, gasPriceBits)
+	return execFee, daFee
 }
 
 func DefaultFeeQuoterDestChainConfig() fee_quoter.FeeQuoterDestChainConfig {
@@ -235,3 +265,11 @@ func DefaultFeeQuoterDestChainConfig() fee_quoter.FeeQuoterDestChainConfig {
 		ChainFamilySelector:               [4]byte(evmFamilySelector),
 	}
 }
+
+// SourceDestPair is represents a pair of source and destination chain selectors.
+// Use this as a key in maps that need to identify sequence numbers, nonces, or
+// other things that require identification.
+type SourceDestPair struct {
+	SourceChainSelector uint64
+	DestChainSelector   uint64
+}
@@ -1,6 +1,7 @@
-package changeset
+package changeset_test
 
 import (
+	"math/big"
 	"testing"
 	"time"
 
@@ -11,27 +12,29 @@ import (
 	"github.com/smartcontractkit/chainlink-testing-framework/lib/utils/testcontext"
 
 	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset/testhelpers"
 	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/offramp"
 	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/router"
 	"github.com/smartcontractkit/chainlink/v2/core/logger"
 )
 
 func TestAddLanesWithTestRouter(t *testing.T) {
-	e := NewMemoryEnvironmentWithJobsAndContracts(t, logger.TestLogger(t), 2, 4, nil)
+	e := testhelpers.NewMemoryEnvironmentWithJobsAndContracts(t, logger.TestLogger(t), 2, 4, nil)
 	// Here we have CR + nodes set up, but no CCIP contracts deployed.
-	state, err := LoadOnchainState(e.Env)
+	state, err := changeset.LoadOnchainState(e.Env)
 	require.NoError(t, err)
 
 	selectors := e.Env.AllChainSelectors()
 	chain1, chain2 := selectors[0], selectors[1]
 
-	_, err = AddLanesWithTestRouter(e.Env, AddLanesConfig{
-		LaneConfigs: []LaneConfig{
+	_, err = changeset.AddLanesWithTestRouter(e.Env, changeset.AddLanesConfig{
+		LaneConfigs: []changeset.LaneConfig{
 			{
 				SourceSelector:        chain1,
 				DestSelector:          chain2,
-				InitialPricesBySource: DefaultInitialPrices,
-				FeeQuoterDestChain:    DefaultFeeQuoterDestChainConfig(),
+				InitialPricesBySource: changeset.DefaultInitialPrices,
+				FeeQuoterDestChain:    changeset.DefaultFeeQuoterDestChainConfig(),
 			},
 		},
 	})
@@ -39,23 +42,23 @@ func TestAddLanesWithTestRouter(t *testing.T) {
 	// Need to keep track of the block number for each chain so that event subscription can be done from that block.
 	startBlocks := make(map[uint64]*uint64)
 	// Send a message from each chain to every other chain.
-	expectedSeqNumExec := make(map[SourceDestPair][]uint64)
+	expectedSeqNumExec := make(map[changeset.SourceDestPair][]uint64)
 	latesthdr, err := e.Env.Chains[chain2].Client.HeaderByNumber(testcontext.Get(t), nil)
 	require.NoError(t, err)
 	block := latesthdr.Number.Uint64()
 	startBlocks[chain2] = &block
-	msgSentEvent := TestSendRequest(t, e.Env, state, chain1, chain2, true, router.ClientEVM2AnyMessage{
+	msgSentEvent := testhelpers.TestSendRequest(t, e.Env, state, chain1, chain2, true, router.ClientEVM2AnyMessage{
 		Receiver:     common.LeftPadBytes(state.Chains[chain2].Receiver.Address().Bytes(), 32),
 		Data:         []byte("hello"),
 		TokenAmounts: nil,
 		FeeToken:     common.HexToAddress("0x0"),
 		ExtraArgs:    nil,
 	})
-	expectedSeqNumExec[SourceDestPair{
+	expectedSeqNumExec[changeset.SourceDestPair{
 		SourceChainSelector: chain1,
 		DestChainSelector:   chain2,
 	}] = []uint64{msgSentEvent.SequenceNumber}
-	ConfirmExecWithSeqNrsForAll(t, e.Env, state, expectedSeqNumExec, startBlocks)
+	testhelpers.ConfirmExecWithSeqNrsForAll(t, e.Env, state, expectedSeqNumExec, startBlocks)
 }
 
 // TestAddLane covers the workflow of adding a lane between two chains and enabling it.
@@ -66,9 +69,9 @@ func TestAddLane(t *testing.T) {
 
 	t.Parallel()
 	// We add more chains to the chainlink nodes than the number of chains where CCIP is deployed.
-	e := NewMemoryEnvironmentWithJobsAndContracts(t, logger.TestLogger(t), 2, 4, nil)
+	e := testhelpers.NewMemoryEnvironmentWithJobsAndContracts(t, logger.TestLogger(t), 2, 4, nil)
 	// Here we have CR + nodes set up, but no CCIP contracts deployed.
-	state, err := LoadOnchainState(e.Env)
+	state, err := changeset.LoadOnchainState(e.Env)
 	require.NoError(t, err)
 
 	selectors := e.Env.AllChainSelectors()
@@ -82,13 +85,13 @@ func TestAddLane(t *testing.T) {
 		require.Len(t, offRamps, 0)
 	}
 
-	replayBlocks, err := LatestBlocksByChain(testcontext.Get(t), e.Env.Chains)
+	replayBlocks, err := testhelpers.LatestBlocksByChain(testcontext.Get(t), e.Env.Chains)
 	require.NoError(t, err)
 
 	// Add one lane from chain1 to chain 2 and send traffic.
-	require.NoError(t, AddLaneWithDefaultPricesAndFeeQuoterConfig(e.Env, state, chain1, chain2, false))
+	require.NoError(t, changeset.AddLaneWithDefaultPricesAndFeeQuoterConfig(e.Env, state, chain1, chain2, false))
 
-	ReplayLogs(t, e.Env.Offchain, replayBlocks)
+	testhelpers.ReplayLogs(t, e.Env.Offchain, replayBlocks)
 	time.Sleep(30 * time.Second)
 	// disable the onRamp initially on OffRamp
 	disableRampTx, err := state.Chains[chain2].OffRamp.ApplySourceChainConfigUpdates(e.Env.Chains[chain2].DeployerKey, []offramp.OffRampSourceChainConfigArgs{
@@ -122,7 +125,7 @@ func TestAddLane(t *testing.T) {
 	startBlock := latesthdr.Number.Uint64()
 	// Send traffic on the first lane and it should not be processed by the plugin as onRamp is disabled
 	// we will check this by confirming that the message is not executed by the end of the test
-	msgSentEvent1 := TestSendRequest(t, e.Env, state, chain1, chain2, false, router.ClientEVM2AnyMessage{
+	msgSentEvent1 := testhelpers.TestSendRequest(t, e.Env, state, chain1, chain2, false, router.ClientEVM2AnyMessage{
 		Receiver:     common.LeftPadBytes(state.Chains[chain2].Receiver.Address().Bytes(), 32),
 		Data:         []byte("hello world"),
 		TokenAmounts: nil,
@@ -132,13 +135,13 @@ func TestAddLane(t *testing.T) {
 	require.Equal(t, uint64(1), msgSentEvent1.SequenceNumber)
 
 	// Add another lane
-	require.NoError(t, AddLaneWithDefaultPricesAndFeeQuoterConfig(e.Env, state, chain2, chain1, false))
+	require.NoError(t, changeset.AddLaneWithDefaultPricesAndFeeQuoterConfig(e.Env, state, chain2, chain1, false))
 
 	// Send traffic on the second lane and it should succeed
 	latesthdr, err = e.Env.Chains[chain1].Client.HeaderByNumber(testcontext.Get(t), nil)
 	require.NoError(t, err)
 	startBlock2 := latesthdr.Number.Uint64()
-	msgSentEvent2 := TestSendRequest(t, e.Env, state, chain2, chain1, false, router.ClientEVM2AnyMessage{
+	msgSentEvent2 := testhelpers.TestSendRequest(t, e.Env, state, chain2, chain1, false, router.ClientEVM2AnyMessage{
 		Receiver:     common.LeftPadBytes(state.Chains[chain2].Receiver.Address().Bytes(), 32),
 		Data:         []byte("hello world"),
 		TokenAmounts: nil,
@@ -148,7 +151,7 @@ func TestAddLane(t *testing.T) {
 	require.Equal(t, uint64(1), msgSentEvent2.SequenceNumber)
 	require.NoError(t,
 		commonutils.JustError(
-			ConfirmExecWithSeqNrs(
+			testhelpers.ConfirmExecWithSeqNrs(
 				t,
 				e.Env.Chains[chain2],
 				e.Env.Chains[chain1],
@@ -160,7 +163,7 @@ func TestAddLane(t *testing.T) {
 	)
 
 	// now check for the previous message from chain 1 to chain 2 that it has not been executed till now as the onRamp was disabled
-	ConfirmNoExecConsistentlyWithSeqNr(t, e.Env.Chains[chain1], e.Env.Chains[chain2], state.Chains[chain2].OffRamp, msgSentEvent1.SequenceNumber, 30*time.Second)
+	testhelpers.ConfirmNoExecConsistentlyWithSeqNr(t, e.Env.Chains[chain1], e.Env.Chains[chain2], state.Chains[chain2].OffRamp, msgSentEvent1.SequenceNumber, 30*time.Second)
 
 	// enable the onRamp on OffRamp
 	enableRampTx, err := state.Chains[chain2].OffRamp.ApplySourceChainConfigUpdates(e.Env.Chains[chain2].DeployerKey, []offramp.OffRampSourceChainConfigArgs{
@@ -180,12 +183,12 @@ func TestAddLane(t *testing.T) {
 	require.True(t, srcCfg.IsEnabled)
 
 	// we need the replay here otherwise plugin is not able to locate the message
-	ReplayLogs(t, e.Env.Offchain, replayBlocks)
+	testhelpers.ReplayLogs(t, e.Env.Offchain, replayBlocks)
 	time.Sleep(30 * time.Second)
 	// Now that the onRamp is enabled, the request should be processed
 	require.NoError(t,
 		commonutils.JustError(
-			ConfirmExecWithSeqNrs(
+			testhelpers.ConfirmExecWithSeqNrs(
 				t,
 				e.Env.Chains[chain1],
 				e.Env.Chains[chain2],
@@ -196,3 +199,67 @@ func TestAddLane(t *testing.T) {
 		),
 	)
 }
+
+func TestUnpackFee(t *testing.T) {
+	execFee, daFee := changeset.UnpackFee(changeset.ToPackedFee(big.NewInt(8e14), big.NewInt(3e16)))
+	require.Equal(t, big.NewInt(8e14), execFee)
+	require.Equal(t, big.NewInt(3e16), daFee)
+
+	execFee, daFee = changeset.UnpackFee(changeset.DefaultInitialPrices.GasPrice)
+	require.Equal(t, big.NewInt(8e14), execFee)
+	require.Zero(t, daFee.Sign())
+}
+
+func TestDataAvailabilityCost(t *testing.T) {
+	cfg := testhelpers.L2FeeQuoterDestChainConfig()
+	// ((480 + 5 + 2*288 + 32) * 16 + 188) * 2 * 6840 * 1e14
+	expected, ok := new(big.Int).SetString("24180768000000000000000", 10)
+	require.True(t, ok)
+	require.Equal(t, expected, testhelpers.DataAvailabilityCost(cfg, big.NewInt(2), 5, 2, 32))
+
+	cfg.DestDataAvailabilityMultiplierBps = 0
+	require.Zero(t, testhelpers.DataAvailabilityCost(cfg, big.NewInt(2), 5, 2, 32).Sign())
+}
+
+// TestAddLaneWithDataAvailabilityFee covers an L2 destination charging a data availability fee,
+// both in the quoted fee and in the fee paid by the message executed on the destination.
+func TestAddLaneWithDataAvailabilityFee(t *testing.T) {
+	e := testhelpers.NewMemoryEnvironmentWithJobsAndContracts(t, logger.TestLogger(t), 2, 4, nil)
+	state, err := changeset.LoadOnchainState(e.Env)
+	require.NoError(t, err)
+
+	selectors := e.Env.AllChainSelectors()
+	chain1, chain2 := selectors[0], selectors[1]
+
+	prices := changeset.DefaultInitialPrices
+	prices.GasPrice = changeset.ToPackedFee(big.NewInt(8e14), big.NewInt(4e16))
+	_, err = changeset.AddLanesWithTestRouter(e.Env, changeset.AddLanesConfig{
+		LaneConfigs: []changeset.LaneConfig{
+			{
+				SourceSelector:        chain1,
+				DestSelector:          chain2,
+				InitialPricesBySource: prices,
+				FeeQuoterDestChain:    testhelpers.L2FeeQuoterDestChainConfig(),
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	latesthdr, err := e.Env.Chains[chain2].Client.HeaderByNumber(testcontext.Get(t), nil)
+	require.NoError(t, err)
+	block := latesthdr.Number.Uint64()
+	msg := router.ClientEVM2AnyMessage{
+		Receiver:     common.LeftPadBytes(state.Chains[chain2].Receiver.Address().Bytes(), 32),
+		Data:         []byte("hello from an L2"),
+		TokenAmounts: nil,
+		FeeToken:     common.HexToAddress("0x0"),
+		ExtraArgs:    testhelpers.MakeEVMExtraArgsV2(300_000, false),
+	}
+	fee := testhelpers.AssertFeeIncludesDataAvailability(t, state, chain1, chain2, true, msg)
+
+	msgSentEvent := testhelpers.TestSendRequest(t, e.Env, state, chain1, chain2, true, msg)
+	require.Equal(t, fee.Total.String(), msgSentEvent.Message.FeeTokenAmount.String())
+	testhelpers.ConfirmExecWithSeqNrsForAll(t, e.Env, state, map[changeset.SourceDestPair][]uint64{
+		{SourceChainSelector: chain1, DestChainSelector: chain2}: {msgSentEvent.SequenceNumber},
+	}, map[uint64]*uint64{chain2: &block})
+}
@@ -0,0 +1,201 @@
+package changeset
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	cciptypes "github.com/smartcontractkit/chainlink-ccip/pkg/types/ccipocr3"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset/internal"
+	commontypes "github.com/smartcontractkit/chainlink/deployment/common/types"
+	cctypes "github.com/smartcontractkit/chainlink/v2/core/capabilities/ccip/types"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/ccip_home"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/rmn_home"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/keystone/generated/capabilities_registry"
+)
+
+const (
+	// AptosCCIP is the CCIP Move package, which holds the router, onramp, offramp and fee quoter modules.
+	AptosCCIP deployment.ContractType = "AptosCCIP"
+
+	aptosOffRampModule = "offramp"
+)
+
+// AptosCCIPChainState is the CCIPChainState counterpart for Aptos chains.
+type AptosCCIPChainState struct {
+	// CCIP is the address of the resource account the CCIP package is published to, zero if it isn't published.
+	CCIP deployment.AptosAddress
+}
+
+// LoadAptosChainState loads the state of an Aptos chain from its addresses in the address book.
+func LoadAptosChainState(addresses map[string]deployment.TypeAndVersion) (AptosCCIPChainState, error) {
+	var state AptosCCIPChainState
+	for address, tv := range addresses {
+		switch tv.String() {
+		case deployment.NewTypeAndVersion(AptosCCIP, deployment.Version1_6_0_dev).String():
+			addr, err := deployment.ParseAptosAddress(address)
+			if err != nil {
+				return state, err
+			}
+			state.CCIP = addr
+		case deployment.NewTypeAndVersion(commontypes.AptosMultisigAccount, deployment.Version1_0_0).String():
+			// the multisig is loaded by commonchangeset.LoadMultisigExecutors
+			continue
+		default:
+			return state, fmt.Errorf("unknown contract %s", tv)
+		}
+	}
+	return state, nil
+}
+
+// isValidDestChainSelector returns an error unless the selector is one of an EVM or an Aptos chain.
+func isValidDestChainSelector(cs uint64) error {
+	if err := deployment.IsValidChainSelector(cs); err != nil {
+		if deployment.IsValidAptosChainSelector(cs) == nil {
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+// deployAptosChainContracts publishes the CCIP package to the chain and initializes it with the selector
+// of the chain, unless the package is already published.
+func deployAptosChainContracts(
+	e deployment.Environment,
+	chain deployment.AptosChain,
+	ab deployment.AddressBook,
+	chainState AptosCCIPChainState,
+	pkg deployment.AptosPackage,
+) error {
+	if chainState.CCIP != (deployment.AptosAddress{}) {
+		e.Logger.Infow("CCIP package already published", "chain", chain.Selector, "addr", chainState.CCIP)
+		return nil
+	}
+	pkg.Tv = deployment.NewTypeAndVersion(AptosCCIP, deployment.Version1_6_0_dev)
+	addr, err := deployment.PublishAptosPackage(e.Logger, chain, ab, pkg)
+	if err != nil {
+		return fmt.Errorf("failed to publish CCIP package: %w", err)
+	}
+	e.Logger.Infow("Published CCIP package", "chain", chain.Selector, "addr", addr)
+	if _, err := deployment.ExecuteAptosEntryFunction(chain, deployment.AptosEntryFunction{
+		Module:     addr,
+		ModuleName: "ccip",
+		Function:   "initialize",
+		Args:       [][]byte{deployment.BCSU64(chain.Selector)},
+	}); err != nil {
+		return fmt.Errorf("failed to initialize CCIP package: %w", err)
+	}
+	return nil
+}
+
+// configureAptosChain is the configureChain counterpart for Aptos chains: it adds the chain config and the DON
+// of the chain on the home chain, then sets the OCR3 configs on the chain. Token data observers, e.g. USDC,
+// aren't supported on Aptos chains yet.
+func configureAptosChain(
+	e deployment.Environment,
+	c NewChainsConfig,
+	existingState CCIPOnChainState,
+	nodes deployment.Nodes,
+	capReg *capabilities_registry.CapabilitiesRegistry,
+	ccipHome *ccip_home.CCIPHome,
+	rmnHome *rmn_home.RMNHome,
+	chain deployment.AptosChain,
+) error {
+	chainState := existingState.AptosChains[chain.Selector]
+	if chainState.CCIP == (deployment.AptosAddress{}) {
+		return fmt.Errorf("CCIP package not found for aptos chain %d", chain.Selector)
+	}
+	ocrParams, ok := c.OCRParams[chain.Selector]
+	if !ok {
+		return fmt.Errorf("OCR params not found for chain %d", chain.Selector)
+	}
+	ocrParams = ocrParams.withPriceReporting()
+	e.ReportStep(chain.Selector, 1, 2, "add chain config")
+	if _, err := AddChainConfigWithPriceReporting(
+		e.Logger,
+		e.Chains[c.HomeChainSel],
+		ccipHome,
+		chain.Selector,
+		nodes.NonBootstraps().PeerIDs(),
+		ocrParams.PriceReporting,
+	); err != nil {
+		return err
+	}
+	ocrParams.CommitOffChainConfig.PriceFeedChainSelector = cciptypes.ChainSelector(c.FeedChainSel)
+	e.ReportStep(chain.Selector, 2, 2, "add DON")
+	return addAptosDON(e, c.OCRSecrets, capReg, ccipHome, rmnHome, chainState, chain, e.Chains[c.HomeChainSel],
+		nodes.NonBootstraps(), ocrParams)
+}
+
+// addAptosDON is addDON for Aptos destination chains, it sets the OCR3 configs of the DON on the offramp module
+// of the CCIP package.
+func addAptosDON(
+	e deployment.Environment,
+	ocrSecrets deployment.OCRSecrets,
+	capReg *capabilities_registry.CapabilitiesRegistry,
+	ccipHome *ccip_home.CCIPHome,
+	rmnHome *rmn_home.RMNHome,
+	chainState AptosCCIPChainState,
+	dest deployment.AptosChain,
+	home deployment.Chain,
+	nodes deployment.Nodes,
+	ocrParams CCIPOCRParams,
+) error {
+	ocrConfigs, err := internal.BuildOCR3ConfigForOffRampAddress(ocrSecrets, chainState.CCIP[:], dest.Selector, nodes,
+		rmnHome.Address(), ocrParams.OCRParameters, ocrParams.CommitOffChainConfig, ocrParams.ExecuteOffChainConfig)
+	if err != nil {
+		return err
+	}
+	if err := CreateDON(e.Logger, capReg, ccipHome, ocrConfigs, home, dest.Selector, nodes); err != nil {
+		return err
+	}
+	don, err := internal.LatestCCIPDON(capReg)
+	if err != nil {
+		return err
+	}
+	e.Logger.Infow("Added DON", "donID", don.Id)
+
+	for _, pluginType := range []cctypes.PluginType{cctypes.PluginTypeCCIPCommit, cctypes.PluginTypeCCIPExec} {
+		configs, err := ccipHome.GetAllConfigs(&bind.CallOpts{Context: context.Background()}, don.Id, uint8(pluginType))
+		if err != nil {
+			return err
+		}
+		active := configs.ActiveConfig
+		if active.ConfigDigest == [32]byte{} {
+			return fmt.Errorf("no active %s config for DON %d", pluginType, don.Id)
+		}
+		var signers [][]byte
+		var transmitters []deployment.AptosAddress
+		for _, node := range active.Config.Nodes {
+			signers = append(signers, node.SignerKey)
+			var transmitter deployment.AptosAddress
+			if len(node.TransmitterKey) != len(transmitter) {
+				return fmt.Errorf("transmitter %x of %s config is not an aptos account", node.TransmitterKey, pluginType)
+			}
+			copy(transmitter[:], node.TransmitterKey)
+			transmitters = append(transmitters, transmitter)
+		}
+		e.Logger.Infow("Setting OCR3 config", "chain", dest.Selector, "pluginType", pluginType,
+			"configDigest", fmt.Sprintf("%x", active.ConfigDigest))
+		if _, err := deployment.ExecuteAptosEntryFunction(dest, deployment.AptosEntryFunction{
+			Module:     chainState.CCIP,
+			ModuleName: aptosOffRampModule,
+			Function:   "set_ocr3_config",
+			Args: [][]byte{
+				deployment.BCSBytes(active.ConfigDigest[:]),
+				deployment.BCSU8(uint8(pluginType)),
+				deployment.BCSU8(active.Config.FRoleDON),
+				// only commit reports are signed, like on EVM chains
+				deployment.BCSBool(pluginType == cctypes.PluginTypeCCIPCommit),
+				deployment.BCSBytesVector(signers),
+				deployment.BCSAddressVector(transmitters),
+			},
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
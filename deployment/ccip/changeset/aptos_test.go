@@ -0,0 +1,46 @@
+package changeset
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/exp/maps"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/deployment/environment/memory"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+func TestDeployAptosChainContracts(t *testing.T) {
+	lggr := logger.TestLogger(t)
+	aptosChains := memory.NewMemoryAptosChains(t, 1)
+	e := deployment.Environment{
+		Logger:            lggr,
+		ExistingAddresses: deployment.NewMemoryAddressBook(),
+		AptosChains:       aptosChains,
+	}
+	sel := maps.Keys(aptosChains)[0]
+
+	cfg := DeployChainContractsConfig{ChainSelectors: []uint64{sel}}
+	require.ErrorContains(t, cfg.Validate(), "no CCIP package")
+
+	pkg := deployment.AptosPackage{Modules: [][]byte{{0xa1, 0x1c}}, Seed: []byte("ccip")}
+	ab := deployment.NewMemoryAddressBook()
+	require.NoError(t, deployAptosChainContractsForChains(e, ab, []uint64{sel}, pkg))
+	require.NoError(t, e.ExistingAddresses.Merge(ab))
+
+	state, err := LoadOnchainState(e)
+	require.NoError(t, err)
+	addr := state.AptosChains[sel].CCIP
+	require.Equal(t, deployment.AptosResourceAccountAddress(aptosChains[sel].DeployerAddress, pkg.Seed), addr)
+	sim, ok := memory.AsSimAptosClient(aptosChains[sel])
+	require.True(t, ok)
+	require.True(t, sim.Published(addr))
+	calls := sim.Calls(addr, "ccip", "initialize")
+	require.Len(t, calls, 1)
+	require.Equal(t, [][]byte{deployment.BCSU64(sel)}, calls[0].Args)
+
+	// re-running is a no-op
+	require.NoError(t, deployAptosChainContractsForChains(e, deployment.NewMemoryAddressBook(), []uint64{sel}, pkg))
+	require.Len(t, sim.Calls(addr, "ccip", "initialize"), 1)
+}
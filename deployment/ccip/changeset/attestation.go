@@ -0,0 +1,148 @@
+package changeset
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/smartcontractkit/chainlink-ccip/pluginconfig"
+	"github.com/smartcontractkit/chainlink-common/pkg/config"
+
+	"github.com/smartcontractkit/chainlink/v2/core/store/models"
+)
+
+var _ AttestationProvider = usdcAttestationProvider{}
+
+// AttestationProvider is an attestation-gated token, such as USDC with CCTP: releasing the tokens on the
+// destination requires an attestation fetched offchain by a token data observer of the exec plugin.
+// Every provider is configured as a token data observer of the exec plugin of the chains it's enabled on.
+type AttestationProvider interface {
+	// ObserverType is the token data observer type of the provider, e.g. pluginconfig.USDCCCTPHandlerType.
+	ObserverType() string
+	// EnabledChainMap returns the chains whose exec plugin observes the token data of the provider.
+	EnabledChainMap() map[uint64]bool
+	Validate() error
+	// ToTokenDataObserverConfig returns the exec plugin config of the token data observers of the provider.
+	ToTokenDataObserverConfig() []pluginconfig.TokenDataObserverConfig
+	// AttestationAPI returns the API the token data observer of the provider polls for attestations.
+	AttestationAPI() AttestationAPIConfig
+	// MessageEncoding returns how the token data observer of the provider requests the attestation of a message.
+	MessageEncoding() AttestationMessageEncoding
+}
+
+// AttestationMessageEncoding is how a message is identified in the requests of its attestation.
+type AttestationMessageEncoding string
+
+const (
+	// MessageHashInPath requests the attestation of the hex keccak256 hash of the message with
+	// GET <API>/v1/attestations/0x<hash>, as Circle's CCTP API does.
+	MessageHashInPath AttestationMessageEncoding = "hash-in-path"
+	// MessageHashInBody requests the attestation of the hex keccak256 hash of the message with
+	// POST <API> and the JSON body {"messageHashes": ["0x<hash>"]}.
+	MessageHashInBody AttestationMessageEncoding = "hash-in-body"
+)
+
+// AttestationAPIConfig is the offchain API the token data observer polls for attestations.
+type AttestationAPIConfig struct {
+	API         string
+	APITimeout  *config.Duration
+	APIInterval *config.Duration
+	// AuthHeader is the header the AuthToken is sent in, e.g. "Authorization" or "X-Api-Key". The API is
+	// unauthenticated if unset. The token is redacted when the config is formatted or encoded.
+	AuthHeader string
+	AuthToken  models.Secret
+}
+
+func (c AttestationAPIConfig) Validate() error {
+	u, err := url.Parse(c.API)
+	if err != nil {
+		return fmt.Errorf("invalid attestation API %q: %w", c.API, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("attestation API %q must be an http(s) URL", c.API)
+	}
+	if c.APITimeout != nil && c.APITimeout.Duration() <= 0 {
+		return fmt.Errorf("attestation API timeout must be positive")
+	}
+	if c.APIInterval != nil && c.APIInterval.Duration() <= 0 {
+		return fmt.Errorf("attestation API interval must be positive")
+	}
+	if (c.AuthHeader == "") != (c.AuthToken == "") {
+		return fmt.Errorf("attestation API auth header and token must be set together")
+	}
+	return nil
+}
+
+// NewAttestationRequest returns the request of the attestation of the message from the API of the provider,
+// encoded and authenticated as the token data observer of the provider does, e.g. to check the API before
+// enabling the provider.
+func NewAttestationRequest(ctx context.Context, p AttestationProvider, message []byte) (*http.Request, error) {
+	api := p.AttestationAPI()
+	hash := fmt.Sprintf("0x%x", crypto.Keccak256(message))
+	var req *http.Request
+	var err error
+	switch p.MessageEncoding() {
+	case MessageHashInPath:
+		req, err = http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(api.API, "/")+"/v1/attestations/"+hash, nil)
+	case MessageHashInBody:
+		body, merr := json.Marshal(map[string][]string{"messageHashes": {hash}})
+		if merr != nil {
+			return nil, merr
+		}
+		req, err = http.NewRequestWithContext(ctx, http.MethodPost, api.API, bytes.NewReader(body))
+		if err == nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+	default:
+		return nil, fmt.Errorf("unknown message encoding %q of the %s attestation provider", p.MessageEncoding(), p.ObserverType())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s attestation request: %w", p.ObserverType(), err)
+	}
+	if api.AuthHeader != "" {
+		req.Header.Set(api.AuthHeader, string(api.AuthToken))
+	}
+	return req, nil
+}
+
+func validateAttestationProviders(providers []AttestationProvider, chainsToDeploy map[uint64]bool) error {
+	observerTypes := make(map[uint64]map[string]bool)
+	for _, p := range providers {
+		if err := p.Validate(); err != nil {
+			return fmt.Errorf("invalid %s attestation provider: %w", p.ObserverType(), err)
+		}
+		for chain, enabled := range p.EnabledChainMap() {
+			if !enabled {
+				continue
+			}
+			if !chainsToDeploy[chain] {
+				return fmt.Errorf("%s attestation provider is enabled on chain %d which is not in chains to deploy", p.ObserverType(), chain)
+			}
+			if observerTypes[chain] == nil {
+				observerTypes[chain] = make(map[string]bool)
+			}
+			if observerTypes[chain][p.ObserverType()] {
+				return fmt.Errorf("multiple %s attestation providers are enabled on chain %d", p.ObserverType(), chain)
+			}
+			observerTypes[chain][p.ObserverType()] = true
+		}
+	}
+	return nil
+}
+
+// tokenDataObservers returns the token data observers of the providers enabled on the chain.
+func tokenDataObservers(providers []AttestationProvider, chainSel uint64) []pluginconfig.TokenDataObserverConfig {
+	var observers []pluginconfig.TokenDataObserverConfig
+	for _, p := range providers {
+		if p.EnabledChainMap()[chainSel] {
+			observers = append(observers, p.ToTokenDataObserverConfig()...)
+		}
+	}
+	return observers
+}
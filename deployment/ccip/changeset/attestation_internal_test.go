@@ -0,0 +1,110 @@
+package changeset
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-ccip/pluginconfig"
+	"github.com/smartcontractkit/chainlink-common/pkg/config"
+)
+
+// lbtcProvider is a stand-in for an attestation-gated token other than USDC.
+type lbtcProvider struct {
+	AttestationAPIConfig
+	chains []uint64
+}
+
+func (p lbtcProvider) ObserverType() string { return "lbtc" }
+
+func (p lbtcProvider) EnabledChainMap() map[uint64]bool {
+	m := make(map[uint64]bool)
+	for _, c := range p.chains {
+		m[c] = true
+	}
+	return m
+}
+
+func (p lbtcProvider) ToTokenDataObserverConfig() []pluginconfig.TokenDataObserverConfig {
+	return []pluginconfig.TokenDataObserverConfig{{Type: p.ObserverType(), Version: "1.0"}}
+}
+
+func (p lbtcProvider) AttestationAPI() AttestationAPIConfig { return p.AttestationAPIConfig }
+
+func (p lbtcProvider) MessageEncoding() AttestationMessageEncoding { return MessageHashInBody }
+
+func TestValidateAttestationProviders(t *testing.T) {
+	api := AttestationAPIConfig{
+		API:         "http://localhost:8080",
+		APITimeout:  config.MustNewDuration(time.Second),
+		APIInterval: config.MustNewDuration(500 * time.Millisecond),
+	}
+	chains := map[uint64]bool{1: true, 2: true}
+	usdc := usdcAttestationProvider{USDCConfig: USDCConfig{USDCAttestationConfig: api}, chains: []uint64{1}}
+
+	require.NoError(t, validateAttestationProviders([]AttestationProvider{
+		usdc,
+		lbtcProvider{AttestationAPIConfig: api, chains: []uint64{1, 2}},
+	}, chains))
+	// USDC isn't validated unless enabled
+	require.NoError(t, validateAttestationProviders([]AttestationProvider{usdcAttestationProvider{}}, chains))
+
+	require.ErrorContains(t, validateAttestationProviders([]AttestationProvider{
+		lbtcProvider{AttestationAPIConfig: api, chains: []uint64{3}},
+	}, chains), "not in chains to deploy")
+	require.ErrorContains(t, validateAttestationProviders([]AttestationProvider{
+		lbtcProvider{AttestationAPIConfig: api, chains: []uint64{1}},
+		lbtcProvider{AttestationAPIConfig: api, chains: []uint64{1}},
+	}, chains), "multiple lbtc attestation providers")
+	require.ErrorContains(t, validateAttestationProviders([]AttestationProvider{
+		lbtcProvider{AttestationAPIConfig: AttestationAPIConfig{API: "localhost"}, chains: []uint64{1}},
+	}, chains), "must be an http(s) URL")
+
+	authenticated := api
+	authenticated.AuthHeader, authenticated.AuthToken = "X-Api-Key", "secret"
+	require.NoError(t, validateAttestationProviders([]AttestationProvider{
+		lbtcProvider{AttestationAPIConfig: authenticated, chains: []uint64{1}},
+	}, chains))
+	noToken := authenticated
+	noToken.AuthToken = ""
+	require.ErrorContains(t, validateAttestationProviders([]AttestationProvider{
+		lbtcProvider{AttestationAPIConfig: noToken, chains: []uint64{1}},
+	}, chains), "auth header and token must be set together")
+	require.ErrorContains(t, validateAttestationProviders([]AttestationProvider{
+		usdcAttestationProvider{USDCConfig: USDCConfig{USDCAttestationConfig: authenticated}, chains: []uint64{1}},
+	}, chains), "doesn't support an authenticated attestation API")
+
+	observers := tokenDataObservers([]AttestationProvider{
+		usdc,
+		lbtcProvider{AttestationAPIConfig: api, chains: []uint64{2}},
+	}, 2)
+	require.Len(t, observers, 1)
+	require.Equal(t, "lbtc", observers[0].Type)
+}
+
+func TestNewAttestationRequest(t *testing.T) {
+	message := []byte("message")
+	hash := fmt.Sprintf("0x%x", crypto.Keccak256(message))
+	usdc := usdcAttestationProvider{USDCConfig: USDCConfig{USDCAttestationConfig: AttestationAPIConfig{API: "https://iris-api.circle.com/"}}}
+
+	req, err := NewAttestationRequest(context.Background(), usdc, message)
+	require.NoError(t, err)
+	require.Equal(t, http.MethodGet, req.Method)
+	require.Equal(t, "https://iris-api.circle.com/v1/attestations/"+hash, req.URL.String())
+
+	lbtc := lbtcProvider{AttestationAPIConfig: AttestationAPIConfig{API: "https://lbtc.example.com/attestations", AuthHeader: "Authorization", AuthToken: "Bearer secret"}}
+	req, err = NewAttestationRequest(context.Background(), lbtc, message)
+	require.NoError(t, err)
+	require.Equal(t, http.MethodPost, req.Method)
+	require.Equal(t, "https://lbtc.example.com/attestations", req.URL.String())
+	require.Equal(t, "Bearer secret", req.Header.Get("Authorization"))
+	body, err := io.ReadAll(req.Body)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"messageHashes":["`+hash+`"]}`, string(body))
+}
@@ -0,0 +1,94 @@
+package changeset_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset/testhelpers"
+)
+
+func TestMockAttestationServer(t *testing.T) {
+	server := testhelpers.NewMockAttestationServer(func(r *http.Request) (int, []byte) {
+		return http.StatusOK, []byte(`{"status":"approved","message":"` + r.URL.Path[1:] + `"}`)
+	}, map[string]string{"X-Api-Key": "secret"})
+	t.Cleanup(server.Close)
+
+	get := func(apiKey string) (int, string) {
+		req, err := http.NewRequestWithContext(testhelpers.Context(t), http.MethodGet, server.URL+"/0x01", nil)
+		require.NoError(t, err)
+		if apiKey != "" {
+			req.Header.Set("X-Api-Key", apiKey)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return resp.StatusCode, string(body)
+	}
+
+	status, _ := get("")
+	require.Equal(t, http.StatusUnauthorized, status)
+	status, _ = get("wrong")
+	require.Equal(t, http.StatusUnauthorized, status)
+	status, body := get("secret")
+	require.Equal(t, http.StatusOK, status)
+	require.JSONEq(t, `{"status":"approved","message":"0x01"}`, body)
+}
+
+func TestNewAttestationRequest_MockAttestationServer(t *testing.T) {
+	server := testhelpers.NewMockAttestationServer(testhelpers.MockAttestationResponse, map[string]string{"X-Api-Key": "secret"})
+	t.Cleanup(server.Close)
+
+	message := []byte("message")
+	hash := fmt.Sprintf("0x%x", crypto.Keccak256(message))
+	provider := testhelpers.MockAttestationProvider{
+		AttestationAPIConfig: changeset.AttestationAPIConfig{API: server.URL, AuthHeader: "X-Api-Key", AuthToken: "secret"},
+		Chains:               []uint64{1},
+	}
+	require.NoError(t, provider.Validate())
+
+	attest := func(p changeset.AttestationProvider) (int, []byte) {
+		req, err := changeset.NewAttestationRequest(testhelpers.Context(t), p, message)
+		require.NoError(t, err)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return resp.StatusCode, body
+	}
+
+	status, body := attest(provider)
+	require.Equal(t, http.StatusOK, status)
+	var resp struct {
+		Attestations []struct {
+			MessageHash string `json:"messageHash"`
+			Status      string `json:"status"`
+			Attestation string `json:"attestation"`
+		} `json:"attestations"`
+	}
+	require.NoError(t, json.Unmarshal(body, &resp))
+	require.Len(t, resp.Attestations, 1)
+	require.Equal(t, hash, resp.Attestations[0].MessageHash)
+	require.Equal(t, "complete", resp.Attestations[0].Status)
+
+	unauthenticated := provider
+	unauthenticated.AuthHeader, unauthenticated.AuthToken = "", ""
+	status, _ = attest(unauthenticated)
+	require.Equal(t, http.StatusUnauthorized, status)
+
+	// The token isn't leaked when the config is printed or encoded.
+	require.NotContains(t, fmt.Sprintf("%+v", provider.AttestationAPI()), "secret")
+	encoded, err := json.Marshal(provider.AttestationAPI())
+	require.NoError(t, err)
+	require.NotContains(t, string(encoded), "secret")
+	require.Contains(t, string(encoded), "X-Api-Key")
+}
@@ -0,0 +1,161 @@
+package changeset
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/proposal/timelock"
+	nodev1 "github.com/smartcontractkit/chainlink-protos/job-distributor/v1/node"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+)
+
+// MinBootstrappersPerChain is the minimum number of bootstrap nodes every chain must retain,
+// so that losing a single bootstrapper does not partition the CCIP DONs.
+const MinBootstrappersPerChain = 2
+
+var _ deployment.ChangeSet[UpdateBootstrapNodesConfig] = UpdateBootstrapNodesChangeset
+
+type UpdateBootstrapNodesConfig struct {
+	// NodesToAdd are the JD IDs of bootstrap nodes to start using as CCIP bootstrappers.
+	NodesToAdd []string
+	// NodesToRemove are the JD IDs of the current bootstrappers to stop using.
+	NodesToRemove []string
+	// JobSpecOverrides are the overrides the current job specs of the nodes were created with, see
+	// CCIPCapabilityJobspecWithOverrides, which the updated job specs keep.
+	JobSpecOverrides JobSpecOverrides
+}
+
+func (c UpdateBootstrapNodesConfig) Validate(e deployment.Environment) error {
+	if len(c.NodesToAdd) == 0 && len(c.NodesToRemove) == 0 {
+		return fmt.Errorf("no bootstrap nodes to add or remove")
+	}
+	existing := make(map[string]struct{})
+	for _, id := range e.NodeIDs {
+		existing[id] = struct{}{}
+	}
+	toAdd := make(map[string]struct{})
+	for _, id := range c.NodesToAdd {
+		if _, ok := toAdd[id]; ok {
+			return fmt.Errorf("node %s is listed twice in nodes to add", id)
+		}
+		toAdd[id] = struct{}{}
+	}
+	for _, id := range c.NodesToRemove {
+		if _, ok := toAdd[id]; ok {
+			return fmt.Errorf("node %s is listed in both nodes to add and nodes to remove", id)
+		}
+		if _, ok := existing[id]; !ok {
+			return fmt.Errorf("node %s to remove is not part of the environment", id)
+		}
+	}
+	return nil
+}
+
+// UpdateBootstrapNodesChangeset adds and removes CCIP bootstrap nodes.
+// It returns bootstrap job specs for the added nodes, along with updated CCIP job specs for every
+// oracle node of the environment which point at the new set of bootstrap multiaddrs.
+// The changeset fails if any chain would be left with less than MinBootstrappersPerChain bootstrappers.
+// Note that the jobs of removed bootstrappers are not deleted and need to be cleaned up by their operators.
+func UpdateBootstrapNodesChangeset(e deployment.Environment, cfg UpdateBootstrapNodesConfig) (deployment.ChangesetOutput, error) {
+	if err := cfg.Validate(e); err != nil {
+		return deployment.ChangesetOutput{}, errors.Wrapf(err, "invalid config")
+	}
+	nodes, err := deployment.NodeInfo(e.NodeIDs, e.Offchain)
+	if err != nil {
+		return deployment.ChangesetOutput{}, err
+	}
+	added, err := deployment.NodeInfo(cfg.NodesToAdd, e.Offchain)
+	if err != nil {
+		return deployment.ChangesetOutput{}, err
+	}
+	if len(added) != len(cfg.NodesToAdd) {
+		return deployment.ChangesetOutput{}, fmt.Errorf("found %d of %d nodes to add", len(added), len(cfg.NodesToAdd))
+	}
+	toRemove := make(map[string]struct{})
+	for _, id := range cfg.NodesToRemove {
+		toRemove[id] = struct{}{}
+	}
+
+	var bootstraps deployment.Nodes
+	for _, node := range nodes {
+		if !node.IsBootstrap {
+			continue
+		}
+		if _, ok := toRemove[node.NodeID]; ok {
+			continue
+		}
+		bootstraps = append(bootstraps, node)
+	}
+	for _, node := range nodes {
+		if _, ok := toRemove[node.NodeID]; ok && !node.IsBootstrap {
+			return deployment.ChangesetOutput{}, fmt.Errorf("node %s to remove is not a bootstrap node", node.NodeID)
+		}
+	}
+	for _, node := range added {
+		if !node.IsBootstrap {
+			return deployment.ChangesetOutput{}, fmt.Errorf("node %s to add is not a bootstrap node", node.NodeID)
+		}
+		bootstraps = append(bootstraps, node)
+	}
+	if err := validateBootstrappersPerChain(e, bootstraps); err != nil {
+		return deployment.ChangesetOutput{}, err
+	}
+	if err := cfg.JobSpecOverrides.Validate(append(append(deployment.Nodes{}, nodes...), added...)); err != nil {
+		return deployment.ChangesetOutput{}, fmt.Errorf("invalid job spec overrides: %w", err)
+	}
+
+	locators := bootstraps.BootstrapLocators()
+	jobSpecs := make(map[string][]string)
+	for _, node := range added {
+		spec, err := newCCIPBootstrapSpec(node, cfg.JobSpecOverrides.forNode(node))
+		if err != nil {
+			return deployment.ChangesetOutput{}, err
+		}
+		jobSpecs[node.NodeID] = append(jobSpecs[node.NodeID], spec)
+	}
+	for _, node := range nodes.NonBootstraps() {
+		spec, err := newCCIPOracleSpec(node, locators, node.FirstOCRKeybundle().KeyBundleID, cfg.JobSpecOverrides.forNode(node))
+		if err != nil {
+			return deployment.ChangesetOutput{}, err
+		}
+		jobSpecs[node.NodeID] = append(jobSpecs[node.NodeID], spec)
+	}
+	return deployment.ChangesetOutput{
+		Proposals:   []timelock.MCMSWithTimelockProposal{},
+		AddressBook: nil,
+		JobSpecs:    jobSpecs,
+	}, nil
+}
+
+// validateBootstrappersPerChain checks that every chain of the environment is served by at least
+// MinBootstrappersPerChain of the given bootstrap nodes.
+func validateBootstrappersPerChain(e deployment.Environment, bootstraps deployment.Nodes) error {
+	counts := make(map[string]int)
+	for _, node := range bootstraps {
+		resp, err := e.Offchain.ListNodeChainConfigs(context.Background(), &nodev1.ListNodeChainConfigsRequest{
+			Filter: &nodev1.ListNodeChainConfigsRequest_Filter{
+				NodeIds: []string{node.NodeID},
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list chain configs for node %s: %w", node.NodeID, err)
+		}
+		for _, chainConfig := range resp.ChainConfigs {
+			if chainConfig.Ocr2Config != nil && chainConfig.Ocr2Config.IsBootstrap {
+				counts[chainConfig.Chain.Id]++
+			}
+		}
+	}
+	for _, sel := range e.AllChainSelectors() {
+		chainID, err := deployment.ChainID(sel)
+		if err != nil {
+			return err
+		}
+		if n := counts[chainID]; n < MinBootstrappersPerChain {
+			return fmt.Errorf("chain %d would have %d bootstrappers, need at least %d", sel, n, MinBootstrappersPerChain)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package changeset
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/deployment/environment/memory"
+	ccip "github.com/smartcontractkit/chainlink/v2/core/capabilities/ccip/validate"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+func TestUpdateBootstrapNodesChangeset(t *testing.T) {
+	lggr := logger.TestLogger(t)
+	e := memory.NewMemoryEnvironment(t, lggr, zapcore.InfoLevel, memory.MemoryEnvironmentConfig{
+		Chains:     2,
+		Nodes:      4,
+		Bootstraps: 3,
+	})
+	nodes, err := deployment.NodeInfo(e.NodeIDs, e.Offchain)
+	require.NoError(t, err)
+	var bootstrapIDs, oracleIDs []string
+	for _, node := range nodes {
+		if node.IsBootstrap {
+			bootstrapIDs = append(bootstrapIDs, node.NodeID)
+		} else {
+			oracleIDs = append(oracleIDs, node.NodeID)
+		}
+	}
+	require.Len(t, bootstrapIDs, 3)
+
+	t.Run("add bootstrap node", func(t *testing.T) {
+		// Start from an environment which only knows about the first two bootstrappers.
+		env := e
+		env.NodeIDs = append(append([]string{}, oracleIDs...), bootstrapIDs[:2]...)
+		output, err := UpdateBootstrapNodesChangeset(env, UpdateBootstrapNodesConfig{
+			NodesToAdd: bootstrapIDs[2:],
+		})
+		require.NoError(t, err)
+		require.Len(t, output.JobSpecs, len(oracleIDs)+1)
+		for nodeID, jobs := range output.JobSpecs {
+			require.Len(t, jobs, 1)
+			jb, err := ccip.ValidatedCCIPSpec(jobs[0])
+			require.NoError(t, err)
+			if nodeID == bootstrapIDs[2] {
+				require.Empty(t, jb.CCIPSpec.P2PV2Bootstrappers)
+				continue
+			}
+			require.Len(t, jb.CCIPSpec.P2PV2Bootstrappers, 3)
+		}
+	})
+
+	t.Run("keep job spec overrides", func(t *testing.T) {
+		overrides := JobSpecOverrides{
+			Defaults: JobSpecOverride{PluginConfig: map[string]any{"tokenDataObservers": "default"}},
+			Nodes: map[string]JobSpecOverride{
+				oracleIDs[0]:    {RelayConfigs: map[string]any{"evm": map[string]any{"endpoint": "custom"}}},
+				bootstrapIDs[2]: {RelayConfigs: map[string]any{"evm": map[string]any{"endpoint": "bootstrap"}}},
+			},
+		}
+		env := e
+		env.NodeIDs = append(append([]string{}, oracleIDs...), bootstrapIDs[:2]...)
+		output, err := UpdateBootstrapNodesChangeset(env, UpdateBootstrapNodesConfig{
+			NodesToAdd:       bootstrapIDs[2:],
+			JobSpecOverrides: overrides,
+		})
+		require.NoError(t, err)
+		for nodeID, jobs := range output.JobSpecs {
+			jb, err := ccip.ValidatedCCIPSpec(jobs[0])
+			require.NoError(t, err)
+			require.Equal(t, "default", jb.CCIPSpec.PluginConfig["tokenDataObservers"])
+			if override, ok := overrides.Nodes[nodeID]; ok {
+				require.Equal(t, override.RelayConfigs["evm"], jb.CCIPSpec.RelayConfigs["evm"])
+			} else {
+				require.Nil(t, jb.CCIPSpec.RelayConfigs["evm"])
+			}
+		}
+
+		_, err = UpdateBootstrapNodesChangeset(e, UpdateBootstrapNodesConfig{
+			NodesToRemove:    bootstrapIDs[:1],
+			JobSpecOverrides: JobSpecOverrides{Nodes: map[string]JobSpecOverride{"unknown": {}}},
+		})
+		require.ErrorContains(t, err, "job spec override for unknown node unknown")
+	})
+
+	t.Run("remove bootstrap node", func(t *testing.T) {
+		output, err := UpdateBootstrapNodesChangeset(e, UpdateBootstrapNodesConfig{
+			NodesToRemove: bootstrapIDs[:1],
+		})
+		require.NoError(t, err)
+		require.Len(t, output.JobSpecs, len(oracleIDs))
+		for _, jobs := range output.JobSpecs {
+			jb, err := ccip.ValidatedCCIPSpec(jobs[0])
+			require.NoError(t, err)
+			require.Len(t, jb.CCIPSpec.P2PV2Bootstrappers, 2)
+		}
+	})
+
+	t.Run("too few bootstrappers", func(t *testing.T) {
+		_, err := UpdateBootstrapNodesChangeset(e, UpdateBootstrapNodesConfig{
+			NodesToRemove: bootstrapIDs[:2],
+		})
+		require.ErrorContains(t, err, "need at least 2")
+	})
+
+	t.Run("invalid config", func(t *testing.T) {
+		_, err := UpdateBootstrapNodesChangeset(e, UpdateBootstrapNodesConfig{})
+		require.ErrorContains(t, err, "no bootstrap nodes to add or remove")
+
+		_, err = UpdateBootstrapNodesChangeset(e, UpdateBootstrapNodesConfig{
+			NodesToRemove: oracleIDs[:1],
+		})
+		require.ErrorContains(t, err, "is not a bootstrap node")
+
+		_, err = UpdateBootstrapNodesChangeset(e, UpdateBootstrapNodesConfig{
+			NodesToAdd: oracleIDs[:1],
+		})
+		require.ErrorContains(t, err, "is not a bootstrap node")
+	})
+}
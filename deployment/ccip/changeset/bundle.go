@@ -0,0 +1,77 @@
+package changeset
+
+import (
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/gethwrappers"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	commonchangeset "github.com/smartcontractkit/chainlink/deployment/common/changeset"
+	commontypes "github.com/smartcontractkit/chainlink/deployment/common/types"
+)
+
+// BundleConfig configures the deployment of CCIP to chains of an environment whose home chain is deployed.
+type BundleConfig struct {
+	Prerequisites  DeployPrerequisiteConfig
+	MCMS           map[uint64]commontypes.MCMSWithTimelockConfig
+	ChainContracts DeployChainContractsConfig
+	// NewChains builds the config of the chains once their prerequisites are deployed, e.g. to price the tokens
+	// with the feeds deployed with them.
+	NewChains func(e deployment.Environment, state CCIPOnChainState) (NewChainsConfig, error)
+}
+
+// Bundle deploys the prerequisites, MCMS and CCIP contracts to the chains, configures them on the home chain and
+// returns the CCIP job specs of the nodes.
+func Bundle(c BundleConfig) commonchangeset.ProductBundle {
+	return commonchangeset.ProductBundle{
+		Name: "ccip",
+		Stages: []commonchangeset.BundleStage{
+			func(e deployment.Environment) ([]commonchangeset.ChangesetApplication, error) {
+				return []commonchangeset.ChangesetApplication{
+					{
+						Changeset: commonchangeset.WrapChangeSet(DeployPrerequisites),
+						Config:    c.Prerequisites,
+					},
+					{
+						Changeset: commonchangeset.WrapChangeSet(commonchangeset.DeployMCMSWithTimelock),
+						Config:    c.MCMS,
+					},
+				}, nil
+			},
+			func(e deployment.Environment) ([]commonchangeset.ChangesetApplication, error) {
+				state, err := LoadOnchainState(e)
+				if err != nil {
+					return nil, err
+				}
+				newChains, err := c.NewChains(e, state)
+				if err != nil {
+					return nil, err
+				}
+				return []commonchangeset.ChangesetApplication{
+					{
+						Changeset: commonchangeset.WrapChangeSet(DeployChainContracts),
+						Config:    c.ChainContracts,
+					},
+					{
+						Changeset: commonchangeset.WrapChangeSet(ConfigureNewChains),
+						Config:    newChains,
+					},
+					{
+						Changeset: commonchangeset.WrapChangeSet(CCIPCapabilityJobspec),
+					},
+				}, nil
+			},
+		},
+		Timelocks: func(e deployment.Environment) (map[uint64]*gethwrappers.RBACTimelock, error) {
+			state, err := LoadOnchainState(e)
+			if err != nil {
+				return nil, err
+			}
+			timelocks := make(map[uint64]*gethwrappers.RBACTimelock)
+			for sel, chainState := range state.Chains {
+				if chainState.Timelock != nil {
+					timelocks[sel] = chainState.Timelock
+				}
+			}
+			return timelocks, nil
+		},
+	}
+}
@@ -0,0 +1,54 @@
+package changeset_test
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-testing-framework/lib/utils/testcontext"
+
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset/testhelpers"
+	"github.com/smartcontractkit/chainlink/deployment/environment/memory"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/router"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+// TestByzantineNodes checks that messages are committed and executed by DONs of 7 nodes, F = 2,
+// with a silent node and a node signing wrong reports and double transmitting.
+func TestByzantineNodes(t *testing.T) {
+	e := testhelpers.NewMemoryEnvironmentWithJobsAndContracts(t, logger.TestLogger(t), 2, 7, &testhelpers.TestConfigs{
+		Byzantine: map[int]memory.ByzantineBehaviors{
+			0: {memory.ByzantineSilent},
+			1: {memory.ByzantineWrongSignatures, memory.ByzantineDoubleTransmit},
+		},
+	})
+	state, err := changeset.LoadOnchainState(e.Env)
+	require.NoError(t, err)
+	testhelpers.ReplayLogs(t, e.Env.Offchain, e.ReplayBlocks)
+	require.NoError(t, testhelpers.AddLanesForAll(e.Env, state))
+
+	startBlocks := make(map[uint64]*uint64)
+	expectedSeqNum := make(map[changeset.SourceDestPair]uint64)
+	expectedSeqNums := make(map[changeset.SourceDestPair][]uint64)
+	for _, src := range e.Env.AllChainSelectors() {
+		for _, dest := range e.Env.AllChainSelectorsExcluding([]uint64{src}) {
+			latesthdr, err := e.Env.Chains[dest].Client.HeaderByNumber(testcontext.Get(t), nil)
+			require.NoError(t, err)
+			block := latesthdr.Number.Uint64()
+			startBlocks[dest] = &block
+			msgSentEvent := testhelpers.TestSendRequest(t, e.Env, state, src, dest, false, router.ClientEVM2AnyMessage{
+				Receiver:  common.LeftPadBytes(state.Chains[dest].Receiver.Address().Bytes(), 32),
+				Data:      []byte("hello"),
+				FeeToken:  common.HexToAddress("0x0"),
+				ExtraArgs: nil,
+			})
+			pair := changeset.SourceDestPair{SourceChainSelector: src, DestChainSelector: dest}
+			expectedSeqNum[pair] = msgSentEvent.SequenceNumber
+			expectedSeqNums[pair] = []uint64{msgSentEvent.SequenceNumber}
+		}
+	}
+	testhelpers.ConfirmCommitForAllWithExpectedSeqNums(t, e.Env, state, expectedSeqNum, startBlocks)
+	testhelpers.ConfirmExecWithSeqNrsForAll(t, e.Env, state, expectedSeqNums, startBlocks)
+}
@@ -0,0 +1,17 @@
+package changeset
+
+import (
+	"github.com/invopop/jsonschema"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+)
+
+// ConfigSchemas are the JSON Schemas of the changeset configs operators author by hand, keyed by config name.
+// Configs in JSON or YAML are loaded with deployment.LoadConfig, e.g. deployment.LoadConfig[NewChainsConfig](data).
+func ConfigSchemas() map[string]*jsonschema.Schema {
+	return map[string]*jsonschema.Schema{
+		"NewChainsConfig":          deployment.ConfigSchema[NewChainsConfig](),
+		"DeployPrerequisiteConfig": deployment.ConfigSchema[DeployPrerequisiteConfig](),
+		"USDCConfig":               deployment.ConfigSchema[USDCConfig](),
+	}
+}
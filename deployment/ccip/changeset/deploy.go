@@ -1,13 +1,16 @@
 package changeset
 
 import (
+	"context"
 	"fmt"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/smartcontractkit/chainlink-ccip/pkg/reader"
 	cciptypes "github.com/smartcontractkit/chainlink-ccip/pkg/types/ccipocr3"
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
 	"golang.org/x/sync/errgroup"
 
 	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset/internal"
@@ -17,6 +20,8 @@ import (
 	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/fee_quoter"
 	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/maybe_revert_message_receiver"
 	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/mock_rmn_contract"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/mock_usdc_token_messenger"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/mock_usdc_token_transmitter"
 	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/nonce_manager"
 	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/offramp"
 	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/onramp"
@@ -26,6 +31,7 @@ import (
 	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/rmn_remote"
 	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/router"
 	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/token_admin_registry"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/usdc_token_pool"
 	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/weth9"
 	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/shared/generated/burn_mint_erc677"
 	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/shared/generated/multicall3"
@@ -51,27 +57,33 @@ var (
 	CapabilitiesRegistry deployment.ContractType = "CapabilitiesRegistry"
 	PriceFeed            deployment.ContractType = "PriceFeed"
 	// Note test router maps to a regular router contract.
-	TestRouter          deployment.ContractType = "TestRouter"
-	Multicall3          deployment.ContractType = "Multicall3"
-	CCIPReceiver        deployment.ContractType = "CCIPReceiver"
-	BurnMintToken       deployment.ContractType = "BurnMintToken"
-	BurnMintTokenPool   deployment.ContractType = "BurnMintTokenPool"
-	USDCToken           deployment.ContractType = "USDCToken"
-	USDCMockTransmitter deployment.ContractType = "USDCMockTransmitter"
-	USDCTokenMessenger  deployment.ContractType = "USDCTokenMessenger"
-	USDCTokenPool       deployment.ContractType = "USDCTokenPool"
+	TestRouter           deployment.ContractType = "TestRouter"
+	Multicall3           deployment.ContractType = "Multicall3"
+	CCIPReceiver         deployment.ContractType = "CCIPReceiver"
+	BurnMintToken        deployment.ContractType = "BurnMintToken"
+	BurnMintTokenPool    deployment.ContractType = "BurnMintTokenPool"
+	LockReleaseTokenPool deployment.ContractType = "LockReleaseTokenPool"
+	USDCToken            deployment.ContractType = "USDCToken"
+	USDCMockTransmitter  deployment.ContractType = "USDCMockTransmitter"
+	USDCTokenMessenger   deployment.ContractType = "USDCTokenMessenger"
+	USDCTokenPool        deployment.ContractType = "USDCTokenPool"
+	// CCIP 1.5 lane contracts
+	PriceRegistry  deployment.ContractType = "PriceRegistry"
+	EVM2EVMOnRamp  deployment.ContractType = "EVM2EVMOnRamp"
+	EVM2EVMOffRamp deployment.ContractType = "EVM2EVMOffRamp"
 )
 
 type DeployPrerequisiteContractsOpts struct {
-	USDCEnabledChains []uint64
 	Multicall3Enabled bool
+	Features          ChainFeatureFlags
 }
 
 type PrerequisiteOpt func(o *DeployPrerequisiteContractsOpts)
 
-func WithUSDCChains(chains []uint64) PrerequisiteOpt {
+// WithChainFeatures deploys the prerequisites of the features of the chains, e.g. the USDC contracts.
+func WithChainFeatures(features ChainFeatureFlags) PrerequisiteOpt {
 	return func(o *DeployPrerequisiteContractsOpts) {
-		o.USDCEnabledChains = chains
+		o.Features = features
 	}
 }
 
@@ -111,13 +123,7 @@ func deployPrerequisiteContracts(e deployment.Environment, ab deployment.Address
 			opt(deployOpts)
 		}
 	}
-	var isUSDC bool
-	for _, sel := range deployOpts.USDCEnabledChains {
-		if sel == chain.Selector {
-			isUSDC = true
-			break
-		}
-	}
+	isUSDC := deployOpts.Features[chain.Selector].USDCEnabled()
 	lggr := e.Logger
 	chainState, chainExists := state.Chains[chain.Selector]
 	var weth9Contract *weth9.WETH9
@@ -372,6 +378,13 @@ func configureChain(
 	}
 
 	for _, chainSel := range c.ChainsToDeploy {
+		if aptosChain, ok := e.AptosChains[chainSel]; ok {
+			if err := configureAptosChain(e, c, existingState, nodes, capReg, ccipHome, rmnHome, aptosChain); err != nil {
+				e.Logger.Errorw("Failed to configure aptos chain", "chain", chainSel, "err", err)
+				return err
+			}
+			continue
+		}
 		chain, _ := e.Chains[chainSel]
 		chainState, ok := existingState.Chains[chain.Selector]
 		if !ok {
@@ -384,22 +397,35 @@ func configureChain(
 		if chainState.OffRamp == nil {
 			return fmt.Errorf("off ramp not found for chain %d", chain.Selector)
 		}
-		// TODO : better handling - need to scale this for more tokens
-		ocrParams.CommitOffChainConfig.TokenInfo = c.TokenConfig.GetTokenInfo(e.Logger, existingState.Chains[chainSel].LinkToken, existingState.Chains[chainSel].Weth9)
-		_, err = AddChainConfig(
+		priceCfg, err := c.priceSource().TokenPriceConfig(context.Background(), e.Logger, existingState, chain.Selector)
+		if err != nil {
+			return fmt.Errorf("failed to get token prices of chain %d: %w", chain.Selector, err)
+		}
+		if err := setTokenPrices(e, chain, chainState.FeeQuoter, priceCfg.Prices); err != nil {
+			return err
+		}
+		ocrParams.CommitOffChainConfig.TokenInfo = priceCfg.TokenInfo
+		ocrParams = ocrParams.withPriceReporting()
+		e.ReportStep(chain.Selector, 1, 2, "add chain config")
+		_, err = AddChainConfigWithPriceReporting(
 			e.Logger,
 			e.Chains[c.HomeChainSel],
 			ccipHome,
 			chain.Selector,
-			nodes.NonBootstraps().PeerIDs())
+			nodes.NonBootstraps().PeerIDs(),
+			ocrParams.PriceReporting)
 		if err != nil {
 			return err
 		}
-		if enabled, ok := c.USDCConfig.EnabledChainMap()[chainSel]; ok && enabled {
-			ocrParams.ExecuteOffChainConfig.TokenDataObservers = append(ocrParams.ExecuteOffChainConfig.TokenDataObservers, c.USDCConfig.ToTokenDataObserverConfig()...)
+		ocrParams.ExecuteOffChainConfig.TokenDataObservers = append(ocrParams.ExecuteOffChainConfig.TokenDataObservers,
+			tokenDataObservers(c.attestationProviders(), chainSel)...)
+		ocrParams.CommitOffChainConfig.PriceFeedChainSelector = cciptypes.ChainSelector(priceCfg.FeedChainSelector)
+		if priceCfg.FeedChainSelector == 0 {
+			// the commit plugin reports no token price, any chain the nodes read does
+			ocrParams.CommitOffChainConfig.PriceFeedChainSelector = cciptypes.ChainSelector(c.HomeChainSel)
 		}
-		ocrParams.CommitOffChainConfig.PriceFeedChainSelector = cciptypes.ChainSelector(c.FeedChainSel)
 		// For each chain, we create a DON on the home chain (2 OCR instances)
+		e.ReportStep(chain.Selector, 2, 2, "add DON")
 		if err := addDON(
 			e.Logger,
 			c.OCRSecrets,
@@ -433,7 +459,8 @@ func deployCCIPContracts(
 	e deployment.Environment,
 	ab deployment.AddressBook,
 	c NewChainsConfig) error {
-	err := deployChainContractsForChains(e, ab, c.HomeChainSel, c.ChainsToDeploy)
+	c = c.withFeatures()
+	err := deployChainContractsForChains(e, ab, c.HomeChainSel, c.ChainsToDeploy, c.Features)
 	if err != nil {
 		e.Logger.Errorw("Failed to deploy chain contracts", "err", err)
 		return err
@@ -456,7 +483,8 @@ func deployChainContractsForChains(
 	e deployment.Environment,
 	ab deployment.AddressBook,
 	homeChainSel uint64,
-	chainsToDeploy []uint64) error {
+	chainsToDeploy []uint64,
+	features ChainFeatureFlags) error {
 	existingState, err := LoadOnchainState(e)
 	if err != nil {
 		e.Logger.Errorw("Failed to load existing onchain state", "err")
@@ -508,7 +536,7 @@ func deployChainContractsForChains(
 		}
 		deployGrp.Go(
 			func() error {
-				err := deployChainContracts(e, chain, ab, rmnHome)
+				err := deployChainContracts(e, chain, ab, rmnHome, features[chainSel])
 				if err != nil {
 					e.Logger.Errorw("Failed to deploy chain contracts", "chain", chainSel, "err", err)
 					return fmt.Errorf("failed to deploy chain contracts for chain %d: %w", chainSel, err)
@@ -528,6 +556,7 @@ func deployChainContracts(
 	chain deployment.Chain,
 	ab deployment.AddressBook,
 	rmnHome *rmn_home.RMNHome,
+	features ChainFeatures,
 ) error {
 	// check for existing contracts
 	state, err := LoadOnchainState(e)
@@ -617,7 +646,7 @@ func deployChainContracts(
 		},
 		F: 0, // TODO: update when we have signers
 	})
-	if _, err := deployment.ConfirmIfNoError(chain, tx, err); err != nil {
+	if _, err := deployment.ConfirmFinalizedIfNoError(e.Logger, chain, tx, err); err != nil {
 		e.Logger.Errorw("Failed to confirm RMNRemote config", "err", err)
 		return err
 	}
@@ -647,7 +676,9 @@ func deployChainContracts(
 	} else {
 		e.Logger.Infow("rmn proxy already deployed", "addr", chainState.RMNProxyNew.Address)
 	}
-	if chainState.TestRouter == nil {
+	if !features.TestRouterEnabled() {
+		e.Logger.Infow("test router disabled, skipping", "chain", chain.Selector)
+	} else if chainState.TestRouter == nil {
 		testRouterContract, err := deployment.DeployContract(e.Logger, chain, ab,
 			func(chain deployment.Chain) deployment.ContractDeploy[*router.Router] {
 				routerAddr, tx2, routerC, err2 := router.DeployRouter(
@@ -817,3 +848,124 @@ func deployChainContracts(
 	}
 	return nil
 }
+
+// DeployUSDC deploys a USDC token with its mock token messenger and message transmitter, and its USDC token pool.
+func DeployUSDC(
+	lggr logger.Logger,
+	chain deployment.Chain,
+	addresses deployment.AddressBook,
+	rmnProxy common.Address,
+	router common.Address,
+) (
+	*burn_mint_erc677.BurnMintERC677,
+	*usdc_token_pool.USDCTokenPool,
+	*mock_usdc_token_messenger.MockE2EUSDCTokenMessenger,
+	*mock_usdc_token_transmitter.MockE2EUSDCTransmitter,
+	error,
+) {
+	token, err := deployment.DeployContract(lggr, chain, addresses,
+		func(chain deployment.Chain) deployment.ContractDeploy[*burn_mint_erc677.BurnMintERC677] {
+			tokenAddress, tx, tokenContract, err2 := burn_mint_erc677.DeployBurnMintERC677(
+				chain.DeployerKey,
+				chain.Client,
+				"USDC Token",
+				"USDC",
+				uint8(18),
+				big.NewInt(0).Mul(big.NewInt(1e9), big.NewInt(1e18)),
+			)
+			return deployment.ContractDeploy[*burn_mint_erc677.BurnMintERC677]{
+				Address:  tokenAddress,
+				Contract: tokenContract,
+				Tx:       tx,
+				Tv:       deployment.NewTypeAndVersion(USDCToken, deployment.Version1_0_0),
+				Err:      err2,
+			}
+		})
+	if err != nil {
+		lggr.Errorw("Failed to deploy USDC token", "err", err)
+		return nil, nil, nil, nil, err
+	}
+
+	tx, err := token.Contract.GrantMintRole(chain.DeployerKey, chain.DeployerKey.From)
+	if err != nil {
+		lggr.Errorw("Failed to grant mint role", "token", token.Contract.Address(), "err", err)
+		return nil, nil, nil, nil, err
+	}
+	_, err = chain.Confirm(tx)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	transmitter, err := deployment.DeployContract(lggr, chain, addresses,
+		func(chain deployment.Chain) deployment.ContractDeploy[*mock_usdc_token_transmitter.MockE2EUSDCTransmitter] {
+			transmitterAddress, tx, transmitterContract, err2 := mock_usdc_token_transmitter.DeployMockE2EUSDCTransmitter(
+				chain.DeployerKey,
+				chain.Client,
+				0,
+				reader.AllAvailableDomains()[chain.Selector],
+				token.Address,
+			)
+			return deployment.ContractDeploy[*mock_usdc_token_transmitter.MockE2EUSDCTransmitter]{
+				Address:  transmitterAddress,
+				Contract: transmitterContract,
+				Tx:       tx,
+				Tv:       deployment.NewTypeAndVersion(USDCMockTransmitter, deployment.Version1_0_0),
+				Err:      err2,
+			}
+		})
+	if err != nil {
+		lggr.Errorw("Failed to deploy mock USDC transmitter", "err", err)
+		return nil, nil, nil, nil, err
+	}
+
+	lggr.Infow("deployed mock USDC transmitter", "addr", transmitter.Address)
+
+	messenger, err := deployment.DeployContract(lggr, chain, addresses,
+		func(chain deployment.Chain) deployment.ContractDeploy[*mock_usdc_token_messenger.MockE2EUSDCTokenMessenger] {
+			messengerAddress, tx, messengerContract, err2 := mock_usdc_token_messenger.DeployMockE2EUSDCTokenMessenger(
+				chain.DeployerKey,
+				chain.Client,
+				0,
+				transmitter.Address,
+			)
+			return deployment.ContractDeploy[*mock_usdc_token_messenger.MockE2EUSDCTokenMessenger]{
+				Address:  messengerAddress,
+				Contract: messengerContract,
+				Tx:       tx,
+				Tv:       deployment.NewTypeAndVersion(USDCTokenMessenger, deployment.Version1_0_0),
+				Err:      err2,
+			}
+		})
+	if err != nil {
+		lggr.Errorw("Failed to deploy USDC token messenger", "err", err)
+		return nil, nil, nil, nil, err
+	}
+	lggr.Infow("deployed mock USDC token messenger", "addr", messenger.Address)
+
+	tokenPool, err := deployment.DeployContract(lggr, chain, addresses,
+		func(chain deployment.Chain) deployment.ContractDeploy[*usdc_token_pool.USDCTokenPool] {
+			tokenPoolAddress, tx, tokenPoolContract, err2 := usdc_token_pool.DeployUSDCTokenPool(
+				chain.DeployerKey,
+				chain.Client,
+				messenger.Address,
+				token.Address,
+				[]common.Address{},
+				rmnProxy,
+				router,
+			)
+			return deployment.ContractDeploy[*usdc_token_pool.USDCTokenPool]{
+				Address:  tokenPoolAddress,
+				Contract: tokenPoolContract,
+				Tx:       tx,
+				Tv:       deployment.NewTypeAndVersion(USDCTokenPool, deployment.Version1_0_0),
+				Err:      err2,
+			}
+		})
+	if err != nil {
+		lggr.Errorw("Failed to deploy USDC token pool", "err", err)
+		return nil, nil, nil, nil, err
+	}
+	lggr.Infow("deployed USDC token pool", "addr", tokenPool.Address)
+
+	return token.Contract, tokenPool.Contract, messenger.Contract, transmitter.Contract, nil
+}
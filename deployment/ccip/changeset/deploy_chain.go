@@ -19,8 +19,22 @@ func DeployChainContracts(env deployment.Environment, c DeployChainContractsConf
 	if err := c.Validate(); err != nil {
 		return deployment.ChangesetOutput{}, fmt.Errorf("invalid DeployChainContractsConfig: %w", err)
 	}
+	if err := VerifyPinnedArtifacts(ExpectedBytecode); err != nil {
+		return deployment.ChangesetOutput{}, err
+	}
 	newAddresses := deployment.NewMemoryAddressBook()
-	err := deployChainContractsForChains(env, newAddresses, c.HomeChainSelector, c.ChainSelectors)
+	var evmChains, aptosChains []uint64
+	for _, cs := range c.ChainSelectors {
+		if _, ok := env.AptosChains[cs]; ok {
+			aptosChains = append(aptosChains, cs)
+		} else {
+			evmChains = append(evmChains, cs)
+		}
+	}
+	err := deployChainContractsForChains(env, newAddresses, c.HomeChainSelector, evmChains, c.Features)
+	if err == nil && len(aptosChains) > 0 {
+		err = deployAptosChainContractsForChains(env, newAddresses, aptosChains, *c.AptosCCIPPackage)
+	}
 	if err != nil {
 		env.Logger.Errorw("Failed to deploy CCIP contracts", "err", err, "newAddresses", newAddresses)
 		return deployment.ChangesetOutput{AddressBook: newAddresses}, deployment.MaybeDataErr(err)
@@ -35,16 +49,43 @@ func DeployChainContracts(env deployment.Environment, c DeployChainContractsConf
 type DeployChainContractsConfig struct {
 	ChainSelectors    []uint64
 	HomeChainSelector uint64
+	// AptosCCIPPackage is the compiled CCIP package published to the Aptos chains of ChainSelectors, if any.
+	AptosCCIPPackage *deployment.AptosPackage
+	// Features skip the test router of the chains, see ChainFeatureFlags.
+	Features ChainFeatureFlags
 }
 
 func (c DeployChainContractsConfig) Validate() error {
 	for _, cs := range c.ChainSelectors {
-		if err := deployment.IsValidChainSelector(cs); err != nil {
+		if err := isValidDestChainSelector(cs); err != nil {
 			return fmt.Errorf("invalid chain selector: %d - %w", cs, err)
 		}
+		if deployment.IsValidAptosChainSelector(cs) == nil && c.AptosCCIPPackage == nil {
+			return fmt.Errorf("no CCIP package to publish to aptos chain %d", cs)
+		}
 	}
 	if err := deployment.IsValidChainSelector(c.HomeChainSelector); err != nil {
 		return fmt.Errorf("invalid home chain selector: %d - %w", c.HomeChainSelector, err)
 	}
+	return c.Features.Validate(c.ChainSelectors)
+}
+
+func deployAptosChainContractsForChains(
+	e deployment.Environment,
+	ab deployment.AddressBook,
+	chainsToDeploy []uint64,
+	pkg deployment.AptosPackage,
+) error {
+	existingState, err := LoadOnchainState(e)
+	if err != nil {
+		e.Logger.Errorw("Failed to load existing onchain state", "err", err)
+		return err
+	}
+	for _, chainSel := range chainsToDeploy {
+		err := deployAptosChainContracts(e, e.AptosChains[chainSel], ab, existingState.AptosChains[chainSel], pkg)
+		if err != nil {
+			return fmt.Errorf("failed to deploy chain contracts for aptos chain %d: %w", chainSel, err)
+		}
+	}
 	return nil
 }
@@ -1,4 +1,4 @@
-package changeset
+package changeset_test
 
 import (
 	"math/big"
@@ -8,6 +8,8 @@ import (
 	"go.uber.org/zap/zapcore"
 
 	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset/testhelpers"
 	commonchangeset "github.com/smartcontractkit/chainlink/deployment/common/changeset"
 	commontypes "github.com/smartcontractkit/chainlink/deployment/common/types"
 	"github.com/smartcontractkit/chainlink/deployment/environment/memory"
@@ -27,21 +29,21 @@ func TestDeployChainContractsChangeset(t *testing.T) {
 	require.NoError(t, err)
 	p2pIds := nodes.NonBootstraps().PeerIDs()
 	// deploy home chain
-	homeChainCfg := DeployHomeChainConfig{
+	homeChainCfg := changeset.DeployHomeChainConfig{
 		HomeChainSel:     homeChainSel,
-		RMNStaticConfig:  NewTestRMNStaticConfig(),
-		RMNDynamicConfig: NewTestRMNDynamicConfig(),
-		NodeOperators:    NewTestNodeOperator(e.Chains[homeChainSel].DeployerKey.From),
+		RMNStaticConfig:  testhelpers.NewTestRMNStaticConfig(),
+		RMNDynamicConfig: testhelpers.NewTestRMNDynamicConfig(),
+		NodeOperators:    testhelpers.NewTestNodeOperator(e.Chains[homeChainSel].DeployerKey.From),
 		NodeP2PIDsPerNodeOpAdmin: map[string][][32]byte{
 			"NodeOperator": p2pIds,
 		},
 	}
-	output, err := DeployHomeChain(e, homeChainCfg)
+	output, err := changeset.DeployHomeChain(e, homeChainCfg)
 	require.NoError(t, err)
 	require.NoError(t, e.ExistingAddresses.Merge(output.AddressBook))
 
 	// deploy pre-requisites
-	prerequisites, err := DeployPrerequisites(e, DeployPrerequisiteConfig{
+	prerequisites, err := changeset.DeployPrerequisites(e, changeset.DeployPrerequisiteConfig{
 		ChainSelectors: selectors,
 	})
 	require.NoError(t, err)
@@ -62,7 +64,7 @@ func TestDeployChainContractsChangeset(t *testing.T) {
 	require.NoError(t, e.ExistingAddresses.Merge(output.AddressBook))
 
 	// deploy ccip chain contracts
-	output, err = DeployChainContracts(e, DeployChainContractsConfig{
+	output, err = changeset.DeployChainContracts(e, changeset.DeployChainContractsConfig{
 		ChainSelectors:    selectors,
 		HomeChainSelector: homeChainSel,
 	})
@@ -70,7 +72,7 @@ func TestDeployChainContractsChangeset(t *testing.T) {
 	require.NoError(t, e.ExistingAddresses.Merge(output.AddressBook))
 
 	// load onchain state
-	state, err := LoadOnchainState(e)
+	state, err := changeset.LoadOnchainState(e)
 	require.NoError(t, err)
 
 	// verify all contracts populated
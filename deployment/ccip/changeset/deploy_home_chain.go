@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"math/big"
+	"sort"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
@@ -281,19 +282,30 @@ func SetupConfigInfo(chainSelector uint64, readers [][32]byte, fChain uint8, cfg
 	}
 }
 
+// AddChainConfig adds the config of the chain to the CCIPHome with the default price reporting params,
+// see AddChainConfigWithPriceReporting.
 func AddChainConfig(
 	lggr logger.Logger,
 	h deployment.Chain,
 	ccipConfig *ccip_home.CCIPHome,
 	chainSelector uint64,
 	p2pIDs [][32]byte,
+) (ccip_home.CCIPHomeChainConfigArgs, error) {
+	return AddChainConfigWithPriceReporting(lggr, h, ccipConfig, chainSelector, p2pIDs, DefaultPriceReportingParams())
+}
+
+// AddChainConfigWithPriceReporting adds the config of the chain to the CCIPHome, with the gas price deviations
+// of priceReporting.
+func AddChainConfigWithPriceReporting(
+	lggr logger.Logger,
+	h deployment.Chain,
+	ccipConfig *ccip_home.CCIPHome,
+	chainSelector uint64,
+	p2pIDs [][32]byte,
+	priceReporting PriceReportingParams,
 ) (ccip_home.CCIPHomeChainConfigArgs, error) {
 	// First Add ChainConfig that includes all p2pIDs as readers
-	encodedExtraChainConfig, err := chainconfig.EncodeChainConfig(chainconfig.ChainConfig{
-		GasPriceDeviationPPB:    ccipocr3.NewBigIntFromInt64(1000),
-		DAGasPriceDeviationPPB:  ccipocr3.NewBigIntFromInt64(0),
-		OptimisticConfirmations: 1,
-	})
+	encodedExtraChainConfig, err := encodeChainConfig(priceReporting)
 	if err != nil {
 		return ccip_home.CCIPHomeChainConfigArgs{}, err
 	}
@@ -308,6 +320,20 @@ func AddChainConfig(
 	return chainConfig, nil
 }
 
+// encodeChainConfig encodes the chain config stored in the CCIPHome along with the readers of a chain.
+// An unset data availability gas price deviation is encoded as 0.
+func encodeChainConfig(priceReporting PriceReportingParams) ([]byte, error) {
+	daGasPriceDeviationPPB := priceReporting.DAGasPriceDeviationPPB
+	if daGasPriceDeviationPPB == nil {
+		daGasPriceDeviationPPB = big.NewInt(0)
+	}
+	return chainconfig.EncodeChainConfig(chainconfig.ChainConfig{
+		GasPriceDeviationPPB:    ccipocr3.NewBigInt(priceReporting.GasPriceDeviationPPB),
+		DAGasPriceDeviationPPB:  ccipocr3.NewBigInt(daGasPriceDeviationPPB),
+		OptimisticConfirmations: 1,
+	})
+}
+
 // CreateDON creates one DON with 2 plugins (commit and exec)
 // It first set a new candidate for the DON with the first plugin type and AddDON on capReg
 // Then for subsequent operations it uses UpdateDON to promote the first plugin to the active deployment
@@ -475,7 +501,7 @@ func addDON(
 	)
 
 	tx, err := offRamp.SetOCR3Configs(dest.DeployerKey, offrampOCR3Configs)
-	if _, err := deployment.ConfirmIfNoError(dest, tx, err); err != nil {
+	if _, err := deployment.ConfirmFinalizedIfNoError(lggr, dest, tx, err); err != nil {
 		return err
 	}
 
@@ -520,26 +546,41 @@ func addDON(
 	return nil
 }
 
+// ApplyChainConfigUpdatesOp returns the operation adding the configs of the chains to the CCIPHome with the
+// default price reporting params, see ApplyChainConfigUpdatesOpWithPriceReporting.
 func ApplyChainConfigUpdatesOp(
 	e deployment.Environment,
 	state CCIPOnChainState,
 	homeChainSel uint64,
 	chains []uint64,
 ) (mcms.Operation, error) {
-	nodes, err := deployment.NodeInfo(e.NodeIDs, e.Offchain)
-	if err != nil {
-		return mcms.Operation{}, err
+	priceReporting := make(map[uint64]PriceReportingParams, len(chains))
+	for _, chainSel := range chains {
+		priceReporting[chainSel] = DefaultPriceReportingParams()
 	}
-	encodedExtraChainConfig, err := chainconfig.EncodeChainConfig(chainconfig.ChainConfig{
-		GasPriceDeviationPPB:    ccipocr3.NewBigIntFromInt64(1000),
-		DAGasPriceDeviationPPB:  ccipocr3.NewBigIntFromInt64(0),
-		OptimisticConfirmations: 1,
-	})
+	return ApplyChainConfigUpdatesOpWithPriceReporting(e, state, homeChainSel, priceReporting)
+}
+
+// ApplyChainConfigUpdatesOpWithPriceReporting returns the operation adding the configs of the chains of
+// priceReporting to the CCIPHome, with their gas price deviations.
+func ApplyChainConfigUpdatesOpWithPriceReporting(
+	e deployment.Environment,
+	state CCIPOnChainState,
+	homeChainSel uint64,
+	priceReporting map[uint64]PriceReportingParams,
+) (mcms.Operation, error) {
+	nodes, err := deployment.NodeInfo(e.NodeIDs, e.Offchain)
 	if err != nil {
 		return mcms.Operation{}, err
 	}
+	chains := maps.Keys(priceReporting)
+	sort.Slice(chains, func(i, j int) bool { return chains[i] < chains[j] })
 	var chainConfigUpdates []ccip_home.CCIPHomeChainConfigArgs
 	for _, chainSel := range chains {
+		encodedExtraChainConfig, err := encodeChainConfig(priceReporting[chainSel])
+		if err != nil {
+			return mcms.Operation{}, err
+		}
 		chainConfig := SetupConfigInfo(chainSel, nodes.NonBootstraps().PeerIDs(),
 			nodes.DefaultF(), encodedExtraChainConfig)
 		chainConfigUpdates = append(chainConfigUpdates, chainConfig)
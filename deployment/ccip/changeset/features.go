@@ -0,0 +1,76 @@
+package changeset
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+)
+
+// ChainFeatures are the optional components of the CCIP deployment of a chain. The flags are tri-state:
+// an unset flag leaves the component to its default.
+type ChainFeatures struct {
+	// USDC deploys the mock USDC contracts and the USDC token pool of the chain and enables its USDC attestation.
+	// USDC is disabled by default.
+	USDC *bool
+	// RMN enforces the RMN blessing of the commit reports of the chain or not, it overrides the RMNEnabled of its
+	// OCR params if set.
+	RMN *bool
+	// TestRouter deploys the test router, which lanes are tested with before being enabled in the router.
+	// The test router is deployed by default.
+	TestRouter *bool
+}
+
+// USDCEnabled returns whether USDC is enabled for the chain.
+func (f ChainFeatures) USDCEnabled() bool {
+	return f.USDC != nil && *f.USDC
+}
+
+// TestRouterEnabled returns whether the test router of the chain is deployed.
+func (f ChainFeatures) TestRouterEnabled() bool {
+	return f.TestRouter == nil || *f.TestRouter
+}
+
+// ChainFeatureFlags are the features of the chains by chain selector, chains without flags have the default
+// components. They are the only source of the optional components of the chains: the same flags should be
+// passed to DeployPrerequisites, DeployChainContracts and ConfigureNewChains so that the chains are deployed
+// and configured consistently.
+type ChainFeatureFlags map[uint64]ChainFeatures
+
+// USDCChains returns the chains with USDC enabled, sorted.
+func (f ChainFeatureFlags) USDCChains() []uint64 {
+	var chains []uint64
+	for chain, features := range f {
+		if features.USDCEnabled() {
+			chains = append(chains, chain)
+		}
+	}
+	sort.Slice(chains, func(i, j int) bool { return chains[i] < chains[j] })
+	return chains
+}
+
+// Validate checks that the flags are only set for the chains of the config.
+func (f ChainFeatureFlags) Validate(chains []uint64) error {
+	for chain := range f {
+		if !slices.Contains(chains, chain) {
+			return fmt.Errorf("feature flags set for chain %d which is not in the config", chain)
+		}
+	}
+	return nil
+}
+
+// withFeatures returns the config with RMN enforced or not for the chains which set the RMN flag, the
+// OCR params of the other chains are left as they are.
+func (c NewChainsConfig) withFeatures() NewChainsConfig {
+	if len(c.Features) == 0 {
+		return c
+	}
+	ocrParams := make(map[uint64]CCIPOCRParams, len(c.OCRParams))
+	for chain, params := range c.OCRParams {
+		if rmn := c.Features[chain].RMN; rmn != nil {
+			params.CommitOffChainConfig.RMNEnabled = *rmn
+		}
+		ocrParams[chain] = params
+	}
+	c.OCRParams = ocrParams
+	return c
+}
@@ -0,0 +1,41 @@
+package changeset
+
+import (
+	"testing"
+
+	chainsel "github.com/smartcontractkit/chain-selectors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChainFeatureFlags(t *testing.T) {
+	a, b, c := chainsel.TEST_90000001.Selector, chainsel.TEST_90000002.Selector, chainsel.TEST_90000003.Selector
+	enabled, disabled := true, false
+	features := ChainFeatureFlags{
+		b: {USDC: &enabled, RMN: &enabled},
+		a: {USDC: &enabled},
+		c: {USDC: &disabled, TestRouter: &disabled},
+	}
+	require.Equal(t, []uint64{a, b}, features.USDCChains())
+	require.True(t, features[a].TestRouterEnabled())
+	require.False(t, features[c].TestRouterEnabled())
+	require.NoError(t, features.Validate([]uint64{a, b, c}))
+	require.Error(t, features.Validate([]uint64{a, b}))
+
+	params := DefaultOCRParams(a, nil, nil)
+	params.CommitOffChainConfig.RMNEnabled = true
+	cfg := NewChainsConfig{
+		ChainsToDeploy: []uint64{a, b, c},
+		OCRParams:      map[uint64]CCIPOCRParams{a: params, b: params, c: params},
+		Features:       ChainFeatureFlags{a: {USDC: &enabled}, b: {RMN: &disabled}},
+	}
+	withFeatures := cfg.withFeatures()
+	// a chain flagged for USDC only keeps RMN as in its OCR params
+	require.True(t, withFeatures.OCRParams[a].CommitOffChainConfig.RMNEnabled)
+	require.False(t, withFeatures.OCRParams[b].CommitOffChainConfig.RMNEnabled)
+	// chains without flags keep their OCR params
+	require.True(t, withFeatures.OCRParams[c].CommitOffChainConfig.RMNEnabled)
+	// the config passed in isn't modified
+	require.True(t, cfg.OCRParams[b].CommitOffChainConfig.RMNEnabled)
+	// USDC is attested on the chains flagged for it only
+	require.Equal(t, map[uint64]bool{a: true}, cfg.usdcAttestationProvider().EnabledChainMap())
+}
@@ -0,0 +1,202 @@
+package changeset
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/proposal/mcms"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/proposal/timelock"
+	"golang.org/x/exp/maps"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/fee_quoter"
+)
+
+var (
+	_ deployment.ChangeSet[FeeQuoterGasConfigsConfig] = UpdateFeeQuoterGasConfigs
+)
+
+// FeeQuoterGasUpdate updates the gas settings of a destination chain on a fee quoter,
+// unset fields are left unchanged.
+type FeeQuoterGasUpdate struct {
+	// MaxPerMsgGasLimit is the maximum gas limit a message to the destination chain can request.
+	MaxPerMsgGasLimit *uint32
+	// DefaultTxGasLimit is the gas limit of the messages not requesting one.
+	DefaultTxGasLimit *uint32
+	// DestGasOverhead is the gas charged for the execution of every message besides its gas limit.
+	DestGasOverhead *uint32
+}
+
+func (u FeeQuoterGasUpdate) apply(cfg fee_quoter.FeeQuoterDestChainConfig) fee_quoter.FeeQuoterDestChainConfig {
+	if u.MaxPerMsgGasLimit != nil {
+		cfg.MaxPerMsgGasLimit = *u.MaxPerMsgGasLimit
+	}
+	if u.DefaultTxGasLimit != nil {
+		cfg.DefaultTxGasLimit = *u.DefaultTxGasLimit
+	}
+	if u.DestGasOverhead != nil {
+		cfg.DestGasOverhead = *u.DestGasOverhead
+	}
+	return cfg
+}
+
+// FeeQuoterGasConfigsConfig updates the gas settings of destination chains on the fee quoters of the chains.
+type FeeQuoterGasConfigsConfig struct {
+	// Updates are the updates by chain of the fee quoter, then by destination chain.
+	Updates map[uint64]map[uint64]FeeQuoterGasUpdate
+	// MinDelay is the delay of the proposal updating the fee quoters owned by the timelock.
+	MinDelay time.Duration
+}
+
+func (c FeeQuoterGasConfigsConfig) Validate(e deployment.Environment, state CCIPOnChainState) error {
+	if len(c.Updates) == 0 {
+		return fmt.Errorf("no chains to update")
+	}
+	for sel, dests := range c.Updates {
+		if _, ok := e.Chains[sel]; !ok {
+			return fmt.Errorf("chain %d not found in environment", sel)
+		}
+		chainState, ok := state.Chains[sel]
+		if !ok || chainState.FeeQuoter == nil {
+			return fmt.Errorf("fee quoter not deployed on chain %d", sel)
+		}
+		if chainState.Timelock == nil || chainState.ProposerMcm == nil {
+			return fmt.Errorf("mcms not deployed on chain %d", sel)
+		}
+		if len(dests) == 0 {
+			return fmt.Errorf("no destination chains to update on chain %d", sel)
+		}
+		for dest, update := range dests {
+			if dest == sel {
+				return fmt.Errorf("cannot update chain %d as its own destination", sel)
+			}
+			if update.MaxPerMsgGasLimit != nil && *update.MaxPerMsgGasLimit == 0 {
+				return fmt.Errorf("max per message gas limit of dest %d on chain %d must be positive", dest, sel)
+			}
+			if update.DefaultTxGasLimit != nil && *update.DefaultTxGasLimit == 0 {
+				return fmt.Errorf("default tx gas limit of dest %d on chain %d must be positive", dest, sel)
+			}
+		}
+	}
+	return nil
+}
+
+// UpdateFeeQuoterGasConfigs updates the gas settings of destination chains on the fee quoters, without
+// touching the rest of their destination chain configs. The fee quoters still owned by the deployer are
+// updated directly and read back to verify the update, the ones owned by the timelock are updated by
+// the proposal of the output, see VerifyFeeQuoterGasConfigs. Destination chains already configured are skipped.
+func UpdateFeeQuoterGasConfigs(e deployment.Environment, cfg FeeQuoterGasConfigsConfig) (deployment.ChangesetOutput, error) {
+	state, err := LoadOnchainState(e)
+	if err != nil {
+		return deployment.ChangesetOutput{}, fmt.Errorf("failed to load onchain state: %w", err)
+	}
+	if err := cfg.Validate(e, state); err != nil {
+		return deployment.ChangesetOutput{}, fmt.Errorf("invalid FeeQuoterGasConfigsConfig: %w", err)
+	}
+	// the batches are built in the order of the chain selectors, so that the same config gives the same proposal
+	sels := maps.Keys(cfg.Updates)
+	slices.Sort(sels)
+	var batches []timelock.BatchChainOperation
+	for _, sel := range sels {
+		dests := cfg.Updates[sel]
+		feeQuoter := state.Chains[sel].FeeQuoter
+		_, args, err := expectedFeeQuoterGasConfigs(feeQuoter, sel, dests)
+		if err != nil {
+			return deployment.ChangesetOutput{}, err
+		}
+		if len(args) == 0 {
+			e.Logger.Infow("Fee quoter gas configs up to date", "chain", sel)
+			continue
+		}
+		op, err := transactAsOwner(e.Chains[sel], state.Chains[sel].Timelock.Address(), feeQuoter.Owner,
+			func(opts *bind.TransactOpts) (*types.Transaction, error) {
+				return feeQuoter.ApplyDestChainConfigUpdates(opts, args)
+			})
+		if err != nil {
+			return deployment.ChangesetOutput{}, fmt.Errorf("failed to update dest chain configs of fee quoter on chain %d: %w", sel, err)
+		}
+		if op != nil {
+			batches = append(batches, timelock.BatchChainOperation{ChainIdentifier: mcms.ChainIdentifier(sel), Batch: []mcms.Operation{*op}})
+			e.Logger.Infow("Proposing fee quoter gas configs", "chain", sel, "dests", len(args))
+			continue
+		}
+		if err := verifyFeeQuoterGasConfigs(feeQuoter, sel, args); err != nil {
+			return deployment.ChangesetOutput{}, err
+		}
+		e.Logger.Infow("Updated fee quoter gas configs", "chain", sel, "dests", len(args))
+	}
+	return proposeBatches(state, batches, "update fee quoter gas configs", cfg.MinDelay)
+}
+
+// VerifyFeeQuoterGasConfigs reads back the destination chain configs of the fee quoters of the config and checks
+// they have the gas settings of the updates, e.g. once the proposal of UpdateFeeQuoterGasConfigs is executed.
+func VerifyFeeQuoterGasConfigs(e deployment.Environment, cfg FeeQuoterGasConfigsConfig) error {
+	state, err := LoadOnchainState(e)
+	if err != nil {
+		return fmt.Errorf("failed to load onchain state: %w", err)
+	}
+	if err := cfg.Validate(e, state); err != nil {
+		return fmt.Errorf("invalid FeeQuoterGasConfigsConfig: %w", err)
+	}
+	for sel, dests := range cfg.Updates {
+		feeQuoter := state.Chains[sel].FeeQuoter
+		expected, _, err := expectedFeeQuoterGasConfigs(feeQuoter, sel, dests)
+		if err != nil {
+			return err
+		}
+		if err := verifyFeeQuoterGasConfigs(feeQuoter, sel, expected); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// expectedFeeQuoterGasConfigs returns the current configs of the destination chains with the updates applied,
+// and the ones among them the updates change, sorted by destination chain.
+func expectedFeeQuoterGasConfigs(
+	feeQuoter *fee_quoter.FeeQuoter,
+	sel uint64,
+	dests map[uint64]FeeQuoterGasUpdate,
+) (expected, changed []fee_quoter.FeeQuoterDestChainConfigArgs, err error) {
+	for dest, update := range dests {
+		current, err := feeQuoter.GetDestChainConfig(&bind.CallOpts{Context: context.Background()}, dest)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get config of dest %d on chain %d: %w", dest, sel, err)
+		}
+		if !current.IsEnabled {
+			return nil, nil, fmt.Errorf("dest %d is not enabled on the fee quoter of chain %d", dest, sel)
+		}
+		updated := update.apply(current)
+		if updated.DefaultTxGasLimit > updated.MaxPerMsgGasLimit {
+			return nil, nil, fmt.Errorf("default tx gas limit %d of dest %d on chain %d exceeds the max per message gas limit %d",
+				updated.DefaultTxGasLimit, dest, sel, updated.MaxPerMsgGasLimit)
+		}
+		arg := fee_quoter.FeeQuoterDestChainConfigArgs{DestChainSelector: dest, DestChainConfig: updated}
+		expected = append(expected, arg)
+		if updated != current {
+			changed = append(changed, arg)
+		}
+	}
+	for _, args := range [][]fee_quoter.FeeQuoterDestChainConfigArgs{expected, changed} {
+		sort.Slice(args, func(i, j int) bool { return args[i].DestChainSelector < args[j].DestChainSelector })
+	}
+	return expected, changed, nil
+}
+
+func verifyFeeQuoterGasConfigs(feeQuoter *fee_quoter.FeeQuoter, sel uint64, expected []fee_quoter.FeeQuoterDestChainConfigArgs) error {
+	for _, arg := range expected {
+		actual, err := feeQuoter.GetDestChainConfig(&bind.CallOpts{Context: context.Background()}, arg.DestChainSelector)
+		if err != nil {
+			return fmt.Errorf("failed to read back config of dest %d on chain %d: %w", arg.DestChainSelector, sel, err)
+		}
+		if actual != arg.DestChainConfig {
+			return fmt.Errorf("config of dest %d on chain %d is %+v, expected %+v", arg.DestChainSelector, sel, actual, arg.DestChainConfig)
+		}
+	}
+	return nil
+}
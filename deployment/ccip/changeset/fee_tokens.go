@@ -0,0 +1,225 @@
+package changeset
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"slices"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/proposal/timelock"
+	"golang.org/x/exp/maps"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/fee_quoter"
+)
+
+var (
+	_ deployment.ChangeSet[AddFeeTokensConfig] = AddFeeTokens
+)
+
+// FeeTokenChainConfig is a fee token on a single chain.
+type FeeTokenChainConfig struct {
+	Token    common.Address
+	Decimals uint8
+	// Price is the USD price of 1e18 of the smallest unit of the token, with 18 decimals, as stored by the FeeQuoter.
+	// If unset, the price is derived from the USD feed of the token on the feed chain.
+	Price *big.Int
+}
+
+// FeeTokenConfig is a fee token to authorize on the FeeQuoter of each of its chains.
+type FeeTokenConfig struct {
+	Symbol TokenSymbol
+	Chains map[uint64]FeeTokenChainConfig
+}
+
+// AddFeeTokensConfig authorizes fee tokens, seeding their prices first so that fees in them can be quoted right away.
+type AddFeeTokensConfig struct {
+	// FeedChainSel is the chain of the USD feeds of the tokens without a configured price.
+	FeedChainSel uint64
+	Tokens       []FeeTokenConfig
+}
+
+func (c AddFeeTokensConfig) Validate(e deployment.Environment, state CCIPOnChainState) error {
+	if len(c.Tokens) == 0 {
+		return fmt.Errorf("no fee tokens to add")
+	}
+	symbols := make(map[TokenSymbol]struct{})
+	for _, token := range c.Tokens {
+		if token.Symbol == "" {
+			return fmt.Errorf("fee token symbol must be set")
+		}
+		if _, ok := symbols[token.Symbol]; ok {
+			return fmt.Errorf("duplicate fee token %s", token.Symbol)
+		}
+		symbols[token.Symbol] = struct{}{}
+		if len(token.Chains) == 0 {
+			return fmt.Errorf("no chains for fee token %s", token.Symbol)
+		}
+		for sel, chainCfg := range token.Chains {
+			if _, ok := e.Chains[sel]; !ok {
+				return fmt.Errorf("chain %d of fee token %s not found in environment", sel, token.Symbol)
+			}
+			if chainState, ok := state.Chains[sel]; !ok || chainState.FeeQuoter == nil {
+				return fmt.Errorf("fee quoter not deployed on chain %d", sel)
+			}
+			if chainCfg.Token == (common.Address{}) {
+				return fmt.Errorf("fee token %s address must be set for chain %d", token.Symbol, sel)
+			}
+			if chainCfg.Price != nil && chainCfg.Price.Sign() <= 0 {
+				return fmt.Errorf("price of fee token %s on chain %d must be positive", token.Symbol, sel)
+			}
+		}
+	}
+	return nil
+}
+
+// AddFeeTokens seeds the prices of the fee tokens and authorizes them on the FeeQuoter of their chains.
+// Each token must have a price source, either a configured price or a USD feed on the feed chain with a
+// positive answer, which is checked for all the tokens before any of them is authorized.
+// Tokens already authorized as fee tokens are skipped, so the changeset can be re-run after a partial failure.
+// The commit plugin only reports the prices of the tokens of its config, the seeded prices of the other tokens
+// must be kept fresh with RefreshFeeTokenPrices.
+func AddFeeTokens(e deployment.Environment, cfg AddFeeTokensConfig) (deployment.ChangesetOutput, error) {
+	state, err := LoadOnchainState(e)
+	if err != nil {
+		return deployment.ChangesetOutput{}, fmt.Errorf("failed to load onchain state: %w", err)
+	}
+	if err := cfg.Validate(e, state); err != nil {
+		return deployment.ChangesetOutput{}, fmt.Errorf("invalid AddFeeTokensConfig: %w", err)
+	}
+	prices, err := feeTokenPrices(state, cfg)
+	if err != nil {
+		return deployment.ChangesetOutput{}, err
+	}
+	for sel, updates := range prices {
+		if err := addFeeTokensOnChain(e, e.Chains[sel], state.Chains[sel].FeeQuoter, updates); err != nil {
+			e.Logger.Errorw("Failed to add fee tokens", "chain", sel, "err", err)
+			return deployment.ChangesetOutput{}, deployment.MaybeDataErr(err)
+		}
+	}
+	return deployment.ChangesetOutput{
+		Proposals:   []timelock.MCMSWithTimelockProposal{},
+		AddressBook: nil,
+		JobSpecs:    nil,
+	}, nil
+}
+
+// RefreshFeeTokenPrices keeps the prices of the fee tokens fresh until the context is done: on each tick of the
+// interval, the prices are derived again from their sources and set on the FeeQuoters of their chains, so that
+// they don't go stale after the TokenPriceStalenessThreshold of the FeeQuoters. The first tick is immediate.
+// It returns the first error of a refresh, or nil once the context is done.
+func RefreshFeeTokenPrices(ctx context.Context, e deployment.Environment, cfg AddFeeTokensConfig, interval time.Duration) error {
+	state, err := LoadOnchainState(e)
+	if err != nil {
+		return fmt.Errorf("failed to load onchain state: %w", err)
+	}
+	if err := cfg.Validate(e, state); err != nil {
+		return fmt.Errorf("invalid AddFeeTokensConfig: %w", err)
+	}
+	tick := time.NewTicker(interval)
+	defer tick.Stop()
+	for {
+		prices, err := feeTokenPrices(state, cfg)
+		if err != nil {
+			return err
+		}
+		sels := maps.Keys(prices)
+		slices.Sort(sels)
+		for _, sel := range sels {
+			if err := setTokenPrices(e, e.Chains[sel], state.Chains[sel].FeeQuoter, prices[sel]); err != nil {
+				return deployment.MaybeDataErr(err)
+			}
+		}
+		select {
+		case <-tick.C:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// feeTokenPrices returns the current prices of the fee tokens by chain.
+func feeTokenPrices(state CCIPOnChainState, cfg AddFeeTokensConfig) (map[uint64][]fee_quoter.InternalTokenPriceUpdate, error) {
+	prices := make(map[uint64][]fee_quoter.InternalTokenPriceUpdate)
+	for _, token := range cfg.Tokens {
+		for sel, chainCfg := range token.Chains {
+			price, err := feeTokenPrice(state, cfg.FeedChainSel, token.Symbol, chainCfg)
+			if err != nil {
+				return nil, fmt.Errorf("no price source for fee token %s on chain %d: %w", token.Symbol, sel, err)
+			}
+			prices[sel] = append(prices[sel], fee_quoter.InternalTokenPriceUpdate{SourceToken: chainCfg.Token, UsdPerToken: price})
+		}
+	}
+	return prices, nil
+}
+
+// feeTokenPrice returns the configured price of the token, or its price from its USD feed on the feed chain.
+func feeTokenPrice(state CCIPOnChainState, feedChainSel uint64, symbol TokenSymbol, cfg FeeTokenChainConfig) (*big.Int, error) {
+	if cfg.Price != nil {
+		return cfg.Price, nil
+	}
+	feed, ok := state.Chains[feedChainSel].USDFeeds[symbol]
+	if !ok || feed == nil {
+		return nil, fmt.Errorf("no price configured and no USD feed for %s on feed chain %d", symbol, feedChainSel)
+	}
+	opts := &bind.CallOpts{Context: context.Background()}
+	feedDecimals, err := feed.Decimals(opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get decimals of USD feed %s: %w", feed.Address(), err)
+	}
+	round, err := feed.LatestRoundData(opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest round of USD feed %s: %w", feed.Address(), err)
+	}
+	if round.Answer == nil || round.Answer.Sign() <= 0 {
+		return nil, fmt.Errorf("USD feed %s has no positive answer", feed.Address())
+	}
+	return feedAnswerToTokenPrice(round.Answer, feedDecimals, cfg.Decimals), nil
+}
+
+// feedAnswerToTokenPrice converts the answer of a USD feed to the price of the token as stored by the FeeQuoter,
+// the USD price of 1e18 of the smallest unit of the token with 18 decimals: answer * 10^(36 - feedDecimals - tokenDecimals).
+func feedAnswerToTokenPrice(answer *big.Int, feedDecimals, tokenDecimals uint8) *big.Int {
+	exp := 36 - int64(feedDecimals) - int64(tokenDecimals)
+	if exp >= 0 {
+		return new(big.Int).Mul(answer, new(big.Int).Exp(big.NewInt(10), big.NewInt(exp), nil))
+	}
+	return new(big.Int).Div(answer, new(big.Int).Exp(big.NewInt(10), big.NewInt(-exp), nil))
+}
+
+func addFeeTokensOnChain(e deployment.Environment, chain deployment.Chain, feeQuoter *fee_quoter.FeeQuoter, prices []fee_quoter.InternalTokenPriceUpdate) error {
+	feeTokens, err := feeQuoter.GetFeeTokens(&bind.CallOpts{Context: context.Background()})
+	if err != nil {
+		return fmt.Errorf("failed to get fee tokens: %w", err)
+	}
+	var toAdd []common.Address
+	var updates []fee_quoter.InternalTokenPriceUpdate
+	for _, update := range prices {
+		if slices.Contains(feeTokens, update.SourceToken) {
+			e.Logger.Infow("Fee token already added", "chain", chain.Selector, "token", update.SourceToken)
+			continue
+		}
+		toAdd = append(toAdd, update.SourceToken)
+		updates = append(updates, update)
+	}
+	if len(toAdd) == 0 {
+		return nil
+	}
+	// seed the prices first, fees can't be quoted in a fee token without a price
+	tx, err := feeQuoter.UpdatePrices(chain.DeployerKey, fee_quoter.InternalPriceUpdates{
+		TokenPriceUpdates: updates,
+		GasPriceUpdates:   []fee_quoter.InternalGasPriceUpdate{},
+	})
+	if _, err := deployment.ConfirmIfNoError(chain, tx, err); err != nil {
+		return fmt.Errorf("failed to seed fee token prices: %w", err)
+	}
+	tx, err = feeQuoter.ApplyFeeTokensUpdates(chain.DeployerKey, []common.Address{}, toAdd)
+	if _, err := deployment.ConfirmIfNoError(chain, tx, err); err != nil {
+		return fmt.Errorf("failed to add fee tokens: %w", err)
+	}
+	e.Logger.Infow("Added fee tokens", "chain", chain.Selector, "tokens", toAdd)
+	return nil
+}
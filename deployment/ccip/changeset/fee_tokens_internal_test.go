@@ -0,0 +1,17 @@
+package changeset
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeedAnswerToTokenPrice(t *testing.T) {
+	// $20 with 8 feed decimals
+	answer := big.NewInt(20e8)
+	require.Equal(t, "20000000000000000000", feedAnswerToTokenPrice(answer, 8, 18).String())
+	// 6 decimals tokens are priced per 1e18 of their smallest unit
+	require.Equal(t, "20000000000000000000000000000000", feedAnswerToTokenPrice(answer, 8, 6).String())
+	require.Equal(t, "2", feedAnswerToTokenPrice(answer, 18, 27).String())
+}
@@ -0,0 +1,92 @@
+package changeset_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-testing-framework/lib/utils/testcontext"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset/testhelpers"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/shared/generated/burn_mint_erc677"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+func TestAddFeeTokens(t *testing.T) {
+	lggr := logger.TestLogger(t)
+	e := testhelpers.NewMemoryEnvironmentWithJobsAndContracts(t, lggr, 2, 4, nil)
+	state, err := changeset.LoadOnchainState(e.Env)
+	require.NoError(t, err)
+	chainA, chainB := e.HomeChainSel, e.FeedChainSel
+
+	deployToken := func(sel uint64, symbol string) *burn_mint_erc677.BurnMintERC677 {
+		chain := e.Env.Chains[sel]
+		_, tx, token, err := burn_mint_erc677.DeployBurnMintERC677(chain.DeployerKey, chain.Client, symbol, symbol, 18, big.NewInt(0))
+		_, err = deployment.ConfirmIfNoError(chain, tx, err)
+		require.NoError(t, err)
+		return token
+	}
+	fee := map[uint64]*burn_mint_erc677.BurnMintERC677{chainA: deployToken(chainA, "FEE"), chainB: deployToken(chainB, "FEE")}
+	unpriced := deployToken(chainA, "UNPRICED")
+
+	// a token without a price source fails before any token is authorized
+	_, err = changeset.AddFeeTokens(e.Env, changeset.AddFeeTokensConfig{
+		FeedChainSel: e.FeedChainSel,
+		Tokens: []changeset.FeeTokenConfig{
+			{Symbol: "FEE", Chains: map[uint64]changeset.FeeTokenChainConfig{chainA: {Token: fee[chainA].Address(), Decimals: 18, Price: big.NewInt(1e18)}}},
+			{Symbol: "UNPRICED", Chains: map[uint64]changeset.FeeTokenChainConfig{chainA: {Token: unpriced.Address(), Decimals: 18}}},
+		},
+	})
+	require.ErrorContains(t, err, "no price source for fee token UNPRICED")
+	feeTokens, err := state.Chains[chainA].FeeQuoter.GetFeeTokens(nil)
+	require.NoError(t, err)
+	require.NotContains(t, feeTokens, fee[chainA].Address())
+
+	// the price of a token with a USD feed is derived from the feed
+	cfg := changeset.AddFeeTokensConfig{
+		FeedChainSel: e.FeedChainSel,
+		Tokens: []changeset.FeeTokenConfig{
+			{Symbol: "FEE", Chains: map[uint64]changeset.FeeTokenChainConfig{
+				chainA: {Token: fee[chainA].Address(), Decimals: 18, Price: big.NewInt(1e18)},
+				chainB: {Token: fee[chainB].Address(), Decimals: 18, Price: big.NewInt(2e18)},
+			}},
+			{Symbol: changeset.LinkSymbol, Chains: map[uint64]changeset.FeeTokenChainConfig{chainA: {Token: unpriced.Address(), Decimals: 18}}},
+		},
+	}
+	_, err = changeset.AddFeeTokens(e.Env, cfg)
+	require.NoError(t, err)
+	for sel, price := range map[uint64]int64{chainA: 1e18, chainB: 2e18} {
+		feeTokens, err := state.Chains[sel].FeeQuoter.GetFeeTokens(nil)
+		require.NoError(t, err)
+		require.Contains(t, feeTokens, fee[sel].Address())
+		tokenPrice, err := state.Chains[sel].FeeQuoter.GetTokenPrice(nil, fee[sel].Address())
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(price), tokenPrice.Value)
+	}
+	linkPrice, err := changeset.ExportedFeeTokenPrice(state, e.FeedChainSel, changeset.LinkSymbol, changeset.FeeTokenChainConfig{Decimals: 18})
+	require.NoError(t, err)
+	tokenPrice, err := state.Chains[chainA].FeeQuoter.GetTokenPrice(nil, unpriced.Address())
+	require.NoError(t, err)
+	require.Equal(t, linkPrice, tokenPrice.Value)
+
+	// re-running skips the tokens already added
+	_, err = changeset.AddFeeTokens(e.Env, cfg)
+	require.NoError(t, err)
+
+	// the prices are set again on each tick, from the current price sources
+	before, err := state.Chains[chainA].FeeQuoter.GetTokenPrice(nil, fee[chainA].Address())
+	require.NoError(t, err)
+	cfg.Tokens[0].Chains[chainA] = changeset.FeeTokenChainConfig{Token: fee[chainA].Address(), Decimals: 18, Price: big.NewInt(3e18)}
+	ctx, cancel := context.WithTimeout(testcontext.Get(t), 3*time.Second)
+	defer cancel()
+	require.NoError(t, changeset.RefreshFeeTokenPrices(ctx, e.Env, cfg, time.Second))
+	after, err := state.Chains[chainA].FeeQuoter.GetTokenPrice(nil, fee[chainA].Address())
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(3e18), after.Value)
+	require.Greater(t, after.Timestamp, before.Timestamp)
+}
@@ -0,0 +1,85 @@
+package changeset_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-testing-framework/lib/utils/testcontext"
+
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset/testhelpers"
+	"github.com/smartcontractkit/chainlink/deployment/environment/memory"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/router"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+// TestCommitWaitsForSourceFinality checks that a message is only committed once its block is finalized
+// at the finality depth of the source chain.
+func TestCommitWaitsForSourceFinality(t *testing.T) {
+	const finalityDepth = 20
+	e := testhelpers.NewMemoryEnvironmentWithJobsAndContracts(t, logger.TestLogger(t), 2, 4, &testhelpers.TestConfigs{
+		Finality: memory.FinalityConfig{Depth: finalityDepth, TagEnabled: true},
+	})
+	ctx := testcontext.Get(t)
+	state, err := changeset.LoadOnchainState(e.Env)
+	require.NoError(t, err)
+	selectors := e.Env.AllChainSelectors()
+	src, dest := selectors[0], selectors[1]
+
+	_, err = changeset.AddLanesWithTestRouter(e.Env, changeset.AddLanesConfig{
+		LaneConfigs: []changeset.LaneConfig{
+			{
+				SourceSelector:        src,
+				DestSelector:          dest,
+				InitialPricesBySource: changeset.DefaultInitialPrices,
+				FeeQuoterDestChain:    changeset.DefaultFeeQuoterDestChainConfig(),
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	latesthdr, err := e.Env.Chains[dest].Client.HeaderByNumber(ctx, nil)
+	require.NoError(t, err)
+	block := latesthdr.Number.Uint64()
+	msgSentEvent := testhelpers.TestSendRequest(t, e.Env, state, src, dest, true, router.ClientEVM2AnyMessage{
+		Receiver:  common.LeftPadBytes(state.Chains[dest].Receiver.Address().Bytes(), 32),
+		Data:      []byte("hello"),
+		FeeToken:  common.HexToAddress("0x0"),
+		ExtraArgs: nil,
+	})
+
+	srcBackend, ok := memory.AsBackend(e.Env.Chains[src].Client)
+	require.True(t, ok)
+	destBackend, ok := memory.AsBackend(e.Env.Chains[dest].Client)
+	require.True(t, ok)
+	finalized, err := srcBackend.FinalizedBlockNumber(ctx)
+	require.NoError(t, err)
+	require.Less(t, finalized, msgSentEvent.Raw.BlockNumber)
+
+	// only the destination chain is mined, the block of the message stays unfinalized
+	offRamp := state.Chains[dest].OffRamp
+	testhelpers.RequireConsistently(t, func() bool {
+		destBackend.Commit()
+		it, err := offRamp.FilterCommitReportAccepted(&bind.FilterOpts{Context: ctx, Start: block})
+		require.NoError(t, err)
+		defer it.Close()
+		for it.Next() {
+			for _, root := range it.Event.MerkleRoots {
+				if root.SourceChainSelector == src {
+					return false
+				}
+			}
+		}
+		require.NoError(t, it.Error())
+		return true
+	}, 30*time.Second, 3*time.Second, "message of block %d committed before it was finalized", msgSentEvent.Raw.BlockNumber)
+
+	require.NoError(t, srcBackend.CommitUntilFinalized(ctx, msgSentEvent.Raw.BlockNumber))
+	testhelpers.ConfirmExecWithSeqNrsForAll(t, e.Env, state, map[changeset.SourceDestPair][]uint64{
+		{SourceChainSelector: src, DestChainSelector: dest}: {msgSentEvent.SequenceNumber},
+	}, map[uint64]*uint64{dest: &block})
+}
@@ -0,0 +1,43 @@
+package changeset
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/rmn_home"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/router"
+)
+
+func ExportedDeployPrerequisiteChainContracts(e deployment.Environment, ab deployment.AddressBook, selectors []uint64, opts ...PrerequisiteOpt) error {
+	return deployPrerequisiteChainContracts(e, ab, selectors, opts...)
+}
+
+func ExportedDeployCCIPContracts(e deployment.Environment, ab deployment.AddressBook, c NewChainsConfig) error {
+	return deployCCIPContracts(e, ab, c)
+}
+
+func ExportedDeployChainContracts(e deployment.Environment, chain deployment.Chain, ab deployment.AddressBook, rmnHome *rmn_home.RMNHome, features ChainFeatures) error {
+	return deployChainContracts(e, chain, ab, rmnHome, features)
+}
+
+func ExportedFeeTokenPrice(state CCIPOnChainState, feedChainSel uint64, symbol TokenSymbol, cfg FeeTokenChainConfig) (*big.Int, error) {
+	return feeTokenPrice(state, feedChainSel, symbol, cfg)
+}
+
+func (c NewChainsConfig) ExportedPriceSource() PriceSource {
+	return c.priceSource()
+}
+
+// NewTestOffRamps returns n off ramps with distinct source chains and addresses.
+func NewTestOffRamps(n int) []router.RouterOffRamp {
+	offRamps := make([]router.RouterOffRamp, 0, n)
+	for i := 0; i < n; i++ {
+		offRamps = append(offRamps, router.RouterOffRamp{
+			SourceChainSelector: uint64(i + 1),
+			OffRamp:             common.BigToAddress(big.NewInt(int64(1000 + i))),
+		})
+	}
+	return offRamps
+}
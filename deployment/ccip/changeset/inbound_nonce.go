@@ -0,0 +1,185 @@
+package changeset
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/proposal/mcms"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/proposal/timelock"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/nonce_manager"
+)
+
+var (
+	_ deployment.ChangeSet[SkipInboundNonceConfig] = SkipInboundNonce
+)
+
+// SkipInboundNonceConfig skips the ordered messages of a sender up to a nonce, to recover a lane blocked
+// by an ordered message the DON can't execute: the offramp only executes the ordered messages of a sender
+// in nonce order, so the later messages of the sender wait for it forever.
+type SkipInboundNonceConfig struct {
+	SourceChainSelector uint64
+	DestChainSelector   uint64
+	// Sender is the encoded address of the sender on the source chain.
+	Sender []byte
+	// SkipToNonce is the nonce of the last message to skip, the next ordered message of the sender
+	// executed is the one with the following nonce.
+	SkipToNonce uint64
+	// MinDelay is the delay of the proposal skipping the nonces when the timelock owns the nonce manager.
+	MinDelay time.Duration
+}
+
+func (c SkipInboundNonceConfig) Validate(e deployment.Environment, state CCIPOnChainState) error {
+	if err := deployment.IsValidChainSelector(c.SourceChainSelector); err != nil {
+		return fmt.Errorf("invalid source chain selector: %w", err)
+	}
+	chain, ok := e.Chains[c.DestChainSelector]
+	if !ok {
+		return fmt.Errorf("chain %d not found in environment", c.DestChainSelector)
+	}
+	if len(c.Sender) == 0 {
+		return fmt.Errorf("sender is required")
+	}
+	nonceManager, err := state.TryGetNonceManager(c.DestChainSelector)
+	if err != nil {
+		return err
+	}
+	owner, err := nonceManager.Owner(&bind.CallOpts{Context: context.Background()})
+	if err != nil {
+		return fmt.Errorf("failed to get owner of nonce manager on chain %d: %w", c.DestChainSelector, err)
+	}
+	if owner == chain.DeployerKey.From {
+		return nil
+	}
+	chainState := state.Chains[c.DestChainSelector]
+	if chainState.Timelock == nil || chainState.ProposerMcm == nil {
+		return fmt.Errorf("nonce manager on chain %d is owned by %s and mcms is not deployed", c.DestChainSelector, owner)
+	}
+	if owner != chainState.Timelock.Address() {
+		return fmt.Errorf("nonce manager on chain %d is owned by %s, neither the deployer nor the timelock", c.DestChainSelector, owner)
+	}
+	return nil
+}
+
+// SkipInboundNonce skips the ordered messages of the sender up to the nonce of the config by incrementing
+// its inbound nonce on the nonce manager of the destination chain. The owner of the nonce manager is authorized
+// to increment the nonce for the time of the changeset: the deployer directly, the timelock in a single batch of
+// the proposal of the output. Messages already executed are left as they are.
+func SkipInboundNonce(e deployment.Environment, cfg SkipInboundNonceConfig) (deployment.ChangesetOutput, error) {
+	state, err := LoadOnchainState(e)
+	if err != nil {
+		return deployment.ChangesetOutput{}, fmt.Errorf("failed to load onchain state: %w", err)
+	}
+	if err := cfg.Validate(e, state); err != nil {
+		return deployment.ChangesetOutput{}, fmt.Errorf("invalid SkipInboundNonceConfig: %w", err)
+	}
+	chain := e.Chains[cfg.DestChainSelector]
+	nonceManager := state.Chains[cfg.DestChainSelector].NonceManager
+	inbound, err := nonceManager.GetInboundNonce(&bind.CallOpts{Context: context.Background()}, cfg.SourceChainSelector, cfg.Sender)
+	if err != nil {
+		return deployment.ChangesetOutput{}, fmt.Errorf("failed to get inbound nonce on chain %d: %w", cfg.DestChainSelector, err)
+	}
+	if inbound >= cfg.SkipToNonce {
+		e.Logger.Infow("Inbound nonce already past the nonce to skip to", "chain", cfg.DestChainSelector,
+			"source", cfg.SourceChainSelector, "inboundNonce", inbound, "skipToNonce", cfg.SkipToNonce)
+		return deployment.ChangesetOutput{}, nil
+	}
+
+	owner, err := nonceManager.Owner(&bind.CallOpts{Context: context.Background()})
+	if err != nil {
+		return deployment.ChangesetOutput{}, fmt.Errorf("failed to get owner of nonce manager on chain %d: %w", cfg.DestChainSelector, err)
+	}
+	if owner != chain.DeployerKey.From {
+		batch, err := skipInboundNonceBatch(nonceManager, owner, cfg, inbound)
+		if err != nil {
+			return deployment.ChangesetOutput{}, fmt.Errorf("failed to build skip of nonces on chain %d: %w", cfg.DestChainSelector, err)
+		}
+		return proposeBatches(state, []timelock.BatchChainOperation{batch}, "skip inbound nonces", cfg.MinDelay)
+	}
+	if err := skipInboundNonces(chain, nonceManager, cfg, inbound); err != nil {
+		return deployment.ChangesetOutput{}, err
+	}
+	e.Logger.Infow("Skipped inbound nonces", "chain", cfg.DestChainSelector, "source", cfg.SourceChainSelector,
+		"sender", common.Bytes2Hex(cfg.Sender), "from", inbound+1, "to", cfg.SkipToNonce)
+	return deployment.ChangesetOutput{
+		Proposals:   []timelock.MCMSWithTimelockProposal{},
+		AddressBook: nil,
+		JobSpecs:    nil,
+	}, nil
+}
+
+// skipInboundNonces increments the inbound nonce of the sender with the deployer key, which is authorized
+// on the nonce manager until the nonces are skipped or one of them fails.
+func skipInboundNonces(chain deployment.Chain, nonceManager *nonce_manager.NonceManager, cfg SkipInboundNonceConfig, inbound uint64) (err error) {
+	if err := authorizeNonceManagerCaller(chain, nonceManager, true); err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, authorizeNonceManagerCaller(chain, nonceManager, false))
+	}()
+	for nonce := inbound + 1; nonce <= cfg.SkipToNonce; nonce++ {
+		tx, err := nonceManager.IncrementInboundNonce(chain.DeployerKey, cfg.SourceChainSelector, nonce, cfg.Sender)
+		if _, err := deployment.ConfirmIfNoError(chain, tx, err); err != nil {
+			return fmt.Errorf("failed to skip nonce %d on chain %d: %w", nonce, chain.Selector, deployment.MaybeDataErr(err))
+		}
+	}
+	return nil
+}
+
+// skipInboundNonceBatch returns the batch of the timelock incrementing the inbound nonce of the sender: the
+// timelock is authorized on the nonce manager and removed in the same batch, which executes atomically.
+func skipInboundNonceBatch(nonceManager *nonce_manager.NonceManager, timelockAddr common.Address, cfg SkipInboundNonceConfig, inbound uint64) (timelock.BatchChainOperation, error) {
+	var ops []mcms.Operation
+	addOp := func(send func(opts *bind.TransactOpts) (*types.Transaction, error)) error {
+		tx, err := send(deployment.SimTransactOpts())
+		if err != nil {
+			return deployment.MaybeDataErr(err)
+		}
+		ops = append(ops, mcms.Operation{To: nonceManager.Address(), Data: tx.Data(), Value: big.NewInt(0)})
+		return nil
+	}
+	err := addOp(func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return nonceManager.ApplyAuthorizedCallerUpdates(opts, nonce_manager.AuthorizedCallersAuthorizedCallerArgs{AddedCallers: []common.Address{timelockAddr}})
+	})
+	if err != nil {
+		return timelock.BatchChainOperation{}, err
+	}
+	for nonce := inbound + 1; nonce <= cfg.SkipToNonce; nonce++ {
+		err := addOp(func(opts *bind.TransactOpts) (*types.Transaction, error) {
+			return nonceManager.IncrementInboundNonce(opts, cfg.SourceChainSelector, nonce, cfg.Sender)
+		})
+		if err != nil {
+			return timelock.BatchChainOperation{}, err
+		}
+	}
+	err = addOp(func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return nonceManager.ApplyAuthorizedCallerUpdates(opts, nonce_manager.AuthorizedCallersAuthorizedCallerArgs{RemovedCallers: []common.Address{timelockAddr}})
+	})
+	if err != nil {
+		return timelock.BatchChainOperation{}, err
+	}
+	return timelock.BatchChainOperation{
+		ChainIdentifier: mcms.ChainIdentifier(cfg.DestChainSelector),
+		Batch:           ops,
+	}, nil
+}
+
+// authorizeNonceManagerCaller adds or removes the deployer from the authorized callers of the nonce manager.
+func authorizeNonceManagerCaller(chain deployment.Chain, nonceManager *nonce_manager.NonceManager, authorize bool) error {
+	args := nonce_manager.AuthorizedCallersAuthorizedCallerArgs{RemovedCallers: []common.Address{chain.DeployerKey.From}}
+	if authorize {
+		args = nonce_manager.AuthorizedCallersAuthorizedCallerArgs{AddedCallers: []common.Address{chain.DeployerKey.From}}
+	}
+	tx, err := nonceManager.ApplyAuthorizedCallerUpdates(chain.DeployerKey, args)
+	if _, err := deployment.ConfirmIfNoError(chain, tx, err); err != nil {
+		return fmt.Errorf("failed to update authorized callers of nonce manager on chain %d: %w", chain.Selector, deployment.MaybeDataErr(err))
+	}
+	return nil
+}
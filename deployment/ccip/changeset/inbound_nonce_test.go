@@ -0,0 +1,148 @@
+package changeset_test
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/proposal/mcms"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/proposal/timelock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-testing-framework/lib/utils/testcontext"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset/internal"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset/testhelpers"
+	commonchangeset "github.com/smartcontractkit/chainlink/deployment/common/changeset"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/onramp"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/router"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+func TestSkipInboundNonce(t *testing.T) {
+	lggr := logger.TestLogger(t)
+	e := testhelpers.NewMemoryEnvironmentWithJobsAndContracts(t, lggr, 2, 4, nil)
+	state, err := changeset.LoadOnchainState(e.Env)
+	require.NoError(t, err)
+	src, dest := e.HomeChainSel, e.FeedChainSel
+	sender := e.Env.Chains[src].DeployerKey.From
+	testhelpers.ReplayLogs(t, e.Env.Offchain, e.ReplayBlocks)
+	require.NoError(t, testhelpers.AddLanesForAll(e.Env, state))
+
+	latest, err := e.Env.Chains[dest].Client.HeaderByNumber(testcontext.Get(t), nil)
+	require.NoError(t, err)
+	startBlock := latest.Number.Uint64()
+	msgs := testhelpers.SendOrderedRequests(t, e.Env, state, src, dest, 3)
+	_, err = testhelpers.ConfirmExecWithSeqNrs(t, e.Env.Chains[src], e.Env.Chains[dest], state.Chains[dest].OffRamp, &startBlock, testhelpers.SeqNrsOf(msgs))
+	require.NoError(t, err)
+	testhelpers.ConfirmExecutedInNonceOrder(t, state, src, dest, startBlock, msgs)
+	testhelpers.ConfirmInboundNonce(t, state, src, dest, sender, msgs[2].Message.Header.Nonce)
+
+	cfg := changeset.SkipInboundNonceConfig{
+		SourceChainSelector: src,
+		DestChainSelector:   dest,
+		Sender:              common.LeftPadBytes(sender.Bytes(), 32),
+		SkipToNonce:         msgs[2].Message.Header.Nonce + 1,
+	}
+	_, err = changeset.SkipInboundNonce(e.Env, cfg)
+	require.NoError(t, err)
+	testhelpers.ConfirmInboundNonce(t, state, src, dest, sender, cfg.SkipToNonce)
+	// skipping again is a no-op
+	_, err = changeset.SkipInboundNonce(e.Env, cfg)
+	require.NoError(t, err)
+
+	// the message with the skipped nonce is not executed, the following one is
+	msgs = testhelpers.SendOrderedRequests(t, e.Env, state, src, dest, 2)
+	require.Equal(t, cfg.SkipToNonce, msgs[0].Message.Header.Nonce)
+	_, err = testhelpers.ConfirmExecWithSeqNrs(t, e.Env.Chains[src], e.Env.Chains[dest], state.Chains[dest].OffRamp, &startBlock, testhelpers.SeqNrsOf(msgs[1:]))
+	require.NoError(t, err)
+	testhelpers.ConfirmNoExecConsistentlyWithSeqNr(t, e.Env.Chains[src], e.Env.Chains[dest], state.Chains[dest].OffRamp, msgs[0].SequenceNumber, 30*time.Second)
+	testhelpers.ConfirmInboundNonce(t, state, src, dest, sender, msgs[1].Message.Header.Nonce)
+}
+
+func TestSkipInboundNonceUnblocksSender(t *testing.T) {
+	lggr := logger.TestLogger(t)
+	e := testhelpers.NewMemoryEnvironmentWithJobsAndContracts(t, lggr, 2, 4, nil)
+	state, err := changeset.LoadOnchainState(e.Env)
+	require.NoError(t, err)
+	src, dest := e.HomeChainSel, e.FeedChainSel
+	sender := e.Env.Chains[src].DeployerKey.From
+	testhelpers.ReplayLogs(t, e.Env.Offchain, e.ReplayBlocks)
+	require.NoError(t, testhelpers.AddLanesForAll(e.Env, state))
+
+	// the DON never executes a message requesting more gas than its batch gas limit, the onramp accepts it
+	// once the fee quoter allows it
+	maxGas := uint32(2 * internal.BatchGasLimit)
+	_, err = changeset.UpdateFeeQuoterGasConfigs(e.Env, changeset.FeeQuoterGasConfigsConfig{
+		Updates: map[uint64]map[uint64]changeset.FeeQuoterGasUpdate{src: {dest: {MaxPerMsgGasLimit: &maxGas}}},
+	})
+	require.NoError(t, err)
+	// blockSender sends an ordered message the DON can't execute, followed by an ordered message
+	// which waits for it
+	blockSender := func() (stuck, blocked *onramp.OnRampCCIPMessageSent) {
+		stuck = testhelpers.TestSendRequest(t, e.Env, state, src, dest, false, router.ClientEVM2AnyMessage{
+			Receiver:  common.LeftPadBytes(state.Chains[dest].Receiver.Address().Bytes(), 32),
+			Data:      []byte("stuck"),
+			FeeToken:  common.HexToAddress("0x0"),
+			ExtraArgs: testhelpers.MakeEVMExtraArgsV2(uint64(internal.BatchGasLimit)+1, false),
+		})
+		blocked = testhelpers.SendOrderedRequests(t, e.Env, state, src, dest, 1)[0]
+		require.Equal(t, stuck.Message.Header.Nonce+1, blocked.Message.Header.Nonce)
+		testhelpers.ConfirmNoExecConsistentlyWithSeqNr(t, e.Env.Chains[src], e.Env.Chains[dest], state.Chains[dest].OffRamp, blocked.SequenceNumber, 30*time.Second)
+		testhelpers.ConfirmInboundNonce(t, state, src, dest, sender, stuck.Message.Header.Nonce-1)
+		return stuck, blocked
+	}
+	skipTo := func(nonce uint64) changeset.SkipInboundNonceConfig {
+		return changeset.SkipInboundNonceConfig{
+			SourceChainSelector: src,
+			DestChainSelector:   dest,
+			Sender:              common.LeftPadBytes(sender.Bytes(), 32),
+			SkipToNonce:         nonce,
+		}
+	}
+
+	latest, err := e.Env.Chains[dest].Client.HeaderByNumber(testcontext.Get(t), nil)
+	require.NoError(t, err)
+	startBlock := latest.Number.Uint64()
+	stuck, blocked := blockSender()
+	out, err := changeset.SkipInboundNonce(e.Env, skipTo(stuck.Message.Header.Nonce))
+	require.NoError(t, err)
+	require.Empty(t, out.Proposals)
+	_, err = testhelpers.ConfirmExecWithSeqNrs(t, e.Env.Chains[src], e.Env.Chains[dest], state.Chains[dest].OffRamp, &startBlock, []uint64{blocked.SequenceNumber})
+	require.NoError(t, err)
+	testhelpers.ConfirmInboundNonce(t, state, src, dest, sender, blocked.Message.Header.Nonce)
+
+	// once the timelock owns the nonce manager, the nonces are skipped by its proposal
+	chain, nonceManager := e.Env.Chains[dest], state.Chains[dest].NonceManager
+	tx, err := nonceManager.TransferOwnership(chain.DeployerKey, state.Chains[dest].Timelock.Address())
+	_, err = deployment.ConfirmIfNoError(chain, tx, err)
+	require.NoError(t, err)
+	acceptOwnership, err := nonceManager.AcceptOwnership(deployment.SimTransactOpts())
+	require.NoError(t, err)
+	prop, err := changeset.BuildProposalFromBatches(state, []timelock.BatchChainOperation{{
+		ChainIdentifier: mcms.ChainIdentifier(dest),
+		Batch:           []mcms.Operation{{To: nonceManager.Address(), Data: acceptOwnership.Data(), Value: big.NewInt(0)}},
+	}}, "accept ownership", 0)
+	require.NoError(t, err)
+	commonchangeset.ExecuteProposal(t, e.Env, commonchangeset.SignProposal(t, e.Env, prop), state.Chains[dest].Timelock, dest)
+
+	latest, err = chain.Client.HeaderByNumber(testcontext.Get(t), nil)
+	require.NoError(t, err)
+	startBlock = latest.Number.Uint64()
+	stuck, blocked = blockSender()
+	out, err = changeset.SkipInboundNonce(e.Env, skipTo(stuck.Message.Header.Nonce))
+	require.NoError(t, err)
+	require.Len(t, out.Proposals, 1)
+	testhelpers.ConfirmInboundNonce(t, state, src, dest, sender, stuck.Message.Header.Nonce-1)
+	testhelpers.ProcessChangeset(t, e.Env, "SkipInboundNonce", out)
+	_, err = testhelpers.ConfirmExecWithSeqNrs(t, e.Env.Chains[src], chain, state.Chains[dest].OffRamp, &startBlock, []uint64{blocked.SequenceNumber})
+	require.NoError(t, err)
+	testhelpers.ConfirmInboundNonce(t, state, src, dest, sender, blocked.Message.Header.Nonce)
+	// the timelock is only authorized for the time of the proposal
+	callers, err := nonceManager.GetAllAuthorizedCallers(nil)
+	require.NoError(t, err)
+	require.NotContains(t, callers, state.Chains[dest].Timelock.Address())
+}
@@ -1,7 +1,9 @@
 package changeset
 
 import (
+	"context"
 	"fmt"
+	"math/big"
 	"os"
 	"slices"
 	"sort"
@@ -20,12 +22,24 @@ import (
 
 var _ deployment.ChangeSet[NewChainsConfig] = ConfigureNewChains
 
+// RMNSignObservationPrefix is prepended to the observations signed by RMN nodes.
+const RMNSignObservationPrefix = "chainlink ccip 1.6 rmn observation"
+
 // ConfigureNewChains enables new chains as destination for CCIP
 // It performs the following steps:
 // - AddChainConfig + AddDON (candidate->primary promotion i.e. init) on the home chain
 // - SetOCR3Config on the remote chain
 // ConfigureNewChains assumes that the home chain is already enabled and all CCIP contracts are already deployed.
+// The token prices of a StaticPriceSource or an HTTPPriceSource are only set once, see RefreshTokenPrices.
 func ConfigureNewChains(env deployment.Environment, c NewChainsConfig) (deployment.ChangesetOutput, error) {
+	if c.OCRSecrets.IsEmpty() && c.OCRSecretsProvider != nil {
+		secrets, err := c.OCRSecretsProvider.OCRSecrets(context.Background())
+		if err != nil {
+			return deployment.ChangesetOutput{}, fmt.Errorf("failed to get OCR secrets: %w", err)
+		}
+		c.OCRSecrets = secrets
+	}
+	c = c.withFeatures()
 	if err := c.Validate(); err != nil {
 		return deployment.ChangesetOutput{}, fmt.Errorf("invalid NewChainsConfig: %w", err)
 	}
@@ -41,20 +55,12 @@ func ConfigureNewChains(env deployment.Environment, c NewChainsConfig) (deployme
 	}, nil
 }
 
+// USDCConfig is the USDC attestation of the chains with the USDC feature, see ChainFeatures.
 type USDCConfig struct {
-	EnabledChains []uint64
 	USDCAttestationConfig
 	CCTPTokenConfig map[ccipocr3.ChainSelector]pluginconfig.USDCCCTPTokenConfig
 }
 
-func (cfg USDCConfig) EnabledChainMap() map[uint64]bool {
-	m := make(map[uint64]bool)
-	for _, chain := range cfg.EnabledChains {
-		m[chain] = true
-	}
-	return m
-}
-
 func (cfg USDCConfig) ToTokenDataObserverConfig() []pluginconfig.TokenDataObserverConfig {
 	return []pluginconfig.TokenDataObserverConfig{{
 		Type:    pluginconfig.USDCCCTPHandlerType,
@@ -68,22 +74,129 @@ func (cfg USDCConfig) ToTokenDataObserverConfig() []pluginconfig.TokenDataObserv
 	}}
 }
 
-type USDCAttestationConfig struct {
-	API         string
-	APITimeout  *config.Duration
-	APIInterval *config.Duration
+func (cfg USDCConfig) ObserverType() string {
+	return pluginconfig.USDCCCTPHandlerType
+}
+
+func (cfg USDCConfig) AttestationAPI() AttestationAPIConfig {
+	return cfg.USDCAttestationConfig
+}
+
+func (cfg USDCConfig) MessageEncoding() AttestationMessageEncoding {
+	return MessageHashInPath
+}
+
+// usdcAttestationProvider is the USDC config of the chains with USDC enabled.
+type usdcAttestationProvider struct {
+	USDCConfig
+	chains []uint64
+}
+
+func (p usdcAttestationProvider) EnabledChainMap() map[uint64]bool {
+	m := make(map[uint64]bool)
+	for _, chain := range p.chains {
+		m[chain] = true
+	}
+	return m
+}
+
+func (p usdcAttestationProvider) Validate() error {
+	if len(p.chains) == 0 {
+		return nil
+	}
+	if err := p.USDCAttestationConfig.Validate(); err != nil {
+		return fmt.Errorf("invalid USDC attestation config: %w", err)
+	}
+	// Circle's API is public, and the usdc-cctp token data observer has no auth settings.
+	if p.AuthHeader != "" {
+		return fmt.Errorf("the %s token data observer doesn't support an authenticated attestation API", p.ObserverType())
+	}
+	return nil
+}
+
+// USDCAttestationConfig is the attestation API of Circle's CCTP.
+type USDCAttestationConfig = AttestationAPIConfig
+
+// PriceReportingParams are the heuristics the commit plugin reports prices with. A price is reported when it
+// deviated from the last reported one by at least its deviation, or when its heartbeat passed.
+type PriceReportingParams struct {
+	// TokenPriceHeartbeat is the TokenPriceBatchWriteFrequency of the commit plugin. It may be zero when
+	// the commit plugin reports no token prices, e.g. when they are written by feeds.
+	TokenPriceHeartbeat time.Duration
+	// TokenPriceDeviationPPB overrides the deviation, in parts per billion, of every token in the TokenConfig if set.
+	TokenPriceDeviationPPB *big.Int
+	// GasPriceHeartbeat is the RemoteGasPriceBatchWriteFrequency of the commit plugin.
+	GasPriceHeartbeat time.Duration
+	// GasPriceDeviationPPB and DAGasPriceDeviationPPB are the deviations, in parts per billion, of the execution and
+	// data availability gas prices of the chain, as reported to the other chains. They are stored in the chain config of the CCIPHome.
+	// The data availability gas price deviation is optional, any change is reported when it is unset or zero.
+	GasPriceDeviationPPB   *big.Int
+	DAGasPriceDeviationPPB *big.Int
+}
+
+// DefaultPriceReportingParams returns the price reporting heuristics used in tests: token prices follow the
+// deviations of the TokenConfig, and any change of the data availability gas price is reported.
+func DefaultPriceReportingParams() PriceReportingParams {
+	return PriceReportingParams{
+		TokenPriceHeartbeat:    internal.TokenPriceBatchWriteFrequency,
+		GasPriceHeartbeat:      internal.RemoteGasPriceBatchWriteFrequency,
+		GasPriceDeviationPPB:   big.NewInt(1000),
+		DAGasPriceDeviationPPB: big.NewInt(0),
+	}
+}
+
+// Validate checks the params against the commit plugin and chain config validation: the token price heartbeat
+// is only required when token prices are reported, which the commit offchain config validation checks.
+func (p PriceReportingParams) Validate() error {
+	if p.TokenPriceHeartbeat < 0 {
+		return fmt.Errorf("token price heartbeat must not be negative")
+	}
+	if p.GasPriceHeartbeat <= 0 {
+		return fmt.Errorf("gas price heartbeat must be positive")
+	}
+	if p.TokenPriceDeviationPPB != nil && p.TokenPriceDeviationPPB.Sign() <= 0 {
+		return fmt.Errorf("token price deviation must be positive")
+	}
+	if p.GasPriceDeviationPPB == nil || p.GasPriceDeviationPPB.Sign() <= 0 {
+		return fmt.Errorf("gas price deviation must be positive")
+	}
+	if p.DAGasPriceDeviationPPB != nil && p.DAGasPriceDeviationPPB.Sign() < 0 {
+		return fmt.Errorf("data availability gas price deviation must not be negative")
+	}
+	return nil
 }
 
 type CCIPOCRParams struct {
 	OCRParameters         types.OCRParameters
 	CommitOffChainConfig  pluginconfig.CommitOffchainConfig
 	ExecuteOffChainConfig pluginconfig.ExecuteOffchainConfig
+	// PriceReporting takes precedence over the price heartbeats and token deviations of the CommitOffChainConfig.
+	PriceReporting PriceReportingParams
+}
+
+// withPriceReporting returns the params with the price reporting heuristics applied to the commit offchain config.
+// It must be called after the token info of the commit offchain config is set.
+func (p CCIPOCRParams) withPriceReporting() CCIPOCRParams {
+	p.CommitOffChainConfig.TokenPriceBatchWriteFrequency = *config.MustNewDuration(p.PriceReporting.TokenPriceHeartbeat)
+	p.CommitOffChainConfig.RemoteGasPriceBatchWriteFrequency = *config.MustNewDuration(p.PriceReporting.GasPriceHeartbeat)
+	if p.PriceReporting.TokenPriceDeviationPPB != nil {
+		tokenInfo := make(map[ccipocr3.UnknownEncodedAddress]pluginconfig.TokenInfo, len(p.CommitOffChainConfig.TokenInfo))
+		for token, info := range p.CommitOffChainConfig.TokenInfo {
+			info.DeviationPPB = ccipocr3.NewBigInt(new(big.Int).Set(p.PriceReporting.TokenPriceDeviationPPB))
+			tokenInfo[token] = info
+		}
+		p.CommitOffChainConfig.TokenInfo = tokenInfo
+	}
+	return p
 }
 
 func (p CCIPOCRParams) Validate() error {
 	if err := p.OCRParameters.Validate(); err != nil {
 		return fmt.Errorf("invalid OCR parameters: %w", err)
 	}
+	if err := p.PriceReporting.Validate(); err != nil {
+		return fmt.Errorf("invalid price reporting params: %w", err)
+	}
 	if err := p.CommitOffChainConfig.Validate(); err != nil {
 		return fmt.Errorf("invalid commit off-chain config: %w", err)
 	}
@@ -94,27 +207,39 @@ func (p CCIPOCRParams) Validate() error {
 }
 
 type NewChainsConfig struct {
-	HomeChainSel   uint64
-	FeedChainSel   uint64
-	ChainsToDeploy []uint64
+	HomeChainSel   uint64   `jsonschema:"required"`
+	FeedChainSel   uint64   `jsonschema:"required"`
+	ChainsToDeploy []uint64 `jsonschema:"required"`
 	TokenConfig    TokenConfig
 	USDCConfig     USDCConfig
+	// AttestationProviders are the attestation-gated tokens other than USDC, see AttestationProvider.
+	AttestationProviders []AttestationProvider
 	// For setting OCR configuration
 	OCRSecrets deployment.OCRSecrets
-	OCRParams  map[uint64]CCIPOCRParams
+	// OCRSecretsProvider supplies the OCR secrets if OCRSecrets is empty, e.g. from a sealed file or KMS.
+	OCRSecretsProvider deployment.OCRSecretsProvider `json:"-"`
+	OCRParams          map[uint64]CCIPOCRParams
+	// ChainCharacteristics optionally lint the OCR params against the characteristics of the chains, see LintOCRParams.
+	ChainCharacteristics map[uint64]ChainCharacteristics
+	// PriceSource is the source of the token prices of the chains, the USD feeds of the TokenConfig on the feed chain if nil.
+	// Prices set on the FeeQuoters by the price source, see StaticPriceSource, go stale after the
+	// TokenPriceStalenessThreshold of the FeeQuoters unless they are refreshed with RefreshTokenPrices.
+	PriceSource PriceSource `json:"-"`
+	// Features enable USDC for the chains and enforce RMN or not on top of their OCRParams, see ChainFeatureFlags.
+	Features ChainFeatureFlags
 }
 
 func (c NewChainsConfig) Validate() error {
 	if err := deployment.IsValidChainSelector(c.HomeChainSel); err != nil {
 		return fmt.Errorf("invalid home chain selector: %d - %w", c.HomeChainSel, err)
 	}
-	if err := deployment.IsValidChainSelector(c.FeedChainSel); err != nil {
+	if err := deployment.IsValidChainSelector(c.FeedChainSel); err != nil && c.PriceSource == nil {
 		return fmt.Errorf("invalid feed chain selector: %d - %w", c.FeedChainSel, err)
 	}
 	mapChainsToDeploy := make(map[uint64]bool)
 	for _, cs := range c.ChainsToDeploy {
 		mapChainsToDeploy[cs] = true
-		if err := deployment.IsValidChainSelector(cs); err != nil {
+		if err := isValidDestChainSelector(cs); err != nil {
 			return fmt.Errorf("invalid chain selector: %d - %w", cs, err)
 		}
 	}
@@ -123,18 +248,13 @@ func (c NewChainsConfig) Validate() error {
 			return fmt.Errorf("invalid token config for token %s: %w", token, err)
 		}
 	}
-	if c.OCRSecrets.IsEmpty() {
+	if c.OCRSecrets.IsEmpty() && c.OCRSecretsProvider == nil {
 		return fmt.Errorf("no OCR secrets provided")
 	}
-	usdcEnabledChainMap := c.USDCConfig.EnabledChainMap()
-	for chain := range usdcEnabledChainMap {
-		if _, exists := mapChainsToDeploy[chain]; !exists {
-			return fmt.Errorf("chain %d is not in chains to deploy", chain)
-		}
-		if err := deployment.IsValidChainSelector(chain); err != nil {
-			return fmt.Errorf("invalid chain selector: %d - %w", chain, err)
-		}
+	if err := c.Features.Validate(c.ChainsToDeploy); err != nil {
+		return err
 	}
+	usdcEnabledChainMap := c.usdcAttestationProvider().EnabledChainMap()
 	for chain := range c.USDCConfig.CCTPTokenConfig {
 		if _, exists := mapChainsToDeploy[uint64(chain)]; !exists {
 			return fmt.Errorf("chain %d is not in chains to deploy", chain)
@@ -146,6 +266,9 @@ func (c NewChainsConfig) Validate() error {
 			return fmt.Errorf("invalid chain selector: %d - %w", chain, err)
 		}
 	}
+	if err := validateAttestationProviders(c.attestationProviders(), mapChainsToDeploy); err != nil {
+		return err
+	}
 	// Validate OCR params
 	var ocrChains []uint64
 	for chain, ocrParams := range c.OCRParams {
@@ -156,6 +279,9 @@ func (c NewChainsConfig) Validate() error {
 		if err := ocrParams.Validate(); err != nil {
 			return fmt.Errorf("invalid OCR params for chain %d: %w", chain, err)
 		}
+		if err := LintOCRParams(chain, ocrParams, c.ChainCharacteristics); err != nil {
+			return fmt.Errorf("OCR params for chain %d don't fit the chains: %w", chain, err)
+		}
 	}
 	sort.Slice(ocrChains, func(i, j int) bool { return ocrChains[i] < ocrChains[j] })
 	sort.Slice(c.ChainsToDeploy, func(i, j int) bool { return c.ChainsToDeploy[i] < c.ChainsToDeploy[j] })
@@ -165,6 +291,16 @@ func (c NewChainsConfig) Validate() error {
 	return nil
 }
 
+// attestationProviders returns the USDC config along with the other attestation providers.
+func (c NewChainsConfig) attestationProviders() []AttestationProvider {
+	return append([]AttestationProvider{c.usdcAttestationProvider()}, c.AttestationProviders...)
+}
+
+// usdcAttestationProvider returns the USDC config of the chains flagged with USDC.
+func (c NewChainsConfig) usdcAttestationProvider() usdcAttestationProvider {
+	return usdcAttestationProvider{USDCConfig: c.USDCConfig, chains: c.Features.USDCChains()}
+}
+
 func DefaultOCRParams(
 	feedChainSel uint64,
 	tokenInfo map[ccipocr3.UnknownEncodedAddress]pluginconfig.TokenInfo,
@@ -204,7 +340,8 @@ func DefaultOCRParams(
 			RMNEnabled:                         os.Getenv("ENABLE_RMN") == "true", // only enabled in manual test
 			RMNSignaturesTimeout:               30 * time.Minute,
 			MaxMerkleTreeSize:                  merklemulti.MaxNumberTreeLeaves,
-			SignObservationPrefix:              "chainlink ccip 1.6 rmn observation",
+			SignObservationPrefix:              RMNSignObservationPrefix,
 		},
+		PriceReporting: DefaultPriceReportingParams(),
 	}
 }
@@ -0,0 +1,62 @@
+package changeset
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/chainlink-ccip/pkg/types/ccipocr3"
+	"github.com/smartcontractkit/chainlink-ccip/pluginconfig"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPriceReportingParams(t *testing.T) {
+	require.NoError(t, DefaultPriceReportingParams().Validate())
+	for name, mutate := range map[string]func(*PriceReportingParams){
+		"zero token heartbeat": func(p *PriceReportingParams) { p.TokenPriceHeartbeat = 0 },
+		"zero da deviation":    func(p *PriceReportingParams) { p.DAGasPriceDeviationPPB = big.NewInt(0) },
+		"missing da deviation": func(p *PriceReportingParams) { p.DAGasPriceDeviationPPB = nil },
+	} {
+		t.Run(name, func(t *testing.T) {
+			params := DefaultPriceReportingParams()
+			mutate(&params)
+			require.NoError(t, params.Validate())
+		})
+	}
+	for name, mutate := range map[string]func(*PriceReportingParams){
+		"negative token heartbeat": func(p *PriceReportingParams) { p.TokenPriceHeartbeat = -time.Second },
+		"zero gas heartbeat":       func(p *PriceReportingParams) { p.GasPriceHeartbeat = 0 },
+		"zero token deviation":     func(p *PriceReportingParams) { p.TokenPriceDeviationPPB = big.NewInt(0) },
+		"missing gas deviation":    func(p *PriceReportingParams) { p.GasPriceDeviationPPB = nil },
+		"negative da deviation":    func(p *PriceReportingParams) { p.DAGasPriceDeviationPPB = big.NewInt(-1) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			params := DefaultPriceReportingParams()
+			mutate(&params)
+			require.Error(t, params.Validate())
+		})
+	}
+
+	token := ccipocr3.UnknownEncodedAddress("0x1")
+	params := CCIPOCRParams{
+		CommitOffChainConfig: pluginconfig.CommitOffchainConfig{
+			TokenInfo: map[ccipocr3.UnknownEncodedAddress]pluginconfig.TokenInfo{
+				token: {DeviationPPB: ccipocr3.NewBigIntFromInt64(1e9)},
+			},
+		},
+		PriceReporting: PriceReportingParams{
+			TokenPriceHeartbeat:    time.Hour,
+			TokenPriceDeviationPPB: big.NewInt(5e6),
+			GasPriceHeartbeat:      2 * time.Hour,
+			GasPriceDeviationPPB:   big.NewInt(5e7),
+			DAGasPriceDeviationPPB: big.NewInt(1e8),
+		},
+	}
+	applied := params.withPriceReporting()
+	require.Equal(t, time.Hour, applied.CommitOffChainConfig.TokenPriceBatchWriteFrequency.Duration())
+	require.Equal(t, 2*time.Hour, applied.CommitOffChainConfig.RemoteGasPriceBatchWriteFrequency.Duration())
+	require.Equal(t, int64(5e6), applied.CommitOffChainConfig.TokenInfo[token].DeviationPPB.Int64())
+	// the token info of the original params is left untouched
+	require.Equal(t, int64(1e9), params.CommitOffChainConfig.TokenInfo[token].DeviationPPB.Int64())
+}
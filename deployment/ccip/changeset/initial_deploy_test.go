@@ -1,12 +1,19 @@
-package changeset
+package changeset_test
 
 import (
+	"math/big"
+	"slices"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 
+	"github.com/smartcontractkit/chainlink-ccip/pkg/types/ccipocr3"
+
 	"github.com/smartcontractkit/chainlink-testing-framework/lib/utils/testcontext"
 
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset/testhelpers"
 	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/router"
 	"github.com/smartcontractkit/chainlink/v2/core/logger"
 
@@ -15,18 +22,18 @@ import (
 
 func TestInitialDeploy(t *testing.T) {
 	lggr := logger.TestLogger(t)
-	tenv := NewMemoryEnvironmentWithJobsAndContracts(t, lggr, 3, 4, nil)
+	tenv := testhelpers.NewMemoryEnvironmentWithJobsAndContracts(t, lggr, 3, 4, nil)
 	e := tenv.Env
 
-	state, err := LoadOnchainState(e)
+	state, err := changeset.LoadOnchainState(e)
 	require.NoError(t, err)
 	// Add all lanes
-	require.NoError(t, AddLanesForAll(e, state))
+	require.NoError(t, testhelpers.AddLanesForAll(e, state))
 	// Need to keep track of the block number for each chain so that event subscription can be done from that block.
 	startBlocks := make(map[uint64]*uint64)
 	// Send a message from each chain to every other chain.
-	expectedSeqNum := make(map[SourceDestPair]uint64)
-	expectedSeqNumExec := make(map[SourceDestPair][]uint64)
+	expectedSeqNum := make(map[changeset.SourceDestPair]uint64)
+	expectedSeqNumExec := make(map[changeset.SourceDestPair][]uint64)
 
 	for src := range e.Chains {
 		for dest, destChain := range e.Chains {
@@ -37,18 +44,18 @@ func TestInitialDeploy(t *testing.T) {
 			require.NoError(t, err)
 			block := latesthdr.Number.Uint64()
 			startBlocks[dest] = &block
-			msgSentEvent := TestSendRequest(t, e, state, src, dest, false, router.ClientEVM2AnyMessage{
+			msgSentEvent := testhelpers.TestSendRequest(t, e, state, src, dest, false, router.ClientEVM2AnyMessage{
 				Receiver:     common.LeftPadBytes(state.Chains[dest].Receiver.Address().Bytes(), 32),
 				Data:         []byte("hello"),
 				TokenAmounts: nil,
 				FeeToken:     common.HexToAddress("0x0"),
 				ExtraArgs:    nil,
 			})
-			expectedSeqNum[SourceDestPair{
+			expectedSeqNum[changeset.SourceDestPair{
 				SourceChainSelector: src,
 				DestChainSelector:   dest,
 			}] = msgSentEvent.SequenceNumber
-			expectedSeqNumExec[SourceDestPair{
+			expectedSeqNumExec[changeset.SourceDestPair{
 				SourceChainSelector: src,
 				DestChainSelector:   dest,
 			}] = []uint64{msgSentEvent.SequenceNumber}
@@ -56,14 +63,64 @@ func TestInitialDeploy(t *testing.T) {
 	}
 
 	// Wait for all commit reports to land.
-	ConfirmCommitForAllWithExpectedSeqNums(t, e, state, expectedSeqNum, startBlocks)
+	testhelpers.ConfirmCommitForAllWithExpectedSeqNums(t, e, state, expectedSeqNum, startBlocks)
 
 	// Confirm token and gas prices are updated
-	ConfirmTokenPriceUpdatedForAll(t, e, state, startBlocks,
-		DefaultInitialPrices.LinkPrice, DefaultInitialPrices.WethPrice)
+	testhelpers.ConfirmTokenPriceUpdatedForAll(t, e, state, startBlocks,
+		changeset.DefaultInitialPrices.LinkPrice, changeset.DefaultInitialPrices.WethPrice)
 	// TODO: Fix gas prices?
 	//ConfirmGasPriceUpdatedForAll(t, e, state, startBlocks)
 	//
 	//// Wait for all exec reports to land
-	ConfirmExecWithSeqNrsForAll(t, e, state, expectedSeqNumExec, startBlocks)
+	testhelpers.ConfirmExecWithSeqNrsForAll(t, e, state, expectedSeqNumExec, startBlocks)
+}
+
+func TestMultipleFeedChains(t *testing.T) {
+	lggr := logger.TestLogger(t)
+	tenv := testhelpers.NewMemoryEnvironmentWithJobsAndContracts(t, lggr, 4, 4, &testhelpers.TestConfigs{FeedChains: 2})
+	e := tenv.Env
+	require.Len(t, tenv.FeedChainSels, 2)
+	require.Equal(t, tenv.FeedChainSel, tenv.FeedChainSels[0])
+	require.Len(t, tenv.PriceFeedChains, 4)
+
+	state, err := changeset.LoadOnchainState(e)
+	require.NoError(t, err)
+	homeView, err := changeset.ViewCCIPHome(e, tenv.HomeChainSel)
+	require.NoError(t, err)
+	expectedLinkPrices := make(map[uint64]*big.Int)
+	for chain, feedChain := range tenv.PriceFeedChains {
+		don, ok := homeView.DONForChain(chain)
+		require.True(t, ok)
+		commitCfg := don.Commit.Active.CommitOffchainConfig
+		require.Equal(t, ccipocr3.ChainSelector(feedChain), commitCfg.PriceFeedChainSelector)
+		linkFeed := state.Chains[feedChain].USDFeeds[changeset.LinkSymbol].Address()
+		linkInfo := commitCfg.TokenInfo[ccipocr3.UnknownEncodedAddress(state.Chains[chain].LinkToken.Address().String())]
+		require.Equal(t, linkFeed, common.HexToAddress(string(linkInfo.AggregatorAddress)))
+		region := slices.Index(tenv.FeedChainSels, feedChain)
+		expectedLinkPrices[chain] = new(big.Int).Mul(testhelpers.MockLinkPrice, big.NewInt(int64(region+1)))
+	}
+
+	// the commit plugins report the prices of the feed chains of their regions
+	require.NoError(t, testhelpers.AddLanesForAll(e, state))
+	for src := range e.Chains {
+		for dest := range e.Chains {
+			if src == dest {
+				continue
+			}
+			testhelpers.TestSendRequest(t, e, state, src, dest, false, router.ClientEVM2AnyMessage{
+				Receiver:     common.LeftPadBytes(state.Chains[dest].Receiver.Address().Bytes(), 32),
+				Data:         []byte("hello"),
+				TokenAmounts: nil,
+				FeeToken:     common.HexToAddress("0x0"),
+				ExtraArgs:    nil,
+			})
+		}
+	}
+	for chain, expected := range expectedLinkPrices {
+		require.Eventually(t, func() bool {
+			price, err := state.Chains[chain].FeeQuoter.GetTokenPrice(nil, state.Chains[chain].LinkToken.Address())
+			require.NoError(t, err)
+			return price.Value.Cmp(expected) == 0
+		}, 2*time.Minute, time.Second, "LINK price of chain %d not read from feed chain %d", chain, tenv.PriceFeedChains[chain])
+	}
 }
@@ -0,0 +1,236 @@
+// accessorgen generates the typed contract accessors of CCIPOnChainState from the fields of CCIPChainState.
+// Run it with go generate from the changeset package.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// contract is the type and version of the contract bound to a field of CCIPChainState,
+// as the names of the ContractType constant and the deployment version variable.
+type contract struct {
+	Type    string
+	Version string
+}
+
+// contracts lists the fields of CCIPChainState which get accessors.
+// Fields holding maps of contracts are not included.
+var contracts = map[string]contract{
+	"OnRamp":                 {"OnRamp", "Version1_6_0_dev"},
+	"OffRamp":                {"OffRamp", "Version1_6_0_dev"},
+	"FeeQuoter":              {"FeeQuoter", "Version1_6_0_dev"},
+	"RMNProxyNew":            {"ARMProxy", "Version1_6_0_dev"},
+	"RMNProxyExisting":       {"ARMProxy", "Version1_0_0"},
+	"NonceManager":           {"NonceManager", "Version1_6_0_dev"},
+	"TokenAdminRegistry":     {"TokenAdminRegistry", "Version1_5_0"},
+	"RegistryModule":         {"RegistryModule", "Version1_5_0"},
+	"Router":                 {"Router", "Version1_2_0"},
+	"Weth9":                  {"WETH9", "Version1_0_0"},
+	"RMNRemote":              {"RMNRemote", "Version1_6_0_dev"},
+	"MockRMN":                {"MockRMN", "Version1_0_0"},
+	"LinkToken":              {"LinkToken", "Version1_0_0"},
+	"CapabilityRegistry":     {"CapabilitiesRegistry", "Version1_0_0"},
+	"CCIPHome":               {"CCIPHome", "Version1_6_0_dev"},
+	"RMNHome":                {"RMNHome", "Version1_6_0_dev"},
+	"CCIPConfig":             {"CCIPConfig", "Version1_0_0"},
+	"PriceRegistry":          {"PriceRegistry", "Version1_2_0"},
+	"Receiver":               {"CCIPReceiver", "Version1_0_0"},
+	"TestRouter":             {"TestRouter", "Version1_2_0"},
+	"USDCTokenPool":          {"USDCTokenPool", "Version1_0_0"},
+	"MockUSDCTransmitter":    {"USDCMockTransmitter", "Version1_0_0"},
+	"MockUSDCTokenMessenger": {"USDCTokenMessenger", "Version1_0_0"},
+	"Multicall3":             {"Multicall3", "Version1_0_0"},
+}
+
+type field struct {
+	Name    string
+	GoType  string
+	Type    string
+	Version string
+}
+
+type binding struct {
+	// Name is the name of the binding type without its package.
+	Name   string
+	GoType string
+	Fields []field
+}
+
+// semver formats the name of a deployment version variable as its version, e.g. Version1_6_0_dev as 1.6.0-dev.
+func semver(version string) string {
+	v := strings.Replace(strings.TrimPrefix(version, "Version"), "_", ".", 2)
+	return strings.ReplaceAll(v, "_", "-")
+}
+
+var tmpl = template.Must(template.New("accessors").Funcs(template.FuncMap{"semver": semver}).Parse(`// Code generated by accessorgen. DO NOT EDIT.
+
+package changeset
+
+import (
+	"github.com/smartcontractkit/chainlink/deployment"
+{{range .Imports}}
+	{{.}}{{end}}
+)
+{{range .Fields}}
+// TryGet{{.Name}} returns the {{.Type}} {{semver .Version}} of the chain, or an error if it is not deployed.
+func (s CCIPOnChainState) TryGet{{.Name}}(chainSelector uint64) ({{.GoType}}, error) {
+	tv := deployment.NewTypeAndVersion({{.Type}}, deployment.{{.Version}})
+	chainState, err := s.chainState(chainSelector)
+	if err != nil {
+		return nil, err
+	}
+	if chainState.{{.Name}} == nil {
+		return nil, &ContractNotFoundError{ChainSelector: chainSelector, TypeAndVersion: tv}
+	}
+	return chainState.{{.Name}}, nil
+}
+
+// MustGet{{.Name}} returns the {{.Type}} {{semver .Version}} of the chain, it panics if it is not deployed.
+func (s CCIPOnChainState) MustGet{{.Name}}(chainSelector uint64) {{.GoType}} {
+	c, err := s.TryGet{{.Name}}(chainSelector)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+{{end}}{{range .Bindings}}
+// TryGet{{.Name}}ByTypeAndVersion returns the {{.Name}} of the chain with the type and version, one of{{range .Fields}}
+// {{.Type}} {{semver .Version}},{{end}}
+// or an error if it is not deployed.
+func (s CCIPOnChainState) TryGet{{.Name}}ByTypeAndVersion(chainSelector uint64, tv deployment.TypeAndVersion) ({{.GoType}}, error) {
+	switch {
+{{- range .Fields}}
+	case tv.Equal(deployment.NewTypeAndVersion({{.Type}}, deployment.{{.Version}})):
+		return s.TryGet{{.Name}}(chainSelector)
+{{- end}}
+	default:
+		return nil, &UnknownContractError{TypeAndVersion: tv}
+	}
+}
+{{end}}`))
+
+func main() {
+	in := flag.String("in", "state.go", "file declaring CCIPChainState")
+	out := flag.String("out", "state_accessors.go", "generated file")
+	flag.Parse()
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, *in, nil, 0)
+	if err != nil {
+		log.Fatal(err)
+	}
+	imports := make(map[string]string)
+	for _, imp := range file.Imports {
+		path, err := strconv.Unquote(imp.Path.Value)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if imp.Name != nil {
+			imports[imp.Name.Name] = imp.Name.Name + " " + imp.Path.Value
+			continue
+		}
+		imports[path[strings.LastIndex(path, "/")+1:]] = imp.Path.Value
+	}
+
+	st := findStruct(file, "CCIPChainState")
+	if st == nil {
+		log.Fatalf("CCIPChainState not found in %s", *in)
+	}
+	var fields []field
+	bindings := make(map[string]*binding)
+	usedImports := make(map[string]struct{})
+	for _, f := range st.Fields.List {
+		star, ok := f.Type.(*ast.StarExpr)
+		if !ok {
+			continue
+		}
+		sel, ok := star.X.(*ast.SelectorExpr)
+		if !ok {
+			continue
+		}
+		var typ bytes.Buffer
+		if err := printer.Fprint(&typ, fset, f.Type); err != nil {
+			log.Fatal(err)
+		}
+		pkg := sel.X.(*ast.Ident).Name
+		for _, name := range f.Names {
+			c, ok := contracts[name.Name]
+			if !ok {
+				log.Fatalf("no contract type and version for field %s, add it to contracts", name.Name)
+			}
+			fd := field{Name: name.Name, GoType: typ.String(), Type: c.Type, Version: c.Version}
+			fields = append(fields, fd)
+			usedImports[imports[pkg]] = struct{}{}
+			b, ok := bindings[fd.GoType]
+			if !ok {
+				b = &binding{Name: sel.Sel.Name, GoType: fd.GoType}
+				bindings[fd.GoType] = b
+			}
+			b.Fields = append(b.Fields, fd)
+		}
+	}
+	if len(fields) != len(contracts) {
+		log.Fatalf("found %d of the %d fields in contracts", len(fields), len(contracts))
+	}
+
+	var shared []binding
+	for _, b := range bindings {
+		if len(b.Fields) > 1 {
+			shared = append(shared, *b)
+		}
+	}
+	sort.Slice(shared, func(i, j int) bool { return shared[i].Name < shared[j].Name })
+	importList := make([]string, 0, len(usedImports))
+	for imp := range usedImports {
+		importList = append(importList, imp)
+	}
+	sort.Strings(importList)
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, map[string]any{
+		"Imports":  importList,
+		"Fields":   fields,
+		"Bindings": shared,
+	}); err != nil {
+		log.Fatal(err)
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(fmt.Errorf("failed to format generated code: %w\n%s", err, buf.String()))
+	}
+	if err := os.WriteFile(*out, src, 0o600); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func findStruct(file *ast.File, name string) *ast.StructType {
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			ts := spec.(*ast.TypeSpec)
+			if ts.Name.Name != name {
+				continue
+			}
+			if st, ok := ts.Type.(*ast.StructType); ok {
+				return st
+			}
+		}
+	}
+	return nil
+}
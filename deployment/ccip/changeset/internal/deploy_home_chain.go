@@ -415,6 +415,22 @@ func BuildOCR3ConfigForCCIPHome(
 	ocrParams types2.OCRParameters,
 	commitOffchainCfg pluginconfig.CommitOffchainConfig,
 	execOffchainCfg pluginconfig.ExecuteOffchainConfig,
+) (map[types.PluginType]ccip_home.CCIPHomeOCR3Config, error) {
+	return BuildOCR3ConfigForOffRampAddress(ocrSecrets, offRamp.Address().Bytes(), dest.Selector, nodes,
+		rmnHomeAddress, ocrParams, commitOffchainCfg, execOffchainCfg)
+}
+
+// BuildOCR3ConfigForOffRampAddress is BuildOCR3ConfigForCCIPHome for offramps of any chain family,
+// e.g. the 32 byte object address of the CCIP package on Aptos chains.
+func BuildOCR3ConfigForOffRampAddress(
+	ocrSecrets deployment.OCRSecrets,
+	offRampAddress []byte,
+	destSelector uint64,
+	nodes deployment.Nodes,
+	rmnHomeAddress common.Address,
+	ocrParams types2.OCRParameters,
+	commitOffchainCfg pluginconfig.CommitOffchainConfig,
+	execOffchainCfg pluginconfig.ExecuteOffchainConfig,
 ) (map[types.PluginType]ccip_home.CCIPHomeOCR3Config, error) {
 	p2pIDs := nodes.PeerIDs()
 	// Get OCR3 Config from helper
@@ -422,16 +438,16 @@ func BuildOCR3ConfigForCCIPHome(
 	var oracles []confighelper.OracleIdentityExtra
 	for _, node := range nodes {
 		schedule = append(schedule, 1)
-		cfg, exists := node.OCRConfigForChainSelector(dest.Selector)
+		cfg, exists := node.OCRConfigForChainSelector(destSelector)
 		if !exists {
-			return nil, fmt.Errorf("no OCR config for chain %d", dest.Selector)
+			return nil, fmt.Errorf("no OCR config for chain %d", destSelector)
 		}
 		oracles = append(oracles, confighelper.OracleIdentityExtra{
 			OracleIdentity: confighelper.OracleIdentity{
 				OnchainPublicKey:  cfg.OnchainPublicKey,
 				TransmitAccount:   cfg.TransmitAccount,
 				OffchainPublicKey: cfg.OffchainPublicKey,
-				PeerID:            cfg.PeerID.String()[4:],
+				PeerID:            cfg.PeerID.Raw(),
 			}, ConfigEncryptionPublicKey: cfg.ConfigEncryptionPublicKey,
 		})
 	}
@@ -524,10 +540,10 @@ func BuildOCR3ConfigForCCIPHome(
 
 		ocr3Configs[pluginType] = ccip_home.CCIPHomeOCR3Config{
 			PluginType:            uint8(pluginType),
-			ChainSelector:         dest.Selector,
+			ChainSelector:         destSelector,
 			FRoleDON:              configF,
 			OffchainConfigVersion: offchainConfigVersion,
-			OfframpAddress:        offRamp.Address().Bytes(),
+			OfframpAddress:        offRampAddress,
 			Nodes:                 ocrNodes,
 			OffchainConfig:        offchainConfig,
 			RmnHomeAddress:        rmnHomeAddress.Bytes(),
@@ -1,8 +1,12 @@
 package changeset
 
 import (
+	"fmt"
+	"reflect"
+
 	"github.com/smartcontractkit/chainlink/deployment"
 	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset/internal"
+	cctypes "github.com/smartcontractkit/chainlink/v2/core/capabilities/ccip/types"
 	"github.com/smartcontractkit/chainlink/v2/core/capabilities/ccip/validate"
 	"github.com/smartcontractkit/chainlink/v2/core/services/relay"
 )
@@ -10,10 +14,18 @@ import (
 // In our case, the only address needed is the cap registry which is actually an env var.
 // and will pre-exist for our deployment. So the job specs only depend on the environment operators.
 func NewCCIPJobSpecs(nodeIds []string, oc deployment.OffchainClient) (map[string][]string, error) {
+	return NewCCIPJobSpecsWithOverrides(nodeIds, oc, JobSpecOverrides{})
+}
+
+// NewCCIPJobSpecsWithOverrides is NewCCIPJobSpecs with the overrides applied to the job specs of the nodes.
+func NewCCIPJobSpecsWithOverrides(nodeIds []string, oc deployment.OffchainClient, overrides JobSpecOverrides) (map[string][]string, error) {
 	nodes, err := deployment.NodeInfo(nodeIds, oc)
 	if err != nil {
 		return nil, err
 	}
+	if err := overrides.Validate(nodes); err != nil {
+		return nil, fmt.Errorf("invalid job spec overrides: %w", err)
+	}
 	// Generate a set of brand new job specs for CCIP for a specific environment
 	// (including NOPs) and new addresses.
 	// We want to assign one CCIP capability job to each node. And node with
@@ -25,29 +37,10 @@ func NewCCIPJobSpecs(nodeIds []string, oc deployment.OffchainClient) (map[string
 		var spec string
 		var err error
 		if !node.IsBootstrap {
-			spec, err = validate.NewCCIPSpecToml(validate.SpecArgs{
-				P2PV2Bootstrappers:     nodes.BootstrapLocators(),
-				CapabilityVersion:      internal.CapabilityVersion,
-				CapabilityLabelledName: internal.CapabilityLabelledName,
-				OCRKeyBundleIDs: map[string]string{
-					// TODO: Validate that that all EVM chains are using the same keybundle.
-					relay.NetworkEVM: node.FirstOCRKeybundle().KeyBundleID,
-				},
-				P2PKeyID:     node.PeerID.String(),
-				RelayConfigs: nil,
-				PluginConfig: map[string]any{},
-			})
+			// TODO: Validate that that all EVM chains are using the same keybundle.
+			spec, err = newCCIPOracleSpec(node, nodes.BootstrapLocators(), node.FirstOCRKeybundle().KeyBundleID, overrides.forNode(node))
 		} else {
-			spec, err = validate.NewCCIPSpecToml(validate.SpecArgs{
-				P2PV2Bootstrappers:     []string{}, // Intentionally empty for bootstraps.
-				CapabilityVersion:      internal.CapabilityVersion,
-				CapabilityLabelledName: internal.CapabilityLabelledName,
-				OCRKeyBundleIDs:        map[string]string{},
-				// TODO: validate that all EVM chains are using the same keybundle
-				P2PKeyID:     node.PeerID.String(),
-				RelayConfigs: nil,
-				PluginConfig: map[string]any{},
-			})
+			spec, err = newCCIPBootstrapSpec(node, overrides.forNode(node))
 		}
 		if err != nil {
 			return nil, err
@@ -56,3 +49,276 @@ func NewCCIPJobSpecs(nodeIds []string, oc deployment.OffchainClient) (map[string
 	}
 	return nodesToJobSpecs, nil
 }
+
+// JobSpecOverride overrides fields of the CCIP job spec of a node, e.g. for nodes running on different
+// infrastructure than the rest of the DON.
+type JobSpecOverride struct {
+	// P2PV2Bootstrappers replaces the bootstrappers of the environment, e.g. for nodes reaching them through
+	// other endpoints. It is ignored for bootstrap nodes.
+	P2PV2Bootstrappers []string
+	// RelayConfigs are set in the relay configs of the spec by chain family, e.g. "evm".
+	RelayConfigs map[string]any
+	// PluginConfig fields are set in the plugin config of the spec.
+	PluginConfig map[string]any
+}
+
+// merge returns the override with the unset fields taken from the defaults.
+// The relay and plugin configs are merged field by field, the fields of the override taking precedence.
+func (o JobSpecOverride) merge(defaults JobSpecOverride) JobSpecOverride {
+	if o.P2PV2Bootstrappers == nil {
+		o.P2PV2Bootstrappers = defaults.P2PV2Bootstrappers
+	}
+	o.RelayConfigs = mergeSpecFields(defaults.RelayConfigs, o.RelayConfigs)
+	o.PluginConfig = mergeSpecFields(defaults.PluginConfig, o.PluginConfig)
+	return o
+}
+
+func mergeSpecFields(defaults, overrides map[string]any) map[string]any {
+	merged := make(map[string]any, len(defaults)+len(overrides))
+	for k, v := range defaults {
+		merged[k] = v
+	}
+	for k, v := range overrides {
+		merged[k] = v
+	}
+	return merged
+}
+
+// JobSpecOverrides are the overrides of the CCIP job specs of the nodes.
+type JobSpecOverrides struct {
+	// Defaults apply to all the nodes.
+	Defaults JobSpecOverride
+	// Nodes are the overrides of the defaults by JD node ID or peer ID.
+	Nodes map[string]JobSpecOverride
+}
+
+// Validate checks that the overrides are for nodes of the DON.
+func (o JobSpecOverrides) Validate(nodes deployment.Nodes) error {
+	known := make(map[string]struct{}, 2*len(nodes))
+	for _, node := range nodes {
+		known[node.NodeID] = struct{}{}
+		known[node.PeerID.String()] = struct{}{}
+	}
+	for id := range o.Nodes {
+		if _, ok := known[id]; !ok {
+			return fmt.Errorf("job spec override for unknown node %s", id)
+		}
+	}
+	return nil
+}
+
+// forNode returns the override of the node merged with the defaults.
+func (o JobSpecOverrides) forNode(node deployment.Node) JobSpecOverride {
+	override, ok := o.Nodes[node.NodeID]
+	if !ok {
+		override = o.Nodes[node.PeerID.String()]
+	}
+	return override.merge(o.Defaults)
+}
+
+// DONTopology describes how the nodes of an environment are split into CCIP DONs,
+// which allows the commit and exec plugins to be run and scaled by different node subsets.
+type DONTopology struct {
+	// DONs is the list of DONs making up the topology.
+	DONs []DONSpec
+	// BootstrapNodeIDs are the nodes acting as bootstrappers for all DONs in the topology.
+	BootstrapNodeIDs []string
+	// JobSpecOverrides optionally override the job specs of the DON members and bootstrap nodes.
+	JobSpecOverrides JobSpecOverrides
+}
+
+// DONSpec describes the membership of a single CCIP DON.
+type DONSpec struct {
+	Name string
+	// PluginTypes are the plugins the DON runs, e.g. commit only, exec only or both.
+	PluginTypes []cctypes.PluginType
+	// NodeIDs are the JD IDs (or peer IDs) of the DON members.
+	NodeIDs []string
+	// OCRKeyBundleIDs optionally overrides the EVM OCR key bundle used by a DON member, keyed by the
+	// same kind of ID as NodeIDs.
+	// Members without an override use their first OCR key bundle.
+	OCRKeyBundleIDs map[string]string
+	// JobSpecOverrides optionally override the job specs of the DON members, on top of the overrides
+	// of the topology.
+	JobSpecOverrides JobSpecOverrides
+}
+
+func (t DONTopology) Validate() error {
+	if len(t.DONs) == 0 {
+		return fmt.Errorf("at least one DON must be set")
+	}
+	if len(t.BootstrapNodeIDs) == 0 {
+		return fmt.Errorf("at least one bootstrap node must be set")
+	}
+	bootstraps := make(map[string]struct{})
+	for _, id := range t.BootstrapNodeIDs {
+		bootstraps[id] = struct{}{}
+	}
+	names := make(map[string]struct{})
+	plugins := make(map[cctypes.PluginType]struct{})
+	for _, don := range t.DONs {
+		if don.Name == "" {
+			return fmt.Errorf("DON name must be set")
+		}
+		if _, ok := names[don.Name]; ok {
+			return fmt.Errorf("duplicate DON name %s", don.Name)
+		}
+		names[don.Name] = struct{}{}
+		if len(don.PluginTypes) == 0 {
+			return fmt.Errorf("DON %s must run at least one plugin", don.Name)
+		}
+		for _, pluginType := range don.PluginTypes {
+			if pluginType != cctypes.PluginTypeCCIPCommit && pluginType != cctypes.PluginTypeCCIPExec {
+				return fmt.Errorf("DON %s has unsupported plugin type %d", don.Name, pluginType)
+			}
+			plugins[pluginType] = struct{}{}
+		}
+		if len(don.NodeIDs) == 0 {
+			return fmt.Errorf("DON %s must have at least one node", don.Name)
+		}
+		members := make(map[string]struct{})
+		for _, id := range don.NodeIDs {
+			if _, ok := bootstraps[id]; ok {
+				return fmt.Errorf("bootstrap node %s cannot be a member of DON %s", id, don.Name)
+			}
+			if _, ok := members[id]; ok {
+				return fmt.Errorf("node %s is listed twice in DON %s", id, don.Name)
+			}
+			members[id] = struct{}{}
+		}
+		for id := range don.OCRKeyBundleIDs {
+			if _, ok := members[id]; !ok {
+				return fmt.Errorf("DON %s has an OCR key bundle override for non-member node %s", don.Name, id)
+			}
+		}
+	}
+	for _, pluginType := range []cctypes.PluginType{cctypes.PluginTypeCCIPCommit, cctypes.PluginTypeCCIPExec} {
+		if _, ok := plugins[pluginType]; !ok {
+			return fmt.Errorf("no DON runs the %s plugin", pluginType.String())
+		}
+	}
+	return nil
+}
+
+// NewCCIPJobSpecsForTopology generates CCIP job specs for a topology where commit and exec may be
+// run by distinct DONs. The job spec of every DON member is rendered for its DON, with the OCR key bundle
+// and job spec overrides of the DON, and every bootstrap node gets a bootstrap job. Since a node runs one
+// CCIP job for all of its DONs, the job specs rendered for a node member of several DONs must be the same.
+func NewCCIPJobSpecsForTopology(topology DONTopology, oc deployment.OffchainClient) (map[string][]string, error) {
+	if err := topology.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid DON topology: %w", err)
+	}
+	bootstraps, err := deployment.NodeInfo(topology.BootstrapNodeIDs, oc)
+	if err != nil {
+		return nil, err
+	}
+	for _, node := range bootstraps {
+		if !node.IsBootstrap {
+			return nil, fmt.Errorf("node %s is not a bootstrap node", node.NodeID)
+		}
+	}
+	locators := bootstraps.BootstrapLocators()
+	overridden := append(deployment.Nodes{}, bootstraps...)
+
+	// Render the spec of every member for each of its DONs, making sure it is consistent across DONs.
+	var members deployment.Nodes
+	specs := make(map[string]validate.SpecArgs)
+	specDONs := make(map[string]string)
+	for _, don := range topology.DONs {
+		nodes, err := deployment.NodeInfo(don.NodeIDs, oc)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get node info for DON %s: %w", don.Name, err)
+		}
+		if err := don.JobSpecOverrides.Validate(nodes); err != nil {
+			return nil, fmt.Errorf("invalid job spec overrides of DON %s: %w", don.Name, err)
+		}
+		for _, node := range nodes {
+			if node.IsBootstrap {
+				return nil, fmt.Errorf("node %s of DON %s is a bootstrap node", node.NodeID, don.Name)
+			}
+			keyBundle, ok := don.OCRKeyBundleIDs[node.NodeID]
+			if !ok {
+				keyBundle, ok = don.OCRKeyBundleIDs[node.PeerID.String()]
+			}
+			if !ok {
+				keyBundle = node.FirstOCRKeybundle().KeyBundleID
+			}
+			if keyBundle == "" {
+				return nil, fmt.Errorf("node %s of DON %s has no OCR key bundle", node.NodeID, don.Name)
+			}
+			override := don.JobSpecOverrides.forNode(node).merge(topology.JobSpecOverrides.forNode(node))
+			spec := ccipOracleSpecArgs(node, locators, keyBundle, override)
+			existing, ok := specs[node.NodeID]
+			if !ok {
+				specs[node.NodeID] = spec
+				specDONs[node.NodeID] = don.Name
+				members = append(members, node)
+				overridden = append(overridden, node)
+				continue
+			}
+			if existing.OCRKeyBundleIDs[relay.NetworkEVM] != keyBundle {
+				return nil, fmt.Errorf("node %s uses OCR key bundle %s in DON %s but %s in DON %s",
+					node.NodeID, existing.OCRKeyBundleIDs[relay.NetworkEVM], specDONs[node.NodeID], keyBundle, don.Name)
+			}
+			if !reflect.DeepEqual(existing, spec) {
+				return nil, fmt.Errorf("node %s runs a single CCIP job for DONs %s and %s but their job specs differ",
+					node.NodeID, specDONs[node.NodeID], don.Name)
+			}
+		}
+	}
+
+	if err := topology.JobSpecOverrides.Validate(overridden); err != nil {
+		return nil, fmt.Errorf("invalid job spec overrides: %w", err)
+	}
+
+	nodesToJobSpecs := make(map[string][]string)
+	for _, node := range members {
+		spec, err := validate.NewCCIPSpecToml(specs[node.NodeID])
+		if err != nil {
+			return nil, err
+		}
+		nodesToJobSpecs[node.NodeID] = append(nodesToJobSpecs[node.NodeID], spec)
+	}
+	for _, node := range bootstraps {
+		spec, err := newCCIPBootstrapSpec(node, topology.JobSpecOverrides.forNode(node))
+		if err != nil {
+			return nil, err
+		}
+		nodesToJobSpecs[node.NodeID] = append(nodesToJobSpecs[node.NodeID], spec)
+	}
+	return nodesToJobSpecs, nil
+}
+
+func newCCIPOracleSpec(node deployment.Node, bootstrappers []string, keyBundleID string, override JobSpecOverride) (string, error) {
+	return validate.NewCCIPSpecToml(ccipOracleSpecArgs(node, bootstrappers, keyBundleID, override))
+}
+
+func ccipOracleSpecArgs(node deployment.Node, bootstrappers []string, keyBundleID string, override JobSpecOverride) validate.SpecArgs {
+	if override.P2PV2Bootstrappers != nil {
+		bootstrappers = override.P2PV2Bootstrappers
+	}
+	return validate.SpecArgs{
+		P2PV2Bootstrappers:     bootstrappers,
+		CapabilityVersion:      internal.CapabilityVersion,
+		CapabilityLabelledName: internal.CapabilityLabelledName,
+		OCRKeyBundleIDs: map[string]string{
+			relay.NetworkEVM: keyBundleID,
+		},
+		P2PKeyID:     node.PeerID.String(),
+		RelayConfigs: override.RelayConfigs,
+		PluginConfig: override.PluginConfig,
+	}
+}
+
+func newCCIPBootstrapSpec(node deployment.Node, override JobSpecOverride) (string, error) {
+	return validate.NewCCIPSpecToml(validate.SpecArgs{
+		P2PV2Bootstrappers:     []string{}, // Intentionally empty for bootstraps.
+		CapabilityVersion:      internal.CapabilityVersion,
+		CapabilityLabelledName: internal.CapabilityLabelledName,
+		OCRKeyBundleIDs:        map[string]string{},
+		// TODO: validate that all EVM chains are using the same keybundle
+		P2PKeyID:     node.PeerID.String(),
+		RelayConfigs: override.RelayConfigs,
+		PluginConfig: override.PluginConfig,
+	})
+}
@@ -22,3 +22,38 @@ func CCIPCapabilityJobspec(env deployment.Environment, _ any) (deployment.Change
 		JobSpecs:    js,
 	}, nil
 }
+
+var _ deployment.ChangeSet[DONTopology] = CCIPCapabilityJobspecForTopology
+
+// CCIPCapabilityJobspecForTopology returns the job specs for the CCIP capability when commit and exec
+// are run by separate DONs, as described by the given topology.
+// The caller needs to propose these job specs to the offchain system.
+func CCIPCapabilityJobspecForTopology(env deployment.Environment, topology DONTopology) (deployment.ChangesetOutput, error) {
+	js, err := NewCCIPJobSpecsForTopology(topology, env.Offchain)
+	if err != nil {
+		return deployment.ChangesetOutput{}, errors.Wrapf(err, "failed to create job specs")
+	}
+	return deployment.ChangesetOutput{
+		Proposals:   []timelock.MCMSWithTimelockProposal{},
+		AddressBook: nil,
+		JobSpecs:    js,
+	}, nil
+}
+
+var _ deployment.ChangeSet[JobSpecOverrides] = CCIPCapabilityJobspecWithOverrides
+
+// CCIPCapabilityJobspecWithOverrides returns the job specs for the CCIP capability like CCIPCapabilityJobspec,
+// with the overrides applied to the job specs of the nodes, e.g. their relay configs for nodes using
+// different RPC endpoints.
+// The caller needs to propose these job specs to the offchain system.
+func CCIPCapabilityJobspecWithOverrides(env deployment.Environment, overrides JobSpecOverrides) (deployment.ChangesetOutput, error) {
+	js, err := NewCCIPJobSpecsWithOverrides(env.NodeIDs, env.Offchain, overrides)
+	if err != nil {
+		return deployment.ChangesetOutput{}, errors.Wrapf(err, "failed to create job specs")
+	}
+	return deployment.ChangesetOutput{
+		Proposals:   []timelock.MCMSWithTimelockProposal{},
+		AddressBook: nil,
+		JobSpecs:    js,
+	}, nil
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/smartcontractkit/chainlink/deployment"
 	"github.com/smartcontractkit/chainlink/deployment/environment/memory"
+	cctypes "github.com/smartcontractkit/chainlink/v2/core/capabilities/ccip/types"
 	ccip "github.com/smartcontractkit/chainlink/v2/core/capabilities/ccip/validate"
 	"github.com/smartcontractkit/chainlink/v2/core/logger"
 )
@@ -33,3 +34,162 @@ func TestJobSpecChangeset(t *testing.T) {
 		}
 	}
 }
+
+func TestJobSpecChangesetForTopology(t *testing.T) {
+	lggr := logger.TestLogger(t)
+	e := memory.NewMemoryEnvironment(t, lggr, zapcore.InfoLevel, memory.MemoryEnvironmentConfig{
+		Chains:     1,
+		Nodes:      6,
+		Bootstraps: 1,
+	})
+	nodes, err := deployment.NodeInfo(e.NodeIDs, e.Offchain)
+	require.NoError(t, err)
+	var bootstrapIDs, oracleIDs []string
+	for _, node := range nodes {
+		if node.IsBootstrap {
+			bootstrapIDs = append(bootstrapIDs, node.NodeID)
+		} else {
+			oracleIDs = append(oracleIDs, node.NodeID)
+		}
+	}
+	require.Len(t, bootstrapIDs, 1)
+	require.Len(t, oracleIDs, 6)
+
+	commitDON := DONSpec{Name: "commit", PluginTypes: []cctypes.PluginType{cctypes.PluginTypeCCIPCommit}, NodeIDs: oracleIDs[:4]}
+	execDON := DONSpec{Name: "exec", PluginTypes: []cctypes.PluginType{cctypes.PluginTypeCCIPExec}, NodeIDs: oracleIDs[2:]}
+
+	t.Run("separate commit and exec DONs", func(t *testing.T) {
+		output, err := CCIPCapabilityJobspecForTopology(e, DONTopology{
+			DONs:             []DONSpec{commitDON, execDON},
+			BootstrapNodeIDs: bootstrapIDs,
+		})
+		require.NoError(t, err)
+		require.Len(t, output.JobSpecs, len(nodes))
+		for _, node := range nodes {
+			jobs, exists := output.JobSpecs[node.NodeID]
+			require.True(t, exists)
+			// Nodes in both DONs still only get a single job.
+			require.Len(t, jobs, 1)
+			jb, err := ccip.ValidatedCCIPSpec(jobs[0])
+			require.NoError(t, err)
+			if node.IsBootstrap {
+				require.Empty(t, jb.CCIPSpec.P2PV2Bootstrappers)
+				continue
+			}
+			require.Equal(t, []string(jb.CCIPSpec.P2PV2Bootstrappers), nodes.BootstrapLocators())
+			require.Equal(t, node.FirstOCRKeybundle().KeyBundleID, jb.CCIPSpec.OCRKeyBundleIDs["evm"])
+		}
+	})
+
+	t.Run("key bundle override", func(t *testing.T) {
+		override := execDON
+		override.NodeIDs = oracleIDs[4:]
+		override.OCRKeyBundleIDs = map[string]string{oracleIDs[5]: "custom-bundle"}
+		output, err := CCIPCapabilityJobspecForTopology(e, DONTopology{
+			DONs:             []DONSpec{commitDON, override},
+			BootstrapNodeIDs: bootstrapIDs,
+		})
+		require.NoError(t, err)
+		jb, err := ccip.ValidatedCCIPSpec(output.JobSpecs[oracleIDs[5]][0])
+		require.NoError(t, err)
+		require.Equal(t, "custom-bundle", jb.CCIPSpec.OCRKeyBundleIDs["evm"])
+	})
+
+	t.Run("conflicting key bundles", func(t *testing.T) {
+		conflict := execDON
+		conflict.OCRKeyBundleIDs = map[string]string{oracleIDs[2]: "custom-bundle"}
+		_, err := CCIPCapabilityJobspecForTopology(e, DONTopology{
+			DONs:             []DONSpec{commitDON, conflict},
+			BootstrapNodeIDs: bootstrapIDs,
+		})
+		require.ErrorContains(t, err, "uses OCR key bundle")
+	})
+
+	t.Run("job specs rendered per DON", func(t *testing.T) {
+		commitOnly := commitDON
+		commitOnly.NodeIDs = oracleIDs[:3]
+		commitOnly.JobSpecOverrides = JobSpecOverrides{Defaults: JobSpecOverride{PluginConfig: map[string]any{"don": "commit"}}}
+		execOnly := execDON
+		execOnly.NodeIDs = oracleIDs[3:]
+		execOnly.JobSpecOverrides = JobSpecOverrides{Defaults: JobSpecOverride{PluginConfig: map[string]any{"don": "exec"}}}
+		output, err := CCIPCapabilityJobspecForTopology(e, DONTopology{
+			DONs:             []DONSpec{commitOnly, execOnly},
+			BootstrapNodeIDs: bootstrapIDs,
+		})
+		require.NoError(t, err)
+		for i, id := range oracleIDs {
+			jb, err := ccip.ValidatedCCIPSpec(output.JobSpecs[id][0])
+			require.NoError(t, err)
+			if i < 3 {
+				require.Equal(t, "commit", jb.CCIPSpec.PluginConfig["don"])
+			} else {
+				require.Equal(t, "exec", jb.CCIPSpec.PluginConfig["don"])
+			}
+		}
+
+		// the members of both DONs run a single job, which can't be rendered differently per DON
+		commitOnly.NodeIDs = oracleIDs[:4]
+		_, err = CCIPCapabilityJobspecForTopology(e, DONTopology{
+			DONs:             []DONSpec{commitOnly, execOnly},
+			BootstrapNodeIDs: bootstrapIDs,
+		})
+		require.ErrorContains(t, err, "job specs differ")
+	})
+
+	t.Run("invalid topology", func(t *testing.T) {
+		_, err := CCIPCapabilityJobspecForTopology(e, DONTopology{
+			DONs:             []DONSpec{commitDON},
+			BootstrapNodeIDs: bootstrapIDs,
+		})
+		require.ErrorContains(t, err, "no DON runs the CCIPExec plugin")
+
+		_, err = CCIPCapabilityJobspecForTopology(e, DONTopology{
+			DONs:             []DONSpec{commitDON, {Name: "exec", PluginTypes: []cctypes.PluginType{cctypes.PluginTypeCCIPExec}, NodeIDs: bootstrapIDs}},
+			BootstrapNodeIDs: bootstrapIDs,
+		})
+		require.ErrorContains(t, err, "cannot be a member")
+	})
+}
+
+func TestJobSpecChangesetWithOverrides(t *testing.T) {
+	lggr := logger.TestLogger(t)
+	e := memory.NewMemoryEnvironment(t, lggr, zapcore.InfoLevel, memory.MemoryEnvironmentConfig{
+		Chains:     1,
+		Nodes:      4,
+		Bootstraps: 1,
+	})
+	nodes, err := deployment.NodeInfo(e.NodeIDs, e.Offchain)
+	require.NoError(t, err)
+	oracles := nodes.NonBootstraps()
+	overridden := oracles[0]
+	overrides := JobSpecOverrides{
+		Defaults: JobSpecOverride{
+			RelayConfigs: map[string]any{"evm": map[string]any{"endpoint": "default"}},
+			PluginConfig: map[string]any{"tokenDataObservers": "default"},
+		},
+		Nodes: map[string]JobSpecOverride{
+			overridden.PeerID.String(): {
+				P2PV2Bootstrappers: []string{"12D3KooWMoejJznyDuEk5aX6GvbjaG12UzeornPCBNzMRqdwrFJw@other-host:6690"},
+				RelayConfigs:       map[string]any{"evm": map[string]any{"endpoint": "custom"}},
+			},
+		},
+	}
+	output, err := CCIPCapabilityJobspecWithOverrides(e, overrides)
+	require.NoError(t, err)
+	for _, node := range oracles {
+		jb, err := ccip.ValidatedCCIPSpec(output.JobSpecs[node.NodeID][0])
+		require.NoError(t, err)
+		require.Equal(t, "default", jb.CCIPSpec.PluginConfig["tokenDataObservers"])
+		if node.NodeID != overridden.NodeID {
+			require.Equal(t, nodes.BootstrapLocators(), []string(jb.CCIPSpec.P2PV2Bootstrappers))
+			require.Equal(t, map[string]any{"endpoint": "default"}, jb.CCIPSpec.RelayConfigs["evm"])
+			continue
+		}
+		require.Equal(t, overrides.Nodes[node.PeerID.String()].P2PV2Bootstrappers, []string(jb.CCIPSpec.P2PV2Bootstrappers))
+		require.Equal(t, map[string]any{"endpoint": "custom"}, jb.CCIPSpec.RelayConfigs["evm"])
+	}
+
+	overrides.Nodes = map[string]JobSpecOverride{"unknown": {}}
+	_, err = CCIPCapabilityJobspecWithOverrides(e, overrides)
+	require.ErrorContains(t, err, "job spec override for unknown node unknown")
+}
@@ -0,0 +1,153 @@
+package changeset
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/proposal/mcms"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/proposal/timelock"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	cctypes "github.com/smartcontractkit/chainlink/v2/core/capabilities/ccip/types"
+)
+
+// Rotating the OCR keys of CCIP nodes is done in the following steps:
+//  1. RotateOCRKeyBundles creates new key bundles on the nodes, which JD starts advertising.
+//  2. RotateOCRKeysChangeset sets candidate configs carrying the new keys on the CCIPHome
+//     and re-proposes the CCIP jobs with the new key bundles.
+//  3. PromoteAllCandidatesChangeset promotes the candidates for every chain.
+//  4. DeleteRetiredOCRKeyBundles deletes the old key bundles once their grace period has passed.
+
+// RetiredOCRKeyBundle is a key bundle which was replaced on a node by RotateOCRKeyBundles.
+type RetiredOCRKeyBundle struct {
+	NodeID    string
+	BundleID  string
+	RetiredAt time.Time
+}
+
+// RotateOCRKeyBundles creates a new EVM OCR key bundle on each of the given nodes and returns the bundles
+// which they replaced. The offchain client must implement deployment.OCRKeyRotator.
+func RotateOCRKeyBundles(ctx context.Context, oc deployment.OffchainClient, nodeIDs []string) ([]RetiredOCRKeyBundle, error) {
+	rotator, ok := oc.(deployment.OCRKeyRotator)
+	if !ok {
+		return nil, fmt.Errorf("offchain client %T does not support key rotation", oc)
+	}
+	nodes, err := deployment.NodeInfo(nodeIDs, oc)
+	if err != nil {
+		return nil, err
+	}
+	var retired []RetiredOCRKeyBundle
+	for _, node := range nodes.NonBootstraps() {
+		old := node.FirstOCRKeybundle().KeyBundleID
+		if _, err := rotator.CreateOCRKeyBundle(ctx, node.NodeID); err != nil {
+			return retired, fmt.Errorf("failed to create key bundle for node %s: %w", node.NodeID, err)
+		}
+		retired = append(retired, RetiredOCRKeyBundle{
+			NodeID:    node.NodeID,
+			BundleID:  old,
+			RetiredAt: time.Now(),
+		})
+	}
+	return retired, nil
+}
+
+// DeleteRetiredOCRKeyBundles deletes the retired key bundles whose grace period has passed, and returns
+// the ones which still need to be kept around.
+func DeleteRetiredOCRKeyBundles(ctx context.Context, oc deployment.OffchainClient, retired []RetiredOCRKeyBundle, gracePeriod time.Duration) ([]RetiredOCRKeyBundle, error) {
+	rotator, ok := oc.(deployment.OCRKeyRotator)
+	if !ok {
+		return nil, fmt.Errorf("offchain client %T does not support key rotation", oc)
+	}
+	var remaining []RetiredOCRKeyBundle
+	for i, bundle := range retired {
+		if time.Since(bundle.RetiredAt) < gracePeriod {
+			remaining = append(remaining, bundle)
+			continue
+		}
+		if err := rotator.DeleteOCRKeyBundle(ctx, bundle.NodeID, bundle.BundleID); err != nil {
+			return append(remaining, retired[i:]...), fmt.Errorf("failed to delete key bundle %s of node %s: %w", bundle.BundleID, bundle.NodeID, err)
+		}
+	}
+	return remaining, nil
+}
+
+var _ deployment.ChangeSet[RotateOCRKeysConfig] = RotateOCRKeysChangeset
+
+type RotateOCRKeysConfig struct {
+	HomeChainSel uint64
+	FeedChainSel uint64
+	// ChainSelectors are the chains whose DONs get their OCR configs updated.
+	ChainSelectors []uint64
+	TokenConfig    TokenConfig
+	OCRSecrets     deployment.OCRSecrets
+}
+
+func (c RotateOCRKeysConfig) Validate() error {
+	if err := deployment.IsValidChainSelector(c.HomeChainSel); err != nil {
+		return fmt.Errorf("invalid home chain selector: %d - %w", c.HomeChainSel, err)
+	}
+	if err := deployment.IsValidChainSelector(c.FeedChainSel); err != nil {
+		return fmt.Errorf("invalid feed chain selector: %d - %w", c.FeedChainSel, err)
+	}
+	if len(c.ChainSelectors) == 0 {
+		return fmt.Errorf("no chains to update")
+	}
+	for _, cs := range c.ChainSelectors {
+		if err := deployment.IsValidChainSelector(cs); err != nil {
+			return fmt.Errorf("invalid chain selector: %d - %w", cs, err)
+		}
+	}
+	if c.OCRSecrets.IsEmpty() {
+		return fmt.Errorf("no OCR secrets provided")
+	}
+	return nil
+}
+
+// RotateOCRKeysChangeset generates a proposal setting candidate commit and exec configs on the CCIPHome
+// with the OCR keys currently advertised by the nodes, along with CCIP job specs using their current key
+// bundles. It is meant to be applied after RotateOCRKeyBundles, and followed by PromoteAllCandidatesChangeset
+// for every chain once the proposal is executed.
+// Signers in the CapabilityRegistry are derived from the P2P IDs of the nodes and are not affected.
+func RotateOCRKeysChangeset(e deployment.Environment, cfg RotateOCRKeysConfig) (deployment.ChangesetOutput, error) {
+	if err := cfg.Validate(); err != nil {
+		return deployment.ChangesetOutput{}, errors.Wrapf(err, "invalid config")
+	}
+	state, err := LoadOnchainState(e)
+	if err != nil {
+		return deployment.ChangesetOutput{}, err
+	}
+	nodes, err := deployment.NodeInfo(e.NodeIDs, e.Offchain)
+	if err != nil {
+		return deployment.ChangesetOutput{}, err
+	}
+	var ops []mcms.Operation
+	for _, chainSel := range cfg.ChainSelectors {
+		for _, pluginType := range []cctypes.PluginType{cctypes.PluginTypeCCIPCommit, cctypes.PluginTypeCCIPExec} {
+			setCandidateOps, err := setCandidatePluginOps(state, e, nodes, cfg.OCRSecrets,
+				cfg.HomeChainSel, cfg.FeedChainSel, chainSel, cfg.TokenConfig, pluginType)
+			if err != nil {
+				return deployment.ChangesetOutput{}, fmt.Errorf("failed to set %s candidate for chain %d: %w", pluginType.String(), chainSel, err)
+			}
+			ops = append(ops, setCandidateOps...)
+		}
+	}
+	prop, err := BuildProposalFromBatches(state, []timelock.BatchChainOperation{{
+		ChainIdentifier: mcms.ChainIdentifier(cfg.HomeChainSel),
+		Batch:           ops,
+	}}, "SetCandidate with rotated OCR keys", 0)
+	if err != nil {
+		return deployment.ChangesetOutput{}, err
+	}
+	js, err := NewCCIPJobSpecs(e.NodeIDs, e.Offchain)
+	if err != nil {
+		return deployment.ChangesetOutput{}, errors.Wrapf(err, "failed to create job specs")
+	}
+	return deployment.ChangesetOutput{
+		Proposals: []timelock.MCMSWithTimelockProposal{
+			*prop,
+		},
+		JobSpecs: js,
+	}, nil
+}
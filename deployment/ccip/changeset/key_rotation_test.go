@@ -0,0 +1,62 @@
+package changeset
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/smartcontractkit/chainlink-testing-framework/lib/utils/testcontext"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/deployment/environment/memory"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+func TestRotateOCRKeyBundles(t *testing.T) {
+	lggr := logger.TestLogger(t)
+	e := memory.NewMemoryEnvironment(t, lggr, zapcore.InfoLevel, memory.MemoryEnvironmentConfig{
+		Chains:     1,
+		Nodes:      4,
+		Bootstraps: 1,
+	})
+	ctx := testcontext.Get(t)
+	before, err := deployment.NodeInfo(e.NodeIDs, e.Offchain)
+	require.NoError(t, err)
+
+	retired, err := RotateOCRKeyBundles(ctx, e.Offchain, e.NodeIDs)
+	require.NoError(t, err)
+	require.Len(t, retired, len(before.NonBootstraps()))
+
+	after, err := deployment.NodeInfo(e.NodeIDs, e.Offchain)
+	require.NoError(t, err)
+	oldBundles := make(map[string]string)
+	for _, node := range before.NonBootstraps() {
+		oldBundles[node.NodeID] = node.FirstOCRKeybundle().KeyBundleID
+	}
+	for _, node := range after.NonBootstraps() {
+		require.NotEqual(t, oldBundles[node.NodeID], node.FirstOCRKeybundle().KeyBundleID)
+	}
+	for _, bundle := range retired {
+		require.Equal(t, oldBundles[bundle.NodeID], bundle.BundleID)
+	}
+
+	// Nothing is deleted within the grace period.
+	remaining, err := DeleteRetiredOCRKeyBundles(ctx, e.Offchain, retired, time.Hour)
+	require.NoError(t, err)
+	require.Equal(t, retired, remaining)
+
+	remaining, err = DeleteRetiredOCRKeyBundles(ctx, e.Offchain, retired, 0)
+	require.NoError(t, err)
+	require.Empty(t, remaining)
+
+	// Key bundles in use can't be deleted.
+	active := []RetiredOCRKeyBundle{{
+		NodeID:   after.NonBootstraps()[0].NodeID,
+		BundleID: after.NonBootstraps()[0].FirstOCRKeybundle().KeyBundleID,
+	}}
+	remaining, err = DeleteRetiredOCRKeyBundles(ctx, e.Offchain, active, 0)
+	require.ErrorContains(t, err, "still in use")
+	require.Equal(t, active, remaining)
+}
@@ -0,0 +1,290 @@
+package changeset
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/router"
+)
+
+// Migrating a lane from CCIP 1.5 to 1.6 is done in the following steps:
+//  1. The 1.6 onramp, fee quoter and offramp are configured for the lane, without routing traffic to them.
+//  2. The 1.5 onramp is frozen by removing it from the source router, so ccipSend to the destination reverts.
+//  3. The in-flight 1.5 messages are drained: the commit store must have committed and the 1.5 offramp
+//     must have successfully executed every message sent through the frozen onramp.
+//  4. The routers are switched to the 1.6 onramp and offramp, and the 1.5 offramp is removed.
+// A migration which timed out while draining can be resumed by running it again.
+
+var _ deployment.ChangeSet[MigrateLegacyLanesConfig] = MigrateLegacyLanesChangeset
+
+const (
+	defaultDrainTimeout      = 30 * time.Minute
+	defaultDrainPollInterval = 5 * time.Second
+)
+
+type MigrateLegacyLanesConfig struct {
+	// Lanes configures the 1.6 ramps of the lanes to migrate to.
+	Lanes []LaneConfig
+	// TestRouter migrates lanes routed through the test routers instead of the routers.
+	TestRouter bool
+	// DrainCheckWindow is the number of most recent 1.5 messages whose execution is verified before the switch.
+	// Older messages are expected to have been executed or to be past the permissionless execution threshold.
+	// Zero verifies every message sent through the onramp.
+	DrainCheckWindow uint64
+	// DrainTimeout bounds the time waited for the in-flight messages to be executed, defaults to 30 minutes.
+	DrainTimeout time.Duration
+	// DrainPollInterval defaults to 5 seconds.
+	DrainPollInterval time.Duration
+}
+
+func (c MigrateLegacyLanesConfig) Validate() error {
+	if len(c.Lanes) == 0 {
+		return fmt.Errorf("no lanes to migrate")
+	}
+	lanes := make(map[SourceDestPair]struct{})
+	for _, lane := range c.Lanes {
+		if lane.SourceSelector == lane.DestSelector {
+			return fmt.Errorf("cannot migrate lane to the same chain")
+		}
+		pair := SourceDestPair{SourceChainSelector: lane.SourceSelector, DestChainSelector: lane.DestSelector}
+		if _, ok := lanes[pair]; ok {
+			return fmt.Errorf("duplicate lane %d -> %d", lane.SourceSelector, lane.DestSelector)
+		}
+		lanes[pair] = struct{}{}
+		if err := lane.InitialPricesBySource.Validate(); err != nil {
+			return fmt.Errorf("error in validating initial prices for lane %d -> %d: %w", lane.SourceSelector, lane.DestSelector, err)
+		}
+	}
+	if c.DrainTimeout < 0 || c.DrainPollInterval < 0 {
+		return fmt.Errorf("drain timeout and poll interval must not be negative")
+	}
+	return nil
+}
+
+// LaneMigrationReport records the cutover of a lane from 1.5 to 1.6.
+type LaneMigrationReport struct {
+	SourceChainSelector uint64
+	DestChainSelector   uint64
+	LegacyOnRamp        common.Address
+	LegacyOffRamp       common.Address
+	OnRamp              common.Address
+	OffRamp             common.Address
+	// FrozenAtBlock is the source block in which the 1.5 onramp was removed from the router,
+	// zero if it was already frozen by a previous run.
+	FrozenAtBlock uint64
+	// LastLegacySeqNr is the sequence number of the last message sent through the 1.5 onramp.
+	LastLegacySeqNr uint64
+	DrainDuration   time.Duration
+	// SwitchedAtBlock is the source block in which the router was switched to the 1.6 onramp.
+	SwitchedAtBlock uint64
+}
+
+func (r LaneMigrationReport) String() string {
+	return fmt.Sprintf("lane %d -> %d: onramp %s -> %s, offramp %s -> %s, frozen at block %d, drained %d messages in %s, switched at block %d",
+		r.SourceChainSelector, r.DestChainSelector, r.LegacyOnRamp, r.OnRamp, r.LegacyOffRamp, r.OffRamp,
+		r.FrozenAtBlock, r.LastLegacySeqNr, r.DrainDuration, r.SwitchedAtBlock)
+}
+
+// MigrateLegacyLanesChangeset migrates lanes from 1.5 to 1.6 and logs a report of every cutover,
+// see MigrateLegacyLanes.
+func MigrateLegacyLanesChangeset(e deployment.Environment, cfg MigrateLegacyLanesConfig) (deployment.ChangesetOutput, error) {
+	reports, err := MigrateLegacyLanes(e, cfg)
+	for _, report := range reports {
+		e.Logger.Infow("Migrated lane", "report", report.String())
+	}
+	return deployment.ChangesetOutput{}, err
+}
+
+// MigrateLegacyLanes migrates lanes from 1.5 to 1.6 and returns a report of every cutover.
+// Lanes are migrated one after the other, the reports of the lanes migrated before a failure are returned with the error.
+func MigrateLegacyLanes(e deployment.Environment, cfg MigrateLegacyLanesConfig) ([]LaneMigrationReport, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid MigrateLegacyLanesConfig: %w", err)
+	}
+	if cfg.DrainTimeout == 0 {
+		cfg.DrainTimeout = defaultDrainTimeout
+	}
+	if cfg.DrainPollInterval == 0 {
+		cfg.DrainPollInterval = defaultDrainPollInterval
+	}
+	state, err := LoadOnchainState(e)
+	if err != nil {
+		e.Logger.Errorw("Failed to load existing onchain state", "err", err)
+		return nil, err
+	}
+	var reports []LaneMigrationReport
+	for _, lane := range cfg.Lanes {
+		report, err := migrateLegacyLane(e, state, cfg, lane)
+		if err != nil {
+			e.Logger.Errorw("Failed to migrate lane", "source", lane.SourceSelector, "dest", lane.DestSelector, "err", err)
+			return reports, deployment.MaybeDataErr(err)
+		}
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+func migrateLegacyLane(e deployment.Environment, state CCIPOnChainState, cfg MigrateLegacyLanesConfig, lane LaneConfig) (LaneMigrationReport, error) {
+	src, dest := lane.SourceSelector, lane.DestSelector
+	srcChain, destChain := e.Chains[src], e.Chains[dest]
+	srcState, destState := state.Chains[src], state.Chains[dest]
+	legacyOnRamp, ok := srcState.EVM2EVMOnRamp[dest]
+	if !ok {
+		return LaneMigrationReport{}, fmt.Errorf("no 1.5 onramp for lane %d -> %d", src, dest)
+	}
+	legacyOffRamp, ok := destState.EVM2EVMOffRamp[src]
+	if !ok {
+		return LaneMigrationReport{}, fmt.Errorf("no 1.5 offramp for lane %d -> %d", src, dest)
+	}
+	commitStore, ok := destState.CommitStores[src]
+	if !ok {
+		return LaneMigrationReport{}, fmt.Errorf("no 1.5 commit store for lane %d -> %d", src, dest)
+	}
+	if srcState.OnRamp == nil || destState.OffRamp == nil {
+		return LaneMigrationReport{}, fmt.Errorf("1.6 ramps not deployed for lane %d -> %d", src, dest)
+	}
+	srcRouter, destRouter := srcState.Router, destState.Router
+	if cfg.TestRouter {
+		srcRouter, destRouter = srcState.TestRouter, destState.TestRouter
+	}
+	report := LaneMigrationReport{
+		SourceChainSelector: src,
+		DestChainSelector:   dest,
+		LegacyOnRamp:        legacyOnRamp.Address(),
+		LegacyOffRamp:       legacyOffRamp.Address(),
+		OnRamp:              srcState.OnRamp.Address(),
+		OffRamp:             destState.OffRamp.Address(),
+	}
+
+	routedOnRamp, err := srcRouter.GetOnRamp(&bind.CallOpts{Context: context.Background()}, dest)
+	if err != nil {
+		return report, fmt.Errorf("failed to get onramp of router: %w", err)
+	}
+	switch routedOnRamp {
+	case legacyOnRamp.Address(), common.Address{}:
+	case srcState.OnRamp.Address():
+		return report, fmt.Errorf("lane %d -> %d already migrated", src, dest)
+	default:
+		return report, fmt.Errorf("router on chain %d routes to chain %d through unknown onramp %s", src, dest, routedOnRamp)
+	}
+
+	if err := configureLaneRamps(e, state, lane, srcRouter.Address(), destRouter.Address()); err != nil {
+		return report, fmt.Errorf("failed to configure 1.6 ramps: %w", err)
+	}
+
+	if routedOnRamp == legacyOnRamp.Address() {
+		tx, err := srcRouter.ApplyRampUpdates(srcChain.DeployerKey,
+			[]router.RouterOnRamp{{DestChainSelector: dest, OnRamp: common.Address{}}}, []router.RouterOffRamp{}, []router.RouterOffRamp{})
+		report.FrozenAtBlock, err = deployment.ConfirmIfNoError(srcChain, tx, err)
+		if err != nil {
+			return report, fmt.Errorf("failed to freeze 1.5 onramp: %w", err)
+		}
+		e.Logger.Infow("Froze 1.5 onramp", "source", src, "dest", dest, "block", report.FrozenAtBlock)
+	}
+
+	// nothing can be sent through the onramp anymore, so its sequence number is final
+	nextSeqNr, err := legacyOnRamp.GetExpectedNextSequenceNumber(&bind.CallOpts{Context: context.Background()})
+	if err != nil {
+		return report, fmt.Errorf("failed to get next sequence number of 1.5 onramp: %w", err)
+	}
+	report.LastLegacySeqNr = nextSeqNr - 1
+	drainStart := time.Now()
+	if err := waitForLegacyLaneDrained(e, cfg, report, commitStore, legacyOffRamp); err != nil {
+		return report, err
+	}
+	report.DrainDuration = time.Since(drainStart)
+
+	tx, err := srcRouter.ApplyRampUpdates(srcChain.DeployerKey,
+		[]router.RouterOnRamp{{DestChainSelector: dest, OnRamp: srcState.OnRamp.Address()}}, []router.RouterOffRamp{}, []router.RouterOffRamp{})
+	report.SwitchedAtBlock, err = deployment.ConfirmIfNoError(srcChain, tx, err)
+	if err != nil {
+		return report, fmt.Errorf("failed to switch router to 1.6 onramp: %w", err)
+	}
+	offRampRemoves := []router.RouterOffRamp{}
+	isOffRamp, err := destRouter.IsOffRamp(&bind.CallOpts{Context: context.Background()}, src, legacyOffRamp.Address())
+	if err != nil {
+		return report, fmt.Errorf("failed to check 1.5 offramp of router: %w", err)
+	}
+	if isOffRamp {
+		offRampRemoves = append(offRampRemoves, router.RouterOffRamp{SourceChainSelector: src, OffRamp: legacyOffRamp.Address()})
+	}
+	tx, err = destRouter.ApplyRampUpdates(destChain.DeployerKey, []router.RouterOnRamp{}, offRampRemoves,
+		[]router.RouterOffRamp{{SourceChainSelector: src, OffRamp: destState.OffRamp.Address()}})
+	if _, err := deployment.ConfirmIfNoError(destChain, tx, err); err != nil {
+		return report, fmt.Errorf("failed to switch router to 1.6 offramp: %w", err)
+	}
+	return report, nil
+}
+
+// waitForLegacyLaneDrained waits until every message sent through the frozen 1.5 onramp, within the
+// drain check window, was committed and successfully executed. It fails fast on messages whose execution
+// failed, as those need to be manually executed before the 1.5 offramp is removed from the router.
+func waitForLegacyLaneDrained(
+	e deployment.Environment,
+	cfg MigrateLegacyLanesConfig,
+	report LaneMigrationReport,
+	commitStore legacyCommitStore,
+	offRamp legacyOffRamp,
+) error {
+	last := report.LastLegacySeqNr
+	if last == 0 {
+		return nil
+	}
+	first := uint64(1)
+	if cfg.DrainCheckWindow > 0 && last > cfg.DrainCheckWindow {
+		first = last - cfg.DrainCheckWindow + 1
+	}
+	timeout := time.NewTimer(cfg.DrainTimeout)
+	defer timeout.Stop()
+	tick := time.NewTicker(cfg.DrainPollInterval)
+	defer tick.Stop()
+	for {
+		opts := &bind.CallOpts{Context: context.Background()}
+		nextCommitted, err := commitStore.GetExpectedNextSequenceNumber(opts)
+		if err != nil {
+			return fmt.Errorf("failed to get next sequence number of commit store: %w", err)
+		}
+		pending := last - first + 1
+		if nextCommitted > last {
+			for ; first <= last; first++ {
+				state, err := offRamp.GetExecutionState(opts, first)
+				if err != nil {
+					return fmt.Errorf("failed to get execution state of seqNum %d: %w", first, err)
+				}
+				if state == EXECUTION_STATE_FAILURE {
+					return fmt.Errorf("execution of 1.5 message with seqNum %d failed, it must be manually executed before migrating", first)
+				}
+				if state != EXECUTION_STATE_SUCCESS {
+					break
+				}
+			}
+			if first > last {
+				e.Logger.Infow("Drained 1.5 lane", "source", report.SourceChainSelector, "dest", report.DestChainSelector, "lastSeqNr", last)
+				return nil
+			}
+			pending = last - first + 1
+		}
+		e.Logger.Infow("Waiting for 1.5 lane to drain", "source", report.SourceChainSelector, "dest", report.DestChainSelector,
+			"nextCommitted", nextCommitted, "lastSeqNr", last, "pending", pending)
+		select {
+		case <-tick.C:
+		case <-timeout.C:
+			return fmt.Errorf("timed out after %s waiting for 1.5 lane %d -> %d to drain, %d messages up to seqNum %d are not executed yet",
+				cfg.DrainTimeout, report.SourceChainSelector, report.DestChainSelector, pending, last)
+		}
+	}
+}
+
+// legacyCommitStore and legacyOffRamp are the parts of the 1.5 contracts used to verify a lane is drained.
+type legacyCommitStore interface {
+	GetExpectedNextSequenceNumber(opts *bind.CallOpts) (uint64, error)
+}
+
+type legacyOffRamp interface {
+	GetExecutionState(opts *bind.CallOpts, sequenceNumber uint64) (uint8, error)
+}
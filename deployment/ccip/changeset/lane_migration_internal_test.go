@@ -0,0 +1,53 @@
+package changeset
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+type fakeLegacyLane struct {
+	nextCommitted uint64
+	states        map[uint64]uint8
+}
+
+func (f *fakeLegacyLane) GetExpectedNextSequenceNumber(*bind.CallOpts) (uint64, error) {
+	return f.nextCommitted, nil
+}
+
+func (f *fakeLegacyLane) GetExecutionState(_ *bind.CallOpts, seqNr uint64) (uint8, error) {
+	return f.states[seqNr], nil
+}
+
+func TestWaitForLegacyLaneDrained(t *testing.T) {
+	e := deployment.Environment{Name: "dummy", Logger: logger.TestLogger(t)}
+	cfg := MigrateLegacyLanesConfig{DrainTimeout: 50 * time.Millisecond, DrainPollInterval: 10 * time.Millisecond}
+	report := LaneMigrationReport{LastLegacySeqNr: 3}
+
+	lane := &fakeLegacyLane{nextCommitted: 4, states: map[uint64]uint8{
+		1: EXECUTION_STATE_SUCCESS,
+		2: EXECUTION_STATE_SUCCESS,
+		3: EXECUTION_STATE_SUCCESS,
+	}}
+	require.NoError(t, waitForLegacyLaneDrained(e, cfg, report, lane, lane))
+	require.NoError(t, waitForLegacyLaneDrained(e, cfg, LaneMigrationReport{}, &fakeLegacyLane{}, &fakeLegacyLane{}))
+
+	lane.nextCommitted = 3
+	require.ErrorContains(t, waitForLegacyLaneDrained(e, cfg, report, lane, lane), "3 messages up to seqNum 3 are not executed yet")
+
+	lane.nextCommitted = 4
+	lane.states[2] = EXECUTION_STATE_INPROGRESS
+	require.ErrorContains(t, waitForLegacyLaneDrained(e, cfg, report, lane, lane), "2 messages up to seqNum 3 are not executed yet")
+	// only the last message is verified
+	cfg.DrainCheckWindow = 1
+	require.NoError(t, waitForLegacyLaneDrained(e, cfg, report, lane, lane))
+
+	cfg.DrainCheckWindow = 0
+	lane.states[2] = EXECUTION_STATE_FAILURE
+	require.ErrorContains(t, waitForLegacyLaneDrained(e, cfg, report, lane, lane), "seqNum 2 failed, it must be manually executed")
+}
@@ -0,0 +1,126 @@
+package changeset_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	jobv1 "github.com/smartcontractkit/chainlink-protos/job-distributor/v1/job"
+	"github.com/smartcontractkit/chainlink-testing-framework/lib/utils/testcontext"
+
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset/testhelpers"
+	"github.com/smartcontractkit/chainlink/deployment/environment/memory"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/router"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+func TestMigrateLegacyLanes(t *testing.T) {
+	lggr := logger.TestLogger(t)
+	e := testhelpers.NewMemoryEnvironmentWithJobsAndContracts(t, lggr, 2, 4, nil)
+	src, dest := e.HomeChainSel, e.FeedChainSel
+	ctx := testcontext.Get(t)
+
+	out, err := changeset.DeployLegacyLanes(e.Env, changeset.DeployLegacyLanesConfig{Lanes: []changeset.LegacyLaneConfig{{
+		SourceSelector: src,
+		DestSelector:   dest,
+		InitialPrices:  changeset.DefaultInitialPrices,
+		FeeConfig:      changeset.DefaultFeeQuoterDestChainConfig(),
+	}}})
+	require.NoError(t, err)
+	require.NoError(t, e.Env.ExistingAddresses.Merge(out.AddressBook))
+	lanes := []changeset.SourceDestPair{{SourceChainSelector: src, DestChainSelector: dest}}
+	_, err = SetLegacyLanesOCR2Config(e.Env, LegacyLanesOCR2Config{Lanes: lanes})
+	require.NoError(t, err)
+	out, err = LegacyLanesJobSpecs(e.Env, LegacyLanesJobSpecsConfig{Lanes: lanes, Prices: changeset.DefaultInitialPrices})
+	require.NoError(t, err)
+	for nodeID, jobs := range out.JobSpecs {
+		for _, job := range jobs {
+			_, err := e.Env.Offchain.ProposeJob(ctx, &jobv1.ProposeJobRequest{NodeId: nodeID, Spec: job})
+			require.NoError(t, err)
+		}
+	}
+	waitForLegacyFilters(t, e.Env, lanes)
+	testhelpers.ReplayLogs(t, e.Env.Offchain, e.ReplayBlocks)
+	state, err := changeset.LoadOnchainState(e.Env)
+	require.NoError(t, err)
+
+	msg := router.ClientEVM2AnyMessage{
+		Receiver:  common.LeftPadBytes(state.Chains[dest].Receiver.Address().Bytes(), 32),
+		Data:      []byte("hello"),
+		FeeToken:  common.HexToAddress("0x0"),
+		ExtraArgs: nil,
+	}
+	// leave messages in flight on the 1.5 lane
+	for i := 0; i < 3; i++ {
+		_, _, err := testhelpers.CCIPSendRequest(e.Env, state, src, dest, true, msg)
+		require.NoError(t, err)
+	}
+
+	// the simulated destination chain only mines the plugins' transactions when blocks are committed
+	destBackend, ok := memory.AsBackend(e.Env.Chains[dest].Client)
+	require.True(t, ok)
+	migrateCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		tick := time.NewTicker(time.Second)
+		defer tick.Stop()
+		for {
+			select {
+			case <-tick.C:
+				destBackend.Commit()
+			case <-migrateCtx.Done():
+				return
+			}
+		}
+	}()
+	reports, err := changeset.MigrateLegacyLanes(e.Env, changeset.MigrateLegacyLanesConfig{
+		Lanes: []changeset.LaneConfig{{
+			SourceSelector:        src,
+			DestSelector:          dest,
+			InitialPricesBySource: changeset.DefaultInitialPrices,
+			FeeQuoterDestChain:    changeset.DefaultFeeQuoterDestChainConfig(),
+		}},
+		TestRouter:        true,
+		DrainTimeout:      5 * time.Minute,
+		DrainPollInterval: time.Second,
+	})
+	cancel()
+	require.NoError(t, err)
+	require.Len(t, reports, 1)
+	require.Equal(t, uint64(3), reports[0].LastLegacySeqNr)
+	require.NotZero(t, reports[0].FrozenAtBlock)
+	require.Greater(t, reports[0].SwitchedAtBlock, reports[0].FrozenAtBlock)
+	for seqNr := uint64(1); seqNr <= 3; seqNr++ {
+		execState, err := state.Chains[dest].EVM2EVMOffRamp[src].GetExecutionState(&bind.CallOpts{Context: ctx}, seqNr)
+		require.NoError(t, err)
+		require.Equal(t, uint8(changeset.EXECUTION_STATE_SUCCESS), execState)
+	}
+
+	// the test routers now route the lane through the 1.6 ramps
+	isLegacyOffRamp, err := state.Chains[dest].TestRouter.IsOffRamp(&bind.CallOpts{Context: ctx}, src, reports[0].LegacyOffRamp)
+	require.NoError(t, err)
+	require.False(t, isLegacyOffRamp)
+	latesthdr, err := e.Env.Chains[dest].Client.HeaderByNumber(ctx, nil)
+	require.NoError(t, err)
+	startBlock := latesthdr.Number.Uint64()
+	msgSentEvent := testhelpers.TestSendRequest(t, e.Env, state, src, dest, true, msg)
+	_, err = testhelpers.ConfirmExecWithSeqNrs(t, e.Env.Chains[src], e.Env.Chains[dest], state.Chains[dest].OffRamp, &startBlock,
+		[]uint64{msgSentEvent.SequenceNumber})
+	require.NoError(t, err)
+
+	_, err = changeset.MigrateLegacyLanes(e.Env, changeset.MigrateLegacyLanesConfig{
+		Lanes: []changeset.LaneConfig{{
+			SourceSelector:        src,
+			DestSelector:          dest,
+			InitialPricesBySource: changeset.DefaultInitialPrices,
+			FeeQuoterDestChain:    changeset.DefaultFeeQuoterDestChainConfig(),
+		}},
+		TestRouter: true,
+	})
+	require.ErrorContains(t, err, "already migrated")
+}
@@ -0,0 +1,306 @@
+package changeset
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/commit_store"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/evm_2_evm_offramp"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/evm_2_evm_onramp"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/fee_quoter"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/price_registry_1_2_0"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/router"
+)
+
+// Legacy lanes are CCIP 1.5 lanes (EVM2EVMOnRamp, CommitStore and EVM2EVMOffRamp run by the OCR2 plugins)
+// deployed next to the 1.6 lanes of an environment, so that identical traffic can be run through both
+// versions to sign off migrations. They are enabled on the test router, the 1.6 lanes use the main router.
+// Token transfers are not supported, as the token pools only accept the ramps of the main router.
+// Their OCR2 configs and jobs are only set up by the tests of this package.
+
+var _ deployment.ChangeSet[DeployLegacyLanesConfig] = DeployLegacyLanes
+
+// LegacyPermissionLessExecutionThreshold is the permissionless execution threshold of the legacy offramps.
+const LegacyPermissionLessExecutionThreshold = 8 * time.Hour
+
+type LegacyLaneConfig struct {
+	SourceSelector uint64
+	DestSelector   uint64
+	// InitialPrices seeds the price registries of both chains, the commit plugin keeps
+	// the prices of the destination price registry up to date afterwards.
+	InitialPrices InitialPrices
+	// FeeConfig is translated into the fee configuration of the legacy onramp, so that the same
+	// configuration as the 1.6 lane yields comparable fees, see DefaultFeeQuoterDestChainConfig.
+	FeeConfig fee_quoter.FeeQuoterDestChainConfig
+}
+
+type DeployLegacyLanesConfig struct {
+	Lanes []LegacyLaneConfig
+}
+
+func (c DeployLegacyLanesConfig) Validate() error {
+	if len(c.Lanes) == 0 {
+		return fmt.Errorf("no lanes to deploy")
+	}
+	lanes := make(map[SourceDestPair]struct{})
+	for _, lane := range c.Lanes {
+		if lane.SourceSelector == lane.DestSelector {
+			return fmt.Errorf("cannot add lane to the same chain")
+		}
+		pair := SourceDestPair{SourceChainSelector: lane.SourceSelector, DestChainSelector: lane.DestSelector}
+		if _, ok := lanes[pair]; ok {
+			return fmt.Errorf("duplicate lane %d -> %d", lane.SourceSelector, lane.DestSelector)
+		}
+		lanes[pair] = struct{}{}
+		if err := lane.InitialPrices.Validate(); err != nil {
+			return fmt.Errorf("error in validating initial prices for lane %d -> %d: %w", lane.SourceSelector, lane.DestSelector, err)
+		}
+		if lane.FeeConfig == (fee_quoter.FeeQuoterDestChainConfig{}) {
+			return fmt.Errorf("missing fee config for lane %d -> %d", lane.SourceSelector, lane.DestSelector)
+		}
+	}
+	return nil
+}
+
+// DeployLegacyLanes deploys CCIP 1.5 lanes next to the 1.6 lanes of the environment and enables them on the
+// test routers. A 1.2 price registry is deployed on every chain of the lanes which does not have one yet.
+// The lanes only start working once their OCR2 configs are set and the plugin jobs are created,
+// see SetLegacyLanesOCR2Config and LegacyLanesJobSpecs.
+func DeployLegacyLanes(e deployment.Environment, cfg DeployLegacyLanesConfig) (deployment.ChangesetOutput, error) {
+	if err := cfg.Validate(); err != nil {
+		return deployment.ChangesetOutput{}, fmt.Errorf("invalid DeployLegacyLanesConfig: %w", err)
+	}
+	state, err := LoadOnchainState(e)
+	if err != nil {
+		e.Logger.Errorw("Failed to load existing onchain state", "err", err)
+		return deployment.ChangesetOutput{}, err
+	}
+	ab := deployment.NewMemoryAddressBook()
+	priceRegistries := make(map[uint64]*price_registry_1_2_0.PriceRegistry)
+	for _, lane := range cfg.Lanes {
+		for _, sel := range []uint64{lane.SourceSelector, lane.DestSelector} {
+			if _, ok := priceRegistries[sel]; ok {
+				continue
+			}
+			pr, err := deployLegacyPriceRegistry(e, state.Chains[sel], e.Chains[sel], ab)
+			if err != nil {
+				e.Logger.Errorw("Failed to deploy price registry", "chain", sel, "err", err)
+				return deployment.ChangesetOutput{AddressBook: ab}, deployment.MaybeDataErr(err)
+			}
+			priceRegistries[sel] = pr
+		}
+	}
+	for _, lane := range cfg.Lanes {
+		if err := deployLegacyLane(e, state, lane, priceRegistries, ab); err != nil {
+			e.Logger.Errorw("Failed to deploy legacy lane", "source", lane.SourceSelector, "dest", lane.DestSelector, "err", err)
+			return deployment.ChangesetOutput{AddressBook: ab}, deployment.MaybeDataErr(err)
+		}
+	}
+	return deployment.ChangesetOutput{AddressBook: ab}, nil
+}
+
+func deployLegacyPriceRegistry(
+	e deployment.Environment,
+	chainState CCIPChainState,
+	chain deployment.Chain,
+	ab deployment.AddressBook,
+) (*price_registry_1_2_0.PriceRegistry, error) {
+	if chainState.PriceRegistry != nil {
+		e.Logger.Infow("price registry already deployed", "chain", chain.Selector, "addr", chainState.PriceRegistry.Address())
+		return chainState.PriceRegistry, nil
+	}
+	if chainState.LinkToken == nil || chainState.Weth9 == nil {
+		return nil, fmt.Errorf("fee tokens not deployed on chain %d", chain.Selector)
+	}
+	pr, err := deployment.DeployContract(e.Logger, chain, ab,
+		func(chain deployment.Chain) deployment.ContractDeploy[*price_registry_1_2_0.PriceRegistry] {
+			prAddr, tx2, pr, err2 := price_registry_1_2_0.DeployPriceRegistry(
+				chain.DeployerKey,
+				chain.Client,
+				[]common.Address{}, // price updaters, the commit stores are added once deployed
+				[]common.Address{chainState.LinkToken.Address(), chainState.Weth9.Address()},
+				uint32(24*60*60),
+			)
+			return deployment.ContractDeploy[*price_registry_1_2_0.PriceRegistry]{
+				Address: prAddr, Contract: pr, Tx: tx2, Tv: deployment.NewTypeAndVersion(PriceRegistry, deployment.Version1_2_0), Err: err2,
+			}
+		})
+	if err != nil {
+		return nil, err
+	}
+	e.Logger.Infow("deployed price registry", "chain", chain.Selector, "addr", pr.Address)
+	return pr.Contract, nil
+}
+
+func deployLegacyLane(
+	e deployment.Environment,
+	state CCIPOnChainState,
+	lane LegacyLaneConfig,
+	priceRegistries map[uint64]*price_registry_1_2_0.PriceRegistry,
+	ab deployment.AddressBook,
+) error {
+	src, dest := lane.SourceSelector, lane.DestSelector
+	srcChain, destChain := e.Chains[src], e.Chains[dest]
+	srcState, destState := state.Chains[src], state.Chains[dest]
+	if srcState.TestRouter == nil || destState.TestRouter == nil {
+		return fmt.Errorf("test routers not deployed for lane %d -> %d", src, dest)
+	}
+	if _, ok := srcState.EVM2EVMOnRamp[dest]; ok {
+		return fmt.Errorf("legacy lane %d -> %d already deployed", src, dest)
+	}
+	existingOnRamp, err := srcState.TestRouter.GetOnRamp(&bind.CallOpts{Context: context.Background()}, dest)
+	if err != nil {
+		return fmt.Errorf("failed to get onramp of test router: %w", err)
+	}
+	if existingOnRamp != (common.Address{}) {
+		return fmt.Errorf("test router on chain %d already routes to chain %d through %s", src, dest, existingOnRamp)
+	}
+
+	// Seed the prices the legacy onramp computes fees with. The gas price of the destination is never
+	// updated on the source price registry unless the reverse lane exists, so it is seeded for both.
+	for _, pr := range []struct {
+		chain      deployment.Chain
+		chainState CCIPChainState
+		remote     uint64
+	}{{srcChain, srcState, dest}, {destChain, destState, src}} {
+		tx, err := priceRegistries[pr.chain.Selector].UpdatePrices(pr.chain.DeployerKey, price_registry_1_2_0.InternalPriceUpdates{
+			TokenPriceUpdates: []price_registry_1_2_0.InternalTokenPriceUpdate{
+				{SourceToken: pr.chainState.LinkToken.Address(), UsdPerToken: lane.InitialPrices.LinkPrice},
+				{SourceToken: pr.chainState.Weth9.Address(), UsdPerToken: lane.InitialPrices.WethPrice},
+			},
+			GasPriceUpdates: []price_registry_1_2_0.InternalGasPriceUpdate{
+				{DestChainSelector: pr.remote, UsdPerUnitGas: lane.InitialPrices.GasPrice},
+			},
+		})
+		if _, err := deployment.ConfirmIfNoError(pr.chain, tx, err); err != nil {
+			return fmt.Errorf("failed to seed prices on chain %d: %w", pr.chain.Selector, err)
+		}
+	}
+
+	feeCfg := lane.FeeConfig
+	onRamp, err := deployment.DeployContract(e.Logger, srcChain, ab,
+		func(chain deployment.Chain) deployment.ContractDeploy[*evm_2_evm_onramp.EVM2EVMOnRamp] {
+			onRampAddr, tx2, onRamp, err2 := evm_2_evm_onramp.DeployEVM2EVMOnRamp(
+				chain.DeployerKey,
+				chain.Client,
+				evm_2_evm_onramp.EVM2EVMOnRampStaticConfig{
+					LinkToken:          srcState.LinkToken.Address(),
+					ChainSelector:      src,
+					DestChainSelector:  dest,
+					DefaultTxGasLimit:  uint64(feeCfg.DefaultTxGasLimit),
+					MaxNopFeesJuels:    deployment.E18Mult(100_000_000),
+					PrevOnRamp:         common.Address{},
+					RmnProxy:           srcState.RMNProxyExisting.Address(),
+					TokenAdminRegistry: srcState.TokenAdminRegistry.Address(),
+				},
+				evm_2_evm_onramp.EVM2EVMOnRampDynamicConfig{
+					Router:                            srcState.TestRouter.Address(),
+					MaxNumberOfTokensPerMsg:           uint16(feeCfg.MaxNumberOfTokensPerMsg),
+					DestGasOverhead:                   feeCfg.DestGasOverhead,
+					DestGasPerPayloadByte:             uint16(feeCfg.DestGasPerPayloadByte),
+					DestDataAvailabilityOverheadGas:   feeCfg.DestDataAvailabilityOverheadGas,
+					DestGasPerDataAvailabilityByte:    uint16(feeCfg.DestGasPerDataAvailabilityByte),
+					DestDataAvailabilityMultiplierBps: uint16(feeCfg.DestDataAvailabilityMultiplierBps),
+					PriceRegistry:                     priceRegistries[src].Address(),
+					MaxDataBytes:                      feeCfg.MaxDataBytes,
+					MaxPerMsgGasLimit:                 feeCfg.MaxPerMsgGasLimit,
+					DefaultTokenFeeUSDCents:           uint16(feeCfg.DefaultTokenFeeUSDCents),
+					DefaultTokenDestGasOverhead:       feeCfg.DefaultTokenDestGasOverhead,
+				},
+				evm_2_evm_onramp.RateLimiterConfig{IsEnabled: false, Capacity: big.NewInt(0), Rate: big.NewInt(0)},
+				[]evm_2_evm_onramp.EVM2EVMOnRampFeeTokenConfigArgs{
+					{
+						Token:                      srcState.LinkToken.Address(),
+						NetworkFeeUSDCents:         uint32(feeCfg.NetworkFeeUSDCents),
+						GasMultiplierWeiPerEth:     feeCfg.GasMultiplierWeiPerEth,
+						PremiumMultiplierWeiPerEth: 9e17, // same as the fee quoter
+						Enabled:                    true,
+					},
+					{
+						Token:                      srcState.Weth9.Address(),
+						NetworkFeeUSDCents:         uint32(feeCfg.NetworkFeeUSDCents),
+						GasMultiplierWeiPerEth:     feeCfg.GasMultiplierWeiPerEth,
+						PremiumMultiplierWeiPerEth: 1e18, // same as the fee quoter
+						Enabled:                    true,
+					},
+				},
+				[]evm_2_evm_onramp.EVM2EVMOnRampTokenTransferFeeConfigArgs{},
+				[]evm_2_evm_onramp.EVM2EVMOnRampNopAndWeight{},
+			)
+			return deployment.ContractDeploy[*evm_2_evm_onramp.EVM2EVMOnRamp]{
+				Address: onRampAddr, Contract: onRamp, Tx: tx2, Tv: deployment.NewTypeAndVersion(EVM2EVMOnRamp, deployment.Version1_5_0), Err: err2,
+			}
+		})
+	if err != nil {
+		return err
+	}
+	e.Logger.Infow("deployed legacy onramp", "source", src, "dest", dest, "addr", onRamp.Address)
+
+	commitStore, err := deployment.DeployContract(e.Logger, destChain, ab,
+		func(chain deployment.Chain) deployment.ContractDeploy[*commit_store.CommitStore] {
+			csAddr, tx2, cs, err2 := commit_store.DeployCommitStore(
+				chain.DeployerKey,
+				chain.Client,
+				commit_store.CommitStoreStaticConfig{
+					ChainSelector:       dest,
+					SourceChainSelector: src,
+					OnRamp:              onRamp.Address,
+					RmnProxy:            destState.RMNProxyExisting.Address(),
+				},
+			)
+			return deployment.ContractDeploy[*commit_store.CommitStore]{
+				Address: csAddr, Contract: cs, Tx: tx2, Tv: deployment.NewTypeAndVersion(CommitStore, deployment.Version1_5_0), Err: err2,
+			}
+		})
+	if err != nil {
+		return err
+	}
+	e.Logger.Infow("deployed legacy commit store", "source", src, "dest", dest, "addr", commitStore.Address)
+
+	offRamp, err := deployment.DeployContract(e.Logger, destChain, ab,
+		func(chain deployment.Chain) deployment.ContractDeploy[*evm_2_evm_offramp.EVM2EVMOffRamp] {
+			offRampAddr, tx2, offRamp, err2 := evm_2_evm_offramp.DeployEVM2EVMOffRamp(
+				chain.DeployerKey,
+				chain.Client,
+				evm_2_evm_offramp.EVM2EVMOffRampStaticConfig{
+					CommitStore:         commitStore.Address,
+					ChainSelector:       dest,
+					SourceChainSelector: src,
+					OnRamp:              onRamp.Address,
+					PrevOffRamp:         common.Address{},
+					RmnProxy:            destState.RMNProxyExisting.Address(),
+					TokenAdminRegistry:  destState.TokenAdminRegistry.Address(),
+				},
+				evm_2_evm_offramp.RateLimiterConfig{IsEnabled: false, Capacity: big.NewInt(0), Rate: big.NewInt(0)},
+			)
+			return deployment.ContractDeploy[*evm_2_evm_offramp.EVM2EVMOffRamp]{
+				Address: offRampAddr, Contract: offRamp, Tx: tx2, Tv: deployment.NewTypeAndVersion(EVM2EVMOffRamp, deployment.Version1_5_0), Err: err2,
+			}
+		})
+	if err != nil {
+		return err
+	}
+	e.Logger.Infow("deployed legacy offramp", "source", src, "dest", dest, "addr", offRamp.Address)
+
+	tx, err := priceRegistries[dest].ApplyPriceUpdatersUpdates(destChain.DeployerKey, []common.Address{commitStore.Address}, []common.Address{})
+	if _, err := deployment.ConfirmIfNoError(destChain, tx, err); err != nil {
+		return fmt.Errorf("failed to add commit store as price updater: %w", err)
+	}
+	tx, err = srcState.TestRouter.ApplyRampUpdates(srcChain.DeployerKey,
+		[]router.RouterOnRamp{{DestChainSelector: dest, OnRamp: onRamp.Address}}, []router.RouterOffRamp{}, []router.RouterOffRamp{})
+	if _, err := deployment.ConfirmIfNoError(srcChain, tx, err); err != nil {
+		return fmt.Errorf("failed to set legacy onramp on test router: %w", err)
+	}
+	tx, err = destState.TestRouter.ApplyRampUpdates(destChain.DeployerKey,
+		[]router.RouterOnRamp{}, []router.RouterOffRamp{}, []router.RouterOffRamp{{SourceChainSelector: src, OffRamp: offRamp.Address}})
+	if _, err := deployment.ConfirmIfNoError(destChain, tx, err); err != nil {
+		return fmt.Errorf("failed to set legacy offramp on test router: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,419 @@
+package changeset_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"text/template"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/confighelper"
+	"github.com/stretchr/testify/require"
+
+	commonconfig "github.com/smartcontractkit/chainlink-common/pkg/config"
+	"github.com/smartcontractkit/chainlink-common/pkg/types"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset"
+	"github.com/smartcontractkit/chainlink/deployment/environment/memory"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/commit_store"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/evm_2_evm_offramp"
+	"github.com/smartcontractkit/chainlink/v2/core/services/ocr2/plugins/ccip/abihelpers"
+	ccipconfig "github.com/smartcontractkit/chainlink/v2/core/services/ocr2/plugins/ccip/config"
+	"github.com/smartcontractkit/chainlink/v2/core/services/ocr2/plugins/ccip/testhelpers"
+)
+
+// LegacyLanesOCR2Config configures the DON of the environment's non-bootstrap nodes on the commit stores
+// and offramps of the given legacy lanes.
+type LegacyLanesOCR2Config struct {
+	Lanes []changeset.SourceDestPair
+}
+
+func (c LegacyLanesOCR2Config) Validate() error {
+	if len(c.Lanes) == 0 {
+		return fmt.Errorf("no lanes to configure")
+	}
+	return nil
+}
+
+// SetLegacyLanesOCR2Config sets the OCR2 configs of the commit stores and offramps of legacy lanes.
+func SetLegacyLanesOCR2Config(e deployment.Environment, cfg LegacyLanesOCR2Config) (deployment.ChangesetOutput, error) {
+	if err := cfg.Validate(); err != nil {
+		return deployment.ChangesetOutput{}, fmt.Errorf("invalid LegacyLanesOCR2Config: %w", err)
+	}
+	state, err := changeset.LoadOnchainState(e)
+	if err != nil {
+		e.Logger.Errorw("Failed to load existing onchain state", "err", err)
+		return deployment.ChangesetOutput{}, err
+	}
+	nodes, err := deployment.NodeInfo(e.NodeIDs, e.Offchain)
+	if err != nil {
+		e.Logger.Errorw("Failed to get node info", "err", err)
+		return deployment.ChangesetOutput{}, err
+	}
+	for _, lane := range cfg.Lanes {
+		if err := setLegacyLaneOCR2Config(e, state, nodes.NonBootstraps(), lane); err != nil {
+			e.Logger.Errorw("Failed to set OCR2 config of legacy lane", "source", lane.SourceChainSelector, "dest", lane.DestChainSelector, "err", err)
+			return deployment.ChangesetOutput{}, deployment.MaybeDataErr(err)
+		}
+	}
+	return deployment.ChangesetOutput{}, nil
+}
+
+// legacyOCR2Config holds the arguments of setOCR2Config for commit stores and offramps.
+type legacyOCR2Config struct {
+	Signers               []common.Address
+	Transmitters          []common.Address
+	F                     uint8
+	OnchainConfig         []byte
+	OffchainConfigVersion uint64
+	OffchainConfig        []byte
+}
+
+func setLegacyLaneOCR2Config(e deployment.Environment, state changeset.CCIPOnChainState, nodes deployment.Nodes, lane changeset.SourceDestPair) error {
+	src, dest := lane.SourceChainSelector, lane.DestChainSelector
+	destChain, destState := e.Chains[dest], state.Chains[dest]
+	commitStore, ok := destState.CommitStores[src]
+	if !ok {
+		return fmt.Errorf("legacy lane %d -> %d not deployed", src, dest)
+	}
+	offRamp := destState.EVM2EVMOffRamp[src]
+	if destState.PriceRegistry == nil {
+		return fmt.Errorf("price registry not deployed on chain %d", dest)
+	}
+
+	commitOnchainConfig, err := abihelpers.ABIEncode(`[{"components": [{"name":"priceRegistry","type":"address"}], "type":"tuple"}]`,
+		commit_store.CommitStoreDynamicConfig{PriceRegistry: destState.PriceRegistry.Address()})
+	if err != nil {
+		return fmt.Errorf("failed to encode commit onchain config: %w", err)
+	}
+	commitOffchainConfig, err := testhelpers.NewCommitOffchainConfig(
+		*commonconfig.MustNewDuration(10 * time.Second), // gas price heartbeat
+		1,
+		1,
+		*commonconfig.MustNewDuration(10 * time.Second), // token price heartbeat
+		1,
+		*commonconfig.MustNewDuration(5 * time.Second), // inflight cache expiry
+		false,
+	).Encode()
+	if err != nil {
+		return fmt.Errorf("failed to encode commit offchain config: %w", err)
+	}
+	execOnchainConfig, err := abihelpers.ABIEncode(`[{"components": [
+			{"name":"permissionLessExecutionThresholdSeconds","type":"uint32"},
+			{"name":"maxDataBytes","type":"uint32"},
+			{"name":"maxNumberOfTokensPerMsg","type":"uint16"},
+			{"name":"router","type":"address"},
+			{"name":"priceRegistry","type":"address"}
+		], "type":"tuple"}]`,
+		evm_2_evm_offramp.EVM2EVMOffRampDynamicConfig{
+			PermissionLessExecutionThresholdSeconds: uint32(changeset.LegacyPermissionLessExecutionThreshold.Seconds()),
+			MaxDataBytes:                            1e5,
+			MaxNumberOfTokensPerMsg:                 5,
+			Router:                                  destState.TestRouter.Address(),
+			PriceRegistry:                           destState.PriceRegistry.Address(),
+		})
+	if err != nil {
+		return fmt.Errorf("failed to encode exec onchain config: %w", err)
+	}
+	execOffchainConfig, err := testhelpers.NewExecOffchainConfig(
+		1,         // dest optimistic confirmations
+		5_000_000, // batch gas limit
+		0.07,      // relative boost per wait hour
+		*commonconfig.MustNewDuration(time.Minute), // inflight cache expiry
+		*commonconfig.MustNewDuration(time.Minute), // root snooze time
+		0, // batching strategy
+	).Encode()
+	if err != nil {
+		return fmt.Errorf("failed to encode exec offchain config: %w", err)
+	}
+
+	commitCfg, err := buildLegacyOCR2Config(nodes, dest, commitOnchainConfig, commitOffchainConfig)
+	if err != nil {
+		return err
+	}
+	tx, err := commitStore.SetOCR2Config(destChain.DeployerKey, commitCfg.Signers, commitCfg.Transmitters, commitCfg.F,
+		commitCfg.OnchainConfig, commitCfg.OffchainConfigVersion, commitCfg.OffchainConfig)
+	if _, err := deployment.ConfirmIfNoError(destChain, tx, err); err != nil {
+		return fmt.Errorf("failed to set commit store OCR2 config: %w", err)
+	}
+	execCfg, err := buildLegacyOCR2Config(nodes, dest, execOnchainConfig, execOffchainConfig)
+	if err != nil {
+		return err
+	}
+	tx, err = offRamp.SetOCR2Config(destChain.DeployerKey, execCfg.Signers, execCfg.Transmitters, execCfg.F,
+		execCfg.OnchainConfig, execCfg.OffchainConfigVersion, execCfg.OffchainConfig)
+	if _, err := deployment.ConfirmIfNoError(destChain, tx, err); err != nil {
+		return fmt.Errorf("failed to set offramp OCR2 config: %w", err)
+	}
+	return nil
+}
+
+func buildLegacyOCR2Config(nodes deployment.Nodes, dest uint64, onchainConfig, offchainConfig []byte) (legacyOCR2Config, error) {
+	var oracles []confighelper.OracleIdentityExtra
+	var schedule []int
+	for _, node := range nodes {
+		cfg, ok := node.OCRConfigForChainSelector(dest)
+		if !ok {
+			return legacyOCR2Config{}, fmt.Errorf("no OCR config for chain %d on node %s", dest, node.NodeID)
+		}
+		schedule = append(schedule, 1)
+		oracles = append(oracles, confighelper.OracleIdentityExtra{
+			OracleIdentity: confighelper.OracleIdentity{
+				OnchainPublicKey:  cfg.OnchainPublicKey,
+				TransmitAccount:   cfg.TransmitAccount,
+				OffchainPublicKey: cfg.OffchainPublicKey,
+				PeerID:            cfg.PeerID.Raw(),
+			},
+			ConfigEncryptionPublicKey: cfg.ConfigEncryptionPublicKey,
+		})
+	}
+	signers, transmitters, f, onchainConfig, offchainConfigVersion, offchainConfig, err := confighelper.ContractSetConfigArgsForTests(
+		2*time.Second,        // deltaProgress
+		1*time.Second,        // deltaResend
+		1*time.Second,        // deltaRound
+		500*time.Millisecond, // deltaGrace
+		2*time.Second,        // deltaStage
+		3,                    // rMax
+		schedule,
+		oracles,
+		offchainConfig,
+		nil,                  // maxDurationInitialization
+		50*time.Millisecond,  // maxDurationQuery
+		1*time.Second,        // maxDurationObservation
+		100*time.Millisecond, // maxDurationReport
+		100*time.Millisecond, // maxDurationShouldAcceptFinalizedReport
+		100*time.Millisecond, // maxDurationShouldTransmitAcceptedReport
+		int(nodes.DefaultF()),
+		onchainConfig,
+	)
+	if err != nil {
+		return legacyOCR2Config{}, fmt.Errorf("failed to build OCR2 config: %w", err)
+	}
+	cfg := legacyOCR2Config{
+		F:                     f,
+		OnchainConfig:         onchainConfig,
+		OffchainConfigVersion: offchainConfigVersion,
+		OffchainConfig:        offchainConfig,
+	}
+	for _, signer := range signers {
+		if len(signer) != common.AddressLength {
+			return legacyOCR2Config{}, fmt.Errorf("signer %x is not an address", signer)
+		}
+		cfg.Signers = append(cfg.Signers, common.BytesToAddress(signer))
+	}
+	for _, transmitter := range transmitters {
+		if !common.IsHexAddress(string(transmitter)) {
+			return legacyOCR2Config{}, fmt.Errorf("transmitter %s is not an address", transmitter)
+		}
+		cfg.Transmitters = append(cfg.Transmitters, common.HexToAddress(string(transmitter)))
+	}
+	return cfg, nil
+}
+
+// LegacyLanesJobSpecsConfig configures the OCR2 commit and exec jobs of legacy lanes.
+type LegacyLanesJobSpecsConfig struct {
+	Lanes []changeset.SourceDestPair
+	// Prices are the static USD prices the commit plugins report for the fee tokens,
+	// only LinkPrice and WethPrice are used.
+	Prices changeset.InitialPrices
+}
+
+func (c LegacyLanesJobSpecsConfig) Validate() error {
+	if len(c.Lanes) == 0 {
+		return fmt.Errorf("no lanes to create jobs for")
+	}
+	if c.Prices.LinkPrice == nil || c.Prices.WethPrice == nil {
+		return fmt.Errorf("missing static prices")
+	}
+	return nil
+}
+
+// LegacyLanesJobSpecs returns the OCR2 commit and exec job specs of legacy lanes for the nodes of the environment,
+// along with bootstrap job specs for the commit stores and offramps.
+func LegacyLanesJobSpecs(e deployment.Environment, cfg LegacyLanesJobSpecsConfig) (deployment.ChangesetOutput, error) {
+	if err := cfg.Validate(); err != nil {
+		return deployment.ChangesetOutput{}, fmt.Errorf("invalid LegacyLanesJobSpecsConfig: %w", err)
+	}
+	state, err := changeset.LoadOnchainState(e)
+	if err != nil {
+		e.Logger.Errorw("Failed to load existing onchain state", "err", err)
+		return deployment.ChangesetOutput{}, err
+	}
+	nodes, err := deployment.NodeInfo(e.NodeIDs, e.Offchain)
+	if err != nil {
+		e.Logger.Errorw("Failed to get node info", "err", err)
+		return deployment.ChangesetOutput{}, err
+	}
+	jobSpecs := make(map[string][]string)
+	for _, lane := range cfg.Lanes {
+		specs, err := legacyLaneJobSpecs(e, state, nodes, lane, cfg.Prices)
+		if err != nil {
+			e.Logger.Errorw("Failed to build job specs of legacy lane", "source", lane.SourceChainSelector, "dest", lane.DestChainSelector, "err", err)
+			return deployment.ChangesetOutput{}, err
+		}
+		for nodeID, nodeSpecs := range specs {
+			jobSpecs[nodeID] = append(jobSpecs[nodeID], nodeSpecs...)
+		}
+	}
+	return deployment.ChangesetOutput{JobSpecs: jobSpecs}, nil
+}
+
+func legacyLaneJobSpecs(
+	e deployment.Environment,
+	state changeset.CCIPOnChainState,
+	nodes deployment.Nodes,
+	lane changeset.SourceDestPair,
+	prices changeset.InitialPrices,
+) (map[string][]string, error) {
+	src, dest := lane.SourceChainSelector, lane.DestChainSelector
+	srcState, destState := state.Chains[src], state.Chains[dest]
+	commitStore, ok := destState.CommitStores[src]
+	if !ok {
+		return nil, fmt.Errorf("legacy lane %d -> %d not deployed", src, dest)
+	}
+	offRamp := destState.EVM2EVMOffRamp[src]
+	srcChainID, err := deployment.EVMChainID(src)
+	if err != nil {
+		return nil, err
+	}
+	destChainID, err := deployment.EVMChainID(dest)
+	if err != nil {
+		return nil, err
+	}
+	// the commit plugin reports the prices of the destination fee tokens and of the source native token
+	priceGetterConfig, err := json.Marshal(ccipconfig.DynamicPriceGetterConfig{
+		AggregatorPrices: map[common.Address]ccipconfig.AggregatorPriceConfig{},
+		StaticPrices: map[common.Address]ccipconfig.StaticPriceConfig{
+			destState.LinkToken.Address(): {ChainID: destChainID, Price: prices.LinkPrice},
+			destState.Weth9.Address():     {ChainID: destChainID, Price: prices.WethPrice},
+			srcState.Weth9.Address():      {ChainID: srcChainID, Price: prices.WethPrice},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	spec := legacyJobSpec{
+		DestChainID:   destChainID,
+		Bootstrappers: nodes.BootstrapLocators(),
+	}
+	jobSpecs := make(map[string][]string)
+	for _, node := range nodes {
+		if node.IsBootstrap {
+			for _, contractID := range []common.Address{commitStore.Address(), offRamp.Address()} {
+				bootstrapSpec := spec
+				bootstrapSpec.Type = "bootstrap"
+				bootstrapSpec.Name = fmt.Sprintf("bootstrap-%d-%s", dest, contractID.Hex())
+				bootstrapSpec.ContractID = contractID.Hex()
+				rendered, err := bootstrapSpec.String()
+				if err != nil {
+					return nil, err
+				}
+				jobSpecs[node.NodeID] = append(jobSpecs[node.NodeID], rendered)
+			}
+			continue
+		}
+		ocrCfg, ok := node.OCRConfigForChainSelector(dest)
+		if !ok {
+			return nil, fmt.Errorf("no OCR config for chain %d on node %s", dest, node.NodeID)
+		}
+		commitSpec, execSpec := spec, spec
+		commitSpec.Name = fmt.Sprintf("ccip-commit-%d-%d-v1_5", src, dest)
+		commitSpec.PluginType = types.CCIPCommit
+		commitSpec.ContractID = commitStore.Address().Hex()
+		commitSpec.PluginConfig = map[string]string{
+			"offRamp":           fmt.Sprintf("%q", offRamp.Address().Hex()),
+			"priceGetterConfig": fmt.Sprintf("\"\"\"\n%s\n\"\"\"", priceGetterConfig),
+		}
+		execSpec.Name = fmt.Sprintf("ccip-exec-%d-%d-v1_5", src, dest)
+		execSpec.PluginType = types.CCIPExecution
+		execSpec.ContractID = offRamp.Address().Hex()
+		for _, jobSpec := range []legacyJobSpec{commitSpec, execSpec} {
+			jobSpec.Type = "offchainreporting2"
+			jobSpec.OCRKeyBundleID = ocrCfg.KeyBundleID
+			jobSpec.TransmitterID = string(ocrCfg.TransmitAccount)
+			rendered, err := jobSpec.String()
+			if err != nil {
+				return nil, err
+			}
+			jobSpecs[node.NodeID] = append(jobSpecs[node.NodeID], rendered)
+		}
+	}
+	return jobSpecs, nil
+}
+
+// legacyJobSpec is an OCR2 job of a legacy lane, on the destination chain of the lane.
+type legacyJobSpec struct {
+	Type           string
+	Name           string
+	PluginType     types.OCR2PluginType
+	ContractID     string
+	DestChainID    uint64
+	Bootstrappers  []string
+	OCRKeyBundleID string
+	TransmitterID  string
+	// PluginConfig holds TOML values by key.
+	PluginConfig map[string]string
+}
+
+var legacyJobSpecTemplate = template.Must(template.New("legacy job spec").Parse(`type = "{{ .Type }}"
+name = "{{ .Name }}"
+schemaVersion = 1
+relay = "evm"
+contractID = "{{ .ContractID }}"
+contractConfigConfirmations = 1
+contractConfigTrackerPollInterval = "20s"
+{{- if .PluginType }}
+pluginType = "{{ .PluginType }}"
+ocrKeyBundleID = "{{ .OCRKeyBundleID }}"
+transmitterID = "{{ .TransmitterID }}"
+p2pv2Bootstrappers = [{{ range $i, $b := .Bootstrappers }}{{ if $i }}, {{ end }}"{{ $b }}"{{ end }}]
+{{- end }}
+
+[relayConfig]
+chainID = {{ .DestChainID }}
+{{- if .PluginType }}
+
+[pluginConfig]
+{{- range $key, $value := .PluginConfig }}
+{{ $key }} = {{ $value }}
+{{- end }}
+{{- end }}
+`))
+
+func (s legacyJobSpec) String() (string, error) {
+	var buf bytes.Buffer
+	if err := legacyJobSpecTemplate.Execute(&buf, s); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// waitForLegacyFilters waits for the legacy plugins of the non-bootstrap nodes to register the log filters of
+// the ramps of the lanes, so that a log replay picks up the logs emitted before the jobs were created.
+func waitForLegacyFilters(t *testing.T, e deployment.Environment, lanes []changeset.SourceDestPair) {
+	jc, ok := e.Offchain.(*memory.JobClient)
+	require.True(t, ok, "waiting for log filters requires memory nodes, got %T", e.Offchain)
+	state, err := changeset.LoadOnchainState(e)
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		for _, node := range jc.Nodes {
+			if node.IsBoostrap {
+				continue
+			}
+			for _, lane := range lanes {
+				src, dest := lane.SourceChainSelector, lane.DestChainSelector
+				onRampFilter, err := node.HasLogFilter(src, state.Chains[src].EVM2EVMOnRamp[dest].Address())
+				require.NoError(t, err)
+				offRampFilter, err := node.HasLogFilter(dest, state.Chains[dest].EVM2EVMOffRamp[src].Address())
+				require.NoError(t, err)
+				if !onRampFilter || !offRampFilter {
+					return false
+				}
+			}
+		}
+		return true
+	}, time.Minute, time.Second, "legacy plugins did not register their log filters")
+}
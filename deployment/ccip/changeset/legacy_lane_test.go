@@ -0,0 +1,114 @@
+package changeset_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	jobv1 "github.com/smartcontractkit/chainlink-protos/job-distributor/v1/job"
+	"github.com/smartcontractkit/chainlink-testing-framework/lib/utils/testcontext"
+
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset/testhelpers"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/fee_quoter"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/router"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+func TestDeployLegacyLanesConfigValidate(t *testing.T) {
+	lane := changeset.LegacyLaneConfig{
+		SourceSelector: 1,
+		DestSelector:   2,
+		InitialPrices:  changeset.DefaultInitialPrices,
+		FeeConfig:      changeset.DefaultFeeQuoterDestChainConfig(),
+	}
+	require.NoError(t, changeset.DeployLegacyLanesConfig{Lanes: []changeset.LegacyLaneConfig{lane}}.Validate())
+	require.ErrorContains(t, changeset.DeployLegacyLanesConfig{}.Validate(), "no lanes")
+	require.ErrorContains(t, changeset.DeployLegacyLanesConfig{Lanes: []changeset.LegacyLaneConfig{lane, lane}}.Validate(), "duplicate lane 1 -> 2")
+
+	sameChain := lane
+	sameChain.DestSelector = 1
+	require.ErrorContains(t, changeset.DeployLegacyLanesConfig{Lanes: []changeset.LegacyLaneConfig{sameChain}}.Validate(), "same chain")
+	noFees := lane
+	noFees.FeeConfig = fee_quoter.FeeQuoterDestChainConfig{}
+	require.ErrorContains(t, changeset.DeployLegacyLanesConfig{Lanes: []changeset.LegacyLaneConfig{noFees}}.Validate(), "missing fee config")
+}
+
+func TestLaneDiff(t *testing.T) {
+	delivery := testhelpers.LaneDelivery{
+		Nonce:          1,
+		Sender:         common.HexToAddress("0x1"),
+		Data:           []byte("hello"),
+		Fee:            big.NewInt(100),
+		ExecutionState: changeset.EXECUTION_STATE_SUCCESS,
+		ReceiverCalled: true,
+	}
+	diff := testhelpers.LaneDiff{Legacy: delivery, Current: delivery}
+	diff.Legacy.Nonce = 5
+	diff.Legacy.Fee = big.NewInt(110)
+	require.Empty(t, diff.Mismatches())
+	require.InDelta(t, 0.1, diff.FeeDeviation(), 1e-9)
+
+	diff.Legacy.Nonce = 0
+	diff.Legacy.ExecutionState = changeset.EXECUTION_STATE_FAILURE
+	diff.Legacy.ReceiverCalled = false
+	require.Equal(t, []string{
+		"ordering: legacy nonce 0, current nonce 1",
+		"execution state: legacy FAILURE, current SUCCESS",
+		"receiver called: legacy false, current true",
+	}, diff.Mismatches())
+}
+
+func TestLegacyLaneDifferential(t *testing.T) {
+	lggr := logger.TestLogger(t)
+	e := testhelpers.NewMemoryEnvironmentWithJobsAndContracts(t, lggr, 2, 4, nil)
+	src, dest := e.HomeChainSel, e.FeedChainSel
+	ctx := testcontext.Get(t)
+
+	out, err := changeset.DeployLegacyLanes(e.Env, changeset.DeployLegacyLanesConfig{Lanes: []changeset.LegacyLaneConfig{{
+		SourceSelector: src,
+		DestSelector:   dest,
+		InitialPrices:  changeset.DefaultInitialPrices,
+		FeeConfig:      changeset.DefaultFeeQuoterDestChainConfig(),
+	}}})
+	require.NoError(t, err)
+	require.NoError(t, e.Env.ExistingAddresses.Merge(out.AddressBook))
+	lanes := []changeset.SourceDestPair{{SourceChainSelector: src, DestChainSelector: dest}}
+	_, err = SetLegacyLanesOCR2Config(e.Env, LegacyLanesOCR2Config{Lanes: lanes})
+	require.NoError(t, err)
+	out, err = LegacyLanesJobSpecs(e.Env, LegacyLanesJobSpecsConfig{Lanes: lanes, Prices: changeset.DefaultInitialPrices})
+	require.NoError(t, err)
+	for nodeID, jobs := range out.JobSpecs {
+		for _, job := range jobs {
+			_, err := e.Env.Offchain.ProposeJob(ctx, &jobv1.ProposeJobRequest{NodeId: nodeID, Spec: job})
+			require.NoError(t, err)
+		}
+	}
+	waitForLegacyFilters(t, e.Env, lanes)
+	testhelpers.ReplayLogs(t, e.Env.Offchain, e.ReplayBlocks)
+
+	state, err := changeset.LoadOnchainState(e.Env)
+	require.NoError(t, err)
+	require.NoError(t, testhelpers.AddLanesForAll(e.Env, state))
+
+	for _, msg := range []router.ClientEVM2AnyMessage{
+		{
+			Receiver:  common.LeftPadBytes(state.Chains[dest].Receiver.Address().Bytes(), 32),
+			Data:      []byte("hello"),
+			FeeToken:  common.HexToAddress("0x0"),
+			ExtraArgs: nil,
+		},
+		{
+			Receiver:  common.LeftPadBytes(state.Chains[dest].Receiver.Address().Bytes(), 32),
+			Data:      []byte("hello out of order"),
+			FeeToken:  common.HexToAddress("0x0"),
+			ExtraArgs: testhelpers.MakeEVMExtraArgsV2(200_000, true),
+		},
+	} {
+		diff := testhelpers.DiffLanes(t, e.Env, state, src, dest, msg)
+		// the fee models of the versions differ slightly, this catches misconfigured lanes
+		testhelpers.AssertLanesEquivalent(t, diff, 0.25)
+	}
+}
@@ -0,0 +1,70 @@
+package changeset
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// observationRPCRoundTrips is the number of sequential RPC calls an observation of a CCIP plugin makes to a chain.
+const observationRPCRoundTrips = 2
+
+// ChainCharacteristics are the characteristics of a chain the OCR params of the DONs reading it must account for.
+// chain-selectors doesn't provide them, so they come from the config. Unset characteristics are not checked.
+type ChainCharacteristics struct {
+	// BlockTime is the average time between blocks.
+	BlockTime time.Duration
+	// FinalityDepth is the number of blocks mined on top of a block for it to be final.
+	FinalityDepth uint32
+	// RPCLatency is the worst case latency of the RPCs of the nodes to the chain.
+	RPCLatency time.Duration
+}
+
+// FinalityTime is the time for a block to be final, zero if the block time or finality depth is unknown.
+func (c ChainCharacteristics) FinalityTime() time.Duration {
+	return c.BlockTime * time.Duration(c.FinalityDepth)
+}
+
+// LintOCRParams checks the OCR params of the DON of the destination chain dest against the characteristics
+// of the chains, keyed by chain selector. The sources of dest are the other chains of chars.
+// It returns an error listing all the misconfigurations found along with how to fix them.
+func LintOCRParams(dest uint64, params CCIPOCRParams, chars map[uint64]ChainCharacteristics) error {
+	var errs []error
+	ocr := params.OCRParameters
+	if destChars, ok := chars[dest]; ok {
+		if destChars.BlockTime > 0 && ocr.DeltaRound < destChars.BlockTime {
+			errs = append(errs, fmt.Errorf("DeltaRound %s of chain %d is shorter than its block time %s, "+
+				"consecutive rounds would observe the same block: set DeltaRound to at least %s",
+				ocr.DeltaRound, dest, destChars.BlockTime, destChars.BlockTime))
+		}
+		if destChars.RPCLatency > 0 && ocr.MaxDurationShouldTransmitAcceptedReport < destChars.RPCLatency {
+			errs = append(errs, fmt.Errorf("MaxDurationShouldTransmitAcceptedReport %s of chain %d is shorter than its RPC latency %s, "+
+				"reports would not be transmitted: set it to at least %s",
+				ocr.MaxDurationShouldTransmitAcceptedReport, dest, destChars.RPCLatency, destChars.RPCLatency))
+		}
+	}
+	sources := make([]uint64, 0, len(chars))
+	for sel := range chars {
+		sources = append(sources, sel)
+	}
+	sort.Slice(sources, func(i, j int) bool { return sources[i] < sources[j] })
+	visibility := params.ExecuteOffChainConfig.MessageVisibilityInterval.Duration()
+	for _, sel := range sources {
+		c := chars[sel]
+		if minObservation := observationRPCRoundTrips * c.RPCLatency; ocr.MaxDurationObservation < minObservation {
+			errs = append(errs, fmt.Errorf("MaxDurationObservation %s of chain %d is too short for %d calls to chain %d "+
+				"with an RPC latency of %s, observations would time out: set it to at least %s",
+				ocr.MaxDurationObservation, dest, observationRPCRoundTrips, sel, c.RPCLatency, minObservation))
+		}
+		if sel == dest {
+			continue
+		}
+		if finality := c.FinalityTime(); visibility > 0 && visibility <= finality {
+			errs = append(errs, fmt.Errorf("MessageVisibilityInterval %s of chain %d is not longer than the finality time %s "+
+				"of source chain %d, messages would expire before they are final: set it to more than %s",
+				visibility, dest, finality, sel, finality))
+		}
+	}
+	return errors.Join(errs...)
+}
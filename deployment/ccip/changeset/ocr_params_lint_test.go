@@ -0,0 +1,30 @@
+package changeset
+
+import (
+	"testing"
+	"time"
+
+	chainsel "github.com/smartcontractkit/chain-selectors"
+	"github.com/smartcontractkit/chainlink-common/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLintOCRParams(t *testing.T) {
+	dest, source := chainsel.TEST_90000001.Selector, chainsel.TEST_90000002.Selector
+	params := DefaultOCRParams(0, nil, nil)
+	chars := map[uint64]ChainCharacteristics{
+		dest:   {BlockTime: time.Second, RPCLatency: time.Second},
+		source: {BlockTime: 12 * time.Second, FinalityDepth: 64, RPCLatency: time.Second},
+	}
+	require.NoError(t, LintOCRParams(dest, params, chars))
+	require.NoError(t, LintOCRParams(dest, params, nil))
+
+	chars[dest] = ChainCharacteristics{BlockTime: 12 * time.Second, RPCLatency: 3 * time.Second}
+	params.ExecuteOffChainConfig.MessageVisibilityInterval = *config.MustNewDuration(10 * time.Minute)
+	err := LintOCRParams(dest, params, chars)
+	require.ErrorContains(t, err, "DeltaRound 2s of chain 909606746561742123 is shorter than its block time 12s")
+	require.ErrorContains(t, err, "MaxDurationObservation 5s of chain 909606746561742123 is too short for 2 calls to chain "+
+		"909606746561742123 with an RPC latency of 3s, observations would time out: set it to at least 6s")
+	require.ErrorContains(t, err, "MessageVisibilityInterval 10m0s of chain 909606746561742123 is not longer than "+
+		"the finality time 12m48s of source chain 5548718428018410741")
+}
@@ -0,0 +1,153 @@
+package changeset
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/proposal/timelock"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/offramp"
+)
+
+var (
+	_ deployment.ChangeSet[OffRampDynamicConfigsConfig] = UpdateOffRampDynamicConfigs
+)
+
+// OffRampDynamicConfigUpdate updates the dynamic config of an offramp, unset fields are left unchanged.
+type OffRampDynamicConfigUpdate struct {
+	// PermissionLessExecutionThreshold is the time after which anyone can manually execute a message
+	// the DON did not execute.
+	PermissionLessExecutionThreshold time.Duration
+	IsRMNVerificationDisabled        *bool
+	MessageInterceptor               *common.Address
+}
+
+// merge returns the update with the unset fields taken from the defaults.
+func (u OffRampDynamicConfigUpdate) merge(defaults OffRampDynamicConfigUpdate) OffRampDynamicConfigUpdate {
+	if u.PermissionLessExecutionThreshold == 0 {
+		u.PermissionLessExecutionThreshold = defaults.PermissionLessExecutionThreshold
+	}
+	if u.IsRMNVerificationDisabled == nil {
+		u.IsRMNVerificationDisabled = defaults.IsRMNVerificationDisabled
+	}
+	if u.MessageInterceptor == nil {
+		u.MessageInterceptor = defaults.MessageInterceptor
+	}
+	return u
+}
+
+func (u OffRampDynamicConfigUpdate) apply(cfg offramp.OffRampDynamicConfig) offramp.OffRampDynamicConfig {
+	if u.PermissionLessExecutionThreshold > 0 {
+		cfg.PermissionLessExecutionThresholdSeconds = uint32(u.PermissionLessExecutionThreshold / time.Second)
+	}
+	if u.IsRMNVerificationDisabled != nil {
+		cfg.IsRMNVerificationDisabled = *u.IsRMNVerificationDisabled
+	}
+	if u.MessageInterceptor != nil {
+		cfg.MessageInterceptor = *u.MessageInterceptor
+	}
+	return cfg
+}
+
+// OffRampDynamicConfigsConfig updates the dynamic config of the offramps of the chains with the defaults,
+// overridden per chain.
+type OffRampDynamicConfigsConfig struct {
+	Defaults OffRampDynamicConfigUpdate
+	// Chains are the chains to update, with their overrides of the defaults.
+	Chains map[uint64]OffRampDynamicConfigUpdate
+	// SourceFinality is the worst case time for the messages to the chains to be finalized on their source chain.
+	SourceFinality time.Duration
+	// OCRParams are the OCR params of the chains, DefaultOCRParams if unset.
+	OCRParams map[uint64]CCIPOCRParams
+}
+
+func (c OffRampDynamicConfigsConfig) Validate(e deployment.Environment, state CCIPOnChainState) error {
+	if len(c.Chains) == 0 {
+		return fmt.Errorf("no chains to update")
+	}
+	if c.SourceFinality < 0 {
+		return fmt.Errorf("source finality must not be negative")
+	}
+	for sel, override := range c.Chains {
+		if _, ok := e.Chains[sel]; !ok {
+			return fmt.Errorf("chain %d not found in environment", sel)
+		}
+		if chainState, ok := state.Chains[sel]; !ok || chainState.OffRamp == nil {
+			return fmt.Errorf("offramp not deployed on chain %d", sel)
+		}
+		update := override.merge(c.Defaults)
+		threshold := update.PermissionLessExecutionThreshold
+		if threshold == 0 {
+			continue
+		}
+		if threshold%time.Second != 0 || threshold/time.Second > math.MaxUint32 {
+			return fmt.Errorf("permissionless execution threshold %s of chain %d must be a whole number of seconds fitting in a uint32", threshold, sel)
+		}
+		ocrParams, ok := c.OCRParams[sel]
+		if !ok {
+			ocrParams = DefaultOCRParams(0, nil, nil)
+		}
+		worstCase := CommitRoundTripWorstCase(ocrParams, c.SourceFinality)
+		if threshold < worstCase {
+			return fmt.Errorf("permissionless execution threshold %s of chain %d is shorter than the worst case commit round trip %s",
+				threshold, sel, worstCase)
+		}
+	}
+	return nil
+}
+
+// CommitRoundTripWorstCase returns how long a message can take to be committed on its destination chain in the worst case:
+// the finality of the source chain, a leader change and all the rounds of an epoch of the commit DON,
+// and the RMN signatures timeout if RMN is enabled.
+func CommitRoundTripWorstCase(params CCIPOCRParams, sourceFinality time.Duration) time.Duration {
+	ocr := params.OCRParameters
+	worstCase := sourceFinality + ocr.DeltaProgress + time.Duration(ocr.Rmax)*ocr.DeltaRound //nolint:gosec // rmax is small
+	if params.CommitOffChainConfig.RMNEnabled {
+		worstCase += params.CommitOffChainConfig.RMNSignaturesTimeout
+	}
+	return worstCase
+}
+
+// UpdateOffRampDynamicConfigs updates the dynamic config of the offramps, e.g. their permissionless execution
+// threshold which is otherwise left at its deployment default. Offramps already configured are skipped.
+func UpdateOffRampDynamicConfigs(e deployment.Environment, cfg OffRampDynamicConfigsConfig) (deployment.ChangesetOutput, error) {
+	state, err := LoadOnchainState(e)
+	if err != nil {
+		return deployment.ChangesetOutput{}, fmt.Errorf("failed to load onchain state: %w", err)
+	}
+	if err := cfg.Validate(e, state); err != nil {
+		return deployment.ChangesetOutput{}, fmt.Errorf("invalid OffRampDynamicConfigsConfig: %w", err)
+	}
+	for sel, override := range cfg.Chains {
+		chain := e.Chains[sel]
+		offRamp := state.Chains[sel].OffRamp
+		current, err := offRamp.GetDynamicConfig(&bind.CallOpts{Context: context.Background()})
+		if err != nil {
+			return deployment.ChangesetOutput{}, fmt.Errorf("failed to get dynamic config of offramp on chain %d: %w", sel, err)
+		}
+		updated := override.merge(cfg.Defaults).apply(current)
+		if updated == current {
+			e.Logger.Infow("Offramp dynamic config up to date", "chain", sel)
+			continue
+		}
+		tx, err := offRamp.SetDynamicConfig(chain.DeployerKey, updated)
+		if _, err := deployment.ConfirmIfNoError(chain, tx, err); err != nil {
+			e.Logger.Errorw("Failed to set offramp dynamic config", "chain", sel, "err", err)
+			return deployment.ChangesetOutput{}, deployment.MaybeDataErr(err)
+		}
+		e.Logger.Infow("Updated offramp dynamic config", "chain", sel,
+			"permissionLessExecutionThresholdSeconds", updated.PermissionLessExecutionThresholdSeconds,
+			"isRMNVerificationDisabled", updated.IsRMNVerificationDisabled,
+			"messageInterceptor", updated.MessageInterceptor)
+	}
+	return deployment.ChangesetOutput{
+		Proposals:   []timelock.MCMSWithTimelockProposal{},
+		AddressBook: nil,
+		JobSpecs:    nil,
+	}, nil
+}
@@ -0,0 +1,83 @@
+package changeset_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	chainsel "github.com/smartcontractkit/chain-selectors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset/testhelpers"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/offramp"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+func TestOffRampDynamicConfigsValidate(t *testing.T) {
+	selA, selB := chainsel.TEST_90000001.Selector, chainsel.TEST_90000002.Selector
+	e := deployment.Environment{Chains: map[uint64]deployment.Chain{selA: {Selector: selA}, selB: {Selector: selB}}}
+	offRamp, err := offramp.NewOffRamp(common.HexToAddress("0x1"), nil)
+	require.NoError(t, err)
+	state := changeset.CCIPOnChainState{Chains: map[uint64]changeset.CCIPChainState{selA: {OffRamp: offRamp}, selB: {}}}
+
+	params := changeset.DefaultOCRParams(0, nil, nil)
+	worstCase := changeset.CommitRoundTripWorstCase(params, time.Hour)
+	require.Equal(t, time.Hour+params.OCRParameters.DeltaProgress+
+		time.Duration(params.OCRParameters.Rmax)*params.OCRParameters.DeltaRound, worstCase)
+	params.CommitOffChainConfig.RMNEnabled = true
+	require.Equal(t, worstCase+params.CommitOffChainConfig.RMNSignaturesTimeout, changeset.CommitRoundTripWorstCase(params, time.Hour))
+
+	cfg := changeset.OffRampDynamicConfigsConfig{
+		Defaults:       changeset.OffRampDynamicConfigUpdate{PermissionLessExecutionThreshold: 8 * time.Hour},
+		Chains:         map[uint64]changeset.OffRampDynamicConfigUpdate{selA: {}},
+		SourceFinality: time.Hour,
+	}
+	require.NoError(t, cfg.Validate(e, state))
+
+	// overrides take precedence over the defaults
+	cfg.Chains[selA] = changeset.OffRampDynamicConfigUpdate{PermissionLessExecutionThreshold: time.Hour}
+	require.ErrorContains(t, cfg.Validate(e, state), "shorter than the worst case commit round trip")
+	cfg.Chains[selA] = changeset.OffRampDynamicConfigUpdate{PermissionLessExecutionThreshold: 8*time.Hour + time.Millisecond}
+	require.ErrorContains(t, cfg.Validate(e, state), "whole number of seconds")
+
+	cfg.Chains = map[uint64]changeset.OffRampDynamicConfigUpdate{selB: {}}
+	require.ErrorContains(t, cfg.Validate(e, state), "offramp not deployed")
+}
+
+func TestUpdateOffRampDynamicConfigs(t *testing.T) {
+	lggr := logger.TestLogger(t)
+	e := testhelpers.NewMemoryEnvironmentWithJobsAndContracts(t, lggr, 2, 4, nil)
+	state, err := changeset.LoadOnchainState(e.Env)
+	require.NoError(t, err)
+	chainA, chainB := e.HomeChainSel, e.FeedChainSel
+
+	before, err := state.Chains[chainB].OffRamp.GetDynamicConfig(nil)
+	require.NoError(t, err)
+	interceptor := common.HexToAddress("0x10")
+	cfg := changeset.OffRampDynamicConfigsConfig{
+		Defaults: changeset.OffRampDynamicConfigUpdate{PermissionLessExecutionThreshold: 12 * time.Hour},
+		Chains: map[uint64]changeset.OffRampDynamicConfigUpdate{
+			chainA: {},
+			chainB: {PermissionLessExecutionThreshold: 6 * time.Hour, MessageInterceptor: &interceptor},
+		},
+		SourceFinality: time.Hour,
+	}
+	_, err = changeset.UpdateOffRampDynamicConfigs(e.Env, cfg)
+	require.NoError(t, err)
+
+	configA, err := state.Chains[chainA].OffRamp.GetDynamicConfig(nil)
+	require.NoError(t, err)
+	require.Equal(t, uint32(12*60*60), configA.PermissionLessExecutionThresholdSeconds)
+	configB, err := state.Chains[chainB].OffRamp.GetDynamicConfig(nil)
+	require.NoError(t, err)
+	require.Equal(t, uint32(6*60*60), configB.PermissionLessExecutionThresholdSeconds)
+	require.Equal(t, interceptor, configB.MessageInterceptor)
+	require.Equal(t, before.FeeQuoter, configB.FeeQuoter)
+	require.Equal(t, before.IsRMNVerificationDisabled, configB.IsRMNVerificationDisabled)
+
+	// re-running is a no-op
+	_, err = changeset.UpdateOffRampDynamicConfigs(e.Env, cfg)
+	require.NoError(t, err)
+}
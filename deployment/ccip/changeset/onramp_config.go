@@ -0,0 +1,222 @@
+package changeset
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"slices"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/proposal/mcms"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/proposal/timelock"
+	"golang.org/x/exp/maps"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/onramp"
+)
+
+var (
+	_ deployment.ChangeSet[OnRampDynamicConfigsConfig] = UpdateOnRampDynamicConfigs
+)
+
+// OnRampDynamicConfigUpdate updates the dynamic config of an onramp, unset fields are left unchanged.
+type OnRampDynamicConfigUpdate struct {
+	// FeeAggregator receives the fee tokens withdrawn from the onramp.
+	FeeAggregator *common.Address
+	// AllowlistAdmin can update the sender allowlists of the onramp besides its owner.
+	AllowlistAdmin     *common.Address
+	MessageInterceptor *common.Address
+}
+
+// merge returns the update with the unset fields taken from the defaults.
+func (u OnRampDynamicConfigUpdate) merge(defaults OnRampDynamicConfigUpdate) OnRampDynamicConfigUpdate {
+	if u.FeeAggregator == nil {
+		u.FeeAggregator = defaults.FeeAggregator
+	}
+	if u.AllowlistAdmin == nil {
+		u.AllowlistAdmin = defaults.AllowlistAdmin
+	}
+	if u.MessageInterceptor == nil {
+		u.MessageInterceptor = defaults.MessageInterceptor
+	}
+	return u
+}
+
+func (u OnRampDynamicConfigUpdate) apply(cfg onramp.OnRampDynamicConfig) onramp.OnRampDynamicConfig {
+	if u.FeeAggregator != nil {
+		cfg.FeeAggregator = *u.FeeAggregator
+	}
+	if u.AllowlistAdmin != nil {
+		cfg.AllowlistAdmin = *u.AllowlistAdmin
+	}
+	if u.MessageInterceptor != nil {
+		cfg.MessageInterceptor = *u.MessageInterceptor
+	}
+	return cfg
+}
+
+// OnRampDynamicConfigsConfig updates the dynamic config of the onramps of the chains with the defaults,
+// overridden per chain.
+type OnRampDynamicConfigsConfig struct {
+	Defaults OnRampDynamicConfigUpdate
+	// Chains are the chains to update, with their overrides of the defaults.
+	Chains map[uint64]OnRampDynamicConfigUpdate
+	// MinDelay is the delay of the proposal updating the onramps owned by the timelock.
+	MinDelay time.Duration
+}
+
+func (c OnRampDynamicConfigsConfig) Validate(e deployment.Environment, state CCIPOnChainState) error {
+	if len(c.Chains) == 0 {
+		return fmt.Errorf("no chains to update")
+	}
+	for sel, override := range c.Chains {
+		if _, ok := e.Chains[sel]; !ok {
+			return fmt.Errorf("chain %d not found in environment", sel)
+		}
+		chainState, ok := state.Chains[sel]
+		if !ok || chainState.OnRamp == nil {
+			return fmt.Errorf("onramp not deployed on chain %d", sel)
+		}
+		if chainState.Timelock == nil || chainState.ProposerMcm == nil {
+			return fmt.Errorf("mcms not deployed on chain %d", sel)
+		}
+		// the onramp rejects a zero fee aggregator
+		if update := override.merge(c.Defaults); update.FeeAggregator != nil && *update.FeeAggregator == (common.Address{}) {
+			return fmt.Errorf("fee aggregator of chain %d must not be the zero address", sel)
+		}
+	}
+	return nil
+}
+
+// UpdateOnRampDynamicConfigs updates the dynamic config of the onramps, e.g. their fee aggregator, without
+// redeploying them. The onramps still owned by the deployer are updated directly and read back to verify
+// the update, the ones owned by the timelock are updated by the proposal of the output, see
+// VerifyOnRampDynamicConfigs. Onramps already configured are skipped.
+func UpdateOnRampDynamicConfigs(e deployment.Environment, cfg OnRampDynamicConfigsConfig) (deployment.ChangesetOutput, error) {
+	state, err := LoadOnchainState(e)
+	if err != nil {
+		return deployment.ChangesetOutput{}, fmt.Errorf("failed to load onchain state: %w", err)
+	}
+	if err := cfg.Validate(e, state); err != nil {
+		return deployment.ChangesetOutput{}, fmt.Errorf("invalid OnRampDynamicConfigsConfig: %w", err)
+	}
+	// the batches are built in the order of the chain selectors, so that the same config gives the same proposal
+	sels := maps.Keys(cfg.Chains)
+	slices.Sort(sels)
+	var batches []timelock.BatchChainOperation
+	for _, sel := range sels {
+		override := cfg.Chains[sel]
+		onRamp := state.Chains[sel].OnRamp
+		current, err := onRamp.GetDynamicConfig(&bind.CallOpts{Context: context.Background()})
+		if err != nil {
+			return deployment.ChangesetOutput{}, fmt.Errorf("failed to get dynamic config of onramp on chain %d: %w", sel, err)
+		}
+		updated := override.merge(cfg.Defaults).apply(current)
+		if updated == current {
+			e.Logger.Infow("Onramp dynamic config up to date", "chain", sel)
+			continue
+		}
+		op, err := transactAsOwner(e.Chains[sel], state.Chains[sel].Timelock.Address(), onRamp.Owner,
+			func(opts *bind.TransactOpts) (*types.Transaction, error) {
+				return onRamp.SetDynamicConfig(opts, updated)
+			})
+		if err != nil {
+			return deployment.ChangesetOutput{}, fmt.Errorf("failed to set dynamic config of onramp on chain %d: %w", sel, err)
+		}
+		if op != nil {
+			batches = append(batches, timelock.BatchChainOperation{ChainIdentifier: mcms.ChainIdentifier(sel), Batch: []mcms.Operation{*op}})
+			e.Logger.Infow("Proposing onramp dynamic config", "chain", sel)
+			continue
+		}
+		if err := verifyOnRampDynamicConfig(onRamp, sel, updated); err != nil {
+			return deployment.ChangesetOutput{}, err
+		}
+		e.Logger.Infow("Updated onramp dynamic config", "chain", sel,
+			"feeAggregator", updated.FeeAggregator,
+			"allowlistAdmin", updated.AllowlistAdmin,
+			"messageInterceptor", updated.MessageInterceptor)
+	}
+	return proposeBatches(state, batches, "update onramp dynamic configs", cfg.MinDelay)
+}
+
+// VerifyOnRampDynamicConfigs reads back the dynamic config of the onramps of the config and checks it has
+// the fields of the updates, e.g. once the proposal of UpdateOnRampDynamicConfigs is executed.
+func VerifyOnRampDynamicConfigs(e deployment.Environment, cfg OnRampDynamicConfigsConfig) error {
+	state, err := LoadOnchainState(e)
+	if err != nil {
+		return fmt.Errorf("failed to load onchain state: %w", err)
+	}
+	if err := cfg.Validate(e, state); err != nil {
+		return fmt.Errorf("invalid OnRampDynamicConfigsConfig: %w", err)
+	}
+	for sel, override := range cfg.Chains {
+		onRamp := state.Chains[sel].OnRamp
+		current, err := onRamp.GetDynamicConfig(&bind.CallOpts{Context: context.Background()})
+		if err != nil {
+			return fmt.Errorf("failed to get dynamic config of onramp on chain %d: %w", sel, err)
+		}
+		if err := verifyOnRampDynamicConfig(onRamp, sel, override.merge(cfg.Defaults).apply(current)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func verifyOnRampDynamicConfig(onRamp *onramp.OnRamp, sel uint64, expected onramp.OnRampDynamicConfig) error {
+	actual, err := onRamp.GetDynamicConfig(&bind.CallOpts{Context: context.Background()})
+	if err != nil {
+		return fmt.Errorf("failed to read back dynamic config of onramp on chain %d: %w", sel, err)
+	}
+	if actual != expected {
+		return fmt.Errorf("dynamic config of onramp on chain %d is %+v, expected %+v", sel, actual, expected)
+	}
+	return nil
+}
+
+// transactAsOwner sends the transaction with the deployer key if the deployer owns the contract, otherwise it
+// returns the operation for the timelock owning the contract to execute.
+func transactAsOwner(
+	chain deployment.Chain,
+	timelockAddr common.Address,
+	owner func(opts *bind.CallOpts) (common.Address, error),
+	send func(opts *bind.TransactOpts) (*types.Transaction, error),
+) (*mcms.Operation, error) {
+	ownerAddr, err := owner(&bind.CallOpts{Context: context.Background()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get owner: %w", err)
+	}
+	if ownerAddr != chain.DeployerKey.From {
+		if ownerAddr != timelockAddr {
+			return nil, fmt.Errorf("contract is owned by %s, neither the deployer nor the timelock", ownerAddr)
+		}
+		tx, err := send(deployment.SimTransactOpts())
+		if err != nil {
+			return nil, err
+		}
+		return &mcms.Operation{To: *tx.To(), Data: tx.Data(), Value: big.NewInt(0)}, nil
+	}
+	tx, err := send(chain.DeployerKey)
+	if _, err := deployment.ConfirmIfNoError(chain, tx, err); err != nil {
+		return nil, deployment.MaybeDataErr(err)
+	}
+	return nil, nil
+}
+
+// proposeBatches returns the output of a changeset with a proposal of the batches, if any.
+func proposeBatches(state CCIPOnChainState, batches []timelock.BatchChainOperation, description string, minDelay time.Duration) (deployment.ChangesetOutput, error) {
+	if len(batches) == 0 {
+		return deployment.ChangesetOutput{}, nil
+	}
+	prop, err := BuildProposalFromBatches(state, batches, description, minDelay)
+	if err != nil {
+		return deployment.ChangesetOutput{}, err
+	}
+	return deployment.ChangesetOutput{
+		Proposals:   []timelock.MCMSWithTimelockProposal{*prop},
+		AddressBook: nil,
+		JobSpecs:    nil,
+	}, nil
+}
@@ -0,0 +1,108 @@
+package changeset_test
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	chainsel "github.com/smartcontractkit/chain-selectors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset/testhelpers"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/onramp"
+)
+
+func TestOnRampDynamicConfigsValidate(t *testing.T) {
+	selA, selB := chainsel.TEST_90000001.Selector, chainsel.TEST_90000002.Selector
+	e := deployment.Environment{Chains: map[uint64]deployment.Chain{selA: {Selector: selA}, selB: {Selector: selB}}}
+	onRamp, err := onramp.NewOnRamp(common.HexToAddress("0x1"), nil)
+	require.NoError(t, err)
+	state := changeset.CCIPOnChainState{Chains: map[uint64]changeset.CCIPChainState{selA: {OnRamp: onRamp}, selB: {}}}
+
+	cfg := changeset.OnRampDynamicConfigsConfig{Chains: map[uint64]changeset.OnRampDynamicConfigUpdate{selA: {}}}
+	require.ErrorContains(t, cfg.Validate(e, state), "mcms not deployed")
+
+	zero := common.Address{}
+	cfg.Defaults = changeset.OnRampDynamicConfigUpdate{FeeAggregator: &zero}
+	cfg.Chains = map[uint64]changeset.OnRampDynamicConfigUpdate{selB: {}}
+	require.ErrorContains(t, cfg.Validate(e, state), "onramp not deployed")
+	cfg.Chains = map[uint64]changeset.OnRampDynamicConfigUpdate{}
+	require.ErrorContains(t, cfg.Validate(e, state), "no chains to update")
+}
+
+// TestRampConfigChangesets runs the test cases of the ramp config changesets on a cached environment.
+func TestRampConfigChangesets(t *testing.T) {
+	cache := testhelpers.NewDeployedEnvCache()
+	testhelpers.NewCachedMemoryEnvironmentWithJobsAndContracts(t, cache, 2, 4, nil)
+	t.Run("onramp dynamic configs", func(t *testing.T) {
+		testUpdateOnRampDynamicConfigs(t, testhelpers.NewCachedMemoryEnvironmentWithJobsAndContracts(t, cache, 2, 4, nil))
+	})
+	t.Run("fee quoter gas configs", func(t *testing.T) {
+		testUpdateFeeQuoterGasConfigs(t, testhelpers.NewCachedMemoryEnvironmentWithJobsAndContracts(t, cache, 2, 4, nil))
+	})
+}
+
+func testUpdateOnRampDynamicConfigs(t *testing.T, e testhelpers.DeployedEnv) {
+	state, err := changeset.LoadOnchainState(e.Env)
+	require.NoError(t, err)
+	chainA, chainB := e.HomeChainSel, e.FeedChainSel
+
+	before, err := state.Chains[chainB].OnRamp.GetDynamicConfig(nil)
+	require.NoError(t, err)
+	aggregator, admin := common.HexToAddress("0x10"), common.HexToAddress("0x11")
+	cfg := changeset.OnRampDynamicConfigsConfig{
+		Defaults: changeset.OnRampDynamicConfigUpdate{FeeAggregator: &aggregator},
+		Chains: map[uint64]changeset.OnRampDynamicConfigUpdate{
+			chainA: {},
+			chainB: {AllowlistAdmin: &admin},
+		},
+	}
+	_, err = changeset.UpdateOnRampDynamicConfigs(e.Env, cfg)
+	require.NoError(t, err)
+	require.NoError(t, changeset.VerifyOnRampDynamicConfigs(e.Env, cfg))
+
+	configA, err := state.Chains[chainA].OnRamp.GetDynamicConfig(nil)
+	require.NoError(t, err)
+	require.Equal(t, aggregator, configA.FeeAggregator)
+	configB, err := state.Chains[chainB].OnRamp.GetDynamicConfig(nil)
+	require.NoError(t, err)
+	require.Equal(t, aggregator, configB.FeeAggregator)
+	require.Equal(t, admin, configB.AllowlistAdmin)
+	require.Equal(t, before.FeeQuoter, configB.FeeQuoter)
+
+	// re-running is a no-op
+	_, err = changeset.UpdateOnRampDynamicConfigs(e.Env, cfg)
+	require.NoError(t, err)
+}
+
+func testUpdateFeeQuoterGasConfigs(t *testing.T, e testhelpers.DeployedEnv) {
+	state, err := changeset.LoadOnchainState(e.Env)
+	require.NoError(t, err)
+	chainA, chainB := e.HomeChainSel, e.FeedChainSel
+	require.NoError(t, changeset.AddLaneWithDefaultPricesAndFeeQuoterConfig(e.Env, state, chainA, chainB, false))
+
+	before, err := state.Chains[chainA].FeeQuoter.GetDestChainConfig(nil, chainB)
+	require.NoError(t, err)
+	overhead := before.DestGasOverhead + 1000
+	cfg := changeset.FeeQuoterGasConfigsConfig{
+		Updates: map[uint64]map[uint64]changeset.FeeQuoterGasUpdate{
+			chainA: {chainB: {DestGasOverhead: &overhead}},
+		},
+	}
+	_, err = changeset.UpdateFeeQuoterGasConfigs(e.Env, cfg)
+	require.NoError(t, err)
+	require.NoError(t, changeset.VerifyFeeQuoterGasConfigs(e.Env, cfg))
+
+	after, err := state.Chains[chainA].FeeQuoter.GetDestChainConfig(nil, chainB)
+	require.NoError(t, err)
+	expected := before
+	expected.DestGasOverhead = overhead
+	require.Equal(t, expected, after)
+
+	// the default gas limit must not exceed the max
+	tooHigh := after.MaxPerMsgGasLimit + 1
+	cfg.Updates[chainA][chainB] = changeset.FeeQuoterGasUpdate{DefaultTxGasLimit: &tooHigh}
+	_, err = changeset.UpdateFeeQuoterGasConfigs(e.Env, cfg)
+	require.ErrorContains(t, err, "exceeds the max per message gas limit")
+}
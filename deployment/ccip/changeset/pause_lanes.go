@@ -0,0 +1,371 @@
+package changeset
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/proposal/mcms"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/proposal/timelock"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/router"
+)
+
+var _ deployment.ChangeSet[PauseLanesConfig] = PauseLanesChangeset
+
+// PauseMethod is how PauseLanesChangeset stops the traffic of a lane.
+type PauseMethod string
+
+const (
+	// PauseByCurse curses the destination chain on the RMNRemote of the source chain and the source chain
+	// on the RMNRemote of the destination chain, which stops sending, committing and executing on the lane.
+	PauseByCurse PauseMethod = "curse"
+	// PauseByRouter removes the onramp of the lane from the router of the source chain and its offramp
+	// from the router of the destination chain, which stops sending and delivering the messages of the lane.
+	PauseByRouter PauseMethod = "router"
+)
+
+// PauseLanesConfig pauses lanes in an emergency, see PauseLanesChangeset.
+type PauseLanesConfig struct {
+	Lanes  []SourceDestPair
+	Method PauseMethod
+	// MinDelay is the delay of the un-pause proposal.
+	MinDelay time.Duration
+}
+
+func (c PauseLanesConfig) Validate(e deployment.Environment, state CCIPOnChainState) error {
+	if len(c.Lanes) == 0 {
+		return fmt.Errorf("no lanes to pause")
+	}
+	if c.Method != PauseByCurse && c.Method != PauseByRouter {
+		return fmt.Errorf("unknown pause method %q", c.Method)
+	}
+	lanes := make(map[SourceDestPair]struct{})
+	for _, lane := range c.Lanes {
+		if lane.SourceChainSelector == lane.DestChainSelector {
+			return fmt.Errorf("cannot pause lane to the same chain")
+		}
+		if _, ok := lanes[lane]; ok {
+			return fmt.Errorf("duplicate lane %d -> %d", lane.SourceChainSelector, lane.DestChainSelector)
+		}
+		lanes[lane] = struct{}{}
+		for _, sel := range []uint64{lane.SourceChainSelector, lane.DestChainSelector} {
+			if err := c.validateChain(e, state, sel); err != nil {
+				return err
+			}
+		}
+		if state.Chains[lane.SourceChainSelector].OnRamp == nil {
+			return fmt.Errorf("onramp not deployed on chain %d", lane.SourceChainSelector)
+		}
+		if state.Chains[lane.DestChainSelector].OffRamp == nil {
+			return fmt.Errorf("offramp not deployed on chain %d", lane.DestChainSelector)
+		}
+	}
+	return nil
+}
+
+// validateChain checks the lanes of the chain can be paused and un-paused, by the deployer or the timelock
+// owning the contract pausing them.
+func (c PauseLanesConfig) validateChain(e deployment.Environment, state CCIPOnChainState, sel uint64) error {
+	if _, ok := e.Chains[sel]; !ok {
+		return fmt.Errorf("chain %d not found in environment", sel)
+	}
+	chainState, ok := state.Chains[sel]
+	if !ok {
+		return fmt.Errorf("chain %d not found in onchain state", sel)
+	}
+	if chainState.Timelock == nil || chainState.ProposerMcm == nil || chainState.BypasserMcm == nil {
+		return fmt.Errorf("mcms not deployed on chain %d", sel)
+	}
+	if chainState.Router == nil {
+		return fmt.Errorf("router not deployed on chain %d", sel)
+	}
+	if c.Method == PauseByCurse && chainState.RMNRemote == nil {
+		return fmt.Errorf("rmn remote not deployed on chain %d", sel)
+	}
+	_, err := c.pausedByDeployer(e.Chains[sel], chainState)
+	return err
+}
+
+// pausedByDeployer returns whether the deployer owns the contract pausing the lanes of the chain. Otherwise the
+// timelock owns it, and the lanes are paused by a bypass proposal.
+func (c PauseLanesConfig) pausedByDeployer(chain deployment.Chain, chainState CCIPChainState) (bool, error) {
+	var owner common.Address
+	var err error
+	opts := &bind.CallOpts{Context: context.Background()}
+	switch c.Method {
+	case PauseByCurse:
+		owner, err = chainState.RMNRemote.Owner(opts)
+	case PauseByRouter:
+		owner, err = chainState.Router.Owner(opts)
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to get owner of the contract pausing the lanes of chain %d: %w", chain.Selector, err)
+	}
+	switch owner {
+	case chain.DeployerKey.From:
+		return true, nil
+	case chainState.Timelock.Address():
+		return false, nil
+	default:
+		return false, fmt.Errorf("the contract pausing the lanes of chain %d is owned by %s, neither the deployer nor the timelock", chain.Selector, owner)
+	}
+}
+
+// lanePause are the updates pausing lanes on a chain.
+type lanePause struct {
+	// subjects cursed on the RMNRemote.
+	subjects [][16]byte
+	// onRamps removed from the router, with the address they routed to.
+	onRamps []router.RouterOnRamp
+	// offRamps removed from the router.
+	offRamps []router.RouterOffRamp
+}
+
+func (p *lanePause) empty() bool {
+	return len(p.subjects)+len(p.onRamps)+len(p.offRamps) == 0
+}
+
+// PauseLanesChangeset pauses the lanes for incident response. The lanes of the chains where the deployer owns the
+// contract pausing them are paused right away with the deployer key, and verified to revert, see VerifyLanePaused.
+// The lanes of the chains where the timelock owns it are paused by a bypass proposal, which the timelock executes
+// without a delay, see BuildBypassProposalFromBatches. Lanes or parts of lanes already paused are left as they are.
+// The last proposal of the output un-pauses exactly what the changeset paused, for the timelock to execute once the
+// incident is resolved and it owns the contracts pausing the lanes.
+func PauseLanesChangeset(e deployment.Environment, cfg PauseLanesConfig) (deployment.ChangesetOutput, error) {
+	state, err := LoadOnchainState(e)
+	if err != nil {
+		e.Logger.Errorw("Failed to load existing onchain state", "err", err)
+		return deployment.ChangesetOutput{}, err
+	}
+	if err := cfg.Validate(e, state); err != nil {
+		return deployment.ChangesetOutput{}, fmt.Errorf("invalid PauseLanesConfig: %w", err)
+	}
+	pauses, err := planLanePauses(state, cfg)
+	if err != nil {
+		return deployment.ChangesetOutput{}, err
+	}
+	sels := make([]uint64, 0, len(pauses))
+	for sel := range pauses {
+		sels = append(sels, sel)
+	}
+	sort.Slice(sels, func(i, j int) bool { return sels[i] < sels[j] })
+
+	var pauseBatches, unpauseBatches []timelock.BatchChainOperation
+	proposed := make(map[uint64]bool)
+	for _, sel := range sels {
+		pause := pauses[sel]
+		if pause.empty() {
+			continue
+		}
+		byDeployer, err := cfg.pausedByDeployer(e.Chains[sel], state.Chains[sel])
+		if err != nil {
+			return deployment.ChangesetOutput{}, err
+		}
+		if byDeployer {
+			if err := applyLanePause(e.Chains[sel], state.Chains[sel], pause); err != nil {
+				return deployment.ChangesetOutput{}, err
+			}
+			e.Logger.Infow("Paused lanes", "chain", sel, "method", cfg.Method, "cursedSubjects", len(pause.subjects),
+				"removedOnRamps", len(pause.onRamps), "removedOffRamps", len(pause.offRamps))
+		} else {
+			batch, err := pauseBatch(state.Chains[sel], sel, pause)
+			if err != nil {
+				return deployment.ChangesetOutput{}, err
+			}
+			pauseBatches = append(pauseBatches, batch)
+			proposed[sel] = true
+			e.Logger.Infow("Proposed to pause lanes", "chain", sel, "method", cfg.Method, "cursedSubjects", len(pause.subjects),
+				"removedOnRamps", len(pause.onRamps), "removedOffRamps", len(pause.offRamps))
+		}
+		batch, err := unpauseBatch(state.Chains[sel], sel, pause)
+		if err != nil {
+			return deployment.ChangesetOutput{}, err
+		}
+		unpauseBatches = append(unpauseBatches, batch)
+	}
+	for _, lane := range cfg.Lanes {
+		if proposed[lane.SourceChainSelector] || proposed[lane.DestChainSelector] {
+			// verified once the bypass proposal is executed
+			continue
+		}
+		if err := VerifyLanePaused(state, lane); err != nil {
+			return deployment.ChangesetOutput{}, err
+		}
+	}
+	if len(unpauseBatches) == 0 {
+		e.Logger.Infow("Lanes already paused", "lanes", cfg.Lanes)
+		return deployment.ChangesetOutput{}, nil
+	}
+	var proposals []timelock.MCMSWithTimelockProposal
+	if len(pauseBatches) > 0 {
+		prop, err := BuildBypassProposalFromBatches(state, pauseBatches, fmt.Sprintf("pause %d lanes by %s", len(cfg.Lanes), cfg.Method))
+		if err != nil {
+			return deployment.ChangesetOutput{}, err
+		}
+		proposals = append(proposals, *prop)
+	}
+	prop, err := BuildProposalFromBatches(state, unpauseBatches, fmt.Sprintf("un-pause %d lanes paused by %s", len(cfg.Lanes), cfg.Method), cfg.MinDelay)
+	if err != nil {
+		return deployment.ChangesetOutput{}, err
+	}
+	proposals = append(proposals, *prop)
+	return deployment.ChangesetOutput{
+		Proposals:   proposals,
+		AddressBook: nil,
+		JobSpecs:    nil,
+	}, nil
+}
+
+// planLanePauses returns the updates pausing the lanes by chain, skipping the ones already applied.
+func planLanePauses(state CCIPOnChainState, cfg PauseLanesConfig) (map[uint64]*lanePause, error) {
+	opts := &bind.CallOpts{Context: context.Background()}
+	pauses := make(map[uint64]*lanePause)
+	pauseOf := func(sel uint64) *lanePause {
+		if _, ok := pauses[sel]; !ok {
+			pauses[sel] = &lanePause{}
+		}
+		return pauses[sel]
+	}
+	cursed := make(map[uint64][][16]byte)
+	for _, lane := range cfg.Lanes {
+		src, dest := lane.SourceChainSelector, lane.DestChainSelector
+		switch cfg.Method {
+		case PauseByCurse:
+			// the onramp checks the curse of the destination chain, the offramp the one of the source chain
+			for _, c := range []struct{ chain, subject uint64 }{{src, dest}, {dest, src}} {
+				if _, ok := cursed[c.chain]; !ok {
+					subjects, err := state.Chains[c.chain].RMNRemote.GetCursedSubjects(opts)
+					if err != nil {
+						return nil, fmt.Errorf("failed to get cursed subjects of chain %d: %w", c.chain, err)
+					}
+					cursed[c.chain] = subjects
+				}
+				subject := ChainCurseSubject(c.subject)
+				if !slices.Contains(cursed[c.chain], subject) {
+					cursed[c.chain] = append(cursed[c.chain], subject)
+					pauseOf(c.chain).subjects = append(pauseOf(c.chain).subjects, subject)
+				}
+			}
+		case PauseByRouter:
+			onRamp, err := state.Chains[src].Router.GetOnRamp(opts, dest)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get onramp of router on chain %d: %w", src, err)
+			}
+			if onRamp != (common.Address{}) {
+				pauseOf(src).onRamps = append(pauseOf(src).onRamps, router.RouterOnRamp{DestChainSelector: dest, OnRamp: onRamp})
+			}
+			offRamp := state.Chains[dest].OffRamp.Address()
+			isOffRamp, err := state.Chains[dest].Router.IsOffRamp(opts, src, offRamp)
+			if err != nil {
+				return nil, fmt.Errorf("failed to check offramp of router on chain %d: %w", dest, err)
+			}
+			if isOffRamp {
+				pauseOf(dest).offRamps = append(pauseOf(dest).offRamps, router.RouterOffRamp{SourceChainSelector: src, OffRamp: offRamp})
+			}
+		}
+	}
+	return pauses, nil
+}
+
+func applyLanePause(chain deployment.Chain, chainState CCIPChainState, pause *lanePause) error {
+	if len(pause.subjects) > 0 {
+		tx, err := chainState.RMNRemote.Curse0(chain.DeployerKey, pause.subjects)
+		if _, err := deployment.ConfirmIfNoError(chain, tx, err); err != nil {
+			return fmt.Errorf("failed to curse lanes on chain %d: %w", chain.Selector, deployment.MaybeDataErr(err))
+		}
+	}
+	if len(pause.onRamps)+len(pause.offRamps) > 0 {
+		tx, err := chainState.Router.ApplyRampUpdates(chain.DeployerKey, removedOnRamps(pause), pause.offRamps, []router.RouterOffRamp{})
+		if _, err := deployment.ConfirmIfNoError(chain, tx, err); err != nil {
+			return fmt.Errorf("failed to remove lanes from router on chain %d: %w", chain.Selector, deployment.MaybeDataErr(err))
+		}
+	}
+	return nil
+}
+
+// pauseBatch returns the operations pausing the chain, for the timelock owning the contract pausing its lanes.
+func pauseBatch(chainState CCIPChainState, sel uint64, pause *lanePause) (timelock.BatchChainOperation, error) {
+	batch := timelock.BatchChainOperation{ChainIdentifier: mcms.ChainIdentifier(sel)}
+	if len(pause.subjects) > 0 {
+		tx, err := chainState.RMNRemote.Curse0(deployment.SimTransactOpts(), pause.subjects)
+		if err != nil {
+			return batch, fmt.Errorf("failed to pack curse on chain %d: %w", sel, err)
+		}
+		batch.Batch = append(batch.Batch, mcms.Operation{To: chainState.RMNRemote.Address(), Data: tx.Data(), Value: big.NewInt(0)})
+	}
+	if len(pause.onRamps)+len(pause.offRamps) > 0 {
+		tx, err := chainState.Router.ApplyRampUpdates(deployment.SimTransactOpts(), removedOnRamps(pause), pause.offRamps, []router.RouterOffRamp{})
+		if err != nil {
+			return batch, fmt.Errorf("failed to pack ramp updates on chain %d: %w", sel, err)
+		}
+		batch.Batch = append(batch.Batch, mcms.Operation{To: chainState.Router.Address(), Data: tx.Data(), Value: big.NewInt(0)})
+	}
+	return batch, nil
+}
+
+// removedOnRamps returns the updates removing the onramps of the pause from the router.
+func removedOnRamps(pause *lanePause) []router.RouterOnRamp {
+	removed := make([]router.RouterOnRamp, 0, len(pause.onRamps))
+	for _, onRamp := range pause.onRamps {
+		removed = append(removed, router.RouterOnRamp{DestChainSelector: onRamp.DestChainSelector, OnRamp: common.Address{}})
+	}
+	return removed
+}
+
+// unpauseBatch returns the operations reverting the pause of the chain.
+func unpauseBatch(chainState CCIPChainState, sel uint64, pause *lanePause) (timelock.BatchChainOperation, error) {
+	batch := timelock.BatchChainOperation{ChainIdentifier: mcms.ChainIdentifier(sel)}
+	if len(pause.subjects) > 0 {
+		tx, err := chainState.RMNRemote.Uncurse0(deployment.SimTransactOpts(), pause.subjects)
+		if err != nil {
+			return batch, fmt.Errorf("failed to pack uncurse on chain %d: %w", sel, err)
+		}
+		batch.Batch = append(batch.Batch, mcms.Operation{To: chainState.RMNRemote.Address(), Data: tx.Data(), Value: big.NewInt(0)})
+	}
+	if len(pause.onRamps)+len(pause.offRamps) > 0 {
+		tx, err := chainState.Router.ApplyRampUpdates(deployment.SimTransactOpts(), pause.onRamps, []router.RouterOffRamp{}, pause.offRamps)
+		if err != nil {
+			return batch, fmt.Errorf("failed to pack ramp updates on chain %d: %w", sel, err)
+		}
+		batch.Batch = append(batch.Batch, mcms.Operation{To: chainState.Router.Address(), Data: tx.Data(), Value: big.NewInt(0)})
+	}
+	return batch, nil
+}
+
+// VerifyLanePaused verifies that sending a message on the lane through the router of the source chain reverts.
+func VerifyLanePaused(state CCIPOnChainState, lane SourceDestPair) error {
+	src, dest := lane.SourceChainSelector, lane.DestChainSelector
+	_, err := state.Chains[src].Router.GetFee(&bind.CallOpts{Context: context.Background()}, dest, router.ClientEVM2AnyMessage{
+		Receiver:     common.LeftPadBytes(common.Address{}.Bytes(), 32),
+		Data:         []byte{},
+		TokenAmounts: []router.ClientEVMTokenAmount{},
+		FeeToken:     common.Address{},
+		ExtraArgs:    nil,
+	})
+	if err == nil {
+		return fmt.Errorf("lane %d -> %d is not paused, messages can still be sent", src, dest)
+	}
+	revert, ok := deployment.DecodeRevert(err)
+	if !ok {
+		return fmt.Errorf("failed to verify lane %d -> %d is paused: %w", src, dest, err)
+	}
+	if revert.Name != "CursedByRMN" && revert.Name != "UnsupportedDestinationChain" {
+		return fmt.Errorf("sending on lane %d -> %d reverts with %s, not because it is paused", src, dest, revert)
+	}
+	return nil
+}
+
+// ChainCurseSubject is the RMNRemote subject cursing the lanes from or to the chain.
+func ChainCurseSubject(chainSelector uint64) [16]byte {
+	var subject [16]byte
+	binary.BigEndian.PutUint64(subject[8:], chainSelector)
+	return subject
+}
@@ -0,0 +1,123 @@
+package changeset_test
+
+import (
+	"math/big"
+	"slices"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/proposal/mcms"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/proposal/timelock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-testing-framework/lib/utils/testcontext"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset/testhelpers"
+	commonchangeset "github.com/smartcontractkit/chainlink/deployment/common/changeset"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/router"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+func TestPauseLanesChangeset(t *testing.T) {
+	e := testhelpers.NewMemoryEnvironmentWithJobsAndContracts(t, logger.TestLogger(t), 2, 4, nil)
+	state, err := changeset.LoadOnchainState(e.Env)
+	require.NoError(t, err)
+	src, dest := e.HomeChainSel, e.FeedChainSel
+	lane := changeset.SourceDestPair{SourceChainSelector: src, DestChainSelector: dest}
+	testhelpers.ReplayLogs(t, e.Env.Offchain, e.ReplayBlocks)
+	require.NoError(t, testhelpers.AddLanesForAll(e.Env, state))
+
+	_, err = changeset.PauseLanesChangeset(e.Env, changeset.PauseLanesConfig{Lanes: []changeset.SourceDestPair{lane, lane}, Method: changeset.PauseByCurse})
+	require.ErrorContains(t, err, "duplicate lane")
+	_, err = changeset.PauseLanesChangeset(e.Env, changeset.PauseLanesConfig{Lanes: []changeset.SourceDestPair{lane}, Method: "freeze"})
+	require.ErrorContains(t, err, "unknown pause method")
+	require.ErrorContains(t, changeset.VerifyLanePaused(state, lane), "is not paused")
+
+	msg := router.ClientEVM2AnyMessage{
+		Receiver:  common.LeftPadBytes(state.Chains[dest].Receiver.Address().Bytes(), 32),
+		Data:      []byte("hello"),
+		FeeToken:  common.HexToAddress("0x0"),
+		ExtraArgs: nil,
+	}
+	sendAndConfirm := func() {
+		latesthdr, err := e.Env.Chains[dest].Client.HeaderByNumber(testcontext.Get(t), nil)
+		require.NoError(t, err)
+		startBlock := latesthdr.Number.Uint64()
+		msgSentEvent := testhelpers.TestSendRequest(t, e.Env, state, src, dest, false, msg)
+		_, err = testhelpers.ConfirmExecWithSeqNrs(t, e.Env.Chains[src], e.Env.Chains[dest], state.Chains[dest].OffRamp, &startBlock,
+			[]uint64{msgSentEvent.SequenceNumber})
+		require.NoError(t, err)
+	}
+	// pause hands the contracts pausing the lane over to the timelock, which then executes the un-pause proposal
+	pause := func(method changeset.PauseMethod, contractOf func(changeset.CCIPChainState) ownable) {
+		out, err := changeset.PauseLanesChangeset(e.Env, changeset.PauseLanesConfig{Lanes: []changeset.SourceDestPair{lane}, Method: method})
+		require.NoError(t, err)
+		require.Len(t, out.Proposals, 1)
+		require.Equal(t, timelock.Schedule, out.Proposals[0].Operation)
+		require.NoError(t, changeset.VerifyLanePaused(state, lane))
+		_, _, err = testhelpers.CCIPSendRequest(e.Env, state, src, dest, false, msg)
+		require.Error(t, err)
+		// pausing again is a no-op
+		again, err := changeset.PauseLanesChangeset(e.Env, changeset.PauseLanesConfig{Lanes: []changeset.SourceDestPair{lane}, Method: method})
+		require.NoError(t, err)
+		require.Empty(t, again.Proposals)
+
+		for _, sel := range []uint64{src, dest} {
+			chain, chainState := e.Env.Chains[sel], state.Chains[sel]
+			contract := contractOf(chainState)
+			tx, err := contract.TransferOwnership(chain.DeployerKey, chainState.Timelock.Address())
+			_, err = deployment.ConfirmIfNoError(chain, tx, err)
+			require.NoError(t, err)
+			acceptOwnership, err := contract.AcceptOwnership(deployment.SimTransactOpts())
+			require.NoError(t, err)
+			prop, err := changeset.BuildProposalFromBatches(state, []timelock.BatchChainOperation{{
+				ChainIdentifier: mcms.ChainIdentifier(sel),
+				Batch:           []mcms.Operation{{To: contract.Address(), Data: acceptOwnership.Data(), Value: big.NewInt(0)}},
+			}}, "accept ownership", 0)
+			require.NoError(t, err)
+			commonchangeset.ExecuteProposal(t, e.Env, commonchangeset.SignProposal(t, e.Env, prop), chainState.Timelock, sel)
+		}
+		testhelpers.ProcessChangeset(t, e.Env, "PauseLanesChangeset", out)
+		sendAndConfirm()
+	}
+
+	sendAndConfirm()
+	pause(changeset.PauseByCurse, func(s changeset.CCIPChainState) ownable { return s.RMNRemote })
+
+	// the timelock now owns the RMNRemotes, the lane is paused by a bypass proposal
+	out, err := changeset.PauseLanesChangeset(e.Env, changeset.PauseLanesConfig{Lanes: []changeset.SourceDestPair{lane}, Method: changeset.PauseByCurse})
+	require.NoError(t, err)
+	require.Len(t, out.Proposals, 2)
+	require.Equal(t, timelock.Bypass, out.Proposals[0].Operation)
+	require.ErrorContains(t, changeset.VerifyLanePaused(state, lane), "is not paused")
+	auditLog := deployment.NewMemoryAuditLog()
+	e.Env.AuditLog = auditLog
+	testhelpers.ProcessChangeset(t, e.Env, "PauseLanesChangeset", deployment.ChangesetOutput{Proposals: out.Proposals[:1]})
+	require.NoError(t, changeset.VerifyLanePaused(state, lane))
+	testhelpers.ProcessChangeset(t, e.Env, "PauseLanesChangeset", deployment.ChangesetOutput{Proposals: out.Proposals[1:]})
+	sendAndConfirm()
+
+	// the executions are recorded with the changeset and their last transaction, which is audited itself
+	executed, err := auditLog.Query(deployment.AuditQuery{Type: deployment.AuditProposalExecuted, Changeset: "PauseLanesChangeset"})
+	require.NoError(t, err)
+	require.NotEmpty(t, executed)
+	txs, err := auditLog.Query(deployment.AuditQuery{Type: deployment.AuditTxExecuted, Changeset: "PauseLanesChangeset"})
+	require.NoError(t, err)
+	for _, event := range executed {
+		require.NotEqual(t, common.Hash{}, event.TxHash)
+		require.True(t, slices.ContainsFunc(txs, func(tx deployment.AuditEvent) bool { return tx.TxHash == event.TxHash }))
+	}
+	e.Env.AuditLog = nil
+
+	pause(changeset.PauseByRouter, func(s changeset.CCIPChainState) ownable { return s.Router })
+}
+
+type ownable interface {
+	Address() common.Address
+	TransferOwnership(opts *bind.TransactOpts, to common.Address) (*types.Transaction, error)
+	AcceptOwnership(opts *bind.TransactOpts) (*types.Transaction, error)
+}
@@ -2,6 +2,7 @@ package changeset
 
 import (
 	"fmt"
+	"slices"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/pkg/errors"
@@ -23,8 +24,12 @@ func DeployPrerequisites(env deployment.Environment, cfg DeployPrerequisiteConfi
 	if err != nil {
 		return deployment.ChangesetOutput{}, errors.Wrapf(deployment.ErrInvalidConfig, "%v", err)
 	}
+	if err := VerifyPinnedArtifacts(ExpectedBytecode); err != nil {
+		return deployment.ChangesetOutput{}, err
+	}
 	ab := deployment.NewMemoryAddressBook()
-	err = deployPrerequisiteChainContracts(env, ab, cfg.ChainSelectors, cfg.Opts...)
+	opts := append(slices.Clone(cfg.Opts), WithChainFeatures(cfg.Features))
+	err = deployPrerequisiteChainContracts(env, ab, cfg.ChainSelectors, opts...)
 	if err != nil {
 		env.Logger.Errorw("Failed to deploy prerequisite contracts", "err", err, "addressBook", ab)
 		return deployment.ChangesetOutput{
@@ -39,8 +44,10 @@ func DeployPrerequisites(env deployment.Environment, cfg DeployPrerequisiteConfi
 }
 
 type DeployPrerequisiteConfig struct {
-	ChainSelectors []uint64
-	Opts           []PrerequisiteOpt
+	ChainSelectors []uint64          `jsonschema:"required"`
+	Opts           []PrerequisiteOpt `json:"-"`
+	// Features deploy the USDC contracts of the chains, see ChainFeatureFlags.
+	Features ChainFeatureFlags
 	// TODO handle tokens and feeds in prerequisite config
 	Tokens map[TokenSymbol]common.Address
 	Feeds  map[TokenSymbol]common.Address
@@ -54,5 +61,5 @@ func (c DeployPrerequisiteConfig) Validate() error {
 			return fmt.Errorf("invalid chain selector: %d - %w", cs, err)
 		}
 	}
-	return nil
+	return c.Features.Validate(c.ChainSelectors)
 }
@@ -6,6 +6,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap/zapcore"
 
+	"github.com/smartcontractkit/chainlink/deployment"
 	"github.com/smartcontractkit/chainlink/deployment/environment/memory"
 	"github.com/smartcontractkit/chainlink/v2/core/logger"
 )
@@ -34,3 +35,14 @@ func TestDeployPrerequisites(t *testing.T) {
 	require.NotNil(t, state.Chains[newChain].RegistryModule)
 	require.NotNil(t, state.Chains[newChain].Router)
 }
+
+func TestLoadDeployPrerequisiteConfig(t *testing.T) {
+	require.Contains(t, ConfigSchemas(), "DeployPrerequisiteConfig")
+	cfg, err := deployment.LoadConfig[DeployPrerequisiteConfig]([]byte("ChainSelectors: [16015286601757825753]\n"))
+	require.NoError(t, err)
+	require.Equal(t, []uint64{16015286601757825753}, cfg.ChainSelectors)
+
+	_, err = deployment.LoadConfig[DeployPrerequisiteConfig]([]byte(`{"ChainSelectors": ["ethereum"]}`))
+	require.ErrorIs(t, err, deployment.ErrInvalidConfig)
+	require.ErrorContains(t, err, "/ChainSelectors/0")
+}
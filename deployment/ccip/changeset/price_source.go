@@ -0,0 +1,223 @@
+package changeset
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/smartcontractkit/chainlink-ccip/pkg/types/ccipocr3"
+	"github.com/smartcontractkit/chainlink-ccip/pluginconfig"
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"golang.org/x/exp/maps"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/fee_quoter"
+)
+
+var _ deployment.ChangeSet[RefreshTokenPricesConfig] = RefreshTokenPrices
+
+// TokenPriceConfig is where the USD prices of the tokens of a chain come from.
+type TokenPriceConfig struct {
+	// TokenInfo are the aggregators on FeedChainSelector the commit plugin of the chain reports the token prices of.
+	TokenInfo         map[ccipocr3.UnknownEncodedAddress]pluginconfig.TokenInfo
+	FeedChainSelector uint64
+	// Prices are set on the FeeQuoter of the chain when it is configured, for the tokens the commit plugin doesn't
+	// report the prices of.
+	Prices []fee_quoter.InternalTokenPriceUpdate
+}
+
+// PriceSource is the source of the token prices of the chains configured by ConfigureNewChains, which lets
+// environments without a dedicated feed chain be built.
+type PriceSource interface {
+	TokenPriceConfig(ctx context.Context, lggr logger.Logger, state CCIPOnChainState, chainSel uint64) (TokenPriceConfig, error)
+}
+
+var (
+	_ PriceSource = AggregatorPriceSource{}
+	_ PriceSource = StaticPriceSource{}
+	_ PriceSource = HTTPPriceSource{}
+	_ PriceSource = RegionalPriceSource{}
+)
+
+// AggregatorPriceSource reports the prices of the USD aggregators of the TokenConfig, on any EVM chain.
+type AggregatorPriceSource struct {
+	ChainSelector uint64
+	TokenConfig   TokenConfig
+}
+
+func (s AggregatorPriceSource) TokenPriceConfig(_ context.Context, lggr logger.Logger, state CCIPOnChainState, chainSel uint64) (TokenPriceConfig, error) {
+	chainState := state.Chains[chainSel]
+	return TokenPriceConfig{
+		TokenInfo:         s.TokenConfig.GetTokenInfo(lggr, chainState.LinkToken, chainState.Weth9),
+		FeedChainSelector: s.ChainSelector,
+	}, nil
+}
+
+// StaticPriceSource sets fixed prices on the FeeQuoters, which the commit plugin doesn't update. The prices are set
+// once by ConfigureNewChains and are stale once older than the TokenPriceStalenessThreshold of the FeeQuoters, 24h
+// as deployed, after which fee quotes of messages paying with the tokens revert. Environments which run for longer
+// must refresh them with RefreshTokenPrices.
+type StaticPriceSource struct {
+	// Prices are the USD prices of 1e18 of the smallest unit of the tokens, with 18 decimals, as stored by the FeeQuoter.
+	Prices map[TokenSymbol]*big.Int
+}
+
+func (s StaticPriceSource) TokenPriceConfig(_ context.Context, _ logger.Logger, state CCIPOnChainState, chainSel uint64) (TokenPriceConfig, error) {
+	chainState := state.Chains[chainSel]
+	symbols := maps.Keys(s.Prices)
+	slices.Sort(symbols)
+	var cfg TokenPriceConfig
+	for _, symbol := range symbols {
+		price := s.Prices[symbol]
+		if price == nil || price.Sign() <= 0 {
+			return TokenPriceConfig{}, fmt.Errorf("price of %s must be positive", symbol)
+		}
+		var token common.Address
+		switch {
+		case symbol == LinkSymbol && chainState.LinkToken != nil:
+			token = chainState.LinkToken.Address()
+		case symbol == WethSymbol && chainState.Weth9 != nil:
+			token = chainState.Weth9.Address()
+		default:
+			return TokenPriceConfig{}, fmt.Errorf("token %s not found on chain %d", symbol, chainSel)
+		}
+		cfg.Prices = append(cfg.Prices, fee_quoter.InternalTokenPriceUpdate{SourceToken: token, UsdPerToken: price})
+	}
+	return cfg, nil
+}
+
+// HTTPPriceSource is a StaticPriceSource whose prices are fetched from a price API when the chains are configured,
+// they go stale in the same way and are refreshed with RefreshTokenPrices. The API responds to GET requests with a JSON object of the prices by token symbol, see NewMockPriceServer.
+type HTTPPriceSource struct {
+	URL string
+	// Client defaults to http.DefaultClient.
+	Client *http.Client
+}
+
+func (s HTTPPriceSource) TokenPriceConfig(ctx context.Context, lggr logger.Logger, state CCIPOnChainState, chainSel uint64) (TokenPriceConfig, error) {
+	prices, err := s.fetchPrices(ctx)
+	if err != nil {
+		return TokenPriceConfig{}, fmt.Errorf("failed to fetch prices from %s: %w", s.URL, err)
+	}
+	return StaticPriceSource{Prices: prices}.TokenPriceConfig(ctx, lggr, state, chainSel)
+}
+
+func (s HTTPPriceSource) fetchPrices(ctx context.Context) (map[TokenSymbol]*big.Int, error) {
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	var prices map[TokenSymbol]*big.Int
+	if err := json.NewDecoder(resp.Body).Decode(&prices); err != nil {
+		return nil, fmt.Errorf("invalid prices: %w", err)
+	}
+	return prices, nil
+}
+
+// RegionalPriceSource are the price sources of the chains, e.g. the aggregators on the feed chain of their region.
+type RegionalPriceSource map[uint64]PriceSource
+
+func (s RegionalPriceSource) TokenPriceConfig(ctx context.Context, lggr logger.Logger, state CCIPOnChainState, chainSel uint64) (TokenPriceConfig, error) {
+	source, ok := s[chainSel]
+	if !ok {
+		return TokenPriceConfig{}, fmt.Errorf("no price source for chain %d", chainSel)
+	}
+	return source.TokenPriceConfig(ctx, lggr, state, chainSel)
+}
+
+// priceSource returns the price source of the config, the USD aggregators of the TokenConfig on the feed chain
+// by default.
+func (c NewChainsConfig) priceSource() PriceSource {
+	if c.PriceSource != nil {
+		return c.PriceSource
+	}
+	return AggregatorPriceSource{ChainSelector: c.FeedChainSel, TokenConfig: c.TokenConfig}
+}
+
+// setTokenPrices sets the prices of the price source of the chain on its FeeQuoter.
+func setTokenPrices(e deployment.Environment, chain deployment.Chain, feeQuoter *fee_quoter.FeeQuoter, prices []fee_quoter.InternalTokenPriceUpdate) error {
+	if len(prices) == 0 {
+		return nil
+	}
+	if feeQuoter == nil {
+		return fmt.Errorf("fee quoter not found for chain %d", chain.Selector)
+	}
+	tx, err := feeQuoter.UpdatePrices(chain.DeployerKey, fee_quoter.InternalPriceUpdates{
+		TokenPriceUpdates: prices,
+		GasPriceUpdates:   []fee_quoter.InternalGasPriceUpdate{},
+	})
+	if _, err := deployment.ConfirmIfNoError(chain, tx, err); err != nil {
+		return fmt.Errorf("failed to set token prices on chain %d: %w", chain.Selector, err)
+	}
+	e.Logger.Infow("Set token prices", "chain", chain.Selector, "prices", len(prices))
+	return nil
+}
+
+// RefreshTokenPricesConfig refreshes the token prices of the chains with a price source.
+type RefreshTokenPricesConfig struct {
+	ChainSelectors []uint64
+	PriceSource    PriceSource `json:"-"`
+}
+
+func (c RefreshTokenPricesConfig) Validate(e deployment.Environment, state CCIPOnChainState) error {
+	if c.PriceSource == nil {
+		return fmt.Errorf("no price source")
+	}
+	if len(c.ChainSelectors) == 0 {
+		return fmt.Errorf("no chains to refresh")
+	}
+	for _, sel := range c.ChainSelectors {
+		if _, ok := e.Chains[sel]; !ok {
+			return fmt.Errorf("chain %d not found in environment", sel)
+		}
+		if chainState, ok := state.Chains[sel]; !ok || chainState.FeeQuoter == nil {
+			return fmt.Errorf("fee quoter not deployed on chain %d", sel)
+		}
+	}
+	return nil
+}
+
+// RefreshTokenPrices sets the current prices of the price source on the FeeQuoters of the chains, so that the
+// prices set by ConfigureNewChains with a StaticPriceSource or an HTTPPriceSource don't go stale. It is meant
+// to be applied periodically, well within the TokenPriceStalenessThreshold of the FeeQuoters. The prices are set
+// by the deployer key, which is an authorized price updater of the FeeQuoters it deployed. Price sources which
+// only configure the commit plugin, like AggregatorPriceSource, have nothing to refresh.
+func RefreshTokenPrices(e deployment.Environment, cfg RefreshTokenPricesConfig) (deployment.ChangesetOutput, error) {
+	state, err := LoadOnchainState(e)
+	if err != nil {
+		return deployment.ChangesetOutput{}, fmt.Errorf("failed to load onchain state: %w", err)
+	}
+	if err := cfg.Validate(e, state); err != nil {
+		return deployment.ChangesetOutput{}, fmt.Errorf("invalid RefreshTokenPricesConfig: %w", err)
+	}
+	for _, sel := range cfg.ChainSelectors {
+		priceCfg, err := cfg.PriceSource.TokenPriceConfig(context.Background(), e.Logger, state, sel)
+		if err != nil {
+			return deployment.ChangesetOutput{}, fmt.Errorf("failed to get token prices of chain %d: %w", sel, err)
+		}
+		if len(priceCfg.Prices) == 0 {
+			e.Logger.Infow("No token prices to refresh", "chain", sel)
+			continue
+		}
+		if err := setTokenPrices(e, e.Chains[sel], state.Chains[sel].FeeQuoter, priceCfg.Prices); err != nil {
+			return deployment.ChangesetOutput{}, err
+		}
+	}
+	return deployment.ChangesetOutput{}, nil
+}
@@ -0,0 +1,96 @@
+package changeset_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	chainsel "github.com/smartcontractkit/chain-selectors"
+	"github.com/smartcontractkit/chainlink-ccip/pkg/types/ccipocr3"
+	"github.com/smartcontractkit/chainlink-ccip/pluginconfig"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset/testhelpers"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/fee_quoter"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/weth9"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/shared/generated/burn_mint_erc677"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+func TestPriceSources(t *testing.T) {
+	ctx := context.Background()
+	lggr := logger.TestLogger(t)
+	sel := chainsel.TEST_90000001.Selector
+	feedSel := chainsel.TEST_90000002.Selector
+	link, weth := common.HexToAddress("0x1"), common.HexToAddress("0x2")
+	linkToken, err := burn_mint_erc677.NewBurnMintERC677(link, nil)
+	require.NoError(t, err)
+	weth9Token, err := weth9.NewWETH9(weth, nil)
+	require.NoError(t, err)
+	state := changeset.CCIPOnChainState{Chains: map[uint64]changeset.CCIPChainState{
+		sel: {LinkToken: linkToken, Weth9: weth9Token},
+	}}
+
+	aggregator := common.HexToAddress("0x3")
+	tokenConfig := changeset.NewTokenConfig()
+	tokenConfig.UpsertTokenInfo(changeset.LinkSymbol, pluginconfig.TokenInfo{
+		AggregatorAddress: ccipocr3.UnknownEncodedAddress(aggregator.String()),
+		Decimals:          changeset.LinkDecimals,
+		DeviationPPB:      changeset.TestDeviationPPB,
+	})
+	// the aggregators of the TokenConfig on the feed chain by default
+	cfg, err := changeset.NewChainsConfig{FeedChainSel: feedSel, TokenConfig: tokenConfig}.ExportedPriceSource().TokenPriceConfig(ctx, lggr, state, sel)
+	require.NoError(t, err)
+	require.Equal(t, feedSel, cfg.FeedChainSelector)
+	require.Equal(t, tokenConfig.TokenSymbolToInfo[changeset.LinkSymbol], cfg.TokenInfo[ccipocr3.UnknownEncodedAddress(link.String())])
+	require.Empty(t, cfg.Prices)
+
+	prices := map[changeset.TokenSymbol]*big.Int{
+		changeset.LinkSymbol: deployment.E18Mult(20),
+		changeset.WethSymbol: deployment.E18Mult(4000),
+	}
+	expected := []fee_quoter.InternalTokenPriceUpdate{
+		{SourceToken: link, UsdPerToken: deployment.E18Mult(20)},
+		{SourceToken: weth, UsdPerToken: deployment.E18Mult(4000)},
+	}
+	cfg, err = changeset.StaticPriceSource{Prices: prices}.TokenPriceConfig(ctx, lggr, state, sel)
+	require.NoError(t, err)
+	require.Empty(t, cfg.TokenInfo)
+	require.Equal(t, expected, cfg.Prices)
+
+	_, err = changeset.StaticPriceSource{Prices: map[changeset.TokenSymbol]*big.Int{"USDC": big.NewInt(1)}}.TokenPriceConfig(ctx, lggr, state, sel)
+	require.ErrorContains(t, err, "not found")
+	_, err = changeset.StaticPriceSource{Prices: map[changeset.TokenSymbol]*big.Int{changeset.LinkSymbol: big.NewInt(0)}}.TokenPriceConfig(ctx, lggr, state, sel)
+	require.ErrorContains(t, err, "must be positive")
+
+	server := testhelpers.NewMockPriceServer(prices)
+	t.Cleanup(server.Close)
+	cfg, err = changeset.HTTPPriceSource{URL: server.URL}.TokenPriceConfig(ctx, lggr, state, sel)
+	require.NoError(t, err)
+	require.Equal(t, expected, cfg.Prices)
+
+	_, err = changeset.HTTPPriceSource{URL: server.URL + "/missing\x7f"}.TokenPriceConfig(ctx, lggr, state, sel)
+	require.Error(t, err)
+}
+
+func TestRefreshTokenPrices(t *testing.T) {
+	e := testhelpers.NewMemoryEnvironmentWithJobsAndContracts(t, logger.TestLogger(t), 2, 4, nil)
+	state, err := changeset.LoadOnchainState(e.Env)
+	require.NoError(t, err)
+	sel := e.FeedChainSel
+	_, err = changeset.RefreshTokenPrices(e.Env, changeset.RefreshTokenPricesConfig{ChainSelectors: []uint64{sel}})
+	require.ErrorContains(t, err, "no price source")
+
+	prices := map[changeset.TokenSymbol]*big.Int{changeset.LinkSymbol: deployment.E18Mult(21)}
+	_, err = changeset.RefreshTokenPrices(e.Env, changeset.RefreshTokenPricesConfig{
+		ChainSelectors: []uint64{sel},
+		PriceSource:    changeset.StaticPriceSource{Prices: prices},
+	})
+	require.NoError(t, err)
+	price, err := state.Chains[sel].FeeQuoter.GetTokenPrice(nil, state.Chains[sel].LinkToken.Address())
+	require.NoError(t, err)
+	require.Equal(t, deployment.E18Mult(21), price.Value)
+}
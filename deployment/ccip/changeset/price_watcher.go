@@ -0,0 +1,288 @@
+package changeset
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/fee_quoter"
+)
+
+// PriceKind is the kind of price stored in a FeeQuoter.
+type PriceKind string
+
+const (
+	// PriceKindToken is the USD price of a token, see FeeQuoter.getTokenPrice.
+	PriceKindToken PriceKind = "token"
+	// PriceKindGas is the packed USD gas price of a destination chain, the execution gas price
+	// in the lower 112 bits and the data availability gas price in the upper 112 bits.
+	PriceKindGas PriceKind = "gas"
+)
+
+// PriceKey identifies a price in the FeeQuoter of a chain. Token is set for token prices,
+// DestChainSelector for gas prices.
+type PriceKey struct {
+	ChainSelector     uint64
+	Kind              PriceKind
+	Token             common.Address
+	DestChainSelector uint64
+}
+
+func (k PriceKey) String() string {
+	if k.Kind == PriceKindGas {
+		return fmt.Sprintf("gas price of chain %d on chain %d", k.DestChainSelector, k.ChainSelector)
+	}
+	return fmt.Sprintf("price of token %s on chain %d", k.Token, k.ChainSelector)
+}
+
+// PriceUpdate is a price update emitted by a FeeQuoter.
+type PriceUpdate struct {
+	PriceKey
+	Value       *big.Int
+	Timestamp   time.Time
+	BlockNumber uint64
+	TxHash      common.Hash
+}
+
+// PriceWatcher tracks the token and gas price updates of the FeeQuoters of all chains.
+// Updates are collected by polling the FeeQuoter logs, so it does not depend on a test and
+// can back a monitoring exporter as well.
+type PriceWatcher struct {
+	chains     map[uint64]deployment.Chain
+	feeQuoters map[uint64]*fee_quoter.FeeQuoter
+	// tokens are the tokens whose prices are expected to be kept fresh on each chain.
+	tokens map[uint64][]common.Address
+
+	mu        sync.Mutex
+	nextBlock map[uint64]uint64
+	updates   map[PriceKey][]PriceUpdate
+}
+
+// NewPriceWatcher creates a watcher of the FeeQuoters of all chains of the environment, starting at startBlocks,
+// or the genesis block of chains missing from it. The fee tokens of every chain are expected to be kept fresh.
+func NewPriceWatcher(e deployment.Environment, state CCIPOnChainState, startBlocks map[uint64]uint64) (*PriceWatcher, error) {
+	w := &PriceWatcher{
+		chains:     make(map[uint64]deployment.Chain),
+		feeQuoters: make(map[uint64]*fee_quoter.FeeQuoter),
+		tokens:     make(map[uint64][]common.Address),
+		nextBlock:  make(map[uint64]uint64),
+		updates:    make(map[PriceKey][]PriceUpdate),
+	}
+	for sel, chain := range e.Chains {
+		chainState := state.Chains[sel]
+		if chainState.FeeQuoter == nil {
+			return nil, fmt.Errorf("fee quoter not deployed on chain %d", sel)
+		}
+		w.chains[sel] = chain
+		w.feeQuoters[sel] = chainState.FeeQuoter
+		if chainState.LinkToken != nil {
+			w.tokens[sel] = append(w.tokens[sel], chainState.LinkToken.Address())
+		}
+		if chainState.Weth9 != nil {
+			w.tokens[sel] = append(w.tokens[sel], chainState.Weth9.Address())
+		}
+		w.nextBlock[sel] = startBlocks[sel]
+	}
+	return w, nil
+}
+
+// Poll collects the price updates emitted since the last poll.
+func (w *PriceWatcher) Poll(ctx context.Context) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for sel, chain := range w.chains {
+		if err := w.pollChain(ctx, sel, chain); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pollChain collects the price updates emitted on the chain since its last poll.
+func (w *PriceWatcher) pollChain(ctx context.Context, sel uint64, chain deployment.Chain) error {
+	hdr, err := chain.Client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get latest header of chain %d: %w", sel, err)
+	}
+	latest := hdr.Number.Uint64()
+	if latest < w.nextBlock[sel] {
+		return nil
+	}
+	opts := &bind.FilterOpts{Context: ctx, Start: w.nextBlock[sel], End: &latest}
+	tokenIt, err := w.feeQuoters[sel].FilterUsdPerTokenUpdated(opts, nil)
+	if err != nil {
+		return fmt.Errorf("failed to filter token price updates on chain %d: %w", sel, err)
+	}
+	defer tokenIt.Close()
+	for tokenIt.Next() {
+		w.add(PriceUpdate{
+			PriceKey:    PriceKey{ChainSelector: sel, Kind: PriceKindToken, Token: tokenIt.Event.Token},
+			Value:       tokenIt.Event.Value,
+			Timestamp:   time.Unix(tokenIt.Event.Timestamp.Int64(), 0),
+			BlockNumber: tokenIt.Event.Raw.BlockNumber,
+			TxHash:      tokenIt.Event.Raw.TxHash,
+		})
+	}
+	if err := tokenIt.Error(); err != nil {
+		return err
+	}
+	gasIt, err := w.feeQuoters[sel].FilterUsdPerUnitGasUpdated(opts, nil)
+	if err != nil {
+		return fmt.Errorf("failed to filter gas price updates on chain %d: %w", sel, err)
+	}
+	defer gasIt.Close()
+	for gasIt.Next() {
+		w.add(PriceUpdate{
+			PriceKey:    PriceKey{ChainSelector: sel, Kind: PriceKindGas, DestChainSelector: gasIt.Event.DestChain},
+			Value:       gasIt.Event.Value,
+			Timestamp:   time.Unix(gasIt.Event.Timestamp.Int64(), 0),
+			BlockNumber: gasIt.Event.Raw.BlockNumber,
+			TxHash:      gasIt.Event.Raw.TxHash,
+		})
+	}
+	if err := gasIt.Error(); err != nil {
+		return err
+	}
+	w.nextBlock[sel] = latest + 1
+	return nil
+}
+
+func (w *PriceWatcher) add(update PriceUpdate) {
+	w.updates[update.PriceKey] = append(w.updates[update.PriceKey], update)
+}
+
+// Updates returns the updates of the price collected so far, oldest first.
+func (w *PriceWatcher) Updates(key PriceKey) []PriceUpdate {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	updates := append([]PriceUpdate{}, w.updates[key]...)
+	sort.SliceStable(updates, func(i, j int) bool { return updates[i].BlockNumber < updates[j].BlockNumber })
+	return updates
+}
+
+// Keys returns the prices updated so far.
+func (w *PriceWatcher) Keys() []PriceKey {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	keys := make([]PriceKey, 0, len(w.updates))
+	for key := range w.updates {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+	return keys
+}
+
+// CheckFreshness returns an error listing the prices which are older than maxAge at now onchain.
+// These are the fee token prices and the gas prices of all other chains on every chain.
+// A zero maxAge skips the prices of that kind.
+func (w *PriceWatcher) CheckFreshness(ctx context.Context, now time.Time, maxAge map[PriceKind]time.Duration) error {
+	var stale []string
+	check := func(key PriceKey, price fee_quoter.InternalTimestampedPackedUint224) {
+		age := now.Sub(time.Unix(int64(price.Timestamp), 0))
+		if price.Timestamp == 0 {
+			stale = append(stale, fmt.Sprintf("%s was never set", key))
+		} else if age > maxAge[key.Kind] {
+			stale = append(stale, fmt.Sprintf("%s is %s old, max %s", key, age.Truncate(time.Second), maxAge[key.Kind]))
+		}
+	}
+	opts := &bind.CallOpts{Context: ctx}
+	for sel, feeQuoter := range w.feeQuoters {
+		if maxAge[PriceKindToken] > 0 {
+			for _, token := range w.tokens[sel] {
+				price, err := feeQuoter.GetTokenPrice(opts, token)
+				if err != nil {
+					return fmt.Errorf("failed to get price of token %s on chain %d: %w", token, sel, err)
+				}
+				check(PriceKey{ChainSelector: sel, Kind: PriceKindToken, Token: token}, price)
+			}
+		}
+		if maxAge[PriceKindGas] > 0 {
+			for dest := range w.chains {
+				if dest == sel {
+					continue
+				}
+				price, err := feeQuoter.GetDestinationChainGasPrice(opts, dest)
+				if err != nil {
+					return fmt.Errorf("failed to get gas price of chain %d on chain %d: %w", dest, sel, err)
+				}
+				check(PriceKey{ChainSelector: sel, Kind: PriceKindGas, DestChainSelector: dest}, price)
+			}
+		}
+	}
+	if len(stale) > 0 {
+		sort.Strings(stale)
+		return fmt.Errorf("stale prices:\n\t%s", strings.Join(stale, "\n\t"))
+	}
+	return nil
+}
+
+// PriceUpdatePolicy is the policy the commit plugin follows to update a kind of price:
+// a price is only updated before its heartbeat passed if it deviated by at least the deviation.
+type PriceUpdatePolicy struct {
+	Heartbeat time.Duration
+	// DeviationPPB is the deviation in parts per billion. For gas prices it applies to the execution gas price.
+	DeviationPPB *big.Int
+	// DADeviationPPB is the deviation of the data availability gas price, only used for gas prices.
+	DADeviationPPB *big.Int
+}
+
+// CheckUpdatePolicy returns an error listing the updates made before the heartbeat of the previous update
+// of the same price passed, without deviating enough from it. Kinds without a policy are not checked.
+func (w *PriceWatcher) CheckUpdatePolicy(policies map[PriceKind]PriceUpdatePolicy) error {
+	var violations []string
+	for _, key := range w.Keys() {
+		policy, ok := policies[key.Kind]
+		if !ok {
+			continue
+		}
+		violations = append(violations, checkPriceUpdatePolicy(key, w.Updates(key), policy)...)
+	}
+	if len(violations) > 0 {
+		return fmt.Errorf("price updates violating the update policy:\n\t%s", strings.Join(violations, "\n\t"))
+	}
+	return nil
+}
+
+func checkPriceUpdatePolicy(key PriceKey, updates []PriceUpdate, policy PriceUpdatePolicy) []string {
+	var violations []string
+	for i := 1; i < len(updates); i++ {
+		prev, next := updates[i-1], updates[i]
+		elapsed := next.Timestamp.Sub(prev.Timestamp)
+		if elapsed >= policy.Heartbeat {
+			continue
+		}
+		deviated := deviates(prev.Value, next.Value, policy.DeviationPPB)
+		if key.Kind == PriceKindGas {
+			execPrev, daPrev := UnpackFee(prev.Value)
+			execNext, daNext := UnpackFee(next.Value)
+			deviated = deviates(execPrev, execNext, policy.DeviationPPB) || deviates(daPrev, daNext, policy.DADeviationPPB)
+		}
+		if !deviated {
+			violations = append(violations, fmt.Sprintf("%s updated from %s to %s after %s in block %d, within the %s heartbeat",
+				key, prev.Value, next.Value, elapsed, next.BlockNumber, policy.Heartbeat))
+		}
+	}
+	return violations
+}
+
+// deviates returns whether next deviates from prev by at least deviationPPB parts per billion.
+// Any change deviates if deviationPPB is nil or zero.
+func deviates(prev, next *big.Int, deviationPPB *big.Int) bool {
+	diff := new(big.Int).Abs(new(big.Int).Sub(next, prev))
+	if diff.Sign() == 0 {
+		return false
+	}
+	if deviationPPB == nil || deviationPPB.Sign() == 0 || prev.Sign() == 0 {
+		return true
+	}
+	// diff / prev >= deviationPPB / 1e9
+	return new(big.Int).Mul(diff, big.NewInt(1e9)).Cmp(new(big.Int).Mul(prev, deviationPPB)) >= 0
+}
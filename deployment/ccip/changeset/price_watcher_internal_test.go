@@ -0,0 +1,46 @@
+package changeset
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckPriceUpdatePolicy(t *testing.T) {
+	start := time.Unix(1_700_000_000, 0)
+	tokenKey := PriceKey{ChainSelector: 1, Kind: PriceKindToken, Token: common.HexToAddress("0x1")}
+	tokenPolicy := PriceUpdatePolicy{Heartbeat: time.Minute, DeviationPPB: big.NewInt(1e7)} // 1%
+	update := func(key PriceKey, value *big.Int, after time.Duration, block uint64) PriceUpdate {
+		return PriceUpdate{PriceKey: key, Value: value, Timestamp: start.Add(after), BlockNumber: block}
+	}
+
+	require.Empty(t, checkPriceUpdatePolicy(tokenKey, []PriceUpdate{
+		update(tokenKey, big.NewInt(1000), 0, 1),
+		update(tokenKey, big.NewInt(1010), 10*time.Second, 2), // deviated by 1%
+		update(tokenKey, big.NewInt(1010), 90*time.Second, 3), // heartbeat
+	}, tokenPolicy))
+	require.Equal(t, []string{
+		"price of token 0x0000000000000000000000000000000000000001 on chain 1 updated from 1000 to 1005 after 10s in block 2, within the 1m0s heartbeat",
+	}, checkPriceUpdatePolicy(tokenKey, []PriceUpdate{
+		update(tokenKey, big.NewInt(1000), 0, 1),
+		update(tokenKey, big.NewInt(1005), 10*time.Second, 2),
+	}, tokenPolicy))
+
+	gasKey := PriceKey{ChainSelector: 1, Kind: PriceKindGas, DestChainSelector: 2}
+	gasPolicy := PriceUpdatePolicy{Heartbeat: time.Minute, DeviationPPB: big.NewInt(1e7), DADeviationPPB: big.NewInt(1e8)}
+	packed := func(exec, da int64) *big.Int {
+		return new(big.Int).Or(new(big.Int).Lsh(big.NewInt(da), 112), big.NewInt(exec))
+	}
+	require.Empty(t, checkPriceUpdatePolicy(gasKey, []PriceUpdate{
+		update(gasKey, packed(1000, 1000), 0, 1),
+		update(gasKey, packed(1000, 1100), time.Second, 2),   // da deviated by 10%
+		update(gasKey, packed(1010, 1100), 2*time.Second, 3), // exec deviated by 1%
+	}, gasPolicy))
+	require.Len(t, checkPriceUpdatePolicy(gasKey, []PriceUpdate{
+		update(gasKey, packed(1000, 1000), 0, 1),
+		update(gasKey, packed(1005, 1050), time.Second, 2),
+	}, gasPolicy), 1)
+}
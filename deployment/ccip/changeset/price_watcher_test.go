@@ -0,0 +1,63 @@
+package changeset_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-testing-framework/lib/utils/testcontext"
+
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset/testhelpers"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/router"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+func TestPriceWatcher(t *testing.T) {
+	lggr := logger.TestLogger(t)
+	e := testhelpers.NewMemoryEnvironmentWithJobsAndContracts(t, lggr, 2, 4, nil)
+	state, err := changeset.LoadOnchainState(e.Env)
+	require.NoError(t, err)
+	ctx := testcontext.Get(t)
+	// the prices seeded by the deployer while adding the lanes do not follow the update policies
+	require.NoError(t, testhelpers.AddLanesForAll(e.Env, state))
+	startBlocks := make(map[uint64]uint64)
+	commitStartBlocks := make(map[uint64]*uint64)
+	for sel, chain := range e.Env.Chains {
+		latesthdr, err := chain.Client.HeaderByNumber(ctx, nil)
+		require.NoError(t, err)
+		block := latesthdr.Number.Uint64()
+		startBlocks[sel] = block
+		commitStartBlocks[sel] = &block
+	}
+	w, err := changeset.NewPriceWatcher(e.Env, state, startBlocks)
+	require.NoError(t, err)
+
+	// the commit plugins update the prices along with the roots of the messages
+	expectedSeqNum := make(map[changeset.SourceDestPair]uint64)
+	for src := range e.Env.Chains {
+		for dest := range e.Env.Chains {
+			if src == dest {
+				continue
+			}
+			msgSentEvent := testhelpers.TestSendRequest(t, e.Env, state, src, dest, false, router.ClientEVM2AnyMessage{
+				Receiver:  common.LeftPadBytes(state.Chains[dest].Receiver.Address().Bytes(), 32),
+				Data:      []byte("hello"),
+				FeeToken:  common.HexToAddress("0x0"),
+				ExtraArgs: nil,
+			})
+			expectedSeqNum[changeset.SourceDestPair{SourceChainSelector: src, DestChainSelector: dest}] = msgSentEvent.SequenceNumber
+		}
+	}
+	testhelpers.ConfirmCommitForAllWithExpectedSeqNums(t, e.Env, state, expectedSeqNum, commitStartBlocks)
+
+	// let the token price heartbeat pass a few times
+	time.Sleep(5 * testhelpers.DefaultTestPriceUpdatePolicies()[changeset.PriceKindToken].Heartbeat)
+	testhelpers.AssertPricesFresh(t, w, map[changeset.PriceKind]time.Duration{
+		changeset.PriceKindToken: time.Minute,
+		changeset.PriceKindGas:   testhelpers.DefaultTestPriceUpdatePolicies()[changeset.PriceKindGas].Heartbeat,
+	}, testhelpers.DefaultTestPriceUpdatePolicies())
+	require.NotEmpty(t, w.Keys())
+}
@@ -7,9 +7,9 @@ import (
 
 	mapset "github.com/deckarep/golang-set/v2"
 	"github.com/ethereum/go-ethereum/common"
+	owner_helpers "github.com/smartcontractkit/ccip-owner-contracts/pkg/gethwrappers"
 	"github.com/smartcontractkit/ccip-owner-contracts/pkg/proposal/mcms"
 	"github.com/smartcontractkit/ccip-owner-contracts/pkg/proposal/timelock"
-	chainsel "github.com/smartcontractkit/chain-selectors"
 
 	"github.com/smartcontractkit/chainlink/deployment"
 )
@@ -22,7 +22,6 @@ func GenerateAcceptOwnershipProposal(
 	// TODO: Accept rest of contracts
 	var batches []timelock.BatchChainOperation
 	for _, sel := range chains {
-		chain, _ := chainsel.ChainBySelector(sel)
 		acceptOnRamp, err := state.Chains[sel].OnRamp.AcceptOwnership(deployment.SimTransactOpts())
 		if err != nil {
 			return nil, err
@@ -31,7 +30,7 @@ func GenerateAcceptOwnershipProposal(
 		if err != nil {
 			return nil, err
 		}
-		chainSel := mcms.ChainIdentifier(chain.Selector)
+		chainSel := mcms.ChainIdentifier(sel)
 		batches = append(batches, timelock.BatchChainOperation{
 			ChainIdentifier: chainSel,
 			Batch: []mcms.Operation{
@@ -78,12 +77,16 @@ func GenerateAcceptOwnershipProposal(
 }
 
 func BuildProposalMetadata(state CCIPOnChainState, chains []uint64) (map[mcms.ChainIdentifier]common.Address, map[mcms.ChainIdentifier]mcms.ChainMetadata, error) {
+	return buildProposalMetadata(state, chains, func(s CCIPChainState) *owner_helpers.ManyChainMultiSig { return s.ProposerMcm })
+}
+
+func buildProposalMetadata(state CCIPOnChainState, chains []uint64, mcmOf func(CCIPChainState) *owner_helpers.ManyChainMultiSig) (map[mcms.ChainIdentifier]common.Address, map[mcms.ChainIdentifier]mcms.ChainMetadata, error) {
 	tlAddressMap := make(map[mcms.ChainIdentifier]common.Address)
 	metaDataPerChain := make(map[mcms.ChainIdentifier]mcms.ChainMetadata)
 	for _, sel := range chains {
 		chainId := mcms.ChainIdentifier(sel)
 		tlAddressMap[chainId] = state.Chains[sel].Timelock.Address()
-		mcm := state.Chains[sel].ProposerMcm
+		mcm := mcmOf(state.Chains[sel])
 		opCount, err := mcm.GetOpCount(nil)
 		if err != nil {
 			return nil, nil, err
@@ -126,3 +129,31 @@ func BuildProposalFromBatches(state CCIPOnChainState, batches []timelock.BatchCh
 		minDelay.String(),
 	)
 }
+
+// BuildBypassProposalFromBatches is BuildProposalFromBatches for the bypasser MCMS, the operations of the proposal
+// are executed by the timelock as soon as the bypasser signers approve it, without a delay. It is meant for emergencies.
+func BuildBypassProposalFromBatches(state CCIPOnChainState, batches []timelock.BatchChainOperation, description string) (*timelock.MCMSWithTimelockProposal, error) {
+	if len(batches) == 0 {
+		return nil, fmt.Errorf("no operations in batch")
+	}
+	chains := mapset.NewSet[uint64]()
+	for _, op := range batches {
+		chains.Add(uint64(op.ChainIdentifier))
+	}
+	tls, mcmsMd, err := buildProposalMetadata(state, chains.ToSlice(), func(s CCIPChainState) *owner_helpers.ManyChainMultiSig { return s.BypasserMcm })
+	if err != nil {
+		return nil, err
+	}
+	return timelock.NewMCMSWithTimelockProposal(
+		"1",
+		2004259681,
+		[]mcms.Signature{},
+		false,
+		mcmsMd,
+		tls,
+		description,
+		batches,
+		timelock.Bypass,
+		"0s",
+	)
+}
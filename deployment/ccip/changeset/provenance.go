@@ -0,0 +1,162 @@
+package changeset
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/ccip_home"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/fee_quoter"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/nonce_manager"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/offramp"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/onramp"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/pinned_artifacts"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/registry_module_owner_custom"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/rmn_home"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/rmn_proxy_contract"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/rmn_remote"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/router"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/token_admin_registry"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/weth9"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/keystone/generated/capabilities_registry"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/shared/generated/burn_mint_erc677"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/shared/generated/multicall3"
+)
+
+// ExpectedBytecode are the compiled artifacts the contracts deployed by the changesets of this package are
+// expected to match, keyed by type and version. Contracts of other types and versions aren't verified.
+var ExpectedBytecode = map[string]string{
+	deployment.NewTypeAndVersion(OnRamp, deployment.Version1_6_0_dev).String():           onramp.OnRampMetaData.Bin,
+	deployment.NewTypeAndVersion(OffRamp, deployment.Version1_6_0_dev).String():          offramp.OffRampMetaData.Bin,
+	deployment.NewTypeAndVersion(FeeQuoter, deployment.Version1_6_0_dev).String():        fee_quoter.FeeQuoterMetaData.Bin,
+	deployment.NewTypeAndVersion(NonceManager, deployment.Version1_6_0_dev).String():     nonce_manager.NonceManagerMetaData.Bin,
+	deployment.NewTypeAndVersion(RMNRemote, deployment.Version1_6_0_dev).String():        rmn_remote.RMNRemoteMetaData.Bin,
+	deployment.NewTypeAndVersion(RMNHome, deployment.Version1_6_0_dev).String():          rmn_home.RMNHomeMetaData.Bin,
+	deployment.NewTypeAndVersion(CCIPHome, deployment.Version1_6_0_dev).String():         ccip_home.CCIPHomeMetaData.Bin,
+	deployment.NewTypeAndVersion(ARMProxy, deployment.Version1_0_0).String():             rmn_proxy_contract.RMNProxyContractMetaData.Bin,
+	deployment.NewTypeAndVersion(ARMProxy, deployment.Version1_6_0_dev).String():         rmn_proxy_contract.RMNProxyContractMetaData.Bin,
+	deployment.NewTypeAndVersion(Router, deployment.Version1_2_0).String():               router.RouterMetaData.Bin,
+	deployment.NewTypeAndVersion(TestRouter, deployment.Version1_2_0).String():           router.RouterMetaData.Bin,
+	deployment.NewTypeAndVersion(TokenAdminRegistry, deployment.Version1_5_0).String():   token_admin_registry.TokenAdminRegistryMetaData.Bin,
+	deployment.NewTypeAndVersion(RegistryModule, deployment.Version1_5_0).String():       registry_module_owner_custom.RegistryModuleOwnerCustomMetaData.Bin,
+	deployment.NewTypeAndVersion(WETH9, deployment.Version1_0_0).String():                weth9.WETH9MetaData.Bin,
+	deployment.NewTypeAndVersion(LinkToken, deployment.Version1_0_0).String():            burn_mint_erc677.BurnMintERC677MetaData.Bin,
+	deployment.NewTypeAndVersion(Multicall3, deployment.Version1_0_0).String():           multicall3.Multicall3MetaData.Bin,
+	deployment.NewTypeAndVersion(CapabilitiesRegistry, deployment.Version1_0_0).String(): capabilities_registry.CapabilitiesRegistryMetaData.Bin,
+}
+
+// VerifyPinnedArtifacts checks the expected artifacts of the contracts whose type and version is pinned against the
+// published digests of the pins, see core/gethwrappers/ccip/generation/pinned-artifact-versions.txt. The digests don't
+// come from the wrappers, the wrappers are generated from solc artifacts checked against them, so this catches wrappers
+// generated from other artifacts or not regenerated after bumping a pin, and the changesets don't deploy a different
+// version than released.
+func VerifyPinnedArtifacts(expected map[string]string) error {
+	for tv, bin := range expected {
+		pinned, ok := pinned_artifacts.PinnedArtifacts[tv]
+		if !ok {
+			continue
+		}
+		code, err := hexutil.Decode(bin)
+		if err != nil {
+			return fmt.Errorf("invalid artifact of %s: %w", tv, err)
+		}
+		if hash := fmt.Sprintf("%x", sha256.Sum256(code)); hash != pinned.BytecodeHash {
+			return fmt.Errorf("%w: artifact of %s has hash %s but its published digest is %s, regenerate the %s wrapper",
+				deployment.ErrBytecodeMismatch, tv, hash, pinned.BytecodeHash, pinned.Package)
+		}
+	}
+	return nil
+}
+
+type LoadOnchainStateOpts struct {
+	// ExpectedBytecode are the artifacts the contracts are verified against, no contract is verified if nil.
+	ExpectedBytecode map[string]string
+}
+
+type LoadOnchainStateOpt func(o *LoadOnchainStateOpts)
+
+// WithBytecodeVerification verifies the contracts of the EVM chains against the artifacts of their type and
+// version, ExpectedBytecode if nil. See deployment.VerifyBytecode.
+func WithBytecodeVerification(expected map[string]string) LoadOnchainStateOpt {
+	return func(o *LoadOnchainStateOpts) {
+		if expected == nil {
+			expected = ExpectedBytecode
+		}
+		o.ExpectedBytecode = expected
+	}
+}
+
+// BytecodeMismatch is a contract whose code doesn't match the artifact of its type and version.
+type BytecodeMismatch struct {
+	ChainSelector  uint64
+	Address        common.Address
+	TypeAndVersion deployment.TypeAndVersion
+	Err            error
+}
+
+// BytecodeMismatchError is returned by LoadOnchainState with the state when contracts don't match their artifacts,
+// e.g. because they were upgraded by hand.
+type BytecodeMismatchError struct {
+	Mismatches []BytecodeMismatch
+}
+
+func (e *BytecodeMismatchError) Error() string {
+	var contracts []string
+	for _, m := range e.Mismatches {
+		contracts = append(contracts, fmt.Sprintf("%s at %s on chain %d", m.TypeAndVersion, m.Address, m.ChainSelector))
+	}
+	return fmt.Sprintf("%d contracts don't match their artifacts: %s", len(e.Mismatches), strings.Join(contracts, ", "))
+}
+
+// verifyBytecode verifies the contracts of the EVM chains of the environment which have an expected artifact.
+func verifyBytecode(e deployment.Environment, expected map[string]string) error {
+	var mismatches []BytecodeMismatch
+	for sel, chain := range e.Chains {
+		addresses, err := e.ExistingAddresses.AddressesForChain(sel)
+		if errors.Is(err, deployment.ErrChainNotFound) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		for address, tv := range addresses {
+			bin, ok := expected[tv.String()]
+			if !ok {
+				continue
+			}
+			err := deployment.VerifyBytecode(context.Background(), chain, common.HexToAddress(address), bin)
+			if errors.Is(err, deployment.ErrBytecodeMismatch) {
+				mismatches = append(mismatches, BytecodeMismatch{
+					ChainSelector:  sel,
+					Address:        common.HexToAddress(address),
+					TypeAndVersion: tv,
+					Err:            err,
+				})
+				continue
+			}
+			if err != nil {
+				return fmt.Errorf("failed to verify %s at %s on chain %d: %w", tv, address, sel, err)
+			}
+		}
+	}
+	if len(mismatches) == 0 {
+		return nil
+	}
+	sort.Slice(mismatches, func(i, j int) bool {
+		if mismatches[i].ChainSelector != mismatches[j].ChainSelector {
+			return mismatches[i].ChainSelector < mismatches[j].ChainSelector
+		}
+		return mismatches[i].Address.Cmp(mismatches[j].Address) < 0
+	})
+	for _, m := range mismatches {
+		e.Logger.Errorw("Contract doesn't match its artifact", "chain", m.ChainSelector, "address", m.Address, "contract", m.TypeAndVersion)
+	}
+	return &BytecodeMismatchError{Mismatches: mismatches}
+}
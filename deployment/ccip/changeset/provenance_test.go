@@ -0,0 +1,65 @@
+package changeset
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/deployment/environment/memory"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/shared/generated/multicall3"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+func TestLoadOnchainStateWithBytecodeVerification(t *testing.T) {
+	t.Parallel()
+	lggr := logger.TestLogger(t)
+	e := memory.NewMemoryEnvironment(t, lggr, zapcore.InfoLevel, memory.MemoryEnvironmentConfig{
+		Chains: 1,
+	})
+	sel := e.AllChainSelectors()[0]
+	output, err := DeployPrerequisites(e, DeployPrerequisiteConfig{ChainSelectors: []uint64{sel}})
+	require.NoError(t, err)
+	require.NoError(t, e.ExistingAddresses.Merge(output.AddressBook))
+
+	state, err := LoadOnchainState(e, WithBytecodeVerification(nil))
+	require.NoError(t, err)
+	require.NotNil(t, state.Chains[sel].Router)
+
+	// a contract recorded as another one, like a router replaced by hand
+	chain := e.Chains[sel]
+	addr, tx, _, err := multicall3.DeployMulticall3(chain.DeployerKey, chain.Client)
+	require.NoError(t, err)
+	_, err = chain.Confirm(tx)
+	require.NoError(t, err)
+	tv := deployment.NewTypeAndVersion(TestRouter, deployment.Version1_2_0)
+	require.NoError(t, e.ExistingAddresses.Save(sel, addr.Hex(), tv))
+
+	state, err = LoadOnchainState(e, WithBytecodeVerification(nil))
+	var mismatchErr *BytecodeMismatchError
+	require.ErrorAs(t, err, &mismatchErr)
+	require.Len(t, mismatchErr.Mismatches, 1)
+	require.Equal(t, addr, mismatchErr.Mismatches[0].Address)
+	require.Equal(t, tv, mismatchErr.Mismatches[0].TypeAndVersion)
+	require.NotNil(t, state.Chains[sel].TestRouter)
+
+	// only the given artifacts are verified
+	_, err = LoadOnchainState(e, WithBytecodeVerification(map[string]string{}))
+	require.NoError(t, err)
+	_, err = LoadOnchainState(e)
+	require.NoError(t, err)
+}
+
+func TestVerifyPinnedArtifacts(t *testing.T) {
+	require.NoError(t, VerifyPinnedArtifacts(ExpectedBytecode))
+
+	// a wrapper which wasn't regenerated after bumping its pin
+	tv := deployment.NewTypeAndVersion(Router, deployment.Version1_2_0).String()
+	expected := map[string]string{tv: multicall3.Multicall3MetaData.Bin}
+	require.ErrorIs(t, VerifyPinnedArtifacts(expected), deployment.ErrBytecodeMismatch)
+
+	// contracts which aren't pinned aren't verified
+	expected = map[string]string{deployment.NewTypeAndVersion(Multicall3, deployment.Version1_0_0).String(): "0x00"}
+	require.NoError(t, VerifyPinnedArtifacts(expected))
+}
@@ -0,0 +1,201 @@
+package changeset
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/proposal/mcms"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/proposal/timelock"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/router"
+	"github.com/smartcontractkit/chainlink/v2/core/services/ocr2/plugins/ccip/abihelpers"
+)
+
+var _ deployment.ChangeSet[RouterRampUpdatesConfig] = RouterRampUpdatesChangeset
+
+var routerABI = abihelpers.MustParseABI(router.RouterABI)
+
+// DefaultMaxRampUpdateCalldataBytes bounds the calldata of a single applyRampUpdates call,
+// which fits about 250 ramp updates.
+const DefaultMaxRampUpdateCalldataBytes = 16_000
+
+// RouterRampUpdatesConfig batches the ramp updates of many lanes on a single router.
+// The updates are applied in order: onramp updates, then offramp removals, then offramp additions.
+type RouterRampUpdatesConfig struct {
+	ChainSelector uint64
+	// TestRouter updates the test router instead of the router.
+	TestRouter     bool
+	OnRampUpdates  []router.RouterOnRamp
+	OffRampRemoves []router.RouterOffRamp
+	OffRampAdds    []router.RouterOffRamp
+	// MaxCalldataBytes bounds the calldata of each applyRampUpdates call in the proposal, updates
+	// exceeding it are split across multiple calls. Defaults to DefaultMaxRampUpdateCalldataBytes.
+	MaxCalldataBytes int
+	MinDelay         time.Duration
+}
+
+func (c RouterRampUpdatesConfig) Validate(state CCIPOnChainState) error {
+	if err := deployment.IsValidChainSelector(c.ChainSelector); err != nil {
+		return fmt.Errorf("invalid chain selector: %d - %w", c.ChainSelector, err)
+	}
+	chainState, ok := state.Chains[c.ChainSelector]
+	if !ok {
+		return fmt.Errorf("chain %d not found in onchain state", c.ChainSelector)
+	}
+	if (c.TestRouter && chainState.TestRouter == nil) || (!c.TestRouter && chainState.Router == nil) {
+		return fmt.Errorf("router not deployed on chain %d", c.ChainSelector)
+	}
+	if chainState.Timelock == nil || chainState.ProposerMcm == nil {
+		return fmt.Errorf("mcms not deployed on chain %d", c.ChainSelector)
+	}
+	if len(c.OnRampUpdates)+len(c.OffRampRemoves)+len(c.OffRampAdds) == 0 {
+		return fmt.Errorf("no ramp updates")
+	}
+	if c.MaxCalldataBytes < 0 {
+		return fmt.Errorf("max calldata bytes must not be negative")
+	}
+	dests := make(map[uint64]struct{})
+	for _, update := range c.OnRampUpdates {
+		if update.DestChainSelector == c.ChainSelector {
+			return fmt.Errorf("cannot route chain %d to itself", c.ChainSelector)
+		}
+		if _, ok := dests[update.DestChainSelector]; ok {
+			return fmt.Errorf("duplicate onramp update for dest %d", update.DestChainSelector)
+		}
+		dests[update.DestChainSelector] = struct{}{}
+	}
+	for _, offRamps := range [][]router.RouterOffRamp{c.OffRampRemoves, c.OffRampAdds} {
+		seen := make(map[router.RouterOffRamp]struct{})
+		for _, offRamp := range offRamps {
+			if offRamp.SourceChainSelector == c.ChainSelector {
+				return fmt.Errorf("cannot route chain %d to itself", c.ChainSelector)
+			}
+			if _, ok := seen[offRamp]; ok {
+				return fmt.Errorf("duplicate offramp update %s for source %d", offRamp.OffRamp, offRamp.SourceChainSelector)
+			}
+			seen[offRamp] = struct{}{}
+		}
+	}
+	return nil
+}
+
+// RouterRampUpdatesChangeset generates a single proposal applying the ramp updates to a router.
+// Updates whose calldata exceeds the configured limit are chunked into multiple applyRampUpdates calls,
+// each in its own batch so they are executed in separate transactions.
+func RouterRampUpdatesChangeset(e deployment.Environment, cfg RouterRampUpdatesConfig) (deployment.ChangesetOutput, error) {
+	state, err := LoadOnchainState(e)
+	if err != nil {
+		e.Logger.Errorw("Failed to load existing onchain state", "err", err)
+		return deployment.ChangesetOutput{}, err
+	}
+	if err := cfg.Validate(state); err != nil {
+		return deployment.ChangesetOutput{}, fmt.Errorf("invalid RouterRampUpdatesConfig: %w", err)
+	}
+	maxCalldataBytes := cfg.MaxCalldataBytes
+	if maxCalldataBytes == 0 {
+		maxCalldataBytes = DefaultMaxRampUpdateCalldataBytes
+	}
+	r := state.Chains[cfg.ChainSelector].Router
+	if cfg.TestRouter {
+		r = state.Chains[cfg.ChainSelector].TestRouter
+	}
+	chunks, err := chunkRampUpdates(rampUpdates{
+		onRamps:        cfg.OnRampUpdates,
+		offRampRemoves: cfg.OffRampRemoves,
+		offRampAdds:    cfg.OffRampAdds,
+	}, maxCalldataBytes)
+	if err != nil {
+		return deployment.ChangesetOutput{}, err
+	}
+	var batches []timelock.BatchChainOperation
+	for _, chunk := range chunks {
+		batches = append(batches, timelock.BatchChainOperation{
+			ChainIdentifier: mcms.ChainIdentifier(cfg.ChainSelector),
+			Batch: []mcms.Operation{
+				{
+					To:    r.Address(),
+					Data:  chunk,
+					Value: big.NewInt(0),
+				},
+			},
+		})
+	}
+	e.Logger.Infow("Batched router ramp updates", "chain", cfg.ChainSelector, "updates",
+		len(cfg.OnRampUpdates)+len(cfg.OffRampRemoves)+len(cfg.OffRampAdds), "calls", len(chunks))
+	prop, err := BuildProposalFromBatches(state, batches,
+		fmt.Sprintf("apply ramp updates to router %s on chain %d", r.Address(), cfg.ChainSelector), cfg.MinDelay)
+	if err != nil {
+		return deployment.ChangesetOutput{}, err
+	}
+	return deployment.ChangesetOutput{
+		Proposals: []timelock.MCMSWithTimelockProposal{*prop},
+	}, nil
+}
+
+type rampUpdates struct {
+	onRamps        []router.RouterOnRamp
+	offRampRemoves []router.RouterOffRamp
+	offRampAdds    []router.RouterOffRamp
+}
+
+func (u rampUpdates) clone() rampUpdates {
+	return rampUpdates{
+		onRamps:        append([]router.RouterOnRamp{}, u.onRamps...),
+		offRampRemoves: append([]router.RouterOffRamp{}, u.offRampRemoves...),
+		offRampAdds:    append([]router.RouterOffRamp{}, u.offRampAdds...),
+	}
+}
+
+func (u rampUpdates) pack() ([]byte, error) {
+	return routerABI.Pack("applyRampUpdates", u.onRamps, u.offRampRemoves, u.offRampAdds)
+}
+
+// chunkRampUpdates splits the updates into the calldata of as few applyRampUpdates calls as possible,
+// none of which exceeds maxCalldataBytes. The order of the updates is preserved across the calls.
+func chunkRampUpdates(updates rampUpdates, maxCalldataBytes int) ([][]byte, error) {
+	var chunks [][]byte
+	var current rampUpdates
+	var currentData []byte
+	add := func(appendTo func(*rampUpdates)) error {
+		next := current.clone()
+		appendTo(&next)
+		data, err := next.pack()
+		if err != nil {
+			return fmt.Errorf("failed to pack ramp updates: %w", err)
+		}
+		if len(data) > maxCalldataBytes && currentData != nil {
+			chunks = append(chunks, currentData)
+			next = rampUpdates{}
+			appendTo(&next)
+			if data, err = next.pack(); err != nil {
+				return fmt.Errorf("failed to pack ramp updates: %w", err)
+			}
+		}
+		if len(data) > maxCalldataBytes {
+			return fmt.Errorf("a single ramp update needs %d bytes of calldata, more than the limit of %d", len(data), maxCalldataBytes)
+		}
+		current, currentData = next, data
+		return nil
+	}
+	for _, onRamp := range updates.onRamps {
+		if err := add(func(u *rampUpdates) { u.onRamps = append(u.onRamps, onRamp) }); err != nil {
+			return nil, err
+		}
+	}
+	for _, offRamp := range updates.offRampRemoves {
+		if err := add(func(u *rampUpdates) { u.offRampRemoves = append(u.offRampRemoves, offRamp) }); err != nil {
+			return nil, err
+		}
+	}
+	for _, offRamp := range updates.offRampAdds {
+		if err := add(func(u *rampUpdates) { u.offRampAdds = append(u.offRampAdds, offRamp) }); err != nil {
+			return nil, err
+		}
+	}
+	if currentData != nil {
+		chunks = append(chunks, currentData)
+	}
+	return chunks, nil
+}
@@ -0,0 +1,41 @@
+package changeset
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/router"
+)
+
+func TestChunkRampUpdates(t *testing.T) {
+	updates := rampUpdates{
+		onRamps:        []router.RouterOnRamp{{DestChainSelector: 1, OnRamp: common.HexToAddress("0x1")}},
+		offRampRemoves: NewTestOffRamps(10),
+		offRampAdds:    NewTestOffRamps(30),
+	}
+	single, err := chunkRampUpdates(updates, DefaultMaxRampUpdateCalldataBytes)
+	require.NoError(t, err)
+	require.Len(t, single, 1)
+
+	chunks, err := chunkRampUpdates(updates, 1_000)
+	require.NoError(t, err)
+	require.Greater(t, len(chunks), 1)
+	var unpacked rampUpdates
+	method := routerABI.Methods["applyRampUpdates"]
+	for _, chunk := range chunks {
+		require.LessOrEqual(t, len(chunk), 1_000)
+		require.Equal(t, method.ID, chunk[:4])
+		args, err := method.Inputs.Unpack(chunk[4:])
+		require.NoError(t, err)
+		unpacked.onRamps = append(unpacked.onRamps, *abi.ConvertType(args[0], new([]router.RouterOnRamp)).(*[]router.RouterOnRamp)...)
+		unpacked.offRampRemoves = append(unpacked.offRampRemoves, *abi.ConvertType(args[1], new([]router.RouterOffRamp)).(*[]router.RouterOffRamp)...)
+		unpacked.offRampAdds = append(unpacked.offRampAdds, *abi.ConvertType(args[2], new([]router.RouterOffRamp)).(*[]router.RouterOffRamp)...)
+	}
+	require.Equal(t, updates, unpacked)
+
+	_, err = chunkRampUpdates(updates, 100)
+	require.ErrorContains(t, err, "more than the limit of 100")
+}
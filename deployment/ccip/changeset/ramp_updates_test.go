@@ -0,0 +1,54 @@
+package changeset_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/proposal/mcms"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/proposal/timelock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset/testhelpers"
+	commonchangeset "github.com/smartcontractkit/chainlink/deployment/common/changeset"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+func TestRouterRampUpdatesChangeset(t *testing.T) {
+	lggr := logger.TestLogger(t)
+	e := testhelpers.NewMemoryEnvironmentWithJobsAndContracts(t, lggr, 2, 4, nil)
+	state, err := changeset.LoadOnchainState(e.Env)
+	require.NoError(t, err)
+	chainSel := e.HomeChainSel
+	chain, chainState := e.Env.Chains[chainSel], state.Chains[chainSel]
+
+	// hand the test router over to the timelock
+	tx, err := chainState.TestRouter.TransferOwnership(chain.DeployerKey, chainState.Timelock.Address())
+	_, err = deployment.ConfirmIfNoError(chain, tx, err)
+	require.NoError(t, err)
+	acceptOwnership, err := chainState.TestRouter.AcceptOwnership(deployment.SimTransactOpts())
+	require.NoError(t, err)
+	prop, err := changeset.BuildProposalFromBatches(state, []timelock.BatchChainOperation{{
+		ChainIdentifier: mcms.ChainIdentifier(chainSel),
+		Batch:           []mcms.Operation{{To: chainState.TestRouter.Address(), Data: acceptOwnership.Data(), Value: big.NewInt(0)}},
+	}}, "accept test router ownership", 0)
+	require.NoError(t, err)
+	commonchangeset.ExecuteProposal(t, e.Env, commonchangeset.SignProposal(t, e.Env, prop), chainState.Timelock, chainSel)
+
+	offRamps := changeset.NewTestOffRamps(40)
+	out, err := changeset.RouterRampUpdatesChangeset(e.Env, changeset.RouterRampUpdatesConfig{
+		ChainSelector:    chainSel,
+		TestRouter:       true,
+		OffRampAdds:      offRamps,
+		MaxCalldataBytes: 1_000,
+	})
+	require.NoError(t, err)
+	require.Len(t, out.Proposals, 1)
+	require.Greater(t, len(out.Proposals[0].Transactions), 1)
+	commonchangeset.ExecuteProposal(t, e.Env, commonchangeset.SignProposal(t, e.Env, &out.Proposals[0]), chainState.Timelock, chainSel)
+
+	routed, err := chainState.TestRouter.GetOffRamps(nil)
+	require.NoError(t, err)
+	require.Subset(t, routed, offRamps)
+}
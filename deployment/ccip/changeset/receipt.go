@@ -0,0 +1,181 @@
+package changeset
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/generated"
+)
+
+// logParser is implemented by the generated wrappers, ParseLog decodes the events of their ABI.
+type logParser interface {
+	Address() common.Address
+	ParseLog(log types.Log) (generated.AbigenLog, error)
+}
+
+// DecodedLog is a log of a receipt decoded by DecodeReceipt.
+type DecodedLog struct {
+	types.Log
+	// Contract is the type of the contract of the state which emitted the log, empty if it isn't in the state.
+	Contract deployment.ContractType
+	// Event is the typed event of the log, e.g. *onramp.OnRampCCIPMessageSent, nil if it couldn't be decoded.
+	Event generated.AbigenLog
+}
+
+func (l DecodedLog) String() string {
+	if l.Event == nil {
+		return fmt.Sprintf("%s undecoded log %d of %s", l.Contract, l.Index, l.Address)
+	}
+	return fmt.Sprintf("%s %T %+v", l.Contract, l.Event, l.Event)
+}
+
+// DecodeReceipt decodes the logs of the receipt of a transaction on the chain with the ABIs of the CCIP contracts
+// of its state. The logs of contracts which aren't in the state, or whose event isn't in the ABI, are returned
+// without Event rather than failing, so that the receipt can still be logged in full.
+func DecodeReceipt(state CCIPOnChainState, chainSel uint64, receipt *types.Receipt) ([]DecodedLog, error) {
+	if receipt == nil {
+		return nil, fmt.Errorf("no receipt to decode on chain %d", chainSel)
+	}
+	chainState, ok := state.Chains[chainSel]
+	if !ok {
+		return nil, fmt.Errorf("chain %d not found in state", chainSel)
+	}
+	parsers := chainState.logParsers()
+	logs := make([]DecodedLog, 0, len(receipt.Logs))
+	for _, log := range receipt.Logs {
+		decoded := DecodedLog{Log: *log}
+		if contract, ok := parsers[log.Address]; ok {
+			decoded.Contract = contract.contractType
+			// anonymous events have no topic to be matched
+			if len(log.Topics) > 0 {
+				if event, err := contract.parser.ParseLog(*log); err == nil {
+					decoded.Event = event
+				}
+			}
+		}
+		logs = append(logs, decoded)
+	}
+	return logs, nil
+}
+
+// DecodedEvents returns the events of type E of the decoded logs, in the order they were emitted.
+func DecodedEvents[E generated.AbigenLog](logs []DecodedLog) []E {
+	var events []E
+	for _, log := range logs {
+		if event, ok := log.Event.(E); ok {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+type typedLogParser struct {
+	contractType deployment.ContractType
+	parser       logParser
+}
+
+// logParsers returns the wrappers of the deployed contracts of the chain by address. The MCMS contracts and
+// the price feeds aren't included, their wrappers can't decode logs.
+func (c CCIPChainState) logParsers() map[common.Address]typedLogParser {
+	parsers := make(map[common.Address]typedLogParser)
+	add := func(contractType deployment.ContractType, parser logParser) {
+		parsers[parser.Address()] = typedLogParser{contractType: contractType, parser: parser}
+	}
+	if c.OnRamp != nil {
+		add(OnRamp, c.OnRamp)
+	}
+	if c.OffRamp != nil {
+		add(OffRamp, c.OffRamp)
+	}
+	if c.FeeQuoter != nil {
+		add(FeeQuoter, c.FeeQuoter)
+	}
+	for _, onRamp := range c.OnRamps {
+		add(OnRamp, onRamp)
+	}
+	for _, offRamp := range c.OffRamps {
+		add(OffRamp, offRamp)
+	}
+	for _, fq := range c.FeeQuoters {
+		add(FeeQuoter, fq)
+	}
+	if c.RMNProxyNew != nil {
+		add(ARMProxy, c.RMNProxyNew)
+	}
+	if c.RMNProxyExisting != nil {
+		add(ARMProxy, c.RMNProxyExisting)
+	}
+	if c.NonceManager != nil {
+		add(NonceManager, c.NonceManager)
+	}
+	if c.TokenAdminRegistry != nil {
+		add(TokenAdminRegistry, c.TokenAdminRegistry)
+	}
+	if c.RegistryModule != nil {
+		add(RegistryModule, c.RegistryModule)
+	}
+	if c.Router != nil {
+		add(Router, c.Router)
+	}
+	if c.TestRouter != nil {
+		add(TestRouter, c.TestRouter)
+	}
+	if c.Weth9 != nil {
+		add(WETH9, c.Weth9)
+	}
+	if c.RMNRemote != nil {
+		add(RMNRemote, c.RMNRemote)
+	}
+	if c.MockRMN != nil {
+		add(MockRMN, c.MockRMN)
+	}
+	for _, token := range c.BurnMintTokens677 {
+		add(BurnMintToken, token)
+	}
+	if c.LinkToken != nil {
+		add(LinkToken, c.LinkToken)
+	}
+	if c.CapabilityRegistry != nil {
+		add(CapabilitiesRegistry, c.CapabilityRegistry)
+	}
+	if c.CCIPHome != nil {
+		add(CCIPHome, c.CCIPHome)
+	}
+	if c.RMNHome != nil {
+		add(RMNHome, c.RMNHome)
+	}
+	if c.CCIPConfig != nil {
+		add(CCIPConfig, c.CCIPConfig)
+	}
+	if c.PriceRegistry != nil {
+		add(PriceRegistry, c.PriceRegistry)
+	}
+	for _, onRamp := range c.EVM2EVMOnRamp {
+		add(EVM2EVMOnRamp, onRamp)
+	}
+	for _, offRamp := range c.EVM2EVMOffRamp {
+		add(EVM2EVMOffRamp, offRamp)
+	}
+	if c.CommitStore != nil {
+		add(CommitStore, c.CommitStore)
+	}
+	for _, commitStore := range c.CommitStores {
+		add(CommitStore, commitStore)
+	}
+	if c.Receiver != nil {
+		add(CCIPReceiver, c.Receiver)
+	}
+	if c.USDCTokenPool != nil {
+		add(USDCTokenPool, c.USDCTokenPool)
+	}
+	if c.MockUSDCTransmitter != nil {
+		add(USDCMockTransmitter, c.MockUSDCTransmitter)
+	}
+	if c.MockUSDCTokenMessenger != nil {
+		add(USDCTokenMessenger, c.MockUSDCTokenMessenger)
+	}
+	return parsers
+}
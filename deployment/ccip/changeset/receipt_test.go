@@ -0,0 +1,59 @@
+package changeset
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	chainsel "github.com/smartcontractkit/chain-selectors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/router"
+)
+
+func TestDecodeReceipt(t *testing.T) {
+	sel := chainsel.TEST_90000001.Selector
+	routerAddr, testRouterAddr := common.HexToAddress("0x1"), common.HexToAddress("0x2")
+	r, err := router.NewRouter(routerAddr, nil)
+	require.NoError(t, err)
+	testRouter, err := router.NewRouter(testRouterAddr, nil)
+	require.NoError(t, err)
+	state := CCIPOnChainState{Chains: map[uint64]CCIPChainState{
+		sel: {Router: r, TestRouter: testRouter},
+	}}
+
+	routerABI, err := router.RouterMetaData.GetAbi()
+	require.NoError(t, err)
+	from, to := common.HexToAddress("0x3"), common.HexToAddress("0x4")
+	ownershipTransferred := types.Log{
+		Address: testRouterAddr,
+		Topics:  []common.Hash{routerABI.Events["OwnershipTransferred"].ID, common.BytesToHash(from.Bytes()), common.BytesToHash(to.Bytes())},
+		Index:   1,
+	}
+	receipt := &types.Receipt{Logs: []*types.Log{
+		// not in the state
+		{Address: common.HexToAddress("0x5"), Topics: ownershipTransferred.Topics},
+		&ownershipTransferred,
+		// not in the ABI
+		{Address: routerAddr, Topics: []common.Hash{common.HexToHash("0x6")}, Index: 2},
+	}}
+
+	logs, err := DecodeReceipt(state, sel, receipt)
+	require.NoError(t, err)
+	require.Len(t, logs, 3)
+	require.Empty(t, logs[0].Contract)
+	require.Nil(t, logs[0].Event)
+	require.Equal(t, TestRouter, logs[1].Contract)
+	require.Equal(t, Router, logs[2].Contract)
+	require.Nil(t, logs[2].Event)
+
+	events := DecodedEvents[*router.RouterOwnershipTransferred](logs)
+	require.Len(t, events, 1)
+	require.Equal(t, from, events[0].From)
+	require.Equal(t, to, events[0].To)
+	require.Equal(t, ownershipTransferred, events[0].Raw)
+	require.Empty(t, DecodedEvents[*router.RouterOnRampSet](logs))
+
+	_, err = DecodeReceipt(state, chainsel.TEST_90000002.Selector, receipt)
+	require.Error(t, err)
+}
@@ -0,0 +1,210 @@
+package changeset
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/maybe_revert_message_receiver"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/offramp"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/onramp"
+)
+
+// erc20TransferTopic is the topic of the ERC20 Transfer(address,address,uint256) event.
+var erc20TransferTopic = crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+
+// Any2EVMMessage mirrors Client.Any2EVMMessage, the message the offramp delivers to ccipReceive of a receiver.
+type Any2EVMMessage struct {
+	MessageID           [32]byte
+	SourceChainSelector uint64
+	// Sender is the ABI encoded address of the sender on the source chain.
+	Sender           []byte
+	Data             []byte
+	DestTokenAmounts []DestTokenAmount
+}
+
+// DestTokenAmount is a token amount delivered on the destination chain.
+type DestTokenAmount struct {
+	Token  common.Address
+	Amount *big.Int
+}
+
+// SenderAddress returns the sender of a message from an EVM chain.
+func (m Any2EVMMessage) SenderAddress() (common.Address, error) {
+	if len(m.Sender) != 32 {
+		return common.Address{}, fmt.Errorf("sender %x is not an ABI encoded address", m.Sender)
+	}
+	return common.BytesToAddress(m.Sender), nil
+}
+
+// DecodeData decodes the data of the message as the ABI encoded args, see EncodeMessageData.
+func (m Any2EVMMessage) DecodeData(args abi.Arguments) ([]any, error) {
+	values, err := args.Unpack(m.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode data of message %x: %w", m.MessageID, err)
+	}
+	return values, nil
+}
+
+// EncodeMessageData ABI encodes the values as the data of a message, to be decoded on delivery with DecodeData.
+func EncodeMessageData(args abi.Arguments, values ...any) ([]byte, error) {
+	return args.Pack(values...)
+}
+
+// EncodeCCIPReceive returns the calldata of ccipReceive delivering the message to a receiver.
+func EncodeCCIPReceive(msg Any2EVMMessage) ([]byte, error) {
+	receiverABI, err := maybe_revert_message_receiver.MaybeRevertMessageReceiverMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	tokenAmounts := make([]maybe_revert_message_receiver.ClientEVMTokenAmount, 0, len(msg.DestTokenAmounts))
+	for _, ta := range msg.DestTokenAmounts {
+		tokenAmounts = append(tokenAmounts, maybe_revert_message_receiver.ClientEVMTokenAmount{Token: ta.Token, Amount: ta.Amount})
+	}
+	return receiverABI.Pack("ccipReceive", maybe_revert_message_receiver.ClientAny2EVMMessage{
+		MessageId:           msg.MessageID,
+		SourceChainSelector: msg.SourceChainSelector,
+		Sender:              msg.Sender,
+		Data:                msg.Data,
+		DestTokenAmounts:    tokenAmounts,
+	})
+}
+
+// DecodeCCIPReceive decodes the message from the calldata of ccipReceive.
+func DecodeCCIPReceive(calldata []byte) (Any2EVMMessage, error) {
+	receiverABI, err := maybe_revert_message_receiver.MaybeRevertMessageReceiverMetaData.GetAbi()
+	if err != nil {
+		return Any2EVMMessage{}, err
+	}
+	method := receiverABI.Methods["ccipReceive"]
+	if len(calldata) < 4 || !bytes.Equal(calldata[:4], method.ID) {
+		return Any2EVMMessage{}, fmt.Errorf("calldata is not a ccipReceive call")
+	}
+	values, err := method.Inputs.Unpack(calldata[4:])
+	if err != nil {
+		return Any2EVMMessage{}, fmt.Errorf("failed to decode ccipReceive calldata: %w", err)
+	}
+	decoded := *abi.ConvertType(values[0], new(maybe_revert_message_receiver.ClientAny2EVMMessage)).(*maybe_revert_message_receiver.ClientAny2EVMMessage)
+	msg := Any2EVMMessage{
+		MessageID:           decoded.MessageId,
+		SourceChainSelector: decoded.SourceChainSelector,
+		Sender:              decoded.Sender,
+		Data:                decoded.Data,
+	}
+	for _, ta := range decoded.DestTokenAmounts {
+		msg.DestTokenAmounts = append(msg.DestTokenAmounts, DestTokenAmount{Token: ta.Token, Amount: ta.Amount})
+	}
+	return msg, nil
+}
+
+// GetDeliveredMessage returns the message the offramp delivered to the receiver of the sent message
+// in the execution of the state change, as the receiver got it in ccipReceive.
+// The amounts delivered are read from the token transfers to the receiver in the execution,
+// they differ from the amounts sent when the pools scale them between the decimals of the tokens.
+func GetDeliveredMessage(
+	ctx context.Context,
+	dest deployment.Chain,
+	sent *onramp.OnRampCCIPMessageSent,
+	execution *offramp.OffRampExecutionStateChanged,
+) (Any2EVMMessage, error) {
+	if execution.MessageId != sent.Message.Header.MessageId {
+		return Any2EVMMessage{}, fmt.Errorf("execution of message %x is not for message %x", execution.MessageId, sent.Message.Header.MessageId)
+	}
+	if execution.State != EXECUTION_STATE_SUCCESS {
+		return Any2EVMMessage{}, fmt.Errorf("message %x was not delivered, execution state %s",
+			execution.MessageId, ExecutionStateToString(execution.State))
+	}
+	receipt, err := dest.Client.TransactionReceipt(ctx, execution.Raw.TxHash)
+	if err != nil {
+		return Any2EVMMessage{}, fmt.Errorf("failed to get receipt of execution %s on chain %d: %w", execution.Raw.TxHash, dest.Selector, err)
+	}
+	return deliveredMessage(sent, execution.Raw.Address, receipt.Logs)
+}
+
+// deliveredMessage builds the delivered message from the sent message and the logs of the transaction executing it.
+// The transaction can execute several messages, each one's token transfers are logged after the ExecutionStateChanged
+// of the previous message of the offramp and before its own.
+func deliveredMessage(sent *onramp.OnRampCCIPMessageSent, offRamp common.Address, logs []*types.Log) (Any2EVMMessage, error) {
+	if len(sent.Message.Receiver) != 32 {
+		return Any2EVMMessage{}, fmt.Errorf("receiver %x of message %x is not an ABI encoded address", sent.Message.Receiver, sent.Message.Header.MessageId)
+	}
+	start, end := 0, -1
+	for i, log := range logs {
+		if log.Address != offRamp || len(log.Topics) != 4 || log.Topics[0] != (offramp.OffRampExecutionStateChanged{}).Topic() {
+			continue
+		}
+		if log.Topics[3] == common.Hash(sent.Message.Header.MessageId) {
+			end = i
+			break
+		}
+		start = i + 1
+	}
+	if end < 0 {
+		return Any2EVMMessage{}, fmt.Errorf("no execution of message %x by offramp %s in the logs", sent.Message.Header.MessageId, offRamp)
+	}
+	logs = logs[start:end]
+	receiver := common.BytesToAddress(sent.Message.Receiver)
+	msg := Any2EVMMessage{
+		MessageID:           sent.Message.Header.MessageId,
+		SourceChainSelector: sent.Message.Header.SourceChainSelector,
+		Sender:              common.LeftPadBytes(sent.Message.Sender.Bytes(), 32),
+		Data:                sent.Message.Data,
+	}
+	// the tokens are released or minted to the receiver in the order of the message,
+	// before the message is delivered
+	used := make(map[int]struct{})
+	for i, ta := range sent.Message.TokenAmounts {
+		if len(ta.DestTokenAddress) != 32 {
+			return Any2EVMMessage{}, fmt.Errorf("destination token %x of token amount %d is not an ABI encoded address", ta.DestTokenAddress, i)
+		}
+		token := common.BytesToAddress(ta.DestTokenAddress)
+		var amount *big.Int
+		for j, log := range logs {
+			if _, ok := used[j]; ok {
+				continue
+			}
+			if log.Address != token || len(log.Topics) != 3 || log.Topics[0] != erc20TransferTopic ||
+				common.BytesToAddress(log.Topics[2].Bytes()) != receiver {
+				continue
+			}
+			used[j] = struct{}{}
+			amount = new(big.Int).SetBytes(log.Data)
+			break
+		}
+		if amount == nil {
+			return Any2EVMMessage{}, fmt.Errorf("no transfer of token %s to receiver %s for token amount %d", token, receiver, i)
+		}
+		msg.DestTokenAmounts = append(msg.DestTokenAmounts, DestTokenAmount{Token: token, Amount: amount})
+	}
+	return msg, nil
+}
+
+const (
+	EXECUTION_STATE_UNTOUCHED  = 0
+	EXECUTION_STATE_INPROGRESS = 1
+	EXECUTION_STATE_SUCCESS    = 2
+	EXECUTION_STATE_FAILURE    = 3
+)
+
+// ExecutionStateToString returns the name of an OffRamp execution state.
+func ExecutionStateToString(state uint8) string {
+	switch state {
+	case EXECUTION_STATE_UNTOUCHED:
+		return "UNTOUCHED"
+	case EXECUTION_STATE_INPROGRESS:
+		return "IN_PROGRESS"
+	case EXECUTION_STATE_SUCCESS:
+		return "SUCCESS"
+	case EXECUTION_STATE_FAILURE:
+		return "FAILURE"
+	default:
+		return "UNKNOWN"
+	}
+}
@@ -0,0 +1,110 @@
+package changeset
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/offramp"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/onramp"
+)
+
+func TestCCIPReceiveEncoding(t *testing.T) {
+	args := abi.Arguments{{Type: mustABIType(t, "string")}, {Type: mustABIType(t, "uint256")}}
+	data, err := EncodeMessageData(args, "hello", big.NewInt(42))
+	require.NoError(t, err)
+	sender := common.HexToAddress("0x1234")
+	msg := Any2EVMMessage{
+		MessageID:           [32]byte{1},
+		SourceChainSelector: 5009297550715157269,
+		Sender:              common.LeftPadBytes(sender.Bytes(), 32),
+		Data:                data,
+		DestTokenAmounts:    []DestTokenAmount{{Token: common.HexToAddress("0x5678"), Amount: big.NewInt(100)}},
+	}
+	calldata, err := EncodeCCIPReceive(msg)
+	require.NoError(t, err)
+	decoded, err := DecodeCCIPReceive(calldata)
+	require.NoError(t, err)
+	require.Equal(t, msg, decoded)
+
+	decodedSender, err := decoded.SenderAddress()
+	require.NoError(t, err)
+	require.Equal(t, sender, decodedSender)
+	values, err := decoded.DecodeData(args)
+	require.NoError(t, err)
+	require.Equal(t, "hello", values[0])
+	require.Equal(t, big.NewInt(42), values[1])
+
+	_, err = DecodeCCIPReceive(calldata[4:])
+	require.ErrorContains(t, err, "not a ccipReceive call")
+}
+
+func TestDeliveredMessage(t *testing.T) {
+	receiver := common.HexToAddress("0xaaaa")
+	tokenA, tokenB := common.HexToAddress("0x0a"), common.HexToAddress("0x0b")
+	sent := &onramp.OnRampCCIPMessageSent{Message: onramp.InternalEVM2AnyRampMessage{
+		Header:   onramp.InternalRampMessageHeader{MessageId: [32]byte{7}, SourceChainSelector: 1},
+		Sender:   common.HexToAddress("0x1234"),
+		Data:     []byte("hello"),
+		Receiver: common.LeftPadBytes(receiver.Bytes(), 32),
+		TokenAmounts: []onramp.InternalEVM2AnyTokenTransfer{
+			{DestTokenAddress: common.LeftPadBytes(tokenA.Bytes(), 32), Amount: big.NewInt(1e6)},
+			{DestTokenAddress: common.LeftPadBytes(tokenB.Bytes(), 32), Amount: big.NewInt(5)},
+			{DestTokenAddress: common.LeftPadBytes(tokenA.Bytes(), 32), Amount: big.NewInt(2e6)},
+		},
+	}}
+	transfer := func(token, to common.Address, amount int64) *types.Log {
+		return &types.Log{
+			Address: token,
+			Topics:  []common.Hash{erc20TransferTopic, {}, common.BytesToHash(to.Bytes())},
+			Data:    common.LeftPadBytes(big.NewInt(amount).Bytes(), 32),
+		}
+	}
+	offRamp := common.HexToAddress("0x0ff")
+	executed := func(seqNr uint64, messageID [32]byte) *types.Log {
+		return &types.Log{
+			Address: offRamp,
+			Topics: []common.Hash{(offramp.OffRampExecutionStateChanged{}).Topic(), {1},
+				common.BigToHash(new(big.Int).SetUint64(seqNr)), messageID},
+		}
+	}
+	// the message is executed after another message to the same receiver
+	logs := []*types.Log{
+		transfer(tokenA, receiver, 42),
+		executed(1, [32]byte{6}),
+		transfer(tokenA, common.HexToAddress("0xbbbb"), 999), // to another address
+		transfer(tokenA, receiver, 1e18),
+		transfer(tokenB, receiver, 5),
+		transfer(tokenA, receiver, 2e18),
+		executed(2, [32]byte{7}),
+		transfer(tokenA, receiver, 43),
+		executed(3, [32]byte{8}),
+	}
+	msg, err := deliveredMessage(sent, offRamp, logs)
+	require.NoError(t, err)
+	require.Equal(t, [32]byte{7}, msg.MessageID)
+	require.Equal(t, []byte("hello"), msg.Data)
+	sender, err := msg.SenderAddress()
+	require.NoError(t, err)
+	require.Equal(t, sent.Message.Sender, sender)
+	require.Equal(t, []DestTokenAmount{
+		{Token: tokenA, Amount: big.NewInt(1e18)},
+		{Token: tokenB, Amount: big.NewInt(5)},
+		{Token: tokenA, Amount: big.NewInt(2e18)},
+	}, msg.DestTokenAmounts)
+
+	_, err = deliveredMessage(sent, offRamp, append(logs[:4:4], logs[6]))
+	require.ErrorContains(t, err, "no transfer of token")
+	_, err = deliveredMessage(sent, common.HexToAddress("0x0ee"), logs)
+	require.ErrorContains(t, err, "no execution of message")
+}
+
+func mustABIType(t *testing.T, name string) abi.Type {
+	typ, err := abi.NewType(name, "", nil)
+	require.NoError(t, err)
+	return typ
+}
@@ -0,0 +1,239 @@
+package changeset
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/offramp"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/onramp"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/router"
+)
+
+// Promoting a lane from the test routers to the routers is done in the following steps,
+// rolling it back is the same with the routers swapped:
+//  1. The router of the source chain routes the lane to the onramp, which is then switched to only accept
+//     messages from that router, and the test router stops routing the lane.
+//  2. The router of the destination chain accepts the offramp, which is then switched to route the messages
+//     of the lane through that router, and the test router stops accepting the offramp.
+//  3. The routing of the lane is verified to be consistent, see ValidateLaneRouting.
+// Every step is skipped if it was already applied, so a promotion which failed midway can be resumed
+// by running it again.
+
+var _ deployment.ChangeSet[PromoteLanesConfig] = PromoteLanesChangeset
+
+type PromoteLanesConfig struct {
+	Lanes []SourceDestPair
+	// Rollback moves the lanes back from the routers to the test routers.
+	Rollback bool
+}
+
+func (c PromoteLanesConfig) Validate(state CCIPOnChainState) error {
+	if len(c.Lanes) == 0 {
+		return fmt.Errorf("no lanes to promote")
+	}
+	lanes := make(map[SourceDestPair]struct{})
+	for _, lane := range c.Lanes {
+		if lane.SourceChainSelector == lane.DestChainSelector {
+			return fmt.Errorf("cannot promote lane to the same chain")
+		}
+		if _, ok := lanes[lane]; ok {
+			return fmt.Errorf("duplicate lane %d -> %d", lane.SourceChainSelector, lane.DestChainSelector)
+		}
+		lanes[lane] = struct{}{}
+		for _, sel := range []uint64{lane.SourceChainSelector, lane.DestChainSelector} {
+			chainState, ok := state.Chains[sel]
+			if !ok {
+				return fmt.Errorf("chain %d not found in onchain state", sel)
+			}
+			if chainState.Router == nil || chainState.TestRouter == nil {
+				return fmt.Errorf("router and test router must be deployed on chain %d", sel)
+			}
+		}
+		if state.Chains[lane.SourceChainSelector].OnRamp == nil {
+			return fmt.Errorf("onramp not deployed on chain %d", lane.SourceChainSelector)
+		}
+		if state.Chains[lane.DestChainSelector].OffRamp == nil {
+			return fmt.Errorf("offramp not deployed on chain %d", lane.DestChainSelector)
+		}
+	}
+	return nil
+}
+
+// PromoteLanesChangeset moves the lanes from the test routers to the routers, or back with Rollback,
+// and verifies the onramps and offramps of the lanes only reference the routers they were moved to.
+func PromoteLanesChangeset(e deployment.Environment, cfg PromoteLanesConfig) (deployment.ChangesetOutput, error) {
+	state, err := LoadOnchainState(e)
+	if err != nil {
+		e.Logger.Errorw("Failed to load existing onchain state", "err", err)
+		return deployment.ChangesetOutput{}, err
+	}
+	if err := cfg.Validate(state); err != nil {
+		return deployment.ChangesetOutput{}, fmt.Errorf("invalid PromoteLanesConfig: %w", err)
+	}
+	for _, lane := range cfg.Lanes {
+		if err := promoteLane(e, state, lane, cfg.Rollback); err != nil {
+			e.Logger.Errorw("Failed to promote lane", "source", lane.SourceChainSelector, "dest", lane.DestChainSelector,
+				"rollback", cfg.Rollback, "err", err)
+			return deployment.ChangesetOutput{}, deployment.MaybeDataErr(err)
+		}
+		if err := ValidateLaneRouting(state, lane, cfg.Rollback); err != nil {
+			return deployment.ChangesetOutput{}, fmt.Errorf("lane %d -> %d is inconsistent after promotion: %w",
+				lane.SourceChainSelector, lane.DestChainSelector, err)
+		}
+		e.Logger.Infow("Promoted lane", "source", lane.SourceChainSelector, "dest", lane.DestChainSelector, "rollback", cfg.Rollback)
+	}
+	return deployment.ChangesetOutput{}, nil
+}
+
+// laneRouters returns the routers of the source and destination chains a lane is routed through,
+// and the ones it must not be routed through.
+func laneRouters(state CCIPOnChainState, lane SourceDestPair, testRouter bool) (srcRouter, destRouter, srcOther, destOther *router.Router) {
+	srcState, destState := state.Chains[lane.SourceChainSelector], state.Chains[lane.DestChainSelector]
+	if testRouter {
+		return srcState.TestRouter, destState.TestRouter, srcState.Router, destState.Router
+	}
+	return srcState.Router, destState.Router, srcState.TestRouter, destState.TestRouter
+}
+
+func promoteLane(e deployment.Environment, state CCIPOnChainState, lane SourceDestPair, rollback bool) error {
+	src, dest := lane.SourceChainSelector, lane.DestChainSelector
+	srcChain, destChain := e.Chains[src], e.Chains[dest]
+	onRamp, offRamp := state.Chains[src].OnRamp, state.Chains[dest].OffRamp
+	srcRouter, destRouter, srcOther, destOther := laneRouters(state, lane, rollback)
+	opts := &bind.CallOpts{Context: context.Background()}
+
+	routedOnRamp, err := srcRouter.GetOnRamp(opts, dest)
+	if err != nil {
+		return fmt.Errorf("failed to get onramp of router %s: %w", srcRouter.Address(), err)
+	}
+	if routedOnRamp != onRamp.Address() {
+		tx, err := srcRouter.ApplyRampUpdates(srcChain.DeployerKey,
+			[]router.RouterOnRamp{{DestChainSelector: dest, OnRamp: onRamp.Address()}}, []router.RouterOffRamp{}, []router.RouterOffRamp{})
+		if _, err := deployment.ConfirmIfNoError(srcChain, tx, err); err != nil {
+			return fmt.Errorf("failed to route lane through router %s: %w", srcRouter.Address(), err)
+		}
+	}
+	destChainConfig, err := onRamp.GetDestChainConfig(opts, dest)
+	if err != nil {
+		return fmt.Errorf("failed to get dest chain config of onramp: %w", err)
+	}
+	if destChainConfig.Router != srcRouter.Address() {
+		tx, err := onRamp.ApplyDestChainConfigUpdates(srcChain.DeployerKey, []onramp.OnRampDestChainConfigArgs{{
+			DestChainSelector: dest,
+			Router:            srcRouter.Address(),
+			AllowlistEnabled:  destChainConfig.AllowlistEnabled,
+		}})
+		if _, err := deployment.ConfirmIfNoError(srcChain, tx, err); err != nil {
+			return fmt.Errorf("failed to set router of onramp: %w", err)
+		}
+	}
+	routedOnRamp, err = srcOther.GetOnRamp(opts, dest)
+	if err != nil {
+		return fmt.Errorf("failed to get onramp of router %s: %w", srcOther.Address(), err)
+	}
+	if routedOnRamp == onRamp.Address() {
+		tx, err := srcOther.ApplyRampUpdates(srcChain.DeployerKey,
+			[]router.RouterOnRamp{{DestChainSelector: dest, OnRamp: common.Address{}}}, []router.RouterOffRamp{}, []router.RouterOffRamp{})
+		if _, err := deployment.ConfirmIfNoError(srcChain, tx, err); err != nil {
+			return fmt.Errorf("failed to remove lane from router %s: %w", srcOther.Address(), err)
+		}
+	}
+
+	isOffRamp, err := destRouter.IsOffRamp(opts, src, offRamp.Address())
+	if err != nil {
+		return fmt.Errorf("failed to check offramp of router %s: %w", destRouter.Address(), err)
+	}
+	if !isOffRamp {
+		tx, err := destRouter.ApplyRampUpdates(destChain.DeployerKey, []router.RouterOnRamp{}, []router.RouterOffRamp{},
+			[]router.RouterOffRamp{{SourceChainSelector: src, OffRamp: offRamp.Address()}})
+		if _, err := deployment.ConfirmIfNoError(destChain, tx, err); err != nil {
+			return fmt.Errorf("failed to add offramp to router %s: %w", destRouter.Address(), err)
+		}
+	}
+	sourceChainConfig, err := offRamp.GetSourceChainConfig(opts, src)
+	if err != nil {
+		return fmt.Errorf("failed to get source chain config of offramp: %w", err)
+	}
+	if sourceChainConfig.Router != destRouter.Address() {
+		tx, err := offRamp.ApplySourceChainConfigUpdates(destChain.DeployerKey, []offramp.OffRampSourceChainConfigArgs{{
+			Router:              destRouter.Address(),
+			SourceChainSelector: src,
+			IsEnabled:           sourceChainConfig.IsEnabled,
+			OnRamp:              sourceChainConfig.OnRamp,
+		}})
+		if _, err := deployment.ConfirmIfNoError(destChain, tx, err); err != nil {
+			return fmt.Errorf("failed to set router of offramp: %w", err)
+		}
+	}
+	isOffRamp, err = destOther.IsOffRamp(opts, src, offRamp.Address())
+	if err != nil {
+		return fmt.Errorf("failed to check offramp of router %s: %w", destOther.Address(), err)
+	}
+	if isOffRamp {
+		tx, err := destOther.ApplyRampUpdates(destChain.DeployerKey, []router.RouterOnRamp{},
+			[]router.RouterOffRamp{{SourceChainSelector: src, OffRamp: offRamp.Address()}}, []router.RouterOffRamp{})
+		if _, err := deployment.ConfirmIfNoError(destChain, tx, err); err != nil {
+			return fmt.Errorf("failed to remove offramp from router %s: %w", destOther.Address(), err)
+		}
+	}
+	return nil
+}
+
+// ValidateLaneRouting verifies the lane is only routed through the test routers if testRouter is set,
+// or only through the routers otherwise: the onramp and offramp of the lane reference those routers,
+// which route the lane to them, and the other routers don't.
+func ValidateLaneRouting(state CCIPOnChainState, lane SourceDestPair, testRouter bool) error {
+	src, dest := lane.SourceChainSelector, lane.DestChainSelector
+	onRamp, offRamp := state.Chains[src].OnRamp, state.Chains[dest].OffRamp
+	srcRouter, destRouter, srcOther, destOther := laneRouters(state, lane, testRouter)
+	opts := &bind.CallOpts{Context: context.Background()}
+
+	destChainConfig, err := onRamp.GetDestChainConfig(opts, dest)
+	if err != nil {
+		return fmt.Errorf("failed to get dest chain config of onramp: %w", err)
+	}
+	if destChainConfig.Router != srcRouter.Address() {
+		return fmt.Errorf("onramp on chain %d references router %s, expected %s", src, destChainConfig.Router, srcRouter.Address())
+	}
+	routedOnRamp, err := srcRouter.GetOnRamp(opts, dest)
+	if err != nil {
+		return fmt.Errorf("failed to get onramp of router %s: %w", srcRouter.Address(), err)
+	}
+	if routedOnRamp != onRamp.Address() {
+		return fmt.Errorf("router %s on chain %d routes to onramp %s, expected %s", srcRouter.Address(), src, routedOnRamp, onRamp.Address())
+	}
+	routedOnRamp, err = srcOther.GetOnRamp(opts, dest)
+	if err != nil {
+		return fmt.Errorf("failed to get onramp of router %s: %w", srcOther.Address(), err)
+	}
+	if routedOnRamp == onRamp.Address() {
+		return fmt.Errorf("router %s on chain %d still routes to onramp %s", srcOther.Address(), src, onRamp.Address())
+	}
+
+	sourceChainConfig, err := offRamp.GetSourceChainConfig(opts, src)
+	if err != nil {
+		return fmt.Errorf("failed to get source chain config of offramp: %w", err)
+	}
+	if sourceChainConfig.Router != destRouter.Address() {
+		return fmt.Errorf("offramp on chain %d references router %s, expected %s", dest, sourceChainConfig.Router, destRouter.Address())
+	}
+	isOffRamp, err := destRouter.IsOffRamp(opts, src, offRamp.Address())
+	if err != nil {
+		return fmt.Errorf("failed to check offramp of router %s: %w", destRouter.Address(), err)
+	}
+	if !isOffRamp {
+		return fmt.Errorf("router %s on chain %d does not accept offramp %s", destRouter.Address(), dest, offRamp.Address())
+	}
+	isOffRamp, err = destOther.IsOffRamp(opts, src, offRamp.Address())
+	if err != nil {
+		return fmt.Errorf("failed to check offramp of router %s: %w", destOther.Address(), err)
+	}
+	if isOffRamp {
+		return fmt.Errorf("router %s on chain %d still accepts offramp %s", destOther.Address(), dest, offRamp.Address())
+	}
+	return nil
+}
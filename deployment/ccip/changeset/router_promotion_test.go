@@ -0,0 +1,73 @@
+package changeset_test
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-testing-framework/lib/utils/testcontext"
+
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset/testhelpers"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/router"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+func TestPromoteLanes(t *testing.T) {
+	e := testhelpers.NewMemoryEnvironmentWithJobsAndContracts(t, logger.TestLogger(t), 2, 4, nil)
+	state, err := changeset.LoadOnchainState(e.Env)
+	require.NoError(t, err)
+	selectors := e.Env.AllChainSelectors()
+	src, dest := selectors[0], selectors[1]
+	lane := changeset.SourceDestPair{SourceChainSelector: src, DestChainSelector: dest}
+
+	_, err = changeset.AddLanesWithTestRouter(e.Env, changeset.AddLanesConfig{
+		LaneConfigs: []changeset.LaneConfig{{
+			SourceSelector:        src,
+			DestSelector:          dest,
+			InitialPricesBySource: changeset.DefaultInitialPrices,
+			FeeQuoterDestChain:    changeset.DefaultFeeQuoterDestChainConfig(),
+		}},
+	})
+	require.NoError(t, err)
+	require.NoError(t, changeset.ValidateLaneRouting(state, lane, true))
+	require.ErrorContains(t, changeset.ValidateLaneRouting(state, lane, false), "references router")
+
+	_, err = changeset.PromoteLanesChangeset(e.Env, changeset.PromoteLanesConfig{Lanes: []changeset.SourceDestPair{lane, lane}})
+	require.ErrorContains(t, err, "duplicate lane")
+
+	msg := router.ClientEVM2AnyMessage{
+		Receiver:  common.LeftPadBytes(state.Chains[dest].Receiver.Address().Bytes(), 32),
+		Data:      []byte("hello"),
+		FeeToken:  common.HexToAddress("0x0"),
+		ExtraArgs: nil,
+	}
+	sendAndConfirm := func(testRouter bool) {
+		latesthdr, err := e.Env.Chains[dest].Client.HeaderByNumber(testcontext.Get(t), nil)
+		require.NoError(t, err)
+		startBlock := latesthdr.Number.Uint64()
+		msgSentEvent := testhelpers.TestSendRequest(t, e.Env, state, src, dest, testRouter, msg)
+		_, err = testhelpers.ConfirmExecWithSeqNrs(t, e.Env.Chains[src], e.Env.Chains[dest], state.Chains[dest].OffRamp, &startBlock,
+			[]uint64{msgSentEvent.SequenceNumber})
+		require.NoError(t, err)
+	}
+	sendAndConfirm(true)
+
+	_, err = changeset.PromoteLanesChangeset(e.Env, changeset.PromoteLanesConfig{Lanes: []changeset.SourceDestPair{lane}})
+	require.NoError(t, err)
+	require.NoError(t, changeset.ValidateLaneRouting(state, lane, false))
+	_, _, err = testhelpers.CCIPSendRequest(e.Env, state, src, dest, true, msg)
+	require.Error(t, err)
+	sendAndConfirm(false)
+	// promoting again is a no-op
+	_, err = changeset.PromoteLanesChangeset(e.Env, changeset.PromoteLanesConfig{Lanes: []changeset.SourceDestPair{lane}})
+	require.NoError(t, err)
+
+	_, err = changeset.PromoteLanesChangeset(e.Env, changeset.PromoteLanesConfig{Lanes: []changeset.SourceDestPair{lane}, Rollback: true})
+	require.NoError(t, err)
+	require.NoError(t, changeset.ValidateLaneRouting(state, lane, true))
+	_, _, err = testhelpers.CCIPSendRequest(e.Env, state, src, dest, false, msg)
+	require.Error(t, err)
+	sendAndConfirm(true)
+}
@@ -0,0 +1,460 @@
+package changeset
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/proposal/timelock"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	commontypes "github.com/smartcontractkit/chainlink/deployment/common/types"
+)
+
+var (
+	_ deployment.ChangeSet[DeploySolChainContractsConfig] = DeploySolChainContracts
+	_ deployment.ChangeSet[SolTokenPoolsConfig]           = ConfigureSolTokenPools
+)
+
+const (
+	// SolanaCCIPRouter is the CCIP router program, which holds the onramp and offramp of the chain.
+	SolanaCCIPRouter  deployment.ContractType = "SolanaCCIPRouter"
+	SolanaFeeQuoter   deployment.ContractType = "SolanaFeeQuoter"
+	SolanaTokenPool   deployment.ContractType = "SolanaTokenPool"
+	SolanaLookupTable deployment.ContractType = "SolanaLookupTable"
+)
+
+var (
+	solConfigSeed          = []byte("config")
+	solPoolConfigSeed      = []byte("ccip_tokenpool_config")
+	solPoolChainConfigSeed = []byte("ccip_tokenpool_chainconfig")
+)
+
+// SolCCIPChainState is the CCIPChainState counterpart for Solana chains, zero keys are not deployed.
+type SolCCIPChainState struct {
+	Router    solana.PublicKey
+	FeeQuoter solana.PublicKey
+	TokenPool solana.PublicKey
+	// LookupTable is the address lookup table of the accounts of the CCIP programs, used by the
+	// transactions of the nodes to fit in the size limit of Solana transactions.
+	LookupTable solana.PublicKey
+}
+
+// LoadSolChainState loads the state of a Solana chain from its addresses in the address book.
+func LoadSolChainState(addresses map[string]deployment.TypeAndVersion) (SolCCIPChainState, error) {
+	var state SolCCIPChainState
+	for address, tv := range addresses {
+		key, err := solana.PublicKeyFromBase58(address)
+		if err != nil {
+			return state, err
+		}
+		switch tv.String() {
+		case deployment.NewTypeAndVersion(SolanaCCIPRouter, deployment.Version1_6_0_dev).String():
+			state.Router = key
+		case deployment.NewTypeAndVersion(SolanaFeeQuoter, deployment.Version1_6_0_dev).String():
+			state.FeeQuoter = key
+		case deployment.NewTypeAndVersion(SolanaTokenPool, deployment.Version1_6_0_dev).String():
+			state.TokenPool = key
+		case deployment.NewTypeAndVersion(SolanaLookupTable, deployment.Version1_6_0_dev).String():
+			state.LookupTable = key
+		case deployment.NewTypeAndVersion(commontypes.SquadsProgram, deployment.Version1_0_0).String(),
+			deployment.NewTypeAndVersion(commontypes.SquadsMultisig, deployment.Version1_0_0).String():
+			// the multisig is loaded by commonchangeset.LoadMultisigExecutors
+			continue
+		default:
+			return state, fmt.Errorf("unknown contract %s", tv)
+		}
+	}
+	return state, nil
+}
+
+// SolConfigPDA returns the state account of a CCIP program, initialized once the program is deployed.
+func SolConfigPDA(programID solana.PublicKey) (solana.PublicKey, error) {
+	pda, _, err := solana.FindProgramAddress([][]byte{solConfigSeed}, programID)
+	return pda, err
+}
+
+// SolTokenPoolConfigPDA returns the state account of the pool of the mint.
+func SolTokenPoolConfigPDA(poolProgram, mint solana.PublicKey) (solana.PublicKey, error) {
+	pda, _, err := solana.FindProgramAddress([][]byte{solPoolConfigSeed, mint.Bytes()}, poolProgram)
+	return pda, err
+}
+
+// SolTokenPoolChainConfigPDA returns the account of the config of a remote chain of the pool of the mint.
+func SolTokenPoolChainConfigPDA(poolProgram, mint solana.PublicKey, remoteChain uint64) (solana.PublicKey, error) {
+	pda, _, err := solana.FindProgramAddress(
+		[][]byte{solPoolChainConfigSeed, deployment.BorshU64(remoteChain), mint.Bytes()}, poolProgram)
+	return pda, err
+}
+
+// Names of the CCIP programs in the target directory of the chainlink-ccip Solana contracts.
+const (
+	solRouterProgram    = "ccip_router"
+	solFeeQuoterProgram = "fee_quoter"
+	solTokenPoolProgram = "token_pool"
+)
+
+// SolPrograms are the compiled CCIP programs, empty programs are not deployed nor upgraded.
+type SolPrograms struct {
+	Router    []byte
+	FeeQuoter []byte
+	TokenPool []byte
+	// RouterIDL, FeeQuoterIDL and TokenPoolIDL are the IDLs generated along with the programs, the instructions
+	// sent to the programs are encoded with them.
+	RouterIDL    *deployment.AnchorIDL
+	FeeQuoterIDL *deployment.AnchorIDL
+	TokenPoolIDL *deployment.AnchorIDL
+}
+
+// LoadSolPrograms loads the programs built by `anchor build` in the target directory of the chainlink-ccip
+// Solana contracts, from deploy/<program>.so and idl/<program>.json.
+func LoadSolPrograms(targetDir string) (SolPrograms, error) {
+	var programs SolPrograms
+	for _, program := range []struct {
+		name string
+		elf  *[]byte
+		idl  **deployment.AnchorIDL
+	}{
+		{solRouterProgram, &programs.Router, &programs.RouterIDL},
+		{solFeeQuoterProgram, &programs.FeeQuoter, &programs.FeeQuoterIDL},
+		{solTokenPoolProgram, &programs.TokenPool, &programs.TokenPoolIDL},
+	} {
+		elf, err := os.ReadFile(filepath.Join(targetDir, "deploy", program.name+".so"))
+		if err != nil {
+			return SolPrograms{}, fmt.Errorf("failed to read program %s: %w", program.name, err)
+		}
+		b, err := os.ReadFile(filepath.Join(targetDir, "idl", program.name+".json"))
+		if err != nil {
+			return SolPrograms{}, fmt.Errorf("failed to read IDL of program %s: %w", program.name, err)
+		}
+		idl, err := deployment.ParseAnchorIDL(b)
+		if err != nil {
+			return SolPrograms{}, fmt.Errorf("invalid IDL of program %s: %w", program.name, err)
+		}
+		*program.elf, *program.idl = elf, idl
+	}
+	return programs, nil
+}
+
+type DeploySolChainContractsConfig struct {
+	ChainSelectors []uint64
+	Programs       SolPrograms
+	// Upgrade upgrades the programs already deployed to the programs of the config, otherwise they are left as is.
+	Upgrade bool
+}
+
+func (c DeploySolChainContractsConfig) Validate(e deployment.Environment) error {
+	if len(c.ChainSelectors) == 0 {
+		return fmt.Errorf("no chains to deploy")
+	}
+	for _, cs := range c.ChainSelectors {
+		if _, ok := e.SolChains[cs]; !ok {
+			return fmt.Errorf("solana chain %d not found in environment", cs)
+		}
+	}
+	if c.Programs.RouterIDL == nil || c.Programs.FeeQuoterIDL == nil {
+		return fmt.Errorf("IDLs of the router and the fee quoter are required to initialize them")
+	}
+	return nil
+}
+
+// DeploySolChainContracts deploys the CCIP programs to the Solana chains, initializes the state accounts
+// of the router and the fee quoter, and creates the lookup table of their accounts. It is idempotent:
+// the programs already deployed are only upgraded if the config says so, and initialized accounts are skipped.
+// Like DeployChainContracts, it returns the new addresses along with the error so that a failed
+// deployment can be retried.
+func DeploySolChainContracts(e deployment.Environment, c DeploySolChainContractsConfig) (deployment.ChangesetOutput, error) {
+	if err := c.Validate(e); err != nil {
+		return deployment.ChangesetOutput{}, fmt.Errorf("invalid DeploySolChainContractsConfig: %w", err)
+	}
+	state, err := LoadOnchainState(e)
+	if err != nil {
+		return deployment.ChangesetOutput{}, fmt.Errorf("failed to load onchain state: %w", err)
+	}
+	newAddresses := deployment.NewMemoryAddressBook()
+	for _, cs := range c.ChainSelectors {
+		if err := deploySolChainContracts(e, e.SolChains[cs], newAddresses, state.SolChains[cs], c); err != nil {
+			e.Logger.Errorw("Failed to deploy solana chain contracts", "chain", cs, "err", err)
+			return deployment.ChangesetOutput{AddressBook: newAddresses}, fmt.Errorf("failed to deploy chain contracts for solana chain %d: %w", cs, err)
+		}
+	}
+	return deployment.ChangesetOutput{
+		Proposals:   []timelock.MCMSWithTimelockProposal{},
+		AddressBook: newAddresses,
+		JobSpecs:    nil,
+	}, nil
+}
+
+func deploySolChainContracts(
+	e deployment.Environment,
+	chain deployment.SolChain,
+	ab deployment.AddressBook,
+	chainState SolCCIPChainState,
+	c DeploySolChainContractsConfig,
+) error {
+	for _, program := range []struct {
+		id  *solana.PublicKey
+		elf []byte
+		tv  deployment.TypeAndVersion
+	}{
+		{&chainState.Router, c.Programs.Router, deployment.NewTypeAndVersion(SolanaCCIPRouter, deployment.Version1_6_0_dev)},
+		{&chainState.FeeQuoter, c.Programs.FeeQuoter, deployment.NewTypeAndVersion(SolanaFeeQuoter, deployment.Version1_6_0_dev)},
+		{&chainState.TokenPool, c.Programs.TokenPool, deployment.NewTypeAndVersion(SolanaTokenPool, deployment.Version1_6_0_dev)},
+	} {
+		switch {
+		case program.id.IsZero():
+			id, err := deployment.DeploySolProgram(e.Logger, chain, ab, program.elf, program.tv)
+			if err != nil {
+				return err
+			}
+			*program.id = id
+			e.Logger.Infow("Deployed program", "chain", chain.Selector, "program", program.tv, "id", id)
+		case c.Upgrade && len(program.elf) > 0:
+			if err := chain.Client.UpgradeProgram(context.Background(), *program.id, program.elf); err != nil {
+				return fmt.Errorf("failed to upgrade %s: %w", program.tv, err)
+			}
+			e.Logger.Infow("Upgraded program", "chain", chain.Selector, "program", program.tv, "id", *program.id)
+		default:
+			e.Logger.Infow("Program already deployed", "chain", chain.Selector, "program", program.tv, "id", *program.id)
+		}
+	}
+
+	routerConfig, err := SolConfigPDA(chainState.Router)
+	if err != nil {
+		return err
+	}
+	feeQuoterConfig, err := SolConfigPDA(chainState.FeeQuoter)
+	if err != nil {
+		return err
+	}
+	// the onramp of the router is the only one allowed to quote fees
+	if err := initializeSolProgram(e, chain, c.Programs.FeeQuoterIDL, chainState.FeeQuoter, feeQuoterConfig, map[string]any{
+		"router": chainState.Router,
+	}); err != nil {
+		return err
+	}
+	if err := initializeSolProgram(e, chain, c.Programs.RouterIDL, chainState.Router, routerConfig, map[string]any{
+		"svm_chain_selector": chain.Selector,
+		"fee_quoter":         chainState.FeeQuoter,
+	}); err != nil {
+		return err
+	}
+
+	if !chainState.LookupTable.IsZero() {
+		return extendSolLookupTable(chain, chainState.LookupTable, []solana.PublicKey{
+			chainState.Router, routerConfig, chainState.FeeQuoter, feeQuoterConfig, chainState.TokenPool,
+		})
+	}
+	table, err := chain.Client.CreateLookupTable(context.Background(), []solana.PublicKey{
+		solana.SystemProgramID, chainState.Router, routerConfig, chainState.FeeQuoter, feeQuoterConfig, chainState.TokenPool,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create lookup table: %w", err)
+	}
+	if err := ab.Save(chain.Selector, table.String(), deployment.NewTypeAndVersion(SolanaLookupTable, deployment.Version1_6_0_dev)); err != nil {
+		return err
+	}
+	e.Logger.Infow("Created lookup table", "chain", chain.Selector, "table", table)
+	return nil
+}
+
+// initializeSolProgram initializes the state account of the program with the initialize instruction of its IDL,
+// unless it is already initialized.
+func initializeSolProgram(
+	e deployment.Environment,
+	chain deployment.SolChain,
+	idl *deployment.AnchorIDL,
+	programID solana.PublicKey,
+	config solana.PublicKey,
+	args map[string]any,
+) error {
+	exists, err := deployment.SolAccountExists(chain, config)
+	if err != nil || exists {
+		return err
+	}
+	ix, err := idl.Instruction(programID, "initialize", map[string]solana.PublicKey{
+		"config":         config,
+		"authority":      chain.DeployerKey,
+		"system_program": solana.SystemProgramID,
+	}, args)
+	if err != nil {
+		return fmt.Errorf("failed to encode initialize of program %s: %w", programID, err)
+	}
+	if err := deployment.SendSolInstructions(chain, ix); err != nil {
+		return fmt.Errorf("failed to initialize program %s: %w", programID, err)
+	}
+	e.Logger.Infow("Initialized program", "chain", chain.Selector, "program", programID, "config", config)
+	return nil
+}
+
+// extendSolLookupTable appends the addresses missing from the lookup table.
+func extendSolLookupTable(chain deployment.SolChain, table solana.PublicKey, addresses []solana.PublicKey) error {
+	existing, err := chain.Client.GetLookupTable(context.Background(), table)
+	if err != nil {
+		return fmt.Errorf("failed to get lookup table %s: %w", table, err)
+	}
+	inTable := make(map[solana.PublicKey]bool)
+	for _, addr := range existing {
+		inTable[addr] = true
+	}
+	var missing []solana.PublicKey
+	for _, addr := range addresses {
+		if !inTable[addr] {
+			inTable[addr] = true
+			missing = append(missing, addr)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	if err := chain.Client.ExtendLookupTable(context.Background(), table, missing); err != nil {
+		return fmt.Errorf("failed to extend lookup table %s: %w", table, err)
+	}
+	return nil
+}
+
+// SolRemotePool is the pool of a token on a remote chain.
+type SolRemotePool struct {
+	// PoolAddress and TokenAddress are the family specific encoding of the addresses, e.g. 20 bytes on EVM chains.
+	PoolAddress  []byte
+	TokenAddress []byte
+}
+
+// SolTokenPool is the pool of an SPL token.
+type SolTokenPool struct {
+	Mint solana.PublicKey
+	// RemoteChains are the pools of the token on the chains it can be transferred to and from.
+	RemoteChains map[uint64]SolRemotePool
+}
+
+type SolTokenPoolsConfig struct {
+	// Pools are the pools to configure, by solana chain.
+	Pools map[uint64][]SolTokenPool
+	// TokenPoolIDL is the IDL of the deployed token pool program, see SolPrograms.
+	TokenPoolIDL *deployment.AnchorIDL
+}
+
+func (c SolTokenPoolsConfig) Validate(e deployment.Environment, state CCIPOnChainState) error {
+	if len(c.Pools) == 0 {
+		return fmt.Errorf("no pools to configure")
+	}
+	if c.TokenPoolIDL == nil {
+		return fmt.Errorf("IDL of the token pool program is required")
+	}
+	for cs, pools := range c.Pools {
+		if _, ok := e.SolChains[cs]; !ok {
+			return fmt.Errorf("solana chain %d not found in environment", cs)
+		}
+		chainState := state.SolChains[cs]
+		if chainState.TokenPool.IsZero() || chainState.Router.IsZero() || chainState.LookupTable.IsZero() {
+			return fmt.Errorf("CCIP programs not deployed on solana chain %d", cs)
+		}
+		for _, pool := range pools {
+			if pool.Mint.IsZero() {
+				return fmt.Errorf("mint of a pool on solana chain %d is not set", cs)
+			}
+			for remote, remotePool := range pool.RemoteChains {
+				if remote == cs {
+					return fmt.Errorf("pool of mint %s on solana chain %d cannot have its own chain as remote", pool.Mint, cs)
+				}
+				if len(remotePool.PoolAddress) == 0 || len(remotePool.TokenAddress) == 0 {
+					return fmt.Errorf("remote pool of mint %s on chain %d is not set", pool.Mint, remote)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// ConfigureSolTokenPools initializes the pools of SPL tokens with the token pool program, sets their remote
+// chains, and adds their accounts to the lookup table of the chain. Pools already initialized are only updated.
+func ConfigureSolTokenPools(e deployment.Environment, c SolTokenPoolsConfig) (deployment.ChangesetOutput, error) {
+	state, err := LoadOnchainState(e)
+	if err != nil {
+		return deployment.ChangesetOutput{}, fmt.Errorf("failed to load onchain state: %w", err)
+	}
+	if err := c.Validate(e, state); err != nil {
+		return deployment.ChangesetOutput{}, fmt.Errorf("invalid SolTokenPoolsConfig: %w", err)
+	}
+	for cs, pools := range c.Pools {
+		chain, chainState := e.SolChains[cs], state.SolChains[cs]
+		for _, pool := range pools {
+			accounts, err := configureSolTokenPool(e, chain, c.TokenPoolIDL, chainState, pool)
+			if err != nil {
+				return deployment.ChangesetOutput{}, fmt.Errorf("failed to configure pool of mint %s on solana chain %d: %w", pool.Mint, cs, err)
+			}
+			if err := extendSolLookupTable(chain, chainState.LookupTable, accounts); err != nil {
+				return deployment.ChangesetOutput{}, err
+			}
+		}
+	}
+	return deployment.ChangesetOutput{}, nil
+}
+
+// configureSolTokenPool configures the pool and returns its accounts.
+func configureSolTokenPool(
+	e deployment.Environment,
+	chain deployment.SolChain,
+	idl *deployment.AnchorIDL,
+	chainState SolCCIPChainState,
+	pool SolTokenPool,
+) ([]solana.PublicKey, error) {
+	poolConfig, err := SolTokenPoolConfigPDA(chainState.TokenPool, pool.Mint)
+	if err != nil {
+		return nil, err
+	}
+	exists, err := deployment.SolAccountExists(chain, poolConfig)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		ix, err := idl.Instruction(chainState.TokenPool, "initialize", map[string]solana.PublicKey{
+			"config":         poolConfig,
+			"mint":           pool.Mint,
+			"authority":      chain.DeployerKey,
+			"system_program": solana.SystemProgramID,
+		}, map[string]any{"router": chainState.Router})
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode initialize of token pool: %w", err)
+		}
+		if err := deployment.SendSolInstructions(chain, ix); err != nil {
+			return nil, err
+		}
+		e.Logger.Infow("Initialized token pool", "chain", chain.Selector, "mint", pool.Mint, "config", poolConfig)
+	}
+	accounts := []solana.PublicKey{pool.Mint, poolConfig}
+	var instructions []solana.Instruction
+	for remote, remotePool := range pool.RemoteChains {
+		chainConfig, err := SolTokenPoolChainConfigPDA(chainState.TokenPool, pool.Mint, remote)
+		if err != nil {
+			return nil, err
+		}
+		ix, err := idl.Instruction(chainState.TokenPool, "set_chain_remote_config", map[string]solana.PublicKey{
+			"config":         poolConfig,
+			"chain_config":   chainConfig,
+			"authority":      chain.DeployerKey,
+			"system_program": solana.SystemProgramID,
+		}, map[string]any{
+			"remote_chain_selector": remote,
+			"mint":                  pool.Mint,
+			"cfg": map[string]any{
+				"pool_address":  remotePool.PoolAddress,
+				"token_address": remotePool.TokenAddress,
+			},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode set_chain_remote_config of chain %d: %w", remote, err)
+		}
+		instructions = append(instructions, ix)
+		accounts = append(accounts, chainConfig)
+	}
+	if len(instructions) > 0 {
+		if err := deployment.SendSolInstructions(chain, instructions...); err != nil {
+			return nil, err
+		}
+		e.Logger.Infow("Set remote chains of token pool", "chain", chain.Selector, "mint", pool.Mint, "remotes", len(instructions))
+	}
+	return accounts, nil
+}
@@ -0,0 +1,153 @@
+package changeset
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/exp/maps"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/deployment/environment/memory"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+func TestDeploySolChainContracts(t *testing.T) {
+	lggr := logger.TestLogger(t)
+	solChains := memory.NewMemorySolChains(t, 1)
+	e := deployment.Environment{
+		Logger:            lggr,
+		ExistingAddresses: deployment.NewMemoryAddressBook(),
+		SolChains:         solChains,
+	}
+	sel := maps.Keys(solChains)[0]
+	sim, ok := memory.AsSimSolClient(solChains[sel])
+	require.True(t, ok)
+
+	programs := testSolPrograms(t)
+	programs.Router, programs.FeeQuoter, programs.TokenPool = []byte("router"), []byte("fee quoter"), []byte("pool")
+	sim.ExpectIDL(programs.Router, programs.RouterIDL)
+	sim.ExpectIDL(programs.FeeQuoter, programs.FeeQuoterIDL)
+	sim.ExpectIDL(programs.TokenPool, programs.TokenPoolIDL)
+	cfg := DeploySolChainContractsConfig{
+		ChainSelectors: []uint64{sel},
+		Programs:       programs,
+	}
+	out, err := DeploySolChainContracts(e, cfg)
+	require.NoError(t, err)
+	require.NoError(t, e.ExistingAddresses.Merge(out.AddressBook))
+	state, err := LoadOnchainState(e)
+	require.NoError(t, err)
+	chainState := state.SolChains[sel]
+	require.Equal(t, []byte("router"), sim.Program(chainState.Router))
+	require.Len(t, sim.Instructions(chainState.Router), 1)
+	require.Len(t, sim.Instructions(chainState.FeeQuoter), 1)
+	data, err := sim.Instructions(chainState.Router)[0].Data()
+	require.NoError(t, err)
+	name, args, err := programs.RouterIDL.DecodeInstruction(data)
+	require.NoError(t, err)
+	require.Equal(t, "initialize", name)
+	require.Equal(t, map[string]any{"svm_chain_selector": sel, "fee_quoter": chainState.FeeQuoter}, args)
+	table, err := sim.GetLookupTable(context.Background(), chainState.LookupTable)
+	require.NoError(t, err)
+	require.Contains(t, table, chainState.TokenPool)
+
+	// re-running only upgrades the programs
+	cfg.Programs.Router = []byte("router v2")
+	sim.ExpectIDL(cfg.Programs.Router, programs.RouterIDL)
+	cfg.Upgrade = true
+	out, err = DeploySolChainContracts(e, cfg)
+	require.NoError(t, err)
+	addresses, err := out.AddressBook.Addresses()
+	require.NoError(t, err)
+	require.Empty(t, addresses)
+	require.Equal(t, []byte("router v2"), sim.Program(chainState.Router))
+	require.Len(t, sim.Instructions(chainState.Router), 1)
+
+	mint := solana.PublicKey{1}
+	poolsCfg := SolTokenPoolsConfig{Pools: map[uint64][]SolTokenPool{
+		sel: {{Mint: mint, RemoteChains: map[uint64]SolRemotePool{sel: {PoolAddress: []byte{1}, TokenAddress: []byte{2}}}}},
+	}, TokenPoolIDL: programs.TokenPoolIDL}
+	_, err = ConfigureSolTokenPools(e, poolsCfg)
+	require.ErrorContains(t, err, "its own chain as remote")
+
+	remote := uint64(1)
+	poolsCfg.Pools[sel][0].RemoteChains = map[uint64]SolRemotePool{remote: {PoolAddress: []byte{1}, TokenAddress: []byte{2}}}
+	_, err = ConfigureSolTokenPools(e, poolsCfg)
+	require.NoError(t, err)
+	_, err = ConfigureSolTokenPools(e, poolsCfg)
+	require.NoError(t, err)
+	// the pool is initialized once, its remote chains are set every time
+	require.Len(t, sim.Instructions(chainState.TokenPool), 3)
+
+	poolConfig, err := SolTokenPoolConfigPDA(chainState.TokenPool, mint)
+	require.NoError(t, err)
+	chainConfig, err := SolTokenPoolChainConfigPDA(chainState.TokenPool, mint, remote)
+	require.NoError(t, err)
+	table, err = sim.GetLookupTable(context.Background(), chainState.LookupTable)
+	require.NoError(t, err)
+	require.Subset(t, table, []solana.PublicKey{mint, poolConfig, chainConfig})
+	require.Len(t, table, 9)
+}
+
+// TestDeploySolChainContractsLocalnet deploys the programs built from the chainlink-ccip Solana contracts to
+// a solana-test-validator, which executes the instructions of the changeset.
+func TestDeploySolChainContractsLocalnet(t *testing.T) {
+	targetDir := os.Getenv(solTargetDirEnv)
+	if testing.Short() || targetDir == "" {
+		t.Skipf("set %s to the target directory of the chainlink-ccip Solana contracts built with anchor", solTargetDirEnv)
+	}
+	programs, err := LoadSolPrograms(targetDir)
+	require.NoError(t, err)
+	chain := memory.NewSolanaLocalnetChain(t)
+	e := deployment.Environment{
+		Logger:            logger.TestLogger(t),
+		ExistingAddresses: deployment.NewMemoryAddressBook(),
+		SolChains:         map[uint64]deployment.SolChain{chain.Selector: chain},
+	}
+	cfg := DeploySolChainContractsConfig{ChainSelectors: []uint64{chain.Selector}, Programs: programs}
+	out, err := DeploySolChainContracts(e, cfg)
+	require.NoError(t, err)
+	require.NoError(t, e.ExistingAddresses.Merge(out.AddressBook))
+	state, err := LoadOnchainState(e)
+	require.NoError(t, err)
+	chainState := state.SolChains[chain.Selector]
+	for _, program := range []solana.PublicKey{chainState.Router, chainState.FeeQuoter} {
+		config, err := SolConfigPDA(program)
+		require.NoError(t, err)
+		exists, err := deployment.SolAccountExists(chain, config)
+		require.NoError(t, err)
+		require.True(t, exists, "program %s not initialized", program)
+	}
+	table, err := chain.Client.GetLookupTable(context.Background(), chainState.LookupTable)
+	require.NoError(t, err)
+	require.Contains(t, table, chainState.TokenPool)
+
+	// re-running upgrades the programs and skips the initialized accounts
+	cfg.Upgrade = true
+	_, err = DeploySolChainContracts(e, cfg)
+	require.NoError(t, err)
+}
+
+// solTargetDirEnv is the target directory of the chainlink-ccip Solana contracts TestDeploySolChainContractsLocalnet
+// loads the programs from, see LoadSolPrograms.
+const solTargetDirEnv = "CCIP_SOLANA_TARGET_DIR"
+
+// testSolPrograms returns programs with the IDLs of testdata/solana, which have the instructions of the changesets.
+func testSolPrograms(t *testing.T) SolPrograms {
+	var programs SolPrograms
+	for name, idl := range map[string]**deployment.AnchorIDL{
+		solRouterProgram:    &programs.RouterIDL,
+		solFeeQuoterProgram: &programs.FeeQuoterIDL,
+		solTokenPoolProgram: &programs.TokenPoolIDL,
+	} {
+		b, err := os.ReadFile(filepath.Join("testdata", "solana", "idl", name+".json"))
+		require.NoError(t, err)
+		*idl, err = deployment.ParseAnchorIDL(b)
+		require.NoError(t, err)
+	}
+	return programs
+}
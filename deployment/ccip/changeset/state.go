@@ -10,7 +10,6 @@ import (
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/pkg/errors"
-	chainsel "github.com/smartcontractkit/chain-selectors"
 
 	"github.com/smartcontractkit/chainlink/deployment"
 	"github.com/smartcontractkit/chainlink/deployment/ccip/view"
@@ -23,6 +22,8 @@ import (
 	common_v1_0 "github.com/smartcontractkit/chainlink/deployment/common/view/v1_0"
 	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/ccip_config"
 	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/commit_store"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/evm_2_evm_offramp"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/evm_2_evm_onramp"
 	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/mock_rmn_contract"
 	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/registry_module_owner_custom"
 	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/rmn_home"
@@ -37,6 +38,7 @@ import (
 	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/nonce_manager"
 	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/offramp"
 	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/onramp"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/price_registry_1_2_0"
 	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/rmn_proxy_contract"
 	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/rmn_remote"
 	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/router"
@@ -45,13 +47,23 @@ import (
 	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/generated/aggregator_v3_interface"
 )
 
+//go:generate go run ./internal/accessorgen -in state.go -out state_accessors.go
+
 // CCIPChainState holds a Go binding for all the currently deployed CCIP contracts
 // on a chain. If a binding is nil, it means here is no such contract on the chain.
+// Prefer the typed accessors of CCIPOnChainState, e.g. TryGetRouter, over nil checks of the fields.
+// New contract fields need a type and version in internal/accessorgen to regenerate them.
 type CCIPChainState struct {
 	commoncs.MCMSWithTimelockState
 	OnRamp    *onramp.OnRamp
 	OffRamp   *offramp.OffRamp
 	FeeQuoter *fee_quoter.FeeQuoter
+	// OnRamps, OffRamps and FeeQuoters are all the 1.6 versions of the contracts deployed on the chain, e.g. when a
+	// new version is rolled out next to the current one. OnRamp, OffRamp and FeeQuoter are their 1.6.0-dev versions.
+	// The 1.5 ramps are EVM2EVMOnRamp and EVM2EVMOffRamp.
+	OnRamps    ContractVersions[*onramp.OnRamp]
+	OffRamps   ContractVersions[*offramp.OffRamp]
+	FeeQuoters ContractVersions[*fee_quoter.FeeQuoter]
 	// We need 2 RMNProxy contracts because we are in the process of migrating to a new version.
 	// We will switch to the existing one once the migration is complete.
 	// This is the new RMNProxy contract that will be used for testing RMNRemote before migration.
@@ -87,6 +99,14 @@ type CCIPChainState struct {
 	// TODO remove once staging upgraded.
 	CCIPConfig *ccip_config.CCIPConfig
 
+	// CCIP 1.5 lane contracts, see DeployLegacyLanes.
+	PriceRegistry *price_registry_1_2_0.PriceRegistry
+	// EVM2EVMOnRamp maps destination chain selectors to the 1.5 onramp of the lane.
+	EVM2EVMOnRamp map[uint64]*evm_2_evm_onramp.EVM2EVMOnRamp
+	// EVM2EVMOffRamp and CommitStores map source chain selectors to the 1.5 offramp and commit store of the lane.
+	EVM2EVMOffRamp map[uint64]*evm_2_evm_offramp.EVM2EVMOffRamp
+	CommitStores   map[uint64]*commit_store.CommitStore
+
 	// Test contracts
 	Receiver               *maybe_revert_message_receiver.MaybeRevertMessageReceiver
 	TestRouter             *router.Router
@@ -133,6 +153,16 @@ func (c CCIPChainState) GenerateView() (view.ChainView, error) {
 		}
 		chainView.FeeQuoter[c.FeeQuoter.Address().Hex()] = fqView
 	}
+	for _, fq := range c.FeeQuoters {
+		if fq == c.FeeQuoter || c.Router == nil || c.TokenAdminRegistry == nil {
+			continue
+		}
+		fqView, err := v1_6.GenerateFeeQuoterView(fq, c.Router, c.TokenAdminRegistry)
+		if err != nil {
+			return chainView, err
+		}
+		chainView.FeeQuoter[fq.Address().Hex()] = fqView
+	}
 
 	if c.OnRamp != nil && c.Router != nil && c.TokenAdminRegistry != nil {
 		onRampView, err := v1_6.GenerateOnRampView(
@@ -145,6 +175,16 @@ func (c CCIPChainState) GenerateView() (view.ChainView, error) {
 		}
 		chainView.OnRamp[c.OnRamp.Address().Hex()] = onRampView
 	}
+	for _, onRamp := range c.OnRamps {
+		if onRamp == c.OnRamp || c.Router == nil || c.TokenAdminRegistry == nil {
+			continue
+		}
+		onRampView, err := v1_6.GenerateOnRampView(onRamp, c.Router, c.TokenAdminRegistry)
+		if err != nil {
+			return chainView, err
+		}
+		chainView.OnRamp[onRamp.Address().Hex()] = onRampView
+	}
 
 	if c.OffRamp != nil && c.Router != nil {
 		offRampView, err := v1_6.GenerateOffRampView(
@@ -156,6 +196,16 @@ func (c CCIPChainState) GenerateView() (view.ChainView, error) {
 		}
 		chainView.OffRamp[c.OffRamp.Address().Hex()] = offRampView
 	}
+	for _, offRamp := range c.OffRamps {
+		if offRamp == c.OffRamp || c.Router == nil {
+			continue
+		}
+		offRampView, err := v1_6.GenerateOffRampView(offRamp, c.Router)
+		if err != nil {
+			return chainView, err
+		}
+		chainView.OffRamp[offRamp.Address().Hex()] = offRampView
+	}
 
 	if c.CommitStore != nil {
 		commitStoreView, err := v1_5.GenerateCommitStoreView(c.CommitStore)
@@ -165,6 +215,17 @@ func (c CCIPChainState) GenerateView() (view.ChainView, error) {
 		chainView.CommitStore[c.CommitStore.Address().Hex()] = commitStoreView
 	}
 
+	for _, commitStore := range c.CommitStores {
+		if _, ok := chainView.CommitStore[commitStore.Address().Hex()]; ok {
+			continue
+		}
+		commitStoreView, err := v1_5.GenerateCommitStoreView(commitStore)
+		if err != nil {
+			return chainView, err
+		}
+		chainView.CommitStore[commitStore.Address().Hex()] = commitStoreView
+	}
+
 	if c.RMNProxyNew != nil {
 		rmnProxyView, err := v1_0.GenerateRMNProxyView(c.RMNProxyNew)
 		if err != nil {
@@ -179,6 +240,13 @@ func (c CCIPChainState) GenerateView() (view.ChainView, error) {
 		}
 		chainView.CapabilityRegistry[c.CapabilityRegistry.Address().Hex()] = capRegView
 	}
+	if c.CapabilityRegistry != nil && c.CCIPHome != nil {
+		ccipHomeView, err := v1_6.GenerateCCIPHomeView(c.CapabilityRegistry, c.CCIPHome)
+		if err != nil {
+			return chainView, err
+		}
+		chainView.CCIPHome[c.CCIPHome.Address().Hex()] = ccipHomeView
+	}
 	if c.MCMSWithTimelockState.Timelock != nil {
 		mcmsView, err := c.MCMSWithTimelockState.GenerateMCMSWithTimelockView()
 		if err != nil {
@@ -197,17 +265,16 @@ type CCIPOnChainState struct {
 	// We would hold 2 versions of each contract here. Once we upgrade we can phase out the old one.
 	// When generating bindings, make sure the package name corresponds to the version.
 	Chains map[uint64]CCIPChainState
+	// AptosChains are the states of the Aptos chains of the environment.
+	AptosChains map[uint64]AptosCCIPChainState
+	// SolChains are the states of the Solana chains of the environment.
+	SolChains map[uint64]SolCCIPChainState
 }
 
 func (s CCIPOnChainState) View(chains []uint64) (map[string]view.ChainView, error) {
 	m := make(map[string]view.ChainView)
 	for _, chainSelector := range chains {
-		// TODO: Need a utility for this
-		chainid, err := chainsel.ChainIdFromSelector(chainSelector)
-		if err != nil {
-			return m, err
-		}
-		chainName, err := chainsel.NameFromChainId(chainid)
+		chainName, err := deployment.ChainName(chainSelector)
 		if err != nil {
 			return m, err
 		}
@@ -224,9 +291,18 @@ func (s CCIPOnChainState) View(chains []uint64) (map[string]view.ChainView, erro
 	return m, nil
 }
 
-func LoadOnchainState(e deployment.Environment) (CCIPOnChainState, error) {
+// LoadOnchainState loads the bindings of the contracts in the address book of the environment. With
+// WithBytecodeVerification, the state is returned along with a *BytecodeMismatchError if contracts don't
+// match their artifacts.
+func LoadOnchainState(e deployment.Environment, opts ...LoadOnchainStateOpt) (CCIPOnChainState, error) {
+	var o LoadOnchainStateOpts
+	for _, opt := range opts {
+		opt(&o)
+	}
 	state := CCIPOnChainState{
-		Chains: make(map[uint64]CCIPChainState),
+		Chains:      make(map[uint64]CCIPChainState),
+		AptosChains: make(map[uint64]AptosCCIPChainState),
+		SolChains:   make(map[uint64]SolCCIPChainState),
 	}
 	for chainSelector, chain := range e.Chains {
 		addresses, err := e.ExistingAddresses.AddressesForChain(chainSelector)
@@ -238,15 +314,72 @@ func LoadOnchainState(e deployment.Environment) (CCIPOnChainState, error) {
 				return state, err
 			}
 		}
-		chainState, err := LoadChainState(chain, addresses)
+		chain := chain
+		chainState, err := e.StateCache.LoadChain("ccip", chainSelector, addresses, func() (any, error) {
+			return LoadChainState(chain, addresses)
+		})
 		if err != nil {
 			return state, err
 		}
-		state.Chains[chainSelector] = chainState
+		state.Chains[chainSelector] = chainState.(CCIPChainState)
+	}
+	for chainSelector := range e.AptosChains {
+		addresses, err := e.ExistingAddresses.AddressesForChain(chainSelector)
+		if err != nil && !errors.Is(err, deployment.ErrChainNotFound) {
+			return state, err
+		}
+		chainState, err := LoadAptosChainState(addresses)
+		if err != nil {
+			return state, err
+		}
+		state.AptosChains[chainSelector] = chainState
+	}
+	for chainSelector := range e.SolChains {
+		addresses, err := e.ExistingAddresses.AddressesForChain(chainSelector)
+		if err != nil && !errors.Is(err, deployment.ErrChainNotFound) {
+			return state, err
+		}
+		chainState, err := LoadSolChainState(addresses)
+		if err != nil {
+			return state, err
+		}
+		state.SolChains[chainSelector] = chainState
+	}
+	if o.ExpectedBytecode != nil {
+		if err := verifyBytecode(e, o.ExpectedBytecode); err != nil {
+			return state, err
+		}
 	}
 	return state, nil
 }
 
+// ContractNotFoundError is returned by the accessors of CCIPOnChainState for contracts not deployed on a chain.
+type ContractNotFoundError struct {
+	ChainSelector  uint64
+	TypeAndVersion deployment.TypeAndVersion
+}
+
+func (e *ContractNotFoundError) Error() string {
+	return fmt.Sprintf("%s not found on chain %d", e.TypeAndVersion, e.ChainSelector)
+}
+
+// UnknownContractError is returned when resolving a type and version which is not bound to any field of CCIPChainState.
+type UnknownContractError struct {
+	TypeAndVersion deployment.TypeAndVersion
+}
+
+func (e *UnknownContractError) Error() string {
+	return fmt.Sprintf("unknown contract %s", e.TypeAndVersion)
+}
+
+func (s CCIPOnChainState) chainState(chainSelector uint64) (CCIPChainState, error) {
+	chainState, ok := s.Chains[chainSelector]
+	if !ok {
+		return CCIPChainState{}, fmt.Errorf("%w: %d", deployment.ErrChainNotFound, chainSelector)
+	}
+	return chainState, nil
+}
+
 // LoadChainState Loads all state for a chain into state
 func LoadChainState(chain deployment.Chain, addresses map[string]deployment.TypeAndVersion) (CCIPChainState, error) {
 	var state CCIPChainState
@@ -256,6 +389,12 @@ func LoadChainState(chain deployment.Chain, addresses map[string]deployment.Type
 	}
 	state.MCMSWithTimelockState = *mcmsWithTimelock
 	for address, tvStr := range addresses {
+		if isVersionedContract(tvStr.Type) {
+			if err := state.loadContractVersion(chain, common.HexToAddress(address), tvStr); err != nil {
+				return state, err
+			}
+			continue
+		}
 		switch tvStr.String() {
 		case deployment.NewTypeAndVersion(commontypes.RBACTimelock, deployment.Version1_0_0).String(),
 			deployment.NewTypeAndVersion(commontypes.ProposerManyChainMultisig, deployment.Version1_0_0).String(),
@@ -268,18 +407,6 @@ func LoadChainState(chain deployment.Chain, addresses map[string]deployment.Type
 				return state, err
 			}
 			state.CapabilityRegistry = cr
-		case deployment.NewTypeAndVersion(OnRamp, deployment.Version1_6_0_dev).String():
-			onRampC, err := onramp.NewOnRamp(common.HexToAddress(address), chain.Client)
-			if err != nil {
-				return state, err
-			}
-			state.OnRamp = onRampC
-		case deployment.NewTypeAndVersion(OffRamp, deployment.Version1_6_0_dev).String():
-			offRamp, err := offramp.NewOffRamp(common.HexToAddress(address), chain.Client)
-			if err != nil {
-				return state, err
-			}
-			state.OffRamp = offRamp
 		case deployment.NewTypeAndVersion(ARMProxy, deployment.Version1_0_0).String():
 			armProxy, err := rmn_proxy_contract.NewRMNProxyContract(common.HexToAddress(address), chain.Client)
 			if err != nil {
@@ -334,6 +461,20 @@ func LoadChainState(chain deployment.Chain, addresses map[string]deployment.Type
 				return state, err
 			}
 			state.CommitStore = cs
+			sCfg, err := cs.GetStaticConfig(nil)
+			if err != nil {
+				return state, err
+			}
+			if state.CommitStores == nil {
+				state.CommitStores = make(map[uint64]*commit_store.CommitStore)
+			}
+			state.CommitStores[sCfg.SourceChainSelector] = cs
+		case deployment.NewTypeAndVersion(PriceRegistry, deployment.Version1_2_0).String():
+			pr, err := price_registry_1_2_0.NewPriceRegistry(common.HexToAddress(address), chain.Client)
+			if err != nil {
+				return state, err
+			}
+			state.PriceRegistry = pr
 		case deployment.NewTypeAndVersion(TokenAdminRegistry, deployment.Version1_5_0).String():
 			tm, err := token_admin_registry.NewTokenAdminRegistry(common.HexToAddress(address), chain.Client)
 			if err != nil {
@@ -358,12 +499,6 @@ func LoadChainState(chain deployment.Chain, addresses map[string]deployment.Type
 				return state, err
 			}
 			state.TestRouter = r
-		case deployment.NewTypeAndVersion(FeeQuoter, deployment.Version1_6_0_dev).String():
-			fq, err := fee_quoter.NewFeeQuoter(common.HexToAddress(address), chain.Client)
-			if err != nil {
-				return state, err
-			}
-			state.FeeQuoter = fq
 		case deployment.NewTypeAndVersion(LinkToken, deployment.Version1_0_0).String():
 			lt, err := burn_mint_erc677.NewBurnMintERC677(common.HexToAddress(address), chain.Client)
 			if err != nil {
@@ -444,3 +579,20 @@ func LoadChainState(chain deployment.Chain, addresses map[string]deployment.Type
 	}
 	return state, nil
 }
+
+const (
+	// MockLinkAggregatorDescription This is the description of the MockV3Aggregator.sol contract
+	// nolint:lll
+	// https://github.com/smartcontractkit/chainlink/blob/a348b98e90527520049c580000a86fb8ceff7fa7/contracts/src/v0.8/tests/MockV3Aggregator.sol#L76-L76
+	MockLinkAggregatorDescription = "v0.8/tests/MockV3Aggregator.sol"
+	// MockWETHAggregatorDescription WETH use description from MockETHUSDAggregator.sol
+	// nolint:lll
+	// https://github.com/smartcontractkit/chainlink/blob/a348b98e90527520049c580000a86fb8ceff7fa7/contracts/src/v0.8/automation/testhelpers/MockETHUSDAggregator.sol#L19-L19
+	MockWETHAggregatorDescription = "MockETHUSDAggregator"
+)
+
+// MockDescriptionToTokenSymbol maps a mock feed description to token descriptor
+var MockDescriptionToTokenSymbol = map[string]TokenSymbol{
+	MockLinkAggregatorDescription: LinkSymbol,
+	MockWETHAggregatorDescription: WethSymbol,
+}
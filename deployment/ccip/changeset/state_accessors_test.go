@@ -0,0 +1,44 @@
+package changeset
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	chainsel "github.com/smartcontractkit/chain-selectors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/router"
+)
+
+func TestStateAccessors(t *testing.T) {
+	chainSel := chainsel.TEST_90000001.Selector
+	r, err := router.NewRouter(common.HexToAddress("0x1"), nil)
+	require.NoError(t, err)
+	state := CCIPOnChainState{Chains: map[uint64]CCIPChainState{
+		chainSel: {Router: r},
+	}}
+
+	got, err := state.TryGetRouter(chainSel)
+	require.NoError(t, err)
+	require.Equal(t, r, got)
+	require.Equal(t, r, state.MustGetRouter(chainSel))
+
+	_, err = state.TryGetTestRouter(chainSel)
+	require.EqualError(t, err, "TestRouter 1.2.0 not found on chain 909606746561742123")
+	var notFound *ContractNotFoundError
+	require.True(t, errors.As(err, &notFound))
+	require.Panics(t, func() { state.MustGetTestRouter(chainSel) })
+
+	_, err = state.TryGetRouter(chainsel.TEST_90000002.Selector)
+	require.ErrorIs(t, err, deployment.ErrChainNotFound)
+
+	got, err = state.TryGetRouterByTypeAndVersion(chainSel, deployment.NewTypeAndVersion(Router, deployment.Version1_2_0))
+	require.NoError(t, err)
+	require.Equal(t, r, got)
+	_, err = state.TryGetRouterByTypeAndVersion(chainSel, deployment.NewTypeAndVersion(TestRouter, deployment.Version1_2_0))
+	require.True(t, errors.As(err, &notFound))
+	_, err = state.TryGetRouterByTypeAndVersion(chainSel, deployment.NewTypeAndVersion(Router, deployment.Version1_6_0_dev))
+	require.EqualError(t, err, "unknown contract Router 1.6.0-dev")
+}
@@ -0,0 +1,207 @@
+package changeset
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/evm_2_evm_offramp"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/evm_2_evm_onramp"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/fee_quoter"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/offramp"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/onramp"
+)
+
+// ContractVersions are the bindings of the versions of a contract type deployed on a chain at the same time,
+// e.g. while lanes are upgraded, by version. Use Get rather than indexing, which compares the versions as written.
+type ContractVersions[C any] map[semver.Version]C
+
+// normalizedVersion drops the original formatting of the version, e.g. a v prefix, so that equal versions are
+// the same map key.
+func normalizedVersion(v semver.Version) semver.Version {
+	return *semver.New(v.Major(), v.Minor(), v.Patch(), v.Prerelease(), v.Metadata())
+}
+
+// Get returns the binding of the version.
+func (v ContractVersions[C]) Get(version semver.Version) (C, bool) {
+	c, ok := v[normalizedVersion(version)]
+	return c, ok
+}
+
+// Versions returns the deployed versions, in ascending order.
+func (v ContractVersions[C]) Versions() []semver.Version {
+	versions := make([]semver.Version, 0, len(v))
+	for version := range v {
+		versions = append(versions, version)
+	}
+	sort.Slice(versions, func(i, j int) bool { return versions[i].LessThan(&versions[j]) })
+	return versions
+}
+
+// Latest returns the binding of the highest version and the version, false if no version is deployed.
+func (v ContractVersions[C]) Latest() (C, semver.Version, bool) {
+	versions := v.Versions()
+	if len(versions) == 0 {
+		var c C
+		return c, semver.Version{}, false
+	}
+	latest := versions[len(versions)-1]
+	return v[latest], latest, true
+}
+
+// addContractVersion binds the contract to its version. A contract redeployed with the same version replaces the
+// previous one, like the single contract fields of the state.
+func addContractVersion[C any](versions *ContractVersions[C], tv deployment.TypeAndVersion, c C) {
+	if *versions == nil {
+		*versions = make(ContractVersions[C])
+	}
+	(*versions)[normalizedVersion(tv.Version)] = c
+}
+
+// isVersion1_5 and isVersion1_6 tell the generation of a ramp, which is bound with the wrappers of its own
+// generation as their ABIs differ.
+func isVersion1_5(v semver.Version) bool { return v.Major() == 1 && v.Minor() == 5 }
+func isVersion1_6(v semver.Version) bool { return v.Major() == 1 && v.Minor() == 6 }
+
+// loadContractVersion binds the contract of the versioned type at the address with the wrapper of its version.
+// The 1.6 versions also set the field of the contract for the 1.6.0-dev version, the 1.5 ramps are bound per lane.
+func (c *CCIPChainState) loadContractVersion(chain deployment.Chain, address common.Address, tv deployment.TypeAndVersion) error {
+	current := tv.Version.Equal(&deployment.Version1_6_0_dev)
+	switch {
+	case (tv.Type == OnRamp || tv.Type == EVM2EVMOnRamp) && isVersion1_5(tv.Version):
+		return c.loadEVM2EVMOnRamp(chain, address)
+	case (tv.Type == OffRamp || tv.Type == EVM2EVMOffRamp) && isVersion1_5(tv.Version):
+		return c.loadEVM2EVMOffRamp(chain, address)
+	case tv.Type == OnRamp && isVersion1_6(tv.Version):
+		onRampC, err := onramp.NewOnRamp(address, chain.Client)
+		if err != nil {
+			return err
+		}
+		if current {
+			c.OnRamp = onRampC
+		}
+		addContractVersion(&c.OnRamps, tv, onRampC)
+	case tv.Type == OffRamp && isVersion1_6(tv.Version):
+		offRampC, err := offramp.NewOffRamp(address, chain.Client)
+		if err != nil {
+			return err
+		}
+		if current {
+			c.OffRamp = offRampC
+		}
+		addContractVersion(&c.OffRamps, tv, offRampC)
+	case tv.Type == FeeQuoter && isVersion1_6(tv.Version):
+		fq, err := fee_quoter.NewFeeQuoter(address, chain.Client)
+		if err != nil {
+			return err
+		}
+		if current {
+			c.FeeQuoter = fq
+		}
+		addContractVersion(&c.FeeQuoters, tv, fq)
+	default:
+		return &UnknownContractError{TypeAndVersion: tv}
+	}
+	return nil
+}
+
+// loadEVM2EVMOnRamp binds the 1.5 onramp at the address to the destination chain of its lane.
+func (c *CCIPChainState) loadEVM2EVMOnRamp(chain deployment.Chain, address common.Address) error {
+	onRamp, err := evm_2_evm_onramp.NewEVM2EVMOnRamp(address, chain.Client)
+	if err != nil {
+		return err
+	}
+	sCfg, err := onRamp.GetStaticConfig(nil)
+	if err != nil {
+		return err
+	}
+	if c.EVM2EVMOnRamp == nil {
+		c.EVM2EVMOnRamp = make(map[uint64]*evm_2_evm_onramp.EVM2EVMOnRamp)
+	}
+	c.EVM2EVMOnRamp[sCfg.DestChainSelector] = onRamp
+	return nil
+}
+
+// loadEVM2EVMOffRamp binds the 1.5 offramp at the address to the source chain of its lane.
+func (c *CCIPChainState) loadEVM2EVMOffRamp(chain deployment.Chain, address common.Address) error {
+	offRamp, err := evm_2_evm_offramp.NewEVM2EVMOffRamp(address, chain.Client)
+	if err != nil {
+		return err
+	}
+	sCfg, err := offRamp.GetStaticConfig(nil)
+	if err != nil {
+		return err
+	}
+	if c.EVM2EVMOffRamp == nil {
+		c.EVM2EVMOffRamp = make(map[uint64]*evm_2_evm_offramp.EVM2EVMOffRamp)
+	}
+	c.EVM2EVMOffRamp[sCfg.SourceChainSelector] = offRamp
+	return nil
+}
+
+// isVersionedContract reports whether the contract type is loaded by loadContractVersion.
+func isVersionedContract(contractType deployment.ContractType) bool {
+	return contractType == OnRamp || contractType == OffRamp || contractType == FeeQuoter ||
+		contractType == EVM2EVMOnRamp || contractType == EVM2EVMOffRamp
+}
+
+func tryGetContractVersion[C any](s CCIPOnChainState, chainSelector uint64, contractType deployment.ContractType,
+	versions func(CCIPChainState) ContractVersions[C], version semver.Version) (C, error) {
+	var c C
+	chainState, err := s.chainState(chainSelector)
+	if err != nil {
+		return c, err
+	}
+	c, ok := versions(chainState).Get(version)
+	if !ok {
+		return c, &ContractNotFoundError{ChainSelector: chainSelector, TypeAndVersion: deployment.NewTypeAndVersion(contractType, version)}
+	}
+	return c, nil
+}
+
+func tryGetLatestContract[C any](s CCIPOnChainState, chainSelector uint64, contractType deployment.ContractType,
+	versions func(CCIPChainState) ContractVersions[C]) (C, semver.Version, error) {
+	var c C
+	chainState, err := s.chainState(chainSelector)
+	if err != nil {
+		return c, semver.Version{}, err
+	}
+	c, version, ok := versions(chainState).Latest()
+	if !ok {
+		return c, semver.Version{}, fmt.Errorf("no %s found on chain %d", contractType, chainSelector)
+	}
+	return c, version, nil
+}
+
+// TryGetOnRampByVersion returns the OnRamp of the version of the chain, or an error if it is not deployed.
+func (s CCIPOnChainState) TryGetOnRampByVersion(chainSelector uint64, version semver.Version) (*onramp.OnRamp, error) {
+	return tryGetContractVersion(s, chainSelector, OnRamp, func(c CCIPChainState) ContractVersions[*onramp.OnRamp] { return c.OnRamps }, version)
+}
+
+// TryGetLatestOnRamp returns the OnRamp of the highest version of the chain and its version.
+func (s CCIPOnChainState) TryGetLatestOnRamp(chainSelector uint64) (*onramp.OnRamp, semver.Version, error) {
+	return tryGetLatestContract(s, chainSelector, OnRamp, func(c CCIPChainState) ContractVersions[*onramp.OnRamp] { return c.OnRamps })
+}
+
+// TryGetOffRampByVersion returns the OffRamp of the version of the chain, or an error if it is not deployed.
+func (s CCIPOnChainState) TryGetOffRampByVersion(chainSelector uint64, version semver.Version) (*offramp.OffRamp, error) {
+	return tryGetContractVersion(s, chainSelector, OffRamp, func(c CCIPChainState) ContractVersions[*offramp.OffRamp] { return c.OffRamps }, version)
+}
+
+// TryGetLatestOffRamp returns the OffRamp of the highest version of the chain and its version.
+func (s CCIPOnChainState) TryGetLatestOffRamp(chainSelector uint64) (*offramp.OffRamp, semver.Version, error) {
+	return tryGetLatestContract(s, chainSelector, OffRamp, func(c CCIPChainState) ContractVersions[*offramp.OffRamp] { return c.OffRamps })
+}
+
+// TryGetFeeQuoterByVersion returns the FeeQuoter of the version of the chain, or an error if it is not deployed.
+func (s CCIPOnChainState) TryGetFeeQuoterByVersion(chainSelector uint64, version semver.Version) (*fee_quoter.FeeQuoter, error) {
+	return tryGetContractVersion(s, chainSelector, FeeQuoter, func(c CCIPChainState) ContractVersions[*fee_quoter.FeeQuoter] { return c.FeeQuoters }, version)
+}
+
+// TryGetLatestFeeQuoter returns the FeeQuoter of the highest version of the chain and its version.
+func (s CCIPOnChainState) TryGetLatestFeeQuoter(chainSelector uint64) (*fee_quoter.FeeQuoter, semver.Version, error) {
+	return tryGetLatestContract(s, chainSelector, FeeQuoter, func(c CCIPChainState) ContractVersions[*fee_quoter.FeeQuoter] { return c.FeeQuoters })
+}
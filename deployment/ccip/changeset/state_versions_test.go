@@ -0,0 +1,68 @@
+package changeset
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/ethereum/go-ethereum/common"
+	chainsel "github.com/smartcontractkit/chain-selectors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+)
+
+func TestLoadChainStateContractVersions(t *testing.T) {
+	chainSel := chainsel.TEST_90000001.Selector
+	chain := deployment.Chain{Selector: chainSel}
+	version1_6_0 := *semver.MustParse("1.6.0")
+	current, next, other := common.HexToAddress("0x1"), common.HexToAddress("0x2"), common.HexToAddress("0x3")
+	// the next onramp rolled out next to the current one
+	chainState, err := LoadChainState(chain, map[string]deployment.TypeAndVersion{
+		current.Hex(): deployment.NewTypeAndVersion(OnRamp, deployment.Version1_6_0_dev),
+		next.Hex():    deployment.NewTypeAndVersion(OnRamp, version1_6_0),
+	})
+	require.NoError(t, err)
+	require.Equal(t, current, chainState.OnRamp.Address())
+	require.Equal(t, []semver.Version{deployment.Version1_6_0_dev, version1_6_0}, chainState.OnRamps.Versions())
+	state := CCIPOnChainState{Chains: map[uint64]CCIPChainState{chainSel: chainState}}
+
+	onRamp, err := state.TryGetOnRampByVersion(chainSel, *semver.MustParse("v1.6.0"))
+	require.NoError(t, err)
+	require.Equal(t, next, onRamp.Address())
+	onRamp, version, err := state.TryGetLatestOnRamp(chainSel)
+	require.NoError(t, err)
+	require.Equal(t, next, onRamp.Address())
+	require.Equal(t, "1.6.0", version.String())
+
+	_, err = state.TryGetOffRampByVersion(chainSel, version1_6_0)
+	var notFound *ContractNotFoundError
+	require.True(t, errors.As(err, &notFound))
+	require.EqualError(t, err, "OffRamp 1.6.0 not found on chain 909606746561742123")
+	_, _, err = state.TryGetLatestFeeQuoter(chainSel)
+	require.Error(t, err)
+	_, _, err = state.TryGetLatestFeeQuoter(chainsel.TEST_90000002.Selector)
+	require.ErrorIs(t, err, deployment.ErrChainNotFound)
+
+	// a redeployment of the same version replaces the previous one
+	chainState, err = LoadChainState(chain, map[string]deployment.TypeAndVersion{
+		current.Hex(): deployment.NewTypeAndVersion(OffRamp, deployment.Version1_6_0_dev),
+		other.Hex():   deployment.NewTypeAndVersion(OffRamp, deployment.Version1_6_0_dev),
+	})
+	require.NoError(t, err)
+	require.Len(t, chainState.OffRamps, 1)
+	offRamp, ok := chainState.OffRamps.Get(deployment.Version1_6_0_dev)
+	require.True(t, ok)
+	require.Equal(t, chainState.OffRamp, offRamp)
+
+	// there are no wrappers of other generations
+	var unknown *UnknownContractError
+	_, err = LoadChainState(chain, map[string]deployment.TypeAndVersion{
+		current.Hex(): deployment.NewTypeAndVersion(FeeQuoter, deployment.Version1_5_0),
+	})
+	require.True(t, errors.As(err, &unknown))
+	_, err = LoadChainState(chain, map[string]deployment.TypeAndVersion{
+		current.Hex(): deployment.NewTypeAndVersion(OnRamp, *semver.MustParse("1.7.0")),
+	})
+	require.True(t, errors.As(err, &unknown))
+}
@@ -0,0 +1,313 @@
+package testhelpers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/deployment/environment/memory"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/offramp"
+)
+
+// offRampWatcherPollInterval is the interval of the polling fallback of the offramp watchers,
+// events are normally delivered right away by their subscriptions.
+var offRampWatcherPollInterval = 2 * time.Second
+
+// offRampWatcher collects the commit reports and execution state changes of an offramp for all the
+// helpers waiting on them, from a single log subscription per event with a polling fallback which also
+// covers blocks the subscriptions missed. Watchers are shared per offramp, see acquireOffRampWatcher.
+type offRampWatcher struct {
+	lggr    logger.Logger
+	chain   deployment.Chain
+	offRamp *offramp.OffRamp
+	cancel  context.CancelFunc
+	done    chan struct{}
+
+	// backfillMu serializes backfills, which must not run concurrently with each other.
+	backfillMu sync.Mutex
+
+	mu sync.Mutex
+	// fromBlock is the first block covered by the events, nextBlock the next one to poll.
+	fromBlock uint64
+	nextBlock uint64
+	seen      map[logID]struct{}
+	commits   []*offramp.OffRampCommitReportAccepted
+	execs     []*offramp.OffRampExecutionStateChanged
+	// updated is closed and replaced whenever events are added.
+	updated chan struct{}
+	refs    int
+}
+
+type logID struct {
+	txHash   common.Hash
+	logIndex uint
+}
+
+type offRampKey struct {
+	chainSelector uint64
+	offRamp       common.Address
+}
+
+var (
+	offRampWatchersMu sync.Mutex
+	offRampWatchers   = make(map[offRampKey]*offRampWatcher)
+)
+
+// acquireOffRampWatcher returns the watcher of the offramp, starting it if needed, covering the events
+// from startBlock, or the genesis block if nil. The watcher stops once all its acquirers released it.
+func acquireOffRampWatcher(ctx context.Context, lggr logger.Logger, chain deployment.Chain, offRamp *offramp.OffRamp, startBlock *uint64) (*offRampWatcher, func(), error) {
+	var start uint64
+	if startBlock != nil {
+		start = *startBlock
+	}
+	key := offRampKey{chainSelector: chain.Selector, offRamp: offRamp.Address()}
+	offRampWatchersMu.Lock()
+	w, ok := offRampWatchers[key]
+	if !ok {
+		var err error
+		w, err = startOffRampWatcher(ctx, lggr, chain, offRamp)
+		if err != nil {
+			offRampWatchersMu.Unlock()
+			return nil, nil, err
+		}
+		offRampWatchers[key] = w
+	}
+	w.mu.Lock()
+	w.refs++
+	w.mu.Unlock()
+	offRampWatchersMu.Unlock()
+
+	release := func() {
+		offRampWatchersMu.Lock()
+		defer offRampWatchersMu.Unlock()
+		w.mu.Lock()
+		w.refs--
+		last := w.refs == 0
+		w.mu.Unlock()
+		if last {
+			delete(offRampWatchers, key)
+			w.cancel()
+			<-w.done
+		}
+	}
+	if err := w.backfill(ctx, start); err != nil {
+		release()
+		return nil, nil, err
+	}
+	return w, release, nil
+}
+
+func startOffRampWatcher(ctx context.Context, lggr logger.Logger, chain deployment.Chain, offRamp *offramp.OffRamp) (*offRampWatcher, error) {
+	latest, err := chain.Client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest header of chain %d: %w", chain.Selector, err)
+	}
+	// the watcher outlives the context of the acquirer which started it
+	runCtx, cancel := context.WithCancel(context.Background())
+	w := &offRampWatcher{
+		lggr:      lggr,
+		chain:     chain,
+		offRamp:   offRamp,
+		cancel:    cancel,
+		done:      make(chan struct{}),
+		fromBlock: latest.Number.Uint64() + 1,
+		nextBlock: latest.Number.Uint64() + 1,
+		seen:      make(map[logID]struct{}),
+		updated:   make(chan struct{}),
+	}
+	go w.run(runCtx)
+	return w, nil
+}
+
+func (w *offRampWatcher) run(ctx context.Context) {
+	defer close(w.done)
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	commitSink := make(chan *offramp.OffRampCommitReportAccepted)
+	commitSub, err := w.offRamp.WatchCommitReportAccepted(&bind.WatchOpts{Context: ctx}, commitSink)
+	if err != nil {
+		w.lggr.Warnw("Failed to subscribe to commit reports, polling only", "chain", w.chain.Selector, "err", err)
+	} else {
+		defer commitSub.Unsubscribe()
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case err := <-commitSub.Err():
+					w.lggr.Warnw("Commit report subscription failed, polling only", "chain", w.chain.Selector, "err", err)
+					return
+				case event := <-commitSink:
+					w.add([]*offramp.OffRampCommitReportAccepted{event}, nil)
+				}
+			}
+		}()
+	}
+	execSink := make(chan *offramp.OffRampExecutionStateChanged)
+	execSub, err := w.offRamp.WatchExecutionStateChanged(&bind.WatchOpts{Context: ctx}, execSink, nil, nil, nil)
+	if err != nil {
+		w.lggr.Warnw("Failed to subscribe to execution state changes, polling only", "chain", w.chain.Selector, "err", err)
+	} else {
+		defer execSub.Unsubscribe()
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case err := <-execSub.Err():
+					w.lggr.Warnw("Execution state subscription failed, polling only", "chain", w.chain.Selector, "err", err)
+					return
+				case event := <-execSink:
+					w.add(nil, []*offramp.OffRampExecutionStateChanged{event})
+				}
+			}
+		}()
+	}
+
+	ticker := time.NewTicker(offRampWatcherPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			// if it's simulated backend, commit to ensure mining
+			if backend, ok := memory.AsBackend(w.chain.Client); ok {
+				backend.Commit()
+			}
+			if err := w.poll(ctx); err != nil && ctx.Err() == nil {
+				w.lggr.Warnw("Failed to poll offramp events", "chain", w.chain.Selector, "err", err)
+			}
+		}
+	}
+}
+
+// poll collects the events of the blocks mined since the last poll.
+func (w *offRampWatcher) poll(ctx context.Context) error {
+	latest, err := w.chain.Client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return err
+	}
+	end := latest.Number.Uint64()
+	w.mu.Lock()
+	start := w.nextBlock
+	w.mu.Unlock()
+	if end < start {
+		return nil
+	}
+	if err := w.filter(ctx, start, end); err != nil {
+		return err
+	}
+	w.mu.Lock()
+	w.nextBlock = end + 1
+	w.mu.Unlock()
+	return nil
+}
+
+// backfill collects the events from the start block up to the first block covered so far.
+func (w *offRampWatcher) backfill(ctx context.Context, start uint64) error {
+	w.backfillMu.Lock()
+	defer w.backfillMu.Unlock()
+	w.mu.Lock()
+	from := w.fromBlock
+	w.mu.Unlock()
+	if start >= from {
+		return nil
+	}
+	if err := w.filter(ctx, start, from-1); err != nil {
+		return err
+	}
+	w.mu.Lock()
+	w.fromBlock = start
+	w.mu.Unlock()
+	return nil
+}
+
+func (w *offRampWatcher) filter(ctx context.Context, start, end uint64) error {
+	opts := &bind.FilterOpts{Context: ctx, Start: start, End: &end}
+	commitIt, err := w.offRamp.FilterCommitReportAccepted(opts)
+	if err != nil {
+		return fmt.Errorf("failed to filter CommitReportAccepted: %w", err)
+	}
+	var commits []*offramp.OffRampCommitReportAccepted
+	for commitIt.Next() {
+		commits = append(commits, commitIt.Event)
+	}
+	if err := commitIt.Error(); err != nil {
+		return fmt.Errorf("failed to filter CommitReportAccepted: %w", err)
+	}
+	execIt, err := w.offRamp.FilterExecutionStateChanged(opts, nil, nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to filter ExecutionStateChanged: %w", err)
+	}
+	var execs []*offramp.OffRampExecutionStateChanged
+	for execIt.Next() {
+		execs = append(execs, execIt.Event)
+	}
+	if err := execIt.Error(); err != nil {
+		return fmt.Errorf("failed to filter ExecutionStateChanged: %w", err)
+	}
+	w.add(commits, execs)
+	return nil
+}
+
+func (w *offRampWatcher) add(commits []*offramp.OffRampCommitReportAccepted, execs []*offramp.OffRampExecutionStateChanged) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	added := false
+	isNew := func(raw types.Log) bool {
+		id := logID{txHash: raw.TxHash, logIndex: raw.Index}
+		if _, ok := w.seen[id]; ok || raw.Removed {
+			return false
+		}
+		w.seen[id] = struct{}{}
+		added = true
+		return true
+	}
+	for _, c := range commits {
+		if isNew(c.Raw) {
+			w.commits = append(w.commits, c)
+		}
+	}
+	for _, e := range execs {
+		if isNew(e.Raw) {
+			w.execs = append(w.execs, e)
+		}
+	}
+	if added {
+		close(w.updated)
+		w.updated = make(chan struct{})
+	}
+}
+
+// events returns the events collected so far from the start block, and a channel closed once more are added.
+func (w *offRampWatcher) events(startBlock uint64) ([]*offramp.OffRampCommitReportAccepted, []*offramp.OffRampExecutionStateChanged, <-chan struct{}) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	var commits []*offramp.OffRampCommitReportAccepted
+	for _, c := range w.commits {
+		if c.Raw.BlockNumber >= startBlock {
+			commits = append(commits, c)
+		}
+	}
+	var execs []*offramp.OffRampExecutionStateChanged
+	for _, e := range w.execs {
+		if e.Raw.BlockNumber >= startBlock {
+			execs = append(execs, e)
+		}
+	}
+	return commits, execs, w.updated
+}
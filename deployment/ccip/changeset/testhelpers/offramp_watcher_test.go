@@ -0,0 +1,85 @@
+package testhelpers
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-ccip/pkg/types/ccipocr3"
+
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/offramp"
+)
+
+func TestOffRampWatcherEvents(t *testing.T) {
+	w := &offRampWatcher{seen: make(map[logID]struct{}), updated: make(chan struct{})}
+	commit := func(block uint64, index uint, src, minSeqNr, maxSeqNr uint64) *offramp.OffRampCommitReportAccepted {
+		return &offramp.OffRampCommitReportAccepted{
+			MerkleRoots: []offramp.InternalMerkleRoot{{SourceChainSelector: src, MinSeqNr: minSeqNr, MaxSeqNr: maxSeqNr}},
+			Raw:         types.Log{BlockNumber: block, TxHash: common.BigToHash(common.Big1), Index: index},
+		}
+	}
+
+	_, _, updated := w.events(0)
+	w.add([]*offramp.OffRampCommitReportAccepted{commit(10, 0, 1, 1, 5), commit(20, 1, 1, 6, 10)}, nil)
+	require.True(t, isClosed(updated), "waiters must be notified of new events")
+
+	// the same logs delivered again by the polling fallback are dropped
+	_, _, updated = w.events(0)
+	w.add([]*offramp.OffRampCommitReportAccepted{commit(10, 0, 1, 1, 5)}, nil)
+	require.False(t, isClosed(updated))
+	commits, _, _ := w.events(0)
+	require.Len(t, commits, 2)
+
+	// removed logs are dropped
+	removed := &offramp.OffRampExecutionStateChanged{Raw: types.Log{BlockNumber: 30, Index: 2, Removed: true}}
+	w.add(nil, []*offramp.OffRampExecutionStateChanged{removed})
+	_, execs, _ := w.events(0)
+	require.Empty(t, execs)
+
+	commits, _, _ = w.events(15)
+	require.Len(t, commits, 1)
+	require.EqualValues(t, 20, commits[0].Raw.BlockNumber)
+
+	matched, missing := matchCommitReports(commits, 1, []uint64{7, 8})
+	require.Empty(t, missing)
+	require.Len(t, matched, 1)
+	require.EqualValues(t, 6, matched[0].MerkleRoots[0].MinSeqNr)
+	_, missing = matchCommitReports(commits, 2, []uint64{7, 8})
+	require.Equal(t, []uint64{7, 8}, missing)
+	_, missing = matchCommitReports(commits, 1, []uint64{9, 10, 11})
+	require.Equal(t, []uint64{11}, missing)
+}
+
+func isClosed(ch <-chan struct{}) bool {
+	select {
+	case <-ch:
+		return true
+	default:
+		return false
+	}
+}
+
+func TestMatchCommitReports(t *testing.T) {
+	root := func(src, minSeqNr, maxSeqNr uint64) offramp.InternalMerkleRoot {
+		return offramp.InternalMerkleRoot{SourceChainSelector: src, MinSeqNr: minSeqNr, MaxSeqNr: maxSeqNr}
+	}
+	// the range 1-10 of source 1 is batched over two reports, interleaved with the roots of source 2
+	first := &offramp.OffRampCommitReportAccepted{MerkleRoots: []offramp.InternalMerkleRoot{root(2, 1, 5), root(1, 1, 4)}}
+	other := &offramp.OffRampCommitReportAccepted{MerkleRoots: []offramp.InternalMerkleRoot{root(2, 6, 9)}}
+	second := &offramp.OffRampCommitReportAccepted{MerkleRoots: []offramp.InternalMerkleRoot{root(1, 5, 7), root(2, 10, 10)}}
+	commits := []*offramp.OffRampCommitReportAccepted{first, other, second}
+
+	expected := seqNrsOfRange(ccipocr3.NewSeqNumRange(1, 10))
+	matched, missing := matchCommitReports(commits, 1, expected)
+	require.Equal(t, []*offramp.OffRampCommitReportAccepted{first, second}, matched)
+	require.Equal(t, []uint64{8, 9, 10}, missing)
+	require.Equal(t, "[8-10]", formatSeqNrs(missing))
+
+	commits = append(commits, &offramp.OffRampCommitReportAccepted{MerkleRoots: []offramp.InternalMerkleRoot{root(1, 8, 10)}})
+	matched, missing = matchCommitReports(commits, 1, expected)
+	require.Empty(t, missing)
+	require.Len(t, matched, 3)
+	require.Equal(t, "[1 3-4 7]", formatSeqNrs([]uint64{1, 3, 4, 7}))
+}
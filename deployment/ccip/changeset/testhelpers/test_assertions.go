@@ -1,9 +1,11 @@
-package changeset
+package testhelpers
 
 import (
 	"context"
 	"fmt"
 	"math/big"
+	"strconv"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -14,11 +16,13 @@ import (
 	"golang.org/x/sync/errgroup"
 
 	"github.com/smartcontractkit/chainlink-ccip/pkg/types/ccipocr3"
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
 	commonutils "github.com/smartcontractkit/chainlink-common/pkg/utils"
 	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
 	"github.com/smartcontractkit/chainlink/deployment/environment/memory"
 
 	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset"
 	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/fee_quoter"
 	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/offramp"
 )
@@ -26,7 +30,7 @@ import (
 func ConfirmGasPriceUpdatedForAll(
 	t *testing.T,
 	e deployment.Environment,
-	state CCIPOnChainState,
+	state changeset.CCIPOnChainState,
 	startBlocks map[uint64]*uint64,
 	gasPrice *big.Int,
 ) {
@@ -79,7 +83,7 @@ func ConfirmGasPriceUpdated(
 func ConfirmTokenPriceUpdatedForAll(
 	t *testing.T,
 	e deployment.Environment,
-	state CCIPOnChainState,
+	state changeset.CCIPOnChainState,
 	startBlocks map[uint64]*uint64,
 	linkPrice *big.Int,
 	wethPrice *big.Int,
@@ -148,14 +152,6 @@ func ConfirmTokenPriceUpdated(
 	return nil
 }
 
-// SourceDestPair is represents a pair of source and destination chain selectors.
-// Use this as a key in maps that need to identify sequence numbers, nonces, or
-// other things that require identification.
-type SourceDestPair struct {
-	SourceChainSelector uint64
-	DestChainSelector   uint64
-}
-
 // ConfirmCommitForAllWithExpectedSeqNums waits for all chains in the environment to commit the given expectedSeqNums.
 // expectedSeqNums is a map that maps a (source, dest) selector pair to the expected sequence number
 // to confirm the commit for.
@@ -164,11 +160,27 @@ type SourceDestPair struct {
 func ConfirmCommitForAllWithExpectedSeqNums(
 	t *testing.T,
 	e deployment.Environment,
-	state CCIPOnChainState,
-	expectedSeqNums map[SourceDestPair]uint64,
+	state changeset.CCIPOnChainState,
+	expectedSeqNums map[changeset.SourceDestPair]uint64,
 	startBlocks map[uint64]*uint64,
 ) {
-	var wg errgroup.Group
+	ctx, cancel := context.WithTimeout(tests.Context(t), 3*time.Minute)
+	defer cancel()
+	require.NoError(t, WaitForCommitForAllWithExpectedSeqNums(ctx, logger.Test(t), e, state, expectedSeqNums, startBlocks),
+		"all commitments did not confirm")
+}
+
+// WaitForCommitForAllWithExpectedSeqNums is ConfirmCommitForAllWithExpectedSeqNums returning an error
+// instead of failing a test, it gives up when the context is done.
+func WaitForCommitForAllWithExpectedSeqNums(
+	ctx context.Context,
+	lggr logger.Logger,
+	e deployment.Environment,
+	state changeset.CCIPOnChainState,
+	expectedSeqNums map[changeset.SourceDestPair]uint64,
+	startBlocks map[uint64]*uint64,
+) error {
+	wg, ctx := errgroup.WithContext(ctx)
 	for src, srcChain := range e.Chains {
 		for dest, dstChain := range e.Chains {
 			if src == dest {
@@ -182,7 +194,7 @@ func ConfirmCommitForAllWithExpectedSeqNums(
 					startBlock = startBlocks[dstChain.Selector]
 				}
 
-				expectedSeqNum, ok := expectedSeqNums[SourceDestPair{
+				expectedSeqNum, ok := expectedSeqNums[changeset.SourceDestPair{
 					SourceChainSelector: srcChain.Selector,
 					DestChainSelector:   dstChain.Selector,
 				}]
@@ -190,8 +202,9 @@ func ConfirmCommitForAllWithExpectedSeqNums(
 					return nil
 				}
 
-				return commonutils.JustError(ConfirmCommitWithExpectedSeqNumRange(
-					t,
+				return commonutils.JustError(WaitForCommitWithExpectedSeqNumRange(
+					ctx,
+					lggr,
 					srcChain,
 					dstChain,
 					state.Chains[dstChain.Selector].OffRamp,
@@ -203,25 +216,7 @@ func ConfirmCommitForAllWithExpectedSeqNums(
 			})
 		}
 	}
-
-	done := make(chan struct{})
-	go func() {
-		require.NoError(t, wg.Wait())
-		close(done)
-	}()
-
-	require.Eventually(t, func() bool {
-		select {
-		case <-done:
-			return true
-		default:
-			return false
-		}
-	},
-		3*time.Minute,
-		1*time.Second,
-		"all commitments did not confirm",
-	)
+	return wg.Wait()
 }
 
 // ConfirmCommitWithExpectedSeqNumRange waits for a commit report on the destination chain with the expected sequence number range.
@@ -235,16 +230,6 @@ func ConfirmCommitWithExpectedSeqNumRange(
 	startBlock *uint64,
 	expectedSeqNumRange ccipocr3.SeqNumRange,
 ) (*offramp.OffRampCommitReportAccepted, error) {
-	sink := make(chan *offramp.OffRampCommitReportAccepted)
-	subscription, err := offRamp.WatchCommitReportAccepted(&bind.WatchOpts{
-		Context: context.Background(),
-		Start:   startBlock,
-	}, sink)
-	if err != nil {
-		return nil, fmt.Errorf("error to subscribe CommitReportAccepted : %w", err)
-	}
-
-	defer subscription.Unsubscribe()
 	var duration time.Duration
 	deadline, ok := t.Deadline()
 	if ok {
@@ -253,63 +238,133 @@ func ConfirmCommitWithExpectedSeqNumRange(
 	} else {
 		duration = 5 * time.Minute
 	}
-	timer := time.NewTimer(duration)
-	defer timer.Stop()
+	ctx, cancel := context.WithTimeout(tests.Context(t), duration)
+	defer cancel()
+	return WaitForCommitWithExpectedSeqNumRange(ctx, logger.Test(t), src, dest, offRamp, startBlock, expectedSeqNumRange)
+}
+
+// WaitForCommitWithExpectedSeqNumRange is ConfirmCommitWithExpectedSeqNumRange without a test,
+// it times out when the context is done. The commit reports are delivered by the watcher of the offramp,
+// shared with the other helpers waiting on it.
+func WaitForCommitWithExpectedSeqNumRange(
+	ctx context.Context,
+	lggr logger.Logger,
+	src deployment.Chain,
+	dest deployment.Chain,
+	offRamp *offramp.OffRamp,
+	startBlock *uint64,
+	expectedSeqNumRange ccipocr3.SeqNumRange,
+) (*offramp.OffRampCommitReportAccepted, error) {
+	watcher, release, err := acquireOffRampWatcher(ctx, lggr, dest, offRamp, startBlock)
+	if err != nil {
+		return nil, fmt.Errorf("failed to watch offramp %s on chain %d: %w", offRamp.Address().String(), dest.Selector, err)
+	}
+	defer release()
+
+	var start uint64
+	if startBlock != nil {
+		start = *startBlock
+	}
+	expectedSeqNrs := seqNrsOfRange(expectedSeqNumRange)
+	started := time.Now()
 	ticker := time.NewTicker(2 * time.Second)
 	defer ticker.Stop()
+	var missing []uint64
 	for {
+		commits, _, updated := watcher.events(start)
+		var matched []*offramp.OffRampCommitReportAccepted
+		matched, missing = matchCommitReports(commits, src.Selector, expectedSeqNrs)
+		if len(missing) == 0 {
+			event := matched[len(matched)-1]
+			lggr.Infof("Received commit reports for expected seq nr range %s on selector %d from source selector %d in %d reports, last tx hash: %s, token prices: %v",
+				expectedSeqNumRange.String(), dest.Selector, src.Selector, len(matched), event.Raw.TxHash.String(), event.PriceUpdates.TokenPriceUpdates)
+			return event, nil
+		}
 		select {
+		case <-updated:
 		case <-ticker.C:
-			// if it's simulated backend, commit to ensure mining
-			if backend, ok := src.Client.(*memory.Backend); ok {
+			// if it's simulated backend, commit to ensure mining, the watcher mines the destination chain.
+			// Messages are only committed once finalized, which takes the finality depth of the source in blocks.
+			if backend, ok := memory.AsBackend(src.Client); ok {
 				backend.Commit()
-			}
-			if backend, ok := dest.Client.(*memory.Backend); ok {
-				backend.Commit()
-			}
-			t.Logf("Waiting for commit report on chain selector %d from source selector %d expected seq nr range %s",
-				dest.Selector, src.Selector, expectedSeqNumRange.String())
-
-			// Need to do this because the subscription sometimes fails to get the event.
-			iter, err := offRamp.FilterCommitReportAccepted(&bind.FilterOpts{
-				Context: tests.Context(t),
-			})
-			require.NoError(t, err)
-			for iter.Next() {
-				event := iter.Event
-				if len(event.MerkleRoots) > 0 {
-					for _, mr := range event.MerkleRoots {
-						if mr.SourceChainSelector == src.Selector &&
-							uint64(expectedSeqNumRange.Start()) >= mr.MinSeqNr &&
-							uint64(expectedSeqNumRange.End()) <= mr.MaxSeqNr {
-							t.Logf("Received commit report for [%d, %d] on selector %d from source selector %d expected seq nr range %s, token prices: %v, tx hash: %s",
-								mr.MinSeqNr, mr.MaxSeqNr, dest.Selector, src.Selector, expectedSeqNumRange.String(), event.PriceUpdates.TokenPriceUpdates, event.Raw.TxHash.String())
-							return event, nil
-						}
+				if depth := backend.Finality().Depth; depth > 0 {
+					if finalized, err := backend.FinalizedBlockNumber(ctx); err == nil {
+						lggr.Infof("Source selector %d finalized block %d, finality depth %d", src.Selector, finalized, depth)
 					}
 				}
 			}
-		case subErr := <-subscription.Err():
-			return nil, fmt.Errorf("subscription error: %w", subErr)
-		case <-timer.C:
-			return nil, fmt.Errorf("timed out after waiting %s duration for commit report on chain selector %d from source selector %d expected seq nr range %s",
-				duration.String(), dest.Selector, src.Selector, expectedSeqNumRange.String())
-		case report := <-sink:
-			if len(report.MerkleRoots) > 0 {
-				// Check the interval of sequence numbers and make sure it matches
-				// the expected range.
-				for _, mr := range report.MerkleRoots {
-					if mr.SourceChainSelector == src.Selector &&
-						uint64(expectedSeqNumRange.Start()) >= mr.MinSeqNr &&
-						uint64(expectedSeqNumRange.End()) <= mr.MaxSeqNr {
-						t.Logf("Received commit report for [%d, %d] on selector %d from source selector %d expected seq nr range %s, token prices: %v",
-							mr.MinSeqNr, mr.MaxSeqNr, dest.Selector, src.Selector, expectedSeqNumRange.String(), report.PriceUpdates.TokenPriceUpdates)
-						return report, nil
-					}
+			lggr.Infof("Waiting for commit report on chain selector %d from source selector %d expected seq nr range %s, missing seq nrs %s",
+				dest.Selector, src.Selector, expectedSeqNumRange.String(), formatSeqNrs(missing))
+		case <-ctx.Done():
+			return nil, fmt.Errorf("timed out after waiting %s duration for commit report on chain selector %d from source selector %d expected seq nr range %s, "+
+				"seq nrs %s were not committed: %w",
+				time.Since(started).Truncate(time.Second).String(), dest.Selector, src.Selector, expectedSeqNumRange.String(), formatSeqNrs(missing), ctx.Err())
+		}
+	}
+}
+
+// matchCommitReports matches the sequence numbers against the merkle roots of the source chain in the commit reports.
+// The sequence numbers may be committed by several roots of several reports, interleaved with the roots of other lanes.
+// It returns the reports committing them in the order of the commits and the sequence numbers not committed.
+func matchCommitReports(
+	commits []*offramp.OffRampCommitReportAccepted,
+	sourceChainSelector uint64,
+	seqNrs []uint64,
+) (matched []*offramp.OffRampCommitReportAccepted, missing []uint64) {
+	committedBy := make(map[uint64]int)
+	for i, event := range commits {
+		for _, mr := range event.MerkleRoots {
+			if mr.SourceChainSelector != sourceChainSelector {
+				continue
+			}
+			for _, seqNr := range seqNrs {
+				if _, ok := committedBy[seqNr]; !ok && mr.MinSeqNr <= seqNr && seqNr <= mr.MaxSeqNr {
+					committedBy[seqNr] = i
 				}
 			}
 		}
 	}
+	reports := make(map[int]bool)
+	for _, seqNr := range seqNrs {
+		i, ok := committedBy[seqNr]
+		if !ok {
+			missing = append(missing, seqNr)
+			continue
+		}
+		reports[i] = true
+	}
+	for i, event := range commits {
+		if reports[i] {
+			matched = append(matched, event)
+		}
+	}
+	return matched, missing
+}
+
+func seqNrsOfRange(r ccipocr3.SeqNumRange) []uint64 {
+	var seqNrs []uint64
+	for seqNr := r.Start(); seqNr <= r.End(); seqNr++ {
+		seqNrs = append(seqNrs, uint64(seqNr))
+	}
+	return seqNrs
+}
+
+// formatSeqNrs formats sorted sequence numbers with consecutive ones as ranges, e.g. [1-3 5].
+func formatSeqNrs(seqNrs []uint64) string {
+	var parts []string
+	for i := 0; i < len(seqNrs); {
+		j := i
+		for j+1 < len(seqNrs) && seqNrs[j+1] == seqNrs[j]+1 {
+			j++
+		}
+		if i == j {
+			parts = append(parts, strconv.FormatUint(seqNrs[i], 10))
+		} else {
+			parts = append(parts, fmt.Sprintf("%d-%d", seqNrs[i], seqNrs[j]))
+		}
+		i = j + 1
+	}
+	return "[" + strings.Join(parts, " ") + "]"
 }
 
 // ConfirmExecWithSeqNrsForAll waits for all chains in the environment to execute the given expectedSeqNums.
@@ -321,15 +376,30 @@ func ConfirmCommitWithExpectedSeqNumRange(
 func ConfirmExecWithSeqNrsForAll(
 	t *testing.T,
 	e deployment.Environment,
-	state CCIPOnChainState,
-	expectedSeqNums map[SourceDestPair][]uint64,
+	state changeset.CCIPOnChainState,
+	expectedSeqNums map[changeset.SourceDestPair][]uint64,
+	startBlocks map[uint64]*uint64,
+) (executionStates map[changeset.SourceDestPair]map[uint64]int) {
+	ctx, cancel := context.WithTimeout(tests.Context(t), 3*time.Minute)
+	defer cancel()
+	executionStates, err := WaitForExecWithSeqNrsForAll(ctx, logger.Test(t), e, state, expectedSeqNums, startBlocks)
+	require.NoError(t, err)
+	return executionStates
+}
+
+// WaitForExecWithSeqNrsForAll is ConfirmExecWithSeqNrsForAll returning an error instead of failing a test,
+// it gives up when the context is done.
+func WaitForExecWithSeqNrsForAll(
+	ctx context.Context,
+	lggr logger.Logger,
+	e deployment.Environment,
+	state changeset.CCIPOnChainState,
+	expectedSeqNums map[changeset.SourceDestPair][]uint64,
 	startBlocks map[uint64]*uint64,
-) (executionStates map[SourceDestPair]map[uint64]int) {
-	var (
-		wg errgroup.Group
-		mx sync.Mutex
-	)
-	executionStates = make(map[SourceDestPair]map[uint64]int)
+) (executionStates map[changeset.SourceDestPair]map[uint64]int, err error) {
+	var mx sync.Mutex
+	wg, ctx := errgroup.WithContext(ctx)
+	executionStates = make(map[changeset.SourceDestPair]map[uint64]int)
 	for src, srcChain := range e.Chains {
 		for dest, dstChain := range e.Chains {
 			if src == dest {
@@ -343,7 +413,7 @@ func ConfirmExecWithSeqNrsForAll(
 					startBlock = startBlocks[dstChain.Selector]
 				}
 
-				expectedSeqNum, ok := expectedSeqNums[SourceDestPair{
+				expectedSeqNum, ok := expectedSeqNums[changeset.SourceDestPair{
 					SourceChainSelector: srcChain.Selector,
 					DestChainSelector:   dstChain.Selector,
 				}]
@@ -351,8 +421,9 @@ func ConfirmExecWithSeqNrsForAll(
 					return nil
 				}
 
-				innerExecutionStates, err := ConfirmExecWithSeqNrs(
-					t,
+				innerExecutionStates, err := WaitForExecWithSeqNrs(
+					ctx,
+					lggr,
 					srcChain,
 					dstChain,
 					state.Chains[dstChain.Selector].OffRamp,
@@ -364,7 +435,7 @@ func ConfirmExecWithSeqNrsForAll(
 				}
 
 				mx.Lock()
-				executionStates[SourceDestPair{
+				executionStates[changeset.SourceDestPair{
 					SourceChainSelector: srcChain.Selector,
 					DestChainSelector:   dstChain.Selector,
 				}] = innerExecutionStates
@@ -375,8 +446,10 @@ func ConfirmExecWithSeqNrsForAll(
 		}
 	}
 
-	require.NoError(t, wg.Wait())
-	return executionStates
+	if err := wg.Wait(); err != nil {
+		return nil, err
+	}
+	return executionStates, nil
 }
 
 // ConfirmExecWithSeqNrs waits for an execution state change on the destination chain with the expected sequence number.
@@ -389,24 +462,43 @@ func ConfirmExecWithSeqNrs(
 	offRamp *offramp.OffRamp,
 	startBlock *uint64,
 	expectedSeqNrs []uint64,
+) (executionStates map[uint64]int, err error) {
+	ctx, cancel := context.WithTimeout(tests.Context(t), 3*time.Minute)
+	defer cancel()
+	return WaitForExecWithSeqNrs(ctx, logger.Test(t), source, dest, offRamp, startBlock, expectedSeqNrs)
+}
+
+// WaitForExecWithSeqNrs is ConfirmExecWithSeqNrs without a test, it times out when the context is done.
+// The execution state changes are delivered by the watcher of the offramp, shared with the other helpers
+// waiting on it, and the execution states are read from the offramp as a fallback.
+func WaitForExecWithSeqNrs(
+	ctx context.Context,
+	lggr logger.Logger,
+	source, dest deployment.Chain,
+	offRamp *offramp.OffRamp,
+	startBlock *uint64,
+	expectedSeqNrs []uint64,
 ) (executionStates map[uint64]int, err error) {
 	if len(expectedSeqNrs) == 0 {
 		return nil, fmt.Errorf("no expected sequence numbers provided")
 	}
 
-	timer := time.NewTimer(3 * time.Minute)
-	defer timer.Stop()
-	tick := time.NewTicker(3 * time.Second)
-	defer tick.Stop()
-	sink := make(chan *offramp.OffRampExecutionStateChanged)
-	subscription, err := offRamp.WatchExecutionStateChanged(&bind.WatchOpts{
-		Context: context.Background(),
-		Start:   startBlock,
-	}, sink, nil, nil, nil)
+	var start uint64
+	if startBlock != nil {
+		start = *startBlock
+	} else {
+		// without a start block only the state changes from now on count, earlier ones are read from the offramp
+		latest, err := dest.Client.HeaderByNumber(ctx, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get latest header of chain %d: %w", dest.Selector, err)
+		}
+		start = latest.Number.Uint64() + 1
+	}
+	watcher, release, err := acquireOffRampWatcher(ctx, lggr, dest, offRamp, &start)
 	if err != nil {
-		return nil, fmt.Errorf("error to subscribe ExecutionStateChanged : %w", err)
+		return nil, fmt.Errorf("failed to watch offramp %s on chain %d: %w", offRamp.Address().String(), dest.Selector, err)
 	}
-	defer subscription.Unsubscribe()
+	defer release()
 
 	// some state to efficiently track the execution states
 	// of all the expected sequence numbers.
@@ -415,44 +507,45 @@ func ConfirmExecWithSeqNrs(
 	for _, seqNr := range expectedSeqNrs {
 		seqNrsToWatch[seqNr] = struct{}{}
 	}
+	tick := time.NewTicker(3 * time.Second)
+	defer tick.Stop()
 	for {
+		_, execs, updated := watcher.events(start)
+		for _, execEvent := range execs {
+			_, found := seqNrsToWatch[execEvent.SequenceNumber]
+			if found && execEvent.SourceChainSelector == source.Selector {
+				lggr.Infof("Received ExecutionStateChanged (state %s) on chain %d (offramp %s) from chain %d with expected sequence number %d",
+					changeset.ExecutionStateToString(execEvent.State), dest.Selector, offRamp.Address().String(), source.Selector, execEvent.SequenceNumber)
+				executionStates[execEvent.SequenceNumber] = int(execEvent.State)
+				delete(seqNrsToWatch, execEvent.SequenceNumber)
+			}
+		}
+		if len(seqNrsToWatch) == 0 {
+			return executionStates, nil
+		}
 		select {
+		case <-updated:
 		case <-tick.C:
 			for expectedSeqNr := range seqNrsToWatch {
-				scc, executionState := GetExecutionState(t, source, dest, offRamp, expectedSeqNr)
-				t.Logf("Waiting for ExecutionStateChanged on chain %d (offramp %s) from chain %d with expected sequence number %d, current onchain minSeqNr: %d, execution state: %s",
-					dest.Selector, offRamp.Address().String(), source.Selector, expectedSeqNr, scc.MinSeqNr, executionStateToString(executionState))
-				if executionState == EXECUTION_STATE_SUCCESS || executionState == EXECUTION_STATE_FAILURE {
-					t.Logf("Observed %s execution state on chain %d (offramp %s) from chain %d with expected sequence number %d",
-						executionStateToString(executionState), dest.Selector, offRamp.Address().String(), source.Selector, expectedSeqNr)
+				scc, executionState, err := ReadExecutionState(ctx, source, dest, offRamp, expectedSeqNr)
+				if err != nil {
+					return nil, err
+				}
+				lggr.Infof("Waiting for ExecutionStateChanged on chain %d (offramp %s) from chain %d with expected sequence number %d, current onchain minSeqNr: %d, execution state: %s",
+					dest.Selector, offRamp.Address().String(), source.Selector, expectedSeqNr, scc.MinSeqNr, changeset.ExecutionStateToString(executionState))
+				if executionState == changeset.EXECUTION_STATE_SUCCESS || executionState == changeset.EXECUTION_STATE_FAILURE {
+					lggr.Infof("Observed %s execution state on chain %d (offramp %s) from chain %d with expected sequence number %d",
+						changeset.ExecutionStateToString(executionState), dest.Selector, offRamp.Address().String(), source.Selector, expectedSeqNr)
 					executionStates[expectedSeqNr] = int(executionState)
 					delete(seqNrsToWatch, expectedSeqNr)
-					if len(seqNrsToWatch) == 0 {
-						return executionStates, nil
-					}
 				}
 			}
-		case execEvent := <-sink:
-			t.Logf("Received ExecutionStateChanged (state %s) for seqNum %d on chain %d (offramp %s) from chain %d",
-				executionStateToString(execEvent.State), execEvent.SequenceNumber, dest.Selector, offRamp.Address().String(),
-				source.Selector,
-			)
-
-			_, found := seqNrsToWatch[execEvent.SequenceNumber]
-			if found && execEvent.SourceChainSelector == source.Selector {
-				t.Logf("Received ExecutionStateChanged (state %s) on chain %d (offramp %s) from chain %d with expected sequence number %d",
-					executionStateToString(execEvent.State), dest.Selector, offRamp.Address().String(), source.Selector, execEvent.SequenceNumber)
-				executionStates[execEvent.SequenceNumber] = int(execEvent.State)
-				delete(seqNrsToWatch, execEvent.SequenceNumber)
-				if len(seqNrsToWatch) == 0 {
-					return executionStates, nil
-				}
+			if len(seqNrsToWatch) == 0 {
+				return executionStates, nil
 			}
-		case <-timer.C:
-			return nil, fmt.Errorf("timed out waiting for ExecutionStateChanged on chain %d (offramp %s) from chain %d with expected sequence numbers %+v",
-				dest.Selector, offRamp.Address().String(), source.Selector, expectedSeqNrs)
-		case subErr := <-subscription.Err():
-			return nil, fmt.Errorf("subscription error: %w", subErr)
+		case <-ctx.Done():
+			return nil, fmt.Errorf("timed out waiting for ExecutionStateChanged on chain %d (offramp %s) from chain %d with expected sequence numbers %+v: %w",
+				dest.Selector, offRamp.Address().String(), source.Selector, expectedSeqNrs, ctx.Err())
 		}
 	}
 }
@@ -467,29 +560,42 @@ func ConfirmNoExecConsistentlyWithSeqNr(
 	RequireConsistently(t, func() bool {
 		scc, executionState := GetExecutionState(t, source, dest, offRamp, expectedSeqNr)
 		t.Logf("Waiting for ExecutionStateChanged on chain %d (offramp %s) from chain %d with expected sequence number %d, current onchain minSeqNr: %d, execution state: %s",
-			dest.Selector, offRamp.Address().String(), source.Selector, expectedSeqNr, scc.MinSeqNr, executionStateToString(executionState))
-		if executionState == EXECUTION_STATE_UNTOUCHED {
+			dest.Selector, offRamp.Address().String(), source.Selector, expectedSeqNr, scc.MinSeqNr, changeset.ExecutionStateToString(executionState))
+		if executionState == changeset.EXECUTION_STATE_UNTOUCHED {
 			return true
 		}
 		t.Logf("Observed %s execution state on chain %d (offramp %s) from chain %d with expected sequence number %d",
-			executionStateToString(executionState), dest.Selector, offRamp.Address().String(), source.Selector, expectedSeqNr)
+			changeset.ExecutionStateToString(executionState), dest.Selector, offRamp.Address().String(), source.Selector, expectedSeqNr)
 		return false
 	}, timeout, 3*time.Second, "Expected no execution state change on chain %d (offramp %s) from chain %d with expected sequence number %d", dest.Selector, offRamp.Address().String(), source.Selector, expectedSeqNr)
 }
 
 func GetExecutionState(t *testing.T, source, dest deployment.Chain, offRamp *offramp.OffRamp, expectedSeqNr uint64) (offramp.OffRampSourceChainConfig, uint8) {
+	scc, executionState, err := ReadExecutionState(tests.Context(t), source, dest, offRamp, expectedSeqNr)
+	require.NoError(t, err)
+	return scc, executionState
+}
+
+// ReadExecutionState returns the source chain config and the execution state of the message
+// with the sequence number on the offramp.
+func ReadExecutionState(ctx context.Context, source, dest deployment.Chain, offRamp *offramp.OffRamp, expectedSeqNr uint64) (offramp.OffRampSourceChainConfig, uint8, error) {
 	// if it's simulated backend, commit to ensure mining
-	if backend, ok := source.Client.(*memory.Backend); ok {
+	if backend, ok := memory.AsBackend(source.Client); ok {
 		backend.Commit()
 	}
-	if backend, ok := dest.Client.(*memory.Backend); ok {
+	if backend, ok := memory.AsBackend(dest.Client); ok {
 		backend.Commit()
 	}
-	scc, err := offRamp.GetSourceChainConfig(nil, source.Selector)
-	require.NoError(t, err)
-	executionState, err := offRamp.GetExecutionState(nil, source.Selector, expectedSeqNr)
-	require.NoError(t, err)
-	return scc, executionState
+	opts := &bind.CallOpts{Context: ctx}
+	scc, err := offRamp.GetSourceChainConfig(opts, source.Selector)
+	if err != nil {
+		return offramp.OffRampSourceChainConfig{}, 0, fmt.Errorf("failed to get source chain config of chain %d: %w", source.Selector, err)
+	}
+	executionState, err := offRamp.GetExecutionState(opts, source.Selector, expectedSeqNr)
+	if err != nil {
+		return offramp.OffRampSourceChainConfig{}, 0, fmt.Errorf("failed to get execution state of sequence number %d: %w", expectedSeqNr, err)
+	}
+	return scc, executionState, nil
 }
 
 func RequireConsistently(t *testing.T, condition func() bool, duration time.Duration, tick time.Duration, msgAndArgs ...interface{}) {
@@ -508,25 +614,3 @@ func RequireConsistently(t *testing.T, condition func() bool, duration time.Dura
 		}
 	}
 }
-
-const (
-	EXECUTION_STATE_UNTOUCHED  = 0
-	EXECUTION_STATE_INPROGRESS = 1
-	EXECUTION_STATE_SUCCESS    = 2
-	EXECUTION_STATE_FAILURE    = 3
-)
-
-func executionStateToString(state uint8) string {
-	switch state {
-	case EXECUTION_STATE_UNTOUCHED:
-		return "UNTOUCHED"
-	case EXECUTION_STATE_INPROGRESS:
-		return "IN_PROGRESS"
-	case EXECUTION_STATE_SUCCESS:
-		return "SUCCESS"
-	case EXECUTION_STATE_FAILURE:
-		return "FAILURE"
-	default:
-		return "UNKNOWN"
-	}
-}
@@ -0,0 +1,52 @@
+package testhelpers
+
+import (
+	"bytes"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/fee_quoter"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/router"
+)
+
+// MessageBoundaryCase is a message at or just over one of the limits of the FeeQuoter config of its destination chain.
+type MessageBoundaryCase struct {
+	Name string
+	Msg  router.ClientEVM2AnyMessage
+	// ExpectedError is the name of the FeeQuoter error rejecting the message, as decoded by deployment.DecodeRevert,
+	// empty for messages within the limits.
+	ExpectedError string
+	// Baseline is the name of the case the fee of the message must exceed, if any.
+	Baseline string
+}
+
+// MessageBoundaryCases generates the messages at and just over the data size, gas limit and number of tokens
+// limits of the FeeQuoter dest chain config, transferring 1 of the token per token amount, along with the
+// minimal messages the fees of the messages at the limits must exceed.
+func MessageBoundaryCases(destConfig fee_quoter.FeeQuoterDestChainConfig, receiver, token common.Address) []MessageBoundaryCase {
+	msg := func(data []byte, gasLimit uint32, numTokens int) router.ClientEVM2AnyMessage {
+		tokenAmounts := make([]router.ClientEVMTokenAmount, numTokens)
+		for i := range tokenAmounts {
+			tokenAmounts[i] = router.ClientEVMTokenAmount{Token: token, Amount: big.NewInt(1)}
+		}
+		return router.ClientEVM2AnyMessage{
+			Receiver:     common.LeftPadBytes(receiver.Bytes(), 32),
+			Data:         data,
+			TokenAmounts: tokenAmounts,
+			FeeToken:     common.Address{},
+			ExtraArgs:    MakeEVMExtraArgsV2(uint64(gasLimit), false),
+		}
+	}
+	maxData, defaultGas, maxGas, maxTokens := destConfig.MaxDataBytes, destConfig.DefaultTxGasLimit, destConfig.MaxPerMsgGasLimit, int(destConfig.MaxNumberOfTokensPerMsg)
+	return []MessageBoundaryCase{
+		{Name: "minimal", Msg: msg(nil, defaultGas, 0)},
+		{Name: "single token", Msg: msg(nil, defaultGas, 1)},
+		{Name: "max data size", Msg: msg(bytes.Repeat([]byte{1}, int(maxData)), defaultGas, 0), Baseline: "minimal"},
+		{Name: "over max data size", Msg: msg(bytes.Repeat([]byte{1}, int(maxData)+1), defaultGas, 0), ExpectedError: "MessageTooLarge"},
+		{Name: "max gas limit", Msg: msg(nil, maxGas, 0), Baseline: "minimal"},
+		{Name: "over max gas limit", Msg: msg(nil, maxGas+1, 0), ExpectedError: "MessageGasLimitTooHigh"},
+		{Name: "max tokens", Msg: msg(nil, defaultGas, maxTokens), Baseline: "single token"},
+		{Name: "over max tokens", Msg: msg(nil, defaultGas, maxTokens+1), ExpectedError: "UnsupportedNumberOfTokens"},
+	}
+}
@@ -0,0 +1,30 @@
+package testhelpers
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset"
+)
+
+func TestMessageBoundaryCases(t *testing.T) {
+	destConfig := changeset.DefaultFeeQuoterDestChainConfig()
+	cases := MessageBoundaryCases(destConfig, common.HexToAddress("0x1"), common.HexToAddress("0x2"))
+	names := make(map[string]bool)
+	for _, c := range cases {
+		names[c.Name] = true
+	}
+	for _, c := range cases {
+		if c.Baseline != "" {
+			require.True(t, names[c.Baseline], "baseline %s of %s", c.Baseline, c.Name)
+		}
+		switch c.Name {
+		case "max data size":
+			require.Len(t, c.Msg.Data, int(destConfig.MaxDataBytes))
+		case "over max tokens":
+			require.Len(t, c.Msg.TokenAmounts, int(destConfig.MaxNumberOfTokensPerMsg)+1)
+		}
+	}
+}
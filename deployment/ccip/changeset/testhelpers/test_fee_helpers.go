@@ -0,0 +1,152 @@
+package testhelpers
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/fee_quoter"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/router"
+)
+
+const (
+	// messageFixedBytes and messageFixedBytesPerToken mirror Internal.MESSAGE_FIXED_BYTES and
+	// Internal.MESSAGE_FIXED_BYTES_PER_TOKEN, the bytes of a message posted to the DA layer.
+	messageFixedBytes         = 32 * 15
+	messageFixedBytesPerToken = 32 * (4 + (3 + 2))
+)
+
+// L2FeeQuoterDestChainConfig returns the default FeeQuoter dest chain config with data availability
+// parameters in the range of an optimistic rollup, so that the DA component dominates small messages.
+func L2FeeQuoterDestChainConfig() fee_quoter.FeeQuoterDestChainConfig {
+	cfg := changeset.DefaultFeeQuoterDestChainConfig()
+	cfg.DestDataAvailabilityOverheadGas = 188
+	cfg.DestGasPerDataAvailabilityByte = 16
+	cfg.DestDataAvailabilityMultiplierBps = 6840 // 68.4%
+	return cfg
+}
+
+// DataAvailabilityCost returns the data availability cost of a message in USD with 36 decimals,
+// as computed by FeeQuoter._getDataAvailabilityCost. It is zero when the DA multiplier is zero.
+func DataAvailabilityCost(
+	cfg fee_quoter.FeeQuoterDestChainConfig,
+	daGasPrice *big.Int,
+	dataLen int,
+	numTokens int,
+	tokenTransferBytesOverhead uint32,
+) *big.Int {
+	if cfg.DestDataAvailabilityMultiplierBps == 0 {
+		return big.NewInt(0)
+	}
+	lengthBytes := uint64(messageFixedBytes) + uint64(dataLen) +
+		uint64(numTokens)*messageFixedBytesPerToken + uint64(tokenTransferBytesOverhead)
+	daGas := new(big.Int).SetUint64(lengthBytes*uint64(cfg.DestGasPerDataAvailabilityByte) + uint64(cfg.DestDataAvailabilityOverheadGas))
+	cost := daGas.Mul(daGas, daGasPrice)
+	cost.Mul(cost, new(big.Int).SetUint64(uint64(cfg.DestDataAvailabilityMultiplierBps)))
+	return cost.Mul(cost, big.NewInt(1e14))
+}
+
+// ExecutionCost returns the execution cost of a message in USD with 36 decimals,
+// as computed by FeeQuoter.getValidatedFee for messages without tokens.
+func ExecutionCost(cfg fee_quoter.FeeQuoterDestChainConfig, execGasPrice *big.Int, dataLen int, gasLimit uint64) *big.Int {
+	gas := new(big.Int).SetUint64(uint64(cfg.DestGasOverhead) + uint64(dataLen)*uint64(cfg.DestGasPerPayloadByte) + gasLimit)
+	cost := gas.Mul(gas, execGasPrice)
+	return cost.Mul(cost, new(big.Int).SetUint64(cfg.GasMultiplierWeiPerEth))
+}
+
+// FeeComponents is the breakdown of the fee of a message, in fee token units.
+type FeeComponents struct {
+	Premium          *big.Int
+	Execution        *big.Int
+	DataAvailability *big.Int
+	// Total is the fee charged by the FeeQuoter. It is computed from the sum of the components
+	// in USD before converting to the fee token, so it may exceed the sum of the above by rounding.
+	Total *big.Int
+}
+
+// ExpectedFee recomputes the fee of a message without tokens from the onchain FeeQuoter config
+// and prices of the source chain, breaking it down into its premium, execution and DA components.
+func ExpectedFee(
+	ctx context.Context,
+	state changeset.CCIPOnChainState,
+	src, dest uint64,
+	msg router.ClientEVM2AnyMessage,
+) (FeeComponents, error) {
+	fq := state.Chains[src].FeeQuoter
+	opts := &bind.CallOpts{Context: ctx}
+	cfg, err := fq.GetDestChainConfig(opts, dest)
+	if err != nil {
+		return FeeComponents{}, err
+	}
+	feeToken := msg.FeeToken
+	if feeToken == (common.Address{}) {
+		feeToken, err = state.Chains[src].Router.GetWrappedNative(opts)
+		if err != nil {
+			return FeeComponents{}, err
+		}
+	}
+	feeTokenPrice, err := fq.GetTokenPrice(opts, feeToken)
+	if err != nil {
+		return FeeComponents{}, err
+	}
+	premiumMultiplier, err := fq.GetPremiumMultiplierWeiPerEth(opts, feeToken)
+	if err != nil {
+		return FeeComponents{}, err
+	}
+	gasPrice, err := fq.GetDestinationChainGasPrice(opts, dest)
+	if err != nil {
+		return FeeComponents{}, err
+	}
+	execGasPrice, daGasPrice := changeset.UnpackFee(gasPrice.Value)
+
+	gasLimit := uint64(cfg.DefaultTxGasLimit)
+	if len(msg.ExtraArgs) >= 36 && bytes.Equal(msg.ExtraArgs[:4], evmExtraArgsV2Tag) {
+		gasLimit = new(big.Int).SetBytes(msg.ExtraArgs[4:36]).Uint64()
+	}
+
+	premium := new(big.Int).SetUint64(uint64(cfg.NetworkFeeUSDCents))
+	premium.Mul(premium, big.NewInt(1e16))
+	premium.Mul(premium, new(big.Int).SetUint64(premiumMultiplier))
+	execution := ExecutionCost(cfg, execGasPrice, len(msg.Data), gasLimit)
+	da := DataAvailabilityCost(cfg, daGasPrice, len(msg.Data), 0, 0)
+
+	total := new(big.Int).Add(premium, execution)
+	total.Add(total, da)
+	price := feeTokenPrice.Value
+	return FeeComponents{
+		Premium:          new(big.Int).Div(premium, price),
+		Execution:        new(big.Int).Div(execution, price),
+		DataAvailability: new(big.Int).Div(da, price),
+		Total:            total.Div(total, price),
+	}, nil
+}
+
+// AssertFeeIncludesDataAvailability asserts that the router quotes exactly the expected fee
+// of the message and that the fee has a non-zero data availability component.
+func AssertFeeIncludesDataAvailability(
+	t *testing.T,
+	state changeset.CCIPOnChainState,
+	src, dest uint64,
+	testRouter bool,
+	msg router.ClientEVM2AnyMessage,
+) FeeComponents {
+	expected, err := ExpectedFee(Context(t), state, src, dest, msg)
+	require.NoError(t, err)
+	r := state.Chains[src].Router
+	if testRouter {
+		r = state.Chains[src].TestRouter
+	}
+	fee, err := r.GetFee(&bind.CallOpts{Context: Context(t)}, dest, msg)
+	require.NoError(t, err)
+	require.Equal(t, expected.Total.String(), fee.String(), "quoted fee doesn't match the expected fee")
+	require.Positive(t, expected.DataAvailability.Sign(), "fee has no data availability component")
+	t.Logf("Fee from %d to %d: total %s premium %s execution %s data availability %s",
+		src, dest, expected.Total, expected.Premium, expected.Execution, expected.DataAvailability)
+	return expected
+}
@@ -0,0 +1,132 @@
+package testhelpers
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+)
+
+// GasSnapshotUpdateEnv is the environment variable which, when set to "true", makes
+// AssertGasSnapshot overwrite the checked-in snapshot instead of comparing against it.
+const GasSnapshotUpdateEnv = "CCIP_UPDATE_GAS_SNAPSHOT"
+
+// GasSnapshot maps the names of canonical contract interactions to the gas they used.
+// It is stored in the same format as the forge gas snapshots, one "name (gas: N)" entry per line.
+type GasSnapshot map[string]uint64
+
+// RecordTx records the gas used by the transaction txHash on chain under name.
+func (s GasSnapshot) RecordTx(ctx context.Context, chain deployment.Chain, name string, txHash common.Hash) error {
+	receipt, err := chain.Client.TransactionReceipt(ctx, txHash)
+	if err != nil {
+		return fmt.Errorf("failed to get receipt of tx %s on chain %d: %w", txHash, chain.Selector, err)
+	}
+	s[name] = receipt.GasUsed
+	return nil
+}
+
+// LoadGasSnapshot reads a snapshot from path. Empty lines and lines starting with # are ignored.
+func LoadGasSnapshot(path string) (GasSnapshot, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	s := make(GasSnapshot)
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	for lineNr := 1; scanner.Scan(); lineNr++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		name, gas, ok := strings.Cut(line, " (gas: ")
+		if !ok || !strings.HasSuffix(gas, ")") {
+			return nil, fmt.Errorf("%s:%d: malformed entry %q", path, lineNr, line)
+		}
+		s[name], err = strconv.ParseUint(strings.TrimSuffix(gas, ")"), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: invalid gas: %w", path, lineNr, err)
+		}
+	}
+	return s, scanner.Err()
+}
+
+// Write writes the snapshot to path, sorted by name.
+func (s GasSnapshot) Write(path string) error {
+	names := sortedNames(s)
+	var sb strings.Builder
+	sb.WriteString("# Generated by the CCIP gas snapshot tests, run them with " + GasSnapshotUpdateEnv + "=true to update.\n")
+	for _, name := range names {
+		fmt.Fprintf(&sb, "%s (gas: %d)\n", name, s[name])
+	}
+	return os.WriteFile(path, []byte(sb.String()), 0600)
+}
+
+// Compare returns an error listing the entries which drifted by more than tolerance,
+// a fraction of the baseline gas, from the baseline. Entries missing from either snapshot are not compared.
+func (s GasSnapshot) Compare(baseline GasSnapshot, tolerance float64) error {
+	var drifted []string
+	for _, name := range sortedNames(s) {
+		expected, ok := baseline[name]
+		if !ok {
+			continue
+		}
+		actual := s[name]
+		diff := float64(actual) - float64(expected)
+		if diff < 0 {
+			diff = -diff
+		}
+		if diff > tolerance*float64(expected) {
+			drifted = append(drifted, fmt.Sprintf("%s: %d -> %d (%+.2f%%)",
+				name, expected, actual, 100*(float64(actual)-float64(expected))/float64(expected)))
+		}
+	}
+	if len(drifted) > 0 {
+		return fmt.Errorf("gas usage drifted by more than %.2f%%:\n\t%s", 100*tolerance, strings.Join(drifted, "\n\t"))
+	}
+	return nil
+}
+
+// AssertGasSnapshot compares the recorded snapshot against the one checked in at path, failing the
+// test if an entry is missing from either snapshot or drifted by more than tolerance.
+// If GasSnapshotUpdateEnv is set, the checked-in snapshot is replaced with the recorded entries instead.
+func AssertGasSnapshot(t *testing.T, path string, recorded GasSnapshot, tolerance float64) {
+	if os.Getenv(GasSnapshotUpdateEnv) == "true" {
+		require.NoError(t, recorded.Write(path))
+		t.Logf("Updated gas snapshot %s", path)
+		return
+	}
+	baseline, err := LoadGasSnapshot(path)
+	require.NoError(t, err)
+	for _, name := range sortedNames(recorded) {
+		if _, ok := baseline[name]; !ok {
+			t.Errorf("Gas snapshot %s has no entry for %s (gas: %d), run with %s=true to record it", path, name, recorded[name], GasSnapshotUpdateEnv)
+		}
+	}
+	for _, name := range sortedNames(baseline) {
+		if _, ok := recorded[name]; !ok {
+			t.Errorf("Gas snapshot %s has an entry for %s which was not recorded, run with %s=true to remove it", path, name, GasSnapshotUpdateEnv)
+		}
+	}
+	if err := recorded.Compare(baseline, tolerance); err != nil {
+		t.Errorf("Gas snapshot %s: %v", path, err)
+	}
+}
+
+func sortedNames(s GasSnapshot) []string {
+	names := make([]string, 0, len(s))
+	for name := range s {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,121 @@
+package testhelpers
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	cciptypes "github.com/smartcontractkit/chainlink-ccip/pkg/types/ccipocr3"
+	"github.com/smartcontractkit/chainlink-testing-framework/lib/utils/testcontext"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/router"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+func TestGasSnapshot(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "gas.snapshot")
+	snap := GasSnapshot{"ccipSend (no tokens)": 100_000, "commit (roots: 1)": 200_000}
+	require.NoError(t, snap.Write(path))
+	loaded, err := LoadGasSnapshot(path)
+	require.NoError(t, err)
+	require.Equal(t, snap, loaded)
+
+	require.NoError(t, GasSnapshot{"ccipSend (no tokens)": 104_000, "execute (messages: 1)": 1}.Compare(snap, 0.05))
+	err = GasSnapshot{"ccipSend (no tokens)": 94_000, "commit (roots: 1)": 200_000}.Compare(snap, 0.05)
+	require.ErrorContains(t, err, "ccipSend (no tokens): 100000 -> 94000 (-6.00%)")
+	require.NotContains(t, err.Error(), "commit")
+
+	empty := filepath.Join(t.TempDir(), "empty.snapshot")
+	require.NoError(t, GasSnapshot{}.Write(empty))
+	loaded, err = LoadGasSnapshot(empty)
+	require.NoError(t, err)
+	require.Empty(t, loaded)
+
+	malformed := filepath.Join(t.TempDir(), "malformed.snapshot")
+	require.NoError(t, os.WriteFile(malformed, []byte("ccipSend (gas: lots)\n"), 0600))
+	_, err = LoadGasSnapshot(malformed)
+	require.ErrorContains(t, err, "malformed.snapshot:1: invalid gas")
+}
+
+// TestCCIPGasSnapshot records the gas used by canonical CCIP interactions and compares it against
+// testdata/ccip_gas.snapshot. The messages are sent one at a time, each committed and executed before
+// the next is sent, so that every commit report has a single root and every execution report a single message.
+// Entries are named after the lane, by the indices of the chains sorted by selector, and the kind of message.
+func TestCCIPGasSnapshot(t *testing.T) {
+	lggr := logger.TestLogger(t)
+	e := NewMemoryEnvironmentWithJobsAndContracts(t, lggr, 3, 4, nil)
+	state, err := changeset.LoadOnchainState(e.Env)
+	require.NoError(t, err)
+	ctx := testcontext.Get(t)
+
+	dest := e.FeedChainSel
+	chainIndex := make(map[uint64]int)
+	var srcs []uint64
+	for i, sel := range e.Env.AllChainSelectors() {
+		chainIndex[sel] = i
+		if sel != dest {
+			srcs = append(srcs, sel)
+		}
+	}
+	tokenSrc := srcs[0]
+	token, _, _, _, err := DeployTransferableToken(lggr, e.Env.Chains, tokenSrc, dest, state, e.Env.ExistingAddresses, "GAS")
+	require.NoError(t, err)
+	require.NoError(t, AddLanesForAll(e.Env, state))
+
+	amount := big.NewInt(1e18)
+	srcChain := e.Env.Chains[tokenSrc]
+	tx, err := token.Mint(srcChain.DeployerKey, srcChain.DeployerKey.From, amount)
+	_, err = deployment.ConfirmIfNoError(srcChain, tx, err)
+	require.NoError(t, err)
+	tx, err = token.Approve(srcChain.DeployerKey, state.Chains[tokenSrc].Router.Address(), amount)
+	_, err = deployment.ConfirmIfNoError(srcChain, tx, err)
+	require.NoError(t, err)
+
+	offRamp := state.Chains[dest].OffRamp
+	snap := make(GasSnapshot)
+	send := func(src uint64, kind string, tokenAmounts []router.ClientEVMTokenAmount) {
+		name := fmt.Sprintf("%d->%d (%s)", chainIndex[src], chainIndex[dest], kind)
+		latesthdr, err := e.Env.Chains[dest].Client.HeaderByNumber(ctx, nil)
+		require.NoError(t, err)
+		startBlock := latesthdr.Number.Uint64()
+
+		msgSentEvent := TestSendRequest(t, e.Env, state, src, dest, false, router.ClientEVM2AnyMessage{
+			Receiver:     common.LeftPadBytes(state.Chains[dest].Receiver.Address().Bytes(), 32),
+			Data:         []byte("hello"),
+			TokenAmounts: tokenAmounts,
+			FeeToken:     common.HexToAddress("0x0"),
+			ExtraArgs:    nil,
+		})
+		seqNr := msgSentEvent.SequenceNumber
+		require.NoError(t, snap.RecordTx(ctx, e.Env.Chains[src], "ccipSend "+name, msgSentEvent.Raw.TxHash))
+
+		commitEvent, err := ConfirmCommitWithExpectedSeqNumRange(t, e.Env.Chains[src], e.Env.Chains[dest], offRamp, &startBlock,
+			cciptypes.NewSeqNumRange(cciptypes.SeqNum(seqNr), cciptypes.SeqNum(seqNr)))
+		require.NoError(t, err)
+		require.Len(t, commitEvent.MerkleRoots, 1)
+		require.NoError(t, snap.RecordTx(ctx, e.Env.Chains[dest], "commit "+name, commitEvent.Raw.TxHash))
+
+		_, err = ConfirmExecWithSeqNrs(t, e.Env.Chains[src], e.Env.Chains[dest], offRamp, &startBlock, []uint64{seqNr})
+		require.NoError(t, err)
+		it, err := offRamp.FilterExecutionStateChanged(&bind.FilterOpts{Context: ctx, Start: startBlock}, []uint64{src}, []uint64{seqNr}, nil)
+		require.NoError(t, err)
+		defer it.Close()
+		require.True(t, it.Next(), "no execution of %s", name)
+		require.NoError(t, snap.RecordTx(ctx, e.Env.Chains[dest], "execute "+name, it.Event.Raw.TxHash))
+	}
+	send(tokenSrc, "no tokens", nil)
+	send(tokenSrc, "1 token", []router.ClientEVMTokenAmount{{Token: token.Address(), Amount: amount}})
+	for _, src := range srcs[1:] {
+		send(src, "no tokens", nil)
+	}
+
+	AssertGasSnapshot(t, filepath.Join("testdata", "ccip_gas.snapshot"), snap, 0.05)
+}
@@ -0,0 +1,1358 @@
+package testhelpers
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sort"
+	"testing"
+	"time"
+
+	mapset "github.com/deckarep/golang-set/v2"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+	"github.com/smartcontractkit/chainlink-ccip/pluginconfig"
+
+	commonconfig "github.com/smartcontractkit/chainlink-common/pkg/config"
+
+	commonchangeset "github.com/smartcontractkit/chainlink/deployment/common/changeset"
+	commontypes "github.com/smartcontractkit/chainlink/deployment/common/types"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"go.uber.org/multierr"
+	"go.uber.org/zap/zapcore"
+
+	chainsel "github.com/smartcontractkit/chain-selectors"
+
+	"github.com/smartcontractkit/chainlink-ccip/pkg/reader"
+	cciptypes "github.com/smartcontractkit/chainlink-ccip/pkg/types/ccipocr3"
+	jobv1 "github.com/smartcontractkit/chainlink-protos/job-distributor/v1/job"
+	"github.com/smartcontractkit/chainlink-testing-framework/lib/utils/testcontext"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	commonutils "github.com/smartcontractkit/chainlink-common/pkg/utils"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/deployment/environment/devenv"
+	"github.com/smartcontractkit/chainlink/deployment/environment/memory"
+
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/burn_mint_token_pool"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/mock_v3_aggregator_contract"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/onramp"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/router"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/usdc_token_pool"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/generated/aggregator_v3_interface"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/generated/mock_ethusd_aggregator_wrapper"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/shared/generated/burn_mint_erc677"
+	"github.com/smartcontractkit/chainlink/v2/core/services/ocr2/plugins/ccip/abihelpers"
+)
+
+const (
+	HomeChainIndex = 0
+	FeedChainIndex = 1
+)
+
+var (
+	// bytes4 public constant EVM_EXTRA_ARGS_V2_TAG = 0x181dcf10;
+	evmExtraArgsV2Tag = hexutil.MustDecode("0x181dcf10")
+
+	routerABI = abihelpers.MustParseABI(router.RouterABI)
+)
+
+// Context returns a context with the test's deadline, if available.
+func Context(tb testing.TB) context.Context {
+	ctx := context.Background()
+	var cancel func()
+	switch t := tb.(type) {
+	case *testing.T:
+		if d, ok := t.Deadline(); ok {
+			ctx, cancel = context.WithDeadline(ctx, d)
+		}
+	}
+	if cancel == nil {
+		ctx, cancel = context.WithCancel(ctx)
+	}
+	tb.Cleanup(cancel)
+	return ctx
+}
+
+type DeployedEnv struct {
+	Env          deployment.Environment
+	HomeChainSel uint64
+	FeedChainSel uint64
+	// FeedChainSels are the feed chains of the price regions, FeedChainSel first, see TestConfigs.FeedChains.
+	FeedChainSels []uint64
+	// PriceFeedChains are the feed chains the token prices of the chains are read from, by chain.
+	PriceFeedChains map[uint64]uint64
+	ReplayBlocks    map[uint64]uint64
+	// RMN is set if the commit plugins are backed by in-memory RMN nodes.
+	RMN *InMemoryRMN
+	// Telemetry is set if the plugins of the nodes publish their round data, see TestConfigs.PluginTelemetry.
+	Telemetry *memory.PluginTelemetry
+}
+
+// StopNode stops the node as if it crashed, preserving its state, see deployment.NodeRestarter.
+func (e *DeployedEnv) StopNode(t *testing.T, nodeID string) {
+	restarter, ok := e.Env.Offchain.(deployment.NodeRestarter)
+	require.True(t, ok, "offchain client %T can't restart nodes", e.Env.Offchain)
+	require.NoError(t, restarter.StopNode(testcontext.Get(t), nodeID))
+}
+
+// StartNode starts a stopped node, which resumes processing logs from where it left off.
+func (e *DeployedEnv) StartNode(t *testing.T, nodeID string) {
+	restarter, ok := e.Env.Offchain.(deployment.NodeRestarter)
+	require.True(t, ok, "offchain client %T can't restart nodes", e.Env.Offchain)
+	require.NoError(t, restarter.StartNode(testcontext.Get(t), nodeID))
+}
+
+// RestartNode stops and starts the node.
+func (e *DeployedEnv) RestartNode(t *testing.T, nodeID string) {
+	e.StopNode(t, nodeID)
+	e.StartNode(t, nodeID)
+}
+
+func (e *DeployedEnv) SetupJobs(t *testing.T) {
+	ctx := testcontext.Get(t)
+	jbs, err := changeset.NewCCIPJobSpecs(e.Env.NodeIDs, e.Env.Offchain)
+	require.NoError(t, err)
+	for nodeID, jobs := range jbs {
+		for _, job := range jobs {
+			// Note these auto-accept
+			_, err := e.Env.Offchain.ProposeJob(ctx,
+				&jobv1.ProposeJobRequest{
+					NodeId: nodeID,
+					Spec:   job,
+				})
+			require.NoError(t, err)
+		}
+	}
+	// Wait for plugins to register filters?
+	// TODO: Investigate how to avoid.
+	time.Sleep(30 * time.Second)
+	ReplayLogs(t, e.Env.Offchain, e.ReplayBlocks)
+}
+
+// HealthReport aggregates the failing services of all nodes of the environment. It can be used
+// as a readiness gate in tests, and to check that nodes stay healthy in soak tests.
+func (e *DeployedEnv) HealthReport(ctx context.Context) (deployment.HealthReport, error) {
+	return deployment.NewHealthReport(ctx, e.Env.Offchain)
+}
+
+func ReplayLogs(t *testing.T, oc deployment.OffchainClient, replayBlocks map[uint64]uint64) {
+	switch oc := oc.(type) {
+	case *memory.JobClient:
+		require.NoError(t, oc.ReplayLogs(replayBlocks))
+	case *devenv.JobDistributor:
+		require.NoError(t, oc.ReplayLogs(replayBlocks))
+	default:
+		t.Fatalf("unsupported offchain client type %T", oc)
+	}
+}
+
+// ReplayLogsInRange runs a targeted log replay on all nodes managed by the offchain client, see deployment.LogReplayRequest.
+func ReplayLogsInRange(t *testing.T, oc deployment.OffchainClient, req deployment.LogReplayRequest) {
+	replayer, ok := oc.(deployment.LogReplayer)
+	if !ok {
+		t.Fatalf("offchain client %T does not support targeted log replay", oc)
+	}
+	require.NoError(t, replayer.ReplayLogsInRange(testcontext.Get(t), req))
+}
+
+func DeployTestContracts(t *testing.T,
+	lggr logger.Logger,
+	ab deployment.AddressBook,
+	homeChainSel,
+	feedChainSel uint64,
+	chains map[uint64]deployment.Chain,
+	linkPrice *big.Int,
+	wethPrice *big.Int,
+) deployment.CapabilityRegistryConfig {
+	capReg, err := changeset.DeployCapReg(lggr,
+		// deploying cap reg for the first time on a blank chain state
+		changeset.CCIPOnChainState{
+			Chains: make(map[uint64]changeset.CCIPChainState),
+		}, ab, chains[homeChainSel])
+	require.NoError(t, err)
+	_, err = DeployFeeds(lggr, ab, chains[feedChainSel], linkPrice, wethPrice)
+	require.NoError(t, err)
+	evmChainID, err := chainsel.ChainIdFromSelector(homeChainSel)
+	require.NoError(t, err)
+	return deployment.CapabilityRegistryConfig{
+		EVMChainID: evmChainID,
+		Contract:   capReg.Address,
+	}
+}
+
+func LatestBlocksByChain(ctx context.Context, chains map[uint64]deployment.Chain) (map[uint64]uint64, error) {
+	latestBlocks := make(map[uint64]uint64)
+	for _, chain := range chains {
+		latesthdr, err := chain.Client.HeaderByNumber(ctx, nil)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get latest header for chain %d", chain.Selector)
+		}
+		block := latesthdr.Number.Uint64()
+		latestBlocks[chain.Selector] = block
+	}
+	return latestBlocks, nil
+}
+
+func allocateCCIPChainSelectors(chains map[uint64]deployment.Chain) (homeChainSel uint64, feeChainSel uint64) {
+	// Lower chainSel is home chain.
+	var chainSels []uint64
+	// Say first chain is home chain.
+	for chainSel := range chains {
+		chainSels = append(chainSels, chainSel)
+	}
+	sort.Slice(chainSels, func(i, j int) bool {
+		return chainSels[i] < chainSels[j]
+	})
+	// Take lowest for determinism.
+	return chainSels[HomeChainIndex], chainSels[FeedChainIndex]
+}
+
+// allocateFeedChainSelectors returns numFeedChains feed chains, the one of allocateCCIPChainSelectors first, and
+// assigns the other chains to their regions round robin. Feed chains read the prices of their own feeds.
+func allocateFeedChainSelectors(chains map[uint64]deployment.Chain, numFeedChains int) ([]uint64, map[uint64]uint64) {
+	var chainSels []uint64
+	for chainSel := range chains {
+		chainSels = append(chainSels, chainSel)
+	}
+	sort.Slice(chainSels, func(i, j int) bool {
+		return chainSels[i] < chainSels[j]
+	})
+	feedChainSels := slices.Clone(chainSels[FeedChainIndex : FeedChainIndex+numFeedChains])
+	priceFeedChains := make(map[uint64]uint64)
+	for _, feedChainSel := range feedChainSels {
+		priceFeedChains[feedChainSel] = feedChainSel
+	}
+	var i int
+	for _, chainSel := range chainSels {
+		if _, ok := priceFeedChains[chainSel]; ok {
+			continue
+		}
+		priceFeedChains[chainSel] = feedChainSels[i%numFeedChains]
+		i++
+	}
+	return feedChainSels, priceFeedChains
+}
+
+// NewMemoryEnvironment creates a new CCIP environment
+// with capreg, fee tokens, feeds and nodes set up.
+func NewMemoryEnvironment(
+	t *testing.T,
+	lggr logger.Logger,
+	numChains int,
+	numNodes int,
+	linkPrice *big.Int,
+	wethPrice *big.Int) DeployedEnv {
+	return newMemoryEnvironment(t, lggr, numChains, numNodes, linkPrice, wethPrice, 1, 0, memory.FinalityConfig{}, false, nil)
+}
+
+// newMemoryEnvironment is NewMemoryEnvironment, additionally setting up numFeedChains feed chains,
+// backing the commit plugins by numRMNNodes in-memory RMN nodes if non-zero, emulating the finality
+// of the chains, recording the round data of the plugins if telemetry is set and injecting the byzantine
+// behaviors in the plugin nodes of their index.
+func newMemoryEnvironment(
+	t *testing.T,
+	lggr logger.Logger,
+	numChains int,
+	numNodes int,
+	linkPrice *big.Int,
+	wethPrice *big.Int,
+	numFeedChains int,
+	numRMNNodes int,
+	finality memory.FinalityConfig,
+	telemetry bool,
+	byzantine map[int]memory.ByzantineBehaviors) DeployedEnv {
+	require.GreaterOrEqual(t, numChains, 2, "numChains must be at least 2 for home and feed chains")
+	require.GreaterOrEqual(t, numChains, 1+numFeedChains, "numChains must be at least 1 + numFeedChains for home and feed chains")
+	require.GreaterOrEqual(t, numNodes, 4, "numNodes must be at least 4")
+	ctx := testcontext.Get(t)
+	chains := memory.NewMemoryChainsWithFinality(t, numChains, finality)
+	homeChainSel, feedSel := allocateCCIPChainSelectors(chains)
+	feedSels, priceFeedChains := allocateFeedChainSelectors(chains, numFeedChains)
+	replayBlocks, err := LatestBlocksByChain(ctx, chains)
+	require.NoError(t, err)
+
+	ab := deployment.NewMemoryAddressBook()
+	crConfig := DeployTestContracts(t, lggr, ab, homeChainSel, feedSel, chains, linkPrice, wethPrice)
+	for i, sel := range feedSels[1:] {
+		// the LINK prices of the regions differ, to tell which feed chain a price was read from
+		_, err = DeployFeeds(lggr, ab, chains[sel], new(big.Int).Mul(linkPrice, big.NewInt(int64(i+2))), wethPrice)
+		require.NoError(t, err)
+	}
+	var (
+		rmn             *InMemoryRMN
+		pluginTelemetry *memory.PluginTelemetry
+		plugins         memory.PluginRegistry
+		rmnStatic       = NewTestRMNStaticConfig()
+		rmnDynamic      = NewTestRMNDynamicConfig()
+	)
+	if numRMNNodes > 0 {
+		rmn, err = NewInMemoryRMN(chains, numRMNNodes)
+		require.NoError(t, err)
+		plugins.RMNPeerClient = rmn.NewPeerClient
+		rmnStatic = rmn.RMNHomeStaticConfig()
+		rmnDynamic = rmn.RMNHomeDynamicConfig()
+	}
+	if telemetry {
+		pluginTelemetry = memory.NewPluginTelemetry()
+		plugins.PluginTelemetry = pluginTelemetry
+	}
+	nodeOptions := func(idx int, isBootstrap bool) memory.NodeOptions {
+		if isBootstrap {
+			return memory.NodeOptions{}
+		}
+		return memory.NodeOptions{Byzantine: byzantine[idx]}
+	}
+	nodePlugins := func(int, bool) memory.PluginRegistry { return plugins }
+	nodes := memory.NewNodesWithPlugins(t, zapcore.InfoLevel, chains, numNodes, 1, crConfig, nodeOptions, nodePlugins)
+	for id, node := range nodes {
+		require.NoError(t, node.Start(ctx))
+		nodes[id] = node
+	}
+	e := memory.NewMemoryEnvironmentFromChainsNodes(t, lggr, chains, nodes)
+	t.Cleanup(func() {
+		// nodes may have been restarted, stop their current applications
+		require.NoError(t, e.Offchain.(*memory.JobClient).StopNodes())
+	})
+	envNodes, err := deployment.NodeInfo(e.NodeIDs, e.Offchain)
+	require.NoError(t, err)
+	e.ExistingAddresses = ab
+	out, err := changeset.DeployHomeChain(e, changeset.DeployHomeChainConfig{
+		HomeChainSel:     homeChainSel,
+		RMNStaticConfig:  rmnStatic,
+		RMNDynamicConfig: rmnDynamic,
+		NodeOperators:    NewTestNodeOperator(chains[homeChainSel].DeployerKey.From),
+		NodeP2PIDsPerNodeOpAdmin: map[string][][32]byte{
+			"NodeOperator": envNodes.NonBootstraps().PeerIDs(),
+		},
+	})
+	require.NoError(t, err)
+	require.NoError(t, e.ExistingAddresses.Merge(out.AddressBook))
+
+	return DeployedEnv{
+		Env:             e,
+		HomeChainSel:    homeChainSel,
+		FeedChainSel:    feedSel,
+		FeedChainSels:   feedSels,
+		PriceFeedChains: priceFeedChains,
+		ReplayBlocks:    replayBlocks,
+		RMN:             rmn,
+		Telemetry:       pluginTelemetry,
+	}
+}
+
+// NewMemoryEnvironmentWithJobs creates a new CCIP environment
+// with capreg, fee tokens, feeds, nodes and jobs set up.
+func NewMemoryEnvironmentWithJobs(t *testing.T, lggr logger.Logger, numChains int, numNodes int) DeployedEnv {
+	e := NewMemoryEnvironment(t, lggr, numChains, numNodes, MockLinkPrice, MockWethPrice)
+	e.SetupJobs(t)
+	return e
+}
+
+// mockAttestationResponse mocks the USDC attestation server, it returns random Attestation.
+// We don't need to return exactly the same attestation, because our Mocked USDC contract doesn't rely on any specific
+// value, but instead of that it just checks if the attestation is present. Therefore, it makes the test a bit simpler
+// and doesn't require very detailed mocks. Please see tests in chainlink-ccip for detailed tests using real attestations
+func mockAttestationResponse() *httptest.Server {
+	return NewMockAttestationServer(USDCAttestationResponse, nil)
+}
+
+// AttestationResponseEncoder encodes the response of a mock attestation API to the request.
+type AttestationResponseEncoder func(r *http.Request) (statusCode int, body []byte)
+
+// USDCAttestationResponse is the response of Circle's attestation API for a complete attestation.
+func USDCAttestationResponse(*http.Request) (int, []byte) {
+	return http.StatusOK, []byte(`{
+			"status": "complete",
+			"attestation": "0x9049623e91719ef2aa63c55f357be2529b0e7122ae552c18aff8db58b4633c4d3920ff03d3a6d1ddf11f06bf64d7fd60d45447ac81f527ba628877dc5ca759651b08ffae25a6d3b1411749765244f0a1c131cbfe04430d687a2e12fd9d2e6dc08e118ad95d94ad832332cf3c4f7a4f3da0baa803b7be024b02db81951c0f0714de1b"
+		}`)
+}
+
+// MockAttestationProvider is an attestation provider, other than USDC, of an authenticated API requesting the
+// attestations of message hashes in the body, see MockAttestationResponse.
+type MockAttestationProvider struct {
+	changeset.AttestationAPIConfig
+	Chains []uint64
+}
+
+var _ changeset.AttestationProvider = MockAttestationProvider{}
+
+func (p MockAttestationProvider) ObserverType() string { return "mock-attestation" }
+
+func (p MockAttestationProvider) EnabledChainMap() map[uint64]bool {
+	m := make(map[uint64]bool)
+	for _, chain := range p.Chains {
+		m[chain] = true
+	}
+	return m
+}
+
+func (p MockAttestationProvider) ToTokenDataObserverConfig() []pluginconfig.TokenDataObserverConfig {
+	return []pluginconfig.TokenDataObserverConfig{{Type: p.ObserverType(), Version: "1.0"}}
+}
+
+func (p MockAttestationProvider) AttestationAPI() changeset.AttestationAPIConfig {
+	return p.AttestationAPIConfig
+}
+
+func (p MockAttestationProvider) MessageEncoding() changeset.AttestationMessageEncoding {
+	return changeset.MessageHashInBody
+}
+
+// MockAttestationResponse attests the message hashes of the request of a MockAttestationProvider, with the
+// attestation of every hash being the hash itself.
+func MockAttestationResponse(r *http.Request) (int, []byte) {
+	var req struct {
+		MessageHashes []string `json:"messageHashes"`
+	}
+	if r.Method != http.MethodPost || json.NewDecoder(r.Body).Decode(&req) != nil || len(req.MessageHashes) == 0 {
+		return http.StatusBadRequest, nil
+	}
+	type attestation struct {
+		MessageHash string `json:"messageHash"`
+		Status      string `json:"status"`
+		Attestation string `json:"attestation"`
+	}
+	resp := struct {
+		Attestations []attestation `json:"attestations"`
+	}{}
+	for _, hash := range req.MessageHashes {
+		resp.Attestations = append(resp.Attestations, attestation{MessageHash: hash, Status: "complete", Attestation: hash})
+	}
+	body, err := json.Marshal(resp)
+	if err != nil {
+		return http.StatusInternalServerError, nil
+	}
+	return http.StatusOK, body
+}
+
+// NewMockAttestationServer mocks the attestation API of an attestation provider, see AttestationProvider.
+// Requests missing any of the required headers, e.g. an API key, are rejected with 401.
+func NewMockAttestationServer(encode AttestationResponseEncoder, requiredHeaders map[string]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for name, value := range requiredHeaders {
+			if r.Header.Get(name) != value {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+		}
+		status, body := encode(r)
+		w.WriteHeader(status)
+		if _, err := w.Write(body); err != nil {
+			panic(err)
+		}
+	}))
+}
+
+// NewMockPriceServer mocks the price API of an HTTPPriceSource, which responds with the prices.
+func NewMockPriceServer(prices map[changeset.TokenSymbol]*big.Int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(prices); err != nil {
+			panic(err)
+		}
+	}))
+}
+
+type TestConfigs struct {
+	IsUSDC       bool
+	IsMultiCall3 bool
+	// RMNNodes enables RMN with that many in-memory RMN nodes, see InMemoryRMN.
+	RMNNodes int
+	// Finality emulates the finality of the chains, see memory.FinalityConfig.
+	Finality memory.FinalityConfig
+	// PluginTelemetry records the round data of the plugins of the nodes in DeployedEnv.Telemetry,
+	// e.g. the messages exec skipped and why.
+	PluginTelemetry bool
+	// Byzantine injects the byzantine behaviors in the plugin nodes of their index, see memory.ByzantineBehavior.
+	// The DONs tolerate up to F byzantine nodes, numNodes/3.
+	Byzantine map[int]memory.ByzantineBehaviors
+	// FeedChains sets up that many feed chains, one if zero, and the other chains read the prices of the feed chain
+	// of their region, see DeployedEnv.PriceFeedChains. The LINK price of the i-th feed chain is (i+1) * MockLinkPrice.
+	FeedChains int
+}
+
+func NewMemoryEnvironmentWithJobsAndContracts(t *testing.T, lggr logger.Logger, numChains int, numNodes int, tCfg *TestConfigs) DeployedEnv {
+	var err error
+	var numRMNNodes int
+	var finality memory.FinalityConfig
+	var telemetry bool
+	var byzantine map[int]memory.ByzantineBehaviors
+	numFeedChains := 1
+	if tCfg != nil {
+		numRMNNodes = tCfg.RMNNodes
+		numFeedChains = max(tCfg.FeedChains, 1)
+		finality = tCfg.Finality
+		telemetry = tCfg.PluginTelemetry
+		byzantine = tCfg.Byzantine
+	}
+	e := newMemoryEnvironment(t, lggr, numChains, numNodes, MockLinkPrice, MockWethPrice, numFeedChains, numRMNNodes, finality, telemetry, byzantine)
+	allChains := e.Env.AllChainSelectors()
+	cfg := commontypes.MCMSWithTimelockConfig{
+		Canceller:         commonchangeset.SingleGroupMCMS(t),
+		Bypasser:          commonchangeset.SingleGroupMCMS(t),
+		Proposer:          commonchangeset.SingleGroupMCMS(t),
+		TimelockExecutors: e.Env.AllDeployerKeys(),
+		TimelockMinDelay:  big.NewInt(0),
+	}
+	mcmsCfg := make(map[uint64]commontypes.MCMSWithTimelockConfig)
+	for _, c := range e.Env.AllChainSelectors() {
+		mcmsCfg[c] = cfg
+	}
+	usdc, rmn := tCfg != nil && tCfg.IsUSDC, e.RMN != nil
+	features := make(changeset.ChainFeatureFlags)
+	for _, chain := range allChains {
+		features[chain] = changeset.ChainFeatures{USDC: &usdc, RMN: &rmn}
+	}
+	usdcChains := features.USDCChains()
+	var usdcCfg changeset.USDCAttestationConfig
+	if len(usdcChains) > 0 {
+		server := mockAttestationResponse()
+		endpoint := server.URL
+		usdcCfg = changeset.USDCAttestationConfig{
+			API:         endpoint,
+			APITimeout:  commonconfig.MustNewDuration(time.Second),
+			APIInterval: commonconfig.MustNewDuration(500 * time.Millisecond),
+		}
+		devenv.ReaperFor(t, lggr).TrackServer(server)
+	}
+	// the USDC config and the token prices are formed from the prerequisites, which are deployed first
+	newChains := func(_ deployment.Environment, state changeset.CCIPOnChainState) (changeset.NewChainsConfig, error) {
+		tokenConfig := changeset.NewTestTokenConfig(state.Chains[e.FeedChainSel].USDFeeds)
+		priceSource := make(changeset.RegionalPriceSource)
+		for chain, feedChain := range e.PriceFeedChains {
+			priceSource[chain] = changeset.AggregatorPriceSource{ChainSelector: feedChain, TokenConfig: changeset.NewTestTokenConfig(state.Chains[feedChain].USDFeeds)}
+		}
+		usdcCCTPConfig := make(map[cciptypes.ChainSelector]pluginconfig.USDCCCTPTokenConfig)
+		for _, chain := range usdcChains {
+			chainState := state.Chains[chain]
+			if chainState.MockUSDCTokenMessenger == nil || chainState.MockUSDCTransmitter == nil || chainState.USDCTokenPool == nil {
+				return changeset.NewChainsConfig{}, fmt.Errorf("USDC prerequisites missing on chain %d", chain)
+			}
+			usdcCCTPConfig[cciptypes.ChainSelector(chain)] = pluginconfig.USDCCCTPTokenConfig{
+				SourcePoolAddress:            chainState.USDCTokenPool.Address().String(),
+				SourceMessageTransmitterAddr: chainState.MockUSDCTransmitter.Address().String(),
+			}
+		}
+		ocrParams := make(map[uint64]changeset.CCIPOCRParams)
+		for _, chain := range allChains {
+			ocrParams[chain] = changeset.DefaultOCRParams(e.FeedChainSel, nil, nil)
+		}
+		return changeset.NewChainsConfig{
+			HomeChainSel:       e.HomeChainSel,
+			FeedChainSel:       e.FeedChainSel,
+			ChainsToDeploy:     allChains,
+			TokenConfig:        tokenConfig,
+			OCRSecretsProvider: deployment.TestOCRSecrets{},
+			USDCConfig: changeset.USDCConfig{
+				USDCAttestationConfig: usdcCfg,
+				CCTPTokenConfig:       usdcCCTPConfig,
+			},
+			OCRParams:   ocrParams,
+			PriceSource: priceSource,
+			Features:    features,
+		}, nil
+	}
+	e.Env, err = commonchangeset.ApplyBundles(testcontext.Get(t), lggr, e.Env, changeset.Bundle(changeset.BundleConfig{
+		Prerequisites: changeset.DeployPrerequisiteConfig{
+			ChainSelectors: allChains,
+			Features:       features,
+		},
+		MCMS: mcmsCfg,
+		ChainContracts: changeset.DeployChainContractsConfig{
+			ChainSelectors:    allChains,
+			HomeChainSelector: e.HomeChainSel,
+			Features:          features,
+		},
+		NewChains: newChains,
+	}))
+	require.NoError(t, err)
+
+	state, err := changeset.LoadOnchainState(e.Env)
+	require.NoError(t, err)
+	if e.RMN != nil {
+		require.NoError(t, e.RMN.SetRMNRemoteConfigs(e.Env, state, e.HomeChainSel))
+	}
+	require.NotNil(t, state.Chains[e.HomeChainSel].CapabilityRegistry)
+	require.NotNil(t, state.Chains[e.HomeChainSel].CCIPHome)
+	require.NotNil(t, state.Chains[e.HomeChainSel].RMNHome)
+	for _, chain := range allChains {
+		require.NotNil(t, state.Chains[chain].LinkToken)
+		require.NotNil(t, state.Chains[chain].Weth9)
+		require.NotNil(t, state.Chains[chain].TokenAdminRegistry)
+		require.NotNil(t, state.Chains[chain].RegistryModule)
+		require.NotNil(t, state.Chains[chain].Router)
+		require.NotNil(t, state.Chains[chain].RMNRemote)
+		require.NotNil(t, state.Chains[chain].TestRouter)
+		require.NotNil(t, state.Chains[chain].NonceManager)
+		require.NotNil(t, state.Chains[chain].FeeQuoter)
+		require.NotNil(t, state.Chains[chain].OffRamp)
+		require.NotNil(t, state.Chains[chain].OnRamp)
+	}
+	return e
+}
+
+// memoryEnvironmentConfig is the configuration NewMemoryEnvironmentWithJobsAndContracts builds an environment from.
+type memoryEnvironmentConfig struct {
+	NumChains   int
+	NumNodes    int
+	TestConfigs *TestConfigs
+}
+
+// NewCachedMemoryEnvironmentWithJobsAndContracts is NewMemoryEnvironmentWithJobsAndContracts reusing the environment
+// of the cache built for the same configuration, restored to the state it was built in.
+func NewCachedMemoryEnvironmentWithJobsAndContracts(t *testing.T, cache *memory.EnvironmentCache[DeployedEnv], numChains int, numNodes int, tCfg *TestConfigs) DeployedEnv {
+	cfg := memoryEnvironmentConfig{NumChains: numChains, NumNodes: numNodes, TestConfigs: tCfg}
+	return cache.Get(t, cfg, func(t *testing.T) DeployedEnv {
+		return NewMemoryEnvironmentWithJobsAndContracts(t, logger.Test(t), numChains, numNodes, tCfg)
+	})
+}
+
+// NewDeployedEnvCache returns a cache of DeployedEnv, see memory.EnvironmentCache. The plugin telemetry
+// of the restored environments is reset.
+func NewDeployedEnvCache() *memory.EnvironmentCache[DeployedEnv] {
+	cache := memory.NewEnvironmentCache(func(e DeployedEnv) deployment.Environment { return e.Env })
+	cache.Reset = func(e DeployedEnv) {
+		if e.Telemetry != nil {
+			e.Telemetry.Reset()
+		}
+	}
+	return cache
+}
+
+// CCIPSendOpts override how CCIPSendRequest sends a message, see the CCIPSendOpt functions.
+type CCIPSendOpts struct {
+	// Sender is the account the message is sent from, the deployer key of the source chain by default.
+	Sender *bind.TransactOpts
+	// GasFeeCap and GasTipCap are the EIP-1559 fee caps of the transaction, estimated if nil.
+	GasFeeCap *big.Int
+	GasTipCap *big.Int
+	// Value is sent along with the message instead of the fee of the router, for messages paying fees in the native token.
+	Value   *big.Int
+	Context context.Context
+}
+
+type CCIPSendOpt func(o *CCIPSendOpts)
+
+// WithSender sends the message from the account, e.g. one of many senders of a load test.
+func WithSender(sender *bind.TransactOpts) CCIPSendOpt {
+	return func(o *CCIPSendOpts) {
+		o.Sender = sender
+	}
+}
+
+func WithGasFeeCaps(gasFeeCap, gasTipCap *big.Int) CCIPSendOpt {
+	return func(o *CCIPSendOpts) {
+		o.GasFeeCap = gasFeeCap
+		o.GasTipCap = gasTipCap
+	}
+}
+
+// WithSendValue overrides the native fee sent along with the message, e.g. to test underpaid messages.
+func WithSendValue(value *big.Int) CCIPSendOpt {
+	return func(o *CCIPSendOpts) {
+		o.Value = value
+	}
+}
+
+func WithSendContext(ctx context.Context) CCIPSendOpt {
+	return func(o *CCIPSendOpts) {
+		o.Context = ctx
+	}
+}
+
+// CCIPSendRequest sends the message through the router, or the test router, of src and waits for it to be confirmed.
+// The native fee is sent along with the message if it has no fee token. The transact opts of the sender aren't
+// modified, so messages can be sent concurrently from different senders.
+func CCIPSendRequest(
+	e deployment.Environment,
+	state changeset.CCIPOnChainState,
+	src, dest uint64,
+	testRouter bool,
+	evm2AnyMessage router.ClientEVM2AnyMessage,
+	opts ...CCIPSendOpt,
+) (*types.Transaction, uint64, error) {
+	sendOpts := &CCIPSendOpts{
+		Sender:  e.Chains[src].DeployerKey,
+		Context: context.Background(),
+	}
+	for _, opt := range opts {
+		opt(sendOpts)
+	}
+	msg := router.ClientEVM2AnyMessage{
+		Receiver:     evm2AnyMessage.Receiver,
+		Data:         evm2AnyMessage.Data,
+		TokenAmounts: evm2AnyMessage.TokenAmounts,
+		FeeToken:     evm2AnyMessage.FeeToken,
+		ExtraArgs:    evm2AnyMessage.ExtraArgs,
+	}
+	r := state.Chains[src].Router
+	if testRouter {
+		r = state.Chains[src].TestRouter
+	}
+	txOpts := *sendOpts.Sender
+	txOpts.Context = sendOpts.Context
+	if sendOpts.GasFeeCap != nil {
+		txOpts.GasFeeCap = sendOpts.GasFeeCap
+		txOpts.GasTipCap = sendOpts.GasTipCap
+	}
+	txOpts.Value = sendOpts.Value
+	if txOpts.Value == nil {
+		fee, err := r.GetFee(
+			&bind.CallOpts{Context: sendOpts.Context}, dest, msg)
+		if err != nil {
+			return nil, 0, errors.Wrap(deployment.MaybeDataErr(err), "failed to get fee")
+		}
+		if msg.FeeToken == common.HexToAddress("0x0") {
+			txOpts.Value = fee
+		}
+	}
+	tx, err := r.CcipSend(
+		&txOpts,
+		dest,
+		msg)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "failed to send CCIP message")
+	}
+	blockNum, err := e.Chains[src].Confirm(tx)
+	if err != nil {
+		return tx, 0, errors.Wrap(err, "failed to confirm CCIP message")
+	}
+	return tx, blockNum, nil
+}
+
+// CCIPSendCalldata packs the calldata for the Router's ccipSend method.
+// This is expected to be used in Multicall scenarios (i.e multiple ccipSend calls
+// in a single transaction).
+func CCIPSendCalldata(
+	destChainSelector uint64,
+	evm2AnyMessage router.ClientEVM2AnyMessage,
+) ([]byte, error) {
+	calldata, err := routerABI.Methods["ccipSend"].Inputs.Pack(
+		destChainSelector,
+		evm2AnyMessage,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("pack ccipSend calldata: %w", err)
+	}
+
+	calldata = append(routerABI.Methods["ccipSend"].ID, calldata...)
+	return calldata, nil
+}
+
+func TestSendRequest(
+	t *testing.T,
+	e deployment.Environment,
+	state changeset.CCIPOnChainState,
+	src, dest uint64,
+	testRouter bool,
+	evm2AnyMessage router.ClientEVM2AnyMessage,
+	opts ...CCIPSendOpt,
+) (msgSentEvent *onramp.OnRampCCIPMessageSent) {
+	msgSentEvent, err := SendRequest(e, state, src, dest, testRouter, evm2AnyMessage, opts...)
+	require.NoError(t, err)
+	return msgSentEvent
+}
+
+// SendRequest sends the message with the deployer key of the source chain, unless overridden by the opts,
+// and returns the CCIPMessageSent event emitted by the onramp.
+func SendRequest(
+	e deployment.Environment,
+	state changeset.CCIPOnChainState,
+	src, dest uint64,
+	testRouter bool,
+	evm2AnyMessage router.ClientEVM2AnyMessage,
+	opts ...CCIPSendOpt,
+) (*onramp.OnRampCCIPMessageSent, error) {
+	e.Logger.Infof("Sending CCIP request from chain selector %d to chain selector %d",
+		src, dest)
+	tx, blockNum, err := CCIPSendRequest(
+		e,
+		state,
+		src, dest,
+		testRouter,
+		evm2AnyMessage,
+		opts...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	receipt, err := e.Chains[src].Client.TransactionReceipt(context.Background(), tx.Hash())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get receipt of tx %s on chain %d: %w", tx.Hash(), src, err)
+	}
+	logs, err := changeset.DecodeReceipt(state, src, receipt)
+	if err != nil {
+		return nil, err
+	}
+	var msgSentEvent *onramp.OnRampCCIPMessageSent
+	for _, event := range changeset.DecodedEvents[*onramp.OnRampCCIPMessageSent](logs) {
+		if event.DestChainSelector == dest {
+			msgSentEvent = event
+			break
+		}
+	}
+	if msgSentEvent == nil {
+		return nil, fmt.Errorf("no CCIPMessageSent event in tx %s in block %d on chain %d, logs: %v", tx.Hash(), blockNum, src, logs)
+	}
+	e.Logger.Infof("CCIP message (id %x) sent from chain selector %d to chain selector %d tx %s seqNum %d nonce %d sender %s",
+		msgSentEvent.Message.Header.MessageId[:],
+		src,
+		dest,
+		tx.Hash().String(),
+		msgSentEvent.SequenceNumber,
+		msgSentEvent.Message.Header.Nonce,
+		msgSentEvent.Message.Sender.String(),
+	)
+	return msgSentEvent, nil
+}
+
+// MakeEVMExtraArgsV2 creates the extra args for the EVM2Any message that is destined
+// for an EVM chain. The extra args contain the gas limit and allow out of order flag.
+func MakeEVMExtraArgsV2(gasLimit uint64, allowOOO bool) []byte {
+	// extra args is the tag followed by the gas limit and allowOOO abi-encoded.
+	var extraArgs []byte
+	extraArgs = append(extraArgs, evmExtraArgsV2Tag...)
+	gasLimitBytes := new(big.Int).SetUint64(gasLimit).Bytes()
+	// pad from the left to 32 bytes
+	gasLimitBytes = common.LeftPadBytes(gasLimitBytes, 32)
+
+	// abi-encode allowOOO
+	var allowOOOBytes []byte
+	if allowOOO {
+		allowOOOBytes = append(allowOOOBytes, 1)
+	} else {
+		allowOOOBytes = append(allowOOOBytes, 0)
+	}
+	// pad from the left to 32 bytes
+	allowOOOBytes = common.LeftPadBytes(allowOOOBytes, 32)
+
+	extraArgs = append(extraArgs, gasLimitBytes...)
+	extraArgs = append(extraArgs, allowOOOBytes...)
+	return extraArgs
+}
+
+// AddLanesForAll adds densely connected lanes for all chains in the environment so that each chain
+// is connected to every other chain except itself.
+func AddLanesForAll(e deployment.Environment, state changeset.CCIPOnChainState) error {
+	for source := range e.Chains {
+		for dest := range e.Chains {
+			if source != dest {
+				err := changeset.AddLaneWithDefaultPricesAndFeeQuoterConfig(e, state, source, dest, false)
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+var (
+	MockLinkPrice           = deployment.E18Mult(500)
+	MockWethPrice           = big.NewInt(9e8)
+	MockSymbolToDescription = map[changeset.TokenSymbol]string{
+		changeset.LinkSymbol: changeset.MockLinkAggregatorDescription,
+		changeset.WethSymbol: changeset.MockWETHAggregatorDescription,
+	}
+	MockSymbolToDecimals = map[changeset.TokenSymbol]uint8{
+		changeset.LinkSymbol: changeset.LinkDecimals,
+		changeset.WethSymbol: changeset.WethDecimals,
+	}
+)
+
+func DeployFeeds(
+	lggr logger.Logger,
+	ab deployment.AddressBook,
+	chain deployment.Chain,
+	linkPrice *big.Int,
+	wethPrice *big.Int,
+) (map[string]common.Address, error) {
+	linkTV := deployment.NewTypeAndVersion(changeset.PriceFeed, deployment.Version1_0_0)
+	mockLinkFeed := func(chain deployment.Chain) deployment.ContractDeploy[*aggregator_v3_interface.AggregatorV3Interface] {
+		linkFeed, tx, _, err1 := mock_v3_aggregator_contract.DeployMockV3Aggregator(
+			chain.DeployerKey,
+			chain.Client,
+			changeset.LinkDecimals, // decimals
+			linkPrice,              // initialAnswer
+		)
+		aggregatorCr, err2 := aggregator_v3_interface.NewAggregatorV3Interface(linkFeed, chain.Client)
+
+		return deployment.ContractDeploy[*aggregator_v3_interface.AggregatorV3Interface]{
+			Address: linkFeed, Contract: aggregatorCr, Tv: linkTV, Tx: tx, Err: multierr.Append(err1, err2),
+		}
+	}
+
+	mockWethFeed := func(chain deployment.Chain) deployment.ContractDeploy[*aggregator_v3_interface.AggregatorV3Interface] {
+		wethFeed, tx, _, err1 := mock_ethusd_aggregator_wrapper.DeployMockETHUSDAggregator(
+			chain.DeployerKey,
+			chain.Client,
+			wethPrice, // initialAnswer
+		)
+		aggregatorCr, err2 := aggregator_v3_interface.NewAggregatorV3Interface(wethFeed, chain.Client)
+
+		return deployment.ContractDeploy[*aggregator_v3_interface.AggregatorV3Interface]{
+			Address: wethFeed, Contract: aggregatorCr, Tv: linkTV, Tx: tx, Err: multierr.Append(err1, err2),
+		}
+	}
+
+	linkFeedAddress, linkFeedDescription, err := deploySingleFeed(lggr, ab, chain, mockLinkFeed, changeset.LinkSymbol)
+	if err != nil {
+		return nil, err
+	}
+
+	wethFeedAddress, wethFeedDescription, err := deploySingleFeed(lggr, ab, chain, mockWethFeed, changeset.WethSymbol)
+	if err != nil {
+		return nil, err
+	}
+
+	descriptionToAddress := map[string]common.Address{
+		linkFeedDescription: linkFeedAddress,
+		wethFeedDescription: wethFeedAddress,
+	}
+
+	return descriptionToAddress, nil
+}
+
+func deploySingleFeed(
+	lggr logger.Logger,
+	ab deployment.AddressBook,
+	chain deployment.Chain,
+	deployFunc func(deployment.Chain) deployment.ContractDeploy[*aggregator_v3_interface.AggregatorV3Interface],
+	symbol changeset.TokenSymbol,
+) (common.Address, string, error) {
+	//tokenTV := deployment.NewTypeAndVersion(PriceFeed, deployment.Version1_0_0)
+	mockTokenFeed, err := deployment.DeployContract(lggr, chain, ab, deployFunc)
+	if err != nil {
+		lggr.Errorw("Failed to deploy token feed", "err", err, "symbol", symbol)
+		return common.Address{}, "", err
+	}
+
+	lggr.Infow("deployed mockTokenFeed", "addr", mockTokenFeed.Address)
+
+	desc, err := mockTokenFeed.Contract.Description(&bind.CallOpts{})
+	if err != nil {
+		lggr.Errorw("Failed to get description", "err", err, "symbol", symbol)
+		return common.Address{}, "", err
+	}
+
+	if desc != MockSymbolToDescription[symbol] {
+		lggr.Errorw("Unexpected description for token", "symbol", symbol, "desc", desc)
+		return common.Address{}, "", fmt.Errorf("unexpected description: %s", desc)
+	}
+
+	return mockTokenFeed.Address, desc, nil
+}
+
+func ConfirmRequestOnSourceAndDest(t *testing.T, env deployment.Environment, state changeset.CCIPOnChainState, sourceCS, destCS, expectedSeqNr uint64) error {
+	latesthdr, err := env.Chains[destCS].Client.HeaderByNumber(testcontext.Get(t), nil)
+	require.NoError(t, err)
+	startBlock := latesthdr.Number.Uint64()
+	fmt.Printf("startblock %d", startBlock)
+	msgSentEvent := TestSendRequest(t, env, state, sourceCS, destCS, false, router.ClientEVM2AnyMessage{
+		Receiver:     common.LeftPadBytes(state.Chains[destCS].Receiver.Address().Bytes(), 32),
+		Data:         []byte("hello world"),
+		TokenAmounts: nil,
+		FeeToken:     common.HexToAddress("0x0"),
+		ExtraArgs:    nil,
+	})
+	require.Equal(t, expectedSeqNr, msgSentEvent.SequenceNumber)
+
+	fmt.Printf("Request sent for seqnr %d", msgSentEvent.SequenceNumber)
+	require.NoError(t,
+		commonutils.JustError(ConfirmCommitWithExpectedSeqNumRange(t, env.Chains[sourceCS], env.Chains[destCS], state.Chains[destCS].OffRamp, &startBlock, cciptypes.SeqNumRange{
+			cciptypes.SeqNum(msgSentEvent.SequenceNumber),
+			cciptypes.SeqNum(msgSentEvent.SequenceNumber),
+		})))
+
+	fmt.Printf("Commit confirmed for seqnr %d", msgSentEvent.SequenceNumber)
+	require.NoError(
+		t,
+		commonutils.JustError(
+			ConfirmExecWithSeqNrs(
+				t,
+				env.Chains[sourceCS],
+				env.Chains[destCS],
+				state.Chains[destCS].OffRamp,
+				&startBlock,
+				[]uint64{msgSentEvent.SequenceNumber},
+			),
+		),
+	)
+
+	return nil
+}
+
+// TODO: Remove this to replace with ApplyChangeset
+func ProcessChangeset(t *testing.T, e deployment.Environment, name string, c deployment.ChangesetOutput) {
+	require.NoError(t, ApplyChangesetOutput(e, name, c, commonchangeset.TestXXXMCMSSigner))
+}
+
+// ApplyChangesetOutput signs the proposals of the output with the key of the single signer of the MCMS
+// and executes them on the timelocks of their chains, executes the multisig proposals of the non-EVM chains
+// with the deployer keys, then merges the address book of the output into the existing addresses of the environment.
+// The transactions and the executed proposals are recorded to the audit log of the environment as executed by
+// the named changeset.
+func ApplyChangesetOutput(e deployment.Environment, name string, c deployment.ChangesetOutput, signer *ecdsa.PrivateKey) error {
+	e.Chains = deployment.AuditChains(e.AuditLog, name, e.Chains)
+
+	// TODO: Add support for jobspecs as well
+
+	// sign and execute all proposals provided
+	if len(c.Proposals) != 0 {
+		state, err := changeset.LoadOnchainState(e)
+		if err != nil {
+			return err
+		}
+		for _, prop := range c.Proposals {
+			chains := mapset.NewSet[uint64]()
+			for _, op := range prop.Transactions {
+				chains.Add(uint64(op.ChainIdentifier))
+			}
+
+			signed, err := commonchangeset.SignProposalWithKey(e, &prop, signer)
+			if err != nil {
+				return fmt.Errorf("failed to sign proposal: %w", err)
+			}
+			for _, sel := range chains.ToSlice() {
+				if err := commonchangeset.ExecuteProposalOnChain(e, name, signed, state.Chains[sel].Timelock, sel); err != nil {
+					return fmt.Errorf("failed to execute proposal on chain %d: %w", sel, err)
+				}
+			}
+		}
+	}
+
+	if len(c.MultisigProposals) != 0 {
+		executors, err := commonchangeset.LoadMultisigExecutors(e)
+		if err != nil {
+			return err
+		}
+		for _, prop := range c.MultisigProposals {
+			txIDs, err := deployment.ExecuteMultisigProposal(context.Background(), executors, prop)
+			if err != nil {
+				return err
+			}
+			for _, sel := range prop.ChainSelectors() {
+				if err := deployment.AuditMultisigProposalExecuted(e.AuditLog, name, e, sel, prop.Description, txIDs[sel]); err != nil {
+					return fmt.Errorf("failed to audit proposal executed on chain %d: %w", sel, err)
+				}
+			}
+		}
+	}
+
+	// merge address books
+	if c.AddressBook != nil {
+		if err := e.ExistingAddresses.Merge(c.AddressBook); err != nil {
+			return fmt.Errorf("failed to merge address book: %w", err)
+		}
+	}
+	return nil
+}
+
+func DeployTransferableToken(
+	lggr logger.Logger,
+	chains map[uint64]deployment.Chain,
+	src, dst uint64,
+	state changeset.CCIPOnChainState,
+	addresses deployment.AddressBook,
+	token string,
+) (*burn_mint_erc677.BurnMintERC677, *burn_mint_token_pool.BurnMintTokenPool, *burn_mint_erc677.BurnMintERC677, *burn_mint_token_pool.BurnMintTokenPool, error) {
+	return DeployTransferableTokenWithDecimals(lggr, chains, src, dst, state, addresses, token, 18, 18)
+}
+
+// DeployTransferableTokenWithDecimals is DeployTransferableToken with a token of srcDecimals decimals on src
+// and dstDecimals decimals on dst. The pools scale the amounts between the decimals of both ends,
+// see ExpectedRemoteAmount.
+func DeployTransferableTokenWithDecimals(
+	lggr logger.Logger,
+	chains map[uint64]deployment.Chain,
+	src, dst uint64,
+	state changeset.CCIPOnChainState,
+	addresses deployment.AddressBook,
+	token string,
+	srcDecimals, dstDecimals uint8,
+) (*burn_mint_erc677.BurnMintERC677, *burn_mint_token_pool.BurnMintTokenPool, *burn_mint_erc677.BurnMintERC677, *burn_mint_token_pool.BurnMintTokenPool, error) {
+	// Deploy token and pools
+	srcToken, srcPool, err := deployTransferTokenOneEnd(lggr, chains[src], addresses, token, srcDecimals)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	dstToken, dstPool, err := deployTransferTokenOneEnd(lggr, chains[dst], addresses, token, dstDecimals)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	// The pools send their decimals along with the amount, so the remote pool can only scale
+	// the amount correctly if both pools are deployed with the decimals of their token.
+	if err := changeset.ValidateTokenPoolDecimals(chains[src], srcPool); err != nil {
+		return nil, nil, nil, nil, err
+	}
+	if err := changeset.ValidateTokenPoolDecimals(chains[dst], dstPool); err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	// Attach token pools to registry
+	if err := attachTokenToTheRegistry(chains[src], state.Chains[src], chains[src].DeployerKey, srcToken.Address(), srcPool.Address()); err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	if err := attachTokenToTheRegistry(chains[dst], state.Chains[dst], chains[dst].DeployerKey, dstToken.Address(), dstPool.Address()); err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	// Connect pool to each other
+	if err := setTokenPoolCounterPart(chains[src], srcPool, dst, dstToken.Address(), dstPool.Address()); err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	if err := setTokenPoolCounterPart(chains[dst], dstPool, src, srcToken.Address(), srcPool.Address()); err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	// Add burn/mint permissions
+	if err := changeset.GrantMintBurnPermissions(lggr, chains[src], srcToken, srcPool.Address()); err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	if err := changeset.GrantMintBurnPermissions(lggr, chains[dst], dstToken, dstPool.Address()); err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	return srcToken, srcPool, dstToken, dstPool, nil
+}
+
+// ExpectedRemoteAmount returns the amount released or minted on the destination chain for an amount sent
+// from the source chain, as computed by TokenPool._calculateLocalAmount. Scaling down truncates.
+func ExpectedRemoteAmount(amount *big.Int, srcDecimals, dstDecimals uint8) *big.Int {
+	switch {
+	case srcDecimals > dstDecimals:
+		factor := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(srcDecimals-dstDecimals)), nil)
+		return new(big.Int).Div(amount, factor)
+	case srcDecimals < dstDecimals:
+		factor := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(dstDecimals-srcDecimals)), nil)
+		return new(big.Int).Mul(amount, factor)
+	default:
+		return new(big.Int).Set(amount)
+	}
+}
+
+func setUSDCTokenPoolCounterPart(
+	chain deployment.Chain,
+	tokenPool *usdc_token_pool.USDCTokenPool,
+	destChainSelector uint64,
+	destTokenAddress common.Address,
+	destTokenPoolAddress common.Address,
+) error {
+	allowedCaller := common.LeftPadBytes(destTokenPoolAddress.Bytes(), 32)
+	var fixedAddr [32]byte
+	copy(fixedAddr[:], allowedCaller[:32])
+
+	domain, _ := reader.AllAvailableDomains()[destChainSelector]
+
+	domains := []usdc_token_pool.USDCTokenPoolDomainUpdate{
+		{
+			AllowedCaller:     fixedAddr,
+			DomainIdentifier:  domain,
+			DestChainSelector: destChainSelector,
+			Enabled:           true,
+		},
+	}
+	tx, err := tokenPool.SetDomains(chain.DeployerKey, domains)
+	if err != nil {
+		return err
+	}
+
+	_, err = chain.Confirm(tx)
+	if err != nil {
+		return err
+	}
+
+	pool, err := burn_mint_token_pool.NewBurnMintTokenPool(tokenPool.Address(), chain.Client)
+	if err != nil {
+		return err
+	}
+
+	return setTokenPoolCounterPart(chain, pool, destChainSelector, destTokenAddress, destTokenPoolAddress)
+}
+
+func setTokenPoolCounterPart(
+	chain deployment.Chain,
+	tokenPool *burn_mint_token_pool.BurnMintTokenPool,
+	destChainSelector uint64,
+	destTokenAddress common.Address,
+	destTokenPoolAddress common.Address,
+) error {
+	tx, err := tokenPool.ApplyChainUpdates(
+		chain.DeployerKey,
+		[]uint64{},
+		[]burn_mint_token_pool.TokenPoolChainUpdate{
+			{
+				RemoteChainSelector: destChainSelector,
+				RemotePoolAddresses: [][]byte{common.LeftPadBytes(destTokenPoolAddress.Bytes(), 32)},
+				RemoteTokenAddress:  common.LeftPadBytes(destTokenAddress.Bytes(), 32),
+				OutboundRateLimiterConfig: burn_mint_token_pool.RateLimiterConfig{
+					IsEnabled: false,
+					Capacity:  big.NewInt(0),
+					Rate:      big.NewInt(0),
+				},
+				InboundRateLimiterConfig: burn_mint_token_pool.RateLimiterConfig{
+					IsEnabled: false,
+					Capacity:  big.NewInt(0),
+					Rate:      big.NewInt(0),
+				},
+			},
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to apply chain updates on token pool %s: %w", tokenPool.Address(), err)
+	}
+
+	_, err = chain.Confirm(tx)
+	if err != nil {
+		return err
+	}
+
+	tx, err = tokenPool.AddRemotePool(
+		chain.DeployerKey,
+		destChainSelector,
+		destTokenPoolAddress.Bytes(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to set remote pool on token pool %s: %w", tokenPool.Address(), err)
+	}
+
+	_, err = chain.Confirm(tx)
+	return err
+}
+
+func attachTokenToTheRegistry(
+	chain deployment.Chain,
+	state changeset.CCIPChainState,
+	owner *bind.TransactOpts,
+	token common.Address,
+	tokenPool common.Address,
+) error {
+	pool, err := state.TokenAdminRegistry.GetPool(nil, token)
+	if err != nil {
+		return err
+	}
+	// Pool is already registered, don't reattach it, because it would cause revert
+	if pool != (common.Address{}) {
+		return nil
+	}
+
+	tx, err := state.RegistryModule.RegisterAdminViaOwner(owner, token)
+	if err != nil {
+		return err
+	}
+	_, err = chain.Confirm(tx)
+	if err != nil {
+		return err
+	}
+
+	tx, err = state.TokenAdminRegistry.AcceptAdminRole(owner, token)
+	if err != nil {
+		return err
+	}
+	_, err = chain.Confirm(tx)
+	if err != nil {
+		return err
+	}
+
+	tx, err = state.TokenAdminRegistry.SetPool(owner, token, tokenPool)
+	if err != nil {
+		return err
+	}
+	_, err = chain.Confirm(tx)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func deployTransferTokenOneEnd(
+	lggr logger.Logger,
+	chain deployment.Chain,
+	addressBook deployment.AddressBook,
+	tokenSymbol string,
+	tokenDecimals uint8,
+) (*burn_mint_erc677.BurnMintERC677, *burn_mint_token_pool.BurnMintTokenPool, error) {
+	var rmnAddress, routerAddress string
+	chainAddresses, err := addressBook.AddressesForChain(chain.Selector)
+	if err != nil {
+		return nil, nil, err
+	}
+	for address, v := range chainAddresses {
+		if deployment.NewTypeAndVersion(changeset.ARMProxy, deployment.Version1_0_0) == v {
+			rmnAddress = address
+		}
+		if deployment.NewTypeAndVersion(changeset.Router, deployment.Version1_2_0) == v {
+			routerAddress = address
+		}
+		if rmnAddress != "" && routerAddress != "" {
+			break
+		}
+	}
+
+	tokenContract, err := deployment.DeployContract(lggr, chain, addressBook,
+		func(chain deployment.Chain) deployment.ContractDeploy[*burn_mint_erc677.BurnMintERC677] {
+			USDCTokenAddr, tx, token, err2 := burn_mint_erc677.DeployBurnMintERC677(
+				chain.DeployerKey,
+				chain.Client,
+				tokenSymbol,
+				tokenSymbol,
+				tokenDecimals,
+				new(big.Int).Mul(big.NewInt(1e9), new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(tokenDecimals)), nil)),
+			)
+			return deployment.ContractDeploy[*burn_mint_erc677.BurnMintERC677]{
+				USDCTokenAddr, token, tx, deployment.NewTypeAndVersion(changeset.BurnMintToken, deployment.Version1_0_0), err2,
+			}
+		})
+	if err != nil {
+		lggr.Errorw("Failed to deploy Token ERC677", "err", err)
+		return nil, nil, err
+	}
+
+	tx, err := tokenContract.Contract.GrantMintRole(chain.DeployerKey, chain.DeployerKey.From)
+	if err != nil {
+		return nil, nil, err
+	}
+	_, err = chain.Confirm(tx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tokenPool, err := deployment.DeployContract(lggr, chain, addressBook,
+		func(chain deployment.Chain) deployment.ContractDeploy[*burn_mint_token_pool.BurnMintTokenPool] {
+			tokenPoolAddress, tx, tokenPoolContract, err2 := burn_mint_token_pool.DeployBurnMintTokenPool(
+				chain.DeployerKey,
+				chain.Client,
+				tokenContract.Address,
+				tokenDecimals,
+				[]common.Address{},
+				common.HexToAddress(rmnAddress),
+				common.HexToAddress(routerAddress),
+			)
+			return deployment.ContractDeploy[*burn_mint_token_pool.BurnMintTokenPool]{
+				tokenPoolAddress, tokenPoolContract, tx, deployment.NewTypeAndVersion(changeset.BurnMintTokenPool, deployment.Version1_0_0), err2,
+			}
+		})
+	if err != nil {
+		lggr.Errorw("Failed to deploy token pool", "err", err)
+		return nil, nil, err
+	}
+
+	return tokenContract.Contract, tokenPool.Contract, nil
+}
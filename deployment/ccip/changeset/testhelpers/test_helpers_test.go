@@ -0,0 +1,243 @@
+package testhelpers
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/smartcontractkit/chainlink-testing-framework/lib/utils/testcontext"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/burn_mint_token_pool"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/onramp"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/router"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/shared/generated/burn_mint_erc677"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+func TestExpectedRemoteAmount(t *testing.T) {
+	for _, tc := range []struct {
+		name                     string
+		amount                   *big.Int
+		srcDecimals, dstDecimals uint8
+		expected                 *big.Int
+	}{
+		{"same decimals", big.NewInt(1234), 18, 18, big.NewInt(1234)},
+		{"6 to 18", big.NewInt(1e6), 6, 18, big.NewInt(1e18)},
+		{"18 to 6", big.NewInt(1e18), 18, 6, big.NewInt(1e6)},
+		{"18 to 6 truncates", big.NewInt(1e12 - 1), 18, 6, big.NewInt(0)},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected.String(), ExpectedRemoteAmount(tc.amount, tc.srcDecimals, tc.dstDecimals).String())
+		})
+	}
+}
+
+// TestTokenTransferWithDifferentDecimals transfers a token with 6 decimals on one chain
+// and 18 decimals on the other in both directions.
+func TestTokenTransferWithDifferentDecimals(t *testing.T) {
+	lggr := logger.TestLogger(t)
+	e := NewMemoryEnvironmentWithJobsAndContracts(t, lggr, 2, 4, nil)
+	state, err := changeset.LoadOnchainState(e.Env)
+	require.NoError(t, err)
+
+	chainA, chainB := e.HomeChainSel, e.FeedChainSel
+	decimals := map[uint64]uint8{chainA: 6, chainB: 18}
+	tokenA, poolA, tokenB, _, err := DeployTransferableTokenWithDecimals(
+		lggr, e.Env.Chains, chainA, chainB, state, e.Env.ExistingAddresses, "MY_USDC", decimals[chainA], decimals[chainB])
+	require.NoError(t, err)
+
+	// a pool deployed with the decimals of the other side is rejected
+	wrongPool, err := deployment.DeployContract(lggr, e.Env.Chains[chainA], deployment.NewMemoryAddressBook(),
+		func(chain deployment.Chain) deployment.ContractDeploy[*burn_mint_token_pool.BurnMintTokenPool] {
+			addr, tx, pool, err2 := burn_mint_token_pool.DeployBurnMintTokenPool(chain.DeployerKey, chain.Client,
+				tokenA.Address(), decimals[chainB], []common.Address{}, state.Chains[chainA].RMNProxyNew.Address(), state.Chains[chainA].Router.Address())
+			return deployment.ContractDeploy[*burn_mint_token_pool.BurnMintTokenPool]{
+				Address: addr, Contract: pool, Tx: tx, Err: err2,
+				Tv: deployment.NewTypeAndVersion(changeset.BurnMintTokenPool, deployment.Version1_0_0),
+			}
+		})
+	require.NoError(t, err)
+	require.ErrorContains(t, changeset.ValidateTokenPoolDecimals(e.Env.Chains[chainA], wrongPool.Contract), "decimals")
+	require.NoError(t, changeset.ValidateTokenPoolDecimals(e.Env.Chains[chainA], poolA))
+
+	require.NoError(t, AddLanesForAll(e.Env, state))
+
+	tokens := map[uint64]*burn_mint_erc677.BurnMintERC677{chainA: tokenA, chainB: tokenB}
+	amounts := map[uint64]*big.Int{
+		chainA: big.NewInt(1_500_000),                              // 1.5 tokens with 6 decimals
+		chainB: new(big.Int).Mul(big.NewInt(25), big.NewInt(1e17)), // 2.5 tokens with 18 decimals
+	}
+	for sel, token := range tokens {
+		chain := e.Env.Chains[sel]
+		tx, err := token.Mint(chain.DeployerKey, chain.DeployerKey.From, amounts[sel])
+		_, err = deployment.ConfirmIfNoError(chain, tx, err)
+		require.NoError(t, err)
+		tx, err = token.Approve(chain.DeployerKey, state.Chains[sel].Router.Address(), amounts[sel])
+		_, err = deployment.ConfirmIfNoError(chain, tx, err)
+		require.NoError(t, err)
+	}
+
+	startBlocks := make(map[uint64]*uint64)
+	expectedSeqNumExec := make(map[changeset.SourceDestPair][]uint64)
+	for src, dest := range map[uint64]uint64{chainA: chainB, chainB: chainA} {
+		latesthdr, err := e.Env.Chains[dest].Client.HeaderByNumber(testcontext.Get(t), nil)
+		require.NoError(t, err)
+		block := latesthdr.Number.Uint64()
+		startBlocks[dest] = &block
+		msgSentEvent := TestSendRequest(t, e.Env, state, src, dest, false, router.ClientEVM2AnyMessage{
+			Receiver:     common.LeftPadBytes(state.Chains[dest].Receiver.Address().Bytes(), 32),
+			Data:         []byte("hello"),
+			TokenAmounts: []router.ClientEVMTokenAmount{{Token: tokens[src].Address(), Amount: amounts[src]}},
+			FeeToken:     common.HexToAddress("0x0"),
+			ExtraArgs:    nil,
+		})
+		expectedSeqNumExec[changeset.SourceDestPair{
+			SourceChainSelector: src,
+			DestChainSelector:   dest,
+		}] = []uint64{msgSentEvent.SequenceNumber}
+	}
+	ConfirmExecWithSeqNrsForAll(t, e.Env, state, expectedSeqNumExec, startBlocks)
+
+	balanceA, err := tokenA.BalanceOf(nil, state.Chains[chainA].Receiver.Address())
+	require.NoError(t, err)
+	require.Equal(t, ExpectedRemoteAmount(amounts[chainB], decimals[chainB], decimals[chainA]).String(), balanceA.String())
+	require.Equal(t, "2500000", balanceA.String())
+	balanceB, err := tokenB.BalanceOf(nil, state.Chains[chainB].Receiver.Address())
+	require.NoError(t, err)
+	require.Equal(t, ExpectedRemoteAmount(amounts[chainA], decimals[chainA], decimals[chainB]).String(), balanceB.String())
+	require.Equal(t, "1500000000000000000", balanceB.String())
+}
+
+// TestRollingRestart restarts the nodes of the DON one at a time, asserting that messages sent while a node is down
+// are committed and executed, and that the restarted nodes resume processing the logs of the chains.
+func TestRollingRestart(t *testing.T) {
+	e := NewMemoryEnvironmentWithJobsAndContracts(t, logger.TestLogger(t), 2, 4, nil)
+	state, err := changeset.LoadOnchainState(e.Env)
+	require.NoError(t, err)
+	require.NoError(t, AddLanesForAll(e.Env, state))
+	src, dest := e.HomeChainSel, e.FeedChainSel
+	pair := changeset.SourceDestPair{SourceChainSelector: src, DestChainSelector: dest}
+	msg := router.ClientEVM2AnyMessage{
+		Receiver:     common.LeftPadBytes(state.Chains[dest].Receiver.Address().Bytes(), 32),
+		Data:         []byte("hello"),
+		TokenAmounts: nil,
+		FeeToken:     common.HexToAddress("0x0"),
+		ExtraArgs:    nil,
+	}
+	sendAndConfirm := func() *onramp.OnRampCCIPMessageSent {
+		latesthdr, err := e.Env.Chains[dest].Client.HeaderByNumber(testcontext.Get(t), nil)
+		require.NoError(t, err)
+		block := latesthdr.Number.Uint64()
+		startBlocks := map[uint64]*uint64{dest: &block}
+		msgSentEvent := TestSendRequest(t, e.Env, state, src, dest, false, msg)
+		ConfirmCommitForAllWithExpectedSeqNums(t, e.Env, state, map[changeset.SourceDestPair]uint64{pair: msgSentEvent.SequenceNumber}, startBlocks)
+		states := ConfirmExecWithSeqNrsForAll(t, e.Env, state, map[changeset.SourceDestPair][]uint64{pair: {msgSentEvent.SequenceNumber}}, startBlocks)
+		require.Equal(t, changeset.EXECUTION_STATE_SUCCESS, states[pair][msgSentEvent.SequenceNumber])
+		return msgSentEvent
+	}
+	sendAndConfirm()
+
+	nodes, err := deployment.NodeInfo(e.Env.NodeIDs, e.Env.Offchain)
+	require.NoError(t, err)
+	for _, node := range nodes.NonBootstraps() {
+		e.StopNode(t, node.NodeID)
+		// the remaining nodes tolerate the faulty node
+		sendAndConfirm()
+		e.StartNode(t, node.NodeID)
+	}
+
+	msgSentEvent := sendAndConfirm()
+	telemetry, ok := e.Env.Offchain.(deployment.NodeTelemetry)
+	require.True(t, ok)
+	require.Eventually(t, func() bool {
+		observations, err := telemetry.LogObservations(testcontext.Get(t), src, state.Chains[src].OnRamp.Address(),
+			onramp.OnRampCCIPMessageSent{}.Topic(), msgSentEvent.Raw.TxHash)
+		require.NoError(t, err)
+		return len(observations) >= len(nodes.NonBootstraps())
+	}, time.Minute, time.Second, "restarted nodes did not resume log processing")
+}
+
+// TestCCIPSendRequestOpts sends messages concurrently from several senders, with fee caps and fee overrides.
+func TestCCIPSendRequestOpts(t *testing.T) {
+	e := NewMemoryEnvironmentWithJobsAndContracts(t, logger.TestLogger(t), 2, 4, nil)
+	state, err := changeset.LoadOnchainState(e.Env)
+	require.NoError(t, err)
+	require.NoError(t, AddLanesForAll(e.Env, state))
+	allChains := e.Env.AllChainSelectors()
+	src, dest := allChains[0], allChains[1]
+	chain := e.Env.Chains[src]
+	msg := router.ClientEVM2AnyMessage{
+		Receiver:     common.LeftPadBytes(state.Chains[dest].Receiver.Address().Bytes(), 32),
+		Data:         []byte("hello"),
+		TokenAmounts: nil,
+		FeeToken:     common.HexToAddress("0x0"),
+		ExtraArgs:    nil,
+	}
+
+	var senders []*bind.TransactOpts
+	for range 3 {
+		key, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		sender, err := bind.NewKeyedTransactorWithChainID(key, big.NewInt(1337))
+		require.NoError(t, err)
+		fundSender(t, chain, sender.From)
+		senders = append(senders, sender)
+	}
+	gasFeeCap, gasTipCap := big.NewInt(100e9), big.NewInt(2e9)
+	var grp errgroup.Group
+	for _, sender := range senders {
+		grp.Go(func() error {
+			tx, _, err := CCIPSendRequest(e.Env, state, src, dest, false, msg,
+				WithSender(sender), WithGasFeeCaps(gasFeeCap, gasTipCap), WithSendContext(testcontext.Get(t)))
+			if err != nil {
+				return err
+			}
+			from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+			if err != nil {
+				return err
+			}
+			if from != sender.From || tx.GasFeeCap().Cmp(gasFeeCap) != 0 || tx.GasTipCap().Cmp(gasTipCap) != 0 {
+				return fmt.Errorf("message of %s not sent as requested", sender.From)
+			}
+			return nil
+		})
+	}
+	require.NoError(t, grp.Wait())
+	for _, sender := range senders {
+		require.Nil(t, sender.Value)
+	}
+	require.Nil(t, chain.DeployerKey.Value)
+
+	// underpaid fees are rejected by the router
+	_, _, err = CCIPSendRequest(e.Env, state, src, dest, false, msg, WithSendValue(big.NewInt(1)))
+	require.Error(t, err)
+}
+
+func fundSender(t *testing.T, chain deployment.Chain, to common.Address) {
+	ctx := testcontext.Get(t)
+	nonce, err := chain.Client.PendingNonceAt(ctx, chain.DeployerKey.From)
+	require.NoError(t, err)
+	gasPrice, err := chain.Client.SuggestGasPrice(ctx)
+	require.NoError(t, err)
+	tx, err := chain.DeployerKey.Signer(chain.DeployerKey.From, types.NewTx(&types.LegacyTx{
+		Nonce:    nonce,
+		GasPrice: gasPrice,
+		Gas:      21000,
+		To:       &to,
+		Value:    deployment.E18Mult(10),
+	}))
+	require.NoError(t, err)
+	require.NoError(t, chain.Client.SendTransaction(ctx, tx))
+	_, err = chain.Confirm(tx)
+	require.NoError(t, err)
+}
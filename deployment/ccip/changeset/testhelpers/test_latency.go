@@ -0,0 +1,172 @@
+package testhelpers
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset"
+	"github.com/smartcontractkit/chainlink/deployment/environment/memory"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/offramp"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/onramp"
+)
+
+// CommitLatencyPhase is a phase of the latency between sending a message and
+// the finalization of the commit report covering it.
+type CommitLatencyPhase string
+
+const (
+	// CommitPhaseObserved ends when the first node's source reader observed the CCIPMessageSent log.
+	CommitPhaseObserved CommitLatencyPhase = "observed"
+	// CommitPhaseReported ends when a report including the message was accepted for transmission.
+	CommitPhaseReported CommitLatencyPhase = "reported"
+	// CommitPhaseTransmitted ends when the report was first broadcast to the destination chain.
+	CommitPhaseTransmitted CommitLatencyPhase = "transmitted"
+	// CommitPhaseFinalized ends when the block including the report was finalized.
+	CommitPhaseFinalized CommitLatencyPhase = "finalized"
+)
+
+// CommitLatencyPhases lists the phases in the order they happen.
+var CommitLatencyPhases = []CommitLatencyPhase{
+	CommitPhaseObserved,
+	CommitPhaseReported,
+	CommitPhaseTransmitted,
+	CommitPhaseFinalized,
+}
+
+// CommitLatency breaks the send to commit latency of a message down into phases,
+// see CommitLatencyPhases.
+type CommitLatency struct {
+	SentAt        time.Time
+	ObservedAt    time.Time
+	ReportedAt    time.Time
+	TransmittedAt time.Time
+	FinalizedAt   time.Time
+	// Observations maps node IDs to when they observed the message, which helps to spot slow source readers.
+	Observations map[string]time.Time
+	// TransmitterNodeID is the node which transmitted the commit report.
+	TransmitterNodeID string
+}
+
+// Phases returns the duration of every phase.
+func (l CommitLatency) Phases() map[CommitLatencyPhase]time.Duration {
+	return map[CommitLatencyPhase]time.Duration{
+		CommitPhaseObserved:    l.ObservedAt.Sub(l.SentAt),
+		CommitPhaseReported:    l.ReportedAt.Sub(l.ObservedAt),
+		CommitPhaseTransmitted: l.TransmittedAt.Sub(l.ReportedAt),
+		CommitPhaseFinalized:   l.FinalizedAt.Sub(l.TransmittedAt),
+	}
+}
+
+// Total returns the latency from sending the message to the finalization of its commit report.
+func (l CommitLatency) Total() time.Duration {
+	return l.FinalizedAt.Sub(l.SentAt)
+}
+
+func (l CommitLatency) String() string {
+	phases := l.Phases()
+	var sb strings.Builder
+	for _, phase := range CommitLatencyPhases {
+		fmt.Fprintf(&sb, "%s=%s ", phase, phases[phase])
+	}
+	fmt.Fprintf(&sb, "total=%s transmitter=%s", l.Total(), l.TransmitterNodeID)
+	return sb.String()
+}
+
+// CommitLatencyBudget bounds the duration of every phase. Phases without a budget are not checked.
+type CommitLatencyBudget map[CommitLatencyPhase]time.Duration
+
+// Check returns an error listing the phases of l which exceeded their budget.
+func (b CommitLatencyBudget) Check(l CommitLatency) error {
+	phases := l.Phases()
+	var exceeded []string
+	for _, phase := range CommitLatencyPhases {
+		budget, ok := b[phase]
+		if !ok {
+			continue
+		}
+		if phases[phase] > budget {
+			exceeded = append(exceeded, fmt.Sprintf("%s took %s, budget %s", phase, phases[phase], budget))
+		}
+	}
+	if len(exceeded) > 0 {
+		return fmt.Errorf("commit latency budget exceeded: %s", strings.Join(exceeded, "; "))
+	}
+	return nil
+}
+
+// MeasureCommitLatency correlates the given message and the commit report covering it with
+// the telemetry of the nodes to break down the commit latency, see CommitLatency.
+// sentAt is the time right before the message was sent. It waits for the report to be finalized,
+// so it should be called right after the report was committed.
+func MeasureCommitLatency(
+	t *testing.T,
+	e deployment.Environment,
+	state changeset.CCIPOnChainState,
+	sentAt time.Time,
+	msgSentEvent *onramp.OnRampCCIPMessageSent,
+	commitEvent *offramp.OffRampCommitReportAccepted,
+) CommitLatency {
+	ctx := tests.Context(t)
+	telemetry, ok := e.Offchain.(deployment.NodeTelemetry)
+	require.True(t, ok, "offchain client %T does not support node telemetry", e.Offchain)
+	src := msgSentEvent.Message.Header.SourceChainSelector
+	dest := msgSentEvent.DestChainSelector
+
+	observations, err := telemetry.LogObservations(ctx, src, state.Chains[src].OnRamp.Address(),
+		onramp.OnRampCCIPMessageSent{}.Topic(), msgSentEvent.Raw.TxHash)
+	require.NoError(t, err)
+	require.NotEmpty(t, observations, "message %x was not observed by any node", msgSentEvent.Message.Header.MessageId)
+	observedAt := make([]time.Time, 0, len(observations))
+	for _, at := range observations {
+		observedAt = append(observedAt, at)
+	}
+	sort.Slice(observedAt, func(i, j int) bool { return observedAt[i].Before(observedAt[j]) })
+
+	sub, err := telemetry.TxSubmission(ctx, dest, commitEvent.Raw.TxHash)
+	require.NoError(t, err)
+
+	return CommitLatency{
+		SentAt:            sentAt,
+		ObservedAt:        observedAt[0],
+		ReportedAt:        sub.CreatedAt,
+		TransmittedAt:     sub.BroadcastAt,
+		FinalizedAt:       waitForFinalized(t, e.Chains[dest], commitEvent.Raw.BlockNumber),
+		Observations:      observations,
+		TransmitterNodeID: sub.NodeID,
+	}
+}
+
+// AssertCommitLatencyBudget logs the breakdown of the commit latency and fails the test
+// if any phase exceeded its budget.
+func AssertCommitLatencyBudget(t *testing.T, l CommitLatency, budget CommitLatencyBudget) {
+	t.Logf("Commit latency: %s", l)
+	require.NoError(t, budget.Check(l))
+}
+
+// waitForFinalized waits until block is finalized on the chain and returns when that was observed.
+func waitForFinalized(t *testing.T, chain deployment.Chain, block uint64) time.Time {
+	var finalizedAt time.Time
+	require.Eventually(t, func() bool {
+		// if it's simulated backend, commit to ensure mining
+		if backend, ok := memory.AsBackend(chain.Client); ok {
+			backend.Commit()
+		}
+		hdr, err := chain.Client.HeaderByNumber(tests.Context(t), big.NewInt(rpc.FinalizedBlockNumber.Int64()))
+		if err != nil || hdr.Number.Uint64() < block {
+			return false
+		}
+		finalizedAt = time.Now()
+		return true
+	}, 5*time.Minute, 100*time.Millisecond, "block %d was not finalized on chain %d", block, chain.Selector)
+	return finalizedAt
+}
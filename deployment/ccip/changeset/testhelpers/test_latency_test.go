@@ -0,0 +1,83 @@
+package testhelpers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	cciptypes "github.com/smartcontractkit/chainlink-ccip/pkg/types/ccipocr3"
+	"github.com/smartcontractkit/chainlink-testing-framework/lib/utils/testcontext"
+
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/router"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+func TestCommitLatencyBudget(t *testing.T) {
+	sentAt := time.Now()
+	l := CommitLatency{
+		SentAt:        sentAt,
+		ObservedAt:    sentAt.Add(time.Second),
+		ReportedAt:    sentAt.Add(4 * time.Second),
+		TransmittedAt: sentAt.Add(5 * time.Second),
+		FinalizedAt:   sentAt.Add(15 * time.Second),
+	}
+	require.Equal(t, map[CommitLatencyPhase]time.Duration{
+		CommitPhaseObserved:    time.Second,
+		CommitPhaseReported:    3 * time.Second,
+		CommitPhaseTransmitted: time.Second,
+		CommitPhaseFinalized:   10 * time.Second,
+	}, l.Phases())
+	require.Equal(t, 15*time.Second, l.Total())
+
+	require.NoError(t, CommitLatencyBudget{}.Check(l))
+	require.NoError(t, CommitLatencyBudget{CommitPhaseReported: 3 * time.Second}.Check(l))
+	err := CommitLatencyBudget{
+		CommitPhaseObserved:  2 * time.Second,
+		CommitPhaseReported:  2 * time.Second,
+		CommitPhaseFinalized: 5 * time.Second,
+	}.Check(l)
+	require.ErrorContains(t, err, "reported took 3s, budget 2s; finalized took 10s, budget 5s")
+	require.NotContains(t, err.Error(), "observed")
+}
+
+func TestCommitLatencyBreakdown(t *testing.T) {
+	lggr := logger.TestLogger(t)
+	e := NewMemoryEnvironmentWithJobsAndContracts(t, lggr, 2, 4, nil)
+	state, err := changeset.LoadOnchainState(e.Env)
+	require.NoError(t, err)
+	src, dest := e.HomeChainSel, e.FeedChainSel
+	ReplayLogs(t, e.Env.Offchain, e.ReplayBlocks)
+	require.NoError(t, AddLanesForAll(e.Env, state))
+
+	latesthdr, err := e.Env.Chains[dest].Client.HeaderByNumber(testcontext.Get(t), nil)
+	require.NoError(t, err)
+	block := latesthdr.Number.Uint64()
+	sentAt := time.Now()
+	msgSentEvent := TestSendRequest(t, e.Env, state, src, dest, false, router.ClientEVM2AnyMessage{
+		Receiver:     common.LeftPadBytes(state.Chains[dest].Receiver.Address().Bytes(), 32),
+		Data:         []byte("hello"),
+		TokenAmounts: nil,
+		FeeToken:     common.HexToAddress("0x0"),
+		ExtraArgs:    nil,
+	})
+	seqNr := cciptypes.SeqNum(msgSentEvent.SequenceNumber)
+	commitEvent, err := ConfirmCommitWithExpectedSeqNumRange(t, e.Env.Chains[src], e.Env.Chains[dest], state.Chains[dest].OffRamp,
+		&block, cciptypes.NewSeqNumRange(seqNr, seqNr))
+	require.NoError(t, err)
+
+	l := MeasureCommitLatency(t, e.Env, state, sentAt, msgSentEvent, commitEvent)
+	require.NotEmpty(t, l.TransmitterNodeID)
+	for _, phase := range CommitLatencyPhases {
+		require.GreaterOrEqual(t, l.Phases()[phase], time.Duration(0), "phase %s", phase)
+	}
+	// generous budgets, these catch phases that regress by an order of magnitude
+	AssertCommitLatencyBudget(t, l, CommitLatencyBudget{
+		CommitPhaseObserved:    30 * time.Second,
+		CommitPhaseReported:    2 * time.Minute,
+		CommitPhaseTransmitted: 30 * time.Second,
+		CommitPhaseFinalized:   time.Minute,
+	})
+}
@@ -0,0 +1,234 @@
+package testhelpers
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	cciptypes "github.com/smartcontractkit/chainlink-ccip/pkg/types/ccipocr3"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset"
+	"github.com/smartcontractkit/chainlink/deployment/environment/memory"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/evm_2_evm_offramp"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/maybe_revert_message_receiver"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/router"
+)
+
+// LaneDelivery describes how a lane delivered a message.
+type LaneDelivery struct {
+	MessageID      [32]byte
+	SequenceNumber uint64
+	// Nonce is zero for out of order messages.
+	Nonce          uint64
+	Sender         common.Address
+	Data           []byte
+	Fee            *big.Int
+	ExecutionState uint8
+	ReceiverCalled bool
+}
+
+// LaneDiff holds the deliveries of the same message by a legacy 1.5 lane and a 1.6 lane.
+type LaneDiff struct {
+	Legacy  LaneDelivery
+	Current LaneDelivery
+}
+
+// Mismatches lists the delivery semantics the lanes disagree on. Message IDs, sequence numbers
+// and nonces are per lane and only compared in whether the message was ordered. Fees are not
+// compared, as the fee models differ slightly between the versions, see FeeDeviation.
+func (d LaneDiff) Mismatches() []string {
+	var mismatches []string
+	if d.Legacy.Sender != d.Current.Sender {
+		mismatches = append(mismatches, fmt.Sprintf("sender: legacy %s, current %s", d.Legacy.Sender, d.Current.Sender))
+	}
+	if string(d.Legacy.Data) != string(d.Current.Data) {
+		mismatches = append(mismatches, fmt.Sprintf("data: legacy %x, current %x", d.Legacy.Data, d.Current.Data))
+	}
+	if (d.Legacy.Nonce == 0) != (d.Current.Nonce == 0) {
+		mismatches = append(mismatches, fmt.Sprintf("ordering: legacy nonce %d, current nonce %d", d.Legacy.Nonce, d.Current.Nonce))
+	}
+	if d.Legacy.ExecutionState != d.Current.ExecutionState {
+		mismatches = append(mismatches, fmt.Sprintf("execution state: legacy %s, current %s",
+			changeset.ExecutionStateToString(d.Legacy.ExecutionState), changeset.ExecutionStateToString(d.Current.ExecutionState)))
+	}
+	if d.Legacy.ReceiverCalled != d.Current.ReceiverCalled {
+		mismatches = append(mismatches, fmt.Sprintf("receiver called: legacy %t, current %t", d.Legacy.ReceiverCalled, d.Current.ReceiverCalled))
+	}
+	return mismatches
+}
+
+// FeeDeviation returns the difference of the legacy fee from the current fee, as a fraction of the current fee.
+func (d LaneDiff) FeeDeviation() float64 {
+	if d.Current.Fee == nil || d.Current.Fee.Sign() == 0 {
+		return 0
+	}
+	diff, _ := new(big.Float).Quo(
+		new(big.Float).SetInt(new(big.Int).Sub(d.Legacy.Fee, d.Current.Fee)),
+		new(big.Float).SetInt(d.Current.Fee),
+	).Float64()
+	return diff
+}
+
+// DiffLanes sends the same message through the legacy lane (test router) and the 1.6 lane (router) from src to dest,
+// waits for both to be executed and returns how they delivered it.
+// The message must not transfer tokens, see DeployLegacyLanes.
+func DiffLanes(
+	t *testing.T,
+	e deployment.Environment,
+	state changeset.CCIPOnChainState,
+	src, dest uint64,
+	msg router.ClientEVM2AnyMessage,
+) LaneDiff {
+	require.Empty(t, msg.TokenAmounts, "legacy lanes do not support token transfers")
+	latesthdr, err := e.Chains[dest].Client.HeaderByNumber(context.Background(), nil)
+	require.NoError(t, err)
+	startBlock := latesthdr.Number.Uint64()
+
+	legacy := sendLegacyRequest(t, e, state, src, dest, msg)
+	current := TestSendRequest(t, e, state, src, dest, false, msg)
+
+	seqNr := cciptypes.SeqNum(current.SequenceNumber)
+	_, err = ConfirmCommitWithExpectedSeqNumRange(t, e.Chains[src], e.Chains[dest], state.Chains[dest].OffRamp,
+		&startBlock, cciptypes.NewSeqNumRange(seqNr, seqNr))
+	require.NoError(t, err)
+	_, err = ConfirmExecWithSeqNrs(t, e.Chains[src], e.Chains[dest], state.Chains[dest].OffRamp, &startBlock, []uint64{current.SequenceNumber})
+	require.NoError(t, err)
+	it, err := state.Chains[dest].OffRamp.FilterExecutionStateChanged(&bind.FilterOpts{Context: context.Background(), Start: startBlock},
+		[]uint64{src}, []uint64{current.SequenceNumber}, nil)
+	require.NoError(t, err)
+	require.True(t, it.Next())
+	diff := LaneDiff{
+		Legacy: confirmLegacyDelivery(t, e, state, src, dest, startBlock, legacy),
+		Current: LaneDelivery{
+			MessageID:      current.Message.Header.MessageId,
+			SequenceNumber: current.SequenceNumber,
+			Nonce:          current.Message.Header.Nonce,
+			Sender:         current.Message.Sender,
+			Data:           current.Message.Data,
+			Fee:            current.Message.FeeTokenAmount,
+			ExecutionState: it.Event.State,
+			ReceiverCalled: receiverCalled(t, e.Chains[dest], state.Chains[dest].Receiver.Address(), it.Event.Raw.TxHash),
+		},
+	}
+	t.Logf("Lane diff %d -> %d: legacy %+v, current %+v", src, dest, diff.Legacy, diff.Current)
+	return diff
+}
+
+// AssertLanesEquivalent fails the test if the lanes delivered the message differently
+// or the legacy fee deviates from the current one by more than feeTolerance.
+func AssertLanesEquivalent(t *testing.T, diff LaneDiff, feeTolerance float64) {
+	require.Empty(t, diff.Mismatches(), "legacy and current lanes delivered the message differently")
+	deviation := diff.FeeDeviation()
+	require.LessOrEqual(t, deviation, feeTolerance, "legacy fee %s deviates %.2f%% from current fee %s",
+		diff.Legacy.Fee, 100*deviation, diff.Current.Fee)
+	require.GreaterOrEqual(t, deviation, -feeTolerance, "legacy fee %s deviates %.2f%% from current fee %s",
+		diff.Legacy.Fee, 100*deviation, diff.Current.Fee)
+}
+
+func sendLegacyRequest(
+	t *testing.T,
+	e deployment.Environment,
+	state changeset.CCIPOnChainState,
+	src, dest uint64,
+	msg router.ClientEVM2AnyMessage,
+) LaneDelivery {
+	onRamp, ok := state.Chains[src].EVM2EVMOnRamp[dest]
+	require.True(t, ok, "legacy lane %d -> %d not deployed", src, dest)
+	tx, blockNum, err := CCIPSendRequest(e, state, src, dest, true, msg)
+	require.NoError(t, err)
+	it, err := onRamp.FilterCCIPSendRequested(&bind.FilterOpts{
+		Start:   blockNum,
+		End:     &blockNum,
+		Context: context.Background(),
+	})
+	require.NoError(t, err)
+	for it.Next() {
+		if it.Event.Raw.TxHash != tx.Hash() {
+			continue
+		}
+		t.Logf("Legacy CCIP message (id %x) sent from chain selector %d to chain selector %d tx %s seqNum %d nonce %d",
+			it.Event.Message.MessageId[:], src, dest, tx.Hash().String(), it.Event.Message.SequenceNumber, it.Event.Message.Nonce)
+		return LaneDelivery{
+			MessageID:      it.Event.Message.MessageId,
+			SequenceNumber: it.Event.Message.SequenceNumber,
+			Nonce:          it.Event.Message.Nonce,
+			Sender:         it.Event.Message.Sender,
+			Data:           it.Event.Message.Data,
+			Fee:            it.Event.Message.FeeTokenAmount,
+		}
+	}
+	require.FailNow(t, "no CCIPSendRequested event", "tx %s", tx.Hash())
+	return LaneDelivery{}
+}
+
+// confirmLegacyDelivery waits for the legacy lane to commit and execute the message and fills in the execution of sent.
+func confirmLegacyDelivery(
+	t *testing.T,
+	e deployment.Environment,
+	state changeset.CCIPOnChainState,
+	src, dest uint64,
+	startBlock uint64,
+	sent LaneDelivery,
+) LaneDelivery {
+	destChain := e.Chains[dest]
+	commitStore := state.Chains[dest].CommitStores[src]
+	offRamp := state.Chains[dest].EVM2EVMOffRamp[src]
+	opts := &bind.FilterOpts{Context: context.Background(), Start: startBlock}
+
+	require.Eventually(t, func() bool {
+		// if it's simulated backend, commit to ensure mining
+		if backend, ok := memory.AsBackend(destChain.Client); ok {
+			backend.Commit()
+		}
+		it, err := commitStore.FilterReportAccepted(opts)
+		require.NoError(t, err)
+		for it.Next() {
+			if it.Event.Report.Interval.Min <= sent.SequenceNumber && sent.SequenceNumber <= it.Event.Report.Interval.Max {
+				t.Logf("Legacy commit report accepted on chain %d for seqNums %d-%d",
+					dest, it.Event.Report.Interval.Min, it.Event.Report.Interval.Max)
+				return true
+			}
+		}
+		return false
+	}, 3*time.Minute, time.Second, "legacy lane %d -> %d did not commit seqNum %d", src, dest, sent.SequenceNumber)
+
+	var exec *evm_2_evm_offramp.EVM2EVMOffRampExecutionStateChanged
+	require.Eventually(t, func() bool {
+		if backend, ok := memory.AsBackend(destChain.Client); ok {
+			backend.Commit()
+		}
+		it, err := offRamp.FilterExecutionStateChanged(opts, []uint64{sent.SequenceNumber}, [][32]byte{sent.MessageID})
+		require.NoError(t, err)
+		for it.Next() {
+			if it.Event.State == changeset.EXECUTION_STATE_SUCCESS || it.Event.State == changeset.EXECUTION_STATE_FAILURE {
+				exec = it.Event
+				return true
+			}
+		}
+		return false
+	}, 3*time.Minute, time.Second, "legacy lane %d -> %d did not execute seqNum %d", src, dest, sent.SequenceNumber)
+
+	sent.ExecutionState = exec.State
+	sent.ReceiverCalled = receiverCalled(t, destChain, state.Chains[dest].Receiver.Address(), exec.Raw.TxHash)
+	return sent
+}
+
+// receiverCalled returns whether the receiver emitted MessageReceived in the execution transaction.
+func receiverCalled(t *testing.T, chain deployment.Chain, receiver common.Address, txHash common.Hash) bool {
+	receipt, err := chain.Client.TransactionReceipt(context.Background(), txHash)
+	require.NoError(t, err)
+	for _, log := range receipt.Logs {
+		if log.Address == receiver && len(log.Topics) > 0 &&
+			log.Topics[0] == (maybe_revert_message_receiver.MaybeRevertMessageReceiverMessageReceived{}).Topic() {
+			return true
+		}
+	}
+	return false
+}
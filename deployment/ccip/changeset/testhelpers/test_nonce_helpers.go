@@ -0,0 +1,89 @@
+package testhelpers
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-testing-framework/lib/utils/testcontext"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/onramp"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/router"
+)
+
+// SendOrderedRequests sends n ordered messages from the deployer key of src to the receiver of dest
+// and checks that the onramp assigned them consecutive nonces.
+func SendOrderedRequests(
+	t *testing.T,
+	e deployment.Environment,
+	state changeset.CCIPOnChainState,
+	src, dest uint64,
+	n int,
+) []*onramp.OnRampCCIPMessageSent {
+	var msgs []*onramp.OnRampCCIPMessageSent
+	for i := 0; i < n; i++ {
+		msg := TestSendRequest(t, e, state, src, dest, false, router.ClientEVM2AnyMessage{
+			Receiver:     common.LeftPadBytes(state.Chains[dest].Receiver.Address().Bytes(), 32),
+			Data:         []byte("ordered"),
+			TokenAmounts: nil,
+			FeeToken:     common.HexToAddress("0x0"),
+			ExtraArgs:    MakeEVMExtraArgsV2(200_000, false),
+		})
+		require.NotZero(t, msg.Message.Header.Nonce, "ordered message %d has no nonce", msg.SequenceNumber)
+		if len(msgs) > 0 {
+			require.Equal(t, msgs[len(msgs)-1].Message.Header.Nonce+1, msg.Message.Header.Nonce,
+				"ordered messages of the same sender must have consecutive nonces")
+		}
+		msgs = append(msgs, msg)
+	}
+	return msgs
+}
+
+// SeqNrsOf returns the sequence numbers of the messages.
+func SeqNrsOf(msgs []*onramp.OnRampCCIPMessageSent) []uint64 {
+	seqNrs := make([]uint64, 0, len(msgs))
+	for _, msg := range msgs {
+		seqNrs = append(seqNrs, msg.SequenceNumber)
+	}
+	return seqNrs
+}
+
+// ConfirmInboundNonce checks the inbound nonce of the sender of src on the nonce manager of dest,
+// i.e. the nonce of the last ordered message of the sender executed or skipped.
+func ConfirmInboundNonce(t *testing.T, state changeset.CCIPOnChainState, src, dest uint64, sender common.Address, expected uint64) {
+	nonce, err := state.Chains[dest].NonceManager.GetInboundNonce(&bind.CallOpts{Context: testcontext.Get(t)},
+		src, common.LeftPadBytes(sender.Bytes(), 32))
+	require.NoError(t, err)
+	require.Equal(t, expected, nonce, "inbound nonce of %s from chain %d on chain %d", sender, src, dest)
+}
+
+// ConfirmExecutedInNonceOrder checks that the ordered messages, sent by the same sender, were executed
+// by the offramp of dest in nonce order since the start block.
+func ConfirmExecutedInNonceOrder(
+	t *testing.T,
+	state changeset.CCIPOnChainState,
+	src, dest uint64,
+	startBlock uint64,
+	msgs []*onramp.OnRampCCIPMessageSent,
+) {
+	nonces := make(map[uint64]uint64)
+	for _, msg := range msgs {
+		nonces[msg.SequenceNumber] = msg.Message.Header.Nonce
+	}
+	it, err := state.Chains[dest].OffRamp.FilterExecutionStateChanged(&bind.FilterOpts{
+		Context: testcontext.Get(t),
+		Start:   startBlock,
+	}, []uint64{src}, SeqNrsOf(msgs), nil)
+	require.NoError(t, err)
+	var executed []uint64
+	for it.Next() {
+		executed = append(executed, nonces[it.Event.SequenceNumber])
+	}
+	require.NoError(t, it.Error())
+	require.Len(t, executed, len(msgs), "not all the ordered messages were executed")
+	require.IsIncreasing(t, executed, "ordered messages must be executed in nonce order")
+}
@@ -0,0 +1,41 @@
+package testhelpers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset"
+)
+
+// DefaultTestPriceUpdatePolicies returns the default price update policies of the commit plugins of the test environments,
+// see DefaultPriceReportingParams and TestDeviationPPB.
+func DefaultTestPriceUpdatePolicies() map[changeset.PriceKind]changeset.PriceUpdatePolicy {
+	params := changeset.DefaultPriceReportingParams()
+	return map[changeset.PriceKind]changeset.PriceUpdatePolicy{
+		PriceKindToken: {
+			Heartbeat:    params.TokenPriceHeartbeat,
+			DeviationPPB: changeset.TestDeviationPPB.Int,
+		},
+		PriceKindGas: {
+			Heartbeat:      params.GasPriceHeartbeat,
+			DeviationPPB:   params.GasPriceDeviationPPB,
+			DADeviationPPB: params.DAGasPriceDeviationPPB,
+		},
+	}
+}
+
+// AssertPricesFresh polls the watcher and fails the test if any tracked price is older than
+// its max age onchain, or any update violated the update policies.
+func AssertPricesFresh(t *testing.T, w *changeset.PriceWatcher, maxAge map[changeset.PriceKind]time.Duration, policies map[changeset.PriceKind]changeset.PriceUpdatePolicy) {
+	ctx := tests.Context(t)
+	require.NoError(t, w.Poll(ctx))
+	for _, key := range w.Keys() {
+		t.Logf("%d updates of %s", len(w.Updates(key)), key)
+	}
+	require.NoError(t, w.CheckFreshness(ctx, time.Now(), maxAge))
+	require.NoError(t, w.CheckUpdatePolicy(policies))
+}
@@ -0,0 +1,169 @@
+package testhelpers
+
+import (
+	"math/big"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset"
+	"github.com/smartcontractkit/chainlink/deployment/environment/memory"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/onramp"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/router"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/token_pool"
+)
+
+// rateLimitErrors are the errors token pools revert with when a transfer exceeds their rate limiters.
+var rateLimitErrors = []string{"TokenRateLimitReached", "TokenMaxCapacityExceeded"}
+
+// RateLimit is the capacity of a token bucket and the tokens it refills per second.
+type RateLimit struct {
+	Capacity *big.Int
+	Rate     *big.Int
+}
+
+// RefillTime returns how long the bucket takes to refill the amount.
+func (l RateLimit) RefillTime(amount *big.Int) time.Duration {
+	seconds := new(big.Int).Add(amount, new(big.Int).Sub(l.Rate, common.Big1))
+	seconds.Div(seconds, l.Rate)
+	return time.Duration(seconds.Int64()) * time.Second
+}
+
+// config returns the rate limiter config of the limit, disabled if nil.
+func (l *RateLimit) config() token_pool.RateLimiterConfig {
+	if l == nil {
+		return token_pool.RateLimiterConfig{IsEnabled: false, Capacity: big.NewInt(0), Rate: big.NewInt(0)}
+	}
+	return token_pool.RateLimiterConfig{IsEnabled: true, Capacity: l.Capacity, Rate: l.Rate}
+}
+
+// SetLaneRateLimits limits the transfers of the token from src to dest: outbound on the source pool and
+// inbound on the destination pool, a nil limit disables the rate limiter. The rate limiters of the pools
+// for the other direction of the lane are disabled.
+func SetLaneRateLimits(e deployment.Environment, src, dest uint64, tt TokenTransfer, outbound, inbound *RateLimit) error {
+	disabled := (*RateLimit)(nil).config()
+	if err := SetTokenPoolRateLimits(e.Chains[src], tt.SourcePool, dest, outbound.config(), disabled); err != nil {
+		return err
+	}
+	return SetTokenPoolRateLimits(e.Chains[dest], tt.DestPool, src, disabled, inbound.config())
+}
+
+// RateLimitedSend is a message sent by SendTokenTraffic.
+type RateLimitedSend struct {
+	Amount *big.Int
+	// Event is the message sent, nil if the send was rejected.
+	Event *onramp.OnRampCCIPMessageSent
+	// Rejection is the revert of the send by the outbound rate limiter of the source pool, nil if it was sent.
+	Rejection *deployment.RevertError
+}
+
+// SendTokenTraffic sends a message per amount, transferring it to the receiver. Sends rejected by the
+// outbound rate limiter of the source pool are recorded, any other failure fails the test.
+// The deployer key of the source chain must hold and have approved the router for the amounts.
+func SendTokenTraffic(
+	t *testing.T,
+	e deployment.Environment,
+	state changeset.CCIPOnChainState,
+	src, dest uint64,
+	receiver common.Address,
+	tt TokenTransfer,
+	amounts ...*big.Int,
+) []RateLimitedSend {
+	sends := make([]RateLimitedSend, 0, len(amounts))
+	for _, amount := range amounts {
+		transfer := tt
+		transfer.Amount = amount
+		event, err := SendRequest(e, state, src, dest, false, router.ClientEVM2AnyMessage{
+			Receiver:     common.LeftPadBytes(receiver.Bytes(), 32),
+			Data:         []byte("rate limited"),
+			TokenAmounts: TokenAmounts([]TokenTransfer{transfer}),
+			FeeToken:     common.HexToAddress("0x0"),
+			ExtraArgs:    nil,
+		})
+		if err != nil {
+			revert, ok := deployment.DecodeRevert(err)
+			require.True(t, ok && slices.Contains(rateLimitErrors, revert.Name), "send of %s failed: %v", amount, err)
+			sends = append(sends, RateLimitedSend{Amount: amount, Rejection: revert})
+			continue
+		}
+		sends = append(sends, RateLimitedSend{Amount: amount, Event: event})
+	}
+	return sends
+}
+
+// RequireRejections asserts that exactly the sends at the indexes were rejected by the rate limiter
+// and returns the sequence numbers of the messages sent.
+func RequireRejections(t *testing.T, sends []RateLimitedSend, rejected ...int) []uint64 {
+	var seqNrs []uint64
+	for i, send := range sends {
+		if slices.Contains(rejected, i) {
+			require.NotNil(t, send.Rejection, "send %d of %s was not rejected", i, send.Amount)
+			continue
+		}
+		require.Nil(t, send.Rejection, "send %d of %s was rejected", i, send.Amount)
+		seqNrs = append(seqNrs, send.Event.SequenceNumber)
+	}
+	return seqNrs
+}
+
+// AdvanceTime moves the time of the memory chains forward, e.g. for the rate limiters to refill.
+func AdvanceTime(t *testing.T, e deployment.Environment, d time.Duration, chains ...uint64) {
+	for _, sel := range chains {
+		backend, ok := memory.AsBackend(e.Chains[sel].Client)
+		require.True(t, ok, "chain %d is not a memory chain", sel)
+		require.NoError(t, backend.AdjustTime(d), "failed to advance time of chain %d", sel)
+	}
+}
+
+// WaitForRateLimitedExecution waits for the execution of the message of src to fail on dest because
+// it exceeded the inbound rate limiter of the destination pool, and returns the decoded error.
+// The DON doesn't retry failed executions, the message must then be executed manually.
+func WaitForRateLimitedExecution(
+	t *testing.T,
+	state changeset.CCIPOnChainState,
+	src, dest uint64,
+	startBlock uint64,
+	seqNr uint64,
+) *deployment.RevertError {
+	var reason *deployment.RevertError
+	require.Eventually(t, func() bool {
+		it, err := state.Chains[dest].OffRamp.FilterExecutionStateChanged(&bind.FilterOpts{
+			Context: tests.Context(t),
+			Start:   startBlock,
+		}, []uint64{src}, []uint64{seqNr}, nil)
+		require.NoError(t, err)
+		for it.Next() {
+			require.EqualValues(t, changeset.EXECUTION_STATE_FAILURE, it.Event.State, "message %d of lane %d->%d was executed", seqNr, src, dest)
+			reason = tokenHandlingError(it.Event.ReturnData)
+			return true
+		}
+		return false
+	}, tests.WaitTimeout(t), 500*time.Millisecond, "message %d of lane %d->%d was not executed", seqNr, src, dest)
+	require.NotNil(t, reason, "failed to decode the execution error of message %d", seqNr)
+	require.Contains(t, rateLimitErrors, reason.Name, "message %d failed with %s", seqNr, reason)
+	return reason
+}
+
+// tokenHandlingError decodes the error of a failed execution, unwrapping the error of the token pool
+// from the TokenHandlingError of the offramp.
+func tokenHandlingError(returnData []byte) *deployment.RevertError {
+	decoded, ok := deployment.DefaultErrorRegistry().Decode(returnData)
+	if !ok {
+		return nil
+	}
+	if decoded.Name == "TokenHandlingError" && len(decoded.Args) == 1 {
+		if inner, ok := decoded.Args[0].Value.([]byte); ok {
+			if innerDecoded, ok := deployment.DefaultErrorRegistry().Decode(inner); ok {
+				return innerDecoded
+			}
+		}
+	}
+	return decoded
+}
@@ -0,0 +1,91 @@
+package testhelpers
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-testing-framework/lib/utils/testcontext"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/burn_mint_token_pool"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/offramp"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+func TestRateLimitRefillTime(t *testing.T) {
+	limit := RateLimit{Capacity: big.NewInt(100), Rate: big.NewInt(10)}
+	require.Equal(t, 5*time.Second, limit.RefillTime(big.NewInt(50)))
+	require.Equal(t, 6*time.Second, limit.RefillTime(big.NewInt(51)))
+}
+
+func TestTokenHandlingError(t *testing.T) {
+	poolABI, err := burn_mint_token_pool.BurnMintTokenPoolMetaData.GetAbi()
+	require.NoError(t, err)
+	offRampABI, err := offramp.OffRampMetaData.GetAbi()
+	require.NoError(t, err)
+	inner, err := poolABI.Errors["TokenRateLimitReached"].Inputs.Pack(big.NewInt(5), big.NewInt(1), common.HexToAddress("0x1"))
+	require.NoError(t, err)
+	inner = append(poolABI.Errors["TokenRateLimitReached"].ID.Bytes()[:4], inner...)
+	outer, err := offRampABI.Errors["TokenHandlingError"].Inputs.Pack(inner)
+	require.NoError(t, err)
+	outer = append(offRampABI.Errors["TokenHandlingError"].ID.Bytes()[:4], outer...)
+
+	decoded := tokenHandlingError(outer)
+	require.NotNil(t, decoded)
+	require.Equal(t, "TokenRateLimitReached", decoded.Name)
+	require.Nil(t, tokenHandlingError([]byte{1, 2}))
+}
+
+// TestRateLimitBackpressure sends more tokens than the outbound rate limiter of the source pool lets through,
+// and more than the inbound rate limiter of the destination pool accepts, then lets the buckets refill.
+func TestRateLimitBackpressure(t *testing.T) {
+	lggr := logger.TestLogger(t)
+	e := NewMemoryEnvironmentWithJobsAndContracts(t, lggr, 2, 4, nil)
+	state, err := changeset.LoadOnchainState(e.Env)
+	require.NoError(t, err)
+	src, dest := e.HomeChainSel, e.FeedChainSel
+	srcToken, srcPool, destToken, destPool, err := DeployTransferableToken(lggr, e.Env.Chains, src, dest, state, e.Env.ExistingAddresses, "RATE")
+	require.NoError(t, err)
+	require.NoError(t, AddLanesForAll(e.Env, state))
+
+	srcChain := e.Env.Chains[src]
+	total := big.NewInt(1000)
+	tx, err := srcToken.Mint(srcChain.DeployerKey, srcChain.DeployerKey.From, total)
+	_, err = deployment.ConfirmIfNoError(srcChain, tx, err)
+	require.NoError(t, err)
+	tx, err = srcToken.Approve(srcChain.DeployerKey, state.Chains[src].Router.Address(), total)
+	_, err = deployment.ConfirmIfNoError(srcChain, tx, err)
+	require.NoError(t, err)
+
+	tt := TokenTransfer{Token: srcToken.Address(), SourcePool: srcPool.Address(), DestToken: destToken.Address(), DestPool: destPool.Address()}
+	receiver := state.Chains[dest].Receiver.Address()
+	outbound := RateLimit{Capacity: big.NewInt(100), Rate: big.NewInt(1)}
+	require.NoError(t, SetLaneRateLimits(e.Env, src, dest, tt, &outbound, nil))
+
+	header, err := e.Env.Chains[dest].Client.HeaderByNumber(testcontext.Get(t), nil)
+	require.NoError(t, err)
+	startBlock := header.Number.Uint64()
+
+	// the bucket holds 100 tokens: the third send exceeds what is left, the fourth exceeds the capacity
+	sends := SendTokenTraffic(t, e.Env, state, src, dest, receiver, tt, big.NewInt(60), big.NewInt(40), big.NewInt(10), big.NewInt(200))
+	seqNrs := RequireRejections(t, sends, 2, 3)
+	require.Equal(t, "TokenMaxCapacityExceeded", sends[3].Rejection.Name)
+
+	// once refilled, the rejected transfer goes through and is executed after the others
+	AdvanceTime(t, e.Env, outbound.RefillTime(big.NewInt(10)), src)
+	seqNrs = append(seqNrs, RequireRejections(t, SendTokenTraffic(t, e.Env, state, src, dest, receiver, tt, big.NewInt(10)))...)
+	_, err = ConfirmExecWithSeqNrs(t, e.Env.Chains[src], e.Env.Chains[dest], state.Chains[dest].OffRamp, &startBlock, seqNrs)
+	require.NoError(t, err)
+
+	// the destination pool accepts less than the source pool sends, the execution fails
+	inbound := RateLimit{Capacity: big.NewInt(20), Rate: big.NewInt(1)}
+	require.NoError(t, SetLaneRateLimits(e.Env, src, dest, tt, nil, &inbound))
+	sends = SendTokenTraffic(t, e.Env, state, src, dest, receiver, tt, big.NewInt(50))
+	seqNrs = RequireRejections(t, sends)
+	WaitForRateLimitedExecution(t, state, src, dest, startBlock, seqNrs[0])
+}
@@ -0,0 +1,593 @@
+package testhelpers
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/smartcontractkit/chainlink-ccip/commit/merkleroot/rmn"
+	"github.com/smartcontractkit/chainlink-ccip/commit/merkleroot/rmn/rmnpb"
+	rmntypes "github.com/smartcontractkit/chainlink-ccip/commit/merkleroot/rmn/types"
+	cciptypes "github.com/smartcontractkit/chainlink-ccip/pkg/types/ccipocr3"
+	"github.com/smartcontractkit/chainlink-common/pkg/hashutil"
+	"github.com/smartcontractkit/chainlink-common/pkg/merklemulti"
+	"github.com/smartcontractkit/chainlink-common/pkg/services"
+	ragep2ptypes "github.com/smartcontractkit/libocr/ragep2p/types"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset"
+	"github.com/smartcontractkit/chainlink/v2/core/capabilities/ccip/ccipevm"
+	"github.com/smartcontractkit/chainlink/v2/core/capabilities/ccip/oraclecreator"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/ccip_encoding_utils"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/onramp"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/rmn_home"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/rmn_remote"
+	corelogger "github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/ocr2/plugins/ccip/abihelpers"
+)
+
+var (
+	secp256k1N = crypto.S256().Params().N
+
+	encodingUtilsABI = abihelpers.MustParseABI(ccip_encoding_utils.EncodingUtilsABI)
+)
+
+// InMemoryRMNNode is an in-process RMN node.
+type InMemoryRMNNode struct {
+	Index       uint64
+	PeerID      [32]byte
+	OffchainKey ed25519.PrivateKey
+	OnchainKey  *ecdsa.PrivateKey
+}
+
+func (n InMemoryRMNNode) OnchainAddress() common.Address {
+	return crypto.PubkeyToAddress(n.OnchainKey.PublicKey)
+}
+
+// signObservation signs the observation the way the commit plugin verifies it,
+// i.e. ed25519.sign(sha256(prefix|sha256(observation))).
+func (n InMemoryRMNNode) signObservation(obs *rmnpb.Observation) (*rmnpb.SignedObservation, error) {
+	obsBytes, err := proto.Marshal(obs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal observation: %w", err)
+	}
+	obsHash := sha256.Sum256(obsBytes)
+	msgHash := sha256.Sum256(append([]byte(changeset.RMNSignObservationPrefix), obsHash[:]...))
+	return &rmnpb.SignedObservation{
+		Observation: obs,
+		Signature:   ed25519.Sign(n.OffchainKey, msgHash[:]),
+	}, nil
+}
+
+// signReport signs the report the way RMNRemote verifies it, i.e. an ECDSA signature
+// over the ABI encoded report recoverable with v = 27.
+func (n InMemoryRMNNode) signReport(report cciptypes.RMNReport) (*rmnpb.EcdsaSignature, error) {
+	merkleRoots := make([]ccip_encoding_utils.InternalMerkleRoot, 0, len(report.LaneUpdates))
+	for _, lu := range report.LaneUpdates {
+		merkleRoots = append(merkleRoots, ccip_encoding_utils.InternalMerkleRoot{
+			SourceChainSelector: uint64(lu.SourceChainSelector),
+			OnRampAddress:       common.LeftPadBytes(common.BytesToAddress(lu.OnRampAddress).Bytes(), 32),
+			MinSeqNr:            uint64(lu.MinSeqNr),
+			MaxSeqNr:            uint64(lu.MaxSeqNr),
+			MerkleRoot:          lu.MerkleRoot,
+		})
+	}
+	encoded, err := encodingUtilsABI.Methods["exposeRmnReport"].Inputs.Pack(
+		report.ReportVersionDigest,
+		ccip_encoding_utils.RMNRemoteReport{
+			DestChainId:                 report.DestChainID.Int,
+			DestChainSelector:           uint64(report.DestChainSelector),
+			RmnRemoteContractAddress:    common.BytesToAddress(report.RmnRemoteContractAddress),
+			OfframpAddress:              common.BytesToAddress(report.OfframpAddress),
+			RmnHomeContractConfigDigest: report.RmnHomeContractConfigDigest,
+			MerkleRoots:                 merkleRoots,
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to abi encode report: %w", err)
+	}
+	sig, err := crypto.Sign(crypto.Keccak256(encoded), n.OnchainKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign report: %w", err)
+	}
+	s := new(big.Int).SetBytes(sig[32:64])
+	if sig[64] == 1 {
+		// RMNRemote only accepts v = 27, negating s yields the signature with the other recovery id
+		s.Sub(secp256k1N, s)
+	}
+	return &rmnpb.EcdsaSignature{
+		R: sig[:32],
+		S: common.LeftPadBytes(s.Bytes(), 32),
+	}, nil
+}
+
+// withRogueKeys returns the node with fresh keys in place of the keys RMNHome and RMNRemote know it by.
+func (n InMemoryRMNNode) withRogueKeys() (InMemoryRMNNode, error) {
+	_, offchainKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return InMemoryRMNNode{}, err
+	}
+	onchainKey, err := crypto.GenerateKey()
+	if err != nil {
+		return InMemoryRMNNode{}, err
+	}
+	n.OffchainKey, n.OnchainKey = offchainKey, onchainKey
+	return n, nil
+}
+
+// RMNNodeBehavior is the behavior of an in-memory RMN node towards the commit plugins, to verify that the
+// commit plugins tolerate up to F faulty RMN nodes.
+type RMNNodeBehavior int
+
+const (
+	// RMNHonest nodes observe and sign the merkle roots of the lanes.
+	RMNHonest RMNNodeBehavior = iota
+	// RMNOffline nodes don't respond.
+	RMNOffline
+	// RMNWrongRoots nodes observe and sign wrong merkle roots, which the honest nodes don't agree with.
+	RMNWrongRoots
+	// RMNWrongSignatures nodes sign their observations and reports with keys other than the keys they are
+	// configured with in RMNHome and RMNRemote.
+	RMNWrongSignatures
+)
+
+// InMemoryRMN is a set of in-process RMN nodes. They observe the merkle roots of the lanes
+// from the CCIPMessageSent events of the OnRamps and sign RMN reports for the roots they agree with,
+// so that the commit plugins of memory nodes can be tested with RMN enabled, see NewPeerClient.
+// Cursing is done onchain through RMNRemote as usual.
+type InMemoryRMN struct {
+	chains map[uint64]deployment.Chain
+	Nodes  []InMemoryRMNNode
+
+	mu        sync.RWMutex
+	behaviors map[uint64]RMNNodeBehavior
+}
+
+func NewInMemoryRMN(chains map[uint64]deployment.Chain, numNodes int) (*InMemoryRMN, error) {
+	if numNodes < 1 {
+		return nil, errors.New("at least one RMN node is required")
+	}
+	m := &InMemoryRMN{
+		chains:    chains,
+		behaviors: make(map[uint64]RMNNodeBehavior),
+	}
+	for i := 0; i < numNodes; i++ {
+		_, offchainKey, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, err
+		}
+		onchainKey, err := crypto.GenerateKey()
+		if err != nil {
+			return nil, err
+		}
+		var peerID [32]byte
+		if _, err := rand.Read(peerID[:]); err != nil {
+			return nil, err
+		}
+		m.Nodes = append(m.Nodes, InMemoryRMNNode{
+			Index:       uint64(i),
+			PeerID:      peerID,
+			OffchainKey: offchainKey,
+			OnchainKey:  onchainKey,
+		})
+	}
+	return m, nil
+}
+
+func (m *InMemoryRMN) chainSelectors() []uint64 {
+	selectors := make([]uint64, 0, len(m.chains))
+	for sel := range m.chains {
+		selectors = append(selectors, sel)
+	}
+	sort.Slice(selectors, func(i, j int) bool {
+		return selectors[i] < selectors[j]
+	})
+	return selectors
+}
+
+// F is the maximum number of faulty RMN nodes tolerated, both for observing and signing.
+func (m *InMemoryRMN) F() uint64 {
+	return uint64(len(m.Nodes)-1) / 2
+}
+
+// SetOffline makes a node stop responding to the commit plugins, or brings it back online.
+func (m *InMemoryRMN) SetOffline(nodeIndex uint64, offline bool) {
+	behavior := RMNHonest
+	if offline {
+		behavior = RMNOffline
+	}
+	m.SetBehavior(nodeIndex, behavior)
+}
+
+// SetBehavior sets the behavior of a node for the next requests of the commit plugins.
+func (m *InMemoryRMN) SetBehavior(nodeIndex uint64, behavior RMNNodeBehavior) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.behaviors[nodeIndex] = behavior
+}
+
+func (m *InMemoryRMN) behavior(nodeIndex uint64) RMNNodeBehavior {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.behaviors[nodeIndex]
+}
+
+func (m *InMemoryRMN) RMNHomeStaticConfig() rmn_home.RMNHomeStaticConfig {
+	cfg := NewTestRMNStaticConfig()
+	for _, n := range m.Nodes {
+		var offchainPublicKey [32]byte
+		copy(offchainPublicKey[:], n.OffchainKey.Public().(ed25519.PublicKey))
+		cfg.Nodes = append(cfg.Nodes, rmn_home.RMNHomeNode{
+			PeerId:            n.PeerID,
+			OffchainPublicKey: offchainPublicKey,
+		})
+	}
+	return cfg
+}
+
+// RMNHomeDynamicConfig has every node observe every chain.
+func (m *InMemoryRMN) RMNHomeDynamicConfig() rmn_home.RMNHomeDynamicConfig {
+	cfg := NewTestRMNDynamicConfig()
+	observers := new(big.Int)
+	for _, n := range m.Nodes {
+		observers.SetBit(observers, int(n.Index), 1)
+	}
+	for _, sel := range m.chainSelectors() {
+		cfg.SourceChains = append(cfg.SourceChains, rmn_home.RMNHomeSourceChain{
+			ChainSelector:       sel,
+			F:                   m.F(),
+			ObserverNodesBitmap: observers,
+		})
+	}
+	return cfg
+}
+
+func (m *InMemoryRMN) RMNRemoteConfig(rmnHomeDigest [32]byte) rmn_remote.RMNRemoteConfig {
+	cfg := rmn_remote.RMNRemoteConfig{
+		RmnHomeContractConfigDigest: rmnHomeDigest,
+		F:                           m.F(),
+	}
+	for _, n := range m.Nodes {
+		cfg.Signers = append(cfg.Signers, rmn_remote.RMNRemoteSigner{
+			OnchainPublicKey: n.OnchainAddress(),
+			NodeIndex:        n.Index,
+		})
+	}
+	return cfg
+}
+
+// SetRMNRemoteConfigs makes the nodes the signers of the RMNRemote of every chain,
+// for the active RMNHome config.
+func (m *InMemoryRMN) SetRMNRemoteConfigs(e deployment.Environment, state changeset.CCIPOnChainState, homeChainSel uint64) error {
+	activeDigest, err := state.Chains[homeChainSel].RMNHome.GetActiveDigest(&bind.CallOpts{})
+	if err != nil {
+		return fmt.Errorf("failed to get active RMNHome digest: %w", err)
+	}
+	for _, sel := range m.chainSelectors() {
+		chain := e.Chains[sel]
+		tx, err := state.Chains[sel].RMNRemote.SetConfig(chain.DeployerKey, m.RMNRemoteConfig(activeDigest))
+		if _, err := deployment.ConfirmIfNoError(chain, tx, err); err != nil {
+			return fmt.Errorf("failed to set RMNRemote config on chain %d: %w", sel, err)
+		}
+	}
+	return nil
+}
+
+// NewPeerClient connects a commit plugin to the nodes, see memory.PluginRegistry.
+func (m *InMemoryRMN) NewPeerClient(lggr corelogger.Logger) rmn.PeerClient {
+	return &inMemoryRMNPeerClient{
+		lggr:     lggr,
+		rmn:      m,
+		hasher:   ccipevm.NewMessageHasherV1(lggr),
+		respChan: make(chan rmn.PeerResponse),
+		stopCh:   make(services.StopChan),
+	}
+}
+
+var _ oraclecreator.NewRMNPeerClientFn = (*InMemoryRMN)(nil).NewPeerClient
+
+func (m *InMemoryRMN) node(id rmntypes.NodeID) (InMemoryRMNNode, error) {
+	for _, n := range m.Nodes {
+		if n.Index == uint64(id) {
+			return n, nil
+		}
+	}
+	return InMemoryRMNNode{}, fmt.Errorf("unknown RMN node %d", id)
+}
+
+// merkleRoot computes the root of the messages sent on the lane within the interval.
+func (m *InMemoryRMN) merkleRoot(
+	ctx context.Context,
+	hasher cciptypes.MessageHasher,
+	dest *rmnpb.LaneDest,
+	source *rmnpb.LaneSource,
+	interval *rmnpb.ClosedInterval,
+) ([32]byte, error) {
+	chain, ok := m.chains[source.SourceChainSelector]
+	if !ok {
+		return [32]byte{}, fmt.Errorf("unknown source chain %d", source.SourceChainSelector)
+	}
+	onRampAddress := common.BytesToAddress(source.OnrampAddress)
+	onRamp, err := onramp.NewOnRamp(onRampAddress, chain.Client)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	it, err := onRamp.FilterCCIPMessageSent(&bind.FilterOpts{Context: ctx}, []uint64{dest.DestChainSelector}, nil)
+	if err != nil {
+		return [32]byte{}, fmt.Errorf("failed to filter CCIPMessageSent events: %w", err)
+	}
+	defer it.Close()
+
+	leaves := make([][32]byte, interval.MaxMsgNr-interval.MinMsgNr+1)
+	found := 0
+	for it.Next() {
+		msg := it.Event.Message
+		if msg.Header.SequenceNumber < interval.MinMsgNr || msg.Header.SequenceNumber > interval.MaxMsgNr {
+			continue
+		}
+		leaf, err := hasher.Hash(ctx, toCCIPMessage(msg, onRampAddress))
+		if err != nil {
+			return [32]byte{}, fmt.Errorf("failed to hash message %d: %w", msg.Header.SequenceNumber, err)
+		}
+		leaves[msg.Header.SequenceNumber-interval.MinMsgNr] = leaf
+		found++
+	}
+	if err := it.Error(); err != nil {
+		return [32]byte{}, err
+	}
+	if found != len(leaves) {
+		return [32]byte{}, fmt.Errorf("found %d messages in interval [%d, %d]", found, interval.MinMsgNr, interval.MaxMsgNr)
+	}
+	tree, err := merklemulti.NewTree(hashutil.NewKeccak(), leaves)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	return tree.Root(), nil
+}
+
+func toCCIPMessage(msg onramp.InternalEVM2AnyRampMessage, onRampAddress common.Address) cciptypes.Message {
+	tokenAmounts := make([]cciptypes.RampTokenAmount, 0, len(msg.TokenAmounts))
+	for _, ta := range msg.TokenAmounts {
+		tokenAmounts = append(tokenAmounts, cciptypes.RampTokenAmount{
+			SourcePoolAddress: ta.SourcePoolAddress.Bytes(),
+			DestTokenAddress:  ta.DestTokenAddress,
+			ExtraData:         ta.ExtraData,
+			Amount:            cciptypes.NewBigInt(ta.Amount),
+			DestExecData:      ta.DestExecData,
+		})
+	}
+	return cciptypes.Message{
+		Header: cciptypes.RampMessageHeader{
+			MessageID:           msg.Header.MessageId,
+			SourceChainSelector: cciptypes.ChainSelector(msg.Header.SourceChainSelector),
+			DestChainSelector:   cciptypes.ChainSelector(msg.Header.DestChainSelector),
+			SequenceNumber:      cciptypes.SeqNum(msg.Header.SequenceNumber),
+			Nonce:               msg.Header.Nonce,
+			OnRamp:              onRampAddress.Bytes(),
+		},
+		Sender:         msg.Sender.Bytes(),
+		Data:           msg.Data,
+		Receiver:       msg.Receiver,
+		ExtraArgs:      msg.ExtraArgs,
+		FeeToken:       msg.FeeToken.Bytes(),
+		FeeTokenAmount: cciptypes.NewBigInt(msg.FeeTokenAmount),
+		FeeValueJuels:  cciptypes.NewBigInt(msg.FeeValueJuels),
+		TokenAmounts:   tokenAmounts,
+	}
+}
+
+// inMemoryRMNPeerClient routes the requests of a commit plugin to the nodes of a InMemoryRMN.
+type inMemoryRMNPeerClient struct {
+	lggr   corelogger.Logger
+	rmn    *InMemoryRMN
+	hasher cciptypes.MessageHasher
+
+	rmnHomeConfigDigest cciptypes.Bytes32
+	respChan            chan rmn.PeerResponse
+	stopCh              services.StopChan
+	stopOnce            sync.Once
+	wg                  sync.WaitGroup
+}
+
+var _ rmn.PeerClient = (*inMemoryRMNPeerClient)(nil)
+
+func (c *inMemoryRMNPeerClient) InitConnection(
+	_ context.Context,
+	_ cciptypes.Bytes32,
+	rmnHomeConfigDigest cciptypes.Bytes32,
+	_ []ragep2ptypes.PeerID,
+	_ []rmntypes.HomeNodeInfo,
+) error {
+	c.rmnHomeConfigDigest = rmnHomeConfigDigest
+	return nil
+}
+
+func (c *inMemoryRMNPeerClient) Close() error {
+	c.stopOnce.Do(func() { close(c.stopCh) })
+	c.wg.Wait()
+	return nil
+}
+
+func (c *inMemoryRMNPeerClient) Send(rmnNode rmntypes.HomeNodeInfo, request []byte) error {
+	node, err := c.rmn.node(rmnNode.ID)
+	if err != nil {
+		return err
+	}
+	behavior := c.rmn.behavior(node.Index)
+	if behavior == RMNOffline {
+		// the request is lost like it would be for an unresponsive node
+		return nil
+	}
+	if behavior == RMNWrongSignatures {
+		if node, err = node.withRogueKeys(); err != nil {
+			return err
+		}
+	}
+	req := &rmnpb.Request{}
+	if err := proto.Unmarshal(request, req); err != nil {
+		return fmt.Errorf("failed to unmarshal request: %w", err)
+	}
+
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		ctx, cancel := c.stopCh.NewCtx()
+		defer cancel()
+		resp, err := c.handle(ctx, node, behavior, req)
+		if err != nil {
+			c.lggr.Warnw("in-memory RMN node failed to handle request", "node", node.Index, "requestID", req.RequestId, "err", err)
+			return
+		}
+		body, err := proto.Marshal(resp)
+		if err != nil {
+			c.lggr.Errorw("failed to marshal in-memory RMN response", "err", err)
+			return
+		}
+		select {
+		case c.respChan <- rmn.PeerResponse{RMNNodeID: rmnNode.ID, Body: body}:
+		case <-c.stopCh:
+		}
+	}()
+	return nil
+}
+
+func (c *inMemoryRMNPeerClient) Recv() <-chan rmn.PeerResponse {
+	return c.respChan
+}
+
+func (c *inMemoryRMNPeerClient) handle(ctx context.Context, node InMemoryRMNNode, behavior RMNNodeBehavior, req *rmnpb.Request) (*rmnpb.Response, error) {
+	switch r := req.Request.(type) {
+	case *rmnpb.Request_ObservationRequest:
+		signedObs, err := c.observe(ctx, node, behavior, r.ObservationRequest)
+		if err != nil {
+			return nil, err
+		}
+		return &rmnpb.Response{
+			RequestId: req.RequestId,
+			Response:  &rmnpb.Response_SignedObservation{SignedObservation: signedObs},
+		}, nil
+	case *rmnpb.Request_ReportSignatureRequest:
+		sig, err := c.signReport(ctx, node, behavior, r.ReportSignatureRequest)
+		if err != nil {
+			return nil, err
+		}
+		return &rmnpb.Response{
+			RequestId: req.RequestId,
+			Response:  &rmnpb.Response_ReportSignature{ReportSignature: &rmnpb.ReportSignature{Signature: sig}},
+		}, nil
+	default:
+		return nil, fmt.Errorf("unexpected request type %T", req.Request)
+	}
+}
+
+func (c *inMemoryRMNPeerClient) observe(ctx context.Context, node InMemoryRMNNode, behavior RMNNodeBehavior, req *rmnpb.ObservationRequest) (*rmnpb.SignedObservation, error) {
+	obs := &rmnpb.Observation{
+		RmnHomeContractConfigDigest: c.rmnHomeConfigDigest[:],
+		LaneDest:                    req.LaneDest,
+		Timestamp:                   uint64(time.Now().UnixMilli()),
+	}
+	for _, lur := range req.FixedDestLaneUpdateRequests {
+		root, err := c.merkleRoot(ctx, behavior, req.LaneDest, lur.LaneSource, lur.ClosedInterval)
+		if err != nil {
+			return nil, err
+		}
+		obs.FixedDestLaneUpdates = append(obs.FixedDestLaneUpdates, &rmnpb.FixedDestLaneUpdate{
+			LaneSource: &rmnpb.LaneSource{
+				SourceChainSelector: lur.LaneSource.SourceChainSelector,
+				OnrampAddress:       common.BytesToAddress(lur.LaneSource.OnrampAddress).Bytes(),
+			},
+			ClosedInterval: lur.ClosedInterval,
+			Root:           root[:],
+		})
+	}
+	return node.signObservation(obs)
+}
+
+// merkleRoot is the root of the lane the node observes, a wrong one for RMNWrongRoots nodes.
+func (c *inMemoryRMNPeerClient) merkleRoot(
+	ctx context.Context,
+	behavior RMNNodeBehavior,
+	dest *rmnpb.LaneDest,
+	source *rmnpb.LaneSource,
+	interval *rmnpb.ClosedInterval,
+) ([32]byte, error) {
+	root, err := c.rmn.merkleRoot(ctx, c.hasher, dest, source, interval)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	if behavior == RMNWrongRoots {
+		root[0] ^= 0xff
+	}
+	return root, nil
+}
+
+// signReport signs the lane updates of the observations, provided the node observes the same roots.
+func (c *inMemoryRMNPeerClient) signReport(ctx context.Context, node InMemoryRMNNode, behavior RMNNodeBehavior, req *rmnpb.ReportSignatureRequest) (*rmnpb.EcdsaSignature, error) {
+	dest := req.Context.LaneDest
+	updates := make(map[uint64]*rmnpb.FixedDestLaneUpdate)
+	for _, aso := range req.AttributedSignedObservations {
+		for _, lu := range aso.SignedObservation.Observation.FixedDestLaneUpdates {
+			if _, ok := updates[lu.LaneSource.SourceChainSelector]; ok {
+				continue
+			}
+			root, err := c.merkleRoot(ctx, behavior, dest, lu.LaneSource, lu.ClosedInterval)
+			if err != nil {
+				return nil, err
+			}
+			updates[lu.LaneSource.SourceChainSelector] = &rmnpb.FixedDestLaneUpdate{
+				LaneSource:     lu.LaneSource,
+				ClosedInterval: lu.ClosedInterval,
+				Root:           root[:],
+			}
+		}
+	}
+	if len(updates) == 0 {
+		return nil, errors.New("no lane updates to sign")
+	}
+
+	report := cciptypes.RMNReport{
+		DestChainID:                 cciptypes.NewBigInt(new(big.Int).SetUint64(req.Context.EvmDestChainId)),
+		DestChainSelector:           cciptypes.ChainSelector(dest.DestChainSelector),
+		RmnRemoteContractAddress:    req.Context.RmnRemoteContractAddress,
+		OfframpAddress:              dest.OfframpAddress,
+		RmnHomeContractConfigDigest: cciptypes.Bytes32(req.Context.RmnHomeContractConfigDigest),
+	}
+	for _, lu := range updates {
+		report.LaneUpdates = append(report.LaneUpdates, cciptypes.RMNLaneUpdate{
+			SourceChainSelector: cciptypes.ChainSelector(lu.LaneSource.SourceChainSelector),
+			OnRampAddress:       lu.LaneSource.OnrampAddress,
+			MinSeqNr:            cciptypes.SeqNum(lu.ClosedInterval.MinMsgNr),
+			MaxSeqNr:            cciptypes.SeqNum(lu.ClosedInterval.MaxMsgNr),
+			MerkleRoot:          cciptypes.Bytes32(lu.Root),
+		})
+	}
+	sort.Slice(report.LaneUpdates, func(i, j int) bool {
+		return report.LaneUpdates[i].SourceChainSelector < report.LaneUpdates[j].SourceChainSelector
+	})
+
+	chain, ok := c.rmn.chains[dest.DestChainSelector]
+	if !ok {
+		return nil, fmt.Errorf("unknown dest chain %d", dest.DestChainSelector)
+	}
+	rmnRemote, err := rmn_remote.NewRMNRemote(common.BytesToAddress(req.Context.RmnRemoteContractAddress), chain.Client)
+	if err != nil {
+		return nil, err
+	}
+	report.ReportVersionDigest, err = rmnRemote.GetReportDigestHeader(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get report digest header: %w", err)
+	}
+	return node.signReport(report)
+}
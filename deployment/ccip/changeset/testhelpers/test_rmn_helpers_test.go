@@ -0,0 +1,158 @@
+package testhelpers
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/smartcontractkit/chainlink-ccip/commit/merkleroot/rmn"
+	"github.com/smartcontractkit/chainlink-ccip/commit/merkleroot/rmn/rmnpb"
+	cciptypes "github.com/smartcontractkit/chainlink-ccip/pkg/types/ccipocr3"
+	"github.com/smartcontractkit/chainlink-testing-framework/lib/utils/testcontext"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset"
+	"github.com/smartcontractkit/chainlink/deployment/environment/memory"
+	"github.com/smartcontractkit/chainlink/v2/core/capabilities/ccip/ccipevm"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/router"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+func TestInMemoryRMNSignatures(t *testing.T) {
+	lggr := logger.TestLogger(t)
+	rmnNodes, err := NewInMemoryRMN(nil, 3)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), rmnNodes.F())
+	remoteCfg := rmnNodes.RMNRemoteConfig([32]byte{1})
+	require.Len(t, remoteCfg.Signers, 3)
+
+	node := rmnNodes.Nodes[0]
+	signedObs, err := node.signObservation(&rmnpb.Observation{
+		RmnHomeContractConfigDigest: []byte{1},
+		LaneDest:                    &rmnpb.LaneDest{DestChainSelector: 2, OfframpAddress: common.Address{3}.Bytes()},
+		FixedDestLaneUpdates: []*rmnpb.FixedDestLaneUpdate{{
+			LaneSource:     &rmnpb.LaneSource{SourceChainSelector: 4, OnrampAddress: common.Address{5}.Bytes()},
+			ClosedInterval: &rmnpb.ClosedInterval{MinMsgNr: 1, MaxMsgNr: 2},
+			Root:           make([]byte, 32),
+		}},
+		Timestamp: uint64(time.Now().UnixMilli()),
+	})
+	require.NoError(t, err)
+	// verify the same way the commit plugin does
+	obsBytes, err := proto.Marshal(signedObs.Observation)
+	require.NoError(t, err)
+	obsHash := sha256.Sum256(obsBytes)
+	msgHash := sha256.Sum256(append([]byte(changeset.RMNSignObservationPrefix), obsHash[:]...))
+	require.True(t, ed25519.Verify(node.OffchainKey.Public().(ed25519.PublicKey), msgHash[:], signedObs.Signature))
+
+	verifier := ccipevm.NewEVMRMNCrypto(lggr)
+	// sign enough reports to cover both recovery ids
+	for i := byte(0); i < 16; i++ {
+		report := cciptypes.RMNReport{
+			ReportVersionDigest:         cciptypes.Bytes32{0xa},
+			DestChainID:                 cciptypes.NewBigIntFromInt64(1337),
+			DestChainSelector:           2,
+			RmnRemoteContractAddress:    common.Address{6}.Bytes(),
+			OfframpAddress:              common.Address{3}.Bytes(),
+			RmnHomeContractConfigDigest: cciptypes.Bytes32{1},
+			LaneUpdates: []cciptypes.RMNLaneUpdate{{
+				SourceChainSelector: 4,
+				OnRampAddress:       common.Address{5}.Bytes(),
+				MinSeqNr:            1,
+				MaxSeqNr:            2,
+				MerkleRoot:          cciptypes.Bytes32{i},
+			}},
+		}
+		for _, n := range rmnNodes.Nodes {
+			pbSig, err := n.signReport(report)
+			require.NoError(t, err)
+			sig, err := rmn.NewECDSASigFromPB(pbSig)
+			require.NoError(t, err)
+			require.NoError(t, verifier.VerifyReportSignatures(context.Background(),
+				[]cciptypes.RMNECDSASignature{*sig}, report, []cciptypes.UnknownAddress{n.OnchainAddress().Bytes()}))
+		}
+	}
+}
+
+// TestRMNBlessedAndCursedRoots commits roots blessed by in-memory RMN nodes
+// and checks that no roots are committed from a cursed source chain.
+func TestRMNBlessedAndCursedRoots(t *testing.T) {
+	lggr := logger.TestLogger(t)
+	e := NewMemoryEnvironmentWithJobsAndContracts(t, lggr, 2, 4, &TestConfigs{RMNNodes: 3})
+	require.NotNil(t, e.RMN)
+	state, err := changeset.LoadOnchainState(e.Env)
+	require.NoError(t, err)
+	src, dest := e.HomeChainSel, e.FeedChainSel
+	ReplayLogs(t, e.Env.Offchain, e.ReplayBlocks)
+	require.NoError(t, AddLanesForAll(e.Env, state))
+
+	send := func() (uint64, uint64) {
+		latesthdr, err := e.Env.Chains[dest].Client.HeaderByNumber(testcontext.Get(t), nil)
+		require.NoError(t, err)
+		msgSentEvent := TestSendRequest(t, e.Env, state, src, dest, false, router.ClientEVM2AnyMessage{
+			Receiver:     common.LeftPadBytes(state.Chains[dest].Receiver.Address().Bytes(), 32),
+			Data:         []byte("hello"),
+			TokenAmounts: nil,
+			FeeToken:     common.HexToAddress("0x0"),
+			ExtraArgs:    nil,
+		})
+		return latesthdr.Number.Uint64(), msgSentEvent.SequenceNumber
+	}
+
+	// a single unresponsive node is tolerated
+	e.RMN.SetOffline(0, true)
+	block, seqNr := send()
+	_, err = ConfirmCommitWithExpectedSeqNumRange(t, e.Env.Chains[src], e.Env.Chains[dest], state.Chains[dest].OffRamp,
+		&block, cciptypes.NewSeqNumRange(cciptypes.SeqNum(seqNr), cciptypes.SeqNum(seqNr)))
+	require.NoError(t, err)
+	e.RMN.SetOffline(0, false)
+
+	// a single node observing wrong roots or signing with keys unknown to RMNRemote is tolerated
+	for _, behavior := range []RMNNodeBehavior{RMNWrongRoots, RMNWrongSignatures} {
+		e.RMN.SetBehavior(0, behavior)
+		block, seqNr = send()
+		_, err = ConfirmCommitWithExpectedSeqNumRange(t, e.Env.Chains[src], e.Env.Chains[dest], state.Chains[dest].OffRamp,
+			&block, cciptypes.NewSeqNumRange(cciptypes.SeqNum(seqNr), cciptypes.SeqNum(seqNr)))
+		require.NoError(t, err, "behavior %d", behavior)
+	}
+	e.RMN.SetBehavior(0, RMNHonest)
+
+	var subject [16]byte
+	binary.BigEndian.PutUint64(subject[8:], src)
+	destChain := e.Env.Chains[dest]
+	tx, err := state.Chains[dest].RMNRemote.Curse(destChain.DeployerKey, subject)
+	_, err = deployment.ConfirmIfNoError(destChain, tx, err)
+	require.NoError(t, err)
+
+	destBackend, ok := memory.AsBackend(destChain.Client)
+	require.True(t, ok)
+	block, seqNr = send()
+	require.Never(t, func() bool {
+		destBackend.Commit()
+		it, err := state.Chains[dest].OffRamp.FilterCommitReportAccepted(&bind.FilterOpts{Context: testcontext.Get(t), Start: block})
+		require.NoError(t, err)
+		for it.Next() {
+			for _, mr := range it.Event.MerkleRoots {
+				if mr.SourceChainSelector == src && mr.MinSeqNr <= seqNr && seqNr <= mr.MaxSeqNr {
+					return true
+				}
+			}
+		}
+		return false
+	}, time.Minute, 2*time.Second, "roots of a cursed source chain must not be committed")
+
+	tx, err = state.Chains[dest].RMNRemote.Uncurse(destChain.DeployerKey, subject)
+	_, err = deployment.ConfirmIfNoError(destChain, tx, err)
+	require.NoError(t, err)
+	_, err = ConfirmCommitWithExpectedSeqNumRange(t, e.Env.Chains[src], destChain, state.Chains[dest].OffRamp,
+		&block, cciptypes.NewSeqNumRange(cciptypes.SeqNum(seqNr), cciptypes.SeqNum(seqNr)))
+	require.NoError(t, err)
+}
@@ -0,0 +1,160 @@
+package testhelpers
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-testing-framework/lib/utils/testcontext"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/router"
+)
+
+// globalCurseSubject is the RMNRemote subject cursing all the lanes of a chain.
+var globalCurseSubject = [16]byte{0: 0x01, 15: 0x01}
+
+// Scenario describes a CCIP flow as a sequence of steps, e.g.
+//
+//	NewScenario("curse and recover").
+//		Send(a, b, 5).
+//		Curse(b).
+//		Send(a, b, 3).
+//		Uncurse(b).
+//		ExpectExecuted().
+//		Run(t, e)
+//
+// which sends 8 messages from a to b across a curse of b and expects all of them to be executed.
+// The steps are built from the test helpers of the package, Step adds a custom one.
+type Scenario struct {
+	name  string
+	steps []scenarioStep
+}
+
+type scenarioStep struct {
+	name string
+	run  func(t *testing.T, r *ScenarioRun)
+}
+
+// ScenarioRun is the state of a scenario being run, passed to its steps.
+type ScenarioRun struct {
+	Env   DeployedEnv
+	State changeset.CCIPOnChainState
+	// Pending are the sequence numbers of the messages sent since the last expectation, by lane.
+	Pending map[changeset.SourceDestPair][]uint64
+	// startBlocks are the blocks of the destination chains before the first pending message was sent.
+	startBlocks map[uint64]*uint64
+}
+
+func NewScenario(name string) *Scenario {
+	return &Scenario{name: name}
+}
+
+// Step adds a custom step to the scenario.
+func (s *Scenario) Step(name string, run func(t *testing.T, r *ScenarioRun)) *Scenario {
+	s.steps = append(s.steps, scenarioStep{name: name, run: run})
+	return s
+}
+
+// Send sends n messages from src to dest, to the receiver of dest.
+func (s *Scenario) Send(src, dest uint64, n int) *Scenario {
+	return s.Step(fmt.Sprintf("send %d messages %d->%d", n, src, dest), func(t *testing.T, r *ScenarioRun) {
+		lane := changeset.SourceDestPair{SourceChainSelector: src, DestChainSelector: dest}
+		if _, ok := r.startBlocks[dest]; !ok {
+			header, err := r.Env.Env.Chains[dest].Client.HeaderByNumber(testcontext.Get(t), nil)
+			require.NoError(t, err)
+			block := header.Number.Uint64()
+			r.startBlocks[dest] = &block
+		}
+		for i := 0; i < n; i++ {
+			msgSentEvent := TestSendRequest(t, r.Env.Env, r.State, src, dest, false, router.ClientEVM2AnyMessage{
+				Receiver:     common.LeftPadBytes(r.State.Chains[dest].Receiver.Address().Bytes(), 32),
+				Data:         []byte("hello"),
+				TokenAmounts: nil,
+				FeeToken:     common.HexToAddress("0x0"),
+				ExtraArgs:    nil,
+			})
+			r.Pending[lane] = append(r.Pending[lane], msgSentEvent.SequenceNumber)
+		}
+	})
+}
+
+// Curse curses all the lanes of the chain on its RMNRemote.
+func (s *Scenario) Curse(chain uint64) *Scenario {
+	return s.Step(fmt.Sprintf("curse %d", chain), func(t *testing.T, r *ScenarioRun) {
+		r.curse(t, chain, globalCurseSubject, true)
+	})
+}
+
+// Uncurse lifts a curse of all the lanes of the chain.
+func (s *Scenario) Uncurse(chain uint64) *Scenario {
+	return s.Step(fmt.Sprintf("uncurse %d", chain), func(t *testing.T, r *ScenarioRun) {
+		r.curse(t, chain, globalCurseSubject, false)
+	})
+}
+
+// CurseSource curses the lane from src on the RMNRemote of dest.
+func (s *Scenario) CurseSource(dest, src uint64) *Scenario {
+	return s.Step(fmt.Sprintf("curse %d on %d", src, dest), func(t *testing.T, r *ScenarioRun) {
+		r.curse(t, dest, changeset.ChainCurseSubject(src), true)
+	})
+}
+
+// UncurseSource lifts a curse of the lane from src on the RMNRemote of dest.
+func (s *Scenario) UncurseSource(dest, src uint64) *Scenario {
+	return s.Step(fmt.Sprintf("uncurse %d on %d", src, dest), func(t *testing.T, r *ScenarioRun) {
+		r.curse(t, dest, changeset.ChainCurseSubject(src), false)
+	})
+}
+
+// ExpectExecuted waits for all the messages sent since the last expectation to be executed successfully.
+func (s *Scenario) ExpectExecuted() *Scenario {
+	return s.Step("expect executed", func(t *testing.T, r *ScenarioRun) {
+		executionStates := ConfirmExecWithSeqNrsForAll(t, r.Env.Env, r.State, r.Pending, r.startBlocks)
+		for lane, seqNrs := range r.Pending {
+			for _, seqNr := range seqNrs {
+				require.Equal(t, changeset.EXECUTION_STATE_SUCCESS, executionStates[lane][seqNr],
+					"message %d of lane %d->%d was not executed successfully", seqNr, lane.SourceChainSelector, lane.DestChainSelector)
+			}
+		}
+		r.Pending = make(map[changeset.SourceDestPair][]uint64)
+		r.startBlocks = make(map[uint64]*uint64)
+	})
+}
+
+// Run runs the steps of the scenario in order against the environment, with lanes between all its chains.
+func (s *Scenario) Run(t *testing.T, e DeployedEnv) {
+	state, err := changeset.LoadOnchainState(e.Env)
+	require.NoError(t, err)
+	ReplayLogs(t, e.Env.Offchain, e.ReplayBlocks)
+	require.NoError(t, AddLanesForAll(e.Env, state))
+	r := &ScenarioRun{
+		Env:         e,
+		State:       state,
+		Pending:     make(map[changeset.SourceDestPair][]uint64),
+		startBlocks: make(map[uint64]*uint64),
+	}
+	for i, step := range s.steps {
+		e.Env.Logger.Infow("Running scenario step", "scenario", s.name, "step", i, "name", step.name)
+		if !t.Run(fmt.Sprintf("%d %s", i, step.name), func(t *testing.T) { step.run(t, r) }) {
+			t.Fatalf("scenario %s failed at step %d %s", s.name, i, step.name)
+		}
+	}
+}
+
+func (r *ScenarioRun) curse(t *testing.T, chain uint64, subject [16]byte, curse bool) {
+	c := r.Env.Env.Chains[chain]
+	rmnRemote := r.State.Chains[chain].RMNRemote
+	if curse {
+		tx, err := rmnRemote.Curse(c.DeployerKey, subject)
+		_, err = deployment.ConfirmIfNoError(c, tx, err)
+		require.NoError(t, err)
+		return
+	}
+	tx, err := rmnRemote.Uncurse(c.DeployerKey, subject)
+	_, err = deployment.ConfirmIfNoError(c, tx, err)
+	require.NoError(t, err)
+}
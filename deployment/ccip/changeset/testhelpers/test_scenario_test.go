@@ -0,0 +1,21 @@
+package testhelpers
+
+import (
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+// TestScenarioCurseAndUncurse sends messages across a curse of the destination chain,
+// they are all executed once it is lifted.
+func TestScenarioCurseAndUncurse(t *testing.T) {
+	e := NewMemoryEnvironmentWithJobsAndContracts(t, logger.TestLogger(t), 2, 4, nil)
+	a, b := e.HomeChainSel, e.FeedChainSel
+	NewScenario("curse and uncurse").
+		Send(a, b, 5).
+		Curse(b).
+		Send(a, b, 3).
+		Uncurse(b).
+		ExpectExecuted().
+		Run(t, e)
+}
@@ -0,0 +1,351 @@
+package testhelpers
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/lock_release_token_pool"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/onramp"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/router"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/token_pool"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/shared/generated/burn_mint_erc677"
+)
+
+// DeployLockReleaseTransferableToken is DeployTransferableToken with LockReleaseTokenPools instead of
+// BurnMintTokenPools. Both pools are provided the liquidity so that tokens can be released on either side.
+func DeployLockReleaseTransferableToken(
+	lggr logger.Logger,
+	chains map[uint64]deployment.Chain,
+	src, dst uint64,
+	state changeset.CCIPOnChainState,
+	addresses deployment.AddressBook,
+	token string,
+	liquidity *big.Int,
+) (*burn_mint_erc677.BurnMintERC677, *lock_release_token_pool.LockReleaseTokenPool, *burn_mint_erc677.BurnMintERC677, *lock_release_token_pool.LockReleaseTokenPool, error) {
+	srcToken, srcPool, err := deployLockReleaseTokenOneEnd(lggr, chains[src], state.Chains[src], addresses, token, liquidity)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	dstToken, dstPool, err := deployLockReleaseTokenOneEnd(lggr, chains[dst], state.Chains[dst], addresses, token, liquidity)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	if err := attachTokenToTheRegistry(chains[src], state.Chains[src], chains[src].DeployerKey, srcToken.Address(), srcPool.Address()); err != nil {
+		return nil, nil, nil, nil, err
+	}
+	if err := attachTokenToTheRegistry(chains[dst], state.Chains[dst], chains[dst].DeployerKey, dstToken.Address(), dstPool.Address()); err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	if err := setLockReleaseTokenPoolCounterPart(chains[src], srcPool, dst, dstToken.Address(), dstPool.Address()); err != nil {
+		return nil, nil, nil, nil, err
+	}
+	if err := setLockReleaseTokenPoolCounterPart(chains[dst], dstPool, src, srcToken.Address(), srcPool.Address()); err != nil {
+		return nil, nil, nil, nil, err
+	}
+	return srcToken, srcPool, dstToken, dstPool, nil
+}
+
+func deployLockReleaseTokenOneEnd(
+	lggr logger.Logger,
+	chain deployment.Chain,
+	state changeset.CCIPChainState,
+	addressBook deployment.AddressBook,
+	tokenSymbol string,
+	liquidity *big.Int,
+) (*burn_mint_erc677.BurnMintERC677, *lock_release_token_pool.LockReleaseTokenPool, error) {
+	if state.RMNProxyExisting == nil || state.Router == nil {
+		return nil, nil, fmt.Errorf("RMN proxy and router must be deployed on chain %d", chain.Selector)
+	}
+	tokenContract, err := deployment.DeployContract(lggr, chain, addressBook,
+		func(chain deployment.Chain) deployment.ContractDeploy[*burn_mint_erc677.BurnMintERC677] {
+			tokenAddress, tx, token, err2 := burn_mint_erc677.DeployBurnMintERC677(
+				chain.DeployerKey,
+				chain.Client,
+				tokenSymbol,
+				tokenSymbol,
+				18,
+				new(big.Int).Mul(big.NewInt(1e9), big.NewInt(1e18)),
+			)
+			return deployment.ContractDeploy[*burn_mint_erc677.BurnMintERC677]{
+				tokenAddress, token, tx, deployment.NewTypeAndVersion(changeset.BurnMintToken, deployment.Version1_0_0), err2,
+			}
+		})
+	if err != nil {
+		lggr.Errorw("Failed to deploy Token ERC677", "err", err)
+		return nil, nil, err
+	}
+	token := tokenContract.Contract
+
+	tokenPool, err := deployment.DeployContract(lggr, chain, addressBook,
+		func(chain deployment.Chain) deployment.ContractDeploy[*lock_release_token_pool.LockReleaseTokenPool] {
+			poolAddress, tx, pool, err2 := lock_release_token_pool.DeployLockReleaseTokenPool(
+				chain.DeployerKey,
+				chain.Client,
+				token.Address(),
+				18,
+				[]common.Address{},
+				state.RMNProxyExisting.Address(),
+				true, // acceptLiquidity
+				state.Router.Address(),
+			)
+			return deployment.ContractDeploy[*lock_release_token_pool.LockReleaseTokenPool]{
+				poolAddress, pool, tx, deployment.NewTypeAndVersion(changeset.LockReleaseTokenPool, deployment.Version1_0_0), err2,
+			}
+		})
+	if err != nil {
+		lggr.Errorw("Failed to deploy lock release token pool", "err", err)
+		return nil, nil, err
+	}
+	pool := tokenPool.Contract
+
+	// the deployer mints the liquidity and provides it to the pool as its rebalancer
+	tx, err := token.GrantMintRole(chain.DeployerKey, chain.DeployerKey.From)
+	if _, err := deployment.ConfirmIfNoError(chain, tx, err); err != nil {
+		return nil, nil, fmt.Errorf("failed to grant mint role on token %s: %w", token.Address(), err)
+	}
+	tx, err = token.Mint(chain.DeployerKey, chain.DeployerKey.From, liquidity)
+	if _, err := deployment.ConfirmIfNoError(chain, tx, err); err != nil {
+		return nil, nil, fmt.Errorf("failed to mint liquidity of token %s: %w", token.Address(), err)
+	}
+	tx, err = token.Approve(chain.DeployerKey, pool.Address(), liquidity)
+	if _, err := deployment.ConfirmIfNoError(chain, tx, err); err != nil {
+		return nil, nil, fmt.Errorf("failed to approve liquidity of token %s: %w", token.Address(), err)
+	}
+	tx, err = pool.SetRebalancer(chain.DeployerKey, chain.DeployerKey.From)
+	if _, err := deployment.ConfirmIfNoError(chain, tx, err); err != nil {
+		return nil, nil, fmt.Errorf("failed to set rebalancer of token pool %s: %w", pool.Address(), err)
+	}
+	tx, err = pool.ProvideLiquidity(chain.DeployerKey, liquidity)
+	if _, err := deployment.ConfirmIfNoError(chain, tx, err); err != nil {
+		return nil, nil, fmt.Errorf("failed to provide liquidity to token pool %s: %w", pool.Address(), err)
+	}
+	return token, pool, nil
+}
+
+func setLockReleaseTokenPoolCounterPart(
+	chain deployment.Chain,
+	tokenPool *lock_release_token_pool.LockReleaseTokenPool,
+	destChainSelector uint64,
+	destTokenAddress common.Address,
+	destTokenPoolAddress common.Address,
+) error {
+	tx, err := tokenPool.ApplyChainUpdates(
+		chain.DeployerKey,
+		[]uint64{},
+		[]lock_release_token_pool.TokenPoolChainUpdate{
+			{
+				RemoteChainSelector: destChainSelector,
+				RemotePoolAddresses: [][]byte{common.LeftPadBytes(destTokenPoolAddress.Bytes(), 32)},
+				RemoteTokenAddress:  common.LeftPadBytes(destTokenAddress.Bytes(), 32),
+				OutboundRateLimiterConfig: lock_release_token_pool.RateLimiterConfig{
+					IsEnabled: false,
+					Capacity:  big.NewInt(0),
+					Rate:      big.NewInt(0),
+				},
+				InboundRateLimiterConfig: lock_release_token_pool.RateLimiterConfig{
+					IsEnabled: false,
+					Capacity:  big.NewInt(0),
+					Rate:      big.NewInt(0),
+				},
+			},
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to apply chain updates on token pool %s: %w", tokenPool.Address(), err)
+	}
+	_, err = chain.Confirm(tx)
+	return err
+}
+
+// SetTokenPoolRateLimits sets the rate limiters of a token pool of any type for the remote chain.
+func SetTokenPoolRateLimits(
+	chain deployment.Chain,
+	pool common.Address,
+	remoteChainSelector uint64,
+	outbound, inbound token_pool.RateLimiterConfig,
+) error {
+	tokenPool, err := token_pool.NewTokenPool(pool, chain.Client)
+	if err != nil {
+		return err
+	}
+	tx, err := tokenPool.SetChainRateLimiterConfig(chain.DeployerKey, remoteChainSelector, outbound, inbound)
+	if _, err := deployment.ConfirmIfNoError(chain, tx, err); err != nil {
+		return fmt.Errorf("failed to set rate limits of token pool %s for chain %d: %w", pool, remoteChainSelector, err)
+	}
+	return nil
+}
+
+// TokenTransfer is one of the token amounts of a multi token message, with the pools and
+// the destination token it is delivered through.
+type TokenTransfer struct {
+	Token      common.Address
+	Amount     *big.Int
+	SourcePool common.Address
+	DestToken  common.Address
+	DestPool   common.Address
+	// ExpectedDestAmount is the amount delivered to the receiver, Amount if nil.
+	// E.g. the mock USDC transmitter always mints 1.
+	ExpectedDestAmount *big.Int
+}
+
+func (tt TokenTransfer) expectedDestAmount() *big.Int {
+	if tt.ExpectedDestAmount != nil {
+		return tt.ExpectedDestAmount
+	}
+	return tt.Amount
+}
+
+// TokenAmounts returns the token amounts of a message carrying the transfers.
+func TokenAmounts(transfers []TokenTransfer) []router.ClientEVMTokenAmount {
+	amounts := make([]router.ClientEVMTokenAmount, 0, len(transfers))
+	for _, tt := range transfers {
+		amounts = append(amounts, router.ClientEVMTokenAmount{Token: tt.Token, Amount: tt.Amount})
+	}
+	return amounts
+}
+
+// TokenTransferSnapshot is the state the transfers of a message are asserted against,
+// read before sending it.
+type TokenTransferSnapshot struct {
+	// ReceiverBalances by destination token.
+	ReceiverBalances map[common.Address]*big.Int
+	// Outbound and Inbound rate limiter buckets by source and destination pool.
+	Outbound map[common.Address]token_pool.RateLimiterTokenBucket
+	Inbound  map[common.Address]token_pool.RateLimiterTokenBucket
+}
+
+// SnapshotTokenTransfers reads the balances of the receiver and the rate limiter buckets of the pools of the transfers.
+func SnapshotTokenTransfers(ctx context.Context, src, dest deployment.Chain, receiver common.Address, transfers []TokenTransfer) (TokenTransferSnapshot, error) {
+	snapshot := TokenTransferSnapshot{
+		ReceiverBalances: make(map[common.Address]*big.Int),
+		Outbound:         make(map[common.Address]token_pool.RateLimiterTokenBucket),
+		Inbound:          make(map[common.Address]token_pool.RateLimiterTokenBucket),
+	}
+	opts := &bind.CallOpts{Context: ctx}
+	for _, tt := range transfers {
+		token, err := burn_mint_erc677.NewBurnMintERC677(tt.DestToken, dest.Client)
+		if err != nil {
+			return snapshot, err
+		}
+		balance, err := token.BalanceOf(opts, receiver)
+		if err != nil {
+			return snapshot, fmt.Errorf("failed to get balance of token %s on chain %d: %w", tt.DestToken, dest.Selector, err)
+		}
+		snapshot.ReceiverBalances[tt.DestToken] = balance
+
+		srcPool, err := token_pool.NewTokenPool(tt.SourcePool, src.Client)
+		if err != nil {
+			return snapshot, err
+		}
+		outbound, err := srcPool.GetCurrentOutboundRateLimiterState(opts, dest.Selector)
+		if err != nil {
+			return snapshot, fmt.Errorf("failed to get outbound rate limiter of pool %s on chain %d: %w", tt.SourcePool, src.Selector, err)
+		}
+		snapshot.Outbound[tt.SourcePool] = outbound
+
+		destPool, err := token_pool.NewTokenPool(tt.DestPool, dest.Client)
+		if err != nil {
+			return snapshot, err
+		}
+		inbound, err := destPool.GetCurrentInboundRateLimiterState(opts, src.Selector)
+		if err != nil {
+			return snapshot, fmt.Errorf("failed to get inbound rate limiter of pool %s on chain %d: %w", tt.DestPool, dest.Selector, err)
+		}
+		snapshot.Inbound[tt.DestPool] = inbound
+	}
+	return snapshot, nil
+}
+
+// SendMultiTokenMessage sends a single message carrying all the transfers to the receiver and returns
+// the snapshot to assert the delivery against with AssertTokenTransfers.
+// The deployer key of the source chain must hold and have approved the router for the amounts.
+func SendMultiTokenMessage(
+	t *testing.T,
+	e deployment.Environment,
+	state changeset.CCIPOnChainState,
+	src, dest uint64,
+	receiver common.Address,
+	data []byte,
+	transfers []TokenTransfer,
+) (*onramp.OnRampCCIPMessageSent, TokenTransferSnapshot) {
+	snapshot, err := SnapshotTokenTransfers(tests.Context(t), e.Chains[src], e.Chains[dest], receiver, transfers)
+	require.NoError(t, err)
+	msgSentEvent := TestSendRequest(t, e, state, src, dest, false, router.ClientEVM2AnyMessage{
+		Receiver:     common.LeftPadBytes(receiver.Bytes(), 32),
+		Data:         data,
+		TokenAmounts: TokenAmounts(transfers),
+		FeeToken:     common.HexToAddress("0x0"),
+		ExtraArgs:    nil,
+	})
+	require.Len(t, msgSentEvent.Message.TokenAmounts, len(transfers))
+	return msgSentEvent, snapshot
+}
+
+// AssertTokenTransfers asserts that each transfer of an executed message was delivered to the receiver
+// and consumed its amount from the enabled rate limiters of the pools since the snapshot.
+// The pools of a transfer must have the same decimals on both ends.
+func AssertTokenTransfers(
+	t *testing.T,
+	src, dest deployment.Chain,
+	receiver common.Address,
+	transfers []TokenTransfer,
+	before TokenTransferSnapshot,
+) {
+	after, err := SnapshotTokenTransfers(tests.Context(t), src, dest, receiver, transfers)
+	require.NoError(t, err)
+
+	// a token may be transferred more than once in a message
+	expectedBalances := make(map[common.Address]*big.Int)
+	outboundConsumed := make(map[common.Address]*big.Int)
+	inboundConsumed := make(map[common.Address]*big.Int)
+	add := func(m map[common.Address]*big.Int, key common.Address, initial, amount *big.Int) {
+		if _, ok := m[key]; !ok {
+			m[key] = new(big.Int).Set(initial)
+		}
+		m[key].Add(m[key], amount)
+	}
+	for _, tt := range transfers {
+		add(expectedBalances, tt.DestToken, before.ReceiverBalances[tt.DestToken], tt.expectedDestAmount())
+		add(outboundConsumed, tt.SourcePool, common.Big0, tt.Amount)
+		add(inboundConsumed, tt.DestPool, common.Big0, tt.Amount)
+	}
+	for token, expected := range expectedBalances {
+		require.Equal(t, expected.String(), after.ReceiverBalances[token].String(),
+			"balance of receiver %s of token %s on chain %d", receiver, token, dest.Selector)
+	}
+	for pool, consumed := range outboundConsumed {
+		if bucket := before.Outbound[pool]; bucket.IsEnabled {
+			require.Equal(t, consumed.String(), consumedTokens(bucket, after.Outbound[pool]).String(),
+				"outbound rate limiter of pool %s on chain %d", pool, src.Selector)
+		}
+	}
+	for pool, consumed := range inboundConsumed {
+		if bucket := before.Inbound[pool]; bucket.IsEnabled {
+			require.Equal(t, consumed.String(), consumedTokens(bucket, after.Inbound[pool]).String(),
+				"inbound rate limiter of pool %s on chain %d", pool, dest.Selector)
+		}
+	}
+}
+
+// consumedTokens returns the tokens consumed from the bucket between the states, accounting for the refill.
+func consumedTokens(before, after token_pool.RateLimiterTokenBucket) *big.Int {
+	refilled := new(big.Int).Mul(before.Rate, big.NewInt(int64(after.LastUpdated)-int64(before.LastUpdated)))
+	available := new(big.Int).Add(before.Tokens, refilled)
+	if available.Cmp(before.Capacity) > 0 {
+		available = before.Capacity
+	}
+	return available.Sub(available, after.Tokens)
+}
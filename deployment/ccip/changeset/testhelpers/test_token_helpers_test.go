@@ -0,0 +1,29 @@
+package testhelpers
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/token_pool"
+)
+
+func TestConsumedTokens(t *testing.T) {
+	bucket := func(tokens int64, lastUpdated uint32) token_pool.RateLimiterTokenBucket {
+		return token_pool.RateLimiterTokenBucket{Tokens: big.NewInt(tokens), LastUpdated: lastUpdated, IsEnabled: true, Capacity: big.NewInt(1000), Rate: big.NewInt(10)}
+	}
+	for _, tc := range []struct {
+		name          string
+		before, after token_pool.RateLimiterTokenBucket
+		expected      int64
+	}{
+		{"same block", bucket(1000, 100), bucket(700, 100), 300},
+		{"refilled", bucket(500, 100), bucket(300, 105), 250},
+		{"refill capped", bucket(990, 100), bucket(800, 110), 200},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, consumedTokens(tc.before, tc.after).Int64())
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package testhelpers
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/fee_quoter"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/shared/generated/burn_mint_erc677"
+)
+
+func ConfigureUSDCTokenPools(
+	lggr logger.Logger,
+	chains map[uint64]deployment.Chain,
+	src, dst uint64,
+	state changeset.CCIPOnChainState,
+) (*burn_mint_erc677.BurnMintERC677, *burn_mint_erc677.BurnMintERC677, error) {
+	srcToken := state.Chains[src].BurnMintTokens677[changeset.USDCSymbol]
+	dstToken := state.Chains[dst].BurnMintTokens677[changeset.USDCSymbol]
+	srcPool := state.Chains[src].USDCTokenPool
+	dstPool := state.Chains[dst].USDCTokenPool
+
+	// Attach token pools to registry
+	if err := attachTokenToTheRegistry(chains[src], state.Chains[src], chains[src].DeployerKey, srcToken.Address(), srcPool.Address()); err != nil {
+		lggr.Errorw("Failed to attach token to the registry", "err", err, "token", srcToken.Address(), "pool", srcPool.Address())
+		return nil, nil, err
+	}
+
+	if err := attachTokenToTheRegistry(chains[dst], state.Chains[dst], chains[dst].DeployerKey, dstToken.Address(), dstPool.Address()); err != nil {
+		lggr.Errorw("Failed to attach token to the registry", "err", err, "token", dstToken.Address(), "pool", dstPool.Address())
+		return nil, nil, err
+	}
+
+	// Connect pool to each other
+	if err := setUSDCTokenPoolCounterPart(chains[src], srcPool, dst, dstToken.Address(), dstPool.Address()); err != nil {
+		lggr.Errorw("Failed to set counter part", "err", err, "srcPool", srcPool.Address(), "dstPool", dstPool.Address())
+		return nil, nil, err
+	}
+
+	if err := setUSDCTokenPoolCounterPart(chains[dst], dstPool, src, srcToken.Address(), srcPool.Address()); err != nil {
+		lggr.Errorw("Failed to set counter part", "err", err, "srcPool", dstPool.Address(), "dstPool", srcPool.Address())
+		return nil, nil, err
+	}
+
+	// Add burn/mint permissions for source
+	for _, addr := range []common.Address{
+		srcPool.Address(),
+		state.Chains[src].MockUSDCTokenMessenger.Address(),
+		state.Chains[src].MockUSDCTransmitter.Address(),
+	} {
+		if err := changeset.GrantMintBurnPermissions(lggr, chains[src], srcToken, addr); err != nil {
+			lggr.Errorw("Failed to grant mint/burn permissions", "err", err, "token", srcToken.Address(), "minter", addr)
+			return nil, nil, err
+		}
+	}
+
+	// Add burn/mint permissions for dest
+	for _, addr := range []common.Address{
+		dstPool.Address(),
+		state.Chains[dst].MockUSDCTokenMessenger.Address(),
+		state.Chains[dst].MockUSDCTransmitter.Address(),
+	} {
+		if err := changeset.GrantMintBurnPermissions(lggr, chains[dst], dstToken, addr); err != nil {
+			lggr.Errorw("Failed to grant mint/burn permissions", "err", err, "token", dstToken.Address(), "minter", addr)
+			return nil, nil, err
+		}
+	}
+
+	return srcToken, dstToken, nil
+}
+
+func UpdateFeeQuoterForUSDC(
+	lggr logger.Logger,
+	chain deployment.Chain,
+	state changeset.CCIPChainState,
+	dstChain uint64,
+	usdcToken *burn_mint_erc677.BurnMintERC677,
+) error {
+	config := []fee_quoter.FeeQuoterTokenTransferFeeConfigArgs{
+		{
+			DestChainSelector: dstChain,
+			TokenTransferFeeConfigs: []fee_quoter.FeeQuoterTokenTransferFeeConfigSingleTokenArgs{
+				{
+					usdcToken.Address(),
+					fee_quoter.FeeQuoterTokenTransferFeeConfig{
+						MinFeeUSDCents:    50,
+						MaxFeeUSDCents:    50_000,
+						DeciBps:           0,
+						DestGasOverhead:   180_000,
+						DestBytesOverhead: 640,
+						IsEnabled:         true,
+					},
+				},
+			},
+		},
+	}
+
+	tx, err := state.FeeQuoter.ApplyTokenTransferFeeConfigUpdates(
+		chain.DeployerKey,
+		config,
+		[]fee_quoter.FeeQuoterTokenTransferFeeConfigRemoveArgs{},
+	)
+	if err != nil {
+		lggr.Errorw("Failed to apply token transfer fee config updates", "err", err, "config", config)
+		return err
+	}
+
+	_, err = chain.Confirm(tx)
+	return err
+}
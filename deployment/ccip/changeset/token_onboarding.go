@@ -0,0 +1,392 @@
+package changeset
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/proposal/timelock"
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/burn_mint_token_pool"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/shared/generated/burn_mint_erc677"
+)
+
+var (
+	_ deployment.ChangeSet[TokenOnboardingConfig] = OnboardToken
+)
+
+// TokenAdminRegistration is the way the token developer proves it is the administrator
+// of the token to the RegistryModuleOwnerCustom.
+type TokenAdminRegistration string
+
+const (
+	// RegisterAdminViaOwner registers the owner() of the token as its administrator.
+	RegisterAdminViaOwner TokenAdminRegistration = "owner"
+	// RegisterAdminViaGetCCIPAdmin registers the getCCIPAdmin() of the token as its administrator.
+	RegisterAdminViaGetCCIPAdmin TokenAdminRegistration = "getCCIPAdmin"
+)
+
+// TokenOnboardingChainConfig is the configuration of the token on a single chain.
+type TokenOnboardingChainConfig struct {
+	Token    common.Address
+	Decimals uint8
+	// Pool is an already deployed BurnMintTokenPool of the token.
+	// If unset, the pool registered for the token or found in the address book is used, or a new one is deployed.
+	Pool              common.Address
+	AdminRegistration TokenAdminRegistration
+	// GrantMintBurnRoles grants the pool the mint and burn roles of the token, the deployer key must be its owner.
+	GrantMintBurnRoles bool
+	// OutboundRateLimiter and InboundRateLimiter apply to all remote chains, they are disabled if unset.
+	OutboundRateLimiter *burn_mint_token_pool.RateLimiterConfig
+	InboundRateLimiter  *burn_mint_token_pool.RateLimiterConfig
+}
+
+// TokenOnboardingConfig onboards a token to CCIP on all the chains it declares, with every chain
+// configured as a remote chain of all the others.
+type TokenOnboardingConfig struct {
+	Symbol TokenSymbol
+	Chains map[uint64]TokenOnboardingChainConfig
+}
+
+func (c TokenOnboardingConfig) Validate(e deployment.Environment, state CCIPOnChainState) error {
+	if c.Symbol == "" {
+		return fmt.Errorf("token symbol must be set")
+	}
+	if len(c.Chains) < 2 {
+		return fmt.Errorf("token must be onboarded on at least 2 chains, got %d", len(c.Chains))
+	}
+	for sel, chainCfg := range c.Chains {
+		if _, ok := e.Chains[sel]; !ok {
+			return fmt.Errorf("chain %d not found in environment", sel)
+		}
+		chainState, ok := state.Chains[sel]
+		if !ok {
+			return fmt.Errorf("chain %d has no CCIP state", sel)
+		}
+		if chainState.TokenAdminRegistry == nil || chainState.RegistryModule == nil || chainState.Router == nil || chainState.RMNProxyNew == nil {
+			return fmt.Errorf("chain %d is missing token admin registry, registry module, router or RMN proxy", sel)
+		}
+		if chainCfg.Token == (common.Address{}) {
+			return fmt.Errorf("token address must be set for chain %d", sel)
+		}
+		switch chainCfg.AdminRegistration {
+		case RegisterAdminViaOwner, RegisterAdminViaGetCCIPAdmin:
+		default:
+			return fmt.Errorf("unsupported admin registration %q for chain %d", chainCfg.AdminRegistration, sel)
+		}
+	}
+	return nil
+}
+
+// OnboardToken implements the self-serve token onboarding flow of a token developer, on every chain:
+// deploy the token pool, register the developer as token administrator, accept the role, set the pool
+// and configure the pools of the other chains as remote pools.
+// Each step is skipped when it's already done onchain, so the changeset can be re-run after a partial failure:
+// on failure, the pools deployed so far are merged into the existing addresses of the environment to be found
+// again by the next run, instead of being returned.
+// The deployer key of each chain is used as the token developer.
+func OnboardToken(e deployment.Environment, cfg TokenOnboardingConfig) (deployment.ChangesetOutput, error) {
+	state, err := LoadOnchainState(e)
+	if err != nil {
+		return deployment.ChangesetOutput{}, fmt.Errorf("failed to load onchain state: %w", err)
+	}
+	if err := cfg.Validate(e, state); err != nil {
+		return deployment.ChangesetOutput{}, fmt.Errorf("invalid TokenOnboardingConfig: %w", err)
+	}
+	ab := deployment.NewMemoryAddressBook()
+	fail := func(err error) (deployment.ChangesetOutput, error) {
+		err = deployment.MaybeDataErr(err)
+		if mergeErr := e.ExistingAddresses.Merge(ab); mergeErr != nil {
+			err = errors.Join(err, fmt.Errorf("failed to merge address book: %w", mergeErr))
+		}
+		return deployment.ChangesetOutput{}, err
+	}
+	pools := make(map[uint64]*burn_mint_token_pool.BurnMintTokenPool)
+	for sel, chainCfg := range cfg.Chains {
+		pool, err := onboardTokenOnChain(e, ab, state.Chains[sel], e.Chains[sel], chainCfg)
+		if err != nil {
+			e.Logger.Errorw("Failed to onboard token", "chain", sel, "token", chainCfg.Token, "err", err)
+			return fail(err)
+		}
+		pools[sel] = pool
+	}
+	for sel, chainCfg := range cfg.Chains {
+		for remoteSel, remoteCfg := range cfg.Chains {
+			if sel == remoteSel {
+				continue
+			}
+			if err := setTokenPoolRemoteChain(e.Chains[sel], pools[sel], chainCfg, remoteSel, remoteCfg.Token, pools[remoteSel].Address()); err != nil {
+				e.Logger.Errorw("Failed to configure remote chain", "chain", sel, "remoteChain", remoteSel, "err", err)
+				return fail(err)
+			}
+		}
+	}
+	e.Logger.Infow("Onboarded token", "symbol", cfg.Symbol, "chains", len(cfg.Chains))
+	return deployment.ChangesetOutput{
+		Proposals:   []timelock.MCMSWithTimelockProposal{},
+		AddressBook: ab,
+		JobSpecs:    nil,
+	}, nil
+}
+
+func onboardTokenOnChain(
+	e deployment.Environment,
+	ab deployment.AddressBook,
+	state CCIPChainState,
+	chain deployment.Chain,
+	cfg TokenOnboardingChainConfig,
+) (*burn_mint_token_pool.BurnMintTokenPool, error) {
+	opts := &bind.CallOpts{Context: context.Background()}
+	tokenConfig, err := state.TokenAdminRegistry.GetTokenConfig(opts, cfg.Token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token config of %s: %w", cfg.Token, err)
+	}
+
+	pool, err := findTokenPool(e, chain, cfg, tokenConfig.TokenPool)
+	if err != nil {
+		return nil, err
+	}
+	if pool != nil {
+		poolToken, err := pool.GetToken(opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get token of pool %s: %w", pool.Address(), err)
+		}
+		if poolToken != cfg.Token {
+			return nil, fmt.Errorf("pool %s on chain %d is a pool of token %s, not of token %s", pool.Address(), chain.Selector, poolToken, cfg.Token)
+		}
+	} else {
+		deployed, err := deployment.DeployContract(e.Logger, chain, ab,
+			func(chain deployment.Chain) deployment.ContractDeploy[*burn_mint_token_pool.BurnMintTokenPool] {
+				addr, tx, p, err2 := burn_mint_token_pool.DeployBurnMintTokenPool(
+					chain.DeployerKey,
+					chain.Client,
+					cfg.Token,
+					cfg.Decimals,
+					[]common.Address{},
+					state.RMNProxyNew.Address(),
+					state.Router.Address(),
+				)
+				return deployment.ContractDeploy[*burn_mint_token_pool.BurnMintTokenPool]{
+					Address: addr, Contract: p, Tx: tx, Err: err2,
+					Tv: deployment.NewTypeAndVersion(BurnMintTokenPool, deployment.Version1_0_0),
+				}
+			})
+		if err != nil {
+			return nil, fmt.Errorf("failed to deploy token pool: %w", err)
+		}
+		pool = deployed.Contract
+	}
+	if err := ValidateTokenPoolDecimals(chain, pool); err != nil {
+		return nil, err
+	}
+
+	if cfg.GrantMintBurnRoles {
+		if err := grantMissingMintBurnRoles(e, chain, cfg.Token, pool.Address()); err != nil {
+			return nil, err
+		}
+	}
+
+	developer := chain.DeployerKey.From
+	if tokenConfig.Administrator != developer {
+		if tokenConfig.Administrator != (common.Address{}) {
+			return nil, fmt.Errorf("token %s is administered by %s on chain %d", cfg.Token, tokenConfig.Administrator, chain.Selector)
+		}
+		if tokenConfig.PendingAdministrator != developer {
+			register := state.RegistryModule.RegisterAdminViaOwner
+			if cfg.AdminRegistration == RegisterAdminViaGetCCIPAdmin {
+				register = state.RegistryModule.RegisterAdminViaGetCCIPAdmin
+			}
+			tx, err := register(chain.DeployerKey, cfg.Token)
+			if _, err := deployment.ConfirmIfNoError(chain, tx, err); err != nil {
+				return nil, fmt.Errorf("failed to register admin of token %s via %s: %w", cfg.Token, cfg.AdminRegistration, err)
+			}
+		}
+		tx, err := state.TokenAdminRegistry.AcceptAdminRole(chain.DeployerKey, cfg.Token)
+		if _, err := deployment.ConfirmIfNoError(chain, tx, err); err != nil {
+			return nil, fmt.Errorf("failed to accept admin role of token %s: %w", cfg.Token, err)
+		}
+	}
+
+	if tokenConfig.TokenPool != pool.Address() {
+		tx, err := state.TokenAdminRegistry.SetPool(chain.DeployerKey, cfg.Token, pool.Address())
+		if _, err := deployment.ConfirmIfNoError(chain, tx, err); err != nil {
+			return nil, fmt.Errorf("failed to set pool of token %s: %w", cfg.Token, err)
+		}
+	}
+	e.Logger.Infow("Token registered", "chain", chain.Selector, "token", cfg.Token, "pool", pool.Address())
+	return pool, nil
+}
+
+// findTokenPool returns the configured pool, the pool registered for the token or a pool of the token
+// in the address book, in that order. It returns nil if the token has no pool yet.
+func findTokenPool(
+	e deployment.Environment,
+	chain deployment.Chain,
+	cfg TokenOnboardingChainConfig,
+	registeredPool common.Address,
+) (*burn_mint_token_pool.BurnMintTokenPool, error) {
+	if cfg.Pool != (common.Address{}) {
+		return burn_mint_token_pool.NewBurnMintTokenPool(cfg.Pool, chain.Client)
+	}
+	if registeredPool != (common.Address{}) {
+		return burn_mint_token_pool.NewBurnMintTokenPool(registeredPool, chain.Client)
+	}
+	addresses, err := e.ExistingAddresses.AddressesForChain(chain.Selector)
+	if errors.Is(err, deployment.ErrChainNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	for addr, tv := range addresses {
+		if tv.Type != BurnMintTokenPool {
+			continue
+		}
+		pool, err := burn_mint_token_pool.NewBurnMintTokenPool(common.HexToAddress(addr), chain.Client)
+		if err != nil {
+			return nil, err
+		}
+		token, err := pool.GetToken(&bind.CallOpts{Context: context.Background()})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get token of pool %s: %w", addr, err)
+		}
+		if token == cfg.Token {
+			return pool, nil
+		}
+	}
+	return nil, nil
+}
+
+func grantMissingMintBurnRoles(e deployment.Environment, chain deployment.Chain, tokenAddress, pool common.Address) error {
+	token, err := burn_mint_erc677.NewBurnMintERC677(tokenAddress, chain.Client)
+	if err != nil {
+		return err
+	}
+	opts := &bind.CallOpts{Context: context.Background()}
+	isMinter, err := token.IsMinter(opts, pool)
+	if err != nil {
+		return fmt.Errorf("failed to check minter role of %s: %w", pool, err)
+	}
+	isBurner, err := token.IsBurner(opts, pool)
+	if err != nil {
+		return fmt.Errorf("failed to check burner role of %s: %w", pool, err)
+	}
+	if isMinter && isBurner {
+		return nil
+	}
+	return GrantMintBurnPermissions(e.Logger, chain, token, pool)
+}
+
+// setTokenPoolRemoteChain adds the remote chain to the pool, or only the remote pool if the chain
+// is already supported with another pool, e.g. after a pool upgrade on the remote chain.
+func setTokenPoolRemoteChain(
+	chain deployment.Chain,
+	pool *burn_mint_token_pool.BurnMintTokenPool,
+	cfg TokenOnboardingChainConfig,
+	remoteSel uint64,
+	remoteToken common.Address,
+	remotePool common.Address,
+) error {
+	opts := &bind.CallOpts{Context: context.Background()}
+	remotePoolBytes := common.LeftPadBytes(remotePool.Bytes(), 32)
+	supported, err := pool.IsSupportedChain(opts, remoteSel)
+	if err != nil {
+		return fmt.Errorf("failed to check if chain %d is supported by pool %s: %w", remoteSel, pool.Address(), err)
+	}
+	if !supported {
+		disabled := burn_mint_token_pool.RateLimiterConfig{IsEnabled: false, Capacity: big.NewInt(0), Rate: big.NewInt(0)}
+		outbound, inbound := disabled, disabled
+		if cfg.OutboundRateLimiter != nil {
+			outbound = *cfg.OutboundRateLimiter
+		}
+		if cfg.InboundRateLimiter != nil {
+			inbound = *cfg.InboundRateLimiter
+		}
+		tx, err := pool.ApplyChainUpdates(chain.DeployerKey, []uint64{}, []burn_mint_token_pool.TokenPoolChainUpdate{
+			{
+				RemoteChainSelector:       remoteSel,
+				RemotePoolAddresses:       [][]byte{remotePoolBytes},
+				RemoteTokenAddress:        common.LeftPadBytes(remoteToken.Bytes(), 32),
+				OutboundRateLimiterConfig: outbound,
+				InboundRateLimiterConfig:  inbound,
+			},
+		})
+		if _, err := deployment.ConfirmIfNoError(chain, tx, err); err != nil {
+			return fmt.Errorf("failed to add chain %d to pool %s: %w", remoteSel, pool.Address(), err)
+		}
+		return nil
+	}
+	remoteTokenBytes, err := pool.GetRemoteToken(opts, remoteSel)
+	if err != nil {
+		return fmt.Errorf("failed to get remote token of chain %d: %w", remoteSel, err)
+	}
+	if !bytes.Equal(remoteTokenBytes, common.LeftPadBytes(remoteToken.Bytes(), 32)) {
+		return fmt.Errorf("pool %s has remote token %x for chain %d, expected %s", pool.Address(), remoteTokenBytes, remoteSel, remoteToken)
+	}
+	isRemotePool, err := pool.IsRemotePool(opts, remoteSel, remotePoolBytes)
+	if err != nil {
+		return fmt.Errorf("failed to check remote pool of chain %d: %w", remoteSel, err)
+	}
+	if isRemotePool {
+		return nil
+	}
+	tx, err := pool.AddRemotePool(chain.DeployerKey, remoteSel, remotePoolBytes)
+	if _, err := deployment.ConfirmIfNoError(chain, tx, err); err != nil {
+		return fmt.Errorf("failed to add remote pool %s of chain %d to pool %s: %w", remotePool, remoteSel, pool.Address(), err)
+	}
+	return nil
+}
+
+// ValidateTokenPoolDecimals checks that the pool is configured with the decimals of its token.
+// Pools don't read the decimals from the token, a mismatch would scale the amounts received
+// on the remote chains by a power of ten.
+func ValidateTokenPoolDecimals(chain deployment.Chain, pool *burn_mint_token_pool.BurnMintTokenPool) error {
+	poolDecimals, err := pool.GetTokenDecimals(&bind.CallOpts{Context: context.Background()})
+	if err != nil {
+		return fmt.Errorf("failed to get decimals of token pool %s: %w", pool.Address(), err)
+	}
+	tokenAddress, err := pool.GetToken(&bind.CallOpts{Context: context.Background()})
+	if err != nil {
+		return fmt.Errorf("failed to get token of token pool %s: %w", pool.Address(), err)
+	}
+	token, err := burn_mint_erc677.NewBurnMintERC677(tokenAddress, chain.Client)
+	if err != nil {
+		return err
+	}
+	tokenDecimals, err := token.Decimals(&bind.CallOpts{Context: context.Background()})
+	if err != nil {
+		return fmt.Errorf("failed to get decimals of token %s: %w", tokenAddress, err)
+	}
+	if poolDecimals != tokenDecimals {
+		return fmt.Errorf("token pool %s on chain %d has %d decimals but its token %s has %d decimals",
+			pool.Address(), chain.Selector, poolDecimals, tokenAddress, tokenDecimals)
+	}
+	return nil
+}
+
+// GrantMintBurnPermissions grants the mint and burn roles of the token to the address, e.g. its pool.
+func GrantMintBurnPermissions(lggr logger.Logger, chain deployment.Chain, token *burn_mint_erc677.BurnMintERC677, address common.Address) error {
+	lggr.Infow("Granting burn permissions", "token", token.Address(), "burner", address)
+	tx, err := token.GrantBurnRole(chain.DeployerKey, address)
+	if err != nil {
+		return err
+	}
+	_, err = chain.Confirm(tx)
+	if err != nil {
+		return err
+	}
+
+	lggr.Infow("Granting mint permissions", "token", token.Address(), "minter", address)
+	tx, err = token.GrantMintRole(chain.DeployerKey, address)
+	if err != nil {
+		return err
+	}
+	_, err = chain.Confirm(tx)
+	return err
+}
@@ -0,0 +1,152 @@
+package changeset_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-testing-framework/lib/utils/testcontext"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset/testhelpers"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/burn_mint_token_pool"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/router"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/shared/generated/burn_mint_erc677"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+func TestOnboardToken(t *testing.T) {
+	lggr := logger.TestLogger(t)
+	e := testhelpers.NewMemoryEnvironmentWithJobsAndContracts(t, lggr, 2, 4, nil)
+	state, err := changeset.LoadOnchainState(e.Env)
+	require.NoError(t, err)
+	chainA, chainB := e.HomeChainSel, e.FeedChainSel
+
+	// the token developer deploys its own tokens
+	cfg := changeset.TokenOnboardingConfig{
+		Symbol: "DEV",
+		Chains: make(map[uint64]changeset.TokenOnboardingChainConfig),
+	}
+	tokens := make(map[uint64]*burn_mint_erc677.BurnMintERC677)
+	for sel, decimals := range map[uint64]uint8{chainA: 6, chainB: 18} {
+		chain := e.Env.Chains[sel]
+		addr, tx, token, err := burn_mint_erc677.DeployBurnMintERC677(chain.DeployerKey, chain.Client, "DEV", "DEV", decimals, big.NewInt(0))
+		_, err = deployment.ConfirmIfNoError(chain, tx, err)
+		require.NoError(t, err)
+		tx, err = token.GrantMintRole(chain.DeployerKey, chain.DeployerKey.From)
+		_, err = deployment.ConfirmIfNoError(chain, tx, err)
+		require.NoError(t, err)
+		tokens[sel] = token
+		cfg.Chains[sel] = changeset.TokenOnboardingChainConfig{
+			Token:              addr,
+			Decimals:           decimals,
+			AdminRegistration:  changeset.RegisterAdminViaOwner,
+			GrantMintBurnRoles: true,
+		}
+	}
+
+	// a previous run stopped after registering the admin on chain A
+	tx, err := state.Chains[chainA].RegistryModule.RegisterAdminViaOwner(e.Env.Chains[chainA].DeployerKey, tokens[chainA].Address())
+	_, err = deployment.ConfirmIfNoError(e.Env.Chains[chainA], tx, err)
+	require.NoError(t, err)
+
+	output, err := changeset.OnboardToken(e.Env, cfg)
+	require.NoError(t, err)
+	require.NoError(t, e.Env.ExistingAddresses.Merge(output.AddressBook))
+
+	for sel, chainCfg := range cfg.Chains {
+		tokenConfig, err := state.Chains[sel].TokenAdminRegistry.GetTokenConfig(nil, chainCfg.Token)
+		require.NoError(t, err)
+		require.Equal(t, e.Env.Chains[sel].DeployerKey.From, tokenConfig.Administrator)
+		require.NotEqual(t, common.Address{}, tokenConfig.TokenPool)
+	}
+
+	// re-running is a no-op
+	output, err = changeset.OnboardToken(e.Env, cfg)
+	require.NoError(t, err)
+	for sel := range cfg.Chains {
+		addresses, err := output.AddressBook.AddressesForChain(sel)
+		require.ErrorIs(t, err, deployment.ErrChainNotFound)
+		require.Empty(t, addresses)
+	}
+
+	// the pool of another token is rejected
+	other := changeset.TokenOnboardingConfig{Symbol: "OTHER", Chains: make(map[uint64]changeset.TokenOnboardingChainConfig)}
+	otherTokens := make(map[uint64]common.Address)
+	for sel := range cfg.Chains {
+		chain := e.Env.Chains[sel]
+		addr, tx, _, err := burn_mint_erc677.DeployBurnMintERC677(chain.DeployerKey, chain.Client, "OTHER", "OTHER", 6, big.NewInt(0))
+		_, err = deployment.ConfirmIfNoError(chain, tx, err)
+		require.NoError(t, err)
+		otherTokens[sel] = addr
+		tokenConfig, err := state.Chains[sel].TokenAdminRegistry.GetTokenConfig(nil, cfg.Chains[sel].Token)
+		require.NoError(t, err)
+		other.Chains[sel] = changeset.TokenOnboardingChainConfig{
+			Token:             addr,
+			Decimals:          6,
+			Pool:              tokenConfig.TokenPool,
+			AdminRegistration: changeset.RegisterAdminViaOwner,
+		}
+	}
+	_, err = changeset.OnboardToken(e.Env, other)
+	require.ErrorContains(t, err, "is a pool of token")
+
+	// the pool deployed before a failure is kept in the address book: the pools are deployed with
+	// the wrong decimals, so onboarding fails on the first chain right after deploying its pool
+	for sel, chainCfg := range other.Chains {
+		chainCfg.Pool = common.Address{}
+		chainCfg.Decimals = 18
+		other.Chains[sel] = chainCfg
+	}
+	_, err = changeset.OnboardToken(e.Env, other)
+	require.ErrorContains(t, err, "decimals")
+	var deployedPools int
+	for sel := range other.Chains {
+		addresses, err := e.Env.ExistingAddresses.AddressesForChain(sel)
+		require.NoError(t, err)
+		for addr, tv := range addresses {
+			if tv.Type != changeset.BurnMintTokenPool {
+				continue
+			}
+			pool, err := burn_mint_token_pool.NewBurnMintTokenPool(common.HexToAddress(addr), e.Env.Chains[sel].Client)
+			require.NoError(t, err)
+			token, err := pool.GetToken(nil)
+			require.NoError(t, err)
+			if token == otherTokens[sel] {
+				deployedPools++
+			}
+		}
+	}
+	require.Equal(t, 1, deployedPools)
+
+	require.NoError(t, testhelpers.AddLanesForAll(e.Env, state))
+	amount := big.NewInt(1_000_000)
+	chain := e.Env.Chains[chainA]
+	tx, err = tokens[chainA].Mint(chain.DeployerKey, chain.DeployerKey.From, amount)
+	_, err = deployment.ConfirmIfNoError(chain, tx, err)
+	require.NoError(t, err)
+	tx, err = tokens[chainA].Approve(chain.DeployerKey, state.Chains[chainA].Router.Address(), amount)
+	_, err = deployment.ConfirmIfNoError(chain, tx, err)
+	require.NoError(t, err)
+
+	latesthdr, err := e.Env.Chains[chainB].Client.HeaderByNumber(testcontext.Get(t), nil)
+	require.NoError(t, err)
+	block := latesthdr.Number.Uint64()
+	msgSentEvent := testhelpers.TestSendRequest(t, e.Env, state, chainA, chainB, false, router.ClientEVM2AnyMessage{
+		Receiver:     common.LeftPadBytes(state.Chains[chainB].Receiver.Address().Bytes(), 32),
+		Data:         []byte("hello"),
+		TokenAmounts: []router.ClientEVMTokenAmount{{Token: tokens[chainA].Address(), Amount: amount}},
+		FeeToken:     common.HexToAddress("0x0"),
+		ExtraArgs:    nil,
+	})
+	testhelpers.ConfirmExecWithSeqNrsForAll(t, e.Env, state, map[changeset.SourceDestPair][]uint64{
+		{SourceChainSelector: chainA, DestChainSelector: chainB}: {msgSentEvent.SequenceNumber},
+	}, map[uint64]*uint64{chainB: &block})
+
+	balance, err := tokens[chainB].BalanceOf(nil, state.Chains[chainB].Receiver.Address())
+	require.NoError(t, err)
+	require.Equal(t, testhelpers.ExpectedRemoteAmount(amount, 6, 18).String(), balance.String())
+}
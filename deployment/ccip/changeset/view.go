@@ -2,9 +2,12 @@ package changeset
 
 import (
 	"encoding/json"
+	"fmt"
+	"io"
 
 	"github.com/smartcontractkit/chainlink/deployment"
 	ccipview "github.com/smartcontractkit/chainlink/deployment/ccip/view"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/view/v1_6"
 	"github.com/smartcontractkit/chainlink/deployment/common/view"
 )
 
@@ -28,3 +31,28 @@ func ViewCCIP(e deployment.Environment) (json.Marshaler, error) {
 		Nops:   nopsView,
 	}, nil
 }
+
+// ViewCCIPHome returns the view of the CCIPHome of the home chain, with the configs of the chains and of the DONs.
+func ViewCCIPHome(e deployment.Environment, homeChainSel uint64) (v1_6.CCIPHomeView, error) {
+	state, err := LoadOnchainState(e)
+	if err != nil {
+		return v1_6.CCIPHomeView{}, err
+	}
+	chainState, ok := state.Chains[homeChainSel]
+	if !ok || chainState.CapabilityRegistry == nil || chainState.CCIPHome == nil {
+		return v1_6.CCIPHomeView{}, fmt.Errorf("capability registry and CCIPHome not deployed on home chain %d", homeChainSel)
+	}
+	return v1_6.GenerateCCIPHomeView(chainState.CapabilityRegistry, chainState.CCIPHome)
+}
+
+// ExportCCIPHomeSnapshot writes the view of the CCIPHome of the home chain as JSON, e.g. for dashboards
+// or to diff the home chain config before and after a changeset.
+func ExportCCIPHomeSnapshot(e deployment.Environment, homeChainSel uint64, w io.Writer) error {
+	homeView, err := ViewCCIPHome(e, homeChainSel)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", " ")
+	return enc.Encode(homeView)
+}
@@ -0,0 +1,14 @@
+// Package sdk is the stable facade of the CCIP deployment library for external integrators.
+//
+// It loads an environment from RPCs and an address book, reads the CCIP contracts deployed in it,
+// estimates fees, sends messages and tracks them until they are executed. Unlike the changeset test helpers,
+// nothing here depends on a *testing.T, nor on the changesets, so it can be embedded in CLIs and daemons:
+//
+//	e, err := sdk.LoadEnvironment(lggr, cfg)
+//	state, err := sdk.LoadOnchainState(e)
+//	msg, err := sdk.Send(ctx, e, state, src, dest, sdk.Message{Receiver: receiver, Data: data})
+//	status, err := sdk.WaitForExecution(ctx, e, state, msg, sdk.DefaultPollInterval)
+//
+// The Explorer traces any message of the environment by ID across all its chains, from Go or over HTTP,
+// and GenerateFeeReport sums the fees and token transfers of its lanes for finance reconciliation.
+package sdk
@@ -0,0 +1,99 @@
+package sdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/deployment/environment/devenv"
+)
+
+// EnvironmentConfig configures an environment without offchain components.
+type EnvironmentConfig struct {
+	Name   string
+	Chains []devenv.ChainConfig
+	// AddressBook holds the addresses of the contracts deployed on the chains, see ReadAddressBook.
+	AddressBook deployment.AddressBook
+}
+
+func (c EnvironmentConfig) Validate() error {
+	if c.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if len(c.Chains) == 0 {
+		return fmt.Errorf("no chains")
+	}
+	if c.AddressBook == nil {
+		return fmt.Errorf("address book is required")
+	}
+	return nil
+}
+
+// LoadEnvironment connects to the chains of the config. The environment has no nodes,
+// so it can be used to read state and send messages but not to manage jobs.
+func LoadEnvironment(lggr logger.Logger, cfg EnvironmentConfig) (deployment.Environment, error) {
+	if err := cfg.Validate(); err != nil {
+		return deployment.Environment{}, fmt.Errorf("invalid environment config: %w", err)
+	}
+	chains, err := devenv.NewChains(lggr, cfg.Chains)
+	if err != nil {
+		return deployment.Environment{}, fmt.Errorf("failed to create chains: %w", err)
+	}
+	return *deployment.NewEnvironment(cfg.Name, lggr, cfg.AddressBook, chains, nil, nil), nil
+}
+
+// ReadAddressBook reads an address book from JSON of the form
+//
+//	{"<chain selector>": {"<address>": "<type> <version>"}}
+//
+// as written by WriteAddressBook.
+func ReadAddressBook(r io.Reader) (deployment.AddressBook, error) {
+	var raw map[string]map[string]string
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("failed to decode address book: %w", err)
+	}
+	addresses := make(map[uint64]map[string]deployment.TypeAndVersion, len(raw))
+	for sel, chainAddresses := range raw {
+		chainSel, err := strconv.ParseUint(sel, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid chain selector %q: %w", sel, err)
+		}
+		addresses[chainSel] = make(map[string]deployment.TypeAndVersion, len(chainAddresses))
+		for addr, tv := range chainAddresses {
+			typeAndVersion, err := deployment.TypeAndVersionFromString(tv)
+			if err != nil {
+				return nil, fmt.Errorf("invalid type and version of %s on chain %d: %w", addr, chainSel, err)
+			}
+			addresses[chainSel][addr] = typeAndVersion
+		}
+	}
+	// validate the selectors and addresses as if they were saved one by one
+	ab := deployment.NewMemoryAddressBook()
+	if err := ab.Merge(deployment.NewMemoryAddressBookFromMap(addresses)); err != nil {
+		return nil, fmt.Errorf("invalid address book: %w", err)
+	}
+	return ab, nil
+}
+
+// WriteAddressBook writes the address book as JSON, see ReadAddressBook.
+func WriteAddressBook(w io.Writer, ab deployment.AddressBook) error {
+	addresses, err := ab.Addresses()
+	if err != nil {
+		return err
+	}
+	raw := make(map[string]map[string]string, len(addresses))
+	for chainSel, chainAddresses := range addresses {
+		sel := strconv.FormatUint(chainSel, 10)
+		raw[sel] = make(map[string]string, len(chainAddresses))
+		for addr, tv := range chainAddresses {
+			raw[sel][addr] = tv.String()
+		}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(raw)
+}
@@ -0,0 +1,222 @@
+package sdk
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+)
+
+// ErrMessageNotFound is returned by the explorer for messages not sent by any onramp of the environment.
+var ErrMessageNotFound = errors.New("message not found")
+
+// Stage is the transaction a message went through a stage in.
+type Stage struct {
+	ChainSelector uint64      `json:"chainSelector"`
+	BlockNumber   uint64      `json:"blockNumber"`
+	TxHash        common.Hash `json:"txHash"`
+	Timestamp     time.Time   `json:"timestamp"`
+}
+
+// MessageTrace is where a message is, with the transactions it was sent, committed and executed in.
+// Stages the message did not reach yet are nil. Executed is the last execution state change of the message,
+// a failed execution is followed by another one if the message is manually executed.
+type MessageTrace struct {
+	MessageID           common.Hash   `json:"messageId"`
+	SourceChainSelector uint64        `json:"sourceChainSelector"`
+	DestChainSelector   uint64        `json:"destChainSelector"`
+	SequenceNumber      uint64        `json:"sequenceNumber"`
+	Status              MessageStatus `json:"status"`
+	Sent                *Stage        `json:"sent"`
+	Committed           *Stage        `json:"committed,omitempty"`
+	Executed            *Stage        `json:"executed,omitempty"`
+}
+
+// Explorer answers where messages are across all the chains of an environment, from the logs of its onramps
+// and offramps, for soak tests and demos.
+type Explorer struct {
+	env   deployment.Environment
+	state OnchainState
+	// StartBlocks are the blocks the logs of the chains are searched from, the genesis block for chains without one.
+	StartBlocks map[uint64]uint64
+}
+
+// NewExplorer returns an explorer of the messages between the chains of the onchain state.
+func NewExplorer(e deployment.Environment, state OnchainState) *Explorer {
+	return &Explorer{env: e, state: state, StartBlocks: make(map[uint64]uint64)}
+}
+
+func (x *Explorer) filterOpts(ctx context.Context, chainSelector uint64) *bind.FilterOpts {
+	return &bind.FilterOpts{Context: ctx, Start: x.StartBlocks[chainSelector]}
+}
+
+// FindMessage searches the onramps of all chains for the message and traces it on its destination chain.
+func (x *Explorer) FindMessage(ctx context.Context, messageID [32]byte) (MessageTrace, error) {
+	for sel, chainState := range x.state.Chains {
+		if chainState.OnRamp == nil {
+			continue
+		}
+		it, err := chainState.OnRamp.FilterCCIPMessageSent(x.filterOpts(ctx, sel), nil, nil)
+		if err != nil {
+			return MessageTrace{}, fmt.Errorf("failed to filter messages sent on chain %d: %w", sel, err)
+		}
+		var sent *SentMessage
+		for it.Next() {
+			if it.Event.Message.Header.MessageId == messageID {
+				sent = &SentMessage{
+					SourceChainSelector: sel,
+					DestChainSelector:   it.Event.DestChainSelector,
+					MessageID:           messageID,
+					SequenceNumber:      it.Event.SequenceNumber,
+					Nonce:               it.Event.Message.Header.Nonce,
+					BlockNumber:         it.Event.Raw.BlockNumber,
+					TxHash:              it.Event.Raw.TxHash,
+					Event:               it.Event,
+				}
+				break
+			}
+		}
+		err = it.Error()
+		it.Close()
+		if err != nil {
+			return MessageTrace{}, fmt.Errorf("failed to filter messages sent on chain %d: %w", sel, err)
+		}
+		if sent != nil {
+			return x.Trace(ctx, sent)
+		}
+	}
+	return MessageTrace{}, fmt.Errorf("%w: %x", ErrMessageNotFound, messageID)
+}
+
+// Trace returns where the sent message is on its destination chain.
+func (x *Explorer) Trace(ctx context.Context, msg *SentMessage) (MessageTrace, error) {
+	trace := MessageTrace{
+		MessageID:           msg.MessageID,
+		SourceChainSelector: msg.SourceChainSelector,
+		DestChainSelector:   msg.DestChainSelector,
+		SequenceNumber:      msg.SequenceNumber,
+		Status:              MessageStatusSent,
+	}
+	var err error
+	trace.Sent, err = x.stage(ctx, msg.SourceChainSelector, msg.BlockNumber, msg.TxHash)
+	if err != nil {
+		return trace, err
+	}
+	destState, ok := x.state.Chains[msg.DestChainSelector]
+	if !ok || destState.OffRamp == nil {
+		return trace, fmt.Errorf("offramp not deployed on chain %d", msg.DestChainSelector)
+	}
+
+	commitIt, err := destState.OffRamp.FilterCommitReportAccepted(x.filterOpts(ctx, msg.DestChainSelector))
+	if err != nil {
+		return trace, fmt.Errorf("failed to filter commit reports on chain %d: %w", msg.DestChainSelector, err)
+	}
+	defer commitIt.Close()
+	for trace.Committed == nil && commitIt.Next() {
+		for _, root := range commitIt.Event.MerkleRoots {
+			if root.SourceChainSelector == msg.SourceChainSelector &&
+				root.MinSeqNr <= msg.SequenceNumber && msg.SequenceNumber <= root.MaxSeqNr {
+				trace.Status = MessageStatusCommitted
+				trace.Committed, err = x.stage(ctx, msg.DestChainSelector, commitIt.Event.Raw.BlockNumber, commitIt.Event.Raw.TxHash)
+				if err != nil {
+					return trace, err
+				}
+				break
+			}
+		}
+	}
+	if err := commitIt.Error(); err != nil {
+		return trace, fmt.Errorf("failed to filter commit reports on chain %d: %w", msg.DestChainSelector, err)
+	}
+
+	execIt, err := destState.OffRamp.FilterExecutionStateChanged(x.filterOpts(ctx, msg.DestChainSelector),
+		[]uint64{msg.SourceChainSelector}, []uint64{msg.SequenceNumber}, [][32]byte{msg.MessageID})
+	if err != nil {
+		return trace, fmt.Errorf("failed to filter execution state changes on chain %d: %w", msg.DestChainSelector, err)
+	}
+	defer execIt.Close()
+	for execIt.Next() {
+		switch execIt.Event.State {
+		case executionStateInProgress:
+			trace.Status = MessageStatusInProgress
+		case executionStateSuccess:
+			trace.Status = MessageStatusSuccess
+		case executionStateFailure:
+			trace.Status = MessageStatusFailure
+		}
+		trace.Executed, err = x.stage(ctx, msg.DestChainSelector, execIt.Event.Raw.BlockNumber, execIt.Event.Raw.TxHash)
+		if err != nil {
+			return trace, err
+		}
+	}
+	if err := execIt.Error(); err != nil {
+		return trace, fmt.Errorf("failed to filter execution state changes on chain %d: %w", msg.DestChainSelector, err)
+	}
+	return trace, nil
+}
+
+func (x *Explorer) stage(ctx context.Context, chainSelector, blockNumber uint64, txHash common.Hash) (*Stage, error) {
+	chain, ok := x.env.Chains[chainSelector]
+	if !ok {
+		return nil, fmt.Errorf("chain %d not found in environment", chainSelector)
+	}
+	header, err := chain.Client.HeaderByNumber(ctx, new(big.Int).SetUint64(blockNumber))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get header of block %d of chain %d: %w", blockNumber, chainSelector, err)
+	}
+	return &Stage{
+		ChainSelector: chainSelector,
+		BlockNumber:   blockNumber,
+		TxHash:        txHash,
+		Timestamp:     time.Unix(int64(header.Time), 0).UTC(), //nolint:gosec // block timestamps fit in an int64
+	}, nil
+}
+
+// Handler serves the traces of messages as JSON at GET /messages/{id}, with the message ID in hex.
+// It can be served next to a devenv during soak tests and demos:
+//
+//	go http.ListenAndServe("localhost:8080", sdk.NewExplorer(e, state).Handler())
+func (x *Explorer) Handler() http.Handler {
+	return explorerHandler(x.FindMessage)
+}
+
+func explorerHandler(find func(context.Context, [32]byte) (MessageTrace, error)) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /messages/{id}", func(w http.ResponseWriter, r *http.Request) {
+		id, err := parseMessageID(r.PathValue("id"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		trace, err := find(r.Context(), id)
+		if errors.Is(err, ErrMessageNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(trace)
+	})
+	return mux
+}
+
+func parseMessageID(s string) ([32]byte, error) {
+	b, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	if err != nil || len(b) != 32 {
+		return [32]byte{}, fmt.Errorf("invalid message ID %q", s)
+	}
+	return [32]byte(b), nil
+}
@@ -0,0 +1,222 @@
+package sdk
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"sort"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/onramp"
+)
+
+// BlockRange is a range of blocks of a chain, from Start to End inclusive. A nil End is the latest block.
+type BlockRange struct {
+	Start uint64
+	End   *uint64
+}
+
+// LaneFees are the fees paid in a fee token by the messages of a lane.
+type LaneFees struct {
+	SourceChainSelector uint64         `json:"sourceChainSelector"`
+	DestChainSelector   uint64         `json:"destChainSelector"`
+	FeeToken            common.Address `json:"feeToken"`
+	Messages            uint64         `json:"messages"`
+	// FeeTokenAmount is the sum of the fees in the fee token, FeeValueJuels their value in LINK at the time
+	// they were paid.
+	FeeTokenAmount *big.Int `json:"feeTokenAmount"`
+	FeeValueJuels  *big.Int `json:"feeValueJuels"`
+}
+
+// LaneTransfers are the tokens transferred through a source pool by the messages of a lane, which the pool
+// burned or locked depending on its type.
+type LaneTransfers struct {
+	SourceChainSelector uint64         `json:"sourceChainSelector"`
+	DestChainSelector   uint64         `json:"destChainSelector"`
+	SourcePool          common.Address `json:"sourcePool"`
+	Transfers           uint64         `json:"transfers"`
+	Amount              *big.Int       `json:"amount"`
+}
+
+// FeeReport is the fee revenue and the token transfers of the lanes of an environment over block ranges of
+// their source chains, for finance reconciliation. Rows are sorted by lane, then by token.
+type FeeReport struct {
+	Ranges    map[uint64]BlockRange `json:"ranges"`
+	Fees      []LaneFees            `json:"fees"`
+	Transfers []LaneTransfers       `json:"transfers"`
+}
+
+// GenerateFeeReport scans the messages sent by the onramps of all chains of the state over the block ranges
+// of the chains, the whole chain for chains without range, and sums their fees and token transfers per lane.
+func GenerateFeeReport(ctx context.Context, state OnchainState, ranges map[uint64]BlockRange) (FeeReport, error) {
+	b := newFeeReportBuilder()
+	for sel, chainState := range state.Chains {
+		if chainState.OnRamp == nil {
+			continue
+		}
+		r := ranges[sel]
+		b.report.Ranges[sel] = r
+		it, err := chainState.OnRamp.FilterCCIPMessageSent(&bind.FilterOpts{Context: ctx, Start: r.Start, End: r.End}, nil, nil)
+		if err != nil {
+			return FeeReport{}, fmt.Errorf("failed to filter messages sent on chain %d: %w", sel, err)
+		}
+		for it.Next() {
+			b.add(sel, it.Event)
+		}
+		err = it.Error()
+		it.Close()
+		if err != nil {
+			return FeeReport{}, fmt.Errorf("failed to filter messages sent on chain %d: %w", sel, err)
+		}
+	}
+	return b.build(), nil
+}
+
+type laneKey struct {
+	source, dest uint64
+	token        common.Address
+}
+
+type feeReportBuilder struct {
+	report    FeeReport
+	fees      map[laneKey]*LaneFees
+	transfers map[laneKey]*LaneTransfers
+}
+
+func newFeeReportBuilder() *feeReportBuilder {
+	return &feeReportBuilder{
+		report:    FeeReport{Ranges: make(map[uint64]BlockRange)},
+		fees:      make(map[laneKey]*LaneFees),
+		transfers: make(map[laneKey]*LaneTransfers),
+	}
+}
+
+func (b *feeReportBuilder) add(source uint64, event *onramp.OnRampCCIPMessageSent) {
+	msg := event.Message
+	key := laneKey{source: source, dest: event.DestChainSelector, token: msg.FeeToken}
+	fees, ok := b.fees[key]
+	if !ok {
+		fees = &LaneFees{
+			SourceChainSelector: source,
+			DestChainSelector:   event.DestChainSelector,
+			FeeToken:            msg.FeeToken,
+			FeeTokenAmount:      new(big.Int),
+			FeeValueJuels:       new(big.Int),
+		}
+		b.fees[key] = fees
+	}
+	fees.Messages++
+	addAmount(fees.FeeTokenAmount, msg.FeeTokenAmount)
+	addAmount(fees.FeeValueJuels, msg.FeeValueJuels)
+
+	for _, ta := range msg.TokenAmounts {
+		key := laneKey{source: source, dest: event.DestChainSelector, token: ta.SourcePoolAddress}
+		transfers, ok := b.transfers[key]
+		if !ok {
+			transfers = &LaneTransfers{
+				SourceChainSelector: source,
+				DestChainSelector:   event.DestChainSelector,
+				SourcePool:          ta.SourcePoolAddress,
+				Amount:              new(big.Int),
+			}
+			b.transfers[key] = transfers
+		}
+		transfers.Transfers++
+		addAmount(transfers.Amount, ta.Amount)
+	}
+}
+
+func addAmount(sum, amount *big.Int) {
+	if amount != nil {
+		sum.Add(sum, amount)
+	}
+}
+
+func (b *feeReportBuilder) build() FeeReport {
+	report := b.report
+	report.Fees = make([]LaneFees, 0, len(b.fees))
+	for _, fees := range b.fees {
+		report.Fees = append(report.Fees, *fees)
+	}
+	sort.Slice(report.Fees, func(i, j int) bool {
+		return laneLess(
+			laneKey{report.Fees[i].SourceChainSelector, report.Fees[i].DestChainSelector, report.Fees[i].FeeToken},
+			laneKey{report.Fees[j].SourceChainSelector, report.Fees[j].DestChainSelector, report.Fees[j].FeeToken})
+	})
+	report.Transfers = make([]LaneTransfers, 0, len(b.transfers))
+	for _, transfers := range b.transfers {
+		report.Transfers = append(report.Transfers, *transfers)
+	}
+	sort.Slice(report.Transfers, func(i, j int) bool {
+		return laneLess(
+			laneKey{report.Transfers[i].SourceChainSelector, report.Transfers[i].DestChainSelector, report.Transfers[i].SourcePool},
+			laneKey{report.Transfers[j].SourceChainSelector, report.Transfers[j].DestChainSelector, report.Transfers[j].SourcePool})
+	})
+	return report
+}
+
+func laneLess(a, b laneKey) bool {
+	if a.source != b.source {
+		return a.source < b.source
+	}
+	if a.dest != b.dest {
+		return a.dest < b.dest
+	}
+	return a.token.Cmp(b.token) < 0
+}
+
+// WriteJSON writes the report as indented JSON.
+func (r FeeReport) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// WriteFeesCSV writes the fees of the report as CSV, with a header row.
+func (r FeeReport) WriteFeesCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"source_chain_selector", "dest_chain_selector", "fee_token", "messages", "fee_token_amount", "fee_value_juels"}); err != nil {
+		return err
+	}
+	for _, fees := range r.Fees {
+		if err := cw.Write([]string{
+			strconv.FormatUint(fees.SourceChainSelector, 10),
+			strconv.FormatUint(fees.DestChainSelector, 10),
+			fees.FeeToken.Hex(),
+			strconv.FormatUint(fees.Messages, 10),
+			fees.FeeTokenAmount.String(),
+			fees.FeeValueJuels.String(),
+		}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteTransfersCSV writes the token transfers of the report as CSV, with a header row.
+func (r FeeReport) WriteTransfersCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"source_chain_selector", "dest_chain_selector", "source_pool", "transfers", "amount"}); err != nil {
+		return err
+	}
+	for _, transfers := range r.Transfers {
+		if err := cw.Write([]string{
+			strconv.FormatUint(transfers.SourceChainSelector, 10),
+			strconv.FormatUint(transfers.DestChainSelector, 10),
+			transfers.SourcePool.Hex(),
+			strconv.FormatUint(transfers.Transfers, 10),
+			transfers.Amount.String(),
+		}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
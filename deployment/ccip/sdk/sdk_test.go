@@ -0,0 +1,139 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	chainsel "github.com/smartcontractkit/chain-selectors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/onramp"
+)
+
+func TestAddressBookRoundTrip(t *testing.T) {
+	sel := chainsel.TEST_90000001.Selector
+	ab := deployment.NewMemoryAddressBook()
+	require.NoError(t, ab.Save(sel, "0x1000000000000000000000000000000000000000",
+		deployment.NewTypeAndVersion("Router", deployment.Version1_2_0)))
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteAddressBook(&buf, ab))
+	read, err := ReadAddressBook(&buf)
+	require.NoError(t, err)
+	expected, err := ab.Addresses()
+	require.NoError(t, err)
+	actual, err := read.Addresses()
+	require.NoError(t, err)
+	require.Equal(t, expected, actual)
+
+	_, err = ReadAddressBook(strings.NewReader(`{"1": {"0x1000000000000000000000000000000000000000": "Router 1.2.0"}}`))
+	require.ErrorContains(t, err, "invalid address book")
+	_, err = ReadAddressBook(strings.NewReader(`{"abc": {}}`))
+	require.ErrorContains(t, err, "invalid chain selector")
+	_, err = ReadAddressBook(strings.NewReader(`{"1": {"0x1000000000000000000000000000000000000000": "Router"}}`))
+	require.ErrorContains(t, err, "invalid type and version")
+}
+
+func TestMessageStatus(t *testing.T) {
+	require.False(t, MessageStatusCommitted.Final())
+	require.True(t, MessageStatusSuccess.Final())
+	require.True(t, MessageStatusFailure.Final())
+	require.Equal(t, "IN_PROGRESS", MessageStatusInProgress.String())
+}
+
+func TestEVMExtraArgsV2(t *testing.T) {
+	extraArgs := evmExtraArgsV2(200_000, true)
+	require.Len(t, extraArgs, 4+32+32)
+	require.Equal(t, evmExtraArgsV2Tag, extraArgs[:4])
+	require.Equal(t, big.NewInt(200_000), new(big.Int).SetBytes(extraArgs[4:36]))
+	require.Equal(t, byte(1), extraArgs[67])
+	require.Equal(t, byte(0), evmExtraArgsV2(200_000, false)[67])
+	require.Empty(t, Message{}.evm2AnyMessage().ExtraArgs)
+}
+
+func TestExplorerHandler(t *testing.T) {
+	id := [32]byte{1, 2, 3}
+	handler := explorerHandler(func(_ context.Context, messageID [32]byte) (MessageTrace, error) {
+		if messageID != id {
+			return MessageTrace{}, ErrMessageNotFound
+		}
+		return MessageTrace{
+			MessageID: messageID,
+			Status:    MessageStatusCommitted,
+			Sent:      &Stage{ChainSelector: 1, BlockNumber: 10, Timestamp: time.Unix(100, 0).UTC()},
+			Committed: &Stage{ChainSelector: 2, BlockNumber: 20, Timestamp: time.Unix(200, 0).UTC()},
+		}, nil
+	})
+	get := func(path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec
+	}
+
+	rec := get("/messages/" + common.Hash(id).Hex())
+	require.Equal(t, http.StatusOK, rec.Code)
+	var trace map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &trace))
+	require.Equal(t, "COMMITTED", trace["status"])
+	require.Equal(t, common.Hash(id).Hex(), trace["messageId"])
+	require.NotContains(t, trace, "executed")
+
+	require.Equal(t, http.StatusNotFound, get("/messages/"+common.Hash{}.Hex()).Code)
+	require.Equal(t, http.StatusBadRequest, get("/messages/0x1234").Code)
+}
+
+func TestFeeReport(t *testing.T) {
+	link := common.HexToAddress("0x10")
+	pool := common.HexToAddress("0x20")
+	sent := func(dest uint64, feeToken common.Address, fee int64, transfers ...int64) *onramp.OnRampCCIPMessageSent {
+		msg := onramp.InternalEVM2AnyRampMessage{
+			FeeToken:       feeToken,
+			FeeTokenAmount: big.NewInt(fee),
+			FeeValueJuels:  big.NewInt(fee * 2),
+		}
+		for _, amount := range transfers {
+			msg.TokenAmounts = append(msg.TokenAmounts, onramp.InternalEVM2AnyTokenTransfer{SourcePoolAddress: pool, Amount: big.NewInt(amount)})
+		}
+		return &onramp.OnRampCCIPMessageSent{DestChainSelector: dest, Message: msg}
+	}
+	b := newFeeReportBuilder()
+	b.add(1, sent(2, link, 10, 100))
+	b.add(1, sent(2, common.Address{}, 5))
+	b.add(1, sent(2, link, 20, 50, 25))
+	b.add(2, sent(1, link, 1))
+	report := b.build()
+
+	require.Equal(t, []LaneFees{
+		{SourceChainSelector: 1, DestChainSelector: 2, FeeToken: common.Address{}, Messages: 1, FeeTokenAmount: big.NewInt(5), FeeValueJuels: big.NewInt(10)},
+		{SourceChainSelector: 1, DestChainSelector: 2, FeeToken: link, Messages: 2, FeeTokenAmount: big.NewInt(30), FeeValueJuels: big.NewInt(60)},
+		{SourceChainSelector: 2, DestChainSelector: 1, FeeToken: link, Messages: 1, FeeTokenAmount: big.NewInt(1), FeeValueJuels: big.NewInt(2)},
+	}, report.Fees)
+	require.Equal(t, []LaneTransfers{
+		{SourceChainSelector: 1, DestChainSelector: 2, SourcePool: pool, Transfers: 3, Amount: big.NewInt(175)},
+	}, report.Transfers)
+
+	var buf bytes.Buffer
+	require.NoError(t, report.WriteFeesCSV(&buf))
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 4)
+	require.Equal(t, "source_chain_selector,dest_chain_selector,fee_token,messages,fee_token_amount,fee_value_juels", lines[0])
+	require.Equal(t, "1,2,"+link.Hex()+",2,30,60", lines[2])
+	buf.Reset()
+	require.NoError(t, report.WriteTransfersCSV(&buf))
+	require.Equal(t, "source_chain_selector,dest_chain_selector,source_pool,transfers,amount\n1,2,"+pool.Hex()+",3,175\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, report.WriteJSON(&buf))
+	var decoded map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	require.Len(t, decoded["fees"], 3)
+}
@@ -0,0 +1,169 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/onramp"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/router"
+)
+
+// Message is a CCIP message to an EVM chain.
+type Message struct {
+	Receiver     common.Address
+	Data         []byte
+	TokenAmounts []router.ClientEVMTokenAmount
+	// FeeToken is the token the fee is paid in, the native token if unset.
+	FeeToken common.Address
+	// GasLimit and AllowOutOfOrderExecution are encoded in the extra args if GasLimit is set,
+	// otherwise the default extra args of the destination chain apply.
+	GasLimit                 uint64
+	AllowOutOfOrderExecution bool
+	// TestRouter sends the message through the test router instead of the router.
+	TestRouter bool
+}
+
+func (m Message) evm2AnyMessage() router.ClientEVM2AnyMessage {
+	msg := router.ClientEVM2AnyMessage{
+		Receiver:     common.LeftPadBytes(m.Receiver.Bytes(), 32),
+		Data:         m.Data,
+		TokenAmounts: m.TokenAmounts,
+		FeeToken:     m.FeeToken,
+	}
+	if m.GasLimit > 0 {
+		msg.ExtraArgs = evmExtraArgsV2(m.GasLimit, m.AllowOutOfOrderExecution)
+	}
+	return msg
+}
+
+var evmExtraArgsV2Tag = hexutil.MustDecode("0x181dcf10")
+
+// evmExtraArgsV2 encodes the EVMExtraArgsV2 of a message, its tag followed by the abi-encoded gas limit and
+// allowOutOfOrderExecution.
+func evmExtraArgsV2(gasLimit uint64, allowOutOfOrderExecution bool) []byte {
+	var allowOOO byte
+	if allowOutOfOrderExecution {
+		allowOOO = 1
+	}
+	extraArgs := append([]byte{}, evmExtraArgsV2Tag...)
+	extraArgs = append(extraArgs, common.LeftPadBytes(new(big.Int).SetUint64(gasLimit).Bytes(), 32)...)
+	return append(extraArgs, common.LeftPadBytes([]byte{allowOOO}, 32)...)
+}
+
+func getRouter(state OnchainState, src uint64, testRouter bool) (*router.Router, error) {
+	chainState, ok := state.Chains[src]
+	if !ok {
+		return nil, fmt.Errorf("chain %d not found in onchain state", src)
+	}
+	r := chainState.Router
+	if testRouter {
+		r = chainState.TestRouter
+	}
+	if r == nil {
+		return nil, fmt.Errorf("router not deployed on chain %d", src)
+	}
+	return r, nil
+}
+
+// EstimateFee returns the fee of sending the message from src to dest, in the fee token of the message.
+func EstimateFee(ctx context.Context, state OnchainState, src, dest uint64, msg Message) (*big.Int, error) {
+	r, err := getRouter(state, src, msg.TestRouter)
+	if err != nil {
+		return nil, err
+	}
+	fee, err := r.GetFee(&bind.CallOpts{Context: ctx}, dest, msg.evm2AnyMessage())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get fee: %w", deployment.MaybeDataErr(err))
+	}
+	return fee, nil
+}
+
+// SentMessage is a message accepted by the onramp of its source chain.
+type SentMessage struct {
+	SourceChainSelector uint64
+	DestChainSelector   uint64
+	MessageID           [32]byte
+	SequenceNumber      uint64
+	Nonce               uint64
+	// BlockNumber is the block the message was sent in on the source chain.
+	BlockNumber uint64
+	// DestStartBlock is the latest block of the destination chain when the message was sent, the message can't
+	// be committed or executed before it. Zero if the destination chain isn't a chain of the environment.
+	DestStartBlock uint64
+	TxHash         common.Hash
+	Event          *onramp.OnRampCCIPMessageSent
+}
+
+// Send sends the message from src to dest with the deployer key of the source chain and waits for it
+// to be confirmed. Fees in fee tokens must be approved to the router beforehand.
+func Send(ctx context.Context, e deployment.Environment, state OnchainState, src, dest uint64, msg Message) (*SentMessage, error) {
+	r, err := getRouter(state, src, msg.TestRouter)
+	if err != nil {
+		return nil, err
+	}
+	if state.Chains[src].OnRamp == nil {
+		return nil, fmt.Errorf("onramp not deployed on chain %d", src)
+	}
+	var destStartBlock uint64
+	if destChain, ok := e.Chains[dest]; ok {
+		header, err := destChain.Client.HeaderByNumber(ctx, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get latest block of chain %d: %w", dest, err)
+		}
+		destStartBlock = header.Number.Uint64()
+	}
+	evm2AnyMessage := msg.evm2AnyMessage()
+	fee, err := r.GetFee(&bind.CallOpts{Context: ctx}, dest, evm2AnyMessage)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get fee: %w", deployment.MaybeDataErr(err))
+	}
+	chain := e.Chains[src]
+	txOpts := *chain.DeployerKey
+	txOpts.Context = ctx
+	if evm2AnyMessage.FeeToken == (common.Address{}) {
+		txOpts.Value = fee
+	}
+	tx, err := r.CcipSend(&txOpts, dest, evm2AnyMessage)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send message: %w", deployment.MaybeDataErr(err))
+	}
+	blockNum, err := chain.Confirm(tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to confirm message: %w", err)
+	}
+	it, err := state.Chains[src].OnRamp.FilterCCIPMessageSent(&bind.FilterOpts{
+		Start:   blockNum,
+		End:     &blockNum,
+		Context: ctx,
+	}, []uint64{dest}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to filter sent messages: %w", err)
+	}
+	defer it.Close()
+	for it.Next() {
+		if it.Event.Raw.TxHash != tx.Hash() {
+			continue
+		}
+		return &SentMessage{
+			SourceChainSelector: src,
+			DestChainSelector:   dest,
+			MessageID:           it.Event.Message.Header.MessageId,
+			SequenceNumber:      it.Event.SequenceNumber,
+			Nonce:               it.Event.Message.Header.Nonce,
+			BlockNumber:         blockNum,
+			DestStartBlock:      destStartBlock,
+			TxHash:              tx.Hash(),
+			Event:               it.Event,
+		}, nil
+	}
+	if err := it.Error(); err != nil {
+		return nil, fmt.Errorf("failed to filter sent messages: %w", err)
+	}
+	return nil, fmt.Errorf("no CCIPMessageSent event in tx %s on chain %d", tx.Hash(), src)
+}
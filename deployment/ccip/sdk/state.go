@@ -0,0 +1,69 @@
+package sdk
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/offramp"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/onramp"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/router"
+)
+
+// The types and versions of the contracts the SDK reads, as recorded in the address book by the deployment changesets.
+var (
+	routerTypeAndVersion     = deployment.NewTypeAndVersion("Router", deployment.Version1_2_0).String()
+	testRouterTypeAndVersion = deployment.NewTypeAndVersion("TestRouter", deployment.Version1_2_0).String()
+	onRampTypeAndVersion     = deployment.NewTypeAndVersion("OnRamp", deployment.Version1_6_0_dev).String()
+	offRampTypeAndVersion    = deployment.NewTypeAndVersion("OffRamp", deployment.Version1_6_0_dev).String()
+)
+
+// ChainState holds the bindings of the CCIP contracts of a chain used to send and track messages.
+// Contracts not deployed on the chain are nil.
+type ChainState struct {
+	Router     *router.Router
+	TestRouter *router.Router
+	OnRamp     *onramp.OnRamp
+	OffRamp    *offramp.OffRamp
+}
+
+// OnchainState holds the bindings of the CCIP contracts of all chains of an environment.
+type OnchainState struct {
+	Chains map[uint64]ChainState
+}
+
+// LoadOnchainState loads the bindings of the contracts in the address book of the environment.
+func LoadOnchainState(e deployment.Environment) (OnchainState, error) {
+	state := OnchainState{Chains: make(map[uint64]ChainState)}
+	for sel, chain := range e.Chains {
+		addresses, err := e.ExistingAddresses.AddressesForChain(sel)
+		if errors.Is(err, deployment.ErrChainNotFound) {
+			state.Chains[sel] = ChainState{}
+			continue
+		}
+		if err != nil {
+			return state, err
+		}
+		var chainState ChainState
+		for address, tv := range addresses {
+			addr := common.HexToAddress(address)
+			switch tv.String() {
+			case routerTypeAndVersion:
+				chainState.Router, err = router.NewRouter(addr, chain.Client)
+			case testRouterTypeAndVersion:
+				chainState.TestRouter, err = router.NewRouter(addr, chain.Client)
+			case onRampTypeAndVersion:
+				chainState.OnRamp, err = onramp.NewOnRamp(addr, chain.Client)
+			case offRampTypeAndVersion:
+				chainState.OffRamp, err = offramp.NewOffRamp(addr, chain.Client)
+			}
+			if err != nil {
+				return state, fmt.Errorf("failed to bind %s at %s on chain %d: %w", tv, address, sel, err)
+			}
+		}
+		state.Chains[sel] = chainState
+	}
+	return state, nil
+}
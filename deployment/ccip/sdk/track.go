@@ -0,0 +1,136 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+)
+
+// DefaultPollInterval is the interval WaitForExecution polls the destination chain at by default.
+const DefaultPollInterval = 5 * time.Second
+
+// MessageStatus is the progress of a sent message on its destination chain.
+type MessageStatus int
+
+const (
+	// MessageStatusSent messages are not committed on the destination chain yet.
+	MessageStatusSent MessageStatus = iota
+	// MessageStatusCommitted messages are committed but not executed yet.
+	MessageStatusCommitted
+	// MessageStatusInProgress messages are being executed.
+	MessageStatusInProgress
+	// MessageStatusSuccess messages were executed successfully.
+	MessageStatusSuccess
+	// MessageStatusFailure messages failed to execute and can be manually executed.
+	MessageStatusFailure
+)
+
+// The execution states of messages on the offramps, see Internal.MessageExecutionState.
+const (
+	executionStateInProgress uint8 = 1
+	executionStateSuccess    uint8 = 2
+	executionStateFailure    uint8 = 3
+)
+
+func (s MessageStatus) String() string {
+	switch s {
+	case MessageStatusSent:
+		return "SENT"
+	case MessageStatusCommitted:
+		return "COMMITTED"
+	case MessageStatusInProgress:
+		return "IN_PROGRESS"
+	case MessageStatusSuccess:
+		return "SUCCESS"
+	case MessageStatusFailure:
+		return "FAILURE"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// MarshalText encodes the status as its name, e.g. in the traces served by the explorer.
+func (s MessageStatus) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// Final returns whether the status of the message can no longer change without manual execution.
+func (s MessageStatus) Final() bool {
+	return s == MessageStatusSuccess || s == MessageStatusFailure
+}
+
+// GetMessageStatus returns the status of the message on its destination chain. Commit reports are searched from
+// the DestStartBlock of the message, see GetMessageStatusFromBlock.
+func GetMessageStatus(ctx context.Context, state OnchainState, msg *SentMessage) (MessageStatus, error) {
+	return GetMessageStatusFromBlock(ctx, state, msg, msg.DestStartBlock)
+}
+
+// GetMessageStatusFromBlock returns the status of the message on its destination chain, searching the commit
+// reports from startBlock of the destination chain, e.g. for messages not sent with Send.
+func GetMessageStatusFromBlock(ctx context.Context, state OnchainState, msg *SentMessage, startBlock uint64) (MessageStatus, error) {
+	destState, ok := state.Chains[msg.DestChainSelector]
+	if !ok || destState.OffRamp == nil {
+		return MessageStatusSent, fmt.Errorf("offramp not deployed on chain %d", msg.DestChainSelector)
+	}
+	opts := &bind.CallOpts{Context: ctx}
+	executionState, err := destState.OffRamp.GetExecutionState(opts, msg.SourceChainSelector, msg.SequenceNumber)
+	if err != nil {
+		return MessageStatusSent, fmt.Errorf("failed to get execution state: %w", err)
+	}
+	switch executionState {
+	case executionStateInProgress:
+		return MessageStatusInProgress, nil
+	case executionStateSuccess:
+		return MessageStatusSuccess, nil
+	case executionStateFailure:
+		return MessageStatusFailure, nil
+	}
+	it, err := destState.OffRamp.FilterCommitReportAccepted(&bind.FilterOpts{Context: ctx, Start: startBlock})
+	if err != nil {
+		return MessageStatusSent, fmt.Errorf("failed to filter commit reports: %w", err)
+	}
+	defer it.Close()
+	for it.Next() {
+		for _, root := range it.Event.MerkleRoots {
+			if root.SourceChainSelector == msg.SourceChainSelector &&
+				root.MinSeqNr <= msg.SequenceNumber && msg.SequenceNumber <= root.MaxSeqNr {
+				return MessageStatusCommitted, nil
+			}
+		}
+	}
+	if err := it.Error(); err != nil {
+		return MessageStatusSent, fmt.Errorf("failed to filter commit reports: %w", err)
+	}
+	return MessageStatusSent, nil
+}
+
+// WaitForExecution polls the status of the message until it is final or the context is done.
+// The status observed last is returned along with the error of the context.
+func WaitForExecution(ctx context.Context, e deployment.Environment, state OnchainState, msg *SentMessage, pollInterval time.Duration) (MessageStatus, error) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	status := MessageStatusSent
+	for {
+		next, err := GetMessageStatus(ctx, state, msg)
+		if err != nil {
+			return status, err
+		}
+		if next != status {
+			e.Logger.Infow("Message status changed", "messageID", fmt.Sprintf("%x", msg.MessageID),
+				"source", msg.SourceChainSelector, "dest", msg.DestChainSelector, "seqNum", msg.SequenceNumber, "status", next)
+			status = next
+		}
+		if status.Final() {
+			return status, nil
+		}
+		select {
+		case <-ctx.Done():
+			return status, fmt.Errorf("message %x not executed: %w", msg.MessageID, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,239 @@
+package v1_6
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartcontractkit/chainlink-ccip/chainconfig"
+	"github.com/smartcontractkit/chainlink-ccip/pluginconfig"
+
+	"github.com/smartcontractkit/chainlink/deployment/common/view/types"
+	cctypes "github.com/smartcontractkit/chainlink/v2/core/capabilities/ccip/types"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/ccip_home"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/keystone/generated/capabilities_registry"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/p2pkey"
+)
+
+// chainConfigsPageSize is the number of chain configs read from CCIPHome per call.
+const chainConfigsPageSize = 100
+
+// CCIPHomeView is a view of the CCIPHome contract and of the CCIP DONs of the capabilities registry
+// it is the configuration contract of, for monitoring and to verify the state of the home chain.
+type CCIPHomeView struct {
+	types.ContractMetaData
+	ChainConfigs []CCIPHomeChainConfigView `json:"chainConfigs"`
+	DONs         []CCIPHomeDONView         `json:"dons"`
+}
+
+// CCIPHomeChainConfigView is the config of a chain in CCIPHome, with its encoded config decoded.
+type CCIPHomeChainConfigView struct {
+	ChainSelector uint64                  `json:"chainSelector"`
+	Readers       []p2pkey.PeerID         `json:"readers"`
+	FChain        uint8                   `json:"fChain"`
+	Config        chainconfig.ChainConfig `json:"config"`
+}
+
+// CCIPHomeDONView is a CCIP DON with the configs of its plugins.
+type CCIPHomeDONView struct {
+	DONID uint32 `json:"donId"`
+	// ChainSelector is the destination chain of the DON, from its active or else its candidate commit config.
+	ChainSelector uint64                    `json:"chainSelector"`
+	F             uint8                     `json:"f"`
+	Members       []p2pkey.PeerID           `json:"members"`
+	Commit        CCIPHomePluginConfigsView `json:"commit"`
+	Exec          CCIPHomePluginConfigsView `json:"exec"`
+}
+
+// CCIPHomePluginConfigsView are the active and candidate configs of a plugin, nil if unset.
+type CCIPHomePluginConfigsView struct {
+	Active    *CCIPHomeOCR3ConfigView `json:"active,omitempty"`
+	Candidate *CCIPHomeOCR3ConfigView `json:"candidate,omitempty"`
+}
+
+// CCIPHomeOCR3ConfigView is an OCR3 config of a plugin, with its offchain config decoded.
+type CCIPHomeOCR3ConfigView struct {
+	Version               uint32                              `json:"version"`
+	ChainSelector         uint64                              `json:"chainSelector"`
+	ConfigDigest          string                              `json:"configDigest"`
+	FRoleDON              uint8                               `json:"fRoleDON"`
+	OffchainConfigVersion uint64                              `json:"offchainConfigVersion"`
+	OffRampAddress        string                              `json:"offRampAddress"`
+	RMNHomeAddress        string                              `json:"rmnHomeAddress"`
+	Nodes                 []CCIPHomeOCR3NodeView              `json:"nodes"`
+	CommitOffchainConfig  *pluginconfig.CommitOffchainConfig  `json:"commitOffchainConfig,omitempty"`
+	ExecuteOffchainConfig *pluginconfig.ExecuteOffchainConfig `json:"executeOffchainConfig,omitempty"`
+}
+
+type CCIPHomeOCR3NodeView struct {
+	PeerID         p2pkey.PeerID `json:"peerId"`
+	SignerKey      string        `json:"signerKey"`
+	TransmitterKey string        `json:"transmitterKey"`
+}
+
+// DONForChain returns the DON of the destination chain.
+func (v CCIPHomeView) DONForChain(chainSelector uint64) (CCIPHomeDONView, bool) {
+	for _, don := range v.DONs {
+		if don.ChainSelector == chainSelector {
+			return don, true
+		}
+	}
+	return CCIPHomeDONView{}, false
+}
+
+// ChainConfig returns the config of the chain.
+func (v CCIPHomeView) ChainConfig(chainSelector uint64) (CCIPHomeChainConfigView, bool) {
+	for _, cfg := range v.ChainConfigs {
+		if cfg.ChainSelector == chainSelector {
+			return cfg, true
+		}
+	}
+	return CCIPHomeChainConfigView{}, false
+}
+
+func GenerateCCIPHomeView(capReg *capabilities_registry.CapabilitiesRegistry, ccipHome *ccip_home.CCIPHome) (CCIPHomeView, error) {
+	tv, err := types.NewContractMetaData(ccipHome, ccipHome.Address())
+	if err != nil {
+		return CCIPHomeView{}, err
+	}
+	chainConfigs, err := generateCCIPHomeChainConfigViews(ccipHome)
+	if err != nil {
+		return CCIPHomeView{}, err
+	}
+	caps, err := capReg.GetCapabilities(nil)
+	if err != nil {
+		return CCIPHomeView{}, fmt.Errorf("failed to get capabilities: %w", err)
+	}
+	ccipCapabilities := make(map[[32]byte]bool)
+	for _, capability := range caps {
+		if capability.ConfigurationContract == ccipHome.Address() {
+			ccipCapabilities[capability.HashedId] = true
+		}
+	}
+	dons, err := capReg.GetDONs(nil)
+	if err != nil {
+		return CCIPHomeView{}, fmt.Errorf("failed to get DONs: %w", err)
+	}
+	var donViews []CCIPHomeDONView
+	for _, don := range dons {
+		if len(don.CapabilityConfigurations) != 1 || !ccipCapabilities[don.CapabilityConfigurations[0].CapabilityId] {
+			continue
+		}
+		donView := CCIPHomeDONView{
+			DONID:   don.Id,
+			F:       don.F,
+			Members: peerIDs(don.NodeP2PIds),
+		}
+		donView.Commit, err = generateCCIPHomePluginConfigsView(ccipHome, don.Id, cctypes.PluginTypeCCIPCommit)
+		if err != nil {
+			return CCIPHomeView{}, err
+		}
+		donView.Exec, err = generateCCIPHomePluginConfigsView(ccipHome, don.Id, cctypes.PluginTypeCCIPExec)
+		if err != nil {
+			return CCIPHomeView{}, err
+		}
+		if donView.Commit.Active != nil {
+			donView.ChainSelector = donView.Commit.Active.ChainSelector
+		} else if donView.Commit.Candidate != nil {
+			donView.ChainSelector = donView.Commit.Candidate.ChainSelector
+		}
+		donViews = append(donViews, donView)
+	}
+	return CCIPHomeView{
+		ContractMetaData: tv,
+		ChainConfigs:     chainConfigs,
+		DONs:             donViews,
+	}, nil
+}
+
+func generateCCIPHomeChainConfigViews(ccipHome *ccip_home.CCIPHome) ([]CCIPHomeChainConfigView, error) {
+	num, err := ccipHome.GetNumChainConfigurations(nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get number of chain configs: %w", err)
+	}
+	var views []CCIPHomeChainConfigView
+	for page := int64(0); page*chainConfigsPageSize < num.Int64(); page++ {
+		configs, err := ccipHome.GetAllChainConfigs(nil, big.NewInt(page), big.NewInt(chainConfigsPageSize))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get chain configs: %w", err)
+		}
+		for _, cfg := range configs {
+			decoded, err := chainconfig.DecodeChainConfig(cfg.ChainConfig.Config)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode config of chain %d: %w", cfg.ChainSelector, err)
+			}
+			views = append(views, CCIPHomeChainConfigView{
+				ChainSelector: cfg.ChainSelector,
+				Readers:       peerIDs(cfg.ChainConfig.Readers),
+				FChain:        cfg.ChainConfig.FChain,
+				Config:        decoded,
+			})
+		}
+	}
+	return views, nil
+}
+
+func generateCCIPHomePluginConfigsView(ccipHome *ccip_home.CCIPHome, donID uint32, pluginType cctypes.PluginType) (CCIPHomePluginConfigsView, error) {
+	configs, err := ccipHome.GetAllConfigs(nil, donID, uint8(pluginType))
+	if err != nil {
+		return CCIPHomePluginConfigsView{}, fmt.Errorf("failed to get %s configs of DON %d: %w", pluginType, donID, err)
+	}
+	var view CCIPHomePluginConfigsView
+	view.Active, err = generateCCIPHomeOCR3ConfigView(configs.ActiveConfig, pluginType)
+	if err != nil {
+		return view, fmt.Errorf("failed to decode active %s config of DON %d: %w", pluginType, donID, err)
+	}
+	view.Candidate, err = generateCCIPHomeOCR3ConfigView(configs.CandidateConfig, pluginType)
+	if err != nil {
+		return view, fmt.Errorf("failed to decode candidate %s config of DON %d: %w", pluginType, donID, err)
+	}
+	return view, nil
+}
+
+// generateCCIPHomeOCR3ConfigView returns the view of the config, nil if it is unset.
+func generateCCIPHomeOCR3ConfigView(cfg ccip_home.CCIPHomeVersionedConfig, pluginType cctypes.PluginType) (*CCIPHomeOCR3ConfigView, error) {
+	if cfg.ConfigDigest == [32]byte{} {
+		return nil, nil
+	}
+	view := &CCIPHomeOCR3ConfigView{
+		Version:               cfg.Version,
+		ChainSelector:         cfg.Config.ChainSelector,
+		ConfigDigest:          hex.EncodeToString(cfg.ConfigDigest[:]),
+		FRoleDON:              cfg.Config.FRoleDON,
+		OffchainConfigVersion: cfg.Config.OffchainConfigVersion,
+		OffRampAddress:        common.BytesToAddress(cfg.Config.OfframpAddress).Hex(),
+		RMNHomeAddress:        common.BytesToAddress(cfg.Config.RmnHomeAddress).Hex(),
+	}
+	for _, node := range cfg.Config.Nodes {
+		view.Nodes = append(view.Nodes, CCIPHomeOCR3NodeView{
+			PeerID:         p2pkey.PeerID(node.P2pId),
+			SignerKey:      hex.EncodeToString(node.SignerKey),
+			TransmitterKey: hex.EncodeToString(node.TransmitterKey),
+		})
+	}
+	switch pluginType {
+	case cctypes.PluginTypeCCIPCommit:
+		offchainConfig, err := pluginconfig.DecodeCommitOffchainConfig(cfg.Config.OffchainConfig)
+		if err != nil {
+			return nil, err
+		}
+		view.CommitOffchainConfig = &offchainConfig
+	case cctypes.PluginTypeCCIPExec:
+		offchainConfig, err := pluginconfig.DecodeExecuteOffchainConfig(cfg.Config.OffchainConfig)
+		if err != nil {
+			return nil, err
+		}
+		view.ExecuteOffchainConfig = &offchainConfig
+	}
+	return view, nil
+}
+
+func peerIDs(rawIDs [][32]byte) []p2pkey.PeerID {
+	out := make([]p2pkey.PeerID, 0, len(rawIDs))
+	for _, id := range rawIDs {
+		out = append(out, p2pkey.PeerID(id))
+	}
+	return out
+}
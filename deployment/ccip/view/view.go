@@ -25,6 +25,7 @@ type ChainView struct {
 	RMN                map[string]v1_6.RMNRemoteView                 `json:"rmn,omitempty"`
 	OnRamp             map[string]v1_6.OnRampView                    `json:"onRamp,omitempty"`
 	OffRamp            map[string]v1_6.OffRampView                   `json:"offRamp,omitempty"`
+	CCIPHome           map[string]v1_6.CCIPHomeView                  `json:"ccipHome,omitempty"`
 	CapabilityRegistry map[string]common_v1_0.CapabilityRegistryView `json:"capabilityRegistry,omitempty"`
 	MCMSWithTimelock   common_v1_0.MCMSWithTimelockView              `json:"mcmsWithTimelock,omitempty"`
 }
@@ -44,6 +45,7 @@ func NewChain() ChainView {
 		RMN:                make(map[string]v1_6.RMNRemoteView),
 		OnRamp:             make(map[string]v1_6.OnRampView),
 		OffRamp:            make(map[string]v1_6.OffRampView),
+		CCIPHome:           make(map[string]v1_6.CCIPHomeView),
 		CapabilityRegistry: make(map[string]common_v1_0.CapabilityRegistryView),
 		MCMSWithTimelock:   common_v1_0.MCMSWithTimelockView{},
 	}
@@ -0,0 +1,173 @@
+package deployment
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+
+	chain_selectors "github.com/smartcontractkit/chain-selectors"
+)
+
+// CustomChain is a chain which is not part of chain-selectors, e.g. a private or enterprise network.
+// Custom chains are registered at runtime and are then resolved by the helpers below like any other chain.
+type CustomChain struct {
+	Selector uint64 `json:"selector"`
+	// ChainID is the family specific chain id, e.g. the EVM chain id as a decimal string.
+	ChainID string `json:"chainID"`
+	Family  string `json:"family"`
+	Name    string `json:"name"`
+}
+
+func (c CustomChain) validate() error {
+	if c.Selector == 0 {
+		return fmt.Errorf("chain selector must be set")
+	}
+	if c.ChainID == "" || c.Name == "" {
+		return fmt.Errorf("chain id and name must be set for selector %d", c.Selector)
+	}
+	switch c.Family {
+	case chain_selectors.FamilyEVM:
+		if _, err := strconv.ParseUint(c.ChainID, 10, 64); err != nil {
+			return fmt.Errorf("invalid EVM chain id %q for selector %d", c.ChainID, c.Selector)
+		}
+	case chain_selectors.FamilySolana, chain_selectors.FamilyStarknet, chain_selectors.FamilyCosmos, chain_selectors.FamilyAptos:
+	default:
+		return fmt.Errorf("unsupported chain family %q for selector %d", c.Family, c.Selector)
+	}
+	if _, err := chain_selectors.GetSelectorFamily(c.Selector); err == nil {
+		return fmt.Errorf("chain selector %d is already known to chain-selectors", c.Selector)
+	}
+	if _, err := chain_selectors.GetChainDetailsByChainIDAndFamily(c.ChainID, c.Family); err == nil {
+		return fmt.Errorf("%s chain id %s is already known to chain-selectors", c.Family, c.ChainID)
+	}
+	return nil
+}
+
+var customChains = struct {
+	mu         sync.RWMutex
+	bySelector map[uint64]CustomChain
+}{bySelector: make(map[uint64]CustomChain)}
+
+// RegisterCustomChains registers chains which are unknown to chain-selectors.
+// Registering the same chain twice is a no-op, registering conflicting chains is an error.
+func RegisterCustomChains(chains ...CustomChain) error {
+	customChains.mu.Lock()
+	defer customChains.mu.Unlock()
+	for _, c := range chains {
+		if err := c.validate(); err != nil {
+			return err
+		}
+		for _, existing := range customChains.bySelector {
+			if existing == c {
+				continue
+			}
+			if existing.Selector == c.Selector {
+				return fmt.Errorf("chain selector %d is already registered as %s", c.Selector, existing.Name)
+			}
+			if existing.Family == c.Family && existing.ChainID == c.ChainID {
+				return fmt.Errorf("%s chain id %s is already registered as %s", c.Family, c.ChainID, existing.Name)
+			}
+		}
+		customChains.bySelector[c.Selector] = c
+	}
+	return nil
+}
+
+// LoadCustomChains registers the custom chains of a JSON file containing a list of CustomChain.
+func LoadCustomChains(path string) error {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read custom chains: %w", err)
+	}
+	var chains []CustomChain
+	if err := json.Unmarshal(b, &chains); err != nil {
+		return fmt.Errorf("failed to parse custom chains: %w", err)
+	}
+	return RegisterCustomChains(chains...)
+}
+
+func customChain(selector uint64) (CustomChain, bool) {
+	customChains.mu.RLock()
+	defer customChains.mu.RUnlock()
+	c, ok := customChains.bySelector[selector]
+	return c, ok
+}
+
+// ChainFamily returns the family of the chain with the given selector.
+func ChainFamily(selector uint64) (string, error) {
+	if c, ok := customChain(selector); ok {
+		return c.Family, nil
+	}
+	return chain_selectors.GetSelectorFamily(selector)
+}
+
+// ChainID returns the family specific chain id of the chain with the given selector.
+func ChainID(selector uint64) (string, error) {
+	if c, ok := customChain(selector); ok {
+		return c.ChainID, nil
+	}
+	return chain_selectors.GetChainIDFromSelector(selector)
+}
+
+// EVMChainID returns the chain id of the EVM chain with the given selector.
+func EVMChainID(selector uint64) (uint64, error) {
+	if c, ok := customChain(selector); ok {
+		if c.Family != chain_selectors.FamilyEVM {
+			return 0, fmt.Errorf("chain selector %d is not an EVM chain", selector)
+		}
+		return strconv.ParseUint(c.ChainID, 10, 64)
+	}
+	return chain_selectors.ChainIdFromSelector(selector)
+}
+
+// ChainName returns the name of the chain with the given selector.
+func ChainName(selector uint64) (string, error) {
+	if c, ok := customChain(selector); ok {
+		return c.Name, nil
+	}
+	details, err := ChainDetails(selector)
+	if err != nil {
+		return "", err
+	}
+	return details.ChainName, nil
+}
+
+// ChainDetails returns the chain-selectors details of the chain with the given selector.
+func ChainDetails(selector uint64) (chain_selectors.ChainDetails, error) {
+	if c, ok := customChain(selector); ok {
+		return chain_selectors.ChainDetails{ChainSelector: c.Selector, ChainName: c.Name}, nil
+	}
+	family, err := chain_selectors.GetSelectorFamily(selector)
+	if err != nil {
+		return chain_selectors.ChainDetails{}, err
+	}
+	id, err := chain_selectors.GetChainIDFromSelector(selector)
+	if err != nil {
+		return chain_selectors.ChainDetails{}, err
+	}
+	return chain_selectors.GetChainDetailsByChainIDAndFamily(id, family)
+}
+
+// ChainDetailsByChainIDAndFamily returns the details of the chain with the given family specific chain id.
+func ChainDetailsByChainIDAndFamily(chainID string, family string) (chain_selectors.ChainDetails, error) {
+	customChains.mu.RLock()
+	for _, c := range customChains.bySelector {
+		if c.Family == family && c.ChainID == chainID {
+			customChains.mu.RUnlock()
+			return chain_selectors.ChainDetails{ChainSelector: c.Selector, ChainName: c.Name}, nil
+		}
+	}
+	customChains.mu.RUnlock()
+	return chain_selectors.GetChainDetailsByChainIDAndFamily(chainID, family)
+}
+
+// SelectorFromEVMChainID returns the selector of the EVM chain with the given chain id.
+func SelectorFromEVMChainID(chainID uint64) (uint64, error) {
+	details, err := ChainDetailsByChainIDAndFamily(strconv.FormatUint(chainID, 10), chain_selectors.FamilyEVM)
+	if err != nil {
+		return 0, err
+	}
+	return details.ChainSelector, nil
+}
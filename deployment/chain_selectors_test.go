@@ -0,0 +1,74 @@
+package deployment
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	chainsel "github.com/smartcontractkit/chain-selectors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterCustomChains(t *testing.T) {
+	private := CustomChain{
+		Selector: 1234567890123,
+		ChainID:  "987654321",
+		Family:   chainsel.FamilyEVM,
+		Name:     "private-testnet",
+	}
+	require.NoError(t, RegisterCustomChains(private))
+	// registering the same chain is a no-op
+	require.NoError(t, RegisterCustomChains(private))
+
+	family, err := ChainFamily(private.Selector)
+	require.NoError(t, err)
+	require.Equal(t, chainsel.FamilyEVM, family)
+	evmChainID, err := EVMChainID(private.Selector)
+	require.NoError(t, err)
+	require.Equal(t, uint64(987654321), evmChainID)
+	name, err := ChainName(private.Selector)
+	require.NoError(t, err)
+	require.Equal(t, "private-testnet", name)
+	sel, err := SelectorFromEVMChainID(987654321)
+	require.NoError(t, err)
+	require.Equal(t, private.Selector, sel)
+	details, err := ChainDetails(private.Selector)
+	require.NoError(t, err)
+	byID, err := ChainDetailsByChainIDAndFamily(private.ChainID, chainsel.FamilyEVM)
+	require.NoError(t, err)
+	require.Equal(t, details, byID)
+
+	// custom chains can be used in the address book
+	ab := NewMemoryAddressBook()
+	require.NoError(t, ab.Save(private.Selector, common.HexToAddress("0x1").String(), NewTypeAndVersion("OnRamp", Version1_0_0)))
+	addresses, err := ab.AddressesForChain(private.Selector)
+	require.NoError(t, err)
+	require.Len(t, addresses, 1)
+
+	// known chains are still resolved by chain-selectors
+	evmChainID, err = EVMChainID(chainsel.TEST_90000001.Selector)
+	require.NoError(t, err)
+	require.Equal(t, chainsel.TEST_90000001.EvmChainID, evmChainID)
+
+	conflicts := []CustomChain{
+		{Selector: private.Selector, ChainID: "1", Family: chainsel.FamilyEVM, Name: "other"},
+		{Selector: 1, ChainID: private.ChainID, Family: chainsel.FamilyEVM, Name: "other"},
+		{Selector: chainsel.TEST_90000001.Selector, ChainID: "2", Family: chainsel.FamilyEVM, Name: "other"},
+		{Selector: 2, ChainID: "90000001", Family: chainsel.FamilyEVM, Name: "other"},
+		{Selector: 3, ChainID: "not-a-number", Family: chainsel.FamilyEVM, Name: "other"},
+		{Selector: 4, ChainID: "4", Family: "unknown", Name: "other"},
+	}
+	for _, c := range conflicts {
+		require.Error(t, RegisterCustomChains(c), "chain %+v", c)
+	}
+}
+
+func TestLoadCustomChains(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "chains.json")
+	require.NoError(t, os.WriteFile(path, []byte(`[{"selector": 2345678901234, "chainID": "876543210", "family": "evm", "name": "enterprise-net"}]`), 0600))
+	require.NoError(t, LoadCustomChains(path))
+	evmChainID, err := EVMChainID(2345678901234)
+	require.NoError(t, err)
+	require.Equal(t, uint64(876543210), evmChainID)
+}
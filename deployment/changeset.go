@@ -25,9 +25,11 @@ type ChangeSet[C any] func(e Environment, config C) (ChangesetOutput, error)
 // The address book here should contain only new addresses created in
 // this changeset.
 type ChangesetOutput struct {
-	JobSpecs    map[string][]string
-	Proposals   []timelock.MCMSWithTimelockProposal
-	AddressBook AddressBook
+	JobSpecs  map[string][]string
+	Proposals []timelock.MCMSWithTimelockProposal
+	// MultisigProposals are the proposals for the non-EVM chains, see MultisigProposal.
+	MultisigProposals []MultisigProposal
+	AddressBook       AddressBook
 }
 
 // ViewState produces a product specific JSON representation of
@@ -0,0 +1,54 @@
+package changeset
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/gethwrappers"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+)
+
+// BundleStage builds the changesets of a stage of a product bundle. It is called with the environment
+// resulting from the previous stages, so configs can depend on contracts deployed by earlier stages.
+type BundleStage func(e deployment.Environment) ([]ChangesetApplication, error)
+
+// ProductBundle is the ordered set of changesets deploying and configuring one product.
+type ProductBundle struct {
+	Name   string
+	Stages []BundleStage
+	// Timelocks returns the timelocks executing the proposals of the bundle keyed by chain selector.
+	// It can be nil if the bundle makes no proposals.
+	Timelocks func(e deployment.Environment) (map[uint64]*gethwrappers.RBACTimelock, error)
+}
+
+// ApplyBundles applies the bundles in order to the same environment and returns the updated environment.
+// All products share the chains and nodes of the environment, which allows to test them side by side,
+// e.g. to exercise the contention of their log poller filters and transactions on the same nodes.
+// The proposals of the bundles are signed with TestXXXMCMSSigner, see SingleGroupMCMS.
+func ApplyBundles(ctx context.Context, lggr logger.Logger, e deployment.Environment, bundles ...ProductBundle) (deployment.Environment, error) {
+	currentEnv := e
+	for _, bundle := range bundles {
+		for i, stage := range bundle.Stages {
+			lggr.Infow("Applying bundle stage", "bundle", bundle.Name, "stage", i)
+			changesets, err := stage(currentEnv)
+			if err != nil {
+				return e, fmt.Errorf("failed to build stage %d of bundle %s: %w", i, bundle.Name, err)
+			}
+			var timelocks map[uint64]*gethwrappers.RBACTimelock
+			if bundle.Timelocks != nil {
+				timelocks, err = bundle.Timelocks(currentEnv)
+				if err != nil {
+					return e, fmt.Errorf("failed to get timelocks of bundle %s: %w", bundle.Name, err)
+				}
+			}
+			currentEnv, err = applyChangesets(ctx, currentEnv, timelocks, changesets)
+			if err != nil {
+				return e, fmt.Errorf("failed to apply stage %d of bundle %s: %w", i, bundle.Name, err)
+			}
+		}
+	}
+	return currentEnv, nil
+}
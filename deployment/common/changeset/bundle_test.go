@@ -0,0 +1,58 @@
+package changeset
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	chainsel "github.com/smartcontractkit/chain-selectors"
+	"github.com/smartcontractkit/chainlink-testing-framework/lib/utils/testcontext"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+func TestApplyBundles(t *testing.T) {
+	dummyEnv := deployment.Environment{
+		Name:              "dummy",
+		Logger:            logger.TestLogger(t),
+		ExistingAddresses: deployment.NewMemoryAddressBook(),
+		Chains: map[uint64]deployment.Chain{
+			chainsel.TEST_90000001.Selector: {},
+		},
+	}
+	saveStage := func(contractType deployment.ContractType, addr int64) BundleStage {
+		return func(e deployment.Environment) ([]ChangesetApplication, error) {
+			return []ChangesetApplication{{
+				Changeset: WrapChangeSet(SaveExistingContracts),
+				Config: ExistingContractsConfig{
+					ExistingContracts: []Contract{{
+						Address:        common.BigToAddress(big.NewInt(addr)),
+						TypeAndVersion: deployment.NewTypeAndVersion(contractType, deployment.Version1_0_0),
+						ChainSelector:  chainsel.TEST_90000001.Selector,
+					}},
+				},
+			}}, nil
+		}
+	}
+	var seen int
+	bundles := []ProductBundle{
+		{Name: "first", Stages: []BundleStage{saveStage("first", 1)}},
+		{Name: "second", Stages: []BundleStage{
+			func(e deployment.Environment) ([]ChangesetApplication, error) {
+				// later stages see the contracts of earlier bundles
+				addresses, err := e.ExistingAddresses.AddressesForChain(chainsel.TEST_90000001.Selector)
+				seen = len(addresses)
+				return nil, err
+			},
+			saveStage("second", 2),
+		}},
+	}
+	e, err := ApplyBundles(testcontext.Get(t), dummyEnv.Logger, dummyEnv, bundles...)
+	require.NoError(t, err)
+	require.Equal(t, 1, seen)
+	addresses, err := e.ExistingAddresses.AddressesForChain(chainsel.TEST_90000001.Selector)
+	require.NoError(t, err)
+	require.Len(t, addresses, 2)
+}
@@ -0,0 +1,195 @@
+package changeset
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/proposal/timelock"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/shared/generated/erc20"
+)
+
+var _ deployment.ChangeSet[DistributeLinkConfig] = DistributeLinkChangeset
+
+// DistributeLinkConfig configures the distribution of LINK from a treasury, keyed by chain selector.
+type DistributeLinkConfig struct {
+	Chains map[uint64]ChainLinkDistribution
+	// DryRun only reports the transfers and approvals which would be made.
+	DryRun bool
+}
+
+// ChainLinkDistribution configures the distribution of LINK on a single chain.
+type ChainLinkDistribution struct {
+	LinkToken common.Address
+	// Treasury is the address holding the LINK to distribute. Defaults to the deployer key of the chain.
+	// Unless in dry-run mode, its key must be among the deployer key and the signers of the chain,
+	// see deployment.Chain.Signer.
+	Treasury common.Address
+	// Balances are the LINK balances recipients should at least hold, in juels.
+	// Recipients below their balance receive the difference.
+	Balances map[common.Address]*big.Int
+	// Allowances are the allowances the treasury should at least grant to spenders such as routers
+	// and registries, in juels.
+	Allowances map[common.Address]*big.Int
+}
+
+func (c DistributeLinkConfig) Validate(e deployment.Environment) error {
+	if len(c.Chains) == 0 {
+		return fmt.Errorf("no chains to distribute LINK on")
+	}
+	for sel, dist := range c.Chains {
+		if _, ok := e.Chains[sel]; !ok {
+			return fmt.Errorf("chain %d not found in environment", sel)
+		}
+		if dist.LinkToken == (common.Address{}) {
+			return fmt.Errorf("LINK token address must be set for chain %d", sel)
+		}
+		if dist.Treasury != (common.Address{}) && !c.DryRun {
+			if _, err := e.Chains[sel].Signer(dist.Treasury); err != nil {
+				return fmt.Errorf("invalid treasury for chain %d: %w", sel, err)
+			}
+		}
+		if len(dist.Balances) == 0 && len(dist.Allowances) == 0 {
+			return fmt.Errorf("no balances or allowances set for chain %d", sel)
+		}
+		for addr, amount := range dist.Balances {
+			if amount == nil || amount.Sign() < 0 {
+				return fmt.Errorf("invalid balance for %s on chain %d", addr, sel)
+			}
+		}
+		for addr, amount := range dist.Allowances {
+			if amount == nil || amount.Sign() < 0 {
+				return fmt.Errorf("invalid allowance for %s on chain %d", addr, sel)
+			}
+		}
+	}
+	return nil
+}
+
+// LinkTransfer is a LINK transfer made (or planned, for dry runs) by DistributeLink.
+type LinkTransfer struct {
+	ChainSelector uint64
+	To            common.Address
+	Amount        *big.Int
+}
+
+// LinkApproval is an approval made (or planned, for dry runs) by DistributeLink.
+type LinkApproval struct {
+	ChainSelector uint64
+	Spender       common.Address
+	Amount        *big.Int
+}
+
+// LinkDistributionReport summarizes a DistributeLink run.
+type LinkDistributionReport struct {
+	DryRun    bool
+	Transfers []LinkTransfer
+	Approvals []LinkApproval
+}
+
+// DistributeLinkChangeset distributes LINK from the treasury and sets allowances, and logs the
+// transfers and approvals made.
+func DistributeLinkChangeset(e deployment.Environment, cfg DistributeLinkConfig) (deployment.ChangesetOutput, error) {
+	report, err := DistributeLink(e, cfg)
+	if err != nil {
+		return deployment.ChangesetOutput{}, err
+	}
+	for _, t := range report.Transfers {
+		e.Logger.Infow("LINK transfer", "dryRun", report.DryRun, "chainSelector", t.ChainSelector, "to", t.To, "amount", t.Amount)
+	}
+	for _, a := range report.Approvals {
+		e.Logger.Infow("LINK approval", "dryRun", report.DryRun, "chainSelector", a.ChainSelector, "spender", a.Spender, "amount", a.Amount)
+	}
+	return deployment.ChangesetOutput{
+		Proposals:   []timelock.MCMSWithTimelockProposal{},
+		AddressBook: nil,
+		JobSpecs:    nil,
+	}, nil
+}
+
+// DistributeLink tops up the LINK balances of recipients from the treasury and makes sure the treasury
+// grants the configured allowances. In dry-run mode no transaction is sent.
+func DistributeLink(e deployment.Environment, cfg DistributeLinkConfig) (LinkDistributionReport, error) {
+	if err := cfg.Validate(e); err != nil {
+		return LinkDistributionReport{}, errors.Wrapf(deployment.ErrInvalidConfig, "%v", err)
+	}
+	report := LinkDistributionReport{DryRun: cfg.DryRun}
+	for _, sel := range e.AllChainSelectors() {
+		dist, ok := cfg.Chains[sel]
+		if !ok {
+			continue
+		}
+		chain := e.Chains[sel]
+		treasury := chain.DeployerKey
+		if dist.Treasury != (common.Address{}) {
+			// dry runs only read the state of the treasury, its key is not needed
+			treasury = &bind.TransactOpts{From: dist.Treasury}
+			if !cfg.DryRun {
+				signer, err := chain.Signer(dist.Treasury)
+				if err != nil {
+					return report, err
+				}
+				treasury = signer
+			}
+		}
+		link, err := erc20.NewERC20(dist.LinkToken, chain.Client)
+		if err != nil {
+			return report, err
+		}
+		callOpts := &bind.CallOpts{Context: context.Background()}
+
+		needed := big.NewInt(0)
+		var transfers []LinkTransfer
+		for to, target := range dist.Balances {
+			balance, err := link.BalanceOf(callOpts, to)
+			if err != nil {
+				return report, fmt.Errorf("failed to get LINK balance of %s on chain %d: %w", to, sel, err)
+			}
+			if balance.Cmp(target) >= 0 {
+				continue
+			}
+			amount := new(big.Int).Sub(target, balance)
+			needed.Add(needed, amount)
+			transfers = append(transfers, LinkTransfer{ChainSelector: sel, To: to, Amount: amount})
+		}
+		available, err := link.BalanceOf(callOpts, treasury.From)
+		if err != nil {
+			return report, fmt.Errorf("failed to get LINK balance of treasury on chain %d: %w", sel, err)
+		}
+		if available.Cmp(needed) < 0 {
+			return report, fmt.Errorf("treasury %s holds %s juels on chain %d, need %s", treasury.From, available, sel, needed)
+		}
+		for _, t := range transfers {
+			if !cfg.DryRun {
+				tx, err := link.Transfer(treasury, t.To, t.Amount)
+				if _, err := deployment.ConfirmIfNoError(chain, tx, err); err != nil {
+					return report, fmt.Errorf("failed to transfer LINK to %s on chain %d: %w", t.To, sel, err)
+				}
+			}
+			report.Transfers = append(report.Transfers, t)
+		}
+
+		for spender, target := range dist.Allowances {
+			allowance, err := link.Allowance(callOpts, treasury.From, spender)
+			if err != nil {
+				return report, fmt.Errorf("failed to get LINK allowance of %s on chain %d: %w", spender, sel, err)
+			}
+			if allowance.Cmp(target) >= 0 {
+				continue
+			}
+			if !cfg.DryRun {
+				tx, err := link.Approve(treasury, spender, target)
+				if _, err := deployment.ConfirmIfNoError(chain, tx, err); err != nil {
+					return report, fmt.Errorf("failed to approve LINK for %s on chain %d: %w", spender, sel, err)
+				}
+			}
+			report.Approvals = append(report.Approvals, LinkApproval{ChainSelector: sel, Spender: spender, Amount: target})
+		}
+	}
+	return report, nil
+}
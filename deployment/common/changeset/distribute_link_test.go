@@ -0,0 +1,127 @@
+package changeset
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-testing-framework/lib/utils/testcontext"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/deployment/environment/memory"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/shared/generated/burn_mint_erc677"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+func TestDistributeLink(t *testing.T) {
+	chains := memory.NewMemoryChains(t, 1)
+	e := deployment.Environment{
+		Name:              "test",
+		Logger:            logger.TestLogger(t),
+		ExistingAddresses: deployment.NewMemoryAddressBook(),
+		Chains:            chains,
+	}
+	sel := e.AllChainSelectors()[0]
+	chain := e.Chains[sel]
+
+	linkAddr, tx, link, err := burn_mint_erc677.DeployBurnMintERC677(chain.DeployerKey, chain.Client,
+		"Link Token", "LINK", uint8(18), deployment.E18Mult(1e9))
+	_, err = deployment.ConfirmIfNoError(chain, tx, err)
+	require.NoError(t, err)
+	tx, err = link.GrantMintRole(chain.DeployerKey, chain.DeployerKey.From)
+	_, err = deployment.ConfirmIfNoError(chain, tx, err)
+	require.NoError(t, err)
+	tx, err = link.Mint(chain.DeployerKey, chain.DeployerKey.From, deployment.E18Mult(100))
+	_, err = deployment.ConfirmIfNoError(chain, tx, err)
+	require.NoError(t, err)
+
+	recipient := common.HexToAddress("0x1")
+	spender := common.HexToAddress("0x2")
+	cfg := DistributeLinkConfig{
+		Chains: map[uint64]ChainLinkDistribution{
+			sel: {
+				LinkToken:  linkAddr,
+				Balances:   map[common.Address]*big.Int{recipient: deployment.E18Mult(10)},
+				Allowances: map[common.Address]*big.Int{spender: deployment.E18Mult(50)},
+			},
+		},
+		DryRun: true,
+	}
+
+	// A dry run reports the actions without sending anything.
+	report, err := DistributeLink(e, cfg)
+	require.NoError(t, err)
+	require.Equal(t, []LinkTransfer{{ChainSelector: sel, To: recipient, Amount: deployment.E18Mult(10)}}, report.Transfers)
+	require.Equal(t, []LinkApproval{{ChainSelector: sel, Spender: spender, Amount: deployment.E18Mult(50)}}, report.Approvals)
+	balance, err := link.BalanceOf(&bind.CallOpts{}, recipient)
+	require.NoError(t, err)
+	require.Zero(t, balance.Sign())
+
+	cfg.DryRun = false
+	_, err = DistributeLinkChangeset(e, cfg)
+	require.NoError(t, err)
+	balance, err = link.BalanceOf(&bind.CallOpts{}, recipient)
+	require.NoError(t, err)
+	require.Equal(t, deployment.E18Mult(10), balance)
+	allowance, err := link.Allowance(&bind.CallOpts{}, chain.DeployerKey.From, spender)
+	require.NoError(t, err)
+	require.Equal(t, deployment.E18Mult(50), allowance)
+
+	// Nothing left to do.
+	report, err = DistributeLink(e, cfg)
+	require.NoError(t, err)
+	require.Empty(t, report.Transfers)
+	require.Empty(t, report.Approvals)
+
+	cfg.Chains[sel].Balances[recipient] = deployment.E18Mult(1000)
+	_, err = DistributeLink(e, cfg)
+	require.ErrorContains(t, err, "need")
+
+	// The treasury is referenced by address, so that the config can be reviewed and loaded by non-Go
+	// operators. A dry run doesn't need its key.
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	treasury, err := bind.NewKeyedTransactorWithChainID(key, big.NewInt(1337))
+	require.NoError(t, err)
+	require.NoError(t, deployment.SendNative(testcontext.Get(t), chain, chain.DeployerKey, treasury.From, deployment.E18Mult(1)))
+	tx, err = link.Mint(chain.DeployerKey, treasury.From, deployment.E18Mult(100))
+	_, err = deployment.ConfirmIfNoError(chain, tx, err)
+	require.NoError(t, err)
+	cfg = DistributeLinkConfig{
+		Chains: map[uint64]ChainLinkDistribution{
+			sel: {
+				LinkToken: linkAddr,
+				Treasury:  treasury.From,
+				Balances:  map[common.Address]*big.Int{recipient: deployment.E18Mult(20)},
+			},
+		},
+		DryRun: true,
+	}
+	cfgJSON, err := json.Marshal(cfg)
+	require.NoError(t, err)
+	loaded, err := deployment.LoadConfig[DistributeLinkConfig](cfgJSON)
+	require.NoError(t, err)
+	require.Equal(t, cfg, loaded)
+	report, err = DistributeLink(e, loaded)
+	require.NoError(t, err)
+	require.Equal(t, []LinkTransfer{{ChainSelector: sel, To: recipient, Amount: deployment.E18Mult(10)}}, report.Transfers)
+
+	loaded.DryRun = false
+	_, err = DistributeLink(e, loaded)
+	require.ErrorIs(t, err, deployment.ErrInvalidConfig, "the treasury is not a key of the chain")
+	chain.Signers = append(chain.Signers, treasury)
+	e.Chains[sel] = chain
+	_, err = DistributeLinkChangeset(e, loaded)
+	require.NoError(t, err)
+	balance, err = link.BalanceOf(&bind.CallOpts{}, recipient)
+	require.NoError(t, err)
+	require.Equal(t, deployment.E18Mult(20), balance)
+	balance, err = link.BalanceOf(&bind.CallOpts{}, treasury.From)
+	require.NoError(t, err)
+	require.Equal(t, deployment.E18Mult(90), balance)
+}
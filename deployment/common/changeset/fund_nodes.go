@@ -0,0 +1,157 @@
+package changeset
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/proposal/timelock"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+)
+
+var _ deployment.ChangeSet[FundNodesConfig] = FundNodesChangeset
+
+// FundNodesConfig configures the top up of node transmitter addresses.
+// All amounts are in wei and keyed by chain selector. Only chains with a minimum balance are funded.
+type FundNodesConfig struct {
+	// MinBalances is the balance below which a transmitter gets topped up.
+	MinBalances map[uint64]*big.Int
+	// TopUpAmounts is the amount sent to a transmitter below its minimum balance.
+	// Defaults to the minimum balance of the chain.
+	TopUpAmounts map[uint64]*big.Int
+	// Funders are the addresses of the keys sending the top ups, among the deployer key and the signers
+	// of the chain, see deployment.Chain.Signer. Defaults to the deployer key of the chain.
+	Funders map[uint64]common.Address
+}
+
+func (c FundNodesConfig) Validate(e deployment.Environment) error {
+	if len(c.MinBalances) == 0 {
+		return fmt.Errorf("no minimum balances set")
+	}
+	for sel, minBalance := range c.MinBalances {
+		if _, ok := e.Chains[sel]; !ok {
+			return fmt.Errorf("chain %d not found in environment", sel)
+		}
+		if minBalance == nil || minBalance.Sign() <= 0 {
+			return fmt.Errorf("minimum balance for chain %d must be positive", sel)
+		}
+		if amount, ok := c.TopUpAmounts[sel]; ok && (amount == nil || amount.Sign() <= 0) {
+			return fmt.Errorf("top up amount for chain %d must be positive", sel)
+		}
+		if funder, ok := c.Funders[sel]; ok {
+			if _, err := e.Chains[sel].Signer(funder); err != nil {
+				return fmt.Errorf("invalid funder for chain %d: %w", sel, err)
+			}
+		}
+	}
+	return nil
+}
+
+// NodeFunding is the outcome of the balance check of a single transmitter.
+type NodeFunding struct {
+	NodeID        string
+	ChainSelector uint64
+	Transmitter   common.Address
+	// Balance is the balance of the transmitter before any top up.
+	Balance *big.Int
+	// TopUp is the amount sent to the transmitter, nil if it was above its minimum balance.
+	TopUp *big.Int
+}
+
+// FundingReport summarizes a FundNodes run.
+type FundingReport struct {
+	Fundings []NodeFunding
+}
+
+// TotalTopUps returns the total amount sent per chain.
+func (r FundingReport) TotalTopUps() map[uint64]*big.Int {
+	totals := make(map[uint64]*big.Int)
+	for _, f := range r.Fundings {
+		if f.TopUp == nil {
+			continue
+		}
+		if _, ok := totals[f.ChainSelector]; !ok {
+			totals[f.ChainSelector] = big.NewInt(0)
+		}
+		totals[f.ChainSelector].Add(totals[f.ChainSelector], f.TopUp)
+	}
+	return totals
+}
+
+// FundNodesChangeset tops up the transmitter addresses of all non-bootstrap nodes of the environment
+// whose balance is below the configured minimum, and logs a summary of the transfers.
+func FundNodesChangeset(e deployment.Environment, cfg FundNodesConfig) (deployment.ChangesetOutput, error) {
+	report, err := FundNodes(e, cfg)
+	if err != nil {
+		return deployment.ChangesetOutput{}, err
+	}
+	for _, f := range report.Fundings {
+		e.Logger.Infow("Checked node transmitter balance", "nodeID", f.NodeID, "chainSelector", f.ChainSelector,
+			"transmitter", f.Transmitter, "balance", f.Balance, "topUp", f.TopUp)
+	}
+	e.Logger.Infow("Funded node transmitters", "totalsByChain", report.TotalTopUps())
+	return deployment.ChangesetOutput{
+		Proposals:   []timelock.MCMSWithTimelockProposal{},
+		AddressBook: nil,
+		JobSpecs:    nil,
+	}, nil
+}
+
+// FundNodes reads the transmitter address of every non-bootstrap node per chain from the offchain client,
+// and tops up those whose balance is below the configured minimum.
+func FundNodes(e deployment.Environment, cfg FundNodesConfig) (FundingReport, error) {
+	if err := cfg.Validate(e); err != nil {
+		return FundingReport{}, errors.Wrapf(deployment.ErrInvalidConfig, "%v", err)
+	}
+	nodes, err := deployment.NodeInfo(e.NodeIDs, e.Offchain)
+	if err != nil {
+		return FundingReport{}, err
+	}
+	var report FundingReport
+	for _, sel := range e.AllChainSelectors() {
+		minBalance, ok := cfg.MinBalances[sel]
+		if !ok {
+			continue
+		}
+		chain := e.Chains[sel]
+		funder := chain.DeployerKey
+		if addr, ok := cfg.Funders[sel]; ok {
+			funder, err = chain.Signer(addr)
+			if err != nil {
+				return report, err
+			}
+		}
+		topUp := minBalance
+		if amount, ok := cfg.TopUpAmounts[sel]; ok {
+			topUp = amount
+		}
+		for _, node := range nodes.NonBootstraps() {
+			ocrCfg, ok := node.OCRConfigForChainSelector(sel)
+			if !ok || !common.IsHexAddress(string(ocrCfg.TransmitAccount)) {
+				return report, fmt.Errorf("no transmitter found for node %s on chain %d", node.NodeID, sel)
+			}
+			transmitter := common.HexToAddress(string(ocrCfg.TransmitAccount))
+			balance, err := chain.Client.BalanceAt(context.Background(), transmitter, nil)
+			if err != nil {
+				return report, fmt.Errorf("failed to get balance of %s on chain %d: %w", transmitter, sel, err)
+			}
+			funding := NodeFunding{
+				NodeID:        node.NodeID,
+				ChainSelector: sel,
+				Transmitter:   transmitter,
+				Balance:       balance,
+			}
+			if balance.Cmp(minBalance) < 0 {
+				if err := deployment.SendNative(context.Background(), chain, funder, transmitter, topUp); err != nil {
+					return report, fmt.Errorf("failed to fund %s on chain %d: %w", transmitter, sel, err)
+				}
+				funding.TopUp = new(big.Int).Set(topUp)
+			}
+			report.Fundings = append(report.Fundings, funding)
+		}
+	}
+	return report, nil
+}
@@ -0,0 +1,93 @@
+package changeset
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/smartcontractkit/chainlink-testing-framework/lib/utils/testcontext"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/deployment/environment/memory"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+func TestFundNodes(t *testing.T) {
+	lggr := logger.TestLogger(t)
+	e := memory.NewMemoryEnvironment(t, lggr, zapcore.InfoLevel, memory.MemoryEnvironmentConfig{
+		Chains:     2,
+		Nodes:      4,
+		Bootstraps: 1,
+	})
+	chains := e.AllChainSelectors()
+	// Memory nodes start with 1000 ETH, only fund on the first chain.
+	minBalance := deployment.E18Mult(1001)
+	topUp := deployment.E18Mult(5)
+	cfg := FundNodesConfig{
+		MinBalances:  map[uint64]*big.Int{chains[0]: minBalance},
+		TopUpAmounts: map[uint64]*big.Int{chains[0]: topUp},
+	}
+
+	report, err := FundNodes(e, cfg)
+	require.NoError(t, err)
+	require.Len(t, report.Fundings, 4)
+	for _, f := range report.Fundings {
+		require.Equal(t, chains[0], f.ChainSelector)
+		require.Equal(t, topUp, f.TopUp)
+		balance, err := e.Chains[chains[0]].Client.BalanceAt(testcontext.Get(t), f.Transmitter, nil)
+		require.NoError(t, err)
+		require.Equal(t, new(big.Int).Add(f.Balance, topUp), balance)
+	}
+	require.Equal(t, new(big.Int).Mul(topUp, big.NewInt(4)), report.TotalTopUps()[chains[0]])
+
+	// Everyone is above the minimum now.
+	report, err = FundNodes(e, cfg)
+	require.NoError(t, err)
+	require.Len(t, report.Fundings, 4)
+	for _, f := range report.Fundings {
+		require.Nil(t, f.TopUp)
+	}
+	require.Empty(t, report.TotalTopUps())
+
+	// Another key of the chain, referenced by address, sends the top ups of the second chain.
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	funder, err := bind.NewKeyedTransactorWithChainID(key, big.NewInt(1337))
+	require.NoError(t, err)
+	chain := e.Chains[chains[1]]
+	require.NoError(t, deployment.SendNative(testcontext.Get(t), chain, chain.DeployerKey, funder.From, deployment.E18Mult(100)))
+	cfg = FundNodesConfig{
+		MinBalances:  map[uint64]*big.Int{chains[1]: minBalance},
+		TopUpAmounts: map[uint64]*big.Int{chains[1]: topUp},
+		Funders:      map[uint64]common.Address{chains[1]: funder.From},
+	}
+	_, err = FundNodesChangeset(e, cfg)
+	require.ErrorIs(t, err, deployment.ErrInvalidConfig, "the funder is not a key of the chain")
+	chain.Signers = append(chain.Signers, funder)
+	e.Chains[chains[1]] = chain
+
+	// the config can be reviewed and loaded by non-Go operators
+	cfgJSON, err := json.Marshal(cfg)
+	require.NoError(t, err)
+	loaded, err := deployment.LoadConfig[FundNodesConfig](cfgJSON)
+	require.NoError(t, err)
+	require.Equal(t, cfg, loaded)
+
+	report, err = FundNodes(e, loaded)
+	require.NoError(t, err)
+	require.Len(t, report.Fundings, 4)
+	total := report.TotalTopUps()[chains[1]]
+	require.Equal(t, new(big.Int).Mul(topUp, big.NewInt(4)), total)
+	funderBalance, err := chain.Client.BalanceAt(testcontext.Get(t), funder.From, nil)
+	require.NoError(t, err)
+	require.Equal(t, -1, funderBalance.Cmp(new(big.Int).Sub(deployment.E18Mult(100), total)))
+
+	_, err = FundNodesChangeset(e, FundNodesConfig{})
+	require.ErrorIs(t, err, deployment.ErrInvalidConfig)
+}
@@ -41,7 +41,7 @@ func DeployMCMSWithConfig(
 		groupParents,
 		false,
 	)
-	if _, err := deployment.ConfirmIfNoError(chain, mcmsTx, err); err != nil {
+	if _, err := deployment.ConfirmFinalizedIfNoError(lggr, chain, mcmsTx, err); err != nil {
 		lggr.Errorw("Failed to confirm mcm config", "err", err)
 		return mcm, err
 	}
@@ -122,7 +122,7 @@ func DeployMCMSWithTimelockContracts(
 	// We grant the timelock the admin role on the MCMS contracts.
 	tx, err := timelock.Contract.GrantRole(chain.DeployerKey,
 		v1_0.ADMIN_ROLE.ID, timelock.Address)
-	if _, err := deployment.ConfirmIfNoError(chain, tx, err); err != nil {
+	if _, err := deployment.ConfirmFinalizedIfNoError(lggr, chain, tx, err); err != nil {
 		lggr.Errorw("Failed to grant timelock admin role", "err", err)
 		return nil, err
 	}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"crypto/ecdsa"
+	"fmt"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
@@ -43,73 +44,205 @@ func SingleGroupMCMS(t *testing.T) config.Config {
 }
 
 func SignProposal(t *testing.T, env deployment.Environment, proposal *timelock.MCMSWithTimelockProposal) *mcms.Executor {
-	executorClients := make(map[mcms.ChainIdentifier]mcms.ContractDeployBackend)
+	executor, err := SignProposalWithKey(env, proposal, TestXXXMCMSSigner)
+	require.NoError(t, err)
+	return executor
+}
+
+// SignProposalWithKey signs the proposal with the key of a signer of a single group MCMS, see SingleGroupMCMS.
+func SignProposalWithKey(env deployment.Environment, proposal *timelock.MCMSWithTimelockProposal, key *ecdsa.PrivateKey) (*mcms.Executor, error) {
 	for _, chain := range env.Chains {
-		chainselc, exists := chainsel.ChainBySelector(chain.Selector)
-		require.True(t, exists)
-		chainSel := mcms.ChainIdentifier(chainselc.Selector)
-		executorClients[chainSel] = chain.Client
+		if _, err := deployment.ChainFamily(chain.Selector); err != nil {
+			return nil, fmt.Errorf("unknown chain selector %d: %w", chain.Selector, err)
+		}
+	}
+	executor, err := simulatedProposalExecutor(proposal)
+	if err != nil {
+		return nil, err
 	}
-	executor, err := proposal.ToExecutor(true)
-	require.NoError(t, err)
 	payload, err := executor.SigningHash()
-	require.NoError(t, err)
+	if err != nil {
+		return nil, err
+	}
 	// Sign the payload
-	sig, err := crypto.Sign(payload.Bytes(), TestXXXMCMSSigner)
-	require.NoError(t, err)
+	sig, err := crypto.Sign(payload.Bytes(), key)
+	if err != nil {
+		return nil, err
+	}
 	mcmSig, err := mcms.NewSignatureFromBytes(sig)
-	require.NoError(t, err)
+	if err != nil {
+		return nil, err
+	}
 	executor.Proposal.AddSignature(mcmSig)
-	require.NoError(t, executor.Proposal.Validate())
-	return executor
+	if err := executor.Proposal.Validate(); err != nil {
+		return nil, err
+	}
+	return executor, nil
+}
+
+// simulatedProposalExecutor returns the executor of the proposal on simulated chains. The MCMS library resolves the
+// chains of the proposal with chain-selectors, which doesn't know the custom chains, see
+// deployment.RegisterCustomChains. Simulated chains all have the chain id 1337 and the selectors aren't part of the
+// signed root, so custom chains are stood in for by unused chain-selectors test chains while building the executor.
+func simulatedProposalExecutor(proposal *timelock.MCMSWithTimelockProposal) (*mcms.Executor, error) {
+	standIns := make(map[mcms.ChainIdentifier]mcms.ChainIdentifier)
+	for sel := range proposal.ChainMetadata {
+		if _, known := chainsel.ChainBySelector(uint64(sel)); known {
+			continue
+		}
+		family, err := deployment.ChainFamily(uint64(sel))
+		if err != nil {
+			return nil, fmt.Errorf("unknown chain selector %d: %w", sel, err)
+		}
+		if family != chainsel.FamilyEVM {
+			return nil, fmt.Errorf("chain selector %d is not an EVM chain", sel)
+		}
+		standIns[sel] = 0
+	}
+	if len(standIns) == 0 {
+		return proposal.ToExecutor(true)
+	}
+
+	// pick the stand-ins among the test chains which aren't part of the proposal
+	var free []mcms.ChainIdentifier
+	for _, id := range chainsel.TestChainIds() {
+		chain, ok := chainsel.ChainByEvmChainID(id)
+		if _, used := proposal.ChainMetadata[mcms.ChainIdentifier(chain.Selector)]; ok && !used {
+			free = append(free, mcms.ChainIdentifier(chain.Selector))
+		}
+	}
+	if len(free) < len(standIns) {
+		return nil, fmt.Errorf("not enough test chains to stand in for %d custom chains", len(standIns))
+	}
+	original := make(map[mcms.ChainIdentifier]mcms.ChainIdentifier, len(standIns))
+	for sel := range standIns {
+		standIns[sel], free = free[0], free[1:]
+		original[standIns[sel]] = sel
+	}
+	remap := func(sel mcms.ChainIdentifier, m map[mcms.ChainIdentifier]mcms.ChainIdentifier) mcms.ChainIdentifier {
+		if r, ok := m[sel]; ok {
+			return r
+		}
+		return sel
+	}
+
+	stoodIn := *proposal
+	stoodIn.ChainMetadata = make(map[mcms.ChainIdentifier]mcms.ChainMetadata, len(proposal.ChainMetadata))
+	for sel, metadata := range proposal.ChainMetadata {
+		stoodIn.ChainMetadata[remap(sel, standIns)] = metadata
+	}
+	stoodIn.TimelockAddresses = make(map[mcms.ChainIdentifier]common.Address, len(proposal.TimelockAddresses))
+	for sel, addr := range proposal.TimelockAddresses {
+		stoodIn.TimelockAddresses[remap(sel, standIns)] = addr
+	}
+	stoodIn.Transactions = make([]timelock.BatchChainOperation, len(proposal.Transactions))
+	for i, op := range proposal.Transactions {
+		op.ChainIdentifier = remap(op.ChainIdentifier, standIns)
+		stoodIn.Transactions[i] = op
+	}
+	executor, err := stoodIn.ToExecutor(true)
+	if err != nil {
+		return nil, err
+	}
+
+	// restore the custom chains in the executor, the root and its proofs don't depend on the selectors
+	chainMetadata := make(map[mcms.ChainIdentifier]mcms.ChainMetadata, len(executor.Proposal.ChainMetadata))
+	for sel, metadata := range executor.Proposal.ChainMetadata {
+		chainMetadata[remap(sel, original)] = metadata
+	}
+	executor.Proposal.ChainMetadata = chainMetadata
+	for i := range executor.Proposal.Transactions {
+		executor.Proposal.Transactions[i].ChainIdentifier = remap(executor.Proposal.Transactions[i].ChainIdentifier, original)
+	}
+	rootMetadatas := make(map[mcms.ChainIdentifier]owner_helpers.ManyChainMultiSigRootMetadata, len(executor.RootMetadatas))
+	for sel, metadata := range executor.RootMetadatas {
+		rootMetadatas[remap(sel, original)] = metadata
+	}
+	executor.RootMetadatas = rootMetadatas
+	operations := make(map[mcms.ChainIdentifier][]owner_helpers.ManyChainMultiSigOp, len(executor.Operations))
+	for sel, ops := range executor.Operations {
+		operations[remap(sel, original)] = ops
+	}
+	executor.Operations = operations
+	return executor, nil
 }
 
 func ExecuteProposal(t *testing.T, env deployment.Environment, executor *mcms.Executor,
 	timelock *owner_helpers.RBACTimelock, sel uint64) {
 	t.Log("Executing proposal on chain", sel)
+	require.NoError(t, ExecuteProposalOnChain(env, "", executor, timelock, sel))
+}
+
+// ExecuteProposalOnChain sets the root of the signed proposal on the MCMS of the chain and executes
+// its operations for the chain, then executes the batches scheduled on the timelock right away, if any.
+// The timelock must not have a min delay and the deployer key must be its executor. The execution is recorded
+// to the audit log of the environment, if any, as executed by the changeset.
+func ExecuteProposalOnChain(env deployment.Environment, changeset string, executor *mcms.Executor,
+	timelock *owner_helpers.RBACTimelock, sel uint64) error {
 	// Set the root.
-	tx, err2 := executor.SetRootOnChain(env.Chains[sel].Client, env.Chains[sel].DeployerKey, mcms.ChainIdentifier(sel))
-	if err2 != nil {
-		require.NoError(t, deployment.MaybeDataErr(err2))
+	tx, err := executor.SetRootOnChain(env.Chains[sel].Client, env.Chains[sel].DeployerKey, mcms.ChainIdentifier(sel))
+	if err != nil {
+		return deployment.MaybeDataErr(err)
+	}
+	if _, err := env.Chains[sel].Confirm(tx); err != nil {
+		return fmt.Errorf("failed to set root on chain %d: %w", sel, err)
 	}
-	_, err2 = env.Chains[sel].Confirm(tx)
-	require.NoError(t, err2)
+	lastTx := tx.Hash()
 
 	// TODO: This sort of helper probably should move to the MCMS lib.
 	// Execute all the transactions in the proposal which are for this chain.
 	for _, chainOp := range executor.Operations[mcms.ChainIdentifier(sel)] {
 		for idx, op := range executor.ChainAgnosticOps {
 			if bytes.Equal(op.Data, chainOp.Data) && op.To == chainOp.To {
-				opTx, err3 := executor.ExecuteOnChain(env.Chains[sel].Client, env.Chains[sel].DeployerKey, idx)
-				require.NoError(t, err3)
-				block, err3 := env.Chains[sel].Confirm(opTx)
-				require.NoError(t, err3)
-				t.Log("executed", chainOp)
-				it, err3 := timelock.FilterCallScheduled(&bind.FilterOpts{
+				opTx, err := executor.ExecuteOnChain(env.Chains[sel].Client, env.Chains[sel].DeployerKey, idx)
+				if err != nil {
+					return fmt.Errorf("failed to execute operation %d on chain %d: %w", idx, sel, deployment.MaybeDataErr(err))
+				}
+				block, err := env.Chains[sel].Confirm(opTx)
+				if err != nil {
+					return fmt.Errorf("failed to execute operation %d on chain %d: %w", idx, sel, err)
+				}
+				lastTx = opTx.Hash()
+				env.Logger.Infow("Executed operation", "chain", sel, "op", chainOp)
+				it, err := timelock.FilterCallScheduled(&bind.FilterOpts{
 					Start:   block,
 					End:     &block,
 					Context: context.Background(),
 				}, nil, nil)
-				require.NoError(t, err3)
+				if err != nil {
+					return fmt.Errorf("failed to filter scheduled calls on chain %d: %w", sel, err)
+				}
 				var calls []owner_helpers.RBACTimelockCall
 				var pred, salt [32]byte
 				for it.Next() {
 					// Note these are the same for the whole batch, can overwrite
 					pred = it.Event.Predecessor
 					salt = it.Event.Salt
-					t.Log("scheduled", it.Event)
+					env.Logger.Infow("Scheduled call", "chain", sel, "target", it.Event.Target, "index", it.Event.Index)
 					calls = append(calls, owner_helpers.RBACTimelockCall{
 						Target: it.Event.Target,
 						Data:   it.Event.Data,
 						Value:  it.Event.Value,
 					})
 				}
+				if len(calls) == 0 {
+					// bypass proposals execute their calls right away, nothing is scheduled
+					continue
+				}
 				tx, err := timelock.ExecuteBatch(
 					env.Chains[sel].DeployerKey, calls, pred, salt)
-				require.NoError(t, err)
-				_, err = env.Chains[sel].Confirm(tx)
-				require.NoError(t, err)
+				if err != nil {
+					return fmt.Errorf("failed to execute batch on chain %d: %w", sel, deployment.MaybeDataErr(err))
+				}
+				if _, err := env.Chains[sel].Confirm(tx); err != nil {
+					return fmt.Errorf("failed to execute batch on chain %d: %w", sel, err)
+				}
+				lastTx = tx.Hash()
 			}
 		}
 	}
+	if err := deployment.AuditProposalExecuted(env.AuditLog, changeset, env, sel, executor.Proposal.Description, lastTx); err != nil {
+		return fmt.Errorf("failed to audit proposal executed on chain %d: %w", sel, err)
+	}
+	return nil
 }
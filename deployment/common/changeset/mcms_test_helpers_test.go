@@ -0,0 +1,84 @@
+package changeset
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/proposal/mcms"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/proposal/timelock"
+	chainsel "github.com/smartcontractkit/chain-selectors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+)
+
+func TestSignProposalWithKey_CustomChain(t *testing.T) {
+	private := deployment.CustomChain{
+		Selector: 7337001234567,
+		ChainID:  "733700",
+		Family:   chainsel.FamilyEVM,
+		Name:     "private-mcms-testnet",
+	}
+	require.NoError(t, deployment.RegisterCustomChains(private))
+	known := chainsel.TEST_90000001.Selector
+
+	newProposal := func(custom uint64) *timelock.MCMSWithTimelockProposal {
+		chainMetadata := map[mcms.ChainIdentifier]mcms.ChainMetadata{
+			mcms.ChainIdentifier(known):  {MCMAddress: common.HexToAddress("0x01")},
+			mcms.ChainIdentifier(custom): {MCMAddress: common.HexToAddress("0x02"), StartingOpCount: 3},
+		}
+		timelocks := map[mcms.ChainIdentifier]common.Address{
+			mcms.ChainIdentifier(known):  common.HexToAddress("0x11"),
+			mcms.ChainIdentifier(custom): common.HexToAddress("0x12"),
+		}
+		batches := []timelock.BatchChainOperation{
+			{ChainIdentifier: mcms.ChainIdentifier(known), Batch: []mcms.Operation{{To: common.HexToAddress("0x21"), Data: []byte{1}, Value: big.NewInt(0)}}},
+			{ChainIdentifier: mcms.ChainIdentifier(custom), Batch: []mcms.Operation{{To: common.HexToAddress("0x22"), Data: []byte{2}, Value: big.NewInt(0)}}},
+		}
+		proposal, err := timelock.NewMCMSWithTimelockProposal("1", uint32(time.Now().Add(time.Hour).Unix()), []mcms.Signature{},
+			false, chainMetadata, timelocks, "custom chain proposal", batches, timelock.Schedule, "0s")
+		require.NoError(t, err)
+		return proposal
+	}
+	env := deployment.Environment{Chains: map[uint64]deployment.Chain{
+		known:            {Selector: known},
+		private.Selector: {Selector: private.Selector},
+	}}
+
+	proposal := newProposal(private.Selector)
+	executor, err := SignProposalWithKey(env, proposal, TestXXXMCMSSigner)
+	require.NoError(t, err)
+
+	custom := mcms.ChainIdentifier(private.Selector)
+	require.Contains(t, executor.Proposal.ChainMetadata, custom)
+	require.Equal(t, common.HexToAddress("0x02"), executor.RootMetadatas[custom].MultiSig)
+	require.Len(t, executor.Operations[custom], 1)
+	require.Equal(t, common.HexToAddress("0x12"), executor.Operations[custom][0].To)
+	for _, op := range executor.Proposal.Transactions {
+		require.Contains(t, []mcms.ChainIdentifier{mcms.ChainIdentifier(known), custom}, op.ChainIdentifier)
+	}
+	require.Len(t, proposal.ChainMetadata, 2, "the proposal must not be modified")
+	require.Contains(t, proposal.ChainMetadata, custom)
+
+	// the proposal is signed by the key
+	hash, err := executor.SigningHash()
+	require.NoError(t, err)
+	require.Len(t, executor.Proposal.Signatures, 1)
+	signer, err := executor.Proposal.Signatures[0].Recover(hash)
+	require.NoError(t, err)
+	require.Equal(t, crypto.PubkeyToAddress(TestXXXMCMSSigner.Public().(*ecdsa.PublicKey)), signer)
+
+	// the root doesn't depend on the selector of the custom chain
+	standIn, err := newProposal(chainsel.TEST_90000002.Selector).ToExecutor(true)
+	require.NoError(t, err)
+	require.Equal(t, standIn.Tree.Root, executor.Tree.Root)
+
+	// chains unknown to the registry are rejected
+	env.Chains[7337007654321] = deployment.Chain{Selector: 7337007654321}
+	_, err = SignProposalWithKey(env, proposal, TestXXXMCMSSigner)
+	require.ErrorContains(t, err, "unknown chain selector 7337007654321")
+}
@@ -2,8 +2,10 @@ package changeset
 
 import (
 	"errors"
+	"fmt"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/gagliardetto/solana-go"
 	owner_helpers "github.com/smartcontractkit/ccip-owner-contracts/pkg/gethwrappers"
 
 	"github.com/smartcontractkit/chainlink/deployment"
@@ -97,3 +99,55 @@ func LoadMCMSWithTimelockState(chain deployment.Chain, addresses map[string]depl
 	}
 	return &state, nil
 }
+
+// LoadMultisigExecutors returns the executors of multisig proposals for the non-EVM chains of the environment
+// whose multisig is in the address book, see deployment.MultisigProposal.
+func LoadMultisigExecutors(e deployment.Environment) (map[uint64]deployment.MultisigExecutor, error) {
+	executors := make(map[uint64]deployment.MultisigExecutor)
+	for sel, chain := range e.SolChains {
+		addresses, err := chainAddresses(e.ExistingAddresses, sel)
+		if err != nil {
+			return nil, err
+		}
+		multisig := deployment.SolSquadsMultisig{Chain: chain}
+		for address, tv := range addresses {
+			switch tv.String() {
+			case deployment.NewTypeAndVersion(types.SquadsProgram, deployment.Version1_0_0).String():
+				multisig.ProgramID = solana.MustPublicKeyFromBase58(address)
+			case deployment.NewTypeAndVersion(types.SquadsMultisig, deployment.Version1_0_0).String():
+				multisig.Multisig = solana.MustPublicKeyFromBase58(address)
+			}
+		}
+		if multisig.ProgramID.IsZero() != multisig.Multisig.IsZero() {
+			return nil, fmt.Errorf("squads program and multisig must both be set on chain %d", sel)
+		}
+		if !multisig.Multisig.IsZero() {
+			executors[sel] = multisig
+		}
+	}
+	for sel, chain := range e.AptosChains {
+		addresses, err := chainAddresses(e.ExistingAddresses, sel)
+		if err != nil {
+			return nil, err
+		}
+		for address, tv := range addresses {
+			if tv.String() == deployment.NewTypeAndVersion(types.AptosMultisigAccount, deployment.Version1_0_0).String() {
+				multisig, err := deployment.ParseAptosAddress(address)
+				if err != nil {
+					return nil, err
+				}
+				executors[sel] = deployment.AptosMultisig{Chain: chain, Multisig: multisig}
+			}
+		}
+	}
+	return executors, nil
+}
+
+// chainAddresses returns the addresses of the chain, which are none if the chain isn't in the address book.
+func chainAddresses(ab deployment.AddressBook, sel uint64) (map[string]deployment.TypeAndVersion, error) {
+	addresses, err := ab.AddressesForChain(sel)
+	if errors.Is(err, deployment.ErrChainNotFound) {
+		return nil, nil
+	}
+	return addresses, err
+}
@@ -0,0 +1,227 @@
+package changeset
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/deployment/common/types"
+	"github.com/smartcontractkit/chainlink/deployment/environment/memory"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+func TestApplyMultisigProposals(t *testing.T) {
+	lggr := logger.TestLogger(t)
+	e := memory.NewMemoryEnvironment(t, lggr, zapcore.InfoLevel, memory.MemoryEnvironmentConfig{
+		Chains:      1,
+		AptosChains: 1,
+		SolChains:   1,
+	})
+	var solSel, aptosSel uint64
+	for sel := range e.SolChains {
+		solSel = sel
+	}
+	for sel := range e.AptosChains {
+		aptosSel = sel
+	}
+	solChain := e.SolChains[solSel]
+	solClient, ok := memory.AsSimSolClient(solChain)
+	require.True(t, ok)
+	aptosChain := e.AptosChains[aptosSel]
+	aptosClient, ok := memory.AsSimAptosClient(aptosChain)
+	require.True(t, ok)
+
+	// no multisig in the address book yet
+	executors, err := LoadMultisigExecutors(e)
+	require.NoError(t, err)
+	require.Empty(t, executors)
+
+	squads, err := solChain.Client.DeployProgram(context.Background(), []byte("squads"))
+	require.NoError(t, err)
+	target, err := solChain.Client.DeployProgram(context.Background(), []byte("target"))
+	require.NoError(t, err)
+	multisig := solana.NewWallet().PublicKey()
+	solClient.SetAccountData(multisig, squadsMultisigAccount(1, 4, map[solana.PublicKey]uint8{solChain.DeployerKey: 7}))
+	aptosMultisig := deployment.AptosResourceAccountAddress(aptosChain.DeployerAddress, []byte("multisig"))
+	pkg, err := deployment.PublishAptosPackage(lggr, aptosChain, e.ExistingAddresses, deployment.AptosPackage{
+		Modules: [][]byte{{1}},
+		Seed:    []byte("pkg"),
+		Tv:      deployment.NewTypeAndVersion("Package", deployment.Version1_0_0),
+	})
+	require.NoError(t, err)
+	require.NoError(t, e.ExistingAddresses.Save(solSel, squads.String(), deployment.NewTypeAndVersion(types.SquadsProgram, deployment.Version1_0_0)))
+	require.NoError(t, e.ExistingAddresses.Save(solSel, multisig.String(), deployment.NewTypeAndVersion(types.SquadsMultisig, deployment.Version1_0_0)))
+	require.NoError(t, e.ExistingAddresses.Save(aptosSel, aptosMultisig.String(), deployment.NewTypeAndVersion(types.AptosMultisigAccount, deployment.Version1_0_0)))
+
+	executors, err = LoadMultisigExecutors(e)
+	require.NoError(t, err)
+	require.Len(t, executors, 2)
+	vault, err := executors[solSel].(deployment.SolSquadsMultisig).Vault()
+	require.NoError(t, err)
+
+	call := deployment.AptosEntryFunction{Module: pkg, ModuleName: "router", Function: "set_owner", Args: [][]byte{aptosMultisig[:]}}
+	acceptOwnership, err := deployment.NewSolInstructions(deployment.AnchorInstruction(target, "accept_ownership", solana.AccountMetaSlice{
+		solana.Meta(vault).SIGNER(),
+	}))
+	require.NoError(t, err)
+	auditLog := deployment.NewMemoryAuditLog()
+	e.AuditLog = auditLog
+	_, err = ApplyChangesets(t, e, nil, []ChangesetApplication{
+		{
+			Name: "TransferToMultisig",
+			Changeset: WrapChangeSet(func(e deployment.Environment, _ any) (deployment.ChangesetOutput, error) {
+				return deployment.ChangesetOutput{MultisigProposals: []deployment.MultisigProposal{{
+					Description: "transfer ownership",
+					Batches: []deployment.MultisigBatch{
+						{
+							ChainSelector:   solSel,
+							SolInstructions: acceptOwnership,
+						},
+						{ChainSelector: aptosSel, AptosCalls: []deployment.AptosEntryFunction{call}},
+					},
+				}}}, nil
+			}),
+		},
+	})
+	require.NoError(t, err)
+
+	// create, propose, approve and execute the vault transaction
+	require.Len(t, solClient.Instructions(squads), 4)
+	require.Len(t, aptosClient.Calls(deployment.AptosFrameworkAddress, "multisig_account", "create_transaction"), 1)
+	require.Equal(t, []deployment.AptosEntryFunction{call}, aptosClient.Calls(pkg, "router", "set_owner"))
+
+	// the executions are recorded with the changeset and the last transaction on each chain
+	executed, err := auditLog.Query(deployment.AuditQuery{Type: deployment.AuditProposalExecuted, Changeset: "TransferToMultisig"})
+	require.NoError(t, err)
+	require.Len(t, executed, 2)
+	for i, sel := range []uint64{solSel, aptosSel} {
+		require.Equal(t, sel, executed[i].ChainSelector)
+		require.Equal(t, "transfer ownership", executed[i].Description)
+		require.NotEmpty(t, executed[i].TxID)
+	}
+
+	// batches for chains without multisig are rejected
+	require.NoError(t, e.ExistingAddresses.Remove(deployment.NewMemoryAddressBookFromMap(map[uint64]map[string]deployment.TypeAndVersion{
+		aptosSel: {aptosMultisig.String(): deployment.NewTypeAndVersion(types.AptosMultisigAccount, deployment.Version1_0_0)},
+	})))
+	executors, err = LoadMultisigExecutors(e)
+	require.NoError(t, err)
+	_, err = deployment.ExecuteMultisigProposal(context.Background(), executors, deployment.MultisigProposal{
+		Batches: []deployment.MultisigBatch{{ChainSelector: aptosSel, AptosCalls: []deployment.AptosEntryFunction{call}}},
+	})
+	require.ErrorContains(t, err, "multisig not found")
+}
+
+func TestSolSquadsMultisigSigners(t *testing.T) {
+	ctx := context.Background()
+	solChains := memory.NewMemorySolChains(t, 1)
+	var chain deployment.SolChain
+	for _, c := range solChains {
+		chain = c
+	}
+	sim, ok := memory.AsSimSolClient(chain)
+	require.True(t, ok)
+	squads, err := chain.Client.DeployProgram(ctx, []byte("squads"))
+	require.NoError(t, err)
+	target, err := chain.Client.DeployProgram(ctx, []byte("target"))
+	require.NoError(t, err)
+	signer := deployment.SolChain{Selector: chain.Selector, Client: sim, DeployerKey: solana.NewWallet().PublicKey()}
+	multisig := solana.NewWallet().PublicKey()
+	// the deployer and the signer must both approve
+	sim.SetAccountData(multisig, squadsMultisigAccount(2, 0, map[solana.PublicKey]uint8{
+		chain.DeployerKey:  7,
+		signer.DeployerKey: 2,
+	}))
+	m := deployment.SolSquadsMultisig{Chain: chain, ProgramID: squads, Multisig: multisig}
+	instructions, err := deployment.NewSolInstructions(deployment.AnchorInstruction(target, "accept_ownership", nil))
+	require.NoError(t, err)
+	// the simulated chain doesn't execute the Squads program, only the calls to it are counted
+	executed := func() int {
+		n := 0
+		for _, ix := range sim.Instructions(squads) {
+			data, err := ix.Data()
+			require.NoError(t, err)
+			name, _, err := deployment.SquadsIDL.DecodeInstruction(data)
+			require.NoError(t, err)
+			if name == "vault_transaction_execute" {
+				n++
+			}
+		}
+		return n
+	}
+	batch := deployment.MultisigBatch{ChainSelector: chain.Selector, SolInstructions: instructions}
+
+	// the proposal is serializable to be handed to the other signers
+	b, err := json.Marshal(deployment.MultisigProposal{Description: "accept", Batches: []deployment.MultisigBatch{batch}})
+	require.NoError(t, err)
+	var proposal deployment.MultisigProposal
+	require.NoError(t, json.Unmarshal(b, &proposal))
+	require.Equal(t, batch, proposal.Batches[0])
+
+	_, err = m.ExecuteBatch(ctx, batch)
+	require.ErrorContains(t, err, "has 1 of the 2 approvals required")
+	require.Equal(t, 0, executed())
+	m.Signers = []deployment.SolChain{signer}
+	sig, err := m.ExecuteBatch(ctx, batch)
+	require.NoError(t, err)
+	require.NotEmpty(t, sig)
+	require.Equal(t, 1, executed())
+
+	// approvals collected separately, the proposal is executed once approved
+	m.Signers = nil
+	index, err := m.ProposeBatch(ctx, proposal.Batches[0])
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), index)
+	require.NoError(t, deployment.SolSquadsMultisig{Chain: signer, ProgramID: squads, Multisig: multisig}.ApproveBatch(ctx, proposal.Batches[0], index))
+	_, err = m.ExecuteApprovedBatch(ctx, proposal.Batches[0], index)
+	require.ErrorContains(t, err, "invalid proposal")
+	indexSeed := binary.LittleEndian.AppendUint64(nil, index)
+	proposalPDA, _, err := solana.FindProgramAddress([][]byte{
+		[]byte("multisig"), multisig.Bytes(), []byte("transaction"), indexSeed, []byte("proposal"),
+	}, squads)
+	require.NoError(t, err)
+	sim.SetAccountData(proposalPDA, squadsProposalAccount(multisig, index, 3))
+	approvedSig, err := m.ExecuteApprovedBatch(ctx, proposal.Batches[0], index)
+	require.NoError(t, err)
+	require.NotEqual(t, sig, approvedSig)
+	require.Equal(t, 2, executed())
+	// an active proposal isn't executed
+	sim.SetAccountData(proposalPDA, squadsProposalAccount(multisig, index, 1))
+	_, err = m.ExecuteApprovedBatch(ctx, proposal.Batches[0], index)
+	require.ErrorContains(t, err, "is not approved")
+}
+
+// squadsMultisigAccount encodes a Squads multisig account, with the permissions of its members.
+func squadsMultisigAccount(threshold uint16, transactionIndex uint64, members map[solana.PublicKey]uint8) []byte {
+	discriminator := sha256.Sum256([]byte("account:Multisig"))
+	data := append(discriminator[:8:8], make([]byte, 64)...)
+	data = binary.LittleEndian.AppendUint16(data, threshold)
+	data = binary.LittleEndian.AppendUint32(data, 0)
+	data = binary.LittleEndian.AppendUint64(data, transactionIndex)
+	data = binary.LittleEndian.AppendUint64(data, 0)
+	// no rent collector, bump
+	data = append(data, 0, 255)
+	data = binary.LittleEndian.AppendUint32(data, uint32(len(members)))
+	for key, mask := range members {
+		data = append(append(data, key.Bytes()...), mask)
+	}
+	return data
+}
+
+// squadsProposalAccount encodes a Squads proposal account with the status, e.g. 3 for Approved.
+func squadsProposalAccount(multisig solana.PublicKey, transactionIndex uint64, status uint8) []byte {
+	discriminator := sha256.Sum256([]byte("account:Proposal"))
+	data := append(discriminator[:8:8], multisig.Bytes()...)
+	data = binary.LittleEndian.AppendUint64(data, transactionIndex)
+	data = binary.LittleEndian.AppendUint64(append(data, status), 1700000000)
+	// bump, no approved, rejected nor cancelled members
+	data = append(data, 255)
+	return append(data, make([]byte, 12)...)
+}
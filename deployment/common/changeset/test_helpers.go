@@ -1,8 +1,10 @@
 package changeset
 
 import (
+	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	mapset "github.com/deckarep/golang-set/v2"
 	"github.com/smartcontractkit/ccip-owner-contracts/pkg/gethwrappers"
@@ -15,6 +17,11 @@ import (
 type ChangesetApplication struct {
 	Changeset deployment.ChangeSet[any]
 	Config    any
+	// Name identifies the changeset in progress events, its index if unset.
+	Name string
+	// Budget optionally aborts the changeset before it is applied if its estimated cost exceeds
+	// the budget of a chain, see deployment.EstimateChangesetCost.
+	Budget deployment.CostBudget
 }
 
 func WrapChangeSet[C any](fn deployment.ChangeSet[C]) func(e deployment.Environment, config any) (deployment.ChangesetOutput, error) {
@@ -33,13 +40,58 @@ func WrapChangeSet[C any](fn deployment.ChangeSet[C]) func(e deployment.Environm
 }
 
 // ApplyChangesets applies the changeset applications to the environment and returns the updated environment.
+// The progress of the changesets and of the transactions they confirm is reported to the Progress of the environment.
+// If the environment has a Locker, its lock is held while the changesets are applied, and they aren't applied at all
+// if the lock is held by someone else.
 func ApplyChangesets(t *testing.T, e deployment.Environment, timelocksPerChain map[uint64]*gethwrappers.RBACTimelock, changesetApplications []ChangesetApplication) (deployment.Environment, error) {
+	return applyChangesets(testcontext.Get(t), e, timelocksPerChain, changesetApplications)
+}
+
+// applyChangesets is ApplyChangesets outside of tests. Proposals are signed with TestXXXMCMSSigner, so the MCMS
+// of the environment must be SingleGroupMCMS.
+func applyChangesets(ctx context.Context, e deployment.Environment, timelocksPerChain map[uint64]*gethwrappers.RBACTimelock, changesetApplications []ChangesetApplication) (deployment.Environment, error) {
+	if e.Locker != nil {
+		lock, err := e.Locker.Lock(ctx, e.Name)
+		if err != nil {
+			return e, fmt.Errorf("failed to lock environment %s: %w", e.Name, err)
+		}
+		defer func() {
+			if err := lock.Unlock(); err != nil {
+				e.Logger.Errorw("Failed to unlock environment", "name", e.Name, "err", err)
+			}
+		}()
+	}
 	currentEnv := e
+	start := time.Now()
 	for i, csa := range changesetApplications {
-		out, err := csa.Changeset(currentEnv, csa.Config)
+		name := csa.Name
+		if name == "" {
+			name = fmt.Sprintf("changeset %d", i)
+		}
+		progress := e.Progress.WithChangeset(name, i, len(changesetApplications))
+		progress.Report(deployment.ProgressEvent{Type: deployment.ProgressChangesetStarted, Elapsed: time.Since(start)})
+		csEnv := currentEnv
+		csEnv.Chains = deployment.AuditChains(e.AuditLog, name, progress.Chains(currentEnv.Chains))
+		csEnv.Offchain = deployment.AuditOffchain(e.AuditLog, name, currentEnv.Offchain)
+		csEnv.Progress = progress
+		if csa.Budget != nil {
+			cost, err := deployment.EstimateChangesetCost(csEnv, csa.Changeset, csa.Config)
+			if err == nil {
+				err = csa.Budget.Check(cost)
+			}
+			if err != nil {
+				progress.Report(deployment.ProgressEvent{Type: deployment.ProgressChangesetFailed, Elapsed: time.Since(start), Err: err})
+				return e, fmt.Errorf("failed to check budget of changeset at index %d: %w", i, err)
+			}
+		}
+		out, err := csa.Changeset(csEnv, csa.Config)
 		if err != nil {
+			progress.Report(deployment.ProgressEvent{Type: deployment.ProgressChangesetFailed, Elapsed: time.Since(start), Err: err})
 			return e, fmt.Errorf("failed to apply changeset at index %d: %w", i, err)
 		}
+		if err := deployment.AuditChangesetOutput(e.AuditLog, name, csEnv, out); err != nil {
+			return e, fmt.Errorf("failed to audit changeset at index %d: %w", i, err)
+		}
 		var addresses deployment.AddressBook
 		if out.AddressBook != nil {
 			addresses = out.AddressBook
@@ -51,11 +103,10 @@ func ApplyChangesets(t *testing.T, e deployment.Environment, timelocksPerChain m
 			addresses = currentEnv.ExistingAddresses
 		}
 		if out.JobSpecs != nil {
-			ctx := testcontext.Get(t)
 			for nodeID, jobs := range out.JobSpecs {
 				for _, job := range jobs {
 					// Note these auto-accept
-					_, err := currentEnv.Offchain.ProposeJob(ctx,
+					_, err := csEnv.Offchain.ProposeJob(ctx,
 						&jobv1.ProposeJobRequest{
 							NodeId: nodeID,
 							Spec:   job,
@@ -73,13 +124,38 @@ func ApplyChangesets(t *testing.T, e deployment.Environment, timelocksPerChain m
 					chains.Add(uint64(op.ChainIdentifier))
 				}
 
-				signed := SignProposal(t, e, &prop)
+				signed, err := SignProposalWithKey(csEnv, &prop, TestXXXMCMSSigner)
+				if err != nil {
+					return e, fmt.Errorf("failed to sign proposal: %w", err)
+				}
 				for _, sel := range chains.ToSlice() {
 					timelock, ok := timelocksPerChain[sel]
 					if !ok || timelock == nil {
 						return deployment.Environment{}, fmt.Errorf("timelock not found for chain %d", sel)
 					}
-					ExecuteProposal(t, e, signed, timelock, sel)
+					e.Logger.Infow("Executing proposal", "chain", sel)
+					if err := ExecuteProposalOnChain(csEnv, name, signed, timelock, sel); err != nil {
+						return e, fmt.Errorf("failed to execute proposal on chain %d: %w", sel, err)
+					}
+				}
+			}
+		}
+		if len(out.MultisigProposals) != 0 {
+			msEnv := csEnv
+			msEnv.ExistingAddresses = addresses
+			executors, err := LoadMultisigExecutors(msEnv)
+			if err != nil {
+				return e, fmt.Errorf("failed to load multisigs: %w", err)
+			}
+			for _, prop := range out.MultisigProposals {
+				txIDs, err := deployment.ExecuteMultisigProposal(ctx, executors, prop)
+				if err != nil {
+					return e, err
+				}
+				for _, sel := range prop.ChainSelectors() {
+					if err := deployment.AuditMultisigProposalExecuted(e.AuditLog, name, msEnv, sel, prop.Description, txIDs[sel]); err != nil {
+						return e, fmt.Errorf("failed to audit proposal executed on chain %d: %w", sel, err)
+					}
 				}
 			}
 		}
@@ -88,9 +164,21 @@ func ApplyChangesets(t *testing.T, e deployment.Environment, timelocksPerChain m
 			Logger:            e.Logger,
 			ExistingAddresses: addresses,
 			Chains:            e.Chains,
+			AptosChains:       e.AptosChains,
+			SolChains:         e.SolChains,
 			NodeIDs:           e.NodeIDs,
 			Offchain:          e.Offchain,
+			StateCache:        e.StateCache,
+			Progress:          e.Progress,
+			AuditLog:          e.AuditLog,
+			Locker:            e.Locker,
 		}
+		elapsed := time.Since(start)
+		progress.Report(deployment.ProgressEvent{
+			Type:      deployment.ProgressChangesetCompleted,
+			Elapsed:   elapsed,
+			Remaining: elapsed / time.Duration(i+1) * time.Duration(len(changesetApplications)-i-1),
+		})
 	}
 	return currentEnv, nil
 }
@@ -16,6 +16,12 @@ const (
 	CancellerManyChainMultisig deployment.ContractType = "CancellerManyChainMultiSig"
 	ProposerManyChainMultisig  deployment.ContractType = "ProposerManyChainMultiSig"
 	RBACTimelock               deployment.ContractType = "RBACTimelock"
+	// SquadsProgram and SquadsMultisig are the Squads program and the multisig account owning the programs
+	// of a Solana chain, the counterpart of the MCMS with timelock.
+	SquadsProgram  deployment.ContractType = "SquadsProgram"
+	SquadsMultisig deployment.ContractType = "SquadsMultisig"
+	// AptosMultisigAccount is the multisig account owning the packages of an Aptos chain.
+	AptosMultisigAccount deployment.ContractType = "AptosMultisigAccount"
 )
 
 type MCMSWithTimelockConfig struct {
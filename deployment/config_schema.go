@@ -0,0 +1,108 @@
+package deployment
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+
+	"github.com/invopop/jsonschema"
+	jsonschemavalidator "github.com/santhosh-tekuri/jsonschema/v5"
+	"sigs.k8s.io/yaml"
+)
+
+var (
+	bigIntType        = reflect.TypeOf(big.Int{})
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// ConfigSchema returns the JSON Schema of the changeset config type C, derived from its Go type and json tags.
+// Unknown fields are rejected and only the fields tagged with `jsonschema:"required"` are required, see
+// https://github.com/invopop/jsonschema for the other tags.
+func ConfigSchema[C any]() *jsonschema.Schema {
+	r := &jsonschema.Reflector{
+		RequiredFromJSONSchemaTags: true,
+		DoNotReference:             true,
+		Mapper:                     mapConfigType,
+	}
+	var c C
+	return r.Reflect(&c)
+}
+
+// mapConfigType returns the schema of the types which are not encoded like their Go type, e.g. addresses,
+// which are hex strings rather than arrays of bytes.
+func mapConfigType(t reflect.Type) *jsonschema.Schema {
+	if t == bigIntType {
+		return &jsonschema.Schema{Type: "integer"}
+	}
+	if t.Implements(textMarshalerType) || reflect.PointerTo(t).Implements(textMarshalerType) {
+		return &jsonschema.Schema{Type: "string"}
+	}
+	return nil
+}
+
+// LoadConfig decodes the JSON or YAML config of type C for non-Go operators. The config is validated against
+// ConfigSchema, with the paths of the invalid fields in the error, then with its Validate method if it has one.
+func LoadConfig[C any](data []byte) (C, error) {
+	var c C
+	jsonData, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return c, fmt.Errorf("%w: %w", ErrInvalidConfig, err)
+	}
+	if err := validateConfigJSON(ConfigSchema[C](), jsonData); err != nil {
+		return c, err
+	}
+	if err := json.Unmarshal(jsonData, &c); err != nil {
+		return c, fmt.Errorf("%w: %w", ErrInvalidConfig, err)
+	}
+	if v, ok := any(c).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return c, fmt.Errorf("%w: %w", ErrInvalidConfig, err)
+		}
+	}
+	return c, nil
+}
+
+func validateConfigJSON(schema *jsonschema.Schema, data []byte) error {
+	schemaJSON, err := json.Marshal(schema)
+	if err != nil {
+		return err
+	}
+	compiler := jsonschemavalidator.NewCompiler()
+	if err := compiler.AddResource("config.json", bytes.NewReader(schemaJSON)); err != nil {
+		return err
+	}
+	compiled, err := compiler.Compile("config.json")
+	if err != nil {
+		return err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	// keeps large integers, e.g. chain selectors, exact
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidConfig, err)
+	}
+	err = compiled.Validate(v)
+	var validationErr *jsonschemavalidator.ValidationError
+	if !errors.As(err, &validationErr) {
+		return err
+	}
+	var problems []string
+	for _, e := range validationErr.BasicOutput().Errors {
+		// the errors of the parents of the invalid fields only point to their children
+		if e.Error == "" || strings.HasPrefix(e.Error, "doesn't validate with") {
+			continue
+		}
+		location := e.InstanceLocation
+		if location == "" {
+			location = "/"
+		}
+		problems = append(problems, fmt.Sprintf("%s: %s", location, e.Error))
+	}
+	return fmt.Errorf("%w: %s", ErrInvalidConfig, strings.Join(problems, "; "))
+}
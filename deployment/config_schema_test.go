@@ -0,0 +1,59 @@
+package deployment
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+type testChangesetConfig struct {
+	ChainSelectors []uint64 `jsonschema:"required"`
+	Owner          common.Address
+	Limits         map[uint64]uint32
+	Note           string `json:"note,omitempty"`
+}
+
+func (c testChangesetConfig) Validate() error {
+	if len(c.ChainSelectors) == 0 {
+		return errors.New("no chain selectors")
+	}
+	return nil
+}
+
+func TestConfigSchema(t *testing.T) {
+	schema, err := json.Marshal(ConfigSchema[testChangesetConfig]())
+	require.NoError(t, err)
+	var decoded map[string]any
+	require.NoError(t, json.Unmarshal(schema, &decoded))
+	require.Equal(t, []any{"ChainSelectors"}, decoded["required"])
+	properties := decoded["properties"].(map[string]any)
+	require.Equal(t, "string", properties["Owner"].(map[string]any)["type"])
+	require.Contains(t, properties, "note")
+}
+
+func TestLoadConfig(t *testing.T) {
+	cfg, err := LoadConfig[testChangesetConfig]([]byte(`
+ChainSelectors: [16015286601757825753]
+Owner: "0x1000000000000000000000000000000000000000"
+Limits:
+  "16015286601757825753": 10
+`))
+	require.NoError(t, err)
+	require.Equal(t, []uint64{16015286601757825753}, cfg.ChainSelectors)
+	require.Equal(t, common.HexToAddress("0x1000000000000000000000000000000000000000"), cfg.Owner)
+	require.Equal(t, map[uint64]uint32{16015286601757825753: 10}, cfg.Limits)
+
+	_, err = LoadConfig[testChangesetConfig]([]byte(`{"ChainSelectors": [1], "Limits": {"1": "ten"}}`))
+	require.ErrorIs(t, err, ErrInvalidConfig)
+	require.ErrorContains(t, err, "/Limits/1")
+	_, err = LoadConfig[testChangesetConfig]([]byte(`{"ChainSelectors": [1], "Unknown": true}`))
+	require.ErrorContains(t, err, "Unknown")
+	_, err = LoadConfig[testChangesetConfig]([]byte(`{"Owner": "0x1000000000000000000000000000000000000000"}`))
+	require.ErrorContains(t, err, "ChainSelectors")
+	// valid against the schema but not per Validate
+	_, err = LoadConfig[testChangesetConfig]([]byte(`{"ChainSelectors": []}`))
+	require.ErrorContains(t, err, "no chain selectors")
+}
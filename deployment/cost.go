@@ -0,0 +1,316 @@
+package deployment
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"google.golang.org/grpc"
+
+	csav1 "github.com/smartcontractkit/chainlink-protos/job-distributor/v1/csa"
+	jobv1 "github.com/smartcontractkit/chainlink-protos/job-distributor/v1/job"
+	nodev1 "github.com/smartcontractkit/chainlink-protos/job-distributor/v1/node"
+)
+
+// ChainCost is the estimated cost of the transactions a changeset sends to a chain.
+type ChainCost struct {
+	Txs int
+	// Gas is the sum of the gas limits of the transactions, estimated by the bindings unless set on the deployer key.
+	Gas uint64
+	// GasPrice is the gas price suggested by the chain when the changeset was estimated.
+	GasPrice *big.Int
+	// Cost is Gas times GasPrice, in wei.
+	Cost *big.Int
+}
+
+// ChangesetCost is the estimated cost of a changeset by chain selector, see EstimateChangesetCost.
+type ChangesetCost map[uint64]*ChainCost
+
+// CostBudget is the maximum native cost in wei of a changeset by chain selector.
+// Chains without a budget are not limited.
+type CostBudget map[uint64]*big.Int
+
+// Check returns an error listing the chains whose cost exceeds their budget.
+func (b CostBudget) Check(cost ChangesetCost) error {
+	var exceeded []uint64
+	for sel, limit := range b {
+		if c, ok := cost[sel]; ok && c.Cost.Cmp(limit) > 0 {
+			exceeded = append(exceeded, sel)
+		}
+	}
+	if len(exceeded) == 0 {
+		return nil
+	}
+	sort.Slice(exceeded, func(i, j int) bool { return exceeded[i] < exceeded[j] })
+	var sb strings.Builder
+	sb.WriteString("estimated changeset cost exceeds budget:")
+	for _, sel := range exceeded {
+		fmt.Fprintf(&sb, "\n\tchain %d: %s wei for %d txs, budget %s wei", sel, cost[sel].Cost, cost[sel].Txs, b[sel])
+	}
+	return fmt.Errorf("%s", sb.String())
+}
+
+// EstimateChangesetCost estimates the cost of the transactions the changeset sends by running it against
+// the chains of the environment without sending them, pricing their gas at the current gas price of each chain.
+// Only the transactions sent directly are estimated, not the ones of the proposals the changeset returns.
+// As the transactions are not mined, changesets reading the state they write, e.g. calling a contract they
+// deploy, fail: the cost of the transactions sent until then is returned along with the error.
+// Confirm and the receipts of the recorded transactions return right away, in block 0. The job distributor
+// can only be read: changesets proposing jobs or updating nodes can't be estimated.
+// zkSync-class chains are estimated as EVM chains.
+func EstimateChangesetCost[C any](e Environment, cs ChangeSet[C], config C) (ChangesetCost, error) {
+	ctx := context.Background()
+	clients := make(map[uint64]*estimatingClient, len(e.Chains))
+	chains := make(map[uint64]Chain, len(e.Chains))
+	for sel, chain := range e.Chains {
+		gasPrice, err := chain.Client.SuggestGasPrice(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get gas price of chain %d: %w", sel, err)
+		}
+		client := &estimatingClient{
+			OnchainClient: chain.Client,
+			nonces:        make(map[common.Address]uint64),
+			receipts:      make(map[common.Hash]*types.Receipt),
+			cost:          ChainCost{GasPrice: gasPrice, Cost: new(big.Int)},
+		}
+		clients[sel] = client
+		chain.Client = client
+		chain.Confirm = client.confirm
+		chain.ZkDeployer = nil
+		chain.Nonces = nil
+		chains[sel] = chain
+	}
+	addresses := NewMemoryAddressBook()
+	if err := addresses.Merge(e.ExistingAddresses); err != nil {
+		return nil, fmt.Errorf("failed to copy address book: %w", err)
+	}
+	estimateEnv := e
+	estimateEnv.Chains = chains
+	estimateEnv.ExistingAddresses = addresses
+	var offchain *readOnlyOffchainClient
+	if e.Offchain != nil {
+		offchain = &readOnlyOffchainClient{OffchainClient: e.Offchain}
+		estimateEnv.Offchain = offchain
+	}
+	// the state loaded by the estimated changeset must not outlive it
+	estimateEnv.StateCache = nil
+	estimateEnv.Progress = nil
+	_, err := cs(estimateEnv, config)
+
+	cost := make(ChangesetCost)
+	for sel, client := range clients {
+		if client.cost.Txs > 0 {
+			c := client.cost
+			cost[sel] = &c
+		}
+	}
+	if err != nil {
+		return cost, fmt.Errorf("failed to estimate changeset: %w", err)
+	}
+	// the changeset may have ignored the error of the mutation
+	if mutations := offchain.mutations(); len(mutations) > 0 {
+		return cost, fmt.Errorf("failed to estimate changeset: it calls %s of the job distributor", strings.Join(mutations, ", "))
+	}
+	return cost, nil
+}
+
+// estimatingClient records the transactions sent through it instead of sending them, see EstimateChangesetCost.
+type estimatingClient struct {
+	OnchainClient
+	mu       sync.Mutex
+	nonces   map[common.Address]uint64
+	receipts map[common.Hash]*types.Receipt
+	cost     ChainCost
+}
+
+func (c *estimatingClient) unwrap() OnchainClient {
+	return c.OnchainClient
+}
+
+func (c *estimatingClient) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if nonce, ok := c.nonces[account]; ok {
+		return nonce, nil
+	}
+	nonce, err := c.OnchainClient.PendingNonceAt(ctx, account)
+	if err != nil {
+		return 0, err
+	}
+	c.nonces[account] = nonce
+	return nonce, nil
+}
+
+func (c *estimatingClient) SendTransaction(_ context.Context, tx *types.Transaction) error {
+	from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		return fmt.Errorf("failed to recover sender of transaction %s: %w", tx.Hash(), err)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.nonces[from] = tx.Nonce() + 1
+	receipt := &types.Receipt{
+		Type:              tx.Type(),
+		Status:            types.ReceiptStatusSuccessful,
+		TxHash:            tx.Hash(),
+		GasUsed:           tx.Gas(),
+		EffectiveGasPrice: c.cost.GasPrice,
+		BlockNumber:       new(big.Int),
+	}
+	if tx.To() == nil {
+		receipt.ContractAddress = crypto.CreateAddress(from, tx.Nonce())
+	}
+	c.receipts[tx.Hash()] = receipt
+	c.cost.Txs++
+	c.cost.Gas += tx.Gas()
+	c.cost.Cost.Add(c.cost.Cost, new(big.Int).Mul(new(big.Int).SetUint64(tx.Gas()), c.cost.GasPrice))
+	return nil
+}
+
+// TransactionReceipt returns the receipts of the recorded transactions, so that waiting for them doesn't block.
+func (c *estimatingClient) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	c.mu.Lock()
+	receipt, ok := c.receipts[txHash]
+	c.mu.Unlock()
+	if ok {
+		return receipt, nil
+	}
+	return c.OnchainClient.TransactionReceipt(ctx, txHash)
+}
+
+func (c *estimatingClient) confirm(tx *types.Transaction) (uint64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.receipts[tx.Hash()]; !ok {
+		return 0, fmt.Errorf("transaction %s was not sent to the chain", tx.Hash())
+	}
+	return 0, nil
+}
+
+// readOnlyOffchainClient records the calls to the job distributor and fails the ones which would mutate it,
+// see EstimateChangesetCost.
+type readOnlyOffchainClient struct {
+	OffchainClient
+	mu    sync.Mutex
+	calls []offchainCall
+}
+
+type offchainCall struct {
+	method   string
+	mutation bool
+}
+
+func (c *readOnlyOffchainClient) record(method string, mutation bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls = append(c.calls, offchainCall{method: method, mutation: mutation})
+}
+
+// reject records a call mutating the job distributor and returns the error of the call.
+func (c *readOnlyOffchainClient) reject(method string) error {
+	c.record(method, true)
+	return fmt.Errorf("%s is not allowed while estimating the cost of a changeset", method)
+}
+
+// mutations returns the methods mutating the job distributor which were called, nil on a nil client.
+func (c *readOnlyOffchainClient) mutations() []string {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var methods []string
+	for _, call := range c.calls {
+		if call.mutation {
+			methods = append(methods, call.method)
+		}
+	}
+	return methods
+}
+
+func (c *readOnlyOffchainClient) GetJob(ctx context.Context, in *jobv1.GetJobRequest, opts ...grpc.CallOption) (*jobv1.GetJobResponse, error) {
+	c.record("GetJob", false)
+	return c.OffchainClient.GetJob(ctx, in, opts...)
+}
+
+func (c *readOnlyOffchainClient) GetProposal(ctx context.Context, in *jobv1.GetProposalRequest, opts ...grpc.CallOption) (*jobv1.GetProposalResponse, error) {
+	c.record("GetProposal", false)
+	return c.OffchainClient.GetProposal(ctx, in, opts...)
+}
+
+func (c *readOnlyOffchainClient) ListJobs(ctx context.Context, in *jobv1.ListJobsRequest, opts ...grpc.CallOption) (*jobv1.ListJobsResponse, error) {
+	c.record("ListJobs", false)
+	return c.OffchainClient.ListJobs(ctx, in, opts...)
+}
+
+func (c *readOnlyOffchainClient) ListProposals(ctx context.Context, in *jobv1.ListProposalsRequest, opts ...grpc.CallOption) (*jobv1.ListProposalsResponse, error) {
+	c.record("ListProposals", false)
+	return c.OffchainClient.ListProposals(ctx, in, opts...)
+}
+
+func (c *readOnlyOffchainClient) GetNode(ctx context.Context, in *nodev1.GetNodeRequest, opts ...grpc.CallOption) (*nodev1.GetNodeResponse, error) {
+	c.record("GetNode", false)
+	return c.OffchainClient.GetNode(ctx, in, opts...)
+}
+
+func (c *readOnlyOffchainClient) ListNodes(ctx context.Context, in *nodev1.ListNodesRequest, opts ...grpc.CallOption) (*nodev1.ListNodesResponse, error) {
+	c.record("ListNodes", false)
+	return c.OffchainClient.ListNodes(ctx, in, opts...)
+}
+
+func (c *readOnlyOffchainClient) ListNodeChainConfigs(ctx context.Context, in *nodev1.ListNodeChainConfigsRequest, opts ...grpc.CallOption) (*nodev1.ListNodeChainConfigsResponse, error) {
+	c.record("ListNodeChainConfigs", false)
+	return c.OffchainClient.ListNodeChainConfigs(ctx, in, opts...)
+}
+
+func (c *readOnlyOffchainClient) GetKeypair(ctx context.Context, in *csav1.GetKeypairRequest, opts ...grpc.CallOption) (*csav1.GetKeypairResponse, error) {
+	c.record("GetKeypair", false)
+	return c.OffchainClient.GetKeypair(ctx, in, opts...)
+}
+
+func (c *readOnlyOffchainClient) ListKeypairs(ctx context.Context, in *csav1.ListKeypairsRequest, opts ...grpc.CallOption) (*csav1.ListKeypairsResponse, error) {
+	c.record("ListKeypairs", false)
+	return c.OffchainClient.ListKeypairs(ctx, in, opts...)
+}
+
+func (c *readOnlyOffchainClient) ProposeJob(_ context.Context, _ *jobv1.ProposeJobRequest, _ ...grpc.CallOption) (*jobv1.ProposeJobResponse, error) {
+	return nil, c.reject("ProposeJob")
+}
+
+func (c *readOnlyOffchainClient) BatchProposeJob(_ context.Context, _ *jobv1.BatchProposeJobRequest, _ ...grpc.CallOption) (*jobv1.BatchProposeJobResponse, error) {
+	return nil, c.reject("BatchProposeJob")
+}
+
+func (c *readOnlyOffchainClient) UpdateJob(_ context.Context, _ *jobv1.UpdateJobRequest, _ ...grpc.CallOption) (*jobv1.UpdateJobResponse, error) {
+	return nil, c.reject("UpdateJob")
+}
+
+func (c *readOnlyOffchainClient) RevokeJob(_ context.Context, _ *jobv1.RevokeJobRequest, _ ...grpc.CallOption) (*jobv1.RevokeJobResponse, error) {
+	return nil, c.reject("RevokeJob")
+}
+
+func (c *readOnlyOffchainClient) DeleteJob(_ context.Context, _ *jobv1.DeleteJobRequest, _ ...grpc.CallOption) (*jobv1.DeleteJobResponse, error) {
+	return nil, c.reject("DeleteJob")
+}
+
+func (c *readOnlyOffchainClient) RegisterNode(_ context.Context, _ *nodev1.RegisterNodeRequest, _ ...grpc.CallOption) (*nodev1.RegisterNodeResponse, error) {
+	return nil, c.reject("RegisterNode")
+}
+
+func (c *readOnlyOffchainClient) UpdateNode(_ context.Context, _ *nodev1.UpdateNodeRequest, _ ...grpc.CallOption) (*nodev1.UpdateNodeResponse, error) {
+	return nil, c.reject("UpdateNode")
+}
+
+func (c *readOnlyOffchainClient) EnableNode(_ context.Context, _ *nodev1.EnableNodeRequest, _ ...grpc.CallOption) (*nodev1.EnableNodeResponse, error) {
+	return nil, c.reject("EnableNode")
+}
+
+func (c *readOnlyOffchainClient) DisableNode(_ context.Context, _ *nodev1.DisableNodeRequest, _ ...grpc.CallOption) (*nodev1.DisableNodeResponse, error) {
+	return nil, c.reject("DisableNode")
+}
@@ -0,0 +1,118 @@
+package deployment
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	jobv1 "github.com/smartcontractkit/chainlink-protos/job-distributor/v1/job"
+	nodev1 "github.com/smartcontractkit/chainlink-protos/job-distributor/v1/node"
+)
+
+type pricedClient struct {
+	OnchainClient
+}
+
+func (pricedClient) SuggestGasPrice(context.Context) (*big.Int, error) {
+	return big.NewInt(2), nil
+}
+
+func (pricedClient) PendingNonceAt(context.Context, common.Address) (uint64, error) {
+	return 5, nil
+}
+
+func TestEstimateChangesetCost(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	deployer, err := bind.NewKeyedTransactorWithChainID(key, big.NewInt(1337))
+	require.NoError(t, err)
+	e := Environment{
+		ExistingAddresses: NewMemoryAddressBook(),
+		Chains:            map[uint64]Chain{1: {Selector: 1, Client: pricedClient{}, DeployerKey: deployer}},
+	}
+	var nonces []uint64
+	cs := func(e Environment, txs int) (ChangesetOutput, error) {
+		chain := e.Chains[1]
+		for i := 0; i < txs; i++ {
+			nonce, err := chain.Client.PendingNonceAt(context.Background(), deployer.From)
+			require.NoError(t, err)
+			nonces = append(nonces, nonce)
+			tx, err := deployer.Signer(deployer.From, types.NewTx(&types.LegacyTx{Nonce: nonce, Gas: 50000, GasPrice: big.NewInt(1)}))
+			require.NoError(t, err)
+			if err := chain.Client.SendTransaction(context.Background(), tx); err != nil {
+				return ChangesetOutput{}, err
+			}
+			if _, err := chain.Confirm(tx); err != nil {
+				return ChangesetOutput{}, err
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			receipt, err := bind.WaitMined(ctx, chain.Client, tx)
+			cancel()
+			if err != nil {
+				return ChangesetOutput{}, err
+			}
+			if receipt.ContractAddress != crypto.CreateAddress(deployer.From, nonce) {
+				return ChangesetOutput{}, fmt.Errorf("unexpected contract address %s", receipt.ContractAddress)
+			}
+		}
+		return ChangesetOutput{}, nil
+	}
+
+	cost, err := EstimateChangesetCost(e, cs, 3)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{5, 6, 7}, nonces)
+	require.Equal(t, ChangesetCost{1: {Txs: 3, Gas: 150000, GasPrice: big.NewInt(2), Cost: big.NewInt(300000)}}, cost)
+
+	// transactions sent outside of the estimated chain are not confirmed
+	other, err := deployer.Signer(deployer.From, types.NewTx(&types.LegacyTx{Nonce: 9, Gas: 50000, GasPrice: big.NewInt(1)}))
+	require.NoError(t, err)
+	_, err = EstimateChangesetCost(e, func(e Environment, _ any) (ChangesetOutput, error) {
+		_, err := e.Chains[1].Confirm(other)
+		return ChangesetOutput{}, err
+	}, nil)
+	require.ErrorContains(t, err, "was not sent to the chain")
+
+	require.NoError(t, CostBudget{1: big.NewInt(300000), 2: big.NewInt(0)}.Check(cost))
+	require.EqualError(t, CostBudget{1: big.NewInt(299999)}.Check(cost),
+		"estimated changeset cost exceeds budget:\n\tchain 1: 300000 wei for 3 txs, budget 299999 wei")
+}
+
+type listingOffchainClient struct {
+	OffchainClient
+}
+
+func (listingOffchainClient) ListNodes(context.Context, *nodev1.ListNodesRequest, ...grpc.CallOption) (*nodev1.ListNodesResponse, error) {
+	return &nodev1.ListNodesResponse{Nodes: []*nodev1.Node{{Id: "node"}}}, nil
+}
+
+func TestEstimateChangesetCostOffchain(t *testing.T) {
+	e := Environment{ExistingAddresses: NewMemoryAddressBook(), Offchain: listingOffchainClient{}}
+	var nodes int
+	cs := func(e Environment, propose bool) (ChangesetOutput, error) {
+		resp, err := e.Offchain.ListNodes(context.Background(), &nodev1.ListNodesRequest{})
+		if err != nil {
+			return ChangesetOutput{}, err
+		}
+		nodes = len(resp.Nodes)
+		if propose {
+			// the error is ignored, the estimate still fails
+			_, _ = e.Offchain.ProposeJob(context.Background(), &jobv1.ProposeJobRequest{NodeId: "node"})
+		}
+		return ChangesetOutput{}, nil
+	}
+
+	_, err := EstimateChangesetCost(e, cs, false)
+	require.NoError(t, err)
+	require.Equal(t, 1, nodes)
+	_, err = EstimateChangesetCost(e, cs, true)
+	require.EqualError(t, err, "failed to estimate changeset: it calls ProposeJob of the job distributor")
+}
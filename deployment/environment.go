@@ -8,6 +8,7 @@ import (
 	"math/big"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
@@ -46,6 +47,60 @@ type OffchainClient interface {
 	csav1.CSAServiceClient
 }
 
+// LogReplayRequest describes a targeted LogPoller replay. Only logs emitted by Addresses (and, if set, matching
+// one of EventSigs) within [FromBlock, ToBlock] on the chain identified by ChainSelector are backfilled.
+type LogReplayRequest struct {
+	ChainSelector uint64
+	FromBlock     uint64
+	ToBlock       uint64
+	Addresses     []common.Address
+	EventSigs     []common.Hash
+}
+
+// LogReplayer is implemented by offchain clients which can trigger
+// targeted LogPoller replays on the nodes they manage.
+type LogReplayer interface {
+	ReplayLogsInRange(ctx context.Context, req LogReplayRequest) error
+}
+
+// OCRKeyRotator is implemented by offchain clients which can create and delete
+// OCR2 key bundles on the nodes they manage, which is used to rotate node keys.
+type OCRKeyRotator interface {
+	// CreateOCRKeyBundle creates a new EVM OCR2 key bundle on the node and starts advertising
+	// it in the node's chain configs. It returns the ID of the new bundle.
+	CreateOCRKeyBundle(ctx context.Context, nodeID string) (string, error)
+	// DeleteOCRKeyBundle deletes an OCR2 key bundle which is no longer advertised from the node.
+	DeleteOCRKeyBundle(ctx context.Context, nodeID string, bundleID string) error
+}
+
+// JobPluginConfig is the effective plugin config of an OCR2 job running on a node, resolved and validated
+// as its plugin does, with secrets redacted.
+type JobPluginConfig struct {
+	PluginType   string
+	Relay        string
+	RelayConfig  map[string]any
+	PluginConfig map[string]any
+	// ValidationError is set when the plugin config is invalid.
+	ValidationError string
+}
+
+// PluginConfigReader is implemented by offchain clients which can read the effective plugin
+// config of the jobs running on the nodes they manage, e.g. to check a changeset took effect.
+type PluginConfigReader interface {
+	// JobPluginConfig returns the plugin config of the job, which is identified by either its ID
+	// on the node or its external job ID.
+	JobPluginConfig(ctx context.Context, nodeID string, jobID string) (JobPluginConfig, error)
+}
+
+// NodeRestarter is implemented by offchain clients which can stop and start the nodes
+// they manage, preserving their state, which is used to test crashes and rolling restarts.
+type NodeRestarter interface {
+	// StopNode stops the node as if it crashed.
+	StopNode(ctx context.Context, nodeID string) error
+	// StartNode starts a stopped node, which resumes from its persisted state.
+	StartNode(ctx context.Context, nodeID string) error
+}
+
 // Chain represents an EVM chain.
 type Chain struct {
 	// Selectors used as canonical chain identifier.
@@ -53,14 +108,44 @@ type Chain struct {
 	Client   OnchainClient
 	// Note the Sign function can be abstract supporting a variety of key storage mechanisms (e.g. KMS etc).
 	DeployerKey *bind.TransactOpts
-	Confirm     func(tx *types.Transaction) (uint64, error)
+	// Signers are the other keys of the chain, e.g. treasuries, which changeset configs reference by
+	// address so that they remain serializable, see Signer.
+	Signers []*bind.TransactOpts
+	Confirm func(tx *types.Transaction) (uint64, error)
+	// FinalityDepth optionally makes ConfirmFinalized wait for that many blocks on top of the block of
+	// a transaction, for chains without a finalized block tag. By default the tag is used.
+	FinalityDepth uint32
+	// FinalityTimeout optionally overrides DefaultFinalityTimeout.
+	FinalityTimeout time.Duration
+	// Nonces is set when the nonces of the chain are tracked locally, see TrackNonces.
+	Nonces *NonceTracker
+	// ZkDeployer is set on zkSync-class chains, where contracts are deployed with DeployZkContract.
+	ZkDeployer ZkDeployer
+}
+
+// Signer returns the key of the address among the deployer key and the Signers of the chain.
+func (c Chain) Signer(addr common.Address) (*bind.TransactOpts, error) {
+	if c.DeployerKey != nil && c.DeployerKey.From == addr {
+		return c.DeployerKey, nil
+	}
+	for _, signer := range c.Signers {
+		if signer.From == addr {
+			return signer, nil
+		}
+	}
+	return nil, fmt.Errorf("no key for %s on chain %d", addr, c.Selector)
+}
+
+// IsZkChain returns true for zkSync-class chains, see ZkDeployer.
+func (c Chain) IsZkChain() bool {
+	return c.ZkDeployer != nil
 }
 
 // Environment represents an instance of a deployed product
 // including on and offchain components. It is intended to be
 // cross-family to enable a coherent view of a product deployed
 // to all its chains.
-// TODO: Add SolChains, AptosChain etc.
+// TODO: Add other families, e.g. Starknet
 // using Go bindings/libraries from their respective
 // repositories i.e. chainlink-solana, chainlink-cosmos
 // You can think of ExistingAddresses as a set of
@@ -74,8 +159,20 @@ type Environment struct {
 	Logger            logger.Logger
 	ExistingAddresses AddressBook
 	Chains            map[uint64]Chain
-	NodeIDs           []string
-	Offchain          OffchainClient
+	// AptosChains are the Aptos chains of the environment, keyed by selector like Chains.
+	AptosChains map[uint64]AptosChain
+	// SolChains are the Solana chains of the environment, keyed by selector like Chains.
+	SolChains map[uint64]SolChain
+	NodeIDs   []string
+	Offchain  OffchainClient
+	// StateCache optionally caches the onchain state loaded from ExistingAddresses, nil disables caching.
+	StateCache *StateCache
+	// Progress optionally receives the progress events of the changesets applied to the environment.
+	Progress ProgressReporter
+	// AuditLog optionally records the mutating operations of the changesets applied to the environment.
+	AuditLog AuditLog
+	// Locker optionally locks the environment while changesets are applied to it, see EnvironmentLocker.
+	Locker EnvironmentLocker
 }
 
 func NewEnvironment(
@@ -137,6 +234,9 @@ func (e Environment) AllDeployerKeys() []common.Address {
 
 func ConfirmIfNoError(chain Chain, tx *types.Transaction, err error) (uint64, error) {
 	if err != nil {
+		if decoded, ok := DecodeRevert(err); ok {
+			return 0, fmt.Errorf("transaction reverted: %w", decoded)
+		}
 		//revive:disable
 		var d rpc.DataError
 		ok := errors.As(err, &d)
@@ -148,7 +248,12 @@ func ConfirmIfNoError(chain Chain, tx *types.Transaction, err error) (uint64, er
 	return chain.Confirm(tx)
 }
 
+// MaybeDataErr returns the revert of the error decoded into a named error with its parameters,
+// see DecodeRevert, or the rpc.DataError it wraps if the revert data does not match any known error.
 func MaybeDataErr(err error) error {
+	if decoded, ok := DecodeRevert(err); ok {
+		return decoded
+	}
 	//revive:disable
 	var d rpc.DataError
 	ok := errors.As(err, &d)
@@ -210,9 +315,7 @@ func (n Nodes) BootstrapLocators() []string {
 	bootstrapMp := make(map[string]struct{})
 	for _, node := range n {
 		if node.IsBootstrap {
-			bootstrapMp[fmt.Sprintf("%s@%s",
-				// p2p_12D3... -> 12D3...
-				node.PeerID.String()[4:], node.MultiAddr)] = struct{}{}
+			bootstrapMp[fmt.Sprintf("%s@%s", node.PeerID.Raw(), node.MultiAddr)] = struct{}{}
 		}
 	}
 	var locators []string
@@ -240,17 +343,7 @@ func (n Node) OCRConfigForChainDetails(details chain_selectors.ChainDetails) (OC
 }
 
 func (n Node) OCRConfigForChainSelector(chainSel uint64) (OCRConfig, bool) {
-	fam, err := chain_selectors.GetSelectorFamily(chainSel)
-	if err != nil {
-		return OCRConfig{}, false
-	}
-
-	id, err := chain_selectors.GetChainIDFromSelector(chainSel)
-	if err != nil {
-		return OCRConfig{}, false
-	}
-
-	want, err := chain_selectors.GetChainDetailsByChainIDAndFamily(id, fam)
+	want, err := ChainDetails(chainSel)
 	if err != nil {
 		return OCRConfig{}, false
 	}
@@ -382,7 +475,7 @@ func NodeInfo(nodeIDs []string, oc NodeChainConfigsLister) (Nodes, error) {
 				return nil, fmt.Errorf("unsupported chain type %s", chainConfig.Chain.Type)
 			}
 
-			details, err := chain_selectors.GetChainDetailsByChainIDAndFamily(chainConfig.Chain.Id, family)
+			details, err := ChainDetailsByChainIDAndFamily(chainConfig.Chain.Id, family)
 			if err != nil {
 				return nil, err
 			}
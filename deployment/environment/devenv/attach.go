@@ -0,0 +1,97 @@
+package devenv
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+
+	nodev1 "github.com/smartcontractkit/chainlink-protos/job-distributor/v1/node"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+)
+
+// AttachConfig connects to chains and nodes which are already running, e.g. a persistent shared testnet
+// environment, instead of creating them.
+type AttachConfig struct {
+	Chains            []ChainConfig
+	HomeChainSelector uint64
+	FeedChainSelector uint64
+	// JDConfig connects to the job distributor the nodes are already registered with, its NodeInfo is ignored.
+	JDConfig JDConfig
+	// AddressBookPath is the JSON address book of the contracts deployed to the chains,
+	// see deployment.AddressBookFromJSON.
+	AddressBookPath string
+}
+
+func (c AttachConfig) Validate() error {
+	if len(c.Chains) == 0 {
+		return fmt.Errorf("no chains to attach to")
+	}
+	if c.JDConfig.GRPC == "" {
+		return fmt.Errorf("job distributor GRPC endpoint is required")
+	}
+	if c.AddressBookPath == "" {
+		return fmt.Errorf("address book path is required")
+	}
+	return nil
+}
+
+// AttachEnvironment returns an environment connected to externally run chains and nodes, with the addresses
+// of the contracts already deployed to the chains. The nodes are the ones registered with the job distributor.
+func AttachEnvironment(ctx context.Context, lggr logger.Logger, config AttachConfig) (*deployment.Environment, error) {
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid attach config: %w", err)
+	}
+	data, err := os.ReadFile(config.AddressBookPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read address book: %w", err)
+	}
+	ab, err := deployment.AddressBookFromJSON(data)
+	if err != nil {
+		return nil, err
+	}
+	chains, err := NewChains(lggr, config.Chains)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create chains: %w", err)
+	}
+	for _, sel := range []uint64{config.HomeChainSelector, config.FeedChainSelector} {
+		if _, ok := chains[sel]; !ok {
+			return nil, fmt.Errorf("chain %d not found in chain configs", sel)
+		}
+	}
+	addresses, err := ab.Addresses()
+	if err != nil {
+		return nil, err
+	}
+	for sel := range addresses {
+		if _, ok := chains[sel]; !ok {
+			return nil, fmt.Errorf("address book has addresses on chain %d which is not attached", sel)
+		}
+	}
+
+	config.JDConfig.NodeInfo = nil
+	offChain, err := NewJDClient(ctx, lggr, config.JDConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create JD client: %w", err)
+	}
+	nodes, err := offChain.ListNodes(ctx, &nodev1.ListNodesRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nodes registered with the job distributor: %w", err)
+	}
+	var nodeIDs []string
+	for _, node := range nodes.GetNodes() {
+		nodeIDs = append(nodeIDs, node.Id)
+	}
+	lggr.Infow("Attached to environment", "chains", len(chains), "nodes", len(nodeIDs))
+
+	return deployment.NewEnvironment(
+		DevEnv,
+		lggr,
+		ab,
+		chains,
+		nodeIDs,
+		offChain,
+	), nil
+}
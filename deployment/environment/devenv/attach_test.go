@@ -0,0 +1,26 @@
+package devenv
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/test-go/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+)
+
+func TestAttachEnvironment(t *testing.T) {
+	cfg := AttachConfig{
+		Chains:          []ChainConfig{{ChainID: 1337}},
+		JDConfig:        JDConfig{GRPC: "localhost:14231"},
+		AddressBookPath: filepath.Join(t.TempDir(), "address_book.json"),
+	}
+	require.NoError(t, cfg.Validate())
+	require.Error(t, AttachConfig{Chains: cfg.Chains, AddressBookPath: cfg.AddressBookPath}.Validate())
+	require.Error(t, AttachConfig{Chains: cfg.Chains, JDConfig: cfg.JDConfig}.Validate())
+
+	_, err := AttachEnvironment(context.Background(), logger.Test(t), cfg)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to read address book")
+}
@@ -8,7 +8,6 @@ import (
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
-	chainselectors "github.com/smartcontractkit/chain-selectors"
 
 	"github.com/smartcontractkit/chainlink-common/pkg/logger"
 
@@ -27,12 +26,14 @@ type ChainConfig struct {
 	WSRPCs      []string           // websocket rpcs to connect to the chain
 	HTTPRPCs    []string           // http rpcs to connect to the chain
 	DeployerKey *bind.TransactOpts // key to send transactions to the chain
+	// FinalityDepth optionally sets deployment.Chain.FinalityDepth, for chains without a finalized block tag.
+	FinalityDepth uint32
 }
 
 func NewChains(logger logger.Logger, configs []ChainConfig) (map[uint64]deployment.Chain, error) {
 	chains := make(map[uint64]deployment.Chain)
 	for _, chainCfg := range configs {
-		selector, err := chainselectors.SelectorFromChainId(chainCfg.ChainID)
+		selector, err := deployment.SelectorFromEVMChainID(chainCfg.ChainID)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get selector from chain id %d: %w", chainCfg.ChainID, err)
 		}
@@ -51,9 +52,10 @@ func NewChains(logger logger.Logger, configs []ChainConfig) (map[uint64]deployme
 			return nil, fmt.Errorf("failed to connect to chain %s", chainCfg.ChainName)
 		}
 		chains[selector] = deployment.Chain{
-			Selector:    selector,
-			Client:      ec,
-			DeployerKey: chainCfg.DeployerKey,
+			Selector:      selector,
+			Client:        ec,
+			DeployerKey:   chainCfg.DeployerKey,
+			FinalityDepth: chainCfg.FinalityDepth,
 			Confirm: func(tx *types.Transaction) (uint64, error) {
 				var blockNumber uint64
 				if tx == nil {
@@ -10,9 +10,9 @@ import (
 	"github.com/hashicorp/go-multierror"
 	"github.com/rs/zerolog"
 	"github.com/sethvargo/go-retry"
-	chainsel "github.com/smartcontractkit/chain-selectors"
 
 	nodev1 "github.com/smartcontractkit/chainlink-protos/job-distributor/v1/node"
+	"github.com/smartcontractkit/chainlink/deployment"
 	clclient "github.com/smartcontractkit/chainlink/deployment/environment/nodeclient"
 	"github.com/smartcontractkit/chainlink/deployment/environment/web/sdk/client"
 
@@ -23,6 +23,7 @@ const (
 	NodeLabelKeyType        = "type"
 	NodeLabelValueBootstrap = "bootstrap"
 	NodeLabelValuePlugin    = "plugin"
+	NodeLabelKeyImage       = "image"
 )
 
 // NodeInfo holds the information required to create a node
@@ -34,6 +35,7 @@ type NodeInfo struct {
 	AdminAddr   string                   // admin address to send payments to, applicable only for non-bootstrap nodes
 	MultiAddr   string                   // multi address denoting node's FQN (needed for deriving P2PBootstrappers in OCR), applicable only for bootstrap nodes
 	Labels      map[string]string        // labels to use when registering the node with job distributor
+	Image       NodeImage                // docker image the node runs, if known; registered as a label with job distributor
 }
 
 type DON struct {
@@ -62,6 +64,41 @@ func (don *DON) ReplayAllLogs(blockbyChain map[uint64]uint64) error {
 	return nil
 }
 
+// ReplayLogsInRange triggers a targeted log replay on all nodes
+func (don *DON) ReplayLogsInRange(req deployment.LogReplayRequest) error {
+	for _, node := range don.Nodes {
+		if err := node.ReplayLogsInRange(req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FailingServices queries the health checks of all nodes, and returns their failing services keyed
+// by node ID and service name.
+func (don *DON) FailingServices() (map[string]map[string]string, error) {
+	failing := make(map[string]map[string]string)
+	for _, node := range don.Nodes {
+		services, err := node.FailingServices()
+		if err != nil {
+			return nil, err
+		}
+		if len(services) > 0 {
+			failing[node.NodeId] = services
+		}
+	}
+	return failing, nil
+}
+
+// NodesByImage groups the node names by the docker image they run.
+func (don *DON) NodesByImage() map[NodeImage][]string {
+	nodes := make(map[NodeImage][]string)
+	for _, node := range don.Nodes {
+		nodes[node.Image] = append(nodes[node.Image], node.Name)
+	}
+	return nodes
+}
+
 func (don *DON) NodeIds() []string {
 	var nodeIds []string
 	for _, node := range don.Nodes {
@@ -110,6 +147,13 @@ func NewRegisteredDON(ctx context.Context, nodeInfo []NodeInfo, jd JobDistributo
 				Value: &value,
 			})
 		}
+		if !info.Image.IsEmpty() {
+			node.Image = info.Image
+			node.labels = append(node.labels, &ptypes.Label{
+				Key:   NodeLabelKeyImage,
+				Value: ptr(info.Image.String()),
+			})
+		}
 		if info.IsBootstrap {
 			// create multi address for OCR2, applicable only for bootstrap nodes
 			if info.MultiAddr == "" {
@@ -167,6 +211,7 @@ type Node struct {
 	NodeId      string                    // node id returned by job distributor after node is registered with it
 	JDId        string                    // job distributor id returned by node after Job distributor is created in node
 	Name        string                    // name of the node
+	Image       NodeImage                 // docker image the node runs, empty if unknown
 	AccountAddr map[uint64]string         // chain selector to node's account address mapping for supported chains
 	gqlClient   client.Client             // graphql client to interact with the node
 	restClient  *clclient.ChainlinkClient // rest client to interact with the node
@@ -427,7 +472,7 @@ func (n *Node) ExportEVMKeysForChain(chainId string) ([]*clclient.ExportedEVMKey
 // ReplayLogs replays logs for the chains on the node for given block numbers for each chain
 func (n *Node) ReplayLogs(blockByChain map[uint64]uint64) error {
 	for sel, block := range blockByChain {
-		chainID, err := chainsel.ChainIdFromSelector(sel)
+		chainID, err := deployment.EVMChainID(sel)
 		if err != nil {
 			return err
 		}
@@ -442,6 +487,112 @@ func (n *Node) ReplayLogs(blockByChain map[uint64]uint64) error {
 	return nil
 }
 
+// FailingServices returns the services of the node whose health check is failing, along with their output
+func (n *Node) FailingServices() (map[string]string, error) {
+	health, _, err := n.restClient.Health()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get health of node %s: %w", n.Name, err)
+	}
+	failing := make(map[string]string)
+	for _, check := range health.Data {
+		if check.Attributes.Status != "passing" {
+			failing[check.Attributes.Name] = check.Attributes.Output
+		}
+	}
+	return failing, nil
+}
+
+// ReplayLogsInRange triggers a targeted log replay on the node, see deployment.LogReplayRequest
+func (n *Node) ReplayLogsInRange(req deployment.LogReplayRequest) error {
+	chainID, err := deployment.EVMChainID(req.ChainSelector)
+	if err != nil {
+		return err
+	}
+	response, _, err := n.restClient.ReplayLogPollerRange(clclient.ReplayRangeRequest{
+		FromBlock: int64(req.FromBlock),
+		ToBlock:   int64(req.ToBlock),
+		Addresses: req.Addresses,
+		EventSigs: req.EventSigs,
+	}, int64(chainID))
+	if err != nil {
+		return err
+	}
+	if response.Data.Attributes.Message != "Replay completed" {
+		return fmt.Errorf("unexpected response message from log poller's range replay: %s", response.Data.Attributes.Message)
+	}
+	return nil
+}
+
+// JobPluginConfig reads the effective plugin config of the OCR2 job from the node, see deployment.PluginConfigReader
+func (n *Node) JobPluginConfig(jobID string) (deployment.JobPluginConfig, error) {
+	response, _, err := n.restClient.ReadJobPluginConfig(jobID)
+	if err != nil {
+		return deployment.JobPluginConfig{}, fmt.Errorf("failed to read plugin config of job %s from node %s: %w", jobID, n.Name, err)
+	}
+	attrs := response.Data.Attributes
+	return deployment.JobPluginConfig{
+		PluginType:      attrs.PluginType,
+		Relay:           attrs.Relay,
+		RelayConfig:     attrs.RelayConfig,
+		PluginConfig:    attrs.PluginConfig,
+		ValidationError: attrs.ValidationError,
+	}, nil
+}
+
+// nodeKeys are the keys of a node, as fetched from the node.
+type nodeKeys struct {
+	csaPublicKey string
+	peerID       string
+	// ocr2BundleIDs are the IDs of the OCR2 key bundles by chain type, e.g. EVM
+	ocr2BundleIDs map[string]string
+}
+
+// fetchKeys fetches the CSA and P2P keys of the node and its OCR2 key bundles.
+func (n *Node) fetchKeys(ctx context.Context) (nodeKeys, error) {
+	csaKey, err := n.gqlClient.FetchCSAPublicKey(ctx)
+	if err != nil {
+		return nodeKeys{}, fmt.Errorf("failed to fetch CSA public key of node %s: %w", n.Name, err)
+	}
+	peerID, err := n.gqlClient.FetchP2PPeerID(ctx)
+	if err != nil {
+		return nodeKeys{}, fmt.Errorf("failed to fetch peer id of node %s: %w", n.Name, err)
+	}
+	keys := nodeKeys{csaPublicKey: value(csaKey), peerID: value(peerID), ocr2BundleIDs: make(map[string]string)}
+	for _, chainType := range []string{"EVM", "APTOS", "SOLANA", "STARKNET"} {
+		// nodes only have the bundles of the chain types they enable
+		if bundleID, err := n.gqlClient.FetchOCR2KeyBundleID(ctx, chainType); err == nil {
+			keys.ocr2BundleIDs[chainType] = bundleID
+		}
+	}
+	return keys, nil
+}
+
+// staleNodeInfo returns how the node and the chain configs the job distributor has differ from the keys of the node.
+func staleNodeInfo(keys nodeKeys, jdNode *nodev1.Node, chainConfigs []*nodev1.ChainConfig) []string {
+	var stale []string
+	if jdNode.GetPublicKey() != keys.csaPublicKey {
+		stale = append(stale, fmt.Sprintf("CSA public key is %s, the job distributor has %s", keys.csaPublicKey, jdNode.GetPublicKey()))
+	}
+	for _, cfg := range chainConfigs {
+		ocr2 := cfg.GetOcr2Config()
+		if !ocr2.GetEnabled() {
+			continue
+		}
+		chain := fmt.Sprintf("%s chain %s", cfg.GetChain().GetType(), cfg.GetChain().GetId())
+		if peerID := ocr2.GetP2PKeyBundle().GetPeerId(); strings.TrimPrefix(peerID, "p2p_") != strings.TrimPrefix(keys.peerID, "p2p_") {
+			stale = append(stale, fmt.Sprintf("peer id is %s, the job distributor has %s for %s", keys.peerID, peerID, chain))
+		}
+		if ocr2.GetIsBootstrap() {
+			continue
+		}
+		chainType := strings.TrimPrefix(cfg.GetChain().GetType().String(), "CHAIN_TYPE_")
+		if bundleID := ocr2.GetOcrKeyBundle().GetBundleId(); bundleID != keys.ocr2BundleIDs[chainType] {
+			stale = append(stale, fmt.Sprintf("OCR2 key bundle is %q, the job distributor has %q for %s", keys.ocr2BundleIDs[chainType], bundleID, chain))
+		}
+	}
+	return stale
+}
+
 func ptr[T any](v T) *T {
 	return &v
 }
@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/test-go/testify/require"
+
+	nodev1 "github.com/smartcontractkit/chainlink-protos/job-distributor/v1/node"
 )
 
 func TestPtrVal(t *testing.T) {
@@ -17,3 +19,23 @@ func TestPtrVal(t *testing.T) {
 	got = value(y)
 	require.Equal(t, "", got)
 }
+
+func TestStaleNodeInfo(t *testing.T) {
+	keys := nodeKeys{csaPublicKey: "csa", peerID: "p2p_12D3Koo", ocr2BundleIDs: map[string]string{"EVM": "bundle"}}
+	jdNode := &nodev1.Node{PublicKey: "csa"}
+	chainConfig := func(peerID, bundleID string) *nodev1.ChainConfig {
+		return &nodev1.ChainConfig{
+			Chain: &nodev1.Chain{Id: "1337", Type: nodev1.ChainType_CHAIN_TYPE_EVM},
+			Ocr2Config: &nodev1.OCR2Config{
+				Enabled:      true,
+				P2PKeyBundle: &nodev1.OCR2Config_P2PKeyBundle{PeerId: peerID},
+				OcrKeyBundle: &nodev1.OCR2Config_OCRKeyBundle{BundleId: bundleID},
+			},
+		}
+	}
+
+	require.Empty(t, staleNodeInfo(keys, jdNode, []*nodev1.ChainConfig{chainConfig("12D3Koo", "bundle")}))
+	// the keys were rotated on the node after its chain config was pushed
+	require.Len(t, staleNodeInfo(keys, jdNode, []*nodev1.ChainConfig{chainConfig("p2p_old", "old")}), 2)
+	require.Len(t, staleNodeInfo(keys, &nodev1.Node{PublicKey: "old"}, nil), 1)
+}
@@ -18,6 +18,14 @@ type EnvironmentConfig struct {
 	HomeChainSelector uint64
 	FeedChainSelector uint64
 	JDConfig          JDConfig
+	// NodeImages optionally assigns Docker images to the nodes, see ImageMatrix.
+	NodeImages *ImageMatrix
+	// TxSimulation optionally simulates every transaction before it is sent, see deployment.SimulateTransactions.
+	// deployment.TraceCallSimulator traces the calls on chains with the debug namespace enabled, e.g. local geth nodes.
+	TxSimulation *deployment.TxSimulationConfig
+	// TrackNonces optionally tracks the nonces of the deployer keys locally, for RPCs lagging behind
+	// the transactions sent to them, see deployment.TrackNonces.
+	TrackNonces bool
 }
 
 func NewEnvironment(ctx context.Context, lggr logger.Logger, config EnvironmentConfig) (*deployment.Environment, *DON, error) {
@@ -25,7 +33,15 @@ func NewEnvironment(ctx context.Context, lggr logger.Logger, config EnvironmentC
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to create chains: %w", err)
 	}
-	offChain, err := NewJDClient(ctx, config.JDConfig)
+	if config.TxSimulation != nil {
+		if err := deployment.SimulateTransactions(lggr, chains, *config.TxSimulation); err != nil {
+			return nil, nil, fmt.Errorf("failed to enable transaction simulation: %w", err)
+		}
+	}
+	if config.TrackNonces {
+		deployment.TrackNonces(lggr, chains)
+	}
+	offChain, err := NewJDClient(ctx, lggr, config.JDConfig)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to create JD client: %w", err)
 	}
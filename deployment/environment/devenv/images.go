@@ -0,0 +1,63 @@
+package devenv
+
+import (
+	"fmt"
+)
+
+// NodeImage is the Docker image a Chainlink node runs.
+type NodeImage struct {
+	Image   string
+	Version string
+}
+
+func (i NodeImage) IsEmpty() bool {
+	return i.Image == "" && i.Version == ""
+}
+
+func (i NodeImage) String() string {
+	return fmt.Sprintf("%s:%s", i.Image, i.Version)
+}
+
+// ImageMatrix assigns Docker images to the nodes of a DON. It is used to run DONs mixing
+// several Chainlink versions, e.g. to test compatibility during rolling upgrades.
+type ImageMatrix struct {
+	// Default is the image of bootstrap nodes, and of plugin nodes when Versions is empty.
+	Default NodeImage
+	// Versions are spread across the plugin nodes in contiguous groups of (almost) equal size,
+	// e.g. two versions run half of the DON each.
+	Versions []NodeImage
+	// Overrides pin the image of individual nodes, keyed by node name.
+	Overrides map[string]NodeImage
+}
+
+func (m ImageMatrix) Validate() error {
+	if m.Default.Image == "" || m.Default.Version == "" {
+		return fmt.Errorf("default image and version must be set")
+	}
+	for i, img := range m.Versions {
+		if img.Version == "" {
+			return fmt.Errorf("version %d of the image matrix must be set", i)
+		}
+	}
+	for name, img := range m.Overrides {
+		if img.Version == "" {
+			return fmt.Errorf("version of the image override for node %s must be set", name)
+		}
+	}
+	return nil
+}
+
+// ImageForNode returns the image of the named node. For plugin nodes, pluginIdx is the position of the
+// node among the pluginCount plugin nodes of the DON. Images without a repository use the default one.
+func (m ImageMatrix) ImageForNode(name string, isBootstrap bool, pluginIdx, pluginCount int) NodeImage {
+	img := m.Default
+	if override, ok := m.Overrides[name]; ok {
+		img = override
+	} else if !isBootstrap && len(m.Versions) > 0 && pluginCount > 0 {
+		img = m.Versions[pluginIdx*len(m.Versions)/pluginCount]
+	}
+	if img.Image == "" {
+		img.Image = m.Default.Image
+	}
+	return img
+}
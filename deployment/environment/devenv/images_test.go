@@ -0,0 +1,30 @@
+package devenv
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/test-go/testify/require"
+)
+
+func TestImageMatrix(t *testing.T) {
+	v18 := NodeImage{Image: "chainlink", Version: "2.18.0"}
+	v19 := NodeImage{Version: "2.19.0"}
+	m := ImageMatrix{
+		Default:   v18,
+		Versions:  []NodeImage{v18, v19},
+		Overrides: map[string]NodeImage{"node-4": {Image: "custom", Version: "dev"}},
+	}
+	require.NoError(t, m.Validate())
+
+	var got []string
+	for i := 0; i < 4; i++ {
+		got = append(got, m.ImageForNode(fmt.Sprintf("node-%d", i+1), false, i, 4).String())
+	}
+	require.Equal(t, []string{"chainlink:2.18.0", "chainlink:2.18.0", "chainlink:2.19.0", "custom:dev"}, got)
+	require.Equal(t, v18, m.ImageForNode("bootstrap-1", true, 0, 4))
+
+	require.Equal(t, v18, ImageMatrix{Default: v18}.ImageForNode("node-1", false, 0, 4))
+	require.Error(t, ImageMatrix{}.Validate())
+	require.Error(t, ImageMatrix{Default: v18, Versions: []NodeImage{{}}}.Validate())
+}
@@ -2,6 +2,7 @@ package devenv
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"golang.org/x/oauth2"
@@ -9,10 +10,12 @@ import (
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/metadata"
 
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
 	csav1 "github.com/smartcontractkit/chainlink-protos/job-distributor/v1/csa"
 	jobv1 "github.com/smartcontractkit/chainlink-protos/job-distributor/v1/job"
 	nodev1 "github.com/smartcontractkit/chainlink-protos/job-distributor/v1/node"
 	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/deployment/environment/jobspec"
 )
 
 type JDConfig struct {
@@ -65,10 +68,11 @@ type JobDistributor struct {
 	nodev1.NodeServiceClient
 	jobv1.JobServiceClient
 	csav1.CSAServiceClient
-	don *DON
+	don  *DON
+	lggr logger.Logger
 }
 
-func NewJDClient(ctx context.Context, cfg JDConfig) (deployment.OffchainClient, error) {
+func NewJDClient(ctx context.Context, lggr logger.Logger, cfg JDConfig) (deployment.OffchainClient, error) {
 	conn, err := NewJDConnection(cfg)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect Job Distributor service. Err: %w", err)
@@ -78,6 +82,7 @@ func NewJDClient(ctx context.Context, cfg JDConfig) (deployment.OffchainClient,
 		NodeServiceClient: nodev1.NewNodeServiceClient(conn),
 		JobServiceClient:  jobv1.NewJobServiceClient(conn),
 		CSAServiceClient:  csav1.NewCSAServiceClient(conn),
+		lggr:              lggr,
 	}
 	if cfg.NodeInfo != nil && len(cfg.NodeInfo) > 0 {
 		jd.don, err = NewRegisteredDON(ctx, cfg.NodeInfo, *jd)
@@ -101,11 +106,89 @@ func (jd JobDistributor) GetCSAPublicKey(ctx context.Context) (string, error) {
 }
 
 func (jd JobDistributor) ReplayLogs(selectorToBlock map[uint64]uint64) error {
+	if jd.don == nil {
+		return fmt.Errorf("no nodes registered with the job distributor")
+	}
 	return jd.don.ReplayAllLogs(selectorToBlock)
 }
 
+// ReplayLogsInRange implements deployment.LogReplayer
+func (jd JobDistributor) ReplayLogsInRange(_ context.Context, req deployment.LogReplayRequest) error {
+	if jd.don == nil {
+		return fmt.Errorf("no nodes registered with the job distributor")
+	}
+	return jd.don.ReplayLogsInRange(req)
+}
+
+// FailingServices implements deployment.HealthReporter
+func (jd JobDistributor) FailingServices(_ context.Context) (map[string]map[string]string, error) {
+	if jd.don == nil {
+		return nil, fmt.Errorf("no nodes registered with the job distributor")
+	}
+	return jd.don.FailingServices()
+}
+
+// JobPluginConfig implements deployment.PluginConfigReader
+func (jd JobDistributor) JobPluginConfig(_ context.Context, nodeID string, jobID string) (deployment.JobPluginConfig, error) {
+	if jd.don == nil {
+		return deployment.JobPluginConfig{}, fmt.Errorf("no nodes registered with the job distributor")
+	}
+	for _, node := range jd.don.Nodes {
+		if node.NodeId == nodeID {
+			return node.JobPluginConfig(jobID)
+		}
+	}
+	return deployment.JobPluginConfig{}, fmt.Errorf("node id not found: %s", nodeID)
+}
+
+// NodeChainConfigs returns the chain configs the node pushed to the job distributor, which deployment.NodeInfo
+// reads the OCR configs of the node from.
+func (jd JobDistributor) NodeChainConfigs(ctx context.Context, nodeID string) ([]*nodev1.ChainConfig, error) {
+	res, err := jd.ListNodeChainConfigs(ctx, &nodev1.ListNodeChainConfigsRequest{
+		Filter: &nodev1.ListNodeChainConfigsRequest_Filter{NodeIds: []string{nodeID}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list chain configs of node %s: %w", nodeID, err)
+	}
+	return res.GetChainConfigs(), nil
+}
+
+// VerifyNodeInfo re-fetches the CSA, P2P and OCR2 keys of the nodes of the DON and checks that the job distributor
+// has the same ones, i.e. that deployment.NodeInfo is up to date with the nodes.
+func (jd JobDistributor) VerifyNodeInfo(ctx context.Context) error {
+	if jd.don == nil {
+		return fmt.Errorf("no nodes registered with the job distributor")
+	}
+	var errs []error
+	for _, node := range jd.don.Nodes {
+		keys, err := node.fetchKeys(ctx)
+		if err != nil {
+			return err
+		}
+		res, err := jd.GetNode(ctx, &nodev1.GetNodeRequest{Id: node.NodeId})
+		if err != nil {
+			return fmt.Errorf("failed to get node %s: %w", node.Name, err)
+		}
+		chainConfigs, err := jd.NodeChainConfigs(ctx, node.NodeId)
+		if err != nil {
+			return err
+		}
+		for _, stale := range staleNodeInfo(keys, res.GetNode(), chainConfigs) {
+			errs = append(errs, fmt.Errorf("node %s: %s", node.Name, stale))
+		}
+	}
+	return errors.Join(errs...)
+}
+
 // ProposeJob proposes jobs through the jobService and accepts the proposed job on selected node based on ProposeJobRequest.NodeId
 func (jd JobDistributor) ProposeJob(ctx context.Context, in *jobv1.ProposeJobRequest, opts ...grpc.CallOption) (*jobv1.ProposeJobResponse, error) {
+	diagnostics := jobspec.Lint(in.Spec)
+	if err := diagnostics.Err(); err != nil {
+		return nil, err
+	}
+	for _, warning := range diagnostics.Warnings() {
+		jd.lggr.Warnw("Proposed job spec has a lint warning", "nodeID", in.NodeId, "warning", warning.String())
+	}
 	res, err := jd.JobServiceClient.ProposeJob(ctx, in, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to propose job. err: %w", err)
@@ -0,0 +1,271 @@
+package devenv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	tc "github.com/testcontainers/testcontainers-go"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+)
+
+const (
+	// ReaperOwnerLabel labels the containers tracked by a reaper with "<hostname>:<pid>" of the test process,
+	// so that ReapOrphans can find the containers of test processes which did not tear them down.
+	ReaperOwnerLabel = "devenv.reaper.owner"
+	// ReaperCreatedLabel labels the containers tracked by a reaper with the unix time they were created.
+	ReaperCreatedLabel = "devenv.reaper.created"
+
+	// DefaultReaperGrace is how long before the deadline of the test its reaper tears the environment down.
+	DefaultReaperGrace = 2 * time.Minute
+	// reaperTeardownTimeout bounds the teardown of each resource.
+	reaperTeardownTimeout = 30 * time.Second
+)
+
+var reapers sync.Map // *testing.T -> *Reaper
+
+type reaperResource struct {
+	name     string
+	teardown func(ctx context.Context) error
+}
+
+// Reaper tracks the resources created for a test environment, e.g. containers, mock servers, goroutines and
+// temp dirs, and tears them down in reverse order once the test completes, including when it panics, or before
+// the deadline of the test, since cleanups don't run when go test times out.
+type Reaper struct {
+	lggr logger.Logger
+
+	mu        sync.Mutex
+	resources []reaperResource
+	done      bool
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	timer  *time.Timer
+}
+
+// ReaperFor returns the reaper of the test, creating it on first use, so that every component of the
+// environment of the test registers its resources with the same reaper.
+func ReaperFor(t *testing.T, lggr logger.Logger) *Reaper {
+	if r, ok := reapers.Load(t); ok {
+		return r.(*Reaper)
+	}
+	r, loaded := reapers.LoadOrStore(t, newReaper(lggr))
+	reaper := r.(*Reaper)
+	if loaded {
+		return reaper
+	}
+	t.Cleanup(func() {
+		reapers.Delete(t)
+		if err := reaper.Teardown(context.Background()); err != nil {
+			t.Errorf("failed to tear down environment: %v", err)
+		}
+	})
+	if deadline, ok := t.Deadline(); ok {
+		reaper.teardownAt(deadline.Add(-DefaultReaperGrace))
+	}
+	return reaper
+}
+
+func newReaper(lggr logger.Logger) *Reaper {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Reaper{lggr: lggr, ctx: ctx, cancel: cancel}
+}
+
+// teardownAt tears the resources down at the given time if they still are.
+func (r *Reaper) teardownAt(at time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.timer = time.AfterFunc(time.Until(at), func() {
+		r.lggr.Warnw("Test deadline approaching, tearing down environment", "deadline", at)
+		if err := r.Teardown(context.Background()); err != nil {
+			r.lggr.Errorw("Failed to tear down environment", "err", err)
+		}
+	})
+}
+
+// Track registers a resource to tear down. Resources tracked once the reaper is done are torn down immediately.
+func (r *Reaper) Track(name string, teardown func(ctx context.Context) error) {
+	r.mu.Lock()
+	if !r.done {
+		r.resources = append(r.resources, reaperResource{name: name, teardown: teardown})
+		r.mu.Unlock()
+		return
+	}
+	r.mu.Unlock()
+	if err := r.teardown(reaperResource{name: name, teardown: teardown}); err != nil {
+		r.lggr.Errorw("Failed to tear down resource tracked after teardown", "resource", name, "err", err)
+	}
+}
+
+// TrackContainer terminates the container on teardown.
+func (r *Reaper) TrackContainer(c tc.Container) {
+	r.Track("container "+c.GetContainerID(), func(ctx context.Context) error {
+		return c.Terminate(ctx)
+	})
+}
+
+// TrackServer closes the mock server on teardown.
+func (r *Reaper) TrackServer(s *httptest.Server) {
+	r.Track("server "+s.URL, func(context.Context) error {
+		s.Close()
+		return nil
+	})
+}
+
+// TrackTempDir removes the directory on teardown.
+func (r *Reaper) TrackTempDir(dir string) {
+	r.Track("dir "+dir, func(context.Context) error {
+		return os.RemoveAll(dir)
+	})
+}
+
+// Go runs fn in a goroutine, whose context is canceled on teardown. Teardown waits for the goroutine to return.
+func (r *Reaper) Go(name string, fn func(ctx context.Context)) {
+	r.mu.Lock()
+	if r.done {
+		r.mu.Unlock()
+		r.lggr.Warnw("Not starting goroutine of torn down environment", "goroutine", name)
+		return
+	}
+	r.wg.Add(1)
+	r.mu.Unlock()
+	go func() {
+		defer r.wg.Done()
+		fn(r.ctx)
+	}()
+}
+
+// Labels returns the labels to set on the containers of the environment, so that ReapOrphans finds
+// them if the test process dies before tearing them down.
+func (r *Reaper) Labels() map[string]string {
+	return map[string]string{
+		ReaperOwnerLabel:   reaperOwner(),
+		ReaperCreatedLabel: strconv.FormatInt(time.Now().Unix(), 10),
+	}
+}
+
+// Teardown cancels the goroutines and tears the resources down in reverse order, once.
+func (r *Reaper) Teardown(ctx context.Context) error {
+	r.mu.Lock()
+	if r.done {
+		r.mu.Unlock()
+		return nil
+	}
+	r.done = true
+	resources := r.resources
+	r.resources = nil
+	if r.timer != nil {
+		r.timer.Stop()
+	}
+	r.mu.Unlock()
+
+	r.cancel()
+	goroutinesDone := make(chan struct{})
+	go func() {
+		r.wg.Wait()
+		close(goroutinesDone)
+	}()
+	var errs error
+	select {
+	case <-goroutinesDone:
+	case <-time.After(reaperTeardownTimeout):
+		errs = errors.Join(errs, fmt.Errorf("goroutines did not return within %s", reaperTeardownTimeout))
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	for i := len(resources) - 1; i >= 0; i-- {
+		errs = errors.Join(errs, r.teardown(resources[i]))
+	}
+	return errs
+}
+
+func (r *Reaper) teardown(res reaperResource) error {
+	ctx, cancel := context.WithTimeout(context.Background(), reaperTeardownTimeout)
+	defer cancel()
+	if err := res.teardown(ctx); err != nil {
+		return fmt.Errorf("failed to tear down %s: %w", res.name, err)
+	}
+	r.lggr.Debugw("Tore down resource", "resource", res.name)
+	return nil
+}
+
+func reaperOwner() string {
+	hostname, _ := os.Hostname()
+	return fmt.Sprintf("%s:%d", hostname, os.Getpid())
+}
+
+// isOrphan returns whether a container with the reaper labels is left over by a test process,
+// i.e. its owner process on this host is gone or it is older than maxAge.
+func isOrphan(labels map[string]string, hostname string, now time.Time, maxAge time.Duration, alive func(pid int) bool) bool {
+	if created, err := strconv.ParseInt(labels[ReaperCreatedLabel], 10, 64); err == nil && maxAge > 0 &&
+		now.Sub(time.Unix(created, 0)) > maxAge {
+		return true
+	}
+	host, pid, ok := strings.Cut(labels[ReaperOwnerLabel], ":")
+	if !ok || host != hostname {
+		// owned by another host, which reaps its own containers
+		return false
+	}
+	p, err := strconv.Atoi(pid)
+	return err == nil && !alive(p)
+}
+
+func processAlive(pid int) bool {
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	return signalAlive(process.Signal(syscall.Signal(0)))
+}
+
+// signalAlive returns whether the process signaled with signal 0 exists: EPERM means it does but belongs
+// to another user, e.g. a test process of another CI job on the same host.
+func signalAlive(err error) bool {
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
+
+// ReapOrphans is a janitor removing the containers of devenv environments which were not torn down,
+// e.g. because their test process was killed: the containers of processes no longer running on this host,
+// and the containers older than maxAge if set. It returns the IDs of the removed containers.
+func ReapOrphans(ctx context.Context, lggr logger.Logger, maxAge time.Duration) ([]string, error) {
+	cli, err := tc.NewDockerClientWithOpts(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create docker client: %w", err)
+	}
+	defer cli.Close()
+	containers, err := cli.ContainerList(ctx, container.ListOptions{
+		All:     true,
+		Filters: filters.NewArgs(filters.Arg("label", ReaperOwnerLabel)),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list containers: %w", err)
+	}
+	hostname, _ := os.Hostname()
+	var removed []string
+	var errs error
+	for _, c := range containers {
+		if !isOrphan(c.Labels, hostname, time.Now(), maxAge, processAlive) {
+			continue
+		}
+		if err := cli.ContainerRemove(ctx, c.ID, container.RemoveOptions{Force: true, RemoveVolumes: true}); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("failed to remove container %s: %w", c.ID, err))
+			continue
+		}
+		lggr.Infow("Removed orphaned container", "id", c.ID, "names", c.Names, "owner", c.Labels[ReaperOwnerLabel])
+		removed = append(removed, c.ID)
+	}
+	return removed, errs
+}
@@ -0,0 +1,103 @@
+package devenv
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/test-go/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+)
+
+func TestReaper(t *testing.T) {
+	r := newReaper(logger.Test(t))
+	var order []string
+	track := func(name string) {
+		r.Track(name, func(context.Context) error {
+			order = append(order, name)
+			return nil
+		})
+	}
+	track("first")
+	track("second")
+
+	dir := t.TempDir()
+	r.TrackTempDir(dir)
+	server := httptest.NewServer(http.NotFoundHandler())
+	r.TrackServer(server)
+	stopped := make(chan struct{})
+	r.Go("worker", func(ctx context.Context) {
+		<-ctx.Done()
+		close(stopped)
+	})
+
+	require.NoError(t, r.Teardown(context.Background()))
+	require.Equal(t, []string{"second", "first"}, order)
+	<-stopped
+	_, err := os.Stat(dir)
+	require.True(t, os.IsNotExist(err))
+	_, err = http.Get(server.URL) //nolint:noctx // the server is closed
+	require.Error(t, err)
+
+	// teardown happens once, later resources are torn down right away
+	require.NoError(t, r.Teardown(context.Background()))
+	track("late")
+	require.Equal(t, []string{"second", "first", "late"}, order)
+}
+
+func TestReaperDeadline(t *testing.T) {
+	r := newReaper(logger.Test(t))
+	tornDown := make(chan struct{})
+	r.Track("resource", func(context.Context) error {
+		close(tornDown)
+		return nil
+	})
+	r.teardownAt(time.Now().Add(10 * time.Millisecond))
+	select {
+	case <-tornDown:
+	case <-time.After(5 * time.Second):
+		t.Fatal("resource was not torn down before the deadline")
+	}
+}
+
+func TestReaperFor(t *testing.T) {
+	var reaper *Reaper
+	t.Run("test", func(t *testing.T) {
+		reaper = ReaperFor(t, logger.Test(t))
+		require.True(t, reaper == ReaperFor(t, logger.Test(t)))
+		labels := reaper.Labels()
+		require.Equal(t, reaperOwner(), labels[ReaperOwnerLabel])
+		require.NotEmpty(t, labels[ReaperCreatedLabel])
+	})
+	// the reaper is torn down with its test
+	reaper.mu.Lock()
+	defer reaper.mu.Unlock()
+	require.True(t, reaper.done)
+}
+
+func TestIsOrphan(t *testing.T) {
+	now := time.Now()
+	alive := func(pid int) bool { return pid == 1 }
+	labels := func(owner string, created time.Time) map[string]string {
+		return map[string]string{ReaperOwnerLabel: owner, ReaperCreatedLabel: strconv.FormatInt(created.Unix(), 10)}
+	}
+	require.False(t, isOrphan(labels("host:1", now), "host", now, time.Hour, alive))
+	require.True(t, isOrphan(labels("host:2", now), "host", now, time.Hour, alive), "owner process is gone")
+	require.False(t, isOrphan(labels("other:2", now), "host", now, time.Hour, alive), "owned by another host")
+	require.True(t, isOrphan(labels("other:1", now.Add(-2*time.Hour)), "host", now, time.Hour, alive), "too old")
+	require.False(t, isOrphan(labels("host:1", now.Add(-2*time.Hour)), "host", now, 0, alive), "no max age")
+}
+
+func TestProcessAlive(t *testing.T) {
+	require.True(t, processAlive(os.Getpid()))
+	require.True(t, signalAlive(nil))
+	require.True(t, signalAlive(syscall.EPERM), "process of another user")
+	require.False(t, signalAlive(syscall.ESRCH))
+	require.False(t, signalAlive(os.ErrProcessDone))
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/testcontainers/testcontainers-go/exec"
 	tcwait "github.com/testcontainers/testcontainers-go/wait"
 
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
 	"github.com/smartcontractkit/chainlink-testing-framework/lib/docker"
 	"github.com/smartcontractkit/chainlink-testing-framework/lib/docker/test_env"
 	"github.com/smartcontractkit/chainlink-testing-framework/lib/logging"
@@ -113,15 +114,20 @@ func (proxy *RageProxy) Start(t *testing.T, lggr zerolog.Logger, networks []stri
 	}
 
 	l := tc.Logger
+	var reaper *Reaper
+	var labels map[string]string
 	if t != nil {
 		l = logging.CustomT{
 			T: t,
 			L: lggr,
 		}
+		reaper = ReaperFor(t, logger.Test(t))
+		labels = reaper.Labels()
 	}
 	container, err := docker.StartContainerWithRetry(lggr, tc.GenericContainerRequest{
 		ContainerRequest: tc.ContainerRequest{
 			Name:     proxy.ContainerName,
+			Labels:   labels,
 			Networks: networks,
 			Image:    fmt.Sprintf("%s:%s", proxy.ContainerImage, proxy.ContainerVersion),
 			Env: map[string]string{
@@ -158,6 +164,9 @@ func (proxy *RageProxy) Start(t *testing.T, lggr zerolog.Logger, networks []stri
 	if err != nil {
 		return nil, err
 	}
+	if reaper != nil {
+		reaper.TrackContainer(container)
+	}
 	_, reader, err := container.Exec(context.Background(), []string{
 		"cat", ProxyKeyStore}, exec.Multiplexed())
 	if err != nil {
@@ -247,15 +256,23 @@ func (rmn *AFN2Proxy) Start(t *testing.T, lggr zerolog.Logger, reuse bool, netwo
 	}
 
 	l := tc.Logger
+	var reaper *Reaper
+	var labels map[string]string
 	if t != nil {
 		l = logging.CustomT{
 			T: t,
 			L: lggr,
 		}
+		// reused containers outlive the test
+		if !reuse {
+			reaper = ReaperFor(t, logger.Test(t))
+			labels = reaper.Labels()
+		}
 	}
 	container, err := docker.StartContainerWithRetry(lggr, tc.GenericContainerRequest{
 		ContainerRequest: tc.ContainerRequest{
 			Name:     rmn.ContainerName,
+			Labels:   labels,
 			Networks: networks,
 			Image:    fmt.Sprintf("%s:%s", rmn.ContainerImage, rmn.ContainerVersion),
 			Env: map[string]string{
@@ -289,6 +306,9 @@ func (rmn *AFN2Proxy) Start(t *testing.T, lggr zerolog.Logger, reuse bool, netwo
 	if err != nil {
 		return nil, err
 	}
+	if reaper != nil {
+		reaper.TrackContainer(container)
+	}
 	_, reader, err := container.Exec(context.Background(), []string{
 		"cat", RMNKeyStore}, exec.Multiplexed())
 	if err != nil {
@@ -0,0 +1,102 @@
+package devenv
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	clclient "github.com/smartcontractkit/chainlink/deployment/environment/nodeclient"
+)
+
+// SweepConfig returns the funds left over by a test run on public testnets to a treasury.
+type SweepConfig struct {
+	Treasury common.Address
+	// Chains are the selectors of the chains to sweep, e.g. the public testnets of the environment.
+	Chains []uint64
+	// Tokens are the tokens to sweep per chain selector, e.g. LINK.
+	Tokens map[uint64][]common.Address
+}
+
+func (c SweepConfig) Validate(e deployment.Environment) error {
+	if c.Treasury == (common.Address{}) {
+		return fmt.Errorf("treasury address is required")
+	}
+	for _, sel := range c.Chains {
+		if _, ok := e.Chains[sel]; !ok {
+			return fmt.Errorf("chain %d not found in environment", sel)
+		}
+	}
+	return nil
+}
+
+// SweepFunds returns the tokens and native balances of the transmitters of the nodes of the DON, if any,
+// then of the deployer keys to the treasury. Every account is swept even if others fail.
+func SweepFunds(ctx context.Context, lggr logger.Logger, e deployment.Environment, don *DON, cfg SweepConfig) ([]deployment.SweptFunds, error) {
+	if err := cfg.Validate(e); err != nil {
+		return nil, fmt.Errorf("invalid sweep config: %w", err)
+	}
+	var swept []deployment.SweptFunds
+	var errs error
+	for _, sel := range cfg.Chains {
+		chain := e.Chains[sel]
+		chainID, err := deployment.EVMChainID(sel)
+		if err != nil {
+			errs = errors.Join(errs, err)
+			continue
+		}
+		var accounts []*bind.TransactOpts
+		if don != nil {
+			for _, node := range don.Nodes {
+				keys, err := node.transmitterKeys(chainID)
+				if err != nil {
+					errs = errors.Join(errs, fmt.Errorf("failed to export keys of node %s: %w", node.Name, err))
+					continue
+				}
+				accounts = append(accounts, keys...)
+			}
+		}
+		// the deployer goes last, it may have paid for the transfers of the other accounts
+		accounts = append(accounts, chain.DeployerKey)
+		for _, account := range accounts {
+			funds, err := deployment.SweepAccount(ctx, lggr, chain, account, cfg.Treasury, cfg.Tokens[sel])
+			errs = errors.Join(errs, err)
+			swept = append(swept, funds)
+		}
+	}
+	return swept, errs
+}
+
+// transmitterKeys exports the EVM keys of the node for the chain.
+func (n *Node) transmitterKeys(chainID uint64) ([]*bind.TransactOpts, error) {
+	exported, err := n.ExportEVMKeysForChain(strconv.FormatUint(chainID, 10))
+	if err != nil {
+		return nil, err
+	}
+	var keys []*bind.TransactOpts
+	for _, key := range exported {
+		encrypted, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		decrypted, err := keystore.DecryptKey(encrypted, clclient.ChainlinkKeyPassword)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt key %s: %w", key.Address, err)
+		}
+		opts, err := bind.NewKeyedTransactorWithChainID(decrypted.PrivateKey, new(big.Int).SetUint64(chainID))
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, opts)
+	}
+	return keys, nil
+}
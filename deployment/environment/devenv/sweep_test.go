@@ -0,0 +1,65 @@
+package devenv_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/test-go/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/deployment/environment/devenv"
+	"github.com/smartcontractkit/chainlink/deployment/environment/memory"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/shared/generated/burn_mint_erc677"
+)
+
+func TestSweepFunds(t *testing.T) {
+	ctx := context.Background()
+	lggr := logger.Test(t)
+	chains := memory.NewMemoryChains(t, 1)
+	e := deployment.Environment{Chains: chains}
+	var sel uint64
+	for s := range chains {
+		sel = s
+	}
+	chain := chains[sel]
+	deployer := chain.DeployerKey.From
+
+	_, tx, token, err := burn_mint_erc677.DeployBurnMintERC677(chain.DeployerKey, chain.Client, "LINK", "LINK", 18, big.NewInt(0))
+	_, err = deployment.ConfirmIfNoError(chain, tx, err)
+	require.NoError(t, err)
+	tx, err = token.GrantMintRole(chain.DeployerKey, deployer)
+	_, err = deployment.ConfirmIfNoError(chain, tx, err)
+	require.NoError(t, err)
+	tx, err = token.Mint(chain.DeployerKey, deployer, big.NewInt(1e18))
+	_, err = deployment.ConfirmIfNoError(chain, tx, err)
+	require.NoError(t, err)
+
+	treasury := common.HexToAddress("0x1234")
+	cfg := devenv.SweepConfig{
+		Treasury: treasury,
+		Chains:   []uint64{sel},
+		Tokens:   map[uint64][]common.Address{sel: {token.Address()}},
+	}
+	require.Error(t, devenv.SweepConfig{Chains: cfg.Chains}.Validate(e))
+	swept, err := devenv.SweepFunds(ctx, lggr, e, nil, cfg)
+	require.NoError(t, err)
+	require.Len(t, swept, 1)
+	require.Equal(t, deployer, swept[0].Account)
+	require.Equal(t, big.NewInt(1e18), swept[0].Tokens[token.Address()])
+
+	tokenBalance, err := token.BalanceOf(&bind.CallOpts{Context: ctx}, treasury)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(1e18), tokenBalance)
+	nativeBalance, err := chain.Client.BalanceAt(ctx, treasury, nil)
+	require.NoError(t, err)
+	require.Equal(t, swept[0].Native, nativeBalance)
+	require.Equal(t, 1, nativeBalance.Sign())
+	leftover, err := chain.Client.BalanceAt(ctx, deployer, nil)
+	require.NoError(t, err)
+	require.Equal(t, -1, leftover.Cmp(big.NewInt(1e18)), "the deployer keeps the gas fee margin only")
+}
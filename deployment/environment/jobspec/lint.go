@@ -0,0 +1,286 @@
+// Package jobspec lints job specs before they are proposed to nodes, so that malformed specs are reported by the
+// offchain clients with the offending fields instead of failing when the node validates or runs the job.
+package jobspec
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pelletier/go-toml/v2"
+
+	"github.com/smartcontractkit/chainlink/v2/core/capabilities/ccip/validate"
+	"github.com/smartcontractkit/chainlink/v2/core/services/job"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/chaintype"
+	"github.com/smartcontractkit/chainlink/v2/core/services/ocrbootstrap"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
+	"github.com/smartcontractkit/chainlink/v2/core/services/vrf/vrfcommon"
+)
+
+type Severity string
+
+const (
+	// SeverityError diagnostics make the spec invalid.
+	SeverityError Severity = "error"
+	// SeverityWarning diagnostics are likely mistakes the node accepts.
+	SeverityWarning Severity = "warning"
+)
+
+// Diagnostic is an issue of a spec found by Lint.
+type Diagnostic struct {
+	Severity Severity
+	// Field is the path of the offending field, e.g. relayConfig.chainID, empty for the spec as a whole.
+	Field string
+	// Line is the line of the issue in the spec, 0 if unknown.
+	Line    int
+	Message string
+}
+
+func (d Diagnostic) String() string {
+	var sb strings.Builder
+	sb.WriteString(string(d.Severity))
+	if d.Line > 0 {
+		fmt.Fprintf(&sb, " at line %d", d.Line)
+	}
+	if d.Field != "" {
+		fmt.Fprintf(&sb, " in %s", d.Field)
+	}
+	sb.WriteString(": ")
+	sb.WriteString(d.Message)
+	return sb.String()
+}
+
+type Diagnostics []Diagnostic
+
+// Errors returns the diagnostics of SeverityError.
+func (ds Diagnostics) Errors() Diagnostics {
+	var errs Diagnostics
+	for _, d := range ds {
+		if d.Severity == SeverityError {
+			errs = append(errs, d)
+		}
+	}
+	return errs
+}
+
+// Warnings returns the diagnostics of SeverityWarning.
+func (ds Diagnostics) Warnings() Diagnostics {
+	var warnings Diagnostics
+	for _, d := range ds {
+		if d.Severity == SeverityWarning {
+			warnings = append(warnings, d)
+		}
+	}
+	return warnings
+}
+
+// Err returns a *LintError of the error diagnostics, nil if there are none.
+func (ds Diagnostics) Err() error {
+	errs := ds.Errors()
+	if len(errs) == 0 {
+		return nil
+	}
+	return &LintError{Diagnostics: errs}
+}
+
+// LintError is the error of a spec with error diagnostics.
+type LintError struct {
+	Diagnostics Diagnostics
+}
+
+func (e *LintError) Error() string {
+	msgs := make([]string, len(e.Diagnostics))
+	for i, d := range e.Diagnostics {
+		msgs[i] = d.String()
+	}
+	return "invalid job spec: " + strings.Join(msgs, "; ")
+}
+
+// pipelineFields are the fields holding pipelines, by path.
+var pipelineFields = []string{
+	"observationSource",
+	"pluginConfig.juelsPerFeeCoinSource",
+	"pluginConfig.gasPriceSubunitsSource",
+	"pluginConfig.tokenPricesUSDPipeline",
+}
+
+// addressFields are the fields holding EVM contract addresses, by job type.
+var addressFields = map[string][]string{
+	"offchainreporting":  {"contractAddress"},
+	"offchainreporting2": {"contractID"},
+	"bootstrap":          {"contractID"},
+	"fluxmonitor":        {"contractAddress"},
+	"directrequest":      {"contractAddress"},
+	"keeper":             {"contractAddress"},
+	"vrf":                {"coordinatorAddress"},
+}
+
+// nodeValidators are the validators the node runs on the specs of the job types, for the job types the node
+// validates without its config.
+var nodeValidators = map[string]func(spec string) (job.Job, error){
+	"bootstrap": ocrbootstrap.ValidatedBootstrapSpecToml,
+	"ccip":      validate.ValidatedCCIPSpec,
+	"vrf":       vrfcommon.ValidatedVRFSpec,
+}
+
+// Lint parses the TOML spec and checks its pipelines are valid DAGs, the relay config of OCR2 specs, the OCR key
+// bundles and bootstrappers of CCIP specs and the EIP-55 checksum of its EVM contract addresses. Specs of the job
+// types of nodeValidators are also validated as the node validates them.
+func Lint(spec string) Diagnostics {
+	var tree map[string]any
+	if err := toml.Unmarshal([]byte(spec), &tree); err != nil {
+		d := Diagnostic{Severity: SeverityError, Message: err.Error()}
+		var decodeErr *toml.DecodeError
+		if errors.As(err, &decodeErr) {
+			d.Line, _ = decodeErr.Position()
+		}
+		return Diagnostics{d}
+	}
+
+	var ds Diagnostics
+	jobType, _ := tree["type"].(string)
+	if jobType == "" {
+		ds = append(ds, Diagnostic{Severity: SeverityError, Field: "type", Message: "must be set"})
+	}
+
+	for _, field := range pipelineFields {
+		source, _ := lookup(tree, field).(string)
+		if strings.TrimSpace(source) == "" {
+			continue
+		}
+		if _, err := pipeline.Parse(source); err != nil {
+			ds = append(ds, Diagnostic{Severity: SeverityError, Field: field, Message: err.Error()})
+		}
+	}
+
+	if validator, ok := nodeValidators[jobType]; ok {
+		if _, err := validator(spec); err != nil {
+			ds = append(ds, Diagnostic{Severity: SeverityError, Message: err.Error()})
+		}
+	}
+
+	relay, _ := tree["relay"].(string)
+	switch jobType {
+	case "offchainreporting2":
+		// the node validates OCR2 specs against its config, the relay is checked here
+		ds = append(ds, lintRelay(tree, relay)...)
+		if pluginType, _ := tree["pluginType"].(string); pluginType == "" {
+			ds = append(ds, Diagnostic{Severity: SeverityError, Field: "pluginType", Message: "must be set"})
+		}
+	case "ccip":
+		ds = append(ds, lintCCIP(tree)...)
+	}
+
+	if relay == "" || relay == "evm" {
+		for _, field := range addressFields[jobType] {
+			if d, ok := lintAddress(tree, field); !ok {
+				ds = append(ds, d)
+			}
+		}
+	}
+	return ds
+}
+
+func lintRelay(tree map[string]any, relay string) Diagnostics {
+	if relay == "" {
+		return Diagnostics{{Severity: SeverityError, Field: "relay", Message: "must be set"}}
+	}
+	var ds Diagnostics
+	if contractID, _ := tree["contractID"].(string); contractID == "" {
+		ds = append(ds, Diagnostic{Severity: SeverityError, Field: "contractID", Message: "must be set"})
+	}
+	relayConfig, ok := tree["relayConfig"].(map[string]any)
+	if !ok {
+		return append(ds, Diagnostic{Severity: SeverityError, Field: "relayConfig", Message: "must be set"})
+	}
+	switch chainID := relayConfig["chainID"].(type) {
+	case nil:
+		ds = append(ds, Diagnostic{Severity: SeverityError, Field: "relayConfig.chainID", Message: "must be set"})
+	case string:
+		if chainID == "" {
+			ds = append(ds, Diagnostic{Severity: SeverityError, Field: "relayConfig.chainID", Message: "must not be empty"})
+		}
+	case int64:
+		if relay == "evm" && chainID <= 0 {
+			ds = append(ds, Diagnostic{Severity: SeverityError, Field: "relayConfig.chainID", Message: fmt.Sprintf("must be positive, got %d", chainID)})
+		}
+	default:
+		ds = append(ds, Diagnostic{Severity: SeverityError, Field: "relayConfig.chainID", Message: fmt.Sprintf("must be a string or an integer, got %T", chainID)})
+	}
+	return ds
+}
+
+// lintCCIP checks the OCR key bundles of a CCIP spec are set for supported chain families, including the families
+// of its relay configs, and that it has bootstrappers.
+func lintCCIP(tree map[string]any) Diagnostics {
+	var ds Diagnostics
+	bundles, _ := tree["ocrKeyBundleIDs"].(map[string]any)
+	if len(bundles) == 0 {
+		ds = append(ds, Diagnostic{Severity: SeverityError, Field: "ocrKeyBundleIDs", Message: "must be set"})
+	}
+	families := make([]string, 0, len(bundles))
+	for family := range bundles {
+		families = append(families, family)
+	}
+	slices.Sort(families)
+	for _, family := range families {
+		field := "ocrKeyBundleIDs." + family
+		if !chaintype.IsSupportedChainType(chaintype.ChainType(family)) {
+			ds = append(ds, Diagnostic{Severity: SeverityError, Field: field, Message: chaintype.NewErrInvalidChainType(chaintype.ChainType(family)).Error()})
+		}
+		if id, _ := bundles[family].(string); id == "" {
+			ds = append(ds, Diagnostic{Severity: SeverityError, Field: field, Message: "must be a key bundle ID"})
+		}
+	}
+	relayConfigs, _ := tree["relayConfigs"].(map[string]any)
+	relayFamilies := make([]string, 0, len(relayConfigs))
+	for family := range relayConfigs {
+		relayFamilies = append(relayFamilies, family)
+	}
+	slices.Sort(relayFamilies)
+	for _, family := range relayFamilies {
+		if _, ok := bundles[family]; !ok && len(bundles) > 0 {
+			ds = append(ds, Diagnostic{Severity: SeverityWarning, Field: "relayConfigs." + family, Message: "has no OCR key bundle in ocrKeyBundleIDs"})
+		}
+	}
+	if bootstrappers, _ := tree["p2pV2Bootstrappers"].([]any); len(bootstrappers) == 0 {
+		ds = append(ds, Diagnostic{Severity: SeverityWarning, Field: "p2pV2Bootstrappers", Message: "is empty, the node only reaches the other nodes of its DONs if it is a bootstrap node"})
+	}
+	return ds
+}
+
+// lintAddress checks the address of the field, if set, is a hex address with a valid checksum.
+func lintAddress(tree map[string]any, field string) (Diagnostic, bool) {
+	address, _ := tree[field].(string)
+	if address == "" {
+		return Diagnostic{}, true
+	}
+	if !common.IsHexAddress(address) {
+		return Diagnostic{Severity: SeverityError, Field: field, Message: fmt.Sprintf("%q is not a hex address", address)}, false
+	}
+	checksummed := common.HexToAddress(address).Hex()
+	hex := strings.TrimPrefix(strings.TrimPrefix(address, "0x"), "0X")
+	switch {
+	case "0x"+hex == checksummed:
+		return Diagnostic{}, true
+	case hex == strings.ToLower(hex) || hex == strings.ToUpper(hex):
+		return Diagnostic{Severity: SeverityWarning, Field: field, Message: fmt.Sprintf("%s is not checksummed, expected %s", address, checksummed)}, false
+	default:
+		return Diagnostic{Severity: SeverityError, Field: field, Message: fmt.Sprintf("%s has an invalid checksum, expected %s", address, checksummed)}, false
+	}
+}
+
+// lookup returns the value of the dotted path in the tree, nil if it is not set.
+func lookup(tree map[string]any, path string) any {
+	keys := strings.Split(path, ".")
+	for _, key := range keys[:len(keys)-1] {
+		sub, ok := tree[key].(map[string]any)
+		if !ok {
+			return nil
+		}
+		tree = sub
+	}
+	return tree[keys[len(keys)-1]]
+}
@@ -0,0 +1,214 @@
+package jobspec
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const validSpec = `type = "offchainreporting2"
+pluginType = "median"
+relay = "evm"
+schemaVersion = 1
+contractID = "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"
+observationSource = """
+ds1 [type=bridge name=voter_turnout];
+ds1_parse [type=jsonparse path="one,two"];
+ds1 -> ds1_parse;
+"""
+
+[relayConfig]
+chainID = 1337
+`
+
+const validCCIPSpec = `type = "ccip"
+schemaVersion = 1
+capabilityVersion = "v1.0.0"
+capabilityLabelledName = "ccip"
+p2pKeyID = "p2p_key"
+p2pV2Bootstrappers = ["12D3KooWHfYFQ8hGttAYbMCevQVESEQhzJAqFZokMVtom8bNxwGq@127.0.0.1:5001"]
+
+[ocrKeyBundleIDs]
+evm = "evm_bundle"
+
+[relayConfigs.evm.chainReader]
+contracts = {}
+
+[pluginConfig]
+tokenPricesUSDPipeline = """
+link [type=memo value="1000000000000000000"];
+"""
+`
+
+func TestLint(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		spec string
+		want Diagnostics
+	}{
+		{
+			name: "valid",
+			spec: validSpec,
+		},
+		{
+			name: "malformed TOML",
+			spec: "type = \"bootstrap\"\nrelay = \n",
+			want: Diagnostics{{Severity: SeverityError, Line: 2}},
+		},
+		{
+			name: "cyclic pipeline",
+			spec: `type = "offchainreporting2"
+pluginType = "functions"
+relay = "evm"
+contractID = "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"
+observationSource = """
+ds1 [type=bridge name=voter_turnout];
+ds2 [type=bridge name=voter_turnout];
+ds1 -> ds2 -> ds1;
+"""
+
+[relayConfig]
+chainID = 1337
+`,
+			want: Diagnostics{{Severity: SeverityError, Field: "observationSource"}},
+		},
+		{
+			name: "bootstrap spec rejected by the node",
+			spec: `type = "bootstrap"
+schemaVersion = 1
+relay = "evm"
+contractID = "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"
+`,
+			want: Diagnostics{{Severity: SeverityError, Message: "missing required key relayConfig"}},
+		},
+		{
+			name: "missing chain ID and plugin type",
+			spec: `type = "offchainreporting2"
+relay = "evm"
+contractID = "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"
+
+[relayConfig]
+fromBlock = 1
+`,
+			want: Diagnostics{
+				{Severity: SeverityError, Field: "relayConfig.chainID", Message: "must be set"},
+				{Severity: SeverityError, Field: "pluginType", Message: "must be set"},
+			},
+		},
+		{
+			name: "invalid checksum",
+			spec: `type = "bootstrap"
+schemaVersion = 1
+relay = "evm"
+contractID = "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAeD"
+
+[relayConfig]
+chainID = 1337
+`,
+			want: Diagnostics{{Severity: SeverityError, Field: "contractID", Message: "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAeD has an invalid checksum, expected 0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"}},
+		},
+		{
+			name: "not checksummed",
+			spec: `type = "bootstrap"
+schemaVersion = 1
+relay = "evm"
+contractID = "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed"
+
+[relayConfig]
+chainID = 1337
+`,
+			want: Diagnostics{{Severity: SeverityWarning, Field: "contractID", Message: "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed is not checksummed, expected 0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"}},
+		},
+		{
+			name: "non-EVM address",
+			spec: `type = "bootstrap"
+schemaVersion = 1
+relay = "solana"
+contractID = "6UmMZr5MEqiKWD5jqTJd1WCR5kT8oZuFYBLJFi1o6GQX"
+
+[relayConfig]
+chainID = "devnet"
+`,
+		},
+		{
+			name: "ccip",
+			spec: validCCIPSpec,
+		},
+		{
+			name: "ccip spec rejected by the node",
+			spec: strings.Replace(validCCIPSpec, `p2pKeyID = "p2p_key"`, "", 1),
+			want: Diagnostics{{Severity: SeverityError, Message: "p2pKeyID must be set"}},
+		},
+		{
+			name: "ccip key bundles",
+			spec: `type = "ccip"
+schemaVersion = 1
+capabilityVersion = "v1.0.0"
+capabilityLabelledName = "ccip"
+p2pKeyID = "p2p_key"
+
+[ocrKeyBundleIDs]
+evm = ""
+bitcoin = "btc_bundle"
+
+[relayConfigs.solana]
+chainID = "devnet"
+`,
+			want: Diagnostics{
+				{Severity: SeverityError, Field: "ocrKeyBundleIDs.bitcoin"},
+				{Severity: SeverityError, Field: "ocrKeyBundleIDs.evm", Message: "must be a key bundle ID"},
+				{Severity: SeverityWarning, Field: "relayConfigs.solana"},
+				{Severity: SeverityWarning, Field: "p2pV2Bootstrappers"},
+			},
+		},
+		{
+			name: "ccip without key bundles",
+			spec: `type = "ccip"
+schemaVersion = 1
+capabilityVersion = "v1.0.0"
+capabilityLabelledName = "ccip"
+p2pKeyID = "p2p_key"
+p2pV2Bootstrappers = ["12D3KooWHfYFQ8hGttAYbMCevQVESEQhzJAqFZokMVtom8bNxwGq@127.0.0.1:5001"]
+`,
+			want: Diagnostics{{Severity: SeverityError, Field: "ocrKeyBundleIDs", Message: "must be set"}},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := Lint(tc.spec)
+			require.Len(t, got, len(tc.want), got)
+			for i, want := range tc.want {
+				require.Equal(t, want.Severity, got[i].Severity)
+				require.Equal(t, want.Field, got[i].Field)
+				if want.Line != 0 {
+					require.Equal(t, want.Line, got[i].Line)
+				}
+				if want.Message != "" {
+					require.Equal(t, want.Message, got[i].Message)
+				}
+			}
+		})
+	}
+}
+
+func TestDiagnostics_Warnings(t *testing.T) {
+	ds := Lint(strings.Replace(validCCIPSpec, `p2pV2Bootstrappers = ["12D3KooWHfYFQ8hGttAYbMCevQVESEQhzJAqFZokMVtom8bNxwGq@127.0.0.1:5001"]`, "", 1))
+	require.NoError(t, ds.Err())
+	require.Len(t, ds.Warnings(), 1)
+	require.Equal(t, "p2pV2Bootstrappers", ds.Warnings()[0].Field)
+}
+
+func TestDiagnostics_Err(t *testing.T) {
+	require.NoError(t, Lint(validSpec).Err())
+	require.NoError(t, Diagnostics{{Severity: SeverityWarning, Field: "contractID", Message: "not checksummed"}}.Err())
+
+	err := Diagnostics{
+		{Severity: SeverityWarning, Field: "contractID", Message: "not checksummed"},
+		{Severity: SeverityError, Field: "relay", Message: "must be set"},
+		{Severity: SeverityError, Line: 3, Message: "invalid TOML"},
+	}.Err()
+	var lintErr *LintError
+	require.ErrorAs(t, err, &lintErr)
+	require.Len(t, lintErr.Diagnostics, 2)
+	require.EqualError(t, err, "invalid job spec: error in relay: must be set; error at line 3: invalid TOML")
+}
@@ -0,0 +1,199 @@
+package memory
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+	"testing"
+
+	chainsel "github.com/smartcontractkit/chain-selectors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+)
+
+const (
+	// aptosLocalnetChainID is the chain id of Aptos local nodes, the simulated chains use the following ones.
+	aptosLocalnetChainID = 4
+	// aptosLocalnetSelector is the selector of the first simulated Aptos chain, which is unknown to chain-selectors.
+	aptosLocalnetSelector uint64 = 12463857294658392847
+)
+
+// AptosViewFunc implements a view function of the simulated chains.
+type AptosViewFunc func(args [][]byte) ([]json.RawMessage, error)
+
+// SimAptosClient is an in-memory Aptos chain implementing deployment.AptosClient. Packages are published to
+// their resource accounts and entry function calls to published modules or to the framework succeed and are recorded,
+// while calls to modules which were not published fail like on a real chain. Multisig transactions are
+// executed right away, whatever their approvals, and the sequence numbers of multisig_account follow the
+// created transactions. No Move code is executed, use NewAptosLocalnetChain to test against a chain which does.
+type SimAptosClient struct {
+	deployer deployment.AptosAddress
+
+	mu       sync.Mutex
+	version  uint64
+	packages map[deployment.AptosAddress][][]byte
+	calls    []deployment.AptosEntryFunction
+	txs      map[string]deployment.AptosTransaction
+	views    map[string]AptosViewFunc
+	// multisigTxs is the number of transactions created for each multisig account
+	multisigTxs map[deployment.AptosAddress]uint64
+}
+
+var _ deployment.AptosClient = (*SimAptosClient)(nil)
+
+func NewSimAptosClient(deployer deployment.AptosAddress) *SimAptosClient {
+	return &SimAptosClient{
+		deployer: deployer,
+		packages: make(map[deployment.AptosAddress][][]byte),
+		txs:      make(map[string]deployment.AptosTransaction),
+		views:    make(map[string]AptosViewFunc),
+
+		multisigTxs: make(map[deployment.AptosAddress]uint64),
+	}
+}
+
+func (c *SimAptosClient) PublishPackage(_ context.Context, seed []byte, _ []byte, modules [][]byte) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	addr := deployment.AptosResourceAccountAddress(c.deployer, seed)
+	if _, ok := c.packages[addr]; ok {
+		return c.commit(false, "EOBJECT_EXISTS"), nil
+	}
+	c.packages[addr] = modules
+	return c.commit(true, "Executed successfully"), nil
+}
+
+func (c *SimAptosClient) SubmitEntryFunction(_ context.Context, fn deployment.AptosEntryFunction) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.call(fn), nil
+}
+
+func (c *SimAptosClient) SubmitMultisigTransaction(_ context.Context, _ deployment.AptosAddress, fn deployment.AptosEntryFunction) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.call(fn), nil
+}
+
+func (c *SimAptosClient) WaitForTransaction(_ context.Context, txHash string) (deployment.AptosTransaction, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	tx, ok := c.txs[txHash]
+	if !ok {
+		return deployment.AptosTransaction{}, fmt.Errorf("transaction %s not found", txHash)
+	}
+	return tx, nil
+}
+
+func (c *SimAptosClient) View(_ context.Context, fn deployment.AptosEntryFunction) ([]json.RawMessage, error) {
+	c.mu.Lock()
+	view, ok := c.views[fn.String()]
+	if multisig, isMultisig := multisigCall(fn, "next_sequence_number"); !ok && isMultisig {
+		next := c.multisigTxs[multisig] + 1
+		c.mu.Unlock()
+		return []json.RawMessage{json.RawMessage(strconv.Quote(strconv.FormatUint(next, 10)))}, nil
+	}
+	c.mu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("view function %s not found", fn)
+	}
+	return view(fn.Args)
+}
+
+// RegisterView implements the view function of a module, whatever its module address.
+func (c *SimAptosClient) RegisterView(module deployment.AptosAddress, moduleName, function string, view AptosViewFunc) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.views[deployment.AptosEntryFunction{Module: module, ModuleName: moduleName, Function: function}.String()] = view
+}
+
+// Published returns whether a package was published at the address.
+func (c *SimAptosClient) Published(addr deployment.AptosAddress) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, ok := c.packages[addr]
+	return ok
+}
+
+// Calls returns the successful calls to the entry functions of the module, in order.
+func (c *SimAptosClient) Calls(module deployment.AptosAddress, moduleName, function string) []deployment.AptosEntryFunction {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var calls []deployment.AptosEntryFunction
+	for _, call := range c.calls {
+		if call.Module == module && call.ModuleName == moduleName && call.Function == function {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+func (c *SimAptosClient) call(fn deployment.AptosEntryFunction) string {
+	if _, ok := c.packages[fn.Module]; !ok && fn.Module != deployment.AptosFrameworkAddress {
+		return c.commit(false, "LINKER_ERROR")
+	}
+	if multisig, ok := multisigCall(fn, "create_transaction"); ok {
+		c.multisigTxs[multisig]++
+	}
+	c.calls = append(c.calls, fn)
+	return c.commit(true, "Executed successfully")
+}
+
+// multisigCall returns the multisig account of the call if it calls the function of the multisig_account
+// module of the framework.
+func multisigCall(fn deployment.AptosEntryFunction, function string) (deployment.AptosAddress, bool) {
+	if fn.Module != deployment.AptosFrameworkAddress || fn.ModuleName != "multisig_account" || fn.Function != function ||
+		len(fn.Args) == 0 || len(fn.Args[0]) != len(deployment.AptosAddress{}) {
+		return deployment.AptosAddress{}, false
+	}
+	return deployment.AptosAddress(fn.Args[0]), true
+}
+
+func (c *SimAptosClient) commit(success bool, vmStatus string) string {
+	hash := make([]byte, 32)
+	_, _ = rand.Read(hash)
+	c.version++
+	tx := deployment.AptosTransaction{
+		Hash:     "0x" + hex.EncodeToString(hash),
+		Version:  c.version,
+		Success:  success,
+		VMStatus: vmStatus,
+	}
+	c.txs[tx.Hash] = tx
+	return tx.Hash
+}
+
+// NewMemoryAptosChains returns simulated Aptos chains, see SimAptosClient. The chains are registered as
+// custom chains, with the chain ids following the one of Aptos local nodes.
+func NewMemoryAptosChains(t *testing.T, numChains int) map[uint64]deployment.AptosChain {
+	chains := make(map[uint64]deployment.AptosChain)
+	for i := 0; i < numChains; i++ {
+		sel := aptosLocalnetSelector + uint64(i)
+		require.NoError(t, deployment.RegisterCustomChains(deployment.CustomChain{
+			Selector: sel,
+			ChainID:  strconv.Itoa(aptosLocalnetChainID + i),
+			Family:   chainsel.FamilyAptos,
+			Name:     fmt.Sprintf("aptos-localnet-%d", i+1),
+		}))
+		var deployer deployment.AptosAddress
+		_, err := rand.Read(deployer[:])
+		require.NoError(t, err)
+		chains[sel] = deployment.AptosChain{
+			Selector:        sel,
+			Client:          NewSimAptosClient(deployer),
+			DeployerAddress: deployer,
+		}
+	}
+	return chains
+}
+
+// AsSimAptosClient returns the simulated chain of a memory Aptos chain.
+func AsSimAptosClient(chain deployment.AptosChain) (*SimAptosClient, bool) {
+	c, ok := deployment.UnwrapAptosClient(chain.Client).(*SimAptosClient)
+	return c, ok
+}
@@ -0,0 +1,112 @@
+package memory
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	chainsel "github.com/smartcontractkit/chain-selectors"
+	"github.com/stretchr/testify/require"
+	tc "github.com/testcontainers/testcontainers-go"
+	tcwait "github.com/testcontainers/testcontainers-go/wait"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/deployment/environment/devenv"
+)
+
+const (
+	// AptosLocalnetURLEnv is the URL of the REST API of a running Aptos local node, e.g. http://127.0.0.1:8080/v1.
+	// If unset, NewAptosLocalnetChain starts a local node in a container.
+	AptosLocalnetURLEnv = "APTOS_LOCALNET_URL"
+	// AptosLocalnetFaucetURLEnv is the URL of the faucet of the local node of AptosLocalnetURLEnv.
+	AptosLocalnetFaucetURLEnv = "APTOS_LOCALNET_FAUCET_URL"
+	// AptosLocalnetImage is the image of the Aptos CLI the local node is run with.
+	AptosLocalnetImage = "aptoslabs/tools:nightly"
+
+	aptosLocalnetFunding = 1_000_000_000 // octas
+	aptosLocalnetTimeout = 2 * time.Minute
+)
+
+// NewAptosLocalnetChain returns an Aptos chain backed by an Aptos local node, whose deployer is a new account
+// funded by the faucet of the node. The node is the one of AptosLocalnetURLEnv, or else a node started in a
+// container for the test. Unlike the simulated chains, the Move code of the published packages is executed.
+func NewAptosLocalnetChain(t *testing.T) deployment.AptosChain {
+	ctx := context.Background()
+	nodeURL, faucetURL := os.Getenv(AptosLocalnetURLEnv), os.Getenv(AptosLocalnetFaucetURLEnv)
+	if nodeURL == "" {
+		nodeURL, faucetURL = startAptosLocalnet(t)
+	}
+	require.NotEmpty(t, faucetURL, "%s must be set with %s", AptosLocalnetFaucetURLEnv, AptosLocalnetURLEnv)
+
+	require.NoError(t, deployment.RegisterCustomChains(deployment.CustomChain{
+		Selector: aptosLocalnetSelector,
+		ChainID:  strconv.Itoa(aptosLocalnetChainID),
+		Family:   chainsel.FamilyAptos,
+		Name:     "aptos-localnet-1",
+	}))
+	_, key, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	client := deployment.NewRESTAptosClient(nodeURL, key)
+	fundAptosAccount(t, ctx, client, faucetURL, aptosLocalnetFunding)
+	return deployment.AptosChain{
+		Selector:        aptosLocalnetSelector,
+		Client:          client,
+		DeployerAddress: client.Address(),
+	}
+}
+
+// startAptosLocalnet runs an Aptos local node with its faucet for the duration of the test and returns their URLs.
+func startAptosLocalnet(t *testing.T) (string, string) {
+	ctx := context.Background()
+	reaper := devenv.ReaperFor(t, logger.Test(t))
+	container, err := tc.GenericContainer(ctx, tc.GenericContainerRequest{
+		ContainerRequest: tc.ContainerRequest{
+			Image:        AptosLocalnetImage,
+			Labels:       reaper.Labels(),
+			Cmd:          []string{"aptos", "node", "run-local-testnet", "--force-restart", "--assume-yes", "--bind-to", "0.0.0.0", "--no-txn-stream"},
+			ExposedPorts: []string{"8080/tcp", "8081/tcp"},
+			WaitingFor: tcwait.ForAll(
+				tcwait.ForHTTP("/v1").WithPort("8080/tcp"),
+				tcwait.ForHTTP("/").WithPort("8081/tcp"),
+			),
+		},
+		Started: true,
+	})
+	require.NoError(t, err)
+	reaper.TrackContainer(container)
+	host, err := container.Host(ctx)
+	require.NoError(t, err)
+	nodePort, err := container.MappedPort(ctx, "8080/tcp")
+	require.NoError(t, err)
+	faucetPort, err := container.MappedPort(ctx, "8081/tcp")
+	require.NoError(t, err)
+	return fmt.Sprintf("http://%s:%s/v1", host, nodePort.Port()), fmt.Sprintf("http://%s:%s", host, faucetPort.Port())
+}
+
+// fundAptosAccount mints octas to the account of the client with the faucet and waits for them to be committed.
+func fundAptosAccount(t *testing.T, ctx context.Context, client *deployment.RESTAptosClient, faucetURL string, amount uint64) {
+	ctx, cancel := context.WithTimeout(ctx, aptosLocalnetTimeout)
+	defer cancel()
+	url := fmt.Sprintf("%s/mint?amount=%d&address=%s", faucetURL, amount, client.Address())
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, nil)
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode, "failed to fund %s", client.Address())
+	var txHashes []string
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&txHashes))
+	for _, txHash := range txHashes {
+		tx, err := client.WaitForTransaction(ctx, txHash)
+		require.NoError(t, err)
+		require.True(t, tx.Success, "funding tx %s failed: %s", txHash, tx.VMStatus)
+	}
+}
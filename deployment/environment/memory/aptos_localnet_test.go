@@ -0,0 +1,60 @@
+package memory
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+)
+
+func TestAptosLocalnet(t *testing.T) {
+	if testing.Short() {
+		t.Skip("starts an Aptos local node")
+	}
+	ctx := context.Background()
+	chain := NewAptosLocalnetChain(t)
+	framework := func(module, function string, args ...[]byte) deployment.AptosEntryFunction {
+		return deployment.AptosEntryFunction{Module: deployment.AptosFrameworkAddress, ModuleName: module, Function: function, Args: args}
+	}
+
+	// calls are executed by the Move VM, calling a module which doesn't exist fails
+	_, err := deployment.ExecuteAptosEntryFunction(chain, framework("no_such_module", "f"))
+	require.Error(t, err)
+
+	values, err := chain.Client.View(ctx, framework("multisig_account", "get_next_multisig_account_address", chain.DeployerAddress[:]))
+	require.NoError(t, err)
+	require.Len(t, values, 1)
+	var s string
+	require.NoError(t, json.Unmarshal(values[0], &s))
+	multisig, err := deployment.ParseAptosAddress(s)
+	require.NoError(t, err)
+	// a multisig account of the deployer alone, funded to pay for its transactions
+	_, err = deployment.ExecuteAptosEntryFunction(chain, framework("multisig_account", "create",
+		deployment.BCSU64(1), deployment.BCSBytesVector(nil), deployment.BCSBytesVector(nil)))
+	require.NoError(t, err)
+	_, err = deployment.ExecuteAptosEntryFunction(chain, framework("aptos_account", "transfer", multisig[:], deployment.BCSU64(10_000_000)))
+	require.NoError(t, err)
+
+	var recipient deployment.AptosAddress
+	_, err = rand.Read(recipient[:])
+	require.NoError(t, err)
+	exists := func() bool {
+		values, err := chain.Client.View(ctx, framework("account", "exists_at", recipient[:]))
+		require.NoError(t, err)
+		var exists bool
+		require.NoError(t, json.Unmarshal(values[0], &exists))
+		return exists
+	}
+	require.False(t, exists())
+	txHash, err := deployment.AptosMultisig{Chain: chain, Multisig: multisig}.ExecuteBatch(ctx, deployment.MultisigBatch{
+		ChainSelector: chain.Selector,
+		AptosCalls:    []deployment.AptosEntryFunction{framework("aptos_account", "transfer", recipient[:], deployment.BCSU64(1))},
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, txHash)
+	require.True(t, exists())
+}
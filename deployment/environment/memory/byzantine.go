@@ -0,0 +1,193 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient/simulated"
+
+	ocrtypes "github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+
+	evmtypes "github.com/smartcontractkit/chainlink/v2/core/chains/evm/types"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/chaintype"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/ocr2key"
+)
+
+// ByzantineBehavior is a fault injected in a memory node, to verify the fault tolerance of its DON.
+type ByzantineBehavior string
+
+const (
+	// ByzantineWrongSignatures nodes sign a tampered report instead of the report, so their signatures
+	// are well formed but invalid.
+	ByzantineWrongSignatures ByzantineBehavior = "wrong_signatures"
+	// ByzantineSilent nodes fail to sign anything with their OCR keys, so they never contribute
+	// observations, reports or signatures to their DON.
+	ByzantineSilent ByzantineBehavior = "silent"
+	// ByzantineDoubleTransmit nodes send every transaction twice, the copy with the next nonce.
+	// The next transaction of the node itself is then rejected, like a transmission lost by a faulty node.
+	ByzantineDoubleTransmit ByzantineBehavior = "double_transmit"
+)
+
+var errByzantineSilent = errors.New("byzantine node is silent")
+
+// ByzantineBehaviors are the faults injected in a node, see NodeOptions.Byzantine.
+type ByzantineBehaviors []ByzantineBehavior
+
+func (b ByzantineBehaviors) Validate() error {
+	for _, behavior := range b {
+		switch behavior {
+		case ByzantineWrongSignatures, ByzantineSilent, ByzantineDoubleTransmit:
+		default:
+			return fmt.Errorf("unknown byzantine behavior %q", behavior)
+		}
+	}
+	if b.Has(ByzantineSilent) && b.Has(ByzantineWrongSignatures) {
+		return fmt.Errorf("byzantine nodes can't be both %s and %s", ByzantineSilent, ByzantineWrongSignatures)
+	}
+	return nil
+}
+
+// Has returns whether the behavior is injected.
+func (b ByzantineBehaviors) Has(behavior ByzantineBehavior) bool {
+	return slices.Contains(b, behavior)
+}
+
+// byzantineKeyStore is the keystore of a byzantine node, its OCR key bundles misbehave.
+type byzantineKeyStore struct {
+	keystore.Master
+	behaviors ByzantineBehaviors
+}
+
+func (k byzantineKeyStore) OCR2() keystore.OCR2 {
+	return byzantineOCR2{OCR2: k.Master.OCR2(), behaviors: k.behaviors}
+}
+
+type byzantineOCR2 struct {
+	keystore.OCR2
+	behaviors ByzantineBehaviors
+}
+
+func (k byzantineOCR2) Get(id string) (ocr2key.KeyBundle, error) {
+	kb, err := k.OCR2.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	return byzantineKeyBundle{KeyBundle: kb, behaviors: k.behaviors}, nil
+}
+
+func (k byzantineOCR2) GetAll() ([]ocr2key.KeyBundle, error) {
+	kbs, err := k.OCR2.GetAll()
+	return k.wrap(kbs), err
+}
+
+func (k byzantineOCR2) GetAllOfType(chainType chaintype.ChainType) ([]ocr2key.KeyBundle, error) {
+	kbs, err := k.OCR2.GetAllOfType(chainType)
+	return k.wrap(kbs), err
+}
+
+func (k byzantineOCR2) wrap(kbs []ocr2key.KeyBundle) []ocr2key.KeyBundle {
+	wrapped := make([]ocr2key.KeyBundle, len(kbs))
+	for i, kb := range kbs {
+		wrapped[i] = byzantineKeyBundle{KeyBundle: kb, behaviors: k.behaviors}
+	}
+	return wrapped
+}
+
+type byzantineKeyBundle struct {
+	ocr2key.KeyBundle
+	behaviors ByzantineBehaviors
+}
+
+// tamper returns a copy of the report differing in its last byte.
+func tamper(report []byte) []byte {
+	tampered := slices.Clone(report)
+	if len(tampered) == 0 {
+		return []byte{1}
+	}
+	tampered[len(tampered)-1] ^= 0xff
+	return tampered
+}
+
+func (kb byzantineKeyBundle) Sign(reportCtx ocrtypes.ReportContext, report ocrtypes.Report) ([]byte, error) {
+	if kb.behaviors.Has(ByzantineSilent) {
+		return nil, errByzantineSilent
+	}
+	if kb.behaviors.Has(ByzantineWrongSignatures) {
+		report = tamper(report)
+	}
+	return kb.KeyBundle.Sign(reportCtx, report)
+}
+
+func (kb byzantineKeyBundle) Sign3(digest ocrtypes.ConfigDigest, seqNr uint64, report ocrtypes.Report) ([]byte, error) {
+	if kb.behaviors.Has(ByzantineSilent) {
+		return nil, errByzantineSilent
+	}
+	if kb.behaviors.Has(ByzantineWrongSignatures) {
+		report = tamper(report)
+	}
+	return kb.KeyBundle.Sign3(digest, seqNr, report)
+}
+
+func (kb byzantineKeyBundle) OffchainSign(msg []byte) ([]byte, error) {
+	if kb.behaviors.Has(ByzantineSilent) {
+		return nil, errByzantineSilent
+	}
+	return kb.KeyBundle.OffchainSign(msg)
+}
+
+// doubleTransmitBackend is the simulated backend of a node sending every transaction twice.
+type doubleTransmitBackend struct {
+	evmtypes.Backend
+	lggr logger.Logger
+	eth  keystore.Eth
+}
+
+func (b doubleTransmitBackend) Client() simulated.Client {
+	return doubleTransmitClient{Client: b.Backend.Client(), lggr: b.lggr, eth: b.eth}
+}
+
+var _ simulated.Client = doubleTransmitClient{}
+
+type doubleTransmitClient struct {
+	simulated.Client
+	lggr logger.Logger
+	eth  keystore.Eth
+}
+
+func (c doubleTransmitClient) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	if err := c.Client.SendTransaction(ctx, tx); err != nil {
+		return err
+	}
+	// the simulated backend always uses chain id 1337, see EthKeystoreSim
+	chainID := big.NewInt(1337)
+	from, err := types.Sender(types.LatestSignerForChainID(chainID), tx)
+	if err != nil {
+		c.lggr.Warnw("Byzantine node failed to get sender of transaction", "tx", tx.Hash(), "err", err)
+		return nil
+	}
+	duplicate, err := c.eth.SignTx(ctx, from, types.NewTx(&types.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     tx.Nonce() + 1,
+		GasTipCap: tx.GasTipCap(),
+		GasFeeCap: tx.GasFeeCap(),
+		Gas:       tx.Gas(),
+		To:        tx.To(),
+		Value:     tx.Value(),
+		Data:      tx.Data(),
+	}), chainID)
+	if err == nil {
+		err = c.Client.SendTransaction(ctx, duplicate)
+	}
+	if err != nil {
+		c.lggr.Warnw("Byzantine node failed to send duplicate transaction", "tx", tx.Hash(), "err", err)
+		return nil
+	}
+	c.lggr.Infow("Byzantine node sent duplicate transaction", "tx", tx.Hash(), "duplicate", duplicate.Hash())
+	return nil
+}
@@ -0,0 +1,38 @@
+package memory
+
+import (
+	"crypto/rand"
+	"testing"
+
+	ocrtypes "github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/chaintype"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/ocr2key"
+)
+
+func TestByzantineBehaviors(t *testing.T) {
+	require.NoError(t, ByzantineBehaviors{ByzantineWrongSignatures, ByzantineDoubleTransmit}.Validate())
+	require.Error(t, ByzantineBehaviors{ByzantineSilent, ByzantineWrongSignatures}.Validate())
+	require.Error(t, ByzantineBehaviors{"lying"}.Validate())
+	require.Error(t, NodeOptions{Byzantine: ByzantineBehaviors{"lying"}}.Validate())
+	require.NoError(t, NodeOptions{}.Validate())
+
+	kb := ocr2key.MustNewInsecure(rand.Reader, chaintype.EVM)
+	digest, report := ocrtypes.ConfigDigest{1}, ocrtypes.Report{1, 2, 3}
+
+	sig, err := byzantineKeyBundle{KeyBundle: kb, behaviors: ByzantineBehaviors{ByzantineDoubleTransmit}}.Sign3(digest, 1, report)
+	require.NoError(t, err)
+	require.True(t, kb.Verify3(kb.PublicKey(), digest, 1, report, sig))
+
+	sig, err = byzantineKeyBundle{KeyBundle: kb, behaviors: ByzantineBehaviors{ByzantineWrongSignatures}}.Sign3(digest, 1, report)
+	require.NoError(t, err)
+	require.False(t, kb.Verify3(kb.PublicKey(), digest, 1, report, sig))
+	require.Equal(t, ocrtypes.Report{1, 2, 3}, report, "report must not be modified")
+
+	silent := byzantineKeyBundle{KeyBundle: kb, behaviors: ByzantineBehaviors{ByzantineSilent}}
+	_, err = silent.Sign3(digest, 1, report)
+	require.ErrorIs(t, err, errByzantineSilent)
+	_, err = silent.OffchainSign(report)
+	require.ErrorIs(t, err, errByzantineSilent)
+}
@@ -0,0 +1,107 @@
+package memory
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+)
+
+// EnvironmentCache reuses the memory environments built for a test by its subtests when their configurations
+// hash the same, so that a suite pays for the setup of every configuration once, e.g.
+//
+//	func TestSmoke(t *testing.T) {
+//		cache := memory.NewEnvironmentCache(func(e changeset.DeployedEnv) deployment.Environment { return e.Env })
+//		build := func(t *testing.T) changeset.DeployedEnv { ... }
+//		cache.Get(t, config, build)
+//		t.Run("case", func(t *testing.T) {
+//			e := cache.Get(t, config, build)
+//			...
+//		})
+//	}
+//
+// An environment is cached as long as the test which built it runs, so suites build their environments
+// in the parent test. The subtests get them restored to the snapshot taken after they were built,
+// see EnvironmentSnapshot, and the subtests using the same environment are serialized.
+type EnvironmentCache[E any] struct {
+	env func(E) deployment.Environment
+	// Reset optionally resets the state of a restored environment which the snapshot does not cover,
+	// e.g. the events recorded by its plugin telemetry.
+	Reset   func(E)
+	mu      sync.Mutex
+	entries map[string]*cachedEnvironment[E]
+}
+
+type cachedEnvironment[E any] struct {
+	// mu is held while the environment is built and by the subtest using it.
+	mu       sync.Mutex
+	built    bool
+	env      E
+	snapshot *EnvironmentSnapshot
+}
+
+// NewEnvironmentCache returns a cache of environments, env returns the memory environment of a cached environment.
+func NewEnvironmentCache[E any](env func(E) deployment.Environment) *EnvironmentCache[E] {
+	return &EnvironmentCache[E]{
+		env:     env,
+		entries: make(map[string]*cachedEnvironment[E]),
+	}
+}
+
+// Get returns the environment of the configuration, built by build with t if it is not cached yet,
+// restored to the state it was built in otherwise. The configuration describes everything build sets up,
+// e.g. the number of chains and nodes and the changesets applied, it must marshal to JSON.
+// A built environment is cached until t completes, a restored one is reserved to t until it completes.
+func (c *EnvironmentCache[E]) Get(t *testing.T, config any, build func(t *testing.T) E) E {
+	key, err := ConfigHash(config)
+	require.NoError(t, err)
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	if !ok {
+		entry = &cachedEnvironment[E]{}
+		c.entries[key] = entry
+	}
+	c.mu.Unlock()
+
+	entry.mu.Lock()
+	if !entry.built {
+		defer entry.mu.Unlock()
+		t.Logf("Building environment %s", key)
+		env := build(t)
+		entry.snapshot = SnapshotEnvironment(t, c.env(env))
+		entry.env, entry.built = env, true
+		// the environment is torn down with t, once the tests using it are done
+		t.Cleanup(func() {
+			c.mu.Lock()
+			delete(c.entries, key)
+			c.mu.Unlock()
+			entry.mu.Lock()
+			defer entry.mu.Unlock()
+			entry.built = false
+		})
+		return env
+	}
+	t.Cleanup(entry.mu.Unlock)
+	t.Logf("Reusing environment %s", key)
+	entry.snapshot.Restore(t, c.env(entry.env))
+	if c.Reset != nil {
+		c.Reset(entry.env)
+	}
+	return entry.env
+}
+
+// ConfigHash returns the hash of the JSON encoding of the configuration of an environment.
+func ConfigHash(config any) (string, error) {
+	raw, err := json.Marshal(config)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal environment config: %w", err)
+	}
+	hash := sha256.Sum256(raw)
+	return hex.EncodeToString(hash[:]), nil
+}
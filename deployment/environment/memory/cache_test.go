@@ -0,0 +1,45 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+func TestEnvironmentCache(t *testing.T) {
+	cache := NewEnvironmentCache(func(e deployment.Environment) deployment.Environment { return e })
+	builds, resets := 0, 0
+	cache.Reset = func(deployment.Environment) { resets++ }
+	get := func(t *testing.T, numChains int) deployment.Environment {
+		return cache.Get(t, struct{ Chains int }{numChains}, func(t *testing.T) deployment.Environment {
+			builds++
+			return NewMemoryEnvironmentFromChainsNodes(t, logger.TestLogger(t), NewMemoryChains(t, numChains), nil)
+		})
+	}
+	get(t, 1)
+	for i := 0; i < 3; i++ {
+		t.Run("one chain", func(t *testing.T) {
+			e := get(t, 1)
+			sel := e.AllChainSelectors()[0]
+			addresses, err := e.ExistingAddresses.Addresses()
+			require.NoError(t, err)
+			require.Empty(t, addresses[sel], "changes of the previous subtest must be reverted")
+			require.NoError(t, e.ExistingAddresses.Save(sel, common.HexToAddress("0x01").Hex(),
+				deployment.NewTypeAndVersion("Contract", deployment.Version1_0_0)))
+		})
+	}
+	require.Equal(t, 1, builds)
+	require.Equal(t, 3, resets)
+
+	// an environment built by a subtest is torn down with it
+	for i := 0; i < 2; i++ {
+		t.Run("two chains", func(t *testing.T) {
+			require.Len(t, get(t, 2).Chains, 2)
+		})
+	}
+	require.Equal(t, 3, builds)
+}
@@ -3,6 +3,7 @@ package memory
 import (
 	"context"
 	"fmt"
+	"maps"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
@@ -11,8 +12,6 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap/zapcore"
 
-	chainsel "github.com/smartcontractkit/chain-selectors"
-
 	"github.com/smartcontractkit/chainlink/deployment"
 
 	"github.com/smartcontractkit/chainlink-common/pkg/logger"
@@ -27,6 +26,20 @@ type MemoryEnvironmentConfig struct {
 	Nodes          int
 	Bootstraps     int
 	RegistryConfig deployment.CapabilityRegistryConfig
+	// NodeOptions optionally sets the options of the nodes, e.g. to inject byzantine behaviors.
+	NodeOptions NodeOptionsFn
+	// Plugins optionally registers experimental plugins with the nodes.
+	Plugins NodePlugins
+	// Finality optionally emulates the finality of the chains, see FinalityConfig.
+	Finality FinalityConfig
+	// TxSimulation optionally simulates every transaction before it is sent, see deployment.SimulateTransactions.
+	TxSimulation *deployment.TxSimulationConfig
+	// TrackNonces optionally tracks the nonces of the deployer keys locally, see deployment.TrackNonces.
+	TrackNonces bool
+	// AptosChains is the number of simulated Aptos chains, see NewMemoryAptosChains.
+	AptosChains int
+	// SolChains is the number of simulated Solana chains, see NewMemorySolChains.
+	SolChains int
 }
 
 // For placeholders like aptos
@@ -48,6 +61,17 @@ func NewMemoryChains(t *testing.T, numChains int) map[uint64]deployment.Chain {
 	return generateMemoryChain(t, mchains)
 }
 
+// NewMemoryChainsWithFinality is NewMemoryChains emulating the finality of the config on every chain.
+func NewMemoryChainsWithFinality(t *testing.T, numChains int, finality FinalityConfig) map[uint64]deployment.Chain {
+	require.NoError(t, finality.Validate())
+	mchains := GenerateChains(t, numChains)
+	for cid, chain := range mchains {
+		chain.Finality = finality
+		mchains[cid] = chain
+	}
+	return generateMemoryChain(t, mchains)
+}
+
 func NewMemoryChainsWithChainIDs(t *testing.T, chainIDs []uint64) map[uint64]deployment.Chain {
 	mchains := GenerateChainsWithIds(t, chainIDs)
 	return generateMemoryChain(t, mchains)
@@ -57,9 +81,9 @@ func generateMemoryChain(t *testing.T, inputs map[uint64]EVMChain) map[uint64]de
 	chains := make(map[uint64]deployment.Chain)
 	for cid, chain := range inputs {
 		chain := chain
-		sel, err := chainsel.SelectorFromChainId(cid)
+		sel, err := deployment.SelectorFromEVMChainID(cid)
 		require.NoError(t, err)
-		backend := NewBackend(chain.Backend)
+		backend := NewBackendWithFinality(chain.Backend, chain.Finality)
 		chains[sel] = deployment.Chain{
 			Selector:    sel,
 			Client:      backend,
@@ -90,20 +114,38 @@ func generateMemoryChain(t *testing.T, inputs map[uint64]EVMChain) map[uint64]de
 	return chains
 }
 
-func NewNodes(t *testing.T, logLevel zapcore.Level, chains map[uint64]deployment.Chain, numNodes, numBootstraps int, registryConfig deployment.CapabilityRegistryConfig) map[string]Node {
+// NewNodes creates the nodes with the options returned by nodeOptions, if set.
+func NewNodes(t *testing.T, logLevel zapcore.Level, chains map[uint64]deployment.Chain, numNodes, numBootstraps int, registryConfig deployment.CapabilityRegistryConfig, nodeOptions NodeOptionsFn) map[string]Node {
+	return NewNodesWithPlugins(t, logLevel, chains, numNodes, numBootstraps, registryConfig, nodeOptions, nil)
+}
+
+// NewNodesWithPlugins is like NewNodes, but registers the plugins returned by nodePlugins with each node.
+func NewNodesWithPlugins(t *testing.T, logLevel zapcore.Level, chains map[uint64]deployment.Chain, numNodes, numBootstraps int, registryConfig deployment.CapabilityRegistryConfig, nodeOptions NodeOptionsFn, nodePlugins NodePlugins) map[string]Node {
+	optionsFor := func(idx int, isBootstrap bool) NodeOptions {
+		if nodeOptions == nil {
+			return NodeOptions{}
+		}
+		return nodeOptions(idx, isBootstrap)
+	}
+	pluginsFor := func(idx int, isBootstrap bool) PluginRegistry {
+		if nodePlugins == nil {
+			return PluginRegistry{}
+		}
+		return nodePlugins(idx, isBootstrap)
+	}
 	nodesByPeerID := make(map[string]Node)
 	ports := freeport.GetN(t, numBootstraps+numNodes)
 	// bootstrap nodes must be separate nodes from plugin nodes,
 	// since we won't run a bootstrapper and a plugin oracle on the same
 	// chainlink node in production.
 	for i := 0; i < numBootstraps; i++ {
-		node := NewNode(t, ports[i], chains, logLevel, true /* bootstrap */, registryConfig)
+		node := NewNode(t, ports[i], chains, logLevel, true /* bootstrap */, registryConfig, optionsFor(i, true), pluginsFor(i, true))
 		nodesByPeerID[node.Keys.PeerID.String()] = *node
 		// Note in real env, this ID is allocated by JD.
 	}
 	for i := 0; i < numNodes; i++ {
 		// grab port offset by numBootstraps, since above loop also takes some ports.
-		node := NewNode(t, ports[numBootstraps+i], chains, logLevel, false /* bootstrap */, registryConfig)
+		node := NewNode(t, ports[numBootstraps+i], chains, logLevel, false /* bootstrap */, registryConfig, optionsFor(i, false), pluginsFor(i, false))
 		nodesByPeerID[node.Keys.PeerID.String()] = *node
 		// Note in real env, this ID is allocated by JD.
 	}
@@ -118,7 +160,7 @@ func NewMemoryEnvironmentFromChainsNodes(t *testing.T,
 	for id := range nodes {
 		nodeIDs = append(nodeIDs, id)
 	}
-	return *deployment.NewEnvironment(
+	e := deployment.NewEnvironment(
 		Memory,
 		lggr,
 		deployment.NewMemoryAddressBook(),
@@ -126,17 +168,36 @@ func NewMemoryEnvironmentFromChainsNodes(t *testing.T,
 		nodeIDs, // Note these have the p2p_ prefix.
 		NewMemoryJobClient(nodes),
 	)
+	e.StateCache = deployment.NewStateCache()
+	return *e
 }
 
 // To be used by tests and any kind of deployment logic.
 func NewMemoryEnvironment(t *testing.T, lggr logger.Logger, logLevel zapcore.Level, config MemoryEnvironmentConfig) deployment.Environment {
-	chains := NewMemoryChains(t, config.Chains)
-	nodes := NewNodes(t, logLevel, chains, config.Nodes, config.Bootstraps, config.RegistryConfig)
+	chains := NewMemoryChainsWithFinality(t, config.Chains, config.Finality)
+	aptosChains := NewMemoryAptosChains(t, config.AptosChains)
+	solChains := NewMemorySolChains(t, config.SolChains)
+	// the nodes get keys for the non-EVM chains through placeholders
+	nodeChains := maps.Clone(chains)
+	for sel := range aptosChains {
+		nodeChains[sel] = NewMemoryChain(t, sel)
+	}
+	for sel := range solChains {
+		nodeChains[sel] = NewMemoryChain(t, sel)
+	}
+	nodes := NewNodesWithPlugins(t, logLevel, nodeChains, config.Nodes, config.Bootstraps, config.RegistryConfig, config.NodeOptions, config.Plugins)
+	if config.TxSimulation != nil {
+		// the nodes use the simulated backends of the chains directly
+		require.NoError(t, deployment.SimulateTransactions(lggr, chains, *config.TxSimulation))
+	}
+	if config.TrackNonces {
+		deployment.TrackNonces(lggr, chains)
+	}
 	var nodeIDs []string
 	for id := range nodes {
 		nodeIDs = append(nodeIDs, id)
 	}
-	return *deployment.NewEnvironment(
+	e := deployment.NewEnvironment(
 		Memory,
 		lggr,
 		deployment.NewMemoryAddressBook(),
@@ -144,4 +205,8 @@ func NewMemoryEnvironment(t *testing.T, lggr logger.Logger, logLevel zapcore.Lev
 		nodeIDs,
 		NewMemoryJobClient(nodes),
 	)
+	e.AptosChains = aptosChains
+	e.SolChains = solChains
+	e.StateCache = deployment.NewStateCache()
+	return *e
 }
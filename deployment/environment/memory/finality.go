@@ -0,0 +1,120 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient/simulated"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// defaultNodeFinalityDepth is the finality depth of the nodes when the chain doesn't configure one.
+const defaultNodeFinalityDepth = 2
+
+// FinalityConfig emulates the finality of a memory chain. By default the simulated backend finalizes blocks
+// in epochs of 32 while the nodes apply a finality depth of 2. With a depth the finalized and safe blocks
+// lag the latest block by that many blocks, both for the nodes and for the deployment client of the chain,
+// so that the finalized block logic of plugins is exercised.
+type FinalityConfig struct {
+	// Depth is the number of blocks mined on top of a block for it to be final.
+	Depth uint32
+	// TagEnabled makes the nodes read the finalized block from the chain instead of applying the depth themselves.
+	TagEnabled bool
+}
+
+func (c FinalityConfig) Validate() error {
+	if c.TagEnabled && c.Depth == 0 {
+		return fmt.Errorf("finality tag emulation requires a depth")
+	}
+	return nil
+}
+
+// nodeFinalityDepth is the finality depth configured on the nodes.
+func (c FinalityConfig) nodeFinalityDepth() uint32 {
+	if c.Depth == 0 {
+		return defaultNodeFinalityDepth
+	}
+	return c.Depth
+}
+
+// finalizedNumber returns the finalized block number given the latest one.
+func (c FinalityConfig) finalizedNumber(latest uint64) uint64 {
+	if latest < uint64(c.Depth) {
+		return 0
+	}
+	return latest - uint64(c.Depth)
+}
+
+// finalityHeaderByNumber resolves the finalized and safe tags of the finality config,
+// other block numbers are passed through.
+func finalityHeaderByNumber(ctx context.Context, client simulated.Client, finality FinalityConfig, number *big.Int) (*types.Header, error) {
+	if finality.Depth == 0 || number == nil || number.Sign() >= 0 ||
+		(number.Int64() != rpc.FinalizedBlockNumber.Int64() && number.Int64() != rpc.SafeBlockNumber.Int64()) {
+		return client.HeaderByNumber(ctx, number)
+	}
+	latest, err := client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return client.HeaderByNumber(ctx, new(big.Int).SetUint64(finality.finalizedNumber(latest.Number.Uint64())))
+}
+
+// finalityBackend is the simulated backend given to the nodes, reporting the finalized blocks of the finality config.
+type finalityBackend struct {
+	*simulated.Backend
+	finality FinalityConfig
+}
+
+func (b finalityBackend) Client() simulated.Client {
+	return finalityClient{Client: b.Backend.Client(), finality: b.finality}
+}
+
+var _ simulated.Client = finalityClient{}
+
+type finalityClient struct {
+	simulated.Client
+	finality FinalityConfig
+}
+
+func (c finalityClient) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	return finalityHeaderByNumber(ctx, c.Client, c.finality, number)
+}
+
+func (c finalityClient) BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error) {
+	if number != nil && number.Sign() < 0 && c.finality.Depth > 0 {
+		h, err := c.HeaderByNumber(ctx, number)
+		if err != nil {
+			return nil, err
+		}
+		number = h.Number
+	}
+	return c.Client.BlockByNumber(ctx, number)
+}
+
+// FinalizedBlockNumber returns the number of the latest finalized block of the chain.
+func (b *Backend) FinalizedBlockNumber(ctx context.Context) (uint64, error) {
+	h, err := b.HeaderByNumber(ctx, big.NewInt(rpc.FinalizedBlockNumber.Int64()))
+	if err != nil {
+		return 0, err
+	}
+	return h.Number.Uint64(), nil
+}
+
+// CommitUntilFinalized mines blocks until the block is finalized.
+func (b *Backend) CommitUntilFinalized(ctx context.Context, blockNumber uint64) error {
+	for {
+		finalized, err := b.FinalizedBlockNumber(ctx)
+		if err != nil {
+			return err
+		}
+		if finalized >= blockNumber {
+			return nil
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		b.Commit()
+	}
+}
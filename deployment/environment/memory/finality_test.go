@@ -0,0 +1,54 @@
+package memory
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+)
+
+func TestFinality(t *testing.T) {
+	ctx := tests.Context(t)
+	require.Error(t, FinalityConfig{TagEnabled: true}.Validate())
+
+	for _, chain := range NewMemoryChains(t, 1) {
+		backend, ok := AsBackend(chain.Client)
+		require.True(t, ok)
+		latest, err := backend.HeaderByNumber(ctx, nil)
+		require.NoError(t, err)
+		finalized, err := backend.FinalizedBlockNumber(ctx)
+		require.NoError(t, err)
+		require.LessOrEqual(t, finalized, latest.Number.Uint64())
+	}
+
+	finality := FinalityConfig{Depth: 5, TagEnabled: true}
+	for _, chain := range NewMemoryChainsWithFinality(t, 1, finality) {
+		backend, ok := AsBackend(chain.Client)
+		require.True(t, ok)
+		for i := 0; i < 10; i++ {
+			backend.Commit()
+		}
+		latest, err := backend.HeaderByNumber(ctx, nil)
+		require.NoError(t, err)
+		finalized, err := backend.FinalizedBlockNumber(ctx)
+		require.NoError(t, err)
+		require.Equal(t, latest.Number.Uint64()-5, finalized)
+		safe, err := backend.HeaderByNumber(ctx, big.NewInt(rpc.SafeBlockNumber.Int64()))
+		require.NoError(t, err)
+		require.Equal(t, finalized, safe.Number.Uint64())
+
+		// the nodes see the same finalized block
+		client := finalityBackend{Backend: backend.Sim, finality: finality}.Client()
+		block, err := client.BlockByNumber(ctx, big.NewInt(rpc.FinalizedBlockNumber.Int64()))
+		require.NoError(t, err)
+		require.Equal(t, finalized, block.NumberU64())
+
+		require.NoError(t, backend.CommitUntilFinalized(ctx, latest.Number.Uint64()+1))
+		finalized, err = backend.FinalizedBlockNumber(ctx)
+		require.NoError(t, err)
+		require.Equal(t, latest.Number.Uint64()+1, finalized)
+	}
+}
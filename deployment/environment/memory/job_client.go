@@ -7,8 +7,11 @@ import (
 	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/google/uuid"
+	"github.com/pelletier/go-toml/v2"
 	"google.golang.org/grpc"
 
 	chainsel "github.com/smartcontractkit/chain-selectors"
@@ -18,8 +21,17 @@ import (
 	nodev1 "github.com/smartcontractkit/chainlink-protos/job-distributor/v1/node"
 	"github.com/smartcontractkit/chainlink-protos/job-distributor/v1/shared/ptypes"
 
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/deployment/environment/jobspec"
 	"github.com/smartcontractkit/chainlink/v2/core/capabilities/ccip/validate"
+	"github.com/smartcontractkit/chainlink/v2/core/services/job"
 	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/chaintype"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/ocr2key"
+	ocr2validate "github.com/smartcontractkit/chainlink/v2/core/services/ocr2/validate"
+	"github.com/smartcontractkit/chainlink/v2/core/services/ocrbootstrap"
+	"github.com/smartcontractkit/chainlink/v2/core/services/vrf/vrfcommon"
+	"github.com/smartcontractkit/chainlink/v2/core/services/workflows"
+	"github.com/smartcontractkit/chainlink/v2/core/web/presenters"
 )
 
 type JobClient struct {
@@ -186,7 +198,7 @@ func (j JobClient) ListNodeChainConfigs(ctx context.Context, in *nodev1.ListNode
 		})
 	}
 	for _, selector := range n.Chains {
-		family, err := chainsel.GetSelectorFamily(selector)
+		family, err := deployment.ChainFamily(selector)
 		if err != nil {
 			return nil, err
 		}
@@ -196,7 +208,7 @@ func (j JobClient) ListNodeChainConfigs(ctx context.Context, in *nodev1.ListNode
 		}
 
 		// NOTE: this supports non-EVM too
-		chainID, err := chainsel.GetChainIDFromSelector(selector)
+		chainID, err := deployment.ChainID(selector)
 		if err != nil {
 			return nil, err
 		}
@@ -230,15 +242,18 @@ func (j JobClient) ListNodeChainConfigs(ctx context.Context, in *nodev1.ListNode
 		}
 
 		var ctype nodev1.ChainType
+		var account string
 		switch family {
 		case chainsel.FamilyEVM:
 			ctype = nodev1.ChainType_CHAIN_TYPE_EVM
 		case chainsel.FamilySolana:
 			ctype = nodev1.ChainType_CHAIN_TYPE_SOLANA
+			account = n.Keys.SolanaAccount
 		case chainsel.FamilyStarknet:
 			ctype = nodev1.ChainType_CHAIN_TYPE_STARKNET
 		case chainsel.FamilyAptos:
 			ctype = nodev1.ChainType_CHAIN_TYPE_APTOS
+			account = n.Keys.AptosAccount
 		default:
 			panic(fmt.Sprintf("Unsupported chain family %v", family))
 		}
@@ -248,7 +263,7 @@ func (j JobClient) ListNodeChainConfigs(ctx context.Context, in *nodev1.ListNode
 				Id:   chainID,
 				Type: ctype,
 			},
-			AccountAddress: "", // TODO: support AccountAddress
+			AccountAddress: account, // TODO: support AccountAddress of other families
 			AdminAddress:   "",
 			Ocr1Config:     nil,
 			Ocr2Config: &nodev1.OCR2Config{
@@ -291,9 +306,16 @@ func (j JobClient) ListProposals(ctx context.Context, in *jobv1.ListProposalsReq
 }
 
 func (j JobClient) ProposeJob(ctx context.Context, in *jobv1.ProposeJobRequest, opts ...grpc.CallOption) (*jobv1.ProposeJobResponse, error) {
+	diagnostics := jobspec.Lint(in.Spec)
+	if err := diagnostics.Err(); err != nil {
+		return nil, err
+	}
 	n := j.Nodes[in.NodeId]
+	for _, warning := range diagnostics.Warnings() {
+		n.App.GetLogger().Warnw("Proposed job spec has a lint warning", "nodeID", in.NodeId, "warning", warning.String())
+	}
 	// TODO: Use FMS
-	jb, err := validate.ValidatedCCIPSpec(in.Spec)
+	jb, err := validatedJobSpec(ctx, n, in.Spec)
 	if err != nil {
 		return nil, err
 	}
@@ -315,6 +337,30 @@ func (j JobClient) ProposeJob(ctx context.Context, in *jobv1.ProposeJobRequest,
 	}}, nil
 }
 
+// validatedJobSpec validates the spec with the validator of its job type,
+// so that jobs of several products can be proposed to the same nodes.
+func validatedJobSpec(ctx context.Context, n Node, spec string) (job.Job, error) {
+	var header struct {
+		Type job.Type `toml:"type"`
+	}
+	if err := toml.Unmarshal([]byte(spec), &header); err != nil {
+		return job.Job{}, fmt.Errorf("toml error on load: %w", err)
+	}
+	switch header.Type {
+	case job.Workflow:
+		return workflows.ValidatedWorkflowJobSpec(ctx, spec)
+	case job.VRF:
+		return vrfcommon.ValidatedVRFSpec(spec)
+	case job.Bootstrap:
+		return ocrbootstrap.ValidatedBootstrapSpecToml(spec)
+	case job.OffchainReporting2:
+		cfg := n.App.GetConfig()
+		return ocr2validate.ValidatedOracleSpecToml(ctx, cfg.OCR2(), cfg.Insecure(), spec, n.App.GetLoopRegistrarConfig())
+	default:
+		return validate.ValidatedCCIPSpec(spec)
+	}
+}
+
 func (j JobClient) RevokeJob(ctx context.Context, in *jobv1.RevokeJobRequest, opts ...grpc.CallOption) (*jobv1.RevokeJobResponse, error) {
 	//TODO CCIP-3108 implement me
 	panic("implement me")
@@ -334,6 +380,167 @@ func (j JobClient) ReplayLogs(selectorToBlock map[uint64]uint64) error {
 	return nil
 }
 
+// ReplayLogsInRange implements deployment.LogReplayer
+func (j JobClient) ReplayLogsInRange(ctx context.Context, req deployment.LogReplayRequest) error {
+	for _, node := range j.Nodes {
+		if err := node.ReplayLogsInRange(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LogObservations implements deployment.NodeTelemetry
+func (j JobClient) LogObservations(ctx context.Context, chainSelector uint64, address common.Address, eventSig common.Hash, txHash common.Hash) (map[string]time.Time, error) {
+	observations := make(map[string]time.Time)
+	for id, node := range j.Nodes {
+		observedAt, ok, err := node.LogObservedAt(ctx, chainSelector, address, eventSig, txHash)
+		if err != nil {
+			return nil, fmt.Errorf("node %s: %w", id, err)
+		}
+		if ok {
+			observations[id] = observedAt
+		}
+	}
+	return observations, nil
+}
+
+// TxSubmission implements deployment.NodeTelemetry
+func (j JobClient) TxSubmission(ctx context.Context, chainSelector uint64, txHash common.Hash) (deployment.TxSubmission, error) {
+	for id, node := range j.Nodes {
+		sub, ok, err := node.TxSubmission(ctx, txHash)
+		if err != nil {
+			return deployment.TxSubmission{}, fmt.Errorf("node %s: %w", id, err)
+		}
+		if ok {
+			sub.NodeID = id
+			return sub, nil
+		}
+	}
+	return deployment.TxSubmission{}, fmt.Errorf("tx %s on chain %d was not sent by any node", txHash, chainSelector)
+}
+
+// FailingServices implements deployment.HealthReporter
+func (j JobClient) FailingServices(_ context.Context) (map[string]map[string]string, error) {
+	failing := make(map[string]map[string]string)
+	for id, node := range j.Nodes {
+		_, errs := node.App.GetHealthChecker().IsHealthy()
+		for service, err := range errs {
+			if err == nil {
+				continue
+			}
+			if _, ok := failing[id]; !ok {
+				failing[id] = make(map[string]string)
+			}
+			failing[id][service] = err.Error()
+		}
+	}
+	return failing, nil
+}
+
+// JobPluginConfig implements deployment.PluginConfigReader
+func (j JobClient) JobPluginConfig(ctx context.Context, nodeID string, jobID string) (deployment.JobPluginConfig, error) {
+	n, ok := j.Nodes[nodeID]
+	if !ok {
+		return deployment.JobPluginConfig{}, fmt.Errorf("node id not found: %s", nodeID)
+	}
+	var jb job.Job
+	var err error
+	if externalJobID, pErr := uuid.Parse(jobID); pErr == nil {
+		jb, err = n.App.JobORM().FindJobByExternalJobID(ctx, externalJobID)
+	} else if pErr = jb.SetID(jobID); pErr == nil {
+		jb, err = n.App.JobORM().FindJob(ctx, jb.ID)
+	} else {
+		return deployment.JobPluginConfig{}, fmt.Errorf("invalid job id %s: %w", jobID, pErr)
+	}
+	if err != nil {
+		return deployment.JobPluginConfig{}, fmt.Errorf("failed to find job %s on node %s: %w", jobID, nodeID, err)
+	}
+	if jb.Type != job.OffchainReporting2 || jb.OCR2OracleSpec == nil {
+		return deployment.JobPluginConfig{}, fmt.Errorf("job type %s has no plugin config", jb.Type)
+	}
+
+	validationErr := ocr2validate.ValidatePluginConfig(ctx, jb.OCR2OracleSpec)
+	pluginConfig, err := ocr2validate.ResolvePluginConfig(jb.OCR2OracleSpec)
+	if err != nil && validationErr == nil {
+		validationErr = err
+	}
+	r := presenters.NewPluginConfigResource(jb, pluginConfig, validationErr)
+	return deployment.JobPluginConfig{
+		PluginType:      r.PluginType,
+		Relay:           r.Relay,
+		RelayConfig:     r.RelayConfig,
+		PluginConfig:    r.PluginConfig,
+		ValidationError: r.ValidationError,
+	}, nil
+}
+
+// CreateOCRKeyBundle implements deployment.OCRKeyRotator
+func (j JobClient) CreateOCRKeyBundle(ctx context.Context, nodeID string) (string, error) {
+	n, ok := j.Nodes[nodeID]
+	if !ok {
+		return "", fmt.Errorf("node id not found: %s", nodeID)
+	}
+	bundle, err := n.App.GetKeyStore().OCR2().Create(ctx, chaintype.EVM)
+	if err != nil {
+		return "", err
+	}
+	bundles := make(map[chaintype.ChainType]ocr2key.KeyBundle, len(n.Keys.OCRKeyBundles))
+	for ctype, b := range n.Keys.OCRKeyBundles {
+		bundles[ctype] = b
+	}
+	bundles[chaintype.EVM] = bundle
+	n.Keys.OCRKeyBundles = bundles
+	j.Nodes[nodeID] = n
+	return bundle.ID(), nil
+}
+
+// DeleteOCRKeyBundle implements deployment.OCRKeyRotator
+func (j JobClient) DeleteOCRKeyBundle(ctx context.Context, nodeID string, bundleID string) error {
+	n, ok := j.Nodes[nodeID]
+	if !ok {
+		return fmt.Errorf("node id not found: %s", nodeID)
+	}
+	for _, b := range n.Keys.OCRKeyBundles {
+		if b.ID() == bundleID {
+			return fmt.Errorf("key bundle %s is still in use by node %s", bundleID, nodeID)
+		}
+	}
+	return n.App.GetKeyStore().OCR2().Delete(ctx, bundleID)
+}
+
+// StopNode implements deployment.NodeRestarter
+func (j JobClient) StopNode(_ context.Context, nodeID string) error {
+	n, ok := j.Nodes[nodeID]
+	if !ok {
+		return fmt.Errorf("node id not found: %s", nodeID)
+	}
+	err := n.Stop()
+	j.Nodes[nodeID] = n
+	return err
+}
+
+// StartNode implements deployment.NodeRestarter
+func (j JobClient) StartNode(ctx context.Context, nodeID string) error {
+	n, ok := j.Nodes[nodeID]
+	if !ok {
+		return fmt.Errorf("node id not found: %s", nodeID)
+	}
+	err := n.Start(ctx)
+	j.Nodes[nodeID] = n
+	return err
+}
+
+// StopNodes stops all the running nodes.
+func (j JobClient) StopNodes() error {
+	var errs error
+	for id, n := range j.Nodes {
+		errs = errors.Join(errs, n.Stop())
+		j.Nodes[id] = n
+	}
+	return errs
+}
+
 func NewMemoryJobClient(nodesByPeerID map[string]Node) *JobClient {
 	return &JobClient{nodesByPeerID}
 }
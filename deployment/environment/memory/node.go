@@ -2,16 +2,21 @@ package memory
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"math/big"
 	"net"
 	"net/http"
+	"slices"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/jmoiron/sqlx"
 	chainsel "github.com/smartcontractkit/chain-selectors"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap/zapcore"
@@ -28,6 +33,7 @@ import (
 	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/assets"
 	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/client"
 	v2toml "github.com/smartcontractkit/chainlink/v2/core/chains/evm/config/toml"
+	evmtypes "github.com/smartcontractkit/chainlink/v2/core/chains/evm/types"
 	evmutils "github.com/smartcontractkit/chainlink/v2/core/chains/evm/utils/big"
 	"github.com/smartcontractkit/chainlink/v2/core/chains/legacyevm"
 	configv2 "github.com/smartcontractkit/chainlink/v2/core/config/toml"
@@ -52,11 +58,73 @@ type Node struct {
 	Keys       Keys
 	Addr       net.TCPAddr
 	IsBoostrap bool
+	// Options are the options the node was created with
+	Options NodeOptions
+	// Plugins are the experimental plugins registered with the node
+	Plugins PluginRegistry
+	// CapabilitiesRegistry is the local capabilities registry of the node
+	CapabilitiesRegistry *capabilities.Registry
+
+	running bool
+	// stopped is set once the application is stopped, it can't be started again.
+	stopped bool
+	newApp  func() (chainlink.Application, error)
+	// db is the database of the node, kept open across restarts.
+	db *sqlx.DB
+}
+
+// Start starts the node. A stopped node is started with a new application built from its database and keystore,
+// since applications can't be started again once stopped.
+func (n *Node) Start(ctx context.Context) error {
+	if n.running {
+		return fmt.Errorf("node %s is already running", n.Keys.PeerID)
+	}
+	if n.stopped {
+		app, err := n.newApp()
+		if err != nil {
+			return fmt.Errorf("failed to build application of node %s: %w", n.Keys.PeerID, err)
+		}
+		n.App, n.stopped = app, false
+	}
+	if err := n.App.Start(ctx); err != nil {
+		return err
+	}
+	n.running = true
+	return nil
+}
+
+// Stop stops the node as if it crashed. Its database and keystore are preserved, so that the node
+// resumes where it left off once started again. Stopping a stopped node is a no-op.
+func (n *Node) Stop() error {
+	if !n.running {
+		return nil
+	}
+	n.running, n.stopped = false, true
+	return n.App.Stop()
+}
+
+// Restart stops the node if it is running and starts it again.
+func (n *Node) Restart(ctx context.Context) error {
+	if err := n.Stop(); err != nil {
+		return fmt.Errorf("failed to stop node %s: %w", n.Keys.PeerID, err)
+	}
+	return n.Start(ctx)
+}
+
+// Running returns whether the node is started.
+func (n Node) Running() bool {
+	return n.running
+}
+
+// LOOPCommand returns the binary of a LOOP plugin registered with the node.
+func (n Node) LOOPCommand(name string) (string, bool) {
+	cmd, ok := n.Plugins.LOOPs[name]
+	return cmd, ok
 }
 
 func (n Node) ReplayLogs(chains map[uint64]uint64) error {
 	for sel, block := range chains {
-		chainID, _ := chainsel.ChainIdFromSelector(sel)
+		chainID, _ := deployment.EVMChainID(sel)
 		if err := n.App.ReplayFromBlock(big.NewInt(int64(chainID)), block, false); err != nil {
 			return err
 		}
@@ -64,6 +132,102 @@ func (n Node) ReplayLogs(chains map[uint64]uint64) error {
 	return nil
 }
 
+// ReplayLogsInRange runs a targeted log replay on the node, see deployment.LogReplayRequest
+func (n Node) ReplayLogsInRange(ctx context.Context, req deployment.LogReplayRequest) error {
+	chainID, err := deployment.EVMChainID(req.ChainSelector)
+	if err != nil {
+		return err
+	}
+	return n.App.ReplayLogPollerRange(ctx, big.NewInt(int64(chainID)), int64(req.FromBlock), int64(req.ToBlock), req.Addresses, req.EventSigs)
+}
+
+// LogObservedAt returns when the node's log poller persisted the log emitted by address in txHash.
+// ok is false if the node has not observed the log yet.
+func (n Node) LogObservedAt(ctx context.Context, chainSel uint64, address common.Address, eventSig common.Hash, txHash common.Hash) (observedAt time.Time, ok bool, err error) {
+	chainID, err := deployment.EVMChainID(chainSel)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	chain, err := n.App.GetRelayers().LegacyEVMChains().Get(strconv.FormatUint(chainID, 10))
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	logs, err := chain.LogPoller().IndexedLogsByTxHash(ctx, eventSig, address, txHash)
+	if err != nil || len(logs) == 0 {
+		return time.Time{}, false, err
+	}
+	return logs[0].CreatedAt, true, nil
+}
+
+// HasLogFilter returns whether the node's log poller of the chain has a filter on the logs emitted by address.
+func (n Node) HasLogFilter(chainSel uint64, address common.Address) (bool, error) {
+	chainID, err := deployment.EVMChainID(chainSel)
+	if err != nil {
+		return false, err
+	}
+	chain, err := n.App.GetRelayers().LegacyEVMChains().Get(strconv.FormatUint(chainID, 10))
+	if err != nil {
+		return false, err
+	}
+	for _, filter := range chain.LogPoller().GetFilters() {
+		for _, filterAddress := range filter.Addresses {
+			if filterAddress == address {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// TxSubmission returns how the node submitted the transaction with the given hash.
+// ok is false if the transaction was not sent by the node.
+func (n Node) TxSubmission(ctx context.Context, txHash common.Hash) (sub deployment.TxSubmission, ok bool, err error) {
+	tx, err := n.App.TxmStorageService().FindTxByHash(ctx, txHash)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return deployment.TxSubmission{}, false, nil
+		}
+		return deployment.TxSubmission{}, false, err
+	}
+	sub = deployment.TxSubmission{CreatedAt: tx.CreatedAt}
+	if tx.InitialBroadcastAt != nil {
+		sub.BroadcastAt = *tx.InitialBroadcastAt
+	}
+	return sub, true, nil
+}
+
+// NodeOptions configure how a memory node behaves, independently of the plugins registered with it.
+type NodeOptions struct {
+	// Byzantine injects faults in the node, to verify the fault tolerance of its DON.
+	Byzantine ByzantineBehaviors
+	// ConfigOverrides are TOML overlays of the config of the node, applied in order on top of the
+	// memory defaults, e.g. to test DONs whose nodes have different OCR timeouts or NodePool settings.
+	// EVM chains are matched by ChainID.
+	ConfigOverrides []string
+}
+
+// NodeOptionsFn returns the options of a node, given its index among the bootstrap or plugin nodes.
+type NodeOptionsFn func(idx int, isBootstrap bool) NodeOptions
+
+func (o NodeOptions) Validate() error {
+	if err := o.Byzantine.Validate(); err != nil {
+		return err
+	}
+	_, err := o.configOverrides()
+	return err
+}
+
+// configOverrides decodes the ConfigOverrides.
+func (o NodeOptions) configOverrides() ([]chainlink.Config, error) {
+	overrides := make([]chainlink.Config, len(o.ConfigOverrides))
+	for i, overlay := range o.ConfigOverrides {
+		if err := config.DecodeTOML(strings.NewReader(overlay), &overrides[i]); err != nil {
+			return nil, fmt.Errorf("invalid config override %d: %w", i, err)
+		}
+	}
+	return overrides, nil
+}
+
 // Creates a CL node which is:
 // - Configured for OCR
 // - Configured for the chains specified
@@ -75,20 +239,36 @@ func NewNode(
 	logLevel zapcore.Level,
 	bootstrap bool,
 	registryConfig deployment.CapabilityRegistryConfig,
+	opts NodeOptions,
+	pluginRegistries ...PluginRegistry,
 ) *Node {
+	require.NoError(t, opts.Validate())
+	var nodePlugins PluginRegistry
+	for _, p := range pluginRegistries {
+		nodePlugins = nodePlugins.merge(p)
+	}
+	require.NoError(t, nodePlugins.Validate())
+	configOverrides, err := opts.configOverrides()
+	require.NoError(t, err)
+
 	evmchains := make(map[uint64]EVMChain)
 	for _, chain := range chains {
 		// we're only mapping evm chains here
-		if family, err := chainsel.GetSelectorFamily(chain.Selector); err != nil || family != chainsel.FamilyEVM {
+		if family, err := deployment.ChainFamily(chain.Selector); err != nil || family != chainsel.FamilyEVM {
 			continue
 		}
-		evmChainID, err := chainsel.ChainIdFromSelector(chain.Selector)
+		evmChainID, err := deployment.EVMChainID(chain.Selector)
 		if err != nil {
 			t.Fatal(err)
 		}
+		backend, ok := AsBackend(chain.Client)
+		if !ok {
+			t.Fatalf("chain %d is not a memory chain", chain.Selector)
+		}
 		evmchains[evmChainID] = EVMChain{
-			Backend:     chain.Client.(*Backend).Sim,
+			Backend:     backend.Sim,
 			DeployerKey: chain.DeployerKey,
+			Finality:    backend.Finality(),
 		}
 	}
 
@@ -121,48 +301,22 @@ func NewNode(
 		c.Log.Level = ptr(configv2.LogLevel(logLevel))
 
 		var chainConfigs v2toml.EVMConfigs
-		for chainID := range evmchains {
-			chainConfigs = append(chainConfigs, createConfigV2Chain(chainID))
+		for chainID, chain := range evmchains {
+			chainConfigs = append(chainConfigs, createConfigV2Chain(chainID, chain.Finality))
 		}
 		c.EVM = chainConfigs
+
+		for _, override := range configOverrides {
+			require.NoError(t, c.SetFrom(&override))
+		}
 	})
 
 	// Set logging.
 	lggr := logger.TestLogger(t)
 	lggr.SetLogLevel(logLevel)
 
-	// Create clients for the core node backed by sim.
-	clients := make(map[uint64]client.Client)
-	for chainID, chain := range evmchains {
-		clients[chainID] = client.NewSimulatedBackendClient(t, chain.Backend, big.NewInt(int64(chainID)))
-	}
-
 	// Create keystore
 	master := keystore.New(db, utils.FastScryptParams, lggr)
-	kStore := KeystoreSim{
-		eks: &EthKeystoreSim{
-			Eth: master.Eth(),
-		},
-		csa: master.CSA(),
-	}
-
-	// Build evm factory using clients + keystore.
-	mailMon := mailbox.NewMonitor("node", lggr.Named("mailbox"))
-	evmOpts := chainlink.EVMFactoryConfig{
-		ChainOpts: legacyevm.ChainOpts{
-			AppConfig: cfg,
-			GenEthClient: func(i *big.Int) client.Client {
-				ethClient, ok := clients[i.Uint64()]
-				if !ok {
-					t.Fatal("no backend for chainID", i)
-				}
-				return ethClient
-			},
-			MailMon: mailMon,
-			DS:      db,
-		},
-		CSAETHKeystore: kStore,
-	}
 
 	// Build Beholder auth
 	ctx := tests.Context(t)
@@ -171,31 +325,102 @@ func NewNode(
 	beholderAuthHeaders, csaPubKeyHex, err := keystore.BuildBeholderAuth(master)
 	require.NoError(t, err)
 
-	// Build relayer factory with EVM.
-	relayerFactory := chainlink.RelayerFactory{
-		Logger:               lggr,
-		LoopRegistry:         plugins.NewLoopRegistry(lggr.Named("LoopRegistry"), cfg.Tracing(), cfg.Telemetry(), beholderAuthHeaders, csaPubKeyHex),
-		GRPCOpts:             loop.GRPCOpts{},
-		CapabilitiesRegistry: capabilities.NewRegistry(lggr),
+	// Register the in-process capabilities of the node.
+	capabilitiesRegistry := capabilities.NewRegistry(lggr)
+	for _, factory := range nodePlugins.Capabilities {
+		capability, err2 := factory(lggr)
+		require.NoError(t, err2)
+		require.NoError(t, capabilitiesRegistry.Add(ctx, capability))
 	}
-	initOps := []chainlink.CoreRelayerChainInitFunc{chainlink.InitEVM(context.Background(), relayerFactory, evmOpts)}
-	rci, err := chainlink.NewCoreRelayerChainInteroperators(initOps...)
-	require.NoError(t, err)
 
-	app, err := chainlink.NewApplication(chainlink.ApplicationOpts{
-		Config:                     cfg,
-		DS:                         db,
-		KeyStore:                   master,
-		RelayerChainInteroperators: rci,
-		Logger:                     lggr,
-		ExternalInitiatorManager:   nil,
-		CloseLogger:                lggr.Sync,
-		UnrestrictedHTTPClient:     &http.Client{},
-		RestrictedHTTPClient:       &http.Client{},
-		AuditLogger:                audit.NoopLogger,
-		MailMon:                    mailMon,
-		LoopRegistry:               plugins.NewLoopRegistry(lggr, cfg.Tracing(), cfg.Telemetry(), beholderAuthHeaders, csaPubKeyHex),
-	})
+	// Register the LOOP plugins of the node, so that they get their health and metrics ports.
+	// The registry is shared by the relayers and the application, and kept across restarts.
+	loopRegistry := plugins.NewLoopRegistry(lggr.Named("LoopRegistry"), cfg.Tracing(), cfg.Telemetry(), beholderAuthHeaders, csaPubKeyHex)
+	loopNames := maps.Keys(nodePlugins.LOOPs)
+	slices.Sort(loopNames)
+	for _, name := range loopNames {
+		_, err2 := loopRegistry.Register(name)
+		require.NoError(t, err2)
+	}
+
+	var appKeyStore keystore.Master = master
+	if len(opts.Byzantine) > 0 {
+		lggr.Warnw("Injecting byzantine behaviors", "behaviors", opts.Byzantine)
+		appKeyStore = byzantineKeyStore{Master: master, behaviors: opts.Byzantine}
+	}
+
+	// newApp builds the application of the node from its database and keystore,
+	// so that a stopped node can be restarted with its state preserved.
+	newApp := func() (chainlink.Application, error) {
+		// Create clients for the core node backed by sim.
+		clients := make(map[uint64]client.Client)
+		for chainID, chain := range evmchains {
+			var backend evmtypes.Backend = chain.Backend
+			if chain.Finality.Depth > 0 {
+				backend = finalityBackend{Backend: chain.Backend, finality: chain.Finality}
+			}
+			if opts.Byzantine.Has(ByzantineDoubleTransmit) {
+				backend = doubleTransmitBackend{Backend: backend, lggr: lggr, eth: master.Eth()}
+			}
+			clients[chainID] = client.NewSimulatedBackendClient(t, backend, big.NewInt(int64(chainID)))
+		}
+		kStore := KeystoreSim{
+			eks: &EthKeystoreSim{
+				Eth: master.Eth(),
+			},
+			csa: master.CSA(),
+		}
+
+		// Build evm factory using clients + keystore.
+		mailMon := mailbox.NewMonitor("node", lggr.Named("mailbox"))
+		evmOpts := chainlink.EVMFactoryConfig{
+			ChainOpts: legacyevm.ChainOpts{
+				AppConfig: cfg,
+				GenEthClient: func(i *big.Int) client.Client {
+					ethClient, ok := clients[i.Uint64()]
+					if !ok {
+						t.Fatal("no backend for chainID", i)
+					}
+					return ethClient
+				},
+				MailMon: mailMon,
+				DS:      db,
+			},
+			CSAETHKeystore: kStore,
+		}
+
+		// Build relayer factory with EVM.
+		relayerFactory := chainlink.RelayerFactory{
+			Logger:               lggr,
+			LoopRegistry:         loopRegistry,
+			GRPCOpts:             loop.GRPCOpts{},
+			CapabilitiesRegistry: capabilitiesRegistry,
+		}
+		initOps := []chainlink.CoreRelayerChainInitFunc{chainlink.InitEVM(context.Background(), relayerFactory, evmOpts)}
+		rci, err := chainlink.NewCoreRelayerChainInteroperators(initOps...)
+		if err != nil {
+			return nil, err
+		}
+
+		return chainlink.NewApplication(chainlink.ApplicationOpts{
+			Config:                     cfg,
+			DS:                         db,
+			KeyStore:                   appKeyStore,
+			RelayerChainInteroperators: rci,
+			Logger:                     lggr,
+			ExternalInitiatorManager:   nil,
+			CloseLogger:                lggr.Sync,
+			UnrestrictedHTTPClient:     &http.Client{},
+			RestrictedHTTPClient:       &http.Client{},
+			AuditLogger:                audit.NoopLogger,
+			MailMon:                    mailMon,
+			LoopRegistry:               loopRegistry,
+			CapabilitiesRegistry:       capabilitiesRegistry,
+			NewRMNPeerClientFn:         nodePlugins.RMNPeerClient,
+			PluginTelemetrySink:        nodePlugins.PluginTelemetry,
+		})
+	}
+	app, err := newApp()
 	require.NoError(t, err)
 	t.Cleanup(func() {
 		require.NoError(t, db.Close())
@@ -203,11 +428,16 @@ func NewNode(
 	keys := CreateKeys(t, app, chains)
 
 	return &Node{
-		App:        app,
-		Chains:     maps.Keys(chains),
-		Keys:       keys,
-		Addr:       net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: port},
-		IsBoostrap: bootstrap,
+		App:                  app,
+		Chains:               maps.Keys(chains),
+		Keys:                 keys,
+		Addr:                 net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: port},
+		IsBoostrap:           bootstrap,
+		Options:              opts,
+		Plugins:              nodePlugins,
+		CapabilitiesRegistry: capabilitiesRegistry,
+		newApp:               newApp,
+		db:                   db,
 	}
 }
 
@@ -216,6 +446,10 @@ type Keys struct {
 	CSA                      csakey.KeyV2
 	TransmittersByEVMChainID map[uint64]common.Address
 	OCRKeyBundles            map[chaintype.ChainType]ocr2key.KeyBundle
+	// AptosAccount is the transmitter account of the node on Aptos chains, if any.
+	AptosAccount string
+	// SolanaAccount is the transmitter account of the node on Solana chains, if any.
+	SolanaAccount string
 }
 
 func CreateKeys(t *testing.T,
@@ -237,8 +471,9 @@ func CreateKeys(t *testing.T,
 	// create a transmitter for each chain
 	transmitters := make(map[uint64]common.Address)
 	keybundles := make(map[chaintype.ChainType]ocr2key.KeyBundle)
+	var aptosAccount, solanaAccount string
 	for _, chain := range chains {
-		family, err := chainsel.GetSelectorFamily(chain.Selector)
+		family, err := deployment.ChainFamily(chain.Selector)
 		require.NoError(t, err)
 
 		var ctype chaintype.ChainType
@@ -261,12 +496,22 @@ func CreateKeys(t *testing.T,
 		require.NoError(t, err)
 		keybundles[ctype] = keybundle
 
+		if family == chainsel.FamilyAptos && aptosAccount == "" {
+			aptosKey, err2 := app.GetKeyStore().Aptos().Create(ctx)
+			require.NoError(t, err2)
+			aptosAccount = "0x" + aptosKey.Account()
+		}
+		if family == chainsel.FamilySolana && solanaAccount == "" {
+			solanaKey, err2 := app.GetKeyStore().Solana().Create(ctx)
+			require.NoError(t, err2)
+			solanaAccount = solanaKey.PublicKeyStr()
+		}
 		if family != chainsel.FamilyEVM {
-			// TODO: only support EVM transmission keys for now
+			// TODO: only support EVM, Aptos and Solana transmission keys for now
 			continue
 		}
 
-		evmChainID, err := chainsel.ChainIdFromSelector(chain.Selector)
+		evmChainID, err := deployment.EVMChainID(chain.Selector)
 		require.NoError(t, err)
 
 		cid := big.NewInt(int64(evmChainID))
@@ -284,8 +529,9 @@ func CreateKeys(t *testing.T,
 			require.Len(t, sendingKeys, 1)
 			transmitters[evmChainID] = sendingKeys[0]
 		}
-		backend := chain.Client.(*Backend).Sim
-		fundAddress(t, chain.DeployerKey, transmitters[evmChainID], assets.Ether(1000).ToInt(), backend)
+		backend, ok := AsBackend(chain.Client)
+		require.True(t, ok, "chain %d is not a memory chain", chain.Selector)
+		fundAddress(t, chain.DeployerKey, transmitters[evmChainID], assets.Ether(1000).ToInt(), backend.Sim)
 	}
 
 	return Keys{
@@ -293,16 +539,22 @@ func CreateKeys(t *testing.T,
 		CSA:                      csaKey,
 		TransmittersByEVMChainID: transmitters,
 		OCRKeyBundles:            keybundles,
+		AptosAccount:             aptosAccount,
+		SolanaAccount:            solanaAccount,
 	}
 }
 
-func createConfigV2Chain(chainID uint64) *v2toml.EVMConfig {
+func createConfigV2Chain(chainID uint64, finality FinalityConfig) *v2toml.EVMConfig {
 	chainIDBig := evmutils.NewI(int64(chainID))
 	chain := v2toml.Defaults(chainIDBig)
 	chain.GasEstimator.LimitDefault = ptr(uint64(5e6))
 	chain.LogPollInterval = config.MustNewDuration(500 * time.Millisecond)
 	chain.Transactions.ForwardersEnabled = ptr(false)
-	chain.FinalityDepth = ptr(uint32(2))
+	chain.FinalityDepth = ptr(finality.nodeFinalityDepth())
+	if finality.TagEnabled {
+		chain.FinalityTagEnabled = ptr(true)
+		chain.NodePool.FinalizedBlockPollInterval = config.MustNewDuration(500 * time.Millisecond)
+	}
 	return &v2toml.EVMConfig{
 		ChainID: chainIDBig,
 		Enabled: ptr(true),
@@ -1,20 +1,29 @@
 package memory
 
 import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/consul/sdk/freeport"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap/zapcore"
 
+	commoncap "github.com/smartcontractkit/chainlink-common/pkg/capabilities"
 	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+
 	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
 )
 
 func TestNode(t *testing.T) {
 	chains := NewMemoryChains(t, 3)
 	ports := freeport.GetN(t, 1)
-	node := NewNode(t, ports[0], chains, zapcore.DebugLevel, false, deployment.CapabilityRegistryConfig{})
+	node := NewNode(t, ports[0], chains, zapcore.DebugLevel, false, deployment.CapabilityRegistryConfig{}, NodeOptions{})
 	// We expect 3 transmitter keys
 	keys, err := node.App.GetKeyStore().Eth().GetAll(tests.Context(t))
 	require.NoError(t, err)
@@ -24,3 +33,101 @@ func TestNode(t *testing.T) {
 	require.NoError(t, err)
 	require.Len(t, evmChains, 3)
 }
+
+type testTarget struct {
+	commoncap.CapabilityInfo
+}
+
+func (testTarget) RegisterToWorkflow(context.Context, commoncap.RegisterToWorkflowRequest) error {
+	return nil
+}
+
+func (testTarget) UnregisterFromWorkflow(context.Context, commoncap.UnregisterFromWorkflowRequest) error {
+	return nil
+}
+
+func (testTarget) Execute(context.Context, commoncap.CapabilityRequest) (commoncap.CapabilityResponse, error) {
+	return commoncap.CapabilityResponse{}, nil
+}
+
+func TestNodeWithPlugins(t *testing.T) {
+	chains := NewMemoryChains(t, 1)
+	ports := freeport.GetN(t, 1)
+	loop := filepath.Join(t.TempDir(), "experimental-plugin")
+	require.NoError(t, os.WriteFile(loop, []byte("#!/bin/sh\n"), 0o700))
+
+	node := NewNode(t, ports[0], chains, zapcore.DebugLevel, false, deployment.CapabilityRegistryConfig{}, NodeOptions{}, PluginRegistry{
+		LOOPs: map[string]string{"experimental": loop},
+		Capabilities: []CapabilityFactory{func(logger.Logger) (commoncap.BaseCapability, error) {
+			return testTarget{commoncap.MustNewCapabilityInfo("experimental-target@1.0.0", commoncap.CapabilityTypeTarget, "test")}, nil
+		}},
+	})
+	cmd, ok := node.LOOPCommand("experimental")
+	require.True(t, ok)
+	require.Equal(t, loop, cmd)
+	_, ok = node.LOOPCommand("unknown")
+	require.False(t, ok)
+	registered, ok := node.App.GetLoopRegistry().Get("experimental")
+	require.True(t, ok)
+	require.NotZero(t, registered.EnvCfg.PrometheusPort)
+
+	target, err := node.CapabilitiesRegistry.GetTarget(tests.Context(t), "experimental-target@1.0.0")
+	require.NoError(t, err)
+	require.NotNil(t, target)
+}
+
+func TestPluginRegistryValidate(t *testing.T) {
+	require.NoError(t, PluginRegistry{}.Validate())
+	require.Error(t, PluginRegistry{LOOPs: map[string]string{"experimental": ""}}.Validate())
+	require.Error(t, PluginRegistry{LOOPs: map[string]string{"experimental": "/does/not/exist"}}.Validate())
+}
+
+func TestNodeRestart(t *testing.T) {
+	ctx := tests.Context(t)
+	chains := NewMemoryChains(t, 1)
+	ports := freeport.GetN(t, 1)
+	node := NewNode(t, ports[0], chains, zapcore.DebugLevel, false, deployment.CapabilityRegistryConfig{}, NodeOptions{})
+	require.NoError(t, node.Start(ctx))
+	require.Error(t, node.Start(ctx))
+	app := node.App
+
+	require.NoError(t, node.Restart(ctx))
+	t.Cleanup(func() {
+		require.NoError(t, node.Stop())
+	})
+	require.True(t, node.Running())
+	require.NotSame(t, app, node.App)
+	// the keys of the node are preserved
+	keys, err := node.App.GetKeyStore().Eth().GetAll(ctx)
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	for _, transmitter := range node.Keys.TransmittersByEVMChainID {
+		require.Equal(t, transmitter, keys[0].Address)
+	}
+}
+
+func TestNodeConfigOverrides(t *testing.T) {
+	require.ErrorContains(t, NodeOptions{ConfigOverrides: []string{"[OCR2]\nUnknownField = true"}}.Validate(), "invalid config override 0")
+
+	chains := NewMemoryChains(t, 1)
+	var chainID uint64
+	for sel := range chains {
+		var err error
+		chainID, err = deployment.EVMChainID(sel)
+		require.NoError(t, err)
+	}
+	ports := freeport.GetN(t, 1)
+	node := NewNode(t, ports[0], chains, zapcore.DebugLevel, false, deployment.CapabilityRegistryConfig{}, NodeOptions{
+		ConfigOverrides: []string{
+			"[OCR2]\nContractPollInterval = '7s'",
+			fmt.Sprintf("[[EVM]]\nChainID = '%d'\n[EVM.NodePool]\nSelectionMode = 'RoundRobin'", chainID),
+		},
+	})
+	cfg := node.App.GetConfig()
+	require.Equal(t, 7*time.Second, cfg.OCR2().ContractPollInterval())
+	evmChain, err := node.App.GetRelayers().LegacyEVMChains().Get(strconv.FormatUint(chainID, 10))
+	require.NoError(t, err)
+	require.Equal(t, "RoundRobin", evmChain.Config().EVM().NodePool().SelectionMode())
+	// the memory defaults are preserved
+	require.True(t, cfg.Feature().LogPoller())
+}
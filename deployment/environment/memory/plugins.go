@@ -0,0 +1,68 @@
+package memory
+
+import (
+	"fmt"
+	"os"
+
+	commoncap "github.com/smartcontractkit/chainlink-common/pkg/capabilities"
+
+	"github.com/smartcontractkit/chainlink/v2/core/capabilities/ccip/oraclecreator"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+// CapabilityFactory creates an in-process capability for a memory node.
+type CapabilityFactory func(lggr logger.Logger) (commoncap.BaseCapability, error)
+
+// PluginRegistry makes experimental plugins available to a memory node, so that new capabilities
+// can be integration tested in a memory environment before being wired into the main build.
+type PluginRegistry struct {
+	// LOOPs maps plugin names to the path of their binaries. Job specs launching LOOP plugins,
+	// e.g. standard capabilities, resolve the command to run with Node.LOOPCommand.
+	LOOPs map[string]string
+	// Capabilities are registered with the capabilities registry of the node once it is created.
+	Capabilities []CapabilityFactory
+	// RMNPeerClient, if set, connects the CCIP commit plugins of the node to in-process RMN nodes
+	// instead of RMN nodes reachable over p2p.
+	RMNPeerClient oraclecreator.NewRMNPeerClientFn
+	// PluginTelemetry, if set, receives the round data of the CCIP plugins of the node, see PluginTelemetry.
+	PluginTelemetry oraclecreator.PluginTelemetrySink
+}
+
+// NodePlugins returns the plugins of a node, given its index among the bootstrap or plugin nodes.
+type NodePlugins func(idx int, isBootstrap bool) PluginRegistry
+
+func (r PluginRegistry) Validate() error {
+	for name, cmd := range r.LOOPs {
+		if cmd == "" {
+			return fmt.Errorf("no binary set for LOOP plugin %s", name)
+		}
+		if _, err := os.Stat(cmd); err != nil {
+			return fmt.Errorf("invalid binary for LOOP plugin %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// merge returns the plugins of both registries, the LOOPs, RMN peer client and plugin telemetry of o
+// taking precedence.
+func (r PluginRegistry) merge(o PluginRegistry) PluginRegistry {
+	merged := PluginRegistry{
+		LOOPs: make(map[string]string, len(r.LOOPs)+len(o.LOOPs)),
+	}
+	for name, cmd := range r.LOOPs {
+		merged.LOOPs[name] = cmd
+	}
+	for name, cmd := range o.LOOPs {
+		merged.LOOPs[name] = cmd
+	}
+	merged.Capabilities = append(append(merged.Capabilities, r.Capabilities...), o.Capabilities...)
+	merged.RMNPeerClient = r.RMNPeerClient
+	if o.RMNPeerClient != nil {
+		merged.RMNPeerClient = o.RMNPeerClient
+	}
+	merged.PluginTelemetry = r.PluginTelemetry
+	if o.PluginTelemetry != nil {
+		merged.PluginTelemetry = o.PluginTelemetry
+	}
+	return merged
+}
@@ -0,0 +1,27 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+)
+
+// AsBackend returns the simulated backend of a memory chain client, also when it is instrumented or simulates
+// transactions.
+func AsBackend(client deployment.OnchainClient) (*Backend, bool) {
+	backend, ok := deployment.UnwrapClient(client).(*Backend)
+	return backend, ok
+}
+
+// InstrumentRPC counts the RPC calls made to the chains of the environment for the rest of the test
+// and logs a summary of them when it ends. Use the counter to set phases and check budgets.
+func InstrumentRPC(t *testing.T, e *deployment.Environment) *deployment.RPCCallCounter {
+	counter := deployment.NewRPCCallCounter()
+	counter.Instrument(e.Chains)
+	// the cached bindings use the clients before instrumentation
+	e.StateCache.Invalidate(e.AllChainSelectors()...)
+	t.Cleanup(func() {
+		t.Logf("RPC calls:\n%s", counter.Summary())
+	})
+	return counter
+}
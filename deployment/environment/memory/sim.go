@@ -4,6 +4,7 @@ import (
 	"context"
 	"math/big"
 	"sync"
+	"time"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
@@ -14,8 +15,9 @@ import (
 // Backend is a wrapper struct which implements
 // OnchainClient but also exposes backend methods.
 type Backend struct {
-	mu  sync.Mutex
-	Sim *simulated.Backend
+	mu       sync.Mutex
+	Sim      *simulated.Backend
+	finality FinalityConfig
 }
 
 func (b *Backend) Commit() common.Hash {
@@ -49,7 +51,7 @@ func (b *Backend) SendTransaction(ctx context.Context, tx *types.Transaction) er
 }
 
 func (b *Backend) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
-	return b.Sim.Client().HeaderByNumber(ctx, number)
+	return finalityHeaderByNumber(ctx, b.Sim.Client(), b.finality, number)
 }
 
 func (b *Backend) PendingCodeAt(ctx context.Context, account common.Address) ([]byte, error) {
@@ -80,11 +82,55 @@ func (b *Backend) NonceAt(ctx context.Context, account common.Address, blockNumb
 	return b.Sim.Client().NonceAt(ctx, account, blockNumber)
 }
 
+func (b *Backend) ChainID(ctx context.Context) (*big.Int, error) {
+	return b.Sim.Client().ChainID(ctx)
+}
+
 func NewBackend(sim *simulated.Backend) *Backend {
+	return NewBackendWithFinality(sim, FinalityConfig{})
+}
+
+// NewBackendWithFinality is NewBackend emulating the finality of the config.
+func NewBackendWithFinality(sim *simulated.Backend, finality FinalityConfig) *Backend {
 	if sim == nil {
 		panic("simulated backend is nil")
 	}
 	return &Backend{
-		Sim: sim,
+		Sim:      sim,
+		finality: finality,
 	}
 }
+
+// Finality returns the finality emulated by the backend.
+func (b *Backend) Finality() FinalityConfig {
+	return b.finality
+}
+
+// Head returns the hash of the latest block, to revert the chain to it with Revert.
+func (b *Backend) Head(ctx context.Context) (common.Hash, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	header, err := b.Sim.Client().HeaderByNumber(ctx, nil)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return header.Hash(), nil
+}
+
+// Revert drops the pending transactions and the blocks after the block with the hash,
+// which becomes the latest block.
+func (b *Backend) Revert(hash common.Hash) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.Sim.Rollback()
+	return b.Sim.Fork(hash)
+}
+
+// AdjustTime mines the pending transactions and then an empty block the duration after the latest one,
+// e.g. to let the rate limiters of the contracts refill. The following blocks are timestamped from it.
+func (b *Backend) AdjustTime(d time.Duration) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.Sim.Commit()
+	return b.Sim.AdjustTime(d)
+}
@@ -0,0 +1,30 @@
+package memory
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+)
+
+func TestBackendAdjustTime(t *testing.T) {
+	ctx := tests.Context(t)
+	for _, chain := range NewMemoryChains(t, 1) {
+		backend, ok := AsBackend(chain.Client)
+		require.True(t, ok)
+		before, err := backend.HeaderByNumber(ctx, nil)
+		require.NoError(t, err)
+		require.NoError(t, backend.AdjustTime(time.Hour))
+		after, err := backend.HeaderByNumber(ctx, nil)
+		require.NoError(t, err)
+		require.GreaterOrEqual(t, after.Time, before.Time+uint64(time.Hour/time.Second))
+
+		// the following blocks keep the time
+		backend.Commit()
+		next, err := backend.HeaderByNumber(ctx, nil)
+		require.NoError(t, err)
+		require.Greater(t, next.Time, after.Time)
+	}
+}
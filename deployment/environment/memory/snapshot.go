@@ -0,0 +1,237 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+)
+
+// snapshotSchemas are the schemas of the node databases copied by snapshots.
+var snapshotSchemas = []string{"public", "evm"}
+
+// snapshotCounter numbers the snapshots, to name the schemas of their copies of the node databases.
+var snapshotCounter atomic.Int64
+
+// EnvironmentSnapshot is the state of a memory environment: the blocks of its chains,
+// its address book and the databases of its nodes. Taking a snapshot after an expensive setup
+// and restoring it in every test case saves running the setup again, e.g.
+//
+//	e := changeset.NewMemoryEnvironmentWithJobsAndContracts(t, lggr, 2, 4, nil)
+//	snapshot := memory.SnapshotEnvironment(t, e.Env)
+//	t.Run("case", func(t *testing.T) {
+//		snapshot.Restore(t, e.Env)
+//		...
+//	})
+type EnvironmentSnapshot struct {
+	heads     map[uint64]common.Hash
+	addresses map[uint64]map[string]deployment.TypeAndVersion
+	dbs       map[string]dbSnapshot
+}
+
+// dbSnapshot is a copy of a node database in a schema of the database.
+type dbSnapshot struct {
+	schema    string
+	tables    []string
+	sequences []sequenceValue
+}
+
+type sequenceValue struct {
+	name     string
+	value    int64
+	isCalled bool
+}
+
+// SnapshotEnvironment snapshots the state of the memory environment. The running nodes are stopped
+// while their databases are copied, so that the copies are consistent with the chains.
+func SnapshotEnvironment(t *testing.T, e deployment.Environment) *EnvironmentSnapshot {
+	ctx := tests.Context(t)
+	s := &EnvironmentSnapshot{
+		heads: make(map[uint64]common.Hash),
+		dbs:   make(map[string]dbSnapshot),
+	}
+	var err error
+	s.addresses, err = e.ExistingAddresses.Addresses()
+	require.NoError(t, err)
+	withNodesStopped(t, e, func(nodes map[string]Node) {
+		for sel, chain := range e.Chains {
+			backend, ok := AsBackend(chain.Client)
+			require.True(t, ok, "chain %d is not a memory chain", sel)
+			s.heads[sel], err = backend.Head(ctx)
+			require.NoError(t, err)
+		}
+		id := snapshotCounter.Add(1)
+		for nodeID, n := range nodes {
+			snapshot, err := snapshotDB(ctx, n.db, fmt.Sprintf("env_snapshot_%d", id))
+			require.NoError(t, err, "failed to snapshot database of node %s", nodeID)
+			s.dbs[nodeID] = snapshot
+		}
+	})
+	return s
+}
+
+// Restore reverts the memory environment to the snapshot. The running nodes are restarted
+// from the restored databases.
+func (s *EnvironmentSnapshot) Restore(t *testing.T, e deployment.Environment) {
+	ctx := tests.Context(t)
+	withNodesStopped(t, e, func(nodes map[string]Node) {
+		for sel, head := range s.heads {
+			backend, ok := AsBackend(e.Chains[sel].Client)
+			require.True(t, ok, "chain %d is not a memory chain", sel)
+			require.NoError(t, backend.Revert(head), "failed to revert chain %d", sel)
+			if nonces := e.Chains[sel].Nonces; nonces != nil {
+				_, err := nonces.Resync(ctx, e.Chains[sel].DeployerKey.From)
+				require.NoError(t, err)
+			}
+		}
+		for nodeID, snapshot := range s.dbs {
+			n, ok := nodes[nodeID]
+			require.True(t, ok, "node %s of the snapshot not found", nodeID)
+			require.NoError(t, restoreDB(ctx, n.db, snapshot), "failed to restore database of node %s", nodeID)
+		}
+	})
+	require.NoError(t, restoreAddresses(e.ExistingAddresses, s.addresses))
+	// the cached states were loaded from the reverted chains, with the same addresses for the chains
+	// whose addresses did not change since the snapshot
+	e.StateCache.Invalidate(e.AllChainSelectors()...)
+}
+
+// withNodesStopped runs fn with the nodes of the environment stopped and starts the ones which were running again.
+func withNodesStopped(t *testing.T, e deployment.Environment, fn func(nodes map[string]Node)) {
+	jc, ok := e.Offchain.(*JobClient)
+	if !ok {
+		fn(nil)
+		return
+	}
+	var running []string
+	for id, n := range jc.Nodes {
+		if n.Running() {
+			running = append(running, id)
+		}
+	}
+	require.NoError(t, jc.StopNodes())
+	fn(jc.Nodes)
+	for _, id := range running {
+		require.NoError(t, jc.StartNode(tests.Context(t), id))
+	}
+}
+
+func restoreAddresses(ab deployment.AddressBook, snapshot map[uint64]map[string]deployment.TypeAndVersion) error {
+	current, err := ab.Addresses()
+	if err != nil {
+		return err
+	}
+	if added := subtractAddresses(current, snapshot); len(added) > 0 {
+		if err := ab.Remove(deployment.NewMemoryAddressBookFromMap(added)); err != nil {
+			return fmt.Errorf("failed to remove addresses added after the snapshot: %w", err)
+		}
+	}
+	if removed := subtractAddresses(snapshot, current); len(removed) > 0 {
+		if err := ab.Merge(deployment.NewMemoryAddressBookFromMap(removed)); err != nil {
+			return fmt.Errorf("failed to save addresses removed after the snapshot: %w", err)
+		}
+	}
+	return nil
+}
+
+// subtractAddresses returns the addresses of a which are not in b.
+func subtractAddresses(a, b map[uint64]map[string]deployment.TypeAndVersion) map[uint64]map[string]deployment.TypeAndVersion {
+	out := make(map[uint64]map[string]deployment.TypeAndVersion)
+	for sel, addresses := range a {
+		for addr, tv := range addresses {
+			if _, ok := b[sel][addr]; ok {
+				continue
+			}
+			if out[sel] == nil {
+				out[sel] = make(map[string]deployment.TypeAndVersion)
+			}
+			out[sel][addr] = tv
+		}
+	}
+	return out
+}
+
+// snapshotDB copies the tables of the database into the schema and records the values of its sequences.
+func snapshotDB(ctx context.Context, db *sqlx.DB, schema string) (dbSnapshot, error) {
+	snapshot := dbSnapshot{schema: schema}
+	tx, err := db.BeginTxx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead})
+	if err != nil {
+		return snapshot, err
+	}
+	defer tx.Rollback() //nolint:errcheck // no-op once committed
+	if err := tx.SelectContext(ctx, &snapshot.tables, `SELECT format('%I.%I', schemaname, tablename) FROM pg_tables
+		WHERE schemaname = ANY(string_to_array($1, ',')) ORDER BY 1`, strings.Join(snapshotSchemas, ",")); err != nil {
+		return snapshot, fmt.Errorf("failed to list tables: %w", err)
+	}
+	rows, err := tx.QueryContext(ctx, `SELECT format('%I.%I', schemaname, sequencename), COALESCE(last_value, start_value), last_value IS NOT NULL
+		FROM pg_sequences WHERE schemaname = ANY(string_to_array($1, ','))`, strings.Join(snapshotSchemas, ","))
+	if err != nil {
+		return snapshot, fmt.Errorf("failed to list sequences: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var seq sequenceValue
+		if err := rows.Scan(&seq.name, &seq.value, &seq.isCalled); err != nil {
+			return snapshot, err
+		}
+		snapshot.sequences = append(snapshot.sequences, seq)
+	}
+	if err := rows.Err(); err != nil {
+		return snapshot, err
+	}
+	if _, err := tx.ExecContext(ctx, "CREATE SCHEMA "+quoteIdentifier(schema)); err != nil {
+		return snapshot, fmt.Errorf("failed to create snapshot schema: %w", err)
+	}
+	for _, table := range snapshot.tables {
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf("CREATE TABLE %s.%s AS TABLE %s",
+			quoteIdentifier(schema), quoteIdentifier(table), table)); err != nil {
+			return snapshot, fmt.Errorf("failed to copy table %s: %w", table, err)
+		}
+	}
+	return snapshot, tx.Commit()
+}
+
+// restoreDB replaces the content of the tables of the database by their copies and resets its sequences.
+// Foreign keys are not checked while the tables are filled in, they hold in the copies.
+func restoreDB(ctx context.Context, db *sqlx.DB, snapshot dbSnapshot) error {
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint:errcheck // no-op once committed
+	if _, err := tx.ExecContext(ctx, "SET LOCAL session_replication_role = replica"); err != nil {
+		return fmt.Errorf("failed to disable triggers: %w", err)
+	}
+	if len(snapshot.tables) == 0 {
+		return tx.Commit()
+	}
+	if _, err := tx.ExecContext(ctx, "TRUNCATE "+strings.Join(snapshot.tables, ", ")); err != nil {
+		return fmt.Errorf("failed to truncate tables: %w", err)
+	}
+	for _, table := range snapshot.tables {
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf("INSERT INTO %s OVERRIDING SYSTEM VALUE SELECT * FROM %s.%s",
+			table, quoteIdentifier(snapshot.schema), quoteIdentifier(table))); err != nil {
+			return fmt.Errorf("failed to restore table %s: %w", table, err)
+		}
+	}
+	for _, seq := range snapshot.sequences {
+		if _, err := tx.ExecContext(ctx, "SELECT setval($1::regclass, $2, $3)", seq.name, seq.value, seq.isCalled); err != nil {
+			return fmt.Errorf("failed to reset sequence %s: %w", seq.name, err)
+		}
+	}
+	return tx.Commit()
+}
+
+func quoteIdentifier(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+}
@@ -0,0 +1,89 @@
+package memory
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+func TestEnvironmentSnapshot(t *testing.T) {
+	ctx := tests.Context(t)
+	e := NewMemoryEnvironmentFromChainsNodes(t, logger.TestLogger(t), NewMemoryChains(t, 1), nil)
+	tv := deployment.NewTypeAndVersion("Contract", deployment.Version1_0_0)
+	var chain deployment.Chain
+	for _, c := range e.Chains {
+		chain = c
+	}
+	require.NoError(t, e.ExistingAddresses.Save(chain.Selector, common.HexToAddress("0x01").Hex(), tv))
+	recipient := common.HexToAddress("0xbeef")
+	send := func() {
+		nonce, err := chain.Client.PendingNonceAt(ctx, chain.DeployerKey.From)
+		require.NoError(t, err)
+		tx, err := chain.DeployerKey.Signer(chain.DeployerKey.From, types.NewTx(&types.LegacyTx{
+			Nonce: nonce, GasPrice: big.NewInt(1e9), Gas: 21000, To: &recipient, Value: big.NewInt(1),
+		}))
+		require.NoError(t, err)
+		require.NoError(t, chain.Client.SendTransaction(ctx, tx))
+		_, err = chain.Confirm(tx)
+		require.NoError(t, err)
+	}
+	send()
+
+	snapshot := SnapshotEnvironment(t, e)
+	latest, err := chain.Client.HeaderByNumber(ctx, nil)
+	require.NoError(t, err)
+	addresses, err := e.ExistingAddresses.Addresses()
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		send()
+		require.NoError(t, e.ExistingAddresses.Save(chain.Selector, common.HexToAddress("0x02").Hex(), tv))
+
+		snapshot.Restore(t, e)
+		restored, err := chain.Client.HeaderByNumber(ctx, nil)
+		require.NoError(t, err)
+		require.Equal(t, latest.Hash(), restored.Hash())
+		balance, err := chain.Client.BalanceAt(ctx, recipient, nil)
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(1), balance)
+		restoredAddresses, err := e.ExistingAddresses.Addresses()
+		require.NoError(t, err)
+		require.Equal(t, addresses, restoredAddresses)
+	}
+}
+
+func TestEnvironmentSnapshotInvalidatesState(t *testing.T) {
+	e := NewMemoryEnvironmentFromChainsNodes(t, logger.TestLogger(t), NewMemoryChains(t, 1), nil)
+	e.StateCache = deployment.NewStateCache()
+	sel := e.AllChainSelectors()[0]
+	require.NoError(t, e.ExistingAddresses.Save(sel, common.HexToAddress("0x01").Hex(),
+		deployment.NewTypeAndVersion("Contract", deployment.Version1_0_0)))
+	snapshot := SnapshotEnvironment(t, e)
+
+	loads := 0
+	load := func() {
+		addresses, err := e.ExistingAddresses.AddressesForChain(sel)
+		require.NoError(t, err)
+		_, err = e.StateCache.LoadChain("test", sel, addresses, func() (any, error) {
+			loads++
+			return loads, nil
+		})
+		require.NoError(t, err)
+	}
+	load()
+	load()
+	require.Equal(t, 1, loads)
+
+	// the addresses are unchanged, but the state was loaded from the chain as it was after the snapshot
+	snapshot.Restore(t, e)
+	load()
+	require.Equal(t, 2, loads)
+}
@@ -0,0 +1,205 @@
+package memory
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+	chainsel "github.com/smartcontractkit/chain-selectors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+)
+
+// solLocalnetSelector is the selector of the first simulated Solana chain, which is unknown to chain-selectors.
+const solLocalnetSelector uint64 = 8834117563287105413
+
+// SimSolClient is an in-memory Solana chain implementing deployment.SolClient. Instructions to deployed
+// programs succeed and are recorded, and they create the writable accounts they reference which don't
+// exist yet, like the state accounts initialized by Anchor programs. No program is executed: instructions
+// are only checked against the IDLs of the programs registered with ExpectIDL, see NewSolanaLocalnetChain
+// for a chain executing them.
+type SimSolClient struct {
+	mu           sync.Mutex
+	programs     map[solana.PublicKey][]byte
+	accounts     map[solana.PublicKey][]byte
+	lookupTables map[solana.PublicKey][]solana.PublicKey
+	instructions []solana.Instruction
+	idls         map[[sha256.Size]byte]*deployment.AnchorIDL
+}
+
+var _ deployment.SolClient = (*SimSolClient)(nil)
+
+func NewSimSolClient() *SimSolClient {
+	return &SimSolClient{
+		programs:     make(map[solana.PublicKey][]byte),
+		accounts:     make(map[solana.PublicKey][]byte),
+		lookupTables: make(map[solana.PublicKey][]solana.PublicKey),
+		idls:         make(map[[sha256.Size]byte]*deployment.AnchorIDL),
+	}
+}
+
+// ExpectIDL makes the instructions sent to the programs deployed or upgraded from the ELF fail unless
+// they are instructions of the IDL.
+func (c *SimSolClient) ExpectIDL(elf []byte, idl *deployment.AnchorIDL) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.idls[sha256.Sum256(elf)] = idl
+}
+
+func (c *SimSolClient) DeployProgram(_ context.Context, elf []byte) (solana.PublicKey, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	programID, err := randomSolKey()
+	if err != nil {
+		return solana.PublicKey{}, err
+	}
+	c.programs[programID] = elf
+	return programID, nil
+}
+
+func (c *SimSolClient) UpgradeProgram(_ context.Context, programID solana.PublicKey, elf []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.programs[programID]; !ok {
+		return fmt.Errorf("program %s not found", programID)
+	}
+	c.programs[programID] = elf
+	return nil
+}
+
+func (c *SimSolClient) SendInstructions(_ context.Context, instructions []solana.Instruction) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, ix := range instructions {
+		elf, ok := c.programs[ix.ProgramID()]
+		if !ok {
+			return "", fmt.Errorf("program %s not found", ix.ProgramID())
+		}
+		if idl, ok := c.idls[sha256.Sum256(elf)]; ok {
+			data, err := ix.Data()
+			if err != nil {
+				return "", err
+			}
+			if _, _, err := idl.DecodeInstruction(data); err != nil {
+				return "", fmt.Errorf("invalid instruction to program %s: %w", ix.ProgramID(), err)
+			}
+		}
+	}
+	for _, ix := range instructions {
+		data, err := ix.Data()
+		if err != nil {
+			return "", err
+		}
+		for _, meta := range ix.Accounts() {
+			if _, ok := c.accounts[meta.PublicKey]; !ok && meta.IsWritable && !meta.IsSigner {
+				c.accounts[meta.PublicKey] = data
+			}
+		}
+		c.instructions = append(c.instructions, ix)
+	}
+	signature, err := randomSolKey()
+	return signature.String(), err
+}
+
+func (c *SimSolClient) GetAccountData(_ context.Context, account solana.PublicKey) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.accounts[account], nil
+}
+
+func (c *SimSolClient) CreateLookupTable(_ context.Context, addresses []solana.PublicKey) (solana.PublicKey, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	table, err := randomSolKey()
+	if err != nil {
+		return solana.PublicKey{}, err
+	}
+	c.lookupTables[table] = append([]solana.PublicKey{}, addresses...)
+	return table, nil
+}
+
+func (c *SimSolClient) ExtendLookupTable(_ context.Context, table solana.PublicKey, addresses []solana.PublicKey) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.lookupTables[table]; !ok {
+		return fmt.Errorf("lookup table %s not found", table)
+	}
+	c.lookupTables[table] = append(c.lookupTables[table], addresses...)
+	return nil
+}
+
+func (c *SimSolClient) GetLookupTable(_ context.Context, table solana.PublicKey) ([]solana.PublicKey, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	addresses, ok := c.lookupTables[table]
+	if !ok {
+		return nil, fmt.Errorf("lookup table %s not found", table)
+	}
+	return append([]solana.PublicKey{}, addresses...), nil
+}
+
+// SetAccountData sets the data of the account, e.g. of an account of a program deployed by other means.
+func (c *SimSolClient) SetAccountData(account solana.PublicKey, data []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.accounts[account] = data
+}
+
+// Program returns the ELF of the deployed program, nil if it isn't deployed.
+func (c *SimSolClient) Program(programID solana.PublicKey) []byte {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.programs[programID]
+}
+
+// Instructions returns the instructions sent to the program, in order.
+func (c *SimSolClient) Instructions(programID solana.PublicKey) []solana.Instruction {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var instructions []solana.Instruction
+	for _, ix := range c.instructions {
+		if ix.ProgramID() == programID {
+			instructions = append(instructions, ix)
+		}
+	}
+	return instructions
+}
+
+func randomSolKey() (solana.PublicKey, error) {
+	var key solana.PublicKey
+	_, err := rand.Read(key[:])
+	return key, err
+}
+
+// NewMemorySolChains returns simulated Solana chains, see SimSolClient. The chains are registered as custom chains.
+func NewMemorySolChains(t *testing.T, numChains int) map[uint64]deployment.SolChain {
+	chains := make(map[uint64]deployment.SolChain)
+	for i := 0; i < numChains; i++ {
+		sel := solLocalnetSelector + uint64(i)
+		require.NoError(t, deployment.RegisterCustomChains(deployment.CustomChain{
+			Selector: sel,
+			ChainID:  fmt.Sprintf("solana-localnet-%d", i+1),
+			Family:   chainsel.FamilySolana,
+			Name:     fmt.Sprintf("solana-localnet-%d", i+1),
+		}))
+		deployer, err := randomSolKey()
+		require.NoError(t, err)
+		chains[sel] = deployment.SolChain{
+			Selector:    sel,
+			Client:      NewSimSolClient(),
+			DeployerKey: deployer,
+		}
+	}
+	return chains
+}
+
+// AsSimSolClient returns the simulated chain of a memory Solana chain.
+func AsSimSolClient(chain deployment.SolChain) (*SimSolClient, bool) {
+	c, ok := deployment.UnwrapSolClient(chain.Client).(*SimSolClient)
+	return c, ok
+}
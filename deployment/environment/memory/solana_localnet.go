@@ -0,0 +1,93 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	chainsel "github.com/smartcontractkit/chain-selectors"
+	"github.com/stretchr/testify/require"
+	tc "github.com/testcontainers/testcontainers-go"
+	tcwait "github.com/testcontainers/testcontainers-go/wait"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/deployment/environment/devenv"
+)
+
+const (
+	// SolanaLocalnetURLEnv is the URL of the JSON RPC API of a running solana-test-validator, e.g.
+	// http://127.0.0.1:8899. If unset, NewSolanaLocalnetChain starts a validator in a container.
+	SolanaLocalnetURLEnv = "SOLANA_LOCALNET_URL"
+	// SolanaLocalnetImage is the image of the Solana CLI the validator is run with.
+	SolanaLocalnetImage = "solanalabs/solana:v1.18.26"
+
+	solLocalnetFunding = 100 * solana.LAMPORTS_PER_SOL
+	solLocalnetTimeout = 2 * time.Minute
+)
+
+// NewSolanaLocalnetChain returns a Solana chain backed by a solana-test-validator, whose deployer is a new key
+// funded by an airdrop. The validator is the one of SolanaLocalnetURLEnv, or else one started in a container
+// for the test. Unlike the simulated chains, the programs are executed and the instructions must match them.
+func NewSolanaLocalnetChain(t *testing.T) deployment.SolChain {
+	url := os.Getenv(SolanaLocalnetURLEnv)
+	if url == "" {
+		url = startSolanaLocalnet(t)
+	}
+	require.NoError(t, deployment.RegisterCustomChains(deployment.CustomChain{
+		Selector: solLocalnetSelector,
+		ChainID:  "solana-localnet-1",
+		Family:   chainsel.FamilySolana,
+		Name:     "solana-localnet-1",
+	}))
+	key, err := solana.NewRandomPrivateKey()
+	require.NoError(t, err)
+	client := deployment.NewRPCSolClient(url, key)
+	fundSolAccount(t, client, solLocalnetFunding)
+	return deployment.SolChain{
+		Selector:    solLocalnetSelector,
+		Client:      client,
+		DeployerKey: client.PublicKey(),
+	}
+}
+
+// startSolanaLocalnet runs a solana-test-validator for the duration of the test and returns the URL of its API.
+func startSolanaLocalnet(t *testing.T) string {
+	ctx := context.Background()
+	reaper := devenv.ReaperFor(t, logger.Test(t))
+	container, err := tc.GenericContainer(ctx, tc.GenericContainerRequest{
+		ContainerRequest: tc.ContainerRequest{
+			Image:        SolanaLocalnetImage,
+			Labels:       reaper.Labels(),
+			Entrypoint:   []string{"solana-test-validator"},
+			Cmd:          []string{"--reset", "--quiet", "--ledger", "/tmp/ledger", "--bind-address", "0.0.0.0", "--rpc-port", "8899"},
+			ExposedPorts: []string{"8899/tcp"},
+			WaitingFor:   tcwait.ForHTTP("/health").WithPort("8899/tcp").WithStartupTimeout(solLocalnetTimeout),
+		},
+		Started: true,
+	})
+	require.NoError(t, err)
+	reaper.TrackContainer(container)
+	host, err := container.Host(ctx)
+	require.NoError(t, err)
+	port, err := container.MappedPort(ctx, "8899/tcp")
+	require.NoError(t, err)
+	return fmt.Sprintf("http://%s:%s", host, port.Port())
+}
+
+// fundSolAccount airdrops lamports to the key of the client and waits for them to be confirmed.
+func fundSolAccount(t *testing.T, client *deployment.RPCSolClient, lamports uint64) {
+	ctx, cancel := context.WithTimeout(context.Background(), solLocalnetTimeout)
+	defer cancel()
+	_, err := client.RPC().RequestAirdrop(ctx, client.PublicKey(), lamports, rpc.CommitmentConfirmed)
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		balance, err := client.RPC().GetBalance(ctx, client.PublicKey(), rpc.CommitmentConfirmed)
+		return err == nil && balance.Value >= lamports
+	}, solLocalnetTimeout, time.Second, "failed to fund %s", client.PublicKey())
+}
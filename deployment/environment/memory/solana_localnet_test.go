@@ -0,0 +1,62 @@
+package memory
+
+import (
+	"context"
+	"encoding/binary"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+)
+
+func TestSolanaLocalnet(t *testing.T) {
+	if testing.Short() {
+		t.Skip("starts a solana-test-validator")
+	}
+	ctx := context.Background()
+	chain := NewSolanaLocalnetChain(t)
+
+	missing, err := chain.Client.GetAccountData(ctx, solana.PublicKey{1})
+	require.NoError(t, err)
+	require.Nil(t, missing)
+
+	// the SPL memo program of the genesis of the validator is deployed again, it fails on invalid UTF-8
+	elf := programELF(t, chain, solana.MemoProgramID)
+	programID, err := chain.Client.DeployProgram(ctx, elf)
+	require.NoError(t, err)
+	memo := func(text []byte) solana.Instruction {
+		return solana.NewInstruction(programID, solana.AccountMetaSlice{solana.Meta(chain.DeployerKey).SIGNER()}, text)
+	}
+	require.NoError(t, deployment.SendSolInstructions(chain, memo([]byte("deployed"))))
+	require.Error(t, deployment.SendSolInstructions(chain, memo([]byte{0xff})))
+	require.NoError(t, chain.Client.UpgradeProgram(ctx, programID, elf))
+	require.NoError(t, deployment.SendSolInstructions(chain, memo([]byte("upgraded"))))
+	require.Equal(t, elf, programELF(t, chain, programID)[:len(elf)])
+
+	addresses := make([]solana.PublicKey, 25)
+	for i := range addresses {
+		addresses[i] = solana.PublicKey{byte(i + 1)}
+	}
+	table, err := chain.Client.CreateLookupTable(ctx, addresses[:21])
+	require.NoError(t, err)
+	require.NoError(t, chain.Client.ExtendLookupTable(ctx, table, addresses[21:]))
+	got, err := chain.Client.GetLookupTable(ctx, table)
+	require.NoError(t, err)
+	require.Equal(t, addresses, got)
+}
+
+// programELF returns the ELF of a program, deployed with the BPF loader or the upgradeable one.
+func programELF(t *testing.T, chain deployment.SolChain, programID solana.PublicKey) []byte {
+	data, err := chain.Client.GetAccountData(context.Background(), programID)
+	require.NoError(t, err)
+	// UpgradeableLoaderState::Program { programdata_address }
+	if len(data) != 4+32 || binary.LittleEndian.Uint32(data) != 2 {
+		return data
+	}
+	data, err = chain.Client.GetAccountData(context.Background(), solana.PublicKeyFromBytes(data[4:]))
+	require.NoError(t, err)
+	// UpgradeableLoaderState::ProgramData { slot, upgrade_authority_address }
+	return data[4+8+1+32:]
+}
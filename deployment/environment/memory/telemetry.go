@@ -0,0 +1,99 @@
+package memory
+
+import (
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	cciptypes "github.com/smartcontractkit/chainlink-ccip/pkg/types/ccipocr3"
+
+	"github.com/smartcontractkit/chainlink/v2/core/capabilities/ccip/oraclecreator"
+)
+
+// PluginTelemetry records the round data the CCIP plugins of memory nodes publish, so that tests can
+// assert what the plugins did, e.g.
+//
+//	telemetry := memory.NewPluginTelemetry()
+//	nodes := memory.NewNodesWithPlugins(t, zapcore.InfoLevel, chains, 4, 1, crConfig, nil,
+//		func(int, bool) memory.PluginRegistry { return memory.PluginRegistry{PluginTelemetry: telemetry} })
+//	...
+//	telemetry.WaitForMessageSkipped(t, src, dest, seqNr, "TooCostly", time.Minute)
+//
+// The same PluginTelemetry can be shared by all the nodes, the events carry the DON and oracle publishing them.
+type PluginTelemetry struct {
+	mu     sync.Mutex
+	events []oraclecreator.PluginEvent
+}
+
+var _ oraclecreator.PluginTelemetrySink = (*PluginTelemetry)(nil)
+
+func NewPluginTelemetry() *PluginTelemetry {
+	return &PluginTelemetry{}
+}
+
+// PublishPluginEvent implements oraclecreator.PluginTelemetrySink.
+func (p *PluginTelemetry) PublishPluginEvent(event oraclecreator.PluginEvent) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.events = append(p.events, event)
+}
+
+// Events returns the events published so far matching the filter, in the order they were published.
+// A nil filter matches all the events.
+func (p *PluginTelemetry) Events(filter func(oraclecreator.PluginEvent) bool) []oraclecreator.PluginEvent {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var events []oraclecreator.PluginEvent
+	for _, event := range p.events {
+		if filter == nil || filter(event) {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+// SkippedMessages returns the events of the exec plugins of dest skipping the message of src.
+func (p *PluginTelemetry) SkippedMessages(src, dest, seqNr uint64) []oraclecreator.PluginEvent {
+	return p.Events(func(event oraclecreator.PluginEvent) bool {
+		return event.Kind == oraclecreator.PluginEventMessageSkipped && hasMessage(event, src, dest, seqNr)
+	})
+}
+
+// BatchedMessages returns the outcome events of the plugins of dest batching the message of src,
+// i.e. committing or executing it depending on the plugin.
+func (p *PluginTelemetry) BatchedMessages(src, dest, seqNr uint64) []oraclecreator.PluginEvent {
+	return p.Events(func(event oraclecreator.PluginEvent) bool {
+		return event.Kind == oraclecreator.PluginEventOutcome && hasMessage(event, src, dest, seqNr)
+	})
+}
+
+// Reset drops the events published so far, e.g. between the test cases sharing an environment.
+func (p *PluginTelemetry) Reset() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.events = nil
+}
+
+// WaitForMessageSkipped waits for an exec plugin of dest to skip the message of src for the reason,
+// e.g. the TooCostly message state, and returns the event. An empty reason matches any reason.
+func (p *PluginTelemetry) WaitForMessageSkipped(t *testing.T, src, dest, seqNr uint64, reason string, timeout time.Duration) oraclecreator.PluginEvent {
+	var skipped oraclecreator.PluginEvent
+	require.Eventually(t, func() bool {
+		for _, event := range p.SkippedMessages(src, dest, seqNr) {
+			if reason == "" || event.Reason == reason {
+				skipped = event
+				return true
+			}
+		}
+		return false
+	}, timeout, 100*time.Millisecond, "message %d of lane %d->%d was not skipped (reason %q)", seqNr, src, dest, reason)
+	return skipped
+}
+
+func hasMessage(event oraclecreator.PluginEvent, src, dest, seqNr uint64) bool {
+	return uint64(event.DestChainSelector) == dest &&
+		slices.Contains(event.Messages[cciptypes.ChainSelector(src)], cciptypes.SeqNum(seqNr))
+}
@@ -0,0 +1,42 @@
+package memory
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cciptypes "github.com/smartcontractkit/chainlink-ccip/pkg/types/ccipocr3"
+
+	"github.com/smartcontractkit/chainlink/v2/core/capabilities/ccip/oraclecreator"
+	cctypes "github.com/smartcontractkit/chainlink/v2/core/capabilities/ccip/types"
+)
+
+func TestPluginTelemetry(t *testing.T) {
+	telemetry := NewPluginTelemetry()
+	telemetry.PublishPluginEvent(oraclecreator.PluginEvent{
+		Kind:              oraclecreator.PluginEventOutcome,
+		PluginType:        cctypes.PluginTypeCCIPExec,
+		DestChainSelector: 2,
+		Messages:          map[cciptypes.ChainSelector][]cciptypes.SeqNum{1: {1, 2}},
+	})
+	telemetry.PublishPluginEvent(oraclecreator.PluginEvent{
+		Kind:              oraclecreator.PluginEventMessageSkipped,
+		PluginType:        cctypes.PluginTypeCCIPExec,
+		DestChainSelector: 2,
+		Messages:          map[cciptypes.ChainSelector][]cciptypes.SeqNum{1: {3}},
+		Reason:            "TooCostly",
+	})
+
+	require.Len(t, telemetry.Events(nil), 2)
+	assert.Len(t, telemetry.BatchedMessages(1, 2, 2), 1)
+	assert.Empty(t, telemetry.BatchedMessages(2, 1, 2), "lane reversed")
+	assert.Empty(t, telemetry.SkippedMessages(1, 2, 2))
+
+	skipped := telemetry.WaitForMessageSkipped(t, 1, 2, 3, "TooCostly", time.Second)
+	assert.Equal(t, "TooCostly", skipped.Reason)
+
+	telemetry.Reset()
+	assert.Empty(t, telemetry.Events(nil))
+}
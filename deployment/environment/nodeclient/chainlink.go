@@ -187,6 +187,23 @@ func (c *ChainlinkClient) ReadJob(id string) (*Response, *http.Response, error)
 	return specObj, resp.RawResponse, err
 }
 
+// ReadJobPluginConfig reads the effective plugin config, with secrets redacted, of the OCR2 job with the provided ID
+// from the Chainlink node
+func (c *ChainlinkClient) ReadJobPluginConfig(id string) (*PluginConfigResponse, *http.Response, error) {
+	configObj := &PluginConfigResponse{}
+	c.l.Info().Str(NodeURL, c.Config.URL).Str("ID", id).Msg("Reading Job Plugin Config")
+	resp, err := c.APIClient.R().
+		SetResult(&configObj).
+		SetPathParams(map[string]string{
+			"id": id,
+		}).
+		Get("/v2/jobs/{id}/plugin_config")
+	if err != nil {
+		return nil, nil, err
+	}
+	return configObj, resp.RawResponse, VerifyStatusCodeWithResponse(resp, http.StatusOK)
+}
+
 // MustDeleteJob deletes a job with a provided ID from the Chainlink node and returns error if
 // the request is unsuccessful
 func (c *ChainlinkClient) MustDeleteJob(id string) error {
@@ -1234,3 +1251,21 @@ func (c *ChainlinkClient) ReplayLogPollerFromBlock(fromBlock, evmChainID int64)
 
 	return specObj, resp.RawResponse, err
 }
+
+// ReplayLogPollerRange replays LogPoller logs within the given block range, restricted to the given addresses and event signatures
+func (c *ChainlinkClient) ReplayLogPollerRange(req ReplayRangeRequest, evmChainID int64) (*ReplayResponse, *http.Response, error) {
+	specObj := &ReplayResponse{}
+	c.l.Info().Str(NodeURL, c.Config.URL).Int64("From block", req.FromBlock).Int64("To block", req.ToBlock).Int64("EVM chain ID", evmChainID).Msg("Replaying Log Poller block range")
+	resp, err := c.APIClient.R().
+		SetBody(req).
+		SetResult(&specObj).
+		SetQueryParams(map[string]string{
+			"evmChainID": fmt.Sprint(evmChainID),
+		}).
+		Post("/v2/replay_range")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return specObj, resp.RawResponse, err
+}
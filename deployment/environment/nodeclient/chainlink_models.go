@@ -6,6 +6,7 @@ import (
 	"text/template"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/pelletier/go-toml/v2"
 	"gopkg.in/guregu/null.v4"
 
@@ -46,6 +47,23 @@ type HealthResponse struct {
 	} `json:"data"`
 }
 
+// PluginConfigResponse is the model of the effective plugin config of an OCR2 job
+type PluginConfigResponse struct {
+	Data struct {
+		Type       string `json:"type"`
+		ID         string `json:"id"`
+		Attributes struct {
+			Type            string                 `json:"type"`
+			PluginType      string                 `json:"pluginType"`
+			Relay           string                 `json:"relay"`
+			RelayConfig     map[string]interface{} `json:"relayConfig"`
+			PluginConfig    map[string]interface{} `json:"pluginConfig"`
+			Valid           bool                   `json:"valid"`
+			ValidationError string                 `json:"validationError"`
+		} `json:"attributes"`
+	} `json:"data"`
+}
+
 // Response is the generic model that can be used for all Chainlink API responses
 type Response struct {
 	Data map[string]interface{}
@@ -1495,6 +1513,14 @@ type ForwarderAttributes struct {
 	UpdatedAt time.Time `json:"updatedAt"`
 }
 
+// ReplayRangeRequest is the request body for a targeted LogPoller replay
+type ReplayRangeRequest struct {
+	FromBlock int64            `json:"fromBlock"`
+	ToBlock   int64            `json:"toBlock"`
+	Addresses []common.Address `json:"addresses"`
+	EventSigs []common.Hash    `json:"eventSigs"`
+}
+
 type ReplayResponse struct {
 	Data ReplayResponseData `json:"data"`
 }
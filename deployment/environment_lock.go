@@ -0,0 +1,206 @@
+package deployment
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+// ErrEnvironmentLocked is returned when the lock of an environment is held by someone else.
+var ErrEnvironmentLocked = errors.New("environment is locked")
+
+// EnvironmentLocker hands out advisory locks on environments, so that two operators can't
+// apply changesets to the same environment and address book at the same time.
+type EnvironmentLocker interface {
+	// Lock acquires the lock of the environment without waiting for it,
+	// it fails with ErrEnvironmentLocked if the lock is held.
+	Lock(ctx context.Context, name string) (EnvironmentLock, error)
+}
+
+// EnvironmentLock is a held lock of an environment.
+type EnvironmentLock interface {
+	Unlock() error
+}
+
+// lockHolder describes the holder of a lock, to tell who to ask when it is held.
+func lockHolder() string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+	return fmt.Sprintf("pid %d on %s since %s", os.Getpid(), host, time.Now().UTC().Format(time.RFC3339))
+}
+
+// validateLockName rejects environment names which aren't a single path element, so that the locks of
+// environments can't be created outside of the directory or prefix of a locker.
+func validateLockName(name string) error {
+	if name == "" {
+		return errors.New("environment name is required")
+	}
+	if filepath.Base(name) != name || name == "." || name == ".." || strings.ContainsAny(name, `/\`) {
+		return fmt.Errorf("invalid environment name %q", name)
+	}
+	return nil
+}
+
+// FileLocker locks environments with lock files in a directory, for environments
+// only changed from a single machine. A lock left behind by a crashed process has to be
+// removed by hand, its content tells which process held it.
+type FileLocker struct {
+	Dir string
+}
+
+func (l FileLocker) Lock(_ context.Context, name string) (EnvironmentLock, error) {
+	if err := validateLockName(name); err != nil {
+		return nil, err
+	}
+	path := filepath.Join(l.Dir, name+".lock")
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+	if errors.Is(err, os.ErrExist) {
+		holder, readErr := os.ReadFile(path)
+		if readErr != nil {
+			return nil, fmt.Errorf("%w: %s", ErrEnvironmentLocked, path)
+		}
+		return nil, fmt.Errorf("%w: %s held by %s", ErrEnvironmentLocked, path, holder)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create lock file %s: %w", path, err)
+	}
+	defer f.Close()
+	if _, err := f.WriteString(lockHolder()); err != nil {
+		return nil, errors.Join(fmt.Errorf("failed to write lock file %s: %w", path, err), os.Remove(path))
+	}
+	return fileLock{path: path}, nil
+}
+
+type fileLock struct {
+	path string
+}
+
+func (l fileLock) Unlock() error {
+	return os.Remove(l.path)
+}
+
+// PostgresLocker locks environments with session level Postgres advisory locks, for environments
+// shared by operators on different machines. A lock is released when its connection closes,
+// so locks of crashed processes don't outlive them.
+type PostgresLocker struct {
+	DB *sql.DB
+}
+
+func (l PostgresLocker) Lock(ctx context.Context, name string) (EnvironmentLock, error) {
+	if err := validateLockName(name); err != nil {
+		return nil, err
+	}
+	// advisory locks belong to a session, so lock and unlock on the same connection
+	conn, err := l.DB.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get connection: %w", err)
+	}
+	var locked bool
+	if err := conn.QueryRowContext(ctx, `SELECT pg_try_advisory_lock(hashtext($1))`, name).Scan(&locked); err != nil {
+		return nil, errors.Join(fmt.Errorf("failed to lock environment %s: %w", name, err), conn.Close())
+	}
+	if !locked {
+		return nil, errors.Join(fmt.Errorf("%w: %s", ErrEnvironmentLocked, name), conn.Close())
+	}
+	return postgresLock{conn: conn, name: name}, nil
+}
+
+type postgresLock struct {
+	conn *sql.Conn
+	name string
+}
+
+func (l postgresLock) Unlock() error {
+	var unlocked bool
+	err := l.conn.QueryRowContext(context.Background(), `SELECT pg_advisory_unlock(hashtext($1))`, l.name).Scan(&unlocked)
+	if err == nil && !unlocked {
+		err = fmt.Errorf("environment %s was not locked", l.name)
+	}
+	return errors.Join(err, l.conn.Close())
+}
+
+// S3LockClient is the subset of the S3 API used by S3Locker, implemented by *s3.S3.
+type S3LockClient interface {
+	PutObjectWithContext(ctx aws.Context, input *s3.PutObjectInput, opts ...request.Option) (*s3.PutObjectOutput, error)
+	GetObjectWithContext(ctx aws.Context, input *s3.GetObjectInput, opts ...request.Option) (*s3.GetObjectOutput, error)
+	DeleteObjectWithContext(ctx aws.Context, input *s3.DeleteObjectInput, opts ...request.Option) (*s3.DeleteObjectOutput, error)
+}
+
+// S3Locker locks environments with lock objects in a bucket, for environments shared by operators on different
+// machines without a shared database. The objects are created with conditional writes, so only one of concurrent
+// lockers succeeds. Like lock files, a lock left behind by a crashed process has to be deleted by hand.
+type S3Locker struct {
+	Client S3LockClient
+	Bucket string
+	// Prefix of the keys of the lock objects, e.g. "locks/".
+	Prefix string
+}
+
+func (l S3Locker) Lock(ctx context.Context, name string) (EnvironmentLock, error) {
+	if err := validateLockName(name); err != nil {
+		return nil, err
+	}
+	key := l.Prefix + name + ".lock"
+	_, err := l.Client.PutObjectWithContext(ctx, &s3.PutObjectInput{
+		Bucket: aws.String(l.Bucket),
+		Key:    aws.String(key),
+		Body:   bytes.NewReader([]byte(lockHolder())),
+	}, request.WithSetRequestHeaders(map[string]string{"If-None-Match": "*"}))
+	var reqErr awserr.RequestFailure
+	if errors.As(err, &reqErr) &&
+		(reqErr.StatusCode() == http.StatusPreconditionFailed || reqErr.StatusCode() == http.StatusConflict) {
+		holder, readErr := l.holder(ctx, key)
+		if readErr != nil {
+			return nil, fmt.Errorf("%w: s3://%s/%s", ErrEnvironmentLocked, l.Bucket, key)
+		}
+		return nil, fmt.Errorf("%w: s3://%s/%s held by %s", ErrEnvironmentLocked, l.Bucket, key, holder)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create lock object s3://%s/%s: %w", l.Bucket, key, err)
+	}
+	return s3Lock{client: l.Client, bucket: l.Bucket, key: key}, nil
+}
+
+func (l S3Locker) holder(ctx context.Context, key string) ([]byte, error) {
+	out, err := l.Client.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(l.Bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer out.Body.Close()
+	return io.ReadAll(out.Body)
+}
+
+type s3Lock struct {
+	client S3LockClient
+	bucket string
+	key    string
+}
+
+func (l s3Lock) Unlock() error {
+	_, err := l.client.DeleteObjectWithContext(context.Background(), &s3.DeleteObjectInput{
+		Bucket: aws.String(l.bucket),
+		Key:    aws.String(l.key),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete lock object s3://%s/%s: %w", l.bucket, l.key, err)
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package deployment
+
+import (
+	"context"
+	"database/sql"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/s3"
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileLocker(t *testing.T) {
+	ctx := context.Background()
+	locker := FileLocker{Dir: t.TempDir()}
+	lock, err := locker.Lock(ctx, "testnet")
+	require.NoError(t, err)
+
+	_, err = locker.Lock(ctx, "testnet")
+	require.ErrorIs(t, err, ErrEnvironmentLocked)
+	require.ErrorContains(t, err, "held by pid")
+	// other environments are not affected
+	other, err := locker.Lock(ctx, "mainnet")
+	require.NoError(t, err)
+	require.NoError(t, other.Unlock())
+
+	require.NoError(t, lock.Unlock())
+	lock, err = locker.Lock(ctx, "testnet")
+	require.NoError(t, err)
+	require.NoError(t, lock.Unlock())
+
+	for _, name := range []string{"", ".", "..", "../testnet", "envs/testnet", "/tmp/testnet"} {
+		_, err = locker.Lock(ctx, name)
+		require.Error(t, err, name)
+	}
+}
+
+// fakeS3 honours the If-None-Match condition of object writes, like S3 does.
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string]string
+}
+
+func (f *fakeS3) PutObjectWithContext(_ aws.Context, input *s3.PutObjectInput, opts ...request.Option) (*s3.PutObjectOutput, error) {
+	r := &request.Request{HTTPRequest: &http.Request{Header: http.Header{}}}
+	r.ApplyOptions(opts...)
+	body, err := io.ReadAll(input.Body)
+	if err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.objects[*input.Key]; ok && r.HTTPRequest.Header.Get("If-None-Match") == "*" {
+		return nil, awserr.NewRequestFailure(awserr.New("PreconditionFailed", "At least one of the pre-conditions you specified did not hold", nil), http.StatusPreconditionFailed, "")
+	}
+	f.objects[*input.Key] = string(body)
+	return &s3.PutObjectOutput{}, nil
+}
+
+func (f *fakeS3) GetObjectWithContext(_ aws.Context, input *s3.GetObjectInput, _ ...request.Option) (*s3.GetObjectOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	object, ok := f.objects[*input.Key]
+	if !ok {
+		return nil, awserr.NewRequestFailure(awserr.New(s3.ErrCodeNoSuchKey, "not found", nil), http.StatusNotFound, "")
+	}
+	return &s3.GetObjectOutput{Body: io.NopCloser(strings.NewReader(object))}, nil
+}
+
+func (f *fakeS3) DeleteObjectWithContext(_ aws.Context, input *s3.DeleteObjectInput, _ ...request.Option) (*s3.DeleteObjectOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.objects, *input.Key)
+	return &s3.DeleteObjectOutput{}, nil
+}
+
+func TestS3Locker(t *testing.T) {
+	ctx := context.Background()
+	client := &fakeS3{objects: make(map[string]string)}
+	locker := S3Locker{Client: client, Bucket: "deployments", Prefix: "locks/"}
+	lock, err := locker.Lock(ctx, "testnet")
+	require.NoError(t, err)
+	require.Contains(t, client.objects, "locks/testnet.lock")
+
+	_, err = locker.Lock(ctx, "testnet")
+	require.ErrorIs(t, err, ErrEnvironmentLocked)
+	require.ErrorContains(t, err, "held by pid")
+	other, err := locker.Lock(ctx, "mainnet")
+	require.NoError(t, err)
+	require.NoError(t, other.Unlock())
+
+	require.NoError(t, lock.Unlock())
+	require.Empty(t, client.objects)
+	lock, err = locker.Lock(ctx, "testnet")
+	require.NoError(t, err)
+	require.NoError(t, lock.Unlock())
+
+	_, err = locker.Lock(ctx, "../testnet")
+	require.Error(t, err)
+}
+
+func TestPostgresLocker(t *testing.T) {
+	dbURL := os.Getenv("CL_DATABASE_URL")
+	if dbURL == "" {
+		t.Skip("CL_DATABASE_URL is not set")
+	}
+	ctx := context.Background()
+	db, err := sql.Open("postgres", dbURL)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, db.Close()) })
+	// every lock holds a connection of its own, so the lockers of one pool are different sessions
+	locker := PostgresLocker{DB: db}
+	lock, err := locker.Lock(ctx, "testnet")
+	require.NoError(t, err)
+
+	_, err = locker.Lock(ctx, "testnet")
+	require.ErrorIs(t, err, ErrEnvironmentLocked)
+	other, err := locker.Lock(ctx, "mainnet")
+	require.NoError(t, err)
+	require.NoError(t, other.Unlock())
+
+	require.NoError(t, lock.Unlock())
+	lock, err = locker.Lock(ctx, "testnet")
+	require.NoError(t, err)
+	require.NoError(t, lock.Unlock())
+	require.Error(t, lock.Unlock())
+}
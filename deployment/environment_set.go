@@ -0,0 +1,275 @@
+package deployment
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// EnvironmentSet is the ordered set of environments a changeset is promoted through,
+// e.g. staging then prod. The environments are deployments on the same chains,
+// they differ in the addresses of their contracts.
+type EnvironmentSet []Environment
+
+// Environment returns the environment of the set with the name.
+func (s EnvironmentSet) Environment(name string) (Environment, error) {
+	for _, e := range s {
+		if e.Name == name {
+			return e, nil
+		}
+	}
+	return Environment{}, fmt.Errorf("environment %q not found in set", name)
+}
+
+// next returns the environment following the one with the name.
+func (s EnvironmentSet) next(name string) (Environment, error) {
+	for i, e := range s {
+		if e.Name != name {
+			continue
+		}
+		if i == len(s)-1 {
+			return Environment{}, fmt.Errorf("environment %q is the last of the set, there is nothing to promote to", name)
+		}
+		return s[i+1], nil
+	}
+	return Environment{}, fmt.Errorf("environment %q not found in set", name)
+}
+
+// Promotion records the config a changeset was applied with to an environment,
+// to promote the same change to the next environment of the set.
+type Promotion[C any] struct {
+	Environment string `json:"environment"`
+	Config      C      `json:"config"`
+}
+
+// AddressSubstitution is an address of a config replaced by the address of the same contract
+// in another environment.
+type AddressSubstitution struct {
+	ChainSelector  uint64
+	TypeAndVersion TypeAndVersion
+	From           string
+	To             string
+}
+
+func (s AddressSubstitution) String() string {
+	return fmt.Sprintf("chain %d %s: %s -> %s", s.ChainSelector, s.TypeAndVersion, s.From, s.To)
+}
+
+// ConfigDiff is what differs between the config of a changeset in two environments.
+type ConfigDiff struct {
+	From          string
+	To            string
+	Substitutions []AddressSubstitution
+}
+
+func (d ConfigDiff) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s -> %s: %d addresses substituted", d.From, d.To, len(d.Substitutions))
+	for _, s := range d.Substitutions {
+		fmt.Fprintf(&b, "\n\t%s", s)
+	}
+	return b.String()
+}
+
+// ProposeChangeset applies the changeset to the named environment of the set, typically the first one,
+// and returns the promotion recording it to be promoted with PromoteChangeset once validated.
+func ProposeChangeset[C any](set EnvironmentSet, name string, cs ChangeSet[C], config C) (ChangesetOutput, Promotion[C], error) {
+	e, err := set.Environment(name)
+	if err != nil {
+		return ChangesetOutput{}, Promotion[C]{}, err
+	}
+	out, err := cs(e, config)
+	if err != nil {
+		return ChangesetOutput{}, Promotion[C]{}, fmt.Errorf("failed to apply changeset to environment %s: %w", name, err)
+	}
+	return out, Promotion[C]{Environment: name, Config: config}, nil
+}
+
+// PreviewPromotion returns the config the recorded changeset would be applied with to the next
+// environment of the set and its diff with the recorded config, without applying it.
+func PreviewPromotion[C any](set EnvironmentSet, p Promotion[C]) (C, ConfigDiff, error) {
+	var zero C
+	from, err := set.Environment(p.Environment)
+	if err != nil {
+		return zero, ConfigDiff{}, err
+	}
+	to, err := set.next(p.Environment)
+	if err != nil {
+		return zero, ConfigDiff{}, err
+	}
+	return TranslateConfig(from, to, p.Config)
+}
+
+// PromoteChangeset applies the recorded changeset to the next environment of the set, with the
+// addresses of its config substituted by the ones of the same contracts in that environment.
+// It returns the promotion recording it, to promote it further.
+func PromoteChangeset[C any](set EnvironmentSet, cs ChangeSet[C], p Promotion[C]) (ChangesetOutput, Promotion[C], ConfigDiff, error) {
+	config, diff, err := PreviewPromotion(set, p)
+	if err != nil {
+		return ChangesetOutput{}, Promotion[C]{}, ConfigDiff{}, err
+	}
+	out, next, err := ProposeChangeset(set, diff.To, cs, config)
+	if err != nil {
+		return ChangesetOutput{}, Promotion[C]{}, diff, err
+	}
+	return out, next, diff, nil
+}
+
+// TranslateConfig returns the config with the addresses of the address book of from replaced by the
+// addresses of the same contracts, by chain and type and version, in the address book of to.
+// Addresses not in the address book of from, e.g. of EOAs, are kept. The config must round trip through JSON.
+func TranslateConfig[C any](from, to Environment, config C) (C, ConfigDiff, error) {
+	var zero C
+	diff := ConfigDiff{From: from.Name, To: to.Name}
+	raw, err := json.Marshal(config)
+	if err != nil {
+		return zero, diff, fmt.Errorf("failed to marshal config: %w", err)
+	}
+	var roundTrip C
+	if err := json.Unmarshal(raw, &roundTrip); err != nil {
+		return zero, diff, fmt.Errorf("failed to unmarshal config: %w", err)
+	}
+	if !reflect.DeepEqual(config, roundTrip) {
+		return zero, diff, fmt.Errorf("config %T does not round trip through JSON, its addresses can't be substituted", config)
+	}
+	t, err := newAddressTranslator(from.ExistingAddresses, to.ExistingAddresses)
+	if err != nil {
+		return zero, diff, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var tree any
+	if err := dec.Decode(&tree); err != nil {
+		return zero, diff, fmt.Errorf("failed to decode config: %w", err)
+	}
+	tree, err = t.translate(tree)
+	if err != nil {
+		return zero, diff, fmt.Errorf("failed to translate config from %s to %s: %w", from.Name, to.Name, err)
+	}
+	raw, err = json.Marshal(tree)
+	if err != nil {
+		return zero, diff, fmt.Errorf("failed to marshal translated config: %w", err)
+	}
+	var translated C
+	if err := json.Unmarshal(raw, &translated); err != nil {
+		return zero, diff, fmt.Errorf("failed to unmarshal translated config: %w", err)
+	}
+	diff.Substitutions = t.substitutions()
+	return translated, diff, nil
+}
+
+// contractRef is a contract of an address book.
+type contractRef struct {
+	chainSelector uint64
+	tv            TypeAndVersion
+}
+
+type addressTranslator struct {
+	// contracts are the contracts of the source address book by lowercase address, an address
+	// can be the same contract on several chains.
+	contracts map[string][]contractRef
+	target    map[uint64]map[string]TypeAndVersion
+	used      map[string]AddressSubstitution
+}
+
+func newAddressTranslator(from, to AddressBook) (*addressTranslator, error) {
+	fromAddresses, err := from.Addresses()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get source addresses: %w", err)
+	}
+	toAddresses, err := to.Addresses()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get target addresses: %w", err)
+	}
+	t := &addressTranslator{
+		contracts: make(map[string][]contractRef),
+		target:    toAddresses,
+		used:      make(map[string]AddressSubstitution),
+	}
+	for chainSelector, addresses := range fromAddresses {
+		for addr, tv := range addresses {
+			key := strings.ToLower(addr)
+			t.contracts[key] = append(t.contracts[key], contractRef{chainSelector: chainSelector, tv: tv})
+		}
+	}
+	return t, nil
+}
+
+func (t *addressTranslator) translate(v any) (any, error) {
+	switch v := v.(type) {
+	case string:
+		return t.translateString(v)
+	case []any:
+		for i := range v {
+			translated, err := t.translate(v[i])
+			if err != nil {
+				return nil, err
+			}
+			v[i] = translated
+		}
+		return v, nil
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for k, val := range v {
+			key, err := t.translateString(k)
+			if err != nil {
+				return nil, err
+			}
+			translated, err := t.translate(val)
+			if err != nil {
+				return nil, err
+			}
+			out[key] = translated
+		}
+		return out, nil
+	default:
+		return v, nil
+	}
+}
+
+// translateString returns the address of the target contract if s is an address of the source address book.
+func (t *addressTranslator) translateString(s string) (string, error) {
+	refs, ok := t.contracts[strings.ToLower(s)]
+	if !ok {
+		return s, nil
+	}
+	var to string
+	for _, ref := range refs {
+		var matches []string
+		for addr, tv := range t.target[ref.chainSelector] {
+			if tv.Equal(ref.tv) {
+				matches = append(matches, addr)
+			}
+		}
+		switch {
+		case len(matches) == 0:
+			return "", fmt.Errorf("%s %s of chain %d has no counterpart in the target address book", ref.tv, s, ref.chainSelector)
+		case len(matches) > 1:
+			sort.Strings(matches)
+			return "", fmt.Errorf("%s %s of chain %d has several counterparts in the target address book: %s",
+				ref.tv, s, ref.chainSelector, strings.Join(matches, ", "))
+		case to != "" && !strings.EqualFold(to, matches[0]):
+			return "", fmt.Errorf("address %s is deployed on several chains and their counterparts differ: %s and %s", s, to, matches[0])
+		}
+		to = matches[0]
+		t.used[strings.ToLower(s)] = AddressSubstitution{ChainSelector: ref.chainSelector, TypeAndVersion: ref.tv, From: s, To: to}
+	}
+	return to, nil
+}
+
+func (t *addressTranslator) substitutions() []AddressSubstitution {
+	out := make([]AddressSubstitution, 0, len(t.used))
+	for _, s := range t.used {
+		out = append(out, s)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].ChainSelector != out[j].ChainSelector {
+			return out[i].ChainSelector < out[j].ChainSelector
+		}
+		return out[i].From < out[j].From
+	})
+	return out
+}
@@ -0,0 +1,58 @@
+package deployment
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	chainsel "github.com/smartcontractkit/chain-selectors"
+	"github.com/stretchr/testify/require"
+)
+
+type promotedConfig struct {
+	Router common.Address
+	Admin  common.Address
+	Fees   map[string]uint64
+}
+
+func TestPromoteChangeset(t *testing.T) {
+	chain := chainsel.TEST_90000001.Selector
+	router := NewTypeAndVersion("Router", Version1_0_0)
+	stagingRouter := common.HexToAddress("0x01")
+	prodRouter := common.HexToAddress("0x02")
+	admin := common.HexToAddress("0xad")
+	set := EnvironmentSet{
+		{Name: "staging", ExistingAddresses: NewMemoryAddressBookFromMap(map[uint64]map[string]TypeAndVersion{
+			chain: {stagingRouter.Hex(): router},
+		})},
+		{Name: "prod", ExistingAddresses: NewMemoryAddressBookFromMap(map[uint64]map[string]TypeAndVersion{
+			chain: {prodRouter.Hex(): router},
+		})},
+	}
+	var applied []string
+	cs := func(e Environment, c promotedConfig) (ChangesetOutput, error) {
+		applied = append(applied, e.Name+" "+c.Router.Hex())
+		return ChangesetOutput{}, nil
+	}
+	config := promotedConfig{Router: stagingRouter, Admin: admin, Fees: map[string]uint64{stagingRouter.Hex(): 1}}
+
+	_, p, err := ProposeChangeset(set, "staging", cs, config)
+	require.NoError(t, err)
+	require.Equal(t, Promotion[promotedConfig]{Environment: "staging", Config: config}, p)
+
+	preview, diff, err := PreviewPromotion(set, p)
+	require.NoError(t, err)
+	require.Equal(t, promotedConfig{Router: prodRouter, Admin: admin, Fees: map[string]uint64{prodRouter.Hex(): 1}}, preview)
+	require.Equal(t, "staging -> prod: 1 addresses substituted\n\tchain 909606746561742123 Router 1.0.0: "+
+		stagingRouter.Hex()+" -> "+prodRouter.Hex(), diff.String())
+
+	_, p, _, err = PromoteChangeset(set, cs, p)
+	require.NoError(t, err)
+	require.Equal(t, []string{"staging " + stagingRouter.Hex(), "prod " + prodRouter.Hex()}, applied)
+	require.Equal(t, "prod", p.Environment)
+	_, _, _, err = PromoteChangeset(set, cs, p)
+	require.ErrorContains(t, err, "nothing to promote to")
+
+	require.NoError(t, set[1].ExistingAddresses.Save(chain, common.HexToAddress("0x03").Hex(), router))
+	_, _, err = PreviewPromotion(set, Promotion[promotedConfig]{Environment: "staging", Config: config})
+	require.ErrorContains(t, err, "has several counterparts in the target address book")
+}
@@ -4,7 +4,10 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
 	chain_selectors "github.com/smartcontractkit/chain-selectors"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNode_OCRConfigForChainSelector(t *testing.T) {
@@ -89,3 +92,18 @@ func TestNode_OCRConfigForChainSelector(t *testing.T) {
 		})
 	}
 }
+
+func TestChain_Signer(t *testing.T) {
+	deployer := &bind.TransactOpts{From: common.HexToAddress("0x01")}
+	treasury := &bind.TransactOpts{From: common.HexToAddress("0x02")}
+	chain := Chain{Selector: 1, DeployerKey: deployer, Signers: []*bind.TransactOpts{treasury}}
+
+	signer, err := chain.Signer(deployer.From)
+	require.NoError(t, err)
+	require.Same(t, deployer, signer)
+	signer, err = chain.Signer(treasury.From)
+	require.NoError(t, err)
+	require.Same(t, treasury, signer)
+	_, err = chain.Signer(common.HexToAddress("0x03"))
+	require.ErrorContains(t, err, "no key for 0x0000000000000000000000000000000000000003 on chain 1")
+}
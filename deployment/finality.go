@@ -0,0 +1,104 @@
+package deployment
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+)
+
+const (
+	// DefaultFinalityTimeout is the time ConfirmFinalized waits for a block to be finalized
+	// when the chain doesn't configure one.
+	DefaultFinalityTimeout = 30 * time.Minute
+	// finalityPollInterval is the interval at which ConfirmFinalized polls the finalized block.
+	finalityPollInterval = 2 * time.Second
+	// finalityLogInterval is the interval at which ConfirmFinalized logs that it is still waiting.
+	finalityLogInterval = 30 * time.Second
+)
+
+// finalityCommitter is implemented by clients of chains which only mine blocks on demand, e.g. memory chains.
+type finalityCommitter interface {
+	CommitUntilFinalized(ctx context.Context, blockNumber uint64) error
+}
+
+// ConfirmFinalized confirms the transaction like Confirm, then waits for its block to be finalized,
+// according to the finality depth of the chain or else to its finalized block tag.
+// Use it for the transactions which must not be reorged out before the next step, e.g. ownership
+// transfers and OCR configs.
+func (c Chain) ConfirmFinalized(lggr logger.Logger, tx *types.Transaction) (uint64, error) {
+	blockNumber, err := c.Confirm(tx)
+	if err != nil {
+		return blockNumber, err
+	}
+	timeout := c.FinalityTimeout
+	if timeout == 0 {
+		timeout = DefaultFinalityTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	if committer, ok := UnwrapClient(c.Client).(finalityCommitter); ok && c.FinalityDepth == 0 {
+		if err := committer.CommitUntilFinalized(ctx, blockNumber); err != nil {
+			return blockNumber, fmt.Errorf("failed to finalize block %d of tx %s on chain %d: %w", blockNumber, tx.Hash(), c.Selector, err)
+		}
+		return blockNumber, nil
+	}
+	ticker := time.NewTicker(finalityPollInterval)
+	defer ticker.Stop()
+	start, lastLog := time.Now(), time.Now()
+	for {
+		finalized, err := c.finalizedBlockNumber(ctx)
+		if err != nil {
+			lggr.Warnw("Failed to get finalized block", "chain", c.Selector, "err", err)
+		} else if finalized >= blockNumber {
+			lggr.Infow("Transaction finalized", "chain", c.Selector, "tx", tx.Hash(), "block", blockNumber,
+				"waited", time.Since(start))
+			return blockNumber, nil
+		} else if time.Since(lastLog) >= finalityLogInterval {
+			lggr.Infow("Waiting for transaction to be finalized", "chain", c.Selector, "tx", tx.Hash(),
+				"block", blockNumber, "finalized", finalized, "waited", time.Since(start))
+			lastLog = time.Now()
+		}
+		select {
+		case <-ctx.Done():
+			return blockNumber, fmt.Errorf("block %d of tx %s on chain %d not finalized after %s: %w",
+				blockNumber, tx.Hash(), c.Selector, timeout, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// finalizedBlockNumber returns the latest block number minus the finality depth of the chain,
+// or the finalized block number if it has no depth.
+func (c Chain) finalizedBlockNumber(ctx context.Context) (uint64, error) {
+	if c.FinalityDepth == 0 {
+		h, err := c.Client.HeaderByNumber(ctx, big.NewInt(rpc.FinalizedBlockNumber.Int64()))
+		if err != nil {
+			return 0, err
+		}
+		return h.Number.Uint64(), nil
+	}
+	h, err := c.Client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	latest := h.Number.Uint64()
+	if latest < uint64(c.FinalityDepth) {
+		return 0, nil
+	}
+	return latest - uint64(c.FinalityDepth), nil
+}
+
+// ConfirmFinalizedIfNoError is ConfirmIfNoError waiting for the block of the transaction to be finalized,
+// see Chain.ConfirmFinalized.
+func ConfirmFinalizedIfNoError(lggr logger.Logger, chain Chain, tx *types.Transaction, err error) (uint64, error) {
+	if err != nil {
+		return ConfirmIfNoError(chain, tx, err)
+	}
+	return chain.ConfirmFinalized(lggr, tx)
+}
@@ -0,0 +1,37 @@
+package deployment
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+)
+
+func TestConfirmFinalized(t *testing.T) {
+	lggr := logger.Test(t)
+	tx := types.NewTx(&types.LegacyTx{})
+	confirmAt := func(block uint64) func(*types.Transaction) (uint64, error) {
+		return func(*types.Transaction) (uint64, error) {
+			return block, nil
+		}
+	}
+	// the latest block of stubClient is 1
+	chain := Chain{Selector: 1, Client: stubClient{}, Confirm: confirmAt(0), FinalityDepth: 1}
+	block, err := chain.ConfirmFinalized(lggr, tx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), block)
+
+	chain.Confirm = confirmAt(1)
+	chain.FinalityTimeout = 10 * time.Millisecond
+	_, err = chain.ConfirmFinalized(lggr, tx)
+	require.ErrorContains(t, err, "not finalized after 10ms")
+
+	// stubClient resolves the finalized tag to its latest block
+	chain.FinalityDepth = 0
+	block, err = chain.ConfirmFinalized(lggr, tx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), block)
+}
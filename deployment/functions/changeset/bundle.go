@@ -0,0 +1,22 @@
+package changeset
+
+import (
+	"github.com/smartcontractkit/chainlink/deployment"
+	commonchangeset "github.com/smartcontractkit/chainlink/deployment/common/changeset"
+	"github.com/smartcontractkit/chainlink/deployment/functions"
+)
+
+// Bundle deploys a router, its allow list and the coordinator of the DON to every configured chain.
+func Bundle(c functions.DeployRouterConfig) commonchangeset.ProductBundle {
+	return commonchangeset.ProductBundle{
+		Name: "functions",
+		Stages: []commonchangeset.BundleStage{
+			func(e deployment.Environment) ([]commonchangeset.ChangesetApplication, error) {
+				return []commonchangeset.ChangesetApplication{{
+					Changeset: commonchangeset.WrapChangeSet(DeployRouterChangeSet),
+					Config:    c,
+				}}, nil
+			},
+		},
+	}
+}
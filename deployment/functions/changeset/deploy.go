@@ -0,0 +1,18 @@
+package changeset
+
+import (
+	"github.com/smartcontractkit/chainlink/deployment"
+	functionsdeployment "github.com/smartcontractkit/chainlink/deployment/functions"
+)
+
+func DeployRouterChangeSet(env deployment.Environment, c functionsdeployment.DeployRouterConfig) (deployment.ChangesetOutput, error) {
+	ab := deployment.NewMemoryAddressBook()
+	err := functionsdeployment.DeployRouter(env, ab, c)
+	if err != nil {
+		env.Logger.Errorw("Failed to deploy Functions router", "err", err, "addresses", ab)
+		return deployment.ChangesetOutput{AddressBook: ab}, deployment.MaybeDataErr(err)
+	}
+	return deployment.ChangesetOutput{
+		AddressBook: ab,
+	}, nil
+}
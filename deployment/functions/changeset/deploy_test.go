@@ -0,0 +1,49 @@
+package changeset
+
+import (
+	"testing"
+
+	"github.com/smartcontractkit/chainlink-testing-framework/lib/utils/testcontext"
+	"github.com/stretchr/testify/require"
+
+	commonchangeset "github.com/smartcontractkit/chainlink/deployment/common/changeset"
+	"github.com/smartcontractkit/chainlink/deployment/functions"
+)
+
+func TestDeployRouterChangeSet(t *testing.T) {
+	e := newMemoryEnv(t)
+	sel := e.AllChainSelectors()[0]
+	c := functions.DeployRouterConfig{
+		Chains: map[uint64]functions.ChainRouterConfig{sel: deployMockDependencies(t, e.Chains[sel], "fun-test-1")},
+	}
+	e, err := commonchangeset.ApplyBundles(testcontext.Get(t), e.Logger, e, Bundle(c))
+	require.NoError(t, err)
+
+	addrs, err := e.ExistingAddresses.AddressesForChain(sel)
+	require.NoError(t, err)
+	state, err := functions.LoadChainState(e.Chains[sel], addrs)
+	require.NoError(t, err)
+	require.NotNil(t, state.Router)
+	require.NotNil(t, state.Coordinator)
+	require.NotNil(t, state.AllowList)
+	// the requests of the DON and the allow list checks are routed
+	coordinator, err := state.Router.GetContractById(nil, functions.DONID("fun-test-1"))
+	require.NoError(t, err)
+	require.Equal(t, state.Coordinator.Address(), coordinator)
+	allowListID, err := state.Router.GetAllowListId(nil)
+	require.NoError(t, err)
+	allowList, err := state.Router.GetContractById(nil, allowListID)
+	require.NoError(t, err)
+	require.Equal(t, state.AllowList.Address(), allowList)
+
+	// deploying again is a no-op
+	out, err := DeployRouterChangeSet(e, c)
+	require.NoError(t, err)
+	ab, err := out.AddressBook.Addresses()
+	require.NoError(t, err)
+	require.Empty(t, ab)
+
+	c.Chains[sel] = functions.ChainRouterConfig{DONID: "fun-test-1"}
+	_, err = DeployRouterChangeSet(e, c)
+	require.ErrorContains(t, err, "must set the link token")
+}
@@ -0,0 +1,45 @@
+package changeset
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/deployment/environment/memory"
+	"github.com/smartcontractkit/chainlink/deployment/functions"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/generated/link_token_interface"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/generated/mock_v3_aggregator_contract"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+func newMemoryEnv(t *testing.T) deployment.Environment {
+	lggr := logger.TestLogger(t)
+	memEnvConf := memory.MemoryEnvironmentConfig{
+		Chains:         1,
+		Nodes:          2,
+		RegistryConfig: deployment.CapabilityRegistryConfig{},
+	}
+	return memory.NewMemoryEnvironment(t, lggr, zapcore.InfoLevel, memEnvConf)
+}
+
+// deployMockDependencies deploys a LINK token and mock feeds for the coordinator.
+func deployMockDependencies(t *testing.T, chain deployment.Chain, donID string) functions.ChainRouterConfig {
+	linkAddr, tx, _, err := link_token_interface.DeployLinkToken(chain.DeployerKey, chain.Client)
+	_, err = deployment.ConfirmIfNoError(chain, tx, err)
+	require.NoError(t, err)
+	linkNativeAddr, tx, _, err := mock_v3_aggregator_contract.DeployMockV3AggregatorContract(chain.DeployerKey, chain.Client, 18, big.NewInt(5e15))
+	_, err = deployment.ConfirmIfNoError(chain, tx, err)
+	require.NoError(t, err)
+	linkUSDAddr, tx, _, err := mock_v3_aggregator_contract.DeployMockV3AggregatorContract(chain.DeployerKey, chain.Client, 8, big.NewInt(1_500_000_000))
+	_, err = deployment.ConfirmIfNoError(chain, tx, err)
+	require.NoError(t, err)
+	return functions.ChainRouterConfig{
+		LinkToken:      linkAddr,
+		LinkNativeFeed: linkNativeAddr,
+		LinkUSDFeed:    linkUSDAddr,
+		DONID:          donID,
+	}
+}
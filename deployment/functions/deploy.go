@@ -0,0 +1,186 @@
+package functions
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/functions/generated/functions_allow_list"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/functions/generated/functions_coordinator"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/functions/generated/functions_router"
+)
+
+var (
+	FunctionsRouter         deployment.ContractType = "FunctionsRouter"
+	FunctionsCoordinator    deployment.ContractType = "FunctionsCoordinator"
+	TermsOfServiceAllowList deployment.ContractType = "TermsOfServiceAllowList"
+)
+
+// handleOracleFulfillmentSelector is the selector of FunctionsClient.handleOracleFulfillment.
+var handleOracleFulfillmentSelector = [4]byte{0x0c, 0xa7, 0x61, 0x75}
+
+// DefaultRouterConfig is the router config used when none is configured.
+func DefaultRouterConfig() functions_router.FunctionsRouterConfig {
+	return functions_router.FunctionsRouterConfig{
+		MaxConsumersPerSubscription:        100,
+		AdminFee:                           big.NewInt(0),
+		HandleOracleFulfillmentSelector:    handleOracleFulfillmentSelector,
+		GasForCallExactCheck:               5_000,
+		MaxCallbackGasLimits:               []uint32{300_000, 500_000, 1_000_000},
+		SubscriptionDepositMinimumRequests: 10,
+		SubscriptionDepositJuels:           big.NewInt(9e18),
+	}
+}
+
+// DefaultBillingConfig is the coordinator config used when none is configured.
+func DefaultBillingConfig() functions_coordinator.FunctionsBillingConfig {
+	return functions_coordinator.FunctionsBillingConfig{
+		FulfillmentGasPriceOverEstimationBP: 1_000,
+		FeedStalenessSeconds:                86_400,
+		GasOverheadBeforeCallback:           325_000,
+		GasOverheadAfterCallback:            50_000,
+		MinimumEstimateGasPriceWei:          big.NewInt(1_000_000_000),
+		MaxSupportedRequestDataVersion:      1,
+		FallbackUsdPerUnitLink:              1_400_000_000,
+		FallbackUsdPerUnitLinkDecimals:      8,
+		FallbackNativePerUnitLink:           big.NewInt(5_000_000_000_000_000),
+		RequestTimeoutSeconds:               300,
+		TransmitTxSizeBytes:                 1_764,
+	}
+}
+
+// ChainRouterConfig holds the chain specific configuration of the router and the coordinator of the DON.
+type ChainRouterConfig struct {
+	LinkToken      common.Address
+	LinkNativeFeed common.Address
+	LinkUSDFeed    common.Address
+	// DONID routes the requests of the DON to its coordinator, e.g. "fun-ethereum-mainnet-1".
+	DONID string
+	// RouterConfig defaults to DefaultRouterConfig.
+	RouterConfig *functions_router.FunctionsRouterConfig
+	// BillingConfig defaults to DefaultBillingConfig.
+	BillingConfig *functions_coordinator.FunctionsBillingConfig
+}
+
+type DeployRouterConfig struct {
+	Chains map[uint64]ChainRouterConfig
+}
+
+func (c DeployRouterConfig) Validate(e deployment.Environment) error {
+	if len(c.Chains) == 0 {
+		return fmt.Errorf("no chains to deploy")
+	}
+	for sel, chainCfg := range c.Chains {
+		if _, ok := e.Chains[sel]; !ok {
+			return fmt.Errorf("chain %d not found in environment", sel)
+		}
+		if chainCfg.LinkToken == (common.Address{}) || chainCfg.LinkNativeFeed == (common.Address{}) ||
+			chainCfg.LinkUSDFeed == (common.Address{}) {
+			return fmt.Errorf("chain %d must set the link token, link native feed and link usd feed", sel)
+		}
+		if chainCfg.DONID == "" || len(chainCfg.DONID) > 32 {
+			return fmt.Errorf("chain %d DON ID must have 1 to 32 bytes, got %q", sel, chainCfg.DONID)
+		}
+	}
+	return nil
+}
+
+// DeployRouter deploys a FunctionsRouter, a TermsOfServiceAllowList and a FunctionsCoordinator to every configured
+// chain, and routes the requests of the DON and the allow list checks through the router. Chains that already
+// have a router in the environment address book are skipped.
+func DeployRouter(e deployment.Environment, ab deployment.AddressBook, c DeployRouterConfig) error {
+	if err := c.Validate(e); err != nil {
+		return fmt.Errorf("invalid config: %w", err)
+	}
+	for sel, chainCfg := range c.Chains {
+		chain := e.Chains[sel]
+		existing, err := existingChainState(e, chain)
+		if err != nil {
+			return err
+		}
+		if existing.Router != nil {
+			e.Logger.Infow("Functions router already deployed", "chainSelector", sel)
+			continue
+		}
+		if err := deployRouterToChain(e, chain, ab, chainCfg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deployRouterToChain deploys the contracts of the chain and routes the DON to its coordinator.
+//
+// Note that this function modifies the given address book variable.
+func deployRouterToChain(e deployment.Environment, chain deployment.Chain, ab deployment.AddressBook, cfg ChainRouterConfig) error {
+	routerCfg := DefaultRouterConfig()
+	if cfg.RouterConfig != nil {
+		routerCfg = *cfg.RouterConfig
+	}
+	router, err := deployment.DeployContract(e.Logger, chain, ab,
+		func(chain deployment.Chain) deployment.ContractDeploy[*functions_router.FunctionsRouter] {
+			addr, tx, r, err2 := functions_router.DeployFunctionsRouter(chain.DeployerKey, chain.Client, cfg.LinkToken, routerCfg)
+			return deployment.ContractDeploy[*functions_router.FunctionsRouter]{
+				Address: addr, Contract: r, Tx: tx, Err: err2,
+				Tv: deployment.NewTypeAndVersion(FunctionsRouter, deployment.Version1_0_0),
+			}
+		})
+	if err != nil {
+		return fmt.Errorf("failed to deploy FunctionsRouter: %w", err)
+	}
+	// the allow list is disabled, senders are allowed without accepting the terms of service
+	allowList, err := deployment.DeployContract(e.Logger, chain, ab,
+		func(chain deployment.Chain) deployment.ContractDeploy[*functions_allow_list.TermsOfServiceAllowList] {
+			addr, tx, l, err2 := functions_allow_list.DeployTermsOfServiceAllowList(chain.DeployerKey, chain.Client,
+				functions_allow_list.TermsOfServiceAllowListConfig{SignerPublicKey: chain.DeployerKey.From},
+				nil, nil, common.Address{})
+			return deployment.ContractDeploy[*functions_allow_list.TermsOfServiceAllowList]{
+				Address: addr, Contract: l, Tx: tx, Err: err2,
+				Tv: deployment.NewTypeAndVersion(TermsOfServiceAllowList, deployment.Version1_0_0),
+			}
+		})
+	if err != nil {
+		return fmt.Errorf("failed to deploy TermsOfServiceAllowList: %w", err)
+	}
+	billingCfg := DefaultBillingConfig()
+	if cfg.BillingConfig != nil {
+		billingCfg = *cfg.BillingConfig
+	}
+	coordinator, err := deployment.DeployContract(e.Logger, chain, ab,
+		func(chain deployment.Chain) deployment.ContractDeploy[*functions_coordinator.FunctionsCoordinator] {
+			addr, tx, c, err2 := functions_coordinator.DeployFunctionsCoordinator(chain.DeployerKey, chain.Client,
+				router.Address, billingCfg, cfg.LinkNativeFeed, cfg.LinkUSDFeed)
+			return deployment.ContractDeploy[*functions_coordinator.FunctionsCoordinator]{
+				Address: addr, Contract: c, Tx: tx, Err: err2,
+				Tv: deployment.NewTypeAndVersion(FunctionsCoordinator, deployment.Version1_0_0),
+			}
+		})
+	if err != nil {
+		return fmt.Errorf("failed to deploy FunctionsCoordinator: %w", err)
+	}
+
+	allowListID, err := router.Contract.GetAllowListId(nil)
+	if err != nil {
+		return fmt.Errorf("failed to get allow list id: %w", err)
+	}
+	tx, err := router.Contract.ProposeContractsUpdate(chain.DeployerKey,
+		[][32]byte{allowListID, DONID(cfg.DONID)},
+		[]common.Address{allowList.Address, coordinator.Address})
+	if _, err := deployment.ConfirmIfNoError(chain, tx, err); err != nil {
+		return fmt.Errorf("failed to propose router contracts: %w", deployment.MaybeDataErr(err))
+	}
+	tx, err = router.Contract.UpdateContracts(chain.DeployerKey)
+	if _, err := deployment.ConfirmIfNoError(chain, tx, err); err != nil {
+		return fmt.Errorf("failed to update router contracts: %w", deployment.MaybeDataErr(err))
+	}
+	return nil
+}
+
+// DONID returns the id the router routes the requests of the DON by.
+func DONID(donID string) [32]byte {
+	var id [32]byte
+	copy(id[:], donID)
+	return id
+}
@@ -0,0 +1,64 @@
+package functions
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/functions/generated/functions_allow_list"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/functions/generated/functions_coordinator"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/functions/generated/functions_router"
+)
+
+// FunctionsChainState holds a Go binding for all the currently deployed Functions contracts
+// on a chain. If a binding is nil, it means here is no such contract on the chain.
+type FunctionsChainState struct {
+	Router      *functions_router.FunctionsRouter
+	Coordinator *functions_coordinator.FunctionsCoordinator
+	AllowList   *functions_allow_list.TermsOfServiceAllowList
+}
+
+// LoadChainState Loads all state for a chain into state
+func LoadChainState(chain deployment.Chain, addresses map[string]deployment.TypeAndVersion) (FunctionsChainState, error) {
+	var state FunctionsChainState
+	for address, tv := range addresses {
+		switch tv.String() {
+		case deployment.NewTypeAndVersion(FunctionsRouter, deployment.Version1_0_0).String():
+			r, err := functions_router.NewFunctionsRouter(common.HexToAddress(address), chain.Client)
+			if err != nil {
+				return state, err
+			}
+			state.Router = r
+		case deployment.NewTypeAndVersion(FunctionsCoordinator, deployment.Version1_0_0).String():
+			c, err := functions_coordinator.NewFunctionsCoordinator(common.HexToAddress(address), chain.Client)
+			if err != nil {
+				return state, err
+			}
+			state.Coordinator = c
+		case deployment.NewTypeAndVersion(TermsOfServiceAllowList, deployment.Version1_0_0).String():
+			l, err := functions_allow_list.NewTermsOfServiceAllowList(common.HexToAddress(address), chain.Client)
+			if err != nil {
+				return state, err
+			}
+			state.AllowList = l
+		default:
+			// other products can share the address book
+			continue
+		}
+	}
+	return state, nil
+}
+
+func existingChainState(e deployment.Environment, chain deployment.Chain) (FunctionsChainState, error) {
+	addresses, err := e.ExistingAddresses.AddressesForChain(chain.Selector)
+	if errors.Is(err, deployment.ErrChainNotFound) {
+		// nothing deployed yet
+		return FunctionsChainState{}, nil
+	}
+	if err != nil {
+		return FunctionsChainState{}, fmt.Errorf("failed to get addresses for chain %d: %w", chain.Selector, err)
+	}
+	return LoadChainState(chain, addresses)
+}
@@ -0,0 +1,107 @@
+package deployment
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// HealthReporter is implemented by offchain clients which can query
+// the health checks of the nodes they manage.
+type HealthReporter interface {
+	// FailingServices returns the failing services of every node, keyed by node ID
+	// and then by service name, along with the reason they are failing.
+	FailingServices(ctx context.Context) (map[string]map[string]string, error)
+}
+
+// HealthReport aggregates the failing services of the nodes of an environment.
+type HealthReport struct {
+	// Failing maps node IDs to their failing services and the reason they are failing.
+	// Nodes without failing services are omitted.
+	Failing map[string]map[string]string
+}
+
+// NewHealthReport queries the health checks of all nodes managed by the offchain client.
+func NewHealthReport(ctx context.Context, oc OffchainClient) (HealthReport, error) {
+	reporter, ok := oc.(HealthReporter)
+	if !ok {
+		return HealthReport{}, fmt.Errorf("offchain client %T does not support health reports", oc)
+	}
+	failing, err := reporter.FailingServices(ctx)
+	if err != nil {
+		return HealthReport{}, err
+	}
+	report := HealthReport{Failing: make(map[string]map[string]string)}
+	for nodeID, services := range failing {
+		if len(services) > 0 {
+			report.Failing[nodeID] = services
+		}
+	}
+	return report, nil
+}
+
+func (r HealthReport) Healthy() bool {
+	return len(r.Failing) == 0
+}
+
+// ByChain groups the failing services by the chain they belong to, and then by node ID.
+// Chains are identified by family and chain ID, e.g. "EVM.1337". Services which are not
+// tied to a chain are grouped under the empty string.
+func (r HealthReport) ByChain() map[string]map[string][]string {
+	byChain := make(map[string]map[string][]string)
+	for nodeID, services := range r.Failing {
+		for service := range services {
+			chain := serviceChain(service)
+			if _, ok := byChain[chain]; !ok {
+				byChain[chain] = make(map[string][]string)
+			}
+			byChain[chain][nodeID] = append(byChain[chain][nodeID], service)
+		}
+	}
+	for _, nodes := range byChain {
+		for _, services := range nodes {
+			sort.Strings(services)
+		}
+	}
+	return byChain
+}
+
+// Err returns an error listing all failing services, or nil if all nodes are healthy.
+func (r HealthReport) Err() error {
+	if r.Healthy() {
+		return nil
+	}
+	nodeIDs := make([]string, 0, len(r.Failing))
+	for nodeID := range r.Failing {
+		nodeIDs = append(nodeIDs, nodeID)
+	}
+	sort.Strings(nodeIDs)
+	var sb strings.Builder
+	sb.WriteString("unhealthy nodes:")
+	for _, nodeID := range nodeIDs {
+		services := make([]string, 0, len(r.Failing[nodeID]))
+		for service := range r.Failing[nodeID] {
+			services = append(services, service)
+		}
+		sort.Strings(services)
+		for _, service := range services {
+			fmt.Fprintf(&sb, "\n\t%s: %s: %s", nodeID, service, r.Failing[nodeID][service])
+		}
+	}
+	return fmt.Errorf("%s", sb.String())
+}
+
+// serviceChain extracts the chain of a service from its name, e.g. "EVM.1337.Txm" belongs to "EVM.1337".
+func serviceChain(service string) string {
+	parts := strings.SplitN(service, ".", 3)
+	if len(parts) < 2 || parts[1] == "" {
+		return ""
+	}
+	for _, c := range parts[1] {
+		if c < '0' || c > '9' {
+			return ""
+		}
+	}
+	return parts[0] + "." + parts[1]
+}
@@ -0,0 +1,34 @@
+package deployment
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthReport(t *testing.T) {
+	require.NoError(t, HealthReport{}.Err())
+	require.True(t, HealthReport{}.Healthy())
+
+	report := HealthReport{Failing: map[string]map[string]string{
+		"node-1": {
+			"EVM.1337.Txm":         "stuck",
+			"EVM.1337.HeadTracker": "no heads",
+			"Mercury":              "down",
+		},
+		"node-2": {
+			"EVM.2337.LogPoller": "behind",
+		},
+	}}
+	require.False(t, report.Healthy())
+	require.Equal(t, map[string]map[string][]string{
+		"EVM.1337": {"node-1": {"EVM.1337.HeadTracker", "EVM.1337.Txm"}},
+		"EVM.2337": {"node-2": {"EVM.2337.LogPoller"}},
+		"":         {"node-1": {"Mercury"}},
+	}, report.ByChain())
+	require.EqualError(t, report.Err(), `unhealthy nodes:
+	node-1: EVM.1337.HeadTracker: no heads
+	node-1: EVM.1337.Txm: stuck
+	node-1: Mercury: down
+	node-2: EVM.2337.LogPoller: behind`)
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"math/big"
 	"strings"
 
 	"github.com/ethereum/go-ethereum"
@@ -15,7 +16,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/pkg/errors"
-	chain_selectors "github.com/smartcontractkit/chain-selectors"
+	chainsel "github.com/smartcontractkit/chain-selectors"
 
 	"github.com/smartcontractkit/chainlink-common/pkg/logger"
 )
@@ -47,6 +48,44 @@ func SimTransactOpts() *bind.TransactOpts {
 	}, From: common.HexToAddress("0x0"), NoSend: true, GasLimit: 1_000_000}
 }
 
+// NativeTransferGas is the gas of a transfer of the native token of an EVM chain to an account.
+const NativeTransferGas = 21_000
+
+// SendNativeTransfer signs a transfer of amount of the native token of the chain from the account to the address
+// with the nonce and the gas price, and sends it without waiting for its confirmation, e.g. to send several in a row.
+func SendNativeTransfer(ctx context.Context, chain Chain, from *bind.TransactOpts, nonce uint64, gasPrice *big.Int, to common.Address, amount *big.Int) (*types.Transaction, error) {
+	tx, err := from.Signer(from.From, types.NewTx(&types.LegacyTx{
+		Nonce:    nonce,
+		GasPrice: gasPrice,
+		Gas:      NativeTransferGas,
+		To:       &to,
+		Value:    amount,
+	}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign native transfer from %s on chain %d: %w", from.From, chain.Selector, err)
+	}
+	if err := chain.Client.SendTransaction(ctx, tx); err != nil {
+		return nil, MaybeDataErr(err)
+	}
+	return tx, nil
+}
+
+// SendNative transfers amount of the native token of the chain from the account to the address at the suggested
+// gas price, and waits for its confirmation.
+func SendNative(ctx context.Context, chain Chain, from *bind.TransactOpts, to common.Address, amount *big.Int) error {
+	nonce, err := chain.Client.PendingNonceAt(ctx, from.From)
+	if err != nil {
+		return fmt.Errorf("failed to get nonce of %s on chain %d: %w", from.From, chain.Selector, err)
+	}
+	gasPrice, err := chain.Client.SuggestGasPrice(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to suggest gas price on chain %d: %w", chain.Selector, err)
+	}
+	tx, err := SendNativeTransfer(ctx, chain, from, nonce, gasPrice, to, amount)
+	_, err = ConfirmIfNoError(chain, tx, err)
+	return err
+}
+
 func GetErrorReasonFromTx(client bind.ContractBackend, from common.Address, tx *types.Transaction, receipt *types.Receipt) (string, error) {
 	call := ethereum.CallMsg{
 		From:     from,
@@ -58,6 +97,9 @@ func GetErrorReasonFromTx(client bind.ContractBackend, from common.Address, tx *
 	}
 	_, err := client.CallContract(context.Background(), call, receipt.BlockNumber)
 	if err != nil {
+		if decoded, ok := DecodeRevert(err); ok {
+			return decoded.Error(), nil
+		}
 		errorReason, err := parseError(err)
 		if err == nil {
 			return errorReason, nil
@@ -136,6 +178,10 @@ func DeployContract[C any](
 	addressBook AddressBook,
 	deploy func(chain Chain) ContractDeploy[C],
 ) (*ContractDeploy[C], error) {
+	if chain.IsZkChain() {
+		// abigen deploy functions send EVM bytecode in contract creation txs, which zk chains reject
+		return nil, fmt.Errorf("chain %d is a zk chain, contracts must be deployed with DeployZkContract", chain.Selector)
+	}
 	contractDeploy := deploy(chain)
 	if contractDeploy.Err != nil {
 		lggr.Errorw("Failed to deploy contract", "err", contractDeploy.Err)
@@ -158,9 +204,24 @@ func IsValidChainSelector(cs uint64) error {
 	if cs == 0 {
 		return fmt.Errorf("chain selector must be set")
 	}
-	_, err := chain_selectors.ChainIdFromSelector(cs)
+	_, err := EVMChainID(cs)
 	if err != nil {
 		return fmt.Errorf("invalid chain selector: %d - %w", cs, err)
 	}
 	return nil
 }
+
+// IsValidAptosChainSelector is IsValidChainSelector for Aptos chains.
+func IsValidAptosChainSelector(cs uint64) error {
+	if cs == 0 {
+		return fmt.Errorf("chain selector must be set")
+	}
+	family, err := ChainFamily(cs)
+	if err != nil {
+		return fmt.Errorf("invalid chain selector: %d - %w", cs, err)
+	}
+	if family != chainsel.FamilyAptos {
+		return fmt.Errorf("chain selector %d is not an aptos chain", cs)
+	}
+	return nil
+}
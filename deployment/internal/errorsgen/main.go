@@ -0,0 +1,100 @@
+// errorsgen generates the list of the gethwrappers bindings whose custom errors are registered with
+// the default error registry of the deployment package. Run it with go generate from the deployment package.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"go/format"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"text/template"
+)
+
+// wrapperDirs are the directories of the generated bindings, relative to the deployment package,
+// and their import paths.
+var wrapperDirs = map[string]string{
+	"../core/gethwrappers/ccip/generated":     "github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated",
+	"../core/gethwrappers/keystone/generated": "github.com/smartcontractkit/chainlink/v2/core/gethwrappers/keystone/generated",
+	"../core/gethwrappers/shared/generated":   "github.com/smartcontractkit/chainlink/v2/core/gethwrappers/shared/generated",
+}
+
+var (
+	metadataRe = regexp.MustCompile(`(?m)^var (\w+MetaData) = &bind\.MetaData\{`)
+	// errorRe matches the declaration of a custom error in the escaped ABI of a binding.
+	errorRe = regexp.MustCompile(`\\"type\\":\\"error\\"`)
+)
+
+type binding struct {
+	Package    string
+	ImportPath string
+	Metadata   string
+}
+
+var tmpl = template.Must(template.New("errors").Parse(`// Code generated by errorsgen. DO NOT EDIT.
+
+package deployment
+
+import (
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+{{ range . }}
+	"{{ .ImportPath }}"{{ end }}
+)
+
+// generatedErrorMetadata are the bindings declaring custom errors, registered with DefaultErrorRegistry.
+var generatedErrorMetadata = []*bind.MetaData{ {{- range . }}
+	{{ .Package }}.{{ .Metadata }},{{ end }}
+}
+`))
+
+func main() {
+	out := flag.String("out", "revert_errors_generated.go", "output file")
+	flag.Parse()
+
+	var bindings []binding
+	for dir, importPath := range wrapperDirs {
+		packages, err := os.ReadDir(dir)
+		if err != nil {
+			log.Fatalf("failed to read %s: %v", dir, err)
+		}
+		for _, pkg := range packages {
+			if !pkg.IsDir() {
+				continue
+			}
+			files, err := filepath.Glob(filepath.Join(dir, pkg.Name(), "*.go"))
+			if err != nil || len(files) != 1 {
+				log.Fatalf("expected a single binding in %s, found %v: %v", pkg.Name(), files, err)
+			}
+			src, err := os.ReadFile(files[0])
+			if err != nil {
+				log.Fatalf("failed to read binding %s: %v", pkg.Name(), err)
+			}
+			metadata := metadataRe.FindSubmatch(src)
+			if metadata == nil || !errorRe.Match(src) {
+				continue
+			}
+			bindings = append(bindings, binding{
+				Package:    pkg.Name(),
+				ImportPath: path.Join(importPath, pkg.Name()),
+				Metadata:   string(metadata[1]),
+			})
+		}
+	}
+	sort.Slice(bindings, func(i, j int) bool { return bindings[i].ImportPath < bindings[j].ImportPath })
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, bindings); err != nil {
+		log.Fatal(err)
+	}
+	formatted, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatalf("failed to format generated code: %v", err)
+	}
+	if err := os.WriteFile(*out, formatted, 0600); err != nil {
+		log.Fatal(err)
+	}
+}
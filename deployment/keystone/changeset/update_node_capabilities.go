@@ -3,9 +3,6 @@ package changeset
 import (
 	"encoding/json"
 	"fmt"
-	"strconv"
-
-	chainsel "github.com/smartcontractkit/chain-selectors"
 
 	"github.com/smartcontractkit/chainlink/deployment"
 	kslib "github.com/smartcontractkit/chainlink/deployment/keystone"
@@ -21,11 +18,7 @@ type P2PSignerEnc = internal.P2PSignerEnc
 
 func NewP2PSignerEnc(n *deployment.Node, registryChainSel uint64) (*P2PSignerEnc, error) {
 	// TODO: deduplicate everywhere
-	registryChainID, err := chainsel.ChainIdFromSelector(registryChainSel)
-	if err != nil {
-		return nil, err
-	}
-	registryChainDetails, err := chainsel.GetChainDetailsByChainIDAndFamily(strconv.Itoa(int(registryChainID)), chainsel.FamilyEVM)
+	registryChainDetails, err := deployment.ChainDetails(registryChainSel)
 	if err != nil {
 		return nil, err
 	}
@@ -63,8 +56,7 @@ func (req *MutateNodeCapabilitiesRequest) Validate() error {
 	if len(req.P2pToCapabilities) == 0 {
 		return fmt.Errorf("p2pToCapabilities is empty")
 	}
-	_, exists := chainsel.ChainBySelector(req.RegistryChainSel)
-	if !exists {
+	if _, err := deployment.EVMChainID(req.RegistryChainSel); err != nil {
 		return fmt.Errorf("registry chain selector %d does not exist", req.RegistryChainSel)
 	}
 
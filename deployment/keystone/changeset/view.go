@@ -4,8 +4,6 @@ import (
 	"encoding/json"
 	"fmt"
 
-	chainsel "github.com/smartcontractkit/chain-selectors"
-
 	"github.com/smartcontractkit/chainlink/deployment"
 	commonview "github.com/smartcontractkit/chainlink/deployment/common/view"
 	"github.com/smartcontractkit/chainlink/deployment/keystone"
@@ -24,13 +22,9 @@ func ViewKeystone(e deployment.Environment) (json.Marshaler, error) {
 	}
 	chainViews := make(map[string]view.KeystoneChainView)
 	for chainSel, contracts := range state.ContractSets {
-		chainid, err := chainsel.ChainIdFromSelector(chainSel)
-		if err != nil {
-			return nil, fmt.Errorf("failed to resolve chain id for selector %d: %w", chainSel, err)
-		}
-		chainName, err := chainsel.NameFromChainId(chainid)
+		chainName, err := deployment.ChainName(chainSel)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get name for chainid %d selector %d:%w", chainid, chainSel, err)
+			return nil, fmt.Errorf("failed to get name for selector %d:%w", chainSel, err)
 		}
 		v, err := contracts.View()
 		if err != nil {
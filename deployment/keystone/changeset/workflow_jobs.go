@@ -0,0 +1,300 @@
+package changeset
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	kslib "github.com/smartcontractkit/chainlink/deployment/keystone"
+	kcr "github.com/smartcontractkit/chainlink/v2/core/gethwrappers/keystone/generated/capabilities_registry"
+	"github.com/smartcontractkit/chainlink/v2/core/services/workflows"
+)
+
+var _ deployment.ChangeSet[DeployWorkflowsConfig] = DeployWorkflows
+
+// WorkflowSpec is a workflow to run on a workflow DON.
+type WorkflowSpec struct {
+	// Name of the job, defaults to the workflow name.
+	Name string
+	// Workflow is the yaml workflow definition.
+	Workflow string
+}
+
+// WorkflowDON is a DON running workflows.
+type WorkflowDON struct {
+	Name      string
+	NodeIDs   []string
+	Workflows []WorkflowSpec
+}
+
+// WriteTargetDON is a DON exposing write targets for a set of chains.
+// Write targets are served by the chain relayers of the nodes, which are configured with WriteTargetConfigs.
+type WriteTargetDON struct {
+	Name           string
+	NodeIDs        []string
+	ChainSelectors []uint64
+}
+
+type DeployWorkflowsConfig struct {
+	RegistryChainSel uint64
+	// NodeOperator is the name of the registry node operator of the nodes, its admin is the admin of the nodes.
+	NodeOperator string
+	WorkflowDON  WorkflowDON
+	TargetDON    *WriteTargetDON
+}
+
+func (c DeployWorkflowsConfig) Validate(e deployment.Environment) error {
+	if _, ok := e.Chains[c.RegistryChainSel]; !ok {
+		return fmt.Errorf("registry chain %d not found in environment", c.RegistryChainSel)
+	}
+	if c.NodeOperator == "" {
+		return fmt.Errorf("no node operator")
+	}
+	if c.WorkflowDON.Name == "" || len(c.WorkflowDON.NodeIDs) == 0 {
+		return fmt.Errorf("workflow DON must have a name and nodes")
+	}
+	if len(c.WorkflowDON.Workflows) == 0 {
+		return fmt.Errorf("no workflows for DON %s", c.WorkflowDON.Name)
+	}
+	if c.TargetDON != nil {
+		if c.TargetDON.Name == "" || len(c.TargetDON.NodeIDs) == 0 {
+			return fmt.Errorf("target DON must have a name and nodes")
+		}
+		if len(c.TargetDON.ChainSelectors) == 0 {
+			return fmt.Errorf("no chains for target DON %s", c.TargetDON.Name)
+		}
+		for _, sel := range c.TargetDON.ChainSelectors {
+			if _, err := deployment.EVMChainID(sel); err != nil {
+				return fmt.Errorf("unknown chain selector %d for target DON %s", sel, c.TargetDON.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// DeployWorkflows generates the workflow and OCR3 consensus jobs of the workflow DON and records the workflow DON,
+// and the optional write target DON, in the CapabilitiesRegistry along with their capabilities and nodes,
+// see kslib.ConfigureRegistry. The OCR3Capability contract must be deployed on the registry chain.
+// The caller needs to propose the returned job specs to the offchain system.
+func DeployWorkflows(env deployment.Environment, cfg DeployWorkflowsConfig) (deployment.ChangesetOutput, error) {
+	if err := cfg.Validate(env); err != nil {
+		return deployment.ChangesetOutput{}, fmt.Errorf("%w: %w", deployment.ErrInvalidConfig, err)
+	}
+	wfNodes, err := deployment.NodeInfo(cfg.WorkflowDON.NodeIDs, env.Offchain)
+	if err != nil {
+		return deployment.ChangesetOutput{}, fmt.Errorf("failed to get workflow DON nodes: %w", err)
+	}
+	ocr3Addr, err := contractAddress(env, cfg.RegistryChainSel, kslib.OCR3Capability)
+	if err != nil {
+		return deployment.ChangesetOutput{}, err
+	}
+	jobSpecs, err := ocr3JobSpecs(cfg.RegistryChainSel, wfNodes, ocr3Addr)
+	if err != nil {
+		return deployment.ChangesetOutput{}, err
+	}
+	for _, wf := range cfg.WorkflowDON.Workflows {
+		spec, err := WorkflowJobSpec(wf)
+		if err != nil {
+			return deployment.ChangesetOutput{}, err
+		}
+		for _, node := range wfNodes.NonBootstraps() {
+			jobSpecs[node.NodeID] = append(jobSpecs[node.NodeID], spec)
+		}
+	}
+
+	dons := []kslib.DonCapabilities{{
+		Name:         cfg.WorkflowDON.Name,
+		Nops:         []kslib.NOP{nodeOperator(cfg.NodeOperator, wfNodes)},
+		Capabilities: []kcr.CapabilitiesRegistryCapability{kslib.OCR3Cap},
+	}}
+	if cfg.TargetDON != nil {
+		targetNodes, err := deployment.NodeInfo(cfg.TargetDON.NodeIDs, env.Offchain)
+		if err != nil {
+			return deployment.ChangesetOutput{}, fmt.Errorf("failed to get target DON nodes: %w", err)
+		}
+		don := kslib.DonCapabilities{
+			Name: cfg.TargetDON.Name,
+			Nops: []kslib.NOP{nodeOperator(cfg.NodeOperator, targetNodes)},
+		}
+		for _, sel := range cfg.TargetDON.ChainSelectors {
+			don.Capabilities = append(don.Capabilities, writeTargetCapability(sel))
+		}
+		dons = append(dons, don)
+	}
+
+	_, err = kslib.ConfigureRegistry(context.Background(), env.Logger, kslib.ConfigureContractsRequest{
+		RegistryChainSel: cfg.RegistryChainSel,
+		Env:              &env,
+		Dons:             dons,
+	}, env.ExistingAddresses)
+	if err != nil {
+		return deployment.ChangesetOutput{}, fmt.Errorf("failed to configure registry: %w", err)
+	}
+	return deployment.ChangesetOutput{JobSpecs: jobSpecs}, nil
+}
+
+// WorkflowJobSpec returns the job spec of the workflow, validated the same way the node does.
+func WorkflowJobSpec(wf WorkflowSpec) (string, error) {
+	jb, err := workflows.ValidatedWorkflowJobSpec(context.Background(), workflowJobSpec(wf.Name, wf.Workflow))
+	if err != nil {
+		return "", fmt.Errorf("invalid workflow %s: %w", wf.Name, err)
+	}
+	if wf.Name != "" {
+		return workflowJobSpec(wf.Name, wf.Workflow), nil
+	}
+	return workflowJobSpec(jb.WorkflowSpec.WorkflowName, wf.Workflow), nil
+}
+
+func workflowJobSpec(name, workflow string) string {
+	return fmt.Sprintf(`type = "workflow"
+schemaVersion = 1
+name = %s
+workflow = """
+%s
+"""
+`, strconv.Quote(name), workflow)
+}
+
+// ocr3JobSpecs returns the OCR3 consensus capability jobs of the workflow DON: a bootstrap job for its bootstrap
+// nodes and an oracle job for the others, both tracking the OCR3Capability contract of the registry chain.
+func ocr3JobSpecs(registryChainSel uint64, nodes deployment.Nodes, ocr3Addr string) (map[string][]string, error) {
+	chainID, err := deployment.EVMChainID(registryChainSel)
+	if err != nil {
+		return nil, err
+	}
+	bootstrappers := nodes.BootstrapLocators()
+	if len(bootstrappers) == 0 {
+		return nil, fmt.Errorf("no bootstrap node in the workflow DON")
+	}
+	quoted := make([]string, 0, len(bootstrappers))
+	for _, b := range bootstrappers {
+		quoted = append(quoted, strconv.Quote(b))
+	}
+	specs := make(map[string][]string)
+	for _, node := range nodes {
+		if node.IsBootstrap {
+			specs[node.NodeID] = append(specs[node.NodeID], fmt.Sprintf(ocr3BootstrapSpecTemplate, ocr3Addr, chainID))
+			continue
+		}
+		ocrCfg, ok := node.OCRConfigForChainSelector(registryChainSel)
+		if !ok {
+			return nil, fmt.Errorf("no OCR config for chain %d on node %s", registryChainSel, node.NodeID)
+		}
+		specs[node.NodeID] = append(specs[node.NodeID], fmt.Sprintf(ocr3OracleSpecTemplate,
+			ocr3Addr, ocrCfg.KeyBundleID, strings.Join(quoted, ", "), ocrCfg.TransmitAccount, chainID, ocrCfg.KeyBundleID))
+	}
+	return specs, nil
+}
+
+const ocr3BootstrapSpecTemplate = `type = "bootstrap"
+schemaVersion = 1
+name = "Keystone boot"
+contractID = "%s"
+relay = "evm"
+
+[relayConfig]
+chainID = "%d"
+providerType = "ocr3-capability"
+`
+
+const ocr3OracleSpecTemplate = `type = "offchainreporting2"
+schemaVersion = 1
+name = "Keystone"
+contractID = "%s"
+ocrKeyBundleID = "%s"
+p2pv2Bootstrappers = [%s]
+relay = "evm"
+pluginType = "plugin"
+transmitterID = "%s"
+
+[relayConfig]
+chainID = "%d"
+
+[pluginConfig]
+command = "chainlink-ocr3-capability"
+ocrVersion = 3
+pluginName = "ocr-capability"
+providerType = "ocr3-capability"
+telemetryType = "plugin"
+
+[onchainSigningStrategy]
+strategyName = "multi-chain"
+[onchainSigningStrategy.config]
+evm = "%s"
+`
+
+// WriteTargetConfigs returns the node config enabling the write targets of the target DON, by node ID.
+// Write targets are not jobs: the EVM relayer of a node serves the write target of a chain once its
+// EVM.Workflow config sets the forwarder of the chain and the account the node transmits from.
+// The KeystoneForwarder must be deployed on the chains of the target DON.
+func WriteTargetConfigs(env deployment.Environment, target WriteTargetDON) (map[string]string, error) {
+	nodes, err := deployment.NodeInfo(target.NodeIDs, env.Offchain)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get target DON nodes: %w", err)
+	}
+	configs := make(map[string]string)
+	for _, sel := range target.ChainSelectors {
+		chainID, err := deployment.EVMChainID(sel)
+		if err != nil {
+			return nil, err
+		}
+		forwarderAddr, err := contractAddress(env, sel, kslib.KeystoneForwarder)
+		if err != nil {
+			return nil, err
+		}
+		for _, node := range nodes.NonBootstraps() {
+			ocrCfg, ok := node.OCRConfigForChainSelector(sel)
+			if !ok {
+				return nil, fmt.Errorf("no OCR config for chain %d on node %s", sel, node.NodeID)
+			}
+			configs[node.NodeID] += fmt.Sprintf(writeTargetConfigTemplate, chainID, ocrCfg.TransmitAccount, forwarderAddr)
+		}
+	}
+	return configs, nil
+}
+
+const writeTargetConfigTemplate = `[[EVM]]
+ChainID = "%d"
+
+[EVM.Workflow]
+FromAddress = "%s"
+ForwarderAddress = "%s"
+`
+
+// contractAddress returns the address of the contract of the type in the address book of the chain.
+func contractAddress(env deployment.Environment, chainSel uint64, contractType deployment.ContractType) (string, error) {
+	addrs, err := env.ExistingAddresses.AddressesForChain(chainSel)
+	if err != nil {
+		return "", fmt.Errorf("no addresses found for chain %d: %w", chainSel, err)
+	}
+	for addr, tv := range addrs {
+		if tv.Type == contractType {
+			return addr, nil
+		}
+	}
+	return "", fmt.Errorf("no %s found for chain %d", contractType, chainSel)
+}
+
+// nodeOperator returns the node operator of the non-bootstrap nodes.
+func nodeOperator(name string, nodes deployment.Nodes) kslib.NOP {
+	nop := kslib.NOP{Name: name}
+	for _, node := range nodes.NonBootstraps() {
+		nop.Nodes = append(nop.Nodes, node.PeerID.String())
+	}
+	return nop
+}
+
+// writeTargetCapability is the capability of the write target of the chain, served by the chain relayers of the nodes.
+func writeTargetCapability(chainSel uint64) kcr.CapabilitiesRegistryCapability {
+	name := fmt.Sprintf("write_%d", chainSel)
+	if chainName, err := deployment.ChainName(chainSel); err == nil {
+		name = "write_" + chainName
+	}
+	return kcr.CapabilitiesRegistryCapability{
+		LabelledName:   name,
+		Version:        "1.0.0",
+		CapabilityType: uint8(3), // target
+	}
+}
@@ -0,0 +1,148 @@
+package changeset_test
+
+import (
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/deployment/environment/memory"
+	kslib "github.com/smartcontractkit/chainlink/deployment/keystone"
+	"github.com/smartcontractkit/chainlink/deployment/keystone/changeset"
+)
+
+const testWorkflow = `
+name: "myworkflow"
+owner: "0x00000000000000000000000000000000000000aa"
+triggers:
+  - id: "streams-trigger@1.0.0"
+    config:
+      feedIds:
+        - "0x1111111111111111111100000000000000000000000000000000000000000000"
+
+consensus:
+  - id: "offchain_reporting@1.0.0"
+    ref: "evm_median"
+    inputs:
+      observations:
+        - "$(trigger.outputs)"
+    config:
+      report_id: "0001"
+      aggregation_method: "data_feeds"
+      aggregation_config:
+        feeds:
+          "0x1111111111111111111100000000000000000000000000000000000000000000":
+            deviation: "0.001"
+            heartbeat: 3600
+      encoder: "EVM"
+      encoder_config:
+        abi: "(bytes32 FeedID, uint224 Price, uint32 Timestamp)[] Reports"
+
+targets:
+  - id: "write_ethereum-testnet-sepolia@1.0.0"
+    inputs:
+      signed_report: "$(evm_median.outputs)"
+    config:
+      address: "0x0000000000000000000000000000000000000000"
+      params: ["$(report)"]
+      abi: "receive(report bytes)"
+`
+
+func TestWorkflowJobSpec(t *testing.T) {
+	spec, err := changeset.WorkflowJobSpec(changeset.WorkflowSpec{Workflow: testWorkflow})
+	require.NoError(t, err)
+	require.Contains(t, spec, `type = "workflow"`)
+	require.Contains(t, spec, `name = "myworkflow"`)
+
+	spec, err = changeset.WorkflowJobSpec(changeset.WorkflowSpec{Name: "feeds", Workflow: testWorkflow})
+	require.NoError(t, err)
+	require.Contains(t, spec, `name = "feeds"`)
+
+	_, err = changeset.WorkflowJobSpec(changeset.WorkflowSpec{Name: "broken", Workflow: "name: broken"})
+	require.Error(t, err)
+}
+
+func TestDeployWorkflows(t *testing.T) {
+	t.Parallel()
+	lggr := logger.Test(t)
+	env := memory.NewMemoryEnvironment(t, lggr, zapcore.DebugLevel, memory.MemoryEnvironmentConfig{
+		Nodes:      8,
+		Bootstraps: 1,
+		Chains:     1,
+	})
+	registrySel := env.AllChainSelectors()[0]
+
+	resp, err := changeset.DeployCapabilityRegistry(env, registrySel)
+	require.NoError(t, err)
+	require.NoError(t, env.ExistingAddresses.Merge(resp.AddressBook))
+	resp, err = changeset.DeployOCR3(env, registrySel)
+	require.NoError(t, err)
+	require.NoError(t, env.ExistingAddresses.Merge(resp.AddressBook))
+	resp, err = changeset.DeployForwarder(env, registrySel)
+	require.NoError(t, err)
+	require.NoError(t, env.ExistingAddresses.Merge(resp.AddressBook))
+
+	nodes, err := deployment.NodeInfo(env.NodeIDs, env.Offchain)
+	require.NoError(t, err)
+	var bootstrapID string
+	var oracleIDs []string
+	for _, node := range nodes {
+		if node.IsBootstrap {
+			bootstrapID = node.NodeID
+			continue
+		}
+		oracleIDs = append(oracleIDs, node.NodeID)
+	}
+	targetDON := changeset.WriteTargetDON{
+		Name:           "target",
+		NodeIDs:        oracleIDs[4:],
+		ChainSelectors: []uint64{registrySel},
+	}
+
+	out, err := changeset.DeployWorkflows(env, changeset.DeployWorkflowsConfig{
+		RegistryChainSel: registrySel,
+		NodeOperator:     "nop",
+		WorkflowDON: changeset.WorkflowDON{
+			Name:      "wf",
+			NodeIDs:   append([]string{bootstrapID}, oracleIDs[:4]...),
+			Workflows: []changeset.WorkflowSpec{{Workflow: testWorkflow}},
+		},
+		TargetDON: &targetDON,
+	})
+	require.NoError(t, err)
+	require.Len(t, out.JobSpecs, 5)
+	require.Len(t, out.JobSpecs[bootstrapID], 1)
+	require.Contains(t, out.JobSpecs[bootstrapID][0], `type = "bootstrap"`)
+	for _, nodeID := range oracleIDs[:4] {
+		// the OCR3 consensus job and the workflow job
+		require.Len(t, out.JobSpecs[nodeID], 2)
+		require.Contains(t, out.JobSpecs[nodeID][0], `providerType = "ocr3-capability"`)
+		require.Contains(t, out.JobSpecs[nodeID][1], `type = "workflow"`)
+	}
+
+	configs, err := changeset.WriteTargetConfigs(env, targetDON)
+	require.NoError(t, err)
+	require.Len(t, configs, 4)
+	for _, nodeID := range targetDON.NodeIDs {
+		require.Contains(t, configs[nodeID], "[EVM.Workflow]")
+	}
+
+	contractSets, err := kslib.GetContractSets(lggr, &kslib.GetContractSetsRequest{
+		Chains:      env.Chains,
+		AddressBook: env.ExistingAddresses,
+	})
+	require.NoError(t, err)
+	registry := contractSets.ContractSets[registrySel].CapabilitiesRegistry
+	dons, err := registry.GetDONs(nil)
+	require.NoError(t, err)
+	require.Len(t, dons, 2)
+	// only the workflow DON has the OCR3 capability
+	require.NotEqual(t, dons[0].AcceptsWorkflows, dons[1].AcceptsWorkflows)
+	registryNodes, err := registry.GetNodes(nil)
+	require.NoError(t, err)
+	require.Len(t, registryNodes, 8)
+}
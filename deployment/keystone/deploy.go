@@ -8,7 +8,6 @@ import (
 	"errors"
 	"fmt"
 	"sort"
-	"strconv"
 	"strings"
 	"time"
 
@@ -54,8 +53,7 @@ func (r ConfigureContractsRequest) Validate() error {
 			return fmt.Errorf("don validation failed for '%s': %w", don.Name, err)
 		}
 	}
-	_, ok := chainsel.ChainBySelector(r.RegistryChainSel)
-	if !ok {
+	if _, err := deployment.ChainFamily(r.RegistryChainSel); err != nil {
 		return fmt.Errorf("chain %d not found in environment", r.RegistryChainSel)
 	}
 	return nil
@@ -340,6 +338,7 @@ func ConfigureOCR3Contract(env *deployment.Environment, chainSel uint64, dons []
 		}
 
 		_, err := configureOCR3contract(configureOCR3Request{
+			lggr:     env.Logger,
 			cfg:      cfg,
 			chain:    registryChain,
 			contract: contract,
@@ -393,6 +392,7 @@ func ConfigureOCR3ContractFromJD(env *deployment.Environment, cfg ConfigureOCR3C
 		return nil, err
 	}
 	r, err := configureOCR3contract(configureOCR3Request{
+		lggr:     env.Logger,
 		cfg:      cfg.OCR3Config,
 		chain:    registryChain,
 		contract: contract,
@@ -636,11 +636,7 @@ func registerNodes(lggr logger.Logger, req *registerNodesRequest) (*registerNode
 	}
 
 	// TODO: deduplicate everywhere
-	registryChainID, err := chainsel.ChainIdFromSelector(req.chain.Selector)
-	if err != nil {
-		return nil, err
-	}
-	registryChainDetails, err := chainsel.GetChainDetailsByChainIDAndFamily(strconv.Itoa(int(registryChainID)), chainsel.FamilyEVM)
+	registryChainDetails, err := deployment.ChainDetails(req.chain.Selector)
 	if err != nil {
 		return nil, err
 	}
@@ -913,7 +909,7 @@ func configureForwarder(lggr logger.Logger, chain deployment.Chain, fwdr *kf.Key
 			err = DecodeErr(kf.KeystoneForwarderABI, err)
 			return fmt.Errorf("failed to call SetConfig for forwarder %s on chain %d: %w", fwdr.Address().String(), chain.Selector, err)
 		}
-		_, err = chain.Confirm(tx)
+		_, err = chain.ConfirmFinalized(lggr, tx)
 		if err != nil {
 			err = DecodeErr(kf.KeystoneForwarderABI, err)
 			return fmt.Errorf("failed to confirm SetConfig for forwarder %s: %w", fwdr.Address().String(), err)
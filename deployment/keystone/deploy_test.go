@@ -49,14 +49,14 @@ func TestDeploy(t *testing.T) {
 	wfChains := map[uint64]deployment.Chain{}
 	wfChains[sepoliaChainSel] = evmChains[sepoliaChainSel]
 	wfChains[aptosChainSel] = aptosChain
-	wfNodes := memory.NewNodes(t, zapcore.InfoLevel, wfChains, 4, 0, crConfig)
+	wfNodes := memory.NewNodes(t, zapcore.InfoLevel, wfChains, 4, 0, crConfig, nil)
 	require.Len(t, wfNodes, 4)
 
-	cwNodes := memory.NewNodes(t, zapcore.InfoLevel, evmChains, 4, 0, crConfig)
+	cwNodes := memory.NewNodes(t, zapcore.InfoLevel, evmChains, 4, 0, crConfig, nil)
 
 	assetChains := map[uint64]deployment.Chain{}
 	assetChains[sepoliaChainSel] = evmChains[sepoliaChainSel]
-	assetNodes := memory.NewNodes(t, zapcore.InfoLevel, assetChains, 4, 0, crConfig)
+	assetNodes := memory.NewNodes(t, zapcore.InfoLevel, assetChains, 4, 0, crConfig, nil)
 	require.Len(t, assetNodes, 4)
 
 	// TODO: partition nodes into multiple nops
@@ -16,6 +16,8 @@ import (
 	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3confighelper"
 	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
 
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+
 	"github.com/smartcontractkit/chainlink/deployment"
 	kocr3 "github.com/smartcontractkit/chainlink/v2/core/gethwrappers/keystone/generated/ocr3_capability"
 	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/chaintype"
@@ -240,6 +242,7 @@ func GenerateOCR3Config(cfg OracleConfigWithSecrets, nca []NodeKeys) (OCR2Oracle
 }
 
 type configureOCR3Request struct {
+	lggr     logger.Logger
 	cfg      *OracleConfigWithSecrets
 	chain    deployment.Chain
 	contract *kocr3.OCR3Capability
@@ -279,7 +282,7 @@ func configureOCR3contract(req configureOCR3Request) (*configureOCR3Response, er
 		err = DecodeErr(kocr3.OCR3CapabilityABI, err)
 		return nil, fmt.Errorf("failed to call SetConfig for OCR3 contract %s: %w", req.contract.Address().String(), err)
 	}
-	_, err = req.chain.Confirm(tx)
+	_, err = req.chain.ConfirmFinalized(req.lggr, tx)
 	if err != nil {
 		err = DecodeErr(kocr3.OCR3CapabilityABI, err)
 		return nil, fmt.Errorf("failed to confirm SetConfig for OCR3 contract %s: %w", req.contract.Address().String(), err)
@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"slices"
 	"sort"
-	"strconv"
 	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -56,7 +55,7 @@ func toNodeKeys(o *deployment.Node, registryChainSel uint64) NodeKeys {
 	var aptosOnchainPublicKey string
 	var aptosCC *deployment.OCRConfig
 	for details, cfg := range o.SelToOCRConfig {
-		if family, err := chainsel.GetSelectorFamily(details.ChainSelector); err == nil && family == chainsel.FamilyAptos {
+		if family, err := deployment.ChainFamily(details.ChainSelector); err == nil && family == chainsel.FamilyAptos {
 			aptosCC = &cfg
 			break
 		}
@@ -65,11 +64,7 @@ func toNodeKeys(o *deployment.Node, registryChainSel uint64) NodeKeys {
 		aptosOcr2KeyBundleId = aptosCC.KeyBundleID
 		aptosOnchainPublicKey = fmt.Sprintf("%x", aptosCC.OnchainPublicKey[:])
 	}
-	registryChainID, err := chainsel.ChainIdFromSelector(registryChainSel)
-	if err != nil {
-		panic(err)
-	}
-	registryChainDetails, err := chainsel.GetChainDetailsByChainIDAndFamily(strconv.Itoa(int(registryChainID)), chainsel.FamilyEVM)
+	registryChainDetails, err := deployment.ChainDetails(registryChainSel)
 	if err != nil {
 		panic(err)
 	}
@@ -239,7 +234,7 @@ func (d RegisteredDon) signers(chainFamily string) []common.Address {
 		var found bool
 		var registryChainDetails chainsel.ChainDetails
 		for details, _ := range n.SelToOCRConfig {
-			if family, err := chainsel.GetSelectorFamily(details.ChainSelector); err == nil && family == chainFamily {
+			if family, err := deployment.ChainFamily(details.ChainSelector); err == nil && family == chainFamily {
 				found = true
 				registryChainDetails = details
 
@@ -16,3 +16,28 @@ func DeployChannelConfigStoreChangeSet(env deployment.Environment, c llodeployme
 		AddressBook: ab,
 	}, nil
 }
+
+func DeployVerifierChangeSet(env deployment.Environment, c llodeployment.DeployLLOContractConfig) (deployment.ChangesetOutput, error) {
+	ab := deployment.NewMemoryAddressBook()
+	err := llodeployment.DeployVerifier(env, ab, c)
+	if err != nil {
+		env.Logger.Errorw("Failed to deploy Verifier", "err", err, "addresses", ab)
+		return deployment.ChangesetOutput{AddressBook: ab}, deployment.MaybeDataErr(err)
+	}
+	return deployment.ChangesetOutput{
+		AddressBook: ab,
+	}, nil
+}
+
+// ConfigureFeedsChangeSet sets the feed configs on the Verifier and returns the mercury job specs of the DON.
+// The caller needs to propose these job specs to the offchain system.
+func ConfigureFeedsChangeSet(env deployment.Environment, c llodeployment.ConfigureFeedsConfig) (deployment.ChangesetOutput, error) {
+	specs, err := llodeployment.ConfigureFeeds(env, c)
+	if err != nil {
+		env.Logger.Errorw("Failed to configure feeds", "err", err)
+		return deployment.ChangesetOutput{}, deployment.MaybeDataErr(err)
+	}
+	return deployment.ChangesetOutput{
+		JobSpecs: specs,
+	}, nil
+}
@@ -2,9 +2,12 @@ package changeset
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/smartcontractkit/chainlink/deployment"
+	commontypes "github.com/smartcontractkit/chainlink/deployment/common/types"
 	"github.com/smartcontractkit/chainlink/deployment/llo"
 )
 
@@ -27,3 +30,60 @@ func TestDeployChannelConfigStoreChangeSet(t *testing.T) {
 		}
 	}
 }
+
+func TestDeployVerifierChangeSet(t *testing.T) {
+	e := newMemoryEnv(t)
+	c := llo.DeployLLOContractConfig{
+		ChainsToDeploy: []uint64{TestChain.Selector},
+	}
+	out, err := DeployVerifierChangeSet(e, c)
+	require.NoError(t, err)
+	require.NoError(t, e.ExistingAddresses.Merge(out.AddressBook))
+
+	addrs, err := e.ExistingAddresses.AddressesForChain(TestChain.Selector)
+	require.NoError(t, err)
+	state, err := llo.LoadChainState(e.Chains[TestChain.Selector], addrs)
+	require.NoError(t, err)
+	require.NotNil(t, state.VerifierProxy)
+	require.NotNil(t, state.Verifier)
+
+	// deploying again is a no-op
+	out, err = DeployVerifierChangeSet(e, c)
+	require.NoError(t, err)
+	ab, err := out.AddressBook.Addresses()
+	require.NoError(t, err)
+	require.Empty(t, ab)
+
+	feedID := [32]byte{0: 0x00, 1: 0x01, 31: 0x01}
+	out, err = ConfigureFeedsChangeSet(e, llo.ConfigureFeedsConfig{
+		ChainSel: TestChain.Selector,
+		NodeIDs:  e.NodeIDs,
+		Feeds: []llo.FeedConfig{{
+			FeedID:            feedID,
+			Name:              "eth-usd",
+			ObservationSource: `ds [type=memo value="1"];`,
+		}},
+		OCRParameters: commontypes.OCRParameters{
+			DeltaProgress:                           30 * time.Second,
+			DeltaResend:                             10 * time.Second,
+			DeltaInitial:                            20 * time.Second,
+			DeltaRound:                              2 * time.Second,
+			DeltaGrace:                              2 * time.Second,
+			DeltaCertifiedCommitRequest:             10 * time.Second,
+			DeltaStage:                              10 * time.Second,
+			Rmax:                                    3,
+			MaxDurationQuery:                        time.Second,
+			MaxDurationObservation:                  time.Second,
+			MaxDurationShouldAcceptAttestedReport:   time.Second,
+			MaxDurationShouldTransmitAcceptedReport: time.Second,
+		},
+		OCRSecrets:   deployment.XXXGenerateTestOCRSecrets(),
+		ServerURL:    "mercury.example.com:443",
+		ServerPubKey: "0000000000000000000000000000000000000000000000000000000000000001",
+	})
+	require.NoError(t, err)
+	require.Len(t, out.JobSpecs, len(e.NodeIDs))
+	for _, specs := range out.JobSpecs {
+		require.Len(t, specs, 1)
+	}
+}
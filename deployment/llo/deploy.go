@@ -39,6 +39,8 @@ type ContractDeploy[C LLOContract] struct {
 
 var (
 	ChannelConfigStore deployment.ContractType = "ChannelConfigStore"
+	VerifierProxy      deployment.ContractType = "VerifierProxy"
+	Verifier           deployment.ContractType = "Verifier"
 )
 
 func DeployChannelConfigStore(e deployment.Environment, ab deployment.AddressBook, c DeployLLOContractConfig) error {
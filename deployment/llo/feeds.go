@@ -0,0 +1,257 @@
+package llo
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/confighelper"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3confighelper"
+	ocrtypes "github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/deployment/common/types"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/llo-feeds/generated/verifier"
+	"github.com/smartcontractkit/chainlink/v2/core/services/relay/evm"
+)
+
+// FeedConfig is a Data Streams feed served by a DON.
+type FeedConfig struct {
+	FeedID [32]byte
+	Name   string
+	// ObservationSource is the pipeline run by the mercury job to observe the feed.
+	ObservationSource string
+	// LinkFeedID and NativeFeedID are the feeds used to price the report fees.
+	// They are required for v2 and v3 reports, and must be nil for v1 reports.
+	LinkFeedID   *[32]byte
+	NativeFeedID *[32]byte
+	// OnchainConfig is encoded with the mercury StandardOnchainConfigCodec.
+	OnchainConfig []byte
+	// ReportingPluginConfig is the JSON encoded mercury offchain config.
+	ReportingPluginConfig []byte
+}
+
+type ConfigureFeedsConfig struct {
+	ChainSel uint64
+	// NodeIDs are the nodes of the DON, including its bootstrap nodes.
+	NodeIDs       []string
+	Feeds         []FeedConfig
+	OCRParameters types.OCRParameters
+	// OCRSecrets are the secrets the offchain config is encrypted with, the same secrets must be used by all the
+	// signers of a config.
+	OCRSecrets deployment.OCRSecrets `json:"-" toml:"-"`
+	// ServerURL and ServerPubKey identify the mercury server the DON transmits reports to.
+	ServerURL    string
+	ServerPubKey string
+}
+
+func (c ConfigureFeedsConfig) Validate(e deployment.Environment) error {
+	if _, ok := e.Chains[c.ChainSel]; !ok {
+		return fmt.Errorf("chain %d not found in environment", c.ChainSel)
+	}
+	if len(c.NodeIDs) == 0 {
+		return fmt.Errorf("no nodes")
+	}
+	if len(c.Feeds) == 0 {
+		return fmt.Errorf("no feeds")
+	}
+	seen := make(map[[32]byte]struct{})
+	for _, feed := range c.Feeds {
+		if _, ok := seen[feed.FeedID]; ok {
+			return fmt.Errorf("duplicate feed %x", feed.FeedID)
+		}
+		seen[feed.FeedID] = struct{}{}
+		if feed.Name == "" || feed.ObservationSource == "" {
+			return fmt.Errorf("feed %x must have a name and an observation source", feed.FeedID)
+		}
+		if (feed.LinkFeedID == nil) != (feed.NativeFeedID == nil) {
+			return fmt.Errorf("feed %s must set both or neither of the link and native feed ids", feed.Name)
+		}
+	}
+	if c.ServerURL == "" || c.ServerPubKey == "" {
+		return fmt.Errorf("mercury server URL and public key are required")
+	}
+	if c.OCRSecrets.IsEmpty() {
+		return fmt.Errorf("OCR secrets are required")
+	}
+	return c.OCRParameters.Validate()
+}
+
+// ConfigureFeeds sets the OCR config of every feed on the Verifier of the chain,
+// and returns the bootstrap and mercury job specs of the DON keyed by node id.
+func ConfigureFeeds(e deployment.Environment, c ConfigureFeedsConfig) (map[string][]string, error) {
+	if err := c.Validate(e); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+	chain := e.Chains[c.ChainSel]
+	state, err := existingChainState(e, chain)
+	if err != nil {
+		return nil, err
+	}
+	if state.Verifier == nil {
+		return nil, fmt.Errorf("no Verifier on chain %d", c.ChainSel)
+	}
+	nodes, err := deployment.NodeInfo(c.NodeIDs, e.Offchain)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get node info: %w", err)
+	}
+	oracles := nodes.NonBootstraps()
+	if len(oracles) < 4 {
+		return nil, fmt.Errorf("need at least 4 non-bootstrap nodes, got %d", len(oracles))
+	}
+
+	var identities []confighelper.OracleIdentityExtra
+	var offchainTransmitters [][32]byte
+	for _, node := range oracles {
+		ocrCfg, ok := node.OCRConfigForChainSelector(c.ChainSel)
+		if !ok {
+			return nil, fmt.Errorf("no OCR config for chain %d on node %s", c.ChainSel, node.NodeID)
+		}
+		// mercury reports are transmitted to the server with the CSA key, not sent onchain
+		csaKey, err := csaPublicKey(node)
+		if err != nil {
+			return nil, err
+		}
+		identities = append(identities, confighelper.OracleIdentityExtra{
+			OracleIdentity: confighelper.OracleIdentity{
+				OnchainPublicKey:  ocrCfg.OnchainPublicKey,
+				OffchainPublicKey: ocrCfg.OffchainPublicKey,
+				PeerID:            ocrCfg.PeerID.Raw(),
+				TransmitAccount:   ocrtypes.Account(hex.EncodeToString(csaKey[:])),
+			},
+			ConfigEncryptionPublicKey: ocrCfg.ConfigEncryptionPublicKey,
+		})
+		offchainTransmitters = append(offchainTransmitters, csaKey)
+	}
+
+	p := c.OCRParameters
+	for _, feed := range c.Feeds {
+		signers, _, f, onchainConfig, offchainConfigVersion, offchainConfig, err := ocr3confighelper.ContractSetConfigArgsDeterministic(
+			c.OCRSecrets.EphemeralSk,
+			c.OCRSecrets.SharedSecret,
+			p.DeltaProgress,
+			p.DeltaResend,
+			p.DeltaInitial,
+			p.DeltaRound,
+			p.DeltaGrace,
+			p.DeltaCertifiedCommitRequest,
+			p.DeltaStage,
+			p.Rmax,
+			[]int{len(identities)},
+			identities,
+			feed.ReportingPluginConfig,
+			nil, // maxDurationInitialization
+			0,   // maxDurationQuery
+			p.MaxDurationObservation,
+			0, // maxDurationShouldAcceptAttestedReport
+			0, // maxDurationShouldTransmitAcceptedReport
+			(len(identities)-1)/3,
+			feed.OnchainConfig,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build OCR config for feed %s: %w", feed.Name, err)
+		}
+		signerAddresses, err := evm.OnchainPublicKeyToAddress(signers)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert signers of feed %s: %w", feed.Name, err)
+		}
+		tx, err := state.Verifier.SetConfig(chain.DeployerKey, feed.FeedID, signerAddresses, offchainTransmitters, f,
+			onchainConfig, offchainConfigVersion, offchainConfig, []verifier.CommonAddressAndWeight{})
+		if _, err := deployment.ConfirmFinalizedIfNoError(e.Logger, chain, tx, err); err != nil {
+			return nil, fmt.Errorf("failed to set config of feed %s: %w", feed.Name, err)
+		}
+		e.Logger.Infow("Set feed config", "feed", feed.Name, "feedID", hex.EncodeToString(feed.FeedID[:]), "chainSelector", c.ChainSel)
+	}
+
+	return mercuryJobSpecs(c, nodes, state.Verifier.Address())
+}
+
+func mercuryJobSpecs(c ConfigureFeedsConfig, nodes deployment.Nodes, verifierAddr common.Address) (map[string][]string, error) {
+	chainID, err := deployment.EVMChainID(c.ChainSel)
+	if err != nil {
+		return nil, err
+	}
+	bootstrappers := nodes.BootstrapLocators()
+	specs := make(map[string][]string)
+	for _, node := range nodes {
+		for _, feed := range c.Feeds {
+			if node.IsBootstrap {
+				specs[node.NodeID] = append(specs[node.NodeID], fmt.Sprintf(bootstrapSpecTemplate,
+					feed.Name, verifierAddr, feed.FeedID, chainID))
+				continue
+			}
+			ocrCfg, ok := node.OCRConfigForChainSelector(c.ChainSel)
+			if !ok {
+				return nil, fmt.Errorf("no OCR config for chain %d on node %s", c.ChainSel, node.NodeID)
+			}
+			csaKey, err := csaPublicKey(node)
+			if err != nil {
+				return nil, err
+			}
+			var feePricing string
+			if feed.LinkFeedID != nil {
+				feePricing = fmt.Sprintf("linkFeedID = \"0x%x\"\nnativeFeedID = \"0x%x\"\n", *feed.LinkFeedID, *feed.NativeFeedID)
+			}
+			specs[node.NodeID] = append(specs[node.NodeID], fmt.Sprintf(mercurySpecTemplate,
+				feed.Name, verifierAddr, feed.FeedID, ocrCfg.KeyBundleID, strings.Join(quoted(bootstrappers), ", "),
+				csaKey, feed.ObservationSource, c.ServerURL, c.ServerPubKey, feePricing, chainID))
+		}
+	}
+	return specs, nil
+}
+
+const bootstrapSpecTemplate = `type = "bootstrap"
+relay = "evm"
+schemaVersion = 1
+name = "boot-%s"
+contractID = "%s"
+feedID = "0x%x"
+contractConfigTrackerPollInterval = "1s"
+
+[relayConfig]
+chainID = %d
+`
+
+const mercurySpecTemplate = `type = "offchainreporting2"
+schemaVersion = 1
+name = "mercury-%s"
+forwardingAllowed = false
+maxTaskDuration = "1s"
+contractID = "%s"
+feedID = "0x%x"
+contractConfigTrackerPollInterval = "1s"
+ocrKeyBundleID = "%s"
+p2pv2Bootstrappers = [%s]
+relay = "evm"
+pluginType = "mercury"
+transmitterID = "%x"
+observationSource = """
+%s
+"""
+
+[pluginConfig]
+serverURL = "%s"
+serverPubKey = "%s"
+%s
+[relayConfig]
+chainID = %d
+`
+
+func csaPublicKey(node deployment.Node) ([32]byte, error) {
+	var key [32]byte
+	b, err := hex.DecodeString(strings.TrimPrefix(node.CSAKey, "csa_"))
+	if err != nil || len(b) != len(key) {
+		return key, fmt.Errorf("invalid CSA key %q on node %s", node.CSAKey, node.NodeID)
+	}
+	copy(key[:], b)
+	return key, nil
+}
+
+func quoted(ss []string) []string {
+	out := make([]string, 0, len(ss))
+	for _, s := range ss {
+		out = append(out, fmt.Sprintf("%q", s))
+	}
+	return out
+}
@@ -7,12 +7,16 @@ import (
 
 	"github.com/smartcontractkit/chainlink/deployment"
 	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/llo-feeds/generated/channel_config_store"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/llo-feeds/generated/verifier"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/llo-feeds/generated/verifier_proxy"
 )
 
 // LLOChainState holds a Go binding for all the currently deployed LLO contracts
 // on a chain. If a binding is nil, it means here is no such contract on the chain.
 type LLOChainState struct {
 	ChannelConfigStore *channel_config_store.ChannelConfigStore
+	VerifierProxy      *verifier_proxy.VerifierProxy
+	Verifier           *verifier.Verifier
 }
 
 // LoadChainState Loads all state for a chain into state
@@ -26,6 +30,18 @@ func LoadChainState(chain deployment.Chain, addresses map[string]deployment.Type
 				return state, err
 			}
 			state.ChannelConfigStore = ccs
+		case deployment.NewTypeAndVersion(VerifierProxy, deployment.Version1_0_0).String():
+			vp, err := verifier_proxy.NewVerifierProxy(common.HexToAddress(address), chain.Client)
+			if err != nil {
+				return state, err
+			}
+			state.VerifierProxy = vp
+		case deployment.NewTypeAndVersion(Verifier, deployment.Version1_0_0).String():
+			v, err := verifier.NewVerifier(common.HexToAddress(address), chain.Client)
+			if err != nil {
+				return state, err
+			}
+			state.Verifier = v
 		default:
 			return state, fmt.Errorf("unknown contract %s", tvStr)
 		}
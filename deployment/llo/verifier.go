@@ -0,0 +1,82 @@
+package llo
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/llo-feeds/generated/verifier"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/llo-feeds/generated/verifier_proxy"
+)
+
+// DeployVerifier deploys a VerifierProxy and a Verifier behind it to every chain of the config.
+// Chains that already have both contracts in the environment address book are skipped.
+func DeployVerifier(e deployment.Environment, ab deployment.AddressBook, c DeployLLOContractConfig) error {
+	for _, chainSel := range c.ChainsToDeploy {
+		chain, ok := e.Chains[chainSel]
+		if !ok {
+			return fmt.Errorf("Chain %d not found", chainSel)
+		}
+		existing, err := existingChainState(e, chain)
+		if err != nil {
+			return err
+		}
+		if existing.VerifierProxy != nil && existing.Verifier != nil {
+			e.Logger.Infow("Verifier already deployed", "chainSelector", chain.Selector)
+			continue
+		}
+		if err := deployVerifierToChain(e, chain, ab); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deployVerifierToChain deploys a VerifierProxy, a Verifier pointing to it,
+// and registers the Verifier with the proxy.
+//
+// Note that this function modifies the given address book variable.
+func deployVerifierToChain(e deployment.Environment, chain deployment.Chain, ab deployment.AddressBook) error {
+	proxy, err := deployment.DeployContract(e.Logger, chain, ab,
+		func(chain deployment.Chain) deployment.ContractDeploy[*verifier_proxy.VerifierProxy] {
+			// no access controller: verification is open to everyone
+			addr, tx, vp, err2 := verifier_proxy.DeployVerifierProxy(chain.DeployerKey, chain.Client, common.Address{})
+			return deployment.ContractDeploy[*verifier_proxy.VerifierProxy]{
+				Address: addr, Contract: vp, Tx: tx, Err: err2,
+				Tv: deployment.NewTypeAndVersion(VerifierProxy, deployment.Version1_0_0),
+			}
+		})
+	if err != nil {
+		return fmt.Errorf("failed to deploy VerifierProxy: %w", err)
+	}
+	v, err := deployment.DeployContract(e.Logger, chain, ab,
+		func(chain deployment.Chain) deployment.ContractDeploy[*verifier.Verifier] {
+			addr, tx, v, err2 := verifier.DeployVerifier(chain.DeployerKey, chain.Client, proxy.Address)
+			return deployment.ContractDeploy[*verifier.Verifier]{
+				Address: addr, Contract: v, Tx: tx, Err: err2,
+				Tv: deployment.NewTypeAndVersion(Verifier, deployment.Version1_0_0),
+			}
+		})
+	if err != nil {
+		return fmt.Errorf("failed to deploy Verifier: %w", err)
+	}
+	tx, err := proxy.Contract.InitializeVerifier(chain.DeployerKey, v.Address)
+	if _, err := deployment.ConfirmIfNoError(chain, tx, err); err != nil {
+		return fmt.Errorf("failed to initialize Verifier on VerifierProxy: %w", err)
+	}
+	return nil
+}
+
+func existingChainState(e deployment.Environment, chain deployment.Chain) (LLOChainState, error) {
+	addresses, err := e.ExistingAddresses.AddressesForChain(chain.Selector)
+	if errors.Is(err, deployment.ErrChainNotFound) {
+		// nothing deployed yet
+		return LLOChainState{}, nil
+	}
+	if err != nil {
+		return LLOChainState{}, err
+	}
+	return LoadChainState(chain, addresses)
+}
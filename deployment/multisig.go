@@ -0,0 +1,156 @@
+package deployment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gagliardetto/solana-go"
+	chainsel "github.com/smartcontractkit/chain-selectors"
+)
+
+// MultisigBatch is a batch of calls executed atomically by the multisig of a non-EVM chain, the counterpart
+// of a timelock.BatchChainOperation. Only the calls of the family of the chain are set.
+type MultisigBatch struct {
+	ChainSelector uint64 `json:"chainSelector"`
+	// SolInstructions are the instructions of the batch on Solana chains, the multisig vault signs them.
+	SolInstructions []SolInstruction `json:"solInstructions,omitempty"`
+	// AptosCalls are the entry function calls of the batch on Aptos chains, they are sent from the multisig account.
+	AptosCalls []AptosEntryFunction `json:"aptosCalls,omitempty"`
+}
+
+// MultisigProposal is the counterpart of timelock.MCMSWithTimelockProposal for the chains which don't have the
+// MCMS contracts. Changesets touching chains of several families return the timelock proposals of their EVM chains
+// and the multisig proposals of the others, and both are executed when the output is applied. Proposals are
+// serialized as JSON to be handed to the other signers of the multisigs.
+type MultisigProposal struct {
+	Description string          `json:"description"`
+	Batches     []MultisigBatch `json:"batches"`
+}
+
+// SolInstruction is a solana.Instruction which can be serialized, see NewSolInstructions.
+type SolInstruction struct {
+	Program solana.PublicKey `json:"programId"`
+	Metas   []SolAccountMeta `json:"accounts"`
+	Bytes   []byte           `json:"data"`
+}
+
+type SolAccountMeta struct {
+	PublicKey  solana.PublicKey `json:"pubkey"`
+	IsWritable bool             `json:"isWritable"`
+	IsSigner   bool             `json:"isSigner"`
+}
+
+var _ solana.Instruction = SolInstruction{}
+
+// NewSolInstructions converts the instructions to SolInstruction, e.g. to build a MultisigBatch.
+func NewSolInstructions(instructions ...solana.Instruction) ([]SolInstruction, error) {
+	converted := make([]SolInstruction, 0, len(instructions))
+	for _, ix := range instructions {
+		data, err := ix.Data()
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode instruction to program %s: %w", ix.ProgramID(), err)
+		}
+		sol := SolInstruction{Program: ix.ProgramID(), Bytes: data}
+		for _, meta := range ix.Accounts() {
+			sol.Metas = append(sol.Metas, SolAccountMeta{PublicKey: meta.PublicKey, IsWritable: meta.IsWritable, IsSigner: meta.IsSigner})
+		}
+		converted = append(converted, sol)
+	}
+	return converted, nil
+}
+
+func (ix SolInstruction) ProgramID() solana.PublicKey {
+	return ix.Program
+}
+
+func (ix SolInstruction) Accounts() []*solana.AccountMeta {
+	metas := make([]*solana.AccountMeta, 0, len(ix.Metas))
+	for _, meta := range ix.Metas {
+		metas = append(metas, &solana.AccountMeta{PublicKey: meta.PublicKey, IsWritable: meta.IsWritable, IsSigner: meta.IsSigner})
+	}
+	return metas
+}
+
+func (ix SolInstruction) Data() ([]byte, error) {
+	return ix.Bytes, nil
+}
+
+func (p MultisigProposal) Validate() error {
+	if len(p.Batches) == 0 {
+		return errors.New("proposal has no batches")
+	}
+	for i, batch := range p.Batches {
+		family, err := ChainFamily(batch.ChainSelector)
+		if err != nil {
+			return fmt.Errorf("batch %d: %w", i, err)
+		}
+		switch family {
+		case chainsel.FamilySolana:
+			if len(batch.SolInstructions) == 0 || len(batch.AptosCalls) != 0 {
+				return fmt.Errorf("batch %d for solana chain %d must only have instructions", i, batch.ChainSelector)
+			}
+		case chainsel.FamilyAptos:
+			if len(batch.AptosCalls) == 0 || len(batch.SolInstructions) != 0 {
+				return fmt.Errorf("batch %d for aptos chain %d must only have entry function calls", i, batch.ChainSelector)
+			}
+		default:
+			return fmt.Errorf("batch %d: chains of family %s use timelock proposals", i, family)
+		}
+	}
+	return nil
+}
+
+// ChainSelectors returns the selectors of the chains of the batches, in order of first appearance.
+func (p MultisigProposal) ChainSelectors() []uint64 {
+	var selectors []uint64
+	seen := make(map[uint64]bool)
+	for _, batch := range p.Batches {
+		if !seen[batch.ChainSelector] {
+			seen[batch.ChainSelector] = true
+			selectors = append(selectors, batch.ChainSelector)
+		}
+	}
+	return selectors
+}
+
+// MultisigExecutor proposes, approves and executes batches on the multisig of a chain with the deployer key of
+// the chain, a member of the multisig. There is one implementation per chain family, see SolSquadsMultisig and
+// AptosMultisig. Batches whose multisig requires the approvals of other signers are proposed by one of them, who
+// hands the proposal and the index of the batch to the others, who approve it with their own executors, then
+// it is executed once approved by enough signers.
+type MultisigExecutor interface {
+	// ProposeBatch proposes the batch, approved by the deployer key, and returns the index of its multisig
+	// transaction, or of its first one if the calls of the batch are separate transactions.
+	ProposeBatch(ctx context.Context, batch MultisigBatch) (uint64, error)
+	// ApproveBatch approves the proposed batch with the deployer key.
+	ApproveBatch(ctx context.Context, batch MultisigBatch, index uint64) error
+	// ExecuteApprovedBatch executes the proposed batch, which must be approved by the threshold of the multisig,
+	// and returns the id of the last transaction executing it: its signature on Solana, its hash on Aptos.
+	ExecuteApprovedBatch(ctx context.Context, batch MultisigBatch, index uint64) (string, error)
+	// ExecuteBatch proposes the batch, approves it with the deployer key and the signers of the executor,
+	// and executes it, like ExecuteApprovedBatch.
+	ExecuteBatch(ctx context.Context, batch MultisigBatch) (string, error)
+}
+
+// ExecuteMultisigProposal executes the batches of the proposal in order with the executors of their chains, and
+// returns the id of the last transaction executing the proposal on each chain.
+func ExecuteMultisigProposal(ctx context.Context, executors map[uint64]MultisigExecutor, p MultisigProposal) (map[uint64]string, error) {
+	if err := p.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid proposal %q: %w", p.Description, err)
+	}
+	for _, sel := range p.ChainSelectors() {
+		if _, ok := executors[sel]; !ok {
+			return nil, fmt.Errorf("multisig not found for chain %d", sel)
+		}
+	}
+	txIDs := make(map[uint64]string)
+	for i, batch := range p.Batches {
+		txID, err := executors[batch.ChainSelector].ExecuteBatch(ctx, batch)
+		if err != nil {
+			return txIDs, fmt.Errorf("failed to execute batch %d of proposal %q on chain %d: %w", i, p.Description, batch.ChainSelector, err)
+		}
+		txIDs[batch.ChainSelector] = txID
+	}
+	return txIDs, nil
+}
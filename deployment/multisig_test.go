@@ -0,0 +1,81 @@
+package deployment
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+	chainsel "github.com/smartcontractkit/chain-selectors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultisigProposalValidate(t *testing.T) {
+	sol := chainsel.SOLANA_DEVNET.Selector
+	aptos := chainsel.APTOS_TESTNET.Selector
+	ix := SolInstruction{Program: solana.SystemProgramID}
+	call := AptosEntryFunction{ModuleName: "m", Function: "f"}
+
+	p := MultisigProposal{Batches: []MultisigBatch{
+		{ChainSelector: sol, SolInstructions: []SolInstruction{ix}},
+		{ChainSelector: aptos, AptosCalls: []AptosEntryFunction{call}},
+		{ChainSelector: sol, SolInstructions: []SolInstruction{ix}},
+	}}
+	require.NoError(t, p.Validate())
+	require.Equal(t, []uint64{sol, aptos}, p.ChainSelectors())
+
+	// the proposal is handed to the other signers as JSON
+	b, err := json.Marshal(p)
+	require.NoError(t, err)
+	var decoded MultisigProposal
+	require.NoError(t, json.Unmarshal(b, &decoded))
+	require.Equal(t, p, decoded)
+
+	require.Error(t, MultisigProposal{}.Validate())
+	require.Error(t, MultisigProposal{Batches: []MultisigBatch{{ChainSelector: sol, AptosCalls: []AptosEntryFunction{call}}}}.Validate())
+	require.ErrorContains(t, MultisigProposal{Batches: []MultisigBatch{
+		{ChainSelector: chainsel.TEST_90000001.Selector, SolInstructions: []SolInstruction{ix}},
+	}}.Validate(), "timelock proposals")
+}
+
+func TestCompileSquadsMessage(t *testing.T) {
+	vault := solana.NewWallet().PublicKey()
+	program := solana.NewWallet().PublicKey()
+	state := solana.NewWallet().PublicKey()
+	ix := solana.NewInstruction(program, solana.AccountMetaSlice{
+		solana.Meta(solana.SystemProgramID),
+		solana.Meta(state).WRITE(),
+		solana.Meta(vault).SIGNER(),
+	}, []byte{0xaa, 0xbb})
+
+	instructions, err := NewSolInstructions(ix)
+	require.NoError(t, err)
+	message, remaining, err := compileSquadsMessage(vault, instructions)
+	require.NoError(t, err)
+	// the vault, then the writable accounts, then the readonly accounts
+	require.Equal(t, solana.AccountMetaSlice{
+		solana.Meta(vault).WRITE(),
+		solana.Meta(state).WRITE(),
+		solana.Meta(solana.SystemProgramID),
+		solana.Meta(program),
+	}, remaining)
+	require.Equal(t, []byte{1, 1, 1, 4}, message[:4])
+	instruction := message[4+4*32:]
+	require.Equal(t, []byte{1, 3, 3, 2, 1, 0, 2, 0, 0xaa, 0xbb, 0}, instruction)
+
+	other := solana.NewWallet().PublicKey()
+	_, _, err = compileSquadsMessage(vault, []SolInstruction{
+		{Program: program, Metas: []SolAccountMeta{{PublicKey: other, IsSigner: true}}},
+	})
+	require.Error(t, err)
+}
+
+func TestBCSMultisigPayload(t *testing.T) {
+	payload, err := bcsMultisigPayload(AptosEntryFunction{Module: AptosFrameworkAddress, ModuleName: "m", Function: "f", Args: [][]byte{{7}}})
+	require.NoError(t, err)
+	require.Equal(t, byte(0), payload[0])
+	require.Equal(t, AptosFrameworkAddress[:], payload[1:33])
+	require.Equal(t, []byte{1, 'm', 1, 'f', 0, 1, 1, 7}, payload[33:])
+
+	_, err = bcsMultisigPayload(AptosEntryFunction{TypeArgs: []string{"u64"}})
+	require.Error(t, err)
+}
@@ -0,0 +1,265 @@
+package deployment
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+)
+
+// replacementFeeBumpPercent is the fee increase of replacement transactions, above the 10% required by geth.
+const replacementFeeBumpPercent = 20
+
+// NonceTracker tracks the nonces of the transactions sent by the keys of a chain locally, so that lagging RPCs
+// returning stale pending nonces don't make consecutive transactions reuse nonces, see TrackNonces.
+// When the RPC reports a lower pending nonce than the tracked one, the transactions it lost are sent again,
+// leaving no gaps which would block the later transactions. Lost transactions which can't be sent again as they
+// are are replaced, and waiting for them through the chain waits for their replacements.
+type NonceTracker struct {
+	lggr     logger.Logger
+	selector uint64
+	client   OnchainClient
+	mu       sync.Mutex
+	next     map[common.Address]uint64
+	sent     map[common.Address]map[uint64]*types.Transaction
+	// replaced are the replacements of the lost transactions by hash.
+	replaced map[common.Hash]*types.Transaction
+	signers  map[common.Address]bind.SignerFn
+}
+
+// TrackNonces replaces the clients of the chains with NonceTrackingClients and sets their NonceTrackers.
+// The deployer keys of the chains are registered to sign replacement transactions, and Confirm waits for
+// the replacement of a replaced transaction.
+func TrackNonces(lggr logger.Logger, chains map[uint64]Chain) {
+	for sel, chain := range chains {
+		tracker := &NonceTracker{
+			lggr:     lggr,
+			selector: sel,
+			client:   chain.Client,
+			next:     make(map[common.Address]uint64),
+			sent:     make(map[common.Address]map[uint64]*types.Transaction),
+			replaced: make(map[common.Hash]*types.Transaction),
+			signers:  make(map[common.Address]bind.SignerFn),
+		}
+		if chain.DeployerKey != nil && chain.DeployerKey.Signer != nil {
+			tracker.RegisterSigner(chain.DeployerKey.From, chain.DeployerKey.Signer)
+		}
+		if confirm := chain.Confirm; confirm != nil {
+			chain.Confirm = func(tx *types.Transaction) (uint64, error) {
+				return confirm(tracker.Replacement(tx))
+			}
+		}
+		chain.Client = &NonceTrackingClient{OnchainClient: chain.Client, tracker: tracker}
+		chain.Nonces = tracker
+		chains[sel] = chain
+	}
+}
+
+// RegisterSigner registers the signer of a key, used to sign the replacements of its lost transactions
+// which can't be sent again as they are.
+func (t *NonceTracker) RegisterSigner(account common.Address, signer bind.SignerFn) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.signers[account] = signer
+}
+
+// Next returns the nonce of the next transaction of the account, the tracked one unless the RPC
+// reports a higher pending nonce. Gaps are repaired first.
+func (t *NonceTracker) Next(ctx context.Context, account common.Address) (uint64, error) {
+	pending, err := t.client.PendingNonceAt(ctx, account)
+	if err != nil {
+		return 0, err
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	next, ok := t.next[account]
+	if !ok || pending >= next {
+		t.next[account] = pending
+		return pending, nil
+	}
+	if err := t.repairGap(ctx, account, pending, next); err != nil {
+		return 0, err
+	}
+	return next, nil
+}
+
+// Replacement returns the transaction which replaced the lost transaction, the transaction itself
+// if it was not replaced.
+func (t *NonceTracker) Replacement(tx *types.Transaction) *types.Transaction {
+	if tx == nil {
+		return nil
+	}
+	if replacement, ok := t.replacementOf(tx.Hash()); ok {
+		return replacement
+	}
+	return tx
+}
+
+// replacementOf returns the last replacement of the transaction, replacements can be replaced too.
+func (t *NonceTracker) replacementOf(txHash common.Hash) (*types.Transaction, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	replacement, ok := t.replaced[txHash]
+	for ok {
+		next, replaced := t.replaced[replacement.Hash()]
+		if !replaced {
+			break
+		}
+		replacement = next
+	}
+	return replacement, ok
+}
+
+// RepairGaps sends the transactions of the account the RPC lost again, see NonceTracker.
+func (t *NonceTracker) RepairGaps(ctx context.Context, account common.Address) error {
+	_, err := t.Next(ctx, account)
+	return err
+}
+
+// Resync discards the tracked nonce of the account and resumes from the pending nonce of the RPC,
+// e.g. after transactions were sent with the key outside of the environment.
+func (t *NonceTracker) Resync(ctx context.Context, account common.Address) (uint64, error) {
+	pending, err := t.client.PendingNonceAt(ctx, account)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get pending nonce of %s on chain %d: %w", account, t.selector, err)
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.next[account] = pending
+	for nonce := range t.sent[account] {
+		if nonce >= pending {
+			delete(t.sent[account], nonce)
+		}
+	}
+	return pending, nil
+}
+
+// record tracks a transaction sent by the account.
+func (t *NonceTracker) record(account common.Address, tx *types.Transaction) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if _, ok := t.sent[account]; !ok {
+		t.sent[account] = make(map[uint64]*types.Transaction)
+	}
+	t.sent[account][tx.Nonce()] = tx
+	if tx.Nonce() >= t.next[account] {
+		t.next[account] = tx.Nonce() + 1
+	}
+}
+
+// repairGap sends the tracked transactions of nonces [pending, next) again. The caller holds the lock.
+func (t *NonceTracker) repairGap(ctx context.Context, account common.Address, pending, next uint64) error {
+	confirmed, err := t.client.NonceAt(ctx, account, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get nonce of %s on chain %d: %w", account, t.selector, err)
+	}
+	for nonce := range t.sent[account] {
+		if nonce < confirmed {
+			delete(t.sent[account], nonce)
+		}
+	}
+	for nonce := max(pending, confirmed); nonce < next; nonce++ {
+		tx, ok := t.sent[account][nonce]
+		if !ok {
+			return fmt.Errorf("nonce gap of %s on chain %d at nonce %d of a transaction which was not tracked, resync the nonces",
+				account, t.selector, nonce)
+		}
+		t.lggr.Warnw("Sending lost transaction again", "chain", t.selector, "from", account, "nonce", nonce, "tx", tx.Hash())
+		err := t.client.SendTransaction(ctx, tx)
+		if err == nil || isKnownTxErr(err) {
+			continue
+		}
+		signer, ok := t.signers[account]
+		if !ok {
+			return fmt.Errorf("failed to send lost transaction %s on chain %d again: %w", tx.Hash(), t.selector, err)
+		}
+		replacement, rerr := replaceTx(account, signer, tx)
+		if rerr != nil {
+			return fmt.Errorf("failed to replace lost transaction %s on chain %d: %w", tx.Hash(), t.selector, rerr)
+		}
+		t.lggr.Warnw("Replacing lost transaction", "chain", t.selector, "from", account, "nonce", nonce,
+			"tx", tx.Hash(), "replacement", replacement.Hash(), "err", err)
+		if err := t.client.SendTransaction(ctx, replacement); err != nil && !isKnownTxErr(err) {
+			return fmt.Errorf("failed to send replacement %s of transaction %s on chain %d: %w",
+				replacement.Hash(), tx.Hash(), t.selector, err)
+		}
+		t.sent[account][nonce] = replacement
+		t.replaced[tx.Hash()] = replacement
+	}
+	return nil
+}
+
+// isKnownTxErr returns true for the errors of sending a transaction which is pending or confirmed already.
+func isKnownTxErr(err error) bool {
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "already known") || strings.Contains(msg, "known transaction") ||
+		strings.Contains(msg, "nonce too low")
+}
+
+// replaceTx signs a copy of the transaction with its fees bumped by replacementFeeBumpPercent.
+func replaceTx(account common.Address, signer bind.SignerFn, tx *types.Transaction) (*types.Transaction, error) {
+	bump := func(fee *big.Int) *big.Int {
+		bumped := new(big.Int).Mul(fee, big.NewInt(100+replacementFeeBumpPercent))
+		return bumped.Div(bumped, big.NewInt(100))
+	}
+	var data types.TxData
+	switch tx.Type() {
+	case types.LegacyTxType:
+		data = &types.LegacyTx{
+			Nonce: tx.Nonce(), GasPrice: bump(tx.GasPrice()), Gas: tx.Gas(), To: tx.To(), Value: tx.Value(), Data: tx.Data(),
+		}
+	case types.DynamicFeeTxType:
+		data = &types.DynamicFeeTx{
+			ChainID: tx.ChainId(), Nonce: tx.Nonce(), GasTipCap: bump(tx.GasTipCap()), GasFeeCap: bump(tx.GasFeeCap()),
+			Gas: tx.Gas(), To: tx.To(), Value: tx.Value(), Data: tx.Data(), AccessList: tx.AccessList(),
+		}
+	default:
+		return nil, fmt.Errorf("unsupported transaction type %d", tx.Type())
+	}
+	return signer(account, types.NewTx(data))
+}
+
+// NonceTrackingClient assigns the nonces of the NonceTracker of its chain to the transactions sent through it,
+// see TrackNonces.
+type NonceTrackingClient struct {
+	OnchainClient
+	tracker *NonceTracker
+}
+
+var _ OnchainClient = (*NonceTrackingClient)(nil)
+
+func (c *NonceTrackingClient) unwrap() OnchainClient {
+	return c.OnchainClient
+}
+
+func (c *NonceTrackingClient) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	return c.tracker.Next(ctx, account)
+}
+
+// TransactionReceipt returns the receipt of the replacement of the transaction if it was replaced,
+// so that waiting for a lost transaction with bind.WaitMined returns once its replacement is mined.
+func (c *NonceTrackingClient) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	if replacement, ok := c.tracker.replacementOf(txHash); ok {
+		txHash = replacement.Hash()
+	}
+	return c.OnchainClient.TransactionReceipt(ctx, txHash)
+}
+
+func (c *NonceTrackingClient) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		return fmt.Errorf("failed to recover sender of transaction %s: %w", tx.Hash(), err)
+	}
+	if err := c.OnchainClient.SendTransaction(ctx, tx); err != nil {
+		return err
+	}
+	c.tracker.record(from, tx)
+	return nil
+}
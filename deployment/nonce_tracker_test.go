@@ -0,0 +1,107 @@
+package deployment
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+)
+
+// laggingClient reports the nonces it is told to and loses the transactions sent to it if told to.
+type laggingClient struct {
+	OnchainClient
+	pending, confirmed uint64
+	lose               bool
+	sent               []*types.Transaction
+}
+
+func (c *laggingClient) PendingNonceAt(context.Context, common.Address) (uint64, error) {
+	return c.pending, nil
+}
+
+func (c *laggingClient) NonceAt(context.Context, common.Address, *big.Int) (uint64, error) {
+	return c.confirmed, nil
+}
+
+func (c *laggingClient) SendTransaction(_ context.Context, tx *types.Transaction) error {
+	if c.lose {
+		c.lose = false
+		return errors.New("transaction underpriced")
+	}
+	c.sent = append(c.sent, tx)
+	return nil
+}
+
+func (c *laggingClient) TransactionReceipt(_ context.Context, txHash common.Hash) (*types.Receipt, error) {
+	return &types.Receipt{TxHash: txHash}, nil
+}
+
+func TestTrackNonces(t *testing.T) {
+	ctx := context.Background()
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	deployer, err := bind.NewKeyedTransactorWithChainID(key, big.NewInt(1337))
+	require.NoError(t, err)
+	client := &laggingClient{}
+	var confirmed []*types.Transaction
+	confirm := func(tx *types.Transaction) (uint64, error) {
+		confirmed = append(confirmed, tx)
+		return 0, nil
+	}
+	chains := map[uint64]Chain{1: {Selector: 1, Client: client, DeployerKey: deployer, Confirm: confirm}}
+	TrackNonces(logger.Test(t), chains)
+	chain := chains[1]
+	require.Equal(t, client, UnwrapClient(chain.Client))
+
+	send := func() *types.Transaction {
+		nonce, err := chain.Client.PendingNonceAt(ctx, deployer.From)
+		require.NoError(t, err)
+		tx, err := deployer.Signer(deployer.From, types.NewTx(&types.LegacyTx{Nonce: nonce, Gas: 21000, GasPrice: big.NewInt(10)}))
+		require.NoError(t, err)
+		require.NoError(t, chain.Client.SendTransaction(ctx, tx))
+		return tx
+	}
+	// the RPC keeps reporting a pending nonce of 0
+	tx0, tx1 := send(), send()
+	require.Equal(t, uint64(1), tx1.Nonce())
+
+	// the RPC lost the second transaction, which is sent again
+	client.pending, client.confirmed = 1, 1
+	client.sent = nil
+	next, err := chain.Client.PendingNonceAt(ctx, deployer.From)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), next)
+	require.Equal(t, []*types.Transaction{tx1}, client.sent)
+
+	// sending it again fails, it is replaced with higher fees
+	client.sent, client.lose = nil, true
+	require.NoError(t, chain.Nonces.RepairGaps(ctx, deployer.From))
+	require.Len(t, client.sent, 1)
+	require.Equal(t, uint64(1), client.sent[0].Nonce())
+	require.Equal(t, big.NewInt(12), client.sent[0].GasPrice())
+
+	// waiting for the lost transaction waits for its replacement
+	replacement := client.sent[0]
+	require.Equal(t, replacement, chain.Nonces.Replacement(tx1))
+	require.Equal(t, tx0, chain.Nonces.Replacement(tx0))
+	_, err = chain.Confirm(tx1)
+	require.NoError(t, err)
+	require.Equal(t, []*types.Transaction{replacement}, confirmed)
+	receipt, err := bind.WaitMined(ctx, chain.Client, tx1)
+	require.NoError(t, err)
+	require.Equal(t, replacement.Hash(), receipt.TxHash)
+
+	client.pending = 0
+	resynced, err := chain.Nonces.Resync(ctx, deployer.From)
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), resynced)
+	require.Equal(t, tx0.Nonce(), send().Nonce())
+}
@@ -0,0 +1,176 @@
+package deployment
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// OCRSecretsProvider supplies the OCR secrets of a deployment. The same secrets must be supplied to all
+// the changesets setting OCR configs which are signed together, so providers must be deterministic.
+type OCRSecretsProvider interface {
+	OCRSecrets(ctx context.Context) (OCRSecrets, error)
+}
+
+var (
+	_ OCRSecretsProvider = StaticOCRSecrets{}
+	_ OCRSecretsProvider = TestOCRSecrets{}
+	_ OCRSecretsProvider = &RandomOCRSecrets{}
+	_ OCRSecretsProvider = SealedOCRSecretsFile{}
+	_ OCRSecretsProvider = KMSOCRSecrets{}
+)
+
+// StaticOCRSecrets provides fixed secrets.
+type StaticOCRSecrets OCRSecrets
+
+func (s StaticOCRSecrets) OCRSecrets(context.Context) (OCRSecrets, error) {
+	if OCRSecrets(s).IsEmpty() {
+		return OCRSecrets{}, fmt.Errorf("OCR secrets are empty")
+	}
+	return OCRSecrets(s), nil
+}
+
+// TestOCRSecrets provides the secrets of XXXGenerateTestOCRSecrets, for tests only.
+type TestOCRSecrets struct{}
+
+func (TestOCRSecrets) OCRSecrets(context.Context) (OCRSecrets, error) {
+	return XXXGenerateTestOCRSecrets(), nil
+}
+
+// RandomOCRSecrets provides random secrets, generated on first use and provided from then on.
+// Useful for tests which must not share secrets with other environments.
+type RandomOCRSecrets struct {
+	once    sync.Once
+	secrets OCRSecrets
+	err     error
+}
+
+func (r *RandomOCRSecrets) OCRSecrets(context.Context) (OCRSecrets, error) {
+	r.once.Do(func() {
+		if _, err := rand.Read(r.secrets.SharedSecret[:]); err != nil {
+			r.err = fmt.Errorf("failed to generate shared secret: %w", err)
+			return
+		}
+		if _, err := rand.Read(r.secrets.EphemeralSk[:]); err != nil {
+			r.err = fmt.Errorf("failed to generate ephemeral key: %w", err)
+		}
+	})
+	return r.secrets, r.err
+}
+
+// ocrSecretsJSON is the encoding of OCR secrets in sealed files and KMS ciphertexts.
+type ocrSecretsJSON struct {
+	SharedSecret hexutil.Bytes `json:"sharedSecret"`
+	EphemeralSk  hexutil.Bytes `json:"ephemeralSk"`
+}
+
+func marshalOCRSecrets(s OCRSecrets) ([]byte, error) {
+	return json.Marshal(ocrSecretsJSON{SharedSecret: s.SharedSecret[:], EphemeralSk: s.EphemeralSk[:]})
+}
+
+func unmarshalOCRSecrets(b []byte) (OCRSecrets, error) {
+	var encoded ocrSecretsJSON
+	if err := json.Unmarshal(b, &encoded); err != nil {
+		return OCRSecrets{}, fmt.Errorf("invalid OCR secrets: %w", err)
+	}
+	var s OCRSecrets
+	if len(encoded.SharedSecret) != len(s.SharedSecret) || len(encoded.EphemeralSk) != len(s.EphemeralSk) {
+		return OCRSecrets{}, fmt.Errorf("invalid OCR secrets: shared secret must be %d bytes and ephemeral key %d bytes",
+			len(s.SharedSecret), len(s.EphemeralSk))
+	}
+	copy(s.SharedSecret[:], encoded.SharedSecret)
+	copy(s.EphemeralSk[:], encoded.EphemeralSk)
+	if s.IsEmpty() {
+		return OCRSecrets{}, fmt.Errorf("OCR secrets are empty")
+	}
+	return s, nil
+}
+
+// SealOCRSecrets encrypts the secrets with the AES-256 key, to be provided by a SealedOCRSecretsFile.
+func SealOCRSecrets(s OCRSecrets, key [32]byte) ([]byte, error) {
+	plaintext, err := marshalOCRSecrets(s)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := newOCRSecretsCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	return gcm.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+// OpenOCRSecrets decrypts secrets sealed with SealOCRSecrets.
+func OpenOCRSecrets(sealed []byte, key [32]byte) (OCRSecrets, error) {
+	gcm, err := newOCRSecretsCipher(key)
+	if err != nil {
+		return OCRSecrets{}, err
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return OCRSecrets{}, fmt.Errorf("sealed OCR secrets are too short")
+	}
+	plaintext, err := gcm.Open(nil, sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():], nil)
+	if err != nil {
+		return OCRSecrets{}, fmt.Errorf("failed to open sealed OCR secrets: %w", err)
+	}
+	return unmarshalOCRSecrets(plaintext)
+}
+
+func newOCRSecretsCipher(key [32]byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// SealedOCRSecretsFile provides the secrets sealed with SealOCRSecrets in a file.
+type SealedOCRSecretsFile struct {
+	Path string
+	Key  [32]byte
+}
+
+func (f SealedOCRSecretsFile) OCRSecrets(context.Context) (OCRSecrets, error) {
+	sealed, err := os.ReadFile(f.Path)
+	if err != nil {
+		return OCRSecrets{}, fmt.Errorf("failed to read OCR secrets file: %w", err)
+	}
+	return OpenOCRSecrets(sealed, f.Key)
+}
+
+// KMSDecrypter is the subset of the KMS client decrypting KMS wrapped OCR secrets.
+type KMSDecrypter interface {
+	DecryptWithContext(ctx aws.Context, input *kms.DecryptInput, opts ...request.Option) (*kms.DecryptOutput, error)
+}
+
+// KMSOCRSecrets provides the secrets wrapped with the KMS key, as the ciphertext of their JSON encoding
+// {"sharedSecret": "0x...", "ephemeralSk": "0x..."}.
+type KMSOCRSecrets struct {
+	Client     KMSDecrypter
+	KeyID      string
+	Ciphertext []byte
+}
+
+func (k KMSOCRSecrets) OCRSecrets(ctx context.Context) (OCRSecrets, error) {
+	out, err := k.Client.DecryptWithContext(ctx, &kms.DecryptInput{
+		KeyId:          aws.String(k.KeyID),
+		CiphertextBlob: k.Ciphertext,
+	})
+	if err != nil {
+		return OCRSecrets{}, fmt.Errorf("failed to decrypt OCR secrets with KMS key %s: %w", k.KeyID, err)
+	}
+	return unmarshalOCRSecrets(out.Plaintext)
+}
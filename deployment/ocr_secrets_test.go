@@ -0,0 +1,68 @@
+package deployment
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeKMSDecrypter map[string][]byte
+
+func (f fakeKMSDecrypter) DecryptWithContext(_ aws.Context, input *kms.DecryptInput, _ ...request.Option) (*kms.DecryptOutput, error) {
+	plaintext, ok := f[*input.KeyId+string(input.CiphertextBlob)]
+	if !ok {
+		return nil, errors.New("invalid ciphertext")
+	}
+	return &kms.DecryptOutput{Plaintext: plaintext}, nil
+}
+
+func TestOCRSecretsProviders(t *testing.T) {
+	ctx := context.Background()
+	expected := XXXGenerateTestOCRSecrets()
+
+	secrets, err := TestOCRSecrets{}.OCRSecrets(ctx)
+	require.NoError(t, err)
+	require.Equal(t, expected, secrets)
+
+	_, err = StaticOCRSecrets{}.OCRSecrets(ctx)
+	require.ErrorContains(t, err, "empty")
+
+	random := &RandomOCRSecrets{}
+	first, err := random.OCRSecrets(ctx)
+	require.NoError(t, err)
+	require.False(t, first.IsEmpty())
+	second, err := random.OCRSecrets(ctx)
+	require.NoError(t, err)
+	require.Equal(t, first, second)
+
+	var key [32]byte
+	key[0] = 1
+	sealed, err := SealOCRSecrets(expected, key)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "ocr_secrets")
+	require.NoError(t, os.WriteFile(path, sealed, 0o600))
+	secrets, err = SealedOCRSecretsFile{Path: path, Key: key}.OCRSecrets(ctx)
+	require.NoError(t, err)
+	require.Equal(t, expected, secrets)
+	_, err = SealedOCRSecretsFile{Path: path}.OCRSecrets(ctx)
+	require.ErrorContains(t, err, "failed to open sealed OCR secrets")
+
+	plaintext, err := marshalOCRSecrets(expected)
+	require.NoError(t, err)
+	client := fakeKMSDecrypter{"key" + "wrapped": plaintext}
+	secrets, err = KMSOCRSecrets{Client: client, KeyID: "key", Ciphertext: []byte("wrapped")}.OCRSecrets(ctx)
+	require.NoError(t, err)
+	require.Equal(t, expected, secrets)
+	_, err = KMSOCRSecrets{Client: client, KeyID: "other", Ciphertext: []byte("wrapped")}.OCRSecrets(ctx)
+	require.ErrorContains(t, err, "failed to decrypt")
+
+	_, err = unmarshalOCRSecrets([]byte(`{"sharedSecret": "0x01", "ephemeralSk": "0x02"}`))
+	require.ErrorContains(t, err, "must be 16 bytes")
+}
@@ -0,0 +1,111 @@
+package deployment
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// ProgressEventType is the kind of a progress event.
+type ProgressEventType string
+
+const (
+	ProgressChangesetStarted   ProgressEventType = "changeset_started"
+	ProgressChangesetCompleted ProgressEventType = "changeset_completed"
+	ProgressChangesetFailed    ProgressEventType = "changeset_failed"
+	// ProgressStep is reported by changesets starting a step on a chain, see Environment.ReportStep.
+	ProgressStep        ProgressEventType = "step"
+	ProgressTxSubmitted ProgressEventType = "tx_submitted"
+	ProgressTxConfirmed ProgressEventType = "tx_confirmed"
+	ProgressTxFailed    ProgressEventType = "tx_failed"
+)
+
+// ProgressEvent is a structured progress event of a deployment, for CLIs and CI to render progress
+// and to spot stalled deployments.
+type ProgressEvent struct {
+	Type ProgressEventType
+	Time time.Time
+	// Changeset is the name of the changeset being applied, the Index-th of Total changesets.
+	Changeset string
+	Index     int
+	Total     int
+	// ChainSelector is the chain of step and transaction events.
+	ChainSelector uint64
+	// Step is the 1-based index of the step of Steps steps of step events, named StepName.
+	Step     int
+	Steps    int
+	StepName string
+	// TxHash is the transaction of transaction events.
+	TxHash common.Hash
+	// Elapsed is the time since the first changeset started. Remaining is the estimated time until all the
+	// changesets are applied, from the average duration of the changesets applied so far.
+	Elapsed   time.Duration
+	Remaining time.Duration
+	Err       error
+}
+
+// ProgressReporter receives the progress events of a deployment. It must not block.
+type ProgressReporter func(ProgressEvent)
+
+// ProgressChannel returns a reporter sending the events to the channel, dropping them if the channel is full
+// so that a slow consumer never stalls the deployment.
+func ProgressChannel(ch chan<- ProgressEvent) ProgressReporter {
+	return func(event ProgressEvent) {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
+
+// Report sends the event to the reporter, if any, timestamping it.
+func (r ProgressReporter) Report(event ProgressEvent) {
+	if r == nil {
+		return
+	}
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+	r(event)
+}
+
+// WithChangeset returns a reporter adding the changeset being applied to the events.
+func (r ProgressReporter) WithChangeset(name string, index, total int) ProgressReporter {
+	if r == nil {
+		return nil
+	}
+	return func(event ProgressEvent) {
+		event.Changeset, event.Index, event.Total = name, index, total
+		r(event)
+	}
+}
+
+// Chains returns a copy of the chains reporting the transactions they confirm.
+func (r ProgressReporter) Chains(chains map[uint64]Chain) map[uint64]Chain {
+	if r == nil {
+		return chains
+	}
+	reported := make(map[uint64]Chain, len(chains))
+	for sel, chain := range chains {
+		confirm := chain.Confirm
+		chain.Confirm = func(tx *types.Transaction) (uint64, error) {
+			// transactions are confirmed right after they are sent
+			r.Report(ProgressEvent{Type: ProgressTxSubmitted, ChainSelector: sel, TxHash: tx.Hash()})
+			block, err := confirm(tx)
+			if err != nil {
+				r.Report(ProgressEvent{Type: ProgressTxFailed, ChainSelector: sel, TxHash: tx.Hash(), Err: err})
+				return block, err
+			}
+			r.Report(ProgressEvent{Type: ProgressTxConfirmed, ChainSelector: sel, TxHash: tx.Hash()})
+			return block, nil
+		}
+		reported[sel] = chain
+	}
+	return reported
+}
+
+// ReportStep reports that the changeset starts the step-th of steps steps on the chain.
+func (e Environment) ReportStep(chainSelector uint64, step, steps int, name string) {
+	e.Progress.Report(ProgressEvent{Type: ProgressStep, ChainSelector: chainSelector, Step: step, Steps: steps, StepName: name})
+}
@@ -0,0 +1,57 @@
+package deployment
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProgressReporter(t *testing.T) {
+	var nilReporter ProgressReporter
+	nilReporter.Report(ProgressEvent{Type: ProgressStep})
+	require.Nil(t, nilReporter.WithChangeset("cs", 0, 1))
+
+	ch := make(chan ProgressEvent, 4)
+	progress := ProgressChannel(ch).WithChangeset("deploy", 1, 3)
+	failing := errors.New("reverted")
+	chains := progress.Chains(map[uint64]Chain{
+		1: {Selector: 1, Confirm: func(tx *types.Transaction) (uint64, error) { return 10, nil }},
+		2: {Selector: 2, Confirm: func(tx *types.Transaction) (uint64, error) { return 0, failing }},
+	})
+
+	tx := types.NewTx(&types.LegacyTx{Nonce: 1, GasPrice: big.NewInt(1)})
+	block, err := chains[1].Confirm(tx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), block)
+	submitted, confirmed := <-ch, <-ch
+	require.Equal(t, ProgressTxSubmitted, submitted.Type)
+	require.Equal(t, ProgressTxConfirmed, confirmed.Type)
+	require.Equal(t, tx.Hash(), confirmed.TxHash)
+	require.Equal(t, uint64(1), confirmed.ChainSelector)
+	require.Equal(t, "deploy", confirmed.Changeset)
+	require.Equal(t, 1, confirmed.Index)
+	require.Equal(t, 3, confirmed.Total)
+	require.False(t, confirmed.Time.IsZero())
+
+	_, err = chains[2].Confirm(tx)
+	require.ErrorIs(t, err, failing)
+	<-ch
+	failed := <-ch
+	require.Equal(t, ProgressTxFailed, failed.Type)
+	require.ErrorIs(t, failed.Err, failing)
+
+	Environment{Progress: progress}.ReportStep(2, 1, 2, "add DON")
+	step := <-ch
+	require.Equal(t, ProgressStep, step.Type)
+	require.Equal(t, "add DON", step.StepName)
+	require.Equal(t, 2, step.Steps)
+
+	// a full channel drops events instead of blocking
+	for i := 0; i < 5; i++ {
+		progress.Report(ProgressEvent{Type: ProgressStep})
+	}
+	require.Len(t, ch, 4)
+}
@@ -0,0 +1,165 @@
+package deployment
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+//go:generate go run ./internal/errorsgen -out revert_errors_generated.go
+
+// RevertError is a revert decoded into the custom error of a contract, or into a solidity
+// Error(string) or Panic(uint256), with its parameters.
+type RevertError struct {
+	// Name is the name of the error, e.g. InsufficientFeeTokenAmount.
+	Name string
+	// Args are the decoded parameters of the error, in order.
+	Args []RevertErrorArg
+	// Data is the raw revert data.
+	Data []byte
+
+	err error
+}
+
+type RevertErrorArg struct {
+	Name  string
+	Value any
+}
+
+// Error formats the revert as e.g. "execution reverted: InvalidDestChainConfig(destChainSelector: 1)".
+func (e *RevertError) Error() string {
+	var args []string
+	for _, arg := range e.Args {
+		if arg.Name == "" {
+			args = append(args, fmt.Sprintf("%v", arg.Value))
+			continue
+		}
+		args = append(args, fmt.Sprintf("%s: %v", arg.Name, arg.Value))
+	}
+	return fmt.Sprintf("execution reverted: %s(%s)", e.Name, strings.Join(args, ", "))
+}
+
+// Unwrap returns the error the revert was decoded from, e.g. an rpc.DataError.
+func (e *RevertError) Unwrap() error {
+	return e.err
+}
+
+// ErrorRegistry decodes revert data into the custom errors of the registered contract ABIs.
+type ErrorRegistry struct {
+	mu     sync.RWMutex
+	errors map[[4]byte][]abi.Error
+}
+
+func NewErrorRegistry() *ErrorRegistry {
+	return &ErrorRegistry{errors: make(map[[4]byte][]abi.Error)}
+}
+
+// Register adds the custom errors of the ABIs of the bindings to the registry.
+// Errors declared by multiple contracts are only registered once.
+func (r *ErrorRegistry) Register(metadata ...*bind.MetaData) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, md := range metadata {
+		parsed, err := md.GetAbi()
+		if err != nil {
+			return fmt.Errorf("failed to parse ABI: %w", err)
+		}
+		for _, abiError := range parsed.Errors {
+			selector := [4]byte(abiError.ID.Bytes()[:4])
+			registered := false
+			for _, existing := range r.errors[selector] {
+				registered = registered || existing.Sig == abiError.Sig
+			}
+			if !registered {
+				r.errors[selector] = append(r.errors[selector], abiError)
+			}
+		}
+	}
+	return nil
+}
+
+// Decode decodes revert data, it returns false if it does not match any registered error.
+func (r *ErrorRegistry) Decode(data []byte) (*RevertError, bool) {
+	if len(data) < 4 {
+		return nil, false
+	}
+	if reason, err := abi.UnpackRevert(data); err == nil {
+		name := "Error"
+		if bytes.Equal(data[:4], panicSelector) {
+			name = "Panic"
+		}
+		return &RevertError{Name: name, Args: []RevertErrorArg{{Value: reason}}, Data: data}, true
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	// distinct errors may share a selector, the first one the data unpacks into wins
+	for _, abiError := range r.errors[[4]byte(data[:4])] {
+		values, err := abiError.Inputs.Unpack(data[4:])
+		if err != nil {
+			continue
+		}
+		decoded := &RevertError{Name: abiError.Name, Data: data}
+		for i, input := range abiError.Inputs {
+			decoded.Args = append(decoded.Args, RevertErrorArg{Name: input.Name, Value: values[i]})
+		}
+		return decoded, true
+	}
+	return nil, false
+}
+
+var panicSelector = hexutil.MustDecode("0x4e487b71")
+
+var (
+	defaultErrorRegistry     = NewErrorRegistry()
+	defaultErrorRegistryOnce sync.Once
+)
+
+// DefaultErrorRegistry returns the registry of the custom errors of the CCIP, keystone and shared
+// gethwrappers, see revert_errors_generated.go. Errors of other contracts can be registered with it.
+func DefaultErrorRegistry() *ErrorRegistry {
+	defaultErrorRegistryOnce.Do(func() {
+		if err := defaultErrorRegistry.Register(generatedErrorMetadata...); err != nil {
+			panic(fmt.Sprintf("failed to register generated errors: %v", err))
+		}
+	})
+	return defaultErrorRegistry
+}
+
+// DecodeRevert decodes the revert data of the error, if any, with the default registry.
+// The returned RevertError wraps err.
+func DecodeRevert(err error) (*RevertError, bool) {
+	data, ok := revertData(err)
+	if !ok {
+		return nil, false
+	}
+	decoded, ok := DefaultErrorRegistry().Decode(data)
+	if !ok {
+		return nil, false
+	}
+	decoded.err = err
+	return decoded, true
+}
+
+// revertData returns the revert data of an rpc.DataError, which nodes return as a hex string.
+func revertData(err error) ([]byte, bool) {
+	//revive:disable
+	var d rpc.DataError
+	if !errors.As(err, &d) {
+		return nil, false
+	}
+	switch data := d.ErrorData().(type) {
+	case string:
+		decoded, err := hexutil.Decode(strings.TrimPrefix(data, "Reverted "))
+		return decoded, err == nil
+	case []byte:
+		return data, true
+	}
+	return nil, false
+}
@@ -0,0 +1,78 @@
+package deployment
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/fee_quoter"
+)
+
+type dataErr struct {
+	data any
+}
+
+func (e dataErr) Error() string          { return "execution reverted" }
+func (e dataErr) ErrorData() interface{} { return e.data }
+
+func TestDecodeRevert(t *testing.T) {
+	feeQuoterABI, err := fee_quoter.FeeQuoterMetaData.GetAbi()
+	require.NoError(t, err)
+	abiErr := feeQuoterABI.Errors["MessageTooLarge"]
+	args, err := abiErr.Inputs.Pack(big.NewInt(256), big.NewInt(300))
+	require.NoError(t, err)
+	data := append(abiErr.ID.Bytes()[:4], args...)
+	var revert error = dataErr{data: hexutil.Encode(data)}
+	wrapped := fmt.Errorf("failed to send: %w", revert)
+
+	decoded, ok := DecodeRevert(wrapped)
+	require.True(t, ok)
+	require.Equal(t, "MessageTooLarge", decoded.Name)
+	require.Equal(t, []RevertErrorArg{{"maxSize", big.NewInt(256)}, {"actualSize", big.NewInt(300)}}, decoded.Args)
+	require.Equal(t, "execution reverted: MessageTooLarge(maxSize: 256, actualSize: 300)", decoded.Error())
+	var d rpc.DataError
+	require.True(t, errors.As(decoded, &d))
+
+	require.Equal(t, decoded.Error(), MaybeDataErr(wrapped).Error())
+	_, err = ConfirmIfNoError(Chain{}, nil, wrapped)
+	require.EqualError(t, err, "transaction reverted: execution reverted: MessageTooLarge(maxSize: 256, actualSize: 300)")
+
+	// the simulated backend prefixes the revert data
+	decoded, ok = DecodeRevert(dataErr{data: "Reverted " + hexutil.Encode(data)})
+	require.True(t, ok)
+	require.Equal(t, "MessageTooLarge", decoded.Name)
+
+	stringType, err := abi.NewType("string", "", nil)
+	require.NoError(t, err)
+	reason, err := abi.Arguments{{Type: stringType}}.Pack("not allowed")
+	require.NoError(t, err)
+	decoded, ok = DecodeRevert(dataErr{data: append(hexutil.MustDecode("0x08c379a0"), reason...)})
+	require.True(t, ok)
+	require.Equal(t, "execution reverted: Error(not allowed)", decoded.Error())
+
+	// unknown errors are left as is
+	unknown := dataErr{data: "0xdeadbeef"}
+	_, ok = DecodeRevert(unknown)
+	require.False(t, ok)
+	require.Equal(t, unknown, MaybeDataErr(fmt.Errorf("wrapped: %w", unknown)))
+	_, ok = DecodeRevert(errors.New("no data"))
+	require.False(t, ok)
+}
+
+func TestErrorRegistry_Register(t *testing.T) {
+	r := NewErrorRegistry()
+	require.NoError(t, r.Register(fee_quoter.FeeQuoterMetaData, fee_quoter.FeeQuoterMetaData))
+	feeQuoterABI, err := fee_quoter.FeeQuoterMetaData.GetAbi()
+	require.NoError(t, err)
+	for _, abiErr := range feeQuoterABI.Errors {
+		require.Len(t, r.errors[[4]byte(abiErr.ID.Bytes()[:4])], 1, abiErr.Name)
+	}
+	_, ok := r.Decode(feeQuoterABI.Errors["MessageTooLarge"].ID.Bytes()[:4])
+	require.False(t, ok, "missing parameters must not decode")
+}
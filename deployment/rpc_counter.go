@@ -0,0 +1,265 @@
+package deployment
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// DefaultRPCPhase is the phase of the calls made before any phase is set.
+const DefaultRPCPhase = "default"
+
+// RPCCallCounter counts the RPC calls made through InstrumentedClients by phase and method,
+// to find the changesets and helpers making the most calls.
+type RPCCallCounter struct {
+	mu     sync.Mutex
+	phase  string
+	phases []string
+	counts map[string]map[string]int
+}
+
+func NewRPCCallCounter() *RPCCallCounter {
+	return &RPCCallCounter{
+		phase:  DefaultRPCPhase,
+		phases: []string{DefaultRPCPhase},
+		counts: map[string]map[string]int{DefaultRPCPhase: {}},
+	}
+}
+
+// SetPhase attributes the calls made from now on to the phase, e.g. the name of a changeset.
+func (c *RPCCallCounter) SetPhase(phase string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.phase = phase
+	if _, ok := c.counts[phase]; !ok {
+		c.phases = append(c.phases, phase)
+		c.counts[phase] = make(map[string]int)
+	}
+}
+
+func (c *RPCCallCounter) count(method string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.counts[c.phase][method]++
+}
+
+// Count returns the number of calls of the method made in the phase.
+// An empty phase counts all phases and an empty method counts all methods.
+func (c *RPCCallCounter) Count(phase, method string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	total := 0
+	for p, methods := range c.counts {
+		if phase != "" && p != phase {
+			continue
+		}
+		for m, n := range methods {
+			if method == "" || m == method {
+				total += n
+			}
+		}
+	}
+	return total
+}
+
+// RPCBudget is the maximum number of calls of a method in a phase,
+// where an empty phase or method matches all of them.
+type RPCBudget struct {
+	Phase  string
+	Method string
+	Max    int
+}
+
+func (b RPCBudget) String() string {
+	phase, method := b.Phase, b.Method
+	if phase == "" {
+		phase = "all phases"
+	}
+	if method == "" {
+		method = "all methods"
+	}
+	return fmt.Sprintf("%s in %s", method, phase)
+}
+
+// CheckBudgets returns an error listing the budgets which were exceeded.
+func (c *RPCCallCounter) CheckBudgets(budgets ...RPCBudget) error {
+	var exceeded []string
+	for _, b := range budgets {
+		if n := c.Count(b.Phase, b.Method); n > b.Max {
+			exceeded = append(exceeded, fmt.Sprintf("%s: %d calls, budget %d", b, n, b.Max))
+		}
+	}
+	if len(exceeded) > 0 {
+		return fmt.Errorf("RPC call budgets exceeded:\n\t%s", strings.Join(exceeded, "\n\t"))
+	}
+	return nil
+}
+
+// Summary lists the calls of each phase in the order the phases started, most called methods first.
+func (c *RPCCallCounter) Summary() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var b strings.Builder
+	for _, phase := range c.phases {
+		methods := c.counts[phase]
+		if len(methods) == 0 {
+			continue
+		}
+		names := make([]string, 0, len(methods))
+		total := 0
+		for m, n := range methods {
+			names = append(names, m)
+			total += n
+		}
+		sort.Slice(names, func(i, j int) bool {
+			if methods[names[i]] != methods[names[j]] {
+				return methods[names[i]] > methods[names[j]]
+			}
+			return names[i] < names[j]
+		})
+		fmt.Fprintf(&b, "%s: %d calls\n", phase, total)
+		for _, m := range names {
+			fmt.Fprintf(&b, "\t%s: %d\n", m, methods[m])
+		}
+	}
+	return b.String()
+}
+
+// ConfirmMethod is the method the calls of the Confirm functions of the chains are counted as. The calls
+// made by the Confirm functions themselves are not counted.
+const ConfirmMethod = "Confirm"
+
+// Instrument replaces the clients of the chains with InstrumentedClients counting their calls,
+// and counts the calls of their Confirm functions as ConfirmMethod.
+func (c *RPCCallCounter) Instrument(chains map[uint64]Chain) {
+	for sel, chain := range chains {
+		chain.Client = &InstrumentedClient{client: chain.Client, counter: c}
+		if confirm := chain.Confirm; confirm != nil {
+			chain.Confirm = func(tx *types.Transaction) (uint64, error) {
+				c.count(ConfirmMethod)
+				return confirm(tx)
+			}
+		}
+		chains[sel] = chain
+	}
+}
+
+// InstrumentedClient counts the calls of the client it wraps, use UnwrapClient to get the wrapped client.
+type InstrumentedClient struct {
+	client  OnchainClient
+	counter *RPCCallCounter
+}
+
+var _ OnchainClient = (*InstrumentedClient)(nil)
+
+// wrappingClient is implemented by the clients wrapping another client, e.g. InstrumentedClient.
+type wrappingClient[C any] interface {
+	unwrap() C
+}
+
+// unwrapClient returns the innermost client wrapped by client, or client itself if it is not wrapped.
+func unwrapClient[C any](client C) C {
+	for {
+		wc, ok := any(client).(wrappingClient[C])
+		if !ok {
+			return client
+		}
+		client = wc.unwrap()
+	}
+}
+
+// UnwrapClient returns the client wrapped by instrumentation or simulation, or the client itself if it is not wrapped.
+func UnwrapClient(client OnchainClient) OnchainClient {
+	return unwrapClient(client)
+}
+
+// UnwrapSolClient is UnwrapClient for the clients of Solana chains.
+func UnwrapSolClient(client SolClient) SolClient {
+	return unwrapClient(client)
+}
+
+// UnwrapAptosClient is UnwrapClient for the clients of Aptos chains.
+func UnwrapAptosClient(client AptosClient) AptosClient {
+	return unwrapClient(client)
+}
+
+func (c *InstrumentedClient) unwrap() OnchainClient {
+	return c.client
+}
+
+func (c *InstrumentedClient) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	c.counter.count("eth_getCode")
+	return c.client.CodeAt(ctx, contract, blockNumber)
+}
+
+func (c *InstrumentedClient) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	c.counter.count("eth_call")
+	return c.client.CallContract(ctx, call, blockNumber)
+}
+
+func (c *InstrumentedClient) EstimateGas(ctx context.Context, call ethereum.CallMsg) (uint64, error) {
+	c.counter.count("eth_estimateGas")
+	return c.client.EstimateGas(ctx, call)
+}
+
+func (c *InstrumentedClient) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	c.counter.count("eth_gasPrice")
+	return c.client.SuggestGasPrice(ctx)
+}
+
+func (c *InstrumentedClient) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	c.counter.count("eth_maxPriorityFeePerGas")
+	return c.client.SuggestGasTipCap(ctx)
+}
+
+func (c *InstrumentedClient) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	c.counter.count("eth_sendRawTransaction")
+	return c.client.SendTransaction(ctx, tx)
+}
+
+func (c *InstrumentedClient) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	c.counter.count("eth_getBlockByNumber")
+	return c.client.HeaderByNumber(ctx, number)
+}
+
+func (c *InstrumentedClient) PendingCodeAt(ctx context.Context, account common.Address) ([]byte, error) {
+	c.counter.count("eth_getCode")
+	return c.client.PendingCodeAt(ctx, account)
+}
+
+func (c *InstrumentedClient) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	c.counter.count("eth_getTransactionCount")
+	return c.client.PendingNonceAt(ctx, account)
+}
+
+func (c *InstrumentedClient) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	c.counter.count("eth_getLogs")
+	return c.client.FilterLogs(ctx, q)
+}
+
+func (c *InstrumentedClient) SubscribeFilterLogs(ctx context.Context, q ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	c.counter.count("eth_subscribe")
+	return c.client.SubscribeFilterLogs(ctx, q, ch)
+}
+
+func (c *InstrumentedClient) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	c.counter.count("eth_getTransactionReceipt")
+	return c.client.TransactionReceipt(ctx, txHash)
+}
+
+func (c *InstrumentedClient) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	c.counter.count("eth_getBalance")
+	return c.client.BalanceAt(ctx, account, blockNumber)
+}
+
+func (c *InstrumentedClient) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	c.counter.count("eth_getTransactionCount")
+	return c.client.NonceAt(ctx, account, blockNumber)
+}
@@ -0,0 +1,59 @@
+package deployment
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+type stubClient struct {
+	OnchainClient
+}
+
+func (stubClient) HeaderByNumber(context.Context, *big.Int) (*types.Header, error) {
+	return &types.Header{Number: big.NewInt(1)}, nil
+}
+
+func (stubClient) CallContract(context.Context, ethereum.CallMsg, *big.Int) ([]byte, error) {
+	return nil, nil
+}
+
+func TestRPCCallCounter(t *testing.T) {
+	ctx := context.Background()
+	chains := map[uint64]Chain{1: {Selector: 1, Client: stubClient{}, Confirm: func(*types.Transaction) (uint64, error) {
+		return 1, nil
+	}}}
+	counter := NewRPCCallCounter()
+	counter.Instrument(chains)
+	client := chains[1].Client
+	require.Equal(t, stubClient{}, UnwrapClient(client))
+
+	_, err := client.HeaderByNumber(ctx, nil)
+	require.NoError(t, err)
+	counter.SetPhase("deploy")
+	for i := 0; i < 3; i++ {
+		_, err = client.CallContract(ctx, ethereum.CallMsg{}, nil)
+		require.NoError(t, err)
+	}
+	_, err = client.HeaderByNumber(ctx, nil)
+	require.NoError(t, err)
+	_, err = chains[1].Confirm(types.NewTx(&types.LegacyTx{}))
+	require.NoError(t, err)
+
+	require.Equal(t, 6, counter.Count("", ""))
+	require.Equal(t, 5, counter.Count("deploy", ""))
+	require.Equal(t, 2, counter.Count("", "eth_getBlockByNumber"))
+	require.Equal(t, 1, counter.Count("deploy", ConfirmMethod))
+	require.Equal(t, "default: 1 calls\n\teth_getBlockByNumber: 1\n"+
+		"deploy: 5 calls\n\teth_call: 3\n\tConfirm: 1\n\teth_getBlockByNumber: 1\n", counter.Summary())
+
+	require.NoError(t, counter.CheckBudgets(RPCBudget{Phase: "deploy", Max: 5}))
+	require.EqualError(t, counter.CheckBudgets(
+		RPCBudget{Phase: "deploy", Method: "eth_call", Max: 2},
+		RPCBudget{Max: 10},
+	), "RPC call budgets exceeded:\n\teth_call in deploy: 3 calls, budget 2")
+}
@@ -0,0 +1,112 @@
+package deployment
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/gagliardetto/solana-go"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+)
+
+// SolClient is a Solana chain client sending transactions signed by the deployer key of the chain.
+type SolClient interface {
+	// DeployProgram deploys the program with the upgradeable BPF loader, with the deployer as upgrade
+	// authority, and returns its program id.
+	DeployProgram(ctx context.Context, elf []byte) (solana.PublicKey, error)
+	// UpgradeProgram upgrades the program to the ELF, the deployer must be the upgrade authority of the program.
+	UpgradeProgram(ctx context.Context, programID solana.PublicKey, elf []byte) error
+	// SendInstructions sends the instructions in a single transaction, waits for it to be confirmed and
+	// returns its signature.
+	SendInstructions(ctx context.Context, instructions []solana.Instruction) (string, error)
+	// GetAccountData returns the data of the account, or nil if the account doesn't exist.
+	GetAccountData(ctx context.Context, account solana.PublicKey) ([]byte, error)
+	// CreateLookupTable creates an address lookup table with the addresses and returns its address.
+	CreateLookupTable(ctx context.Context, addresses []solana.PublicKey) (solana.PublicKey, error)
+	// ExtendLookupTable appends the addresses to the lookup table.
+	ExtendLookupTable(ctx context.Context, table solana.PublicKey, addresses []solana.PublicKey) error
+	// GetLookupTable returns the addresses of the lookup table.
+	GetLookupTable(ctx context.Context, table solana.PublicKey) ([]solana.PublicKey, error)
+}
+
+// SolChain is the Solana counterpart of Chain.
+type SolChain struct {
+	Selector uint64
+	Client   SolClient
+	// DeployerKey is the public key of the account paying for and signing the transactions of the client.
+	DeployerKey solana.PublicKey
+}
+
+// DeploySolProgram is the DeployContract counterpart for Solana chains. It deploys the program and records
+// its program id in the address book once the deployment is confirmed.
+func DeploySolProgram(lggr logger.Logger, chain SolChain, addressBook AddressBook, elf []byte, tv TypeAndVersion) (solana.PublicKey, error) {
+	if len(elf) == 0 {
+		return solana.PublicKey{}, fmt.Errorf("program %s has no ELF", tv)
+	}
+	programID, err := chain.Client.DeployProgram(context.Background(), elf)
+	if err != nil {
+		lggr.Errorw("Failed to deploy program", "err", err, "program", tv)
+		return solana.PublicKey{}, err
+	}
+	if err := addressBook.Save(chain.Selector, programID.String(), tv); err != nil {
+		lggr.Errorw("Failed to save program address", "err", err)
+		return solana.PublicKey{}, err
+	}
+	return programID, nil
+}
+
+// SendSolInstructions sends the instructions in a single transaction from the deployer key.
+func SendSolInstructions(chain SolChain, instructions ...solana.Instruction) error {
+	if _, err := chain.Client.SendInstructions(context.Background(), instructions); err != nil {
+		return fmt.Errorf("failed to send instructions on chain %d: %w", chain.Selector, err)
+	}
+	return nil
+}
+
+// SolAccountExists returns whether the account exists, e.g. whether the state account of a program is initialized.
+func SolAccountExists(chain SolChain, account solana.PublicKey) (bool, error) {
+	data, err := chain.Client.GetAccountData(context.Background(), account)
+	if err != nil {
+		return false, fmt.Errorf("failed to get account %s on chain %d: %w", account, chain.Selector, err)
+	}
+	return data != nil, nil
+}
+
+// AnchorInstruction returns the instruction calling the method of an Anchor program with the Borsh encoded args,
+// see the Borsh helpers below.
+func AnchorInstruction(programID solana.PublicKey, method string, accounts solana.AccountMetaSlice, args ...[]byte) solana.Instruction {
+	discriminator := sha256.Sum256([]byte("global:" + method))
+	data := append([]byte{}, discriminator[:8]...)
+	for _, arg := range args {
+		data = append(data, arg...)
+	}
+	return solana.NewInstruction(programID, accounts, data)
+}
+
+// Borsh encoding of the arguments of Anchor instructions, see https://borsh.io.
+
+func BorshU8(v uint8) []byte {
+	return []byte{v}
+}
+
+func BorshU64(v uint64) []byte {
+	return binary.LittleEndian.AppendUint64(nil, v)
+}
+
+func BorshBool(v bool) []byte {
+	if v {
+		return []byte{1}
+	}
+	return []byte{0}
+}
+
+// BorshBytes encodes a Vec<u8>.
+func BorshBytes(b []byte) []byte {
+	return append(binary.LittleEndian.AppendUint32(nil, uint32(len(b))), b...)
+}
+
+func BorshPublicKey(key solana.PublicKey) []byte {
+	return key.Bytes()
+}
@@ -0,0 +1,349 @@
+package deployment
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	addresslookuptable "github.com/gagliardetto/solana-go/programs/address-lookup-table"
+	"github.com/gagliardetto/solana-go/programs/system"
+	"github.com/gagliardetto/solana-go/rpc"
+)
+
+const (
+	solConfirmTimeout = time.Minute
+	solPollInterval   = 400 * time.Millisecond
+	// solWriteChunkSize is the size of the chunks of the ELF written to the buffer of a deployment, which
+	// leaves room in the 1232 bytes of a transaction for its signature, accounts and blockhash.
+	solWriteChunkSize = 900
+	// solLookupTableChunkSize is the number of addresses appended to a lookup table per transaction.
+	solLookupTableChunkSize = 20
+
+	// sizes of the accounts of the upgradeable BPF loader: the Buffer and Program variants of UpgradeableLoaderState
+	solBufferHeaderSize = 4 + 1 + 32
+	solProgramSize      = 4 + 32
+)
+
+// SolAddressLookupTableProgramID is the native program of address lookup tables.
+var SolAddressLookupTableProgramID = solana.MustPublicKeyFromBase58("AddressLookupTab1e1111111111111111111111111")
+
+// RPCSolClient is a SolClient sending the transactions of a key through the JSON RPC API of a Solana node,
+// e.g. of a solana-test-validator. Programs are deployed with the upgradeable BPF loader like
+// `solana program deploy`, with twice the size of their ELF as max size so that they can be upgraded.
+type RPCSolClient struct {
+	// Commitment is the commitment transactions are confirmed and accounts read with.
+	Commitment rpc.CommitmentType
+
+	rpc *rpc.Client
+	key solana.PrivateKey
+	// mu serializes the deployments, which send many transactions
+	mu sync.Mutex
+}
+
+var _ SolClient = (*RPCSolClient)(nil)
+
+func NewRPCSolClient(url string, key solana.PrivateKey) *RPCSolClient {
+	return &RPCSolClient{
+		Commitment: rpc.CommitmentConfirmed,
+		rpc:        rpc.New(url),
+		key:        key,
+	}
+}
+
+// PublicKey returns the key paying for and signing the transactions.
+func (c *RPCSolClient) PublicKey() solana.PublicKey {
+	return c.key.PublicKey()
+}
+
+// RPC returns the client of the JSON RPC API.
+func (c *RPCSolClient) RPC() *rpc.Client {
+	return c.rpc
+}
+
+func (c *RPCSolClient) DeployProgram(ctx context.Context, elf []byte) (solana.PublicKey, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	buffer, err := c.writeBuffer(ctx, elf)
+	if err != nil {
+		return solana.PublicKey{}, err
+	}
+	program, err := solana.NewRandomPrivateKey()
+	if err != nil {
+		return solana.PublicKey{}, err
+	}
+	programID := program.PublicKey()
+	programData, _, err := solana.FindProgramAddress([][]byte{programID.Bytes()}, solana.BPFLoaderUpgradeableProgramID)
+	if err != nil {
+		return solana.PublicKey{}, err
+	}
+	create, err := c.createAccount(ctx, programID, solProgramSize, solana.BPFLoaderUpgradeableProgramID)
+	if err != nil {
+		return solana.PublicKey{}, err
+	}
+	payer := c.PublicKey()
+	// DeployWithMaxDataLen { max_data_len }
+	deploy := solana.NewInstruction(solana.BPFLoaderUpgradeableProgramID, solana.AccountMetaSlice{
+		solana.Meta(payer).WRITE().SIGNER(),
+		solana.Meta(programData).WRITE(),
+		solana.Meta(programID).WRITE(),
+		solana.Meta(buffer).WRITE(),
+		solana.Meta(solana.SysVarRentPubkey),
+		solana.Meta(solana.SysVarClockPubkey),
+		solana.Meta(solana.SystemProgramID),
+		solana.Meta(payer).SIGNER(),
+	}, binary.LittleEndian.AppendUint64(binary.LittleEndian.AppendUint32(nil, 2), uint64(2*len(elf))))
+	if _, err := c.send(ctx, []solana.Instruction{create, deploy}, program); err != nil {
+		return solana.PublicKey{}, fmt.Errorf("failed to deploy program: %w", err)
+	}
+	return programID, nil
+}
+
+func (c *RPCSolClient) UpgradeProgram(ctx context.Context, programID solana.PublicKey, elf []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	buffer, err := c.writeBuffer(ctx, elf)
+	if err != nil {
+		return err
+	}
+	programData, _, err := solana.FindProgramAddress([][]byte{programID.Bytes()}, solana.BPFLoaderUpgradeableProgramID)
+	if err != nil {
+		return err
+	}
+	payer := c.PublicKey()
+	// Upgrade, the lamports of the buffer are refunded to the payer
+	upgrade := solana.NewInstruction(solana.BPFLoaderUpgradeableProgramID, solana.AccountMetaSlice{
+		solana.Meta(programData).WRITE(),
+		solana.Meta(programID).WRITE(),
+		solana.Meta(buffer).WRITE(),
+		solana.Meta(payer).WRITE(),
+		solana.Meta(solana.SysVarRentPubkey),
+		solana.Meta(solana.SysVarClockPubkey),
+		solana.Meta(payer).SIGNER(),
+	}, binary.LittleEndian.AppendUint32(nil, 3))
+	if _, err := c.send(ctx, []solana.Instruction{upgrade}); err != nil {
+		return fmt.Errorf("failed to upgrade program %s: %w", programID, err)
+	}
+	return nil
+}
+
+// writeBuffer writes the ELF to a new buffer account of the upgradeable BPF loader, whose authority is the
+// payer, and returns its address.
+func (c *RPCSolClient) writeBuffer(ctx context.Context, elf []byte) (solana.PublicKey, error) {
+	if len(elf) == 0 {
+		return solana.PublicKey{}, errors.New("empty ELF")
+	}
+	buffer, err := solana.NewRandomPrivateKey()
+	if err != nil {
+		return solana.PublicKey{}, err
+	}
+	create, err := c.createAccount(ctx, buffer.PublicKey(), uint64(solBufferHeaderSize+len(elf)), solana.BPFLoaderUpgradeableProgramID)
+	if err != nil {
+		return solana.PublicKey{}, err
+	}
+	payer := c.PublicKey()
+	// InitializeBuffer
+	initialize := solana.NewInstruction(solana.BPFLoaderUpgradeableProgramID, solana.AccountMetaSlice{
+		solana.Meta(buffer.PublicKey()).WRITE(),
+		solana.Meta(payer),
+	}, binary.LittleEndian.AppendUint32(nil, 0))
+	if _, err := c.send(ctx, []solana.Instruction{create, initialize}, buffer); err != nil {
+		return solana.PublicKey{}, fmt.Errorf("failed to create buffer: %w", err)
+	}
+
+	// the chunks are independent, they are all sent before waiting for their confirmation
+	var signatures []solana.Signature
+	for offset := 0; offset < len(elf); offset += solWriteChunkSize {
+		chunk := elf[offset:min(offset+solWriteChunkSize, len(elf))]
+		// Write { offset: u32, bytes: Vec<u8> }
+		data := binary.LittleEndian.AppendUint32(nil, 1)
+		data = binary.LittleEndian.AppendUint32(data, uint32(offset))
+		data = binary.LittleEndian.AppendUint64(data, uint64(len(chunk)))
+		write := solana.NewInstruction(solana.BPFLoaderUpgradeableProgramID, solana.AccountMetaSlice{
+			solana.Meta(buffer.PublicKey()).WRITE(),
+			solana.Meta(payer).SIGNER(),
+		}, append(data, chunk...))
+		sig, err := c.submit(ctx, []solana.Instruction{write})
+		if err != nil {
+			return solana.PublicKey{}, fmt.Errorf("failed to write buffer at offset %d: %w", offset, err)
+		}
+		signatures = append(signatures, sig)
+	}
+	for _, sig := range signatures {
+		if err := c.confirm(ctx, sig); err != nil {
+			return solana.PublicKey{}, fmt.Errorf("failed to write buffer: %w", err)
+		}
+	}
+	return buffer.PublicKey(), nil
+}
+
+// createAccount returns the instruction creating the rent exempt account of the size owned by the program.
+func (c *RPCSolClient) createAccount(ctx context.Context, account solana.PublicKey, size uint64, owner solana.PublicKey) (solana.Instruction, error) {
+	lamports, err := c.rpc.GetMinimumBalanceForRentExemption(ctx, size, c.Commitment)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rent of %d bytes: %w", size, err)
+	}
+	return system.NewCreateAccountInstruction(lamports, size, owner, c.PublicKey(), account).Build(), nil
+}
+
+func (c *RPCSolClient) SendInstructions(ctx context.Context, instructions []solana.Instruction) (string, error) {
+	sig, err := c.send(ctx, instructions)
+	return sig.String(), err
+}
+
+// send sends the instructions in a transaction paid for by the key, with the additional signers, and waits
+// for it to be confirmed.
+func (c *RPCSolClient) send(ctx context.Context, instructions []solana.Instruction, signers ...solana.PrivateKey) (solana.Signature, error) {
+	sig, err := c.submit(ctx, instructions, signers...)
+	if err != nil {
+		return solana.Signature{}, err
+	}
+	return sig, c.confirm(ctx, sig)
+}
+
+func (c *RPCSolClient) submit(ctx context.Context, instructions []solana.Instruction, signers ...solana.PrivateKey) (solana.Signature, error) {
+	blockhash, err := c.rpc.GetLatestBlockhash(ctx, c.Commitment)
+	if err != nil {
+		return solana.Signature{}, fmt.Errorf("failed to get blockhash: %w", err)
+	}
+	tx, err := solana.NewTransaction(instructions, blockhash.Value.Blockhash, solana.TransactionPayer(c.PublicKey()))
+	if err != nil {
+		return solana.Signature{}, err
+	}
+	keys := append([]solana.PrivateKey{c.key}, signers...)
+	if _, err := tx.Sign(func(pub solana.PublicKey) *solana.PrivateKey {
+		for i := range keys {
+			if keys[i].PublicKey() == pub {
+				return &keys[i]
+			}
+		}
+		return nil
+	}); err != nil {
+		return solana.Signature{}, fmt.Errorf("failed to sign transaction: %w", err)
+	}
+	// the preflight simulation returns the errors of the instructions before the transaction is sent
+	sig, err := c.rpc.SendTransactionWithOpts(ctx, tx, rpc.TransactionOpts{PreflightCommitment: c.Commitment})
+	if err != nil {
+		return solana.Signature{}, fmt.Errorf("failed to send transaction: %w", err)
+	}
+	return sig, nil
+}
+
+// confirm waits for the transaction to reach the commitment of the client.
+func (c *RPCSolClient) confirm(ctx context.Context, sig solana.Signature) error {
+	ctx, cancel := context.WithTimeout(ctx, solConfirmTimeout)
+	defer cancel()
+	ticker := time.NewTicker(solPollInterval)
+	defer ticker.Stop()
+	for {
+		statuses, err := c.rpc.GetSignatureStatuses(ctx, false, sig)
+		if err != nil && !errors.Is(err, rpc.ErrNotFound) {
+			return fmt.Errorf("failed to get status of transaction %s: %w", sig, err)
+		}
+		if err == nil && len(statuses.Value) == 1 && statuses.Value[0] != nil {
+			status := statuses.Value[0]
+			if status.Err != nil {
+				return fmt.Errorf("transaction %s failed: %v", sig, status.Err)
+			}
+			switch status.ConfirmationStatus {
+			case rpc.ConfirmationStatusFinalized:
+				return nil
+			case rpc.ConfirmationStatusConfirmed:
+				if c.Commitment != rpc.CommitmentFinalized {
+					return nil
+				}
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("transaction %s not confirmed: %w", sig, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *RPCSolClient) GetAccountData(ctx context.Context, account solana.PublicKey) ([]byte, error) {
+	info, err := c.rpc.GetAccountInfoWithOpts(ctx, account, &rpc.GetAccountInfoOpts{Commitment: c.Commitment})
+	if errors.Is(err, rpc.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	data := info.GetBinary()
+	if data == nil {
+		// the account exists without data
+		data = []byte{}
+	}
+	return data, nil
+}
+
+func (c *RPCSolClient) CreateLookupTable(ctx context.Context, addresses []solana.PublicKey) (solana.PublicKey, error) {
+	// the table is derived from a recent slot, which must be in the slot hashes of the chain
+	slot, err := c.rpc.GetSlot(ctx, rpc.CommitmentFinalized)
+	if err != nil {
+		return solana.PublicKey{}, fmt.Errorf("failed to get slot: %w", err)
+	}
+	authority := c.PublicKey()
+	table, bump, err := solana.FindProgramAddress([][]byte{authority.Bytes(), BorshU64(slot)}, SolAddressLookupTableProgramID)
+	if err != nil {
+		return solana.PublicKey{}, err
+	}
+	// CreateLookupTable { recent_slot: u64, bump_seed: u8 }
+	data := binary.LittleEndian.AppendUint32(nil, 0)
+	data = append(binary.LittleEndian.AppendUint64(data, slot), bump)
+	create := solana.NewInstruction(SolAddressLookupTableProgramID, c.lookupTableAccounts(table), data)
+	if _, err := c.send(ctx, []solana.Instruction{create}); err != nil {
+		return solana.PublicKey{}, fmt.Errorf("failed to create lookup table: %w", err)
+	}
+	if err := c.ExtendLookupTable(ctx, table, addresses); err != nil {
+		return solana.PublicKey{}, err
+	}
+	return table, nil
+}
+
+func (c *RPCSolClient) ExtendLookupTable(ctx context.Context, table solana.PublicKey, addresses []solana.PublicKey) error {
+	for start := 0; start < len(addresses); start += solLookupTableChunkSize {
+		chunk := addresses[start:min(start+solLookupTableChunkSize, len(addresses))]
+		// ExtendLookupTable { new_addresses: Vec<Pubkey> }
+		data := binary.LittleEndian.AppendUint32(nil, 2)
+		data = binary.LittleEndian.AppendUint64(data, uint64(len(chunk)))
+		for _, addr := range chunk {
+			data = append(data, addr.Bytes()...)
+		}
+		extend := solana.NewInstruction(SolAddressLookupTableProgramID, c.lookupTableAccounts(table), data)
+		if _, err := c.send(ctx, []solana.Instruction{extend}); err != nil {
+			return fmt.Errorf("failed to extend lookup table %s: %w", table, err)
+		}
+	}
+	return nil
+}
+
+// lookupTableAccounts are the accounts of the instructions creating and extending the table, whose
+// authority and payer is the key.
+func (c *RPCSolClient) lookupTableAccounts(table solana.PublicKey) solana.AccountMetaSlice {
+	return solana.AccountMetaSlice{
+		solana.Meta(table).WRITE(),
+		solana.Meta(c.PublicKey()).SIGNER(),
+		solana.Meta(c.PublicKey()).WRITE().SIGNER(),
+		solana.Meta(solana.SystemProgramID),
+	}
+}
+
+func (c *RPCSolClient) GetLookupTable(ctx context.Context, table solana.PublicKey) ([]solana.PublicKey, error) {
+	data, err := c.GetAccountData(ctx, table)
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, fmt.Errorf("lookup table %s not found", table)
+	}
+	state, err := addresslookuptable.DecodeAddressLookupTableState(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid lookup table %s: %w", table, err)
+	}
+	return state.Addresses, nil
+}
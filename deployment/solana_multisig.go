@@ -0,0 +1,357 @@
+package deployment
+
+import (
+	"context"
+	_ "embed"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+// Permissions of the members of a Squads multisig.
+const (
+	squadsPermissionInitiate = 1 << iota
+	squadsPermissionVote
+	squadsPermissionExecute
+)
+
+//go:embed idl/squads_multisig_program.json
+var squadsIDLJSON []byte
+
+// SquadsIDL is the IDL of the instructions and accounts of the Squads v4 program used by SolSquadsMultisig.
+var SquadsIDL = func() *AnchorIDL {
+	idl, err := ParseAnchorIDL(squadsIDLJSON)
+	if err != nil {
+		panic(err)
+	}
+	return idl
+}()
+
+// SolSquadsMultisig executes the batches of multisig proposals on a Solana chain with a Squads v4 multisig,
+// whose vault owns the programs. Each batch becomes a vault transaction, which is proposed and approved by
+// the deployer key, approved by the signers until the threshold of the multisig is met, then executed.
+type SolSquadsMultisig struct {
+	Chain SolChain
+	// ProgramID is the id of the Squads program.
+	ProgramID solana.PublicKey
+	// Multisig is the multisig account.
+	Multisig solana.PublicKey
+	// VaultIndex is the index of the vault signing the instructions, 0 by default.
+	VaultIndex uint8
+	// Signers are other members of the multisig approving the batches of ExecuteBatch, i.e. the chain
+	// with the clients of their keys.
+	Signers []SolChain
+}
+
+var _ MultisigExecutor = SolSquadsMultisig{}
+
+// squadsMultisig is the state of a multisig account.
+type squadsMultisig struct {
+	threshold        uint16
+	transactionIndex uint64
+	// permissions are the permissions of the members
+	permissions map[solana.PublicKey]uint8
+}
+
+// Vault returns the vault of the multisig, which is the authority the instructions of the batches are signed by.
+func (m SolSquadsMultisig) Vault() (solana.PublicKey, error) {
+	vault, _, err := solana.FindProgramAddress([][]byte{
+		[]byte("multisig"), m.Multisig.Bytes(), []byte("vault"), {m.VaultIndex},
+	}, m.ProgramID)
+	return vault, err
+}
+
+func (m SolSquadsMultisig) ExecuteBatch(ctx context.Context, batch MultisigBatch) (string, error) {
+	multisig, err := m.load(ctx)
+	if err != nil {
+		return "", err
+	}
+	index, err := m.ProposeBatch(ctx, batch)
+	if err != nil {
+		return "", err
+	}
+	approvals := 0
+	if multisig.permissions[m.Chain.DeployerKey]&squadsPermissionVote != 0 {
+		approvals++
+	}
+	for _, signer := range m.Signers {
+		if approvals >= int(multisig.threshold) {
+			break
+		}
+		if multisig.permissions[signer.DeployerKey]&squadsPermissionVote == 0 {
+			return "", fmt.Errorf("signer %s can't approve transactions of multisig %s", signer.DeployerKey, m.Multisig)
+		}
+		if err := m.withChain(signer).ApproveBatch(ctx, batch, index); err != nil {
+			return "", err
+		}
+		approvals++
+	}
+	if approvals < int(multisig.threshold) {
+		return "", fmt.Errorf("vault transaction %d of multisig %s has %d of the %d approvals required, "+
+			"approve it with the other members then execute it", index, m.Multisig, approvals, multisig.threshold)
+	}
+	return m.executeBatch(ctx, batch, index)
+}
+
+// ProposeBatch creates the vault transaction of the batch and its proposal, which the deployer key approves
+// if it is allowed to vote.
+func (m SolSquadsMultisig) ProposeBatch(ctx context.Context, batch MultisigBatch) (uint64, error) {
+	multisig, err := m.load(ctx)
+	if err != nil {
+		return 0, err
+	}
+	member := m.Chain.DeployerKey
+	if multisig.permissions[member]&squadsPermissionInitiate == 0 {
+		return 0, fmt.Errorf("deployer %s can't propose transactions to multisig %s", member, m.Multisig)
+	}
+	index := multisig.transactionIndex + 1
+	vault, err := m.Vault()
+	if err != nil {
+		return 0, err
+	}
+	message, _, err := compileSquadsMessage(vault, batch.SolInstructions)
+	if err != nil {
+		return 0, err
+	}
+	transaction, proposal, err := m.transactionPDAs(index)
+	if err != nil {
+		return 0, err
+	}
+	create, err := SquadsIDL.Instruction(m.ProgramID, "vault_transaction_create", map[string]solana.PublicKey{
+		"multisig":       m.Multisig,
+		"transaction":    transaction,
+		"creator":        member,
+		"rent_payer":     member,
+		"system_program": solana.SystemProgramID,
+	}, map[string]any{"args": map[string]any{
+		"vault_index":         m.VaultIndex,
+		"ephemeral_signers":   0,
+		"transaction_message": message,
+		"memo":                nil,
+	}})
+	if err != nil {
+		return 0, err
+	}
+	if err := SendSolInstructions(m.Chain, create); err != nil {
+		return 0, fmt.Errorf("failed to create vault transaction %d: %w", index, err)
+	}
+	propose, err := SquadsIDL.Instruction(m.ProgramID, "proposal_create", map[string]solana.PublicKey{
+		"multisig":       m.Multisig,
+		"proposal":       proposal,
+		"creator":        member,
+		"rent_payer":     member,
+		"system_program": solana.SystemProgramID,
+	}, map[string]any{"args": map[string]any{"transaction_index": index, "draft": false}})
+	if err != nil {
+		return 0, err
+	}
+	instructions := []solana.Instruction{propose}
+	if multisig.permissions[member]&squadsPermissionVote != 0 {
+		approve, err := m.approveInstruction(index)
+		if err != nil {
+			return 0, err
+		}
+		instructions = append(instructions, approve)
+	}
+	if err := SendSolInstructions(m.Chain, instructions...); err != nil {
+		return 0, fmt.Errorf("failed to propose vault transaction %d: %w", index, err)
+	}
+	return index, nil
+}
+
+func (m SolSquadsMultisig) ApproveBatch(ctx context.Context, _ MultisigBatch, index uint64) error {
+	approve, err := m.approveInstruction(index)
+	if err != nil {
+		return err
+	}
+	if err := SendSolInstructions(m.Chain, approve); err != nil {
+		return fmt.Errorf("failed to approve vault transaction %d by %s: %w", index, m.Chain.DeployerKey, err)
+	}
+	return nil
+}
+
+// ExecuteApprovedBatch executes the vault transaction of the batch, whose proposal must be approved.
+func (m SolSquadsMultisig) ExecuteApprovedBatch(ctx context.Context, batch MultisigBatch, index uint64) (string, error) {
+	_, proposal, err := m.transactionPDAs(index)
+	if err != nil {
+		return "", err
+	}
+	data, err := m.Chain.Client.GetAccountData(ctx, proposal)
+	if err != nil {
+		return "", fmt.Errorf("failed to get proposal %d: %w", index, err)
+	}
+	if data == nil {
+		return "", fmt.Errorf("proposal %d of multisig %s not found", index, m.Multisig)
+	}
+	fields, err := SquadsIDL.DecodeAccount("Proposal", data)
+	if err != nil {
+		return "", fmt.Errorf("invalid proposal %d: %w", index, err)
+	}
+	if status, ok := fields["status"].(map[string]any); !ok || status["approved"] == nil {
+		return "", fmt.Errorf("proposal %d of multisig %s is not approved: %v", index, m.Multisig, fields["status"])
+	}
+	return m.executeBatch(ctx, batch, index)
+}
+
+func (m SolSquadsMultisig) executeBatch(ctx context.Context, batch MultisigBatch, index uint64) (string, error) {
+	vault, err := m.Vault()
+	if err != nil {
+		return "", err
+	}
+	_, remaining, err := compileSquadsMessage(vault, batch.SolInstructions)
+	if err != nil {
+		return "", err
+	}
+	transaction, proposal, err := m.transactionPDAs(index)
+	if err != nil {
+		return "", err
+	}
+	execute, err := SquadsIDL.Instruction(m.ProgramID, "vault_transaction_execute", map[string]solana.PublicKey{
+		"multisig":    m.Multisig,
+		"proposal":    proposal,
+		"transaction": transaction,
+		"member":      m.Chain.DeployerKey,
+	}, map[string]any{})
+	if err != nil {
+		return "", err
+	}
+	// the accounts of the message, the vault signs through the Squads program
+	execute.AccountValues = append(execute.AccountValues, remaining...)
+	sig, err := m.Chain.Client.SendInstructions(ctx, []solana.Instruction{execute})
+	if err != nil {
+		return "", fmt.Errorf("failed to execute vault transaction %d on chain %d: %w", index, m.Chain.Selector, err)
+	}
+	return sig, nil
+}
+
+func (m SolSquadsMultisig) approveInstruction(index uint64) (*solana.GenericInstruction, error) {
+	_, proposal, err := m.transactionPDAs(index)
+	if err != nil {
+		return nil, err
+	}
+	return SquadsIDL.Instruction(m.ProgramID, "proposal_approve", map[string]solana.PublicKey{
+		"multisig": m.Multisig,
+		"member":   m.Chain.DeployerKey,
+		"proposal": proposal,
+	}, map[string]any{"args": map[string]any{"memo": nil}})
+}
+
+// load decodes the multisig account.
+func (m SolSquadsMultisig) load(ctx context.Context) (squadsMultisig, error) {
+	data, err := m.Chain.Client.GetAccountData(ctx, m.Multisig)
+	if err != nil {
+		return squadsMultisig{}, fmt.Errorf("failed to get multisig %s: %w", m.Multisig, err)
+	}
+	if data == nil {
+		return squadsMultisig{}, fmt.Errorf("multisig %s not found", m.Multisig)
+	}
+	fields, err := SquadsIDL.DecodeAccount("Multisig", data)
+	if err != nil {
+		return squadsMultisig{}, fmt.Errorf("invalid multisig %s: %w", m.Multisig, err)
+	}
+	multisig := squadsMultisig{
+		threshold:        fields["threshold"].(uint16),
+		transactionIndex: fields["transaction_index"].(uint64),
+		permissions:      make(map[solana.PublicKey]uint8),
+	}
+	for _, member := range fields["members"].([]any) {
+		member := member.(map[string]any)
+		multisig.permissions[member["key"].(solana.PublicKey)] = member["permissions"].(map[string]any)["mask"].(uint8)
+	}
+	return multisig, nil
+}
+
+// transactionPDAs returns the vault transaction and the proposal accounts of the transaction index.
+func (m SolSquadsMultisig) transactionPDAs(index uint64) (solana.PublicKey, solana.PublicKey, error) {
+	indexSeed := BorshU64(index)
+	transaction, _, err := solana.FindProgramAddress([][]byte{
+		[]byte("multisig"), m.Multisig.Bytes(), []byte("transaction"), indexSeed,
+	}, m.ProgramID)
+	if err != nil {
+		return solana.PublicKey{}, solana.PublicKey{}, err
+	}
+	proposal, _, err := solana.FindProgramAddress([][]byte{
+		[]byte("multisig"), m.Multisig.Bytes(), []byte("transaction"), indexSeed, []byte("proposal"),
+	}, m.ProgramID)
+	return transaction, proposal, err
+}
+
+// withChain returns the executor sending the transactions with the key of the chain.
+func (m SolSquadsMultisig) withChain(chain SolChain) SolSquadsMultisig {
+	m.Chain, m.Signers = chain, nil
+	return m
+}
+
+// compileSquadsMessage serializes the instructions as the TransactionMessage of a Squads vault transaction, with
+// the vault as the only signer, and returns the accounts the execution of the message must be passed.
+func compileSquadsMessage(vault solana.PublicKey, instructions []SolInstruction) ([]byte, solana.AccountMetaSlice, error) {
+	if len(instructions) == 0 {
+		return nil, nil, fmt.Errorf("no instructions")
+	}
+	metas := solana.AccountMetaSlice{solana.Meta(vault).WRITE().SIGNER()}
+	indexes := map[solana.PublicKey]int{vault: 0}
+	add := func(meta *solana.AccountMeta) {
+		if i, ok := indexes[meta.PublicKey]; ok {
+			metas[i].IsWritable = metas[i].IsWritable || meta.IsWritable
+			return
+		}
+		indexes[meta.PublicKey] = len(metas)
+		metas = append(metas, &solana.AccountMeta{PublicKey: meta.PublicKey, IsWritable: meta.IsWritable})
+	}
+	for _, ix := range instructions {
+		for _, meta := range ix.Accounts() {
+			if meta.IsSigner && meta.PublicKey != vault {
+				return nil, nil, fmt.Errorf("account %s can't sign vault transactions", meta.PublicKey)
+			}
+			add(meta)
+		}
+		add(solana.Meta(ix.ProgramID()))
+	}
+	// the vault is the only signer, then come the writable accounts
+	var writable, readonly solana.AccountMetaSlice
+	for _, meta := range metas[1:] {
+		if meta.IsWritable {
+			writable = append(writable, meta)
+		} else {
+			readonly = append(readonly, meta)
+		}
+	}
+	keys := append(append(solana.AccountMetaSlice{metas[0]}, writable...), readonly...)
+	if len(keys) > 255 {
+		return nil, nil, fmt.Errorf("too many accounts: %d", len(keys))
+	}
+	for i, meta := range keys {
+		indexes[meta.PublicKey] = i
+	}
+
+	message := []byte{1, 1, uint8(len(writable)), uint8(len(keys))}
+	for _, meta := range keys {
+		message = append(message, meta.PublicKey.Bytes()...)
+	}
+	message = append(message, uint8(len(instructions)))
+	for _, ix := range instructions {
+		data, err := ix.Data()
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(data) > 0xFFFF {
+			return nil, nil, fmt.Errorf("instruction data too large: %d bytes", len(data))
+		}
+		message = append(message, uint8(indexes[ix.ProgramID()]), uint8(len(ix.Accounts())))
+		for _, meta := range ix.Accounts() {
+			message = append(message, uint8(indexes[meta.PublicKey]))
+		}
+		message = binary.LittleEndian.AppendUint16(message, uint16(len(data)))
+		message = append(message, data...)
+	}
+	// no address lookup tables
+	message = append(message, 0)
+
+	// the vault signs through the Squads program when the message is executed
+	remaining := solana.AccountMetaSlice{solana.Meta(vault).WRITE()}
+	for _, meta := range keys[1:] {
+		remaining = append(remaining, &solana.AccountMeta{PublicKey: meta.PublicKey, IsWritable: meta.IsWritable})
+	}
+	return message, remaining, nil
+}
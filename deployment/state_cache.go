@@ -0,0 +1,88 @@
+package deployment
+
+import (
+	"maps"
+	"sync"
+)
+
+// StateCache caches the onchain state loaded from the address book of an environment per chain, so that
+// flows loading the state over and over only construct the bindings of chains whose addresses changed.
+// An entry is invalidated when the addresses of its chain differ from the ones it was loaded with,
+// e.g. after merging the address book of a changeset deploying to the chain.
+type StateCache struct {
+	mu      sync.Mutex
+	entries map[stateCacheKey]stateCacheEntry
+	hits    int
+	misses  int
+}
+
+type stateCacheKey struct {
+	// kind tells apart the states of different products loaded from the same chain.
+	kind          string
+	chainSelector uint64
+}
+
+type stateCacheEntry struct {
+	addresses map[string]TypeAndVersion
+	value     any
+}
+
+func NewStateCache() *StateCache {
+	return &StateCache{
+		entries: make(map[stateCacheKey]stateCacheEntry),
+	}
+}
+
+// LoadChain returns the state of the kind cached for the chain if it was loaded with the same addresses,
+// otherwise it loads and caches it. A nil cache always loads.
+func (c *StateCache) LoadChain(kind string, chainSelector uint64, addresses map[string]TypeAndVersion, load func() (any, error)) (any, error) {
+	if c == nil {
+		return load()
+	}
+	key := stateCacheKey{kind: kind, chainSelector: chainSelector}
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	if ok && maps.EqualFunc(entry.addresses, addresses, TypeAndVersion.Equal) {
+		c.hits++
+		c.mu.Unlock()
+		return entry.value, nil
+	}
+	c.misses++
+	c.mu.Unlock()
+
+	// load without holding the lock, concurrent loads of the same chain are harmless
+	value, err := load()
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = stateCacheEntry{addresses: maps.Clone(addresses), value: value}
+	return value, nil
+}
+
+// Invalidate drops the states cached for the chains, e.g. after replacing their clients.
+func (c *StateCache) Invalidate(chainSelectors ...uint64) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key := range c.entries {
+		for _, chainSelector := range chainSelectors {
+			if key.chainSelector == chainSelector {
+				delete(c.entries, key)
+			}
+		}
+	}
+}
+
+// Stats returns the number of loads served from the cache and the number of loads which were not.
+func (c *StateCache) Stats() (hits, misses int) {
+	if c == nil {
+		return 0, 0
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.hits, c.misses
+}
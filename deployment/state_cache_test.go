@@ -0,0 +1,60 @@
+package deployment
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	chainsel "github.com/smartcontractkit/chain-selectors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStateCache(t *testing.T) {
+	chain1, chain2 := chainsel.TEST_90000001.Selector, chainsel.TEST_90000002.Selector
+	ab := NewMemoryAddressBook()
+	require.NoError(t, ab.Save(chain1, common.HexToAddress("0x1").String(), NewTypeAndVersion("OnRamp", Version1_0_0)))
+	require.NoError(t, ab.Save(chain2, common.HexToAddress("0x1").String(), NewTypeAndVersion("OnRamp", Version1_0_0)))
+
+	loads := make(map[uint64]int)
+	cache := NewStateCache()
+	load := func(chainSelector uint64) any {
+		addresses, err := ab.AddressesForChain(chainSelector)
+		require.NoError(t, err)
+		value, err := cache.LoadChain("test", chainSelector, addresses, func() (any, error) {
+			loads[chainSelector]++
+			return len(addresses), nil
+		})
+		require.NoError(t, err)
+		return value
+	}
+
+	require.Equal(t, 1, load(chain1))
+	require.Equal(t, 1, load(chain2))
+	require.Equal(t, 1, load(chain1))
+	require.Equal(t, map[uint64]int{chain1: 1, chain2: 1}, loads)
+
+	// merging addresses of chain1 only reloads chain1
+	require.NoError(t, ab.Merge(NewMemoryAddressBookFromMap(map[uint64]map[string]TypeAndVersion{
+		chain1: {common.HexToAddress("0x2").String(): NewTypeAndVersion("OffRamp", Version1_0_0)},
+	})))
+	require.Equal(t, 2, load(chain1))
+	require.Equal(t, 1, load(chain2))
+	require.Equal(t, map[uint64]int{chain1: 2, chain2: 1}, loads)
+
+	cache.Invalidate(chain2)
+	require.Equal(t, 1, load(chain2))
+	require.Equal(t, map[uint64]int{chain1: 2, chain2: 2}, loads)
+	hits, misses := cache.Stats()
+	require.Equal(t, 2, hits)
+	require.Equal(t, 4, misses)
+
+	// errors are not cached
+	_, err := cache.LoadChain("test", chain1, nil, func() (any, error) { return nil, errors.New("boom") })
+	require.Error(t, err)
+
+	// a nil cache always loads
+	var noCache *StateCache
+	value, err := noCache.LoadChain("test", chain1, nil, func() (any, error) { return 1, nil })
+	require.NoError(t, err)
+	require.Equal(t, 1, value)
+}
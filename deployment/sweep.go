@@ -0,0 +1,87 @@
+package deployment
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/shared/generated/erc20"
+)
+
+// sweepGasFeeMultiplier is the margin on the gas fee of the native transfer kept by swept accounts,
+// e.g. to pay for the L1 data fees of rollups.
+const sweepGasFeeMultiplier = 2
+
+// SweptFunds are the funds an account returned to the treasury.
+type SweptFunds struct {
+	ChainSelector uint64
+	Account       common.Address
+	Native        *big.Int
+	Tokens        map[common.Address]*big.Int
+}
+
+// SweepAccount returns the balances of the tokens and the native balance of the account to the treasury.
+// The account keeps the native balance needed to pay for the transfers.
+func SweepAccount(ctx context.Context, lggr logger.Logger, chain Chain, from *bind.TransactOpts, treasury common.Address, tokens []common.Address) (SweptFunds, error) {
+	swept := SweptFunds{
+		ChainSelector: chain.Selector,
+		Account:       from.From,
+		Native:        big.NewInt(0),
+		Tokens:        make(map[common.Address]*big.Int),
+	}
+	if from.From == treasury {
+		return swept, nil
+	}
+	opts := *from
+	opts.Context = ctx
+	for _, token := range tokens {
+		erc20Token, err := erc20.NewERC20(token, chain.Client)
+		if err != nil {
+			return swept, err
+		}
+		balance, err := erc20Token.BalanceOf(&bind.CallOpts{Context: ctx}, from.From)
+		if err != nil {
+			return swept, fmt.Errorf("failed to get balance of token %s of %s on chain %d: %w", token, from.From, chain.Selector, err)
+		}
+		if balance.Sign() == 0 {
+			continue
+		}
+		tx, err := erc20Token.Transfer(&opts, treasury, balance)
+		if _, err := ConfirmIfNoError(chain, tx, err); err != nil {
+			return swept, fmt.Errorf("failed to sweep token %s of %s on chain %d: %w", token, from.From, chain.Selector, err)
+		}
+		swept.Tokens[token] = balance
+		lggr.Infow("Swept token", "chain", chain.Selector, "account", from.From, "token", token, "amount", balance)
+	}
+
+	balance, err := chain.Client.BalanceAt(ctx, from.From, nil)
+	if err != nil {
+		return swept, fmt.Errorf("failed to get balance of %s on chain %d: %w", from.From, chain.Selector, err)
+	}
+	gasPrice, err := chain.Client.SuggestGasPrice(ctx)
+	if err != nil {
+		return swept, fmt.Errorf("failed to suggest gas price on chain %d: %w", chain.Selector, err)
+	}
+	fee := new(big.Int).Mul(gasPrice, big.NewInt(NativeTransferGas*sweepGasFeeMultiplier))
+	amount := new(big.Int).Sub(balance, fee)
+	if amount.Sign() <= 0 {
+		lggr.Infow("Native balance too low to sweep", "chain", chain.Selector, "account", from.From, "balance", balance)
+		return swept, nil
+	}
+	nonce, err := chain.Client.PendingNonceAt(ctx, from.From)
+	if err != nil {
+		return swept, fmt.Errorf("failed to get nonce of %s on chain %d: %w", from.From, chain.Selector, err)
+	}
+	tx, err := SendNativeTransfer(ctx, chain, from, nonce, gasPrice, treasury, amount)
+	if _, err := ConfirmIfNoError(chain, tx, err); err != nil {
+		return swept, fmt.Errorf("failed to sweep native balance of %s on chain %d: %w", from.From, chain.Selector, err)
+	}
+	swept.Native = amount
+	lggr.Infow("Swept native balance", "chain", chain.Selector, "account", from.From, "amount", amount)
+	return swept, nil
+}
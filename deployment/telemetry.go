@@ -0,0 +1,30 @@
+package deployment
+
+import (
+	"context"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// NodeTelemetry is implemented by offchain clients which can query when the nodes
+// they manage observed onchain events and submitted transactions. It allows tests
+// to correlate offchain activity with onchain events.
+type NodeTelemetry interface {
+	// LogObservations returns, keyed by node ID, when each node's log poller persisted
+	// the log with the given event signature emitted by address in the transaction txHash.
+	// Nodes which have not observed the log are omitted.
+	LogObservations(ctx context.Context, chainSelector uint64, address common.Address, eventSig common.Hash, txHash common.Hash) (map[string]time.Time, error)
+	// TxSubmission returns how the transaction txHash was submitted by one of the nodes.
+	TxSubmission(ctx context.Context, chainSelector uint64, txHash common.Hash) (TxSubmission, error)
+}
+
+// TxSubmission describes a transaction as recorded by the transaction manager of the node which sent it.
+type TxSubmission struct {
+	NodeID string
+	// CreatedAt is when the transaction was handed to the transaction manager,
+	// e.g. when an OCR report was accepted for transmission.
+	CreatedAt time.Time
+	// BroadcastAt is when the transaction was first broadcast to the chain.
+	BroadcastAt time.Time
+}
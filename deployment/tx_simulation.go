@@ -0,0 +1,211 @@
+package deployment
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+)
+
+// TxSimulator simulates a transaction before it is sent, e.g. with eth_call, debug_traceCall or an external
+// simulation service.
+type TxSimulator interface {
+	// Simulate returns the revert of the call in TxSimulation.Err, and an error if it could not be simulated.
+	// The client is the one of the chain the transaction is sent to.
+	Simulate(ctx context.Context, chainSelector uint64, client OnchainClient, call ethereum.CallMsg) (TxSimulation, error)
+}
+
+// TxSimulation is the result of the simulation of a transaction.
+type TxSimulation struct {
+	// Err is the revert of the transaction, nil if it succeeds. It wraps the revert data, if any, see DecodeRevert.
+	Err error
+	// Trace is the call trace of the transaction, if the simulator traces calls.
+	Trace json.RawMessage
+}
+
+// TxSimulationConfig enables the simulation of every transaction sent to the chains of an environment,
+// so that changesets fail before spending gas on transactions which revert.
+type TxSimulationConfig struct {
+	// Simulator defaults to CallSimulator.
+	Simulator TxSimulator
+	// TraceDir optionally stores the simulations of the reverted transactions, including their traces,
+	// as <chain selector>-<tx hash>.json.
+	TraceDir string
+}
+
+// SimulateTransactions replaces the clients of the chains with SimulatingClients simulating the transactions
+// before sending them. A transaction whose simulation reverts is not sent, the revert is returned instead.
+func SimulateTransactions(lggr logger.Logger, chains map[uint64]Chain, cfg TxSimulationConfig) error {
+	if cfg.Simulator == nil {
+		cfg.Simulator = CallSimulator{}
+	}
+	if cfg.TraceDir != "" {
+		if err := os.MkdirAll(cfg.TraceDir, 0700); err != nil {
+			return fmt.Errorf("failed to create trace dir: %w", err)
+		}
+	}
+	for sel, chain := range chains {
+		chain.Client = &SimulatingClient{OnchainClient: chain.Client, lggr: lggr, selector: sel, cfg: cfg}
+		chains[sel] = chain
+	}
+	return nil
+}
+
+// SimulatingClient simulates the transactions sent through the client it wraps, see SimulateTransactions.
+type SimulatingClient struct {
+	OnchainClient
+	lggr     logger.Logger
+	selector uint64
+	cfg      TxSimulationConfig
+}
+
+var _ OnchainClient = (*SimulatingClient)(nil)
+
+func (c *SimulatingClient) unwrap() OnchainClient {
+	return c.OnchainClient
+}
+
+func (c *SimulatingClient) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		return fmt.Errorf("failed to recover sender of transaction %s: %w", tx.Hash(), err)
+	}
+	call := ethereum.CallMsg{
+		From:       from,
+		To:         tx.To(),
+		Gas:        tx.Gas(),
+		Value:      tx.Value(),
+		Data:       tx.Data(),
+		AccessList: tx.AccessList(),
+	}
+	sim, err := c.cfg.Simulator.Simulate(ctx, c.selector, c.OnchainClient, call)
+	if err != nil {
+		return fmt.Errorf("failed to simulate transaction %s on chain %d: %w", tx.Hash(), c.selector, err)
+	}
+	if sim.Err != nil {
+		c.lggr.Errorw("Simulated transaction reverted, not sending it", "chain", c.selector, "tx", tx.Hash(),
+			"from", from, "to", tx.To(), "err", MaybeDataErr(sim.Err), "trace", c.storeSimulation(tx, call, sim))
+		return sim.Err
+	}
+	return c.OnchainClient.SendTransaction(ctx, tx)
+}
+
+// simulationRecord is the stored simulation of a reverted transaction.
+type simulationRecord struct {
+	ChainSelector uint64          `json:"chainSelector"`
+	TxHash        common.Hash     `json:"txHash"`
+	From          common.Address  `json:"from"`
+	To            *common.Address `json:"to"`
+	Value         *hexutil.Big    `json:"value"`
+	Data          hexutil.Bytes   `json:"data"`
+	Error         string          `json:"error"`
+	Trace         json.RawMessage `json:"trace,omitempty"`
+}
+
+// storeSimulation stores the simulation in the trace dir and returns its path, empty if it is not stored.
+func (c *SimulatingClient) storeSimulation(tx *types.Transaction, call ethereum.CallMsg, sim TxSimulation) string {
+	if c.cfg.TraceDir == "" {
+		return ""
+	}
+	record, err := json.MarshalIndent(simulationRecord{
+		ChainSelector: c.selector,
+		TxHash:        tx.Hash(),
+		From:          call.From,
+		To:            call.To,
+		Value:         (*hexutil.Big)(call.Value),
+		Data:          call.Data,
+		Error:         MaybeDataErr(sim.Err).Error(),
+		Trace:         sim.Trace,
+	}, "", "  ")
+	if err != nil {
+		c.lggr.Warnw("Failed to encode simulation", "tx", tx.Hash(), "err", err)
+		return ""
+	}
+	path := filepath.Join(c.cfg.TraceDir, fmt.Sprintf("%d-%s.json", c.selector, tx.Hash()))
+	if err := os.WriteFile(path, record, 0600); err != nil {
+		c.lggr.Warnw("Failed to store simulation", "tx", tx.Hash(), "err", err)
+		return ""
+	}
+	return path
+}
+
+// CallSimulator simulates transactions with eth_call on the latest block, without tracing them.
+type CallSimulator struct{}
+
+func (CallSimulator) Simulate(ctx context.Context, _ uint64, client OnchainClient, call ethereum.CallMsg) (TxSimulation, error) {
+	_, err := client.CallContract(ctx, call, nil)
+	if err == nil {
+		return TxSimulation{}, nil
+	}
+	var d rpc.DataError
+	if errors.As(err, &d) || strings.Contains(err.Error(), "execution reverted") {
+		return TxSimulation{Err: err}, nil
+	}
+	return TxSimulation{}, err
+}
+
+// TraceCallSimulator simulates transactions with debug_traceCall and the call tracer on the latest block.
+// It requires the debug namespace of the RPC of the chain, e.g. of a local geth or anvil node.
+type TraceCallSimulator struct{}
+
+// callFrame is the part of the output of the call tracer used to find reverts.
+type callFrame struct {
+	Error  string        `json:"error"`
+	Output hexutil.Bytes `json:"output"`
+}
+
+// traceRevertError is a revert found by the call tracer, with its revert data.
+type traceRevertError struct {
+	msg  string
+	data hexutil.Bytes
+}
+
+func (e traceRevertError) Error() string          { return e.msg }
+func (e traceRevertError) ErrorData() interface{} { return e.data.String() }
+
+func (TraceCallSimulator) Simulate(ctx context.Context, chainSelector uint64, client OnchainClient, call ethereum.CallMsg) (TxSimulation, error) {
+	rpcClient, ok := UnwrapClient(client).(interface{ Client() *rpc.Client })
+	if !ok {
+		return TxSimulation{}, fmt.Errorf("client of chain %d has no RPC client to trace calls with", chainSelector)
+	}
+	arg := map[string]any{
+		"from":  call.From,
+		"data":  hexutil.Bytes(call.Data),
+		"value": (*hexutil.Big)(call.Value),
+	}
+	if call.To != nil {
+		arg["to"] = call.To
+	}
+	if call.Gas != 0 {
+		arg["gas"] = hexutil.Uint64(call.Gas)
+	}
+	if call.Value == nil {
+		arg["value"] = (*hexutil.Big)(big.NewInt(0))
+	}
+	var trace json.RawMessage
+	if err := rpcClient.Client().CallContext(ctx, &trace, "debug_traceCall", arg, "latest",
+		map[string]any{"tracer": "callTracer"}); err != nil {
+		return TxSimulation{}, fmt.Errorf("failed to trace call: %w", err)
+	}
+	var frame callFrame
+	if err := json.Unmarshal(trace, &frame); err != nil {
+		return TxSimulation{}, fmt.Errorf("failed to decode call trace: %w", err)
+	}
+	sim := TxSimulation{Trace: trace}
+	if frame.Error != "" {
+		sim.Err = traceRevertError{msg: frame.Error, data: frame.Output}
+	}
+	return sim, nil
+}
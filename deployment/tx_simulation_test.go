@@ -0,0 +1,65 @@
+package deployment
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+)
+
+type sendingClient struct {
+	OnchainClient
+	sent []*types.Transaction
+}
+
+func (c *sendingClient) SendTransaction(_ context.Context, tx *types.Transaction) error {
+	c.sent = append(c.sent, tx)
+	return nil
+}
+
+type stubSimulator struct {
+	revert error
+}
+
+func (s stubSimulator) Simulate(context.Context, uint64, OnchainClient, ethereum.CallMsg) (TxSimulation, error) {
+	return TxSimulation{Err: s.revert, Trace: []byte(`{"type":"CALL"}`)}, nil
+}
+
+func TestSimulateTransactions(t *testing.T) {
+	ctx := context.Background()
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	to := common.HexToAddress("0x1")
+	tx, err := types.SignNewTx(key, types.LatestSignerForChainID(big.NewInt(1337)), &types.LegacyTx{
+		To: &to, Gas: 21000, GasPrice: big.NewInt(1),
+	})
+	require.NoError(t, err)
+
+	client := &sendingClient{}
+	chains := map[uint64]Chain{1: {Selector: 1, Client: client}}
+	sim := &stubSimulator{}
+	dir := t.TempDir()
+	require.NoError(t, SimulateTransactions(logger.Test(t), chains, TxSimulationConfig{Simulator: sim, TraceDir: dir}))
+	require.Equal(t, client, UnwrapClient(chains[1].Client))
+
+	require.NoError(t, chains[1].Client.SendTransaction(ctx, tx))
+	require.Len(t, client.sent, 1)
+
+	sim.revert = errors.New("execution reverted")
+	require.EqualError(t, chains[1].Client.SendTransaction(ctx, tx), "execution reverted")
+	require.Len(t, client.sent, 1)
+	record, err := os.ReadFile(filepath.Join(dir, "1-"+tx.Hash().String()+".json"))
+	require.NoError(t, err)
+	require.Contains(t, string(record), `"error": "execution reverted"`)
+	require.Contains(t, string(record), `"type": "CALL"`)
+}
@@ -0,0 +1,29 @@
+package changeset
+
+import (
+	"github.com/smartcontractkit/chainlink/deployment"
+	commonchangeset "github.com/smartcontractkit/chainlink/deployment/common/changeset"
+	"github.com/smartcontractkit/chainlink/deployment/vrf"
+)
+
+// Bundle deploys a coordinator to every configured chain and registers the given proving keys.
+func Bundle(c vrf.DeployCoordinatorConfig, provingKeys []vrf.ConfigureProvingKeysConfig) commonchangeset.ProductBundle {
+	return commonchangeset.ProductBundle{
+		Name: "vrf",
+		Stages: []commonchangeset.BundleStage{
+			func(e deployment.Environment) ([]commonchangeset.ChangesetApplication, error) {
+				changesets := []commonchangeset.ChangesetApplication{{
+					Changeset: commonchangeset.WrapChangeSet(DeployCoordinatorChangeSet),
+					Config:    c,
+				}}
+				for _, keys := range provingKeys {
+					changesets = append(changesets, commonchangeset.ChangesetApplication{
+						Changeset: commonchangeset.WrapChangeSet(ConfigureProvingKeysChangeSet),
+						Config:    keys,
+					})
+				}
+				return changesets, nil
+			},
+		},
+	}
+}
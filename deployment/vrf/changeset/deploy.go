@@ -0,0 +1,57 @@
+package changeset
+
+import (
+	"github.com/smartcontractkit/chainlink/deployment"
+	vrfdeployment "github.com/smartcontractkit/chainlink/deployment/vrf"
+)
+
+func DeployCoordinatorChangeSet(env deployment.Environment, c vrfdeployment.DeployCoordinatorConfig) (deployment.ChangesetOutput, error) {
+	ab := deployment.NewMemoryAddressBook()
+	err := vrfdeployment.DeployCoordinator(env, ab, c)
+	if err != nil {
+		env.Logger.Errorw("Failed to deploy VRF coordinator", "err", err, "addresses", ab)
+		return deployment.ChangesetOutput{AddressBook: ab}, deployment.MaybeDataErr(err)
+	}
+	return deployment.ChangesetOutput{
+		AddressBook: ab,
+	}, nil
+}
+
+// ConfigureProvingKeysChangeSet registers the VRF keys of the nodes and returns their vrf job specs.
+// The caller needs to propose these job specs to the offchain system.
+func ConfigureProvingKeysChangeSet(env deployment.Environment, c vrfdeployment.ConfigureProvingKeysConfig) (deployment.ChangesetOutput, error) {
+	specs, err := vrfdeployment.ConfigureProvingKeys(env, c)
+	if err != nil {
+		env.Logger.Errorw("Failed to configure VRF proving keys", "err", err)
+		return deployment.ChangesetOutput{}, deployment.MaybeDataErr(err)
+	}
+	return deployment.ChangesetOutput{
+		JobSpecs: specs,
+	}, nil
+}
+
+// CreateSubscriptionChangeSet creates and funds a subscription. The id of the subscription is logged.
+func CreateSubscriptionChangeSet(env deployment.Environment, c vrfdeployment.CreateSubscriptionConfig) (deployment.ChangesetOutput, error) {
+	subID, err := vrfdeployment.CreateSubscription(env, c)
+	if err != nil {
+		env.Logger.Errorw("Failed to create VRF subscription", "err", err, "subID", subID)
+		return deployment.ChangesetOutput{}, deployment.MaybeDataErr(err)
+	}
+	return deployment.ChangesetOutput{}, nil
+}
+
+func FundSubscriptionChangeSet(env deployment.Environment, c vrfdeployment.FundSubscriptionConfig) (deployment.ChangesetOutput, error) {
+	if err := vrfdeployment.FundSubscription(env, c); err != nil {
+		env.Logger.Errorw("Failed to fund VRF subscription", "err", err, "subID", c.SubID)
+		return deployment.ChangesetOutput{}, deployment.MaybeDataErr(err)
+	}
+	return deployment.ChangesetOutput{}, nil
+}
+
+func AddConsumersChangeSet(env deployment.Environment, c vrfdeployment.AddConsumersConfig) (deployment.ChangesetOutput, error) {
+	if err := vrfdeployment.AddConsumers(env, c); err != nil {
+		env.Logger.Errorw("Failed to add VRF consumers", "err", err, "subID", c.SubID)
+		return deployment.ChangesetOutput{}, deployment.MaybeDataErr(err)
+	}
+	return deployment.ChangesetOutput{}, nil
+}
@@ -0,0 +1,65 @@
+package changeset
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/deployment/vrf"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/vrfkey"
+)
+
+func TestVRFChangeSets(t *testing.T) {
+	e := newMemoryEnv(t)
+	sel := e.AllChainSelectors()[0]
+	c := vrf.DeployCoordinatorConfig{
+		Chains: map[uint64]vrf.ChainCoordinatorConfig{sel: {}},
+	}
+	out, err := DeployCoordinatorChangeSet(e, c)
+	require.NoError(t, err)
+	require.NoError(t, e.ExistingAddresses.Merge(out.AddressBook))
+
+	// deploying again is a no-op
+	out, err = DeployCoordinatorChangeSet(e, c)
+	require.NoError(t, err)
+	ab, err := out.AddressBook.Addresses()
+	require.NoError(t, err)
+	require.Empty(t, ab)
+
+	nodeKeys := make(map[string]string)
+	for _, nodeID := range e.NodeIDs {
+		key, err := vrfkey.NewV2()
+		require.NoError(t, err)
+		nodeKeys[nodeID] = key.PublicKey.String()
+	}
+	cfg := vrf.ConfigureProvingKeysConfig{ChainSel: sel, NodeKeys: nodeKeys}
+	out, err = ConfigureProvingKeysChangeSet(e, cfg)
+	require.NoError(t, err)
+	require.Len(t, out.JobSpecs, len(e.NodeIDs))
+	// registered keys are skipped
+	_, err = ConfigureProvingKeysChangeSet(e, cfg)
+	require.NoError(t, err)
+
+	consumer := common.HexToAddress("0x1234")
+	subID, err := vrf.CreateSubscription(e, vrf.CreateSubscriptionConfig{
+		ChainSel:    sel,
+		Consumers:   []common.Address{consumer},
+		NativeFunds: big.NewInt(1e18),
+	})
+	require.NoError(t, err)
+	_, err = FundSubscriptionChangeSet(e, vrf.FundSubscriptionConfig{ChainSel: sel, SubID: subID, NativeFunds: big.NewInt(1e18)})
+	require.NoError(t, err)
+	_, err = AddConsumersChangeSet(e, vrf.AddConsumersConfig{ChainSel: sel, SubID: subID, Consumers: []common.Address{consumer}})
+	require.NoError(t, err)
+
+	addrs, err := e.ExistingAddresses.AddressesForChain(sel)
+	require.NoError(t, err)
+	state, err := vrf.LoadChainState(e.Chains[sel], addrs)
+	require.NoError(t, err)
+	sub, err := state.Coordinator.GetSubscription(nil, subID)
+	require.NoError(t, err)
+	require.Equal(t, []common.Address{consumer}, sub.Consumers)
+	require.Equal(t, big.NewInt(2e18), sub.NativeBalance)
+}
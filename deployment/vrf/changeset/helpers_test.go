@@ -0,0 +1,21 @@
+package changeset
+
+import (
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/deployment/environment/memory"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+func newMemoryEnv(t *testing.T) deployment.Environment {
+	lggr := logger.TestLogger(t)
+	memEnvConf := memory.MemoryEnvironmentConfig{
+		Chains:         1,
+		Nodes:          2,
+		RegistryConfig: deployment.CapabilityRegistryConfig{},
+	}
+	return memory.NewMemoryEnvironment(t, lggr, zapcore.InfoLevel, memEnvConf)
+}
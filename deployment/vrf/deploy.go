@@ -0,0 +1,161 @@
+package vrf
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/generated/blockhash_store"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/generated/vrf_coordinator_v2_5"
+)
+
+var (
+	Version2_5_0 = *semver.MustParse("2.5.0")
+)
+
+var (
+	BlockhashStore     deployment.ContractType = "BlockhashStore"
+	VRFCoordinatorV2_5 deployment.ContractType = "VRFCoordinatorV2_5"
+)
+
+// CoordinatorConfig mirrors the arguments of VRFCoordinatorV2_5.setConfig.
+type CoordinatorConfig struct {
+	MinimumRequestConfirmations       uint16
+	MaxGasLimit                       uint32
+	StalenessSeconds                  uint32
+	GasAfterPaymentCalculation        uint32
+	FallbackWeiPerUnitLink            *big.Int
+	FulfillmentFlatFeeNativePPM       uint32
+	FulfillmentFlatFeeLinkDiscountPPM uint32
+	NativePremiumPercentage           uint8
+	LinkPremiumPercentage             uint8
+}
+
+// DefaultCoordinatorConfig is the coordinator config used when none is configured.
+func DefaultCoordinatorConfig() CoordinatorConfig {
+	return CoordinatorConfig{
+		MinimumRequestConfirmations:       3,
+		MaxGasLimit:                       2_500_000,
+		StalenessSeconds:                  86_400,
+		GasAfterPaymentCalculation:        33_825,
+		FallbackWeiPerUnitLink:            big.NewInt(5_000_000_000_000_000),
+		FulfillmentFlatFeeNativePPM:       500,
+		FulfillmentFlatFeeLinkDiscountPPM: 100,
+		NativePremiumPercentage:           24,
+		LinkPremiumPercentage:             20,
+	}
+}
+
+// ChainCoordinatorConfig holds the chain specific configuration of the coordinator.
+type ChainCoordinatorConfig struct {
+	// LinkToken and LinkNativeFeed enable LINK billing. Both can be left empty to only bill in native.
+	LinkToken      common.Address
+	LinkNativeFeed common.Address
+	// Config defaults to DefaultCoordinatorConfig.
+	Config *CoordinatorConfig
+}
+
+type DeployCoordinatorConfig struct {
+	Chains map[uint64]ChainCoordinatorConfig
+}
+
+func (c DeployCoordinatorConfig) Validate(e deployment.Environment) error {
+	if len(c.Chains) == 0 {
+		return fmt.Errorf("no chains to deploy")
+	}
+	for sel, chainCfg := range c.Chains {
+		if _, ok := e.Chains[sel]; !ok {
+			return fmt.Errorf("chain %d not found in environment", sel)
+		}
+		if (chainCfg.LinkToken == common.Address{}) != (chainCfg.LinkNativeFeed == common.Address{}) {
+			return fmt.Errorf("chain %d must set both or neither of the link token and link native feed", sel)
+		}
+	}
+	return nil
+}
+
+// DeployCoordinator deploys a BlockhashStore and a VRFCoordinatorV2_5 to every configured chain and configures
+// the coordinator. Chains that already have a coordinator in the environment address book are skipped.
+func DeployCoordinator(e deployment.Environment, ab deployment.AddressBook, c DeployCoordinatorConfig) error {
+	if err := c.Validate(e); err != nil {
+		return fmt.Errorf("invalid config: %w", err)
+	}
+	for sel, chainCfg := range c.Chains {
+		chain := e.Chains[sel]
+		existing, err := existingChainState(e, chain)
+		if err != nil {
+			return err
+		}
+		if existing.Coordinator != nil {
+			e.Logger.Infow("VRF coordinator already deployed", "chainSelector", sel)
+			continue
+		}
+		if err := deployCoordinatorToChain(e, chain, ab, existing, chainCfg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deployCoordinatorToChain deploys the coordinator, reusing an existing BlockhashStore if there is one.
+//
+// Note that this function modifies the given address book variable.
+func deployCoordinatorToChain(e deployment.Environment, chain deployment.Chain, ab deployment.AddressBook, existing VRFChainState, cfg ChainCoordinatorConfig) error {
+	var bhsAddr common.Address
+	if existing.BlockhashStore != nil {
+		bhsAddr = existing.BlockhashStore.Address()
+	} else {
+		bhs, err := deployment.DeployContract(e.Logger, chain, ab,
+			func(chain deployment.Chain) deployment.ContractDeploy[*blockhash_store.BlockhashStore] {
+				addr, tx, s, err2 := blockhash_store.DeployBlockhashStore(chain.DeployerKey, chain.Client)
+				return deployment.ContractDeploy[*blockhash_store.BlockhashStore]{
+					Address: addr, Contract: s, Tx: tx, Err: err2,
+					Tv: deployment.NewTypeAndVersion(BlockhashStore, deployment.Version1_0_0),
+				}
+			})
+		if err != nil {
+			return fmt.Errorf("failed to deploy BlockhashStore: %w", err)
+		}
+		bhsAddr = bhs.Address
+	}
+	coordinator, err := deployment.DeployContract(e.Logger, chain, ab,
+		func(chain deployment.Chain) deployment.ContractDeploy[*vrf_coordinator_v2_5.VRFCoordinatorV25] {
+			addr, tx, c, err2 := vrf_coordinator_v2_5.DeployVRFCoordinatorV25(chain.DeployerKey, chain.Client, bhsAddr)
+			return deployment.ContractDeploy[*vrf_coordinator_v2_5.VRFCoordinatorV25]{
+				Address: addr, Contract: c, Tx: tx, Err: err2,
+				Tv: deployment.NewTypeAndVersion(VRFCoordinatorV2_5, Version2_5_0),
+			}
+		})
+	if err != nil {
+		return fmt.Errorf("failed to deploy VRFCoordinatorV2_5: %w", err)
+	}
+
+	coordinatorCfg := DefaultCoordinatorConfig()
+	if cfg.Config != nil {
+		coordinatorCfg = *cfg.Config
+	}
+	tx, err := coordinator.Contract.SetConfig(chain.DeployerKey,
+		coordinatorCfg.MinimumRequestConfirmations,
+		coordinatorCfg.MaxGasLimit,
+		coordinatorCfg.StalenessSeconds,
+		coordinatorCfg.GasAfterPaymentCalculation,
+		coordinatorCfg.FallbackWeiPerUnitLink,
+		coordinatorCfg.FulfillmentFlatFeeNativePPM,
+		coordinatorCfg.FulfillmentFlatFeeLinkDiscountPPM,
+		coordinatorCfg.NativePremiumPercentage,
+		coordinatorCfg.LinkPremiumPercentage,
+	)
+	if _, err := deployment.ConfirmIfNoError(chain, tx, err); err != nil {
+		return fmt.Errorf("failed to set coordinator config: %w", deployment.MaybeDataErr(err))
+	}
+	if cfg.LinkToken != (common.Address{}) {
+		tx, err := coordinator.Contract.SetLINKAndLINKNativeFeed(chain.DeployerKey, cfg.LinkToken, cfg.LinkNativeFeed)
+		if _, err := deployment.ConfirmIfNoError(chain, tx, err); err != nil {
+			return fmt.Errorf("failed to set coordinator LINK and LINK native feed: %w", deployment.MaybeDataErr(err))
+		}
+	}
+	return nil
+}
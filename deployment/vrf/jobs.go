@@ -0,0 +1,157 @@
+package vrf
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/v2/core/services/signatures/secp256k1"
+)
+
+type ConfigureProvingKeysConfig struct {
+	ChainSel uint64
+	// NodeKeys maps the id of every VRF node to the compressed 0x-hex public key of its VRF key.
+	// VRF keys are not exposed by the offchain client, so they have to be provided by the node operators.
+	NodeKeys map[string]string
+	// GasLanePriceWei is the maximum gas price the proving keys fulfill requests at, defaults to 100 gwei.
+	GasLanePriceWei uint64
+	// MinIncomingConfirmations defaults to 3.
+	MinIncomingConfirmations uint32
+}
+
+func (c ConfigureProvingKeysConfig) Validate(e deployment.Environment) error {
+	if _, ok := e.Chains[c.ChainSel]; !ok {
+		return fmt.Errorf("chain %d not found in environment", c.ChainSel)
+	}
+	if len(c.NodeKeys) == 0 {
+		return fmt.Errorf("no node keys")
+	}
+	for nodeID, key := range c.NodeKeys {
+		if _, err := secp256k1.NewPublicKeyFromHex(key); err != nil {
+			return fmt.Errorf("invalid VRF key of node %s: %w", nodeID, err)
+		}
+	}
+	return nil
+}
+
+// ConfigureProvingKeys registers the VRF key of every node as a proving key on the coordinator of the chain,
+// and returns the vrf job specs of the nodes keyed by node id. Keys that are already registered are skipped.
+func ConfigureProvingKeys(e deployment.Environment, c ConfigureProvingKeysConfig) (map[string][]string, error) {
+	if err := c.Validate(e); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+	chain, state, err := coordinatorState(e, c.ChainSel)
+	if err != nil {
+		return nil, err
+	}
+	chainID, err := deployment.EVMChainID(c.ChainSel)
+	if err != nil {
+		return nil, err
+	}
+	var nodeIDs []string
+	for nodeID := range c.NodeKeys {
+		nodeIDs = append(nodeIDs, nodeID)
+	}
+	nodes, err := deployment.NodeInfo(nodeIDs, e.Offchain)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get node info: %w", err)
+	}
+	gasLanePrice := c.GasLanePriceWei
+	if gasLanePrice == 0 {
+		gasLanePrice = 100_000_000_000
+	}
+	minConfs := c.MinIncomingConfirmations
+	if minConfs == 0 {
+		minConfs = 3
+	}
+
+	specs := make(map[string][]string)
+	for _, node := range nodes {
+		// the key was validated above
+		key, _ := secp256k1.NewPublicKeyFromHex(c.NodeKeys[node.NodeID])
+		point, err := key.Point()
+		if err != nil {
+			return nil, fmt.Errorf("invalid VRF key of node %s: %w", node.NodeID, err)
+		}
+		long := secp256k1.LongMarshal(point)
+		provingKey := [2]*big.Int{new(big.Int).SetBytes(long[:32]), new(big.Int).SetBytes(long[32:])}
+		keyHash, err := key.Hash()
+		if err != nil {
+			return nil, err
+		}
+		registered, err := state.Coordinator.SProvingKeys(&bind.CallOpts{Context: context.Background()}, keyHash)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get proving key %s: %w", keyHash, err)
+		}
+		if !registered.Exists {
+			tx, err := state.Coordinator.RegisterProvingKey(chain.DeployerKey, provingKey, gasLanePrice)
+			if _, err := deployment.ConfirmIfNoError(chain, tx, err); err != nil {
+				return nil, fmt.Errorf("failed to register proving key of node %s: %w", node.NodeID, deployment.MaybeDataErr(err))
+			}
+			e.Logger.Infow("Registered VRF proving key", "chainSelector", c.ChainSel, "node", node.NodeID, "keyHash", keyHash)
+		} else if registered.MaxGas != gasLanePrice {
+			return nil, fmt.Errorf("proving key of node %s is registered with gas lane price %d, not %d", node.NodeID, registered.MaxGas, gasLanePrice)
+		}
+
+		ocrCfg, ok := node.OCRConfigForChainSelector(c.ChainSel)
+		if !ok {
+			return nil, fmt.Errorf("no OCR config for chain %d on node %s", c.ChainSel, node.NodeID)
+		}
+		specs[node.NodeID] = append(specs[node.NodeID],
+			vrfJobSpec(state.Coordinator.Address(), chainID, key, string(ocrCfg.TransmitAccount), minConfs, gasLanePrice))
+	}
+	return specs, nil
+}
+
+func vrfJobSpec(coordinator common.Address, chainID uint64, key secp256k1.PublicKey, fromAddress string, minConfs uint32, gasLanePrice uint64) string {
+	return fmt.Sprintf(vrfSpecTemplate,
+		coordinator, chainID, key.String(), fromAddress, minConfs, gasLanePrice,
+		fmt.Sprintf(observationSourceTemplate, coordinator, coordinator, coordinator))
+}
+
+const vrfSpecTemplate = `type = "vrf"
+schemaVersion = 1
+name = "vrf-v2-plus"
+coordinatorAddress = "%s"
+evmChainID = "%d"
+publicKey = "%s"
+fromAddresses = ["%s"]
+minIncomingConfirmations = %d
+gasLanePrice = "%d wei"
+batchFulfillmentEnabled = false
+requestTimeout = "24h"
+backoffInitialDelay = "1m"
+backoffMaxDelay = "1h"
+pollPeriod = "5s"
+observationSource = """
+%s
+"""
+`
+
+const observationSourceTemplate = `decode_log              [type=ethabidecodelog
+                         abi="RandomWordsRequested(bytes32 indexed keyHash,uint256 requestId,uint256 preSeed,uint256 indexed subId,uint16 minimumRequestConfirmations,uint32 callbackGasLimit,uint32 numWords,bytes extraArgs,address indexed sender)"
+                         data="$(jobRun.logData)"
+                         topics="$(jobRun.logTopics)"]
+generate_proof          [type=vrfv2plus
+                         publicKey="$(jobSpec.publicKey)"
+                         requestBlockHash="$(jobRun.logBlockHash)"
+                         requestBlockNumber="$(jobRun.logBlockNumber)"
+                         topics="$(jobRun.logTopics)"]
+estimate_gas            [type=estimategaslimit
+                         to="%s"
+                         multiplier="1.1"
+                         data="$(generate_proof.output)"
+                         block="latest"]
+simulate_fulfillment    [type=ethcall
+                         to="%s"
+                         gas="$(estimate_gas)"
+                         gasPrice="$(jobSpec.maxGasPrice)"
+                         extractRevertReason=true
+                         contract="%s"
+                         data="$(generate_proof.output)"
+                         block="latest"]
+decode_log->generate_proof->estimate_gas->simulate_fulfillment`
@@ -0,0 +1,27 @@
+package vrf
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/vrfkey"
+	"github.com/smartcontractkit/chainlink/v2/core/services/vrf/vrfcommon"
+)
+
+func TestVRFJobSpec(t *testing.T) {
+	key, err := vrfkey.NewV2()
+	require.NoError(t, err)
+	coordinator := common.HexToAddress("0x1")
+	from := "0x0000000000000000000000000000000000000002"
+
+	spec := vrfJobSpec(coordinator, 1337, key.PublicKey, from, 3, 1_000_000_000)
+	jb, err := vrfcommon.ValidatedVRFSpec(spec)
+	require.NoError(t, err)
+	require.Equal(t, coordinator, jb.VRFSpec.CoordinatorAddress.Address())
+	require.Equal(t, key.PublicKey, jb.VRFSpec.PublicKey)
+	require.Len(t, jb.VRFSpec.FromAddresses, 1)
+	require.Equal(t, from, jb.VRFSpec.FromAddresses[0].String())
+	require.Equal(t, "1 gwei", jb.VRFSpec.GasLanePrice.String())
+}
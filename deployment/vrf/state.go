@@ -0,0 +1,68 @@
+package vrf
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/generated/blockhash_store"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/generated/vrf_coordinator_v2_5"
+)
+
+// VRFChainState holds a Go binding for all the currently deployed VRF contracts
+// on a chain. If a binding is nil, it means here is no such contract on the chain.
+type VRFChainState struct {
+	BlockhashStore *blockhash_store.BlockhashStore
+	Coordinator    *vrf_coordinator_v2_5.VRFCoordinatorV25
+}
+
+// LoadChainState Loads all state for a chain into state
+func LoadChainState(chain deployment.Chain, addresses map[string]deployment.TypeAndVersion) (VRFChainState, error) {
+	var state VRFChainState
+	for address, tv := range addresses {
+		switch tv.String() {
+		case deployment.NewTypeAndVersion(BlockhashStore, deployment.Version1_0_0).String():
+			s, err := blockhash_store.NewBlockhashStore(common.HexToAddress(address), chain.Client)
+			if err != nil {
+				return state, err
+			}
+			state.BlockhashStore = s
+		case deployment.NewTypeAndVersion(VRFCoordinatorV2_5, Version2_5_0).String():
+			c, err := vrf_coordinator_v2_5.NewVRFCoordinatorV25(common.HexToAddress(address), chain.Client)
+			if err != nil {
+				return state, err
+			}
+			state.Coordinator = c
+		default:
+			// other products can share the address book
+			continue
+		}
+	}
+	return state, nil
+}
+
+func existingChainState(e deployment.Environment, chain deployment.Chain) (VRFChainState, error) {
+	addresses, err := e.ExistingAddresses.AddressesForChain(chain.Selector)
+	if errors.Is(err, deployment.ErrChainNotFound) {
+		// nothing deployed yet
+		return VRFChainState{}, nil
+	}
+	if err != nil {
+		return VRFChainState{}, fmt.Errorf("failed to get addresses for chain %d: %w", chain.Selector, err)
+	}
+	return LoadChainState(chain, addresses)
+}
+
+func coordinatorState(e deployment.Environment, chainSel uint64) (deployment.Chain, VRFChainState, error) {
+	chain := e.Chains[chainSel]
+	state, err := existingChainState(e, chain)
+	if err != nil {
+		return chain, state, err
+	}
+	if state.Coordinator == nil {
+		return chain, state, fmt.Errorf("no VRF coordinator on chain %d", chainSel)
+	}
+	return chain, state, nil
+}
@@ -0,0 +1,200 @@
+package vrf
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/utils"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/generated/link_token_interface"
+)
+
+type CreateSubscriptionConfig struct {
+	ChainSel uint64
+	// Consumers are added to the subscription once it is created.
+	Consumers []common.Address
+	// LinkFunds and NativeFunds are optional initial funds, taken from the deployer key.
+	LinkFunds   *big.Int
+	NativeFunds *big.Int
+}
+
+func (c CreateSubscriptionConfig) Validate(e deployment.Environment) error {
+	if _, ok := e.Chains[c.ChainSel]; !ok {
+		return fmt.Errorf("chain %d not found in environment", c.ChainSel)
+	}
+	return validateFunds(c.LinkFunds, c.NativeFunds)
+}
+
+// CreateSubscription creates a subscription owned by the deployer key on the coordinator of the chain,
+// adds its consumers and funds it. It returns the id of the subscription.
+func CreateSubscription(e deployment.Environment, c CreateSubscriptionConfig) (*big.Int, error) {
+	if err := c.Validate(e); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+	chain, state, err := coordinatorState(e, c.ChainSel)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := state.Coordinator.CreateSubscription(chain.DeployerKey)
+	block, err := deployment.ConfirmIfNoError(chain, tx, err)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create subscription: %w", deployment.MaybeDataErr(err))
+	}
+	it, err := state.Coordinator.FilterSubscriptionCreated(&bind.FilterOpts{Start: block, End: &block, Context: context.Background()}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to filter SubscriptionCreated events: %w", err)
+	}
+	defer it.Close()
+	var subID *big.Int
+	for it.Next() {
+		if it.Event.Raw.TxHash == tx.Hash() {
+			subID = it.Event.SubId
+		}
+	}
+	if subID == nil {
+		return nil, fmt.Errorf("no SubscriptionCreated event in tx %s", tx.Hash())
+	}
+	e.Logger.Infow("Created VRF subscription", "chainSelector", c.ChainSel, "subID", subID)
+
+	if err := addConsumers(e, chain, state, subID, c.Consumers); err != nil {
+		return subID, err
+	}
+	return subID, fundSubscription(e, chain, state, subID, c.LinkFunds, c.NativeFunds)
+}
+
+type FundSubscriptionConfig struct {
+	ChainSel    uint64
+	SubID       *big.Int
+	LinkFunds   *big.Int
+	NativeFunds *big.Int
+}
+
+func (c FundSubscriptionConfig) Validate(e deployment.Environment) error {
+	if _, ok := e.Chains[c.ChainSel]; !ok {
+		return fmt.Errorf("chain %d not found in environment", c.ChainSel)
+	}
+	if c.SubID == nil {
+		return fmt.Errorf("no subscription id")
+	}
+	if c.LinkFunds == nil && c.NativeFunds == nil {
+		return fmt.Errorf("no funds")
+	}
+	return validateFunds(c.LinkFunds, c.NativeFunds)
+}
+
+// FundSubscription adds LINK and/or native funds from the deployer key to a subscription.
+func FundSubscription(e deployment.Environment, c FundSubscriptionConfig) error {
+	if err := c.Validate(e); err != nil {
+		return fmt.Errorf("invalid config: %w", err)
+	}
+	chain, state, err := coordinatorState(e, c.ChainSel)
+	if err != nil {
+		return err
+	}
+	return fundSubscription(e, chain, state, c.SubID, c.LinkFunds, c.NativeFunds)
+}
+
+type AddConsumersConfig struct {
+	ChainSel  uint64
+	SubID     *big.Int
+	Consumers []common.Address
+}
+
+func (c AddConsumersConfig) Validate(e deployment.Environment) error {
+	if _, ok := e.Chains[c.ChainSel]; !ok {
+		return fmt.Errorf("chain %d not found in environment", c.ChainSel)
+	}
+	if c.SubID == nil {
+		return fmt.Errorf("no subscription id")
+	}
+	if len(c.Consumers) == 0 {
+		return fmt.Errorf("no consumers")
+	}
+	return nil
+}
+
+// AddConsumers adds consumers to a subscription owned by the deployer key.
+// Consumers that are already part of the subscription are skipped.
+func AddConsumers(e deployment.Environment, c AddConsumersConfig) error {
+	if err := c.Validate(e); err != nil {
+		return fmt.Errorf("invalid config: %w", err)
+	}
+	chain, state, err := coordinatorState(e, c.ChainSel)
+	if err != nil {
+		return err
+	}
+	return addConsumers(e, chain, state, c.SubID, c.Consumers)
+}
+
+func addConsumers(e deployment.Environment, chain deployment.Chain, state VRFChainState, subID *big.Int, consumers []common.Address) error {
+	if len(consumers) == 0 {
+		return nil
+	}
+	sub, err := state.Coordinator.GetSubscription(&bind.CallOpts{Context: context.Background()}, subID)
+	if err != nil {
+		return fmt.Errorf("failed to get subscription %s: %w", subID, err)
+	}
+	existing := make(map[common.Address]struct{})
+	for _, consumer := range sub.Consumers {
+		existing[consumer] = struct{}{}
+	}
+	for _, consumer := range consumers {
+		if _, ok := existing[consumer]; ok {
+			continue
+		}
+		tx, err := state.Coordinator.AddConsumer(chain.DeployerKey, subID, consumer)
+		if _, err := deployment.ConfirmIfNoError(chain, tx, err); err != nil {
+			return fmt.Errorf("failed to add consumer %s to subscription %s: %w", consumer, subID, deployment.MaybeDataErr(err))
+		}
+		e.Logger.Infow("Added VRF consumer", "subID", subID, "consumer", consumer)
+	}
+	return nil
+}
+
+func fundSubscription(e deployment.Environment, chain deployment.Chain, state VRFChainState, subID, linkFunds, nativeFunds *big.Int) error {
+	if linkFunds != nil && linkFunds.Sign() > 0 {
+		linkAddr, err := state.Coordinator.LINK(&bind.CallOpts{Context: context.Background()})
+		if err != nil {
+			return fmt.Errorf("failed to get coordinator LINK token: %w", err)
+		}
+		if linkAddr == (common.Address{}) {
+			return fmt.Errorf("coordinator on chain %d does not support LINK billing", chain.Selector)
+		}
+		link, err := link_token_interface.NewLinkToken(linkAddr, chain.Client)
+		if err != nil {
+			return err
+		}
+		data, err := utils.ABIEncode(`[{"type":"uint256"}]`, subID)
+		if err != nil {
+			return err
+		}
+		tx, err := link.TransferAndCall(chain.DeployerKey, state.Coordinator.Address(), linkFunds, data)
+		if _, err := deployment.ConfirmIfNoError(chain, tx, err); err != nil {
+			return fmt.Errorf("failed to fund subscription %s with LINK: %w", subID, deployment.MaybeDataErr(err))
+		}
+	}
+	if nativeFunds != nil && nativeFunds.Sign() > 0 {
+		opts := *chain.DeployerKey
+		opts.Value = nativeFunds
+		tx, err := state.Coordinator.FundSubscriptionWithNative(&opts, subID)
+		if _, err := deployment.ConfirmIfNoError(chain, tx, err); err != nil {
+			return fmt.Errorf("failed to fund subscription %s with native: %w", subID, deployment.MaybeDataErr(err))
+		}
+	}
+	e.Logger.Infow("Funded VRF subscription", "subID", subID, "link", linkFunds, "native", nativeFunds)
+	return nil
+}
+
+func validateFunds(linkFunds, nativeFunds *big.Int) error {
+	if linkFunds != nil && linkFunds.Sign() < 0 {
+		return fmt.Errorf("negative LINK funds")
+	}
+	if nativeFunds != nil && nativeFunds.Sign() < 0 {
+		return fmt.Errorf("negative native funds")
+	}
+	return nil
+}
@@ -0,0 +1,367 @@
+package deployment
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+)
+
+var (
+	// ZkContractDeployer is the system contract deploying all contracts on zkSync-class chains.
+	ZkContractDeployer = common.HexToAddress("0x0000000000000000000000000000000000008006")
+	// zkContractDeployedTopic is ContractDeployed(address indexed deployerAddress, bytes32 indexed bytecodeHash, address indexed contractAddress)
+	zkContractDeployedTopic = crypto.Keccak256Hash([]byte("ContractDeployed(address,bytes32,address)"))
+	// zkContractDeployerABI is the create function of the ContractDeployer.
+	zkContractDeployerABI = mustParseABI(`[{"type":"function","name":"create","stateMutability":"payable","inputs":[
+		{"name":"_salt","type":"bytes32"},{"name":"_bytecodeHash","type":"bytes32"},{"name":"_input","type":"bytes"}
+	],"outputs":[{"name":"","type":"address"}]}]`)
+)
+
+const (
+	// zkEIP712TxType is the type of the EIP-712 transactions of zkSync-class chains.
+	zkEIP712TxType = 0x71
+	// ZkDefaultGasPerPubdata is the default limit of the gas paid per byte of pubdata.
+	ZkDefaultGasPerPubdata = 50_000
+)
+
+// ZkDeployer deploys contracts on zkSync-class chains. Those chains deploy contracts through the
+// ContractDeployer system contract, with EIP-712 transactions carrying the zksolc bytecode as a
+// factory dependency, so the abigen deploy functions used by DeployContract don't work on them.
+type ZkDeployer interface {
+	// DeployContract sends the deployment of the zksolc bytecode with the abi encoded constructor args
+	// from the deployer key of the chain and returns the hash of the transaction.
+	DeployContract(ctx context.Context, bytecode []byte, constructorArgs []byte) (common.Hash, error)
+}
+
+// ZkContractDeploy represents the deployment of a contract on a zkSync-class chain.
+type ZkContractDeploy[C any] struct {
+	// Bytecode is the zksolc bytecode of the contract.
+	Bytecode        []byte
+	ConstructorArgs []byte
+	Tv              TypeAndVersion
+	// Bind binds the Go binding of the contract to the deployed address.
+	Bind func(address common.Address, client OnchainClient) (C, error)
+}
+
+// DeployZkContract is the DeployContract counterpart for zkSync-class chains, see ZkDeployer.
+// The address of the contract is derived by the chain, so it is read from the deployment receipt.
+func DeployZkContract[C any](
+	ctx context.Context,
+	lggr logger.Logger,
+	chain Chain,
+	addressBook AddressBook,
+	deploy ZkContractDeploy[C],
+) (*ContractDeploy[C], error) {
+	if !chain.IsZkChain() {
+		return nil, fmt.Errorf("chain %d is not a zk chain", chain.Selector)
+	}
+	if _, err := ZkBytecodeHash(deploy.Bytecode); err != nil {
+		return nil, err
+	}
+	txHash, err := chain.ZkDeployer.DeployContract(ctx, deploy.Bytecode, deploy.ConstructorArgs)
+	if err != nil {
+		lggr.Errorw("Failed to deploy contract", "err", err)
+		return nil, err
+	}
+	receipt, err := waitZkReceipt(ctx, chain.Client, txHash)
+	if err != nil {
+		lggr.Errorw("Failed to confirm deployment", "err", err, "tx", txHash)
+		return nil, err
+	}
+	addr, err := ZkDeployedAddress(receipt)
+	if err != nil {
+		return nil, err
+	}
+	contract, err := deploy.Bind(addr, chain.Client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to bind contract at %s: %w", addr, err)
+	}
+	if err := addressBook.Save(chain.Selector, addr.String(), deploy.Tv); err != nil {
+		lggr.Errorw("Failed to save contract address", "err", err)
+		return nil, err
+	}
+	return &ContractDeploy[C]{
+		Address:  addr,
+		Contract: contract,
+		Tv:       deploy.Tv,
+	}, nil
+}
+
+// ZkDeployedAddress returns the address of the contract deployed by the transaction of the receipt.
+func ZkDeployedAddress(receipt *types.Receipt) (common.Address, error) {
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return common.Address{}, fmt.Errorf("deployment tx %s reverted", receipt.TxHash)
+	}
+	// the last deployment is the contract itself, the previous ones are deployed by its constructor
+	for i := len(receipt.Logs) - 1; i >= 0; i-- {
+		log := receipt.Logs[i]
+		if log.Address == ZkContractDeployer && len(log.Topics) == 4 && log.Topics[0] == zkContractDeployedTopic {
+			return common.BytesToAddress(log.Topics[3].Bytes()), nil
+		}
+	}
+	return common.Address{}, fmt.Errorf("no ContractDeployed event in deployment tx %s", receipt.TxHash)
+}
+
+// ZkBytecodeHash returns the versioned hash identifying zksolc bytecode on zkSync-class chains:
+// a version byte, a zero byte, the length of the bytecode in 32 byte words, and the last 28 bytes
+// of its sha256 hash.
+func ZkBytecodeHash(bytecode []byte) ([32]byte, error) {
+	var hash [32]byte
+	if len(bytecode) == 0 || len(bytecode)%32 != 0 {
+		return hash, fmt.Errorf("zk bytecode length %d must be a non zero multiple of 32", len(bytecode))
+	}
+	words := len(bytecode) / 32
+	if words%2 == 0 || words > 1<<16-1 {
+		return hash, fmt.Errorf("zk bytecode must have an odd number of words less than 2^16, got %d", words)
+	}
+	sum := sha256.Sum256(bytecode)
+	copy(hash[4:], sum[4:])
+	hash[0] = 1
+	binary.BigEndian.PutUint16(hash[2:4], uint16(words))
+	return hash, nil
+}
+
+// waitZkReceipt polls the receipt of the transaction for up to 5 minutes, or until ctx is done.
+func waitZkReceipt(ctx context.Context, client OnchainClient, txHash common.Hash) (*types.Receipt, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+	defer cancel()
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		receipt, err := client.TransactionReceipt(ctx, txHash)
+		if err == nil {
+			return receipt, nil
+		}
+		if !errors.Is(err, ethereum.NotFound) {
+			return nil, fmt.Errorf("failed to get receipt of tx %s: %w", txHash, err)
+		}
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("tx %s not mined: %w", txHash, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// ZkRPCClient is the JSON-RPC client of a zkSync-class chain, e.g. *rpc.Client.
+type ZkRPCClient interface {
+	CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error
+}
+
+// ZkSyncDeployer is the ZkDeployer of zkSync Era chains. It deploys contracts by calling create on the
+// ContractDeployer with the bytecode hash of the contract, in an EIP-712 transaction carrying the bytecode
+// as its factory dependency. The key must be the key of the deployer of the chain.
+type ZkSyncDeployer struct {
+	client  ZkRPCClient
+	chainID *big.Int
+	key     *ecdsa.PrivateKey
+	// GasLimit of the deployment transactions, they are estimated by the chain if zero.
+	GasLimit uint64
+	// GasPerPubdata defaults to ZkDefaultGasPerPubdata.
+	GasPerPubdata uint64
+}
+
+var _ ZkDeployer = &ZkSyncDeployer{}
+
+func NewZkSyncDeployer(client ZkRPCClient, chainID *big.Int, key *ecdsa.PrivateKey) *ZkSyncDeployer {
+	return &ZkSyncDeployer{client: client, chainID: chainID, key: key}
+}
+
+func (d *ZkSyncDeployer) DeployContract(ctx context.Context, bytecode []byte, constructorArgs []byte) (common.Hash, error) {
+	bytecodeHash, err := ZkBytecodeHash(bytecode)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	data, err := zkContractDeployerABI.Pack("create", [32]byte{}, bytecodeHash, constructorArgs)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to pack create call: %w", err)
+	}
+	from := crypto.PubkeyToAddress(d.key.PublicKey)
+	to := ZkContractDeployer
+	tx := zkTx{
+		Nonce:                new(big.Int),
+		MaxPriorityFeePerGas: new(big.Int),
+		GasLimit:             new(big.Int).SetUint64(d.GasLimit),
+		To:                   &to,
+		Value:                new(big.Int),
+		Data:                 data,
+		ChainID1:             d.chainID,
+		ChainID2:             d.chainID,
+		From:                 &from,
+		GasPerPubdata:        new(big.Int).SetUint64(d.GasPerPubdata),
+		FactoryDeps:          [][]byte{bytecode},
+	}
+	if d.GasPerPubdata == 0 {
+		tx.GasPerPubdata.SetUint64(ZkDefaultGasPerPubdata)
+	}
+
+	var nonce hexutil.Uint64
+	if err := d.client.CallContext(ctx, &nonce, "eth_getTransactionCount", from, "pending"); err != nil {
+		return common.Hash{}, fmt.Errorf("failed to get nonce of %s: %w", from, err)
+	}
+	tx.Nonce.SetUint64(uint64(nonce))
+	var gasPrice hexutil.Big
+	if err := d.client.CallContext(ctx, &gasPrice, "eth_gasPrice"); err != nil {
+		return common.Hash{}, fmt.Errorf("failed to get gas price: %w", err)
+	}
+	tx.MaxFeePerGas = gasPrice.ToInt()
+	if d.GasLimit == 0 {
+		var gasLimit hexutil.Uint64
+		if err := d.client.CallContext(ctx, &gasLimit, "eth_estimateGas", tx.callArgs()); err != nil {
+			return common.Hash{}, fmt.Errorf("failed to estimate deployment gas: %w", err)
+		}
+		tx.GasLimit.SetUint64(uint64(gasLimit))
+	}
+
+	hash, err := tx.signingHash(bytecodeHash)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	sig, err := crypto.Sign(hash, d.key)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to sign deployment: %w", err)
+	}
+	sig[64] += 27
+	tx.CustomSignature = sig
+	raw, err := rlp.EncodeToBytes(tx)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to encode deployment: %w", err)
+	}
+	var txHash common.Hash
+	if err := d.client.CallContext(ctx, &txHash, "eth_sendRawTransaction", hexutil.Bytes(append([]byte{zkEIP712TxType}, raw...))); err != nil {
+		return common.Hash{}, fmt.Errorf("failed to send deployment: %w", err)
+	}
+	return txHash, nil
+}
+
+// zkTx is the RLP encoding of an EIP-712 transaction, the legacy signature fields are unused.
+type zkTx struct {
+	Nonce                *big.Int
+	MaxPriorityFeePerGas *big.Int
+	MaxFeePerGas         *big.Int
+	GasLimit             *big.Int
+	To                   *common.Address `rlp:"nil"`
+	Value                *big.Int
+	Data                 []byte
+	ChainID1             *big.Int
+	Empty1               string
+	Empty2               string
+	ChainID2             *big.Int
+	From                 *common.Address
+	GasPerPubdata        *big.Int
+	FactoryDeps          [][]byte
+	CustomSignature      []byte
+	PaymasterParams      *zkPaymasterParams `rlp:"nil"`
+}
+
+type zkPaymasterParams struct {
+	Paymaster      common.Address
+	PaymasterInput []byte
+}
+
+// signingHash is the EIP-712 hash of the transaction, the factory dependencies are signed by their bytecode hashes.
+func (tx zkTx) signingHash(factoryDepHashes ...[32]byte) ([]byte, error) {
+	deps := make([]interface{}, len(factoryDepHashes))
+	for i, h := range factoryDepHashes {
+		deps[i] = h[:]
+	}
+	typedData := apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": {
+				{Name: "name", Type: "string"},
+				{Name: "version", Type: "string"},
+				{Name: "chainId", Type: "uint256"},
+			},
+			"Transaction": {
+				{Name: "txType", Type: "uint256"},
+				{Name: "from", Type: "uint256"},
+				{Name: "to", Type: "uint256"},
+				{Name: "gasLimit", Type: "uint256"},
+				{Name: "gasPerPubdataByteLimit", Type: "uint256"},
+				{Name: "maxFeePerGas", Type: "uint256"},
+				{Name: "maxPriorityFeePerGas", Type: "uint256"},
+				{Name: "paymaster", Type: "uint256"},
+				{Name: "nonce", Type: "uint256"},
+				{Name: "value", Type: "uint256"},
+				{Name: "data", Type: "bytes"},
+				{Name: "factoryDeps", Type: "bytes32[]"},
+				{Name: "paymasterInput", Type: "bytes"},
+			},
+		},
+		PrimaryType: "Transaction",
+		Domain: apitypes.TypedDataDomain{
+			Name:    "zkSync",
+			Version: "2",
+			ChainId: (*math.HexOrDecimal256)(tx.ChainID2),
+		},
+		Message: apitypes.TypedDataMessage{
+			"txType":                 big.NewInt(zkEIP712TxType),
+			"from":                   new(big.Int).SetBytes(tx.From.Bytes()),
+			"to":                     new(big.Int).SetBytes(tx.To.Bytes()),
+			"gasLimit":               tx.GasLimit,
+			"gasPerPubdataByteLimit": tx.GasPerPubdata,
+			"maxFeePerGas":           tx.MaxFeePerGas,
+			"maxPriorityFeePerGas":   tx.MaxPriorityFeePerGas,
+			"paymaster":              new(big.Int),
+			"nonce":                  tx.Nonce,
+			"value":                  tx.Value,
+			"data":                   tx.Data,
+			"factoryDeps":            deps,
+			"paymasterInput":         []byte{},
+		},
+	}
+	hash, _, err := apitypes.TypedDataAndHash(typedData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash deployment: %w", err)
+	}
+	return hash, nil
+}
+
+// callArgs are the eth_estimateGas args of the transaction. The factory dependencies are byte arrays
+// encoded as arrays of numbers, as the chain expects.
+func (tx zkTx) callArgs() map[string]interface{} {
+	deps := make([][]uint, len(tx.FactoryDeps))
+	for i, dep := range tx.FactoryDeps {
+		deps[i] = make([]uint, len(dep))
+		for j, b := range dep {
+			deps[i][j] = uint(b)
+		}
+	}
+	return map[string]interface{}{
+		"from":  tx.From,
+		"to":    tx.To,
+		"data":  hexutil.Bytes(tx.Data),
+		"value": (*hexutil.Big)(tx.Value),
+		"type":  hexutil.Uint64(zkEIP712TxType),
+		"eip712Meta": map[string]interface{}{
+			"gasPerPubdata": (*hexutil.Big)(tx.GasPerPubdata),
+			"factoryDeps":   deps,
+		},
+	}
+}
+
+func mustParseABI(s string) abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(s))
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}
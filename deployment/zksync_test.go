@@ -0,0 +1,213 @@
+package deployment
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	chainsel "github.com/smartcontractkit/chain-selectors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+type fakeZkDeployer struct {
+	txHash   common.Hash
+	bytecode []byte
+}
+
+func (d *fakeZkDeployer) DeployContract(_ context.Context, bytecode []byte, _ []byte) (common.Hash, error) {
+	d.bytecode = bytecode
+	return d.txHash, nil
+}
+
+type fakeZkClient struct {
+	OnchainClient
+	receipts map[common.Hash]*types.Receipt
+	calls    int
+}
+
+func (c *fakeZkClient) TransactionReceipt(_ context.Context, txHash common.Hash) (*types.Receipt, error) {
+	c.calls++
+	// the receipt shows up on the second poll
+	if r, ok := c.receipts[txHash]; ok && c.calls > 1 {
+		return r, nil
+	}
+	return nil, ethereum.NotFound
+}
+
+func contractDeployedLog(addr common.Address) *types.Log {
+	return &types.Log{
+		Address: ZkContractDeployer,
+		Topics: []common.Hash{
+			zkContractDeployedTopic,
+			common.BytesToHash(common.HexToAddress("0xaa").Bytes()),
+			{},
+			common.BytesToHash(addr.Bytes()),
+		},
+	}
+}
+
+func TestZkBytecodeHash(t *testing.T) {
+	bytecode := make([]byte, 3*32)
+	hash, err := ZkBytecodeHash(bytecode)
+	require.NoError(t, err)
+	require.Equal(t, byte(1), hash[0])
+	require.Equal(t, byte(0), hash[1])
+	require.Equal(t, []byte{0, 3}, hash[2:4])
+
+	_, err = ZkBytecodeHash(make([]byte, 33))
+	require.Error(t, err)
+	_, err = ZkBytecodeHash(make([]byte, 2*32))
+	require.Error(t, err)
+	_, err = ZkBytecodeHash(nil)
+	require.Error(t, err)
+}
+
+func TestDeployZkContract(t *testing.T) {
+	lggr := logger.TestLogger(t)
+	txHash := common.HexToHash("0x01")
+	// the constructor deploys a helper contract before the contract itself is deployed
+	helper, deployed := common.HexToAddress("0x1111"), common.HexToAddress("0x2222")
+	client := &fakeZkClient{receipts: map[common.Hash]*types.Receipt{
+		txHash: {
+			Status: types.ReceiptStatusSuccessful,
+			TxHash: txHash,
+			Logs:   []*types.Log{contractDeployedLog(helper), contractDeployedLog(deployed)},
+		},
+	}}
+	deployer := &fakeZkDeployer{txHash: txHash}
+	chain := Chain{
+		Selector:   chainsel.TEST_90000001.Selector,
+		Client:     client,
+		ZkDeployer: deployer,
+	}
+	tv := NewTypeAndVersion("Router", Version1_0_0)
+
+	// EVM deployments are refused on zk chains
+	_, err := DeployContract(lggr, chain, NewMemoryAddressBook(), func(chain Chain) ContractDeploy[any] {
+		t.Fatal("EVM deployment attempted on a zk chain")
+		return ContractDeploy[any]{}
+	})
+	require.Error(t, err)
+
+	ab := NewMemoryAddressBook()
+	bytecode := make([]byte, 32)
+	out, err := DeployZkContract(context.Background(), lggr, chain, ab, ZkContractDeploy[common.Address]{
+		Bytecode: bytecode,
+		Tv:       tv,
+		Bind: func(address common.Address, _ OnchainClient) (common.Address, error) {
+			return address, nil
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, deployed, out.Address)
+	require.Equal(t, deployed, out.Contract)
+	require.Equal(t, bytecode, deployer.bytecode)
+	addresses, err := ab.AddressesForChain(chain.Selector)
+	require.NoError(t, err)
+	require.Equal(t, map[string]TypeAndVersion{deployed.String(): tv}, addresses)
+
+	// reverted deployments are not saved
+	client.receipts[txHash].Status = types.ReceiptStatusFailed
+	_, err = DeployZkContract(context.Background(), lggr, chain, NewMemoryAddressBook(), ZkContractDeploy[common.Address]{
+		Bytecode: bytecode,
+		Tv:       tv,
+		Bind: func(address common.Address, _ OnchainClient) (common.Address, error) {
+			return address, nil
+		},
+	})
+	require.ErrorContains(t, err, "reverted")
+}
+
+func TestDeployZkContract_Cancelled(t *testing.T) {
+	lggr := logger.TestLogger(t)
+	// the receipt never shows up
+	chain := Chain{
+		Selector:   chainsel.TEST_90000001.Selector,
+		Client:     &fakeZkClient{},
+		ZkDeployer: &fakeZkDeployer{txHash: common.HexToHash("0x01")},
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := DeployZkContract(ctx, lggr, chain, NewMemoryAddressBook(), ZkContractDeploy[common.Address]{
+		Bytecode: make([]byte, 32),
+		Tv:       NewTypeAndVersion("Router", Version1_0_0),
+		Bind: func(address common.Address, _ OnchainClient) (common.Address, error) {
+			return address, nil
+		},
+	})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Less(t, time.Since(start), 5*time.Second)
+}
+
+type fakeZkRPCClient struct {
+	raw []byte
+}
+
+func (c *fakeZkRPCClient) CallContext(_ context.Context, result interface{}, method string, args ...interface{}) error {
+	switch method {
+	case "eth_getTransactionCount":
+		*result.(*hexutil.Uint64) = 3
+	case "eth_gasPrice":
+		*result.(*hexutil.Big) = hexutil.Big(*big.NewInt(100))
+	case "eth_estimateGas":
+		*result.(*hexutil.Uint64) = 1_000_000
+	case "eth_sendRawTransaction":
+		c.raw = args[0].(hexutil.Bytes)
+		*result.(*common.Hash) = crypto.Keccak256Hash(c.raw)
+	default:
+		return fmt.Errorf("unexpected method %s", method)
+	}
+	return nil
+}
+
+func TestZkSyncDeployer(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	client := &fakeZkRPCClient{}
+	deployer := NewZkSyncDeployer(client, big.NewInt(324), key)
+	bytecode := bytes.Repeat([]byte{0xab}, 3*32)
+	constructorArgs := []byte{1, 2, 3}
+
+	txHash, err := deployer.DeployContract(context.Background(), bytecode, constructorArgs)
+	require.NoError(t, err)
+	require.Equal(t, crypto.Keccak256Hash(client.raw), txHash)
+
+	require.Equal(t, byte(zkEIP712TxType), client.raw[0])
+	var tx zkTx
+	require.NoError(t, rlp.DecodeBytes(client.raw[1:], &tx))
+	require.Equal(t, uint64(3), tx.Nonce.Uint64())
+	require.Equal(t, uint64(100), tx.MaxFeePerGas.Uint64())
+	require.Equal(t, uint64(1_000_000), tx.GasLimit.Uint64())
+	require.Equal(t, uint64(ZkDefaultGasPerPubdata), tx.GasPerPubdata.Uint64())
+	require.Equal(t, ZkContractDeployer, *tx.To)
+	require.Equal(t, crypto.PubkeyToAddress(key.PublicKey), *tx.From)
+	require.Equal(t, [][]byte{bytecode}, tx.FactoryDeps)
+
+	// the ContractDeployer creates the contract of the bytecode hash with the constructor args
+	bytecodeHash, err := ZkBytecodeHash(bytecode)
+	require.NoError(t, err)
+	args, err := zkContractDeployerABI.Methods["create"].Inputs.Unpack(tx.Data[4:])
+	require.NoError(t, err)
+	require.Equal(t, bytecodeHash, args[1])
+	require.Equal(t, constructorArgs, args[2])
+
+	hash, err := tx.signingHash(bytecodeHash)
+	require.NoError(t, err)
+	sig := bytes.Clone(tx.CustomSignature)
+	sig[64] -= 27
+	pub, err := crypto.SigToPub(hash, sig)
+	require.NoError(t, err)
+	require.Equal(t, crypto.PubkeyToAddress(key.PublicKey), crypto.PubkeyToAddress(*pub))
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/smartcontractkit/chainlink-common/pkg/merklemulti"
 	"github.com/smartcontractkit/chainlink/deployment"
 	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset/testhelpers"
 	"github.com/smartcontractkit/chainlink/integration-tests/testsetups"
 	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/assets"
 	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/offramp"
@@ -32,10 +33,10 @@ import (
 func Test_CCIPBatching(t *testing.T) {
 	// Setup 3 chains, with 2 lanes going to the dest.
 	lggr := logger.TestLogger(t)
-	ctx := changeset.Context(t)
+	ctx := testhelpers.Context(t)
 	// Will load 3 chains when specified by the overrides.toml or env vars (E2E_TEST_SELECTED_NETWORK).
 	// See e2e-tests.yml.
-	e, _, _ := testsetups.NewLocalDevEnvironmentWithDefaultPrice(t, lggr, &changeset.TestConfigs{
+	e, _, _ := testsetups.NewLocalDevEnvironmentWithDefaultPrice(t, lggr, &testhelpers.TestConfigs{
 		IsUSDC:       false,
 		IsMultiCall3: true, // needed for this test
 	})
@@ -88,7 +89,7 @@ func Test_CCIPBatching(t *testing.T) {
 		)
 		require.NoError(t, err)
 
-		_, err = changeset.ConfirmCommitWithExpectedSeqNumRange(
+		_, err = testhelpers.ConfirmCommitWithExpectedSeqNumRange(
 			t,
 			e.Env.Chains[sourceChain],
 			e.Env.Chains[destChain],
@@ -98,7 +99,7 @@ func Test_CCIPBatching(t *testing.T) {
 		)
 		require.NoErrorf(t, err, "failed to confirm commit from chain %d", sourceChain)
 
-		states, err := changeset.ConfirmExecWithSeqNrs(
+		states, err := testhelpers.ConfirmExecWithSeqNrs(
 			t,
 			e.Env.Chains[sourceChain],
 			e.Env.Chains[destChain],
@@ -288,7 +289,7 @@ func Test_CCIPBatching(t *testing.T) {
 			}
 		}
 
-		_, err = changeset.ConfirmCommitWithExpectedSeqNumRange(
+		_, err = testhelpers.ConfirmCommitWithExpectedSeqNumRange(
 			t,
 			e.Env.Chains[sourceChain],
 			e.Env.Chains[destChain],
@@ -310,7 +311,7 @@ type outputErr[T any] struct {
 
 func assertExecAsync(
 	t *testing.T,
-	e changeset.DeployedEnv,
+	e testhelpers.DeployedEnv,
 	state changeset.CCIPOnChainState,
 	sourceChainSelector,
 	destChainSelector uint64,
@@ -319,7 +320,7 @@ func assertExecAsync(
 	errs chan<- outputErr[map[uint64]int],
 ) {
 	defer wg.Done()
-	states, err := changeset.ConfirmExecWithSeqNrs(
+	states, err := testhelpers.ConfirmExecWithSeqNrs(
 		t,
 		e.Env.Chains[sourceChainSelector],
 		e.Env.Chains[destChainSelector],
@@ -333,7 +334,7 @@ func assertExecAsync(
 
 func assertCommitReportsAsync(
 	t *testing.T,
-	e changeset.DeployedEnv,
+	e testhelpers.DeployedEnv,
 	state changeset.CCIPOnChainState,
 	sourceChainSelector,
 	destChainSelector uint64,
@@ -343,7 +344,7 @@ func assertCommitReportsAsync(
 	errs chan<- outputErr[*offramp.OffRampCommitReportAccepted],
 ) {
 	defer wg.Done()
-	commitReport, err := changeset.ConfirmCommitWithExpectedSeqNumRange(
+	commitReport, err := testhelpers.ConfirmCommitWithExpectedSeqNumRange(
 		t,
 		e.Env.Chains[sourceChainSelector],
 		e.Env.Chains[destChainSelector],
@@ -358,7 +359,7 @@ func assertCommitReportsAsync(
 func sendMessagesAsync(
 	ctx context.Context,
 	t *testing.T,
-	e changeset.DeployedEnv,
+	e testhelpers.DeployedEnv,
 	state changeset.CCIPOnChainState,
 	sourceChainSelector,
 	destChainSelector uint64,
@@ -465,7 +466,7 @@ func genMessages(
 
 		totalValue.Add(totalValue, fee)
 
-		calldata, err := changeset.CCIPSendCalldata(destChainSelector, msg)
+		calldata, err := testhelpers.CCIPSendCalldata(destChainSelector, msg)
 		if err != nil {
 			return nil, nil, fmt.Errorf("generate calldata: %w", err)
 		}
@@ -0,0 +1,79 @@
+package smoke
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-testing-framework/lib/utils/testcontext"
+
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset/testhelpers"
+	"github.com/smartcontractkit/chainlink/integration-tests/testsetups"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/router"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+// Test_CCIPDataAvailabilityFee treats every destination as an L2 charging a data availability fee
+// and checks that both the quoted fee and the fee paid by the executed messages include it.
+func Test_CCIPDataAvailabilityFee(t *testing.T) {
+	lggr := logger.TestLogger(t)
+	tenv, _, _ := testsetups.NewLocalDevEnvironmentWithDefaultPrice(t, lggr, nil)
+	e := tenv.Env
+	state, err := changeset.LoadOnchainState(e)
+	require.NoError(t, err)
+
+	prices := changeset.DefaultInitialPrices
+	prices.GasPrice = changeset.ToPackedFee(big.NewInt(8e14), big.NewInt(4e16))
+	for src := range e.Chains {
+		for dest := range e.Chains {
+			if src == dest {
+				continue
+			}
+			require.NoError(t, changeset.AddLane(e, state, changeset.LaneConfig{
+				SourceSelector:        src,
+				DestSelector:          dest,
+				InitialPricesBySource: prices,
+				FeeQuoterDestChain:    testhelpers.L2FeeQuoterDestChainConfig(),
+			}, false))
+		}
+	}
+
+	startBlocks := make(map[uint64]*uint64)
+	expectedSeqNum := make(map[changeset.SourceDestPair]uint64)
+	expectedSeqNumExec := make(map[changeset.SourceDestPair][]uint64)
+	for src := range e.Chains {
+		for dest, destChain := range e.Chains {
+			if src == dest {
+				continue
+			}
+			latesthdr, err := destChain.Client.HeaderByNumber(testcontext.Get(t), nil)
+			require.NoError(t, err)
+			block := latesthdr.Number.Uint64()
+			startBlocks[dest] = &block
+
+			msg := router.ClientEVM2AnyMessage{
+				Receiver:     common.LeftPadBytes(state.Chains[dest].Receiver.Address().Bytes(), 32),
+				Data:         []byte("message paying for data availability"),
+				TokenAmounts: nil,
+				FeeToken:     common.HexToAddress("0x0"),
+				ExtraArgs:    nil,
+			}
+			fee := testhelpers.AssertFeeIncludesDataAvailability(t, state, src, dest, false, msg)
+			msgSentEvent := testhelpers.TestSendRequest(t, e, state, src, dest, false, msg)
+			require.Equal(t, fee.Total.String(), msgSentEvent.Message.FeeTokenAmount.String())
+
+			pair := changeset.SourceDestPair{
+				SourceChainSelector: src,
+				DestChainSelector:   dest,
+			}
+			expectedSeqNum[pair] = msgSentEvent.SequenceNumber
+			expectedSeqNumExec[pair] = []uint64{msgSentEvent.SequenceNumber}
+		}
+	}
+
+	testhelpers.ConfirmCommitForAllWithExpectedSeqNums(t, e, state, expectedSeqNum, startBlocks)
+	testhelpers.ConfirmExecWithSeqNrsForAll(t, e, state, expectedSeqNumExec, startBlocks)
+}
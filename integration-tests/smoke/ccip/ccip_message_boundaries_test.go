@@ -0,0 +1,90 @@
+package smoke
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-testing-framework/lib/utils/testcontext"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset/testhelpers"
+	"github.com/smartcontractkit/chainlink/integration-tests/testsetups"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+// TestMessageBoundaries sends the messages at the data size, gas limit and number of tokens limits of the
+// FeeQuoter dest chain config read from the chain, asserting they are executed and charged more than minimal
+// messages, and asserting that the messages just over the limits are rejected.
+func TestMessageBoundaries(t *testing.T) {
+	lggr := logger.TestLogger(t)
+	tenv, _, _ := testsetups.NewLocalDevEnvironmentWithDefaultPrice(t, lggr, nil)
+	e := tenv.Env
+	state, err := changeset.LoadOnchainState(e)
+	require.NoError(t, err)
+
+	src, dest := tenv.HomeChainSel, tenv.FeedChainSel
+	srcToken, _, _, _, err := testhelpers.DeployTransferableToken(lggr, e.Chains, src, dest, state, e.ExistingAddresses, "MY_TOKEN")
+	require.NoError(t, err)
+	require.NoError(t, testhelpers.AddLanesForAll(e, state))
+
+	ctx := testcontext.Get(t)
+	destConfig, err := state.Chains[src].FeeQuoter.GetDestChainConfig(&bind.CallOpts{Context: ctx}, dest)
+	require.NoError(t, err)
+	lggr.Infow("Testing message boundaries", "maxDataBytes", destConfig.MaxDataBytes,
+		"maxPerMsgGasLimit", destConfig.MaxPerMsgGasLimit, "maxNumberOfTokensPerMsg", destConfig.MaxNumberOfTokensPerMsg)
+
+	srcChain := e.Chains[src]
+	amount := big.NewInt(int64(2 * (destConfig.MaxNumberOfTokensPerMsg + 1)))
+	tx, err := srcToken.Mint(srcChain.DeployerKey, srcChain.DeployerKey.From, amount)
+	_, err = deployment.ConfirmIfNoError(srcChain, tx, err)
+	require.NoError(t, err)
+	tx, err = srcToken.Approve(srcChain.DeployerKey, state.Chains[src].Router.Address(), amount)
+	_, err = deployment.ConfirmIfNoError(srcChain, tx, err)
+	require.NoError(t, err)
+
+	latesthdr, err := e.Chains[dest].Client.HeaderByNumber(ctx, nil)
+	require.NoError(t, err)
+	block := latesthdr.Number.Uint64()
+	startBlocks := map[uint64]*uint64{dest: &block}
+
+	cases := testhelpers.MessageBoundaryCases(destConfig, state.Chains[dest].Receiver.Address(), srcToken.Address())
+	fees := make(map[string]*big.Int)
+	var seqNums []uint64
+	for _, tc := range cases {
+		fee, err := state.Chains[src].Router.GetFee(&bind.CallOpts{Context: ctx}, dest, tc.Msg)
+		if tc.ExpectedError != "" {
+			require.Error(t, err, tc.Name)
+			revertErr, ok := deployment.DecodeRevert(deployment.MaybeDataErr(err))
+			require.True(t, ok, "%s: undecodable revert %v", tc.Name, err)
+			require.Equal(t, tc.ExpectedError, revertErr.Name, tc.Name)
+
+			_, _, err = testhelpers.CCIPSendRequest(e, state, src, dest, false, tc.Msg)
+			require.Error(t, err, tc.Name)
+			revertErr, ok = deployment.DecodeRevert(err)
+			require.True(t, ok, "%s: undecodable revert %v", tc.Name, err)
+			require.Equal(t, tc.ExpectedError, revertErr.Name, tc.Name)
+			continue
+		}
+		require.NoError(t, err, tc.Name)
+		fees[tc.Name] = fee
+		if tc.Baseline != "" {
+			require.Equal(t, 1, fee.Cmp(fees[tc.Baseline]), "fee of %s must exceed the fee of %s", tc.Name, tc.Baseline)
+		}
+		msgSentEvent := testhelpers.TestSendRequest(t, e, state, src, dest, false, tc.Msg)
+		require.Equal(t, fee.String(), msgSentEvent.Message.FeeTokenAmount.String(), tc.Name)
+		seqNums = append(seqNums, msgSentEvent.SequenceNumber)
+	}
+
+	pair := changeset.SourceDestPair{SourceChainSelector: src, DestChainSelector: dest}
+	testhelpers.ConfirmCommitForAllWithExpectedSeqNums(t, e, state,
+		map[changeset.SourceDestPair]uint64{pair: seqNums[len(seqNums)-1]}, startBlocks)
+	states := testhelpers.ConfirmExecWithSeqNrsForAll(t, e, state,
+		map[changeset.SourceDestPair][]uint64{pair: seqNums}, startBlocks)
+	for _, seqNum := range seqNums {
+		require.Equal(t, changeset.EXECUTION_STATE_SUCCESS, states[pair][seqNum], "message %d", seqNum)
+	}
+}
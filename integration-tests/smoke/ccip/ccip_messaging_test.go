@@ -18,6 +18,7 @@ import (
 
 	"github.com/smartcontractkit/chainlink/deployment"
 	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset/testhelpers"
 	"github.com/smartcontractkit/chainlink/integration-tests/testsetups"
 	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/offramp"
 	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/onramp"
@@ -28,7 +29,7 @@ import (
 type testCaseSetup struct {
 	t                      *testing.T
 	sender                 []byte
-	deployedEnv            changeset.DeployedEnv
+	deployedEnv            testhelpers.DeployedEnv
 	onchainState           changeset.CCIPOnChainState
 	sourceChain, destChain uint64
 }
@@ -48,7 +49,7 @@ type messagingTestCaseOutput struct {
 func Test_CCIPMessaging(t *testing.T) {
 	// Setup 2 chains and a single lane.
 	lggr := logger.TestLogger(t)
-	ctx := changeset.Context(t)
+	ctx := testhelpers.Context(t)
 	e, _, _ := testsetups.NewLocalDevEnvironmentWithDefaultPrice(t, lggr, nil)
 
 	state, err := changeset.LoadOnchainState(e.Env)
@@ -145,8 +146,8 @@ func Test_CCIPMessaging(t *testing.T) {
 			},
 			state.Chains[destChain].Receiver.Address(),
 			[]byte("hello CCIPReceiver with low exec gas"),
-			changeset.MakeEVMExtraArgsV2(1, false), // 1 gas is too low.
-			changeset.EXECUTION_STATE_FAILURE,      // state would be failed onchain due to low gas
+			testhelpers.MakeEVMExtraArgsV2(1, false), // 1 gas is too low.
+			changeset.EXECUTION_STATE_FAILURE,        // state would be failed onchain due to low gas
 		)
 
 		manuallyExecute(ctx, t, latestHead.Number.Uint64(), state, destChain, out, sourceChain, e, sender)
@@ -164,7 +165,7 @@ func manuallyExecute(
 	destChain uint64,
 	out messagingTestCaseOutput,
 	sourceChain uint64,
-	e changeset.DeployedEnv,
+	e testhelpers.DeployedEnv,
 	sender []byte,
 ) {
 	merkleRoot := getMerkleRoot(
@@ -287,12 +288,12 @@ func getMessageHash(
 	return iter.Event.MessageHash
 }
 
-func sleepAndReplay(t *testing.T, e changeset.DeployedEnv, sourceChain, destChain uint64) {
+func sleepAndReplay(t *testing.T, e testhelpers.DeployedEnv, sourceChain, destChain uint64) {
 	time.Sleep(30 * time.Second)
 	replayBlocks := make(map[uint64]uint64)
 	replayBlocks[sourceChain] = 1
 	replayBlocks[destChain] = 1
-	changeset.ReplayLogs(t, e.Env.Offchain, replayBlocks)
+	testhelpers.ReplayLogs(t, e.Env.Offchain, replayBlocks)
 }
 
 func runMessagingTestCase(
@@ -311,7 +312,7 @@ func runMessagingTestCase(
 	require.Equal(tc.t, tc.nonce, latestNonce)
 
 	startBlocks := make(map[uint64]*uint64)
-	msgSentEvent := changeset.TestSendRequest(tc.t, tc.deployedEnv.Env, tc.onchainState, tc.sourceChain, tc.destChain, false, router.ClientEVM2AnyMessage{
+	msgSentEvent := testhelpers.TestSendRequest(tc.t, tc.deployedEnv.Env, tc.onchainState, tc.sourceChain, tc.destChain, false, router.ClientEVM2AnyMessage{
 		Receiver:     common.LeftPadBytes(receiver.Bytes(), 32),
 		Data:         msgData,
 		TokenAmounts: nil,
@@ -338,8 +339,8 @@ func runMessagingTestCase(
 		out.replayed = true
 	}
 
-	changeset.ConfirmCommitForAllWithExpectedSeqNums(tc.t, tc.deployedEnv.Env, tc.onchainState, expectedSeqNum, startBlocks)
-	execStates := changeset.ConfirmExecWithSeqNrsForAll(tc.t, tc.deployedEnv.Env, tc.onchainState, expectedSeqNumExec, startBlocks)
+	testhelpers.ConfirmCommitForAllWithExpectedSeqNums(tc.t, tc.deployedEnv.Env, tc.onchainState, expectedSeqNum, startBlocks)
+	execStates := testhelpers.ConfirmExecWithSeqNrsForAll(tc.t, tc.deployedEnv.Env, tc.onchainState, expectedSeqNumExec, startBlocks)
 
 	require.Equalf(
 		tc.t,
@@ -0,0 +1,114 @@
+package smoke
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-testing-framework/lib/utils/testcontext"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset/testhelpers"
+	"github.com/smartcontractkit/chainlink/integration-tests/testsetups"
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/utils"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/token_pool"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/shared/generated/burn_mint_erc677"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+// TestMultiTokenTransfer sends a single message carrying a burn/mint token, a lock/release token
+// and USDC, and asserts that each of them is delivered and consumes the rate limiters of its pools.
+func TestMultiTokenTransfer(t *testing.T) {
+	lggr := logger.TestLogger(t)
+	tenv, _, _ := testsetups.NewLocalDevEnvironmentWithDefaultPrice(t, lggr, &testhelpers.TestConfigs{
+		IsUSDC: true,
+	})
+	e := tenv.Env
+	state, err := changeset.LoadOnchainState(e)
+	require.NoError(t, err)
+
+	src, dest := tenv.HomeChainSel, tenv.FeedChainSel
+	srcBurnMint, srcBurnMintPool, destBurnMint, destBurnMintPool, err := testhelpers.DeployTransferableToken(
+		lggr, e.Chains, src, dest, state, e.ExistingAddresses, "MY_TOKEN")
+	require.NoError(t, err)
+	liquidity := new(big.Int).Mul(big.NewInt(1e18), big.NewInt(100))
+	srcLockRelease, srcLockReleasePool, destLockRelease, destLockReleasePool, err := testhelpers.DeployLockReleaseTransferableToken(
+		lggr, e.Chains, src, dest, state, e.ExistingAddresses, "MY_LR_TOKEN", liquidity)
+	require.NoError(t, err)
+	srcUSDC, destUSDC, err := testhelpers.ConfigureUSDCTokenPools(lggr, e.Chains, src, dest, state)
+	require.NoError(t, err)
+	require.NoError(t, testhelpers.UpdateFeeQuoterForUSDC(lggr, e.Chains[src], state.Chains[src], dest, srcUSDC))
+
+	require.NoError(t, testhelpers.AddLanesForAll(e, state))
+
+	srcChain := e.Chains[src]
+	twoCoins := new(big.Int).Mul(big.NewInt(1e18), big.NewInt(2))
+	for _, token := range []*burn_mint_erc677.BurnMintERC677{srcBurnMint, srcLockRelease, srcUSDC} {
+		tx, err := token.Mint(srcChain.DeployerKey, srcChain.DeployerKey.From, twoCoins)
+		_, err = deployment.ConfirmIfNoError(srcChain, tx, err)
+		require.NoError(t, err)
+		tx, err = token.Approve(srcChain.DeployerKey, state.Chains[src].Router.Address(), twoCoins)
+		_, err = deployment.ConfirmIfNoError(srcChain, tx, err)
+		require.NoError(t, err)
+	}
+
+	// enable the rate limiters of both ends of the burn/mint and lock/release pools
+	rateLimit := token_pool.RateLimiterConfig{
+		IsEnabled: true,
+		Capacity:  new(big.Int).Mul(big.NewInt(1e18), big.NewInt(10)),
+		Rate:      big.NewInt(1e15),
+	}
+	for _, pools := range [][2]common.Address{
+		{srcBurnMintPool.Address(), destBurnMintPool.Address()},
+		{srcLockReleasePool.Address(), destLockReleasePool.Address()},
+	} {
+		require.NoError(t, testhelpers.SetTokenPoolRateLimits(srcChain, pools[0], dest, rateLimit, rateLimit))
+		require.NoError(t, testhelpers.SetTokenPoolRateLimits(e.Chains[dest], pools[1], src, rateLimit, rateLimit))
+	}
+
+	oneCoin := big.NewInt(1e18)
+	transfers := []testhelpers.TokenTransfer{
+		{
+			Token:      srcBurnMint.Address(),
+			Amount:     oneCoin,
+			SourcePool: srcBurnMintPool.Address(),
+			DestToken:  destBurnMint.Address(),
+			DestPool:   destBurnMintPool.Address(),
+		},
+		{
+			Token:      srcLockRelease.Address(),
+			Amount:     new(big.Int).Div(oneCoin, big.NewInt(2)),
+			SourcePool: srcLockReleasePool.Address(),
+			DestToken:  destLockRelease.Address(),
+			DestPool:   destLockReleasePool.Address(),
+		},
+		{
+			Token:      srcUSDC.Address(),
+			Amount:     big.NewInt(1),
+			SourcePool: state.Chains[src].USDCTokenPool.Address(),
+			DestToken:  destUSDC.Address(),
+			DestPool:   state.Chains[dest].USDCTokenPool.Address(),
+			// MockE2EUSDCTransmitter always mints 1, see MockE2EUSDCTransmitter.sol for more details
+			ExpectedDestAmount: big.NewInt(1),
+		},
+	}
+
+	latesthdr, err := e.Chains[dest].Client.HeaderByNumber(testcontext.Get(t), nil)
+	require.NoError(t, err)
+	block := latesthdr.Number.Uint64()
+	startBlocks := map[uint64]*uint64{dest: &block}
+
+	receiver := utils.RandomAddress()
+	msgSentEvent, snapshot := testhelpers.SendMultiTokenMessage(t, e, state, src, dest, receiver, []byte("hello"), transfers)
+	identifier := changeset.SourceDestPair{SourceChainSelector: src, DestChainSelector: dest}
+	testhelpers.ConfirmCommitForAllWithExpectedSeqNums(t, e, state,
+		map[changeset.SourceDestPair]uint64{identifier: msgSentEvent.SequenceNumber}, startBlocks)
+	states := testhelpers.ConfirmExecWithSeqNrsForAll(t, e, state,
+		map[changeset.SourceDestPair][]uint64{identifier: {msgSentEvent.SequenceNumber}}, startBlocks)
+	require.Equal(t, changeset.EXECUTION_STATE_SUCCESS, states[identifier][msgSentEvent.SequenceNumber])
+
+	testhelpers.AssertTokenTransfers(t, e.Chains[src], e.Chains[dest], receiver, transfers, snapshot)
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/smartcontractkit/chainlink-testing-framework/lib/utils/testcontext"
 
 	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset/testhelpers"
 	"github.com/smartcontractkit/chainlink/integration-tests/testsetups"
 	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/onramp"
 	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/router"
@@ -25,7 +26,7 @@ func TestInitialDeployOnLocal(t *testing.T) {
 	require.NoError(t, err)
 
 	// Add all lanes
-	require.NoError(t, changeset.AddLanesForAll(e, state))
+	require.NoError(t, testhelpers.AddLanesForAll(e, state))
 	// Need to keep track of the block number for each chain so that event subscription can be done from that block.
 	startBlocks := make(map[uint64]*uint64)
 	// Send a message from each chain to every other chain.
@@ -40,7 +41,7 @@ func TestInitialDeployOnLocal(t *testing.T) {
 			require.NoError(t, err)
 			block := latesthdr.Number.Uint64()
 			startBlocks[dest] = &block
-			msgSentEvent := changeset.TestSendRequest(t, e, state, src, dest, false, router.ClientEVM2AnyMessage{
+			msgSentEvent := testhelpers.TestSendRequest(t, e, state, src, dest, false, router.ClientEVM2AnyMessage{
 				Receiver:     common.LeftPadBytes(state.Chains[dest].Receiver.Address().Bytes(), 32),
 				Data:         []byte("hello world"),
 				TokenAmounts: nil,
@@ -59,7 +60,7 @@ func TestInitialDeployOnLocal(t *testing.T) {
 	}
 
 	// Wait for all commit reports to land.
-	changeset.ConfirmCommitForAllWithExpectedSeqNums(t, e, state, expectedSeqNum, startBlocks)
+	testhelpers.ConfirmCommitForAllWithExpectedSeqNums(t, e, state, expectedSeqNum, startBlocks)
 
 	// After commit is reported on all chains, token prices should be updated in FeeQuoter.
 	for dest := range e.Chains {
@@ -67,11 +68,11 @@ func TestInitialDeployOnLocal(t *testing.T) {
 		feeQuoter := state.Chains[dest].FeeQuoter
 		timestampedPrice, err := feeQuoter.GetTokenPrice(nil, linkAddress)
 		require.NoError(t, err)
-		require.Equal(t, changeset.MockLinkPrice, timestampedPrice.Value)
+		require.Equal(t, testhelpers.MockLinkPrice, timestampedPrice.Value)
 	}
 
 	// Wait for all exec reports to land
-	changeset.ConfirmExecWithSeqNrsForAll(t, e, state, expectedSeqNumExec, startBlocks)
+	testhelpers.ConfirmExecWithSeqNrsForAll(t, e, state, expectedSeqNumExec, startBlocks)
 
 	// TODO: Apply the proposal.
 }
@@ -84,7 +85,7 @@ func TestTokenTransfer(t *testing.T) {
 	state, err := changeset.LoadOnchainState(e)
 	require.NoError(t, err)
 
-	srcToken, _, dstToken, _, err := changeset.DeployTransferableToken(
+	srcToken, _, dstToken, _, err := testhelpers.DeployTransferableToken(
 		lggr,
 		tenv.Env.Chains,
 		tenv.HomeChainSel,
@@ -96,7 +97,7 @@ func TestTokenTransfer(t *testing.T) {
 	require.NoError(t, err)
 
 	// Add all lanes
-	require.NoError(t, changeset.AddLanesForAll(e, state))
+	require.NoError(t, testhelpers.AddLanesForAll(e, state))
 	// Need to keep track of the block number for each chain so that event subscription can be done from that block.
 	startBlocks := make(map[uint64]*uint64)
 	// Send a message from each chain to every other chain.
@@ -159,7 +160,7 @@ func TestTokenTransfer(t *testing.T) {
 				msgSentEvent *onramp.OnRampCCIPMessageSent
 			)
 			if src == tenv.HomeChainSel && dest == tenv.FeedChainSel {
-				msgSentEvent = changeset.TestSendRequest(t, e, state, src, dest, false, router.ClientEVM2AnyMessage{
+				msgSentEvent = testhelpers.TestSendRequest(t, e, state, src, dest, false, router.ClientEVM2AnyMessage{
 					Receiver:     receiver,
 					Data:         data,
 					TokenAmounts: tokens[src],
@@ -167,7 +168,7 @@ func TestTokenTransfer(t *testing.T) {
 					ExtraArgs:    nil,
 				})
 			} else {
-				msgSentEvent = changeset.TestSendRequest(t, e, state, src, dest, false, router.ClientEVM2AnyMessage{
+				msgSentEvent = testhelpers.TestSendRequest(t, e, state, src, dest, false, router.ClientEVM2AnyMessage{
 					Receiver:     receiver,
 					Data:         data,
 					TokenAmounts: nil,
@@ -188,7 +189,7 @@ func TestTokenTransfer(t *testing.T) {
 	}
 
 	// Wait for all commit reports to land.
-	changeset.ConfirmCommitForAllWithExpectedSeqNums(t, e, state, expectedSeqNum, startBlocks)
+	testhelpers.ConfirmCommitForAllWithExpectedSeqNums(t, e, state, expectedSeqNum, startBlocks)
 
 	// After commit is reported on all chains, token prices should be updated in FeeQuoter.
 	for dest := range e.Chains {
@@ -196,11 +197,11 @@ func TestTokenTransfer(t *testing.T) {
 		feeQuoter := state.Chains[dest].FeeQuoter
 		timestampedPrice, err := feeQuoter.GetTokenPrice(nil, linkAddress)
 		require.NoError(t, err)
-		require.Equal(t, changeset.MockLinkPrice, timestampedPrice.Value)
+		require.Equal(t, testhelpers.MockLinkPrice, timestampedPrice.Value)
 	}
 
 	// Wait for all exec reports to land
-	changeset.ConfirmExecWithSeqNrsForAll(t, e, state, expectedSeqNumExec, startBlocks)
+	testhelpers.ConfirmExecWithSeqNrsForAll(t, e, state, expectedSeqNumExec, startBlocks)
 
 	balance, err := dstToken.BalanceOf(nil, state.Chains[tenv.FeedChainSel].Receiver.Address())
 	require.NoError(t, err)
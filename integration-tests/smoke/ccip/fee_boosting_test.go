@@ -11,6 +11,7 @@ import (
 
 	"github.com/smartcontractkit/chainlink/deployment"
 	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset/testhelpers"
 	"github.com/smartcontractkit/chainlink/integration-tests/testsetups"
 	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/router"
 	"github.com/smartcontractkit/chainlink/v2/core/logger"
@@ -19,7 +20,7 @@ import (
 type feeboostTestCase struct {
 	t                      *testing.T
 	sender                 []byte
-	deployedEnv            changeset.DeployedEnv
+	deployedEnv            testhelpers.DeployedEnv
 	onchainState           changeset.CCIPOnChainState
 	initialPrices          changeset.InitialPrices
 	priceFeedPrices        priceFeedPrices
@@ -33,7 +34,7 @@ type priceFeedPrices struct {
 
 // TODO: find a way to reuse the same test setup for all tests
 func Test_CCIPFeeBoosting(t *testing.T) {
-	setupTestEnv := func(t *testing.T, numChains int) (changeset.DeployedEnv, changeset.CCIPOnChainState, []uint64) {
+	setupTestEnv := func(t *testing.T, numChains int) (testhelpers.DeployedEnv, changeset.CCIPOnChainState, []uint64) {
 		e, _, _ := testsetups.NewLocalDevEnvironment(t, logger.TestLogger(t), deployment.E18Mult(5), big.NewInt(9e8), nil)
 
 		state, err := changeset.LoadOnchainState(e.Env)
@@ -93,7 +94,7 @@ func runFeeboostTestCase(tc feeboostTestCase) {
 	startBlocks := make(map[uint64]*uint64)
 	expectedSeqNum := make(map[changeset.SourceDestPair]uint64)
 	expectedSeqNumExec := make(map[changeset.SourceDestPair][]uint64)
-	msgSentEvent := changeset.TestSendRequest(tc.t, tc.deployedEnv.Env, tc.onchainState, tc.sourceChain, tc.destChain, false, router.ClientEVM2AnyMessage{
+	msgSentEvent := testhelpers.TestSendRequest(tc.t, tc.deployedEnv.Env, tc.onchainState, tc.sourceChain, tc.destChain, false, router.ClientEVM2AnyMessage{
 		Receiver:     common.LeftPadBytes(tc.onchainState.Chains[tc.destChain].Receiver.Address().Bytes(), 32),
 		Data:         []byte("message that needs fee boosting"),
 		TokenAmounts: nil,
@@ -114,8 +115,8 @@ func runFeeboostTestCase(tc feeboostTestCase) {
 	replayBlocks := make(map[uint64]uint64)
 	replayBlocks[tc.sourceChain] = 1
 	replayBlocks[tc.destChain] = 1
-	changeset.ReplayLogs(tc.t, tc.deployedEnv.Env.Offchain, replayBlocks)
+	testhelpers.ReplayLogs(tc.t, tc.deployedEnv.Env.Offchain, replayBlocks)
 
-	changeset.ConfirmCommitForAllWithExpectedSeqNums(tc.t, tc.deployedEnv.Env, tc.onchainState, expectedSeqNum, startBlocks)
-	changeset.ConfirmExecWithSeqNrsForAll(tc.t, tc.deployedEnv.Env, tc.onchainState, expectedSeqNumExec, startBlocks)
+	testhelpers.ConfirmCommitForAllWithExpectedSeqNums(tc.t, tc.deployedEnv.Env, tc.onchainState, expectedSeqNum, startBlocks)
+	testhelpers.ConfirmExecWithSeqNrsForAll(tc.t, tc.deployedEnv.Env, tc.onchainState, expectedSeqNumExec, startBlocks)
 }
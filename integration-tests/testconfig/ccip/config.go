@@ -3,6 +3,7 @@ package ccip
 import (
 	"fmt"
 	"math"
+	"os"
 	"strconv"
 
 	"github.com/AlekSi/pointer"
@@ -26,6 +27,8 @@ const (
 	E2E_RMN_RAGEPROXY_VERSION = "E2E_RMN_RAGEPROXY_VERSION"
 	E2E_RMN_AFN2PROXY_IMAGE   = "E2E_RMN_AFN2PROXY_IMAGE"
 	E2E_RMN_AFN2PROXY_VERSION = "E2E_RMN_AFN2PROXY_VERSION"
+	E2E_CCIP_ADDRESS_BOOK     = "E2E_CCIP_ADDRESS_BOOK"
+	E2E_CCIP_FUNDS_TREASURY   = "E2E_CCIP_FUNDS_TREASURY"
 )
 
 var (
@@ -40,6 +43,11 @@ type Config struct {
 	HomeChainSelector       *string                                     `toml:",omitempty"`
 	FeedChainSelector       *string                                     `toml:",omitempty"`
 	RMNConfig               RMNConfig                                   `toml:",omitempty"`
+	// AddressBookPath is the JSON address book of an already deployed environment to attach to,
+	// see testsetups.NewAttachedEnvironment.
+	AddressBookPath *string `toml:",omitempty"`
+	// FundsTreasury is the address the funds left over on live networks are returned to once the test completes.
+	FundsTreasury *string `toml:",omitempty"`
 }
 
 type RMNConfig struct {
@@ -86,6 +94,11 @@ type NodeConfig struct {
 	NoOfPluginNodes *int                        `toml:",omitempty"`
 	NoOfBootstraps  *int                        `toml:",omitempty"`
 	ClientConfig    *nodeclient.ChainlinkConfig `toml:",omitempty"`
+	// ImageVersions are Chainlink image versions spread across the plugin nodes, e.g. ["2.18.0", "2.19.0"]
+	// runs half of the DON on each version. Defaults to the version of the ChainlinkImage config.
+	ImageVersions []string `toml:",omitempty"`
+	// ImageVersionOverrides pin the Chainlink image version of individual nodes, keyed by node name.
+	ImageVersionOverrides map[string]string `toml:",omitempty"`
 }
 
 type JDConfig struct {
@@ -146,6 +159,26 @@ func (o *JDConfig) GetJDDBVersion() string {
 	return dbversion
 }
 
+// GetAddressBookPath returns the address book of the environment to attach to, empty if the
+// environment is to be created instead.
+func (o *Config) GetAddressBookPath() string {
+	path := pointer.GetString(o.AddressBookPath)
+	if path == "" {
+		return os.Getenv(E2E_CCIP_ADDRESS_BOOK)
+	}
+	return path
+}
+
+// GetFundsTreasury returns the address the funds left over on live networks are returned to,
+// empty if they are to be left on the accounts of the test.
+func (o *Config) GetFundsTreasury() string {
+	treasury := pointer.GetString(o.FundsTreasury)
+	if treasury == "" {
+		return os.Getenv(E2E_CCIP_FUNDS_TREASURY)
+	}
+	return treasury
+}
+
 func (o *Config) Validate() error {
 	return nil
 }
@@ -2,6 +2,7 @@ package testsetups
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"math/big"
 	"os"
@@ -34,6 +35,7 @@ import (
 	evmcfg "github.com/smartcontractkit/chainlink/v2/core/chains/evm/config/toml"
 	corechainlink "github.com/smartcontractkit/chainlink/v2/core/services/chainlink"
 
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset/testhelpers"
 	"github.com/smartcontractkit/chainlink/deployment/environment/devenv"
 	clclient "github.com/smartcontractkit/chainlink/deployment/environment/nodeclient"
 	"github.com/smartcontractkit/chainlink/integration-tests/actions"
@@ -58,7 +60,7 @@ import (
 
 // DeployedLocalDevEnvironment is a helper struct for setting up a local dev environment with docker
 type DeployedLocalDevEnvironment struct {
-	changeset.DeployedEnv
+	testhelpers.DeployedEnv
 	testEnv *test_env.CLClusterTestEnv
 	DON     *devenv.DON
 }
@@ -82,22 +84,25 @@ func (d DeployedLocalDevEnvironment) RestartChainlinkNodes(t *testing.T) error {
 	return errGrp.Wait()
 }
 
-func NewLocalDevEnvironmentWithDefaultPrice(t *testing.T, lggr logger.Logger, tCfg *changeset.TestConfigs) (changeset.DeployedEnv, *test_env.CLClusterTestEnv, tc.TestConfig) {
-	return NewLocalDevEnvironment(t, lggr, changeset.MockLinkPrice, changeset.MockWethPrice, tCfg)
+func NewLocalDevEnvironmentWithDefaultPrice(t *testing.T, lggr logger.Logger, tCfg *testhelpers.TestConfigs) (testhelpers.DeployedEnv, *test_env.CLClusterTestEnv, tc.TestConfig) {
+	return NewLocalDevEnvironment(t, lggr, testhelpers.MockLinkPrice, testhelpers.MockWethPrice, tCfg)
 }
 
 func NewLocalDevEnvironment(
 	t *testing.T,
 	lggr logger.Logger,
 	linkPrice, wethPrice *big.Int,
-	tCfg *changeset.TestConfigs,
-) (changeset.DeployedEnv, *test_env.CLClusterTestEnv, tc.TestConfig) {
+	tCfg *testhelpers.TestConfigs,
+) (testhelpers.DeployedEnv, *test_env.CLClusterTestEnv, tc.TestConfig) {
 	if tCfg == nil {
 		// set to the default constructed value
-		tCfg = &changeset.TestConfigs{}
+		tCfg = &testhelpers.TestConfigs{}
 	}
 
 	ctx := testcontext.Get(t)
+	if tenv, cfg, ok := NewAttachedEnvironment(t, lggr); ok {
+		return tenv, nil, cfg
+	}
 	// create a local docker environment with simulated chains and job-distributor
 	// we cannot create the chainlink nodes yet as we need to deploy the capability registry first
 	envConfig, testEnv, cfg := CreateDockerEnv(t)
@@ -111,11 +116,11 @@ func NewLocalDevEnvironment(
 	require.NotEmpty(t, homeChainSel, "homeChainSel should not be empty")
 	feedSel := envConfig.FeedChainSelector
 	require.NotEmpty(t, feedSel, "feedSel should not be empty")
-	replayBlocks, err := changeset.LatestBlocksByChain(ctx, chains)
+	replayBlocks, err := testhelpers.LatestBlocksByChain(ctx, chains)
 	require.NoError(t, err)
 
 	ab := deployment.NewMemoryAddressBook()
-	crConfig := changeset.DeployTestContracts(t, lggr, ab, homeChainSel, feedSel, chains, linkPrice, wethPrice)
+	crConfig := testhelpers.DeployTestContracts(t, lggr, ab, homeChainSel, feedSel, chains, linkPrice, wethPrice)
 
 	// start the chainlink nodes with the CR address
 	err = StartChainlinkNodes(t, envConfig,
@@ -128,18 +133,23 @@ func NewLocalDevEnvironment(
 	require.NotNil(t, e)
 	e.ExistingAddresses = ab
 
+	env := *e
+	// the sweep runs once the funds of the nodes are returned to the deployer keys
+	SweepFundsOnTeardown(t, lggr, cfg, &env, don)
+
 	// fund the nodes
 	zeroLogLggr := logging.GetTestLogger(t)
 	FundNodes(t, zeroLogLggr, testEnv, cfg, don.PluginNodes())
 
-	env := *e
 	envNodes, err := deployment.NodeInfo(env.NodeIDs, env.Offchain)
 	require.NoError(t, err)
 	allChains := env.AllChainSelectors()
-	var usdcChains []uint64
-	if tCfg.IsUSDC {
-		usdcChains = allChains
+	usdc := tCfg.IsUSDC
+	features := make(changeset.ChainFeatureFlags)
+	for _, chain := range allChains {
+		features[chain] = changeset.ChainFeatures{USDC: &usdc}
 	}
+	usdcChains := features.USDCChains()
 	mcmsCfgPerChain := commontypes.MCMSWithTimelockConfig{
 		Canceller:         commonchangeset.SingleGroupMCMS(t),
 		Bypasser:          commonchangeset.SingleGroupMCMS(t),
@@ -158,9 +168,9 @@ func NewLocalDevEnvironment(
 			Changeset: commonchangeset.WrapChangeSet(changeset.DeployHomeChain),
 			Config: changeset.DeployHomeChainConfig{
 				HomeChainSel:     homeChainSel,
-				RMNStaticConfig:  changeset.NewTestRMNStaticConfig(),
-				RMNDynamicConfig: changeset.NewTestRMNDynamicConfig(),
-				NodeOperators:    changeset.NewTestNodeOperator(chains[homeChainSel].DeployerKey.From),
+				RMNStaticConfig:  testhelpers.NewTestRMNStaticConfig(),
+				RMNDynamicConfig: testhelpers.NewTestRMNDynamicConfig(),
+				NodeOperators:    testhelpers.NewTestNodeOperator(chains[homeChainSel].DeployerKey.From),
 				NodeP2PIDsPerNodeOpAdmin: map[string][][32]byte{
 					"NodeOperator": envNodes.NonBootstraps().PeerIDs(),
 				},
@@ -171,9 +181,9 @@ func NewLocalDevEnvironment(
 			Config: changeset.DeployPrerequisiteConfig{
 				ChainSelectors: allChains,
 				Opts: []changeset.PrerequisiteOpt{
-					changeset.WithUSDCChains(usdcChains),
 					changeset.WithMulticall3(tCfg.IsMultiCall3),
 				},
+				Features: features,
 			},
 		},
 		{
@@ -185,6 +195,7 @@ func NewLocalDevEnvironment(
 			Config: changeset.DeployChainContractsConfig{
 				ChainSelectors:    allChains,
 				HomeChainSelector: homeChainSel,
+				Features:          features,
 			},
 		},
 	})
@@ -226,17 +237,17 @@ func NewLocalDevEnvironment(
 		{
 			Changeset: commonchangeset.WrapChangeSet(changeset.ConfigureNewChains),
 			Config: changeset.NewChainsConfig{
-				HomeChainSel:   homeChainSel,
-				FeedChainSel:   feedSel,
-				ChainsToDeploy: allChains,
-				TokenConfig:    tokenConfig,
-				OCRSecrets:     deployment.XXXGenerateTestOCRSecrets(),
-				OCRParams:      ocrParams,
+				HomeChainSel:       homeChainSel,
+				FeedChainSel:       feedSel,
+				ChainsToDeploy:     allChains,
+				TokenConfig:        tokenConfig,
+				OCRSecretsProvider: deployment.TestOCRSecrets{},
+				OCRParams:          ocrParams,
 				USDCConfig: changeset.USDCConfig{
-					EnabledChains:         usdcChains,
 					USDCAttestationConfig: usdcAttestationCfg,
 					CCTPTokenConfig:       usdcCCTPConfig,
 				},
+				Features: features,
 			},
 		},
 		{
@@ -246,9 +257,9 @@ func NewLocalDevEnvironment(
 	require.NoError(t, err)
 
 	// Ensure capreg logs are up to date.
-	changeset.ReplayLogs(t, e.Offchain, replayBlocks)
+	testhelpers.ReplayLogs(t, e.Offchain, replayBlocks)
 
-	return changeset.DeployedEnv{
+	return testhelpers.DeployedEnv{
 		Env:          env,
 		HomeChainSel: homeChainSel,
 		FeedChainSel: feedSel,
@@ -256,11 +267,54 @@ func NewLocalDevEnvironment(
 	}, testEnv, cfg
 }
 
+// NewAttachedEnvironment attaches to the already deployed environment whose address book is set in the test config,
+// e.g. a persistent shared testnet environment, see devenv.AttachEnvironment. The chains are the selected networks
+// and the nodes are the ones registered with the job distributor of the test config.
+// It returns false if no address book is set, in which case NewLocalDevEnvironment creates a local environment.
+func NewAttachedEnvironment(t *testing.T, lggr logger.Logger) (testhelpers.DeployedEnv, tc.TestConfig, bool) {
+	cfg := loadTestConfig(t)
+	addressBookPath := cfg.CCIP.GetAddressBookPath()
+	if addressBookPath == "" {
+		return testhelpers.DeployedEnv{}, cfg, false
+	}
+	evmNetworks := networks.MustGetSelectedNetworkConfig(cfg.GetNetworkConfig())
+	homeChainSel, err := cfg.CCIP.GetHomeChainSelector(evmNetworks)
+	require.NoError(t, err, "Error getting home chain selector")
+	feedSel, err := cfg.CCIP.GetFeedChainSelector(evmNetworks)
+	require.NoError(t, err, "Error getting feed chain selector")
+
+	e, err := devenv.AttachEnvironment(testcontext.Get(t), lggr, devenv.AttachConfig{
+		Chains:            CreateChainConfigFromNetworks(t, nil, nil, cfg.GetNetworkConfig()),
+		HomeChainSelector: homeChainSel,
+		FeedChainSelector: feedSel,
+		JDConfig: devenv.JDConfig{
+			GRPC:  cfg.CCIP.JobDistributorConfig.GetJDGRPC(),
+			WSRPC: cfg.CCIP.JobDistributorConfig.GetJDWSRPC(),
+			Creds: insecure.NewCredentials(),
+		},
+		AddressBookPath: addressBookPath,
+	})
+	require.NoError(t, err)
+
+	// the contracts of the address book must be loadable
+	state, err := changeset.LoadOnchainState(*e)
+	require.NoError(t, err)
+	require.NotNil(t, state.Chains[homeChainSel].CCIPHome, "CCIPHome not found on home chain %d", homeChainSel)
+	for _, sel := range e.AllChainSelectors() {
+		require.NotNil(t, state.Chains[sel].Router, "router not found on chain %d", sel)
+	}
+	return testhelpers.DeployedEnv{
+		Env:          *e,
+		HomeChainSel: homeChainSel,
+		FeedChainSel: feedSel,
+	}, cfg, true
+}
+
 func NewLocalDevEnvironmentWithRMN(
 	t *testing.T,
 	lggr logger.Logger,
 	numRmnNodes int,
-) (changeset.DeployedEnv, devenv.RMNCluster) {
+) (testhelpers.DeployedEnv, devenv.RMNCluster) {
 	tenv, dockerenv, testCfg := NewLocalDevEnvironmentWithDefaultPrice(t, lggr, nil)
 	l := logging.GetTestLogger(t)
 	config := GenerateTestRMNConfig(t, numRmnNodes, tenv, MustNetworksToRPCMap(dockerenv.EVMNetworks))
@@ -307,7 +361,7 @@ func MustCCIPNameToRMNName(a string) string {
 	return v
 }
 
-func GenerateTestRMNConfig(t *testing.T, nRMNNodes int, tenv changeset.DeployedEnv, rpcMap map[uint64]string) map[string]devenv.RMNConfig {
+func GenerateTestRMNConfig(t *testing.T, nRMNNodes int, tenv testhelpers.DeployedEnv, rpcMap map[uint64]string) map[string]devenv.RMNConfig {
 	// Find the bootstrappers.
 	nodes, err := deployment.NodeInfo(tenv.Env.NodeIDs, tenv.Env.Offchain)
 	require.NoError(t, err)
@@ -391,15 +445,15 @@ func CreateDockerEnv(t *testing.T) (
 	*test_env.CLClusterTestEnv,
 	tc.TestConfig,
 ) {
-	if _, err := os.Stat(".env"); err == nil || !os.IsNotExist(err) {
-		require.NoError(t, gotenv.Load(".env"), "Error loading .env file")
-	}
-
-	cfg, err := tc.GetChainAndTestTypeSpecificConfig("Smoke", tc.CCIP)
-	require.NoError(t, err, "Error getting config")
-
+	cfg := loadTestConfig(t)
 	evmNetworks := networks.MustGetSelectedNetworkConfig(cfg.GetNetworkConfig())
 
+	// remove the containers left over by test processes which did not tear their environment down
+	lggr := logger.TestLogger(t)
+	if _, err := devenv.ReapOrphans(testcontext.Get(t), lggr, 0); err != nil {
+		lggr.Warnw("Failed to remove orphaned containers", "err", err)
+	}
+
 	// find out if the selected networks are provided with PrivateEthereumNetworks configs
 	// if yes, PrivateEthereumNetworkConfig will be used to create simulated private ethereum networks in docker environment
 	var privateEthereumNetworks []*ctfconfig.EthereumNetworkConfig
@@ -423,6 +477,13 @@ func CreateDockerEnv(t *testing.T) (
 	}
 	env, err := builder.Build()
 	require.NoError(t, err, "Error building test environment")
+	reaper := devenv.ReaperFor(t, lggr)
+	if env.MockAdapter != nil {
+		trackComponent(reaper, &env.MockAdapter.EnvComponent)
+	}
+	if env.JobDistributor != nil {
+		trackComponent(reaper, &env.JobDistributor.EnvComponent)
+	}
 
 	// we need to update the URLs for the simulated networks to the private chain RPCs in the docker test environment
 	// so that the chainlink nodes and rmn nodes can internally connect to the chain
@@ -472,6 +533,16 @@ func CreateDockerEnv(t *testing.T) (
 	}, env, cfg
 }
 
+func loadTestConfig(t *testing.T) tc.TestConfig {
+	if _, err := os.Stat(".env"); err == nil || !os.IsNotExist(err) {
+		require.NoError(t, gotenv.Load(".env"), "Error loading .env file")
+	}
+
+	cfg, err := tc.GetChainAndTestTypeSpecificConfig("Smoke", tc.CCIP)
+	require.NoError(t, err, "Error getting config")
+	return cfg
+}
+
 // StartChainlinkNodes starts docker containers for chainlink nodes on the existing test environment based on provided test config
 // Once the nodes starts, it updates the devenv EnvironmentConfig with the node info
 // which includes chainlink API URL, email, password and internal IP
@@ -490,21 +561,34 @@ func StartChainlinkNodes(
 	if env.ClCluster == nil {
 		env.ClCluster = &test_env.ClCluster{}
 	}
+	images := NodeImageMatrix(cfg)
+	if envConfig != nil && envConfig.NodeImages != nil {
+		images = *envConfig.NodeImages
+	}
+	if err := images.Validate(); err != nil {
+		return fmt.Errorf("invalid node images: %w", err)
+	}
+	noOfBootstraps := pointer.GetInt(cfg.CCIP.CLNode.NoOfBootstraps)
+	firstNode := len(env.ClCluster.Nodes)
 	var nodeInfo []devenv.NodeInfo
 	for i := 1; i <= noOfNodes; i++ {
-		if i <= pointer.GetInt(cfg.CCIP.CLNode.NoOfBootstraps) {
+		if i <= noOfBootstraps {
+			name := fmt.Sprintf("bootstrap-%d", i)
 			nodeInfo = append(nodeInfo, devenv.NodeInfo{
 				IsBootstrap: true,
-				Name:        fmt.Sprintf("bootstrap-%d", i),
+				Name:        name,
 				// TODO : make this configurable
 				P2PPort: "6690",
+				Image:   images.ImageForNode(name, true, 0, 0),
 			})
 		} else {
+			name := fmt.Sprintf("node-%d", i-1)
 			nodeInfo = append(nodeInfo, devenv.NodeInfo{
 				IsBootstrap: false,
-				Name:        fmt.Sprintf("node-%d", i-1),
+				Name:        name,
 				// TODO : make this configurable
 				P2PPort: "6690",
+				Image:   images.ImageForNode(name, false, i-1-noOfBootstraps, noOfNodes-noOfBootstraps),
 			})
 		}
 		toml, _, err := SetNodeConfig(
@@ -521,10 +605,11 @@ func StartChainlinkNodes(
 		if err != nil {
 			return err
 		}
+		image := nodeInfo[i-1].Image
 		ccipNode, err := test_env.NewClNode(
 			[]string{env.DockerNetwork.Name},
-			pointer.GetString(cfg.GetChainlinkImageConfig().Image),
-			pointer.GetString(cfg.GetChainlinkImageConfig().Version),
+			image.Image,
+			image.Version,
 			toml,
 			env.LogStream,
 			test_env.WithPgDBOptions(
@@ -541,6 +626,13 @@ func StartChainlinkNodes(
 	if err != nil {
 		return err
 	}
+	reaper := devenv.ReaperFor(t, logger.TestLogger(t))
+	for _, n := range env.ClCluster.Nodes[firstNode:] {
+		if n.PostgresDb != nil {
+			trackComponent(reaper, &n.PostgresDb.EnvComponent)
+		}
+		trackComponent(reaper, &n.EnvComponent)
+	}
 	for i, n := range env.ClCluster.Nodes {
 		nodeInfo[i].CLConfig = clclient.ChainlinkConfig{
 			URL:        n.API.URL(),
@@ -556,6 +648,38 @@ func StartChainlinkNodes(
 	return nil
 }
 
+// trackComponent terminates the container of the component when the reaper tears the environment down.
+// The container is looked up on teardown, since restarting a node replaces its container.
+func trackComponent(reaper *devenv.Reaper, c *ctftestenv.EnvComponent) {
+	reaper.Track("container "+c.ContainerName, func(ctx context.Context) error {
+		if c.Container == nil {
+			return nil
+		}
+		return c.Container.Terminate(ctx)
+	})
+}
+
+// NodeImageMatrix builds the image matrix of the chainlink nodes from the test config. All nodes run the
+// ChainlinkImage config, unless CCIP.CLNode sets image versions to run a mixed version DON.
+func NodeImageMatrix(cfg tc.TestConfig) devenv.ImageMatrix {
+	images := devenv.ImageMatrix{
+		Default: devenv.NodeImage{
+			Image:   pointer.GetString(cfg.GetChainlinkImageConfig().Image),
+			Version: pointer.GetString(cfg.GetChainlinkImageConfig().Version),
+		},
+	}
+	for _, version := range cfg.CCIP.CLNode.ImageVersions {
+		images.Versions = append(images.Versions, devenv.NodeImage{Version: version})
+	}
+	if len(cfg.CCIP.CLNode.ImageVersionOverrides) > 0 {
+		images.Overrides = make(map[string]devenv.NodeImage)
+		for name, version := range cfg.CCIP.CLNode.ImageVersionOverrides {
+			images.Overrides[name] = devenv.NodeImage{Version: version}
+		}
+	}
+	return images
+}
+
 // FundNodes sends funds to the chainlink nodes based on the provided test config
 // It also sets up a clean-up function to return the funds back to the deployer account once the test is done
 // It assumes that the chainlink nodes are already started and the account addresses for all chains are available
@@ -646,6 +770,47 @@ func FundNodes(t *testing.T, lggr zerolog.Logger, env *test_env.CLClusterTestEnv
 	require.NoError(t, fundGrp.Wait(), "Error funding chainlink nodes")
 }
 
+// SweepFundsOnTeardown returns the native balances and LINK left on the live networks of the environment to the
+// funds treasury of the test config, if any, once the environment is torn down, see devenv.SweepFunds.
+// The LINK token is looked up when sweeping, so env can be updated with the contracts deployed by the test.
+func SweepFundsOnTeardown(t *testing.T, lggr logger.Logger, cfg tc.TestConfig, env *deployment.Environment, don *devenv.DON) {
+	treasury := cfg.CCIP.GetFundsTreasury()
+	if treasury == "" {
+		return
+	}
+	require.True(t, common.IsHexAddress(treasury), "invalid funds treasury %s", treasury)
+	var liveChains []uint64
+	for _, net := range networks.MustGetSelectedNetworkConfig(cfg.GetNetworkConfig()) {
+		if net.Simulated {
+			continue
+		}
+		sel, err := chainsel.SelectorFromChainId(uint64(net.ChainID)) //nolint:gosec // chain ids are positive
+		require.NoError(t, err)
+		liveChains = append(liveChains, sel)
+	}
+	if len(liveChains) == 0 {
+		return
+	}
+	devenv.ReaperFor(t, lggr).Track("funds sweep", func(ctx context.Context) error {
+		tokens := make(map[uint64][]common.Address)
+		if state, err := changeset.LoadOnchainState(*env); err == nil {
+			for _, sel := range liveChains {
+				if link := state.Chains[sel].LinkToken; link != nil {
+					tokens[sel] = []common.Address{link.Address()}
+				}
+			}
+		} else {
+			lggr.Warnw("Failed to load onchain state, not sweeping LINK", "err", err)
+		}
+		_, err := devenv.SweepFunds(ctx, lggr, *env, don, devenv.SweepConfig{
+			Treasury: common.HexToAddress(treasury),
+			Chains:   liveChains,
+			Tokens:   tokens,
+		})
+		return err
+	})
+}
+
 // CreateChainConfigFromNetworks creates a list of ChainConfig from the network config provided in test config.
 // It either creates it from the private ethereum networks created by the test environment or from the
 // network URLs provided in the network config ( if the network is a live testnet).